@@ -24,10 +24,13 @@ func main() {
 	admissionCmd.RunAdmissionServer(
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterDeploymentMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewMachinePoolMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
 	)
@@ -35,12 +35,16 @@ import (
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/adoptclusterrequest"
+	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/azureprivatelink"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
+	"github.com/openshift/hive/pkg/controller/clusterready"
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
 	"github.com/openshift/hive/pkg/controller/clustersync"
@@ -48,11 +52,16 @@ import (
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
+	"github.com/openshift/hive/pkg/controller/gcpprivateserviceconnect"
 	"github.com/openshift/hive/pkg/controller/hibernation"
 	"github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/postinstalljob"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
+	"github.com/openshift/hive/pkg/controller/secretinventory"
+	"github.com/openshift/hive/pkg/controller/sshkeyrotation"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
+	"github.com/openshift/hive/pkg/controller/syncsetagent"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/controller/velerobackup"
@@ -70,26 +79,35 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	remotemachineset.ControllerName:     remotemachineset.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
+	adoptclusterrequest.ControllerName:      adoptclusterrequest.Add,
+	awsprivatelink.ControllerName:           awsprivatelink.Add,
+	azureprivatelink.ControllerName:         azureprivatelink.Add,
+	clusterclaim.ControllerName:             clusterclaim.Add,
+	clusterdeployment.ControllerName:        clusterdeployment.Add,
+	clusterdeprovision.ControllerName:       clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:     clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:         clusterprovision.Add,
+	clusterready.ControllerName:             clusterready.Add,
+	clusterrelocate.ControllerName:          clusterrelocate.Add,
+	clusterstate.ControllerName:             clusterstate.Add,
+	clustersync.ControllerName:              clustersync.Add,
+	clusterversion.ControllerName:           clusterversion.Add,
+	controlplanecerts.ControllerName:        controlplanecerts.Add,
+	dnsendpoint.ControllerName:              dnsendpoint.Add,
+	dnszone.ControllerName:                  dnszone.Add,
+	gcpprivateserviceconnect.ControllerName: gcpprivateserviceconnect.Add,
+	metrics.ControllerName:                  metrics.Add,
+	postinstalljob.ControllerName:           postinstalljob.Add,
+	remoteingress.ControllerName:            remoteingress.Add,
+	remotemachineset.ControllerName:         remotemachineset.Add,
+	secretinventory.ControllerName:          secretinventory.Add,
+	sshkeyrotation.ControllerName:           sshkeyrotation.Add,
+	syncidentityprovider.ControllerName:     syncidentityprovider.Add,
+	syncsetagent.ControllerName:             syncsetagent.Add,
+	unreachable.ControllerName:              unreachable.Add,
+	velerobackup.ControllerName:             velerobackup.Add,
+	clusterpool.ControllerName:              clusterpool.Add,
+	hibernation.ControllerName:              hibernation.Add,
 }
 
 type controllerManagerOptions struct {
@@ -12,12 +12,15 @@ import (
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/migration"
 	"github.com/openshift/hive/contrib/pkg/report"
+	"github.com/openshift/hive/contrib/pkg/syncset"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
 	"github.com/openshift/hive/contrib/pkg/version"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/installmanager"
+	"github.com/openshift/hive/pkg/syncsetagent"
 )
 
 func main() {
@@ -54,6 +57,10 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(adm.NewAdmCommand())
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
+	cmd.AddCommand(syncset.NewSyncSetCommand())
+	cmd.AddCommand(migration.NewExportCommand())
+	cmd.AddCommand(migration.NewImportCommand())
+	cmd.AddCommand(syncsetagent.NewSyncSetAgentCommand())
 
 	return cmd
 }
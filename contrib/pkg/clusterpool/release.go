@@ -0,0 +1,107 @@
+package clusterpool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// ReleaseQuarantinedOptions is the set of options to release the quarantined clusters of a pool.
+type ReleaseQuarantinedOptions struct {
+	ClusterPoolName string
+	ClusterNames    []string
+	Namespace       string
+	Reason          string
+	DryRun          bool
+
+	log log.FieldLogger
+}
+
+// NewReleaseQuarantinedCommand creates a command that releases the quarantined clusters of a ClusterPool.
+func NewReleaseQuarantinedCommand() *cobra.Command {
+	opt := &ReleaseQuarantinedOptions{log: log.WithField("command", "clusterpool release-quarantined")}
+
+	cmd := &cobra.Command{
+		Use:   "release-quarantined CLUSTER_POOL_NAME [CLUSTER_NAME...]",
+		Short: "releases the quarantined clusters of a ClusterPool",
+		Long: `Releases the quarantined clusters of the ClusterPool in the given namespace by deleting them, so that the pool
+replaces them. All of the quarantined clusters of the pool are released unless cluster names are given.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.ClusterPoolName = args[0]
+			opt.ClusterNames = args[1:]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterPool")
+	flags.StringVar(&opt.Reason, "reason", "", "Only release clusters quarantined for this reason")
+	flags.BoolVar(&opt.DryRun, "dry-run", false, "List the clusters that would be released without releasing them")
+	return cmd
+}
+
+func (o *ReleaseQuarantinedOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create client")
+	}
+	if o.Namespace == "" {
+		if o.Namespace, err = utils.DefaultNamespace(); err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	names := sets.NewString(o.ClusterNames...)
+	found := sets.NewString()
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || poolRef.Namespace != o.Namespace || poolRef.PoolName != o.ClusterPoolName {
+			continue
+		}
+		if names.Len() > 0 && !names.Has(cd.Name) {
+			continue
+		}
+		found.Insert(cd.Name)
+		logger := o.log.WithField("cluster", cd.Name)
+		switch {
+		case poolRef.Quarantine == nil:
+			if names.Len() > 0 {
+				logger.Warn("cluster is not quarantined")
+			}
+			continue
+		case o.Reason != "" && string(poolRef.Quarantine.Reason) != o.Reason:
+			continue
+		case cd.DeletionTimestamp != nil:
+			logger.Info("cluster is already being deleted")
+			continue
+		}
+		logger = logger.WithField("reason", poolRef.Quarantine.Reason)
+		if o.DryRun {
+			logger.Info("would release quarantined cluster")
+			continue
+		}
+		if err := c.Delete(context.Background(), cd); err != nil {
+			return errors.Wrapf(err, "could not delete ClusterDeployment %s", cd.Name)
+		}
+		logger.Info("released quarantined cluster")
+	}
+	if missing := names.Difference(found); missing.Len() > 0 {
+		return errors.Errorf("clusters not found in pool: %v", missing.List())
+	}
+	return nil
+}
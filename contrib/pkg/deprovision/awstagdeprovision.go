@@ -11,7 +11,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/destroy/aws"
+	installertypesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/library-go/pkg/controller/fileobserver"
 
 	"github.com/openshift/hive/pkg/constants"
@@ -22,12 +24,25 @@ import (
 func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	opt := &aws.ClusterUninstaller{}
 	var logLevel string
+	var serviceEndpoints []string
+	var clusterDeprovision string
 	cmd := &cobra.Command{
 		Use:   "aws-tag-deprovision KEY=VALUE ...",
 		Short: "Deprovision AWS assets (as created by openshift-installer) with the given tag(s)",
 		Long:  "Deprovision AWS assets (as created by openshift-installer) with the given tag(s).  A resource matches the filter if any of the key/value pairs are in its tags.",
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := completeAWSUninstaller(opt, logLevel, args); err != nil {
+			hooks := make(log.LevelHooks)
+			var reporter *remainingResourcesReporter
+			if clusterDeprovision != "" {
+				tracker := newRemainingResourcesTracker()
+				hooks.Add(tracker)
+				var err error
+				if reporter, err = newRemainingResourcesReporter(tracker, clusterDeprovision); err != nil {
+					log.WithError(err).Fatal("Cannot create reporter of remaining resources")
+				}
+			}
+
+			if err := completeAWSUninstaller(opt, logLevel, args, hooks); err != nil {
 				log.WithError(err).Error("Cannot complete command")
 				return
 			}
@@ -79,7 +94,25 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 				}()
 			}
 
+			if len(serviceEndpoints) > 0 {
+				if err := setAWSServiceEndpoints(opt, serviceEndpoints); err != nil {
+					log.WithError(err).Fatal("Cannot set AWS service endpoints")
+				}
+			}
+
+			if reporter != nil {
+				stop := make(chan struct{})
+				go reporter.run(stop)
+				defer func() {
+					close(stop)
+					reporter.report()
+				}()
+			}
+
 			if err := opt.Run(); err != nil {
+				if reporter != nil {
+					reporter.report()
+				}
 				log.WithError(err).Fatal("Runtime error")
 			}
 		},
@@ -87,10 +120,12 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to use")
+	flags.StringArrayVar(&serviceEndpoints, "service-endpoint", nil, "NAME=URL of an AWS service endpoint that overrides the default endpoint of the service; may be repeated")
+	flags.StringVar(&clusterDeprovision, "cluster-deprovision", "", "name of the ClusterDeprovision in the current namespace to report the remaining resources to; requires the debug log level")
 	return cmd
 }
 
-func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string) error {
+func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string, hooks log.LevelHooks) error {
 
 	for _, arg := range args {
 		filter := aws.Filter{}
@@ -113,13 +148,34 @@ func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []s
 		Formatter: &log.TextFormatter{
 			FullTimestamp: true,
 		},
-		Hooks: make(log.LevelHooks),
+		Hooks: hooks,
 		Level: level,
 	})
 
 	return nil
 }
 
+// setAWSServiceEndpoints sets the session of the uninstaller to one using the given NAME=URL service endpoints.
+func setAWSServiceEndpoints(o *aws.ClusterUninstaller, serviceEndpoints []string) error {
+	endpoints := make([]installertypesaws.ServiceEndpoint, 0, len(serviceEndpoints))
+	for _, e := range serviceEndpoints {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("incorrectly formatted service endpoint %q", e)
+		}
+		endpoints = append(endpoints, installertypesaws.ServiceEndpoint{Name: parts[0], URL: parts[1]})
+	}
+	session, err := awssession.GetSessionWithOptions(
+		awssession.WithRegion(o.Region),
+		awssession.WithServiceEndpoints(o.Region, endpoints),
+	)
+	if err != nil {
+		return err
+	}
+	o.Session = session
+	return nil
+}
+
 func parseFilter(filterMap aws.Filter, str string) error {
 	parts := strings.SplitN(str, "=", 2)
 	if len(parts) != 2 {
@@ -0,0 +1,201 @@
+package deprovision
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// remainingResourcesReportInterval is how often the remaining resources are reported in the status of the
+	// ClusterDeprovision.
+	remainingResourcesReportInterval = time.Minute
+
+	// maxRemainingResources limits the number of remaining resources reported.
+	maxRemainingResources = 100
+
+	// maxRemainingResourceReasonLength limits the length of the reason reported for a remaining resource.
+	maxRemainingResourceReasonLength = 256
+)
+
+var (
+	// destroyedMessages are logged by the AWS destroyer for a resource once it has been destroyed.
+	destroyedMessages = map[string]bool{
+		"Deleted":    true,
+		"Released":   true,
+		"Terminated": true,
+	}
+
+	// progressMessages are logged by the AWS destroyer for a resource that it is still destroying. Any other message
+	// logged for a resource is an error that kept it from being destroyed.
+	progressMessages = map[string]bool{
+		"Emptied":                         true,
+		"Revoked egress permissions":      true,
+		"Revoked ingress permissions":     true,
+		"Skipping default security group": true,
+		"Terminating":                     true,
+		"Versions Deleted":                true,
+	}
+)
+
+// remainingResourcesTracker is a logrus hook that tracks the resources that the AWS destroyer has found but not yet
+// destroyed, from the messages it logs for each resource along with its ARN.
+type remainingResourcesTracker struct {
+	mutex     sync.Mutex
+	resources map[string]hivev1.RemainingCloudResource
+}
+
+func newRemainingResourcesTracker() *remainingResourcesTracker {
+	return &remainingResourcesTracker{resources: map[string]hivev1.RemainingCloudResource{}}
+}
+
+// Levels implements logrus.Hook.
+func (t *remainingResourcesTracker) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (t *remainingResourcesTracker) Fire(entry *log.Entry) error {
+	arnString, ok := entry.Data["arn"].(string)
+	if !ok {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if destroyedMessages[entry.Message] {
+		delete(t.resources, arnString)
+		return nil
+	}
+	resource, ok := t.resources[arnString]
+	if !ok {
+		parsed, err := arn.Parse(arnString)
+		if err != nil {
+			return nil
+		}
+		resource = remainingResourceFromARN(parsed)
+	}
+	if !progressMessages[entry.Message] {
+		reason := strings.SplitN(entry.Message, "\n", 2)[0]
+		if len(reason) > maxRemainingResourceReasonLength {
+			reason = reason[:maxRemainingResourceReasonLength] + "..."
+		}
+		resource.Reason = reason
+	}
+	t.resources[arnString] = resource
+	return nil
+}
+
+// remainingResourceFromARN returns the type, ID and region of the resource named by the ARN. The resource part of
+// an ARN is either the ID of the resource, or its type and ID separated by a slash or colon.
+func remainingResourceFromARN(parsed arn.ARN) hivev1.RemainingCloudResource {
+	resource := hivev1.RemainingCloudResource{
+		Type:   parsed.Service,
+		ID:     parsed.Resource,
+		Region: parsed.Region,
+	}
+	if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+		resource.Type = parsed.Service + ":" + parsed.Resource[:i]
+		resource.ID = parsed.Resource[i+1:]
+	}
+	return resource
+}
+
+// list returns the remaining resources sorted by type and ID, up to the most that are reported.
+func (t *remainingResourcesTracker) list() []hivev1.RemainingCloudResource {
+	t.mutex.Lock()
+	resources := make([]hivev1.RemainingCloudResource, 0, len(t.resources))
+	for _, resource := range t.resources {
+		resources = append(resources, resource)
+	}
+	t.mutex.Unlock()
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	if len(resources) > maxRemainingResources {
+		resources = resources[:maxRemainingResources]
+	}
+	return resources
+}
+
+// remainingResourcesReporter reports the remaining resources in the status of a ClusterDeprovision.
+type remainingResourcesReporter struct {
+	tracker *remainingResourcesTracker
+	client  client.Client
+	key     types.NamespacedName
+	logger  log.FieldLogger
+}
+
+// newRemainingResourcesReporter returns a reporter for the ClusterDeprovision of the given name in the namespace of
+// the uninstall pod.
+func newRemainingResourcesReporter(tracker *remainingResourcesTracker, name string) (*remainingResourcesReporter, error) {
+	namespace, err := utils.DefaultNamespace()
+	if err != nil {
+		return nil, err
+	}
+	c, err := utils.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	return &remainingResourcesReporter{
+		tracker: tracker,
+		client:  c,
+		key:     types.NamespacedName{Namespace: namespace, Name: name},
+		logger:  log.WithField("clusterDeprovision", name),
+	}, nil
+}
+
+// run reports the remaining resources periodically until stopped.
+func (r *remainingResourcesReporter) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(remainingResourcesReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+// report updates the status of the ClusterDeprovision with the remaining resources when they have changed. Errors
+// are logged rather than returned, as failing to report does not stop the uninstall.
+func (r *remainingResourcesReporter) report() {
+	resources := r.tracker.list()
+	if len(resources) == 0 {
+		resources = nil
+	}
+	deprovision := &hivev1.ClusterDeprovision{}
+	if err := r.client.Get(context.TODO(), r.key, deprovision); err != nil {
+		r.logger.WithError(err).Warn("could not get ClusterDeprovision to report remaining resources")
+		return
+	}
+	if deprovision.Status.RemainingResourcesReportTime != nil && reflect.DeepEqual(deprovision.Status.RemainingResources, resources) {
+		return
+	}
+	now := metav1.Now()
+	deprovision.Status.RemainingResources = resources
+	deprovision.Status.RemainingResourcesReportTime = &now
+	if err := r.client.Status().Update(context.TODO(), deprovision); err != nil {
+		r.logger.WithError(err).Warn("could not report remaining resources")
+		return
+	}
+	r.logger.WithField("remainingResources", len(resources)).Debug("reported remaining resources")
+}
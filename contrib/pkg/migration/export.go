@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivemigration "github.com/openshift/hive/pkg/migration"
+)
+
+// ExportOptions contains the options for exporting Hive resources
+type ExportOptions struct {
+	File       string
+	Namespaces []string
+
+	log log.FieldLogger
+}
+
+// NewExportCommand is the entrypoint to create the 'export' subcommand
+func NewExportCommand() *cobra.Command {
+	opt := &ExportOptions{log: log.WithField("command", "export")}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports Hive resources to an archive",
+		Long: "Exports the Hive resources in the given namespaces, or in the whole hub if no namespace is given, to an " +
+			"archive along with the secrets, config maps and ClusterImageSets they depend on. The archive holds the " +
+			"secrets unencrypted.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.File, "file", "f", "", "File to write the archive to")
+	flags.StringSliceVarP(&opt.Namespaces, "namespace", "n", nil, "Namespace to export. May be repeated. Exports the whole hub when not set.")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func (o *ExportOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create client")
+	}
+	f, err := os.OpenFile(o.File, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not create archive")
+	}
+	defer f.Close()
+	if err := hivemigration.Export(c, scheme.Scheme, hivemigration.ExportOptions{Namespaces: o.Namespaces}, f, o.log); err != nil {
+		os.Remove(o.File)
+		return err
+	}
+	o.log.WithField("file", o.File).Info("export complete")
+	return nil
+}
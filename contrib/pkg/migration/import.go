@@ -0,0 +1,64 @@
+package migration
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivemigration "github.com/openshift/hive/pkg/migration"
+)
+
+// ImportOptions contains the options for importing Hive resources
+type ImportOptions struct {
+	File             string
+	NamespaceMapping map[string]string
+
+	log log.FieldLogger
+}
+
+// NewImportCommand is the entrypoint to create the 'import' subcommand
+func NewImportCommand() *cobra.Command {
+	opt := &ImportOptions{log: log.WithField("command", "import")}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports Hive resources from an archive",
+		Long: "Creates the resources in an archive written by 'hiveutil export'. Resources that already exist are left " +
+			"unchanged. Owner references are updated to point to the imported owners.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.File, "file", "f", "", "Archive to import")
+	flags.StringToStringVar(&opt.NamespaceMapping, "namespace-mapping", nil, "Namespaces to import resources into, as OLD=NEW pairs")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func (o *ImportOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create client")
+	}
+	f, err := os.Open(o.File)
+	if err != nil {
+		return errors.Wrap(err, "could not open archive")
+	}
+	defer f.Close()
+	if err := hivemigration.Import(c, scheme.Scheme, hivemigration.ImportOptions{NamespaceMapping: o.NamespaceMapping}, f, o.log); err != nil {
+		return err
+	}
+	o.log.WithField("file", o.File).Info("import complete")
+	return nil
+}
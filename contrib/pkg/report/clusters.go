@@ -0,0 +1,279 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const defaultClustersChunkSize = 500
+
+// ClustersReportOptions is the set of options for searching the clusters of the fleet.
+type ClustersReportOptions struct {
+	// Namespace limits the search to the clusters in the given namespace.
+	Namespace string
+	// AllNamespaces searches the clusters in all namespaces.
+	AllNamespaces bool
+	// Selector is a label selector that the clusters must match.
+	Selector string
+	// Version is the version of the clusters in the form "[MAJOR]", "[MAJOR].[MINOR]" or "[MAJOR].[MINOR].[PATCH]".
+	Version string
+	// Platform is the platform of the clusters.
+	Platform string
+	// Region is the region of the clusters.
+	Region string
+	// Conditions are the conditions that the clusters must have, in the form "[TYPE]=[STATUS]".
+	Conditions []string
+	// Limit is the maximum number of clusters to fetch from the server. When set, only a single page is fetched
+	// and the token to fetch the next page is printed.
+	Limit int64
+	// Continue is the token of the page to fetch, returned by a previous search with a limit.
+	Continue string
+	// Output is the format of the output. Valid values are "json" and "yaml". The default is a table.
+	Output string
+
+	selector   labels.Selector
+	conditions map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus
+}
+
+// NewClustersReportCommand creates a command that searches the clusters of the fleet.
+func NewClustersReportCommand() *cobra.Command {
+	opt := &ClustersReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "clusters",
+		Short: "Prints the clusters matching the given version, platform, region, labels and conditions",
+		Long: `Prints the clusters matching the given version, platform, region, labels and conditions.
+
+The version, platform, region and label filters are evaluated by the server using the labels that hive sets on
+ClusterDeployments. The condition filters are evaluated on each page returned by the server, so a page may contain
+fewer clusters than the limit.`,
+		Example: `  # All clusters on 4.6.1 in us-east-1 that are failing to apply syncsets
+  hiveutil report clusters -A --version 4.6.1 --region us-east-1 --condition SyncSetFailed=True
+
+  # The first page of 100 AWS clusters, followed by the next page
+  hiveutil report clusters -A --platform aws --limit 100
+  hiveutil report clusters -A --platform aws --limit 100 --continue <token>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			if err := opt.Run(dynClient, os.Stdout); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace. Defaults to the namespace of the current context.")
+	flags.BoolVarP(&opt.AllNamespaces, "all-namespaces", "A", false, "Include clusters in all namespaces.")
+	flags.StringVarP(&opt.Selector, "selector", "l", "", "Only include clusters matching the given label selector.")
+	flags.StringVar(&opt.Version, "version", "", "Only include clusters on the given version. (i.e. 4, 4.6 or 4.6.1)")
+	flags.StringVar(&opt.Platform, "platform", "", "Only include clusters on the given platform. (i.e. aws)")
+	flags.StringVar(&opt.Region, "region", "", "Only include clusters in the given region.")
+	flags.StringArrayVar(&opt.Conditions, "condition", nil, "Only include clusters with the given condition status. (i.e. SyncSetFailed=True) Can be repeated.")
+	flags.Int64Var(&opt.Limit, "limit", 0, "Fetch a single page of at most this many clusters and print the token for the next page.")
+	flags.StringVar(&opt.Continue, "continue", "", "Token of the page to fetch, printed by a previous search with a limit.")
+	flags.StringVarP(&opt.Output, "output", "o", "", "Output format. Valid values: json,yaml")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *ClustersReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	if o.Namespace == "" && !o.AllNamespaces {
+		namespace, err := contributils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+		o.Namespace = namespace
+	}
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return errors.Wrap(err, "invalid label selector")
+	}
+	addRequirement := func(key, value string) error {
+		req, err := labels.NewRequirement(key, selection.Equals, []string{value})
+		if err != nil {
+			return err
+		}
+		selector = selector.Add(*req)
+		return nil
+	}
+	if o.Version != "" {
+		versionLabels := []string{
+			constants.VersionMajorLabel,
+			constants.VersionMajorMinorLabel,
+			constants.VersionMajorMinorPatchLabel,
+		}
+		parts := strings.Split(o.Version, ".")
+		if len(parts) > len(versionLabels) {
+			return errors.Errorf("invalid version %q, expected [MAJOR], [MAJOR].[MINOR] or [MAJOR].[MINOR].[PATCH]", o.Version)
+		}
+		if err := addRequirement(versionLabels[len(parts)-1], o.Version); err != nil {
+			return errors.Wrap(err, "invalid version")
+		}
+	}
+	if o.Platform != "" {
+		if err := addRequirement(hivev1.HiveClusterPlatformLabel, o.Platform); err != nil {
+			return errors.Wrap(err, "invalid platform")
+		}
+	}
+	if o.Region != "" {
+		if err := addRequirement(hivev1.HiveClusterRegionLabel, o.Region); err != nil {
+			return errors.Wrap(err, "invalid region")
+		}
+	}
+	o.selector = selector
+
+	o.conditions = map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus{}
+	for _, c := range o.Conditions {
+		parts := strings.SplitN(c, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("invalid condition %q, expected [TYPE]=[STATUS]", c)
+		}
+		o.conditions[hivev1.ClusterDeploymentConditionType(parts[0])] = corev1.ConditionStatus(parts[1])
+	}
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *ClustersReportOptions) Validate(cmd *cobra.Command) error {
+	if o.Limit < 0 {
+		return errors.New("limit cannot be negative")
+	}
+	if o.Continue != "" && o.Limit == 0 {
+		return errors.New("continue requires a limit")
+	}
+	switch o.Output {
+	case "", "json", "yaml":
+	default:
+		return errors.Errorf("invalid output format %q", o.Output)
+	}
+	for t, s := range o.conditions {
+		switch s {
+		case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		default:
+			return errors.Errorf("invalid status %q for condition %s", s, t)
+		}
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *ClustersReportOptions) Run(dynClient client.Client, out io.Writer) error {
+	matches := &hivev1.ClusterDeploymentList{}
+	limit, token := o.Limit, o.Continue
+	if limit == 0 {
+		limit = defaultClustersChunkSize
+	}
+	for {
+		cdList := &hivev1.ClusterDeploymentList{}
+		err := dynClient.List(context.Background(), cdList,
+			client.InNamespace(o.Namespace),
+			client.MatchingLabelsSelector{Selector: o.selector},
+			client.Limit(limit),
+			client.Continue(token),
+		)
+		if err != nil {
+			return errors.Wrap(err, "error listing cluster deployments")
+		}
+		for _, cd := range cdList.Items {
+			if o.matchesConditions(&cd) {
+				matches.Items = append(matches.Items, cd)
+			}
+		}
+		token = cdList.Continue
+		if o.Limit != 0 || token == "" {
+			break
+		}
+	}
+	matches.Continue = token
+
+	switch o.Output {
+	case "json":
+		b, err := json.MarshalIndent(matches, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(b))
+	case "yaml":
+		b, err := yaml.Marshal(matches)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(b))
+	default:
+		o.printTable(matches, out)
+	}
+	return nil
+}
+
+func (o *ClustersReportOptions) matchesConditions(cd *hivev1.ClusterDeployment) bool {
+	for t, s := range o.conditions {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, t)
+		if cond == nil || cond.Status != s {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *ClustersReportOptions) printTable(cdList *hivev1.ClusterDeploymentList, out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tPLATFORM\tREGION\tVERSION\tINSTALLED\tPOWERSTATE")
+	for _, cd := range cdList.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+			cd.Namespace,
+			cd.Name,
+			valueOrUnknown(cd.Labels[hivev1.HiveClusterPlatformLabel]),
+			valueOrUnknown(cd.Labels[hivev1.HiveClusterRegionLabel]),
+			valueOrUnknown(cd.Labels[constants.VersionMajorMinorPatchLabel]),
+			cd.Spec.Installed,
+			cd.Spec.PowerState,
+		)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\n%d clusters found\n", len(cdList.Items))
+	if cdList.Continue != "" {
+		fmt.Fprintf(out, "More clusters available, fetch the next page with --continue %s\n", cdList.Continue)
+	}
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
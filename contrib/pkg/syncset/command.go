@@ -0,0 +1,16 @@
+package syncset
+
+import "github.com/spf13/cobra"
+
+// NewSyncSetCommand is the entrypoint to create the 'syncset' subcommand
+func NewSyncSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "syncset",
+		Short: "Utility to manage SyncSets",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewReapplyCommand())
+	return cmd
+}
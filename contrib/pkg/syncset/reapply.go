@@ -0,0 +1,122 @@
+package syncset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const reapplyPollInterval = 2 * time.Second
+
+// ReapplyOptions is the set of options to reapply a syncset to a cluster.
+type ReapplyOptions struct {
+	ClusterDeploymentName string
+	SyncSetName           string
+	Namespace             string
+	Selector              bool
+	Timeout               time.Duration
+
+	log log.FieldLogger
+}
+
+// NewReapplyCommand creates a command that reapplies a single SyncSet or SelectorSyncSet to a single cluster.
+func NewReapplyCommand() *cobra.Command {
+	opt := &ReapplyOptions{log: log.WithField("command", "syncset reapply")}
+
+	cmd := &cobra.Command{
+		Use:   "reapply CLUSTER_DEPLOYMENT_NAME SYNCSET_NAME",
+		Short: "reapplies a SyncSet to a cluster right away",
+		Long: `Reapplies a SyncSet or SelectorSyncSet to a cluster right away, rather than waiting for the next full reapply,
+and waits for the result.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.ClusterDeploymentName = args[0]
+			opt.SyncSetName = args[1]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	flags.BoolVar(&opt.Selector, "selector", false, "Reapply the SelectorSyncSet with the given name instead of the SyncSet")
+	flags.DurationVar(&opt.Timeout, "timeout", 2*time.Minute, "How long to wait for the result. Zero does not wait.")
+	return cmd
+}
+
+func (o *ReapplyOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create client")
+	}
+	if o.Namespace == "" {
+		if o.Namespace, err = utils.DefaultNamespace(); err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	kind := "SyncSet"
+	if o.Selector {
+		kind = "SelectorSyncSet"
+	}
+	cdKey := types.NamespacedName{Namespace: o.Namespace, Name: o.ClusterDeploymentName}
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.Background(), cdKey, cd); err != nil {
+		return errors.Wrap(err, "could not get ClusterDeployment")
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.ReapplySyncSetAnnotation] = fmt.Sprintf("%s/%s", kind, o.SyncSetName)
+	if err := c.Update(context.Background(), cd); err != nil {
+		return errors.Wrap(err, "could not request reapply")
+	}
+	o.log.WithField("clusterDeployment", cdKey).Infof("requested reapply of %s %s", kind, o.SyncSetName)
+	if o.Timeout == 0 {
+		return nil
+	}
+
+	// The annotation is removed once the result of the reapply has been recorded in the ClusterSync.
+	err = wait.PollImmediate(reapplyPollInterval, o.Timeout, func() (bool, error) {
+		if err := c.Get(context.Background(), cdKey, cd); err != nil {
+			return false, err
+		}
+		_, pending := cd.Annotations[constants.ReapplySyncSetAnnotation]
+		return !pending, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "reapply did not complete")
+	}
+	return o.reportResult(c, cdKey, kind)
+}
+
+func (o *ReapplyOptions) reportResult(c client.Client, cdKey types.NamespacedName, kind string) error {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	if err := c.Get(context.Background(), cdKey, clusterSync); err != nil {
+		return errors.Wrap(err, "could not get ClusterSync")
+	}
+	result := clusterSync.Status.LastReapplyRequest
+	if result == nil || result.Kind != kind || result.Name != o.SyncSetName {
+		return errors.New("the result of the reapply was not recorded in the ClusterSync")
+	}
+	if result.Result != hiveintv1alpha1.SuccessSyncSetResult {
+		return fmt.Errorf("reapply failed: %s", result.FailureMessage)
+	}
+	o.log.Infof("%s %s reapplied at %s", kind, o.SyncSetName, result.CompletionTime.Format(time.RFC3339))
+	return nil
+}
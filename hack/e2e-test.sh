@@ -120,6 +120,7 @@ trap 'teardown' EXIT
 
 function save_hive_logs() {
   oc logs -n "${HIVE_NS}" deployment/hive-controllers > "${ARTIFACT_DIR}/hive-controllers.log"
+  oc logs -n "${HIVE_NS}" statefulset/hive-clustersync > "${ARTIFACT_DIR}/hive-clustersync.log"
   oc logs -n "${HIVE_NS}" deployment/hiveadmission > "${ARTIFACT_DIR}/hiveadmission.log"
 }
 
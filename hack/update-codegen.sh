@@ -16,6 +16,6 @@ GOFLAGS="" bash ${CODEGEN_PKG}/generate-groups.sh "all" \
 GOFLAGS="" bash ${CODEGEN_PKG}/generate-groups.sh "deepcopy" \
   github.com/openshift/hive/pkg/client \
   github.com/openshift/hive/pkg/apis \
-  "hive:v1/aws hive:v1/azure hive:v1/baremetal hive:v1/gcp hive:v1/openstack hive:v1/ovirt hive:v1/vsphere" \
+  "hive:v1/agent hive:v1/aws hive:v1/azure hive:v1/baremetal hive:v1/gcp hive:v1/ibmcloud hive:v1/nutanix hive:v1/openstack hive:v1/ovirt hive:v1/vsphere" \
   --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt \
   ${verify}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdoptClusterRequestSpec defines the desired state of the AdoptClusterRequest.
+type AdoptClusterRequestSpec struct {
+	// AdminKubeconfigSecretRef references the secret containing the admin kubeconfig of the cluster to adopt.
+	// The secret must be in the namespace of the AdoptClusterRequest and have a "kubeconfig" key.
+	AdminKubeconfigSecretRef corev1.LocalObjectReference `json:"adminKubeconfigSecretRef"`
+
+	// AdminPasswordSecretRef references the secret containing the admin username and password of the cluster.
+	// +optional
+	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
+
+	// BaseDomain is the base domain of the cluster. The cluster name is discovered from the DNS configuration of
+	// the cluster, which must be a subdomain of the base domain.
+	BaseDomain string `json:"baseDomain"`
+
+	// CredentialsSecretRef references the secret containing the cloud credentials that Hive will use to manage,
+	// and eventually deprovision, the cluster. The format of the secret depends on the discovered platform of the
+	// cluster, and is the same as for the credentials secret of the ClusterDeployment platform.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// PullSecretRef is the reference to the secret to use when pulling images for the adopted cluster.
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// ClusterDeploymentName is the name of the ClusterDeployment to create for the adopted cluster. The
+	// ClusterDeployment is created in the namespace of the AdoptClusterRequest. Defaults to the name of the
+	// AdoptClusterRequest.
+	// +optional
+	ClusterDeploymentName string `json:"clusterDeploymentName,omitempty"`
+}
+
+// AdoptClusterRequestStatus defines the observed state of the AdoptClusterRequest.
+type AdoptClusterRequestStatus struct {
+	// ClusterName is the name of the cluster discovered from the cluster.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// ClusterID is the unique ID of the cluster discovered from the cluster.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// InfraID is the infrastructure ID of the cluster discovered from the cluster.
+	// +optional
+	InfraID string `json:"infraID,omitempty"`
+
+	// Platform is the cloud platform of the cluster discovered from the cluster.
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// Region is the cloud region of the cluster discovered from the cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// ClusterDeploymentRef references the ClusterDeployment created for the adopted cluster.
+	// +optional
+	ClusterDeploymentRef *corev1.LocalObjectReference `json:"clusterDeploymentRef,omitempty"`
+
+	// Conditions includes more detailed status for the adoption.
+	// +optional
+	Conditions []AdoptClusterRequestCondition `json:"conditions,omitempty"`
+}
+
+// AdoptClusterRequestCondition contains details for the current condition of an adopt cluster request.
+type AdoptClusterRequestCondition struct {
+	// Type is the type of the condition.
+	Type AdoptClusterRequestConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AdoptClusterRequestConditionType is a valid value for AdoptClusterRequestCondition.Type.
+type AdoptClusterRequestConditionType string
+
+const (
+	// AdoptClusterRequestPreflightFailedCondition is set when the cluster could not be validated for adoption. The
+	// reason of the condition identifies the check that failed.
+	AdoptClusterRequestPreflightFailedCondition AdoptClusterRequestConditionType = "PreflightFailed"
+	// AdoptClusterRequestAdoptedCondition is set when the ClusterDeployment for the adopted cluster has been created.
+	AdoptClusterRequestAdoptedCondition AdoptClusterRequestConditionType = "Adopted"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AdoptClusterRequest is a request to adopt an existing cluster into Hive. The cluster is validated, and its
+// metadata is discovered from the cluster itself, before a ClusterDeployment is created for it.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".status.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="InfraID",type="string",JSONPath=".status.infraID"
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".status.platform"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".status.region"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=adoptclusterrequests
+type AdoptClusterRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AdoptClusterRequestSpec   `json:"spec"`
+	Status AdoptClusterRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AdoptClusterRequestList contains a list of AdoptClusterRequests.
+type AdoptClusterRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AdoptClusterRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AdoptClusterRequest{}, &AdoptClusterRequestList{})
+}
@@ -0,0 +1,4 @@
+// Package agent contains API Schema definitions for clusters installed by the agent-based installer.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/pkg/apis/hive
+package agent
@@ -17,4 +17,80 @@ type Platform struct {
 	// UserTags specifies additional tags for AWS resources created for the cluster.
 	// +optional
 	UserTags map[string]string `json:"userTags,omitempty"`
+
+	// Subnets are the IDs of existing subnets to install the cluster into. All subnets must be in the same VPC, with
+	// a private subnet in each availability zone of the cluster, and also a public subnet in each of these zones
+	// unless the cluster is published internally. Hive does not delete the VPC or its subnets when the cluster is
+	// deprovisioned. When omitted, the installer creates a VPC for the cluster.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// PrivateLink configures access to the cluster's API through AWS PrivateLink. Use this for clusters that are
+	// installed without a public API endpoint.
+	// +optional
+	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+
+	// AMIID is the ID of the AMI to boot the machines of the cluster from, instead of the RHCOS AMI of the release
+	// image. Use this for disconnected environments where the RHCOS AMI has been copied in advance. The AMI must be
+	// in Region.
+	// +optional
+	AMIID string `json:"amiID,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints used for AWS services when provisioning, managing, hibernating and
+	// deprovisioning the cluster. Use this for regions whose service endpoints are not known to Hive, such as C2S.
+	// There must be at most one endpoint per service.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+}
+
+// ServiceEndpoint overrides the endpoint of an AWS service.
+type ServiceEndpoint struct {
+	// Name is the name of the AWS service, such as ec2, elasticloadbalancing, route53 or s3.
+	Name string `json:"name"`
+
+	// URL is the URL of the endpoint. It must use the https scheme.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+}
+
+// PrivateLinkAccess configures access to the cluster's API through AWS PrivateLink.
+type PrivateLinkAccess struct {
+	// Enabled, when true, makes Hive create a VPC endpoint service for the internal API load balancer of the
+	// cluster, and a VPC endpoint for that service in one of the VPCs configured in the awsPrivateLink section of
+	// HiveConfig. Hive also creates a private hosted zone resolving the API domain of the cluster to the VPC
+	// endpoint, so that Hive reaches the cluster's API through the VPC endpoint.
+	Enabled bool `json:"enabled"`
+}
+
+// PlatformStatus contains the observed state on AWS platform.
+type PlatformStatus struct {
+	// PrivateLink contains the AWS resources created by Hive for accessing the cluster through AWS PrivateLink.
+	// +optional
+	PrivateLink *PrivateLinkAccessStatus `json:"privateLink,omitempty"`
+}
+
+// PrivateLinkAccessStatus contains the AWS resources created by Hive for accessing the cluster through AWS
+// PrivateLink.
+type PrivateLinkAccessStatus struct {
+	// VPCEndpointService is the VPC endpoint service created in the account of the cluster for the internal API
+	// load balancer of the cluster.
+	// +optional
+	VPCEndpointService VPCEndpointService `json:"vpcEndpointService,omitempty"`
+
+	// VPCEndpointID is the ID of the VPC endpoint created in the Hive account for the VPC endpoint service.
+	// +optional
+	VPCEndpointID string `json:"vpcEndpointID,omitempty"`
+
+	// HostedZoneID is the ID of the private hosted zone created in the Hive account that resolves the API domain
+	// of the cluster to the VPC endpoint.
+	// +optional
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+}
+
+// VPCEndpointService identifies a VPC endpoint service.
+type VPCEndpointService struct {
+	// Name is the name of the VPC endpoint service.
+	Name string `json:"name,omitempty"`
+	// ID is the ID of the VPC endpoint service.
+	ID string `json:"id,omitempty"`
 }
@@ -18,6 +18,93 @@ type Platform struct {
 
 	// BaseDomainResourceGroupName specifies the resource group where the azure DNS zone for the base domain is found
 	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
+
+	// NetworkResourceGroupName is the name of the resource group holding VirtualNetwork. Required with
+	// VirtualNetwork.
+	// +optional
+	NetworkResourceGroupName string `json:"networkResourceGroupName,omitempty"`
+
+	// VirtualNetwork is the name of an existing virtual network to install the cluster into. Hive does not delete
+	// the virtual network or its subnets when the cluster is deprovisioned. When omitted, the installer creates a
+	// virtual network for the cluster.
+	// +optional
+	VirtualNetwork string `json:"virtualNetwork,omitempty"`
+
+	// ControlPlaneSubnet is the name of an existing subnet of VirtualNetwork for the control plane machines.
+	// Required with VirtualNetwork.
+	// +optional
+	ControlPlaneSubnet string `json:"controlPlaneSubnet,omitempty"`
+
+	// ComputeSubnet is the name of an existing subnet of VirtualNetwork for the compute machines. Required with
+	// VirtualNetwork.
+	// +optional
+	ComputeSubnet string `json:"computeSubnet,omitempty"`
+
+	// PrivateLink configures access to the cluster's API through Azure Private Link. Use this for clusters that are
+	// installed without a public API endpoint.
+	// +optional
+	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+
+	// ClusterOSImage is the resource ID of an image version in an Azure shared image gallery to boot the machines
+	// of the cluster from, instead of the RHCOS image of the release image. Use this for disconnected environments
+	// where the RHCOS image has been copied in advance. The gallery must be replicated to Region.
+	// +optional
+	ClusterOSImage string `json:"clusterOSImage,omitempty"`
+}
+
+// PrivateLinkAccess configures access to the cluster's API through Azure Private Link.
+type PrivateLinkAccess struct {
+	// Enabled, when true, makes Hive publish the internal API load balancer of the cluster with a private link
+	// service, and create a private endpoint for that service in one of the virtual networks configured in the
+	// azurePrivateLink section of HiveConfig. Hive then reaches the cluster's API through the private endpoint.
+	Enabled bool `json:"enabled"`
+
+	// NATSubnetCIDR is the CIDR of the subnet that Hive creates in the virtual network of the cluster for the
+	// private link service. Connections from the private endpoint reach the cluster from addresses in this subnet.
+	// It must be within the address space of the virtual network of the cluster and must not overlap with its other
+	// subnets. A /29 is sufficient.
+	// +optional
+	NATSubnetCIDR string `json:"natSubnetCIDR,omitempty"`
+}
+
+// PlatformStatus contains the observed state on Azure platform.
+type PlatformStatus struct {
+	// PrivateLink contains the Azure resources created by Hive for accessing the cluster through Azure Private Link.
+	// +optional
+	PrivateLink *PrivateLinkAccessStatus `json:"privateLink,omitempty"`
+}
+
+// PrivateLinkAccessStatus contains the Azure resources created by Hive for accessing the cluster through Azure
+// Private Link.
+type PrivateLinkAccessStatus struct {
+	// NATSubnet is the name of the subnet created in the virtual network of the cluster for the private link
+	// service.
+	// +optional
+	NATSubnet string `json:"natSubnet,omitempty"`
+
+	// PrivateLinkService is the name of the private link service created in the resource group of the cluster for
+	// the internal API load balancer of the cluster.
+	// +optional
+	PrivateLinkService string `json:"privateLinkService,omitempty"`
+
+	// PrivateEndpoint is the name of the private endpoint created in the Hive resource group.
+	// +optional
+	PrivateEndpoint string `json:"privateEndpoint,omitempty"`
+
+	// PrivateDNSZone is the name of the private DNS zone created in the Hive resource group that resolves the API
+	// domain of the cluster to the private endpoint.
+	// +optional
+	PrivateDNSZone string `json:"privateDNSZone,omitempty"`
+
+	// VirtualNetworkLink is the name of the link between the private DNS zone and the virtual network of the
+	// private endpoint.
+	// +optional
+	VirtualNetworkLink string `json:"virtualNetworkLink,omitempty"`
+
+	// EndpointIP is the IP address of the private endpoint. Hive connects to the cluster's API through this address
+	// once the connection of the private endpoint has been approved.
+	// +optional
+	EndpointIP string `json:"endpointIP,omitempty"`
 }
 
 //SetBaseDomain parses the baseDomainID and sets the related fields on azure.Platform
@@ -31,6 +31,18 @@ type ClusterClaimStatus struct {
 	// Conditions includes more detailed status for the cluster pool.
 	// +optional
 	Conditions []ClusterClaimCondition `json:"conditions,omitempty"`
+
+	// Lifetime is the lifetime of the claim once it is assigned a cluster. It is the lifetime in the spec of the claim,
+	// or the default claim lifetime of the pool, limited to the maximum claim lifetime of the pool, and is updated when
+	// either changes.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// QueuePosition is the position of the claim among the claims of its pool that are waiting for a cluster, starting
+	// at 1. Claims are assigned clusters in the order in which they were created. It is not set once the claim is
+	// assigned a cluster.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
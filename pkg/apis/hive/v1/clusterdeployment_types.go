@@ -6,8 +6,11 @@ import (
 
 	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/apis/hive/v1/agent"
 	"github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
 	"github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	"github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	"github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	"github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	"github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
@@ -21,6 +24,18 @@ const (
 	// job before cleaning up the API object.
 	FinalizerDeprovision string = "hive.openshift.io/deprovision"
 
+	// FinalizerAWSPrivateLink is used on ClusterDeployments to ensure we clean up the AWS PrivateLink resources
+	// created for the cluster before cleaning up the API object.
+	FinalizerAWSPrivateLink string = "hive.openshift.io/aws-private-link"
+
+	// FinalizerGCPPrivateServiceConnect is used on ClusterDeployments to ensure we clean up the GCP Private Service
+	// Connect resources created for the cluster before cleaning up the API object.
+	FinalizerGCPPrivateServiceConnect string = "hive.openshift.io/gcp-private-service-connect"
+
+	// FinalizerAzurePrivateLink is used on ClusterDeployments to ensure we clean up the Azure Private Link resources
+	// created for the cluster before cleaning up the API object.
+	FinalizerAzurePrivateLink string = "hive.openshift.io/azure-private-link"
+
 	// HiveClusterTypeLabel is an optional label that can be applied to ClusterDeployments. It is
 	// shown in short output, usable in searching, and adds metrics vectors which can be used to
 	// alert on cluster types differently.
@@ -114,6 +129,12 @@ type ClusterDeploymentSpec struct {
 	// May be unset in the case of adopted clusters.
 	Provisioning *Provisioning `json:"provisioning,omitempty"`
 
+	// ClusterInstallRef is a reference to the ClusterInstall resource that installs the cluster, such as the
+	// AgentClusterInstall of assisted-service. It is used in place of spec.provisioning, and Hive does not launch
+	// install pods for the cluster.
+	// +optional
+	ClusterInstallRef *ClusterInstallLocalReference `json:"clusterInstallRef,omitempty"`
+
 	// ClusterPoolRef is a reference to the ClusterPool that this ClusterDeployment originated from.
 	// +optional
 	ClusterPoolRef *ClusterPoolReference `json:"clusterPoolRef,omitempty"`
@@ -132,6 +153,93 @@ type ClusterDeploymentSpec struct {
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
+
+	// EtcdBackup configures the etcd backups taken on the cluster before Hive makes disruptive changes to it, such
+	// as rotating the serving certificates of the control plane.
+	// +optional
+	EtcdBackup *EtcdBackupConfig `json:"etcdBackup,omitempty"`
+
+	// Proxy is the egress proxy of the cluster. It is rendered into the install-config of the cluster, and Hive
+	// reaches the API of the cluster through it. Changes made after the cluster is installed only affect how Hive
+	// reaches the cluster.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// PostInstallJobs are run in order on the cluster once it is installed. Each job is started once the previous
+	// one has succeeded, and the cluster is not Ready until all of them have succeeded. Jobs are identified by their
+	// name: a job is not run again once it has succeeded, and changing a job that has already been started is not
+	// supported.
+	// +optional
+	PostInstallJobs []PostInstallJob `json:"postInstallJobs,omitempty"`
+
+	// Detach stops Hive from managing the cluster without destroying it. Once the cluster is detached, Hive no
+	// longer acts on the cluster, the resources already synced to the cluster are left in place, and deleting the
+	// ClusterDeployment does not deprovision the cluster or delete its managed DNSZone. Only installed clusters can
+	// be detached, and a detached cluster cannot be attached again.
+	// +optional
+	Detach *ClusterDetach `json:"detach,omitempty"`
+}
+
+// ClusterDetach configures how a cluster is detached from Hive.
+type ClusterDetach struct {
+	// PreserveSecrets hands the admin kubeconfig and admin password secrets of the cluster off to the user, so that
+	// they are kept when the ClusterDeployment is deleted.
+	// +optional
+	PreserveSecrets bool `json:"preserveSecrets,omitempty"`
+}
+
+// PostInstallJob is a job run on a cluster once it is installed.
+type PostInstallJob struct {
+	// Name identifies the job. It is also the name of the Job on the cluster.
+	Name string `json:"name"`
+
+	// Image is the container image run by the job.
+	Image string `json:"image"`
+
+	// Command is the entrypoint of the container. The entrypoint of the image is used when not set.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Timeout is how long the job may run before it is failed. Defaults to 1 hour.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Proxy is the egress proxy configuration of a cluster.
+type Proxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of domains, IP addresses and CIDRs that are reached without the proxy. A
+	// domain also matches all of its subdomains.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// TrustedCA is a reference to a ConfigMap in the namespace of the ClusterDeployment holding the CA bundle that
+	// signs the certificates of the proxy, under the "ca-bundle.crt" key. It is added to the additionalTrustBundle
+	// of the install-config.
+	// +optional
+	TrustedCA *corev1.LocalObjectReference `json:"trustedCA,omitempty"`
+}
+
+// EtcdBackupConfig configures the etcd backups taken on a cluster before disruptive changes.
+type EtcdBackupConfig struct {
+	// Enabled turns on etcd backups before disruptive changes. The changes wait until the backup has completed.
+	Enabled bool `json:"enabled"`
+
+	// Directory is the directory on the control plane node under which backups are written. Defaults to
+	// /home/core/backups.
+	// +optional
+	Directory string `json:"directory,omitempty"`
 }
 
 // Provisioning contains settings used only for initial cluster provisioning.
@@ -150,15 +258,40 @@ type Provisioning struct {
 	// that will take precedence over the one from the ClusterImageSet.
 	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
 
+	// InstallerImageOverride is the installer image to install the cluster with, instead of the installer image of
+	// the release image. It takes precedence over the installer image override of the ClusterImageSet. When the
+	// installer and cli images are both overridden, the images are not extracted from the release image, and the
+	// imageset job is not run.
+	// +optional
+	InstallerImageOverride string `json:"installerImageOverride,omitempty"`
+
+	// CLIImageOverride is the oc cli image to install the cluster with, instead of the cli image of the release
+	// image. It takes precedence over the cli image override of the ClusterImageSet.
+	// +optional
+	CLIImageOverride string `json:"cliImageOverride,omitempty"`
+
 	// ManifestsConfigMapRef is a reference to user-provided manifests to
 	// add to or replace manifests that are generated by the installer.
 	ManifestsConfigMapRef *corev1.LocalObjectReference `json:"manifestsConfigMapRef,omitempty"`
 
+	// Manifests are references to ConfigMaps and Secrets containing user-provided manifests to add to or replace
+	// manifests that are generated by the installer. Each key of a ConfigMap or Secret is written as a file to the
+	// installer's manifests or openshift directory before the cluster is created. Sources later in the list
+	// replace files with the same name from earlier sources and from ManifestsConfigMapRef.
+	// +optional
+	Manifests []InstallManifestsSource `json:"manifests,omitempty"`
+
+	// Networking configures the networks of the cluster, replacing the networks of the InstallConfig. Use it to
+	// install dual-stack or IPv6-only clusters on platforms that support them.
+	// +optional
+	Networking *Networking `json:"networking,omitempty"`
+
 	// SSHPrivateKeySecretRef is the reference to the secret that contains the private SSH key to use
 	// for access to compute instances. This private key should correspond to the public key included
 	// in the InstallConfig. The private key is used by Hive to gather logs on the target cluster if
 	// there are install failures.
 	// The SSH private key is expected to be in the secret data under the "ssh-privatekey" key.
+	// Once the cluster is installed, the reference may be changed to rotate the SSH key of the cluster hosts.
 	// +optional
 	SSHPrivateKeySecretRef *corev1.LocalObjectReference `json:"sshPrivateKeySecretRef,omitempty"`
 
@@ -171,14 +304,149 @@ type Provisioning struct {
 	// additional features of the installer.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// RetryPolicy configures how Hive retries failed installs. When not set, failed installs are retried for all
+	// failures with a backoff starting at one minute, doubling with each attempt up to a maximum of 24 hours.
+	// +optional
+	RetryPolicy *InstallRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Timeout is the maximum time an install attempt may run. When the timeout is reached, the installer is
+	// stopped, the partially installed cluster is cleaned up, and the attempt is marked failed with the
+	// ProvisionTimedOut reason. The attempt is then retried according to the RetryPolicy. When not set, install
+	// attempts are not timed out.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// DryRun, when true, stops provisioning after the installer has rendered the install-config and manifests
+	// for the cluster. The rendered output is stored in a ConfigMap referenced by the DryRunComplete condition
+	// so that it can be reviewed before any cloud resources are created. Set DryRun to false to provision the
+	// cluster.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Networking configures the networks of a cluster. A network lists CIDRs of a single address family for a
+// single-stack cluster, and of both IPv4 and IPv6 for a dual-stack cluster. All networks that are set must use the
+// same address families.
+type Networking struct {
+	// NetworkType is the network plugin of the cluster, such as OVNKubernetes or OpenShiftSDN. IPv6 requires
+	// OVNKubernetes. When omitted, the network type of the InstallConfig is used.
+	// +optional
+	NetworkType string `json:"networkType,omitempty"`
+
+	// MachineNetwork are the CIDRs of the networks of the machines of the cluster. No network of the cluster may
+	// overlap with another.
+	// +optional
+	MachineNetwork []string `json:"machineNetwork,omitempty"`
+
+	// ClusterNetwork are the address pools from which pod addresses are allocated.
+	// +optional
+	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
+
+	// ServiceNetwork are the CIDRs from which service addresses are allocated. There may be at most one CIDR of
+	// each address family.
+	// +optional
+	ServiceNetwork []string `json:"serviceNetwork,omitempty"`
+}
+
+const (
+	// NetworkTypeOVNKubernetes is the OVN-Kubernetes network plugin.
+	NetworkTypeOVNKubernetes = "OVNKubernetes"
+	// NetworkTypeOpenShiftSDN is the OpenShift SDN network plugin.
+	NetworkTypeOpenShiftSDN = "OpenShiftSDN"
+)
+
+// ClusterNetworkEntry is an address pool from which pod addresses are allocated.
+type ClusterNetworkEntry struct {
+	// CIDR is the address range of the pool.
+	CIDR string `json:"cidr"`
+
+	// HostPrefix is the prefix length of the subnet allocated to each node from the pool.
+	HostPrefix int32 `json:"hostPrefix"`
 }
 
+// InstallRetryPolicy configures how Hive retries failed installs.
+type InstallRetryPolicy struct {
+	// MaxAttempts is the maximum number of times Hive will attempt to install the cluster. When set, this takes
+	// precedence over InstallAttemptsLimit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// BackoffBase is the time to wait before retrying after the first failed install. The time to wait doubles
+	// with each subsequent failed install. Defaults to 1m.
+	// +optional
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+
+	// BackoffCeiling is the maximum time to wait before retrying a failed install. Defaults to 24h.
+	// +optional
+	BackoffCeiling *metav1.Duration `json:"backoffCeiling,omitempty"`
+
+	// RetryOn is the list of classes of install failures for which Hive will retry the install. When a failure
+	// is not in one of these classes, provisioning is stopped. Defaults to retrying for all failures.
+	// +optional
+	RetryOn []InstallFailureClass `json:"retryOn,omitempty"`
+}
+
+// InstallFailureClass is a classification of the reason that an install failed.
+// +kubebuilder:validation:Enum=Infrastructure;Configuration
+type InstallFailureClass string
+
+const (
+	// InstallFailureClassInfrastructure is for failures that may succeed when retried, such as cloud provider
+	// rate limiting or timeouts waiting for the cluster to come up. These are the failures with an ErrorClass of
+	// TransientCloudError, CapacityError, HiveInternalError or Unknown.
+	InstallFailureClassInfrastructure InstallFailureClass = "Infrastructure"
+	// InstallFailureClassConfiguration is for failures caused by the install configuration or cloud account that
+	// will not succeed when retried without intervention, such as an existing DNS record or an exhausted quota.
+	// These are the failures with an ErrorClass of UserError or QuotaError.
+	InstallFailureClassConfiguration InstallFailureClass = "Configuration"
+)
+
+// InstallManifestsSource is a ConfigMap or Secret containing user-provided manifests for the installer. Exactly
+// one of ConfigMapRef and SecretRef must be set.
+type InstallManifestsSource struct {
+	// ConfigMapRef is a reference to a ConfigMap containing manifests.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// SecretRef is a reference to a Secret containing manifests.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Directory is the installer directory to which the manifests are added. Defaults to manifests.
+	// +optional
+	Directory InstallManifestsDirectory `json:"directory,omitempty"`
+}
+
+// InstallManifestsDirectory is a directory of manifests generated by the installer.
+// +kubebuilder:validation:Enum=manifests;openshift
+type InstallManifestsDirectory string
+
+const (
+	// InstallManifestsDirectoryManifests is the installer directory containing the manifests for the cluster.
+	InstallManifestsDirectoryManifests InstallManifestsDirectory = "manifests"
+	// InstallManifestsDirectoryOpenShift is the installer directory containing the OpenShift specific manifests,
+	// such as MachineConfigs and Machine API objects.
+	InstallManifestsDirectoryOpenShift InstallManifestsDirectory = "openshift"
+)
+
 // ClusterImageSetReference is a reference to a ClusterImageSet
 type ClusterImageSetReference struct {
 	// Name is the name of the ClusterImageSet that this refers to
 	Name string `json:"name"`
 }
 
+// ClusterInstallLocalReference is a reference to a ClusterInstall resource in the namespace of the
+// ClusterDeployment.
+type ClusterInstallLocalReference struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+
+	Name string `json:"name"`
+}
+
 // ClusterPoolReference is a reference to a ClusterPool
 type ClusterPoolReference struct {
 	// Namespace is the namespace where the ClusterPool resides.
@@ -188,8 +456,55 @@ type ClusterPoolReference struct {
 	// ClaimName is the name of the ClusterClaim that claimed the cluster from the pool.
 	// +optional
 	ClaimName string `json:"claimName,omitempty"`
+	// Quarantine is set when the cluster has been quarantined by the pool because it is broken. A quarantined
+	// cluster is neither assigned to claims nor replaced until it is released.
+	// +optional
+	Quarantine *ClusterPoolQuarantine `json:"quarantine,omitempty"`
+	// CustomizationRef is the ClusterDeploymentCustomization in the namespace of the pool that was applied to the
+	// cluster from the inventory of the pool.
+	// +optional
+	CustomizationRef *corev1.LocalObjectReference `json:"customizationRef,omitempty"`
+	// Flavor is the name of the flavor of the pool with which the cluster was created.
+	// +optional
+	Flavor string `json:"flavor,omitempty"`
+	// Region is the region of the pool in which the cluster was created.
+	// +optional
+	Region string `json:"region,omitempty"`
+}
+
+// ClusterPoolQuarantine describes why a cluster of a pool was quarantined.
+type ClusterPoolQuarantine struct {
+	// Reason is the machine-readable reason the cluster was quarantined.
+	Reason ClusterPoolQuarantineReason `json:"reason"`
+	// Message is a human-readable description of why the cluster was quarantined.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time is when the cluster was quarantined.
+	Time metav1.Time `json:"time"`
 }
 
+// ClusterPoolQuarantineReason is the reason a cluster of a pool was quarantined.
+// +kubebuilder:validation:Enum=ProvisionFailed;HibernationFailed;ResumeFailed;ClaimHandoffFailed;Unreachable;ClusterVersionDegraded
+type ClusterPoolQuarantineReason string
+
+const (
+	// ClusterPoolQuarantineProvisionFailed is used when provisioning of the cluster was stopped without the cluster
+	// being installed.
+	ClusterPoolQuarantineProvisionFailed ClusterPoolQuarantineReason = "ProvisionFailed"
+	// ClusterPoolQuarantineHibernationFailed is used when the machines of an unclaimed cluster could not be stopped.
+	ClusterPoolQuarantineHibernationFailed ClusterPoolQuarantineReason = "HibernationFailed"
+	// ClusterPoolQuarantineResumeFailed is used when the machines of an unclaimed cluster could not be started.
+	ClusterPoolQuarantineResumeFailed ClusterPoolQuarantineReason = "ResumeFailed"
+	// ClusterPoolQuarantineClaimHandoffFailed is used when a cluster assigned to a claim could not be resumed for
+	// the claim. The claim is then assigned a different cluster.
+	ClusterPoolQuarantineClaimHandoffFailed ClusterPoolQuarantineReason = "ClaimHandoffFailed"
+	// ClusterPoolQuarantineUnreachable is used when the API of a running unclaimed cluster could not be reached.
+	ClusterPoolQuarantineUnreachable ClusterPoolQuarantineReason = "Unreachable"
+	// ClusterPoolQuarantineClusterVersionDegraded is used when the ClusterVersion of a running unclaimed cluster
+	// reports that the cluster is not available or is failing.
+	ClusterPoolQuarantineClusterVersionDegraded ClusterPoolQuarantineReason = "ClusterVersionDegraded"
+)
+
 // ClusterMetadata contains metadata information about the installed cluster.
 type ClusterMetadata struct {
 
@@ -240,8 +555,179 @@ type ClusterDeploymentStatus struct {
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
+
+	// SecretReferences is an inventory of the secrets referenced by the ClusterDeployment along with whether each
+	// of the secrets was found and is valid for its intended use.
+	// +optional
+	SecretReferences []SecretReferenceStatus `json:"secretReferences,omitempty"`
+
+	// Platform contains the observed state for the specific platform upon which to perform the installation.
+	// +optional
+	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// LastEtcdBackup is the most recent etcd backup taken on the cluster before a disruptive change.
+	// +optional
+	LastEtcdBackup *EtcdBackupStatus `json:"lastEtcdBackup,omitempty"`
+
+	// SSHKey is the SSH public key authorized on the hosts of the cluster.
+	// +optional
+	SSHKey *SSHKeyStatus `json:"sshKey,omitempty"`
+
+	// PostInstallJobs is the state of the post-install jobs that have been started on the cluster.
+	// +optional
+	PostInstallJobs []PostInstallJobStatus `json:"postInstallJobs,omitempty"`
+}
+
+// PostInstallJobState is the state of a post-install job.
+type PostInstallJobState string
+
+const (
+	// PostInstallJobRunning is the state of a post-install job that has been started and has not finished yet.
+	PostInstallJobRunning PostInstallJobState = "Running"
+	// PostInstallJobSucceeded is the state of a post-install job that has succeeded.
+	PostInstallJobSucceeded PostInstallJobState = "Succeeded"
+	// PostInstallJobFailed is the state of a post-install job that has failed.
+	PostInstallJobFailed PostInstallJobState = "Failed"
+)
+
+// PostInstallJobStatus is the state of a post-install job of a cluster.
+type PostInstallJobStatus struct {
+	// Name is the name of the job.
+	Name string `json:"name"`
+
+	// State is the state of the job.
+	State PostInstallJobState `json:"state"`
+
+	// Message is a human-readable description of the state of the job.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when Hive started the job.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when Hive observed that the job succeeded.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// SSHKeyStatus is the SSH public key authorized on the hosts of a cluster.
+type SSHKeyStatus struct {
+	// SecretName is the name of the secret holding the private key of the authorized public key.
+	SecretName string `json:"secretName"`
+
+	// Fingerprint is the SHA256 fingerprint of the authorized public key.
+	Fingerprint string `json:"fingerprint"`
+
+	// LastRotationTime is when Hive last pushed a new public key to the cluster. It is not set while the cluster
+	// still has the key it was installed with.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// EtcdBackupStatus is an etcd backup taken on a cluster before a disruptive change.
+type EtcdBackupStatus struct {
+	// Name is the name of the backup. It is also the name of the Job on the cluster that takes the backup.
+	Name string `json:"name"`
+
+	// Reason is the disruptive change the backup was taken before.
+	Reason string `json:"reason"`
+
+	// Path is the directory on the control plane node holding the backup.
+	Path string `json:"path"`
+
+	// Node is the control plane node holding the backup. It is set once the backup has completed.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// CompletionTime is when the backup completed. It is not set while the backup is in progress.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
+// PlatformStatus contains the observed state for the specific platform upon which to
+// perform the installation.
+type PlatformStatus struct {
+	// AWS is the observed state on AWS.
+	// +optional
+	AWS *aws.PlatformStatus `json:"aws,omitempty"`
+
+	// GCP is the observed state on GCP.
+	// +optional
+	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
+
+	// Azure is the observed state on Azure.
+	// +optional
+	Azure *azure.PlatformStatus `json:"azure,omitempty"`
+}
+
+// SecretReferenceType describes how a secret referenced by a ClusterDeployment is used.
+type SecretReferenceType string
+
+const (
+	// PullSecretReference is the pull secret used when pulling images.
+	PullSecretReference SecretReferenceType = "PullSecret"
+	// CredentialsSecretReference is the secret containing the cloud platform credentials.
+	CredentialsSecretReference SecretReferenceType = "Credentials"
+	// CertificatesSecretReference is the secret containing the CA certificates for the platform.
+	CertificatesSecretReference SecretReferenceType = "Certificates"
+	// LibvirtSSHPrivateKeySecretReference is the secret containing the SSH key for the libvirt provisioning host.
+	LibvirtSSHPrivateKeySecretReference SecretReferenceType = "LibvirtSSHPrivateKey"
+	// InstallConfigSecretReference is the secret containing the install config.
+	InstallConfigSecretReference SecretReferenceType = "InstallConfig"
+	// SSHPrivateKeySecretReference is the secret containing the SSH private key for the cluster hosts.
+	SSHPrivateKeySecretReference SecretReferenceType = "SSHPrivateKey"
+	// AdminKubeconfigSecretReference is the secret containing the admin kubeconfig for the cluster.
+	AdminKubeconfigSecretReference SecretReferenceType = "AdminKubeconfig"
+	// AdminPasswordSecretReference is the secret containing the admin username and password for the cluster.
+	AdminPasswordSecretReference SecretReferenceType = "AdminPassword"
+	// CertificateBundleSecretReference is the secret containing a certificate bundle.
+	CertificateBundleSecretReference SecretReferenceType = "CertificateBundle"
+)
+
+// SecretReferenceStatus contains the status of a secret referenced by a ClusterDeployment.
+type SecretReferenceStatus struct {
+	// Name is the name of the referenced secret.
+	Name string `json:"name"`
+
+	// Type describes how the secret is used by the ClusterDeployment.
+	Type SecretReferenceType `json:"type"`
+
+	// Conditions includes more detailed status for the secret reference.
+	// +optional
+	Conditions []SecretReferenceCondition `json:"conditions,omitempty"`
+}
+
+// SecretReferenceCondition contains details for the current condition of a secret reference.
+type SecretReferenceCondition struct {
+	// Type is the type of the condition.
+	Type SecretReferenceConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SecretReferenceConditionType is a valid value for SecretReferenceCondition.Type
+type SecretReferenceConditionType string
+
+const (
+	// SecretReferenceFoundCondition is true when the referenced secret exists.
+	SecretReferenceFoundCondition SecretReferenceConditionType = "Found"
+
+	// SecretReferenceValidCondition is true when the referenced secret contains the data expected for its type.
+	SecretReferenceValidCondition SecretReferenceConditionType = "Valid"
+)
+
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
 type ClusterDeploymentCondition struct {
 	// Type is the type of the condition.
@@ -287,6 +773,10 @@ const (
 	// UnreachableCondition indicates that Hive is unable to establish an API connection to the remote cluster.
 	UnreachableCondition ClusterDeploymentConditionType = "Unreachable"
 
+	// ClusterVersionDegradedCondition indicates that the ClusterVersion of the remote cluster reports that the
+	// cluster is not available or is failing.
+	ClusterVersionDegradedCondition ClusterDeploymentConditionType = "ClusterVersionDegraded"
+
 	// ActiveAPIURLOverrideCondition indicates that Hive is communicating with the remote cluster using the
 	// API URL override.
 	ActiveAPIURLOverrideCondition ClusterDeploymentConditionType = "ActiveAPIURLOverride"
@@ -295,6 +785,22 @@ const (
 	// (ie manageDNS==true) has not yet indicated that the DNS zone is successfully responding to queries.
 	DNSNotReadyCondition ClusterDeploymentConditionType = "DNSNotReady"
 
+	// AWSPrivateLinkNotReadyCondition indicates that the AWS PrivateLink resources used to reach the cluster's API
+	// are not ready.
+	AWSPrivateLinkNotReadyCondition ClusterDeploymentConditionType = "AWSPrivateLinkNotReady"
+
+	// GCPPrivateServiceConnectNotReadyCondition indicates that the GCP Private Service Connect resources used to
+	// reach the cluster's API are not ready.
+	GCPPrivateServiceConnectNotReadyCondition ClusterDeploymentConditionType = "GCPPrivateServiceConnectNotReady"
+
+	// AzurePrivateLinkNotReadyCondition indicates that the Azure Private Link resources used to reach the cluster's
+	// API are not ready.
+	AzurePrivateLinkNotReadyCondition ClusterDeploymentConditionType = "AzurePrivateLinkNotReady"
+
+	// EtcdBackupFailedCondition indicates that the etcd backup taken before a disruptive change to the cluster
+	// failed. The change waits until a backup succeeds.
+	EtcdBackupFailedCondition ClusterDeploymentConditionType = "EtcdBackupFailed"
+
 	// ProvisionFailedCondition indicates that a provision failed
 	ProvisionFailedCondition ClusterDeploymentConditionType = "ProvisionFailed"
 
@@ -316,6 +822,33 @@ const (
 
 	// ProvisionStoppedCondition is set when cluster provisioning is stopped
 	ProvisionStoppedCondition ClusterDeploymentConditionType = "ProvisionStopped"
+
+	// DryRunCompleteCondition is set when a dry-run provision has rendered the install-config and manifests for
+	// the cluster.
+	DryRunCompleteCondition ClusterDeploymentConditionType = "DryRunComplete"
+
+	// InvalidSecretReferencesCondition is set when one or more of the secrets referenced by the ClusterDeployment
+	// is missing or does not contain the expected data.
+	InvalidSecretReferencesCondition ClusterDeploymentConditionType = "InvalidSecretReferences"
+
+	// PostInstallJobsNotCompleteCondition is set when the post-install jobs of the cluster have not all succeeded yet.
+	PostInstallJobsNotCompleteCondition ClusterDeploymentConditionType = "PostInstallJobsNotComplete"
+
+	// PausedCondition is set when reconciliation of the ClusterDeployment has been paused with the
+	// hive.openshift.io/reconcile-pause annotation.
+	PausedCondition ClusterDeploymentConditionType = "Paused"
+
+	// DetachedCondition is set when the cluster has been detached from Hive with spec.detach.
+	DetachedCondition ClusterDeploymentConditionType = "Detached"
+
+	// ReadyCondition rolls the other conditions of the ClusterDeployment up into a single condition. It is True
+	// when the cluster is installed and usable. Otherwise it is False with the reason of the first of the
+	// following that applies, in order of precedence:
+	//   Deleting, Detached, Relocating, RelocationFailed, ProvisionStopped, ClusterImageSetNotFound, InvalidSecretReferences,
+	//   InstallerImageResolutionFailed, DNSNotReady, InstallLaunchError, ProvisionFailed, DryRunComplete,
+	//   Provisioning (for clusters that are not yet installed), Hibernating, Unreachable, ReachabilityUnknown,
+	//   InvalidSecretReferences, CertificateNotFound, SyncSetFailed, PostInstallJobsNotComplete (for installed clusters).
+	ReadyCondition ClusterDeploymentConditionType = "Ready"
 )
 
 // AllClusterDeploymentConditions is a slice containing all condition types. This can be used for dealing with
@@ -326,15 +859,50 @@ var AllClusterDeploymentConditions = []ClusterDeploymentConditionType{
 	ControlPlaneCertificateNotFoundCondition,
 	IngressCertificateNotFoundCondition,
 	UnreachableCondition,
+	ClusterVersionDegradedCondition,
 	ActiveAPIURLOverrideCondition,
 	DNSNotReadyCondition,
+	AWSPrivateLinkNotReadyCondition,
+	GCPPrivateServiceConnectNotReadyCondition,
+	AzurePrivateLinkNotReadyCondition,
+	EtcdBackupFailedCondition,
 	ProvisionFailedCondition,
 	SyncSetFailedCondition,
 	RelocationFailedCondition,
 	ClusterHibernatingCondition,
 	InstallLaunchErrorCondition,
+	DryRunCompleteCondition,
+	InvalidSecretReferencesCondition,
+	PostInstallJobsNotCompleteCondition,
+	PausedCondition,
+	DetachedCondition,
+	ReadyCondition,
 }
 
+// InitializedConditionReason is used as the reason of a condition that has been initialized with status Unknown
+// before its controller has set it. Every condition in AllClusterDeploymentConditions is initialized, so that
+// consumers of the ClusterDeployment can rely on each condition being present.
+const InitializedConditionReason = "Initialized"
+
+// Ready condition reasons
+const (
+	// ClusterReadyReason is used as the reason when the cluster is installed and usable.
+	ClusterReadyReason = "ClusterReady"
+	// DeletingReadyReason is used as the reason when the ClusterDeployment is being deleted.
+	DeletingReadyReason = "Deleting"
+	// RelocatingReadyReason is used as the reason when the ClusterDeployment is being relocated to another Hive
+	// instance.
+	RelocatingReadyReason = "Relocating"
+	// ProvisioningReadyReason is used as the reason when the cluster is being provisioned.
+	ProvisioningReadyReason = "Provisioning"
+	// CertificateNotFoundReadyReason is used as the reason when a control plane or ingress certificate bundle is
+	// not available.
+	CertificateNotFoundReadyReason = "CertificateNotFound"
+	// ReachabilityUnknownReadyReason is used as the reason when the installed cluster has not been probed for
+	// reachability yet, so its Unreachable condition is missing or Unknown.
+	ReachabilityUnknownReadyReason = "ReachabilityUnknown"
+)
+
 // Cluster hibernating reasons
 const (
 	// ResumingHibernationReason is used as the reason when the cluster is transitioning
@@ -360,6 +928,12 @@ const (
 	// FailedToStartHibernationReason is used when there was an error starting machines
 	// to leave hibernation
 	FailedToStartHibernationReason = "FailedToStart"
+	// PreflightCheckFailedHibernationReason is used when the cluster was not hibernated because the hibernation
+	// preflight check found constructs in the cluster that are known to break when the cluster is stopped and started
+	PreflightCheckFailedHibernationReason = "PreflightCheckFailed"
+	// RestartingHibernationReason is used when the machines of a resuming cluster are being restarted because its
+	// nodes did not become ready, typically because their certificates expired while the cluster was hibernating
+	RestartingHibernationReason = "Restarting"
 )
 
 // +genclient
@@ -400,6 +974,11 @@ type Platform struct {
 	// AWS is the configuration used when installing on AWS.
 	AWS *aws.Platform `json:"aws,omitempty"`
 
+	// AgentBareMetal is the configuration used when performing an agent based install on bare metal. The install
+	// is carried out by the ClusterInstall implementation referenced by spec.clusterInstallRef.
+	// +optional
+	AgentBareMetal *agent.BareMetalPlatform `json:"agentBareMetal,omitempty"`
+
 	// Azure is the configuration used when installing on Azure.
 	// +optional
 	Azure *azure.Platform `json:"azure,omitempty"`
@@ -411,6 +990,14 @@ type Platform struct {
 	// +optional
 	GCP *gcp.Platform `json:"gcp,omitempty"`
 
+	// IBMCloud is the configuration used when installing on IBM Cloud.
+	// +optional
+	IBMCloud *ibmcloud.Platform `json:"ibmcloud,omitempty"`
+
+	// Nutanix is the configuration used when installing on Nutanix.
+	// +optional
+	Nutanix *nutanix.Platform `json:"nutanix,omitempty"`
+
 	// OpenStack is the configuration used when installing on OpenStack
 	OpenStack *openstack.Platform `json:"openstack,omitempty"`
 
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterDeploymentCustomizationSpec defines the customization applied to a cluster created from a ClusterPool.
+type ClusterDeploymentCustomizationSpec struct {
+	// InstallConfigPatches is a list of JSON patches (RFC 6902) applied to the install-config of the cluster.
+	// +optional
+	InstallConfigPatches []PatchEntity `json:"installConfigPatches,omitempty"`
+}
+
+// PatchEntity is a single JSON patch (RFC 6902) operation.
+type PatchEntity struct {
+	// Op is the operation to perform.
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	// +required
+	Op string `json:"op"`
+	// Path is the JSON pointer to the target of the operation.
+	// +required
+	Path string `json:"path"`
+	// From is the JSON pointer to the source of a move or copy operation.
+	// +optional
+	From string `json:"from,omitempty"`
+	// Value is the string value used by an add, replace or test operation.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomization is an entry in the inventory of a ClusterPool. Each cluster created from the pool
+// consumes one entry, and the customization of the entry is applied to the cluster.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=clusterdeploymentcustomizations,shortName=cdc
+type ClusterDeploymentCustomization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterDeploymentCustomizationSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentCustomizationList contains a list of ClusterDeploymentCustomizations.
+type ClusterDeploymentCustomizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeploymentCustomization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeploymentCustomization{}, &ClusterDeploymentCustomizationList{})
+}
@@ -3,6 +3,9 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 )
 
 // ClusterDeprovisionSpec defines the desired state of ClusterDeprovision
@@ -22,9 +25,40 @@ type ClusterDeprovisionStatus struct {
 	// Completed is true when the uninstall has completed successfully
 	Completed bool `json:"completed,omitempty"`
 
+	// Retries is the number of times the uninstall job was retried after failing
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// RemainingResources are the cloud resources of the cluster that the uninstall job has not yet destroyed, as last
+	// reported by the job. At most 100 resources are listed. Only reported by the AWS uninstaller.
+	// +optional
+	RemainingResources []RemainingCloudResource `json:"remainingResources,omitempty"`
+
+	// RemainingResourcesReportTime is the time at which the uninstall job last reported the remaining resources.
+	// +optional
+	RemainingResourcesReportTime *metav1.Time `json:"remainingResourcesReportTime,omitempty"`
+}
+
+// RemainingCloudResource is a cloud resource of a cluster that has not yet been destroyed.
+type RemainingCloudResource struct {
+	// Type is the type of the resource, such as ec2:security-group.
+	Type string `json:"type"`
+
+	// ID is the ID of the resource, such as sg-0123456789abcdef0.
+	ID string `json:"id"`
+
+	// Region is the region of the resource, if any.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Reason is the last error returned when destroying the resource, which is usually why the resource is
+	// blocking the deprovision.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
@@ -36,12 +70,19 @@ type ClusterDeprovisionPlatform struct {
 	Azure *AzureClusterDeprovision `json:"azure,omitempty"`
 	// GCP contains GCP-specific deprovision settings
 	GCP *GCPClusterDeprovision `json:"gcp,omitempty"`
+	// IBMCloud contains IBM Cloud-specific deprovision settings
+	IBMCloud *IBMCloudClusterDeprovision `json:"ibmcloud,omitempty"`
+	// Nutanix contains Nutanix-specific deprovision settings
+	Nutanix *NutanixClusterDeprovision `json:"nutanix,omitempty"`
 	// OpenStack contains OpenStack-specific deprovision settings
 	OpenStack *OpenStackClusterDeprovision `json:"openstack,omitempty"`
 	// VSphere contains VMWare vSphere-specific deprovision settings
 	VSphere *VSphereClusterDeprovision `json:"vsphere,omitempty"`
 	// Ovirt contains oVirt-specific deprovision settings
 	Ovirt *OvirtClusterDeprovision `json:"ovirt,omitempty"`
+	// External contains deprovision settings for a platform that is only deprovisioned by an external destroyer
+	// configured in HiveConfig
+	External *ExternalClusterDeprovision `json:"external,omitempty"`
 }
 
 // AWSClusterDeprovision contains AWS-specific configuration for a ClusterDeprovision
@@ -51,6 +92,10 @@ type AWSClusterDeprovision struct {
 
 	// CredentialsSecretRef is the AWS account credentials to use for deprovisioning the cluster
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints used for AWS services when deprovisioning the cluster
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 }
 
 // AzureClusterDeprovision contains Azure-specific configuration for a ClusterDeprovision
@@ -67,6 +112,32 @@ type GCPClusterDeprovision struct {
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
+// IBMCloudClusterDeprovision contains IBM Cloud-specific configuration for a ClusterDeprovision
+type IBMCloudClusterDeprovision struct {
+	// Region is the IBM Cloud region for this deprovision
+	Region string `json:"region"`
+	// ResourceGroupName is the name of the resource group that holds the resources of the cluster
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+	// CISInstanceCRN is the CRN of the IBM Cloud Internet Services instance managing the DNS zone of the cluster
+	// +optional
+	CISInstanceCRN string `json:"cisInstanceCRN,omitempty"`
+	// CredentialsSecretRef is the IBM Cloud API key to use for deprovisioning the cluster
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// NutanixClusterDeprovision contains Nutanix-specific configuration for a ClusterDeprovision
+type NutanixClusterDeprovision struct {
+	// PrismCentral is the endpoint of the Prism Central managing the cluster.
+	PrismCentral nutanix.PrismEndpoint `json:"prismCentral"`
+	// CredentialsSecretRef is the Prism Central account credentials to use for deprovisioning the cluster
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+	// CertificatesSecretRef refers to a secret that contains the CA certificates necessary for
+	// communicating with Prism Central.
+	// +optional
+	CertificatesSecretRef *corev1.LocalObjectReference `json:"certificatesSecretRef,omitempty"`
+}
+
 // OpenStackClusterDeprovision contains OpenStack-specific configuration for a ClusterDeprovision
 type OpenStackClusterDeprovision struct {
 	// Cloud is the secion in the clouds.yaml secret below to use for auth/connectivity.
@@ -98,6 +169,19 @@ type OvirtClusterDeprovision struct {
 	CertificatesSecretRef corev1.LocalObjectReference `json:"certificatesSecretRef"`
 }
 
+// ExternalClusterDeprovision contains configuration for a ClusterDeprovision run by an external destroyer
+type ExternalClusterDeprovision struct {
+	// Platform is the name of the platform of the cluster. It selects the external destroyer in HiveConfig that
+	// deprovisions the cluster.
+	Platform string `json:"platform"`
+	// CredentialsSecretRef is the account credentials to use for deprovisioning the cluster
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+	// Parameters are platform-specific settings passed to the external destroyer
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -143,6 +227,9 @@ type ClusterDeprovisionConditionType string
 const (
 	// AuthenticationFailureClusterDeprovisionCondition is true when credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeprovisionCondition ClusterDeprovisionConditionType = "AuthenticationFailure"
+	// DeprovisionFailedClusterDeprovisionCondition is true when the last uninstall job failed. The reason classifies
+	// the failure and the message names the cloud resource types that could not be removed, when known.
+	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
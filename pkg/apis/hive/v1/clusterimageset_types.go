@@ -9,6 +9,16 @@ type ClusterImageSetSpec struct {
 	// ReleaseImage is the image that contains the payload to use when installing
 	// a cluster.
 	ReleaseImage string `json:"releaseImage"`
+
+	// InstallerImageOverride is the installer image to install clusters with, instead of the installer image of
+	// ReleaseImage. Use this with a fixed installer build to skip extracting the images from the release image
+	// when CLIImageOverride is also set.
+	// +optional
+	InstallerImageOverride string `json:"installerImageOverride,omitempty"`
+
+	// CLIImageOverride is the oc cli image to install clusters with, instead of the cli image of ReleaseImage.
+	// +optional
+	CLIImageOverride string `json:"cliImageOverride,omitempty"`
 }
 
 // ClusterImageSetStatus defines the observed state of ClusterImageSet
@@ -21,6 +21,13 @@ type ClusterPoolSpec struct {
 	// +required
 	Size int32 `json:"size"`
 
+	// RunningCount is the number of unclaimed clusters of the pool to keep running, so that they can be claimed
+	// without waiting for them to resume from hibernation. The other unclaimed clusters are kept hibernating. When a
+	// running cluster is claimed, a hibernating cluster is resumed in its place. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunningCount int32 `json:"runningCount,omitempty"`
+
 	// BaseDomain is the base domain to use for all clusters created in this pool.
 	// +required
 	BaseDomain string `json:"baseDomain"`
@@ -33,6 +40,251 @@ type ClusterPoolSpec struct {
 	// claimed will not be affected when this value is modified.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install each cluster of the pool.
+	// Clusters that fail to install within the limit are quarantined. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
+
+	// ReplaceBrokenClusters deletes and replaces the installed unclaimed clusters of the pool that are quarantined
+	// because they broke, rather than keeping them for investigation. Clusters that fail to install are always kept,
+	// so that a systemic problem, such as an exhausted cloud quota, is not hidden behind clusters being created over
+	// and over again.
+	// +optional
+	ReplaceBrokenClusters bool `json:"replaceBrokenClusters,omitempty"`
+
+	// Paused stops the pool from creating new clusters. The pool keeps assigning the clusters it has to claims.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Draining stops the pool from assigning clusters to claims, and deletes its unclaimed clusters without replacing
+	// them. Claimed clusters are deleted with their claims as usual, so that the pool can be decommissioned once they
+	// are gone. Quarantined clusters are kept until they are released.
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
+	// Inventory is a list of entries that customize the clusters created for the pool. Each new cluster consumes an
+	// entry that is not used by another cluster of the pool, and the pool does not create clusters when none are left.
+	// An entry is released for reuse when its cluster is deleted.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+
+	// Flavors are variants of the clusters of the pool, such as clusters in different regions or with different
+	// instance types. Each new cluster is created with the flavor that has the fewest clusters for its weight, so that
+	// the clusters of the pool, and so its claims, are spread across the flavors. Claims do not choose a flavor.
+	// +optional
+	Flavors []ClusterPoolFlavor `json:"flavors,omitempty"`
+
+	// Regions is an ordered list of regions in which the clusters of the pool are created. New clusters are created in
+	// the first region that is available. When a cluster fails to provision because of a quota or capacity error, its
+	// region is marked unavailable for a while and the cluster is replaced by one in the next region. The region of
+	// each cluster is recorded in its ClusterPoolReference.
+	// +optional
+	Regions []ClusterPoolRegion `json:"regions,omitempty"`
+
+	// ClaimLifetime defines the lifetimes of the ClusterClaims of the pool.
+	// +optional
+	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// ClaimReadiness lists the SyncSets and SelectorSyncSets that must have been applied to a cluster of the pool
+	// before it is assigned to a claim, so that claims never receive a cluster that is only partly configured.
+	// +optional
+	ClaimReadiness *ClusterPoolClaimReadiness `json:"claimReadiness,omitempty"`
+
+	// CapacityPoolRef is a reference to a capacity pool defined in HiveConfig, such as one per cloud account. The
+	// pools referencing the same capacity pool share its limit on the clusters that are provisioning at the same time.
+	// +optional
+	CapacityPoolRef *CapacityPoolReference `json:"capacityPoolRef,omitempty"`
+
+	// Priority is the priority of the pool in its capacity pool. When the capacity pool cannot provision all of the
+	// clusters its pools need, the clusters of the pools with a higher priority are provisioned first. Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// HibernationConfig configures the hibernation of the unclaimed clusters of the pool.
+	// +optional
+	HibernationConfig *ClusterPoolHibernationConfig `json:"hibernationConfig,omitempty"`
+
+	// MaxClusterAge is the maximum age of the installed unclaimed clusters of the pool. Clusters older than this are
+	// deleted and replaced, so that the pool does not hand out clusters with expired certificates or outdated images.
+	// +optional
+	MaxClusterAge *metav1.Duration `json:"maxClusterAge,omitempty"`
+
+	// Autoscaling sizes the pool from its historical claim rate, so that it has clusters ready for the claims it
+	// expects. While Autoscaling is set, it takes the place of Size.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// Schedules change the size of the pool on a calendar, such as to keep more clusters during business hours than
+	// overnight. At any time, the size of the pool is the size of the schedule that started last. Size applies until
+	// one of the schedules has started. Schedules cannot be combined with Autoscaling.
+	// +optional
+	Schedules []ClusterPoolSizeSchedule `json:"schedules,omitempty"`
+
+	// UpdateStrategy replaces the unclaimed clusters of the pool that were created from an earlier version of its
+	// spec, such as before its ClusterImageSet, platform or flavors were changed. Without an update strategy, such
+	// clusters are kept until they are claimed.
+	// +optional
+	UpdateStrategy *ClusterPoolUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// ClusterPoolAutoscaling configures a pool to size itself from its historical claim rate. The pool keeps as many
+// unclaimed clusters as the larger of the number of claims in the last replenish window, and the number of claims in
+// the coming replenish window one week earlier, within the minimum and maximum sizes.
+type ClusterPoolAutoscaling struct {
+	// MinSize is the smallest number of unclaimed clusters the pool keeps.
+	// +kubebuilder:validation:Minimum=0
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the largest number of unclaimed clusters the pool keeps.
+	// +kubebuilder:validation:Minimum=0
+	MaxSize int32 `json:"maxSize"`
+
+	// ReplenishWindow is how long the pool takes to replace claimed clusters, which is about the time it takes to
+	// install a cluster. It is rounded up to whole hours. Defaults to 1h.
+	// +optional
+	ReplenishWindow *metav1.Duration `json:"replenishWindow,omitempty"`
+}
+
+// ClusterPoolHibernationConfig configures the hibernation of the unclaimed clusters of a pool.
+type ClusterPoolHibernationConfig struct {
+	// Schedule hibernates and resumes all of the unclaimed clusters of the pool at set times, such as to hibernate
+	// them outside of working hours. While the schedule is in effect, it takes the place of RunningCount.
+	// +optional
+	Schedule *HibernationSchedule `json:"schedule,omitempty"`
+}
+
+// ClusterPoolSizeSchedule sets the size of a pool from the times given by a cron expression, until another schedule of
+// the pool starts.
+type ClusterPoolSizeSchedule struct {
+	// Name identifies the schedule. It is reported in the status of the pool while the schedule is in effect.
+	// +required
+	Name string `json:"name"`
+
+	// Start is a cron expression of the times at which the schedule starts, such as "0 8 * * 1-5".
+	// +required
+	Start string `json:"start"`
+
+	// Size is the number of unclaimed clusters the pool keeps while the schedule is in effect.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Size int32 `json:"size"`
+
+	// TimeZone is the IANA name of the time zone in which the cron expression is evaluated, such as
+	// "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// HibernationSchedule is a schedule of times at which to hibernate and resume clusters. At any time, the clusters are
+// hibernating if the last of those times was a time to hibernate, and running if it was a time to resume.
+type HibernationSchedule struct {
+	// Hibernate is a cron expression of the times at which to hibernate the clusters, such as "0 19 * * 1-5".
+	Hibernate string `json:"hibernate"`
+
+	// Resume is a cron expression of the times at which to resume the clusters, such as "0 7 * * 1-5".
+	Resume string `json:"resume"`
+
+	// TimeZone is the IANA name of the time zone in which the cron expressions are evaluated, such as
+	// "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CapacityPoolReference is a reference to a capacity pool defined in HiveConfig.
+type CapacityPoolReference struct {
+	// Name is the name of the capacity pool.
+	Name string `json:"name"`
+}
+
+// ClusterPoolClaimLifetime defines the lifetimes of the ClusterClaims of a pool. The lifetime of a claim starts when it
+// is assigned a cluster.
+type ClusterPoolClaimLifetime struct {
+	// Default is the lifetime of a claim of the pool that does not set a lifetime.
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+
+	// Maximum is the longest lifetime of a claim of the pool. Claims are not allowed to set a longer lifetime, and
+	// the lifetime of claims that did so before the maximum was set is reduced to the maximum.
+	// +optional
+	Maximum *metav1.Duration `json:"maximum,omitempty"`
+}
+
+// ClusterPoolClaimReadiness lists the syncsets that must have been applied to a cluster of a pool before it is
+// assigned to a claim.
+type ClusterPoolClaimReadiness struct {
+	// SyncSets are the names of SyncSets, in the namespace of each cluster, that must have been applied to it.
+	// +optional
+	SyncSets []string `json:"syncSets,omitempty"`
+	// SelectorSyncSets are the names of SelectorSyncSets that must have been applied to each cluster.
+	// +optional
+	SelectorSyncSets []string `json:"selectorSyncSets,omitempty"`
+}
+
+// ClusterPoolFlavor is a variant of the clusters of a ClusterPool.
+type ClusterPoolFlavor struct {
+	// Name identifies the flavor. It is recorded in the ClusterPoolReference of the clusters of the flavor.
+	// +required
+	Name string `json:"name"`
+	// Weight is the share of the clusters of the pool created with the flavor, relative to the weights of the other
+	// flavors.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	Weight int32 `json:"weight"`
+	// Platform replaces the platform of the pool for the clusters of the flavor, such as to create them in another
+	// region or cloud account.
+	// +optional
+	Platform *Platform `json:"platform,omitempty"`
+	// InstallConfigPatches is a list of JSON patches (RFC 6902) applied to the install-config of the clusters of the
+	// flavor, such as to choose other instance types. They are applied before the patches of an inventory entry.
+	// +optional
+	InstallConfigPatches []PatchEntity `json:"installConfigPatches,omitempty"`
+}
+
+// ClusterPoolRegion is a region in which the clusters of a ClusterPool can be created.
+type ClusterPoolRegion struct {
+	// Name is the name of the region on the platform of the pool, such as "us-east-1".
+	// +required
+	Name string `json:"name"`
+	// ImageSetRef replaces the ClusterImageSet of the pool for the clusters created in the region, such as when the
+	// region needs another release image.
+	// +optional
+	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
+}
+
+// ClusterPoolUpdateStrategy defines how a pool replaces its outdated clusters. At least one of MaxUnavailable and
+// MaxSurge must be set.
+type ClusterPoolUpdateStrategy struct {
+	// MaxUnavailable is the number of ready clusters below the size of the pool to which the pool may drop while it
+	// deletes outdated clusters. Outdated clusters that are still installing are deleted regardless. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnavailable int32 `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the number of clusters above the size of the pool that the pool may create to replace outdated
+	// clusters before deleting them. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSurge int32 `json:"maxSurge,omitempty"`
+}
+
+// InventoryEntryKind is the kind of resource referenced by an inventory entry.
+// +kubebuilder:validation:Enum="";ClusterDeploymentCustomization
+type InventoryEntryKind string
+
+const (
+	// ClusterDeploymentCustomizationInventoryEntry is an inventory entry referencing a ClusterDeploymentCustomization.
+	ClusterDeploymentCustomizationInventoryEntry InventoryEntryKind = "ClusterDeploymentCustomization"
+)
+
+// InventoryEntry references a resource in the namespace of the pool that customizes a cluster of the pool.
+type InventoryEntry struct {
+	// Kind is the kind of the referenced resource. Defaults to ClusterDeploymentCustomization.
+	// +optional
+	Kind InventoryEntryKind `json:"kind,omitempty"`
+	// Name is the name of the referenced resource.
+	// +required
+	Name string `json:"name"`
 }
 
 // ClusterPoolStatus defines the observed state of ClusterPool
@@ -43,9 +295,95 @@ type ClusterPoolStatus struct {
 	// Ready is the number of unclaimed clusters that have been installed and are ready to be claimed.
 	Ready int32 `json:"ready"`
 
+	// Quarantined is the number of clusters of the pool that have been quarantined because they are broken.
+	// +optional
+	Quarantined int32 `json:"quarantined,omitempty"`
+
+	// Outdated is the number of unclaimed clusters of the pool that were created from an earlier version of its spec.
+	// +optional
+	Outdated int32 `json:"outdated,omitempty"`
+
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// Inventory is the state of each entry of the inventory of the pool.
+	// +optional
+	Inventory []InventoryEntryStatus `json:"inventory,omitempty"`
+
+	// TargetSize is the number of unclaimed clusters the pool keeps. It is the size of the pool, the size of its
+	// schedule in effect, or the size chosen by autoscaling.
+	// +optional
+	TargetSize int32 `json:"targetSize,omitempty"`
+
+	// ActiveSizeSchedule is the name of the schedule of the pool in effect, if any.
+	// +optional
+	ActiveSizeSchedule string `json:"activeSizeSchedule,omitempty"`
+
+	// NextSizeChange is the time at which the next schedule of the pool starts, if any.
+	// +optional
+	NextSizeChange *metav1.Time `json:"nextSizeChange,omitempty"`
+
+	// ClaimHistory counts the claims of the pool by the hour in which they were created, over the last week. It is
+	// kept while autoscaling is configured.
+	// +optional
+	ClaimHistory []ClaimHistoryEntry `json:"claimHistory,omitempty"`
+
+	// UnavailableRegions are the regions of the pool in which a cluster recently failed to provision because of a
+	// quota or capacity error. New clusters are created in other regions until the region is retried.
+	// +optional
+	UnavailableRegions []ClusterPoolUnavailableRegion `json:"unavailableRegions,omitempty"`
+}
+
+// ClusterPoolUnavailableRegion is a region of a pool in which a cluster failed to provision.
+type ClusterPoolUnavailableRegion struct {
+	// Name is the name of the region.
+	Name string `json:"name"`
+	// Since is the time at which the region was found to be unavailable.
+	Since metav1.Time `json:"since"`
+	// Message is the provision failure that made the region unavailable.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClaimHistoryEntry counts the claims of a pool created within an hour.
+type ClaimHistoryEntry struct {
+	// Hour is the start of the hour.
+	Hour metav1.Time `json:"hour"`
+
+	// Claims is the number of claims created within the hour.
+	Claims int32 `json:"claims"`
+}
+
+// InventoryEntryState is the state of an inventory entry.
+type InventoryEntryState string
+
+const (
+	// InventoryEntryAvailable is used for an entry that can be used by a new cluster.
+	InventoryEntryAvailable InventoryEntryState = "Available"
+	// InventoryEntryConsumed is used for an entry that is used by a cluster of the pool.
+	InventoryEntryConsumed InventoryEntryState = "Consumed"
+	// InventoryEntryBroken is used for an entry that cannot be used, because the resource it references does not
+	// exist or could not be applied to a cluster.
+	InventoryEntryBroken InventoryEntryState = "Broken"
+)
+
+// InventoryEntryStatus is the state of an inventory entry.
+type InventoryEntryStatus struct {
+	// Name is the name of the inventory entry.
+	Name string `json:"name"`
+	// State is the state of the entry.
+	State InventoryEntryState `json:"state"`
+	// ClusterNamespace is the namespace of the ClusterDeployment that consumed the entry.
+	// +optional
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// Message is a human-readable description of why the entry is broken.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the referenced resource when the entry was found to be broken. A broken
+	// entry is retried once the resource has been updated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -75,6 +413,22 @@ const (
 	// ClusterPoolMissingDependentsCondition is set when a cluster pool is missing dependencies required to create a
 	// cluster. Dependencies include resources such as the ClusterImageSet and the credentials Secret.
 	ClusterPoolMissingDependenciesCondition ClusterPoolConditionType = "MissingDependencies"
+	// ClusterPoolInventoryBrokenCondition is set when entries of the inventory of a cluster pool are broken.
+	ClusterPoolInventoryBrokenCondition ClusterPoolConditionType = "InventoryBroken"
+	// ClusterPoolCapacityConstrainedCondition is set when a cluster pool is waiting for capacity in its capacity pool
+	// to provision the clusters it needs.
+	ClusterPoolCapacityConstrainedCondition ClusterPoolConditionType = "CapacityConstrained"
+	// ClusterPoolPausedCondition is set when a cluster pool is paused and does not create new clusters.
+	ClusterPoolPausedCondition ClusterPoolConditionType = "Paused"
+	// ClusterPoolDrainingCondition is set when a cluster pool is draining its clusters and does not assign clusters to
+	// claims.
+	ClusterPoolDrainingCondition ClusterPoolConditionType = "Draining"
+	// ClusterPoolAllClustersCurrentCondition is set when all of the unclaimed clusters of a cluster pool were created
+	// from the current version of its spec.
+	ClusterPoolAllClustersCurrentCondition ClusterPoolConditionType = "AllClustersCurrent"
+	// ClusterPoolProvisioningStalledCondition is set when a cluster pool cannot provision the clusters it needs, or
+	// holds clusters that failed to provision in their place.
+	ClusterPoolProvisioningStalledCondition ClusterPoolConditionType = "ProvisioningStalled"
 )
 
 // +genclient
@@ -87,6 +441,7 @@ const (
 // +kubebuilder:subresource:scale:specpath=.spec.size,statuspath=.status.size
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready"
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
+// +kubebuilder:printcolumn:name="Quarantined",type="string",JSONPath=".status.quarantined"
 // +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
 // +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
 // +kubebuilder:resource:path=clusterpools,shortName=cp
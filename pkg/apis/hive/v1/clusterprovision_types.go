@@ -44,6 +44,20 @@ type ClusterProvisionSpec struct {
 
 	// PrevInfraID is the infra ID of the previous failed provision attempt.
 	PrevInfraID *string `json:"prevInfraID,omitempty"`
+
+	// DryRun indicates that the install job only renders the install-config and manifests for the cluster,
+	// without provisioning any cloud resources.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DryRunConfigMapRef references the ConfigMap containing the install-config and manifests rendered by a
+	// dry-run provision.
+	// +optional
+	DryRunConfigMapRef *corev1.LocalObjectReference `json:"dryRunConfigMapRef,omitempty"`
+
+	// Timeout is the maximum time the provision may run, measured from the creation of the provision.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -54,6 +68,16 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// ErrorClass is the class of the error that failed the provision, such as UserError, QuotaError or
+	// CapacityError. It is Unknown when the install log matched no known failure.
+	// +optional
+	ErrorClass string `json:"errorClass,omitempty"`
+
+	// LogURLs are the URLs of the provision logs that were uploaded to object storage, keyed by the name of the
+	// log file. Logs are only uploaded when object storage is configured in the HiveConfig.
+	// +optional
+	LogURLs map[string]string `json:"logURLs,omitempty"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -68,6 +92,9 @@ const (
 	ClusterProvisionStageComplete ClusterProvisionStage = "complete"
 	// ClusterProvisionStageFailed indicates that the cluster provision failed.
 	ClusterProvisionStageFailed ClusterProvisionStage = "failed"
+	// ClusterProvisionStageDryRunComplete indicates that a dry-run provision rendered the install-config and
+	// manifests successfully.
+	ClusterProvisionStageDryRunComplete ClusterProvisionStage = "dryRunComplete"
 )
 
 // ClusterProvisionCondition contains details for the current condition of a cluster provision
@@ -100,6 +127,9 @@ const (
 	// ClusterProvisionCompletedCondition is set when a cluster provision completes.
 	ClusterProvisionCompletedCondition ClusterProvisionConditionType = "ClusterProvisionCompleted"
 
+	// ClusterProvisionDryRunCompletedCondition is set when a dry-run provision completes.
+	ClusterProvisionDryRunCompletedCondition ClusterProvisionConditionType = "ClusterProvisionDryRunCompleted"
+
 	// ClusterProvisionFailedCondition is set when a cluster provision fails.
 	ClusterProvisionFailedCondition ClusterProvisionConditionType = "ClusterProvisionFailed"
 
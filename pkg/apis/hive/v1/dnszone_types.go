@@ -3,6 +3,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
 )
 
 const (
@@ -53,8 +55,13 @@ type AWSDNSZoneSpec struct {
 	// Region is the AWS region to use for route53 operations.
 	// This defaults to us-east-1.
 	// For AWS China, use cn-northwest-1.
+	// For AWS GovCloud, use us-gov-west-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints used for AWS services, such as route53.
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 }
 
 // AWSResourceTag represents a tag that is applied to an AWS cloud resource
@@ -172,6 +179,10 @@ const (
 	// AuthenticationFailureCondition is true when credentials cannot be used to create a
 	// DNS zone because they fail authentication
 	AuthenticationFailureCondition DNSZoneConditionType = "AuthenticationFailure"
+	// ZoneConflictCondition is true when the zone of the DNSZone is claimed by another DNSZone, either because an
+	// older DNSZone with a different owner has the same zone or because the ownership record of the hosted zone in
+	// the DNS provider names another DNSZone
+	ZoneConflictCondition DNSZoneConditionType = "ZoneConflict"
 )
 
 // +genclient
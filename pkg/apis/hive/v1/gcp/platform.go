@@ -13,4 +13,90 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// NetworkProjectID is the ID of the host project of a Shared VPC (XPN) holding Network. Set it to install the
+	// cluster into a Shared VPC network of another project. When omitted, Network is in the project of the cluster.
+	// +optional
+	NetworkProjectID string `json:"networkProjectID,omitempty"`
+
+	// Network is the name of an existing VPC network to install the cluster into. Hive does not delete the network
+	// or its subnets when the cluster is deprovisioned. When omitted, the installer creates a network for the
+	// cluster.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// ControlPlaneSubnet is the name of an existing subnet of Network for the control plane machines. Required
+	// with Network.
+	// +optional
+	ControlPlaneSubnet string `json:"controlPlaneSubnet,omitempty"`
+
+	// ComputeSubnet is the name of an existing subnet of Network for the compute machines. Required with Network.
+	// +optional
+	ComputeSubnet string `json:"computeSubnet,omitempty"`
+
+	// PrivateServiceConnect configures access to the cluster's API through GCP Private Service Connect. Use this
+	// for clusters that are installed without a public API endpoint.
+	// +optional
+	PrivateServiceConnect *PrivateServiceConnectAccess `json:"privateServiceConnect,omitempty"`
+
+	// ClusterOSImage is the image to boot the machines of the cluster from, instead of the RHCOS image of the
+	// release image. Use this for disconnected environments where the RHCOS image has been copied in advance. It is
+	// either the URL of the image or the name of an image in the project of the cluster.
+	// +optional
+	ClusterOSImage string `json:"clusterOSImage,omitempty"`
+}
+
+// PrivateServiceConnectAccess configures access to the cluster's API through GCP Private Service Connect.
+type PrivateServiceConnectAccess struct {
+	// Enabled, when true, makes Hive publish the internal API load balancer of the cluster with a service
+	// attachment, and create a Private Service Connect endpoint for that service attachment in one of the subnets
+	// configured in the gcpPrivateServiceConnect section of HiveConfig. Hive then reaches the cluster's API through
+	// the endpoint.
+	Enabled bool `json:"enabled"`
+
+	// ServiceAttachmentSubnetCIDR is the CIDR of the subnet that Hive creates in the network of the cluster for the
+	// service attachment. Connections from the endpoint reach the cluster from addresses in this subnet. It must
+	// not overlap with the other subnets of the network of the cluster. A /29 is sufficient.
+	// +optional
+	ServiceAttachmentSubnetCIDR string `json:"serviceAttachmentSubnetCIDR,omitempty"`
+}
+
+// PlatformStatus contains the observed state on GCP platform.
+type PlatformStatus struct {
+	// PrivateServiceConnect contains the GCP resources created by Hive for accessing the cluster through GCP
+	// Private Service Connect.
+	// +optional
+	PrivateServiceConnect *PrivateServiceConnectAccessStatus `json:"privateServiceConnect,omitempty"`
+}
+
+// PrivateServiceConnectAccessStatus contains the GCP resources created by Hive for accessing the cluster through
+// GCP Private Service Connect.
+type PrivateServiceConnectAccessStatus struct {
+	// ServiceAttachmentSubnet is the name of the subnet created in the project of the cluster for the service
+	// attachment.
+	// +optional
+	ServiceAttachmentSubnet string `json:"serviceAttachmentSubnet,omitempty"`
+
+	// ServiceAttachmentFirewall is the name of the firewall rule created in the project of the cluster that allows
+	// connections from the service attachment subnet to the API of the cluster.
+	// +optional
+	ServiceAttachmentFirewall string `json:"serviceAttachmentFirewall,omitempty"`
+
+	// ServiceAttachment is the name of the service attachment created in the project of the cluster for the
+	// internal API load balancer of the cluster.
+	// +optional
+	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+
+	// EndpointAddress is the name of the internal address reserved in the Hive project for the endpoint.
+	// +optional
+	EndpointAddress string `json:"endpointAddress,omitempty"`
+
+	// Endpoint is the name of the forwarding rule created in the Hive project for the endpoint.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// EndpointIP is the IP address of the endpoint. Hive connects to the cluster's API through this address once
+	// the endpoint has been accepted by the service attachment.
+	// +optional
+	EndpointIP string `json:"endpointIP,omitempty"`
 }
@@ -45,6 +45,11 @@ func (in *Metadata) DeepCopy() *Metadata {
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccess)
+		**out = **in
+	}
 	return
 }
 
@@ -57,3 +62,56 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccessStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccess) DeepCopyInto(out *PrivateServiceConnectAccess) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccess.
+func (in *PrivateServiceConnectAccess) DeepCopy() *PrivateServiceConnectAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccessStatus) DeepCopyInto(out *PrivateServiceConnectAccessStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccessStatus.
+func (in *PrivateServiceConnectAccessStatus) DeepCopy() *PrivateServiceConnectAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -55,6 +55,12 @@ type HiveConfigSpec struct {
 	// The default reapply interval is two hours.
 	SyncSetReapplyInterval string `json:"syncSetReapplyInterval,omitempty"`
 
+	// SyncSetDriftCheckInterval is a string duration indicating how often the resources of SyncSets and
+	// SelectorSyncSets with reapplyOnDrift set are checked for changes made in the clusters.
+	// The default drift check interval is ten minutes.
+	// +optional
+	SyncSetDriftCheckInterval string `json:"syncSetDriftCheckInterval,omitempty"`
+
 	// MaintenanceMode can be set to true to disable the hive controllers in situations where we need to ensure
 	// nothing is running that will add or act upon finalizers on Hive types. This should rarely be needed.
 	// Sets replicas to 0 for the hive-controllers deployment to accomplish this.
@@ -63,14 +69,50 @@ type HiveConfigSpec struct {
 	// DeprovisionsDisabled can be set to true to block deprovision jobs from running.
 	DeprovisionsDisabled *bool `json:"deprovisionsDisabled,omitempty"`
 
+	// ExternalDestroyers are destroyers that deprovision jobs run in place of the destroyers built into Hive. They
+	// allow clusters on platforms that Hive cannot deprovision itself to be deprovisioned without rebuilding Hive.
+	// If several external destroyers are configured for a platform, the first one is used.
+	// +optional
+	ExternalDestroyers []ExternalDestroyer `json:"externalDestroyers,omitempty"`
+
 	// DeleteProtection can be set to "enabled" to turn on automatic delete protection for ClusterDeployments. When
 	// enabled, Hive will add the "hive.openshift.io/protected-delete" annotation to new ClusterDeployments. Once a
 	// ClusterDeployment has been installed, a user must remove the annotation from a ClusterDeployment prior to
-	// deleting it.
+	// deleting it. In addition, hiveadmission will reject the deletion of any ClusterDeployment that has not been
+	// explicitly annotated with "hive.openshift.io/delete-protection=disabled". Deletes made by Hive itself, and
+	// deletes of unclaimed ClusterPool clusters, are exempt from this check, but not from the protected-delete
+	// annotation.
 	// +kubebuilder:validation:Enum=enabled
 	// +optional
 	DeleteProtection DeleteProtectionType `json:"deleteProtection,omitempty"`
 
+	// NamespacePerCluster can be set to "enabled" to have every ClusterDeployment live in a namespace of its own,
+	// which Hive owns. When enabled, hiveadmission will reject new ClusterDeployments whose name is not the name of
+	// their namespace, and Hive will label the namespace of each ClusterDeployment with
+	// "hive.openshift.io/cluster-namespace" if the namespace was created after the mode was enabled. A labelled
+	// namespace, along with the secrets and other resources in it, is deleted once its ClusterDeployment has been
+	// deprovisioned and removed. Hive does not create the namespaces of ClusterDeployments that are not from a
+	// ClusterPool, nor move secrets into them.
+	// +kubebuilder:validation:Enum=enabled
+	// +optional
+	NamespacePerCluster NamespacePerClusterType `json:"namespacePerCluster,omitempty"`
+
+	// SyncSetDryRun can be set to "enabled" to have hiveadmission dry-run the resources of a SyncSet or
+	// SelectorSyncSet in one of the installed clusters that it applies to when the resources are created or changed.
+	// Resources that the cluster rejects as invalid are rejected by hiveadmission, instead of failing to apply to every
+	// cluster later. Templated syncsets are not dry-run. When enabled, hiveadmission is allowed to read
+	// ClusterDeployments and the admin kubeconfig secrets of the clusters.
+	// +kubebuilder:validation:Enum=enabled
+	// +optional
+	SyncSetDryRun SyncSetDryRunType `json:"syncSetDryRun,omitempty"`
+
+	// SyncSetAgent enables the syncset agent, which pulls the SyncSets and SelectorSyncSets of a cluster from Hive
+	// instead of Hive pushing them to the cluster. Clusters opt in with the hive.openshift.io/syncset-agent
+	// annotation on their ClusterDeployment. This is meant for clusters that can reach Hive, but that Hive cannot
+	// reach.
+	// +optional
+	SyncSetAgent *SyncSetAgentConfig `json:"syncSetAgent,omitempty"`
+
 	// DisabledControllers allows selectively disabling Hive controllers by name.
 	// The name of an individual controller matches the name of the controller as seen in the Hive logging output.
 	DisabledControllers []string `json:"disabledControllers,omitempty"`
@@ -78,6 +120,243 @@ type HiveConfigSpec struct {
 	// ControllersConfig is used to configure different hive controllers
 	// +optional
 	ControllersConfig *ControllersConfig `json:"controllersConfig,omitempty"`
+
+	// ComponentImages allows overriding the image used for individual Hive components. Components without
+	// an override use the same image as the hive-operator.
+	// +optional
+	ComponentImages *ComponentImagesConfig `json:"componentImages,omitempty"`
+
+	// Scheduling allows controlling which nodes the Hive components and the jobs launched by Hive are
+	// scheduled onto.
+	// +optional
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// PullThroughCache configures the install and imageset jobs to pull release and installer images through a
+	// pull-through cache registry rather than directly from the source registry.
+	// +optional
+	PullThroughCache *PullThroughCacheConfig `json:"pullThroughCache,omitempty"`
+
+	// AdmissionPolicy configures an external policy service which hiveadmission consults when ClusterDeployments
+	// and ClusterPools are created. This allows enforcing custom rules, such as allowed regions or instance types,
+	// in addition to the validation built into hiveadmission.
+	// +optional
+	AdmissionPolicy *AdmissionPolicyConfig `json:"admissionPolicy,omitempty"`
+
+	// ProvisionHooks are external services which install pods call before and after provisioning a cluster. They
+	// allow site-specific steps, such as allocating IP addresses or filing firewall tickets, to be integrated into
+	// provisioning. Hooks of the same stage are called in the order listed.
+	// +optional
+	ProvisionHooks []ProvisionHook `json:"provisionHooks,omitempty"`
+
+	// AWSPrivateLink configures the resources used to reach ClusterDeployments that have AWS PrivateLink enabled.
+	// +optional
+	AWSPrivateLink *AWSPrivateLinkConfig `json:"awsPrivateLink,omitempty"`
+
+	// GCPPrivateServiceConnect configures the resources used to reach ClusterDeployments that have GCP Private
+	// Service Connect enabled.
+	// +optional
+	GCPPrivateServiceConnect *GCPPrivateServiceConnectConfig `json:"gcpPrivateServiceConnect,omitempty"`
+
+	// AzurePrivateLink configures the resources used to reach ClusterDeployments that have Azure Private Link
+	// enabled.
+	// +optional
+	AzurePrivateLink *AzurePrivateLinkConfig `json:"azurePrivateLink,omitempty"`
+
+	// PriorityClasses has the operator create PriorityClasses for the Hive workloads, so that under node pressure
+	// less important pods are evicted before the Hive control loops and the installs in flight. The hive-critical
+	// PriorityClass is assigned to the hive-controllers and hiveadmission pods, and the hive-provision
+	// PriorityClass to the install pods. When omitted, no PriorityClasses are created or assigned.
+	// +optional
+	PriorityClasses *PriorityClassesConfig `json:"priorityClasses,omitempty"`
+
+	// Autoscaling has the operator size the Hive controllers for the number of ClusterDeployments and SyncSets on
+	// the hub, within the given bounds. The sizes are recomputed every few minutes. When omitted, the Hive
+	// controllers keep their default resource requests and ClusterSyncs are reconciled by a single
+	// hive-clustersync replica.
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// ClusterSyncSharding controls how the ClusterDeployments are spread across the hive-clustersync replicas.
+	// ClusterDeployments can also be pinned to a replica with the hive.openshift.io/clustersync-shard annotation.
+	// +optional
+	ClusterSyncSharding *ClusterSyncShardingConfig `json:"clusterSyncSharding,omitempty"`
+
+	// ClusterDefaults are applied by hiveadmission to the ClusterDeployments and MachinePools created without the
+	// given fields, so that they can be left out of self-service specs.
+	// +optional
+	ClusterDefaults *ClusterDefaultsConfig `json:"clusterDefaults,omitempty"`
+
+	// MachinePoolReplicaLimits caps the total replicas of the MachinePools of each tenant, so that one tenant cannot
+	// scale its clusters beyond its share of a shared cloud account. hiveadmission rejects MachinePools that would
+	// take their tenant over its limit, and Hive stops scaling up the MachinePools of a tenant that is over its
+	// limit.
+	// +optional
+	MachinePoolReplicaLimits *MachinePoolReplicaLimitsConfig `json:"machinePoolReplicaLimits,omitempty"`
+
+	// ClusterPoolCapacityPools are the capacity pools that ClusterPools may reference, such as one per cloud account
+	// shared by several pools. The clusters of the pools of a capacity pool are provisioned in the order of the
+	// priorities of the pools, within the limits of the capacity pool.
+	// +optional
+	ClusterPoolCapacityPools []ClusterPoolCapacityPool `json:"clusterPoolCapacityPools,omitempty"`
+}
+
+// ClusterPoolCapacityPool is a limit shared by the ClusterPools referencing it, or using the cloud credentials of its
+// account.
+type ClusterPoolCapacityPool struct {
+	// Name is the name of the capacity pool, referenced by the capacityPoolRef of ClusterPools.
+	Name string `json:"name"`
+
+	// MaxConcurrentProvisions is the most clusters of the pools of the capacity pool that may be provisioning at the
+	// same time.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentProvisions int32 `json:"maxConcurrentProvisions"`
+
+	// MaxClusters is the most clusters, claimed or not, that the pools of the capacity pool may have at the same
+	// time, such as to stay within the VPC or elastic IP quota of a cloud account. The number of clusters is not
+	// limited when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+
+	// CredentialsSecretRefs are the cloud credentials secrets of the account of the capacity pool. The ClusterPools
+	// using one of them for their platform, or for the platform of one of their flavors, are part of the capacity
+	// pool without referencing it.
+	// +optional
+	CredentialsSecretRefs []corev1.SecretReference `json:"credentialsSecretRefs,omitempty"`
+}
+
+// MachinePoolReplicaLimitsConfig contains the limits on the total replicas of the MachinePools of each tenant. The
+// replicas of an autoscaling MachinePool are counted as its maximum replicas.
+type MachinePoolReplicaLimitsConfig struct {
+	// TenantLabel is the label of the namespaces naming their tenant. The namespaces with the same value of the
+	// label belong to the same tenant. A namespace without the label is a tenant of its own, named after the
+	// namespace. When omitted, every namespace is a tenant of its own.
+	// +optional
+	TenantLabel string `json:"tenantLabel,omitempty"`
+
+	// DefaultMaxReplicas is the limit of the tenants that are not listed in Tenants. The replicas of these tenants
+	// are not limited when omitted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefaultMaxReplicas *int64 `json:"defaultMaxReplicas,omitempty"`
+
+	// Tenants are the limits of individual tenants.
+	// +optional
+	Tenants []TenantReplicaLimit `json:"tenants,omitempty"`
+}
+
+// TenantReplicaLimit is the limit on the total replicas of the MachinePools of a tenant.
+type TenantReplicaLimit struct {
+	// Name is the name of the tenant.
+	Name string `json:"name"`
+
+	// MaxReplicas is the most replicas in total of the MachinePools of the tenant.
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int64 `json:"maxReplicas"`
+}
+
+// ClusterDefaultsConfig contains the defaults of the fields of new ClusterDeployments and MachinePools.
+type ClusterDefaultsConfig struct {
+	// ImageSetRef is the ClusterImageSet used to provision the ClusterDeployments that specify neither an image set
+	// nor a release image.
+	// +optional
+	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
+
+	// MachinePoolReplicas is the number of replicas of the MachinePools that specify neither replicas nor
+	// autoscaling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MachinePoolReplicas *int64 `json:"machinePoolReplicas,omitempty"`
+
+	// Platforms are the defaults of each platform. Only the aws, azure, gcp and ibmcloud platforms are supported.
+	// +optional
+	Platforms []PlatformClusterDefaults `json:"platforms,omitempty"`
+}
+
+// PlatformClusterDefaults contains the defaults of the ClusterDeployments and MachinePools of a platform.
+type PlatformClusterDefaults struct {
+	// Platform is the name of the platform, such as aws.
+	Platform string `json:"platform"`
+
+	// Region is the region of the ClusterDeployments on the platform that do not specify one.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// InstanceType is the instance type of the MachinePools on the platform that do not specify one.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+}
+
+// AutoscalingConfig contains the bounds within which the operator sizes the Hive controllers.
+type AutoscalingConfig struct {
+	// Controllers bounds the resource requests of the hive-controllers pod, which grow with the number of
+	// ClusterDeployments.
+	// +optional
+	Controllers *ResourceRequestBounds `json:"controllers,omitempty"`
+
+	// ClusterSync bounds the replicas of the hive-clustersync StatefulSet and the resource requests of its pods.
+	// Replicas are added as the number of ClusterDeployments grows, and the resource requests of each replica
+	// grow with the ClusterDeployments and SyncSets that it syncs.
+	// +optional
+	ClusterSync *ClusterSyncAutoscaling `json:"clusterSync,omitempty"`
+}
+
+// ClusterSyncShardingConfig contains the settings of the sharding of the ClusterDeployments across the
+// hive-clustersync replicas.
+type ClusterSyncShardingConfig struct {
+	// RebalanceTrigger is an arbitrary value, such as a date, that is hashed with the namespace and name of each
+	// ClusterDeployment to pick the replica that syncs it. Changing the value reshuffles the ClusterDeployments that
+	// are not pinned to a replica, such as when some replicas are overloaded. The hive-clustersync pods are
+	// restarted when the value changes.
+	// +optional
+	RebalanceTrigger string `json:"rebalanceTrigger,omitempty"`
+}
+
+// ResourceRequestBounds contains the least and most resources requested by a scaled container.
+type ResourceRequestBounds struct {
+	// Min is the least resources requested. Defaults to the resources requested when not autoscaling.
+	// +optional
+	Min corev1.ResourceList `json:"min,omitempty"`
+
+	// Max is the most resources requested. Resource requests are not capped when omitted.
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+}
+
+// ClusterSyncAutoscaling contains the bounds of the hive-clustersync StatefulSet.
+type ClusterSyncAutoscaling struct {
+	ResourceRequestBounds `json:",inline"`
+
+	// MinReplicas is the least number of hive-clustersync replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the most hive-clustersync replicas. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// ClustersPerReplica is the number of ClusterDeployments synced by each hive-clustersync replica. Defaults to
+	// 500.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ClustersPerReplica *int32 `json:"clustersPerReplica,omitempty"`
+}
+
+// PriorityClassesConfig contains the values of the PriorityClasses created for the Hive workloads.
+type PriorityClassesConfig struct {
+	// CriticalValue is the value of the hive-critical PriorityClass. Defaults to 1000000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000000
+	// +optional
+	CriticalValue *int32 `json:"criticalValue,omitempty"`
+
+	// ProvisionValue is the value of the hive-provision PriorityClass. Defaults to 100000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000000
+	// +optional
+	ProvisionValue *int32 `json:"provisionValue,omitempty"`
 }
 
 // HiveConfigStatus defines the observed state of Hive
@@ -93,6 +372,61 @@ type HiveConfigStatus struct {
 	// ConfigApplied will be set by the hive operator to indicate whether or not the LastGenerationObserved
 	// was successfully reconciled.
 	ConfigApplied bool `json:"configApplied,omitempty"`
+
+	// DeployedWebhooks are the admission webhook configurations deployed by the hive operator. Webhook
+	// configurations deployed previously that are no longer in this list are deleted by the operator.
+	// +optional
+	DeployedWebhooks []DeployedWebhook `json:"deployedWebhooks,omitempty"`
+
+	// NamespacePerClusterEnabledTime is the time at which namespace-per-cluster was enabled. Only namespaces created
+	// after this time are owned by their ClusterDeployment. It is cleared when namespace-per-cluster is disabled.
+	// +optional
+	NamespacePerClusterEnabledTime *metav1.Time `json:"namespacePerClusterEnabledTime,omitempty"`
+}
+
+// DeployedWebhook is an admission webhook configuration deployed by the hive operator.
+type DeployedWebhook struct {
+	// Kind is the kind of the webhook configuration, ValidatingWebhookConfiguration or MutatingWebhookConfiguration.
+	Kind string `json:"kind"`
+
+	// Name is the name of the webhook configuration.
+	Name string `json:"name"`
+
+	// Asset is the path of the asset the webhook configuration was deployed from.
+	Asset string `json:"asset"`
+
+	// Checksum is an md5 hash of the asset the webhook configuration was deployed from.
+	Checksum string `json:"checksum"`
+}
+
+// ExternalDestroyer is a container image that destroys the cloud resources of the clusters on a platform.
+//
+// The destroyer container is run with the following environment variables set:
+//   CLUSTER_DEPROVISION: the spec of the ClusterDeprovision, as JSON
+//   PLATFORM: the platform of the cluster
+//   INFRA_ID: the infrastructure ID of the cluster
+//   CLUSTER_ID: the cluster ID of the cluster, if known
+//   CREDENTIALS_DIR: the directory holding the keys of the credentials secret of the ClusterDeprovision, if any
+// The container is run again until it exits successfully, so it must only do so once all resources of the cluster
+// have been destroyed.
+type ExternalDestroyer struct {
+	// Platform is the platform of the clusters destroyed, e.g. "aws" or "baremetal".
+	Platform string `json:"platform"`
+
+	// Image is the container image of the destroyer.
+	Image string `json:"image"`
+
+	// ImagePullPolicy is the pull policy of the image. Defaults to IfNotPresent.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Command is the entrypoint of the destroyer. The entrypoint of the image is used if not set.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
 }
 
 // BackupConfig contains settings for the Velero backup integration.
@@ -127,12 +461,333 @@ type FailedProvisionConfig struct {
 
 	// SkipGatherLogs disables functionality that attempts to gather full logs from the cluster if an installation
 	// fails for any reason. The logs will be stored in a persistent volume for up to 7 days.
-	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
-	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	SkipGatherLogs bool `json:"skipGatherLogs,omitempty"`
+
+	// AWS contains settings to upload provision logs to AWS S3 or an S3 compatible provider.
+	// When object storage is configured, the installer log of every provision attempt, and the log bundles
+	// gathered from failed attempts, are uploaded and the URLs of the objects are recorded in the status of
+	// the ClusterProvision. Only a single cloud provider may be configured at a time.
+	// +optional
+	AWS *FailedProvisionAWSConfig `json:"aws,omitempty"`
+
+	// GCP contains settings to upload provision logs to Google Cloud Storage.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+
+	// Azure contains settings to upload provision logs to Azure Blob Storage.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+}
+
+// ComponentImagesConfig contains image overrides for the individual Hive components.
+type ComponentImagesConfig struct {
+	// Controllers overrides the image used for the hive-controllers deployment. It is also the default
+	// image of the hive-clustersync statefulset, which runs the clustersync controller.
+	// +optional
+	Controllers *ImageOverride `json:"controllers,omitempty"`
+
+	// ClusterSync overrides the image used for the hive-clustersync statefulset. Fields not set fall back
+	// to the image and pull policy of the hive-controllers deployment.
+	// +optional
+	ClusterSync *ImageOverride `json:"clusterSync,omitempty"`
+
+	// Admission overrides the image used for the hiveadmission deployment.
+	// +optional
+	Admission *ImageOverride `json:"admission,omitempty"`
+
+	// Jobs overrides the image used for the install, deprovision and imageset jobs launched by the
+	// hive controllers.
+	// +optional
+	Jobs *ImageOverride `json:"jobs,omitempty"`
+}
+
+// ImageOverride specifies the image to use for a Hive component.
+type ImageOverride struct {
+	// Image is the image to use when none of the ArchitectureImages match the architecture of the
+	// nodes the component runs on. If empty, the hive-operator image is used.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy is the pull policy to use for the image. If empty, the pull policy of the
+	// hive-operator is used.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ArchitectureImages is a list of images to use for specific CPU architectures. The entry matching
+	// the architecture of the nodes the component runs on takes precedence over Image. These are the nodes
+	// of the hub cluster allowed by the scheduling configuration of the component, and the entry is only
+	// used when they all have the same architecture.
+	// +optional
+	ArchitectureImages []ArchitectureImage `json:"architectureImages,omitempty"`
+}
+
+// ArchitectureImage is the image to use for a specific CPU architecture.
+type ArchitectureImage struct {
+	// Architecture is the CPU architecture using GOARCH naming, e.g. amd64, arm64, ppc64le or s390x.
+	Architecture string `json:"architecture"`
+
+	// Image is the image to use on this architecture.
+	Image string `json:"image"`
+}
+
+// SchedulingConfig contains the scheduling settings for the individual Hive components.
+type SchedulingConfig struct {
+	// Controllers configures scheduling for the hive-controllers deployment.
+	// +optional
+	Controllers *PodSchedulingConfig `json:"controllers,omitempty"`
+
+	// Admission configures scheduling for the hiveadmission deployment.
+	// +optional
+	Admission *PodSchedulingConfig `json:"admission,omitempty"`
+
+	// Jobs configures scheduling for the install, deprovision and imageset jobs launched by the
+	// hive controllers.
+	// +optional
+	Jobs *PodSchedulingConfig `json:"jobs,omitempty"`
+}
+
+// PodSchedulingConfig contains settings controlling which nodes pods are scheduled onto.
+type PodSchedulingConfig struct {
+	// NodeSelector is added to the node selector of the pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the tolerations of the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Architectures restricts the pods to nodes with one of the listed CPU architectures, using GOARCH
+	// naming, e.g. amd64 or arm64. This is implemented as a required node affinity on the
+	// kubernetes.io/arch node label.
+	// +optional
+	Architectures []string `json:"architectures,omitempty"`
+}
+
+// PullThroughCacheConfig contains settings for pulling images for install and imageset jobs through a
+// pull-through cache registry.
+type PullThroughCacheConfig struct {
+	// Mirrors are the image repositories to pull through the cache. An image is rewritten using the mirror with
+	// the most specific matching source.
+	Mirrors []RegistryMirror `json:"mirrors"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace containing the credentials for the cache
+	// registry. The secret is expected to be of type kubernetes.io/dockerconfigjson. The credentials are copied
+	// into a separate pull secret for the install and imageset jobs of each ClusterDeployment. They are not added
+	// to the pull secret of the ClusterDeployment, so they do not reach the installed cluster.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// RegistryMirror maps an image repository to the repository of the pull-through cache that mirrors it.
+type RegistryMirror struct {
+	// Source is the registry or repository being mirrored, e.g. quay.io/openshift-release-dev.
+	Source string `json:"source"`
+
+	// Mirror is the registry or repository that replaces the source in image references, e.g.
+	// cache.example.com/quay.io/openshift-release-dev.
+	Mirror string `json:"mirror"`
+}
+
+// AWSPrivateLinkConfig contains the settings for reaching clusters through AWS PrivateLink.
+type AWSPrivateLinkConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace containing the AWS credentials for the
+	// account in which the VPC endpoints and private hosted zones are created. The Hive cluster must be able to
+	// reach the VPCs in EndpointVPCInventory.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// EndpointVPCInventory is the list of VPCs in which VPC endpoints can be created. The VPC endpoint for a
+	// cluster is created in the first VPC of the inventory in the region of the cluster.
+	EndpointVPCInventory []AWSPrivateLinkInventory `json:"endpointVPCInventory"`
+
+	// AssociatedVPCs are additional VPCs associated with every private hosted zone created for a cluster, so that
+	// the API domain of the cluster resolves to the VPC endpoint from these VPCs. Use this when Hive runs in a VPC
+	// other than the VPCs in EndpointVPCInventory.
+	// +optional
+	AssociatedVPCs []AWSPrivateLinkVPC `json:"associatedVPCs,omitempty"`
+}
+
+// AWSPrivateLinkInventory is a VPC in which VPC endpoints can be created.
+type AWSPrivateLinkInventory struct {
+	AWSPrivateLinkVPC `json:",inline"`
+
+	// Subnets are the subnets of the VPC in which the network interfaces of the VPC endpoints are created. Only
+	// the subnets in the availability zones of the cluster's load balancer are used.
+	Subnets []AWSPrivateLinkSubnet `json:"subnets"`
+}
+
+// AWSPrivateLinkVPC identifies a VPC.
+type AWSPrivateLinkVPC struct {
+	// VPCID is the ID of the VPC.
+	VPCID string `json:"vpcID"`
+	// Region is the AWS region of the VPC.
+	Region string `json:"region"`
+}
+
+// AWSPrivateLinkSubnet identifies a subnet.
+type AWSPrivateLinkSubnet struct {
+	// SubnetID is the ID of the subnet.
+	SubnetID string `json:"subnetID"`
+	// AvailabilityZone is the availability zone of the subnet.
+	AvailabilityZone string `json:"availabilityZone"`
+}
+
+// GCPPrivateServiceConnectConfig contains the settings for reaching clusters through GCP Private Service Connect.
+type GCPPrivateServiceConnectConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace containing the GCP credentials for the
+	// project in which the Private Service Connect endpoints are created. The Hive cluster must be able to reach
+	// the subnets in EndpointVPCInventory.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// EndpointVPCInventory is the list of networks in which endpoints can be created. The endpoint for a cluster is
+	// created in the first subnet of the inventory in the region of the cluster.
+	EndpointVPCInventory []GCPPrivateServiceConnectInventory `json:"endpointVPCInventory"`
+}
+
+// GCPPrivateServiceConnectInventory is a network in which Private Service Connect endpoints can be created.
+type GCPPrivateServiceConnectInventory struct {
+	// Network is the name of the network in the project of the credentials.
+	Network string `json:"network"`
+
+	// Subnets are the subnets of the network in which the addresses of the endpoints are reserved.
+	Subnets []GCPPrivateServiceConnectSubnet `json:"subnets"`
+}
+
+// GCPPrivateServiceConnectSubnet identifies a subnet.
+type GCPPrivateServiceConnectSubnet struct {
+	// Subnet is the name of the subnet.
+	Subnet string `json:"subnet"`
+	// Region is the GCP region of the subnet.
+	Region string `json:"region"`
+}
+
+// AzurePrivateLinkConfig contains the settings for reaching clusters through Azure Private Link.
+type AzurePrivateLinkConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace containing the Azure credentials for the
+	// subscription in which the private endpoints are created.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// ResourceGroupName is the resource group in which the private endpoints and private DNS zones are created. The
+	// virtual networks of EndpointVNetInventory must be in this resource group.
+	ResourceGroupName string `json:"resourceGroupName"`
+
+	// EndpointVNetInventory is the list of virtual networks in which private endpoints can be created. The private
+	// endpoint for a cluster is created in the first virtual network of the inventory in the region of the cluster.
+	// The Hive cluster must be able to reach the subnets of these virtual networks.
+	EndpointVNetInventory []AzurePrivateLinkInventory `json:"endpointVNetInventory"`
+}
+
+// AzurePrivateLinkInventory is a virtual network in which private endpoints can be created.
+type AzurePrivateLinkInventory struct {
+	// VirtualNetwork is the name of the virtual network.
+	VirtualNetwork string `json:"virtualNetwork"`
+
+	// Region is the Azure region of the virtual network.
+	Region string `json:"region"`
+
+	// Subnet is the name of the subnet of the virtual network in which the private endpoints are created.
+	Subnet string `json:"subnet"`
+}
+
+// AdmissionPolicyConfig contains the settings for the external admission policy service.
+type AdmissionPolicyConfig struct {
+	// URL is the URL of the policy service. The admission request is sent to the URL in an AdmissionReview using
+	// the same protocol as for a Kubernetes validating admission webhook. The policy service responds with an
+	// AdmissionReview whose response allows or denies the request.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to verify the serving certificate of the policy service. If not
+	// specified, the system trust roots are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// TimeoutSeconds is the timeout for calls to the policy service. Defaults to 10 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines how errors calling the policy service are handled. "Fail" rejects the request and
+	// "Ignore" allows it. Defaults to "Fail".
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	// +optional
+	FailurePolicy AdmissionPolicyFailurePolicyType `json:"failurePolicy,omitempty"`
 }
 
+// AdmissionPolicyFailurePolicyType specifies how errors calling the admission policy service are handled.
+type AdmissionPolicyFailurePolicyType string
+
+const (
+	// AdmissionPolicyFailurePolicyFail rejects the request when the policy service cannot be called.
+	AdmissionPolicyFailurePolicyFail AdmissionPolicyFailurePolicyType = "Fail"
+	// AdmissionPolicyFailurePolicyIgnore allows the request when the policy service cannot be called.
+	AdmissionPolicyFailurePolicyIgnore AdmissionPolicyFailurePolicyType = "Ignore"
+)
+
+// ProvisionHook is an external service called by install pods before or after provisioning a cluster.
+//
+// The hook is sent an HTTP POST with a JSON encoded ProvisionHookReview whose request holds the stage, the
+// ClusterDeployment and, for PreProvision hooks, the generated install-config without its pull secret. The hook
+// responds with a ProvisionHookReview whose response allows or denies the provision. A PreProvision hook may
+// return an install-config which replaces the generated one, and may deny the provision with a reason, which
+// fails the provision. The response of a PostProvision hook is only logged, as the cluster has been installed.
+type ProvisionHook struct {
+	// Name identifies the hook in logs and provision failure messages.
+	Name string `json:"name"`
+
+	// Stage is the point in provisioning at which the hook is called.
+	// +kubebuilder:validation:Enum=PreProvision;PostProvision
+	Stage ProvisionHookStage `json:"stage"`
+
+	// URL is the URL of the hook.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to verify the serving certificate of the hook. If not specified,
+	// the system trust roots are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// TimeoutSeconds is the timeout for calls to the hook. Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=600
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines how errors calling a PreProvision hook are handled. "Fail" fails the provision and
+	// "Ignore" continues it with the install-config unchanged. Defaults to "Fail". Errors calling a PostProvision
+	// hook are always ignored.
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	// +optional
+	FailurePolicy ProvisionHookFailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// ProvisionHookStage is the point in provisioning at which a provision hook is called.
+type ProvisionHookStage string
+
+const (
+	// ProvisionHookStagePreProvision hooks are called once the install-config has been generated and before the
+	// installer is run.
+	ProvisionHookStagePreProvision ProvisionHookStage = "PreProvision"
+	// ProvisionHookStagePostProvision hooks are called once the cluster has been installed successfully.
+	ProvisionHookStagePostProvision ProvisionHookStage = "PostProvision"
+)
+
+// ProvisionHookFailurePolicyType specifies how errors calling a provision hook are handled.
+type ProvisionHookFailurePolicyType string
+
+const (
+	// ProvisionHookFailurePolicyFail fails the provision when the hook cannot be called.
+	ProvisionHookFailurePolicyFail ProvisionHookFailurePolicyType = "Fail"
+	// ProvisionHookFailurePolicyIgnore continues the provision when the hook cannot be called.
+	ProvisionHookFailurePolicyIgnore ProvisionHookFailurePolicyType = "Ignore"
+)
+
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
-// details for accessing/managing the domain.
+// details for accessing/managing the domain. Each ManageDNSConfig uses its own
+// credentials, so domains may be managed across multiple cloud accounts and
+// cloud providers. When a domain falls under domains from more than one
+// ManageDNSConfig, the one with the most specific matching domain is used. The
+// DNSZone of a cluster on another cloud provider than the ManageDNSConfig is
+// created with the cloud provider and credentials of the ManageDNSConfig.
 type ManageDNSConfig struct {
 
 	// Domains is the list of domains that hive will be managing entries for with the provided credentials.
@@ -179,6 +834,32 @@ type FailedProvisionAWSConfig struct {
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Google Cloud Storage. It will need permission to create objects in the bucket.
+	// Secret should have a key named 'osServiceAccount.json'.
+	// The credentials must specify the project to use.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the Google Cloud Storage bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Blob Storage.
+	// Secret should have a key named 'accountKey' that contains an access key of the storage account.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the Azure storage account to store the logs in.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the blob container of the storage account to store the logs in.
+	Container string `json:"container"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -211,6 +892,32 @@ const (
 	DeleteProtectionEnabled DeleteProtectionType = "enabled"
 )
 
+type NamespacePerClusterType string
+
+const (
+	NamespacePerClusterEnabled NamespacePerClusterType = "enabled"
+)
+
+type SyncSetDryRunType string
+
+const (
+	SyncSetDryRunEnabled SyncSetDryRunType = "enabled"
+)
+
+// SyncSetAgentConfig contains the configuration of the syncset agent.
+type SyncSetAgentConfig struct {
+	// HubURL is the https URL at which the syncset agents on the clusters reach the hive-syncset-server Service in
+	// the Hive namespace, such as the URL of a re-encrypt Route to the Service. The Service serves TLS with a
+	// serving certificate issued by the service CA.
+	// +kubebuilder:validation:Pattern=`^https://`
+	HubURL string `json:"hubURL"`
+
+	// PollInterval is a string duration indicating how often the syncset agents pull and apply the syncsets of
+	// their cluster. The default poll interval is one minute.
+	// +optional
+	PollInterval string `json:"pollInterval,omitempty"`
+}
+
 // ManageDNSAzureConfig contains Azure-specific info to manage a given domain
 type ManageDNSAzureConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -243,7 +950,7 @@ type ControllerConfig struct {
 	QueueBurst *int32 `json:"queueBurst,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;secretinventory;clusterready;adoptclusterrequest;awsprivatelink;gcpprivateserviceconnect;azureprivatelink;sshkeyrotation;postinstalljob;syncsetagent
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -252,26 +959,35 @@ func (controllerName ControllerName) String() string {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	RemoteMachinesetControllerName     ControllerName = "remotemachineset"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
+	ClusterClaimControllerName             ControllerName = "clusterclaim"
+	ClusterDeploymentControllerName        ControllerName = "clusterDeployment"
+	ClusterDeprovisionControllerName       ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName              ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName     ControllerName = "clusterpoolnamespace"
+	ClusterProvisionControllerName         ControllerName = "clusterProvision"
+	ClusterRelocateControllerName          ControllerName = "clusterRelocate"
+	ClusterStateControllerName             ControllerName = "clusterState"
+	ClusterVersionControllerName           ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName        ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName              ControllerName = "dnsendpoint"
+	DNSZoneControllerName                  ControllerName = "dnszone"
+	HibernationControllerName              ControllerName = "hibernation"
+	RemoteIngressControllerName            ControllerName = "remoteingress"
+	RemoteMachinesetControllerName         ControllerName = "remotemachineset"
+	SyncIdentityProviderControllerName     ControllerName = "syncidentityprovider"
+	UnreachableControllerName              ControllerName = "unreachable"
+	VeleroBackupControllerName             ControllerName = "velerobackup"
+	MetricsControllerName                  ControllerName = "metrics"
+	ClustersyncControllerName              ControllerName = "clustersync"
+	SecretInventoryControllerName          ControllerName = "secretinventory"
+	ClusterReadyControllerName             ControllerName = "clusterready"
+	AdoptClusterRequestControllerName      ControllerName = "adoptclusterrequest"
+	AWSPrivateLinkControllerName           ControllerName = "awsprivatelink"
+	GCPPrivateServiceConnectControllerName ControllerName = "gcpprivateserviceconnect"
+	AzurePrivateLinkControllerName         ControllerName = "azureprivatelink"
+	SSHKeyRotationControllerName           ControllerName = "sshkeyrotation"
+	PostInstallJobControllerName           ControllerName = "postinstalljob"
+	SyncSetAgentControllerName             ControllerName = "syncsetagent"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
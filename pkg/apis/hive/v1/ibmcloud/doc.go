@@ -0,0 +1,4 @@
+// Package ibmcloud contains API Schema definitions for IBM Cloud clusters.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/pkg/apis/hive
+package ibmcloud
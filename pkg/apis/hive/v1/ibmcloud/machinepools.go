@@ -0,0 +1,27 @@
+package ibmcloud
+
+// MachinePool stores the configuration for a machine pool installed on IBM Cloud.
+type MachinePool struct {
+	// InstanceType is the VSI machine profile.
+	// eg. bx2-4x16
+	InstanceType string `json:"type"`
+
+	// Zones is the list of availability zones used for machines in the pool.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// Set sets the values from `required` to `a`.
+func (a *MachinePool) Set(required *MachinePool) {
+	if required == nil || a == nil {
+		return
+	}
+
+	if required.InstanceType != "" {
+		a.InstanceType = required.InstanceType
+	}
+
+	if len(required.Zones) > 0 {
+		a.Zones = required.Zones
+	}
+}
@@ -0,0 +1,26 @@
+package ibmcloud
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores all the global configuration that all machinesets
+// use.
+type Platform struct {
+	// CredentialsSecretRef refers to a secret that contains the IBM Cloud API key
+	// in the ibmcloud_api_key field.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region specifies the IBM Cloud region where the cluster will be created.
+	Region string `json:"region"`
+
+	// ResourceGroupName is the name of the resource group that holds the resources of the cluster. The installer
+	// creates a resource group named after the infra ID of the cluster when not set.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// CISInstanceCRN is the CRN of the IBM Cloud Internet Services instance managing the DNS zone of the base
+	// domain of the cluster.
+	// +optional
+	CISInstanceCRN string `json:"cisInstanceCRN,omitempty"`
+}
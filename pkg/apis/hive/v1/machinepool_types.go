@@ -7,6 +7,8 @@ import (
 	"github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	"github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	"github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	"github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	"github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
@@ -74,6 +76,10 @@ type MachinePoolPlatform struct {
 	Azure *azure.MachinePool `json:"azure,omitempty"`
 	// GCP is the configuration used when installing on GCP.
 	GCP *gcp.MachinePool `json:"gcp,omitempty"`
+	// IBMCloud is the configuration used when installing on IBM Cloud.
+	IBMCloud *ibmcloud.MachinePool `json:"ibmcloud,omitempty"`
+	// Nutanix is the configuration used when installing on Nutanix.
+	Nutanix *nutanix.MachinePool `json:"nutanix,omitempty"`
 	// OpenStack is the configuration used when installing on OpenStack.
 	OpenStack *openstack.MachinePool `json:"openstack,omitempty"`
 	// VSphere is the configuration used when installing on vSphere
@@ -149,6 +155,10 @@ const (
 	// UnsupportedConfigurationMachinePoolCondition is true when the configuration of the MachinePool is unsupported
 	// by the cluster.
 	UnsupportedConfigurationMachinePoolCondition MachinePoolConditionType = "UnsupportedConfiguration"
+
+	// ReplicaLimitExceededMachinePoolCondition is true when the MachinePools of the tenant of the MachinePool have more
+	// replicas in total than the limit of the tenant. The MachinePool is not scaled up while this is true.
+	ReplicaLimitExceededMachinePoolCondition MachinePoolConditionType = "ReplicaLimitExceeded"
 )
 
 // +genclient
@@ -0,0 +1,4 @@
+// Package nutanix contains API Schema definitions for Nutanix clusters.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/pkg/apis/hive
+package nutanix
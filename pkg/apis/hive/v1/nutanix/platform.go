@@ -0,0 +1,41 @@
+package nutanix
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores any global configuration used for Nutanix platforms.
+type Platform struct {
+	// PrismCentral is the endpoint of the Prism Central managing the Prism Element cluster.
+	PrismCentral PrismEndpoint `json:"prismCentral"`
+
+	// PrismElementUUID is the UUID of the Prism Element cluster that the virtual machines will be created on.
+	PrismElementUUID string `json:"prismElementUUID"`
+
+	// CredentialsSecretRef refers to a secret that contains the Prism Central account access
+	// credentials: username, password fields.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CertificatesSecretRef refers to a secret that contains the CA certificates necessary for
+	// communicating with Prism Central.
+	// +optional
+	CertificatesSecretRef *corev1.LocalObjectReference `json:"certificatesSecretRef,omitempty"`
+
+	// SubnetUUIDs are the UUIDs of the subnets the virtual machines will be attached to.
+	SubnetUUIDs []string `json:"subnetUUIDs"`
+
+	// ImageUUID is the UUID of the RHCOS image to create the virtual machines from. The installer uploads the
+	// image of the release when not set.
+	// +optional
+	ImageUUID string `json:"imageUUID,omitempty"`
+}
+
+// PrismEndpoint is the address of a Prism Central or Prism Element.
+type PrismEndpoint struct {
+	// Address is the domain name or IP address of the endpoint.
+	Address string `json:"address"`
+
+	// Port is the port of the endpoint.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
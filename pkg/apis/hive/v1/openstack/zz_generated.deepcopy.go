@@ -12,6 +12,21 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		*out = new(RootVolume)
 		**out = **in
 	}
+	if in.AdditionalNetworkIDs != nil {
+		in, out := &in.AdditionalNetworkIDs, &out.AdditionalNetworkIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalSecurityGroupIDs != nil {
+		in, out := &in.AdditionalSecurityGroupIDs, &out.AdditionalSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
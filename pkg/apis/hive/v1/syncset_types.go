@@ -21,9 +21,25 @@ const (
 	SyncResourceApplyMode SyncSetResourceApplyMode = "Sync"
 )
 
+// SyncSetResourceDeletionPolicy is a string representing what happens to a
+// resource of a SyncSet in the target cluster when it is removed from a SyncSet
+// in "Sync" resource apply mode.
+// +kubebuilder:validation:Enum="";Delete;Orphan
+type SyncSetResourceDeletionPolicy string
+
+const (
+	// DeleteResourceDeletionPolicy indicates that the resource is deleted from
+	// the target cluster. This is the default.
+	DeleteResourceDeletionPolicy SyncSetResourceDeletionPolicy = "Delete"
+
+	// OrphanResourceDeletionPolicy indicates that the resource is left in the
+	// target cluster, and is no longer managed by the SyncSet.
+	OrphanResourceDeletionPolicy SyncSetResourceDeletionPolicy = "Orphan"
+)
+
 // SyncSetApplyBehavior is a string representing the behavior to use when
 // aplying a syncset to target cluster.
-// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate
+// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate;ServerSideApply
 type SyncSetApplyBehavior string
 
 const (
@@ -42,6 +58,12 @@ const (
 	// is not added to the target resource with the "lastApplied" value. It allows
 	// for syncing larger resources, but loses the ability to sync map entry deletes.
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
+
+	// ServerSideApplySyncSetApplyBehavior results in resources getting applied to
+	// the target cluster using server-side apply with the "hive" field manager.
+	// Fields owned by other field managers in the target cluster are not
+	// overwritten; a resource that would change them fails to apply with a conflict.
+	ServerSideApplySyncSetApplyBehavior SyncSetApplyBehavior = "ServerSideApply"
 )
 
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
@@ -77,18 +99,37 @@ type SyncObjectPatch struct {
 	// Patch is the patch to apply.
 	Patch string `json:"patch"`
 
-	// PatchType indicates the PatchType as "strategic" (default), "json", or "merge".
+	// PatchType indicates the PatchType as "strategic" (default), "json", or "merge". A "json" patch is an
+	// RFC 6902 JSON patch, whose "test" operations make the patch fail, without changing the object, when the
+	// object does not have the expected values.
 	// +optional
 	PatchType string `json:"patchType,omitempty"`
 }
 
+// SyncObjectReference is a reference to an object in the target cluster
+type SyncObjectReference struct {
+	// APIVersion is the Group and Version of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the Kind of the object.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// Namespace is the Namespace of the object. It is empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // SecretReference is a reference to a secret by name and namespace
 type SecretReference struct {
 	// Name is the name of the secret
 	Name string `json:"name"`
 	// Namespace is the namespace where the secret lives. If not present for the source
 	// secret reference, it is assumed to be the same namespace as the syncset with the
-	// reference.
+	// reference. The source secret of a SyncSet can only be in another namespace if the
+	// user creating or updating the SyncSet can get the secret.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 }
@@ -101,6 +142,35 @@ type SecretMapping struct {
 
 	// TargetRef specifies the target name and namespace of the secret on the target cluster
 	TargetRef SecretReference `json:"targetRef"`
+
+	// DeletePolicy indicates what happens to the target secret with the "Sync" resource apply mode when it is removed
+	// from the syncset, or when the syncset is deleted or no longer applies to the cluster. "Delete" deletes it.
+	// "Orphan" leaves it in the target cluster. It defaults to the resourceDeletionPolicy of the syncset.
+	// +optional
+	DeletePolicy SyncSetResourceDeletionPolicy `json:"deletePolicy,omitempty"`
+}
+
+// SyncSetResourceReference is a reference to a ConfigMap or Secret on the management cluster holding resources to sync
+type SyncSetResourceReference struct {
+	// Kind is the kind of the object holding the resources, either "ConfigMap" or "Secret".
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+
+	// Name is the name of the object holding the resources.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the object holding the resources. It defaults to the namespace of the SyncSet, and
+	// is required for SelectorSyncSets. The object of a SyncSet can only be in another namespace if the user creating
+	// or updating the SyncSet can get the object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// DeletePolicy indicates what happens to the objects held in the referenced object with the "Sync" resource apply
+	// mode when they are removed from the syncset, or when the syncset is deleted or no longer applies to the cluster.
+	// "Delete" deletes them. "Orphan" leaves them in the target cluster. It defaults to the resourceDeletionPolicy of
+	// the syncset, and is overridden by the hive.openshift.io/syncset-deletion-policy annotation on an object.
+	// +optional
+	DeletePolicy SyncSetResourceDeletionPolicy `json:"deletePolicy,omitempty"`
 }
 
 // SyncConditionType is a valid value for SyncCondition.Type
@@ -208,12 +278,28 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Resources []runtime.RawExtension `json:"resources,omitempty"`
 
+	// ResourceRefs is the list of ConfigMaps and Secrets on the management cluster holding more objects to sync, so
+	// that large manifests are not embedded in the syncset. Each key of the data of the ConfigMaps and Secrets holds
+	// one or more YAML or JSON documents, and the keys are read in sorted order. The objects are read each time the
+	// syncset is applied, and are synced after the objects of Resources.
+	// +optional
+	ResourceRefs []SyncSetResourceReference `json:"resourceRefs,omitempty"`
+
 	// ResourceApplyMode indicates if the Resource apply mode is "Upsert" (default) or "Sync".
 	// ApplyMode "Upsert" indicates create and update.
 	// ApplyMode "Sync" indicates create, update and delete.
 	// +optional
 	ResourceApplyMode SyncSetResourceApplyMode `json:"resourceApplyMode,omitempty"`
 
+	// ResourceDeletionPolicy indicates what happens to the resources and secrets in the target cluster with the
+	// "Sync" resource apply mode when they are removed from the syncset, or when the syncset is deleted or no longer
+	// applies to the cluster. "Delete" (default) deletes them. "Orphan" leaves them in the target cluster. The policy
+	// of a single resource can be set with the hive.openshift.io/syncset-deletion-policy annotation on the resource,
+	// and the policy of the objects of a resource reference or secret mapping with its deletePolicy. The policy in
+	// effect when a resource is applied is the one used when it is removed.
+	// +optional
+	ResourceDeletionPolicy SyncSetResourceDeletionPolicy `json:"resourceDeletionPolicy,omitempty"`
+
 	// Patches is the list of patches to apply.
 	// +optional
 	Patches []SyncObjectPatch `json:"patches,omitempty"`
@@ -222,6 +308,12 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Secrets []SecretMapping `json:"secretMappings,omitempty"`
 
+	// ResourcesToDelete is the list of objects to delete from the target cluster. The objects are deleted each time
+	// the syncset is applied, after the resources, secrets and patches, so an object that is created again in the
+	// target cluster is deleted again. Objects that are not found in the target cluster are considered deleted.
+	// +optional
+	ResourcesToDelete []SyncObjectReference `json:"resourcesToDelete,omitempty"`
+
 	// ApplyBehavior indicates how resources in this syncset will be applied to the target
 	// cluster. The default value of "Apply" indicates that resources should be applied
 	// using the 'oc apply' command. If no value is set, "Apply" is assumed.
@@ -231,8 +323,35 @@ type SyncSetCommonSpec struct {
 	// the use of the 'oc apply' command, allowing larger resources to be synced, but losing
 	// some functionality of the 'oc apply' command such as the ability to remove annotations,
 	// labels, and other map entries in general.
+	// A value of "ServerSideApply" indicates that the resource will be applied using server-side
+	// apply with the "hive" field manager, failing with a conflict when the resource would change
+	// fields owned by other field managers in the target cluster.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// Templated indicates that the string values of the resources and the patches are Go templates, which are rendered
+	// for each cluster before they are applied. The templates can use the .ClusterName, .Namespace, .Labels, .Platform,
+	// .BaseDomain and .InfraID of the ClusterDeployment of the cluster.
+	// +optional
+	Templated bool `json:"templated,omitempty"`
+
+	// ReapplyInterval is how often the syncset is reapplied to each cluster, overriding the syncSetReapplyInterval of
+	// the HiveConfig for this syncset. It must be at least one minute.
+	// +optional
+	ReapplyInterval *metav1.Duration `json:"reapplyInterval,omitempty"`
+
+	// ReapplyOnDrift indicates that Hive checks the resources of the syncset in each cluster at the
+	// syncSetDriftCheckInterval of the HiveConfig, and reapplies the syncset when a resource was deleted or a field set
+	// by the syncset was changed in the cluster. Secrets and patches are not checked.
+	// +optional
+	ReapplyOnDrift bool `json:"reapplyOnDrift,omitempty"`
+
+	// ApplyWave orders the apply of the SyncSets and SelectorSyncSets of a cluster. Syncsets in a lower wave are
+	// applied before syncsets in a higher wave, and a wave is not applied until all of the syncsets in the lower waves
+	// have been applied successfully. Syncsets in the same wave are applied in the order of their names. The default
+	// is 0, and the wave may be negative.
+	// +optional
+	ApplyWave int32 `json:"applyWave,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -245,6 +364,36 @@ type SelectorSyncSetSpec struct {
 	// applies to in any namespace.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// ClusterDeploymentFieldSelector narrows the clusters matching the ClusterDeploymentSelector down to the clusters
+	// whose ClusterDeployment also matches the fields of this selector.
+	// +optional
+	ClusterDeploymentFieldSelector *ClusterDeploymentFieldSelector `json:"clusterDeploymentFieldSelector,omitempty"`
+}
+
+// ClusterDeploymentFieldSelector selects ClusterDeployments by their platform, region, version and power state. A
+// ClusterDeployment matches when it matches every field of the selector that is set.
+type ClusterDeploymentFieldSelector struct {
+	// Platforms is the list of platforms of the matching clusters, such as "aws" or "gcp", as in the
+	// hive.openshift.io/cluster-platform label of the ClusterDeployment.
+	// +optional
+	Platforms []string `json:"platforms,omitempty"`
+
+	// Regions is the list of regions of the matching clusters, as in the hive.openshift.io/cluster-region label of
+	// the ClusterDeployment.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// VersionRange is the range of versions of the matching clusters, such as ">=4.8.0 <4.10.0", as in the
+	// hive.openshift.io/version-major-minor-patch label of the ClusterDeployment. Clusters whose version is not known
+	// yet do not match.
+	// +optional
+	VersionRange string `json:"versionRange,omitempty"`
+
+	// PowerStates is the list of the power states of the matching clusters, as in spec.powerState of the
+	// ClusterDeployment. A ClusterDeployment without a power state is Running.
+	// +optional
+	PowerStates []ClusterPowerState `json:"powerStates,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
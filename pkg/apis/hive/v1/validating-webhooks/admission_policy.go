@@ -0,0 +1,155 @@
+package validatingwebhooks
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultAdmissionPolicyTimeout = 10 * time.Second
+	// maxAdmissionPolicyResponseSize limits how much of a response from the policy service is read.
+	maxAdmissionPolicyResponseSize = 1024 * 1024
+)
+
+// admissionPolicyReviewer consults the external admission policy service configured in HiveConfig. A nil
+// admissionPolicyReviewer allows every request.
+type admissionPolicyReviewer struct {
+	url      string
+	client   *http.Client
+	failOpen bool
+}
+
+// newAdmissionPolicyReviewerFromEnv returns a reviewer for the admission policy service configured in the
+// environment, or nil if no policy service is configured.
+func newAdmissionPolicyReviewerFromEnv(logger log.FieldLogger) *admissionPolicyReviewer {
+	value, ok := os.LookupEnv(constants.AdmissionPolicyEnvVar)
+	if !ok || value == "" {
+		return nil
+	}
+	config := &hivev1.AdmissionPolicyConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Fatalf("Unable to parse %s", constants.AdmissionPolicyEnvVar)
+	}
+	reviewer, err := newAdmissionPolicyReviewer(config)
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to configure admission policy")
+	}
+	logger.WithField("url", config.URL).Info("Admission policy enabled")
+	return reviewer
+}
+
+func newAdmissionPolicyReviewer(config *hivev1.AdmissionPolicyConfig) (*admissionPolicyReviewer, error) {
+	if config.URL == "" {
+		return nil, errors.New("no URL configured for the admission policy service")
+	}
+	timeout := defaultAdmissionPolicyTimeout
+	if config.TimeoutSeconds != nil {
+		timeout = time.Duration(*config.TimeoutSeconds) * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(config.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CABundle) {
+			return nil, errors.New("no certificates found in the CA bundle of the admission policy service")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &admissionPolicyReviewer{
+		url:      config.URL,
+		client:   &http.Client{Transport: transport, Timeout: timeout},
+		failOpen: config.FailurePolicy == hivev1.AdmissionPolicyFailurePolicyIgnore,
+	}, nil
+}
+
+// review sends the admission request to the policy service. It returns nil if the policy service allows the
+// request, and the response to return from the webhook otherwise.
+func (r *admissionPolicyReviewer) review(admissionSpec *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	if r == nil {
+		return nil
+	}
+	response, err := r.call(admissionSpec)
+	if err != nil {
+		if r.failOpen {
+			logger.WithError(err).Warn("Error calling admission policy service, ignoring")
+			return nil
+		}
+		logger.WithError(err).Error("Error calling admission policy service")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: fmt.Sprintf("admission policy could not be evaluated: %v", err),
+			},
+		}
+	}
+	if response.Allowed {
+		logger.Debug("Request allowed by admission policy")
+		return nil
+	}
+
+	message := "denied by admission policy"
+	if response.Result != nil && response.Result.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, response.Result.Message)
+	}
+	logger.WithField("message", message).Info("Request denied by admission policy")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusForbidden, Reason: metav1.StatusReasonForbidden,
+			Message: message,
+		},
+	}
+}
+
+func (r *admissionPolicyReviewer) call(admissionSpec *admissionv1beta1.AdmissionRequest) (*admissionv1beta1.AdmissionResponse, error) {
+	body, err := json.Marshal(&admissionv1beta1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionv1beta1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		},
+		Request: admissionSpec,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode admission review")
+	}
+	resp, err := r.client.Post(r.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxAdmissionPolicyResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response: %s", resp.Status)
+	}
+	review := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(respBody, review); err != nil {
+		return nil, errors.Wrap(err, "could not decode admission review")
+	}
+	if review.Response == nil {
+		return nil, errors.New("admission review has no response")
+	}
+	if review.Response.UID != admissionSpec.UID {
+		return nil, errors.Errorf("admission review response UID %q does not match request UID %q", review.Response.UID, admissionSpec.UID)
+	}
+	return review.Response, nil
+}
@@ -0,0 +1,143 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestAdmissionPolicyReview(t *testing.T) {
+	const uid = types.UID("test-uid")
+	tests := []struct {
+		name            string
+		failurePolicy   hivev1.AdmissionPolicyFailurePolicyType
+		statusCode      int
+		response        *admissionv1beta1.AdmissionResponse
+		rawResponse     string
+		expectAllowed   bool
+		expectedCode    int32
+		expectedMessage string
+	}{
+		{
+			name:          "allowed",
+			statusCode:    http.StatusOK,
+			response:      &admissionv1beta1.AdmissionResponse{UID: uid, Allowed: true},
+			expectAllowed: true,
+		},
+		{
+			name:       "denied",
+			statusCode: http.StatusOK,
+			response: &admissionv1beta1.AdmissionResponse{
+				UID:     uid,
+				Allowed: false,
+				Result:  &metav1.Status{Message: "region us-west-1 is not allowed"},
+			},
+			expectedCode:    http.StatusForbidden,
+			expectedMessage: "denied by admission policy: region us-west-1 is not allowed",
+		},
+		{
+			name:            "denied without message",
+			statusCode:      http.StatusOK,
+			response:        &admissionv1beta1.AdmissionResponse{UID: uid, Allowed: false},
+			expectedCode:    http.StatusForbidden,
+			expectedMessage: "denied by admission policy",
+		},
+		{
+			name:            "error response",
+			statusCode:      http.StatusServiceUnavailable,
+			expectedCode:    http.StatusInternalServerError,
+			expectedMessage: "admission policy could not be evaluated: unexpected response: 503 Service Unavailable",
+		},
+		{
+			name:          "error response ignored",
+			failurePolicy: hivev1.AdmissionPolicyFailurePolicyIgnore,
+			statusCode:    http.StatusServiceUnavailable,
+			expectAllowed: true,
+		},
+		{
+			name:            "missing response",
+			statusCode:      http.StatusOK,
+			rawResponse:     `{"apiVersion": "admission.k8s.io/v1beta1", "kind": "AdmissionReview"}`,
+			expectedCode:    http.StatusInternalServerError,
+			expectedMessage: "admission policy could not be evaluated: admission review has no response",
+		},
+		{
+			name:            "mismatched UID",
+			statusCode:      http.StatusOK,
+			response:        &admissionv1beta1.AdmissionResponse{UID: "other-uid", Allowed: true},
+			expectedCode:    http.StatusInternalServerError,
+			expectedMessage: `admission policy could not be evaluated: admission review response UID "other-uid" does not match request UID "test-uid"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received *admissionv1beta1.AdmissionReview
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				received = &admissionv1beta1.AdmissionReview{}
+				json.Unmarshal(body, received)
+				w.WriteHeader(test.statusCode)
+				switch {
+				case test.rawResponse != "":
+					w.Write([]byte(test.rawResponse))
+				case test.response != nil:
+					json.NewEncoder(w).Encode(&admissionv1beta1.AdmissionReview{Response: test.response})
+				}
+			}))
+			defer server.Close()
+
+			reviewer, err := newAdmissionPolicyReviewer(&hivev1.AdmissionPolicyConfig{
+				URL:           server.URL,
+				FailurePolicy: test.failurePolicy,
+			})
+			require.NoError(t, err, "unexpected error creating reviewer")
+
+			request := &admissionv1beta1.AdmissionRequest{
+				UID:       uid,
+				Operation: admissionv1beta1.Create,
+				Resource:  metav1.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "clusterdeployments"},
+				Object:    runtime.RawExtension{Raw: []byte(`{"spec":{"platform":{"aws":{"region":"us-west-1"}}}}`)},
+			}
+			response := reviewer.review(request, log.WithField("test", test.name))
+
+			if assert.NotNil(t, received, "expected request to policy service") {
+				assert.Equal(t, request, received.Request, "unexpected request sent to policy service")
+			}
+			if test.expectAllowed {
+				assert.Nil(t, response, "expected request to be allowed")
+				return
+			}
+			if assert.NotNil(t, response, "expected request to be denied") {
+				assert.False(t, response.Allowed, "expected request to be denied")
+				assert.Equal(t, test.expectedCode, response.Result.Code, "unexpected code")
+				assert.Equal(t, test.expectedMessage, response.Result.Message, "unexpected message")
+			}
+		})
+	}
+}
+
+func TestAdmissionPolicyReviewNotConfigured(t *testing.T) {
+	var reviewer *admissionPolicyReviewer
+	assert.Nil(t, reviewer.review(&admissionv1beta1.AdmissionRequest{}, log.StandardLogger()), "expected request to be allowed")
+}
+
+func TestNewAdmissionPolicyReviewer(t *testing.T) {
+	_, err := newAdmissionPolicyReviewer(&hivev1.AdmissionPolicyConfig{})
+	assert.Error(t, err, "expected error without URL")
+
+	_, err = newAdmissionPolicyReviewer(&hivev1.AdmissionPolicyConfig{URL: "https://policy.example.com", CABundle: []byte("not a certificate")})
+	assert.Error(t, err, "expected error with invalid CA bundle")
+}
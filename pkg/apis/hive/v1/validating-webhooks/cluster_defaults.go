@@ -0,0 +1,38 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// clusterDefaultsFromEnv returns the cluster defaults configured in the environment, or nil if none are configured.
+func clusterDefaultsFromEnv(logger log.FieldLogger) *hivev1.ClusterDefaultsConfig {
+	value, ok := os.LookupEnv(constants.ClusterDefaultsEnvVar)
+	if !ok || value == "" {
+		return nil
+	}
+	config := &hivev1.ClusterDefaultsConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Fatalf("Unable to parse %s", constants.ClusterDefaultsEnvVar)
+	}
+	logger.Info("Cluster defaults enabled")
+	return config
+}
+
+// platformDefaults returns the defaults of the named platform, or nil if there are none.
+func platformDefaults(config *hivev1.ClusterDefaultsConfig, platform string) *hivev1.PlatformClusterDefaults {
+	if config == nil || platform == "" {
+		return nil
+	}
+	for i := range config.Platforms {
+		if config.Platforms[i].Platform == platform {
+			return &config.Platforms[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveclient "github.com/openshift/hive/pkg/client/clientset/versioned"
+)
+
+const (
+	clusterClaimGroup    = "hive.openshift.io"
+	clusterClaimVersion  = "v1"
+	clusterClaimResource = "clusterclaims"
+)
+
+// ClusterClaimValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterClaimValidatingAdmissionHook struct {
+	decoder    *admission.Decoder
+	hiveClient hiveclient.Interface
+}
+
+// NewClusterClaimValidatingAdmissionHook constructs a new ClusterClaimValidatingAdmissionHook
+func NewClusterClaimValidatingAdmissionHook(decoder *admission.Decoder) *ClusterClaimValidatingAdmissionHook {
+	return &ClusterClaimValidatingAdmissionHook{
+		decoder: decoder,
+	}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimvalidators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *ClusterClaimValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimvalidators",
+		},
+		"clusterclaimvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Initializing validation REST resource")
+	hiveClient, err := hiveclient.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create hive client")
+	}
+	a.hiveClient = hiveClient
+	return nil
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *ClusterClaimValidatingAdmissionHook) Validate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(request, logger) {
+		logger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	logger.Info("Validating request")
+
+	switch request.Operation {
+	case admissionv1beta1.Create:
+		return a.validateCreateRequest(request, logger)
+	case admissionv1beta1.Update:
+		return a.validateUpdateRequest(request, logger)
+	default:
+		logger.Info("Successful validation")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *ClusterClaimValidatingAdmissionHook) shouldValidate(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) bool {
+	logger = logger.WithField("method", "shouldValidate")
+
+	if request.Resource.Group != clusterClaimGroup {
+		logger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if request.Resource.Version != clusterClaimVersion {
+		logger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if request.Resource.Resource != clusterClaimResource {
+		logger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	logger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateCreateRequest specifically validates create operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateCreateRequest(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	logger = logger.WithField("method", "validateCreateRequest")
+
+	newObject, resp := a.decode(request.Object, logger.WithField("decode", "Object"))
+	if resp != nil {
+		return resp
+	}
+
+	logger = logger.
+		WithField("object.Name", newObject.Name).
+		WithField("object.Namespace", newObject.Namespace)
+
+	if resp := a.lifetimeResponse(request, newObject, logger); resp != nil {
+		return resp
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// validateUpdateRequest specifically validates update operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateUpdateRequest(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	logger = logger.WithField("method", "validateUpdateRequest")
+
+	newObject, resp := a.decode(request.Object, logger.WithField("decode", "Object"))
+	if resp != nil {
+		return resp
+	}
+
+	logger = logger.
+		WithField("object.Name", newObject.Name).
+		WithField("object.Namespace", newObject.Namespace)
+
+	oldObject, resp := a.decode(request.OldObject, logger.WithField("decode", "OldObject"))
+	if resp != nil {
+		return resp
+	}
+
+	// A claim that set its lifetime before the pool set a shorter maximum can still be updated, as long as the update
+	// does not lengthen its lifetime.
+	if !lifetimeLengthened(oldObject.Spec.Lifetime, newObject.Spec.Lifetime) {
+		logger.Info("Successful validation")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	if resp := a.lifetimeResponse(request, newObject, logger); resp != nil {
+		return resp
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// lifetimeResponse returns the response rejecting the request if the ClusterClaim sets a lifetime that is not
+// positive or is longer than the maximum claim lifetime of its pool, or nil if the lifetime is valid.
+func (a *ClusterClaimValidatingAdmissionHook) lifetimeResponse(request *admissionv1beta1.AdmissionRequest, claim *hivev1.ClusterClaim, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	allErrs, err := a.validateLifetime(claim, logger)
+	if err != nil {
+		logger.WithError(err).Error("failed to validate lifetime")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	if len(allErrs) > 0 {
+		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+	return nil
+}
+
+// validateLifetime validates that the lifetime of the ClusterClaim is positive and not longer than the maximum claim
+// lifetime of its pool. A claim of a pool that does not exist is not limited.
+func (a *ClusterClaimValidatingAdmissionHook) validateLifetime(claim *hivev1.ClusterClaim, logger log.FieldLogger) (field.ErrorList, error) {
+	lifetime := claim.Spec.Lifetime
+	if lifetime == nil {
+		return nil, nil
+	}
+	lifetimePath := field.NewPath("spec", "lifetime")
+	if lifetime.Duration <= 0 {
+		return field.ErrorList{field.Invalid(lifetimePath, lifetime.Duration.String(), "must be positive")}, nil
+	}
+	if claim.Spec.ClusterPoolName == "" {
+		return nil, nil
+	}
+	pool, err := a.hiveClient.HiveV1().ClusterPools(claim.Namespace).Get(context.TODO(), claim.Spec.ClusterPoolName, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		logger.WithField("pool", claim.Spec.ClusterPoolName).Debug("pool of claim does not exist")
+		return nil, nil
+	case err != nil:
+		return nil, pkgerrors.Wrap(err, "could not get pool of claim")
+	}
+	if pool.Spec.ClaimLifetime == nil || pool.Spec.ClaimLifetime.Maximum == nil {
+		return nil, nil
+	}
+	if maximum := pool.Spec.ClaimLifetime.Maximum.Duration; lifetime.Duration > maximum {
+		return field.ErrorList{field.Invalid(lifetimePath, lifetime.Duration.String(), fmt.Sprintf("must not be longer than the maximum claim lifetime of the pool, %s", maximum))}, nil
+	}
+	return nil, nil
+}
+
+// lifetimeLengthened returns true if the new lifetime of a ClusterClaim is set and longer than the old lifetime.
+func lifetimeLengthened(old, new *metav1.Duration) bool {
+	switch {
+	case new == nil:
+		return false
+	case old == nil:
+		return true
+	default:
+		return new.Duration > old.Duration
+	}
+}
+
+func (a *ClusterClaimValidatingAdmissionHook) decode(raw runtime.RawExtension, logger log.FieldLogger) (*hivev1.ClusterClaim, *admissionv1beta1.AdmissionResponse) {
+	obj := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(raw, obj); err != nil {
+		logger.WithError(err).Error("failed to decode")
+		return nil, &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+	return obj, nil
+}
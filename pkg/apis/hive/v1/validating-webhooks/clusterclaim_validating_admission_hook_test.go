@@ -0,0 +1,186 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivefake "github.com/openshift/hive/pkg/client/clientset/versioned/fake"
+)
+
+func Test_ClusterClaimAdmission_Validate_Kind(t *testing.T) {
+	cases := []struct {
+		name          string
+		group         string
+		version       string
+		resource      string
+		expectAllowed bool
+	}{
+		{
+			name:          "wrong group",
+			group:         "not a group",
+			version:       clusterClaimVersion,
+			resource:      clusterClaimResource,
+			expectAllowed: true,
+		},
+		{
+			name:          "wrong version",
+			group:         clusterClaimGroup,
+			version:       "not a version",
+			resource:      clusterClaimResource,
+			expectAllowed: true,
+		},
+		{
+			name:          "wrong resource",
+			group:         clusterClaimGroup,
+			version:       clusterClaimVersion,
+			resource:      "not a resource",
+			expectAllowed: true,
+		},
+		{
+			name:     "cluster claim",
+			group:    clusterClaimGroup,
+			version:  clusterClaimVersion,
+			resource: clusterClaimResource,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+			cut.hiveClient = hivefake.NewSimpleClientset()
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    tc.group,
+					Version:  tc.version,
+					Resource: tc.resource,
+				},
+				Operation: admissionv1beta1.Create,
+				Object:    runtime.RawExtension{Raw: []byte(`{"spec":{"lifetime":"-1h"}}`)},
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+		})
+	}
+}
+
+func Test_ClusterClaimAdmission_Validate_Lifetime(t *testing.T) {
+	poolWithMaximum := func(maximum time.Duration) *hivev1.ClusterPool {
+		return &hivev1.ClusterPool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-pool"},
+			Spec: hivev1.ClusterPoolSpec{
+				ClaimLifetime: &hivev1.ClusterPoolClaimLifetime{
+					Maximum: &metav1.Duration{Duration: maximum},
+				},
+			},
+		}
+	}
+	claimWithLifetime := func(lifetime time.Duration) *hivev1.ClusterClaim {
+		claim := &hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-claim"},
+			Spec:       hivev1.ClusterClaimSpec{ClusterPoolName: "test-pool"},
+		}
+		if lifetime != 0 {
+			claim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
+		}
+		return claim
+	}
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		old           *hivev1.ClusterClaim
+		new           *hivev1.ClusterClaim
+		expectAllowed bool
+	}{
+		{
+			name:          "create without lifetime",
+			existing:      []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			new:           claimWithLifetime(0),
+			expectAllowed: true,
+		},
+		{
+			name:          "create with negative lifetime",
+			new:           claimWithLifetime(-1 * time.Hour),
+			expectAllowed: false,
+		},
+		{
+			name:          "create within maximum lifetime",
+			existing:      []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			new:           claimWithLifetime(3 * time.Hour),
+			expectAllowed: true,
+		},
+		{
+			name:     "create over maximum lifetime",
+			existing: []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			new:      claimWithLifetime(4 * time.Hour),
+		},
+		{
+			name: "create without maximum lifetime",
+			existing: []runtime.Object{&hivev1.ClusterPool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-pool"},
+			}},
+			new:           claimWithLifetime(100 * time.Hour),
+			expectAllowed: true,
+		},
+		{
+			name:          "create for missing pool",
+			new:           claimWithLifetime(100 * time.Hour),
+			expectAllowed: true,
+		},
+		{
+			name:     "update lengthening lifetime over maximum",
+			existing: []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			old:      claimWithLifetime(1 * time.Hour),
+			new:      claimWithLifetime(4 * time.Hour),
+		},
+		{
+			name:          "update lengthening lifetime within maximum",
+			existing:      []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			old:           claimWithLifetime(1 * time.Hour),
+			new:           claimWithLifetime(2 * time.Hour),
+			expectAllowed: true,
+		},
+		{
+			name:          "update of claim over maximum lifetime not lengthening lifetime",
+			existing:      []runtime.Object{poolWithMaximum(3 * time.Hour)},
+			old:           claimWithLifetime(5 * time.Hour),
+			new:           claimWithLifetime(5 * time.Hour),
+			expectAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+			cut.hiveClient = hivefake.NewSimpleClientset(tc.existing...)
+			newAsJSON, err := json.Marshal(tc.new)
+			if !assert.NoError(t, err, "unexpected error marshalling new claim") {
+				return
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    clusterClaimGroup,
+					Version:  clusterClaimVersion,
+					Resource: clusterClaimResource,
+				},
+				Operation: admissionv1beta1.Create,
+				Object:    runtime.RawExtension{Raw: newAsJSON},
+			}
+			if tc.old != nil {
+				oldAsJSON, err := json.Marshal(tc.old)
+				if !assert.NoError(t, err, "unexpected error marshalling old claim") {
+					return
+				}
+				request.Operation = admissionv1beta1.Update
+				request.OldObject = runtime.RawExtension{Raw: oldAsJSON}
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+		})
+	}
+}
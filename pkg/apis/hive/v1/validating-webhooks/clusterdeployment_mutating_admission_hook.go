@@ -0,0 +1,316 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/idna"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ClusterDeploymentMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterDeploymentMutatingAdmissionHook struct {
+	decoder         *admission.Decoder
+	kubeClient      kubernetes.Interface
+	clusterDefaults *hivev1.ClusterDefaultsConfig
+}
+
+// NewClusterDeploymentMutatingAdmissionHook constructs a new ClusterDeploymentMutatingAdmissionHook
+func NewClusterDeploymentMutatingAdmissionHook(decoder *admission.Decoder) *ClusterDeploymentMutatingAdmissionHook {
+	logger := log.WithField("mutatingWebhook", "clusterdeployment")
+	return &ClusterDeploymentMutatingAdmissionHook{
+		decoder:         decoder,
+		clusterDefaults: clusterDefaultsFromEnv(logger),
+	}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                  webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterdeploymentmutators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterDeploymentMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    clusterDeploymentAdmissionGroup,
+		"version":  clusterDeploymentAdmissionVersion,
+		"resource": "clusterdeploymentmutator",
+	}).Info("Registering mutation REST resource")
+
+	// NOTE: This GVR is meant to be different than the ClusterDeployment CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    clusterDeploymentAdmissionGroup,
+			Version:  clusterDeploymentAdmissionVersion,
+			Resource: "clusterdeploymentmutators",
+		},
+		"clusterdeploymentmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterDeploymentMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    clusterDeploymentAdmissionGroup,
+		"version":  clusterDeploymentAdmissionVersion,
+		"resource": "clusterdeploymentmutator",
+	}).Info("Initializing mutation REST resource")
+	kubeClient, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return errors.Wrap(err, "could not create kube client")
+	}
+	a.kubeClient = kubeClient
+	return nil
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// New ClusterDeployments have their base domain and cluster name normalized to the canonical form used for DNS records:
+// lowercase, without a trailing dot, and with internationalized labels converted to punycode. The base domain and
+// cluster name are immutable, so existing ClusterDeployments are not modified.
+// The pull secret and platform credentials of new ClusterDeployments that omit them are defaulted to the secrets
+// named by the annotations of their namespace, if any. The image set and region are defaulted from the cluster
+// defaults of HiveConfig.
+func (a *ClusterDeploymentMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !isClusterDeploymentRequest(admissionSpec) || admissionSpec.Operation != admissionv1beta1.Create {
+		contextLogger.Info("Skipping mutation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	newObject := &hivev1.ClusterDeployment{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	var patches []map[string]interface{}
+	for _, f := range []struct {
+		path  string
+		name  string
+		value string
+	}{
+		{path: "/spec/baseDomain", name: ".spec.baseDomain", value: newObject.Spec.BaseDomain},
+		{path: "/spec/clusterName", name: ".spec.clusterName", value: newObject.Spec.ClusterName},
+	} {
+		normalized, err := normalizeDomain(f.value)
+		if err != nil {
+			message := fmt.Sprintf("Invalid %s: %v", f.name, err)
+			contextLogger.Error(message)
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: message,
+				},
+			}
+		}
+		if normalized != f.value {
+			contextLogger.WithField("field", f.name).WithField("original", f.value).WithField("normalized", normalized).Info("Normalizing field")
+			patches = append(patches, map[string]interface{}{
+				"op":    "replace",
+				"path":  f.path,
+				"value": normalized,
+			})
+		}
+	}
+
+	defaultPatches, err := a.namespaceDefaultPatches(admissionSpec.Namespace, newObject, contextLogger)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed looking up namespace defaults")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	patches = append(patches, defaultPatches...)
+	patches = append(patches, a.clusterDefaultPatches(newObject, contextLogger)...)
+
+	if len(patches) == 0 {
+		contextLogger.Info("No mutation needed")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	patch, err := json.Marshal(patches)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// namespaceDefaultPatches returns the patches setting the pull secret and platform credentials omitted from the
+// ClusterDeployment to the defaults named by the annotations of its namespace. The namespace is only looked up when
+// something is omitted.
+func (a *ClusterDeploymentMutatingAdmissionHook) namespaceDefaultPatches(namespace string, cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]map[string]interface{}, error) {
+	type secretDefault struct {
+		path       string
+		annotation string
+	}
+	var missing []secretDefault
+	if cd.Spec.PullSecretRef == nil {
+		missing = append(missing, secretDefault{
+			path:       "/spec/pullSecretRef",
+			annotation: constants.DefaultPullSecretAnnotation,
+		})
+	}
+	if platform, credentials := platformCredentials(&cd.Spec.Platform); credentials != nil && credentials.Name == "" {
+		missing = append(missing, secretDefault{
+			path:       fmt.Sprintf("/spec/platform/%s/credentialsSecretRef", platform),
+			annotation: fmt.Sprintf(constants.DefaultCredentialsSecretAnnotationFormat, platform),
+		})
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	ns, err := a.kubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get namespace")
+	}
+	var patches []map[string]interface{}
+	for _, m := range missing {
+		name := ns.Annotations[m.annotation]
+		if name == "" {
+			continue
+		}
+		logger.WithField("path", m.path).WithField("secret", name).Info("Defaulting secret from namespace")
+		patches = append(patches, map[string]interface{}{
+			"op":    "add",
+			"path":  m.path,
+			"value": map[string]interface{}{"name": name},
+		})
+	}
+	return patches, nil
+}
+
+// clusterDefaultPatches returns the patches setting the image set and region omitted from the ClusterDeployment to
+// the cluster defaults. The image set is only defaulted when no release image is given either, and not for
+// ClusterDeployments that are not provisioned by Hive.
+func (a *ClusterDeploymentMutatingAdmissionHook) clusterDefaultPatches(cd *hivev1.ClusterDeployment, logger log.FieldLogger) []map[string]interface{} {
+	if a.clusterDefaults == nil {
+		return nil
+	}
+	var patches []map[string]interface{}
+	if imageSet := a.clusterDefaults.ImageSetRef; imageSet != nil && cd.Spec.Provisioning != nil &&
+		cd.Spec.Provisioning.ImageSetRef == nil && cd.Spec.Provisioning.ReleaseImage == "" {
+		logger.WithField("imageSet", imageSet.Name).Info("Defaulting image set")
+		patches = append(patches, map[string]interface{}{
+			"op":    "add",
+			"path":  "/spec/provisioning/imageSetRef",
+			"value": map[string]interface{}{"name": imageSet.Name},
+		})
+	}
+	platform, region := platformRegion(&cd.Spec.Platform)
+	if defaults := platformDefaults(a.clusterDefaults, platform); defaults != nil && defaults.Region != "" && region == "" {
+		logger.WithField("region", defaults.Region).Info("Defaulting region")
+		patches = append(patches, map[string]interface{}{
+			"op":    "add",
+			"path":  fmt.Sprintf("/spec/platform/%s/region", platform),
+			"value": defaults.Region,
+		})
+	}
+	return patches
+}
+
+// platformRegion returns the name of the platform and its region, or an empty name if the platform has no cluster
+// defaults.
+func platformRegion(platform *hivev1.Platform) (string, string) {
+	switch {
+	case platform.AWS != nil:
+		return "aws", platform.AWS.Region
+	case platform.Azure != nil:
+		return "azure", platform.Azure.Region
+	case platform.GCP != nil:
+		return "gcp", platform.GCP.Region
+	case platform.IBMCloud != nil:
+		return "ibmcloud", platform.IBMCloud.Region
+	}
+	return "", ""
+}
+
+// platformCredentials returns the name of the platform and the reference to its credentials secret, or nil if the
+// platform has no credentials.
+func platformCredentials(platform *hivev1.Platform) (string, *corev1.LocalObjectReference) {
+	switch {
+	case platform.AWS != nil:
+		return "aws", &platform.AWS.CredentialsSecretRef
+	case platform.Azure != nil:
+		return "azure", &platform.Azure.CredentialsSecretRef
+	case platform.GCP != nil:
+		return "gcp", &platform.GCP.CredentialsSecretRef
+	case platform.IBMCloud != nil:
+		return "ibmcloud", &platform.IBMCloud.CredentialsSecretRef
+	case platform.Nutanix != nil:
+		return "nutanix", &platform.Nutanix.CredentialsSecretRef
+	case platform.OpenStack != nil:
+		return "openstack", &platform.OpenStack.CredentialsSecretRef
+	case platform.VSphere != nil:
+		return "vsphere", &platform.VSphere.CredentialsSecretRef
+	case platform.Ovirt != nil:
+		return "ovirt", &platform.Ovirt.CredentialsSecretRef
+	}
+	return "", nil
+}
+
+func isClusterDeploymentRequest(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	return admissionSpec.Resource.Group == clusterDeploymentGroup &&
+		admissionSpec.Resource.Version == clusterDeploymentVersion &&
+		admissionSpec.Resource.Resource == clusterDeploymentResource
+}
+
+// normalizeDomain returns the canonical form of a domain name as used for DNS records: lowercase, without a trailing
+// dot, and with internationalized labels converted to punycode.
+func normalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return "", nil
+	}
+	normalized, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(normalized), nil
+}
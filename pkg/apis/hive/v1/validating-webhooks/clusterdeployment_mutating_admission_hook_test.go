@@ -0,0 +1,192 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterDeploymentAdmit(t *testing.T) {
+	cases := []struct {
+		name                 string
+		baseDomain           string
+		clusterName          string
+		platform             hivev1.Platform
+		pullSecretRef        *corev1.LocalObjectReference
+		provisioning         *hivev1.Provisioning
+		namespaceAnnotations map[string]string
+		clusterDefaults      *hivev1.ClusterDefaultsConfig
+		operation            admissionv1beta1.Operation
+		expectedAllowed      bool
+		expectedPatches      []map[string]interface{}
+	}{
+		{
+			name:            "canonical",
+			baseDomain:      "example.com",
+			clusterName:     "test-cluster",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "mixed case and trailing dot",
+			baseDomain:      "Example.COM.",
+			clusterName:     "Test-Cluster",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			expectedPatches: []map[string]interface{}{
+				{"op": "replace", "path": "/spec/baseDomain", "value": "example.com"},
+				{"op": "replace", "path": "/spec/clusterName", "value": "test-cluster"},
+			},
+		},
+		{
+			name:            "internationalized domain",
+			baseDomain:      "Bücher.example",
+			clusterName:     "test-cluster",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			expectedPatches: []map[string]interface{}{
+				{"op": "replace", "path": "/spec/baseDomain", "value": "xn--bcher-kva.example"},
+			},
+		},
+		{
+			name:            "invalid domain",
+			baseDomain:      "exa_mple.com",
+			clusterName:     "test-cluster",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:        "namespace defaults",
+			baseDomain:  "example.com",
+			clusterName: "test-cluster",
+			platform:    hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-east-1"}},
+			namespaceAnnotations: map[string]string{
+				constants.DefaultPullSecretAnnotation:              "team-pull-secret",
+				"hive.openshift.io/default-aws-credentials-secret": "team-aws-creds",
+				"hive.openshift.io/default-gcp-credentials-secret": "team-gcp-creds",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			expectedPatches: []map[string]interface{}{
+				{"op": "add", "path": "/spec/pullSecretRef", "value": map[string]interface{}{"name": "team-pull-secret"}},
+				{"op": "add", "path": "/spec/platform/aws/credentialsSecretRef", "value": map[string]interface{}{"name": "team-aws-creds"}},
+			},
+		},
+		{
+			name:          "namespace defaults not used when set",
+			baseDomain:    "example.com",
+			clusterName:   "test-cluster",
+			platform:      hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-east-1", CredentialsSecretRef: corev1.LocalObjectReference{Name: "my-creds"}}},
+			pullSecretRef: &corev1.LocalObjectReference{Name: "my-pull-secret"},
+			namespaceAnnotations: map[string]string{
+				constants.DefaultPullSecretAnnotation:              "team-pull-secret",
+				"hive.openshift.io/default-aws-credentials-secret": "team-aws-creds",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:         "cluster defaults",
+			baseDomain:   "example.com",
+			clusterName:  "test-cluster",
+			platform:     hivev1.Platform{AWS: &hivev1aws.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "my-creds"}}},
+			provisioning: &hivev1.Provisioning{},
+			clusterDefaults: &hivev1.ClusterDefaultsConfig{
+				ImageSetRef: &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+				Platforms: []hivev1.PlatformClusterDefaults{
+					{Platform: "gcp", Region: "us-east1"},
+					{Platform: "aws", Region: "us-east-1"},
+				},
+			},
+			pullSecretRef:   &corev1.LocalObjectReference{Name: "my-pull-secret"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+			expectedPatches: []map[string]interface{}{
+				{"op": "add", "path": "/spec/provisioning/imageSetRef", "value": map[string]interface{}{"name": "default-image-set"}},
+				{"op": "add", "path": "/spec/platform/aws/region", "value": "us-east-1"},
+			},
+		},
+		{
+			name:         "cluster defaults not used when set",
+			baseDomain:   "example.com",
+			clusterName:  "test-cluster",
+			platform:     hivev1.Platform{AWS: &hivev1aws.Platform{Region: "us-west-2", CredentialsSecretRef: corev1.LocalObjectReference{Name: "my-creds"}}},
+			provisioning: &hivev1.Provisioning{ReleaseImage: "example.com/release:latest"},
+			clusterDefaults: &hivev1.ClusterDefaultsConfig{
+				ImageSetRef: &hivev1.ClusterImageSetReference{Name: "default-image-set"},
+				Platforms:   []hivev1.PlatformClusterDefaults{{Platform: "aws", Region: "us-east-1"}},
+			},
+			pullSecretRef:   &corev1.LocalObjectReference{Name: "my-pull-secret"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "update is not mutated",
+			baseDomain:      "Example.com",
+			clusterName:     "test-cluster",
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+			hook.clusterDefaults = tc.clusterDefaults
+			hook.kubeClient = fakekubeclient.NewSimpleClientset(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-namespace",
+					Annotations: tc.namespaceAnnotations,
+				},
+			})
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					BaseDomain:    tc.baseDomain,
+					ClusterName:   tc.clusterName,
+					Platform:      tc.platform,
+					PullSecretRef: tc.pullSecretRef,
+					Provisioning:  tc.provisioning,
+				},
+			}
+			raw, err := json.Marshal(cd)
+			require.NoError(t, err, "could not marshal cluster deployment")
+			request := &admissionv1beta1.AdmissionRequest{
+				Namespace: "test-namespace",
+				Operation: tc.operation,
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeployments",
+				},
+				Object: runtime.RawExtension{Raw: raw},
+			}
+
+			response := hook.Admit(request)
+
+			if !assert.Equal(t, tc.expectedAllowed, response.Allowed, "unexpected allowed") {
+				t.Logf("Response result = %#v", response.Result)
+			}
+			if tc.expectedPatches == nil {
+				assert.Nil(t, response.Patch, "expected no patch")
+				return
+			}
+			var patches []map[string]interface{}
+			require.NoError(t, json.Unmarshal(response.Patch, &patches), "could not unmarshal patch")
+			assert.Equal(t, tc.expectedPatches, patches, "unexpected patches")
+			if assert.NotNil(t, response.PatchType, "missing patch type") {
+				assert.Equal(t, admissionv1beta1.PatchTypeJSONPatch, *response.PatchType, "unexpected patch type")
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package validatingwebhooks
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -22,7 +26,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 )
 
@@ -33,16 +39,26 @@ const (
 
 	clusterDeploymentAdmissionGroup   = "admission.hive.openshift.io"
 	clusterDeploymentAdmissionVersion = "v1"
+
+	// longestClusterDNSPrefix is the longest prefix added to the cluster domain for the DNS records of a cluster.
+	longestClusterDNSPrefix = "api-int"
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "EtcdBackup", "Proxy", "PostInstallJobs", "Detach"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder             *admission.Decoder
 	validManagedDomains []string
+	deleteProtection    bool
+	namespacePerCluster bool
+	admissionPolicy     *admissionPolicyReviewer
+	// hiveControllersUser is the user name of the service account of the hive controllers. Deletes made by the
+	// controllers, such as those of ClusterPools and ClusterClaims, are not subject to the delete protection enabled
+	// in HiveConfig.
+	hiveControllersUser string
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -57,9 +73,21 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 		domains = append(domains, md.Domains...)
 	}
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
+	deleteProtection, _ := strconv.ParseBool(os.Getenv(constants.ProtectedDeleteEnvVar))
+	if deleteProtection {
+		logger.Info("Delete protection enabled")
+	}
+	namespacePerCluster, _ := strconv.ParseBool(os.Getenv(constants.NamespacePerClusterEnvVar))
+	if namespacePerCluster {
+		logger.Info("Namespace per cluster enabled")
+	}
 	return &ClusterDeploymentValidatingAdmissionHook{
 		decoder:             decoder,
 		validManagedDomains: domains,
+		deleteProtection:    deleteProtection,
+		namespacePerCluster: namespacePerCluster,
+		admissionPolicy:     newAdmissionPolicyReviewerFromEnv(logger),
+		hiveControllersUser: fmt.Sprintf("system:serviceaccount:%s:%s", controllerutils.GetHiveNamespace(), constants.HiveControllersServiceAccountName),
 	}
 }
 
@@ -238,8 +266,18 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	if !newObject.Spec.Installed {
-		if newObject.Spec.Provisioning == nil {
+	if a.namespacePerCluster && newObject.Name != newObject.Namespace {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), newObject.Name, "must be the name of the namespace of the ClusterDeployment when namespace-per-cluster is enabled"))
+	}
+
+	allErrs = append(allErrs, validateClusterDomain(specPath, newObject.Spec)...)
+
+	if newObject.Spec.ClusterInstallRef != nil {
+		allErrs = append(allErrs, validateClusterInstallRef(specPath, newObject.Spec)...)
+	} else if !newObject.Spec.Installed {
+		if newObject.Spec.Platform.AgentBareMetal != nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("clusterInstallRef"), "must specify a ClusterInstall for agent based installs"))
+		} else if newObject.Spec.Provisioning == nil {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning"), "provisioning is required if not installed"))
 
 		} else if newObject.Spec.Provisioning.InstallConfigSecretRef.Name == "" {
@@ -254,6 +292,15 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		if newObject.Spec.Provisioning.SSHPrivateKeySecretRef != nil && newObject.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		allErrs = append(allErrs, validateManifestsSources(specPath.Child("provisioning", "manifests"), newObject.Spec.Provisioning.Manifests)...)
+		allErrs = append(allErrs, validateNetworking(specPath.Child("provisioning", "networking"), newObject.Spec.Provisioning.Networking, newObject.Spec.Platform)...)
+	}
+
+	allErrs = append(allErrs, validateAPIURLOverride(specPath.Child("controlPlaneConfig", "apiURLOverride"), newObject.Spec.ControlPlaneConfig.APIURLOverride)...)
+	allErrs = append(allErrs, validateProxy(specPath.Child("proxy"), newObject.Spec.Proxy)...)
+	allErrs = append(allErrs, validatePostInstallJobs(specPath.Child("postInstallJobs"), newObject.Spec.PostInstallJobs)...)
+	if newObject.Spec.Detach != nil && !newObject.Spec.Installed {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("detach"), newObject.Spec.Detach, "cannot detach a cluster that is not installed"))
 	}
 
 	if poolRef := newObject.Spec.ClusterPoolRef; poolRef != nil {
@@ -270,6 +317,10 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
+	if r := a.admissionPolicy.review(admissionSpec, contextLogger); r != nil {
+		return r
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -277,6 +328,279 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	}
 }
 
+// validateClusterDomain validates that the base domain and cluster name are in the canonical form produced by the
+// mutating webhook, and that the DNS names created for the cluster fit within the DNS length limits.
+func validateClusterDomain(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.BaseDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.BaseDomain) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("baseDomain"), spec.BaseDomain, msg))
+		}
+		for _, label := range strings.Split(spec.BaseDomain, ".") {
+			if len(label) > validation.DNS1123LabelMaxLength {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("baseDomain"), spec.BaseDomain,
+					fmt.Sprintf("label %q: %s", label, validation.MaxLenError(validation.DNS1123LabelMaxLength))))
+			}
+		}
+	}
+	if spec.ClusterName != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.ClusterName) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("clusterName"), spec.ClusterName, msg))
+		}
+	}
+	if len(allErrs) > 0 || spec.BaseDomain == "" || spec.ClusterName == "" {
+		return allErrs
+	}
+	// The longest DNS name created for a cluster is the internal API name.
+	if longest := fmt.Sprintf("%s.%s.%s", longestClusterDNSPrefix, spec.ClusterName, spec.BaseDomain); len(longest) > validation.DNS1123SubdomainMaxLength {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("baseDomain"), spec.BaseDomain,
+			fmt.Sprintf("DNS name %s for the cluster: %s", longest, validation.MaxLenError(validation.DNS1123SubdomainMaxLength))))
+	}
+	return allErrs
+}
+
+func validateManifestsSources(path *field.Path, sources []hivev1.InstallManifestsSource) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, source := range sources {
+		sourcePath := path.Index(i)
+		switch {
+		case source.ConfigMapRef != nil && source.SecretRef != nil:
+			allErrs = append(allErrs, field.Invalid(sourcePath, source, "must specify only one of configMapRef and secretRef"))
+		case source.ConfigMapRef != nil:
+			if source.ConfigMapRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sourcePath.Child("configMapRef", "name"), "must specify a name for the ConfigMap"))
+			}
+		case source.SecretRef != nil:
+			if source.SecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sourcePath.Child("secretRef", "name"), "must specify a name for the Secret"))
+			}
+		default:
+			allErrs = append(allErrs, field.Required(sourcePath, "must specify one of configMapRef and secretRef"))
+		}
+	}
+	return allErrs
+}
+
+func validateClusterInstallRef(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	refPath := specPath.Child("clusterInstallRef")
+	ref := spec.ClusterInstallRef
+	if ref.Group == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("group"), "must specify the group of the ClusterInstall"))
+	}
+	if ref.Version == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("version"), "must specify the version of the ClusterInstall"))
+	}
+	if ref.Kind == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("kind"), "must specify the kind of the ClusterInstall"))
+	}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), "must specify the name of the ClusterInstall"))
+	}
+	if spec.Provisioning != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("provisioning"), "provisioning cannot be used together with clusterInstallRef"))
+	}
+	return allErrs
+}
+
+func validateProxy(path *field.Path, proxy *hivev1.Proxy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if proxy == nil {
+		return allErrs
+	}
+	validateURL := func(urlPath *field.Path, proxyURL string) {
+		if proxyURL == "" {
+			return
+		}
+		u, err := url.Parse(proxyURL)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(urlPath, proxyURL, err.Error()))
+		case u.Scheme != "http" && u.Scheme != "https":
+			allErrs = append(allErrs, field.Invalid(urlPath, proxyURL, "must be an http or https URL"))
+		case u.Host == "":
+			allErrs = append(allErrs, field.Invalid(urlPath, proxyURL, "must specify the host of the proxy"))
+		case !isBracketedIPv6Host(u.Host):
+			allErrs = append(allErrs, field.Invalid(urlPath, proxyURL, "must enclose an IPv6 address in brackets"))
+		}
+	}
+	validateURL(path.Child("httpProxy"), proxy.HTTPProxy)
+	validateURL(path.Child("httpsProxy"), proxy.HTTPSProxy)
+	if proxy.TrustedCA != nil && proxy.TrustedCA.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("trustedCA", "name"), "must specify a name for the ConfigMap"))
+	}
+	return allErrs
+}
+
+// isBracketedIPv6Host returns false if the host of a URL is an IPv6 address that is not enclosed in brackets, which
+// makes the port of the URL ambiguous.
+func isBracketedIPv6Host(host string) bool {
+	return strings.HasPrefix(host, "[") || strings.Count(host, ":") < 2
+}
+
+func validateAPIURLOverride(path *field.Path, override string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if override == "" {
+		return allErrs
+	}
+	u, err := url.Parse(override)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(path, override, err.Error()))
+	case u.Host == "":
+		allErrs = append(allErrs, field.Invalid(path, override, "must specify the host of the API"))
+	case !isBracketedIPv6Host(u.Host):
+		allErrs = append(allErrs, field.Invalid(path, override, "must enclose an IPv6 address in brackets"))
+	}
+	return allErrs
+}
+
+// validateNetworking checks that the networks of a cluster are valid CIDRs which all use the same address families and
+// do not overlap, and that IPv6 is only used on platforms that support it.
+func validateNetworking(path *field.Path, networking *hivev1.Networking, platform hivev1.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if networking == nil {
+		return allErrs
+	}
+	type parsedCIDR struct {
+		path  *field.Path
+		ipNet *net.IPNet
+	}
+	var parsed []parsedCIDR
+	// families returns the address families of the CIDRs of a network, reporting the CIDRs that do not parse, or
+	// that overlap with a CIDR parsed before.
+	families := func(cidrs []string, cidrPath func(int) *field.Path, onePerFamily bool) sets.String {
+		found := sets.NewString()
+		for i, cidr := range cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(cidrPath(i), cidr, err.Error()))
+				continue
+			}
+			for _, other := range parsed {
+				if other.ipNet.Contains(ipNet.IP) || ipNet.Contains(other.ipNet.IP) {
+					allErrs = append(allErrs, field.Invalid(cidrPath(i), cidr, fmt.Sprintf("must not overlap with %s", other.path)))
+				}
+			}
+			parsed = append(parsed, parsedCIDR{path: cidrPath(i), ipNet: ipNet})
+			family := "IPv4"
+			if ipNet.IP.To4() == nil {
+				family = "IPv6"
+			}
+			if onePerFamily && found.Has(family) {
+				allErrs = append(allErrs, field.Invalid(cidrPath(i), cidr, fmt.Sprintf("must specify at most one %s CIDR", family)))
+			}
+			found.Insert(family)
+		}
+		return found
+	}
+
+	clusterCIDRs := make([]string, len(networking.ClusterNetwork))
+	for i, entry := range networking.ClusterNetwork {
+		clusterCIDRs[i] = entry.CIDR
+		if _, ipNet, err := net.ParseCIDR(entry.CIDR); err == nil {
+			ones, bits := ipNet.Mask.Size()
+			if int(entry.HostPrefix) < ones || int(entry.HostPrefix) > bits {
+				allErrs = append(allErrs, field.Invalid(path.Child("clusterNetwork").Index(i).Child("hostPrefix"), entry.HostPrefix, fmt.Sprintf("must be between %d and %d", ones, bits)))
+			}
+		}
+	}
+
+	allFamilies := sets.NewString()
+	var expectedFamilies sets.String
+	for _, network := range []struct {
+		path         *field.Path
+		cidrs        []string
+		cidrPath     func(int) *field.Path
+		onePerFamily bool
+	}{
+		{
+			path:     path.Child("machineNetwork"),
+			cidrs:    networking.MachineNetwork,
+			cidrPath: func(i int) *field.Path { return path.Child("machineNetwork").Index(i) },
+		},
+		{
+			path:     path.Child("clusterNetwork"),
+			cidrs:    clusterCIDRs,
+			cidrPath: func(i int) *field.Path { return path.Child("clusterNetwork").Index(i).Child("cidr") },
+		},
+		{
+			path:         path.Child("serviceNetwork"),
+			cidrs:        networking.ServiceNetwork,
+			cidrPath:     func(i int) *field.Path { return path.Child("serviceNetwork").Index(i) },
+			onePerFamily: true,
+		},
+	} {
+		networkFamilies := families(network.cidrs, network.cidrPath, network.onePerFamily)
+		if networkFamilies.Len() == 0 {
+			continue
+		}
+		allFamilies = allFamilies.Union(networkFamilies)
+		if expectedFamilies == nil {
+			expectedFamilies = networkFamilies
+		} else if !networkFamilies.Equal(expectedFamilies) {
+			allErrs = append(allErrs, field.Invalid(network.path, network.cidrs, fmt.Sprintf("must use the same address families as the other networks: %s", strings.Join(expectedFamilies.List(), ", "))))
+		}
+	}
+
+	if allFamilies.Has("IPv6") {
+		if platform.BareMetal == nil {
+			allErrs = append(allErrs, field.Forbidden(path, "IPv6 networks are only supported for bare metal clusters"))
+		}
+		if networking.NetworkType != hivev1.NetworkTypeOVNKubernetes {
+			allErrs = append(allErrs, field.Invalid(path.Child("networkType"), networking.NetworkType, fmt.Sprintf("must be %s for IPv6 networks", hivev1.NetworkTypeOVNKubernetes)))
+		}
+	}
+	return allErrs
+}
+
+func validatePostInstallJobs(path *field.Path, jobs []hivev1.PostInstallJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, job := range jobs {
+		jobPath := path.Index(i)
+		if job.Name == "" {
+			allErrs = append(allErrs, field.Required(jobPath.Child("name"), "must specify a name for the job"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(job.Name) {
+				allErrs = append(allErrs, field.Invalid(jobPath.Child("name"), job.Name, msg))
+			}
+			if names.Has(job.Name) {
+				allErrs = append(allErrs, field.Duplicate(jobPath.Child("name"), job.Name))
+			}
+			names.Insert(job.Name)
+		}
+		if job.Image == "" {
+			allErrs = append(allErrs, field.Required(jobPath.Child("image"), "must specify an image for the job"))
+		}
+		if job.Timeout != nil && job.Timeout.Duration < time.Second {
+			allErrs = append(allErrs, field.Invalid(jobPath.Child("timeout"), job.Timeout.Duration.String(), "must be at least one second"))
+		}
+	}
+	return allErrs
+}
+
+func validateAWSServiceEndpoints(path *field.Path, serviceEndpoints []hivev1aws.ServiceEndpoint) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, e := range serviceEndpoints {
+		endpointPath := path.Index(i)
+		switch {
+		case e.Name == "":
+			allErrs = append(allErrs, field.Required(endpointPath.Child("name"), "must specify a name for the service endpoint"))
+		case names.Has(e.Name):
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("name"), e.Name))
+		default:
+			names.Insert(e.Name)
+		}
+		u, err := url.Parse(e.URL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("url"), e.URL, "must be a valid https URL"))
+		}
+	}
+	return allErrs
+}
+
 func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.ErrorList {
 	allErrs := field.ErrorList{}
 	numberOfPlatforms := 0
@@ -289,6 +613,19 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if aws.Region == "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "must specify AWS region"))
 		}
+		allErrs = append(allErrs, validateAWSServiceEndpoints(awsPath.Child("serviceEndpoints"), aws.ServiceEndpoints)...)
+		subnets := sets.NewString()
+		for i, subnet := range aws.Subnets {
+			subnetPath := awsPath.Child("subnets").Index(i)
+			switch {
+			case !strings.HasPrefix(subnet, "subnet-"):
+				allErrs = append(allErrs, field.Invalid(subnetPath, subnet, "must be the ID of a subnet"))
+			case subnets.Has(subnet):
+				allErrs = append(allErrs, field.Duplicate(subnetPath, subnet))
+			default:
+				subnets.Insert(subnet)
+			}
+		}
 	}
 	if azure := platform.Azure; azure != nil {
 		numberOfPlatforms++
@@ -302,6 +639,27 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if azure.BaseDomainResourceGroupName == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("baseDomainResourceGroupName"), "must specify the Azure resource group for the base domain"))
 		}
+		if azure.VirtualNetwork != "" {
+			if azure.NetworkResourceGroupName == "" {
+				allErrs = append(allErrs, field.Required(azurePath.Child("networkResourceGroupName"), "must specify the resource group of the virtual network"))
+			}
+			if azure.ControlPlaneSubnet == "" {
+				allErrs = append(allErrs, field.Required(azurePath.Child("controlPlaneSubnet"), "must specify the control plane subnet of the virtual network"))
+			}
+			if azure.ComputeSubnet == "" {
+				allErrs = append(allErrs, field.Required(azurePath.Child("computeSubnet"), "must specify the compute subnet of the virtual network"))
+			}
+		} else if azure.NetworkResourceGroupName != "" || azure.ControlPlaneSubnet != "" || azure.ComputeSubnet != "" {
+			allErrs = append(allErrs, field.Required(azurePath.Child("virtualNetwork"), "must specify the virtual network of the network resource group and subnets"))
+		}
+		if privateLink := azure.PrivateLink; privateLink != nil && privateLink.Enabled {
+			cidrPath := azurePath.Child("privateLink", "natSubnetCIDR")
+			if privateLink.NATSubnetCIDR == "" {
+				allErrs = append(allErrs, field.Required(cidrPath, "must specify the CIDR of the NAT subnet"))
+			} else if _, _, err := net.ParseCIDR(privateLink.NATSubnetCIDR); err != nil {
+				allErrs = append(allErrs, field.Invalid(cidrPath, privateLink.NATSubnetCIDR, err.Error()))
+			}
+		}
 	}
 	if gcp := platform.GCP; gcp != nil {
 		numberOfPlatforms++
@@ -312,6 +670,61 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if gcp.Region == "" {
 			allErrs = append(allErrs, field.Required(gcpPath.Child("region"), "must specify GCP region"))
 		}
+		if psc := gcp.PrivateServiceConnect; psc != nil && psc.Enabled {
+			cidrPath := gcpPath.Child("privateServiceConnect", "serviceAttachmentSubnetCIDR")
+			if psc.ServiceAttachmentSubnetCIDR == "" {
+				allErrs = append(allErrs, field.Required(cidrPath, "must specify the CIDR of the service attachment subnet"))
+			} else if _, _, err := net.ParseCIDR(psc.ServiceAttachmentSubnetCIDR); err != nil {
+				allErrs = append(allErrs, field.Invalid(cidrPath, psc.ServiceAttachmentSubnetCIDR, err.Error()))
+			}
+			if gcp.NetworkProjectID != "" {
+				allErrs = append(allErrs, field.Invalid(gcpPath.Child("privateServiceConnect", "enabled"), psc.Enabled, "cannot use Private Service Connect with a Shared VPC network"))
+			}
+		}
+		if gcp.Network != "" {
+			if gcp.ControlPlaneSubnet == "" {
+				allErrs = append(allErrs, field.Required(gcpPath.Child("controlPlaneSubnet"), "must specify the control plane subnet of the network"))
+			}
+			if gcp.ComputeSubnet == "" {
+				allErrs = append(allErrs, field.Required(gcpPath.Child("computeSubnet"), "must specify the compute subnet of the network"))
+			}
+		} else {
+			if gcp.NetworkProjectID != "" {
+				allErrs = append(allErrs, field.Required(gcpPath.Child("network"), "must specify the network of the network project"))
+			}
+			if gcp.ControlPlaneSubnet != "" || gcp.ComputeSubnet != "" {
+				allErrs = append(allErrs, field.Required(gcpPath.Child("network"), "must specify the network of the subnets"))
+			}
+		}
+	}
+	if ibmcloud := platform.IBMCloud; ibmcloud != nil {
+		numberOfPlatforms++
+		ibmcloudPath := path.Child("ibmcloud")
+		if ibmcloud.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(ibmcloudPath.Child("credentialsSecretRef", "name"), "must specify secrets for IBM Cloud access"))
+		}
+		if ibmcloud.Region == "" {
+			allErrs = append(allErrs, field.Required(ibmcloudPath.Child("region"), "must specify IBM Cloud region"))
+		}
+	}
+	if nutanix := platform.Nutanix; nutanix != nil {
+		numberOfPlatforms++
+		nutanixPath := path.Child("nutanix")
+		if nutanix.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("credentialsSecretRef", "name"), "must specify secrets for Prism Central access"))
+		}
+		if nutanix.CertificatesSecretRef != nil && nutanix.CertificatesSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("certificatesSecretRef", "name"), "must specify the name of the Prism Central certificates secret"))
+		}
+		if nutanix.PrismCentral.Address == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("prismCentral", "address"), "must specify Prism Central address"))
+		}
+		if nutanix.PrismElementUUID == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("prismElementUUID"), "must specify Prism Element UUID"))
+		}
+		if len(nutanix.SubnetUUIDs) == 0 {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("subnetUUIDs"), "must specify at least one subnet UUID"))
+		}
 	}
 	if openstack := platform.OpenStack; openstack != nil {
 		numberOfPlatforms++
@@ -361,6 +774,22 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	if baremetal := platform.BareMetal; baremetal != nil {
 		numberOfPlatforms++
 	}
+	if agent := platform.AgentBareMetal; agent != nil {
+		numberOfPlatforms++
+		agentPath := path.Child("agentBareMetal")
+		if agent.APIVIP != "" && net.ParseIP(agent.APIVIP) == nil {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child("apiVIP"), agent.APIVIP, "must be an IP address"))
+		}
+		if agent.IngressVIP != "" && net.ParseIP(agent.IngressVIP) == nil {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child("ingressVIP"), agent.IngressVIP, "must be an IP address"))
+		}
+		if agent.APIVIP != "" && agent.APIVIP == agent.IngressVIP {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child("ingressVIP"), agent.IngressVIP, "must differ from apiVIP"))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&agent.AgentSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(agentPath.Child("agentSelector"), agent.AgentSelector, err.Error()))
+		}
+	}
 	switch {
 	case numberOfPlatforms == 0:
 		allErrs = append(allErrs, field.Required(path, "must specify a platform"))
@@ -428,7 +857,18 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	// Add the new data to the contextLogger
 	contextLogger.Data["oldObject.Name"] = oldObject.Name
 
-	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(&oldObject.Spec, &newObject.Spec)
+	// Provisioning is immutable except that a dry run may be turned off so that the cluster gets provisioned, and
+	// that the SSH key may be rotated once the cluster is installed.
+	newSpec := newObject.Spec.DeepCopy()
+	if oldProvisioning := oldObject.Spec.Provisioning; oldProvisioning != nil && newSpec.Provisioning != nil {
+		if oldProvisioning.DryRun {
+			newSpec.Provisioning.DryRun = true
+		}
+		if oldObject.Spec.Installed {
+			newSpec.Provisioning.SSHPrivateKeySecretRef = oldProvisioning.SSHPrivateKeySecretRef
+		}
+	}
+	hasChangedImmutableField, changedFieldName := hasChangedImmutableField(&oldObject.Spec, newSpec)
 	if hasChangedImmutableField {
 		message := fmt.Sprintf("Attempted to change ClusterDeployment.Spec.%v. ClusterDeployment.Spec is immutable except for %v", changedFieldName, mutableFields)
 		contextLogger.Infof("Failed validation: %v", message)
@@ -465,6 +905,10 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
+	if p := newObject.Spec.Provisioning; p != nil && p.SSHPrivateKeySecretRef != nil && p.SSHPrivateKeySecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
+	}
+
 	if newObject.Spec.Installed {
 		if newObject.Spec.ClusterMetadata != nil {
 			if oldObject.Spec.Installed {
@@ -479,6 +923,20 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateAPIURLOverride(specPath.Child("controlPlaneConfig", "apiURLOverride"), newObject.Spec.ControlPlaneConfig.APIURLOverride)...)
+	allErrs = append(allErrs, validateProxy(specPath.Child("proxy"), newObject.Spec.Proxy)...)
+	allErrs = append(allErrs, validatePostInstallJobs(specPath.Child("postInstallJobs"), newObject.Spec.PostInstallJobs)...)
+
+	// A cluster can only be detached once it is installed, and cannot be attached again.
+	switch oldDetach, newDetach := oldObject.Spec.Detach, newObject.Spec.Detach; {
+	case oldDetach != nil && newDetach == nil:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("detach"), newDetach, "cannot attach a detached cluster"))
+	case oldDetach == nil && newDetach != nil && !newObject.Spec.Installed:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("detach"), newDetach, "cannot detach a cluster that is not installed"))
+	case oldDetach == nil && newDetach != nil && oldObject.DeletionTimestamp != nil:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("detach"), newDetach, "cannot detach a cluster that is being deleted"))
+	}
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, newObject.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
@@ -555,6 +1013,23 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 		}
 	}
 
+	// With delete protection enabled, a ClusterDeployment can only be deleted once it has been explicitly opted out.
+	// Deletes made by the hive controllers, such as those of ClusterPools and ClusterClaims, and deletes of unclaimed
+	// ClusterPool clusters, are not subject to it.
+	switch {
+	case !a.deleteProtection:
+	case request.UserInfo.Username == a.hiveControllersUser:
+		logger.Info("Allowing delete by the hive controllers despite delete protection")
+	case oldObject.Spec.ClusterPoolRef != nil && oldObject.Spec.ClusterPoolRef.ClaimName == "":
+		logger.Info("Allowing delete of unclaimed ClusterPool cluster despite delete protection")
+	case oldObject.Annotations[constants.DeleteProtectionAnnotation] != constants.DeleteProtectionDisabled:
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("metadata", "annotations", constants.DeleteProtectionAnnotation),
+			oldObject.Annotations[constants.DeleteProtectionAnnotation],
+			fmt.Sprintf("delete protection is enabled, annotation must be set to %q to delete", constants.DeleteProtectionDisabled),
+		))
+	}
+
 	if len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
@@ -4,26 +4,35 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/pkg/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1baremetal "github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
 	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	hivev1ibmcloud "github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	hivev1nutanix "github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
 	"github.com/openshift/hive/pkg/constants"
 )
 
+const hiveControllersTestUser = "system:serviceaccount:hive:hive-controllers"
+
 var validTestManagedDomains = []string{
 	"aaa.com",
 	"foo.aaa.com",
@@ -35,7 +44,7 @@ func clusterDeploymentTemplate() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		Spec: hivev1.ClusterDeploymentSpec{
 			BaseDomain:  "example.com",
-			ClusterName: "SameClusterName",
+			ClusterName: "sameclustername",
 			Provisioning: &hivev1.Provisioning{
 				InstallConfigSecretRef: corev1.LocalObjectReference{
 					Name: "test-install-config",
@@ -71,6 +80,26 @@ func validGCPClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func validIBMCloudClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.IBMCloud = &hivev1ibmcloud.Platform{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+		Region:               "us-south",
+	}
+	return cd
+}
+
+func validNutanixClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.Nutanix = &hivev1nutanix.Platform{
+		PrismCentral:         hivev1nutanix.PrismEndpoint{Address: "prism-central.example.com", Port: 9440},
+		PrismElementUUID:     "0005b0f1-8f43-a0f2-02b7-3cecef193712",
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+		SubnetUUIDs:          []string{"c7938dc6-7659-453e-a688-e26020c68e43"},
+	}
+	return cd
+}
+
 func validAWSClusterDeployment() *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.AWS = &hivev1aws.Platform{
@@ -139,6 +168,25 @@ func validOvirtClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func validAgentBareMetalClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Provisioning = nil
+	cd.Spec.Platform.AgentBareMetal = &hivev1agent.BareMetalPlatform{
+		APIVIP:     "192.168.111.2",
+		IngressVIP: "192.168.111.3",
+		AgentSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"cluster": "sameclustername"},
+		},
+	}
+	cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+		Group:   "extensions.hive.openshift.io",
+		Version: "v1beta1",
+		Kind:    "AgentClusterInstall",
+		Name:    "sameclustername",
+	}
+	return cd
+}
+
 // Meant to be used to compare new and old as the same values.
 func validClusterDeploymentSameValues() *hivev1.ClusterDeployment {
 	return validAWSClusterDeployment()
@@ -146,7 +194,7 @@ func validClusterDeploymentSameValues() *hivev1.ClusterDeployment {
 
 func validClusterDeploymentDifferentImmutableValue() *hivev1.ClusterDeployment {
 	cd := validAWSClusterDeployment()
-	cd.Spec.ClusterName = "DifferentClusterName"
+	cd.Spec.ClusterName = "differentclustername"
 	return cd
 }
 
@@ -187,14 +235,17 @@ func TestClusterDeploymentInitialize(t *testing.T) {
 
 func TestClusterDeploymentValidate(t *testing.T) {
 	cases := []struct {
-		name            string
-		newObject       *hivev1.ClusterDeployment
-		newObjectRaw    []byte
-		oldObject       *hivev1.ClusterDeployment
-		oldObjectRaw    []byte
-		operation       admissionv1beta1.Operation
-		expectedAllowed bool
-		gvr             *metav1.GroupVersionResource
+		name                string
+		newObject           *hivev1.ClusterDeployment
+		newObjectRaw        []byte
+		oldObject           *hivev1.ClusterDeployment
+		oldObjectRaw        []byte
+		operation           admissionv1beta1.Operation
+		expectedAllowed     bool
+		gvr                 *metav1.GroupVersionResource
+		deleteProtection    bool
+		namespacePerCluster bool
+		username            string
 	}{
 		{
 			name:            "Test valid create",
@@ -223,6 +274,97 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test enabling etcd backups",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.EtcdBackup = &hivev1.EtcdBackupConfig{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test changing proxy",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{HTTPSProxy: "http://proxy.example.com:3128"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test adding post-install jobs",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PostInstallJobs = []hivev1.PostInstallJob{{Name: "register", Image: "example.com/agent:latest"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test detaching installed cluster",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Detach = &hivev1.ClusterDetach{PreserveSecrets: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test detaching cluster that is not installed",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Detach = &hivev1.ClusterDetach{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test attaching detached cluster",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Detach = &hivev1.ClusterDetach{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test changing proxy to invalid URL",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{HTTPSProxy: "proxy.example.com:3128"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test setting installed flag",
 			oldObject: validAWSClusterDeployment(),
@@ -343,6 +485,90 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test turning off dry run",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.DryRun = true
+				return cd
+			}(),
+			newObject:       validAWSClusterDeployment(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test turning on dry run",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.DryRun = true
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test changing provisioning when turning off dry run",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.DryRun = true
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.ReleaseImage = "test-release-image"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test rotating ssh key after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "new-ssh-key"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test rotating ssh key to empty name after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test changing ssh key before installed",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "new-ssh-key"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test Update Operation is NOT allowed with different immutable data",
 			oldObject:       validAWSClusterDeployment(),
@@ -432,136 +658,461 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:            "Test updating existing empty ingress to populated ingress",
-			oldObject:       validAWSClusterDeployment(),
-			newObject:       validClusterDeploymentWithIngress(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: true,
-		},
-		{
-			name:      "Test updating existing ingress to one missing default",
-			oldObject: validClusterDeploymentWithIngress(),
+			name: "Test new clusterdeployment with manifests sources",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Name = "notdefault"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Manifests = []hivev1.InstallManifestsSource{
+					{ConfigMapRef: &corev1.LocalObjectReference{Name: "manifests"}},
+					{SecretRef: &corev1.LocalObjectReference{Name: "machineconfigs"}, Directory: hivev1.InstallManifestsDirectoryOpenShift},
+				}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: false,
-		},
-		{
-			name:            "Test valid managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("bar.foo.aaa.com"),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name:            "Test base domain is not child of a managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("bar.bad-domain.com"),
-			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
-		},
-		{
-			name:            "Test base domain is not direct child of a managed domain",
-			newObject:       clusterDeploymentWithManagedDomain("baz.foo.bbb.com"),
-			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
-		},
-		{
-			name:            "Test base domain is not same as one of the managed domains",
-			newObject:       clusterDeploymentWithManagedDomain("foo.aaa.com"),
-			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
-		},
-		{
-			name: "Test managed DNS is valid on GCP",
+			name: "Test new clusterdeployment with empty manifests source",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validGCPClusterDeployment()
-				cd.Spec.ManageDNS = true
-				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Manifests = []hivev1.InstallManifestsSource{{}}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "Test managed DNS is valid on Azure",
+			name: "Test new clusterdeployment with manifests source referencing both a ConfigMap and a Secret",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.ManageDNS = true
-				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Manifests = []hivev1.InstallManifestsSource{{
+					ConfigMapRef: &corev1.LocalObjectReference{Name: "manifests"},
+					SecretRef:    &corev1.LocalObjectReference{Name: "manifests"},
+				}}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name:      "Test allow modifying controlPlaneConfig",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test new clusterdeployment with manifests source missing a name",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.ControlPlaneConfig = hivev1.ControlPlaneConfigSpec{
-					ServingCertificates: hivev1.ControlPlaneServingCertificateSpec{
-						Default: "someNonExistentCertificateBundle",
-					},
+				cd.Spec.Provisioning.Manifests = []hivev1.InstallManifestsSource{
+					{SecretRef: &corev1.LocalObjectReference{}},
 				}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name:      "Test allow modifying certificateBundles",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test new clusterdeployment with IPv4 networking",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:     "testCertificateBundle",
-						Generate: false,
-						CertificateSecretRef: corev1.LocalObjectReference{
-							Name: "testCertBundle-Secret",
-						},
-					},
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					MachineNetwork: []string{"10.0.0.0/16"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 23}},
+					ServiceNetwork: []string{"172.30.0.0/16"},
 				}
-
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Test invalid wildcard ingress domain",
+			name: "Test new clusterdeployment with invalid network CIDR",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Domain = "*.apps.sameclustername.example.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					MachineNetwork: []string{"10.0.0.0"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Test invalid domain on ingress domain",
+			name: "Test new clusterdeployment with host prefix smaller than the cluster network",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].Domain = "apps.sameclustername.NOTexample.com"
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 12}},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Cluster deployment name is too long",
+			name: "Test new clusterdeployment with overlapping machine and service networks",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Name = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					MachineNetwork: []string{"172.30.0.0/16"},
+					ServiceNetwork: []string{"172.30.128.0/20"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Cluster name is too long",
+			name: "Test new clusterdeployment with overlapping cluster networks",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{
+						{CIDR: "10.128.0.0/14", HostPrefix: 23},
+						{CIDR: "10.130.0.0/16", HostPrefix: 23},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test new clusterdeployment with OVNKubernetes network type",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    hivev1.NetworkTypeOVNKubernetes,
+					MachineNetwork: []string{"10.0.0.0/16"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 23}},
+					ServiceNetwork: []string{"172.30.0.0/16"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test new bare metal clusterdeployment with dual-stack networking",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := func() *hivev1.ClusterDeployment {
+					cd := validAWSClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{BareMetal: &hivev1baremetal.Platform{
+						LibvirtSSHPrivateKeySecretRef: corev1.LocalObjectReference{Name: "libvirt-ssh"},
+					}}
+					return cd
+				}()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    "OVNKubernetes",
+					MachineNetwork: []string{"10.0.0.0/16", "fd00::/48"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{
+						{CIDR: "10.128.0.0/14", HostPrefix: 23},
+						{CIDR: "fd01::/48", HostPrefix: 64},
+					},
+					ServiceNetwork: []string{"172.30.0.0/16", "fd02::/112"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test new bare metal clusterdeployment with IPv6-only networking",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := func() *hivev1.ClusterDeployment {
+					cd := validAWSClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{BareMetal: &hivev1baremetal.Platform{
+						LibvirtSSHPrivateKeySecretRef: corev1.LocalObjectReference{Name: "libvirt-ssh"},
+					}}
+					return cd
+				}()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    "OVNKubernetes",
+					MachineNetwork: []string{"fd00::/48"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "fd01::/48", HostPrefix: 64}},
+					ServiceNetwork: []string{"fd02::/112"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test new bare metal clusterdeployment with mismatched address families",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := func() *hivev1.ClusterDeployment {
+					cd := validAWSClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{BareMetal: &hivev1baremetal.Platform{
+						LibvirtSSHPrivateKeySecretRef: corev1.LocalObjectReference{Name: "libvirt-ssh"},
+					}}
+					return cd
+				}()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    "OVNKubernetes",
+					MachineNetwork: []string{"10.0.0.0/16", "fd00::/48"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 23}},
+					ServiceNetwork: []string{"172.30.0.0/16", "fd02::/112"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test new bare metal clusterdeployment with two IPv4 service networks",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := func() *hivev1.ClusterDeployment {
+					cd := validAWSClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{BareMetal: &hivev1baremetal.Platform{
+						LibvirtSSHPrivateKeySecretRef: corev1.LocalObjectReference{Name: "libvirt-ssh"},
+					}}
+					return cd
+				}()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					ServiceNetwork: []string{"172.30.0.0/16", "172.31.0.0/16"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test new bare metal clusterdeployment with IPv6 networking without OVNKubernetes",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := func() *hivev1.ClusterDeployment {
+					cd := validAWSClusterDeployment()
+					cd.Spec.Platform = hivev1.Platform{BareMetal: &hivev1baremetal.Platform{
+						LibvirtSSHPrivateKeySecretRef: corev1.LocalObjectReference{Name: "libvirt-ssh"},
+					}}
+					return cd
+				}()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    "OpenShiftSDN",
+					MachineNetwork: []string{"10.0.0.0/16", "fd00::/48"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{
+						{CIDR: "10.128.0.0/14", HostPrefix: 23},
+						{CIDR: "fd01::/48", HostPrefix: 64},
+					},
+					ServiceNetwork: []string{"172.30.0.0/16", "fd02::/112"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test new AWS clusterdeployment with dual-stack networking",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Networking = &hivev1.Networking{
+					NetworkType:    "OVNKubernetes",
+					MachineNetwork: []string{"10.0.0.0/16", "fd00::/48"},
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{
+						{CIDR: "10.128.0.0/14", HostPrefix: 23},
+						{CIDR: "fd01::/48", HostPrefix: 64},
+					},
+					ServiceNetwork: []string{"172.30.0.0/16", "fd02::/112"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create in namespace of the same name with namespace per cluster",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "mycluster"
+				cd.Namespace = "mycluster"
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			namespacePerCluster: true,
+			expectedAllowed:     true,
+		},
+		{
+			name: "Test create in shared namespace with namespace per cluster",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "mycluster"
+				cd.Namespace = "clusters"
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			namespacePerCluster: true,
+			expectedAllowed:     false,
+		},
+		{
+			name:                "Test update in shared namespace with namespace per cluster",
+			oldObject:           validAWSClusterDeployment(),
+			newObject:           validAWSClusterDeployment(),
+			operation:           admissionv1beta1.Update,
+			namespacePerCluster: true,
+			expectedAllowed:     true,
+		},
+		{
+			name: "Test new clusterdeployment with AWS service endpoints",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://ec2.example.com"},
+					{Name: "elasticloadbalancing", URL: "https://elb.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test new clusterdeployment with duplicate AWS service endpoints",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://ec2.example.com"},
+					{Name: "ec2", URL: "https://ec2-other.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test new clusterdeployment with non-https AWS service endpoint",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "http://ec2.example.com"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test updating existing empty ingress to populated ingress",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       validClusterDeploymentWithIngress(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test updating existing ingress to one missing default",
+			oldObject: validClusterDeploymentWithIngress(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].Name = "notdefault"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("bar.foo.aaa.com"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test base domain is not child of a managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("bar.bad-domain.com"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test base domain is not direct child of a managed domain",
+			newObject:       clusterDeploymentWithManagedDomain("baz.foo.bbb.com"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test base domain is not same as one of the managed domains",
+			newObject:       clusterDeploymentWithManagedDomain("foo.aaa.com"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test managed DNS is valid on GCP",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS is valid on Azure",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test allow modifying controlPlaneConfig",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig = hivev1.ControlPlaneConfigSpec{
+					ServingCertificates: hivev1.ControlPlaneServingCertificateSpec{
+						Default: "someNonExistentCertificateBundle",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test allow modifying certificateBundles",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:     "testCertificateBundle",
+						Generate: false,
+						CertificateSecretRef: corev1.LocalObjectReference{
+							Name: "testCertBundle-Secret",
+						},
+					},
+				}
+
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test invalid wildcard ingress domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].Domain = "*.apps.sameclustername.example.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test invalid domain on ingress domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].Domain = "apps.sameclustername.NOTexample.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Cluster deployment name is too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Name = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Cluster name is too long",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
 				cd.Spec.ClusterName = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-long-name"
@@ -571,104 +1122,572 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:            "Azure create valid",
-			newObject:       validAzureClusterDeployment(),
+			name: "Base domain is not lowercase",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "Example.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Base domain has trailing dot",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "example.com."
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Base domain label is too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "this-is-a-long-long-long-long-long-long-long-long-long-long-long-label.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Cluster DNS names are too long",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = strings.Repeat("a", 60) + "." + strings.Repeat("b", 60) + "." + strings.Repeat("c", 60) + ".com"
+				cd.Spec.ClusterName = strings.Repeat("d", 63)
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Punycode base domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BaseDomain = "xn--bcher-kva.example"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Azure create valid",
+			newObject:       validAzureClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure create missing region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create missing credentials",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.CredentialsSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create missing baseDomainResourceGroupName",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.BaseDomainResourceGroupName = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Azure update region",
+			oldObject: validAzureClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		// TODO: ensure Azure clusterDeployments have necessary info for
+		// machine sets
+		// {
+		// 	name: "not setting instance type on Azure machine pool",
+		// 	newObject: func() *hivev1.ClusterDeployment {
+		// 		cd := validAzureClusterDeployment()
+		// 		cd.Spec.Compute = []hivev1.MachinePool{
+		// 			{
+		// 				Name: "testmachinepool",
+		// 				Platform: hivev1.MachinePoolPlatform{
+		// 					Azure: &hivev1azure.MachinePool{
+		// 						InstanceType: "",
+		// 					},
+		// 				},
+		// 			},
+		// 		}
+		// 		return cd
+		// 	}(),
+		// 	operation:       admissionv1beta1.Create,
+		// 	expectedAllowed: false,
+		// },
+		{
+			name: "create with two cloud platforms",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				awsCD := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS = awsCD.Spec.Platform.AWS
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with no cloud platforms",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "ingress with serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].ServingCertificate = "test-serving-cert"
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "ingress with missing serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].ServingCertificate = "missing-serving-cert"
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "valid serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "serving certificate without name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "serving certificate without secret reference",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name: "test-serving-cert",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "InstallConfig is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallConfigSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "valid IBM Cloud clusterdeployment",
+			newObject:       validIBMCloudClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "IBM Cloud clusterdeployment without region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validIBMCloudClusterDeployment()
+				cd.Spec.Platform.IBMCloud.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "IBM Cloud clusterdeployment with managed DNS",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validIBMCloudClusterDeployment()
+				cd.Spec.ManageDNS = true
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "valid Nutanix clusterdeployment",
+			newObject:       validNutanixClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Nutanix clusterdeployment without Prism Central address",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNutanixClusterDeployment()
+				cd.Spec.Platform.Nutanix.PrismCentral.Address = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Nutanix clusterdeployment without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNutanixClusterDeployment()
+				cd.Spec.Platform.Nutanix.SubnetUUIDs = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "valid GCP clusterdeployment",
+			newObject:       validGCPClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP Private Service Connect valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled:                     true,
+					ServiceAttachmentSubnetCIDR: "192.168.0.0/29",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP Private Service Connect without subnet CIDR",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP Private Service Connect with invalid subnet CIDR",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled:                     true,
+					ServiceAttachmentSubnetCIDR: "192.168.0.0",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP Shared VPC valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.NetworkProjectID = "host-project"
+				cd.Spec.Platform.GCP.Network = "shared-network"
+				cd.Spec.Platform.GCP.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.GCP.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP existing network without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.Network = "existing-network"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP network project without network",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.NetworkProjectID = "host-project"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP Shared VPC with Private Service Connect",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.NetworkProjectID = "host-project"
+				cd.Spec.Platform.GCP.Network = "shared-network"
+				cd.Spec.Platform.GCP.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.GCP.ComputeSubnet = "compute-subnet"
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled:                     true,
+					ServiceAttachmentSubnetCIDR: "192.168.0.0/29",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS existing subnets valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Subnets = []string{"subnet-0123456789abcdef0", "subnet-0123456789abcdef1"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS existing subnet not a subnet ID",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Subnets = []string{"vpc-0123456789abcdef0"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS duplicate existing subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Subnets = []string{"subnet-0123456789abcdef0", "subnet-0123456789abcdef0"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure existing virtual network valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				cd.Spec.Platform.Azure.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure existing virtual network without resource group",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				cd.Spec.Platform.Azure.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure existing virtual network without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure subnets without virtual network",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure Private Link valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{
+					Enabled:       true,
+					NATSubnetCIDR: "10.0.255.0/29",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure Private Link without NAT subnet CIDR",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure Private Link with invalid NAT subnet CIDR",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccess{
+					Enabled:       true,
+					NATSubnetCIDR: "10.0.255.0",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "proxy valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "https://proxy.example.com:3129",
+					NoProxy:    ".example.com,10.0.0.0/16",
+					TrustedCA:  &corev1.LocalObjectReference{Name: "proxy-ca"},
+				}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Azure create missing region",
+			name: "proxy with unsupported scheme",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.Region = ""
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{HTTPProxy: "socks5://proxy.example.com:1080"}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Azure create missing credentials",
+			name: "proxy with unbracketed IPv6 address",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.CredentialsSecretRef.Name = ""
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{HTTPProxy: "http://fd00::1:3128"}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Azure create missing baseDomainResourceGroupName",
+			name: "proxy with IPv6 address",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.BaseDomainResourceGroupName = ""
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.Proxy{
+					HTTPProxy: "http://[fd00::1]:3128",
+					NoProxy:   "fd00::/48",
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
+			expectedAllowed: true,
 		},
 		{
-			name:      "Azure update region",
-			oldObject: validAzureClusterDeployment(),
+			name: "API URL override with IPv6 address",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.Region = ""
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://[fd00::5]:6443"
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: false,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
 		},
-		// TODO: ensure Azure clusterDeployments have necessary info for
-		// machine sets
-		// {
-		// 	name: "not setting instance type on Azure machine pool",
-		// 	newObject: func() *hivev1.ClusterDeployment {
-		// 		cd := validAzureClusterDeployment()
-		// 		cd.Spec.Compute = []hivev1.MachinePool{
-		// 			{
-		// 				Name: "testmachinepool",
-		// 				Platform: hivev1.MachinePoolPlatform{
-		// 					Azure: &hivev1azure.MachinePool{
-		// 						InstanceType: "",
-		// 					},
-		// 				},
-		// 			},
-		// 		}
-		// 		return cd
-		// 	}(),
-		// 	operation:       admissionv1beta1.Create,
-		// 	expectedAllowed: false,
-		// },
 		{
-			name: "create with two cloud platforms",
+			name: "API URL override with unbracketed IPv6 address",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				awsCD := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS = awsCD.Spec.Platform.AWS
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "https://fd00::5:6443"
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "create with no cloud platforms",
+			name: "API URL override without host",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure = nil
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.APIURLOverride = "api.example.com:6443"
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "ingress with serving certificate",
+			name: "post-install jobs valid",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].ServingCertificate = "test-serving-cert"
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd := validAWSClusterDeployment()
+				cd.Spec.PostInstallJobs = []hivev1.PostInstallJob{
+					{Name: "register", Image: "example.com/agent:latest", Args: []string{"--register"}},
+					{Name: "scan", Image: "example.com/scanner:latest", Timeout: &metav1.Duration{Duration: 30 * time.Minute}},
 				}
 				return cd
 			}(),
@@ -676,15 +1695,12 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: true,
 		},
 		{
-			name: "ingress with missing serving certificate",
+			name: "post-install jobs with duplicate names",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].ServingCertificate = "missing-serving-cert"
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd := validAWSClusterDeployment()
+				cd.Spec.PostInstallJobs = []hivev1.PostInstallJob{
+					{Name: "register", Image: "example.com/agent:latest"},
+					{Name: "register", Image: "example.com/scanner:latest"},
 				}
 				return cd
 			}(),
@@ -692,42 +1708,32 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name: "valid serving certificate",
+			name: "post-install job with invalid name",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
-				}
+				cd.Spec.PostInstallJobs = []hivev1.PostInstallJob{{Name: "Register_Agent", Image: "example.com/agent:latest"}}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without name",
+			name: "post-install job without image",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
-				}
+				cd.Spec.PostInstallJobs = []hivev1.PostInstallJob{{Name: "register"}}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without secret reference",
+			name: "proxy trusted CA without name",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name: "test-serving-cert",
-					},
+				cd.Spec.Proxy = &hivev1.Proxy{
+					HTTPSProxy: "https://proxy.example.com:3129",
+					TrustedCA:  &corev1.LocalObjectReference{},
 				}
 				return cd
 			}(),
@@ -735,20 +1741,61 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name: "InstallConfig is missing",
+			name:            "agent bare metal create valid",
+			newObject:       validAgentBareMetalClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "agent bare metal without cluster install",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning.InstallConfigSecretRef.Name = ""
+				cd := validAgentBareMetalClusterDeployment()
+				cd.Spec.ClusterInstallRef = nil
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name:            "valid GCP clusterdeployment",
-			newObject:       validGCPClusterDeployment(),
+			name: "agent bare metal with invalid VIP",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAgentBareMetalClusterDeployment()
+				cd.Spec.Platform.AgentBareMetal.APIVIP = "api.example.com"
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
+		},
+		{
+			name: "cluster install without kind",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAgentBareMetalClusterDeployment()
+				cd.Spec.ClusterInstallRef.Kind = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "cluster install with provisioning",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAgentBareMetalClusterDeployment()
+				cd.Spec.Provisioning = clusterDeploymentTemplate().Spec.Provisioning
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test changing cluster install",
+			oldObject: validAgentBareMetalClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAgentBareMetalClusterDeployment()
+				cd.Spec.ClusterInstallRef.Name = "other"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
 		},
 		{
 			name: "Provisioning is missing",
@@ -798,6 +1845,196 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Delete,
 			expectedAllowed: true,
 		},
+		{
+			name:             "Test delete with delete protection enabled",
+			oldObject:        validAWSClusterDeployment(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete with delete protection enabled and protected delete annotation false",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "false"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete with delete protection enabled and protected delete annotation not a bool",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "disabled"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete with delete protection enabled and protected delete annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete with delete protection disabled by annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.DeleteProtectionAnnotation] = constants.DeleteProtectionDisabled
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  true,
+		},
+		{
+			name: "Test delete with delete protection annotation not disabled",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.DeleteProtectionAnnotation] = "false"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete with delete protection disabled by annotation and protected delete annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 2)
+				}
+				cd.Annotations[constants.DeleteProtectionAnnotation] = constants.DeleteProtectionDisabled
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete of unclaimed pool cluster with delete protection enabled",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace: "pool-namespace",
+					PoolName:  "pool",
+				}
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  true,
+		},
+		{
+			name: "Test delete of claimed pool cluster with delete protection enabled",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace: "pool-namespace",
+					PoolName:  "pool",
+					ClaimName: "claim",
+				}
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete of pool cluster with protected delete annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace: "pool-namespace",
+					PoolName:  "pool",
+				}
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete by hive controllers with protected delete annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace: "pool-namespace",
+					PoolName:  "pool",
+				}
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			username:         hiveControllersTestUser,
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
+		{
+			name: "Test delete of claimed cluster by hive controllers when claim expires",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+					Namespace: "pool-namespace",
+					PoolName:  "pool",
+					ClaimName: "claim",
+				}
+				return cd
+			}(),
+			operation:        admissionv1beta1.Delete,
+			username:         hiveControllersTestUser,
+			deleteProtection: true,
+			expectedAllowed:  true,
+		},
+		{
+			name:             "Test delete by hive controllers with delete protection enabled",
+			oldObject:        validAWSClusterDeployment(),
+			operation:        admissionv1beta1.Delete,
+			username:         hiveControllersTestUser,
+			deleteProtection: true,
+			expectedAllowed:  true,
+		},
+		{
+			name:             "Test delete by other service account with delete protection enabled",
+			oldObject:        validAWSClusterDeployment(),
+			operation:        admissionv1beta1.Delete,
+			username:         "system:serviceaccount:other-namespace:hive-controllers",
+			deleteProtection: true,
+			expectedAllowed:  false,
+		},
 		{
 			name:            "Test delete on OpenShift 3.11",
 			oldObject:       nil,
@@ -824,6 +2061,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			data := ClusterDeploymentValidatingAdmissionHook{
 				decoder:             createDecoder(t),
 				validManagedDomains: validTestManagedDomains,
+				deleteProtection:    tc.deleteProtection,
+				namespacePerCluster: tc.namespacePerCluster,
+				hiveControllersUser: hiveControllersTestUser,
 			}
 
 			if tc.gvr == nil {
@@ -851,6 +2091,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 				OldObject: runtime.RawExtension{
 					Raw: tc.oldObjectRaw,
 				},
+				UserInfo: authenticationv1.UserInfo{
+					Username: tc.username,
+				},
 			}
 
 			// Act
@@ -3,6 +3,8 @@ package validatingwebhooks
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -10,12 +12,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/cron"
 )
 
 const (
@@ -29,13 +34,16 @@ const (
 
 // ClusterPoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterPoolValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder         *admission.Decoder
+	admissionPolicy *admissionPolicyReviewer
 }
 
 // NewClusterPoolValidatingAdmissionHook constructs a new ClusterPoolValidatingAdmissionHook
 func NewClusterPoolValidatingAdmissionHook(decoder *admission.Decoder) *ClusterPoolValidatingAdmissionHook {
+	logger := log.WithField("validating_webhook", "clusterpool")
 	return &ClusterPoolValidatingAdmissionHook{
-		decoder: decoder,
+		decoder:         decoder,
+		admissionPolicy: newAdmissionPolicyReviewerFromEnv(logger),
 	}
 }
 
@@ -96,6 +104,8 @@ func (a *ClusterPoolValidatingAdmissionHook) Validate(admissionSpec *admissionv1
 		return a.validateCreate(admissionSpec)
 	case admissionv1beta1.Update:
 		return a.validateUpdate(admissionSpec)
+	case admissionv1beta1.Delete:
+		return a.validateDelete(admissionSpec)
 	default:
 		contextLogger.Info("Successful validation")
 		return &admissionv1beta1.AdmissionResponse{
@@ -178,6 +188,18 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
+	allErrs = append(allErrs, validateFlavors(specPath.Child("flavors"), newObject.Spec.Flavors)...)
+	allErrs = append(allErrs, validateRegions(specPath.Child("regions"), newObject.Spec.Regions)...)
+	allErrs = append(allErrs, validateClaimLifetime(specPath.Child("claimLifetime"), newObject.Spec.ClaimLifetime)...)
+	allErrs = append(allErrs, validateClaimReadiness(specPath.Child("claimReadiness"), newObject.Spec.ClaimReadiness)...)
+	allErrs = append(allErrs, validateHibernationConfig(specPath.Child("hibernationConfig"), newObject.Spec.HibernationConfig)...)
+	if age := newObject.Spec.MaxClusterAge; age != nil && age.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxClusterAge"), age.Duration.String(), "must be positive"))
+	}
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateSchedules(specPath.Child("schedules"), newObject.Spec.Schedules, newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateUpdateStrategy(specPath.Child("updateStrategy"), newObject.Spec.UpdateStrategy)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -187,6 +209,10 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 		}
 	}
 
+	if r := a.admissionPolicy.review(admissionSpec, contextLogger); r != nil {
+		return r
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -238,6 +264,18 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateInventory(specPath.Child("inventory"), newObject.Spec.Inventory)...)
+	allErrs = append(allErrs, validateFlavors(specPath.Child("flavors"), newObject.Spec.Flavors)...)
+	allErrs = append(allErrs, validateRegions(specPath.Child("regions"), newObject.Spec.Regions)...)
+	allErrs = append(allErrs, validateClaimLifetime(specPath.Child("claimLifetime"), newObject.Spec.ClaimLifetime)...)
+	allErrs = append(allErrs, validateClaimReadiness(specPath.Child("claimReadiness"), newObject.Spec.ClaimReadiness)...)
+	allErrs = append(allErrs, validateHibernationConfig(specPath.Child("hibernationConfig"), newObject.Spec.HibernationConfig)...)
+	if age := newObject.Spec.MaxClusterAge; age != nil && age.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxClusterAge"), age.Duration.String(), "must be positive"))
+	}
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateSchedules(specPath.Child("schedules"), newObject.Spec.Schedules, newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateUpdateStrategy(specPath.Child("updateStrategy"), newObject.Spec.UpdateStrategy)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +292,259 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+// validateDelete specifically validates delete operations for ClusterPool objects. A pool protected from deletion can
+// only be deleted once it has been drained of its unclaimed clusters, so that they are not deleted by accident.
+func (a *ClusterPoolValidatingAdmissionHook) validateDelete(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"method":    "validateDelete",
+	})
+
+	// If running on OpenShift 3.11, OldObject will not be populated. All we can do is accept the DELETE request.
+	if len(request.OldObject.Raw) == 0 {
+		logger.Info("Cannot validate the DELETE since OldObject is empty")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	oldObject := &hivev1.ClusterPool{}
+	if err := a.decoder.DecodeRaw(request.OldObject, oldObject); err != nil {
+		logger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	logger.Data["object.Name"] = oldObject.Name
+
+	allErrs := field.ErrorList{}
+	annotationPath := field.NewPath("metadata", "annotations", constants.ProtectedDeleteAnnotation)
+	if value := oldObject.Annotations[constants.ProtectedDeleteAnnotation]; value != "" {
+		if enabled, err := strconv.ParseBool(value); !enabled || err != nil {
+			logger.WithField(constants.ProtectedDeleteAnnotation, value).Info("Protected Delete annotation present but not set to true")
+		} else if !oldObject.Spec.Draining {
+			allErrs = append(allErrs, field.Invalid(annotationPath, value, "cannot delete until the pool is draining, or the annotation is removed"))
+		} else if oldObject.Status.Size > 0 {
+			allErrs = append(allErrs, field.Invalid(annotationPath, value, fmt.Sprintf("cannot delete until the %d unclaimed clusters of the pool are drained, or the annotation is removed", oldObject.Status.Size)))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// validateInventory validates that the entries of the inventory of a ClusterPool name distinct
+// ClusterDeploymentCustomizations.
+func validateInventory(path *field.Path, inventory []hivev1.InventoryEntry) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, entry := range inventory {
+		entryPath := path.Index(i)
+		if entry.Name == "" {
+			allErrs = append(allErrs, field.Required(entryPath.Child("name"), "must specify a ClusterDeploymentCustomization"))
+			continue
+		}
+		if names.Has(entry.Name) {
+			allErrs = append(allErrs, field.Duplicate(entryPath.Child("name"), entry.Name))
+		}
+		names.Insert(entry.Name)
+	}
+	return allErrs
+}
+
+// validateFlavors validates that the flavors of a ClusterPool have distinct names and positive weights, and that their
+// platforms are valid.
+func validateFlavors(path *field.Path, flavors []hivev1.ClusterPoolFlavor) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, flavor := range flavors {
+		flavorPath := path.Index(i)
+		if flavor.Name == "" {
+			allErrs = append(allErrs, field.Required(flavorPath.Child("name"), "must specify a name"))
+		} else if names.Has(flavor.Name) {
+			allErrs = append(allErrs, field.Duplicate(flavorPath.Child("name"), flavor.Name))
+		}
+		names.Insert(flavor.Name)
+		if flavor.Weight < 1 {
+			allErrs = append(allErrs, field.Invalid(flavorPath.Child("weight"), flavor.Weight, "must be positive"))
+		}
+		if flavor.Platform != nil {
+			allErrs = append(allErrs, validateClusterPlatform(flavorPath.Child("platform"), *flavor.Platform)...)
+		}
+	}
+	return allErrs
+}
+
+// validateRegions validates that the regions of a ClusterPool have distinct names, and that their ClusterImageSets are
+// named.
+func validateRegions(path *field.Path, regions []hivev1.ClusterPoolRegion) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, region := range regions {
+		regionPath := path.Index(i)
+		if region.Name == "" {
+			allErrs = append(allErrs, field.Required(regionPath.Child("name"), "must specify a name"))
+		} else if names.Has(region.Name) {
+			allErrs = append(allErrs, field.Duplicate(regionPath.Child("name"), region.Name))
+		}
+		names.Insert(region.Name)
+		if region.ImageSetRef != nil && region.ImageSetRef.Name == "" {
+			allErrs = append(allErrs, field.Required(regionPath.Child("imageSetRef", "name"), "must specify a name"))
+		}
+	}
+	return allErrs
+}
+
+// validateClaimLifetime validates that the claim lifetimes of a ClusterPool are positive, and that the default lifetime
+// is not longer than the maximum lifetime.
+func validateClaimLifetime(path *field.Path, claimLifetime *hivev1.ClusterPoolClaimLifetime) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if claimLifetime == nil {
+		return allErrs
+	}
+	if d := claimLifetime.Default; d != nil && d.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("default"), d.Duration.String(), "must be positive"))
+	}
+	if m := claimLifetime.Maximum; m != nil && m.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maximum"), m.Duration.String(), "must be positive"))
+	}
+	if d, m := claimLifetime.Default, claimLifetime.Maximum; d != nil && m != nil && d.Duration > m.Duration {
+		allErrs = append(allErrs, field.Invalid(path.Child("default"), d.Duration.String(), "must not be longer than the maximum lifetime"))
+	}
+	return allErrs
+}
+
+// validateClaimReadiness validates that the syncsets of the claim readiness of a ClusterPool are named.
+func validateClaimReadiness(path *field.Path, claimReadiness *hivev1.ClusterPoolClaimReadiness) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if claimReadiness == nil {
+		return allErrs
+	}
+	for i, name := range claimReadiness.SyncSets {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("syncSets").Index(i), "must specify a name"))
+		}
+	}
+	for i, name := range claimReadiness.SelectorSyncSets {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("selectorSyncSets").Index(i), "must specify a name"))
+		}
+	}
+	return allErrs
+}
+
+// validateAutoscaling validates that the sizes of an autoscaled ClusterPool are not negative, that the minimum size is
+// not larger than the maximum size, and that the replenish window is positive.
+func validateAutoscaling(path *field.Path, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if autoscaling == nil {
+		return allErrs
+	}
+	if autoscaling.MinSize < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minSize"), autoscaling.MinSize, "must not be negative"))
+	}
+	if autoscaling.MaxSize < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), autoscaling.MaxSize, "must not be negative"))
+	}
+	if autoscaling.MinSize > autoscaling.MaxSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("minSize"), autoscaling.MinSize, "must not be larger than maxSize"))
+	}
+	if w := autoscaling.ReplenishWindow; w != nil && w.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("replenishWindow"), w.Duration.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+// validateUpdateStrategy validates that the limits of the update strategy of a ClusterPool are not negative, and that
+// at least one of them is set so that outdated clusters can be replaced.
+func validateUpdateStrategy(path *field.Path, strategy *hivev1.ClusterPoolUpdateStrategy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy == nil {
+		return allErrs
+	}
+	if strategy.MaxUnavailable < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), strategy.MaxUnavailable, "must not be negative"))
+	}
+	if strategy.MaxSurge < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSurge"), strategy.MaxSurge, "must not be negative"))
+	}
+	if strategy.MaxUnavailable == 0 && strategy.MaxSurge == 0 {
+		allErrs = append(allErrs, field.Invalid(path, strategy, "at least one of maxUnavailable and maxSurge must be positive"))
+	}
+	return allErrs
+}
+
+// validateHibernationConfig validates that the hibernation schedule of a ClusterPool consists of valid cron
+// expressions, and that its time zone is known.
+func validateHibernationConfig(path *field.Path, hibernationConfig *hivev1.ClusterPoolHibernationConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hibernationConfig == nil || hibernationConfig.Schedule == nil {
+		return allErrs
+	}
+	schedulePath := path.Child("schedule")
+	schedule := hibernationConfig.Schedule
+	if _, err := cron.Parse(schedule.Hibernate); err != nil {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("hibernate"), schedule.Hibernate, err.Error()))
+	}
+	if _, err := cron.Parse(schedule.Resume); err != nil {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("resume"), schedule.Resume, err.Error()))
+	}
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("timeZone"), schedule.TimeZone, "unknown time zone"))
+		}
+	}
+	return allErrs
+}
+
+// validateSchedules validates that the size schedules of a ClusterPool have distinct names, start on valid cron
+// expressions in known time zones, and have sizes that are not negative. An autoscaled ClusterPool cannot have size
+// schedules.
+func validateSchedules(path *field.Path, schedules []hivev1.ClusterPoolSizeSchedule, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(schedules) > 0 && autoscaling != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "must not be set for an autoscaled pool"))
+	}
+	names := sets.NewString()
+	for i, schedule := range schedules {
+		schedulePath := path.Index(i)
+		if schedule.Name == "" {
+			allErrs = append(allErrs, field.Required(schedulePath.Child("name"), "must specify a name"))
+		} else if names.Has(schedule.Name) {
+			allErrs = append(allErrs, field.Duplicate(schedulePath.Child("name"), schedule.Name))
+		}
+		names.Insert(schedule.Name)
+		if _, err := cron.Parse(schedule.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("start"), schedule.Start, err.Error()))
+		}
+		if schedule.Size < 0 {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("size"), schedule.Size, "must not be negative"))
+		}
+		if schedule.TimeZone != "" {
+			if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+				allErrs = append(allErrs, field.Invalid(schedulePath.Child("timeZone"), schedule.TimeZone, "unknown time zone"))
+			}
+		}
+	}
+	return allErrs
+}
@@ -3,6 +3,7 @@ package validatingwebhooks
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,6 +17,7 @@ import (
 	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
 	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 func clusterPoolTemplate() *hivev1.ClusterPool {
@@ -210,6 +212,352 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "create with inventory",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc2"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with unnamed inventory entry",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: ""}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with duplicate inventory entries",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Inventory = []hivev1.InventoryEntry{{Name: "cdc1"}, {Name: "cdc1"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with claim lifetimes",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{
+					Default: &metav1.Duration{Duration: 1 * time.Hour},
+					Maximum: &metav1.Duration{Duration: 3 * time.Hour},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with negative claim lifetime",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{
+					Maximum: &metav1.Duration{Duration: -1 * time.Hour},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with default claim lifetime longer than maximum",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{
+					Default: &metav1.Duration{Duration: 4 * time.Hour},
+					Maximum: &metav1.Duration{Duration: 3 * time.Hour},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with hibernation schedule",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationConfig = &hivev1.ClusterPoolHibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						Hibernate: "0 19 * * mon-fri",
+						Resume:    "0 7 * * mon-fri",
+						TimeZone:  "Europe/Berlin",
+					},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with invalid hibernation schedule",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationConfig = &hivev1.ClusterPoolHibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						Hibernate: "0 25 * * *",
+						Resume:    "0 7 * * *",
+					},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with unknown hibernation time zone",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationConfig = &hivev1.ClusterPoolHibernationConfig{
+					Schedule: &hivev1.HibernationSchedule{
+						Hibernate: "0 19 * * *",
+						Resume:    "0 7 * * *",
+						TimeZone:  "Nowhere/Special",
+					},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with maximum cluster age",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.MaxClusterAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with zero maximum cluster age",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.MaxClusterAge = &metav1.Duration{}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize:         1,
+					MaxSize:         10,
+					ReplenishWindow: &metav1.Duration{Duration: 2 * time.Hour},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with autoscaling minimum size larger than maximum",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize: 5,
+					MaxSize: 2,
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with size schedules",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Schedules = []hivev1.ClusterPoolSizeSchedule{
+					{Name: "day", Start: "0 8 * * mon-fri", Size: 20, TimeZone: "Europe/Berlin"},
+					{Name: "night", Start: "0 18 * * mon-fri", Size: 2, TimeZone: "Europe/Berlin"},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with duplicate size schedule names",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Schedules = []hivev1.ClusterPoolSizeSchedule{
+					{Name: "day", Start: "0 8 * * *", Size: 20},
+					{Name: "day", Start: "0 18 * * *", Size: 2},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with invalid size schedule start",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Schedules = []hivev1.ClusterPoolSizeSchedule{
+					{Name: "day", Start: "0 25 * * *", Size: 20},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with unknown size schedule time zone",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Schedules = []hivev1.ClusterPoolSizeSchedule{
+					{Name: "day", Start: "0 8 * * *", Size: 20, TimeZone: "Nowhere/Special"},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with size schedules and autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: 1, MaxSize: 10}
+				pool.Spec.Schedules = []hivev1.ClusterPoolSizeSchedule{
+					{Name: "day", Start: "0 8 * * *", Size: 20},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with flavors",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				westPlatform := pool.Spec.Platform.DeepCopy()
+				westPlatform.AWS.Region = "us-west-2"
+				pool.Spec.Flavors = []hivev1.ClusterPoolFlavor{
+					{Name: "east", Weight: 2},
+					{Name: "west", Weight: 1, Platform: westPlatform},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with duplicate flavor names",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Flavors = []hivev1.ClusterPoolFlavor{
+					{Name: "east", Weight: 1},
+					{Name: "east", Weight: 1},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with zero flavor weight",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Flavors = []hivev1.ClusterPoolFlavor{{Name: "east"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with invalid flavor platform",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Flavors = []hivev1.ClusterPoolFlavor{{Name: "empty", Weight: 1, Platform: &hivev1.Platform{}}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with regions",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Regions = []hivev1.ClusterPoolRegion{
+					{Name: "us-east-1"},
+					{Name: "us-west-2", ImageSetRef: &hivev1.ClusterImageSetReference{Name: "west-image-set"}},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with duplicate region names",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Regions = []hivev1.ClusterPoolRegion{{Name: "us-east-1"}, {Name: "us-east-1"}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with unnamed claim readiness syncset",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimReadiness = &hivev1.ClusterPoolClaimReadiness{SyncSets: []string{"config", ""}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with update strategy",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.UpdateStrategy = &hivev1.ClusterPoolUpdateStrategy{MaxSurge: 1}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with empty update strategy",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.UpdateStrategy = &hivev1.ClusterPoolUpdateStrategy{}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with negative maxUnavailable",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.UpdateStrategy = &hivev1.ClusterPoolUpdateStrategy{MaxUnavailable: -1, MaxSurge: 1}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "valid GCP clusterdeployment",
 			newObject:       validGCPClusterPool(),
@@ -222,6 +570,49 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Delete,
 			expectedAllowed: true,
 		},
+		{
+			name: "delete protected pool that is not draining",
+			oldObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
+		{
+			name: "delete protected pool with unclaimed clusters",
+			oldObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+				pool.Spec.Draining = true
+				pool.Status.Size = 2
+				return pool
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
+		{
+			name: "delete protected pool that is drained",
+			oldObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+				pool.Spec.Draining = true
+				return pool
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "delete pool with protection disabled",
+			oldObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "false"}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
 	}
 
 	for _, tc := range cases {
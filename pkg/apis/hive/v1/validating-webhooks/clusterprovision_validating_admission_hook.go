@@ -27,10 +27,11 @@ const (
 
 var (
 	validProvisionStages = map[hivev1.ClusterProvisionStage]bool{
-		hivev1.ClusterProvisionStageInitializing: true,
-		hivev1.ClusterProvisionStageProvisioning: true,
-		hivev1.ClusterProvisionStageComplete:     true,
-		hivev1.ClusterProvisionStageFailed:       true,
+		hivev1.ClusterProvisionStageInitializing:   true,
+		hivev1.ClusterProvisionStageProvisioning:   true,
+		hivev1.ClusterProvisionStageComplete:       true,
+		hivev1.ClusterProvisionStageFailed:         true,
+		hivev1.ClusterProvisionStageDryRunComplete: true,
 	}
 
 	validProvisionStageValues = func() []string {
@@ -233,13 +234,15 @@ func validateClusterProvisionUpdate(old, new *hivev1.ClusterProvision) field.Err
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef.Name, old.Spec.ClusterDeploymentRef.Name, specPath.Child("clusterDeploymentRef", "name"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.PodSpec, old.Spec.PodSpec, specPath.Child("podSpec"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Attempt, old.Spec.Attempt, specPath.Child("attempt"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.DryRun, old.Spec.DryRun, specPath.Child("dryRun"))...)
 	if old.Spec.Stage != new.Spec.Stage {
 		badStageTransition := true
 		switch old.Spec.Stage {
 		case hivev1.ClusterProvisionStageInitializing:
 			badStageTransition = new.Spec.Stage == hivev1.ClusterProvisionStageComplete
 		case hivev1.ClusterProvisionStageProvisioning:
-			badStageTransition = new.Spec.Stage == hivev1.ClusterProvisionStageInitializing
+			badStageTransition = new.Spec.Stage == hivev1.ClusterProvisionStageInitializing ||
+				new.Spec.Stage == hivev1.ClusterProvisionStageDryRunComplete
 		}
 		if badStageTransition {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("stage"), new.Spec.Stage, fmt.Sprintf("cannot transition from %s to %s", old.Spec.Stage, new.Spec.Stage)))
@@ -300,6 +303,17 @@ func validateClusterProvisionSpecInvariants(spec *hivev1.ClusterProvisionSpec, f
 			allErrs = append(allErrs, field.Required(fldPath.Child("adminPasswordSecretRef"), fmt.Sprintf("admin password secret must be set for %s cluster", hivev1.ClusterProvisionStageComplete)))
 		}
 	}
+	if spec.Stage == hivev1.ClusterProvisionStageDryRunComplete {
+		if !spec.DryRun {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("stage"), spec.Stage, fmt.Sprintf("stage must not be %s if not a dry run", hivev1.ClusterProvisionStageDryRunComplete)))
+		}
+		if spec.DryRunConfigMapRef == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("dryRunConfigMapRef"), fmt.Sprintf("dry-run configmap must be set for %s cluster", hivev1.ClusterProvisionStageDryRunComplete)))
+		}
+	}
+	if spec.DryRunConfigMapRef != nil && spec.DryRunConfigMapRef.Name == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dryRunConfigMapRef", "name"), spec.DryRunConfigMapRef.Name, "dry-run configmap must have a non-empty name"))
+	}
 	if spec.ClusterID != nil && *spec.ClusterID == "" {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterID"), spec.ClusterID, "cluster ID must not be an empty string"))
 	}
@@ -158,6 +158,35 @@ func Test_ClusterProvisionAdmission_Validate_Create(t *testing.T) {
 			}(),
 			expectAllowed: true,
 		},
+		{
+			name: "dry run complete stage",
+			provision: func() *hivev1.ClusterProvision {
+				p := testClusterProvision()
+				p.Spec.Stage = hivev1.ClusterProvisionStageDryRunComplete
+				p.Spec.DryRun = true
+				p.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: "test-dry-run"}
+				return p
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "dry run complete stage when not a dry run",
+			provision: func() *hivev1.ClusterProvision {
+				p := testClusterProvision()
+				p.Spec.Stage = hivev1.ClusterProvisionStageDryRunComplete
+				p.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: "test-dry-run"}
+				return p
+			}(),
+		},
+		{
+			name: "missing dry-run configmap for dry run complete stage",
+			provision: func() *hivev1.ClusterProvision {
+				p := testClusterProvision()
+				p.Spec.Stage = hivev1.ClusterProvisionStageDryRunComplete
+				p.Spec.DryRun = true
+				return p
+			}(),
+		},
 		{
 			name: "bad stage",
 			provision: func() *hivev1.ClusterProvision {
@@ -315,6 +344,15 @@ func Test_ClusterProvisionAdmission_Validate_Update(t *testing.T) {
 				return p
 			}(),
 		},
+		{
+			name: "change dry run",
+			old:  testClusterProvision(),
+			new: func() *hivev1.ClusterProvision {
+				p := testClusterProvision()
+				p.Spec.DryRun = true
+				return p
+			}(),
+		},
 		{
 			name: "bad stage",
 			old:  testClusterProvision(),
@@ -526,6 +564,10 @@ func Test_ClusterProvisionAdmission_Validate_Update_StageTransition(t *testing.T
 			from: hivev1.ClusterProvisionStageProvisioning,
 			to:   hivev1.ClusterProvisionStageFailed,
 		},
+		{
+			from: hivev1.ClusterProvisionStageInitializing,
+			to:   hivev1.ClusterProvisionStageDryRunComplete,
+		},
 	}
 	for oldStage := range validProvisionStages {
 		for newStage := range validProvisionStages {
@@ -536,12 +578,16 @@ func Test_ClusterProvisionAdmission_Validate_Update_StageTransition(t *testing.T
 					cut.Initialize(nil, nil)
 					oldProvision := testCompletedClusterProvision()
 					oldProvision.Spec.Stage = oldStage
+					oldProvision.Spec.DryRun = true
+					oldProvision.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: "test-dry-run"}
 					oldAsJSON, err := json.Marshal(oldProvision)
 					if !assert.NoError(t, err, "unexpected error marshalling old provision") {
 						return
 					}
 					newProvision := testCompletedClusterProvision()
 					newProvision.Spec.Stage = newStage
+					newProvision.Spec.DryRun = true
+					newProvision.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: "test-dry-run"}
 					newAsJSON, err := json.Marshal(newProvision)
 					if !assert.NoError(t, err, "unexpected error marshalling new provision") {
 						return
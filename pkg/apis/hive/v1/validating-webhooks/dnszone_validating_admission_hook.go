@@ -1,10 +1,12 @@
 package validatingwebhooks
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -15,6 +17,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveclient "github.com/openshift/hive/pkg/client/clientset/versioned"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -25,7 +29,8 @@ const (
 
 // DNSZoneValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type DNSZoneValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder    *admission.Decoder
+	hiveClient hiveclient.Interface
 }
 
 // NewDNSZoneValidatingAdmissionHook constructs a new DNSZoneValidatingAdmissionHook
@@ -59,7 +64,12 @@ func (a *DNSZoneValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Confi
 		"version":  "v1",
 		"resource": "dnszonevalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	hiveClient, err := hiveclient.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return errors.Wrap(err, "could not create hive client")
+	}
+	a.hiveClient = hiveClient
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -168,6 +178,31 @@ func (a *DNSZoneValidatingAdmissionHook) validateCreate(admissionSpec *admission
 		}
 	}
 
+	if a.hiveClient != nil {
+		dnsZones, err := a.hiveClient.HiveV1().DNSZones(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			contextLogger.WithError(err).Error("could not list DNSZones")
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+					Message: err.Error(),
+				},
+			}
+		}
+		if claimant := controllerutils.ConflictingDNSZone(newObject, dnsZones.Items); claimant != nil {
+			message := fmt.Sprintf("Failed validation: zone %s is already claimed by DNSZone %s/%s", newObject.Spec.Zone, claimant.Namespace, claimant.Name)
+			contextLogger.Infof(message)
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusConflict, Reason: metav1.StatusReasonConflict,
+					Message: message,
+				},
+			}
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
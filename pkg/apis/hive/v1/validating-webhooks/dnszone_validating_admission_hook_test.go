@@ -3,13 +3,17 @@ package validatingwebhooks
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivefake "github.com/openshift/hive/pkg/client/clientset/versioned/fake"
 	"github.com/stretchr/testify/assert"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 )
 
 func TestDNSZoneValidatingResource(t *testing.T) {
@@ -35,7 +39,7 @@ func TestDNSZoneInitialize(t *testing.T) {
 	data := NewDNSZoneValidatingAdmissionHook(createDecoder(t))
 
 	// Act
-	err := data.Initialize(nil, nil)
+	err := data.Initialize(&rest.Config{}, nil)
 
 	// Assert
 	assert.Nil(t, err)
@@ -188,3 +192,111 @@ func TestDNSZoneValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSZoneValidateZoneConflict(t *testing.T) {
+	dnsZone := func(namespace, name, zone string, ownerUID types.UID) *hivev1.DNSZone {
+		dz := &hivev1.DNSZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: hivev1.DNSZoneSpec{
+				Zone: zone,
+			},
+		}
+		if ownerUID != "" {
+			controller := true
+			dz.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: hivev1.SchemeGroupVersion.String(),
+				Kind:       "ClusterDeployment",
+				Name:       name,
+				UID:        ownerUID,
+				Controller: &controller,
+			}}
+		}
+		return dz
+	}
+	deleted := func(dz *hivev1.DNSZone) *hivev1.DNSZone {
+		now := metav1.Now()
+		dz.DeletionTimestamp = &now
+		return dz
+	}
+
+	cases := []struct {
+		name            string
+		existing        []runtime.Object
+		newObject       *hivev1.DNSZone
+		expectedAllowed bool
+	}{
+		{
+			name:            "no existing zones",
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "different zone",
+			existing:        []runtime.Object{dnsZone("ns2", "zone2", "other.example.com", "owner2")},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "zone claimed by another owner",
+			existing:        []runtime.Object{dnsZone("ns2", "zone2", "blah.example.com", "owner2")},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "zone claimed by another owner with different case and trailing dot",
+			existing:        []runtime.Object{dnsZone("ns2", "zone2", "Blah.Example.com.", "owner2")},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "zone claimed by zone without owner",
+			existing:        []runtime.Object{dnsZone("ns2", "zone2", "blah.example.com", "")},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", ""),
+			expectedAllowed: false,
+		},
+		{
+			name:            "zone shared with same owner",
+			existing:        []runtime.Object{dnsZone("ns2", "zone2", "blah.example.com", "owner1")},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "zone claimed by deleted zone",
+			existing:        []runtime.Object{deleted(dnsZone("ns2", "zone2", "blah.example.com", "owner2"))},
+			newObject:       dnsZone("ns1", "zone1", "blah.example.com", "owner1"),
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewDNSZoneValidatingAdmissionHook(createDecoder(t))
+			data.hiveClient = hivefake.NewSimpleClientset(tc.existing...)
+			tc.newObject.CreationTimestamp = metav1.Time{}
+			newObjectRaw, _ := json.Marshal(tc.newObject)
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: admissionv1beta1.Create,
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "dnszones",
+				},
+				Object: runtime.RawExtension{
+					Raw: newObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
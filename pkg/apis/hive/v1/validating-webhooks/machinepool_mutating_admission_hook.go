@@ -0,0 +1,166 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// MachinePoolMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type MachinePoolMutatingAdmissionHook struct {
+	decoder         *admission.Decoder
+	clusterDefaults *hivev1.ClusterDefaultsConfig
+}
+
+// NewMachinePoolMutatingAdmissionHook constructs a new MachinePoolMutatingAdmissionHook
+func NewMachinePoolMutatingAdmissionHook(decoder *admission.Decoder) *MachinePoolMutatingAdmissionHook {
+	logger := log.WithField("mutatingWebhook", "machinepool")
+	return &MachinePoolMutatingAdmissionHook{
+		decoder:         decoder,
+		clusterDefaults: clusterDefaultsFromEnv(logger),
+	}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/machinepoolmutators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *MachinePoolMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "machinepoolmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the MachinePool CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "machinepoolmutators",
+		},
+		"machinepoolmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *MachinePoolMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "machinepoolmutator",
+	}).Info("Initializing mutation REST resource")
+
+	return nil // No initialization needed right now.
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// New MachinePools that omit their instance type or size are defaulted from the cluster defaults of HiveConfig.
+func (a *MachinePoolMutatingAdmissionHook) Admit(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !isMachinePoolRequest(request) || request.Operation != admissionv1beta1.Create || a.clusterDefaults == nil {
+		logger.Info("Skipping mutation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	pool := &hivev1.MachinePool{}
+	if err := a.decoder.DecodeRaw(request.Object, pool); err != nil {
+		logger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+	logger.Data["object.Name"] = pool.Name
+
+	patches := a.clusterDefaultPatches(pool, logger)
+	if len(patches) == 0 {
+		logger.Info("No mutation needed")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	patch, err := json.Marshal(patches)
+	if err != nil {
+		logger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// clusterDefaultPatches returns the patches setting the instance type and replicas omitted from the MachinePool to
+// the cluster defaults. The replicas are not defaulted for MachinePools that autoscale.
+func (a *MachinePoolMutatingAdmissionHook) clusterDefaultPatches(pool *hivev1.MachinePool, logger log.FieldLogger) []map[string]interface{} {
+	var patches []map[string]interface{}
+	platform, instanceType := machinePoolInstanceType(&pool.Spec.Platform)
+	if defaults := platformDefaults(a.clusterDefaults, platform); defaults != nil && defaults.InstanceType != "" && instanceType == "" {
+		logger.WithField("instanceType", defaults.InstanceType).Info("Defaulting instance type")
+		patches = append(patches, map[string]interface{}{
+			"op":    "add",
+			"path":  fmt.Sprintf("/spec/platform/%s/type", platform),
+			"value": defaults.InstanceType,
+		})
+	}
+	if replicas := a.clusterDefaults.MachinePoolReplicas; replicas != nil && pool.Spec.Replicas == nil && pool.Spec.Autoscaling == nil {
+		logger.WithField("replicas", *replicas).Info("Defaulting replicas")
+		patches = append(patches, map[string]interface{}{
+			"op":    "add",
+			"path":  "/spec/replicas",
+			"value": *replicas,
+		})
+	}
+	return patches
+}
+
+// machinePoolInstanceType returns the name of the platform of the machine pool and its instance type, or an empty
+// name if the platform has no cluster defaults.
+func machinePoolInstanceType(platform *hivev1.MachinePoolPlatform) (string, string) {
+	switch {
+	case platform.AWS != nil:
+		return "aws", platform.AWS.InstanceType
+	case platform.Azure != nil:
+		return "azure", platform.Azure.InstanceType
+	case platform.GCP != nil:
+		return "gcp", platform.GCP.InstanceType
+	case platform.IBMCloud != nil:
+		return "ibmcloud", platform.IBMCloud.InstanceType
+	}
+	return "", ""
+}
+
+func isMachinePoolRequest(request *admissionv1beta1.AdmissionRequest) bool {
+	return request.Resource.Group == machinePoolGroup &&
+		request.Resource.Version == machinePoolVersion &&
+		request.Resource.Resource == machinePoolResource
+}
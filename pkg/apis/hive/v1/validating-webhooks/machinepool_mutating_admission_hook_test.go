@@ -0,0 +1,113 @@
+package validatingwebhooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+)
+
+func TestMachinePoolAdmit(t *testing.T) {
+	clusterDefaults := &hivev1.ClusterDefaultsConfig{
+		MachinePoolReplicas: pointer.Int64Ptr(3),
+		Platforms: []hivev1.PlatformClusterDefaults{
+			{Platform: "aws", Region: "us-east-1", InstanceType: "m5.xlarge"},
+		},
+	}
+	cases := []struct {
+		name            string
+		spec            hivev1.MachinePoolSpec
+		clusterDefaults *hivev1.ClusterDefaultsConfig
+		operation       admissionv1beta1.Operation
+		expectedPatches []map[string]interface{}
+	}{
+		{
+			name: "no cluster defaults",
+			spec: hivev1.MachinePoolSpec{
+				Platform: hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{}},
+			},
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name: "cluster defaults",
+			spec: hivev1.MachinePoolSpec{
+				Platform: hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{}},
+			},
+			clusterDefaults: clusterDefaults,
+			operation:       admissionv1beta1.Create,
+			expectedPatches: []map[string]interface{}{
+				{"op": "add", "path": "/spec/platform/aws/type", "value": "m5.xlarge"},
+				{"op": "add", "path": "/spec/replicas", "value": float64(3)},
+			},
+		},
+		{
+			name: "cluster defaults not used when set",
+			spec: hivev1.MachinePoolSpec{
+				Replicas: pointer.Int64Ptr(1),
+				Platform: hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{InstanceType: "m5.large"}},
+			},
+			clusterDefaults: clusterDefaults,
+			operation:       admissionv1beta1.Create,
+		},
+		{
+			name: "replicas not defaulted when autoscaling",
+			spec: hivev1.MachinePoolSpec{
+				Autoscaling: &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 3},
+				Platform:    hivev1.MachinePoolPlatform{GCP: &hivev1gcp.MachinePool{}},
+			},
+			clusterDefaults: clusterDefaults,
+			operation:       admissionv1beta1.Create,
+		},
+		{
+			name: "update is not mutated",
+			spec: hivev1.MachinePoolSpec{
+				Platform: hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{}},
+			},
+			clusterDefaults: clusterDefaults,
+			operation:       admissionv1beta1.Update,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := NewMachinePoolMutatingAdmissionHook(createDecoder(t))
+			hook.clusterDefaults = tc.clusterDefaults
+			pool := &hivev1.MachinePool{Spec: tc.spec}
+			raw, err := json.Marshal(pool)
+			require.NoError(t, err, "could not marshal machine pool")
+			request := &admissionv1beta1.AdmissionRequest{
+				Namespace: "test-namespace",
+				Operation: tc.operation,
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "machinepools",
+				},
+				Object: runtime.RawExtension{Raw: raw},
+			}
+
+			response := hook.Admit(request)
+
+			assert.True(t, response.Allowed, "expected request to be allowed")
+			if tc.expectedPatches == nil {
+				assert.Nil(t, response.Patch, "expected no patch")
+				return
+			}
+			var patches []map[string]interface{}
+			require.NoError(t, json.Unmarshal(response.Patch, &patches), "could not unmarshal patch")
+			assert.Equal(t, tc.expectedPatches, patches, "unexpected patches")
+			if assert.NotNil(t, response.PatchType, "missing patch type") {
+				assert.Equal(t, admissionv1beta1.PatchTypeJSONPatch, *response.PatchType, "unexpected patch type")
+			}
+		})
+	}
+}
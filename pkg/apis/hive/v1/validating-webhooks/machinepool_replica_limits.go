@@ -0,0 +1,88 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/replicalimits"
+)
+
+// replicaLimitsFromEnv returns the machine pool replica limits configured in the environment, or nil if none are
+// configured.
+func replicaLimitsFromEnv(logger log.FieldLogger) *hivev1.MachinePoolReplicaLimitsConfig {
+	value, ok := os.LookupEnv(constants.MachinePoolReplicaLimitsEnvVar)
+	if !ok || value == "" {
+		return nil
+	}
+	config := &hivev1.MachinePoolReplicaLimitsConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		logger.WithError(err).Fatalf("Unable to parse %s", constants.MachinePoolReplicaLimitsEnvVar)
+	}
+	logger.Info("Machine pool replica limits enabled")
+	return config
+}
+
+// validateReplicaLimit validates that the MachinePool does not take the total replicas of the MachinePools of its
+// tenant over the limit of the tenant. An update that does not add replicas to the MachinePool is always allowed, so
+// that a tenant over its limit can still scale down.
+func (a *MachinePoolValidatingAdmissionHook) validateReplicaLimit(old, new *hivev1.MachinePool, logger log.FieldLogger) (field.ErrorList, error) {
+	if a.replicaLimits == nil {
+		return nil, nil
+	}
+	replicas := replicalimits.PoolReplicas(new)
+	if old != nil && replicas <= replicalimits.PoolReplicas(old) {
+		return nil, nil
+	}
+
+	namespace, err := a.kubeClient.CoreV1().Namespaces().Get(context.TODO(), new.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get namespace")
+	}
+	tenant, selector := replicalimits.Tenant(a.replicaLimits, namespace)
+	maxReplicas := replicalimits.MaxReplicas(a.replicaLimits, tenant)
+	if maxReplicas == nil {
+		return nil, nil
+	}
+
+	namespaces := []string{namespace.Name}
+	if selector != nil {
+		namespaceList, err := a.kubeClient.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list namespaces of tenant")
+		}
+		namespaces = namespaces[:0]
+		for _, ns := range namespaceList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	total := replicas
+	for _, ns := range namespaces {
+		pools, err := a.hiveClient.HiveV1().MachinePools(ns).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list machine pools of tenant")
+		}
+		total += replicalimits.TotalReplicas(pools.Items, types.NamespacedName{Namespace: new.Namespace, Name: new.Name})
+	}
+	logger = logger.WithFields(log.Fields{"tenant": tenant, "replicas": total, "maxReplicas": *maxReplicas})
+	if total <= *maxReplicas {
+		logger.Debug("machine pool is within the replica limit of its tenant")
+		return nil, nil
+	}
+	logger.Info("machine pool exceeds the replica limit of its tenant")
+	replicasPath := field.NewPath("spec", "replicas")
+	if new.Spec.Autoscaling != nil {
+		replicasPath = field.NewPath("spec", "autoscaling", "maxReplicas")
+	}
+	return field.ErrorList{field.Forbidden(replicasPath, fmt.Sprintf("the MachinePools of tenant %q would have %d replicas, over the limit of %d", tenant, total, *maxReplicas))}, nil
+}
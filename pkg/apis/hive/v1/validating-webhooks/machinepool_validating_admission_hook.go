@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/google/uuid"
+	pkgerrors "github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -14,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -21,9 +24,12 @@ import (
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	hivev1ibmcloud "github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	hivev1nutanix "github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+	hiveclient "github.com/openshift/hive/pkg/client/clientset/versioned"
 )
 
 const (
@@ -38,12 +44,19 @@ const (
 
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder       *admission.Decoder
+	kubeClient    kubernetes.Interface
+	hiveClient    hiveclient.Interface
+	replicaLimits *hivev1.MachinePoolReplicaLimitsConfig
 }
 
 // NewMachinePoolValidatingAdmissionHook constructs a new MachinePoolValidatingAdmissionHook
 func NewMachinePoolValidatingAdmissionHook(decoder *admission.Decoder) *MachinePoolValidatingAdmissionHook {
-	return &MachinePoolValidatingAdmissionHook{decoder: decoder}
+	logger := log.WithField("validatingWebhook", "machinepool")
+	return &MachinePoolValidatingAdmissionHook{
+		decoder:       decoder,
+		replicaLimits: replicaLimitsFromEnv(logger),
+	}
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
@@ -72,8 +85,20 @@ func (a *MachinePoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"version":  "v1",
 		"resource": "machinepoolvalidator",
 	}).Info("Initializing validation REST resource")
-
-	return nil // No initialization needed right now.
+	if a.replicaLimits == nil {
+		return nil // The clients are only needed to enforce replica limits.
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create kube client")
+	}
+	a.kubeClient = kubeClient
+	hiveClient, err := hiveclient.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create hive client")
+	}
+	a.hiveClient = hiveClient
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -158,6 +183,10 @@ func (a *MachinePoolValidatingAdmissionHook) validateCreateRequest(request *admi
 		}
 	}
 
+	if resp := a.replicaLimitResponse(request, nil, newObject, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -192,6 +221,10 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 		}
 	}
 
+	if resp := a.replicaLimitResponse(request, oldObject, newObject, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -199,6 +232,31 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 	}
 }
 
+// replicaLimitResponse returns the response rejecting the request if the MachinePool exceeds the replica limit of its
+// tenant, or nil if the request is within the limit.
+func (a *MachinePoolValidatingAdmissionHook) replicaLimitResponse(request *admissionv1beta1.AdmissionRequest, old, new *hivev1.MachinePool, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	allErrs, err := a.validateReplicaLimit(old, new, logger)
+	if err != nil {
+		logger.WithError(err).Error("failed to validate replica limit")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	if len(allErrs) > 0 {
+		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+	return nil
+}
+
 func (a *MachinePoolValidatingAdmissionHook) decode(raw runtime.RawExtension, logger log.FieldLogger) (*hivev1.MachinePool, *admissionv1beta1.AdmissionResponse) {
 	obj := &hivev1.MachinePool{}
 	if err := a.decoder.DecodeRaw(raw, obj); err != nil {
@@ -284,9 +342,19 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		allErrs = append(allErrs, validateGCPMachinePoolPlatformInvariants(p, platformPath.Child("gcp"))...)
 		numberOfMachineSets = len(p.Zones)
 	}
+	if p := spec.Platform.IBMCloud; p != nil {
+		platforms = append(platforms, "ibmcloud")
+		allErrs = append(allErrs, validateIBMCloudMachinePoolPlatformInvariants(p, platformPath.Child("ibmcloud"))...)
+		numberOfMachineSets = len(p.Zones)
+	}
+	if p := spec.Platform.Nutanix; p != nil {
+		platforms = append(platforms, "nutanix")
+		allErrs = append(allErrs, validateNutanixMachinePoolPlatformInvariants(p, platformPath.Child("nutanix"))...)
+	}
 	if p := spec.Platform.OpenStack; p != nil {
 		platforms = append(platforms, "openstack")
 		allErrs = append(allErrs, validateOpenStackMachinePoolPlatformInvariants(p, platformPath.Child("openstack"))...)
+		numberOfMachineSets = len(p.Zones)
 	}
 	if p := spec.Platform.VSphere; p != nil {
 		platforms = append(platforms, "vsphere")
@@ -361,6 +429,36 @@ func validateGCPMachinePoolPlatformInvariants(platform *hivev1gcp.MachinePool, f
 	return allErrs
 }
 
+func validateIBMCloudMachinePoolPlatformInvariants(platform *hivev1ibmcloud.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, zone := range platform.Zones {
+		if zone == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
+		}
+	}
+	if platform.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), "instance type is required"))
+	}
+	return allErrs
+}
+
+func validateNutanixMachinePoolPlatformInvariants(platform *hivev1nutanix.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform.NumCPUs <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpus"), platform.NumCPUs, "number of CPUs must be positive"))
+	}
+	if platform.NumCoresPerSocket <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("coresPerSocket"), platform.NumCoresPerSocket, "number of cores per socket must be positive"))
+	}
+	if platform.MemoryMiB <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), platform.MemoryMiB, "memory size must be positive"))
+	}
+	if platform.DiskSizeGiB <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("osDisk", "diskSizeGiB"), platform.DiskSizeGiB, "disk size must be positive"))
+	}
+	return allErrs
+}
+
 func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, zone := range platform.Zones {
@@ -384,6 +482,21 @@ func validateOpenStackMachinePoolPlatformInvariants(platform *hivev1openstack.Ma
 	if platform.Flavor == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("type"), "flavor name is required"))
 	}
+	for i, zone := range platform.Zones {
+		if zone == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
+		}
+	}
+	for i, id := range platform.AdditionalNetworkIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalNetworkIDs").Index(i), id, "network ID must be a UUID"))
+		}
+	}
+	for i, id := range platform.AdditionalSecurityGroupIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalSecurityGroupIDs").Index(i), id, "security group ID must be a UUID"))
+		}
+	}
 	return allErrs
 }
 
@@ -11,12 +11,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	hivev1ibmcloud "github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	hivev1nutanix "github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
+	hivev1openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
+	hivefake "github.com/openshift/hive/pkg/client/clientset/versioned/fake"
 )
 
 func Test_MachinePoolAdmission_Validate_Kind(t *testing.T) {
@@ -396,6 +401,52 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "explicit IBM Cloud zones",
+			provision: func() *hivev1.MachinePool {
+				pool := testIBMCloudMachinePool()
+				pool.Spec.Platform.IBMCloud.Zones = []string{"test-zone-1", "test-zone-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty IBM Cloud zone name",
+			provision: func() *hivev1.MachinePool {
+				pool := testIBMCloudMachinePool()
+				pool.Spec.Platform.IBMCloud.Zones = []string{""}
+				return pool
+			}(),
+		},
+		{
+			name: "missing IBM Cloud instance type",
+			provision: func() *hivev1.MachinePool {
+				pool := testIBMCloudMachinePool()
+				pool.Spec.Platform.IBMCloud.InstanceType = ""
+				return pool
+			}(),
+		},
+		{
+			name:          "valid Nutanix machine pool",
+			provision:     testNutanixMachinePool(),
+			expectAllowed: true,
+		},
+		{
+			name: "missing Nutanix CPUs",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.NumCPUs = 0
+				return pool
+			}(),
+		},
+		{
+			name: "negative Nutanix disk size",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.DiskSizeGiB = -1
+				return pool
+			}(),
+		},
 		{
 			name: "explicit Azure zones",
 			provision: func() *hivev1.MachinePool {
@@ -429,6 +480,62 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name:          "OpenStack pool",
+			provision:     testOpenStackMachinePool(),
+			expectAllowed: true,
+		},
+		{
+			name: "missing OpenStack flavor",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.Flavor = ""
+				return pool
+			}(),
+		},
+		{
+			name: "explicit OpenStack zones",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.Zones = []string{"test-zone-1", "test-zone-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty OpenStack zone name",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.Zones = []string{""}
+				return pool
+			}(),
+		},
+		{
+			name: "OpenStack additional networks and security groups",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.AdditionalNetworkIDs = []string{"0d7e7d3c-9e5e-4b8c-9d5a-2f3c4b5a6d7e"}
+				pool.Spec.Platform.OpenStack.AdditionalSecurityGroupIDs = []string{"6b1a3c2d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid OpenStack additional network ID",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.AdditionalNetworkIDs = []string{"my-network"}
+				return pool
+			}(),
+		},
+		{
+			name: "invalid OpenStack additional security group ID",
+			provision: func() *hivev1.MachinePool {
+				pool := testOpenStackMachinePool()
+				pool.Spec.Platform.OpenStack.AdditionalSecurityGroupIDs = []string{"my-security-group"}
+				return pool
+			}(),
+		},
 		{
 			name: "valid labels",
 			provision: func() *hivev1.MachinePool {
@@ -566,6 +673,147 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 	}
 }
 
+func Test_MachinePoolAdmission_Validate_ReplicaLimits(t *testing.T) {
+	replicaLimits := &hivev1.MachinePoolReplicaLimitsConfig{
+		TenantLabel:        "tenant",
+		DefaultMaxReplicas: pointer.Int64Ptr(6),
+		Tenants:            []hivev1.TenantReplicaLimit{{Name: "large", MaxReplicas: 20}},
+	}
+	tenantNamespace := func(name, tenant string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tenant": tenant}}}
+	}
+	poolWithReplicas := func(namespace, cdName string, replicas int64) *hivev1.MachinePool {
+		pool := testMachinePool()
+		pool.Namespace = namespace
+		pool.Name = fmt.Sprintf("%s-%s", cdName, defaultWorkerPoolName)
+		pool.Spec.ClusterDeploymentRef.Name = cdName
+		pool.Spec.Replicas = pointer.Int64Ptr(replicas)
+		return pool
+	}
+	namespaces := []runtime.Object{
+		tenantNamespace("test-namespace", "small"),
+		tenantNamespace("other-namespace", "small"),
+		tenantNamespace("large-namespace", "large"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled-namespace"}},
+	}
+	cases := []struct {
+		name          string
+		replicaLimits *hivev1.MachinePoolReplicaLimitsConfig
+		existing      []runtime.Object
+		old           *hivev1.MachinePool
+		new           *hivev1.MachinePool
+		expectAllowed bool
+	}{
+		{
+			name:          "no replica limits",
+			existing:      []runtime.Object{poolWithReplicas("other-namespace", "other-cluster", 6)},
+			new:           poolWithReplicas("test-namespace", "test-cluster", 3),
+			expectAllowed: true,
+		},
+		{
+			name:          "create within limit",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("other-namespace", "other-cluster", 3)},
+			new:           poolWithReplicas("test-namespace", "test-cluster", 3),
+			expectAllowed: true,
+		},
+		{
+			name:          "create over limit of tenant",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("other-namespace", "other-cluster", 4)},
+			new:           poolWithReplicas("test-namespace", "test-cluster", 3),
+		},
+		{
+			name:          "other tenants not counted",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("large-namespace", "other-cluster", 10)},
+			new:           poolWithReplicas("test-namespace", "test-cluster", 3),
+			expectAllowed: true,
+		},
+		{
+			name:          "tenant limit overrides default",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("large-namespace", "other-cluster", 10)},
+			new:           poolWithReplicas("large-namespace", "test-cluster", 10),
+			expectAllowed: true,
+		},
+		{
+			name:          "unlabeled namespace is its own tenant",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("other-namespace", "other-cluster", 6)},
+			new:           poolWithReplicas("unlabeled-namespace", "test-cluster", 6),
+			expectAllowed: true,
+		},
+		{
+			name:          "autoscaling max replicas counted",
+			replicaLimits: replicaLimits,
+			new: func() *hivev1.MachinePool {
+				pool := poolWithReplicas("test-namespace", "test-cluster", 0)
+				pool.Spec.Replicas = nil
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 8}
+				return pool
+			}(),
+		},
+		{
+			name:          "update scaling up over limit",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("test-namespace", "test-cluster", 3)},
+			old:           poolWithReplicas("test-namespace", "test-cluster", 3),
+			new:           poolWithReplicas("test-namespace", "test-cluster", 7),
+		},
+		{
+			name:          "update scaling up within limit",
+			replicaLimits: replicaLimits,
+			existing:      []runtime.Object{poolWithReplicas("test-namespace", "test-cluster", 3)},
+			old:           poolWithReplicas("test-namespace", "test-cluster", 3),
+			new:           poolWithReplicas("test-namespace", "test-cluster", 6),
+			expectAllowed: true,
+		},
+		{
+			name:          "update scaling down while over limit",
+			replicaLimits: replicaLimits,
+			existing: []runtime.Object{
+				poolWithReplicas("other-namespace", "other-cluster", 6),
+				poolWithReplicas("test-namespace", "test-cluster", 5),
+			},
+			old:           poolWithReplicas("test-namespace", "test-cluster", 5),
+			new:           poolWithReplicas("test-namespace", "test-cluster", 4),
+			expectAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewMachinePoolValidatingAdmissionHook(createDecoder(t))
+			cut.replicaLimits = tc.replicaLimits
+			cut.kubeClient = kubefake.NewSimpleClientset(namespaces...)
+			cut.hiveClient = hivefake.NewSimpleClientset(tc.existing...)
+			newAsJSON, err := json.Marshal(tc.new)
+			if !assert.NoError(t, err, "unexpected error marshalling new pool") {
+				return
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    machinePoolGroup,
+					Version:  machinePoolVersion,
+					Resource: machinePoolResource,
+				},
+				Operation: admissionv1beta1.Create,
+				Object:    runtime.RawExtension{Raw: newAsJSON},
+			}
+			if tc.old != nil {
+				oldAsJSON, err := json.Marshal(tc.old)
+				if !assert.NoError(t, err, "unexpected error marshalling old pool") {
+					return
+				}
+				request.Operation = admissionv1beta1.Update
+				request.OldObject = runtime.RawExtension{Raw: oldAsJSON}
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+		})
+	}
+}
+
 func testMachinePool() *hivev1.MachinePool {
 	cdName := "test-deployment"
 	return &hivev1.MachinePool{
@@ -608,6 +856,41 @@ func testAzureMachinePool() *hivev1.MachinePool {
 	return pool
 }
 
+func testIBMCloudMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		IBMCloud: &hivev1ibmcloud.MachinePool{
+			InstanceType: "bx2-4x16",
+		},
+	}
+	return pool
+}
+
+func testNutanixMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		Nutanix: &hivev1nutanix.MachinePool{
+			NumCPUs:           4,
+			NumCoresPerSocket: 1,
+			MemoryMiB:         16384,
+			OSDisk: hivev1nutanix.OSDisk{
+				DiskSizeGiB: 120,
+			},
+		},
+	}
+	return pool
+}
+
+func testOpenStackMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		OpenStack: &hivev1openstack.MachinePool{
+			Flavor: "test-flavor",
+		},
+	}
+	return pool
+}
+
 func validAWSMachinePoolPlatform() *hivev1aws.MachinePoolPlatform {
 	return &hivev1aws.MachinePoolPlatform{
 		InstanceType: "test-instance-type",
@@ -3,6 +3,8 @@ package validatingwebhooks
 import (
 	"net/http"
 
+	"github.com/blang/semver/v4"
+	pkgerrors "github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -25,6 +27,9 @@ const (
 // SelectorSyncSetValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type SelectorSyncSetValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	// dryRunner dry-runs the resources of SelectorSyncSets in a target cluster. It is nil unless the dry-run is
+	// enabled.
+	dryRunner *syncSetDryRunner
 }
 
 // NewSelectorSyncSetValidatingAdmissionHook constructs a new SelectorSyncSetValidatingAdmissionHook
@@ -58,7 +63,12 @@ func (a *SelectorSyncSetValidatingAdmissionHook) Initialize(kubeClientConfig *re
 		"version":  "v1",
 		"resource": "selectorsyncsetvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	dryRunner, err := newSyncSetDryRunner(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create selectorsyncset dry-runner")
+	}
+	a.dryRunner = dryRunner
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -157,8 +167,18 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec").Child("resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
+	allErrs = append(allErrs, validateJSONPatches(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, true, field.NewPath("spec", "resourceRefs"))...)
+	allErrs = append(allErrs, validateClusterDeploymentFieldSelector(newObject.Spec.ClusterDeploymentFieldSelector, field.NewPath("spec", "clusterDeploymentFieldSelector"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, field.NewPath("spec", "resourcesToDelete"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateResourceDeletionPolicy(newObject.Spec.ResourceDeletionPolicy, field.NewPath("spec", "resourceDeletionPolicy"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateReapplyInterval(newObject.Spec.ReapplyInterval, field.NewPath("spec", "reapplyInterval"))...)
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, a.dryRunner.validateSelectorSyncSet(newObject, nil, contextLogger)...)
+	}
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -198,14 +218,36 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		}
 	}
 
+	oldObject := &hivev1.SelectorSyncSet{}
+	if err := a.decoder.DecodeRaw(admissionSpec.OldObject, oldObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling OldObject: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
 	// Add the new data to the contextLogger
 	contextLogger.Data["object.Name"] = newObject.Name
 
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec", "resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
+	allErrs = append(allErrs, validateJSONPatches(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, true, field.NewPath("spec", "resourceRefs"))...)
+	allErrs = append(allErrs, validateClusterDeploymentFieldSelector(newObject.Spec.ClusterDeploymentFieldSelector, field.NewPath("spec", "clusterDeploymentFieldSelector"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, field.NewPath("spec", "resourcesToDelete"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateResourceDeletionPolicy(newObject.Spec.ResourceDeletionPolicy, field.NewPath("spec", "resourceDeletionPolicy"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateReapplyInterval(newObject.Spec.ReapplyInterval, field.NewPath("spec", "reapplyInterval"))...)
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, a.dryRunner.validateSelectorSyncSet(newObject, oldObject, contextLogger)...)
+	}
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -222,3 +264,25 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+// validateClusterDeploymentFieldSelector validates the version range and the power states of the field selector.
+func validateClusterDeploymentFieldSelector(selector *hivev1.ClusterDeploymentFieldSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if selector == nil {
+		return allErrs
+	}
+	if selector.VersionRange != "" {
+		if _, err := semver.ParseRange(selector.VersionRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("versionRange"), selector.VersionRange, err.Error()))
+		}
+	}
+	for i, state := range selector.PowerStates {
+		switch state {
+		case hivev1.RunningClusterPowerState, hivev1.HibernatingClusterPowerState:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("powerStates").Index(i), state,
+				[]string{string(hivev1.RunningClusterPowerState), string(hivev1.HibernatingClusterPowerState)}))
+		}
+	}
+	return allErrs
+}
@@ -108,6 +108,131 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			selectorSyncSet: testSecretReferenceSelectorSyncSet(),
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test valid resource reference",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourceRefs = []hivev1.SyncSetResourceReference{{Kind: "ConfigMap", Name: "manifests", Namespace: "manifests-ns"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource reference no namespace",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourceRefs = []hivev1.SyncSetResourceReference{{Kind: "ConfigMap", Name: "manifests"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid field selector",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ClusterDeploymentFieldSelector = &hivev1.ClusterDeploymentFieldSelector{
+					Platforms:    []string{"aws"},
+					Regions:      []string{"us-east-1"},
+					VersionRange: ">=4.8.0 <4.10.0",
+					PowerStates:  []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid field selector version range",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ClusterDeploymentFieldSelector = &hivev1.ClusterDeploymentFieldSelector{VersionRange: "newer than 4.8"}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid field selector power state",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ClusterDeploymentFieldSelector = &hivev1.ClusterDeploymentFieldSelector{PowerStates: []hivev1.ClusterPowerState{"Resuming"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid templated create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSetWithResources(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"{{ .ClusterName }}"},"data":{"env":"{{ index .Labels \"env\" }}"}}`)
+				ss.Spec.Templated = true
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "foo",
+					Patch:      `{"data":{"infraID":"{{ .InfraID }}"}}`,
+					PatchType:  "merge",
+				}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid templated resource update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSetWithResources(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"{{ .ClusterName "}}`)
+				ss.Spec.Templated = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid templated patch create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testPatchSelectorSyncSet("merge")
+				ss.Spec.Templated = true
+				ss.Spec.Patches[0].Patch = `{"data":{"infraID":"{{ .InfraID"}}`
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test untemplated resource with template syntax create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: testSelectorSyncSetWithResources(
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo"},"data":{"text":"{{ .ClusterName"}}`,
+			),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid resourcesToDelete create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{
+					{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "bar"},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resourcesToDelete no apiVersion update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{
+					{Kind: "ConfigMap", Namespace: "foo", Name: "bar"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test invalid SecretReference no source name create",
 			operation: admissionv1beta1.Create,
@@ -401,7 +526,7 @@ func testPatchSelectorSyncSet(patchType string) *hivev1.SelectorSyncSet {
 		SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
 			Patches: []hivev1.SyncObjectPatch{
 				{
-					Patch:     "blah",
+					Patch:     `[{"op": "remove", "path": "/metadata/labels/blah"}]`,
 					PatchType: "json",
 				},
 				{
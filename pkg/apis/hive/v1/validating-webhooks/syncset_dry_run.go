@@ -0,0 +1,229 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveclient "github.com/openshift/hive/pkg/client/clientset/versioned"
+	"github.com/openshift/hive/pkg/clusterselector"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	// syncSetDryRunTimeout bounds the requests to the target cluster, so that a slow cluster does not time out the
+	// admission request.
+	syncSetDryRunTimeout = 5 * time.Second
+
+	// syncSetDryRunFieldManager is the field manager of the dry-run applies, which is the field manager that the
+	// clustersync controller uses for server-side applies.
+	syncSetDryRunFieldManager = "hive"
+
+	syncSetDryRunControllerName hivev1.ControllerName = "syncsetdryrun"
+)
+
+// syncSetDryRunner dry-runs the resources of a syncset in one of the clusters that the syncset applies to, so that
+// resources the cluster would reject are rejected when the syncset is created or updated.
+type syncSetDryRunner struct {
+	hiveClient hiveclient.Interface
+	// remoteClient builds a client, and the RESTMapper of the client, for the cluster of a ClusterDeployment.
+	remoteClient func(cd *hivev1.ClusterDeployment) (client.Client, meta.RESTMapper, error)
+}
+
+// newSyncSetDryRunner returns a syncSetDryRunner when the dry-run of syncsets is enabled, and nil otherwise.
+func newSyncSetDryRunner(kubeClientConfig *rest.Config) (*syncSetDryRunner, error) {
+	if os.Getenv(constants.SyncSetDryRunEnvVar) != "true" {
+		return nil, nil
+	}
+	hiveClient, err := hiveclient.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "could not create hive client")
+	}
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	c, err := client.New(kubeClientConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "could not create client")
+	}
+	return &syncSetDryRunner{
+		hiveClient: hiveClient,
+		remoteClient: func(cd *hivev1.ClusterDeployment) (client.Client, meta.RESTMapper, error) {
+			cfg, err := remoteclient.NewBuilder(c, cd, syncSetDryRunControllerName).RESTConfig()
+			if err != nil {
+				return nil, nil, err
+			}
+			cfg.Timeout = syncSetDryRunTimeout
+			mapper, err := apiutil.NewDynamicRESTMapper(cfg)
+			if err != nil {
+				return nil, nil, err
+			}
+			remoteClient, err := client.New(cfg, client.Options{Mapper: mapper})
+			return remoteClient, mapper, err
+		},
+	}, nil
+}
+
+// validateSyncSet dry-runs the resources of the SyncSet in one of its clusters. The resources are not dry-run again
+// when an update leaves them unchanged.
+func (d *syncSetDryRunner) validateSyncSet(syncSet, oldSyncSet *hivev1.SyncSet, logger log.FieldLogger) field.ErrorList {
+	var oldSpec *hivev1.SyncSetCommonSpec
+	if oldSyncSet != nil {
+		oldSpec = &oldSyncSet.Spec.SyncSetCommonSpec
+	}
+	if d == nil || !shouldDryRun(&syncSet.ObjectMeta, &syncSet.Spec.SyncSetCommonSpec, oldSpec) {
+		return nil
+	}
+	var cds []hivev1.ClusterDeployment
+	for _, ref := range syncSet.Spec.ClusterDeploymentRefs {
+		cd, err := d.hiveClient.HiveV1().ClusterDeployments(syncSet.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				logger.WithError(err).WithField("clusterDeployment", ref.Name).Warn("could not get ClusterDeployment for dry-run")
+			}
+			continue
+		}
+		cds = append(cds, *cd)
+	}
+	return d.dryRunResources(syncSet.Spec.Resources, cds, logger)
+}
+
+// validateSelectorSyncSet dry-runs the resources of the SelectorSyncSet in one of the clusters it selects. The
+// resources are not dry-run again when an update leaves them unchanged.
+func (d *syncSetDryRunner) validateSelectorSyncSet(selectorSyncSet, oldSelectorSyncSet *hivev1.SelectorSyncSet, logger log.FieldLogger) field.ErrorList {
+	var oldSpec *hivev1.SyncSetCommonSpec
+	if oldSelectorSyncSet != nil {
+		oldSpec = &oldSelectorSyncSet.Spec.SyncSetCommonSpec
+	}
+	if d == nil || !shouldDryRun(&selectorSyncSet.ObjectMeta, &selectorSyncSet.Spec.SyncSetCommonSpec, oldSpec) {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&selectorSyncSet.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		// An invalid selector matches no clusters.
+		return nil
+	}
+	cdList, err := d.hiveClient.HiveV1().ClusterDeployments("").List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		logger.WithError(err).Warn("could not list ClusterDeployments for dry-run")
+		return nil
+	}
+	var cds []hivev1.ClusterDeployment
+	for i := range cdList.Items {
+		if clusterselector.Matches(selectorSyncSet.Spec.ClusterDeploymentFieldSelector, &cdList.Items[i]) {
+			cds = append(cds, cdList.Items[i])
+		}
+	}
+	return d.dryRunResources(selectorSyncSet.Spec.Resources, cds, logger)
+}
+
+// shouldDryRun returns true when the resources of a syncset that is not being deleted are new or have changed.
+// Templated resources are rendered for each cluster, so they are not dry-run.
+func shouldDryRun(objMeta *metav1.ObjectMeta, spec, oldSpec *hivev1.SyncSetCommonSpec) bool {
+	switch {
+	case objMeta.DeletionTimestamp != nil, spec.Templated, len(spec.Resources) == 0:
+		return false
+	case oldSpec != nil && reflect.DeepEqual(spec.Resources, oldSpec.Resources):
+		return false
+	}
+	return true
+}
+
+// dryRunTarget returns the first of the installed and reachable clusters, in the order of their namespaces and names,
+// or nil if there is none.
+func dryRunTarget(cds []hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	sort.Slice(cds, func(i, j int) bool {
+		if cds[i].Namespace != cds[j].Namespace {
+			return cds[i].Namespace < cds[j].Namespace
+		}
+		return cds[i].Name < cds[j].Name
+	})
+	for i, cd := range cds {
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil || cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+			continue
+		}
+		if unreachable, _ := remoteclient.Unreachable(&cd); unreachable {
+			continue
+		}
+		return &cds[i]
+	}
+	return nil
+}
+
+// dryRunResources server-side applies the resources in one of the clusters with the dry-run option. Only resources
+// that the cluster rejects as invalid are reported. A cluster that cannot be reached, and resources of kinds or in
+// namespaces that the cluster does not have yet, which another syncset may add, do not fail the validation.
+func (d *syncSetDryRunner) dryRunResources(resources []runtime.RawExtension, cds []hivev1.ClusterDeployment, logger log.FieldLogger) field.ErrorList {
+	allErrs := field.ErrorList{}
+	cd := dryRunTarget(cds)
+	if cd == nil {
+		logger.Debug("no installed and reachable cluster to dry-run syncset resources in")
+		return allErrs
+	}
+	logger = logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name)
+	remoteClient, mapper, err := d.remoteClient(cd)
+	if err != nil {
+		logger.WithError(err).Warn("could not connect to cluster to dry-run syncset resources")
+		return allErrs
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), syncSetDryRunTimeout)
+	defer cancel()
+	fldPath := field.NewPath("spec", "resources")
+	for i, resource := range resources {
+		u := &unstructured.Unstructured{}
+		// Resources that cannot be unmarshalled are reported by validateResource.
+		if err := json.Unmarshal(resource.Raw, u); err != nil {
+			continue
+		}
+		gvk := u.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			logger.WithError(err).WithField("resourceIndex", i).Info("skipping dry-run of resource of a kind that the cluster does not have")
+			continue
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && u.GetNamespace() == "" {
+			u.SetNamespace(corev1.NamespaceDefault)
+		}
+		err = remoteClient.Patch(ctx, u, client.Apply,
+			client.DryRunAll,
+			client.ForceOwnership,
+			client.FieldOwner(syncSetDryRunFieldManager),
+		)
+		switch {
+		case err == nil:
+		case errors.IsInvalid(err), errors.IsBadRequest(err):
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), strings.TrimSpace(fmt.Sprintf("%s %s", u.GetKind(), u.GetName())),
+				fmt.Sprintf("rejected by a dry-run in cluster %s/%s: %v", cd.Namespace, cd.Name, err)))
+		case errors.IsNotFound(err):
+			logger.WithError(err).WithField("resourceIndex", i).Info("skipping dry-run of resource in a namespace that the cluster does not have")
+		default:
+			logger.WithError(err).WithField("resourceIndex", i).Warn("could not dry-run resource")
+		}
+	}
+	return allErrs
+}
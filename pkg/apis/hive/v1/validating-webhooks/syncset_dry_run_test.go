@@ -0,0 +1,256 @@
+package validatingwebhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivefake "github.com/openshift/hive/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	dryRunCDName    = "test-cd"
+	dryRunNamespace = "test-namespace"
+)
+
+// fakeDryRunClient records the objects that are dry-run, and returns the error for the name of the object.
+type fakeDryRunClient struct {
+	client.Client
+	dryRun []string
+	errs   map[string]error
+}
+
+func (c *fakeDryRunClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	patchOpts := &client.PatchOptions{}
+	patchOpts.ApplyOptions(opts)
+	if patch != client.Apply || len(patchOpts.DryRun) != 1 || patchOpts.DryRun[0] != metav1.DryRunAll {
+		return fmt.Errorf("unexpected patch that is not a dry-run apply")
+	}
+	u := obj.(*unstructured.Unstructured)
+	c.dryRun = append(c.dryRun, u.GetNamespace()+"/"+u.GetName())
+	return c.errs[u.GetName()]
+}
+
+func dryRunRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	return mapper
+}
+
+func dryRunCD(installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dryRunNamespace,
+			Name:      dryRunCDName,
+			Labels:    map[string]string{"test-label": "test-value"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{Installed: installed},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+	}
+}
+
+func dryRunSyncSet(resources ...string) *hivev1.SyncSet {
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: dryRunNamespace, Name: "test-syncset"},
+		Spec: hivev1.SyncSetSpec{
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: dryRunCDName}},
+		},
+	}
+	for _, r := range resources {
+		syncSet.Spec.Resources = append(syncSet.Spec.Resources, runtime.RawExtension{Raw: []byte(r)})
+	}
+	return syncSet
+}
+
+const (
+	dryRunConfigMap         = `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test-cm"}}`
+	dryRunNamespaceResource = `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "test-ns"}}`
+	dryRunUnknownKind       = `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test-widget"}}`
+)
+
+func TestSyncSetDryRun(t *testing.T) {
+	invalidErr := errors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "test-cm", nil)
+	cases := []struct {
+		name             string
+		syncSet          *hivev1.SyncSet
+		oldSyncSet       *hivev1.SyncSet
+		cd               *hivev1.ClusterDeployment
+		connectErr       error
+		errs             map[string]error
+		expectedDryRun   []string
+		expectedErrCount int
+	}{
+		{
+			name:           "valid resource",
+			syncSet:        dryRunSyncSet(dryRunConfigMap),
+			cd:             dryRunCD(true),
+			expectedDryRun: []string{"default/test-cm"},
+		},
+		{
+			name:             "invalid resource",
+			syncSet:          dryRunSyncSet(dryRunConfigMap),
+			cd:               dryRunCD(true),
+			errs:             map[string]error{"test-cm": invalidErr},
+			expectedDryRun:   []string{"default/test-cm"},
+			expectedErrCount: 1,
+		},
+		{
+			name:             "bad request",
+			syncSet:          dryRunSyncSet(dryRunConfigMap),
+			cd:               dryRunCD(true),
+			errs:             map[string]error{"test-cm": errors.NewBadRequest("test bad request")},
+			expectedDryRun:   []string{"default/test-cm"},
+			expectedErrCount: 1,
+		},
+		{
+			name:           "namespace not found",
+			syncSet:        dryRunSyncSet(dryRunConfigMap),
+			cd:             dryRunCD(true),
+			errs:           map[string]error{"test-cm": errors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "default")},
+			expectedDryRun: []string{"default/test-cm"},
+		},
+		{
+			name:           "other error",
+			syncSet:        dryRunSyncSet(dryRunConfigMap),
+			cd:             dryRunCD(true),
+			errs:           map[string]error{"test-cm": errors.NewInternalError(fmt.Errorf("test error"))},
+			expectedDryRun: []string{"default/test-cm"},
+		},
+		{
+			name:           "cluster-scoped resource",
+			syncSet:        dryRunSyncSet(dryRunNamespaceResource),
+			cd:             dryRunCD(true),
+			expectedDryRun: []string{"/test-ns"},
+		},
+		{
+			name:           "unknown kind",
+			syncSet:        dryRunSyncSet(dryRunUnknownKind, dryRunConfigMap),
+			cd:             dryRunCD(true),
+			expectedDryRun: []string{"default/test-cm"},
+		},
+		{
+			name:    "cluster not installed",
+			syncSet: dryRunSyncSet(dryRunConfigMap),
+			cd:      dryRunCD(false),
+		},
+		{
+			name:    "cluster unreachable",
+			syncSet: dryRunSyncSet(dryRunConfigMap),
+			cd: func() *hivev1.ClusterDeployment {
+				cd := dryRunCD(true)
+				cd.Status.Conditions[0].Status = corev1.ConditionTrue
+				return cd
+			}(),
+		},
+		{
+			name:    "no cluster",
+			syncSet: dryRunSyncSet(dryRunConfigMap),
+		},
+		{
+			name:       "cannot connect to cluster",
+			syncSet:    dryRunSyncSet(dryRunConfigMap),
+			cd:         dryRunCD(true),
+			connectErr: fmt.Errorf("test connect error"),
+		},
+		{
+			name: "templated",
+			syncSet: func() *hivev1.SyncSet {
+				s := dryRunSyncSet(dryRunConfigMap)
+				s.Spec.Templated = true
+				return s
+			}(),
+			cd: dryRunCD(true),
+		},
+		{
+			name:       "resources unchanged",
+			syncSet:    dryRunSyncSet(dryRunConfigMap),
+			oldSyncSet: dryRunSyncSet(dryRunConfigMap),
+			cd:         dryRunCD(true),
+		},
+		{
+			name:           "resources changed",
+			syncSet:        dryRunSyncSet(dryRunConfigMap),
+			oldSyncSet:     dryRunSyncSet(),
+			cd:             dryRunCD(true),
+			expectedDryRun: []string{"default/test-cm"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var existing []runtime.Object
+			if tc.cd != nil {
+				existing = append(existing, tc.cd)
+			}
+			remoteClient := &fakeDryRunClient{errs: tc.errs}
+			dryRunner := &syncSetDryRunner{
+				hiveClient: hivefake.NewSimpleClientset(existing...),
+				remoteClient: func(cd *hivev1.ClusterDeployment) (client.Client, meta.RESTMapper, error) {
+					return remoteClient, dryRunRESTMapper(), tc.connectErr
+				},
+			}
+			errs := dryRunner.validateSyncSet(tc.syncSet, tc.oldSyncSet, log.WithField("test", t.Name()))
+			assert.Len(t, errs, tc.expectedErrCount, "unexpected validation errors")
+			assert.Equal(t, tc.expectedDryRun, remoteClient.dryRun, "unexpected resources dry-run")
+		})
+	}
+}
+
+func TestSelectorSyncSetDryRun(t *testing.T) {
+	cases := []struct {
+		name           string
+		selector       map[string]string
+		expectedDryRun []string
+	}{
+		{
+			name:           "selected cluster",
+			selector:       map[string]string{"test-label": "test-value"},
+			expectedDryRun: []string{"default/test-cm"},
+		},
+		{
+			name:     "no selected cluster",
+			selector: map[string]string{"test-label": "other-value"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			remoteClient := &fakeDryRunClient{}
+			dryRunner := &syncSetDryRunner{
+				hiveClient: hivefake.NewSimpleClientset(dryRunCD(true)),
+				remoteClient: func(cd *hivev1.ClusterDeployment) (client.Client, meta.RESTMapper, error) {
+					return remoteClient, dryRunRESTMapper(), nil
+				},
+			}
+			selectorSyncSet := &hivev1.SelectorSyncSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-selectorsyncset"},
+				Spec: hivev1.SelectorSyncSetSpec{
+					SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+						Resources: []runtime.RawExtension{{Raw: []byte(dryRunConfigMap)}},
+					},
+					ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: tc.selector},
+				},
+			}
+			errs := dryRunner.validateSelectorSyncSet(selectorSyncSet, nil, log.WithField("test", t.Name()))
+			assert.Empty(t, errs, "unexpected validation errors")
+			assert.Equal(t, tc.expectedDryRun, remoteClient.dryRun, "unexpected resources dry-run")
+		})
+	}
+}
@@ -1,22 +1,33 @@
 package validatingwebhooks
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"text/template"
+	"time"
 
+	pkgerrors "github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 )
 
 const (
@@ -43,6 +54,18 @@ var validPatchTypes = map[string]bool{
 
 var validPatchTypeSlice = []string{"json", "merge", "strategic"}
 
+// jsonPatchOpMembers are the members that each operation of a JSON patch requires, besides the op.
+var jsonPatchOpMembers = map[string][]string{
+	"add":     {"path", "value"},
+	"remove":  {"path"},
+	"replace": {"path", "value"},
+	"move":    {"from", "path"},
+	"copy":    {"from", "path"},
+	"test":    {"path", "value"},
+}
+
+var validJSONPatchOpSlice = []string{"add", "remove", "replace", "move", "copy", "test"}
+
 var (
 	validResourceApplyModes = map[hivev1.SyncSetResourceApplyMode]bool{
 		hivev1.UpsertResourceApplyMode: true,
@@ -56,11 +79,26 @@ var (
 		}
 		return v
 	}()
+
+	validResourceDeletionPolicies = map[hivev1.SyncSetResourceDeletionPolicy]bool{
+		hivev1.DeleteResourceDeletionPolicy: true,
+		hivev1.OrphanResourceDeletionPolicy: true,
+	}
+
+	validResourceDeletionPolicySlice = []string{
+		string(hivev1.DeleteResourceDeletionPolicy),
+		string(hivev1.OrphanResourceDeletionPolicy),
+	}
 )
 
 // SyncSetValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type SyncSetValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	// kubeClient creates the SubjectAccessReviews that check that users can get the source secrets in other
+	// namespaces.
+	kubeClient kubernetes.Interface
+	// dryRunner dry-runs the resources of SyncSets in a target cluster. It is nil unless the dry-run is enabled.
+	dryRunner *syncSetDryRunner
 }
 
 // NewSyncSetValidatingAdmissionHook constructs a new SyncSetValidatingAdmissionHook
@@ -94,7 +132,17 @@ func (a *SyncSetValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Confi
 		"version":  "v1",
 		"resource": "syncsetvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	kubeClient, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create kube client")
+	}
+	a.kubeClient = kubeClient
+	dryRunner, err := newSyncSetDryRunner(kubeClientConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "could not create syncset dry-runner")
+	}
+	a.dryRunner = dryRunner
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -193,9 +241,19 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec").Child("resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
+	allErrs = append(allErrs, validateJSONPatches(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
-	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, field.NewPath("spec", "resourcesToDelete"))...)
+	allErrs = append(allErrs, a.validateSourceSecretAccess(newObject.Spec.Secrets, nil, newObject.Namespace, admissionSpec.UserInfo, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, false, field.NewPath("spec", "resourceRefs"))...)
+	allErrs = append(allErrs, a.validateResourceRefAccess(newObject.Spec.ResourceRefs, nil, newObject.Namespace, admissionSpec.UserInfo, field.NewPath("spec", "resourceRefs"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateResourceDeletionPolicy(newObject.Spec.ResourceDeletionPolicy, field.NewPath("spec", "resourceDeletionPolicy"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateReapplyInterval(newObject.Spec.ReapplyInterval, field.NewPath("spec", "reapplyInterval"))...)
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, a.dryRunner.validateSyncSet(newObject, nil, contextLogger)...)
+	}
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -235,15 +293,37 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 		}
 	}
 
+	oldObject := &hivev1.SyncSet{}
+	if err := a.decoder.DecodeRaw(admissionSpec.OldObject, oldObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling OldObject: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
 	// Add the new data to the contextLogger
 	contextLogger.Data["object.Name"] = newObject.Name
 
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec", "resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
+	allErrs = append(allErrs, validateJSONPatches(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
-	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, field.NewPath("spec", "resourcesToDelete"))...)
+	allErrs = append(allErrs, a.validateSourceSecretAccess(newObject.Spec.Secrets, oldObject.Spec.Secrets, newObject.Namespace, admissionSpec.UserInfo, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateResourceRefs(newObject.Spec.ResourceRefs, false, field.NewPath("spec", "resourceRefs"))...)
+	allErrs = append(allErrs, a.validateResourceRefAccess(newObject.Spec.ResourceRefs, oldObject.Spec.ResourceRefs, newObject.Namespace, admissionSpec.UserInfo, field.NewPath("spec", "resourceRefs"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateResourceDeletionPolicy(newObject.Spec.ResourceDeletionPolicy, field.NewPath("spec", "resourceDeletionPolicy"))...)
+	allErrs = append(allErrs, validateTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateReapplyInterval(newObject.Spec.ReapplyInterval, field.NewPath("spec", "reapplyInterval"))...)
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, a.dryRunner.validateSyncSet(newObject, oldObject, contextLogger)...)
+	}
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -272,6 +352,55 @@ func validatePatches(patches []hivev1.SyncObjectPatch, fldPath *field.Path) fiel
 	return allErrs
 }
 
+// validateJSONPatches validates that the patches with the "json" patch type are JSON patches as defined in RFC 6902, a
+// list of operations that each have the members their op requires. The patches of a templated syncset are only valid
+// once they are rendered, so they are not validated.
+func validateJSONPatches(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Templated {
+		return allErrs
+	}
+	for i, patch := range spec.Patches {
+		if patch.PatchType != "json" {
+			continue
+		}
+		patchPath := fldPath.Index(i).Child("patch")
+		var ops []map[string]interface{}
+		// Like kubectl, the patch may be written in YAML.
+		data, err := yaml.YAMLToJSON([]byte(patch.Patch))
+		if err == nil {
+			err = json.Unmarshal(data, &ops)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath, patch.Patch, "must be a list of JSON patch operations"))
+			continue
+		}
+		for j, op := range ops {
+			opPath := patchPath.Index(j)
+			kind, _ := op["op"].(string)
+			members, ok := jsonPatchOpMembers[kind]
+			if !ok {
+				allErrs = append(allErrs, field.NotSupported(opPath.Child("op"), op["op"], validJSONPatchOpSlice))
+				continue
+			}
+			for _, member := range members {
+				value, ok := op[member]
+				switch {
+				case !ok:
+					allErrs = append(allErrs, field.Required(opPath.Child(member), fmt.Sprintf("%s is required for the %s operation", member, kind)))
+				case member == "value":
+				default:
+					// The path and from members are JSON pointers, which are empty or start with a slash.
+					if pointer, ok := value.(string); !ok || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+						allErrs = append(allErrs, field.Invalid(opPath.Child(member), value, "must be a JSON pointer"))
+					}
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
 func validateResourceApplyMode(resourceApplyMode hivev1.SyncSetResourceApplyMode, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if resourceApplyMode != "" && !validResourceApplyModes[resourceApplyMode] {
@@ -280,6 +409,26 @@ func validateResourceApplyMode(resourceApplyMode hivev1.SyncSetResourceApplyMode
 	return allErrs
 }
 
+func validateResourceDeletionPolicy(policy hivev1.SyncSetResourceDeletionPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy != "" && !validResourceDeletionPolicies[policy] {
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, validResourceDeletionPolicySlice))
+	}
+	return allErrs
+}
+
+// minReapplyInterval is the shortest reapply interval of a syncset, so that the syncset is not reapplied on every
+// reconcile of a cluster.
+const minReapplyInterval = time.Minute
+
+func validateReapplyInterval(reapplyInterval *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if reapplyInterval != nil && reapplyInterval.Duration < minReapplyInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath, reapplyInterval.Duration.String(), "must be at least "+minReapplyInterval.String()))
+	}
+	return allErrs
+}
+
 func validateResources(resources []runtime.RawExtension, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, resource := range resources {
@@ -302,6 +451,74 @@ func validateResource(resource runtime.RawExtension, fldPath *field.Path) field.
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("APIVersion"), u.GetAPIVersion(), "must use kubernetes group for this resource kind"))
 	}
 
+	if policy, ok := u.GetAnnotations()[constants.SyncSetDeletionPolicyAnnotation]; ok && !validResourceDeletionPolicies[hivev1.SyncSetResourceDeletionPolicy(policy)] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("metadata", "annotations").Key(constants.SyncSetDeletionPolicyAnnotation), policy, validResourceDeletionPolicySlice))
+	}
+
+	return allErrs
+}
+
+// validateTemplates validates that the string values of the resources and the patches of a templated syncset are
+// valid templates.
+func validateTemplates(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !spec.Templated {
+		return allErrs
+	}
+	for i, resource := range spec.Resources {
+		u := &unstructured.Unstructured{}
+		// Resources that cannot be unmarshalled are reported by validateResource.
+		if err := json.Unmarshal(resource.Raw, u); err != nil {
+			continue
+		}
+		if err := validateTemplateValue(u.Object); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resources").Index(i), string(resource.Raw), err.Error()))
+		}
+	}
+	for i, patch := range spec.Patches {
+		if err := validateTemplateValue(patch.Patch); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patches").Index(i).Child("patch"), patch.Patch, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateTemplateValue(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "{{") {
+			_, err := template.New("").Parse(v)
+			return err
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			if err := validateTemplateValue(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := validateTemplateValue(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateResourcesToDelete(resourcesToDelete []hivev1.SyncObjectReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range resourcesToDelete {
+		if ref.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("apiVersion"), "APIVersion is required"))
+		}
+		if ref.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("kind"), "Kind is required"))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "Name is required"))
+		}
+	}
 	return allErrs
 }
 
@@ -310,23 +527,134 @@ func validateSecrets(secrets []hivev1.SecretMapping, fldPath *field.Path) field.
 	for i, secret := range secrets {
 		allErrs = append(allErrs, validateSecretRef(secret.SourceRef, fldPath.Index(i).Child("sourceRef"))...)
 		allErrs = append(allErrs, validateSecretRef(secret.TargetRef, fldPath.Index(i).Child("targetRef"))...)
+		allErrs = append(allErrs, validateResourceDeletionPolicy(secret.DeletePolicy, fldPath.Index(i).Child("deletePolicy"))...)
 	}
 	return allErrs
 }
 
-func validateSourceSecretInSyncSetNamespace(secrets []hivev1.SecretMapping, syncSetNS string, fldPath *field.Path) field.ErrorList {
+// validateSourceSecretAccess validates that the requesting user can get each of the source secrets in other namespaces
+// than the SyncSet, so that a SyncSet cannot copy secrets that its author cannot read. Source secrets that were already
+// referenced before an update are not checked again, so that other users can still update the SyncSet.
+func (a *SyncSetValidatingAdmissionHook) validateSourceSecretAccess(secrets, oldSecrets []hivev1.SecretMapping, syncSetNS string, userInfo authenticationv1.UserInfo, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, secret := range secrets {
-		if secret.SourceRef.Namespace != syncSetNS && secret.SourceRef.Namespace != "" {
-			path := fldPath.Index(i).Child("sourceRef")
+		ref := secret.SourceRef
+		if ref.Namespace == "" || ref.Namespace == syncSetNS || containsSourceSecret(oldSecrets, ref) {
+			continue
+		}
+		path := fldPath.Index(i).Child("sourceRef")
+		if a.kubeClient == nil {
+			allErrs = append(allErrs, field.Forbidden(path, "cannot check access to source secrets in other namespaces"))
+			continue
+		}
+		allowed, err := a.userCanGet(userInfo, "secrets", ref.Namespace, ref.Name)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(path, pkgerrors.Wrap(err, "could not check access to source secret")))
+			continue
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(path,
+				fmt.Sprintf("user %q cannot get secret %s in namespace %s", userInfo.Username, ref.Name, ref.Namespace)))
+		}
+	}
+	return allErrs
+}
 
-			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), secret.SourceRef.Namespace,
-				"source secret reference must be in same namespace as SyncSet"))
+// validateResourceRefAccess validates that the requesting user can get each of the referenced ConfigMaps and Secrets in
+// other namespaces than the SyncSet, like the source secrets of the SyncSet.
+func (a *SyncSetValidatingAdmissionHook) validateResourceRefAccess(refs, oldRefs []hivev1.SyncSetResourceReference, syncSetNS string, userInfo authenticationv1.UserInfo, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		if ref.Namespace == "" || ref.Namespace == syncSetNS || containsResourceRef(oldRefs, ref) {
+			continue
+		}
+		path := fldPath.Index(i)
+		if a.kubeClient == nil {
+			allErrs = append(allErrs, field.Forbidden(path, "cannot check access to resource references in other namespaces"))
+			continue
+		}
+		resource := "configmaps"
+		if ref.Kind == "Secret" {
+			resource = "secrets"
+		}
+		allowed, err := a.userCanGet(userInfo, resource, ref.Namespace, ref.Name)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(path, pkgerrors.Wrap(err, "could not check access to resource reference")))
+			continue
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(path,
+				fmt.Sprintf("user %q cannot get %s %s in namespace %s", userInfo.Username, strings.ToLower(ref.Kind), ref.Name, ref.Namespace)))
+		}
+	}
+	return allErrs
+}
+
+// userCanGet returns whether the user can get the object of the resource with a SubjectAccessReview.
+func (a *SyncSetValidatingAdmissionHook) userCanGet(userInfo authenticationv1.UserInfo, resource, namespace, name string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Resource:  resource,
+				Name:      name,
+			},
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+		},
+	}
+	sar, err := a.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+func containsResourceRef(refs []hivev1.SyncSetResourceReference, ref hivev1.SyncSetResourceReference) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
 		}
 	}
+	return false
+}
+
+// validateResourceRefs validates the references to the ConfigMaps and Secrets holding resources. The namespace is
+// required for SelectorSyncSets, which are not namespaced.
+func validateResourceRefs(refs []hivev1.SyncSetResourceReference, requireNamespace bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		path := fldPath.Index(i)
+		if ref.Kind != "ConfigMap" && ref.Kind != "Secret" {
+			allErrs = append(allErrs, field.NotSupported(path.Child("kind"), ref.Kind, []string{"ConfigMap", "Secret"}))
+		}
+		if len(ref.Name) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "Name is required"))
+		}
+		if requireNamespace && len(ref.Namespace) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("namespace"), "Namespace is required"))
+		}
+		allErrs = append(allErrs, validateResourceDeletionPolicy(ref.DeletePolicy, path.Child("deletePolicy"))...)
+	}
 	return allErrs
 }
 
+func containsSourceSecret(secrets []hivev1.SecretMapping, ref hivev1.SecretReference) bool {
+	for _, secret := range secrets {
+		if secret.SourceRef == ref {
+			return true
+		}
+	}
+	return false
+}
+
 func validateSecretRef(ref hivev1.SecretReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(ref.Name) == 0 {
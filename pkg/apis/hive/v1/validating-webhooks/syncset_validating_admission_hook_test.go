@@ -3,19 +3,26 @@ package validatingwebhooks
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 )
 
 const (
-	syncSetNS = "test-namespace"
+	syncSetNS   = "test-namespace"
+	allowedUser = "allowed-user"
 )
 
 func TestSyncSetValidatingResource(t *testing.T) {
@@ -41,7 +48,7 @@ func TestSyncSetInitialize(t *testing.T) {
 	data := NewSyncSetValidatingAdmissionHook(createDecoder(t))
 
 	// Act
-	err := data.Initialize(nil, nil)
+	err := data.Initialize(&rest.Config{}, nil)
 
 	// Assert
 	assert.Nil(t, err)
@@ -53,6 +60,10 @@ func TestSyncSetValidate(t *testing.T) {
 		operation       admissionv1beta1.Operation
 		expectedAllowed bool
 		syncSet         *hivev1.SyncSet
+		// oldSyncSet is the SyncSet before an update. It defaults to syncSet.
+		oldSyncSet *hivev1.SyncSet
+		// user is the requesting user. Only allowedUser can get secrets in other namespaces.
+		user string
 	}{
 		{
 			name:            "Test valid patch type create",
@@ -78,6 +89,58 @@ func TestSyncSetValidate(t *testing.T) {
 			syncSet:         testInvalidPatchSyncSet(),
 			expectedAllowed: false,
 		},
+		{
+			name:            "Test valid JSON patch with test operation",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet(`[{"op": "test", "path": "/data/key", "value": "expected"}, {"op": "replace", "path": "/data/key", "value": "new"}]`),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test valid JSON patch in YAML",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet("- op: test\n  path: /data/key\n  value: null\n- op: move\n  from: /data/key\n  path: /data/other\n"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test JSON patch that is not a list of operations",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet(`{"op": "remove", "path": "/data/key"}`),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test JSON patch with unsupported operation",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet(`[{"op": "check", "path": "/data/key", "value": "expected"}]`),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test JSON patch test operation without value",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testJSONPatchSyncSet(`[{"op": "test", "path": "/data/key"}]`),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test JSON patch move operation without from",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet(`[{"op": "move", "path": "/data/key"}]`),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test JSON patch with invalid path",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testJSONPatchSyncSet(`[{"op": "remove", "path": "data/key"}]`),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test templated JSON patch not validated",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testJSONPatchSyncSet(`[{"op": "add", "path": "/data/key", "value": {{ .ClusterName | printf "%q" }}}]`)
+				ss.Spec.Templated = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
 		{
 			name:            "Test create with no patches",
 			operation:       admissionv1beta1.Create,
@@ -113,7 +176,7 @@ func TestSyncSetValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:      "Test invalid SecretReference source not in SyncSet namespace",
+			name:      "Test invalid SecretReference source not in SyncSet namespace without access",
 			operation: admissionv1beta1.Create,
 			syncSet: func() *hivev1.SyncSet {
 				ss := testSecretReferenceSyncSet()
@@ -122,6 +185,38 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid SecretReference source not in SyncSet namespace with access",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].SourceRef.Namespace = "anotherns"
+				return ss
+			}(),
+			user:            allowedUser,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid SecretReference source not in SyncSet namespace added without access",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].SourceRef.Namespace = "anotherns"
+				return ss
+			}(),
+			oldSyncSet:      testSecretReferenceSyncSet(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid SecretReference source not in SyncSet namespace unchanged without access",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].SourceRef.Namespace = "anotherns"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
 		{
 			name:      "Test valid SecretReference source has empty namespace",
 			operation: admissionv1beta1.Create,
@@ -132,6 +227,43 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: true,
 		},
+		{
+			name:            "Test valid resource reference",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testResourceRefSyncSet("ConfigMap", "manifests", ""),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test invalid resource reference kind",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testResourceRefSyncSet("Deployment", "manifests", ""),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test invalid resource reference no name",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testResourceRefSyncSet("Secret", "", ""),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test invalid resource reference not in SyncSet namespace without access",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testResourceRefSyncSet("Secret", "manifests", "anotherns"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid resource reference not in SyncSet namespace with access",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testResourceRefSyncSet("ConfigMap", "manifests", "anotherns"),
+			user:            allowedUser,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test valid resource reference not in SyncSet namespace unchanged without access",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testResourceRefSyncSet("ConfigMap", "manifests", "anotherns"),
+			expectedAllowed: true,
+		},
 		{
 			name:      "Test invalid SecretReference no target name create",
 			operation: admissionv1beta1.Create,
@@ -242,6 +374,137 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid Orphan resourceDeletionPolicy create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourceApplyMode = "Sync"
+				ss.Spec.ResourceDeletionPolicy = "Orphan"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resourceDeletionPolicy update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourceDeletionPolicy = "orphan"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid deletion policy annotation create",
+			operation:       admissionv1beta1.Create,
+			syncSet:         testSyncSetWithResources(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","annotations":{"hive.openshift.io/syncset-deletion-policy":"Orphan"}}}`),
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test invalid deletion policy annotation update",
+			operation:       admissionv1beta1.Update,
+			syncSet:         testSyncSetWithResources(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","annotations":{"hive.openshift.io/syncset-deletion-policy":"Keep"}}}`),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid resource reference deletePolicy create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefSyncSet("ConfigMap", "foo", "")
+				ss.Spec.ResourceRefs[0].DeletePolicy = hivev1.OrphanResourceDeletionPolicy
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource reference deletePolicy update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testResourceRefSyncSet("ConfigMap", "foo", "")
+				ss.Spec.ResourceRefs[0].DeletePolicy = "Retain"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid secret mapping deletePolicy create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].DeletePolicy = hivev1.DeleteResourceDeletionPolicy
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid secret mapping deletePolicy create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].DeletePolicy = "retain"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid reapplyInterval create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ReapplyInterval = &metav1.Duration{Duration: 30 * time.Minute}
+				ss.Spec.ReapplyOnDrift = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid reapplyInterval update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ReapplyInterval = &metav1.Duration{Duration: 10 * time.Second}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid resourcesToDelete create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{
+					{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "bar"},
+					{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "bar"},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resourcesToDelete no kind create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{
+					{APIVersion: "v1", Namespace: "foo", Name: "bar"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resourcesToDelete no name update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{
+					{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -381,8 +644,13 @@ func TestSyncSetValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := NewSyncSetValidatingAdmissionHook(createDecoder(t))
+			data.kubeClient = fakeSubjectAccessReviewClient()
 
 			objectRaw, _ := json.Marshal(tc.syncSet)
+			oldObjectRaw := objectRaw
+			if tc.oldSyncSet != nil {
+				oldObjectRaw, _ = json.Marshal(tc.oldSyncSet)
+			}
 
 			gvr := metav1.GroupVersionResource{
 				Group:    "hive.openshift.io",
@@ -397,8 +665,9 @@ func TestSyncSetValidate(t *testing.T) {
 					Raw: objectRaw,
 				},
 				OldObject: runtime.RawExtension{
-					Raw: objectRaw,
+					Raw: oldObjectRaw,
 				},
+				UserInfo: authenticationv1.UserInfo{Username: tc.user},
 			}
 
 			response := data.Validate(request)
@@ -407,6 +676,17 @@ func TestSyncSetValidate(t *testing.T) {
 	}
 }
 
+// fakeSubjectAccessReviewClient returns a client whose SubjectAccessReviews only allow allowedUser.
+func fakeSubjectAccessReviewClient() *kubefake.Clientset {
+	c := kubefake.NewSimpleClientset()
+	c.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		sar := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == allowedUser
+		return true, sar, nil
+	})
+	return c
+}
+
 func testValidPatchSyncSet() *hivev1.SyncSet {
 	return testPatchSyncSet("merge")
 }
@@ -421,7 +701,7 @@ func testPatchSyncSet(patchType string) *hivev1.SyncSet {
 		SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
 			Patches: []hivev1.SyncObjectPatch{
 				{
-					Patch:     "blah",
+					Patch:     `[{"op": "remove", "path": "/metadata/labels/blah"}]`,
 					PatchType: "json",
 				},
 				{
@@ -434,6 +714,19 @@ func testPatchSyncSet(patchType string) *hivev1.SyncSet {
 	return ss
 }
 
+func testJSONPatchSyncSet(patch string) *hivev1.SyncSet {
+	ss := testSyncSet()
+	ss.Spec.Patches = []hivev1.SyncObjectPatch{{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "dest-namespace",
+		Name:       "dest-name",
+		Patch:      patch,
+		PatchType:  "json",
+	}}
+	return ss
+}
+
 func testSecretReferenceSyncSet() *hivev1.SyncSet {
 	ss := testSyncSet()
 	ss.Spec = hivev1.SyncSetSpec{
@@ -455,6 +748,16 @@ func testSecretReferenceSyncSet() *hivev1.SyncSet {
 	return ss
 }
 
+func testResourceRefSyncSet(kind, name, namespace string) *hivev1.SyncSet {
+	ss := testSyncSet()
+	ss.Spec.ResourceRefs = []hivev1.SyncSetResourceReference{{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+	}}
+	return ss
+}
+
 func testSyncSet() *hivev1.SyncSet {
 	return &hivev1.SyncSet{
 		ObjectMeta: metav1.ObjectMeta{
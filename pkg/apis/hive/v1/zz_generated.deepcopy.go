@@ -6,10 +6,13 @@ package v1
 
 import (
 	configv1 "github.com/openshift/api/config/v1"
+	agent "github.com/openshift/hive/pkg/apis/hive/v1/agent"
 	aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
 	baremetal "github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
 	gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	ibmcloud "github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	nutanix "github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	openstack "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 	ovirt "github.com/openshift/hive/pkg/apis/hive/v1/ovirt"
 	vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
@@ -27,6 +30,11 @@ func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -49,6 +57,11 @@ func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 		*out = make([]AWSResourceTag, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -84,182 +97,184 @@ func (in *AWSDNSZoneStatus) DeepCopy() *AWSDNSZoneStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AWSResourceTag) DeepCopyInto(out *AWSResourceTag) {
+func (in *AWSPrivateLinkConfig) DeepCopyInto(out *AWSPrivateLinkConfig) {
 	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVPCInventory != nil {
+		in, out := &in.EndpointVPCInventory, &out.EndpointVPCInventory
+		*out = make([]AWSPrivateLinkInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AssociatedVPCs != nil {
+		in, out := &in.AssociatedVPCs, &out.AssociatedVPCs
+		*out = make([]AWSPrivateLinkVPC, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSResourceTag.
-func (in *AWSResourceTag) DeepCopy() *AWSResourceTag {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateLinkConfig.
+func (in *AWSPrivateLinkConfig) DeepCopy() *AWSPrivateLinkConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AWSResourceTag)
+	out := new(AWSPrivateLinkConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
+func (in *AWSPrivateLinkInventory) DeepCopyInto(out *AWSPrivateLinkInventory) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	out.AWSPrivateLinkVPC = in.AWSPrivateLinkVPC
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]AWSPrivateLinkSubnet, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterDeprovision.
-func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateLinkInventory.
+func (in *AWSPrivateLinkInventory) DeepCopy() *AWSPrivateLinkInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureClusterDeprovision)
+	out := new(AWSPrivateLinkInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
+func (in *AWSPrivateLinkSubnet) DeepCopyInto(out *AWSPrivateLinkSubnet) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneSpec.
-func (in *AzureDNSZoneSpec) DeepCopy() *AzureDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateLinkSubnet.
+func (in *AWSPrivateLinkSubnet) DeepCopy() *AWSPrivateLinkSubnet {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureDNSZoneSpec)
+	out := new(AWSPrivateLinkSubnet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureDNSZoneStatus) DeepCopyInto(out *AzureDNSZoneStatus) {
+func (in *AWSPrivateLinkVPC) DeepCopyInto(out *AWSPrivateLinkVPC) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneStatus.
-func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateLinkVPC.
+func (in *AWSPrivateLinkVPC) DeepCopy() *AWSPrivateLinkVPC {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureDNSZoneStatus)
+	out := new(AWSPrivateLinkVPC)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
+func (in *AWSResourceTag) DeepCopyInto(out *AWSResourceTag) {
 	*out = *in
-	out.Velero = in.Velero
-	if in.MinBackupPeriodSeconds != nil {
-		in, out := &in.MinBackupPeriodSeconds, &out.MinBackupPeriodSeconds
-		*out = new(int)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
-func (in *BackupConfig) DeepCopy() *BackupConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSResourceTag.
+func (in *AWSResourceTag) DeepCopy() *AWSResourceTag {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupConfig)
+	out := new(AWSResourceTag)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupReference) DeepCopyInto(out *BackupReference) {
+func (in *AdmissionPolicyConfig) DeepCopyInto(out *AdmissionPolicyConfig) {
 	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReference.
-func (in *BackupReference) DeepCopy() *BackupReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPolicyConfig.
+func (in *AdmissionPolicyConfig) DeepCopy() *AdmissionPolicyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupReference)
+	out := new(AdmissionPolicyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
+func (in *AdoptClusterRequest) DeepCopyInto(out *AdoptClusterRequest) {
 	*out = *in
-	out.CertificateSecretRef = in.CertificateSecretRef
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
-func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptClusterRequest.
+func (in *AdoptClusterRequest) DeepCopy() *AdoptClusterRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateBundleSpec)
+	out := new(AdoptClusterRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
-func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdoptClusterRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(CertificateBundleStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
+func (in *AdoptClusterRequestCondition) DeepCopyInto(out *AdoptClusterRequestCondition) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
-func (in *Checkpoint) DeepCopy() *Checkpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptClusterRequestCondition.
+func (in *AdoptClusterRequestCondition) DeepCopy() *AdoptClusterRequestCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(Checkpoint)
+	out := new(AdoptClusterRequestCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Checkpoint) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
+func (in *AdoptClusterRequestList) DeepCopyInto(out *AdoptClusterRequestList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Checkpoint, len(*in))
+		*out = make([]AdoptClusterRequest, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -267,18 +282,18 @@ func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
-func (in *CheckpointList) DeepCopy() *CheckpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptClusterRequestList.
+func (in *AdoptClusterRequestList) DeepCopy() *AdoptClusterRequestList {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointList)
+	out := new(AdoptClusterRequestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CheckpointList) DeepCopyObject() runtime.Object {
+func (in *AdoptClusterRequestList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -286,402 +301,318 @@ func (in *CheckpointList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
+func (in *AdoptClusterRequestSpec) DeepCopyInto(out *AdoptClusterRequestSpec) {
 	*out = *in
-	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
-	out.LastBackupRef = in.LastBackupRef
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
-func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptClusterRequestSpec.
+func (in *AdoptClusterRequestSpec) DeepCopy() *AdoptClusterRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointSpec)
+	out := new(AdoptClusterRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
+func (in *AdoptClusterRequestStatus) DeepCopyInto(out *AdoptClusterRequestStatus) {
 	*out = *in
+	if in.ClusterDeploymentRef != nil {
+		in, out := &in.ClusterDeploymentRef, &out.ClusterDeploymentRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AdoptClusterRequestCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
-func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptClusterRequestStatus.
+func (in *AdoptClusterRequestStatus) DeepCopy() *AdoptClusterRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointStatus)
+	out := new(AdoptClusterRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
+func (in *ArchitectureImage) DeepCopyInto(out *ArchitectureImage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
-func (in *ClusterClaim) DeepCopy() *ClusterClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchitectureImage.
+func (in *ArchitectureImage) DeepCopy() *ArchitectureImage {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaim)
+	out := new(ArchitectureImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaim) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
+func (in *AutoscalingConfig) DeepCopyInto(out *AutoscalingConfig) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = new(ResourceRequestBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSync != nil {
+		in, out := &in.ClusterSync, &out.ClusterSync
+		*out = new(ClusterSyncAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
-func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingConfig.
+func (in *AutoscalingConfig) DeepCopy() *AutoscalingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimCondition)
+	out := new(AutoscalingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
+func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
-func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterDeprovision.
+func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimList)
+	out := new(AzureClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
+func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
 	*out = *in
-	if in.Subjects != nil {
-		in, out := &in.Subjects, &out.Subjects
-		*out = make([]rbacv1.Subject, len(*in))
-		copy(*out, *in)
-	}
-	if in.Lifetime != nil {
-		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
-		**out = **in
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
-func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneSpec.
+func (in *AzureDNSZoneSpec) DeepCopy() *AzureDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimSpec)
+	out := new(AzureDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
+func (in *AzureDNSZoneStatus) DeepCopyInto(out *AzureDNSZoneStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterClaimCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
-func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneStatus.
+func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimStatus)
+	out := new(AzureDNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
+func (in *AzurePrivateLinkConfig) DeepCopyInto(out *AzurePrivateLinkConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVNetInventory != nil {
+		in, out := &in.EndpointVNetInventory, &out.EndpointVNetInventory
+		*out = make([]AzurePrivateLinkInventory, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
-func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkConfig.
+func (in *AzurePrivateLinkConfig) DeepCopy() *AzurePrivateLinkConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeployment)
+	out := new(AzurePrivateLinkConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
+func (in *AzurePrivateLinkInventory) DeepCopyInto(out *AzurePrivateLinkInventory) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
-func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkInventory.
+func (in *AzurePrivateLinkInventory) DeepCopy() *AzurePrivateLinkInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentCondition)
+	out := new(AzurePrivateLinkInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeployment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.Velero = in.Velero
+	if in.MinBackupPeriodSeconds != nil {
+		in, out := &in.MinBackupPeriodSeconds, &out.MinBackupPeriodSeconds
+		*out = new(int)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
-func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
+func (in *BackupConfig) DeepCopy() *BackupConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentList)
+	out := new(BackupConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
+func (in *BackupReference) DeepCopyInto(out *BackupReference) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = make([]ClusterIngress, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleSpec, len(*in))
-		copy(*out, *in)
-	}
-	if in.ClusterMetadata != nil {
-		in, out := &in.ClusterMetadata, &out.ClusterMetadata
-		*out = new(ClusterMetadata)
-		**out = **in
-	}
-	if in.Provisioning != nil {
-		in, out := &in.Provisioning, &out.Provisioning
-		*out = new(Provisioning)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ClusterPoolRef != nil {
-		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
-		*out = new(ClusterPoolReference)
-		**out = **in
-	}
-	if in.HibernateAfter != nil {
-		in, out := &in.HibernateAfter, &out.HibernateAfter
-		*out = new(metav1.Duration)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
-func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReference.
+func (in *BackupReference) DeepCopy() *BackupReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentSpec)
+	out := new(BackupReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+func (in *CapacityPoolReference) DeepCopyInto(out *CapacityPoolReference) {
 	*out = *in
-	if in.InstallerImage != nil {
-		in, out := &in.InstallerImage, &out.InstallerImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.CLIImage != nil {
-		in, out := &in.CLIImage, &out.CLIImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeploymentCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstalledTimestamp != nil {
-		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.ProvisionRef != nil {
-		in, out := &in.ProvisionRef, &out.ProvisionRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
-func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityPoolReference.
+func (in *CapacityPoolReference) DeepCopy() *CapacityPoolReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentStatus)
+	out := new(CapacityPoolReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.CertificateSecretRef = in.CertificateSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
-func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
+func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovision)
+	out := new(CertificateBundleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
+func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(CertificateBundleStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
-func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
+func (in *Checkpoint) DeepCopy() *Checkpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionCondition)
+	out := new(Checkpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Checkpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
+func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeprovision, len(*in))
+		*out = make([]Checkpoint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -689,18 +620,18 @@ func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
-func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
+func (in *CheckpointList) DeepCopy() *CheckpointList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionList)
+	out := new(CheckpointList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
+func (in *CheckpointList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -708,113 +639,78 @@ func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
+func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(OpenStackClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(VSphereClusterDeprovision)
-		**out = **in
-	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(OvirtClusterDeprovision)
-		**out = **in
-	}
+	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
+	out.LastBackupRef = in.LastBackupRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
-func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
+func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionPlatform)
+	out := new(CheckpointSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
+func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
-func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
+func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionSpec)
+	out := new(CheckpointStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
+func (in *ClaimHistoryEntry) DeepCopyInto(out *ClaimHistoryEntry) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeprovisionCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Hour.DeepCopyInto(&out.Hour)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
-func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimHistoryEntry.
+func (in *ClaimHistoryEntry) DeepCopy() *ClaimHistoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionStatus)
+	out := new(ClaimHistoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
-func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
+func (in *ClusterClaim) DeepCopy() *ClusterClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSet)
+	out := new(ClusterClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+func (in *ClusterClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -822,175 +718,208 @@ func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterImageSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
-func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
+func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetList)
+	out := new(ClusterClaimCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
-func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
+func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetReference)
+	out := new(ClusterClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
-func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
+func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetSpec)
+	out := new(ClusterClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterClaimCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
-func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
+func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetStatus)
+	out := new(ClusterClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+func (in *ClusterDefaultsConfig) DeepCopyInto(out *ClusterDefaultsConfig) {
 	*out = *in
-	if in.NamespaceSelector != nil {
-		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
 	}
-	if in.RouteSelector != nil {
-		in, out := &in.RouteSelector, &out.RouteSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.MachinePoolReplicas != nil {
+		in, out := &in.MachinePoolReplicas, &out.MachinePoolReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformClusterDefaults, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
-func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultsConfig.
+func (in *ClusterDefaultsConfig) DeepCopy() *ClusterDefaultsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterIngress)
+	out := new(ClusterDefaultsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
-	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
-	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
-func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
+func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMetadata)
+	out := new(ClusterDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
+func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
-func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
+func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterOperatorState)
+	out := new(ClusterDeploymentCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+func (in *ClusterDeploymentCustomization) DeepCopyInto(out *ClusterDeploymentCustomization) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
-func (in *ClusterPool) DeepCopy() *ClusterPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomization.
+func (in *ClusterDeploymentCustomization) DeepCopy() *ClusterDeploymentCustomization {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPool)
+	out := new(ClusterDeploymentCustomization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPool) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentCustomization) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -998,31 +927,13 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
-func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterPoolCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+func (in *ClusterDeploymentCustomizationList) DeepCopyInto(out *ClusterDeploymentCustomizationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterPool, len(*in))
+		*out = make([]ClusterDeploymentCustomization, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1030,18 +941,18 @@ func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
-func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationList.
+func (in *ClusterDeploymentCustomizationList) DeepCopy() *ClusterDeploymentCustomizationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolList)
+	out := new(ClusterDeploymentCustomizationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentCustomizationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1049,57 +960,65 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+func (in *ClusterDeploymentCustomizationSpec) DeepCopyInto(out *ClusterDeploymentCustomizationSpec) {
 	*out = *in
+	if in.InstallConfigPatches != nil {
+		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
+		*out = make([]PatchEntity, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
-func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCustomizationSpec.
+func (in *ClusterDeploymentCustomizationSpec) DeepCopy() *ClusterDeploymentCustomizationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolReference)
+	out := new(ClusterDeploymentCustomizationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+func (in *ClusterDeploymentFieldSelector) DeepCopyInto(out *ClusterDeploymentFieldSelector) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out.ImageSetRef = in.ImageSetRef
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PowerStates != nil {
+		in, out := &in.PowerStates, &out.PowerStates
+		*out = make([]ClusterPowerState, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
-func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentFieldSelector.
+func (in *ClusterDeploymentFieldSelector) DeepCopy() *ClusterDeploymentFieldSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolSpec)
+	out := new(ClusterDeploymentFieldSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterPoolCondition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeployment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1107,38 +1026,18 @@ func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
-func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterPoolStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
-func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
+func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvision)
+	out := new(ClusterDeploymentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvision) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1146,125 +1045,151 @@ func (in *ClusterProvision) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
-func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
-	if in == nil {
-		return nil
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	out := new(ClusterProvisionCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterProvision, len(*in))
+	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]ClusterIngress, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
-func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterProvisionList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleSpec, len(*in))
+		copy(*out, *in)
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
-	*out = *in
-	out.ClusterDeploymentRef = in.ClusterDeploymentRef
-	in.PodSpec.DeepCopyInto(&out.PodSpec)
-	if in.ClusterID != nil {
-		in, out := &in.ClusterID, &out.ClusterID
-		*out = new(string)
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
 		**out = **in
 	}
-	if in.InfraID != nil {
-		in, out := &in.InfraID, &out.InfraID
-		*out = new(string)
-		**out = **in
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(Provisioning)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.InstallLog != nil {
-		in, out := &in.InstallLog, &out.InstallLog
-		*out = new(string)
+	if in.ClusterInstallRef != nil {
+		in, out := &in.ClusterInstallRef, &out.ClusterInstallRef
+		*out = new(ClusterInstallLocalReference)
 		**out = **in
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(runtime.RawExtension)
+	if in.ClusterPoolRef != nil {
+		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
+		*out = new(ClusterPoolReference)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AdminKubeconfigSecretRef != nil {
-		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.AdminPasswordSecretRef != nil {
-		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.InstallAttemptsLimit != nil {
+		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
+		*out = new(int32)
 		**out = **in
 	}
-	if in.PrevClusterID != nil {
-		in, out := &in.PrevClusterID, &out.PrevClusterID
-		*out = new(string)
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackupConfig)
 		**out = **in
 	}
-	if in.PrevInfraID != nil {
-		in, out := &in.PrevInfraID, &out.PrevInfraID
-		*out = new(string)
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostInstallJobs != nil {
+		in, out := &in.PostInstallJobs, &out.PostInstallJobs
+		*out = make([]PostInstallJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Detach != nil {
+		in, out := &in.Detach, &out.Detach
+		*out = new(ClusterDetach)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
-func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
+func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionSpec)
+	out := new(ClusterDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 	*out = *in
-	if in.JobRef != nil {
-		in, out := &in.JobRef, &out.JobRef
-		*out = new(corev1.LocalObjectReference)
+	if in.InstallerImage != nil {
+		in, out := &in.InstallerImage, &out.InstallerImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.CLIImage != nil {
+		in, out := &in.CLIImage, &out.CLIImage
+		*out = new(string)
 		**out = **in
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterProvisionCondition, len(*in))
+		*out = make([]ClusterDeploymentCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstalledTimestamp != nil {
+		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProvisionRef != nil {
+		in, out := &in.ProvisionRef, &out.ProvisionRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretReferences != nil {
+		in, out := &in.SecretReferences, &out.SecretReferences
+		*out = make([]SecretReferenceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastEtcdBackup != nil {
+		in, out := &in.LastEtcdBackup, &out.LastEtcdBackup
+		*out = new(EtcdBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = new(SSHKeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostInstallJobs != nil {
+		in, out := &in.PostInstallJobs, &out.PostInstallJobs
+		*out = make([]PostInstallJobStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1272,38 +1197,38 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
-func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
+func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionStatus)
+	out := new(ClusterDeploymentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
-func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
+func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocate)
+	out := new(ClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1311,13 +1236,31 @@ func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
+func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRelocate, len(*in))
+		*out = make([]ClusterDeprovision, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1325,18 +1268,18 @@ func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
-func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
+func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateList)
+	out := new(ClusterDeprovisionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1344,61 +1287,1908 @@ func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
 	*out = *in
-	out.KubeconfigSecretRef = in.KubeconfigSecretRef
-	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(IBMCloudClusterDeprovision)
+		**out = **in
+	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(NutanixClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(OpenStackClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(VSphereClusterDeprovision)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(OvirtClusterDeprovision)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
-func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
+func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateSpec)
+	out := new(ClusterDeprovisionPlatform)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
 	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
-func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
+func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateStatus)
+	out := new(ClusterDeprovisionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDeprovisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemainingResources != nil {
+		in, out := &in.RemainingResources, &out.RemainingResources
+		*out = make([]RemainingCloudResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemainingResourcesReportTime != nil {
+		in, out := &in.RemainingResourcesReportTime, &out.RemainingResourcesReportTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
+func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDetach) DeepCopyInto(out *ClusterDetach) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDetach.
+func (in *ClusterDetach) DeepCopy() *ClusterDetach {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDetach)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
+func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
+func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
+func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
+func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
+func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
+func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstallLocalReference) DeepCopyInto(out *ClusterInstallLocalReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstallLocalReference.
+func (in *ClusterInstallLocalReference) DeepCopy() *ClusterInstallLocalReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstallLocalReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+	*out = *in
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
+func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkEntry) DeepCopyInto(out *ClusterNetworkEntry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkEntry.
+func (in *ClusterNetworkEntry) DeepCopy() *ClusterNetworkEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
+func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
+func (in *ClusterPool) DeepCopy() *ClusterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolCapacityPool) DeepCopyInto(out *ClusterPoolCapacityPool) {
+	*out = *in
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CredentialsSecretRefs != nil {
+		in, out := &in.CredentialsSecretRefs, &out.CredentialsSecretRefs
+		*out = make([]corev1.SecretReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCapacityPool.
+func (in *ClusterPoolCapacityPool) DeepCopy() *ClusterPoolCapacityPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolCapacityPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.ReplenishWindow != nil {
+		in, out := &in.ReplenishWindow, &out.ReplenishWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimLifetime.
+func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimLifetime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimReadiness) DeepCopyInto(out *ClusterPoolClaimReadiness) {
+	*out = *in
+	if in.SyncSets != nil {
+		in, out := &in.SyncSets, &out.SyncSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectorSyncSets != nil {
+		in, out := &in.SelectorSyncSets, &out.SelectorSyncSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimReadiness.
+func (in *ClusterPoolClaimReadiness) DeepCopy() *ClusterPoolClaimReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
+func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolFlavor) DeepCopyInto(out *ClusterPoolFlavor) {
+	*out = *in
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallConfigPatches != nil {
+		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
+		*out = make([]PatchEntity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolFlavor.
+func (in *ClusterPoolFlavor) DeepCopy() *ClusterPoolFlavor {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolFlavor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolHibernationConfig) DeepCopyInto(out *ClusterPoolHibernationConfig) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(HibernationSchedule)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolHibernationConfig.
+func (in *ClusterPoolHibernationConfig) DeepCopy() *ClusterPoolHibernationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolHibernationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
+func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolQuarantine) DeepCopyInto(out *ClusterPoolQuarantine) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolQuarantine.
+func (in *ClusterPoolQuarantine) DeepCopy() *ClusterPoolQuarantine {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolQuarantine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+	*out = *in
+	if in.Quarantine != nil {
+		in, out := &in.Quarantine, &out.Quarantine
+		*out = new(ClusterPoolQuarantine)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomizationRef != nil {
+		in, out := &in.CustomizationRef, &out.CustomizationRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
+func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolRegion) DeepCopyInto(out *ClusterPoolRegion) {
+	*out = *in
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolRegion.
+func (in *ClusterPoolRegion) DeepCopy() *ClusterPoolRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSizeSchedule) DeepCopyInto(out *ClusterPoolSizeSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSizeSchedule.
+func (in *ClusterPoolSizeSchedule) DeepCopy() *ClusterPoolSizeSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSizeSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	out.ImageSetRef = in.ImageSetRef
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstallAttemptsLimit != nil {
+		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]ClusterPoolFlavor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]ClusterPoolRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClaimLifetime != nil {
+		in, out := &in.ClaimLifetime, &out.ClaimLifetime
+		*out = new(ClusterPoolClaimLifetime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimReadiness != nil {
+		in, out := &in.ClaimReadiness, &out.ClaimReadiness
+		*out = new(ClusterPoolClaimReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityPoolRef != nil {
+		in, out := &in.CapacityPoolRef, &out.CapacityPoolRef
+		*out = new(CapacityPoolReference)
+		**out = **in
+	}
+	if in.HibernationConfig != nil {
+		in, out := &in.HibernationConfig, &out.HibernationConfig
+		*out = new(ClusterPoolHibernationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxClusterAge != nil {
+		in, out := &in.MaxClusterAge, &out.MaxClusterAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ClusterPoolSizeSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(ClusterPoolUpdateStrategy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
+func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterPoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimHistory != nil {
+		in, out := &in.ClaimHistory, &out.ClaimHistory
+		*out = make([]ClaimHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextSizeChange != nil {
+		in, out := &in.NextSizeChange, &out.NextSizeChange
+		*out = (*in).DeepCopy()
+	}
+	if in.UnavailableRegions != nil {
+		in, out := &in.UnavailableRegions, &out.UnavailableRegions
+		*out = make([]ClusterPoolUnavailableRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
+func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolUnavailableRegion) DeepCopyInto(out *ClusterPoolUnavailableRegion) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolUnavailableRegion.
+func (in *ClusterPoolUnavailableRegion) DeepCopy() *ClusterPoolUnavailableRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolUnavailableRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolUpdateStrategy) DeepCopyInto(out *ClusterPoolUpdateStrategy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolUpdateStrategy.
+func (in *ClusterPoolUpdateStrategy) DeepCopy() *ClusterPoolUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
+func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
+func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterProvision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
+func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
+	*out = *in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InfraID != nil {
+		in, out := &in.InfraID, &out.InfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallLog != nil {
+		in, out := &in.InstallLog, &out.InstallLog
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminKubeconfigSecretRef != nil {
+		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PrevClusterID != nil {
+		in, out := &in.PrevClusterID, &out.PrevClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrevInfraID != nil {
+		in, out := &in.PrevInfraID, &out.PrevInfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DryRunConfigMapRef != nil {
+		in, out := &in.DryRunConfigMapRef, &out.DryRunConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
+func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+	*out = *in
+	if in.JobRef != nil {
+		in, out := &in.JobRef, &out.JobRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterProvisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogURLs != nil {
+		in, out := &in.LogURLs, &out.LogURLs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
+func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
+func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRelocate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
+func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
+func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
+func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
 func (in *ClusterState) DeepCopy() *ClusterState {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterState)
+	out := new(ClusterState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
+func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
+func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = make([]ClusterOperatorState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSyncAutoscaling) DeepCopyInto(out *ClusterSyncAutoscaling) {
+	*out = *in
+	in.ResourceRequestBounds.DeepCopyInto(&out.ResourceRequestBounds)
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClustersPerReplica != nil {
+		in, out := &in.ClustersPerReplica, &out.ClustersPerReplica
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSyncAutoscaling.
+func (in *ClusterSyncAutoscaling) DeepCopy() *ClusterSyncAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSyncAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSyncShardingConfig) DeepCopyInto(out *ClusterSyncShardingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSyncShardingConfig.
+func (in *ClusterSyncShardingConfig) DeepCopy() *ClusterSyncShardingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSyncShardingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentImagesConfig) DeepCopyInto(out *ComponentImagesConfig) {
+	*out = *in
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = new(ImageOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSync != nil {
+		in, out := &in.ClusterSync, &out.ClusterSync
+		*out = new(ImageOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(ImageOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(ImageOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentImagesConfig.
+func (in *ComponentImagesConfig) DeepCopy() *ComponentImagesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentImagesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
+func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneAdditionalCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+	*out = *in
+	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
+func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
+func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneServingCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+	*out = *in
+	if in.ConcurrentReconciles != nil {
+		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientQPS != nil {
+		in, out := &in.ClientQPS, &out.ClientQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientBurst != nil {
+		in, out := &in.ClientBurst, &out.ClientBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueQPS != nil {
+		in, out := &in.QueueQPS, &out.QueueQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueBurst != nil {
+		in, out := &in.QueueBurst, &out.QueueBurst
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
+func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]SpecificControllerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
+func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
+func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneSpec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+	*out = *in
+	if in.LastSyncTimestamp != nil {
+		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DNSZoneCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployedWebhook) DeepCopyInto(out *DeployedWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployedWebhook.
+func (in *DeployedWebhook) DeepCopy() *DeployedWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployedWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupConfig) DeepCopyInto(out *EtcdBackupConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupConfig.
+func (in *EtcdBackupConfig) DeepCopy() *EtcdBackupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupStatus) DeepCopyInto(out *EtcdBackupStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupStatus.
+func (in *EtcdBackupStatus) DeepCopy() *EtcdBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterDeprovision) DeepCopyInto(out *ExternalClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterDeprovision.
+func (in *ExternalClusterDeprovision) DeepCopy() *ExternalClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDestroyer) DeepCopyInto(out *ExternalDestroyer) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDestroyer.
+func (in *ExternalDestroyer) DeepCopy() *ExternalDestroyer {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDestroyer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
+func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAWSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(FailedProvisionAWSConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
+func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
+func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
+func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+	*out = *in
+	if in.ZoneName != nil {
+		in, out := &in.ZoneName, &out.ZoneName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
+func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectConfig) DeepCopyInto(out *GCPPrivateServiceConnectConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVPCInventory != nil {
+		in, out := &in.EndpointVPCInventory, &out.EndpointVPCInventory
+		*out = make([]GCPPrivateServiceConnectInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectConfig.
+func (in *GCPPrivateServiceConnectConfig) DeepCopy() *GCPPrivateServiceConnectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectInventory) DeepCopyInto(out *GCPPrivateServiceConnectInventory) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]GCPPrivateServiceConnectSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectInventory.
+func (in *GCPPrivateServiceConnectInventory) DeepCopy() *GCPPrivateServiceConnectInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopyInto(out *GCPPrivateServiceConnectSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectSubnet.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopy() *GCPPrivateServiceConnectSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
+func (in *HiveConfig) DeepCopy() *HiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterState) DeepCopyObject() runtime.Object {
+func (in *HiveConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1406,13 +3196,162 @@ func (in *ClusterState) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterState, len(*in))
+		*out = make([]HiveConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
+func (in *HiveConfigList) DeepCopy() *HiveConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+	*out = *in
+	if in.ManagedDomains != nil {
+		in, out := &in.ManagedDomains, &out.ManagedDomains
+		*out = make([]ManageDNSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
+		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GlobalPullSecretRef != nil {
+		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
+	if in.MaintenanceMode != nil {
+		in, out := &in.MaintenanceMode, &out.MaintenanceMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeprovisionsDisabled != nil {
+		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExternalDestroyers != nil {
+		in, out := &in.ExternalDestroyers, &out.ExternalDestroyers
+		*out = make([]ExternalDestroyer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncSetAgent != nil {
+		in, out := &in.SyncSetAgent, &out.SyncSetAgent
+		*out = new(SyncSetAgentConfig)
+		**out = **in
+	}
+	if in.DisabledControllers != nil {
+		in, out := &in.DisabledControllers, &out.DisabledControllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllersConfig != nil {
+		in, out := &in.ControllersConfig, &out.ControllersConfig
+		*out = new(ControllersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComponentImages != nil {
+		in, out := &in.ComponentImages, &out.ComponentImages
+		*out = new(ComponentImagesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullThroughCache != nil {
+		in, out := &in.PullThroughCache, &out.PullThroughCache
+		*out = new(PullThroughCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionPolicy != nil {
+		in, out := &in.AdmissionPolicy, &out.AdmissionPolicy
+		*out = new(AdmissionPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisionHooks != nil {
+		in, out := &in.ProvisionHooks, &out.ProvisionHooks
+		*out = make([]ProvisionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AWSPrivateLink != nil {
+		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
+		*out = new(AWSPrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCPPrivateServiceConnect != nil {
+		in, out := &in.GCPPrivateServiceConnect, &out.GCPPrivateServiceConnect
+		*out = new(GCPPrivateServiceConnectConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzurePrivateLink != nil {
+		in, out := &in.AzurePrivateLink, &out.AzurePrivateLink
+		*out = new(AzurePrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = new(PriorityClassesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSyncSharding != nil {
+		in, out := &in.ClusterSyncSharding, &out.ClusterSyncSharding
+		*out = new(ClusterSyncShardingConfig)
+		**out = **in
+	}
+	if in.ClusterDefaults != nil {
+		in, out := &in.ClusterDefaults, &out.ClusterDefaults
+		*out = new(ClusterDefaultsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachinePoolReplicaLimits != nil {
+		in, out := &in.MachinePoolReplicaLimits, &out.MachinePoolReplicaLimits
+		*out = new(MachinePoolReplicaLimitsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterPoolCapacityPools != nil {
+		in, out := &in.ClusterPoolCapacityPools, &out.ClusterPoolCapacityPools
+		*out = make([]ClusterPoolCapacityPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1420,173 +3359,275 @@ func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
-func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
+func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
+	*out = *in
+	if in.DeployedWebhooks != nil {
+		in, out := &in.DeployedWebhooks, &out.DeployedWebhooks
+		*out = make([]DeployedWebhook, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespacePerClusterEnabledTime != nil {
+		in, out := &in.NamespacePerClusterEnabledTime, &out.NamespacePerClusterEnabledTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
+func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMCloudClusterDeprovision) DeepCopyInto(out *IBMCloudClusterDeprovision) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCloudClusterDeprovision.
+func (in *IBMCloudClusterDeprovision) DeepCopy() *IBMCloudClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMCloudClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
+func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverride) DeepCopyInto(out *ImageOverride) {
+	*out = *in
+	if in.ArchitectureImages != nil {
+		in, out := &in.ArchitectureImages, &out.ArchitectureImages
+		*out = make([]ArchitectureImage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverride.
+func (in *ImageOverride) DeepCopy() *ImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallManifestsSource) DeepCopyInto(out *InstallManifestsSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallManifestsSource.
+func (in *InstallManifestsSource) DeepCopy() *InstallManifestsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallManifestsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallRetryPolicy) DeepCopyInto(out *InstallRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffBase != nil {
+		in, out := &in.BackoffBase, &out.BackoffBase
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BackoffCeiling != nil {
+		in, out := &in.BackoffCeiling, &out.BackoffCeiling
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]InstallFailureClass, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallRetryPolicy.
+func (in *InstallRetryPolicy) DeepCopy() *InstallRetryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateList)
+	out := new(InstallRetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterStateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
-func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateSpec)
+	out := new(InventoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+func (in *InventoryEntryStatus) DeepCopyInto(out *InventoryEntryStatus) {
 	*out = *in
-	if in.LastUpdated != nil {
-		in, out := &in.LastUpdated, &out.LastUpdated
-		*out = (*in).DeepCopy()
-	}
-	if in.ClusterOperators != nil {
-		in, out := &in.ClusterOperators, &out.ClusterOperators
-		*out = make([]ClusterOperatorState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntryStatus.
+func (in *InventoryEntryStatus) DeepCopy() *InventoryEntryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(InventoryEntryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
-func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
+func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneAdditionalCertificate)
+	out := new(KubeconfigSecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
-	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
-func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePool.
+func (in *MachinePool) DeepCopy() *MachinePool {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfigSpec)
+	out := new(MachinePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+func (in *MachinePoolAutoscaling) DeepCopyInto(out *MachinePoolAutoscaling) {
 	*out = *in
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
-func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolAutoscaling.
+func (in *MachinePoolAutoscaling) DeepCopy() *MachinePoolAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneServingCertificateSpec)
+	out := new(MachinePoolAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+func (in *MachinePoolCondition) DeepCopyInto(out *MachinePoolCondition) {
 	*out = *in
-	if in.ConcurrentReconciles != nil {
-		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientQPS != nil {
-		in, out := &in.ClientQPS, &out.ClientQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientBurst != nil {
-		in, out := &in.ClientBurst, &out.ClientBurst
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueQPS != nil {
-		in, out := &in.QueueQPS, &out.QueueQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueBurst != nil {
-		in, out := &in.QueueBurst, &out.QueueBurst
-		*out = new(int32)
-		**out = **in
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
-func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolCondition.
+func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerConfig)
+	out := new(MachinePoolCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+func (in *MachinePoolList) DeepCopyInto(out *MachinePoolList) {
 	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(ControllerConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Controllers != nil {
-		in, out := &in.Controllers, &out.Controllers
-		*out = make([]SpecificControllerConfig, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MachinePool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1594,38 +3635,46 @@ func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
-func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolList.
+func (in *MachinePoolList) DeepCopy() *MachinePoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllersConfig)
+	out := new(MachinePoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *MachinePoolNameLease) DeepCopyInto(out *MachinePoolNameLease) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Spec = in.Spec
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLease.
+func (in *MachinePoolNameLease) DeepCopy() *MachinePoolNameLease {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(MachinePoolNameLease)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+func (in *MachinePoolNameLease) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1633,31 +3682,13 @@ func (in *DNSZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
-func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DNSZoneCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+func (in *MachinePoolNameLeaseList) DeepCopyInto(out *MachinePoolNameLeaseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSZone, len(*in))
+		*out = make([]MachinePoolNameLease, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1665,18 +3696,18 @@ func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
-func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseList.
+func (in *MachinePoolNameLeaseList) DeepCopy() *MachinePoolNameLeaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneList)
+	out := new(MachinePoolNameLeaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+func (in *MachinePoolNameLeaseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1684,915 +3715,950 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+func (in *MachinePoolNameLeaseSpec) DeepCopyInto(out *MachinePoolNameLeaseSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneSpec)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneSpec)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
-func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseSpec.
+func (in *MachinePoolNameLeaseSpec) DeepCopy() *MachinePoolNameLeaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneSpec)
+	out := new(MachinePoolNameLeaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+func (in *MachinePoolNameLeaseStatus) DeepCopyInto(out *MachinePoolNameLeaseStatus) {
 	*out = *in
-	if in.LastSyncTimestamp != nil {
-		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.NameServers != nil {
-		in, out := &in.NameServers, &out.NameServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseStatus.
+func (in *MachinePoolNameLeaseStatus) DeepCopy() *MachinePoolNameLeaseStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(MachinePoolNameLeaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
+	*out = *in
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneStatus)
+		*out = new(aws.MachinePoolPlatform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.MachinePool)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneStatus)
+		*out = new(gcp.MachinePool)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneStatus)
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(ibmcloud.MachinePool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(nutanix.MachinePool)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DNSZoneCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(openstack.MachinePool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(vsphere.MachinePool)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(ovirt.MachinePool)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
-func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolPlatform.
+func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneStatus)
+	out := new(MachinePoolPlatform)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+func (in *MachinePoolReplicaLimitsConfig) DeepCopyInto(out *MachinePoolReplicaLimitsConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.DefaultMaxReplicas != nil {
+		in, out := &in.DefaultMaxReplicas, &out.DefaultMaxReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]TenantReplicaLimit, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
-func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolReplicaLimitsConfig.
+func (in *MachinePoolReplicaLimitsConfig) DeepCopy() *MachinePoolReplicaLimitsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionAWSConfig)
+	out := new(MachinePoolReplicaLimitsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(FailedProvisionAWSConfig)
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(MachinePoolAutoscaling)
 		**out = **in
 	}
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
-func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSpec.
+func (in *MachinePoolSpec) DeepCopy() *MachinePoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionConfig)
+	out := new(MachinePoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.MachineSets != nil {
+		in, out := &in.MachineSets, &out.MachineSets
+		*out = make([]MachineSetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MachinePoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
-func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolStatus.
+func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterDeprovision)
+	out := new(MachinePoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
-func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetStatus.
+func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneSpec)
+	out := new(MachineSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
 	*out = *in
-	if in.ZoneName != nil {
-		in, out := &in.ZoneName, &out.ZoneName
-		*out = new(string)
-		**out = **in
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
-func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAWSConfig.
+func (in *ManageDNSAWSConfig) DeepCopy() *ManageDNSAWSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneStatus)
+	out := new(ManageDNSAWSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+func (in *ManageDNSAzureConfig) DeepCopyInto(out *ManageDNSAzureConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
-func (in *HiveConfig) DeepCopy() *HiveConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAzureConfig.
+func (in *ManageDNSAzureConfig) DeepCopy() *ManageDNSAzureConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfig)
+	out := new(ManageDNSAzureConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
+func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]HiveConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(ManageDNSAWSConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(ManageDNSGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(ManageDNSAzureConfig)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
-func (in *HiveConfigList) DeepCopy() *HiveConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
+func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigList)
+	out := new(ManageDNSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
 	*out = *in
-	if in.ManagedDomains != nil {
-		in, out := &in.ManagedDomains, &out.ManagedDomains
-		*out = make([]ManageDNSConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
-		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.GlobalPullSecretRef != nil {
-		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
-	if in.MaintenanceMode != nil {
-		in, out := &in.MaintenanceMode, &out.MaintenanceMode
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DeprovisionsDisabled != nil {
-		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisabledControllers != nil {
-		in, out := &in.DisabledControllers, &out.DisabledControllers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ControllersConfig != nil {
-		in, out := &in.ControllersConfig, &out.ControllersConfig
-		*out = new(ControllersConfig)
-		(*in).DeepCopyInto(*out)
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
-func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
+func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigSpec)
+	out := new(ManageDNSGCPConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
+func (in *Networking) DeepCopyInto(out *Networking) {
 	*out = *in
+	if in.MachineNetwork != nil {
+		in, out := &in.MachineNetwork, &out.MachineNetwork
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterNetwork != nil {
+		in, out := &in.ClusterNetwork, &out.ClusterNetwork
+		*out = make([]ClusterNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceNetwork != nil {
+		in, out := &in.ServiceNetwork, &out.ServiceNetwork
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
-func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Networking.
+func (in *Networking) DeepCopy() *Networking {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigStatus)
+	out := new(Networking)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+func (in *NutanixClusterDeprovision) DeepCopyInto(out *NutanixClusterDeprovision) {
 	*out = *in
+	out.PrismCentral = in.PrismCentral
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.CertificatesSecretRef != nil {
+		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
-func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixClusterDeprovision.
+func (in *NutanixClusterDeprovision) DeepCopy() *NutanixClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderStatus)
+	out := new(NutanixClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
 	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
-func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
+func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigSecretReference)
+	out := new(OpenStackClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePool) DeepCopyInto(out *MachinePool) {
+func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	out.CertificatesSecretRef = in.CertificatesSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePool.
-func (in *MachinePool) DeepCopy() *MachinePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
+func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePool)
+	out := new(OvirtClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MachinePool) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolAutoscaling) DeepCopyInto(out *MachinePoolAutoscaling) {
+func (in *PatchEntity) DeepCopyInto(out *PatchEntity) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolAutoscaling.
-func (in *MachinePoolAutoscaling) DeepCopy() *MachinePoolAutoscaling {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchEntity.
+func (in *PatchEntity) DeepCopy() *PatchEntity {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolAutoscaling)
+	out := new(PatchEntity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolCondition) DeepCopyInto(out *MachinePoolCondition) {
+func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentBareMetal != nil {
+		in, out := &in.AgentBareMetal, &out.AgentBareMetal
+		*out = new(agent.BareMetalPlatform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BareMetal != nil {
+		in, out := &in.BareMetal, &out.BareMetal
+		*out = new(baremetal.Platform)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(ibmcloud.Platform)
+		**out = **in
+	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(nutanix.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(openstack.Platform)
+		**out = **in
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(vsphere.Platform)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(ovirt.Platform)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolCondition.
-func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
+func (in *Platform) DeepCopy() *Platform {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolCondition)
+	out := new(Platform)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolList) DeepCopyInto(out *MachinePoolList) {
+func (in *PlatformClusterDefaults) DeepCopyInto(out *PlatformClusterDefaults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]MachinePool, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolList.
-func (in *MachinePoolList) DeepCopy() *MachinePoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformClusterDefaults.
+func (in *PlatformClusterDefaults) DeepCopy() *PlatformClusterDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolList)
+	out := new(PlatformClusterDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MachinePoolList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolNameLease) DeepCopyInto(out *MachinePoolNameLease) {
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLease.
-func (in *MachinePoolNameLease) DeepCopy() *MachinePoolNameLease {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolNameLease)
+	out := new(PlatformStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MachinePoolNameLease) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolNameLeaseList) DeepCopyInto(out *MachinePoolNameLeaseList) {
+func (in *PodSchedulingConfig) DeepCopyInto(out *PodSchedulingConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]MachinePoolNameLease, len(*in))
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseList.
-func (in *MachinePoolNameLeaseList) DeepCopy() *MachinePoolNameLeaseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSchedulingConfig.
+func (in *PodSchedulingConfig) DeepCopy() *PodSchedulingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolNameLeaseList)
+	out := new(PodSchedulingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MachinePoolNameLeaseList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolNameLeaseSpec) DeepCopyInto(out *MachinePoolNameLeaseSpec) {
+func (in *PostInstallJob) DeepCopyInto(out *PostInstallJob) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseSpec.
-func (in *MachinePoolNameLeaseSpec) DeepCopy() *MachinePoolNameLeaseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostInstallJob.
+func (in *PostInstallJob) DeepCopy() *PostInstallJob {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolNameLeaseSpec)
+	out := new(PostInstallJob)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolNameLeaseStatus) DeepCopyInto(out *MachinePoolNameLeaseStatus) {
+func (in *PostInstallJobStatus) DeepCopyInto(out *PostInstallJobStatus) {
 	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNameLeaseStatus.
-func (in *MachinePoolNameLeaseStatus) DeepCopy() *MachinePoolNameLeaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostInstallJobStatus.
+func (in *PostInstallJobStatus) DeepCopy() *PostInstallJobStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolNameLeaseStatus)
+	out := new(PostInstallJobStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
+func (in *PriorityClassesConfig) DeepCopyInto(out *PriorityClassesConfig) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(aws.MachinePoolPlatform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(azure.MachinePool)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(gcp.MachinePool)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(openstack.MachinePool)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(vsphere.MachinePool)
+	if in.CriticalValue != nil {
+		in, out := &in.CriticalValue, &out.CriticalValue
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(ovirt.MachinePool)
-		(*in).DeepCopyInto(*out)
+	if in.ProvisionValue != nil {
+		in, out := &in.ProvisionValue, &out.ProvisionValue
+		*out = new(int32)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolPlatform.
-func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassesConfig.
+func (in *PriorityClassesConfig) DeepCopy() *PriorityClassesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolPlatform)
+	out := new(PriorityClassesConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
+func (in *ProvisionHook) DeepCopyInto(out *ProvisionHook) {
 	*out = *in
-	out.ClusterDeploymentRef = in.ClusterDeploymentRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int64)
-		**out = **in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
 	}
-	if in.Autoscaling != nil {
-		in, out := &in.Autoscaling, &out.Autoscaling
-		*out = new(MachinePoolAutoscaling)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
 		**out = **in
 	}
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Taints != nil {
-		in, out := &in.Taints, &out.Taints
-		*out = make([]corev1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSpec.
-func (in *MachinePoolSpec) DeepCopy() *MachinePoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionHook.
+func (in *ProvisionHook) DeepCopy() *ProvisionHook {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolSpec)
+	out := new(ProvisionHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
+func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 	*out = *in
-	if in.MachineSets != nil {
-		in, out := &in.MachineSets, &out.MachineSets
-		*out = make([]MachineSetStatus, len(*in))
+	out.InstallConfigSecretRef = in.InstallConfigSecretRef
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	if in.ManifestsConfigMapRef != nil {
+		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]InstallManifestsSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(Networking)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHPrivateKeySecretRef != nil {
+		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SSHKnownHosts != nil {
+		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]MachinePoolCondition, len(*in))
+	if in.InstallerEnv != nil {
+		in, out := &in.InstallerEnv, &out.InstallerEnv
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(InstallRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolStatus.
-func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
+func (in *Provisioning) DeepCopy() *Provisioning {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolStatus)
+	out := new(Provisioning)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
+func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetStatus.
-func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineSetStatus)
+	out := new(Proxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
+func (in *PullThroughCacheConfig) DeepCopyInto(out *PullThroughCacheConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAWSConfig.
-func (in *ManageDNSAWSConfig) DeepCopy() *ManageDNSAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullThroughCacheConfig.
+func (in *PullThroughCacheConfig) DeepCopy() *PullThroughCacheConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSAWSConfig)
+	out := new(PullThroughCacheConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSAzureConfig) DeepCopyInto(out *ManageDNSAzureConfig) {
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSAzureConfig.
-func (in *ManageDNSAzureConfig) DeepCopy() *ManageDNSAzureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSAzureConfig)
+	out := new(RegistryMirror)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
+func (in *RemainingCloudResource) DeepCopyInto(out *RemainingCloudResource) {
 	*out = *in
-	if in.Domains != nil {
-		in, out := &in.Domains, &out.Domains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(ManageDNSAWSConfig)
-		**out = **in
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(ManageDNSGCPConfig)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(ManageDNSAzureConfig)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
-func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemainingCloudResource.
+func (in *RemainingCloudResource) DeepCopy() *RemainingCloudResource {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSConfig)
+	out := new(RemainingCloudResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+func (in *ResourceRequestBounds) DeepCopyInto(out *ResourceRequestBounds) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
-func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequestBounds.
+func (in *ResourceRequestBounds) DeepCopy() *ResourceRequestBounds {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSGCPConfig)
+	out := new(ResourceRequestBounds)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
+func (in *SSHKeyStatus) DeepCopyInto(out *SSHKeyStatus) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
-func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyStatus.
+func (in *SSHKeyStatus) DeepCopy() *SSHKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenStackClusterDeprovision)
+	out := new(SSHKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
+func (in *SchedulingConfig) DeepCopyInto(out *SchedulingConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
-	out.CertificatesSecretRef = in.CertificatesSecretRef
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = new(PodSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(PodSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(PodSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
-func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingConfig.
+func (in *SchedulingConfig) DeepCopy() *SchedulingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OvirtClusterDeprovision)
+	out := new(SchedulingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Platform) DeepCopyInto(out *Platform) {
+func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(aws.Platform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(azure.Platform)
-		**out = **in
-	}
-	if in.BareMetal != nil {
-		in, out := &in.BareMetal, &out.BareMetal
-		*out = new(baremetal.Platform)
-		**out = **in
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(gcp.Platform)
-		**out = **in
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(openstack.Platform)
-		**out = **in
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(vsphere.Platform)
-		**out = **in
-	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(ovirt.Platform)
-		**out = **in
-	}
+	out.SourceRef = in.SourceRef
+	out.TargetRef = in.TargetRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
-func (in *Platform) DeepCopy() *Platform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretMapping.
+func (in *SecretMapping) DeepCopy() *SecretMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(Platform)
+	out := new(SecretMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provisioning) DeepCopyInto(out *Provisioning) {
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
-	out.InstallConfigSecretRef = in.InstallConfigSecretRef
-	if in.ImageSetRef != nil {
-		in, out := &in.ImageSetRef, &out.ImageSetRef
-		*out = new(ClusterImageSetReference)
-		**out = **in
-	}
-	if in.ManifestsConfigMapRef != nil {
-		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHPrivateKeySecretRef != nil {
-		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHKnownHosts != nil {
-		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstallerEnv != nil {
-		in, out := &in.InstallerEnv, &out.InstallerEnv
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
-func (in *Provisioning) DeepCopy() *Provisioning {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(Provisioning)
+	out := new(SecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
+func (in *SecretReferenceCondition) DeepCopyInto(out *SecretReferenceCondition) {
 	*out = *in
-	out.SourceRef = in.SourceRef
-	out.TargetRef = in.TargetRef
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretMapping.
-func (in *SecretMapping) DeepCopy() *SecretMapping {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReferenceCondition.
+func (in *SecretReferenceCondition) DeepCopy() *SecretReferenceCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretMapping)
+	out := new(SecretReferenceCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+func (in *SecretReferenceStatus) DeepCopyInto(out *SecretReferenceStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]SecretReferenceCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReferenceStatus.
+func (in *SecretReferenceStatus) DeepCopy() *SecretReferenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(SecretReferenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2742,6 +4808,11 @@ func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.ClusterDeploymentFieldSelector != nil {
+		in, out := &in.ClusterDeploymentFieldSelector, &out.ClusterDeploymentFieldSelector
+		*out = new(ClusterDeploymentFieldSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2928,6 +4999,22 @@ func (in *SyncObjectPatch) DeepCopy() *SyncObjectPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncObjectReference) DeepCopyInto(out *SyncObjectReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncObjectReference.
+func (in *SyncObjectReference) DeepCopy() *SyncObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSet) DeepCopyInto(out *SyncSet) {
 	*out = *in
@@ -2956,6 +5043,22 @@ func (in *SyncSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetAgentConfig) DeepCopyInto(out *SyncSetAgentConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetAgentConfig.
+func (in *SyncSetAgentConfig) DeepCopy() *SyncSetAgentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetAgentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 	*out = *in
@@ -2966,6 +5069,11 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]SyncSetResourceReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]SyncObjectPatch, len(*in))
@@ -2976,6 +5084,16 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 		*out = make([]SecretMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcesToDelete != nil {
+		in, out := &in.ResourcesToDelete, &out.ResourcesToDelete
+		*out = make([]SyncObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReapplyInterval != nil {
+		in, out := &in.ReapplyInterval, &out.ReapplyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -3066,6 +5184,22 @@ func (in *SyncSetObjectStatus) DeepCopy() *SyncSetObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetResourceReference) DeepCopyInto(out *SyncSetResourceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetResourceReference.
+func (in *SyncSetResourceReference) DeepCopy() *SyncSetResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 	*out = *in
@@ -3127,6 +5261,22 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantReplicaLimit) DeepCopyInto(out *TenantReplicaLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantReplicaLimit.
+func (in *TenantReplicaLimit) DeepCopy() *TenantReplicaLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantReplicaLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterDeprovision) DeepCopyInto(out *VSphereClusterDeprovision) {
 	*out = *in
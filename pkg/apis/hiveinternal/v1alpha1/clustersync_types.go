@@ -40,6 +40,31 @@ type ClusterSyncStatus struct {
 	// FirstSuccessTime is the time we first successfully applied all (selector)syncsets to a cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// LastReapplyRequest is the result of the last on-demand reapply of a SyncSet or SelectorSyncSet requested with
+	// the hive.openshift.io/reapply-syncset annotation on the ClusterDeployment.
+	// +optional
+	LastReapplyRequest *ReapplyRequestStatus `json:"lastReapplyRequest,omitempty"`
+}
+
+// ReapplyRequestStatus is the result of an on-demand reapply of a SyncSet or SelectorSyncSet to the cluster.
+type ReapplyRequestStatus struct {
+	// Kind is the kind of the reapplied syncset, either SyncSet or SelectorSyncSet.
+	Kind string `json:"kind"`
+
+	// Name is the name of the reapplied syncset.
+	Name string `json:"name"`
+
+	// Result is the result of the reapply.
+	Result SyncSetResult `json:"result"`
+
+	// FailureMessage is a message describing why the syncset could not be reapplied. This is only set when Result is
+	// Failure.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// CompletionTime is when the reapply completed.
+	CompletionTime metav1.Time `json:"completionTime"`
 }
 
 // SyncStatus is the status of applying a specific SyncSet or SelectorSyncSet to the cluster.
@@ -55,6 +80,16 @@ type SyncStatus struct {
 	// +optional
 	ResourcesToDelete []SyncResourceReference `json:"resourcesToDelete,omitempty"`
 
+	// ResourcesDeleted is the list of resources in spec.resourcesToDelete of the SyncSet or SelectorSyncSet that were
+	// deleted from the cluster, or were not found in it, the last time the SyncSet or SelectorSyncSet was applied.
+	// +optional
+	ResourcesDeleted []SyncResourceReference `json:"resourcesDeleted,omitempty"`
+
+	// Resources is the apply status of each of the resources, secrets and patches of the SyncSet or SelectorSyncSet,
+	// in the order in which they are applied.
+	// +optional
+	Resources []SyncResourceStatus `json:"resources,omitempty"`
+
 	// Result is the result of the last attempt to apply the SyncSet or SelectorSyncSet to the cluster.
 	Result SyncSetResult `json:"result"`
 
@@ -69,6 +104,34 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// LastApplyTime is the time when the SyncSet or SelectorSyncSet was last applied to the cluster. It is only set
+	// for a SyncSet or SelectorSyncSet with a reapply interval, which is reapplied on its own schedule.
+	// +optional
+	LastApplyTime *metav1.Time `json:"lastApplyTime,omitempty"`
+}
+
+// SyncResourceStatus is the status of applying a resource, secret or patch of a SyncSet or SelectorSyncSet to the
+// cluster.
+type SyncResourceStatus struct {
+	SyncResourceReference `json:",inline"`
+
+	// Hash is the hash of the resource, secret or patch that was last applied successfully to the cluster.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// LastApplyTime is the time when the resource, secret or patch was last applied to the cluster with a different
+	// hash or result. Applying it again with the same hash and result, such as during a periodic full re-apply, does
+	// not change the time.
+	LastApplyTime metav1.Time `json:"lastApplyTime"`
+
+	// Result is the result of the last attempt to apply the resource, secret or patch to the cluster.
+	Result SyncSetResult `json:"result"`
+
+	// FailureMessage is a message describing why the resource, secret or patch could not be applied. This is only set
+	// when Result is Failure.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
 }
 
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
@@ -128,6 +191,10 @@ const (
 	// ClusterSyncFailed is the type of condition used to indicate whether there are SyncSets or SelectorSyncSets which
 	// have not been applied due to an error.
 	ClusterSyncFailed ClusterSyncConditionType = "Failed"
+
+	// ClusterSyncPaused is the type of condition used to indicate whether applying the SyncSets and SelectorSyncSets
+	// to the cluster is paused with the hive.openshift.io/syncset-pause annotation on the ClusterDeployment.
+	ClusterSyncPaused ClusterSyncConditionType = "Paused"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
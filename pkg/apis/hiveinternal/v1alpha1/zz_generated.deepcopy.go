@@ -208,6 +208,11 @@ func (in *ClusterSyncStatus) DeepCopyInto(out *ClusterSyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastReapplyRequest != nil {
+		in, out := &in.LastReapplyRequest, &out.LastReapplyRequest
+		*out = new(ReapplyRequestStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -221,6 +226,23 @@ func (in *ClusterSyncStatus) DeepCopy() *ClusterSyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReapplyRequestStatus) DeepCopyInto(out *ReapplyRequestStatus) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReapplyRequestStatus.
+func (in *ReapplyRequestStatus) DeepCopy() *ReapplyRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReapplyRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncResourceReference) DeepCopyInto(out *SyncResourceReference) {
 	*out = *in
@@ -237,6 +259,24 @@ func (in *SyncResourceReference) DeepCopy() *SyncResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceStatus) DeepCopyInto(out *SyncResourceStatus) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	in.LastApplyTime.DeepCopyInto(&out.LastApplyTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceStatus.
+func (in *SyncResourceStatus) DeepCopy() *SyncResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	*out = *in
@@ -245,11 +285,27 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = make([]SyncResourceReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcesDeleted != nil {
+		in, out := &in.ResourcesDeleted, &out.ResourcesDeleted
+		*out = make([]SyncResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]SyncResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	if in.FirstSuccessTime != nil {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastApplyTime != nil {
+		in, out := &in.LastApplyTime, &out.LastApplyTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
@@ -31,6 +32,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 )
 
@@ -63,10 +65,20 @@ type Client interface {
 	TerminateInstances(*ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
 	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
 	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
+	CreateVpcEndpointServiceConfiguration(*ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
+	DescribeVpcEndpointServiceConfigurations(*ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
+	DeleteVpcEndpointServiceConfigurations(*ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
+	ModifyVpcEndpointServicePermissions(*ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
+	CreateVpcEndpoint(*ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
+	DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
 
 	// ELB
 	RegisterInstancesWithLoadBalancer(*elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error)
 
+	// ELBV2
+	DescribeLoadBalancersV2(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+
 	// IAM
 	CreateAccessKey(*iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error)
 	CreateUser(*iam.CreateUserInput) (*iam.CreateUserOutput, error)
@@ -96,6 +108,7 @@ type Client interface {
 	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
 	ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
 	ChangeResourceRecordSets(*route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	AssociateVPCWithHostedZone(*route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
 
 	// ResourceTagging
 	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
@@ -107,6 +120,7 @@ type Client interface {
 type awsClient struct {
 	ec2Client     ec2iface.EC2API
 	elbClient     elbiface.ELBAPI
+	elbv2Client   *elbv2.ELBV2
 	iamClient     iamiface.IAMAPI
 	route53Client route53iface.Route53API
 	s3Client      s3iface.S3API
@@ -164,11 +178,51 @@ func (c *awsClient) StartInstances(input *ec2.StartInstancesInput) (*ec2.StartIn
 	return c.ec2Client.StartInstances(input)
 }
 
+func (c *awsClient) CreateVpcEndpointServiceConfiguration(input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateVpcEndpointServiceConfiguration").Inc()
+	return c.ec2Client.CreateVpcEndpointServiceConfiguration(input)
+}
+
+func (c *awsClient) DescribeVpcEndpointServiceConfigurations(input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServiceConfigurations").Inc()
+	return c.ec2Client.DescribeVpcEndpointServiceConfigurations(input)
+}
+
+func (c *awsClient) DeleteVpcEndpointServiceConfigurations(input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpointServiceConfigurations").Inc()
+	return c.ec2Client.DeleteVpcEndpointServiceConfigurations(input)
+}
+
+func (c *awsClient) ModifyVpcEndpointServicePermissions(input *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServicePermissions").Inc()
+	return c.ec2Client.ModifyVpcEndpointServicePermissions(input)
+}
+
+func (c *awsClient) CreateVpcEndpoint(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateVpcEndpoint").Inc()
+	return c.ec2Client.CreateVpcEndpoint(input)
+}
+
+func (c *awsClient) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpoints").Inc()
+	return c.ec2Client.DescribeVpcEndpoints(input)
+}
+
+func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpoints").Inc()
+	return c.ec2Client.DeleteVpcEndpoints(input)
+}
+
 func (c *awsClient) RegisterInstancesWithLoadBalancer(input *elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
 	metricAWSAPICalls.WithLabelValues("RegisterInstancesWithLoadBalancer").Inc()
 	return c.elbClient.RegisterInstancesWithLoadBalancer(input)
 }
 
+func (c *awsClient) DescribeLoadBalancersV2(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeLoadBalancersV2").Inc()
+	return c.elbv2Client.DescribeLoadBalancers(input)
+}
+
 func (c *awsClient) CreateAccessKey(input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateAccessKey").Inc()
 	return c.iamClient.CreateAccessKey(input)
@@ -281,11 +335,26 @@ func (c *awsClient) ChangeResourceRecordSets(input *route53.ChangeResourceRecord
 	return c.route53Client.ChangeResourceRecordSets(input)
 }
 
+func (c *awsClient) AssociateVPCWithHostedZone(input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	metricAWSAPICalls.WithLabelValues("AssociateVPCWithHostedZone").Inc()
+	return c.route53Client.AssociateVPCWithHostedZone(input)
+}
+
 func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetCallerIdentity").Inc()
 	return c.stsClient.GetCallerIdentity(input)
 }
 
+// Options are the options for creating an AWS client.
+type Options struct {
+	// Region is the AWS region of the client.
+	Region string
+
+	// ServiceEndpoints overrides the endpoints of AWS services. Services without an override use the standard
+	// endpoint of the region.
+	ServiceEndpoints []hivev1aws.ServiceEndpoint
+}
+
 // NewClient creates our client wrapper object for the actual AWS clients we use.
 // For authentication the underlying clients will use either the cluster AWS credentials
 // secret if defined (i.e. in the root cluster),
@@ -294,10 +363,15 @@ func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.G
 // Pass a nil client, and empty secret name and namespace to load credentials from the standard
 // AWS environment variables.
 func NewClient(kubeClient client.Client, secretName, namespace, region string) (Client, error) {
+	return NewClientWithOptions(kubeClient, secretName, namespace, Options{Region: region})
+}
+
+// NewClientWithOptions is like NewClient, with the region and service endpoints of the client given in options.
+func NewClientWithOptions(kubeClient client.Client, secretName, namespace string, options Options) (Client, error) {
 
 	// Special case to not use a secret to gather credentials.
 	if secretName == "" {
-		return NewClientFromSecret(nil, region)
+		return NewClientFromSecretWithOptions(nil, options)
 	}
 
 	secret := &corev1.Secret{}
@@ -311,7 +385,7 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 		return nil, err
 	}
 
-	return NewClientFromSecret(secret, region)
+	return NewClientFromSecretWithOptions(secret, options)
 }
 
 // NewClientFromSecret creates our client wrapper object for the actual AWS clients we use.
@@ -321,9 +395,15 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 //
 // Pass a nil secret to load credentials from the standard AWS environment variables.
 func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
+	return NewClientFromSecretWithOptions(secret, Options{Region: region})
+}
+
+// NewClientFromSecretWithOptions is like NewClientFromSecret, with the region and service endpoints of the client
+// given in options.
+func NewClientFromSecretWithOptions(secret *corev1.Secret, options Options) (Client, error) {
 	awsConfig := &aws.Config{
-		Region:           aws.String(region),
-		EndpointResolver: endpoints.ResolverFunc(awsChinaEndpointResolver),
+		Region:           aws.String(options.Region),
+		EndpointResolver: newEndpointResolver(options.Region, options.ServiceEndpoints),
 	}
 
 	// Special case to not use a secret to gather credentials.
@@ -358,6 +438,7 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 	return &awsClient{
 		ec2Client:     ec2.New(s),
 		elbClient:     elb.New(s),
+		elbv2Client:   elbv2.New(s),
 		iamClient:     iam.New(s),
 		s3Client:      s3.New(s),
 		route53Client: route53.New(s),
@@ -366,6 +447,41 @@ func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
 	}, nil
 }
 
+// newEndpointResolver returns a resolver that resolves the endpoints of the services in serviceEndpoints to their
+// URL, and the endpoints of the other services to their standard endpoint.
+func newEndpointResolver(region string, serviceEndpoints []hivev1aws.ServiceEndpoint) endpoints.Resolver {
+	overrides := make(map[string]string, len(serviceEndpoints))
+	for _, e := range serviceEndpoints {
+		overrides[e.Name] = e.URL
+	}
+	return endpoints.ResolverFunc(func(service, r string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		if url, ok := overrides[service]; ok {
+			return endpoints.ResolvedEndpoint{
+				URL:           url,
+				SigningRegion: region,
+			}, nil
+		}
+		return awsChinaEndpointResolver(service, r, optFns...)
+	})
+}
+
+// Route53Region returns the region to use for Route53 operations in the partition of the given region. Route53 is a
+// global service whose endpoint lives in a single region of each partition.
+func Route53Region(region string) string {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return constants.AWSRoute53Region
+	}
+	switch partition.ID() {
+	case endpoints.AwsCnPartitionID:
+		return constants.AWSChinaRoute53Region
+	case endpoints.AwsUsGovPartitionID:
+		return constants.AWSGovCloudRoute53Region
+	default:
+		return constants.AWSRoute53Region
+	}
+}
+
 func awsChinaEndpointResolver(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
 	if service != route53.EndpointsID || region != constants.AWSChinaRoute53Region {
 		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
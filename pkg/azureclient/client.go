@@ -7,6 +7,8 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -36,6 +38,36 @@ type Client interface {
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Load Balancers
+	GetLoadBalancer(ctx context.Context, resourceGroupName, name string) (network.LoadBalancer, error)
+
+	// Network Interfaces
+	GetNetworkInterface(ctx context.Context, resourceGroupName, name string) (network.Interface, error)
+
+	// Subnets
+	GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) (*Subnet, error)
+	CreateOrUpdateSubnet(ctx context.Context, resourceGroupName, virtualNetwork string, subnet *Subnet) error
+	DeleteSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) error
+
+	// Private Link Services
+	GetPrivateLinkService(ctx context.Context, resourceGroupName, name string) (*PrivateLinkService, error)
+	CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *PrivateLinkService) error
+	DeletePrivateLinkService(ctx context.Context, resourceGroupName, name string) error
+
+	// Private Endpoints
+	GetPrivateEndpoint(ctx context.Context, resourceGroupName, name string) (*PrivateEndpoint, error)
+	CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *PrivateEndpoint) error
+	DeletePrivateEndpoint(ctx context.Context, resourceGroupName, name string) error
+
+	// Private DNS Zones
+	GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error)
+	CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string) error
+	DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error
+	CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) error
+	GetVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) (privatedns.VirtualNetworkLink, error)
+	CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name, virtualNetworkID string) error
+	DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) error
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -57,6 +89,13 @@ type azureClient struct {
 	recordSetsClient      *dns.RecordSetsClient
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
+
+	loadBalancersClient       *network.LoadBalancersClient
+	interfacesClient          *network.InterfacesClient
+	networkClient             *network.BaseClient
+	privateZonesClient        *privatedns.PrivateZonesClient
+	privateRecordSetsClient   *privatedns.RecordSetsClient
+	virtualNetworkLinksClient *privatedns.VirtualNetworkLinksClient
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -112,6 +151,55 @@ func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, na
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) GetLoadBalancer(ctx context.Context, resourceGroupName, name string) (network.LoadBalancer, error) {
+	return c.loadBalancersClient.Get(ctx, resourceGroupName, name, "")
+}
+
+func (c *azureClient) GetNetworkInterface(ctx context.Context, resourceGroupName, name string) (network.Interface, error) {
+	return c.interfacesClient.Get(ctx, resourceGroupName, name, "")
+}
+
+func (c *azureClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	return c.privateZonesClient.Get(ctx, resourceGroupName, zone)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	_, err := c.privateZonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+	}, "", "")
+	return err
+}
+
+func (c *azureClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	_, err := c.privateZonesClient.Delete(ctx, resourceGroupName, zone, "")
+	return err
+}
+
+func (c *azureClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) error {
+	_, err := c.privateRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordType, recordSetName, recordSet, "", "")
+	return err
+}
+
+func (c *azureClient) GetVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) (privatedns.VirtualNetworkLink, error) {
+	return c.virtualNetworkLinksClient.Get(ctx, resourceGroupName, zone, name)
+}
+
+func (c *azureClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name, virtualNetworkID string) error {
+	_, err := c.virtualNetworkLinksClient.CreateOrUpdate(ctx, resourceGroupName, zone, name, privatedns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork:      &privatedns.SubResource{ID: to.StringPtr(virtualNetworkID)},
+			RegistrationEnabled: to.BoolPtr(false),
+		},
+	}, "", "")
+	return err
+}
+
+func (c *azureClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) error {
+	_, err := c.virtualNetworkLinksClient.Delete(ctx, resourceGroupName, zone, name, "")
+	return err
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
@@ -129,7 +217,20 @@ func NewClient(creds []byte) (Client, error) {
 	return newClient(authJSONFromBytes(creds))
 }
 
-func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
+// SubscriptionIDFromSecret returns the subscription of the Azure creds in the specified secret.
+func SubscriptionIDFromSecret(secret *corev1.Secret) (string, error) {
+	authMap, err := readAuthMap(authJSONFromSecretSource(secret))
+	if err != nil {
+		return "", err
+	}
+	subscriptionID, ok := authMap["subscriptionId"]
+	if !ok {
+		return "", errors.New("missing subscriptionId in auth")
+	}
+	return subscriptionID, nil
+}
+
+func readAuthMap(authJSONSource func() ([]byte, error)) (map[string]string, error) {
 	authJSON, err := authJSONSource()
 	if err != nil {
 		return nil, err
@@ -138,6 +239,14 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 	if err := json.Unmarshal(authJSON, &authMap); err != nil {
 		return nil, err
 	}
+	return authMap, nil
+}
+
+func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
+	authMap, err := readAuthMap(authJSONSource)
+	if err != nil {
+		return nil, err
+	}
 	clientID, ok := authMap["clientId"]
 	if !ok {
 		return nil, errors.New("missing clientId in auth")
@@ -174,11 +283,35 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
+	loadBalancersClient := network.NewLoadBalancersClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	loadBalancersClient.Authorizer = authorizer
+
+	interfacesClient := network.NewInterfacesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	interfacesClient.Authorizer = authorizer
+
+	networkClient := network.NewWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	networkClient.Authorizer = authorizer
+
+	privateZonesClient := privatedns.NewPrivateZonesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	privateZonesClient.Authorizer = authorizer
+
+	privateRecordSetsClient := privatedns.NewRecordSetsClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	privateRecordSetsClient.Authorizer = authorizer
+
+	virtualNetworkLinksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	virtualNetworkLinksClient.Authorizer = authorizer
+
 	return &azureClient{
-		resourceSKUsClient:    &resourceSKUsClient,
-		recordSetsClient:      &recordSetsClient,
-		zonesClient:           &zonesClient,
-		virtualMachinesClient: &virtualMachinesClient,
+		resourceSKUsClient:        &resourceSKUsClient,
+		recordSetsClient:          &recordSetsClient,
+		zonesClient:               &zonesClient,
+		virtualMachinesClient:     &virtualMachinesClient,
+		loadBalancersClient:       &loadBalancersClient,
+		interfacesClient:          &interfacesClient,
+		networkClient:             &networkClient,
+		privateZonesClient:        &privateZonesClient,
+		privateRecordSetsClient:   &privateRecordSetsClient,
+		virtualNetworkLinksClient: &virtualNetworkLinksClient,
 	}, nil
 }
 
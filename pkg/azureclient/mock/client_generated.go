@@ -8,6 +8,8 @@ import (
 	context "context"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	privatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	reflect "reflect"
@@ -184,6 +186,265 @@ func (mr *MockClientMockRecorder) StartVirtualMachine(ctx, resourceGroup, name i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVirtualMachine", reflect.TypeOf((*MockClient)(nil).StartVirtualMachine), ctx, resourceGroup, name)
 }
 
+// GetLoadBalancer mocks base method
+func (m *MockClient) GetLoadBalancer(ctx context.Context, resourceGroupName, name string) (network.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancer", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(network.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancer indicates an expected call of GetLoadBalancer
+func (mr *MockClientMockRecorder) GetLoadBalancer(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockClient)(nil).GetLoadBalancer), ctx, resourceGroupName, name)
+}
+
+// GetNetworkInterface mocks base method
+func (m *MockClient) GetNetworkInterface(ctx context.Context, resourceGroupName, name string) (network.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInterface", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(network.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkInterface indicates an expected call of GetNetworkInterface
+func (mr *MockClientMockRecorder) GetNetworkInterface(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInterface", reflect.TypeOf((*MockClient)(nil).GetNetworkInterface), ctx, resourceGroupName, name)
+}
+
+// GetSubnet mocks base method
+func (m *MockClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) (*azureclient.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnet", ctx, resourceGroupName, virtualNetwork, name)
+	ret0, _ := ret[0].(*azureclient.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnet indicates an expected call of GetSubnet
+func (mr *MockClientMockRecorder) GetSubnet(ctx, resourceGroupName, virtualNetwork, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, resourceGroupName, virtualNetwork, name)
+}
+
+// CreateOrUpdateSubnet mocks base method
+func (m *MockClient) CreateOrUpdateSubnet(ctx context.Context, resourceGroupName, virtualNetwork string, subnet *azureclient.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateSubnet", ctx, resourceGroupName, virtualNetwork, subnet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateSubnet indicates an expected call of CreateOrUpdateSubnet
+func (mr *MockClientMockRecorder) CreateOrUpdateSubnet(ctx, resourceGroupName, virtualNetwork, subnet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateSubnet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateSubnet), ctx, resourceGroupName, virtualNetwork, subnet)
+}
+
+// DeleteSubnet mocks base method
+func (m *MockClient) DeleteSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnet", ctx, resourceGroupName, virtualNetwork, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnet indicates an expected call of DeleteSubnet
+func (mr *MockClientMockRecorder) DeleteSubnet(ctx, resourceGroupName, virtualNetwork, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockClient)(nil).DeleteSubnet), ctx, resourceGroupName, virtualNetwork, name)
+}
+
+// GetPrivateLinkService mocks base method
+func (m *MockClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, name string) (*azureclient.PrivateLinkService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateLinkService", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(*azureclient.PrivateLinkService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateLinkService indicates an expected call of GetPrivateLinkService
+func (mr *MockClientMockRecorder) GetPrivateLinkService(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateLinkService", reflect.TypeOf((*MockClient)(nil).GetPrivateLinkService), ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdatePrivateLinkService mocks base method
+func (m *MockClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *azureclient.PrivateLinkService) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateLinkService", ctx, resourceGroupName, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdatePrivateLinkService indicates an expected call of CreateOrUpdatePrivateLinkService
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateLinkService(ctx, resourceGroupName, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateLinkService", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateLinkService), ctx, resourceGroupName, service)
+}
+
+// DeletePrivateLinkService mocks base method
+func (m *MockClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateLinkService", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateLinkService indicates an expected call of DeletePrivateLinkService
+func (mr *MockClientMockRecorder) DeletePrivateLinkService(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateLinkService", reflect.TypeOf((*MockClient)(nil).DeletePrivateLinkService), ctx, resourceGroupName, name)
+}
+
+// GetPrivateEndpoint mocks base method
+func (m *MockClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, name string) (*azureclient.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateEndpoint", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(*azureclient.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateEndpoint indicates an expected call of GetPrivateEndpoint
+func (mr *MockClientMockRecorder) GetPrivateEndpoint(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateEndpoint", reflect.TypeOf((*MockClient)(nil).GetPrivateEndpoint), ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdatePrivateEndpoint mocks base method
+func (m *MockClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *azureclient.PrivateEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateEndpoint", ctx, resourceGroupName, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdatePrivateEndpoint indicates an expected call of CreateOrUpdatePrivateEndpoint
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateEndpoint(ctx, resourceGroupName, endpoint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateEndpoint), ctx, resourceGroupName, endpoint)
+}
+
+// DeletePrivateEndpoint mocks base method
+func (m *MockClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateEndpoint", ctx, resourceGroupName, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateEndpoint indicates an expected call of DeletePrivateEndpoint
+func (mr *MockClientMockRecorder) DeletePrivateEndpoint(ctx, resourceGroupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).DeletePrivateEndpoint), ctx, resourceGroupName, name)
+}
+
+// GetPrivateZone mocks base method
+func (m *MockClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateZone indicates an expected call of GetPrivateZone
+func (mr *MockClientMockRecorder) GetPrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateZone", reflect.TypeOf((*MockClient)(nil).GetPrivateZone), ctx, resourceGroupName, zone)
+}
+
+// CreateOrUpdatePrivateZone mocks base method
+func (m *MockClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdatePrivateZone indicates an expected call of CreateOrUpdatePrivateZone
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateZone), ctx, resourceGroupName, zone)
+}
+
+// DeletePrivateZone mocks base method
+func (m *MockClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateZone indicates an expected call of DeletePrivateZone
+func (mr *MockClientMockRecorder) DeletePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateZone", reflect.TypeOf((*MockClient)(nil).DeletePrivateZone), ctx, resourceGroupName, zone)
+}
+
+// CreateOrUpdatePrivateRecordSet mocks base method
+func (m *MockClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdatePrivateRecordSet indicates an expected call of CreateOrUpdatePrivateRecordSet
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+}
+
+// GetVirtualNetworkLink mocks base method
+func (m *MockClient) GetVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) (privatedns.VirtualNetworkLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualNetworkLink", ctx, resourceGroupName, zone, name)
+	ret0, _ := ret[0].(privatedns.VirtualNetworkLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualNetworkLink indicates an expected call of GetVirtualNetworkLink
+func (mr *MockClientMockRecorder) GetVirtualNetworkLink(ctx, resourceGroupName, zone, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).GetVirtualNetworkLink), ctx, resourceGroupName, zone, name)
+}
+
+// CreateOrUpdateVirtualNetworkLink mocks base method
+func (m *MockClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name, virtualNetworkID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateVirtualNetworkLink", ctx, resourceGroupName, zone, name, virtualNetworkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateVirtualNetworkLink indicates an expected call of CreateOrUpdateVirtualNetworkLink
+func (mr *MockClientMockRecorder) CreateOrUpdateVirtualNetworkLink(ctx, resourceGroupName, zone, name, virtualNetworkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateVirtualNetworkLink), ctx, resourceGroupName, zone, name, virtualNetworkID)
+}
+
+// DeleteVirtualNetworkLink mocks base method
+func (m *MockClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualNetworkLink", ctx, resourceGroupName, zone, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualNetworkLink indicates an expected call of DeleteVirtualNetworkLink
+func (mr *MockClientMockRecorder) DeleteVirtualNetworkLink(ctx, resourceGroupName, zone, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).DeleteVirtualNetworkLink), ctx, resourceGroupName, zone, name)
+}
+
 // MockResourceSKUsPage is a mock of ResourceSKUsPage interface
 type MockResourceSKUsPage struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,222 @@
+package azureclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// The vendored network API predates Azure Private Link, so subnets with private link service network policies,
+// private link services and private endpoints are managed through the REST API directly.
+
+const privateLinkAPIVersion = "2020-05-01"
+
+// SubResource references another Azure resource by ID.
+type SubResource struct {
+	ID string `json:"id,omitempty"`
+}
+
+// Subnet is a subnet of a virtual network.
+type Subnet struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Properties *SubnetProperties `json:"properties,omitempty"`
+}
+
+// SubnetProperties are the properties of a subnet.
+type SubnetProperties struct {
+	AddressPrefix string `json:"addressPrefix,omitempty"`
+
+	// PrivateLinkServiceNetworkPolicies must be Disabled for the subnet to hold the NAT addresses of a private link
+	// service.
+	PrivateLinkServiceNetworkPolicies string `json:"privateLinkServiceNetworkPolicies,omitempty"`
+
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkService publishes a load balancer through Azure Private Link.
+type PrivateLinkService struct {
+	ID         string                        `json:"id,omitempty"`
+	Name       string                        `json:"name,omitempty"`
+	Location   string                        `json:"location,omitempty"`
+	Properties *PrivateLinkServiceProperties `json:"properties,omitempty"`
+}
+
+// PrivateLinkServiceProperties are the properties of a private link service.
+type PrivateLinkServiceProperties struct {
+	// LoadBalancerFrontendIPConfigurations are the frontend IP configurations of the load balancer being
+	// published.
+	LoadBalancerFrontendIPConfigurations []SubResource `json:"loadBalancerFrontendIpConfigurations,omitempty"`
+
+	// IPConfigurations are the NAT addresses used as the source addresses of the connections from the private
+	// endpoints.
+	IPConfigurations []PrivateLinkServiceIPConfiguration `json:"ipConfigurations,omitempty"`
+
+	// Visibility lists the subscriptions that may connect to the private link service.
+	Visibility *PrivateLinkServiceSubscriptions `json:"visibility,omitempty"`
+
+	// AutoApproval lists the subscriptions whose connections are approved without manual action.
+	AutoApproval *PrivateLinkServiceSubscriptions `json:"autoApproval,omitempty"`
+
+	Alias             string `json:"alias,omitempty"`
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkServiceIPConfiguration is a NAT address of a private link service.
+type PrivateLinkServiceIPConfiguration struct {
+	Name       string                                       `json:"name,omitempty"`
+	Properties *PrivateLinkServiceIPConfigurationProperties `json:"properties,omitempty"`
+}
+
+// PrivateLinkServiceIPConfigurationProperties are the properties of a NAT address of a private link service.
+type PrivateLinkServiceIPConfigurationProperties struct {
+	Subnet                    *SubResource `json:"subnet,omitempty"`
+	PrivateIPAllocationMethod string       `json:"privateIPAllocationMethod,omitempty"`
+	Primary                   bool         `json:"primary,omitempty"`
+}
+
+// PrivateLinkServiceSubscriptions is a list of subscriptions.
+type PrivateLinkServiceSubscriptions struct {
+	Subscriptions []string `json:"subscriptions,omitempty"`
+}
+
+// PrivateEndpoint connects a subnet to a private link service.
+type PrivateEndpoint struct {
+	ID         string                     `json:"id,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Location   string                     `json:"location,omitempty"`
+	Properties *PrivateEndpointProperties `json:"properties,omitempty"`
+}
+
+// PrivateEndpointProperties are the properties of a private endpoint.
+type PrivateEndpointProperties struct {
+	// Subnet is the subnet from which the address of the private endpoint is allocated.
+	Subnet *SubResource `json:"subnet,omitempty"`
+
+	// PrivateLinkServiceConnections are the connections of the private endpoint to private link services.
+	PrivateLinkServiceConnections []PrivateLinkServiceConnection `json:"privateLinkServiceConnections,omitempty"`
+
+	// NetworkInterfaces are the network interfaces created for the private endpoint.
+	NetworkInterfaces []SubResource `json:"networkInterfaces,omitempty"`
+
+	ProvisioningState string `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkServiceConnection is a connection of a private endpoint to a private link service.
+type PrivateLinkServiceConnection struct {
+	Name       string                                  `json:"name,omitempty"`
+	Properties *PrivateLinkServiceConnectionProperties `json:"properties,omitempty"`
+}
+
+// PrivateLinkServiceConnectionProperties are the properties of a connection of a private endpoint to a private
+// link service.
+type PrivateLinkServiceConnectionProperties struct {
+	PrivateLinkServiceID string `json:"privateLinkServiceId,omitempty"`
+	RequestMessage       string `json:"requestMessage,omitempty"`
+
+	// PrivateLinkServiceConnectionState is the state of the connection as seen by the private link service.
+	PrivateLinkServiceConnectionState *PrivateLinkServiceConnectionState `json:"privateLinkServiceConnectionState,omitempty"`
+}
+
+// PrivateLinkServiceConnectionState is the state of a connection to a private link service.
+type PrivateLinkServiceConnectionState struct {
+	// Status is one of Pending, Approved, Rejected or Disconnected.
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (c *azureClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) (*Subnet, error) {
+	subnet := &Subnet{}
+	if err := c.doNetworkRequest(ctx, "GetSubnet", http.MethodGet, subnetPath(resourceGroupName, virtualNetwork, name), nil, subnet); err != nil {
+		return nil, err
+	}
+	return subnet, nil
+}
+
+func (c *azureClient) CreateOrUpdateSubnet(ctx context.Context, resourceGroupName, virtualNetwork string, subnet *Subnet) error {
+	return c.doNetworkRequest(ctx, "CreateOrUpdateSubnet", http.MethodPut, subnetPath(resourceGroupName, virtualNetwork, subnet.Name), subnet, nil)
+}
+
+func (c *azureClient) DeleteSubnet(ctx context.Context, resourceGroupName, virtualNetwork, name string) error {
+	return c.doNetworkRequest(ctx, "DeleteSubnet", http.MethodDelete, subnetPath(resourceGroupName, virtualNetwork, name), nil, nil)
+}
+
+func (c *azureClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, name string) (*PrivateLinkService, error) {
+	service := &PrivateLinkService{}
+	if err := c.doNetworkRequest(ctx, "GetPrivateLinkService", http.MethodGet, networkResourcePath(resourceGroupName, "privateLinkServices", name), nil, service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, service *PrivateLinkService) error {
+	return c.doNetworkRequest(ctx, "CreateOrUpdatePrivateLinkService", http.MethodPut, networkResourcePath(resourceGroupName, "privateLinkServices", service.Name), service, nil)
+}
+
+func (c *azureClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, name string) error {
+	return c.doNetworkRequest(ctx, "DeletePrivateLinkService", http.MethodDelete, networkResourcePath(resourceGroupName, "privateLinkServices", name), nil, nil)
+}
+
+func (c *azureClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, name string) (*PrivateEndpoint, error) {
+	endpoint := &PrivateEndpoint{}
+	if err := c.doNetworkRequest(ctx, "GetPrivateEndpoint", http.MethodGet, networkResourcePath(resourceGroupName, "privateEndpoints", name), nil, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpoint *PrivateEndpoint) error {
+	return c.doNetworkRequest(ctx, "CreateOrUpdatePrivateEndpoint", http.MethodPut, networkResourcePath(resourceGroupName, "privateEndpoints", endpoint.Name), endpoint, nil)
+}
+
+func (c *azureClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, name string) error {
+	return c.doNetworkRequest(ctx, "DeletePrivateEndpoint", http.MethodDelete, networkResourcePath(resourceGroupName, "privateEndpoints", name), nil, nil)
+}
+
+func subnetPath(resourceGroupName, virtualNetwork, name string) string {
+	return networkResourcePath(resourceGroupName, "virtualNetworks", virtualNetwork) + "/subnets/" + autorest.Encode("path", name)
+}
+
+func networkResourcePath(resourceGroupName, resourceType, name string) string {
+	return "/resourceGroups/" + autorest.Encode("path", resourceGroupName) +
+		"/providers/Microsoft.Network/" + resourceType + "/" + autorest.Encode("path", name)
+}
+
+// doNetworkRequest sends a request to the network API for a resource in the subscription of the client. Errors are
+// returned as autorest.DetailedError, like the errors of the generated network client. Long running operations are
+// not waited on.
+func (c *azureClient) doNetworkRequest(ctx context.Context, method, httpMethod, path string, body, out interface{}) error {
+	decorators := []autorest.PrepareDecorator{
+		autorest.WithMethod(httpMethod),
+		autorest.WithBaseURL(c.networkClient.BaseURI),
+		autorest.WithPath("/subscriptions/" + autorest.Encode("path", c.networkClient.SubscriptionID) + path),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": privateLinkAPIVersion}),
+	}
+	if body != nil {
+		decorators = append(decorators, autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(body))
+	}
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", method, nil, "Failure preparing request")
+	}
+
+	resp, err := c.networkClient.Send(req, azure.DoRetryWithRegistration(c.networkClient.Client))
+	if err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", method, resp, "Failure sending request")
+	}
+
+	responders := []autorest.RespondDecorator{
+		c.networkClient.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent),
+	}
+	if out != nil {
+		responders = append(responders, autorest.ByUnmarshallingJSON(out))
+	}
+	responders = append(responders, autorest.ByClosing())
+	if err := autorest.Respond(resp, responders...); err != nil {
+		return autorest.NewErrorWithError(err, "azureclient", method, resp, "Failure responding to request")
+	}
+	return nil
+}
@@ -12,6 +12,10 @@ type FakeHiveV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeHiveV1) AdoptClusterRequests(namespace string) v1.AdoptClusterRequestInterface {
+	return &FakeAdoptClusterRequests{c, namespace}
+}
+
 func (c *FakeHiveV1) Checkpoints(namespace string) v1.CheckpointInterface {
 	return &FakeCheckpoints{c, namespace}
 }
@@ -24,6 +28,10 @@ func (c *FakeHiveV1) ClusterDeployments(namespace string) v1.ClusterDeploymentIn
 	return &FakeClusterDeployments{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterDeploymentCustomizations(namespace string) v1.ClusterDeploymentCustomizationInterface {
+	return &FakeClusterDeploymentCustomizations{c, namespace}
+}
+
 func (c *FakeHiveV1) ClusterDeprovisions(namespace string) v1.ClusterDeprovisionInterface {
 	return &FakeClusterDeprovisions{c, namespace}
 }
@@ -2,12 +2,16 @@
 
 package v1
 
+type AdoptClusterRequestExpansion interface{}
+
 type CheckpointExpansion interface{}
 
 type ClusterClaimExpansion interface{}
 
 type ClusterDeploymentExpansion interface{}
 
+type ClusterDeploymentCustomizationExpansion interface{}
+
 type ClusterDeprovisionExpansion interface{}
 
 type ClusterImageSetExpansion interface{}
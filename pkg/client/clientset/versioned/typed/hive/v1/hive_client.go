@@ -10,9 +10,11 @@ import (
 
 type HiveV1Interface interface {
 	RESTClient() rest.Interface
+	AdoptClusterRequestsGetter
 	CheckpointsGetter
 	ClusterClaimsGetter
 	ClusterDeploymentsGetter
+	ClusterDeploymentCustomizationsGetter
 	ClusterDeprovisionsGetter
 	ClusterImageSetsGetter
 	ClusterPoolsGetter
@@ -34,6 +36,10 @@ type HiveV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *HiveV1Client) AdoptClusterRequests(namespace string) AdoptClusterRequestInterface {
+	return newAdoptClusterRequests(c, namespace)
+}
+
 func (c *HiveV1Client) Checkpoints(namespace string) CheckpointInterface {
 	return newCheckpoints(c, namespace)
 }
@@ -46,6 +52,10 @@ func (c *HiveV1Client) ClusterDeployments(namespace string) ClusterDeploymentInt
 	return newClusterDeployments(c, namespace)
 }
 
+func (c *HiveV1Client) ClusterDeploymentCustomizations(namespace string) ClusterDeploymentCustomizationInterface {
+	return newClusterDeploymentCustomizations(c, namespace)
+}
+
 func (c *HiveV1Client) ClusterDeprovisions(namespace string) ClusterDeprovisionInterface {
 	return newClusterDeprovisions(c, namespace)
 }
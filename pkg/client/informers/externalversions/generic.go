@@ -38,12 +38,16 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=hive.openshift.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("adoptclusterrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().AdoptClusterRequests().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("checkpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().Checkpoints().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterclaims"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterClaims().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdeployments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeployments().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterdeploymentcustomizations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeploymentCustomizations().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdeprovisions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterDeprovisions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterimagesets"):
@@ -8,12 +8,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AdoptClusterRequests returns a AdoptClusterRequestInformer.
+	AdoptClusterRequests() AdoptClusterRequestInformer
 	// Checkpoints returns a CheckpointInformer.
 	Checkpoints() CheckpointInformer
 	// ClusterClaims returns a ClusterClaimInformer.
 	ClusterClaims() ClusterClaimInformer
 	// ClusterDeployments returns a ClusterDeploymentInformer.
 	ClusterDeployments() ClusterDeploymentInformer
+	// ClusterDeploymentCustomizations returns a ClusterDeploymentCustomizationInformer.
+	ClusterDeploymentCustomizations() ClusterDeploymentCustomizationInformer
 	// ClusterDeprovisions returns a ClusterDeprovisionInformer.
 	ClusterDeprovisions() ClusterDeprovisionInformer
 	// ClusterImageSets returns a ClusterImageSetInformer.
@@ -55,6 +59,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AdoptClusterRequests returns a AdoptClusterRequestInformer.
+func (v *version) AdoptClusterRequests() AdoptClusterRequestInformer {
+	return &adoptClusterRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Checkpoints returns a CheckpointInformer.
 func (v *version) Checkpoints() CheckpointInformer {
 	return &checkpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -70,6 +79,11 @@ func (v *version) ClusterDeployments() ClusterDeploymentInformer {
 	return &clusterDeploymentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterDeploymentCustomizations returns a ClusterDeploymentCustomizationInformer.
+func (v *version) ClusterDeploymentCustomizations() ClusterDeploymentCustomizationInformer {
+	return &clusterDeploymentCustomizationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterDeprovisions returns a ClusterDeprovisionInformer.
 func (v *version) ClusterDeprovisions() ClusterDeprovisionInformer {
 	return &clusterDeprovisionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
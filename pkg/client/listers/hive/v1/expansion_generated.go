@@ -2,6 +2,14 @@
 
 package v1
 
+// AdoptClusterRequestListerExpansion allows custom methods to be added to
+// AdoptClusterRequestLister.
+type AdoptClusterRequestListerExpansion interface{}
+
+// AdoptClusterRequestNamespaceListerExpansion allows custom methods to be added to
+// AdoptClusterRequestNamespaceLister.
+type AdoptClusterRequestNamespaceListerExpansion interface{}
+
 // CheckpointListerExpansion allows custom methods to be added to
 // CheckpointLister.
 type CheckpointListerExpansion interface{}
@@ -26,6 +34,14 @@ type ClusterDeploymentListerExpansion interface{}
 // ClusterDeploymentNamespaceLister.
 type ClusterDeploymentNamespaceListerExpansion interface{}
 
+// ClusterDeploymentCustomizationListerExpansion allows custom methods to be added to
+// ClusterDeploymentCustomizationLister.
+type ClusterDeploymentCustomizationListerExpansion interface{}
+
+// ClusterDeploymentCustomizationNamespaceListerExpansion allows custom methods to be added to
+// ClusterDeploymentCustomizationNamespaceLister.
+type ClusterDeploymentCustomizationNamespaceListerExpansion interface{}
+
 // ClusterDeprovisionListerExpansion allows custom methods to be added to
 // ClusterDeprovisionLister.
 type ClusterDeprovisionListerExpansion interface{}
@@ -33,6 +33,8 @@ type AWSCloudBuilder struct {
 	UserTags map[string]string
 	// Region is the AWS region to which to install the cluster
 	Region string
+	// Subnets are the IDs of existing subnets to install the cluster into.
+	Subnets []string
 }
 
 func NewAWSCloudBuilderFromSecret(credsSecret *corev1.Secret) *AWSCloudBuilder {
@@ -74,6 +76,7 @@ func (p *AWSCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			},
 			Region:   p.Region,
 			UserTags: p.UserTags,
+			Subnets:  p.Subnets,
 		},
 	}
 }
@@ -94,7 +97,8 @@ func (p *AWSCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 	// Inject platform details into InstallConfig:
 	ic.Platform = installertypes.Platform{
 		AWS: &awsinstallertypes.Platform{
-			Region: p.Region,
+			Region:  p.Region,
+			Subnets: p.Subnets,
 		},
 	}
 
@@ -31,6 +31,18 @@ type AzureCloudBuilder struct {
 
 	// Region is the Azure region to which to install the cluster.
 	Region string
+
+	// NetworkResourceGroupName is the resource group of the existing virtual network to install the cluster into.
+	NetworkResourceGroupName string
+
+	// VirtualNetwork is the name of an existing virtual network to install the cluster into.
+	VirtualNetwork string
+
+	// ControlPlaneSubnet is the name of the existing subnet for the control plane machines.
+	ControlPlaneSubnet string
+
+	// ComputeSubnet is the name of the existing subnet for the compute machines.
+	ComputeSubnet string
 }
 
 func NewAzureCloudBuilderFromSecret(credsSecret *corev1.Secret) *AzureCloudBuilder {
@@ -69,6 +81,10 @@ func (p *AzureCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			},
 			Region:                      p.Region,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
+			NetworkResourceGroupName:    p.NetworkResourceGroupName,
+			VirtualNetwork:              p.VirtualNetwork,
+			ControlPlaneSubnet:          p.ControlPlaneSubnet,
+			ComputeSubnet:               p.ComputeSubnet,
 		},
 	}
 }
@@ -89,6 +105,10 @@ func (p *AzureCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installerty
 		Azure: &azureinstallertypes.Platform{
 			Region:                      p.Region,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
+			NetworkResourceGroupName:    p.NetworkResourceGroupName,
+			VirtualNetwork:              p.VirtualNetwork,
+			ControlPlaneSubnet:          p.ControlPlaneSubnet,
+			ComputeSubnet:               p.ComputeSubnet,
 		},
 	}
 
@@ -112,6 +112,10 @@ type Builder struct {
 	// MachineNetwork is the subnet to use for the cluster's machine network.
 	MachineNetwork string
 
+	// Networking optionally configures the networks and network type of the cluster. The networks that are set
+	// replace the defaults of the InstallConfig, including MachineNetwork.
+	Networking *hivev1.Networking
+
 	// SkipMachinePools should be true if you do not want Hive to manage MachineSets in the spoke cluster once it is installed.
 	SkipMachinePools bool
 
@@ -162,6 +166,18 @@ func (o *Builder) Validate() error {
 		}
 	}
 
+	if o.Networking != nil {
+		cidrs := append(append([]string{}, o.Networking.MachineNetwork...), o.Networking.ServiceNetwork...)
+		for _, entry := range o.Networking.ClusterNetwork {
+			cidrs = append(cidrs, entry.CIDR)
+		}
+		for _, cidr := range cidrs {
+			if _, err := ipnet.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid network CIDR %q: %s", cidr, err.Error())
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -305,11 +321,43 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 	}
 
 	cd.Spec.Provisioning.InstallConfigSecretRef = corev1.LocalObjectReference{Name: o.getInstallConfigSecretName()}
+	if o.Networking != nil {
+		cd.Spec.Provisioning.Networking = o.Networking.DeepCopy()
+	}
 	cd.Spec.Platform = o.CloudBuilder.GetCloudPlatform(o)
 
 	return cd
 }
 
+// addInstallConfigNetworking replaces the networks of the InstallConfig with those set in the Networking of the
+// builder. The CIDRs have already been validated.
+func (o *Builder) addInstallConfigNetworking(networking *installertypes.Networking) {
+	if o.Networking.NetworkType != "" {
+		networking.NetworkType = o.Networking.NetworkType
+	}
+	if len(o.Networking.MachineNetwork) > 0 {
+		networking.MachineNetwork = make([]installertypes.MachineNetworkEntry, len(o.Networking.MachineNetwork))
+		for i, cidr := range o.Networking.MachineNetwork {
+			networking.MachineNetwork[i] = installertypes.MachineNetworkEntry{CIDR: *ipnet.MustParseCIDR(cidr)}
+		}
+	}
+	if len(o.Networking.ClusterNetwork) > 0 {
+		networking.ClusterNetwork = make([]installertypes.ClusterNetworkEntry, len(o.Networking.ClusterNetwork))
+		for i, entry := range o.Networking.ClusterNetwork {
+			networking.ClusterNetwork[i] = installertypes.ClusterNetworkEntry{
+				CIDR:       *ipnet.MustParseCIDR(entry.CIDR),
+				HostPrefix: entry.HostPrefix,
+			}
+		}
+	}
+	if len(o.Networking.ServiceNetwork) > 0 {
+		networking.ServiceNetwork = make([]ipnet.IPNet, len(o.Networking.ServiceNetwork))
+		for i, cidr := range o.Networking.ServiceNetwork {
+			networking.ServiceNetwork[i] = *ipnet.MustParseCIDR(cidr)
+		}
+	}
+}
+
 func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 	installConfig := &installertypes.InstallConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -348,6 +396,10 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 		AdditionalTrustBundle: o.AdditionalTrustBundle,
 	}
 
+	if o.Networking != nil {
+		o.addInstallConfigNetworking(installConfig.Networking)
+	}
+
 	o.CloudBuilder.addInstallConfigPlatform(o, installConfig)
 
 	d, err := yaml.Marshal(installConfig)
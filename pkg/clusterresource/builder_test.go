@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+	installertypes "github.com/openshift/installer/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -155,6 +158,41 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, adminKubeconfig.Name, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name)
 			},
 		},
+		{
+			name: "AWS cluster with networking",
+			builder: func() *Builder {
+				awsBuilder := createAWSClusterBuilder()
+				awsBuilder.Networking = &hivev1.Networking{
+					NetworkType:    hivev1.NetworkTypeOVNKubernetes,
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.132.0.0/14", HostPrefix: 24}},
+					ServiceNetwork: []string{"172.31.0.0/16"},
+				}
+				return awsBuilder
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, &hivev1.Networking{
+					NetworkType:    hivev1.NetworkTypeOVNKubernetes,
+					ClusterNetwork: []hivev1.ClusterNetworkEntry{{CIDR: "10.132.0.0/14", HostPrefix: 24}},
+					ServiceNetwork: []string{"172.31.0.0/16"},
+				}, cd.Spec.Provisioning.Networking)
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				require.NotNil(t, installConfigSecret)
+				installConfig := &installertypes.InstallConfig{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+				assert.Equal(t, &installertypes.Networking{
+					NetworkType: hivev1.NetworkTypeOVNKubernetes,
+					MachineNetwork: []installertypes.MachineNetworkEntry{
+						{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")},
+					},
+					ClusterNetwork: []installertypes.ClusterNetworkEntry{
+						{CIDR: *ipnet.MustParseCIDR("10.132.0.0/14"), HostPrefix: 24},
+					},
+					ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.31.0.0/16")},
+				}, installConfig.Networking)
+			},
+		},
 		{
 			name:    "Azure cluster",
 			builder: createAzureClusterBuilder(),
@@ -32,6 +32,18 @@ type GCPCloudBuilder struct {
 
 	// Region is the GCP region to which to install the cluster.
 	Region string
+
+	// NetworkProjectID is the host project of the Shared VPC holding Network.
+	NetworkProjectID string
+
+	// Network is the name of an existing VPC network to install the cluster into.
+	Network string
+
+	// ControlPlaneSubnet is the name of the existing subnet for the control plane machines.
+	ControlPlaneSubnet string
+
+	// ComputeSubnet is the name of the existing subnet for the compute machines.
+	ComputeSubnet string
 }
 
 func NewGCPCloudBuilderFromSecret(credsSecret *corev1.Secret) (*GCPCloudBuilder, error) {
@@ -73,7 +85,11 @@ func (p *GCPCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
 			},
-			Region: p.Region,
+			Region:             p.Region,
+			NetworkProjectID:   p.NetworkProjectID,
+			Network:            p.Network,
+			ControlPlaneSubnet: p.ControlPlaneSubnet,
+			ComputeSubnet:      p.ComputeSubnet,
 		},
 	}
 }
@@ -88,8 +104,11 @@ func (p *GCPCloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachineP
 func (p *GCPCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertypes.InstallConfig) {
 	ic.Platform = installertypes.Platform{
 		GCP: &installergcp.Platform{
-			ProjectID: p.ProjectID,
-			Region:    p.Region,
+			ProjectID:          p.ProjectID,
+			Region:             p.Region,
+			Network:            p.Network,
+			ControlPlaneSubnet: p.ControlPlaneSubnet,
+			ComputeSubnet:      p.ComputeSubnet,
 		},
 	}
 
@@ -0,0 +1,63 @@
+// Package clusterselector matches ClusterDeployments against the field selectors of SelectorSyncSets.
+package clusterselector
+
+import (
+	"github.com/blang/semver/v4"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Matches returns whether the ClusterDeployment matches every field of the selector that is set. A nil selector
+// matches every ClusterDeployment, and a version range that cannot be parsed matches none.
+func Matches(selector *hivev1.ClusterDeploymentFieldSelector, cd *hivev1.ClusterDeployment) bool {
+	if selector == nil {
+		return true
+	}
+	if len(selector.Platforms) > 0 && !contains(selector.Platforms, cd.Labels[hivev1.HiveClusterPlatformLabel]) {
+		return false
+	}
+	if len(selector.Regions) > 0 && !contains(selector.Regions, cd.Labels[hivev1.HiveClusterRegionLabel]) {
+		return false
+	}
+	if len(selector.PowerStates) > 0 && !containsPowerState(selector.PowerStates, powerState(cd)) {
+		return false
+	}
+	if selector.VersionRange != "" {
+		versionRange, err := semver.ParseRange(selector.VersionRange)
+		if err != nil {
+			return false
+		}
+		version, err := semver.ParseTolerant(cd.Labels[constants.VersionMajorMinorPatchLabel])
+		if err != nil || !versionRange(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// powerState returns the power state of the ClusterDeployment, which is Running when it is not set.
+func powerState(cd *hivev1.ClusterDeployment) hivev1.ClusterPowerState {
+	if cd.Spec.PowerState == "" {
+		return hivev1.RunningClusterPowerState
+	}
+	return cd.Spec.PowerState
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPowerState(states []hivev1.ClusterPowerState, state hivev1.ClusterPowerState) bool {
+	for _, s := range states {
+		if s == state || s == "" && state == hivev1.RunningClusterPowerState {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package clusterselector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestMatches(t *testing.T) {
+	cd := testcd.Build(
+		testcd.WithName("test-cluster"),
+		testcd.WithNamespace("test-namespace"),
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+		testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+		testcd.WithClusterVersion("4.9.12"),
+	)
+	cases := []struct {
+		name     string
+		selector *hivev1.ClusterDeploymentFieldSelector
+		cd       *hivev1.ClusterDeployment
+		expected bool
+	}{
+		{
+			name:     "nil selector",
+			expected: true,
+		},
+		{
+			name:     "empty selector",
+			selector: &hivev1.ClusterDeploymentFieldSelector{},
+			expected: true,
+		},
+		{
+			name:     "platform matches",
+			selector: &hivev1.ClusterDeploymentFieldSelector{Platforms: []string{"gcp", "aws"}},
+			expected: true,
+		},
+		{
+			name:     "platform does not match",
+			selector: &hivev1.ClusterDeploymentFieldSelector{Platforms: []string{"gcp"}},
+			expected: false,
+		},
+		{
+			name:     "region matches",
+			selector: &hivev1.ClusterDeploymentFieldSelector{Regions: []string{"us-east-1"}},
+			expected: true,
+		},
+		{
+			name:     "region does not match",
+			selector: &hivev1.ClusterDeploymentFieldSelector{Regions: []string{"eu-west-1"}},
+			expected: false,
+		},
+		{
+			name:     "version in range",
+			selector: &hivev1.ClusterDeploymentFieldSelector{VersionRange: ">=4.8.0 <4.10.0"},
+			expected: true,
+		},
+		{
+			name:     "version not in range",
+			selector: &hivev1.ClusterDeploymentFieldSelector{VersionRange: ">=4.10.0"},
+			expected: false,
+		},
+		{
+			name:     "version not known",
+			selector: &hivev1.ClusterDeploymentFieldSelector{VersionRange: ">=4.8.0"},
+			cd:       testcd.Build(),
+			expected: false,
+		},
+		{
+			name:     "invalid version range",
+			selector: &hivev1.ClusterDeploymentFieldSelector{VersionRange: "newer than 4.8"},
+			expected: false,
+		},
+		{
+			name:     "power state defaults to running",
+			selector: &hivev1.ClusterDeploymentFieldSelector{PowerStates: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState}},
+			expected: true,
+		},
+		{
+			name:     "power state does not match",
+			selector: &hivev1.ClusterDeploymentFieldSelector{PowerStates: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState}},
+			cd:       testcd.Build(testcd.WithPowerState(hivev1.HibernatingClusterPowerState)),
+			expected: false,
+		},
+		{
+			name:     "power state matches",
+			selector: &hivev1.ClusterDeploymentFieldSelector{PowerStates: []hivev1.ClusterPowerState{hivev1.HibernatingClusterPowerState}},
+			cd:       testcd.Build(testcd.WithPowerState(hivev1.HibernatingClusterPowerState)),
+			expected: true,
+		},
+		{
+			name: "all fields match",
+			selector: &hivev1.ClusterDeploymentFieldSelector{
+				Platforms:    []string{"aws"},
+				Regions:      []string{"us-east-1"},
+				VersionRange: ">=4.9.0",
+				PowerStates:  []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+			},
+			expected: true,
+		},
+		{
+			name: "one field does not match",
+			selector: &hivev1.ClusterDeploymentFieldSelector{
+				Platforms:    []string{"aws"},
+				Regions:      []string{"us-west-2"},
+				VersionRange: ">=4.9.0",
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testCD := cd
+			if tc.cd != nil {
+				testCD = tc.cd
+			}
+			assert.Equal(t, tc.expected, Matches(tc.selector, testCD))
+		})
+	}
+}
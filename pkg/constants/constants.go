@@ -8,6 +8,8 @@ import (
 const (
 	mergedPullSecretSuffix = "merged-pull-secret"
 
+	pullThroughCachePullSecretSuffix = "pull-through-cache-pull-secret"
+
 	// VeleroBackupEnvVar is the name of the environment variable used to tell the controller manager to enable velero backup integration.
 	VeleroBackupEnvVar = "HIVE_VELERO_BACKUP"
 
@@ -18,6 +20,17 @@ const (
 	// processing of any ClusterDeprovisions.
 	DeprovisionsDisabledEnvVar = "DEPROVISIONS_DISABLED"
 
+	// TrustedCABundleConfigMapKey is the key of the CA bundle in the ConfigMap referenced by the trustedCA of a proxy.
+	TrustedCABundleConfigMapKey = "ca-bundle.crt"
+
+	// ExternalDestroyersEnvVar is the name of the environment variable containing the JSON encoded external
+	// destroyers run by deprovision jobs.
+	ExternalDestroyersEnvVar = "EXTERNAL_DESTROYERS"
+
+	// ProvisionHooksEnvVar is the name of the environment variable containing the JSON encoded provision hooks
+	// called by install pods.
+	ProvisionHooksEnvVar = "PROVISION_HOOKS"
+
 	// MinBackupPeriodSecondsEnvVar is the name of the environment variable used to tell the controller manager the minimum period of time between backups.
 	MinBackupPeriodSecondsEnvVar = "HIVE_MIN_BACKUP_PERIOD_SECONDS"
 
@@ -51,6 +64,15 @@ const (
 	// has been deleted.
 	ClusterPoolNameLabel = "hive.openshift.io/cluster-pool-name"
 
+	// ClusterNamespaceLabel is the label that is used to signal that a namespace is owned by the ClusterDeployment
+	// named in the value of the label when namespace-per-cluster is enabled. The label is used to reap namespaces
+	// after the ClusterDeployment has been deleted.
+	ClusterNamespaceLabel = "hive.openshift.io/cluster-namespace"
+
+	// AdoptClusterRequestNameLabel is the label that is used to identify the AdoptClusterRequest which created a
+	// ClusterDeployment for an adopted cluster.
+	AdoptClusterRequestNameLabel = "hive.openshift.io/adopt-cluster-request-name"
+
 	// SyncSetNameLabel is the label that is used to identify a relationship to a given syncset object.
 	SyncSetNameLabel = "hive.openshift.io/syncset-name"
 
@@ -87,6 +109,10 @@ const (
 	// SecretTypeMergedPullSecret is used as a value of SecretTypeLabel that says the secret is specifically used for storing a pull secret.
 	SecretTypeMergedPullSecret = "merged-pull-secret"
 
+	// SecretTypePullThroughCachePullSecret is used as a value of SecretTypeLabel that says the secret is specifically
+	// used for storing the credentials of the pull-through cache for the install and imageset jobs.
+	SecretTypePullThroughCachePullSecret = "pull-through-cache-pull-secret"
+
 	// SecretTypeKubeConfig is used as a value of SecretTypeLabel that says the secret is specifically used for storing a kubeconfig.
 	SecretTypeKubeConfig = "kubeconfig"
 
@@ -99,6 +125,18 @@ const (
 	// SyncSetTypeControlPlaneCerts is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute control plane certificates.
 	SyncSetTypeControlPlaneCerts = "controlplanecerts"
 
+	// SyncSetTypeEtcdBackup is used as a value of SyncSetTypeLabel that says the syncset is specifically used to run etcd backups.
+	SyncSetTypeEtcdBackup = "etcdbackup"
+
+	// SyncSetTypeSSHKey is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute the SSH key of the cluster hosts.
+	SyncSetTypeSSHKey = "sshkey"
+
+	// SyncSetTypePostInstallJobs is used as a value of SyncSetTypeLabel that says the syncset is specifically used to run the post-install jobs of the cluster.
+	SyncSetTypePostInstallJobs = "postinstalljobs"
+
+	// SyncSetTypeSyncSetAgent is used as a value of SyncSetTypeLabel that says the syncset is specifically used to install the syncset agent on the cluster.
+	SyncSetTypeSyncSetAgent = "syncsetagent"
+
 	// SyncSetTypeRemoteIngress is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute remote ingress information.
 	SyncSetTypeRemoteIngress = "remoteingress"
 
@@ -116,12 +154,42 @@ const (
 	// The default is defined above.
 	HiveNamespaceEnvVar = "HIVE_NS"
 
+	// HiveControllersServiceAccountName is the name of the service account used by hive-controllers and
+	// hive-clustersync.
+	HiveControllersServiceAccountName = "hive-controllers"
+
 	// CheckpointName is the name of the object in each namespace in which the namespace's backup information is stored.
 	CheckpointName = "hive"
 
 	// SyncsetPauseAnnotation is a annotation used by clusterDeployment, if it's true, then we will disable syncing to a specific cluster
 	SyncsetPauseAnnotation = "hive.openshift.io/syncset-pause"
 
+	// SyncSetAgentAnnotation is an annotation used on ClusterDeployments to have the syncset agent on the cluster pull
+	// the syncsets of the cluster when it is set to "true". Only the syncset that installs the agent is pushed to
+	// the cluster.
+	SyncSetAgentAnnotation = "hive.openshift.io/syncset-agent"
+
+	// ClusterSyncShardAnnotation is an annotation used on ClusterDeployments to pin the cluster to the
+	// hive-clustersync replica with the given ordinal, such as "2", rather than to the replica picked by hashing the
+	// namespace and name of the ClusterDeployment. The annotation is ignored when the ordinal is not that of a
+	// replica.
+	ClusterSyncShardAnnotation = "hive.openshift.io/clustersync-shard"
+
+	// ReconcilePauseAnnotation is an annotation used on ClusterDeployments to halt reconciliation of the cluster by the
+	// clusterdeployment, clustersync, machinepool, hibernation and clusterversion controllers. Set to "true". This
+	// allows manual changes to be made to the cluster and its resources without Hive undoing them.
+	ReconcilePauseAnnotation = "hive.openshift.io/reconcile-pause"
+
+	// ReapplySyncSetAnnotation is an annotation used on ClusterDeployments to reapply a single SyncSet or
+	// SelectorSyncSet to the cluster right away, rather than waiting for the next full reapply. The value is the kind
+	// and name of the syncset, such as "SyncSet/my-syncset" or "SelectorSyncSet/my-selector-syncset". The annotation
+	// is removed once the syncset has been reapplied, and the result is recorded in the status of the ClusterSync.
+	ReapplySyncSetAnnotation = "hive.openshift.io/reapply-syncset"
+
+	// SyncSetDeletionPolicyAnnotation is an annotation used on the resources of a SyncSet or SelectorSyncSet to
+	// override the resourceDeletionPolicy of the syncset for a single resource. The value is "Delete" or "Orphan".
+	SyncSetDeletionPolicyAnnotation = "hive.openshift.io/syncset-deletion-policy"
+
 	// HiveManagedLabel is a label added to any resources we sync to the remote cluster to help identify that they are
 	// managed by Hive, and any manual changes may be undone the next time the resource is reconciled.
 	HiveManagedLabel = "hive.openshift.io/managed"
@@ -140,14 +208,127 @@ const (
 	// for the cluster provision to complete by running `openshift-install wait-for install-complete` command.
 	WaitForInstallCompleteExecutionsAnnotation = "hive.openshift.io/wait-for-install-complete-executions"
 
+	// DebugModeUntilAnnotation is an annotation used on ClusterDeployments to turn on debug logging for the cluster
+	// until the specified time, in RFC 3339 format. While debug mode is on, the controllers acting on the
+	// ClusterDeployment log at debug level and install jobs run the installer with debug logging. The time can be at
+	// most 24 hours in the future. The annotation is removed once the time has passed.
+	// Example: "2020-09-01T15:00:00Z".
+	DebugModeUntilAnnotation = "hive.openshift.io/debug-mode-until"
+
+	// HibernationPreflightCheckAnnotation is an annotation used on ClusterDeployments to check the cluster for
+	// constructs known to break when the cluster is stopped and started, such as local persistent volumes, before
+	// the cluster is hibernated. When the value is "true" and the check fails, the cluster is not hibernated.
+	HibernationPreflightCheckAnnotation = "hive.openshift.io/hibernation-preflight-check"
+
+	// ForceHibernationAnnotation is an annotation used on ClusterDeployments to hibernate the cluster even when the
+	// hibernation preflight check fails, if the value is "true".
+	ForceHibernationAnnotation = "hive.openshift.io/force-hibernation"
+
+	// HibernationRestartedMachinesAnnotation is an annotation set by the hibernation controller on ClusterDeployments
+	// to record the comma-separated names of the machines it restarted to recover the nodes of the cluster during the
+	// current resume. It is removed when the cluster is next resumed.
+	HibernationRestartedMachinesAnnotation = "hive.openshift.io/hibernation-restarted-machines"
+
 	// ProtectedDeleteAnnotation is an annotation used on ClusterDeployments to indicate that the ClusterDeployment
-	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment.
+	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment. On ClusterPools, it
+	// allows the pool to be deleted only once it is draining and has no unclaimed clusters left.
 	ProtectedDeleteAnnotation = "hive.openshift.io/protected-delete"
 
-	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager whether
-	// protected delete is enabled.
+	// ClusterPoolSpecHashAnnotation is an annotation used on ClusterDeployments created for a ClusterPool to record a
+	// hash of the parts of the spec of the pool from which the cluster was created. The pool uses it to find the
+	// clusters that are outdated after its spec changes.
+	ClusterPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
+
+	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager and
+	// hiveadmission whether protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
 
+	// NamespacePerClusterEnvVar is the name of the environment variable used to tell the controller manager and
+	// hiveadmission whether namespace-per-cluster is enabled.
+	NamespacePerClusterEnvVar = "NAMESPACE_PER_CLUSTER"
+
+	// NamespacePerClusterEnabledTimeEnvVar is the name of the environment variable used to tell the controller
+	// manager the time, in RFC 3339 format, at which namespace-per-cluster was enabled.
+	NamespacePerClusterEnabledTimeEnvVar = "NAMESPACE_PER_CLUSTER_ENABLED_TIME"
+
+	// SyncSetDryRunEnvVar is the name of the environment variable used to tell hiveadmission whether the resources
+	// of SyncSets and SelectorSyncSets are dry-run in a target cluster when they are created or updated.
+	SyncSetDryRunEnvVar = "SYNCSET_DRY_RUN"
+
+	// SyncSetAgentEnvVar is the name of the environment variable containing the JSON encoded syncset agent
+	// configuration from HiveConfig. It is only set when the syncset agent is enabled.
+	SyncSetAgentEnvVar = "SYNCSET_AGENT"
+
+	// JobSchedulingEnvVar is the name of the environment variable containing the JSON encoded scheduling
+	// settings to apply to jobs launched by the hive controllers.
+	JobSchedulingEnvVar = "JOB_SCHEDULING"
+
+	// PullThroughCacheEnvVar is the name of the environment variable containing the JSON encoded pull-through
+	// cache settings to apply to install and imageset jobs.
+	PullThroughCacheEnvVar = "PULL_THROUGH_CACHE"
+
+	// AWSPrivateLinkEnvVar is the name of the environment variable containing the JSON encoded settings for
+	// reaching clusters through AWS PrivateLink.
+	AWSPrivateLinkEnvVar = "AWS_PRIVATELINK"
+
+	// GCPPrivateServiceConnectEnvVar is the name of the environment variable containing the JSON encoded settings
+	// for reaching clusters through GCP Private Service Connect.
+	GCPPrivateServiceConnectEnvVar = "GCP_PRIVATE_SERVICE_CONNECT"
+
+	// AzurePrivateLinkEnvVar is the name of the environment variable containing the JSON encoded settings for
+	// reaching clusters through Azure Private Link.
+	AzurePrivateLinkEnvVar = "AZURE_PRIVATE_LINK"
+
+	// ProvisionPriorityClassEnvVar is the name of the environment variable containing the name of the
+	// PriorityClass assigned to install pods.
+	ProvisionPriorityClassEnvVar = "PROVISION_PRIORITY_CLASS"
+
+	// ClusterSyncPodNameEnvVar is the name of the environment variable containing the name of the hive-clustersync
+	// pod. The ordinal suffix of the name selects the shard of ClusterDeployments synced by the pod.
+	ClusterSyncPodNameEnvVar = "HIVE_CLUSTERSYNC_POD_NAME"
+
+	// ClusterSyncReplicasEnvVar is the name of the environment variable containing the number of hive-clustersync
+	// replicas that the ClusterDeployments are sharded across.
+	ClusterSyncReplicasEnvVar = "HIVE_CLUSTERSYNC_REPLICAS"
+
+	// ClusterSyncShardSeedEnvVar is the name of the environment variable containing the value that is hashed with
+	// the ClusterDeployments to shard them across the hive-clustersync replicas.
+	ClusterSyncShardSeedEnvVar = "HIVE_CLUSTERSYNC_SHARD_SEED"
+
+	// AdmissionPolicyEnvVar is the name of the environment variable containing the JSON encoded settings of the
+	// external policy service consulted by hiveadmission.
+	AdmissionPolicyEnvVar = "ADMISSION_POLICY"
+
+	// ClusterDefaultsEnvVar is the name of the environment variable containing the JSON encoded defaults that
+	// hiveadmission applies to new ClusterDeployments and MachinePools.
+	ClusterDefaultsEnvVar = "CLUSTER_DEFAULTS"
+
+	// MachinePoolReplicaLimitsEnvVar is the name of the environment variable containing the JSON encoded limits on
+	// the total replicas of the MachinePools of each tenant.
+	MachinePoolReplicaLimitsEnvVar = "MACHINE_POOL_REPLICA_LIMITS"
+
+	// ClusterPoolCapacityPoolsEnvVar is the name of the environment variable containing the JSON encoded capacity
+	// pools shared by ClusterPools.
+	ClusterPoolCapacityPoolsEnvVar = "CLUSTER_POOL_CAPACITY_POOLS"
+
+	// DefaultPullSecretAnnotation is an annotation used on namespaces to name the secret in the namespace that is
+	// used as the pull secret of ClusterDeployments created in the namespace without one.
+	DefaultPullSecretAnnotation = "hive.openshift.io/default-pull-secret"
+
+	// DefaultCredentialsSecretAnnotationFormat is the format of the annotations used on namespaces to name the
+	// secret in the namespace that holds the platform credentials of ClusterDeployments created in the namespace
+	// without them. The platform is filled in, for example "hive.openshift.io/default-aws-credentials-secret".
+	DefaultCredentialsSecretAnnotationFormat = "hive.openshift.io/default-%s-credentials-secret"
+
+	// DeleteProtectionAnnotation is an annotation used on ClusterDeployments to opt out of the delete protection
+	// enforced by hiveadmission when delete protection is enabled in HiveConfig. Set to "disabled" to allow the
+	// ClusterDeployment to be deleted.
+	DeleteProtectionAnnotation = "hive.openshift.io/delete-protection"
+
+	// DeleteProtectionDisabled is the value of the DeleteProtectionAnnotation that allows a ClusterDeployment to be
+	// deleted.
+	DeleteProtectionDisabled = "disabled"
+
 	// RelocateAnnotation is an annotation used on ClusterDeployments and DNSZones to indicate that the resource
 	// is involved in a relocation between Hive instances.
 	// The value of the annotation has the format "{ClusterRelocate}/{Status}", where
@@ -213,6 +394,9 @@ const (
 	// AWSChinaRoute53Region is the region to use for AWS China route53 operations.
 	AWSChinaRoute53Region = "cn-northwest-1"
 
+	// AWSGovCloudRoute53Region is the region to use for AWS GovCloud route53 operations.
+	AWSGovCloudRoute53Region = "us-gov-west-1"
+
 	// AWSChinaRegionPrefix is the prefix for regions in AWS China.
 	AWSChinaRegionPrefix = "cn-"
 
@@ -229,12 +413,21 @@ const (
 	// AWSSecretAccessKeySecretKey is the key we use in a Kubernetes Secret containing AWS credentials for the access key ID.
 	AWSSecretAccessKeySecretKey = "aws_secret_access_key"
 
+	// IBMCloudAPIKeySecretKey is the key we use in a Kubernetes Secret containing the IBM Cloud API key.
+	IBMCloudAPIKeySecretKey = "ibmcloud_api_key"
+
+	// IBMCloudAPIKeyEnvVar is the environment variable specifying the IBM Cloud API key.
+	IBMCloudAPIKeyEnvVar = "IC_API_KEY"
+
 	// TLSCrtSecretKey is the key we use in a Kubernetes Secret containing a TLS certificate.
 	TLSCrtSecretKey = "tls.crt"
 
 	// TLSKeySecretKey is the key we use in a Kubernetes Secret containing a TLS certificate key.
 	TLSKeySecretKey = "tls.key"
 
+	// InstallConfigSecretKey is the key we use in a Kubernetes Secret containing an install config.
+	InstallConfigSecretKey = "install-config.yaml"
+
 	// VSphereUsernameEnvVar is the environent variable specifying the vSphere username.
 	VSphereUsernameEnvVar = "GOVC_USERNAME"
 
@@ -256,6 +449,12 @@ const (
 	// VSphereDataStoreEnvVar is the environment variable specifying the vSphere default datastore.
 	VSphereDataStoreEnvVar = "GOVC_DATASTORE"
 
+	// NutanixUsernameEnvVar is the environment variable specifying the Prism Central username.
+	NutanixUsernameEnvVar = "NUTANIX_USERNAME"
+
+	// NutanixPasswordEnvVar is the environment variable specifying the Prism Central password.
+	NutanixPasswordEnvVar = "NUTANIX_PASSWORD"
+
 	// VersionMajorLabel is a label applied to ClusterDeployments to show the version of the cluster
 	// in the form "[MAJOR]".
 	VersionMajorLabel = "hive.openshift.io/version-major"
@@ -285,6 +484,12 @@ const (
 	// InstallLogsUploadProviderAWS is used to specify that AWS is the cloud provider to upload logs to.
 	InstallLogsUploadProviderAWS = "aws"
 
+	// InstallLogsUploadProviderGCP is used to specify that GCP is the cloud provider to upload logs to.
+	InstallLogsUploadProviderGCP = "gcp"
+
+	// InstallLogsUploadProviderAzure is used to specify that Azure is the cloud provider to upload logs to.
+	InstallLogsUploadProviderAzure = "azure"
+
 	// InstallLogsAWSRegionEnvVar is the environment variable specifying the region to use with S3
 	InstallLogsAWSRegionEnvVar = "HIVE_INSTALL_LOGS_AWS_REGION"
 
@@ -294,6 +499,18 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// InstallLogsGCPBucketEnvVar is the environment variable specifying the Google Cloud Storage bucket to use.
+	InstallLogsGCPBucketEnvVar = "HIVE_INSTALL_LOGS_GCP_BUCKET"
+
+	// InstallLogsAzureStorageAccountEnvVar is the environment variable specifying the Azure storage account to use.
+	InstallLogsAzureStorageAccountEnvVar = "HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT"
+
+	// InstallLogsAzureContainerEnvVar is the environment variable specifying the Azure blob container to use.
+	InstallLogsAzureContainerEnvVar = "HIVE_INSTALL_LOGS_AZURE_CONTAINER"
+
+	// AzureStorageAccountKeySecretKey is the key in a secret which holds the access key of an Azure storage account.
+	AzureStorageAccountKeySecretKey = "accountKey"
+
 	// ReconcileIDLen is the length of the random strings we generate for contextual loggers in controller
 	// Reconcile functions.
 	ReconcileIDLen = 8
@@ -303,3 +520,9 @@ const (
 func GetMergedPullSecretName(cd *hivev1.ClusterDeployment) string {
 	return apihelpers.GetResourceName(cd.Name, mergedPullSecretSuffix)
 }
+
+// GetPullThroughCachePullSecretName returns the name of the pull secret per cluster deployment holding the
+// credentials of the pull-through cache for the install and imageset jobs
+func GetPullThroughCachePullSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, pullThroughCachePullSecretSuffix)
+}
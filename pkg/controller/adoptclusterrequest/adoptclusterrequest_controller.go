@@ -0,0 +1,483 @@
+package adoptclusterrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.AdoptClusterRequestControllerName
+
+	// preflightRetryInterval is how long to wait before re-running the preflight checks after they failed.
+	preflightRetryInterval = 5 * time.Minute
+
+	preflightPassedReason           = "PreflightPassed"
+	clusterDeploymentCreatedReason  = "ClusterDeploymentCreated"
+	clusterDeploymentExistsReason   = "ClusterDeploymentExists"
+	kubeconfigSecretNotFoundReason  = "KubeconfigSecretNotFound"
+	invalidKubeconfigReason         = "InvalidKubeconfig"
+	clusterUnreachableReason        = "ClusterUnreachable"
+	discoveryFailedReason           = "DiscoveryFailed"
+	baseDomainMismatchReason        = "BaseDomainMismatch"
+	unsupportedPlatformReason       = "UnsupportedPlatform"
+	credentialsSecretNotFoundReason = "CredentialsSecretNotFound"
+	credentialsInvalidReason        = "CredentialsInvalid"
+	infrastructureNotFoundReason    = "InfrastructureNotFound"
+	gcpInstanceFields               = "items/*/instances(name),nextPageToken"
+	clusterVersionObjectName        = "version"
+	clusterInfrastructureObjectName = "cluster"
+	clusterDNSObjectName            = "cluster"
+)
+
+type awsClientBuilderType func(secret *corev1.Secret, region string) (awsclient.Client, error)
+type gcpClientBuilderType func(secret *corev1.Secret) (gcpclient.Client, error)
+
+// Add creates a new AdoptClusterRequest Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAdoptClusterRequest
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileAdoptClusterRequest {
+	logger := log.WithField("controller", ControllerName)
+	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
+	return &ReconcileAdoptClusterRequest{
+		Client: c,
+		logger: logger,
+		remoteClientBuilder: func(secret *corev1.Secret) remoteclient.Builder {
+			return remoteclient.NewBuilderFromKubeconfig(c, secret)
+		},
+		awsClientBuilder: awsclient.NewClientFromSecret,
+		gcpClientBuilder: gcpclient.NewClientFromSecret,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAdoptClusterRequest, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("adoptclusterrequest-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to AdoptClusterRequest
+	if err := c.Watch(&source.Kind{Type: &hivev1.AdoptClusterRequest{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAdoptClusterRequest{}
+
+// ReconcileAdoptClusterRequest reconciles an AdoptClusterRequest object
+type ReconcileAdoptClusterRequest struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the cluster being adopted from its admin kubeconfig secret.
+	remoteClientBuilder func(secret *corev1.Secret) remoteclient.Builder
+
+	// awsClientBuilder is a function pointer to the function that builds the AWS client used to verify the
+	// infrastructure of the cluster.
+	awsClientBuilder awsClientBuilderType
+
+	// gcpClientBuilder is a function pointer to the function that builds the GCP client used to verify the
+	// infrastructure of the cluster.
+	gcpClientBuilder gcpClientBuilderType
+}
+
+// preflightError is an error found while validating the cluster to adopt. It is reported on the PreflightFailed
+// condition of the AdoptClusterRequest rather than returned from the reconcile.
+type preflightError struct {
+	reason  string
+	message string
+}
+
+func (e *preflightError) Error() string {
+	return e.message
+}
+
+func newPreflightError(reason, format string, args ...interface{}) *preflightError {
+	return &preflightError{reason: reason, message: fmt.Sprintf(format, args...)}
+}
+
+// clusterInfo is the metadata discovered from the cluster to adopt.
+type clusterInfo struct {
+	clusterName string
+	clusterID   string
+	infraID     string
+	platform    openshiftapiv1.PlatformType
+	region      string
+	gcpProject  string
+}
+
+// Reconcile validates the cluster referenced by an AdoptClusterRequest and creates a ClusterDeployment for it.
+func (r *ReconcileAdoptClusterRequest) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "adoptClusterRequest", request.NamespacedName)
+	logger.Info("reconciling adopt cluster request")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the AdoptClusterRequest instance
+	req := &hivev1.AdoptClusterRequest{}
+	if err := r.Get(context.TODO(), request.NamespacedName, req); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("adopt cluster request not found")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting AdoptClusterRequest")
+		return reconcile.Result{}, err
+	}
+
+	if req.DeletionTimestamp != nil {
+		logger.Debug("adopt cluster request is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	if cond := controllerutils.FindAdoptClusterRequestCondition(req.Status.Conditions, hivev1.AdoptClusterRequestAdoptedCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		logger.Debug("cluster has already been adopted")
+		return reconcile.Result{}, nil
+	}
+
+	cdName := req.Spec.ClusterDeploymentName
+	if cdName == "" {
+		cdName = req.Name
+	}
+	logger = logger.WithField("clusterDeployment", cdName)
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: req.Namespace, Name: cdName}, cd); {
+	case err == nil:
+		if cd.Labels[constants.AdoptClusterRequestNameLabel] == req.Name {
+			logger.Info("ClusterDeployment was already created for adopt cluster request")
+			return r.setAdopted(req, cd, logger)
+		}
+		return r.setPreflightFailed(req,
+			newPreflightError(clusterDeploymentExistsReason, "ClusterDeployment %s already exists", cdName),
+			logger)
+	case !apierrors.IsNotFound(err):
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	info, err := r.preflight(req, logger)
+	if err != nil {
+		if pfErr, ok := err.(*preflightError); ok {
+			return r.setPreflightFailed(req, pfErr, logger)
+		}
+		return reconcile.Result{}, err
+	}
+
+	cd = buildClusterDeployment(req, cdName, info)
+	logger.WithField("infraID", info.infraID).Info("creating ClusterDeployment for adopted cluster")
+	if err := r.Create(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create ClusterDeployment")
+		return reconcile.Result{}, errors.Wrap(err, "could not create ClusterDeployment")
+	}
+
+	return r.setAdopted(req, cd, logger)
+}
+
+// preflight validates the admin kubeconfig, discovers the metadata of the cluster, and verifies that the cloud
+// credentials can see the infrastructure of the cluster. Validation failures are returned as a *preflightError.
+func (r *ReconcileAdoptClusterRequest) preflight(req *hivev1.AdoptClusterRequest, logger log.FieldLogger) (*clusterInfo, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: req.Namespace, Name: req.Spec.AdminKubeconfigSecretRef.Name}, kubeconfigSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newPreflightError(kubeconfigSecretNotFoundReason, "admin kubeconfig secret %s not found", req.Spec.AdminKubeconfigSecretRef.Name)
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting admin kubeconfig secret")
+		return nil, err
+	}
+	if len(kubeconfigSecret.Data[constants.KubeconfigSecretKey]) == 0 {
+		return nil, newPreflightError(invalidKubeconfigReason, "admin kubeconfig secret %s does not contain key %s", kubeconfigSecret.Name, constants.KubeconfigSecretKey)
+	}
+	remoteClient, err := r.remoteClientBuilder(kubeconfigSecret).Build()
+	if err != nil {
+		return nil, newPreflightError(invalidKubeconfigReason, "could not build client from admin kubeconfig: %v", err)
+	}
+
+	info, err := discoverClusterInfo(remoteClient, req.Spec.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("infraID", info.infraID).WithField("platform", info.platform)
+	logger.Info("discovered cluster to adopt")
+
+	credsSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: req.Namespace, Name: req.Spec.CredentialsSecretRef.Name}, credsSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newPreflightError(credentialsSecretNotFoundReason, "credentials secret %s not found", req.Spec.CredentialsSecretRef.Name)
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting credentials secret")
+		return nil, err
+	}
+
+	switch info.platform {
+	case openshiftapiv1.AWSPlatformType:
+		err = r.verifyAWSInfrastructure(credsSecret, info, logger)
+	case openshiftapiv1.GCPPlatformType:
+		err = r.verifyGCPInfrastructure(credsSecret, info, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// discoverClusterInfo reads the metadata of the cluster from its ClusterVersion, Infrastructure, and DNS configuration.
+func discoverClusterInfo(c client.Client, baseDomain string) (*clusterInfo, error) {
+	clusterVersion := &openshiftapiv1.ClusterVersion{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: clusterVersionObjectName}, clusterVersion); err != nil {
+		return nil, newPreflightError(clusterUnreachableReason, "could not get ClusterVersion from cluster: %v", err)
+	}
+	infra := &openshiftapiv1.Infrastructure{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: clusterInfrastructureObjectName}, infra); err != nil {
+		return nil, newPreflightError(discoveryFailedReason, "could not get Infrastructure from cluster: %v", err)
+	}
+	dns := &openshiftapiv1.DNS{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: clusterDNSObjectName}, dns); err != nil {
+		return nil, newPreflightError(discoveryFailedReason, "could not get DNS from cluster: %v", err)
+	}
+
+	info := &clusterInfo{
+		clusterID: string(clusterVersion.Spec.ClusterID),
+		infraID:   infra.Status.InfrastructureName,
+		platform:  infra.Status.Platform,
+	}
+	if info.clusterID == "" || info.infraID == "" {
+		return nil, newPreflightError(discoveryFailedReason, "cluster does not report a cluster ID and infrastructure name")
+	}
+
+	suffix := "." + strings.TrimSuffix(baseDomain, ".")
+	clusterDomain := strings.TrimSuffix(dns.Spec.BaseDomain, ".")
+	if !strings.HasSuffix(clusterDomain, suffix) || len(clusterDomain) == len(suffix) {
+		return nil, newPreflightError(baseDomainMismatchReason, "cluster domain %s is not a subdomain of base domain %s", clusterDomain, baseDomain)
+	}
+	info.clusterName = strings.TrimSuffix(clusterDomain, suffix)
+
+	if ps := infra.Status.PlatformStatus; ps != nil {
+		if ps.Type != "" {
+			info.platform = ps.Type
+		}
+		switch {
+		case ps.AWS != nil:
+			info.region = ps.AWS.Region
+		case ps.GCP != nil:
+			info.region = ps.GCP.Region
+			info.gcpProject = ps.GCP.ProjectID
+		}
+	}
+	switch info.platform {
+	case openshiftapiv1.AWSPlatformType, openshiftapiv1.GCPPlatformType:
+	default:
+		return nil, newPreflightError(unsupportedPlatformReason, "adopting clusters on platform %q is not supported", info.platform)
+	}
+	if info.region == "" {
+		return nil, newPreflightError(discoveryFailedReason, "cluster does not report a region for platform %s", info.platform)
+	}
+	return info, nil
+}
+
+func (r *ReconcileAdoptClusterRequest) verifyAWSInfrastructure(secret *corev1.Secret, info *clusterInfo, logger log.FieldLogger) error {
+	awsClient, err := r.awsClientBuilder(secret, info.region)
+	if err != nil {
+		return newPreflightError(credentialsInvalidReason, "could not create AWS client: %v", err)
+	}
+	out, err := awsClient.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String(fmt.Sprintf("tag:kubernetes.io/cluster/%s", info.infraID)),
+				Values: []*string{aws.String("owned")},
+			},
+		},
+	})
+	if err != nil {
+		return newPreflightError(credentialsInvalidReason, "could not list AWS instances: %v", err)
+	}
+	count := 0
+	for _, reservation := range out.Reservations {
+		count += len(reservation.Instances)
+	}
+	logger.WithField("count", count).Debug("found AWS instances of cluster")
+	if count == 0 {
+		return newPreflightError(infrastructureNotFoundReason, "no AWS instances owned by infrastructure %s found in region %s", info.infraID, info.region)
+	}
+	return nil
+}
+
+func (r *ReconcileAdoptClusterRequest) verifyGCPInfrastructure(secret *corev1.Secret, info *clusterInfo, logger log.FieldLogger) error {
+	projectID, err := gcpclient.ProjectIDFromSecret(secret)
+	if err != nil {
+		return newPreflightError(credentialsInvalidReason, "could not read GCP project from credentials: %v", err)
+	}
+	if info.gcpProject != "" && projectID != info.gcpProject {
+		return newPreflightError(infrastructureNotFoundReason, "credentials are for GCP project %s but the cluster is in project %s", projectID, info.gcpProject)
+	}
+	gcpClient, err := r.gcpClientBuilder(secret)
+	if err != nil {
+		return newPreflightError(credentialsInvalidReason, "could not create GCP client: %v", err)
+	}
+	count := 0
+	err = gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
+		Filter: fmt.Sprintf("name eq \"%s-.*\"", info.infraID),
+		Fields: gcpInstanceFields,
+	}, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			count += len(scopedList.Instances)
+		}
+		return nil
+	})
+	if err != nil {
+		return newPreflightError(credentialsInvalidReason, "could not list GCP instances: %v", err)
+	}
+	logger.WithField("count", count).Debug("found GCP instances of cluster")
+	if count == 0 {
+		return newPreflightError(infrastructureNotFoundReason, "no GCP instances of infrastructure %s found in project %s", info.infraID, projectID)
+	}
+	return nil
+}
+
+func buildClusterDeployment(req *hivev1.AdoptClusterRequest, name string, info *clusterInfo) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: req.Namespace,
+			Labels: map[string]string{
+				constants.AdoptClusterRequestNameLabel: req.Name,
+			},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: info.clusterName,
+			BaseDomain:  req.Spec.BaseDomain,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                info.clusterID,
+				InfraID:                  info.infraID,
+				AdminKubeconfigSecretRef: req.Spec.AdminKubeconfigSecretRef,
+			},
+			PullSecretRef: req.Spec.PullSecretRef,
+		},
+	}
+	if req.Spec.AdminPasswordSecretRef != nil {
+		cd.Spec.ClusterMetadata.AdminPasswordSecretRef = *req.Spec.AdminPasswordSecretRef
+	}
+	switch info.platform {
+	case openshiftapiv1.AWSPlatformType:
+		cd.Spec.Platform.AWS = &hivev1aws.Platform{
+			CredentialsSecretRef: req.Spec.CredentialsSecretRef,
+			Region:               info.region,
+		}
+	case openshiftapiv1.GCPPlatformType:
+		cd.Spec.Platform.GCP = &hivev1gcp.Platform{
+			CredentialsSecretRef: req.Spec.CredentialsSecretRef,
+			Region:               info.region,
+		}
+	}
+	return cd
+}
+
+func (r *ReconcileAdoptClusterRequest) setPreflightFailed(req *hivev1.AdoptClusterRequest, pfErr *preflightError, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.WithField("reason", pfErr.reason).WithError(pfErr).Warn("preflight check failed for adopt cluster request")
+	conds, changed := controllerutils.SetAdoptClusterRequestConditionWithChangeCheck(
+		req.Status.Conditions,
+		hivev1.AdoptClusterRequestPreflightFailedCondition,
+		corev1.ConditionTrue,
+		pfErr.reason,
+		pfErr.message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		req.Status.Conditions = conds
+		if err := r.Status().Update(context.TODO(), req); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update AdoptClusterRequest status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: preflightRetryInterval}, nil
+}
+
+func (r *ReconcileAdoptClusterRequest) setAdopted(req *hivev1.AdoptClusterRequest, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	req.Status.ClusterName = cd.Spec.ClusterName
+	if md := cd.Spec.ClusterMetadata; md != nil {
+		req.Status.ClusterID = md.ClusterID
+		req.Status.InfraID = md.InfraID
+	}
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		req.Status.Platform = string(openshiftapiv1.AWSPlatformType)
+		req.Status.Region = cd.Spec.Platform.AWS.Region
+	case cd.Spec.Platform.GCP != nil:
+		req.Status.Platform = string(openshiftapiv1.GCPPlatformType)
+		req.Status.Region = cd.Spec.Platform.GCP.Region
+	}
+	req.Status.ClusterDeploymentRef = &corev1.LocalObjectReference{Name: cd.Name}
+	req.Status.Conditions = controllerutils.SetAdoptClusterRequestCondition(
+		req.Status.Conditions,
+		hivev1.AdoptClusterRequestPreflightFailedCondition,
+		corev1.ConditionFalse,
+		preflightPassedReason,
+		"Preflight checks passed",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	req.Status.Conditions = controllerutils.SetAdoptClusterRequestCondition(
+		req.Status.Conditions,
+		hivev1.AdoptClusterRequestAdoptedCondition,
+		corev1.ConditionTrue,
+		clusterDeploymentCreatedReason,
+		fmt.Sprintf("ClusterDeployment %s created for adopted cluster", cd.Name),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.TODO(), req); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update AdoptClusterRequest status")
+		return reconcile.Result{}, err
+	}
+	logger.Info("cluster adopted")
+	return reconcile.Result{}, nil
+}
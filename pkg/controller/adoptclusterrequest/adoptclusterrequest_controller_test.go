@@ -0,0 +1,394 @@
+package adoptclusterrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testNamespace       = "test-namespace"
+	testRequestName     = "test-request"
+	testKubeconfigName  = "test-kubeconfig"
+	testCredentialsName = "test-creds"
+	testBaseDomain      = "example.com"
+	testClusterName     = "mycluster"
+	testClusterID       = "test-cluster-id"
+	testInfraID         = "mycluster-abcde"
+	testRegion          = "us-east-1"
+	testGCPProject      = "test-project"
+	testGCPCredentials  = `{"type": "service_account", "project_id": "test-project"}`
+)
+
+func TestReconcileAdoptClusterRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	remoteScheme := runtime.NewScheme()
+	openshiftapiv1.Install(remoteScheme)
+
+	tests := []struct {
+		name                 string
+		request              *hivev1.AdoptClusterRequest
+		existing             []runtime.Object
+		remote               []runtime.Object
+		remoteBuildErr       error
+		setupAWSMock         func(*mockaws.MockClient)
+		setupGCPMock         func(*mockgcp.MockClient)
+		expectedReason       string
+		expectCD             bool
+		expectedPlatform     string
+		expectNoStatusChange bool
+	}{
+		{
+			name:     "adopt AWS cluster",
+			request:  testRequest(),
+			existing: []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			remote:   testRemoteObjects(testAWSInfrastructure()),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().DescribeInstances(gomock.Any()).Return(instancesOutput(3), nil)
+			},
+			expectCD:         true,
+			expectedPlatform: "AWS",
+		},
+		{
+			name:     "adopt GCP cluster",
+			request:  testRequest(),
+			existing: []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(map[string][]byte{constants.GCPCredentialsName: []byte(testGCPCredentials)})},
+			remote:   testRemoteObjects(testGCPInfrastructure(testGCPProject)),
+			setupGCPMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ gcpclient.ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
+						return pagesFn(&compute.InstanceAggregatedList{
+							Items: map[string]compute.InstancesScopedList{
+								"zones/us-east1-b": {Instances: []*compute.Instance{{Name: testInfraID + "-master-0"}}},
+							},
+						})
+					})
+			},
+			expectCD:         true,
+			expectedPlatform: "GCP",
+		},
+		{
+			name:           "kubeconfig secret missing",
+			request:        testRequest(),
+			existing:       []runtime.Object{testCredentialsSecret(nil)},
+			expectedReason: kubeconfigSecretNotFoundReason,
+		},
+		{
+			name:           "invalid kubeconfig",
+			request:        testRequest(),
+			existing:       []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			remoteBuildErr: errors.New("bad kubeconfig"),
+			expectedReason: invalidKubeconfigReason,
+		},
+		{
+			name:           "cluster unreachable",
+			request:        testRequest(),
+			existing:       []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			expectedReason: clusterUnreachableReason,
+		},
+		{
+			name:    "base domain mismatch",
+			request: testRequest(),
+			existing: []runtime.Object{
+				testKubeconfigSecret(),
+				testCredentialsSecret(nil),
+			},
+			remote: []runtime.Object{
+				testClusterVersion(),
+				testAWSInfrastructure(),
+				&openshiftapiv1.DNS{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					Spec:       openshiftapiv1.DNSSpec{BaseDomain: testClusterName + ".other.com"},
+				},
+			},
+			expectedReason: baseDomainMismatchReason,
+		},
+		{
+			name:     "unsupported platform",
+			request:  testRequest(),
+			existing: []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			remote: testRemoteObjects(&openshiftapiv1.Infrastructure{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Status: openshiftapiv1.InfrastructureStatus{
+					InfrastructureName: testInfraID,
+					Platform:           openshiftapiv1.VSpherePlatformType,
+				},
+			}),
+			expectedReason: unsupportedPlatformReason,
+		},
+		{
+			name:           "credentials secret missing",
+			request:        testRequest(),
+			existing:       []runtime.Object{testKubeconfigSecret()},
+			remote:         testRemoteObjects(testAWSInfrastructure()),
+			expectedReason: credentialsSecretNotFoundReason,
+		},
+		{
+			name:     "credentials cannot list instances",
+			request:  testRequest(),
+			existing: []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			remote:   testRemoteObjects(testAWSInfrastructure()),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().DescribeInstances(gomock.Any()).Return(nil, errors.New("access denied"))
+			},
+			expectedReason: credentialsInvalidReason,
+		},
+		{
+			name:     "no AWS instances found",
+			request:  testRequest(),
+			existing: []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(nil)},
+			remote:   testRemoteObjects(testAWSInfrastructure()),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().DescribeInstances(gomock.Any()).Return(instancesOutput(0), nil)
+			},
+			expectedReason: infrastructureNotFoundReason,
+		},
+		{
+			name:           "GCP project mismatch",
+			request:        testRequest(),
+			existing:       []runtime.Object{testKubeconfigSecret(), testCredentialsSecret(map[string][]byte{constants.GCPCredentialsName: []byte(testGCPCredentials)})},
+			remote:         testRemoteObjects(testGCPInfrastructure("other-project")),
+			expectedReason: infrastructureNotFoundReason,
+		},
+		{
+			name:    "existing ClusterDeployment",
+			request: testRequest(),
+			existing: []runtime.Object{
+				testKubeconfigSecret(),
+				testCredentialsSecret(nil),
+				&hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testRequestName}},
+			},
+			expectedReason: clusterDeploymentExistsReason,
+		},
+		{
+			name: "already adopted",
+			request: func() *hivev1.AdoptClusterRequest {
+				req := testRequest()
+				req.Status.Conditions = []hivev1.AdoptClusterRequestCondition{{
+					Type:   hivev1.AdoptClusterRequestAdoptedCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return req
+			}(),
+			expectNoStatusChange: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme, append(test.existing, test.request)...)
+			remoteClient := fake.NewFakeClientWithScheme(remoteScheme, test.remote...)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, test.remoteBuildErr).AnyTimes()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.setupAWSMock != nil {
+				test.setupAWSMock(mockAWSClient)
+			}
+			mockGCPClient := mockgcp.NewMockClient(mockCtrl)
+			if test.setupGCPMock != nil {
+				test.setupGCPMock(mockGCPClient)
+			}
+
+			r := &ReconcileAdoptClusterRequest{
+				Client: fakeClient,
+				logger: log.WithField("controller", "adoptclusterrequest"),
+				remoteClientBuilder: func(*corev1.Secret) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+				awsClientBuilder: func(_ *corev1.Secret, region string) (awsclient.Client, error) {
+					assert.Equal(t, testRegion, region, "unexpected region for AWS client")
+					return mockAWSClient, nil
+				},
+				gcpClientBuilder: func(*corev1.Secret) (gcpclient.Client, error) {
+					return mockGCPClient, nil
+				},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testRequestName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			req := &hivev1.AdoptClusterRequest{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testRequestName}, req))
+
+			if test.expectNoStatusChange {
+				assert.Equal(t, test.request.Status.Conditions, req.Status.Conditions, "unexpected change to conditions")
+				return
+			}
+
+			preflightCond := controllerutils.FindAdoptClusterRequestCondition(req.Status.Conditions, hivev1.AdoptClusterRequestPreflightFailedCondition)
+			adoptedCond := controllerutils.FindAdoptClusterRequestCondition(req.Status.Conditions, hivev1.AdoptClusterRequestAdoptedCondition)
+
+			cd := &hivev1.ClusterDeployment{}
+			cdErr := fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testRequestName}, cd)
+
+			if !test.expectCD {
+				require.NotNil(t, preflightCond, "expected PreflightFailed condition")
+				assert.Equal(t, corev1.ConditionTrue, preflightCond.Status, "unexpected PreflightFailed status")
+				assert.Equal(t, test.expectedReason, preflightCond.Reason, "unexpected PreflightFailed reason")
+				assert.Nil(t, adoptedCond, "unexpected Adopted condition")
+				assert.Equal(t, preflightRetryInterval, result.RequeueAfter, "unexpected requeue")
+				if test.expectedReason != clusterDeploymentExistsReason {
+					assert.True(t, apierrors.IsNotFound(cdErr), "expected no ClusterDeployment")
+				}
+				return
+			}
+
+			require.NoError(t, cdErr, "expected ClusterDeployment")
+			assert.True(t, cd.Spec.Installed, "expected ClusterDeployment to be installed")
+			assert.Equal(t, testClusterName, cd.Spec.ClusterName, "unexpected cluster name")
+			assert.Equal(t, testBaseDomain, cd.Spec.BaseDomain, "unexpected base domain")
+			assert.Equal(t, testRequestName, cd.Labels[constants.AdoptClusterRequestNameLabel], "unexpected adopt cluster request label")
+			if assert.NotNil(t, cd.Spec.ClusterMetadata, "expected cluster metadata") {
+				assert.Equal(t, testClusterID, cd.Spec.ClusterMetadata.ClusterID, "unexpected cluster ID")
+				assert.Equal(t, testInfraID, cd.Spec.ClusterMetadata.InfraID, "unexpected infra ID")
+				assert.Equal(t, testKubeconfigName, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, "unexpected kubeconfig secret")
+			}
+			switch test.expectedPlatform {
+			case "AWS":
+				if assert.NotNil(t, cd.Spec.Platform.AWS, "expected AWS platform") {
+					assert.Equal(t, testRegion, cd.Spec.Platform.AWS.Region, "unexpected region")
+					assert.Equal(t, testCredentialsName, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, "unexpected credentials")
+				}
+			case "GCP":
+				if assert.NotNil(t, cd.Spec.Platform.GCP, "expected GCP platform") {
+					assert.Equal(t, "us-east1", cd.Spec.Platform.GCP.Region, "unexpected region")
+					assert.Equal(t, testCredentialsName, cd.Spec.Platform.GCP.CredentialsSecretRef.Name, "unexpected credentials")
+				}
+			}
+
+			if assert.NotNil(t, adoptedCond, "expected Adopted condition") {
+				assert.Equal(t, corev1.ConditionTrue, adoptedCond.Status, "unexpected Adopted status")
+			}
+			assert.Nil(t, preflightCond, "unexpected PreflightFailed condition")
+			assert.Equal(t, test.expectedPlatform, req.Status.Platform, "unexpected platform in status")
+			assert.Equal(t, testInfraID, req.Status.InfraID, "unexpected infra ID in status")
+			if assert.NotNil(t, req.Status.ClusterDeploymentRef, "expected ClusterDeployment reference") {
+				assert.Equal(t, testRequestName, req.Status.ClusterDeploymentRef.Name, "unexpected ClusterDeployment reference")
+			}
+		})
+	}
+}
+
+func testRequest() *hivev1.AdoptClusterRequest {
+	return &hivev1.AdoptClusterRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testRequestName,
+		},
+		Spec: hivev1.AdoptClusterRequestSpec{
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigName},
+			BaseDomain:               testBaseDomain,
+			CredentialsSecretRef:     corev1.LocalObjectReference{Name: testCredentialsName},
+		},
+	}
+}
+
+func testKubeconfigSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testKubeconfigName},
+		Data: map[string][]byte{
+			constants.KubeconfigSecretKey: []byte("some-kubeconfig-data"),
+		},
+	}
+}
+
+func testCredentialsSecret(data map[string][]byte) *corev1.Secret {
+	if data == nil {
+		data = map[string][]byte{
+			constants.AWSAccessKeyIDSecretKey:     []byte("key-id"),
+			constants.AWSSecretAccessKeySecretKey: []byte("secret-key"),
+		}
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testCredentialsName},
+		Data:       data,
+	}
+}
+
+func testClusterVersion() *openshiftapiv1.ClusterVersion {
+	return &openshiftapiv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "version"},
+		Spec:       openshiftapiv1.ClusterVersionSpec{ClusterID: testClusterID},
+	}
+}
+
+func testRemoteObjects(infra *openshiftapiv1.Infrastructure) []runtime.Object {
+	return []runtime.Object{
+		testClusterVersion(),
+		infra,
+		&openshiftapiv1.DNS{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec:       openshiftapiv1.DNSSpec{BaseDomain: testClusterName + "." + testBaseDomain},
+		},
+	}
+}
+
+func testAWSInfrastructure() *openshiftapiv1.Infrastructure {
+	return &openshiftapiv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: openshiftapiv1.InfrastructureStatus{
+			InfrastructureName: testInfraID,
+			Platform:           openshiftapiv1.AWSPlatformType,
+			PlatformStatus: &openshiftapiv1.PlatformStatus{
+				Type: openshiftapiv1.AWSPlatformType,
+				AWS:  &openshiftapiv1.AWSPlatformStatus{Region: testRegion},
+			},
+		},
+	}
+}
+
+func testGCPInfrastructure(project string) *openshiftapiv1.Infrastructure {
+	return &openshiftapiv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: openshiftapiv1.InfrastructureStatus{
+			InfrastructureName: testInfraID,
+			Platform:           openshiftapiv1.GCPPlatformType,
+			PlatformStatus: &openshiftapiv1.PlatformStatus{
+				Type: openshiftapiv1.GCPPlatformType,
+				GCP:  &openshiftapiv1.GCPPlatformStatus{ProjectID: project, Region: "us-east1"},
+			},
+		},
+	}
+}
+
+func instancesOutput(count int) *ec2.DescribeInstancesOutput {
+	reservation := &ec2.Reservation{}
+	for i := 0; i < count; i++ {
+		reservation.Instances = append(reservation.Instances, &ec2.Instance{})
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}
+}
@@ -0,0 +1,717 @@
+package awsprivatelink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.AWSPrivateLinkControllerName
+
+	// waitForResourceInterval is how long to wait before checking again on an AWS resource that is not ready.
+	waitForResourceInterval = time.Minute
+
+	// privateLinkTagKey is the tag set on the AWS resources created for a cluster. The value is the infra ID of
+	// the cluster.
+	privateLinkTagKey = "hive.openshift.io/private-link-access-for"
+
+	privateLinkReadyReason         = "PrivateLinkReady"
+	notConfiguredReason            = "NotConfigured"
+	noEndpointVPCReason            = "NoEndpointVPCInRegion"
+	waitingForInstallReason        = "WaitingForInstall"
+	waitingForLoadBalancerReason   = "WaitingForLoadBalancer"
+	waitingForVPCEndpointReason    = "WaitingForVPCEndpoint"
+	vpcEndpointServiceFailedReason = "VPCEndpointServiceFailed"
+	vpcEndpointFailedReason        = "VPCEndpointFailed"
+	hostedZoneFailedReason         = "HostedZoneFailed"
+)
+
+type awsClientBuilderType func(c client.Client, secretName, namespace string, options awsclient.Options) (awsclient.Client, error)
+
+// Add creates a new AWSPrivateLink Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAWSPrivateLink
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileAWSPrivateLink {
+	return &ReconcileAWSPrivateLink{
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:           log.WithField("controller", ControllerName),
+		awsClientBuilder: awsclient.NewClientWithOptions,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("awsprivatelink-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAWSPrivateLink{}
+
+// ReconcileAWSPrivateLink reconciles the AWS PrivateLink resources for a ClusterDeployment
+type ReconcileAWSPrivateLink struct {
+	client.Client
+	logger log.FieldLogger
+
+	// awsClientBuilder is a function pointer to the function that builds the AWS clients for the account of the
+	// cluster and for the account in which the VPC endpoints are created.
+	awsClientBuilder awsClientBuilderType
+}
+
+// Reconcile creates a VPC endpoint service for the internal API load balancer of a cluster with AWS PrivateLink
+// enabled, a VPC endpoint for that service, and a private hosted zone resolving the API domain of the cluster to
+// the VPC endpoint.
+func (r *ReconcileAWSPrivateLink) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if !cd.DeletionTimestamp.IsZero() {
+		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerAWSPrivateLink) {
+			return reconcile.Result{}, nil
+		}
+		return r.cleanupPrivateLink(cd, cdLog)
+	}
+
+	if !isPrivateLinkEnabled(cd) {
+		cdLog.Debug("AWS PrivateLink is not enabled for the cluster")
+		return reconcile.Result{}, nil
+	}
+
+	if !controllerutils.HasFinalizer(cd, hivev1.FinalizerAWSPrivateLink) {
+		cdLog.Info("adding AWS PrivateLink finalizer")
+		controllerutils.AddFinalizer(cd, hivev1.FinalizerAWSPrivateLink)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error adding AWS PrivateLink finalizer")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	config, err := controllerutils.GetAWSPrivateLinkConfig()
+	if err != nil {
+		cdLog.WithError(err).Error("could not get AWS PrivateLink config")
+		return reconcile.Result{}, err
+	}
+	if config == nil {
+		cdLog.Warn("AWS PrivateLink is not configured in HiveConfig")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, notConfiguredReason,
+			"AWS PrivateLink is not configured in HiveConfig", cdLog)
+	}
+
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		cdLog.Debug("waiting for the infra ID of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForInstallReason,
+			"Waiting for the cluster install to start", cdLog)
+	}
+
+	return r.reconcilePrivateLink(cd, config, cdLog)
+}
+
+func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeployment, config *hivev1.AWSPrivateLinkConfig, logger log.FieldLogger) (reconcile.Result, error) {
+	region := cd.Spec.Platform.AWS.Region
+	inventory := findEndpointVPC(config, region)
+	if inventory == nil {
+		logger.WithField("region", region).Warn("no VPC in the endpoint VPC inventory is in the region of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, noEndpointVPCReason,
+			fmt.Sprintf("No VPC in the endpoint VPC inventory is in region %s", region), logger)
+	}
+	logger = logger.WithField("endpointVPC", inventory.VPCID)
+
+	spokeClient, err := r.awsClientBuilder(r.Client, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, awsclient.Options{
+		Region:           region,
+		ServiceEndpoints: cd.Spec.Platform.AWS.ServiceEndpoints,
+	})
+	if err != nil {
+		logger.WithError(err).Error("error creating AWS client for the cluster account")
+		return reconcile.Result{}, err
+	}
+	hubClient, err := r.awsClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace(), awsclient.Options{
+		Region: region,
+	})
+	if err != nil {
+		logger.WithError(err).Error("error creating AWS client for the VPC endpoint account")
+		return reconcile.Result{}, err
+	}
+
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	lb, err := findInternalLoadBalancer(spokeClient, infraID)
+	if err != nil {
+		logger.WithError(err).Error("error looking up the internal API load balancer")
+		return reconcile.Result{}, err
+	}
+	if lb == nil {
+		logger.Info("waiting for the internal API load balancer to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForLoadBalancerReason,
+			"Waiting for the internal API load balancer of the cluster to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	status := privateLinkStatus(cd)
+
+	service, err := r.ensureEndpointService(cd, status, lb, spokeClient, hubClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the VPC endpoint service")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, vpcEndpointServiceFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+
+	endpoint, err := r.ensureEndpoint(cd, status, service, inventory, hubClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the VPC endpoint")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, vpcEndpointFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if !strings.EqualFold(aws.StringValue(endpoint.State), ec2.StateAvailable) || len(endpoint.DnsEntries) == 0 {
+		logger.WithField("state", aws.StringValue(endpoint.State)).Info("waiting for the VPC endpoint to become available")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForVPCEndpointReason,
+			fmt.Sprintf("Waiting for VPC endpoint %s to become available", status.VPCEndpointID), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	if err := r.ensureHostedZone(cd, status, endpoint, inventory, config.AssociatedVPCs, hubClient, logger); err != nil {
+		logger.WithError(err).Error("error reconciling the private hosted zone")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, hostedZoneFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionFalse, privateLinkReadyReason,
+		fmt.Sprintf("The cluster API is reachable through VPC endpoint %s", status.VPCEndpointID), logger)
+}
+
+// ensureEndpointService returns the VPC endpoint service for the internal API load balancer, creating it if needed.
+// The account in which the VPC endpoints are created is allowed to connect to the service.
+func (r *ReconcileAWSPrivateLink) ensureEndpointService(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1aws.PrivateLinkAccessStatus,
+	lb *elbv2.LoadBalancer,
+	spokeClient, hubClient awsclient.Client,
+	logger log.FieldLogger,
+) (*ec2.ServiceConfiguration, error) {
+	if id := status.VPCEndpointService.ID; id != "" {
+		resp, err := spokeClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+			ServiceIds: aws.StringSlice([]string{id}),
+		})
+		switch {
+		case err == nil && len(resp.ServiceConfigurations) > 0:
+			return resp.ServiceConfigurations[0], nil
+		case err != nil && !isAWSErrorCode(err, "InvalidVpcEndpointServiceId.NotFound"):
+			return nil, errors.Wrap(err, "could not describe VPC endpoint service")
+		}
+		logger.WithField("vpcEndpointService", id).Warn("VPC endpoint service not found, creating it again")
+	}
+
+	identity, err := hubClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the identity of the VPC endpoint account")
+	}
+	principal, err := accountRootPrincipal(aws.StringValue(identity.Arn), aws.StringValue(identity.Account))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("creating VPC endpoint service")
+	resp, err := spokeClient.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+		AcceptanceRequired:      aws.Bool(false),
+		NetworkLoadBalancerArns: []*string{lb.LoadBalancerArn},
+		TagSpecifications:       tagSpecifications("vpc-endpoint-service", cd.Spec.ClusterMetadata.InfraID),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create VPC endpoint service")
+	}
+	service := resp.ServiceConfiguration
+	status.VPCEndpointService = hivev1aws.VPCEndpointService{
+		Name: aws.StringValue(service.ServiceName),
+		ID:   aws.StringValue(service.ServiceId),
+	}
+	if err := r.updateStatus(cd, logger); err != nil {
+		return nil, err
+	}
+
+	logger.WithField("principal", principal).Info("allowing the VPC endpoint account to connect to the VPC endpoint service")
+	if _, err := spokeClient.ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
+		ServiceId:            service.ServiceId,
+		AddAllowedPrincipals: aws.StringSlice([]string{principal}),
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not allow the VPC endpoint account to connect to the VPC endpoint service")
+	}
+	return service, nil
+}
+
+// ensureEndpoint returns the VPC endpoint for the VPC endpoint service, creating it if needed.
+func (r *ReconcileAWSPrivateLink) ensureEndpoint(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1aws.PrivateLinkAccessStatus,
+	service *ec2.ServiceConfiguration,
+	inventory *hivev1.AWSPrivateLinkInventory,
+	hubClient awsclient.Client,
+	logger log.FieldLogger,
+) (*ec2.VpcEndpoint, error) {
+	if id := status.VPCEndpointID; id != "" {
+		resp, err := hubClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+			VpcEndpointIds: aws.StringSlice([]string{id}),
+		})
+		switch {
+		case err == nil && len(resp.VpcEndpoints) > 0:
+			return resp.VpcEndpoints[0], nil
+		case err != nil && !isAWSErrorCode(err, "InvalidVpcEndpointId.NotFound"):
+			return nil, errors.Wrap(err, "could not describe VPC endpoint")
+		}
+		logger.WithField("vpcEndpoint", id).Warn("VPC endpoint not found, creating it again")
+	}
+
+	zones := sets.NewString(aws.StringValueSlice(service.AvailabilityZones)...)
+	var subnetIDs []string
+	for _, subnet := range inventory.Subnets {
+		if zones.Has(subnet.AvailabilityZone) {
+			subnetIDs = append(subnetIDs, subnet.SubnetID)
+		}
+	}
+	if len(subnetIDs) == 0 {
+		return nil, fmt.Errorf("no subnets of VPC %s are in the availability zones of the load balancer: %s",
+			inventory.VPCID, strings.Join(zones.List(), ", "))
+	}
+
+	logger.WithField("subnets", subnetIDs).Info("creating VPC endpoint")
+	resp, err := hubClient.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+		VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
+		ServiceName:       service.ServiceName,
+		VpcId:             aws.String(inventory.VPCID),
+		SubnetIds:         aws.StringSlice(subnetIDs),
+		TagSpecifications: tagSpecifications("vpc-endpoint", cd.Spec.ClusterMetadata.InfraID),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create VPC endpoint")
+	}
+	status.VPCEndpointID = aws.StringValue(resp.VpcEndpoint.VpcEndpointId)
+	if err := r.updateStatus(cd, logger); err != nil {
+		return nil, err
+	}
+	return resp.VpcEndpoint, nil
+}
+
+// ensureHostedZone creates the private hosted zone for the API domain of the cluster if needed, associates it with
+// the endpoint VPC and the associated VPCs, and points the API domain at the VPC endpoint.
+func (r *ReconcileAWSPrivateLink) ensureHostedZone(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1aws.PrivateLinkAccessStatus,
+	endpoint *ec2.VpcEndpoint,
+	inventory *hivev1.AWSPrivateLinkInventory,
+	associatedVPCs []hivev1.AWSPrivateLinkVPC,
+	hubClient awsclient.Client,
+	logger log.FieldLogger,
+) error {
+	apiDomain := apiDomain(cd)
+	logger = logger.WithField("apiDomain", apiDomain)
+
+	if status.HostedZoneID == "" {
+		logger.Info("creating private hosted zone")
+		resp, err := hubClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+			Name:            aws.String(apiDomain),
+			CallerReference: aws.String(fmt.Sprintf("%s-%d", cd.Spec.ClusterMetadata.InfraID, time.Now().Unix())),
+			HostedZoneConfig: &route53.HostedZoneConfig{
+				PrivateZone: aws.Bool(true),
+				Comment:     aws.String(fmt.Sprintf("AWS PrivateLink access for cluster %s/%s", cd.Namespace, cd.Name)),
+			},
+			VPC: &route53.VPC{
+				VPCId:     aws.String(inventory.VPCID),
+				VPCRegion: aws.String(inventory.Region),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not create private hosted zone")
+		}
+		status.HostedZoneID = aws.StringValue(resp.HostedZone.Id)
+		if err := r.updateStatus(cd, logger); err != nil {
+			return err
+		}
+	}
+
+	zone, err := hubClient.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(status.HostedZoneID)})
+	if err != nil {
+		if isAWSErrorCode(err, route53.ErrCodeNoSuchHostedZone) {
+			logger.WithField("hostedZone", status.HostedZoneID).Warn("private hosted zone not found, creating it again")
+			status.HostedZoneID = ""
+			if err := r.updateStatus(cd, logger); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(err, "could not get private hosted zone")
+	}
+	associated := sets.NewString()
+	for _, vpc := range zone.VPCs {
+		associated.Insert(aws.StringValue(vpc.VPCId))
+	}
+	for _, vpc := range associatedVPCs {
+		if associated.Has(vpc.VPCID) {
+			continue
+		}
+		logger.WithField("vpc", vpc.VPCID).Info("associating VPC with private hosted zone")
+		if _, err := hubClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			HostedZoneId: aws.String(status.HostedZoneID),
+			VPC: &route53.VPC{
+				VPCId:     aws.String(vpc.VPCID),
+				VPCRegion: aws.String(vpc.Region),
+			},
+		}); err != nil {
+			return errors.Wrapf(err, "could not associate VPC %s with private hosted zone", vpc.VPCID)
+		}
+	}
+
+	if _, err := hubClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(status.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name: aws.String(apiDomain),
+					Type: aws.String(route53.RRTypeA),
+					AliasTarget: &route53.AliasTarget{
+						DNSName:              endpoint.DnsEntries[0].DnsName,
+						HostedZoneId:         endpoint.DnsEntries[0].HostedZoneId,
+						EvaluateTargetHealth: aws.Bool(false),
+					},
+				},
+			}},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "could not point the API domain at the VPC endpoint")
+	}
+	return nil
+}
+
+// cleanupPrivateLink deletes the AWS resources created for the cluster and removes the AWS PrivateLink finalizer.
+func (r *ReconcileAWSPrivateLink) cleanupPrivateLink(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	var status *hivev1aws.PrivateLinkAccessStatus
+	if cd.Status.Platform != nil && cd.Status.Platform.AWS != nil {
+		status = cd.Status.Platform.AWS.PrivateLink
+	}
+
+	if status != nil && (status.HostedZoneID != "" || status.VPCEndpointID != "") {
+		config, err := controllerutils.GetAWSPrivateLinkConfig()
+		if err != nil {
+			logger.WithError(err).Error("could not get AWS PrivateLink config")
+			return reconcile.Result{}, err
+		}
+		if config == nil {
+			logger.Warn("AWS PrivateLink is no longer configured in HiveConfig, leaving the VPC endpoint and private hosted zone in place")
+		} else {
+			hubClient, err := r.awsClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace(), awsclient.Options{
+				Region: cd.Spec.Platform.AWS.Region,
+			})
+			if err != nil {
+				logger.WithError(err).Error("error creating AWS client for the VPC endpoint account")
+				return reconcile.Result{}, err
+			}
+			if status.HostedZoneID != "" {
+				if err := deleteHostedZone(hubClient, status.HostedZoneID, logger); err != nil {
+					logger.WithError(err).Error("error deleting private hosted zone")
+					return reconcile.Result{}, err
+				}
+				status.HostedZoneID = ""
+				if err := r.updateStatus(cd, logger); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+			if status.VPCEndpointID != "" {
+				if err := deleteEndpoint(hubClient, status.VPCEndpointID, logger); err != nil {
+					logger.WithError(err).Error("error deleting VPC endpoint")
+					return reconcile.Result{}, err
+				}
+				status.VPCEndpointID = ""
+				if err := r.updateStatus(cd, logger); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+		}
+	}
+
+	if status != nil && status.VPCEndpointService.ID != "" {
+		spokeClient, err := r.awsClientBuilder(r.Client, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, awsclient.Options{
+			Region:           cd.Spec.Platform.AWS.Region,
+			ServiceEndpoints: cd.Spec.Platform.AWS.ServiceEndpoints,
+		})
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Warn("AWS credentials for the cluster not found, leaving the VPC endpoint service in place")
+		case err != nil:
+			logger.WithError(err).Error("error creating AWS client for the cluster account")
+			return reconcile.Result{}, err
+		default:
+			deleted, err := deleteEndpointService(spokeClient, status.VPCEndpointService.ID, logger)
+			if err != nil {
+				logger.WithError(err).Error("error deleting VPC endpoint service")
+				return reconcile.Result{}, err
+			}
+			if !deleted {
+				logger.Info("waiting for the VPC endpoint to be deleted before deleting the VPC endpoint service")
+				return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+			}
+			status.VPCEndpointService = hivev1aws.VPCEndpointService{}
+			if err := r.updateStatus(cd, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	logger.Info("removing AWS PrivateLink finalizer")
+	controllerutils.DeleteFinalizer(cd, hivev1.FinalizerAWSPrivateLink)
+	if err := r.Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error removing AWS PrivateLink finalizer")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func deleteHostedZone(hubClient awsclient.Client, hostedZoneID string, logger log.FieldLogger) error {
+	logger = logger.WithField("hostedZone", hostedZoneID)
+	resp, err := hubClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(hostedZoneID)})
+	if err != nil {
+		if isAWSErrorCode(err, route53.ErrCodeNoSuchHostedZone) {
+			logger.Info("private hosted zone already deleted")
+			return nil
+		}
+		return errors.Wrap(err, "could not list records of private hosted zone")
+	}
+	var changes []*route53.Change
+	for _, recordSet := range resp.ResourceRecordSets {
+		switch aws.StringValue(recordSet.Type) {
+		case route53.RRTypeSoa, route53.RRTypeNs:
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: recordSet,
+		})
+	}
+	if len(changes) > 0 {
+		logger.Info("deleting records of private hosted zone")
+		if _, err := hubClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(hostedZoneID),
+			ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		}); err != nil {
+			return errors.Wrap(err, "could not delete records of private hosted zone")
+		}
+	}
+	logger.Info("deleting private hosted zone")
+	if _, err := hubClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: aws.String(hostedZoneID)}); err != nil &&
+		!isAWSErrorCode(err, route53.ErrCodeNoSuchHostedZone) {
+		return errors.Wrap(err, "could not delete private hosted zone")
+	}
+	return nil
+}
+
+func deleteEndpoint(hubClient awsclient.Client, endpointID string, logger log.FieldLogger) error {
+	logger.WithField("vpcEndpoint", endpointID).Info("deleting VPC endpoint")
+	resp, err := hubClient.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{VpcEndpointIds: aws.StringSlice([]string{endpointID})})
+	if err != nil {
+		if isAWSErrorCode(err, "InvalidVpcEndpointId.NotFound") {
+			return nil
+		}
+		return errors.Wrap(err, "could not delete VPC endpoint")
+	}
+	for _, item := range resp.Unsuccessful {
+		if item.Error != nil && aws.StringValue(item.Error.Code) != "InvalidVpcEndpointId.NotFound" {
+			return fmt.Errorf("could not delete VPC endpoint: %s", aws.StringValue(item.Error.Message))
+		}
+	}
+	return nil
+}
+
+// deleteEndpointService deletes the VPC endpoint service. It returns false if the service cannot be deleted yet
+// because the VPC endpoint connected to it is still being deleted.
+func deleteEndpointService(spokeClient awsclient.Client, serviceID string, logger log.FieldLogger) (bool, error) {
+	logger.WithField("vpcEndpointService", serviceID).Info("deleting VPC endpoint service")
+	resp, err := spokeClient.DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: aws.StringSlice([]string{serviceID}),
+	})
+	if err != nil {
+		if isAWSErrorCode(err, "InvalidVpcEndpointServiceId.NotFound") {
+			return true, nil
+		}
+		return false, errors.Wrap(err, "could not delete VPC endpoint service")
+	}
+	for _, item := range resp.Unsuccessful {
+		if item.Error == nil {
+			continue
+		}
+		switch aws.StringValue(item.Error.Code) {
+		case "InvalidVpcEndpointServiceId.NotFound":
+		case "ExistingVpcEndpointConnections":
+			return false, nil
+		default:
+			return false, fmt.Errorf("could not delete VPC endpoint service: %s", aws.StringValue(item.Error.Message))
+		}
+	}
+	return true, nil
+}
+
+func (r *ReconcileAWSPrivateLink) setNotReadyCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AWSPrivateLinkNotReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	return r.updateStatus(cd, logger)
+}
+
+func (r *ReconcileAWSPrivateLink) updateStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment status")
+		return err
+	}
+	return nil
+}
+
+func isPrivateLinkEnabled(cd *hivev1.ClusterDeployment) bool {
+	aws := cd.Spec.Platform.AWS
+	return aws != nil && aws.PrivateLink != nil && aws.PrivateLink.Enabled
+}
+
+// privateLinkStatus returns the AWS PrivateLink status of the cluster deployment, adding it if needed.
+func privateLinkStatus(cd *hivev1.ClusterDeployment) *hivev1aws.PrivateLinkAccessStatus {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.AWS == nil {
+		cd.Status.Platform.AWS = &hivev1aws.PlatformStatus{}
+	}
+	if cd.Status.Platform.AWS.PrivateLink == nil {
+		cd.Status.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccessStatus{}
+	}
+	return cd.Status.Platform.AWS.PrivateLink
+}
+
+func findEndpointVPC(config *hivev1.AWSPrivateLinkConfig, region string) *hivev1.AWSPrivateLinkInventory {
+	for i, inventory := range config.EndpointVPCInventory {
+		if inventory.Region == region {
+			return &config.EndpointVPCInventory[i]
+		}
+	}
+	return nil
+}
+
+// findInternalLoadBalancer returns the internal API load balancer created by the installer for the cluster, or nil
+// if it does not exist yet.
+func findInternalLoadBalancer(spokeClient awsclient.Client, infraID string) (*elbv2.LoadBalancer, error) {
+	resp, err := spokeClient.DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{
+		Names: aws.StringSlice([]string{infraID + "-int"}),
+	})
+	if err != nil {
+		if isAWSErrorCode(err, elbv2.ErrCodeLoadBalancerNotFoundException) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(resp.LoadBalancers) == 0 {
+		return nil, nil
+	}
+	return resp.LoadBalancers[0], nil
+}
+
+func apiDomain(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+}
+
+// accountRootPrincipal returns the ARN of the root principal of the account, in the partition of the given ARN.
+func accountRootPrincipal(callerARN, account string) (string, error) {
+	parts := strings.SplitN(callerARN, ":", 3)
+	if len(parts) < 3 || parts[1] == "" {
+		return "", fmt.Errorf("could not determine the partition of ARN %q", callerARN)
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:root", parts[1], account), nil
+}
+
+func tagSpecifications(resourceType, infraID string) []*ec2.TagSpecification {
+	return []*ec2.TagSpecification{{
+		ResourceType: aws.String(resourceType),
+		Tags: []*ec2.Tag{{
+			Key:   aws.String(privateLinkTagKey),
+			Value: aws.String(infraID),
+		}},
+	}}
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == code
+	}
+	return false
+}
@@ -0,0 +1,417 @@
+package awsprivatelink
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testNamespace       = "test-namespace"
+	testName            = "test-cluster"
+	testClusterName     = "mycluster"
+	testBaseDomain      = "example.com"
+	testInfraID         = "mycluster-abcde"
+	testRegion          = "us-east-1"
+	testCredentialsName = "test-creds"
+	testHubCredentials  = "hub-creds"
+	testEndpointVPC     = "vpc-hub"
+	testAssociatedVPC   = "vpc-associated"
+	testLBARN           = "arn:aws:elasticloadbalancing:us-east-1:111111111111:loadbalancer/net/mycluster-abcde-int/1"
+	testServiceID       = "vpce-svc-1"
+	testServiceName     = "com.amazonaws.vpce.us-east-1.vpce-svc-1"
+	testEndpointID      = "vpce-1"
+	testHostedZoneID    = "/hostedzone/Z1"
+)
+
+func TestReconcileAWSPrivateLink(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		config             *hivev1.AWSPrivateLinkConfig
+		setupAWSMock       func(*mockaws.MockClient)
+		expectFinalizer    bool
+		expectNoCondition  bool
+		expectedReason     string
+		expectedStatus     corev1.ConditionStatus
+		expectRequeueAfter bool
+		expectedPLStatus   *hivev1aws.PrivateLinkAccessStatus
+	}{
+		{
+			name:              "private link not enabled",
+			cd:                testClusterDeployment(withoutPrivateLink),
+			config:            testConfig(),
+			expectNoCondition: true,
+		},
+		{
+			name:              "add finalizer",
+			cd:                testClusterDeployment(),
+			config:            testConfig(),
+			expectFinalizer:   true,
+			expectNoCondition: true,
+		},
+		{
+			name:            "not configured",
+			cd:              testClusterDeployment(withFinalizer),
+			expectFinalizer: true,
+			expectedReason:  notConfiguredReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:            "waiting for infra ID",
+			cd:              testClusterDeployment(withFinalizer, withoutInfraID),
+			config:          testConfig(),
+			expectFinalizer: true,
+			expectedReason:  waitingForInstallReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name: "no endpoint VPC in region",
+			cd:   testClusterDeployment(withFinalizer),
+			config: func() *hivev1.AWSPrivateLinkConfig {
+				c := testConfig()
+				c.EndpointVPCInventory[0].Region = "us-west-2"
+				return c
+			}(),
+			expectFinalizer: true,
+			expectedReason:  noEndpointVPCReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:   "waiting for load balancer",
+			cd:     testClusterDeployment(withFinalizer),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().DescribeLoadBalancersV2(gomock.Any()).
+					Return(nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil))
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForLoadBalancerReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "create all resources",
+			cd:     testClusterDeployment(withFinalizer, withNotReadyCondition(waitingForLoadBalancerReason)),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				mockLoadBalancer(m)
+				m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+					Account: aws.String("222222222222"),
+					Arn:     aws.String("arn:aws:iam::222222222222:user/hive"),
+				}, nil)
+				m.EXPECT().CreateVpcEndpointServiceConfiguration(gomock.Any()).Return(&ec2.CreateVpcEndpointServiceConfigurationOutput{
+					ServiceConfiguration: testServiceConfiguration(),
+				}, nil)
+				m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
+					ServiceId:            aws.String(testServiceID),
+					AddAllowedPrincipals: aws.StringSlice([]string{"arn:aws:iam::222222222222:root"}),
+				}).Return(&ec2.ModifyVpcEndpointServicePermissionsOutput{}, nil)
+				m.EXPECT().CreateVpcEndpoint(gomock.Any()).DoAndReturn(func(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+					assert.Equal(t, []string{"subnet-a"}, aws.StringValueSlice(input.SubnetIds), "unexpected subnets")
+					assert.Equal(t, testEndpointVPC, aws.StringValue(input.VpcId), "unexpected VPC")
+					return &ec2.CreateVpcEndpointOutput{VpcEndpoint: testEndpoint(ec2.StateAvailable)}, nil
+				})
+				mockHostedZone(m, true)
+			},
+			expectFinalizer: true,
+			expectedReason:  privateLinkReadyReason,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedPLStatus: &hivev1aws.PrivateLinkAccessStatus{
+				VPCEndpointService: hivev1aws.VPCEndpointService{Name: testServiceName, ID: testServiceID},
+				VPCEndpointID:      testEndpointID,
+				HostedZoneID:       testHostedZoneID,
+			},
+		},
+		{
+			name:   "waiting for VPC endpoint",
+			cd:     testClusterDeployment(withFinalizer, withPrivateLinkStatus(testServiceID, testEndpointID, "")),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				mockLoadBalancer(m)
+				mockExistingService(m)
+				m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+					VpcEndpoints: []*ec2.VpcEndpoint{testEndpoint(ec2.StatePending)},
+				}, nil)
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForVPCEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "existing resources",
+			cd:     testClusterDeployment(withFinalizer, withNotReadyCondition(waitingForVPCEndpointReason), withPrivateLinkStatus(testServiceID, testEndpointID, testHostedZoneID)),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				mockLoadBalancer(m)
+				mockExistingService(m)
+				m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+					VpcEndpoints: []*ec2.VpcEndpoint{testEndpoint(ec2.StateAvailable)},
+				}, nil)
+				mockHostedZone(m, false)
+			},
+			expectFinalizer: true,
+			expectedReason:  privateLinkReadyReason,
+			expectedStatus:  corev1.ConditionFalse,
+		},
+		{
+			name:   "cleanup",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withPrivateLinkStatus(testServiceID, testEndpointID, testHostedZoneID)),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						{Name: aws.String("api.mycluster.example.com."), Type: aws.String(route53.RRTypeSoa)},
+						{Name: aws.String("api.mycluster.example.com."), Type: aws.String(route53.RRTypeNs)},
+						{Name: aws.String("api.mycluster.example.com."), Type: aws.String(route53.RRTypeA)},
+					},
+				}, nil)
+				m.EXPECT().ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					if assert.Len(t, input.ChangeBatch.Changes, 1, "unexpected record changes") {
+						assert.Equal(t, route53.RRTypeA, aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.Type), "unexpected record deleted")
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+				m.EXPECT().DeleteHostedZone(gomock.Any()).Return(&route53.DeleteHostedZoneOutput{}, nil)
+				m.EXPECT().DeleteVpcEndpoints(gomock.Any()).Return(&ec2.DeleteVpcEndpointsOutput{}, nil)
+				m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DeleteVpcEndpointServiceConfigurationsOutput{}, nil)
+			},
+			expectNoCondition: true,
+			expectedPLStatus:  &hivev1aws.PrivateLinkAccessStatus{},
+		},
+		{
+			name:   "cleanup waits for VPC endpoint deletion",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withPrivateLinkStatus(testServiceID, "", "")),
+			config: testConfig(),
+			setupAWSMock: func(m *mockaws.MockClient) {
+				m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DeleteVpcEndpointServiceConfigurationsOutput{
+					Unsuccessful: []*ec2.UnsuccessfulItem{{
+						ResourceId: aws.String(testServiceID),
+						Error: &ec2.UnsuccessfulItemError{
+							Code:    aws.String("ExistingVpcEndpointConnections"),
+							Message: aws.String("service has existing endpoint connections"),
+						},
+					}},
+				}, nil)
+			},
+			expectFinalizer:    true,
+			expectNoCondition:  true,
+			expectRequeueAfter: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.config != nil {
+				b, err := json.Marshal(test.config)
+				require.NoError(t, err, "unexpected error marshaling config")
+				require.NoError(t, os.Setenv(constants.AWSPrivateLinkEnvVar, string(b)))
+			} else {
+				require.NoError(t, os.Unsetenv(constants.AWSPrivateLinkEnvVar))
+			}
+			defer os.Unsetenv(constants.AWSPrivateLinkEnvVar)
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.cd)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.setupAWSMock != nil {
+				test.setupAWSMock(mockAWSClient)
+			}
+
+			r := &ReconcileAWSPrivateLink{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+				awsClientBuilder: func(_ client.Client, secretName, namespace string, options awsclient.Options) (awsclient.Client, error) {
+					assert.Equal(t, testRegion, options.Region, "unexpected region for AWS client")
+					return mockAWSClient, nil
+				},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectRequeueAfter, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+
+			assert.Equal(t, test.expectFinalizer, controllerutils.HasFinalizer(cd, hivev1.FinalizerAWSPrivateLink), "unexpected finalizer")
+
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AWSPrivateLinkNotReadyCondition)
+			if test.expectNoCondition {
+				assert.Nil(t, cond, "unexpected AWSPrivateLinkNotReady condition")
+			} else if assert.NotNil(t, cond, "expected AWSPrivateLinkNotReady condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+
+			if test.expectedPLStatus != nil {
+				if assert.NotNil(t, cd.Status.Platform, "expected platform status") &&
+					assert.NotNil(t, cd.Status.Platform.AWS, "expected AWS platform status") {
+					assert.Equal(t, test.expectedPLStatus, cd.Status.Platform.AWS.PrivateLink, "unexpected private link status")
+				}
+			}
+		})
+	}
+}
+
+type cdOption func(*hivev1.ClusterDeployment)
+
+func testClusterDeployment(opts ...cdOption) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testClusterName,
+			BaseDomain:  testBaseDomain,
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               testRegion,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: testCredentialsName},
+					PrivateLink:          &hivev1aws.PrivateLinkAccess{Enabled: true},
+				},
+			},
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID: testInfraID,
+			},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func withoutPrivateLink(cd *hivev1.ClusterDeployment) {
+	cd.Spec.Platform.AWS.PrivateLink = nil
+}
+
+func withFinalizer(cd *hivev1.ClusterDeployment) {
+	controllerutils.AddFinalizer(cd, hivev1.FinalizerAWSPrivateLink)
+}
+
+func withoutInfraID(cd *hivev1.ClusterDeployment) {
+	cd.Spec.ClusterMetadata = nil
+}
+
+func withDeletion(cd *hivev1.ClusterDeployment) {
+	now := metav1.Now()
+	cd.DeletionTimestamp = &now
+}
+
+func withNotReadyCondition(reason string) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.AWSPrivateLinkNotReadyCondition,
+			Status: corev1.ConditionTrue,
+			Reason: reason,
+		})
+	}
+}
+
+func withPrivateLinkStatus(serviceID, endpointID, hostedZoneID string) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		status := privateLinkStatus(cd)
+		if serviceID != "" {
+			status.VPCEndpointService = hivev1aws.VPCEndpointService{Name: testServiceName, ID: serviceID}
+		}
+		status.VPCEndpointID = endpointID
+		status.HostedZoneID = hostedZoneID
+	}
+}
+
+func testConfig() *hivev1.AWSPrivateLinkConfig {
+	return &hivev1.AWSPrivateLinkConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: testHubCredentials},
+		EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+			AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{VPCID: testEndpointVPC, Region: testRegion},
+			Subnets: []hivev1.AWSPrivateLinkSubnet{
+				{SubnetID: "subnet-a", AvailabilityZone: "us-east-1a"},
+				{SubnetID: "subnet-c", AvailabilityZone: "us-east-1c"},
+			},
+		}},
+		AssociatedVPCs: []hivev1.AWSPrivateLinkVPC{{VPCID: testAssociatedVPC, Region: testRegion}},
+	}
+}
+
+func testServiceConfiguration() *ec2.ServiceConfiguration {
+	return &ec2.ServiceConfiguration{
+		ServiceId:         aws.String(testServiceID),
+		ServiceName:       aws.String(testServiceName),
+		AvailabilityZones: aws.StringSlice([]string{"us-east-1a", "us-east-1b"}),
+	}
+}
+
+func testEndpoint(state string) *ec2.VpcEndpoint {
+	return &ec2.VpcEndpoint{
+		VpcEndpointId: aws.String(testEndpointID),
+		State:         aws.String(state),
+		DnsEntries: []*ec2.DnsEntry{{
+			DnsName:      aws.String("vpce-1.vpce-svc-1.us-east-1.vpce.amazonaws.com"),
+			HostedZoneId: aws.String("Z7HUB22UULQXV"),
+		}},
+	}
+}
+
+func mockLoadBalancer(m *mockaws.MockClient) {
+	m.EXPECT().DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{
+		Names: aws.StringSlice([]string{testInfraID + "-int"}),
+	}).Return(&elbv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []*elbv2.LoadBalancer{{LoadBalancerArn: aws.String(testLBARN)}},
+	}, nil)
+}
+
+func mockExistingService(m *mockaws.MockClient) {
+	m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
+		ServiceConfigurations: []*ec2.ServiceConfiguration{testServiceConfiguration()},
+	}, nil)
+}
+
+func mockHostedZone(m *mockaws.MockClient, create bool) {
+	if create {
+		m.EXPECT().CreateHostedZone(gomock.Any()).Return(&route53.CreateHostedZoneOutput{
+			HostedZone: &route53.HostedZone{Id: aws.String(testHostedZoneID)},
+		}, nil)
+	}
+	m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+		VPCs: []*route53.VPC{{VPCId: aws.String(testEndpointVPC), VPCRegion: aws.String(testRegion)}},
+	}, nil)
+	m.EXPECT().AssociateVPCWithHostedZone(gomock.Any()).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil)
+	m.EXPECT().ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+}
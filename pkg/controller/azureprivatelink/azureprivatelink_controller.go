@@ -0,0 +1,840 @@
+package azureprivatelink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.AzurePrivateLinkControllerName
+
+	// waitForResourceInterval is how long to wait before checking again on an Azure resource that is not ready.
+	waitForResourceInterval = 30 * time.Second
+
+	// resourceSuffix is appended to the infra ID of the cluster to name the Azure resources created for the
+	// cluster.
+	resourceSuffix = "-private-link"
+
+	// provisioningStateSucceeded is the provisioning state of Azure resources that are ready for use.
+	provisioningStateSucceeded = "Succeeded"
+
+	privateLinkReadyReason             = "PrivateLinkReady"
+	notConfiguredReason                = "NotConfigured"
+	noEndpointVNetReason               = "NoEndpointVNetInRegion"
+	waitingForInstallReason            = "WaitingForInstall"
+	waitingForLoadBalancerReason       = "WaitingForLoadBalancer"
+	waitingForPrivateLinkServiceReason = "WaitingForPrivateLinkService"
+	waitingForPrivateEndpointReason    = "WaitingForPrivateEndpoint"
+	waitingForPrivateDNSZoneReason     = "WaitingForPrivateDNSZone"
+	privateLinkServiceFailedReason     = "PrivateLinkServiceFailed"
+	privateEndpointFailedReason        = "PrivateEndpointFailed"
+	privateDNSZoneFailedReason         = "PrivateDNSZoneFailed"
+	privateEndpointRejectedReason      = "PrivateEndpointRejected"
+
+	// privateLinkServiceConnectionApproved is the status of an approved connection to a private link service.
+	privateLinkServiceConnectionApproved = "Approved"
+)
+
+// azureClientBuilderType builds an Azure client from the credentials in the given secret, and returns the
+// subscription of those credentials.
+type azureClientBuilderType func(c client.Client, secretName, namespace string) (azureclient.Client, string, error)
+
+// Add creates a new AzurePrivateLink Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAzurePrivateLink
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileAzurePrivateLink {
+	return &ReconcileAzurePrivateLink{
+		Client:             controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:             log.WithField("controller", ControllerName),
+		azureClientBuilder: newAzureClient,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAzurePrivateLink, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("azureprivatelink-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAzurePrivateLink{}
+
+// ReconcileAzurePrivateLink reconciles the Azure Private Link resources for a ClusterDeployment
+type ReconcileAzurePrivateLink struct {
+	client.Client
+	logger log.FieldLogger
+
+	// azureClientBuilder is a function pointer to the function that builds the Azure clients for the subscription
+	// of the cluster and for the subscription in which the private endpoints are created.
+	azureClientBuilder azureClientBuilderType
+}
+
+// Reconcile publishes the internal API load balancer of a cluster with Azure Private Link enabled through a private
+// link service, creates a private endpoint for that service through which Hive reaches the cluster's API, and a
+// private DNS zone resolving the API domain of the cluster to the private endpoint.
+func (r *ReconcileAzurePrivateLink) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if !cd.DeletionTimestamp.IsZero() {
+		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerAzurePrivateLink) {
+			return reconcile.Result{}, nil
+		}
+		return r.cleanupPrivateLink(cd, cdLog)
+	}
+
+	if !isPrivateLinkEnabled(cd) {
+		cdLog.Debug("Azure Private Link is not enabled for the cluster")
+		return reconcile.Result{}, nil
+	}
+
+	if !controllerutils.HasFinalizer(cd, hivev1.FinalizerAzurePrivateLink) {
+		cdLog.Info("adding Azure Private Link finalizer")
+		controllerutils.AddFinalizer(cd, hivev1.FinalizerAzurePrivateLink)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error adding Azure Private Link finalizer")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	config, err := controllerutils.GetAzurePrivateLinkConfig()
+	if err != nil {
+		cdLog.WithError(err).Error("could not get Azure Private Link config")
+		return reconcile.Result{}, err
+	}
+	if config == nil {
+		cdLog.Warn("Azure Private Link is not configured in HiveConfig")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, notConfiguredReason,
+			"Azure Private Link is not configured in HiveConfig", cdLog)
+	}
+
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		cdLog.Debug("waiting for the infra ID of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForInstallReason,
+			"Waiting for the cluster install to start", cdLog)
+	}
+
+	return r.reconcilePrivateLink(cd, config, cdLog)
+}
+
+func (r *ReconcileAzurePrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeployment, config *hivev1.AzurePrivateLinkConfig, logger log.FieldLogger) (reconcile.Result, error) {
+	region := cd.Spec.Platform.Azure.Region
+	inventory := findEndpointVNet(config, region)
+	if inventory == nil {
+		logger.WithField("region", region).Warn("no virtual network in the endpoint VNet inventory is in the region of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, noEndpointVNetReason,
+			fmt.Sprintf("No virtual network in the endpoint VNet inventory is in region %s", region), logger)
+	}
+	logger = logger.WithField("endpointVNet", inventory.VirtualNetwork)
+
+	spokeClient, _, err := r.azureClientBuilder(r.Client, cd.Spec.Platform.Azure.CredentialsSecretRef.Name, cd.Namespace)
+	if err != nil {
+		logger.WithError(err).Error("error creating Azure client for the cluster subscription")
+		return reconcile.Result{}, err
+	}
+	hubClient, hubSubscription, err := r.azureClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace())
+	if err != nil {
+		logger.WithError(err).Error("error creating Azure client for the endpoint subscription")
+		return reconcile.Result{}, err
+	}
+
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	lb, err := spokeClient.GetLoadBalancer(context.TODO(), clusterResourceGroup(cd), infraID+"-internal")
+	if err != nil {
+		if isAzureStatusCode(err, http.StatusNotFound) {
+			logger.Info("waiting for the internal API load balancer to be created")
+			if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForLoadBalancerReason,
+				"Waiting for the internal API load balancer of the cluster to be created", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+		}
+		logger.WithError(err).Error("error looking up the internal API load balancer")
+		return reconcile.Result{}, err
+	}
+
+	status := privateLinkStatus(cd)
+
+	service, err := r.ensurePrivateLinkService(cd, status, &lb, hubSubscription, spokeClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the private link service")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, privateLinkServiceFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if service == nil {
+		logger.Info("waiting for the private link service to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForPrivateLinkServiceReason,
+			"Waiting for the private link service of the cluster to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	endpoint, err := r.ensurePrivateEndpoint(cd, status, service, hubSubscription, config.ResourceGroupName, inventory, hubClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the private endpoint")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, privateEndpointFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if endpoint == nil {
+		logger.Info("waiting for the private endpoint to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForPrivateEndpointReason,
+			"Waiting for the private endpoint to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	switch connectionStatus := endpointConnectionStatus(endpoint); connectionStatus {
+	case privateLinkServiceConnectionApproved:
+	case "Rejected", "Disconnected":
+		logger.WithField("connectionStatus", connectionStatus).Warn("the connection of the private endpoint is not approved")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, privateEndpointRejectedReason,
+			fmt.Sprintf("The connection of private endpoint %s to the private link service is %s", endpoint.Name, connectionStatus), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	default:
+		logger.WithField("connectionStatus", connectionStatus).Info("waiting for the connection of the private endpoint to be approved")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForPrivateEndpointReason,
+			fmt.Sprintf("Waiting for the connection of private endpoint %s to be approved", endpoint.Name), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	endpointIP, err := privateEndpointIP(endpoint, hubClient)
+	if err != nil {
+		logger.WithError(err).Error("error looking up the address of the private endpoint")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, privateEndpointFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if endpointIP == "" {
+		logger.Info("waiting for the private endpoint to be assigned an address")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForPrivateEndpointReason,
+			fmt.Sprintf("Waiting for private endpoint %s to be assigned an address", endpoint.Name), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	ready, err := r.ensurePrivateDNSZone(cd, status, endpointIP, hubSubscription, config.ResourceGroupName, inventory, hubClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the private DNS zone")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, privateDNSZoneFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if !ready {
+		logger.Info("waiting for the private DNS zone to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForPrivateDNSZoneReason,
+			"Waiting for the private DNS zone to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	if status.EndpointIP != endpointIP {
+		logger.WithField("endpointIP", endpointIP).Info("the cluster API is reachable through the private endpoint")
+		status.EndpointIP = endpointIP
+		if err := r.updateStatus(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionFalse, privateLinkReadyReason,
+		fmt.Sprintf("The cluster API is reachable through private endpoint %s at %s", endpoint.Name, endpointIP), logger)
+}
+
+// ensurePrivateLinkService returns the private link service for the internal API load balancer. The NAT subnet of
+// the private link service and the private link service are created as needed, in which case nil is returned until
+// they have been provisioned. The subscription in which the private endpoints are created is allowed to connect to
+// the private link service, and its connections are approved automatically.
+func (r *ReconcileAzurePrivateLink) ensurePrivateLinkService(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1azure.PrivateLinkAccessStatus,
+	lb *network.LoadBalancer,
+	hubSubscription string,
+	spokeClient azureclient.Client,
+	logger log.FieldLogger,
+) (*azureclient.PrivateLinkService, error) {
+	name := resourceName(cd)
+	cidr := cd.Spec.Platform.Azure.PrivateLink.NATSubnetCIDR
+	if cidr == "" {
+		return nil, errors.New("the CIDR of the NAT subnet is not set")
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil || len(*lb.FrontendIPConfigurations) == 0 {
+		return nil, errors.New("the internal API load balancer has no frontend IP configuration")
+	}
+	frontend := (*lb.FrontendIPConfigurations)[0]
+	if frontend.FrontendIPConfigurationPropertiesFormat == nil || frontend.Subnet == nil {
+		return nil, errors.New("the frontend IP configuration of the internal API load balancer has no subnet")
+	}
+	// The NAT subnet is created in the virtual network of the load balancer, which is not necessarily in the resource
+	// group of the cluster.
+	lbSubnet, err := azure.ParseResourceID(to.String(frontend.Subnet.ID))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse the subnet of the internal API load balancer")
+	}
+	vnetResourceGroup, vnet := lbSubnet.ResourceGroup, virtualNetworkOfSubnet(to.String(frontend.Subnet.ID))
+	if vnet == "" {
+		return nil, fmt.Errorf("could not find the virtual network of subnet %s", to.String(frontend.Subnet.ID))
+	}
+
+	subnet, err := spokeClient.GetSubnet(context.TODO(), vnetResourceGroup, vnet, name)
+	if err != nil {
+		if !isAzureStatusCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get NAT subnet")
+		}
+		logger.WithField("cidr", cidr).Info("creating NAT subnet")
+		if err := spokeClient.CreateOrUpdateSubnet(context.TODO(), vnetResourceGroup, vnet, &azureclient.Subnet{
+			Name: name,
+			Properties: &azureclient.SubnetProperties{
+				AddressPrefix:                     cidr,
+				PrivateLinkServiceNetworkPolicies: "Disabled",
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not create NAT subnet")
+		}
+		status.NATSubnet = name
+		return nil, r.updateStatus(cd, logger)
+	}
+	if subnet.Properties == nil || subnet.Properties.ProvisioningState != provisioningStateSucceeded {
+		return nil, nil
+	}
+
+	service, err := spokeClient.GetPrivateLinkService(context.TODO(), clusterResourceGroup(cd), name)
+	if err != nil {
+		if !isAzureStatusCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get private link service")
+		}
+		logger.WithField("consumerSubscription", hubSubscription).Info("creating private link service")
+		if err := spokeClient.CreateOrUpdatePrivateLinkService(context.TODO(), clusterResourceGroup(cd), &azureclient.PrivateLinkService{
+			Name:     name,
+			Location: to.String(lb.Location),
+			Properties: &azureclient.PrivateLinkServiceProperties{
+				LoadBalancerFrontendIPConfigurations: []azureclient.SubResource{{ID: to.String(frontend.ID)}},
+				IPConfigurations: []azureclient.PrivateLinkServiceIPConfiguration{{
+					Name: name,
+					Properties: &azureclient.PrivateLinkServiceIPConfigurationProperties{
+						Subnet:                    &azureclient.SubResource{ID: subnet.ID},
+						PrivateIPAllocationMethod: "Dynamic",
+						Primary:                   true,
+					},
+				}},
+				Visibility:   &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: []string{hubSubscription}},
+				AutoApproval: &azureclient.PrivateLinkServiceSubscriptions{Subscriptions: []string{hubSubscription}},
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not create private link service")
+		}
+		status.PrivateLinkService = name
+		return nil, r.updateStatus(cd, logger)
+	}
+	if service.Properties == nil || service.Properties.ProvisioningState != provisioningStateSucceeded {
+		return nil, nil
+	}
+	return service, nil
+}
+
+// ensurePrivateEndpoint returns the private endpoint for the private link service. The private endpoint is created
+// as needed, in which case nil is returned until it has been provisioned.
+func (r *ReconcileAzurePrivateLink) ensurePrivateEndpoint(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1azure.PrivateLinkAccessStatus,
+	service *azureclient.PrivateLinkService,
+	hubSubscription, hubResourceGroup string,
+	inventory *hivev1.AzurePrivateLinkInventory,
+	hubClient azureclient.Client,
+	logger log.FieldLogger,
+) (*azureclient.PrivateEndpoint, error) {
+	name := resourceName(cd)
+
+	endpoint, err := hubClient.GetPrivateEndpoint(context.TODO(), hubResourceGroup, name)
+	if err != nil {
+		if !isAzureStatusCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get private endpoint")
+		}
+		logger.WithField("subnet", inventory.Subnet).Info("creating private endpoint")
+		if err := hubClient.CreateOrUpdatePrivateEndpoint(context.TODO(), hubResourceGroup, &azureclient.PrivateEndpoint{
+			Name:     name,
+			Location: inventory.Region,
+			Properties: &azureclient.PrivateEndpointProperties{
+				Subnet: &azureclient.SubResource{
+					ID: virtualNetworkID(hubSubscription, hubResourceGroup, inventory.VirtualNetwork) + "/subnets/" + inventory.Subnet,
+				},
+				PrivateLinkServiceConnections: []azureclient.PrivateLinkServiceConnection{{
+					Name: name,
+					Properties: &azureclient.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: service.ID,
+						RequestMessage:       description(cd),
+					},
+				}},
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not create private endpoint")
+		}
+		status.PrivateEndpoint = name
+		return nil, r.updateStatus(cd, logger)
+	}
+	if endpoint.Properties == nil || endpoint.Properties.ProvisioningState != provisioningStateSucceeded {
+		return nil, nil
+	}
+	return endpoint, nil
+}
+
+// ensurePrivateDNSZone creates the private DNS zone for the API domain of the cluster if needed, points the API
+// domain at the private endpoint, and links the zone to the virtual network of the private endpoint. It returns
+// false until the zone has been created.
+func (r *ReconcileAzurePrivateLink) ensurePrivateDNSZone(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1azure.PrivateLinkAccessStatus,
+	endpointIP, hubSubscription, hubResourceGroup string,
+	inventory *hivev1.AzurePrivateLinkInventory,
+	hubClient azureclient.Client,
+	logger log.FieldLogger,
+) (bool, error) {
+	zone := apiDomain(cd)
+	logger = logger.WithField("apiDomain", zone)
+
+	if _, err := hubClient.GetPrivateZone(context.TODO(), hubResourceGroup, zone); err != nil {
+		if !isAzureStatusCode(err, http.StatusNotFound) {
+			return false, errors.Wrap(err, "could not get private DNS zone")
+		}
+		logger.Info("creating private DNS zone")
+		if err := hubClient.CreateOrUpdatePrivateZone(context.TODO(), hubResourceGroup, zone); err != nil {
+			return false, errors.Wrap(err, "could not create private DNS zone")
+		}
+		status.PrivateDNSZone = zone
+		return false, r.updateStatus(cd, logger)
+	}
+
+	if err := hubClient.CreateOrUpdatePrivateRecordSet(context.TODO(), hubResourceGroup, zone, "@", privatedns.A, privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL:      to.Int64Ptr(60),
+			ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr(endpointIP)}},
+		},
+	}); err != nil {
+		return false, errors.Wrap(err, "could not point the API domain at the private endpoint")
+	}
+
+	name := resourceName(cd)
+	if _, err := hubClient.GetVirtualNetworkLink(context.TODO(), hubResourceGroup, zone, name); err != nil {
+		if !isAzureStatusCode(err, http.StatusNotFound) {
+			return false, errors.Wrap(err, "could not get virtual network link of private DNS zone")
+		}
+		logger.WithField("vnet", inventory.VirtualNetwork).Info("linking private DNS zone to virtual network")
+		if err := hubClient.CreateOrUpdateVirtualNetworkLink(context.TODO(), hubResourceGroup, zone, name,
+			virtualNetworkID(hubSubscription, hubResourceGroup, inventory.VirtualNetwork)); err != nil {
+			return false, errors.Wrapf(err, "could not link private DNS zone to virtual network %s", inventory.VirtualNetwork)
+		}
+		status.VirtualNetworkLink = name
+		if err := r.updateStatus(cd, logger); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// cleanupPrivateLink deletes the Azure resources created for the cluster and removes the Azure Private Link
+// finalizer. Azure deletes resources asynchronously, so each resource is deleted in turn, requeueing until the
+// previous one is gone.
+func (r *ReconcileAzurePrivateLink) cleanupPrivateLink(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	var status *hivev1azure.PrivateLinkAccessStatus
+	if cd.Status.Platform != nil && cd.Status.Platform.Azure != nil {
+		status = cd.Status.Platform.Azure.PrivateLink
+	}
+
+	if status != nil && (status.VirtualNetworkLink != "" || status.PrivateDNSZone != "" || status.PrivateEndpoint != "") {
+		config, err := controllerutils.GetAzurePrivateLinkConfig()
+		if err != nil {
+			logger.WithError(err).Error("could not get Azure Private Link config")
+			return reconcile.Result{}, err
+		}
+		if config == nil {
+			logger.Warn("Azure Private Link is no longer configured in HiveConfig, leaving the private endpoint in place")
+		} else {
+			hubClient, _, err := r.azureClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace())
+			if err != nil {
+				logger.WithError(err).Error("error creating Azure client for the endpoint subscription")
+				return reconcile.Result{}, err
+			}
+			rg := config.ResourceGroupName
+			zone := status.PrivateDNSZone
+			status.EndpointIP = ""
+			steps := []deleteStep{
+				{
+					kind: "virtual network link",
+					name: &status.VirtualNetworkLink,
+					get: func(name string) error {
+						_, err := hubClient.GetVirtualNetworkLink(context.TODO(), rg, zone, name)
+						return err
+					},
+					delete: func(name string) error {
+						return hubClient.DeleteVirtualNetworkLink(context.TODO(), rg, zone, name)
+					},
+				},
+				{
+					kind: "private DNS zone",
+					name: &status.PrivateDNSZone,
+					get: func(name string) error {
+						_, err := hubClient.GetPrivateZone(context.TODO(), rg, name)
+						return err
+					},
+					delete: func(name string) error {
+						return hubClient.DeletePrivateZone(context.TODO(), rg, name)
+					},
+				},
+				{
+					kind: "private endpoint",
+					name: &status.PrivateEndpoint,
+					get: func(name string) error {
+						_, err := hubClient.GetPrivateEndpoint(context.TODO(), rg, name)
+						return err
+					},
+					delete: func(name string) error {
+						return hubClient.DeletePrivateEndpoint(context.TODO(), rg, name)
+					},
+				},
+			}
+			if result, done, err := r.runDeleteSteps(cd, steps, logger); !done || err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if status != nil && (status.PrivateLinkService != "" || status.NATSubnet != "") {
+		spokeClient, _, err := r.azureClientBuilder(r.Client, cd.Spec.Platform.Azure.CredentialsSecretRef.Name, cd.Namespace)
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Warn("Azure credentials for the cluster not found, leaving the private link service in place")
+		case err != nil:
+			logger.WithError(err).Error("error creating Azure client for the cluster subscription")
+			return reconcile.Result{}, err
+		default:
+			steps := []deleteStep{{
+				kind: "private link service",
+				name: &status.PrivateLinkService,
+				get: func(name string) error {
+					_, err := spokeClient.GetPrivateLinkService(context.TODO(), clusterResourceGroup(cd), name)
+					return err
+				},
+				delete: func(name string) error {
+					return spokeClient.DeletePrivateLinkService(context.TODO(), clusterResourceGroup(cd), name)
+				},
+			}}
+			if status.NATSubnet != "" {
+				vnetResourceGroup, vnet, err := natSubnetVirtualNetwork(cd, spokeClient)
+				if err != nil {
+					logger.WithError(err).Error("error looking up the virtual network of the NAT subnet")
+					return reconcile.Result{}, err
+				}
+				if vnet == "" {
+					logger.Info("the virtual network of the cluster is gone, the NAT subnet was deleted with it")
+					status.NATSubnet = ""
+				} else {
+					steps = append(steps, deleteStep{
+						kind: "NAT subnet",
+						name: &status.NATSubnet,
+						get: func(name string) error {
+							_, err := spokeClient.GetSubnet(context.TODO(), vnetResourceGroup, vnet, name)
+							return err
+						},
+						delete: func(name string) error {
+							return spokeClient.DeleteSubnet(context.TODO(), vnetResourceGroup, vnet, name)
+						},
+					})
+				}
+			}
+			if result, done, err := r.runDeleteSteps(cd, steps, logger); !done || err != nil {
+				return result, err
+			}
+		}
+	}
+
+	logger.Info("removing Azure Private Link finalizer")
+	controllerutils.DeleteFinalizer(cd, hivev1.FinalizerAzurePrivateLink)
+	if err := r.Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error removing Azure Private Link finalizer")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// deleteStep deletes an Azure resource recorded in the status of the cluster deployment.
+type deleteStep struct {
+	kind   string
+	name   *string
+	get    func(name string) error
+	delete func(name string) error
+}
+
+// runDeleteSteps deletes the resources of the steps in order. A resource is removed from the status once it is gone.
+// It returns false if a resource is still being deleted.
+func (r *ReconcileAzurePrivateLink) runDeleteSteps(cd *hivev1.ClusterDeployment, steps []deleteStep, logger log.FieldLogger) (reconcile.Result, bool, error) {
+	for _, step := range steps {
+		if *step.name == "" {
+			continue
+		}
+		logger := logger.WithField("resource", *step.name)
+		// Azure accepts deletes of resources that do not exist, so the resource is looked up to tell whether it is
+		// gone.
+		err := step.get(*step.name)
+		switch {
+		case isAzureStatusCode(err, http.StatusNotFound):
+			logger.Infof("%s deleted", step.kind)
+			*step.name = ""
+			if err := r.updateStatus(cd, logger); err != nil {
+				return reconcile.Result{}, false, err
+			}
+			continue
+		case err != nil:
+			logger.WithError(err).Errorf("error getting %s", step.kind)
+			return reconcile.Result{}, false, errors.Wrapf(err, "could not get %s", step.kind)
+		}
+		logger.Infof("deleting %s", step.kind)
+		if err := step.delete(*step.name); err != nil {
+			logger.WithError(err).Errorf("error deleting %s", step.kind)
+			return reconcile.Result{}, false, errors.Wrapf(err, "could not delete %s", step.kind)
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, false, nil
+	}
+	return reconcile.Result{}, true, nil
+}
+
+func (r *ReconcileAzurePrivateLink) setNotReadyCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AzurePrivateLinkNotReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	return r.updateStatus(cd, logger)
+}
+
+func (r *ReconcileAzurePrivateLink) updateStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment status")
+		return err
+	}
+	return nil
+}
+
+func newAzureClient(c client.Client, secretName, namespace string) (azureclient.Client, string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, "", err
+	}
+	subscriptionID, err := azureclient.SubscriptionIDFromSecret(secret)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not get the subscription of the Azure credentials")
+	}
+	azureClient, err := azureclient.NewClientFromSecret(secret)
+	if err != nil {
+		return nil, "", err
+	}
+	return azureClient, subscriptionID, nil
+}
+
+func isPrivateLinkEnabled(cd *hivev1.ClusterDeployment) bool {
+	azure := cd.Spec.Platform.Azure
+	return azure != nil && azure.PrivateLink != nil && azure.PrivateLink.Enabled
+}
+
+// privateLinkStatus returns the Azure Private Link status of the cluster deployment, adding it if needed.
+func privateLinkStatus(cd *hivev1.ClusterDeployment) *hivev1azure.PrivateLinkAccessStatus {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure == nil {
+		cd.Status.Platform.Azure = &hivev1azure.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure.PrivateLink == nil {
+		cd.Status.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkAccessStatus{}
+	}
+	return cd.Status.Platform.Azure.PrivateLink
+}
+
+// findEndpointVNet returns the first virtual network of the endpoint VNet inventory in the region.
+func findEndpointVNet(config *hivev1.AzurePrivateLinkConfig, region string) *hivev1.AzurePrivateLinkInventory {
+	for i, inventory := range config.EndpointVNetInventory {
+		if inventory.Region == region {
+			return &config.EndpointVNetInventory[i]
+		}
+	}
+	return nil
+}
+
+// endpointConnectionStatus returns the status of the connection of the private endpoint to the private link service.
+func endpointConnectionStatus(endpoint *azureclient.PrivateEndpoint) string {
+	for _, connection := range endpoint.Properties.PrivateLinkServiceConnections {
+		if connection.Properties != nil && connection.Properties.PrivateLinkServiceConnectionState != nil {
+			return connection.Properties.PrivateLinkServiceConnectionState.Status
+		}
+	}
+	return ""
+}
+
+// privateEndpointIP returns the private IP address of the network interface of the private endpoint, or an empty
+// string if it has none yet.
+func privateEndpointIP(endpoint *azureclient.PrivateEndpoint, hubClient azureclient.Client) (string, error) {
+	if len(endpoint.Properties.NetworkInterfaces) == 0 {
+		return "", nil
+	}
+	nicID, err := azure.ParseResourceID(endpoint.Properties.NetworkInterfaces[0].ID)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse the network interface of the private endpoint")
+	}
+	nic, err := hubClient.GetNetworkInterface(context.TODO(), nicID.ResourceGroup, nicID.ResourceName)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get the network interface of the private endpoint")
+	}
+	if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+		return "", nil
+	}
+	for _, ipConfig := range *nic.IPConfigurations {
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat != nil && to.String(ipConfig.PrivateIPAddress) != "" {
+			return to.String(ipConfig.PrivateIPAddress), nil
+		}
+	}
+	return "", nil
+}
+
+// natSubnetVirtualNetwork returns the resource group and name of the virtual network holding the NAT subnet, which
+// is the virtual network of the internal API load balancer. An empty name is returned if the load balancer is gone.
+func natSubnetVirtualNetwork(cd *hivev1.ClusterDeployment, spokeClient azureclient.Client) (string, string, error) {
+	lb, err := spokeClient.GetLoadBalancer(context.TODO(), clusterResourceGroup(cd), cd.Spec.ClusterMetadata.InfraID+"-internal")
+	if err != nil {
+		if isAzureStatusCode(err, http.StatusNotFound) {
+			return "", "", nil
+		}
+		return "", "", errors.Wrap(err, "could not get the internal API load balancer")
+	}
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil {
+		return "", "", nil
+	}
+	for _, frontend := range *lb.FrontendIPConfigurations {
+		if frontend.FrontendIPConfigurationPropertiesFormat == nil || frontend.Subnet == nil {
+			continue
+		}
+		subnetID := to.String(frontend.Subnet.ID)
+		subnet, err := azure.ParseResourceID(subnetID)
+		if err != nil {
+			return "", "", errors.Wrap(err, "could not parse the subnet of the internal API load balancer")
+		}
+		return subnet.ResourceGroup, virtualNetworkOfSubnet(subnetID), nil
+	}
+	return "", "", nil
+}
+
+// virtualNetworkOfSubnet returns the name of the virtual network in the ID of a subnet.
+func virtualNetworkOfSubnet(subnetID string) string {
+	parts := strings.Split(subnetID, "/")
+	for i, part := range parts {
+		if strings.EqualFold(part, "virtualNetworks") && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func virtualNetworkID(subscription, resourceGroup, vnet string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s", subscription, resourceGroup, vnet)
+}
+
+// clusterResourceGroup returns the resource group created by the installer for the cluster.
+func clusterResourceGroup(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.ClusterMetadata.InfraID + "-rg"
+}
+
+func apiDomain(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+}
+
+func resourceName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.ClusterMetadata.InfraID + resourceSuffix
+}
+
+func description(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("Private Link access for cluster %s/%s", cd.Namespace, cd.Name)
+}
+
+func isAzureStatusCode(err error, code int) bool {
+	if detailedErr, ok := err.(autorest.DetailedError); ok {
+		return detailedErr.StatusCode == code
+	}
+	return false
+}
@@ -0,0 +1,605 @@
+package azureprivatelink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testNamespace        = "test-namespace"
+	testName             = "test-cluster"
+	testInfraID          = "mycluster-abcde"
+	testRegion           = "eastus"
+	testCredentialsName  = "test-creds"
+	testHubCredentials   = "hub-creds"
+	testHubSubscription  = "hub-subscription"
+	testHubResourceGroup = "hub-rg"
+	testHubVNet          = "hub-vnet"
+	testHubSubnet        = "hub-subnet"
+	testNATSubnetCIDR    = "10.0.255.0/29"
+	testEndpointIP       = "10.1.0.5"
+	testAPIDomain        = "api.mycluster.example.com"
+	testClusterRG        = testInfraID + "-rg"
+	testLBName           = testInfraID + "-internal"
+	testResourceName     = testInfraID + resourceSuffix
+	testClusterVNet      = testInfraID + "-vnet"
+	testFrontendID       = "/subscriptions/spoke-subscription/resourceGroups/mycluster-abcde-rg/providers/Microsoft.Network/loadBalancers/mycluster-abcde-internal/frontendIPConfigurations/internal-lb-ip"
+	testMasterSubnetID   = "/subscriptions/spoke-subscription/resourceGroups/mycluster-abcde-rg/providers/Microsoft.Network/virtualNetworks/mycluster-abcde-vnet/subnets/mycluster-abcde-master-subnet"
+	testNATSubnetID      = "/subscriptions/spoke-subscription/resourceGroups/mycluster-abcde-rg/providers/Microsoft.Network/virtualNetworks/mycluster-abcde-vnet/subnets/mycluster-abcde-private-link"
+	testServiceID        = "/subscriptions/spoke-subscription/resourceGroups/mycluster-abcde-rg/providers/Microsoft.Network/privateLinkServices/mycluster-abcde-private-link"
+	testHubVNetID        = "/subscriptions/hub-subscription/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"
+	testEndpointNICName  = "mycluster-abcde-private-link.nic.0123"
+	testEndpointNICID    = "/subscriptions/hub-subscription/resourceGroups/hub-rg/providers/Microsoft.Network/networkInterfaces/" + testEndpointNICName
+)
+
+func TestReconcileAzurePrivateLink(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		config             *hivev1.AzurePrivateLinkConfig
+		setupSpokeMock     func(*mockazure.MockClient)
+		setupHubMock       func(*mockazure.MockClient)
+		expectFinalizer    bool
+		expectNoCondition  bool
+		expectedReason     string
+		expectedStatus     corev1.ConditionStatus
+		expectRequeueAfter bool
+		expectedPLStatus   *hivev1azure.PrivateLinkAccessStatus
+	}{
+		{
+			name:              "private link not enabled",
+			cd:                testClusterDeployment(withoutPrivateLink),
+			config:            testConfig(),
+			expectNoCondition: true,
+		},
+		{
+			name:              "add finalizer",
+			cd:                testClusterDeployment(),
+			config:            testConfig(),
+			expectFinalizer:   true,
+			expectNoCondition: true,
+		},
+		{
+			name:            "not configured",
+			cd:              testClusterDeployment(withFinalizer),
+			expectFinalizer: true,
+			expectedReason:  notConfiguredReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:            "waiting for infra ID",
+			cd:              testClusterDeployment(withFinalizer, withoutInfraID),
+			config:          testConfig(),
+			expectFinalizer: true,
+			expectedReason:  waitingForInstallReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name: "no endpoint virtual network in region",
+			cd:   testClusterDeployment(withFinalizer),
+			config: func() *hivev1.AzurePrivateLinkConfig {
+				c := testConfig()
+				c.EndpointVNetInventory[0].Region = "westus"
+				return c
+			}(),
+			expectFinalizer: true,
+			expectedReason:  noEndpointVNetReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:   "waiting for load balancer",
+			cd:     testClusterDeployment(withFinalizer),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetLoadBalancer(gomock.Any(), testClusterRG, testLBName).Return(network.LoadBalancer{}, notFound())
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForLoadBalancerReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "create NAT subnet",
+			cd:     testClusterDeployment(withFinalizer),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockLoadBalancer(m)
+				m.EXPECT().GetSubnet(gomock.Any(), testClusterRG, testClusterVNet, testResourceName).Return(nil, notFound())
+				m.EXPECT().CreateOrUpdateSubnet(gomock.Any(), testClusterRG, testClusterVNet, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _ string, subnet *azureclient.Subnet) error {
+						assert.Equal(t, testResourceName, subnet.Name, "unexpected subnet name")
+						assert.Equal(t, testNATSubnetCIDR, subnet.Properties.AddressPrefix, "unexpected subnet CIDR")
+						assert.Equal(t, "Disabled", subnet.Properties.PrivateLinkServiceNetworkPolicies, "unexpected subnet network policies")
+						return nil
+					})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateLinkServiceReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus:   &hivev1azure.PrivateLinkAccessStatus{NATSubnet: testResourceName},
+		},
+		{
+			name:   "create private link service",
+			cd:     testClusterDeployment(withFinalizer, withStatus(&hivev1azure.PrivateLinkAccessStatus{NATSubnet: testResourceName})),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockLoadBalancer(m)
+				mockNATSubnet(m)
+				m.EXPECT().GetPrivateLinkService(gomock.Any(), testClusterRG, testResourceName).Return(nil, notFound())
+				m.EXPECT().CreateOrUpdatePrivateLinkService(gomock.Any(), testClusterRG, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, service *azureclient.PrivateLinkService) error {
+						assert.Equal(t, testRegion, service.Location, "unexpected location")
+						assert.Equal(t, []azureclient.SubResource{{ID: testFrontendID}}, service.Properties.LoadBalancerFrontendIPConfigurations, "unexpected frontend")
+						if assert.Len(t, service.Properties.IPConfigurations, 1, "unexpected IP configurations") {
+							assert.Equal(t, testNATSubnetID, service.Properties.IPConfigurations[0].Properties.Subnet.ID, "unexpected NAT subnet")
+						}
+						assert.Equal(t, []string{testHubSubscription}, service.Properties.Visibility.Subscriptions, "unexpected visibility")
+						assert.Equal(t, []string{testHubSubscription}, service.Properties.AutoApproval.Subscriptions, "unexpected auto approval")
+						return nil
+					})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateLinkServiceReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus: &hivev1azure.PrivateLinkAccessStatus{
+				NATSubnet:          testResourceName,
+				PrivateLinkService: testResourceName,
+			},
+		},
+		{
+			name:   "waiting for private link service to be provisioned",
+			cd:     testClusterDeployment(withFinalizer, withStatus(spokeStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockLoadBalancer(m)
+				mockNATSubnet(m)
+				m.EXPECT().GetPrivateLinkService(gomock.Any(), testClusterRG, testResourceName).Return(&azureclient.PrivateLinkService{
+					ID:         testServiceID,
+					Properties: &azureclient.PrivateLinkServiceProperties{ProvisioningState: "Updating"},
+				}, nil)
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateLinkServiceReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus:   spokeStatus(),
+		},
+		{
+			name:   "create private endpoint",
+			cd:     testClusterDeployment(withFinalizer, withStatus(spokeStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockPrivateLinkService(m)
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testResourceName).Return(nil, notFound())
+				m.EXPECT().CreateOrUpdatePrivateEndpoint(gomock.Any(), testHubResourceGroup, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, endpoint *azureclient.PrivateEndpoint) error {
+						assert.Equal(t, testHubVNetID+"/subnets/"+testHubSubnet, endpoint.Properties.Subnet.ID, "unexpected endpoint subnet")
+						if assert.Len(t, endpoint.Properties.PrivateLinkServiceConnections, 1, "unexpected connections") {
+							assert.Equal(t, testServiceID, endpoint.Properties.PrivateLinkServiceConnections[0].Properties.PrivateLinkServiceID, "unexpected private link service")
+						}
+						return nil
+					})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus: func() *hivev1azure.PrivateLinkAccessStatus {
+				s := spokeStatus()
+				s.PrivateEndpoint = testResourceName
+				return s
+			}(),
+		},
+		{
+			name:   "waiting for connection to be approved",
+			cd:     testClusterDeployment(withFinalizer, withStatus(endpointStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockPrivateLinkService(m)
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				mockPrivateEndpoint(m, "Pending")
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus:   endpointStatus(),
+		},
+		{
+			name:   "connection rejected",
+			cd:     testClusterDeployment(withFinalizer, withStatus(endpointStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockPrivateLinkService(m)
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				mockPrivateEndpoint(m, "Rejected")
+			},
+			expectFinalizer:    true,
+			expectedReason:     privateEndpointRejectedReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "create private DNS zone",
+			cd:     testClusterDeployment(withFinalizer, withStatus(endpointStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockPrivateLinkService(m)
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				mockPrivateEndpoint(m, privateLinkServiceConnectionApproved)
+				mockEndpointNIC(m)
+				m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, notFound())
+				m.EXPECT().CreateOrUpdatePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(nil)
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForPrivateDNSZoneReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPLStatus: func() *hivev1azure.PrivateLinkAccessStatus {
+				s := endpointStatus()
+				s.PrivateDNSZone = testAPIDomain
+				return s
+			}(),
+		},
+		{
+			name:   "private link ready",
+			cd:     testClusterDeployment(withFinalizer, withNotReadyCondition(waitingForPrivateDNSZoneReason), withStatus(zoneStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				mockPrivateLinkService(m)
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				mockPrivateEndpoint(m, privateLinkServiceConnectionApproved)
+				mockEndpointNIC(m)
+				m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, nil)
+				m.EXPECT().CreateOrUpdatePrivateRecordSet(gomock.Any(), testHubResourceGroup, testAPIDomain, "@", privatedns.A, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _, _ string, _ privatedns.RecordType, recordSet privatedns.RecordSet) error {
+						if assert.Len(t, *recordSet.ARecords, 1, "unexpected A records") {
+							assert.Equal(t, testEndpointIP, to.String((*recordSet.ARecords)[0].Ipv4Address), "unexpected A record")
+						}
+						return nil
+					})
+				m.EXPECT().GetVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName).Return(privatedns.VirtualNetworkLink{}, notFound())
+				m.EXPECT().CreateOrUpdateVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName, testHubVNetID).Return(nil)
+			},
+			expectFinalizer: true,
+			expectedReason:  privateLinkReadyReason,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedPLStatus: func() *hivev1azure.PrivateLinkAccessStatus {
+				s := fullStatus()
+				s.EndpointIP = testEndpointIP
+				return s
+			}(),
+		},
+		{
+			name:   "cleanup deletes virtual network link",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus())),
+			config: testConfig(),
+			setupHubMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName).Return(privatedns.VirtualNetworkLink{}, nil)
+				m.EXPECT().DeleteVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName).Return(nil)
+			},
+			expectFinalizer:    true,
+			expectNoCondition:  true,
+			expectRequeueAfter: true,
+			expectedPLStatus:   fullStatus(),
+		},
+		{
+			name:   "cleanup deletes private DNS zone",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus())),
+			config: testConfig(),
+			setupHubMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName).Return(privatedns.VirtualNetworkLink{}, notFound())
+				m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, nil)
+				m.EXPECT().DeletePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(nil)
+			},
+			expectFinalizer:    true,
+			expectNoCondition:  true,
+			expectRequeueAfter: true,
+			expectedPLStatus: func() *hivev1azure.PrivateLinkAccessStatus {
+				s := fullStatus()
+				s.VirtualNetworkLink = ""
+				return s
+			}(),
+		},
+		{
+			name:   "cleanup done",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetPrivateLinkService(gomock.Any(), testClusterRG, testResourceName).Return(nil, notFound())
+				mockLoadBalancer(m)
+				m.EXPECT().GetSubnet(gomock.Any(), testClusterRG, testClusterVNet, testResourceName).Return(nil, notFound())
+			},
+			setupHubMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testResourceName).Return(privatedns.VirtualNetworkLink{}, notFound())
+				m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, notFound())
+				m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testResourceName).Return(nil, notFound())
+			},
+			expectNoCondition: true,
+			expectedPLStatus:  &hivev1azure.PrivateLinkAccessStatus{},
+		},
+		{
+			name:   "cleanup with cluster virtual network gone",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(spokeStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockazure.MockClient) {
+				m.EXPECT().GetLoadBalancer(gomock.Any(), testClusterRG, testLBName).Return(network.LoadBalancer{}, notFound())
+				m.EXPECT().GetPrivateLinkService(gomock.Any(), testClusterRG, testResourceName).Return(nil, notFound())
+			},
+			expectNoCondition: true,
+			expectedPLStatus:  &hivev1azure.PrivateLinkAccessStatus{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.config != nil {
+				b, err := json.Marshal(test.config)
+				require.NoError(t, err, "unexpected error marshaling config")
+				require.NoError(t, os.Setenv(constants.AzurePrivateLinkEnvVar, string(b)))
+			} else {
+				require.NoError(t, os.Unsetenv(constants.AzurePrivateLinkEnvVar))
+			}
+			defer os.Unsetenv(constants.AzurePrivateLinkEnvVar)
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.cd)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			spokeClient := mockazure.NewMockClient(mockCtrl)
+			if test.setupSpokeMock != nil {
+				test.setupSpokeMock(spokeClient)
+			}
+			hubClient := mockazure.NewMockClient(mockCtrl)
+			if test.setupHubMock != nil {
+				test.setupHubMock(hubClient)
+			}
+
+			r := &ReconcileAzurePrivateLink{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+				azureClientBuilder: func(_ client.Client, secretName, _ string) (azureclient.Client, string, error) {
+					if secretName == testHubCredentials {
+						return hubClient, testHubSubscription, nil
+					}
+					return spokeClient, "spoke-subscription", nil
+				},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectRequeueAfter, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+
+			assert.Equal(t, test.expectFinalizer, controllerutils.HasFinalizer(cd, hivev1.FinalizerAzurePrivateLink), "unexpected finalizer")
+
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AzurePrivateLinkNotReadyCondition)
+			if test.expectNoCondition {
+				assert.Nil(t, cond, "unexpected AzurePrivateLinkNotReady condition")
+			} else if assert.NotNil(t, cond, "expected AzurePrivateLinkNotReady condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+
+			if test.expectedPLStatus != nil {
+				if assert.NotNil(t, cd.Status.Platform, "expected platform status") &&
+					assert.NotNil(t, cd.Status.Platform.Azure, "expected Azure platform status") {
+					assert.Equal(t, test.expectedPLStatus, cd.Status.Platform.Azure.PrivateLink, "unexpected private link status")
+				}
+			}
+		})
+	}
+}
+
+type cdOption func(*hivev1.ClusterDeployment)
+
+func testClusterDeployment(opts ...cdOption) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "mycluster",
+			BaseDomain:  "example.com",
+			Platform: hivev1.Platform{
+				Azure: &hivev1azure.Platform{
+					Region:               testRegion,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: testCredentialsName},
+					PrivateLink: &hivev1azure.PrivateLinkAccess{
+						Enabled:       true,
+						NATSubnetCIDR: testNATSubnetCIDR,
+					},
+				},
+			},
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID: testInfraID,
+			},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func withoutPrivateLink(cd *hivev1.ClusterDeployment) {
+	cd.Spec.Platform.Azure.PrivateLink = nil
+}
+
+func withFinalizer(cd *hivev1.ClusterDeployment) {
+	controllerutils.AddFinalizer(cd, hivev1.FinalizerAzurePrivateLink)
+}
+
+func withoutInfraID(cd *hivev1.ClusterDeployment) {
+	cd.Spec.ClusterMetadata = nil
+}
+
+func withDeletion(cd *hivev1.ClusterDeployment) {
+	now := metav1.Now()
+	cd.DeletionTimestamp = &now
+}
+
+func withNotReadyCondition(reason string) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.AzurePrivateLinkNotReadyCondition,
+			Status: corev1.ConditionTrue,
+			Reason: reason,
+		})
+	}
+}
+
+func withStatus(status *hivev1azure.PrivateLinkAccessStatus) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		*privateLinkStatus(cd) = *status
+	}
+}
+
+func spokeStatus() *hivev1azure.PrivateLinkAccessStatus {
+	return &hivev1azure.PrivateLinkAccessStatus{
+		NATSubnet:          testResourceName,
+		PrivateLinkService: testResourceName,
+	}
+}
+
+func endpointStatus() *hivev1azure.PrivateLinkAccessStatus {
+	s := spokeStatus()
+	s.PrivateEndpoint = testResourceName
+	return s
+}
+
+func zoneStatus() *hivev1azure.PrivateLinkAccessStatus {
+	s := endpointStatus()
+	s.PrivateDNSZone = testAPIDomain
+	return s
+}
+
+func fullStatus() *hivev1azure.PrivateLinkAccessStatus {
+	s := zoneStatus()
+	s.VirtualNetworkLink = testResourceName
+	return s
+}
+
+func testConfig() *hivev1.AzurePrivateLinkConfig {
+	return &hivev1.AzurePrivateLinkConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: testHubCredentials},
+		ResourceGroupName:    testHubResourceGroup,
+		EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+			VirtualNetwork: testHubVNet,
+			Region:         testRegion,
+			Subnet:         testHubSubnet,
+		}},
+	}
+}
+
+func notFound() error {
+	return autorest.DetailedError{StatusCode: http.StatusNotFound}
+}
+
+func mockLoadBalancer(m *mockazure.MockClient) {
+	m.EXPECT().GetLoadBalancer(gomock.Any(), testClusterRG, testLBName).Return(network.LoadBalancer{
+		Name:     to.StringPtr(testLBName),
+		Location: to.StringPtr(testRegion),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{{
+				ID: to.StringPtr(testFrontendID),
+				FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+					Subnet: &network.Subnet{ID: to.StringPtr(testMasterSubnetID)},
+				},
+			}},
+		},
+	}, nil)
+}
+
+func mockNATSubnet(m *mockazure.MockClient) {
+	m.EXPECT().GetSubnet(gomock.Any(), testClusterRG, testClusterVNet, testResourceName).Return(&azureclient.Subnet{
+		ID:   testNATSubnetID,
+		Name: testResourceName,
+		Properties: &azureclient.SubnetProperties{
+			AddressPrefix:     testNATSubnetCIDR,
+			ProvisioningState: provisioningStateSucceeded,
+		},
+	}, nil)
+}
+
+func mockPrivateLinkService(m *mockazure.MockClient) {
+	mockLoadBalancer(m)
+	mockNATSubnet(m)
+	m.EXPECT().GetPrivateLinkService(gomock.Any(), testClusterRG, testResourceName).Return(&azureclient.PrivateLinkService{
+		ID:         testServiceID,
+		Name:       testResourceName,
+		Properties: &azureclient.PrivateLinkServiceProperties{ProvisioningState: provisioningStateSucceeded},
+	}, nil)
+}
+
+func mockPrivateEndpoint(m *mockazure.MockClient, connectionStatus string) {
+	m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testResourceName).Return(&azureclient.PrivateEndpoint{
+		Name: testResourceName,
+		Properties: &azureclient.PrivateEndpointProperties{
+			ProvisioningState: provisioningStateSucceeded,
+			PrivateLinkServiceConnections: []azureclient.PrivateLinkServiceConnection{{
+				Name: testResourceName,
+				Properties: &azureclient.PrivateLinkServiceConnectionProperties{
+					PrivateLinkServiceID:              testServiceID,
+					PrivateLinkServiceConnectionState: &azureclient.PrivateLinkServiceConnectionState{Status: connectionStatus},
+				},
+			}},
+			NetworkInterfaces: []azureclient.SubResource{{ID: testEndpointNICID}},
+		},
+	}, nil)
+}
+
+func mockEndpointNIC(m *mockazure.MockClient) {
+	m.EXPECT().GetNetworkInterface(gomock.Any(), testHubResourceGroup, testEndpointNICName).Return(network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{{
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					PrivateIPAddress: to.StringPtr(testEndpointIP),
+				},
+			}},
+		},
+	}, nil)
+}
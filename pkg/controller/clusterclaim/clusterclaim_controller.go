@@ -59,7 +59,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterclaim-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -82,6 +82,16 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentRecon
 		return err
 	}
 
+	// Watch for changes to the claim lifetimes of ClusterPools
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterPool{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForClusterPool(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive-claim-owner Role
 	if err := c.Watch(
 		&source.Kind{Type: &rbacv1.Role{}},
@@ -130,6 +140,24 @@ func requestsForClusterDeployment(o handler.MapObject) []reconcile.Request {
 	return []reconcile.Request{{NamespacedName: *claim}}
 }
 
+func requestsForClusterPool(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		claims := &hivev1.ClusterClaimList{}
+		if err := c.List(context.Background(), claims, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterClaims for ClusterPool")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, claim := range claims.Items {
+			if claim.Spec.ClusterPoolName != o.Meta.GetName() || claim.Spec.Namespace == "" {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}})
+		}
+		return requests
+	}
+}
+
 func requestsForRBACResources(c client.Client, resourceName string, logger log.FieldLogger) handler.ToRequestsFunc {
 	return func(o handler.MapObject) []reconcile.Request {
 		if o.Meta.GetName() != resourceName {
@@ -200,7 +228,11 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 	logger = logger.WithField("cluster", clusterName)
 
 	// Delete ClusterClaim after its lifetime elapses
-	if lifetime := claim.Spec.Lifetime; lifetime != nil {
+	lifetime, err := r.ensureLifetime(claim, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if lifetime != nil {
 		logger.WithField("lifetime", lifetime).Debug("checking whether lifetime of ClusterClaim has elapsed")
 		pendingCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
 		if pendingCond != nil && pendingCond.Status == corev1.ConditionFalse {
@@ -216,7 +248,7 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 			}
 			defer func() {
 				result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(
-					claim.Spec.Lifetime.Duration-time.Since(pendingCond.LastTransitionTime.Time),
+					lifetime.Duration-time.Since(pendingCond.LastTransitionTime.Time),
 					result,
 					returnErr,
 				)
@@ -237,12 +269,47 @@ func (r *ReconcileClusterClaim) Reconcile(request reconcile.Request) (result rec
 	case "":
 		return r.reconcileForNewAssignment(claim, cd, logger)
 	case claim.Name:
+		if cd.Spec.ClusterPoolRef.Quarantine != nil || claimHandoffFailed(claim, cd) {
+			return r.reconcileForFailedHandoff(claim, cd, logger)
+		}
 		return r.reconcileForExistingAssignment(claim, cd, logger)
 	default:
 		return r.reconcileForAssignmentConflict(claim, logger)
 	}
 }
 
+// ensureLifetime returns the lifetime of the claim, computed from the lifetime in the spec of the claim and the claim
+// lifetimes of its pool, and records it in the status of the claim. If the pool no longer exists, the lifetime last
+// recorded is kept.
+func (r *ReconcileClusterClaim) ensureLifetime(claim *hivev1.ClusterClaim, logger log.FieldLogger) (*metav1.Duration, error) {
+	lifetime := claim.Spec.Lifetime
+	if claim.Status.Lifetime != nil {
+		lifetime = claim.Status.Lifetime
+	}
+	if poolName := claim.Spec.ClusterPoolName; poolName != "" {
+		pool := &hivev1.ClusterPool{}
+		switch err := r.Get(context.Background(), client.ObjectKey{Namespace: claim.Namespace, Name: poolName}, pool); {
+		case apierrors.IsNotFound(err):
+			logger.WithField("pool", poolName).Debug("pool of ClusterClaim not found, keeping recorded lifetime")
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterPool")
+			return nil, err
+		default:
+			lifetime = controllerutils.ClusterClaimLifetime(pool, claim)
+		}
+	}
+	if reflect.DeepEqual(lifetime, claim.Status.Lifetime) {
+		return lifetime, nil
+	}
+	logger.WithField("lifetime", lifetime).Info("updating lifetime of ClusterClaim")
+	claim.Status.Lifetime = lifetime
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update lifetime of ClusterClaim")
+		return nil, err
+	}
+	return lifetime, nil
+}
+
 func (r *ReconcileClusterClaim) reconcileDeletedClaim(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
 	if !controllerutils.HasFinalizer(claim, finalizer) {
 		return reconcile.Result{}, nil
@@ -285,23 +352,7 @@ func (r *ReconcileClusterClaim) cleanupResources(claim *hivev1.ClusterClaim, log
 		return nil
 	}
 
-	// Delete RoleBinding
-	if err := resource.DeleteAnyExistingObject(
-		r,
-		client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleBindingName},
-		&rbacv1.RoleBinding{},
-		logger,
-	); err != nil {
-		return err
-	}
-
-	// Delete Role
-	if err := resource.DeleteAnyExistingObject(
-		r,
-		client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleName},
-		&rbacv1.Role{},
-		logger,
-	); err != nil {
+	if err := r.deleteRBAC(clusterName, logger); err != nil {
 		return err
 	}
 
@@ -389,10 +440,98 @@ func (r *ReconcileClusterClaim) reconcileForAssignmentConflict(claim *hivev1.Clu
 	return reconcile.Result{}, nil
 }
 
+// claimHandoffFailed returns true if the cluster claimed by the claim failed to resume before it had run for the
+// claim.
+func claimHandoffFailed(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment) bool {
+	hibernatingCond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCond == nil || hibernatingCond.Reason != hivev1.FailedToStartHibernationReason {
+		return false
+	}
+	// The cluster has run for the claim if it has stopped hibernating since it was claimed.
+	pendingCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+	if pendingCond != nil && pendingCond.Status == corev1.ConditionFalse &&
+		hibernatingCond.LastTransitionTime.After(pendingCond.LastTransitionTime.Time) {
+		return false
+	}
+	return true
+}
+
+// reconcileForFailedHandoff quarantines a claimed cluster that could not be resumed for the claim, and unassigns the
+// cluster from the claim so that the pool assigns it a different cluster.
+func (r *ReconcileClusterClaim) reconcileForFailedHandoff(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	if cd.Spec.ClusterPoolRef.Quarantine == nil {
+		message := "Cluster could not be resumed for the claim"
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition); cond != nil {
+			message = cond.Message
+		}
+		logger.WithField("message", message).Warn("quarantining claimed cluster that could not be resumed")
+		cd.Spec.ClusterPoolRef.Quarantine = &hivev1.ClusterPoolQuarantine{
+			Reason:  hivev1.ClusterPoolQuarantineClaimHandoffFailed,
+			Message: message,
+			Time:    metav1.Now(),
+		}
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not quarantine ClusterDeployment")
+			return reconcile.Result{}, err
+		}
+		hivemetrics.MetricClusterPoolClustersQuarantinedTotal.WithLabelValues(
+			cd.Spec.ClusterPoolRef.Namespace,
+			cd.Spec.ClusterPoolRef.PoolName,
+			string(hivev1.ClusterPoolQuarantineClaimHandoffFailed),
+		).Inc()
+	}
+
+	if err := r.deleteRBAC(cd.Namespace, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	logger.Info("unassigning quarantined cluster from claim")
+	claim.Spec.Namespace = ""
+	if err := r.Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not unassign cluster from ClusterClaim")
+		return reconcile.Result{}, err
+	}
+	claim.Status.Conditions = controllerutils.SetClusterClaimCondition(
+		claim.Status.Conditions,
+		hivev1.ClusterClaimPendingCondition,
+		corev1.ConditionTrue,
+		"AssignedClusterQuarantined",
+		"Assigned cluster was quarantined because it could not be resumed",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileClusterClaim) deleteRBAC(clusterName string, logger log.FieldLogger) error {
+	// Delete RoleBinding
+	if err := resource.DeleteAnyExistingObject(
+		r,
+		client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleBindingName},
+		&rbacv1.RoleBinding{},
+		logger,
+	); err != nil {
+		return err
+	}
+
+	// Delete Role
+	return resource.DeleteAnyExistingObject(
+		r,
+		client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleName},
+		&rbacv1.Role{},
+		logger,
+	)
+}
+
+// createRBAC grants the subjects of the claim access to the claimed cluster. When the claim has no subjects, any
+// access granted to subjects previously listed in the claim is revoked.
 func (r *ReconcileClusterClaim) createRBAC(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
 	if len(claim.Spec.Subjects) == 0 {
 		logger.Debug("not creating RBAC since claim does not specify any subjects")
-		return nil
+		return r.deleteRBAC(cd.Namespace, logger)
 	}
 	if cd.Spec.ClusterMetadata == nil {
 		return errors.New("ClusterDeployment does not have ClusterMetadata")
@@ -21,6 +21,7 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 )
 
@@ -78,6 +79,8 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
+		expectQuarantined                      bool
+		expectedLifetime                       *metav1.Duration
 	}{
 		{
 			name:                 "new assignment",
@@ -215,6 +218,13 @@ func TestReconcileClusterClaim(t *testing.T) {
 			cd:                   cdBuilder.Build(testcd.WithUnclaimedClusterPoolReference(claimNamespace, "test-pool")),
 			expectCompletedClaim: true,
 		},
+		{
+			name:                 "RBAC removed when subjects are removed",
+			claim:                claimBuilder.Build(testclaim.WithCluster(clusterName), testclaim.WithSubjects(nil)),
+			cd:                   cdBuilder.Build(testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName)),
+			existing:             []runtime.Object{testRole(), testRoleBinding()},
+			expectCompletedClaim: true,
+		},
 		{
 			name:  "update existing role",
 			claim: claimBuilder.Build(testclaim.WithCluster(clusterName)),
@@ -308,6 +318,135 @@ func TestReconcileClusterClaim(t *testing.T) {
 			expectRBAC:           true,
 			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
 		},
+		{
+			name: "claim with elapsed lifetime in status is deleted",
+			claim: claimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(3*time.Hour),
+				testclaim.WithStatusLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd:            cdBuilder.Build(testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName)),
+			expectDeleted: true,
+		},
+		{
+			name: "claim with lifetime reduced by pool maximum is deleted",
+			claim: claimBuilder.Build(
+				testclaim.WithPool("test-pool"),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(3*time.Hour),
+				testclaim.WithStatusLifetime(3*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName)),
+			existing: []runtime.Object{
+				testcp.FullBuilder(claimNamespace, "test-pool", scheme).Build(testcp.WithMaximumClaimLifetime(1 * time.Hour)),
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "claim lifetime follows pool default",
+			claim: claimBuilder.Build(
+				testclaim.WithPool("test-pool"),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName)),
+			existing: []runtime.Object{
+				testcp.FullBuilder(claimNamespace, "test-pool", scheme).Build(testcp.WithDefaultClaimLifetime(3 * time.Hour)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{{
+				Type:    hivev1.ClusterClaimPendingCondition,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ClusterClaimed",
+				Message: "Cluster claimed",
+			}},
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
+			expectedLifetime:     &metav1.Duration{Duration: 3 * time.Hour},
+		},
+		{
+			name: "claimed cluster that failed to resume is quarantined",
+			claim: claimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.ClusterHibernatingCondition,
+					Status:             corev1.ConditionTrue,
+					Reason:             hivev1.FailedToStartHibernationReason,
+					Message:            "Failed to start machines: quota exceeded",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				}),
+			),
+			existing: []runtime.Object{
+				testRole(),
+				testRoleBinding(),
+			},
+			expectNoAssignment: true,
+			expectQuarantined:  true,
+			expectedConditions: []hivev1.ClusterClaimCondition{{
+				Type:    hivev1.ClusterClaimPendingCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  "AssignedClusterQuarantined",
+				Message: "Assigned cluster was quarantined because it could not be resumed",
+			}},
+		},
+		{
+			name: "claimed cluster that failed to resume after running is not quarantined",
+			claim: claimBuilder.Build(
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.ClusterHibernatingCondition,
+					Status:             corev1.ConditionTrue,
+					Reason:             hivev1.FailedToStartHibernationReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+				}),
+			),
+			expectCompletedClaim: true,
+			expectedConditions: []hivev1.ClusterClaimCondition{{
+				Type:    hivev1.ClusterClaimPendingCondition,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ClusterClaimed",
+				Message: "Cluster claimed",
+			}},
+			expectRBAC: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -359,9 +498,16 @@ func TestReconcileClusterClaim(t *testing.T) {
 			assignedClusterDeploymentExists := false
 			for _, cd := range cds.Items {
 				isAssignedCD := cd.Name == claim.Spec.Namespace
+				if test.expectQuarantined {
+					if assert.NotNil(t, cd.Spec.ClusterPoolRef.Quarantine, "expected ClusterDeployment to be quarantined") {
+						assert.Equal(t, hivev1.ClusterPoolQuarantineClaimHandoffFailed, cd.Spec.ClusterPoolRef.Quarantine.Reason, "unexpected quarantine reason")
+					}
+				} else {
+					assert.Nil(t, cd.Spec.ClusterPoolRef.Quarantine, "expected ClusterDeployment to not be quarantined")
+				}
 				if isAssignedCD && test.expectCompletedClaim {
 					assert.Equal(t, claimName, cd.Spec.ClusterPoolRef.ClaimName, "expected ClusterDeployment to be claimed by ClusterClaim")
-				} else {
+				} else if !test.expectQuarantined {
 					assert.NotEqual(t, claimName, cd.Spec.ClusterPoolRef.ClaimName, "expected ClusterDeployment to not be claimed by ClusterClaim")
 				}
 				if isAssignedCD {
@@ -389,6 +535,9 @@ func TestReconcileClusterClaim(t *testing.T) {
 				cond.LastProbeTime = metav1.Time{}
 			}
 			assert.ElementsMatch(t, test.expectedConditions, claim.Status.Conditions, "unexpected conditions")
+			if test.expectedLifetime != nil {
+				assert.Equal(t, test.expectedLifetime, claim.Status.Lifetime, "unexpected lifetime")
+			}
 
 			if test.expectNoFinalizer {
 				assert.NotContains(t, claim.Finalizers, finalizer, "expected no finalizer on claim")
@@ -7,7 +7,6 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/util/flowcontrol"
@@ -40,11 +40,14 @@ import (
 	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 	"github.com/openshift/hive/pkg/imageset"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -60,6 +63,8 @@ const (
 	clusterImageSetNotFoundReason = "ClusterImageSetNotFound"
 	clusterImageSetFoundReason    = "ClusterImageSetFound"
 
+	imagesOverriddenReason = "ImagesOverridden"
+
 	dnsNotReadyReason  = "DNSNotReady"
 	dnsReadyReason     = "DNSReady"
 	dnsReadyAnnotation = "hive.openshift.io/dnsready"
@@ -67,14 +72,17 @@ const (
 	deleteAfterAnnotation    = "hive.openshift.io/delete-after" // contains a duration after which the cluster should be cleaned up.
 	tryInstallOnceAnnotation = "hive.openshift.io/try-install-once"
 
-	platformAWS       = "aws"
-	platformAzure     = "azure"
-	platformGCP       = "gcp"
-	platformOpenStack = "openstack"
-	platformVSphere   = "vsphere"
-	platformBaremetal = "baremetal"
-	platformUnknown   = "unknown"
-	regionUnknown     = "unknown"
+	platformAWS            = "aws"
+	platformAzure          = "azure"
+	platformGCP            = "gcp"
+	platformIBMCloud       = "ibmcloud"
+	platformNutanix        = "nutanix"
+	platformOpenStack      = "openstack"
+	platformVSphere        = "vsphere"
+	platformBaremetal      = "baremetal"
+	platformAgentBaremetal = "agent-baremetal"
+	platformUnknown        = "unknown"
+	regionUnknown          = "unknown"
 )
 
 var (
@@ -174,6 +182,29 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	if namespacePerCluster, err := strconv.ParseBool(os.Getenv(constants.NamespacePerClusterEnvVar)); namespacePerCluster && err == nil {
+		// Without the time the mode was enabled, any namespace could be reaped, so no namespace is labelled.
+		if enabledTime, err := time.Parse(time.RFC3339, os.Getenv(constants.NamespacePerClusterEnabledTimeEnvVar)); err != nil {
+			logger.WithError(err).Error("could not parse the time namespace per cluster was enabled, namespaces will not be labelled")
+		} else {
+			logger.WithField("enabledTime", enabledTime).Info("Namespace per cluster enabled")
+			r.namespacePerCluster = true
+			r.namespacePerClusterEnabledTime = enabledTime
+		}
+	}
+
+	externalDestroyers, err := controllerutils.GetExternalDestroyers()
+	if err != nil {
+		logger.WithError(err).Error("could not get external destroyers")
+	}
+	r.externalDestroyers = externalDestroyers
+
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
+	r.managedDomains = managedDomains
+
 	return r
 }
 
@@ -185,7 +216,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 	}
 
 	c, err := controller.New("clusterdeployment-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -272,13 +303,27 @@ type ReconcileClusterDeployment struct {
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
 	protectedDelete bool
+
+	// namespacePerCluster is true when every ClusterDeployment lives in a namespace of its own, which is labelled so
+	// that it is reaped once the ClusterDeployment has been deleted
+	namespacePerCluster bool
+
+	// namespacePerClusterEnabledTime is when namespace-per-cluster was enabled. Namespaces created before then are
+	// never labelled, since they may hold resources that predate their ClusterDeployment
+	namespacePerClusterEnabledTime time.Time
+
+	// externalDestroyers are the destroyers configured in HiveConfig for deprovisioning clusters on platforms that
+	// Hive cannot deprovision itself
+	externalDestroyers []hivev1.ExternalDestroyer
+
+	// managedDomains are the managed domains configured in HiveConfig, each with the cloud and credentials hosting it
+	managedDomains []hivev1.ManageDNSConfig
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
 // and what is in the ClusterDeployment.Spec
 //
 // Automatically generate RBAC rules to allow the Controller to read and write Deployments
-//
 func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (result reconcile.Result, returnErr error) {
 	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
 	cdLog.Info("reconciling cluster deployment")
@@ -301,6 +346,29 @@ func (r *ReconcileClusterDeployment) Reconcile(request reconcile.Request) (resul
 		return reconcile.Result{}, err
 	}
 
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	// Initialize the conditions so that every condition is present, even before its controller has set it
+	if conds, changed := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, hivev1.AllClusterDeploymentConditions); changed {
+		cdLog.Info("initializing cluster deployment conditions")
+		cd.Status.Conditions = conds
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to initialize cluster deployment conditions")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.setPausedCondition(cd, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	if controllerutils.IsReconcilePaused(cd) {
+		cdLog.WithField("annotation", constants.ReconcilePauseAnnotation).Warn("reconciling cluster is paused by annotation")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsDetached(cd) {
+		return r.reconcileDetached(cd, cdLog)
+	}
+
 	// Ensure owner references are correctly set
 	err = controllerutils.ReconcileOwnerReferences(cd, generateOwnershipUniqueKeys(cd), r, r.scheme, r.logger)
 	if err != nil {
@@ -434,7 +502,8 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 	}
 
 	// Set region label on the ClusterDeployment
-	if region := getClusterRegion(cd); cd.Spec.Platform.BareMetal == nil && cd.Labels[hivev1.HiveClusterRegionLabel] != region {
+	if region := getClusterRegion(cd); cd.Spec.Platform.BareMetal == nil && cd.Spec.Platform.AgentBareMetal == nil &&
+		cd.Labels[hivev1.HiveClusterRegionLabel] != region {
 		if cd.Labels == nil {
 			cd.Labels = make(map[string]string)
 		}
@@ -450,6 +519,17 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, err
 	}
 
+	// Remove the debug mode annotation once debug mode has ended
+	if until, ok := controllerutils.DebugModeUntil(cd); ok && time.Now().After(until) {
+		cdLog.WithField("debugModeUntil", until).Info("debug mode has ended, removing annotation")
+		delete(cd.Annotations, constants.DebugModeUntilAnnotation)
+		err := r.Update(context.TODO(), cd)
+		if err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to remove debug mode annotation")
+		}
+		return reconcile.Result{}, err
+	}
+
 	if cd.DeletionTimestamp != nil {
 		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
 			// Make sure we have no deprovision underway metric even though this was probably cleared when we
@@ -513,6 +593,12 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, nil
 	}
 
+	if r.namespacePerCluster && cd.Name == cd.Namespace {
+		if err := r.ensureClusterNamespaceLabel(cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if cd.Spec.Installed {
 		// set installedTimestamp for adopted clusters
 		if cd.Status.InstalledTimestamp == nil {
@@ -578,6 +664,11 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return *result, err
 	}
 
+	// Clusters installed by a ClusterInstall are not provisioned by Hive.
+	if cd.Spec.ClusterInstallRef != nil {
+		return r.reconcileClusterInstall(cd, cdLog)
+	}
+
 	imageSet, err := r.getClusterImageSet(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -603,6 +694,14 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	switch updated, err := r.updatePullThroughCachePullSecret(cd, cdLog); {
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "Error updating the pull-through cache pull secret")
+		return reconcile.Result{}, err
+	case updated:
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	switch result, err := r.resolveInstallerImage(cd, imageSet, releaseImage, cdLog); {
 	case err != nil:
 		return reconcile.Result{}, err
@@ -635,7 +734,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			}
 			return reconcile.Result{}, nil
 		}
-		if cd.Spec.InstallAttemptsLimit != nil && cd.Status.InstallRestarts >= int(*cd.Spec.InstallAttemptsLimit) {
+		if limit := installAttemptsLimit(cd); limit != nil && cd.Status.InstallRestarts >= int(*limit) {
 			cdLog.Debug("not creating new provision since the install attempts limit has been reached")
 			conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 				cd.Status.Conditions,
@@ -689,7 +788,13 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 
 	for _, provision := range existingProvisions {
-		if provision.Spec.Stage != hivev1.ClusterProvisionStageFailed {
+		switch provision.Spec.Stage {
+		case hivev1.ClusterProvisionStageFailed:
+		case hivev1.ClusterProvisionStageDryRunComplete:
+			if isDryRun(cd) {
+				return reconcile.Result{}, r.adoptProvision(cd, provision, cdLog)
+			}
+		default:
 			return reconcile.Result{}, r.adoptProvision(cd, provision, cdLog)
 		}
 	}
@@ -732,6 +837,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	}
 
 	extraEnvVars := getInstallLogEnvVars(cd.Name)
+	extraEnvVars = addEnvVarIfFound(constants.ProvisionHooksEnvVar, extraEnvVars)
 
 	podSpec, err := install.InstallerPodSpec(
 		cd,
@@ -746,6 +852,15 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		cdLog.WithError(err).Error("could not generate installer pod spec")
 		return reconcile.Result{}, err
 	}
+	if err := controllerutils.ApplyJobScheduling(podSpec); err != nil {
+		cdLog.WithError(err).Error("could not apply job scheduling to installer pod spec")
+		return reconcile.Result{}, err
+	}
+	controllerutils.ApplyProvisionPriorityClass(podSpec)
+	if err := controllerutils.ApplyPullThroughCache(podSpec, constants.GetPullThroughCachePullSecretName(cd)); err != nil {
+		cdLog.WithError(err).Error("could not apply pull-through cache to installer pod spec")
+		return reconcile.Result{}, err
+	}
 
 	provision := &hivev1.ClusterProvision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -760,8 +875,12 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 			PodSpec: *podSpec,
 			Attempt: cd.Status.InstallRestarts,
 			Stage:   hivev1.ClusterProvisionStageInitializing,
+			DryRun:  isDryRun(cd),
 		},
 	}
+	if cd.Spec.Provisioning != nil {
+		provision.Spec.Timeout = cd.Spec.Provisioning.Timeout
+	}
 
 	// Copy over the cluster ID and infra ID from previous provision so that a failed install can be removed.
 	if cd.Spec.ClusterMetadata != nil {
@@ -844,23 +963,35 @@ func getInstallLogEnvVars(secretPrefix string) []corev1.EnvVar {
 		Value: cloudProvider,
 	})
 
-	if cloudProvider == constants.InstallLogsUploadProviderAWS {
-		secretName, foundSrc := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
-		if foundSrc {
-			extraEnvVars = append(extraEnvVars, corev1.EnvVar{
-				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-				Value: secretPrefix + "-" + secretName,
-			})
-		}
+	secretName, foundSrc := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if foundSrc {
+		extraEnvVars = append(extraEnvVars, corev1.EnvVar{
+			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+			Value: secretPrefix + "-" + secretName,
+		})
+	}
 
+	switch cloudProvider {
+	case constants.InstallLogsUploadProviderAWS:
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSRegionEnvVar, extraEnvVars)
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSServiceEndpointEnvVar, extraEnvVars)
 		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAWSS3BucketEnvVar, extraEnvVars)
+	case constants.InstallLogsUploadProviderGCP:
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsGCPBucketEnvVar, extraEnvVars)
+	case constants.InstallLogsUploadProviderAzure:
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAzureStorageAccountEnvVar, extraEnvVars)
+		extraEnvVars = addEnvVarIfFound(constants.InstallLogsAzureContainerEnvVar, extraEnvVars)
 	}
 
 	return extraEnvVars
 }
 
+// isDryRun returns true if provisioning of the ClusterDeployment should stop after the install-config and
+// manifests have been rendered.
+func isDryRun(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Provisioning != nil && cd.Spec.Provisioning.DryRun
+}
+
 func addEnvVarIfFound(name string, envVars []corev1.EnvVar) []corev1.EnvVar {
 	value, found := os.LookupEnv(name)
 	if !found {
@@ -923,6 +1054,8 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 		return r.reconcileFailedProvision(cd, provision, cdLog)
 	case hivev1.ClusterProvisionStageComplete:
 		return r.reconcileCompletedProvision(cd, provision, cdLog)
+	case hivev1.ClusterProvisionStageDryRunComplete:
+		return r.reconcileDryRunCompleteProvision(cd, provision, cdLog)
 	default:
 		cdLog.WithField("stage", provision.Spec.Stage).Error("unknown provision stage")
 		return reconcile.Result{}, errors.New("unknown provision stage")
@@ -955,14 +1088,46 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	nextProvisionTime := time.Now()
 	reason := "MissingCondition"
 
+	var retryPolicy *hivev1.InstallRetryPolicy
+	if cd.Spec.Provisioning != nil {
+		retryPolicy = cd.Spec.Provisioning.RetryPolicy
+	}
+
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
-		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
+		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, retryPolicy, cdLog)
 		reason = failedCond.Reason
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
 
+	if failureClass := classifyInstallFailure(errorclass.Class(provision.Status.ErrorClass)); !shouldRetryInstall(failureClass, retryPolicy) {
+		cdLog.WithField("reason", reason).WithField("errorClass", provision.Status.ErrorClass).WithField("failureClass", failureClass).Info("not retrying provision since the retry policy does not retry this class of failure")
+		conditions, failedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionFailedCondition,
+			corev1.ConditionTrue,
+			reason,
+			fmt.Sprintf("Provision %s failed. %s failures are not retried.", provision.Name, failureClass),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		conditions, stoppedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			hivev1.ProvisionStoppedCondition,
+			corev1.ConditionTrue,
+			"FailureNotRetried",
+			fmt.Sprintf("Install failed with reason %s, which is classified as a %s failure and is not retried by the retry policy", reason, failureClass),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if failedChanged || stoppedChanged {
+			cd.Status.Conditions = conditions
+			if err := r.statusUpdate(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
 	newConditions, condChange := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionFailedCondition,
@@ -988,6 +1153,43 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	return r.clearOutCurrentProvision(cd, cdLog)
 }
 
+func (r *ReconcileClusterDeployment) reconcileDryRunCompleteProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
+	if isDryRun(cd) {
+		cdLog.Debug("dry run completed")
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.DryRunCompleteCondition,
+			corev1.ConditionTrue,
+			"DryRunComplete",
+			fmt.Sprintf("The install-config and manifests rendered by provision %s are in ConfigMap %s", provision.Name, provision.Spec.DryRunConfigMapRef.Name),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			cd.Status.Conditions = conditions
+			return reconcile.Result{}, r.statusUpdate(cd, cdLog)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// The dry run has been turned off. Clear out the dry-run provision to make way for a real provision. This is
+	// not counted as an install restart.
+	cdLog.Info("clearing current dry-run provision to make way for a new provision")
+	cd.Status.ProvisionRef = nil
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.DryRunCompleteCondition,
+		corev1.ConditionFalse,
+		"DryRunDisabled",
+		"Dry run has been turned off",
+		controllerutils.UpdateConditionNever,
+	)
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not clear out current dry-run provision")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
 	cdLog.Info("provision completed successfully")
 
@@ -1173,6 +1375,23 @@ func (r *ReconcileClusterDeployment) statusUpdate(cd *hivev1.ClusterDeployment,
 	return err
 }
 
+// imageOverrides returns the installer and cli images that override those of the release image. The overrides of
+// the ClusterDeployment take precedence over those of the ClusterImageSet, which only apply when the release image
+// comes from the ClusterImageSet.
+func imageOverrides(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet) (string, string) {
+	installerImage := cd.Spec.Provisioning.InstallerImageOverride
+	cliImage := cd.Spec.Provisioning.CLIImageOverride
+	if imageSet != nil && cd.Spec.Provisioning.ReleaseImage == "" {
+		if installerImage == "" {
+			installerImage = imageSet.Spec.InstallerImageOverride
+		}
+		if cliImage == "" {
+			cliImage = imageSet.Spec.CLIImageOverride
+		}
+	}
+	return installerImage, cliImage
+}
+
 func (r *ReconcileClusterDeployment) resolveInstallerImage(cd *hivev1.ClusterDeployment, imageSet *hivev1.ClusterImageSet, releaseImage string, cdLog log.FieldLogger) (*reconcile.Result, error) {
 	areImagesResolved := cd.Status.InstallerImage != nil && cd.Status.CLIImage != nil
 
@@ -1187,7 +1406,37 @@ func (r *ReconcileClusterDeployment) resolveInstallerImage(cd *hivev1.ClusterDep
 			return nil, nil
 		}
 
-		job := imageset.GenerateImageSetJob(cd, releaseImage, controllerutils.ServiceAccountName)
+		installerImageOverride, cliImageOverride := imageOverrides(cd, imageSet)
+		// With both images overridden there is nothing to extract from the release image, so skip the job.
+		if installerImageOverride != "" && cliImageOverride != "" {
+			cdLog.WithFields(log.Fields{
+				"installerImage": installerImageOverride,
+				"cliImage":       cliImageOverride,
+			}).Info("using overridden installer and cli images")
+			cd.Status.InstallerImage = &installerImageOverride
+			cd.Status.CLIImage = &cliImageOverride
+			cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+				cd.Status.Conditions,
+				hivev1.InstallerImageResolutionFailedCondition,
+				corev1.ConditionFalse,
+				imagesOverriddenReason,
+				"The installer and cli images are overridden.",
+				controllerutils.UpdateConditionNever)
+			if err := r.statusUpdate(cd, cdLog); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+
+		job := imageset.GenerateImageSetJob(cd, releaseImage, installerImageOverride, cliImageOverride, controllerutils.ServiceAccountName)
+		if err := controllerutils.ApplyJobScheduling(&job.Spec.Template.Spec); err != nil {
+			cdLog.WithError(err).Error("could not apply job scheduling to imageset job")
+			return nil, err
+		}
+		if err := controllerutils.ApplyPullThroughCache(&job.Spec.Template.Spec, constants.GetPullThroughCachePullSecretName(cd)); err != nil {
+			cdLog.WithError(err).Error("could not apply pull-through cache to imageset job")
+			return nil, err
+		}
 
 		cdLog.WithField("derivedObject", job.Name).Debug("Setting labels on derived object")
 		job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
@@ -1328,6 +1577,148 @@ func (r *ReconcileClusterDeployment) setImageSetNotFoundCondition(cd *hivev1.Clu
 	return err
 }
 
+// setPausedCondition sets the Paused condition to reflect whether reconciliation of the cluster deployment is paused.
+func (r *ReconcileClusterDeployment) setPausedCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := "ReconcileNotPaused"
+	message := "Reconciliation is not paused"
+	if controllerutils.IsReconcilePaused(cd) {
+		status = corev1.ConditionTrue
+		reason = "ReconcilePaused"
+		message = fmt.Sprintf("Reconciliation is paused by the %s annotation", constants.ReconcilePauseAnnotation)
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PausedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionNever)
+	if !changed {
+		return nil
+	}
+	cdLog.Infof("setting PausedCondition to %v", status)
+	cd.Status.Conditions = conds
+	err := r.Status().Update(context.TODO(), cd)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update status conditions")
+	}
+	return err
+}
+
+// reconcileDetached stops Hive from managing a detached cluster. The deprovision finalizer is removed and the managed
+// DNSZone is handed off so that deleting the ClusterDeployment leaves the cluster and its DNS in place, and the admin
+// secrets are handed off to the user if requested.
+func (r *ReconcileClusterDeployment) reconcileDetached(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+	cdLog.Debug("cluster is detached")
+	if cd.Spec.Detach.PreserveSecrets && cd.Spec.ClusterMetadata != nil {
+		for _, name := range []string{
+			cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name,
+			cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name,
+		} {
+			if err := r.handOffSecret(cd, name, cdLog); err != nil {
+				cdLog.WithError(err).WithField("secret", name).Log(controllerutils.LogLevel(err), "could not hand off secret")
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if cd.Spec.ManageDNS {
+		if err := r.handOffDNSZone(cd, cdLog); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not hand off dnszone")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
+		cdLog.Info("removing deprovision finalizer from detached cluster")
+		controllerutils.DeleteFinalizer(cd, hivev1.FinalizerDeprovision)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not remove deprovision finalizer")
+			return reconcile.Result{}, err
+		}
+	}
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.DetachedCondition,
+		corev1.ConditionTrue,
+		"ClusterDetached",
+		"Cluster has been detached from Hive",
+		controllerutils.UpdateConditionNever)
+	if !changed {
+		return reconcile.Result{}, nil
+	}
+	cdLog.Info("cluster has been detached")
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not set detached condition")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// handOffSecret removes the ClusterDeployment and the other Hive objects of the cluster from the owners of the
+// secret, so that the secret is not garbage collected when the ClusterDeployment is deleted.
+func (r *ReconcileClusterDeployment) handOffSecret(cd *hivev1.ClusterDeployment, name string, cdLog log.FieldLogger) error {
+	if name == "" {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, secret); {
+	case apierrors.IsNotFound(err):
+		cdLog.WithField("secret", name).Warn("secret to hand off not found")
+		return nil
+	case err != nil:
+		return err
+	}
+	owners := withoutHiveOwners(secret.OwnerReferences)
+	if len(owners) == len(secret.OwnerReferences) {
+		return nil
+	}
+	cdLog.WithField("secret", name).Info("handing off secret of detached cluster")
+	secret.OwnerReferences = owners
+	return r.Update(context.TODO(), secret)
+}
+
+// handOffDNSZone removes the ClusterDeployment from the owners of the managed DNSZone of the cluster, so that the
+// DNSZone, and with it the hosted zone the cluster still relies on, is not deleted with the ClusterDeployment.
+func (r *ReconcileClusterDeployment) handOffDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	if !dnsZone.DeletionTimestamp.IsZero() {
+		cdLog.Warn("managed dnszone of detached cluster is already being deleted")
+		return nil
+	}
+	owners := withoutHiveOwners(dnsZone.OwnerReferences)
+	if len(owners) == len(dnsZone.OwnerReferences) {
+		return nil
+	}
+	cdLog.WithField("dnszone", dnsZone.Name).Info("handing off dnszone of detached cluster")
+	dnsZone.OwnerReferences = owners
+	return r.Update(context.TODO(), dnsZone)
+}
+
+// withoutHiveOwners returns the owner references that do not refer to Hive objects.
+func withoutHiveOwners(ownerRefs []metav1.OwnerReference) []metav1.OwnerReference {
+	var owners []metav1.OwnerReference
+	for _, owner := range ownerRefs {
+		if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil && gv.Group == hivev1.SchemeGroupVersion.Group {
+			continue
+		}
+		owners = append(owners, owner)
+	}
+	return owners
+}
+
 // setClusterStatusURLs fetches the openshift console route from the remote cluster and uses it to determine
 // the correct APIURL and WebConsoleURL, and then set them in the Status. Typically only called if these Status fields
 // are unset.
@@ -1415,11 +1806,16 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 		return true, nil
 	}
 
-	// We do not yet support deprovision for BareMetal, for now skip deprovision and remove finalizer.
-	if cd.Spec.Platform.BareMetal != nil {
+	// We do not yet support deprovision for BareMetal, for now skip deprovision and remove finalizer unless an
+	// external destroyer has been configured for it.
+	if cd.Spec.Platform.BareMetal != nil && !r.hasExternalDestroyer(platformBaremetal) {
 		cdLog.Info("skipping deprovision for BareMetal cluster, removing finalizer")
 		return true, nil
 	}
+	if cd.Spec.Platform.AgentBareMetal != nil && !r.hasExternalDestroyer(platformAgentBaremetal) {
+		cdLog.Info("skipping deprovision for AgentBareMetal cluster, removing finalizer")
+		return true, nil
+	}
 
 	// Generate a deprovision request
 	request, err := generateDeprovision(cd)
@@ -1570,6 +1966,34 @@ func (r *ReconcileClusterDeployment) stopProvisioning(cd *hivev1.ClusterDeployme
 	}
 }
 
+// ensureClusterNamespaceLabel labels the namespace of the ClusterDeployment as owned by the ClusterDeployment, so that
+// the namespace is reaped once the ClusterDeployment has been deleted. Only namespaces created after
+// namespace-per-cluster was enabled are labelled.
+func (r *ReconcileClusterDeployment) ensureClusterNamespaceLabel(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: cd.Namespace}, ns); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting namespace")
+		return err
+	}
+	if ns.Labels[constants.ClusterNamespaceLabel] == cd.Name {
+		return nil
+	}
+	if ns.CreationTimestamp.Time.Before(r.namespacePerClusterEnabledTime) {
+		cdLog.Debug("not labelling namespace created before namespace per cluster was enabled")
+		return nil
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[constants.ClusterNamespaceLabel] = cd.Name
+	cdLog.Info("labelling namespace as owned by the clusterdeployment")
+	if err := r.Update(context.TODO(), ns); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error labelling namespace")
+		return err
+	}
+	return nil
+}
+
 func (r *ReconcileClusterDeployment) addClusterDeploymentFinalizer(cd *hivev1.ClusterDeployment) error {
 	cd = cd.DeepCopy()
 	controllerutils.AddFinalizer(cd, hivev1.FinalizerDeprovision)
@@ -1661,6 +2085,10 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 		return nil, errors.New("Existing unowned DNS zone")
 	}
 
+	if err := r.ensureManagedDNSZoneCredentials(cd, dnsZone, logger); err != nil {
+		return nil, err
+	}
+
 	availableCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 	insufficientCredentialsCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.InsufficientCredentialsCondition)
 	authenticationFailureCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.AuthenticationFailureCondition)
@@ -1715,14 +2143,16 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		for k, v := range cd.Spec.Platform.AWS.UserTags {
 			additionalTags = append(additionalTags, hivev1.AWSResourceTag{Key: k, Value: v})
 		}
-		region := ""
-		if strings.HasPrefix(cd.Spec.Platform.AWS.Region, constants.AWSChinaRegionPrefix) {
-			region = constants.AWSChinaRoute53Region
+		// Route53 operations outside of the standard partition have to go to the Route53 region of the partition.
+		region := awsclient.Route53Region(cd.Spec.Platform.AWS.Region)
+		if region == constants.AWSRoute53Region {
+			region = ""
 		}
 		dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
 			CredentialsSecretRef: cd.Spec.Platform.AWS.CredentialsSecretRef,
 			AdditionalTags:       additionalTags,
 			Region:               region,
+			ServiceEndpoints:     cd.Spec.Platform.AWS.ServiceEndpoints,
 		}
 	case cd.Spec.Platform.GCP != nil:
 		dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
@@ -1735,6 +2165,28 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		}
 	}
 
+	if md := r.crossCloudManagedDomain(cd); md != nil {
+		logger.WithField("domains", md.Domains).Info("creating DNSZone on the cloud of its managed domain")
+		credentialsSecretRef := corev1.LocalObjectReference{Name: managedDNSZoneCredentialsSecretName(cd.Name)}
+		dnsZone.Spec.AWS, dnsZone.Spec.GCP, dnsZone.Spec.Azure = nil, nil, nil
+		switch {
+		case md.AWS != nil:
+			dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+				Region:               md.AWS.Region,
+			}
+		case md.GCP != nil:
+			dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+			}
+		case md.Azure != nil:
+			dnsZone.Spec.Azure = &hivev1.AzureDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+				ResourceGroupName:    md.Azure.ResourceGroupName,
+			}
+		}
+	}
+
 	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
 	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
 	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.DNSZoneTypeLabel, constants.DNSZoneTypeChild)
@@ -1749,6 +2201,91 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		return err
 	}
 	logger.Info("dns zone created")
+	return r.ensureManagedDNSZoneCredentials(cd, dnsZone, logger)
+}
+
+// crossCloudManagedDomain returns the managed domain containing the base domain of the cluster when it is hosted on a
+// different cloud than the cluster, or nil otherwise. The DNSZone of the cluster is then created on the cloud of the
+// managed domain with its credentials, since the credentials of the cluster are for another cloud.
+func (r *ReconcileClusterDeployment) crossCloudManagedDomain(cd *hivev1.ClusterDeployment) *hivev1.ManageDNSConfig {
+	md := manageddns.MatchManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
+	if md == nil {
+		return nil
+	}
+	switch p := cd.Spec.Platform; {
+	case md.AWS != nil && p.AWS == nil:
+	case md.GCP != nil && p.GCP == nil:
+	case md.Azure != nil && p.Azure == nil:
+	default:
+		return nil
+	}
+	return md
+}
+
+// managedDNSZoneCredentialsSecretName returns the name of the copy of the credentials of a managed domain used by the
+// DNSZone of a cluster on another cloud.
+func managedDNSZoneCredentialsSecretName(cdName string) string {
+	return controllerutils.DNSZoneName(cdName) + "-creds"
+}
+
+// ensureManagedDNSZoneCredentials keeps a copy of the credentials of the managed domain in the namespace of the DNSZone
+// when the DNSZone uses them. The copy is owned by the DNSZone so that it remains until the zone has been deleted.
+func (r *ReconcileClusterDeployment) ensureManagedDNSZoneCredentials(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	md := r.crossCloudManagedDomain(cd)
+	if md == nil {
+		return nil
+	}
+	var srcName string
+	switch {
+	case md.AWS != nil:
+		srcName = md.AWS.CredentialsSecretRef.Name
+	case md.GCP != nil:
+		srcName = md.GCP.CredentialsSecretRef.Name
+	case md.Azure != nil:
+		srcName = md.Azure.CredentialsSecretRef.Name
+	}
+	src := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: srcName}, src); err != nil {
+		logger.WithError(err).WithField("secret", srcName).Log(controllerutils.LogLevel(err), "could not get managed domain credentials")
+		return err
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Namespace: dnsZone.Namespace, Name: managedDNSZoneCredentialsSecretName(cd.Name)}
+	switch err := r.Get(context.TODO(), secretName, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName.Name,
+				Namespace: secretName.Namespace,
+				Labels:    map[string]string{constants.ClusterDeploymentNameLabel: cd.Name},
+			},
+			Type: src.Type,
+			Data: src.Data,
+		}
+		if err := controllerutil.SetControllerReference(dnsZone, secret, r.scheme); err != nil {
+			logger.WithError(err).Error("error setting controller reference on managed domain credentials")
+			return err
+		}
+		logger.WithField("secret", secretName.Name).Info("copying managed domain credentials for dns zone")
+		if err := r.Create(context.TODO(), secret); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create managed domain credentials")
+			return err
+		}
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get managed domain credentials for dns zone")
+		return err
+	}
+	if reflect.DeepEqual(secret.Data, src.Data) {
+		return nil
+	}
+	logger.WithField("secret", secretName.Name).Info("updating managed domain credentials for dns zone")
+	secret.Data = src.Data
+	if err := r.Update(context.TODO(), secret); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update managed domain credentials")
+		return err
+	}
 	return nil
 }
 
@@ -1820,6 +2357,16 @@ func (r *ReconcileClusterDeployment) cleanupInstallLogPVC(cd *hivev1.ClusterDepl
 	return nil
 }
 
+// hasExternalDestroyer returns true if an external destroyer has been configured for the given platform.
+func (r *ReconcileClusterDeployment) hasExternalDestroyer(platform string) bool {
+	for _, destroyer := range r.externalDestroyers {
+		if destroyer.Platform == platform {
+			return true
+		}
+	}
+	return false
+}
+
 func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovision, error) {
 	req := &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1837,6 +2384,7 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
 			Region:               cd.Spec.Platform.AWS.Region,
 			CredentialsSecretRef: &cd.Spec.Platform.AWS.CredentialsSecretRef,
+			ServiceEndpoints:     cd.Spec.Platform.AWS.ServiceEndpoints,
 		}
 	case cd.Spec.Platform.Azure != nil:
 		req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
@@ -1847,6 +2395,19 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 			Region:               cd.Spec.Platform.GCP.Region,
 			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
 		}
+	case cd.Spec.Platform.IBMCloud != nil:
+		req.Spec.Platform.IBMCloud = &hivev1.IBMCloudClusterDeprovision{
+			Region:               cd.Spec.Platform.IBMCloud.Region,
+			ResourceGroupName:    cd.Spec.Platform.IBMCloud.ResourceGroupName,
+			CISInstanceCRN:       cd.Spec.Platform.IBMCloud.CISInstanceCRN,
+			CredentialsSecretRef: cd.Spec.Platform.IBMCloud.CredentialsSecretRef,
+		}
+	case cd.Spec.Platform.Nutanix != nil:
+		req.Spec.Platform.Nutanix = &hivev1.NutanixClusterDeprovision{
+			PrismCentral:          cd.Spec.Platform.Nutanix.PrismCentral,
+			CredentialsSecretRef:  cd.Spec.Platform.Nutanix.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.Nutanix.CertificatesSecretRef,
+		}
 	case cd.Spec.Platform.OpenStack != nil:
 		req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
 			Cloud:                cd.Spec.Platform.OpenStack.Cloud,
@@ -1864,6 +2425,14 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
 			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
 		}
+	case cd.Spec.Platform.BareMetal != nil:
+		req.Spec.Platform.External = &hivev1.ExternalClusterDeprovision{
+			Platform: platformBaremetal,
+		}
+	case cd.Spec.Platform.AgentBareMetal != nil:
+		req.Spec.Platform.External = &hivev1.ExternalClusterDeprovision{
+			Platform: platformAgentBaremetal,
+		}
 	default:
 		return nil, errors.New("unsupported cloud provider for deprovision")
 	}
@@ -1965,11 +2534,36 @@ func (r *ReconcileClusterDeployment) mergePullSecrets(cd *hivev1.ClusterDeployme
 // updatePullSecretInfo creates or updates the merged pull secret for the clusterdeployment.
 // It returns true when the merged pull secret has been created or updated.
 func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	return r.updateDerivedPullSecret(pullSecret, constants.GetMergedPullSecretName(cd), constants.SecretTypeMergedPullSecret, cd, cdLog)
+}
+
+// updatePullThroughCachePullSecret creates or updates the pull secret holding the credentials of the pull-through
+// cache for the install and imageset jobs of the clusterdeployment. The credentials are kept out of the merged pull
+// secret so that they do not end up in the installed cluster.
+// It returns true when the pull secret has been created or updated.
+func (r *ReconcileClusterDeployment) updatePullThroughCachePullSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
+	cache, err := controllerutils.GetPullThroughCache()
+	if err != nil {
+		return false, err
+	}
+	if !controllerutils.PullThroughCacheHasCredentials(cache) {
+		return false, nil
+	}
+	cachePullSecret, err := controllerutils.LoadSecretData(r.Client, cache.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace(), corev1.DockerConfigJsonKey)
+	if err != nil {
+		return false, errors.Wrap(err, "pull-through cache credentials could not be retrieved")
+	}
+	return r.updateDerivedPullSecret(cachePullSecret, constants.GetPullThroughCachePullSecretName(cd), constants.SecretTypePullThroughCachePullSecret, cd, cdLog)
+}
+
+// updateDerivedPullSecret creates or updates a pull secret owned by the clusterdeployment.
+// It returns true when the pull secret has been created or updated.
+func (r *ReconcileClusterDeployment) updateDerivedPullSecret(pullSecret, secretName, secretType string, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (bool, error) {
 	var err error
+	cdLog = cdLog.WithField("secretName", secretName)
 	pullSecretObjExists := true
 	existingPullSecretObj := &corev1.Secret{}
-	mergedSecretName := constants.GetMergedPullSecretName(cd)
-	err = r.Get(context.TODO(), types.NamespacedName{Name: mergedSecretName, Namespace: cd.Namespace}, existingPullSecretObj)
+	err = r.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: cd.Namespace}, existingPullSecretObj)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			cdLog.Info("Existing pull secret object not found")
@@ -1982,54 +2576,97 @@ func (r *ReconcileClusterDeployment) updatePullSecretInfo(pullSecret string, cd
 	if pullSecretObjExists {
 		existingPullSecret, ok := existingPullSecretObj.Data[corev1.DockerConfigJsonKey]
 		if !ok {
-			return false, fmt.Errorf("Pull secret %s did not contain key %s", mergedSecretName, corev1.DockerConfigJsonKey)
+			return false, fmt.Errorf("Pull secret %s did not contain key %s", secretName, corev1.DockerConfigJsonKey)
 		}
 		if string(existingPullSecret) == pullSecret {
-			cdLog.Debug("Existing and the new merged pull secret are same")
+			cdLog.Debug("Existing and the new pull secret are same")
 			return false, nil
 		}
-		cdLog.Info("Existing merged pull secret hash did not match with latest merged pull secret")
+		cdLog.Info("Existing pull secret hash did not match with latest pull secret")
 		existingPullSecretObj.Data[corev1.DockerConfigJsonKey] = []byte(pullSecret)
 		err = r.Update(context.TODO(), existingPullSecretObj)
 		if err != nil {
-			return false, errors.Wrap(err, "error updating merged pull secret object")
+			return false, errors.Wrap(err, "error updating pull secret object")
 		}
-		cdLog.WithField("secretName", mergedSecretName).Info("Updated the merged pull secret object successfully")
+		cdLog.Info("Updated the pull secret object successfully")
 	} else {
 
 		// create a new pull secret object
 		newPullSecretObj := generatePullSecretObj(
 			pullSecret,
-			mergedSecretName,
+			secretName,
 			cd,
 		)
 
 		cdLog.WithField("derivedObject", newPullSecretObj.Name).Debug("Setting labels on derived object")
 		newPullSecretObj.Labels = k8slabels.AddLabel(newPullSecretObj.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
-		newPullSecretObj.Labels = k8slabels.AddLabel(newPullSecretObj.Labels, constants.SecretTypeLabel, constants.SecretTypeMergedPullSecret)
+		newPullSecretObj.Labels = k8slabels.AddLabel(newPullSecretObj.Labels, constants.SecretTypeLabel, secretType)
 		err = controllerutil.SetControllerReference(cd, newPullSecretObj, r.scheme)
 		if err != nil {
-			cdLog.Errorf("error setting controller reference on new merged pull secret: %v", err)
+			cdLog.Errorf("error setting controller reference on new pull secret: %v", err)
 			return false, err
 		}
 		err = r.Create(context.TODO(), newPullSecretObj)
 		if err != nil {
 			return false, errors.Wrap(err, "error creating new pull secret object")
 		}
-		cdLog.WithField("secretName", mergedSecretName).Info("Created the merged pull secret object successfully")
+		cdLog.Info("Created the pull secret object successfully")
 	}
 	return true, nil
 }
 
-func calculateNextProvisionTime(failureTime time.Time, retries int, cdLog log.FieldLogger) time.Time {
-	// (2^currentRetries) * 60 seconds up to a max of 24 hours.
-	const sleepCap = 24 * time.Hour
-	const retryCap = 11 // log_2_(24*60)
+func calculateNextProvisionTime(failureTime time.Time, retries int, retryPolicy *hivev1.InstallRetryPolicy, cdLog log.FieldLogger) time.Time {
+	// (2^currentRetries) * base up to a max of the ceiling. By default, the base is 60 seconds and the ceiling is
+	// 24 hours.
+	base, ceiling := time.Minute, 24*time.Hour
+	if retryPolicy != nil {
+		if retryPolicy.BackoffBase != nil && retryPolicy.BackoffBase.Duration > 0 {
+			base = retryPolicy.BackoffBase.Duration
+		}
+		if retryPolicy.BackoffCeiling != nil && retryPolicy.BackoffCeiling.Duration > 0 {
+			ceiling = retryPolicy.BackoffCeiling.Duration
+		}
+	}
 
-	if retries >= retryCap {
-		return failureTime.Add(sleepCap)
+	sleep := base
+	for i := 0; i < retries && sleep < ceiling; i++ {
+		sleep *= 2
+	}
+	if sleep > ceiling {
+		sleep = ceiling
 	}
-	return failureTime.Add((1 << uint(retries)) * time.Minute)
+	return failureTime.Add(sleep)
+}
+
+// installAttemptsLimit returns the maximum number of install attempts for the cluster deployment, if any.
+func installAttemptsLimit(cd *hivev1.ClusterDeployment) *int32 {
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.RetryPolicy != nil && cd.Spec.Provisioning.RetryPolicy.MaxAttempts != nil {
+		return cd.Spec.Provisioning.RetryPolicy.MaxAttempts
+	}
+	return cd.Spec.InstallAttemptsLimit
+}
+
+// classifyInstallFailure returns the class of install failure, for the retry policy, of a provision that failed with
+// an error of the class. Errors of the user and quota errors will not succeed when retried without intervention. All
+// other errors, including those that could not be classified, are treated as infrastructure failures.
+func classifyInstallFailure(class errorclass.Class) hivev1.InstallFailureClass {
+	switch class {
+	case errorclass.UserError, errorclass.QuotaError:
+		return hivev1.InstallFailureClassConfiguration
+	}
+	return hivev1.InstallFailureClassInfrastructure
+}
+
+func shouldRetryInstall(failureClass hivev1.InstallFailureClass, retryPolicy *hivev1.InstallRetryPolicy) bool {
+	if retryPolicy == nil || len(retryPolicy.RetryOn) == 0 {
+		return true
+	}
+	for _, c := range retryPolicy.RetryOn {
+		if c == failureClass {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*hivev1.ClusterProvision, error) {
@@ -2213,12 +2850,18 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 		return platformAzure
 	case cd.Spec.Platform.GCP != nil:
 		return platformGCP
+	case cd.Spec.Platform.IBMCloud != nil:
+		return platformIBMCloud
+	case cd.Spec.Platform.Nutanix != nil:
+		return platformNutanix
 	case cd.Spec.Platform.OpenStack != nil:
 		return platformOpenStack
 	case cd.Spec.Platform.VSphere != nil:
 		return platformVSphere
 	case cd.Spec.Platform.BareMetal != nil:
 		return platformBaremetal
+	case cd.Spec.Platform.AgentBareMetal != nil:
+		return platformAgentBaremetal
 	}
 	return platformUnknown
 }
@@ -2232,6 +2875,8 @@ func getClusterRegion(cd *hivev1.ClusterDeployment) string {
 		return cd.Spec.Platform.Azure.Region
 	case cd.Spec.Platform.GCP != nil:
 		return cd.Spec.Platform.GCP.Region
+	case cd.Spec.Platform.IBMCloud != nil:
+		return cd.Spec.Platform.IBMCloud.Region
 	}
 	return regionUnknown
 }
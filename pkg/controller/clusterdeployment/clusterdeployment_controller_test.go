@@ -17,6 +17,7 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,11 +35,13 @@ import (
 
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/pkg/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/apis/hive/v1/baremetal"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testclusterdeprovision "github.com/openshift/hive/pkg/test/clusterdeprovision"
@@ -141,6 +144,27 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Initialize conditions",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				for _, conditionType := range hivev1.AllClusterDeploymentConditions {
+					assert.NotNil(t, controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType), "missing %s condition", conditionType)
+				}
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+				if assert.NotNil(t, cond, "missing Unreachable condition") {
+					assert.Equal(t, corev1.ConditionUnknown, cond.Status, "unexpected Unreachable status")
+					assert.Equal(t, hivev1.InitializedConditionReason, cond.Reason, "unexpected Unreachable reason")
+				}
+			},
+		},
 		{
 			name: "Create provision",
 			existing: []runtime.Object{
@@ -174,6 +198,114 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Empty(t, provisions, "expected provision to not exist")
 			},
 		},
+		{
+			name: "Reconcile paused",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Annotations = map[string]string{constants.ReconcilePauseAnnotation: "true"}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PausedCondition)
+				if assert.NotNil(t, cond, "expected Paused condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected Paused condition to be true")
+					assert.Equal(t, "ReconcilePaused", cond.Reason, "unexpected Paused condition reason")
+				}
+			},
+		},
+		{
+			name: "Detach cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testInstalledClusterDeployment(time.Now())
+					cd.Spec.Detach = &hivev1.ClusterDetach{PreserveSecrets: true}
+					return cd
+				}(),
+				func() *corev1.Secret {
+					s := testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig)
+					s.OwnerReferences = []metav1.OwnerReference{
+						{APIVersion: "hive.openshift.io/v1", Kind: "ClusterDeployment", Name: testName},
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "other-owner"},
+					}
+					return s
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				assert.False(t, controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision), "expected deprovision finalizer to be removed")
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DetachedCondition)
+				if assert.NotNil(t, cond, "expected Detached condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected Detached condition to be true")
+				}
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: adminKubeconfigSecret}, secret))
+				if assert.Len(t, secret.OwnerReferences, 1, "expected Hive owner to be removed from admin kubeconfig") {
+					assert.Equal(t, "other-owner", secret.OwnerReferences[0].Name, "unexpected owner of admin kubeconfig")
+				}
+				assert.Empty(t, getProvisions(c), "expected provision to not exist")
+			},
+		},
+		{
+			name: "Detach cluster with managed DNS",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testInstalledClusterDeployment(time.Now())
+					cd.Spec.ManageDNS = true
+					cd.Spec.Detach = &hivev1.ClusterDetach{}
+					return cd
+				}(),
+				func() *hivev1.DNSZone {
+					zone := testAvailableDNSZone()
+					zone.Finalizers = []string{hivev1.FinalizerDNSZone}
+					return zone
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "missing clusterdeployment")
+				assert.False(t, controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision), "expected deprovision finalizer to be removed")
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "missing dnszone")
+				assert.Empty(t, zone.OwnerReferences, "expected clusterdeployment to be removed from dnszone owners")
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "expected dnszone finalizer to be kept")
+			},
+		},
+		{
+			name: "Reconcile resumed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.PausedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "ReconcilePaused",
+					})
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PausedCondition)
+				if assert.NotNil(t, cond, "expected Paused condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected Paused condition to be false")
+				}
+			},
+		},
 		{
 			name: "Adopt provision",
 			existing: []runtime.Object{
@@ -194,7 +326,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		{
 			name: "No-op Running provision",
 			existing: []runtime.Object{
-				testClusterDeploymentWithProvision(),
+				testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision()),
 				testProvision(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
@@ -202,7 +334,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
 				if assert.NotNil(t, cd, "no clusterdeployment found") {
-					if e, a := testClusterDeploymentWithProvision(), cd; !assert.True(t, apiequality.Semantic.DeepEqual(e, a), "unexpected change in clusterdeployment") {
+					if e, a := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision()), cd; !assert.True(t, apiequality.Semantic.DeepEqual(e, a), "unexpected change in clusterdeployment") {
 						t.Logf("diff = %s", diff.ObjectReflectDiff(e, a))
 					}
 				}
@@ -458,6 +590,35 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Equal(t, 0, len(cd.Finalizers))
 			},
 		},
+		{
+			name: "Create deprovision for deleted BareMetal cluster with external destroyer",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Platform.AWS = nil
+					cd.Spec.Platform.BareMetal = &baremetal.Platform{}
+					cd.Labels[hivev1.HiveClusterPlatformLabel] = "baremetal"
+					now := metav1.Now()
+					cd.DeletionTimestamp = &now
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.externalDestroyers = []hivev1.ExternalDestroyer{{Platform: "baremetal", Image: "example.com/destroyer"}}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				deprovision := getDeprovision(c)
+				if assert.NotNil(t, deprovision, "expected deprovision request") {
+					if assert.NotNil(t, deprovision.Spec.Platform.External, "expected external deprovision") {
+						assert.Equal(t, "baremetal", deprovision.Spec.Platform.External.Platform)
+					}
+				}
+				cd := getCD(c)
+				assert.Contains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected finalizer")
+			},
+		},
 		{
 			name: "Delete expired cluster deployment",
 			existing: []runtime.Object{
@@ -597,6 +758,65 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Use overridden images without imageset job",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Status.CLIImage = nil
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					cd.Spec.Provisioning.CLIImageOverride = "cd-cli-image:latest"
+					return cd
+				}(),
+				func() *hivev1.ClusterImageSet {
+					cis := testClusterImageSet()
+					cis.Spec.InstallerImageOverride = "imageset-installer-image:latest"
+					cis.Spec.CLIImageOverride = "imageset-cli-image:latest"
+					return cis
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getImageSetJob(c), "expected no imageset job")
+				cd := getCDFromClient(c)
+				if assert.NotNil(t, cd.Status.InstallerImage, "expected installer image") {
+					assert.Equal(t, "imageset-installer-image:latest", *cd.Status.InstallerImage, "unexpected installer image")
+				}
+				if assert.NotNil(t, cd.Status.CLIImage, "expected cli image") {
+					assert.Equal(t, "cd-cli-image:latest", *cd.Status.CLIImage, "unexpected cli image")
+				}
+				condition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InstallerImageResolutionFailedCondition)
+				if assert.NotNil(t, condition, "expected installer image resolution condition") {
+					assert.Equal(t, corev1.ConditionFalse, condition.Status, "unexpected condition status")
+					assert.Equal(t, imagesOverriddenReason, condition.Reason, "unexpected condition reason")
+				}
+				assert.Len(t, getProvisions(c), 1, "expected provision to exist")
+			},
+		},
+		{
+			name: "Pass installer image override to imageset job",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+					cd.Spec.Provisioning.InstallerImageOverride = "cd-installer-image:latest"
+					return cd
+				}(),
+				testClusterImageSet(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				job := getImageSetJob(c)
+				require.NotNil(t, job, "expected imageset job")
+				assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, "cd-installer-image:latest", "expected installer image override in job args")
+				assert.NotContains(t, job.Spec.Template.Spec.Containers[0].Args, "--cli-image-override", "unexpected cli image override in job args")
+			},
+		},
 		{
 			name: "Ensure release image from clusterimageset is used as override image in install job",
 			existing: []runtime.Object{
@@ -656,6 +876,76 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Equal(t, constants.DNSZoneTypeChild, zone.Labels[constants.DNSZoneTypeLabel], "incorrect dnszone type label")
 			},
 		},
+		{
+			name: "Create DNSZone with credentials of managed domain on another cloud",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.Spec.BaseDomain = "cluster.gcp.example.com"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "gcp-dns-creds"},
+					Data:       map[string][]byte{constants.GCPCredentialsName: []byte("gcp-creds")},
+				},
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.managedDomains = []hivev1.ManageDNSConfig{
+					{
+						Domains: []string{"example.com"},
+						AWS:     &hivev1.ManageDNSAWSConfig{CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-dns-creds"}},
+					},
+					{
+						Domains: []string{"gcp.example.com"},
+						GCP:     &hivev1.ManageDNSGCPConfig{CredentialsSecretRef: corev1.LocalObjectReference{Name: "gcp-dns-creds"}},
+					},
+				}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				assert.Nil(t, zone.Spec.AWS, "expected no AWS zone")
+				if assert.NotNil(t, zone.Spec.GCP, "expected GCP zone") {
+					assert.Equal(t, testName+"-zone-creds", zone.Spec.GCP.CredentialsSecretRef.Name, "unexpected zone credentials")
+				}
+				secret := &corev1.Secret{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-zone-creds"}, secret)
+				require.NoError(t, err, "expected zone credentials to be copied")
+				assert.Equal(t, "gcp-creds", string(secret.Data[constants.GCPCredentialsName]), "unexpected zone credentials")
+				assert.True(t, metav1.IsControlledBy(secret, zone), "expected zone credentials to be owned by the zone")
+			},
+		},
+		{
+			name: "Create DNSZone with cluster credentials for managed domain on the same cloud",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.Spec.BaseDomain = "cluster.aws.example.com"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.managedDomains = []hivev1.ManageDNSConfig{{
+					Domains: []string{"aws.example.com"},
+					AWS:     &hivev1.ManageDNSAWSConfig{CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-dns-creds"}},
+				}}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				if assert.NotNil(t, zone.Spec.AWS, "expected AWS zone") {
+					assert.Equal(t, "aws-credentials", zone.Spec.AWS.CredentialsSecretRef.Name, "unexpected zone credentials")
+				}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-zone-creds"}, &corev1.Secret{})
+				assert.True(t, errors.IsNotFound(err), "expected no copy of managed domain credentials")
+			},
+		},
 		{
 			name: "Wait when DNSZone is not available yet",
 			existing: []runtime.Object{
@@ -898,40 +1188,185 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				for _, p := range getProvisions(c) {
 					actualAttempts = append(actualAttempts, p.Spec.Attempt)
 				}
-				expectedAttempts := []int{0, 2, 3, 4}
-				assert.ElementsMatch(t, expectedAttempts, actualAttempts, "unexpected provisions kept")
+				expectedAttempts := []int{0, 2, 3, 4}
+				assert.ElementsMatch(t, expectedAttempts, actualAttempts, "unexpected provisions kept")
+			},
+		},
+		{
+			name: "Adopt provision",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testProvision(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "provision reference not set") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision referenced")
+					}
+				}
+			},
+		},
+		{
+			name: "Do not adopt failed provision",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testFailedProvisionAttempt(0),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected provision reference to not be set")
+				}
+			},
+		},
+		{
+			name: "Create dry-run provision",
+			existing: []runtime.Object{
+				testDryRunClusterDeployment(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					assert.True(t, provisions[0].Spec.DryRun, "expected dry-run provision")
+				}
+			},
+		},
+		{
+			name: "Create provision with timeout",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.Timeout = &metav1.Duration{Duration: 2 * time.Hour}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") && assert.NotNil(t, provisions[0].Spec.Timeout, "expected provision timeout") {
+					assert.Equal(t, 2*time.Hour, provisions[0].Spec.Timeout.Duration, "unexpected provision timeout")
+				}
+			},
+		},
+		{
+			name: "Set condition when dry run complete",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testDryRunClusterDeployment()
+					cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: provisionName}
+					return cd
+				}(),
+				testDryRunCompleteProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.DryRunCompleteCondition)
+					if assert.NotNil(t, cond, "missing dry run complete condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+						assert.Contains(t, cond.Message, "dry-run-output", "expected configmap name in condition message")
+					}
+				}
+			},
+		},
+		{
+			name: "Clear out dry-run provision when dry run turned off",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				testDryRunCompleteProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 0, cd.Status.InstallRestarts, "expected install restart count to not be incremented")
+				}
+			},
+		},
+		{
+			name: "Do not adopt dry-run provision when dry run turned off",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testDryRunCompleteProvision(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected provision reference to not be set")
+				}
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 2, "expected new provision to be created") {
+					for _, provision := range provisions {
+						if provision.Name != provisionName {
+							assert.False(t, provision.Spec.DryRun, "expected new provision to not be a dry run")
+						}
+					}
+				}
 			},
 		},
 		{
-			name: "Adopt provision",
+			name: "Remove debug mode annotation after debug mode ended",
 			existing: []runtime.Object{
-				testClusterDeployment(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					if cd.Annotations == nil {
+						cd.Annotations = make(map[string]string, 1)
+					}
+					cd.Annotations[constants.DebugModeUntilAnnotation] = time.Now().Add(-time.Minute).Format(time.RFC3339)
+					return cd
+				}(),
+				testProvision(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
-				testProvision(),
 			},
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				if assert.NotNil(t, cd, "missing cluster deployment") {
-					if assert.NotNil(t, cd.Status.ProvisionRef, "provision reference not set") {
-						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision referenced")
-					}
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.NotContains(t, cd.Annotations, constants.DebugModeUntilAnnotation, "expected debug mode annotation to be removed")
 				}
 			},
 		},
 		{
-			name: "Do not adopt failed provision",
+			name: "Keep debug mode annotation during debug mode",
 			existing: []runtime.Object{
-				testClusterDeployment(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					if cd.Annotations == nil {
+						cd.Annotations = make(map[string]string, 1)
+					}
+					cd.Annotations[constants.DebugModeUntilAnnotation] = time.Now().Add(time.Hour).Format(time.RFC3339)
+					return cd
+				}(),
+				testProvision(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
-				testFailedProvisionAttempt(0),
 			},
-			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				if assert.NotNil(t, cd, "missing cluster deployment") {
-					assert.Nil(t, cd.Status.ProvisionRef, "expected provision reference to not be set")
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Contains(t, cd.Annotations, constants.DebugModeUntilAnnotation, "expected debug mode annotation to be kept")
 				}
 			},
 		},
@@ -999,6 +1434,69 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Stop after failed provision not retried by retry policy",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Provisioning.RetryPolicy = &hivev1.InstallRetryPolicy{
+						RetryOn: []hivev1.InstallFailureClass{hivev1.InstallFailureClassInfrastructure},
+					}
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Status.Conditions[0].Reason = "DNSAlreadyExists"
+					provision.Status.ErrorClass = string(errorclass.UserError)
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Status.ProvisionRef, "missing provision ref") {
+						assert.Equal(t, provisionName, cd.Status.ProvisionRef.Name, "unexpected provision ref name")
+					}
+					assert.Equal(t, 0, cd.Status.InstallRestarts, "expected install restart count to be unchanged")
+					assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+					assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "FailureNotRetried")
+					assertConditionReason(t, cd, hivev1.ProvisionFailedCondition, "DNSAlreadyExists")
+				}
+			},
+		},
+		{
+			name: "Clear out provision retried by retry policy",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					cd.Spec.Provisioning.RetryPolicy = &hivev1.InstallRetryPolicy{
+						RetryOn: []hivev1.InstallFailureClass{hivev1.InstallFailureClassInfrastructure},
+					}
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now().Add(-2 * time.Minute))
+					provision.Status.Conditions[0].Reason = "AWSAPIRateLimitExceeded"
+					provision.Status.ErrorClass = string(errorclass.TransientCloudError)
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 1, cd.Status.InstallRestarts, "expected incremented install restart count")
+				}
+			},
+		},
 		{
 			name: "Delete outstanding provision on delete",
 			existing: []runtime.Object{
@@ -1226,9 +1724,10 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			expectErr: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				require.Equal(t, 1, len(cd.Status.Conditions))
-				require.Equal(t, corev1.ConditionTrue, cd.Status.Conditions[0].Status)
-				require.Equal(t, clusterImageSetNotFoundReason, cd.Status.Conditions[0].Reason)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterImageSetNotFoundCondition)
+				require.NotNil(t, cond, "missing ClusterImageSetNotFound condition")
+				require.Equal(t, corev1.ConditionTrue, cond.Status)
+				require.Equal(t, clusterImageSetNotFoundReason, cond.Reason)
 			},
 		},
 		{
@@ -1252,9 +1751,10 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				require.Equal(t, 1, len(cd.Status.Conditions))
-				require.Equal(t, corev1.ConditionFalse, cd.Status.Conditions[0].Status)
-				require.Equal(t, clusterImageSetFoundReason, cd.Status.Conditions[0].Reason)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterImageSetNotFoundCondition)
+				require.NotNil(t, cond, "missing ClusterImageSetNotFound condition")
+				require.Equal(t, corev1.ConditionFalse, cond.Status)
+				require.Equal(t, clusterImageSetFoundReason, cond.Reason)
 			},
 		},
 		{
@@ -1500,6 +2000,111 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "InstallAttemptsLimitReached")
 			},
 		},
+		{
+			name: "retry policy max attempts takes precedence over the limit",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 2
+					cd.Spec.InstallAttemptsLimit = pointer.Int32Ptr(5)
+					cd.Spec.Provisioning.RetryPolicy = &hivev1.InstallRetryPolicy{MaxAttempts: pointer.Int32Ptr(2)}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, "InstallAttemptsLimitReached")
+			},
+		},
+		{
+			name: "wait for cluster install to be created",
+			existing: []runtime.Object{
+				testAgentClusterDeployment(),
+			},
+			expectedRequeueAfter: clusterInstallRequeueTime,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assert.Nil(t, cd.Status.ProvisionRef, "expected no provision")
+			},
+		},
+		{
+			name: "copy cluster metadata from cluster install",
+			existing: []runtime.Object{
+				testAgentClusterDeployment(),
+				testClusterInstall(true),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				if assert.NotNil(t, cd.Spec.ClusterMetadata, "expected cluster metadata") {
+					assert.Equal(t, testClusterID, cd.Spec.ClusterMetadata.ClusterID, "unexpected cluster ID")
+					assert.Equal(t, adminKubeconfigSecret, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, "unexpected admin kubeconfig secret")
+				}
+				assert.False(t, cd.Spec.Installed, "expected cluster to not be installed yet")
+			},
+		},
+		{
+			name: "cluster install failed",
+			existing: []runtime.Object{
+				testAgentClusterDeployment(),
+				func() runtime.Object {
+					ci := testClusterInstall(false)
+					ci.Object["status"] = map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":    "Failed",
+								"status":  "True",
+								"reason":  "InstallationFailed",
+								"message": "hosts failed to boot",
+							},
+						},
+					}
+					return ci
+				}(),
+			},
+			expectedRequeueAfter: clusterInstallRequeueTime,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionFailedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionFailedCondition, "InstallationFailed")
+				assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+			},
+		},
+		{
+			name: "cluster install completed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testAgentClusterDeployment()
+					cd.Spec.ClusterMetadata = testClusterDeployment().Spec.ClusterMetadata
+					return cd
+				}(),
+				func() runtime.Object {
+					ci := testClusterInstall(true)
+					ci.Object["status"] = map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":   "Completed",
+								"status": "True",
+								"reason": "InstallationCompleted",
+							},
+						},
+					}
+					return ci
+				}(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+				assert.NotNil(t, cd.Status.InstalledTimestamp, "expected installed timestamp")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1614,6 +2219,7 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 		name             string
 		failureTime      time.Time
 		attempt          int
+		retryPolicy      *hivev1.InstallRetryPolicy
 		expectedNextTime time.Time
 	}{
 		{
@@ -1658,10 +2264,38 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 			attempt:          999999,
 			expectedNextTime: time.Date(2019, time.July, 17, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:        "custom backoff base",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     2,
+			retryPolicy: &hivev1.InstallRetryPolicy{
+				BackoffBase: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 0, 20, 0, 0, time.UTC),
+		},
+		{
+			name:        "custom backoff ceiling",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     10,
+			retryPolicy: &hivev1.InstallRetryPolicy{
+				BackoffCeiling: &metav1.Duration{Duration: time.Hour},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "backoff base above ceiling",
+			failureTime: time.Date(2019, time.July, 16, 0, 0, 0, 0, time.UTC),
+			attempt:     0,
+			retryPolicy: &hivev1.InstallRetryPolicy{
+				BackoffBase:    &metav1.Duration{Duration: 2 * time.Hour},
+				BackoffCeiling: &metav1.Duration{Duration: time.Hour},
+			},
+			expectedNextTime: time.Date(2019, time.July, 16, 1, 0, 0, 0, time.UTC),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, log.WithField("controller", "clusterDeployment"))
+			actualNextTime := calculateNextProvisionTime(tc.failureTime, tc.attempt, tc.retryPolicy, log.WithField("controller", "clusterDeployment"))
 			assert.Equal(t, tc.expectedNextTime.String(), actualNextTime.String(), "unexpected next provision time")
 		})
 	}
@@ -1823,6 +2457,50 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func testAgentClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		AgentBareMetal: &hivev1agent.BareMetalPlatform{
+			APIVIP:     "192.168.111.2",
+			IngressVIP: "192.168.111.3",
+		},
+	}
+	cd.Spec.Provisioning = nil
+	cd.Spec.ClusterMetadata = nil
+	cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+		Group:   "extensions.hive.openshift.io",
+		Version: "v1beta1",
+		Kind:    "AgentClusterInstall",
+		Name:    testName,
+	}
+	cd.Labels[hivev1.HiveClusterPlatformLabel] = platformAgentBaremetal
+	delete(cd.Labels, hivev1.HiveClusterRegionLabel)
+	return cd
+}
+
+func testClusterInstall(withMetadata bool) *unstructured.Unstructured {
+	ci := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "extensions.hive.openshift.io/v1beta1",
+		"kind":       "AgentClusterInstall",
+		"metadata": map[string]interface{}{
+			"namespace": testNamespace,
+			"name":      testName,
+		},
+		"spec": map[string]interface{}{},
+	}}
+	if withMetadata {
+		ci.Object["spec"] = map[string]interface{}{
+			"clusterMetadata": map[string]interface{}{
+				"clusterID":                testClusterID,
+				"infraID":                  testInfraID,
+				"adminKubeconfigSecretRef": map[string]interface{}{"name": adminKubeconfigSecret},
+				"adminPasswordSecretRef":   map[string]interface{}{"name": adminPasswordSecret},
+			},
+		}
+	}
+	return ci
+}
+
 func testInstalledClusterDeployment(installedAt time.Time) *hivev1.ClusterDeployment {
 	cd := testClusterDeployment()
 	cd.Spec.Installed = true
@@ -1881,6 +2559,25 @@ func testClusterDeploymentWithProvision() *hivev1.ClusterDeployment {
 	return cd
 }
 
+// testClusterDeploymentWithInitializedConditions returns the cluster deployment with the conditions it has once it
+// has been reconciled: every condition initialized, and reconciliation not paused.
+func testClusterDeploymentWithInitializedConditions(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
+	cd.Status.Conditions, _ = controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, hivev1.AllClusterDeploymentConditions)
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.PausedCondition,
+		corev1.ConditionFalse,
+		"ReconcileNotPaused",
+		"Reconciliation is not paused",
+		controllerutils.UpdateConditionNever,
+	)
+	for i := range cd.Status.Conditions {
+		cd.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+		cd.Status.Conditions[i].LastProbeTime = metav1.Time{}
+	}
+	return cd
+}
+
 func testProvision() *hivev1.ClusterProvision {
 	cd := testClusterDeployment()
 	provision := &hivev1.ClusterProvision{
@@ -1914,6 +2611,20 @@ func testSuccessfulProvision() *hivev1.ClusterProvision {
 	return provision
 }
 
+func testDryRunClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Provisioning.DryRun = true
+	return cd
+}
+
+func testDryRunCompleteProvision() *hivev1.ClusterProvision {
+	provision := testProvision()
+	provision.Spec.Stage = hivev1.ClusterProvisionStageDryRunComplete
+	provision.Spec.DryRun = true
+	provision.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: "dry-run-output"}
+	return provision
+}
+
 func testFailedProvisionAttempt(attempt int) *hivev1.ClusterProvision {
 	provision := testProvision()
 	provision.Name = fmt.Sprintf("%s-%02d", provision.Name, attempt)
@@ -2245,6 +2956,7 @@ func TestMergePullSecrets(t *testing.T) {
 		existingObjs            []runtime.Object
 		expectedErr             bool
 		addGlobalSecretToHiveNs bool
+		cachePullSecret         string
 	}{
 		{
 			name:             "merged pull secret should be be equal to local secret",
@@ -2301,6 +3013,29 @@ func TestMergePullSecrets(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:             "pull-through cache credentials not merged into pull secret",
+			localPullSecret:  `{"auths": {"registry.svc.ci.okd.org": {"auth": "dXNljlfjldsfSDD"}}}`,
+			cachePullSecret:  `{"auths":{"cache.example.com":{"auth":"Y2FjaGU="}}}`,
+			mergedPullSecret: `{"auths": {"registry.svc.ci.okd.org": {"auth": "dXNljlfjldsfSDD"}}}`,
+			existingObjs: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := getCDWithoutPullSecret()
+					cd.Spec.PullSecretRef = &corev1.LocalObjectReference{
+						Name: pullSecretSecret,
+					}
+					return cd
+				}(),
+			},
+		},
+		{
+			name:            "pull-through cache credentials do not replace a missing pull secret",
+			cachePullSecret: `{"auths":{"cache.example.com":{"auth":"Y2FjaGU="}}}`,
+			existingObjs: []runtime.Object{
+				getCDWithoutPullSecret(),
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -2329,6 +3064,12 @@ func TestMergePullSecrets(t *testing.T) {
 				os.Setenv(constants.GlobalPullSecret, globalPullSecret)
 			}
 			defer os.Unsetenv(constants.GlobalPullSecret)
+			if test.cachePullSecret != "" {
+				cacheSecretObj := createGlobalPullSecretObj(corev1.SecretTypeDockerConfigJson, "cache-pull-secret", corev1.DockerConfigJsonKey, test.cachePullSecret)
+				require.NoError(t, rcd.Create(context.TODO(), cacheSecretObj), "unexpected error creating pull-through cache secret")
+				os.Setenv(constants.PullThroughCacheEnvVar, `{"mirrors":[],"credentialsSecretRef":{"name":"cache-pull-secret"}}`)
+			}
+			defer os.Unsetenv(constants.PullThroughCacheEnvVar)
 
 			expetedPullSecret, err := rcd.mergePullSecrets(cd, rcd.logger)
 			if test.expectedErr {
@@ -2343,6 +3084,65 @@ func TestMergePullSecrets(t *testing.T) {
 	}
 }
 
+func TestUpdatePullThroughCachePullSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	const cachePullSecret = `{"auths":{"cache.example.com":{"auth":"Y2FjaGU="}}}`
+
+	tests := []struct {
+		name            string
+		cache           string
+		expectedUpdated bool
+		expectedSecret  bool
+	}{
+		{
+			name: "no pull-through cache",
+		},
+		{
+			name:  "pull-through cache without credentials",
+			cache: `{"mirrors":[{"source":"quay.io","mirror":"cache.example.com/quay.io"}]}`,
+		},
+		{
+			name:            "pull-through cache with credentials",
+			cache:           `{"mirrors":[],"credentialsSecretRef":{"name":"cache-pull-secret"}}`,
+			expectedUpdated: true,
+			expectedSecret:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existingObjs := []runtime.Object{
+				testClusterDeployment(),
+				createGlobalPullSecretObj(corev1.SecretTypeDockerConfigJson, "cache-pull-secret", corev1.DockerConfigJsonKey, cachePullSecret),
+			}
+			rcd := &ReconcileClusterDeployment{
+				Client: fake.NewFakeClient(existingObjs...),
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterDeployment"),
+			}
+			if test.cache != "" {
+				os.Setenv(constants.PullThroughCacheEnvVar, test.cache)
+			}
+			defer os.Unsetenv(constants.PullThroughCacheEnvVar)
+
+			cd := getCDFromClient(rcd.Client)
+			updated, err := rcd.updatePullThroughCachePullSecret(cd, rcd.logger)
+			require.NoError(t, err, "unexpected error updating pull-through cache pull secret")
+			assert.Equal(t, test.expectedUpdated, updated, "unexpected updated")
+
+			secret := &corev1.Secret{}
+			err = rcd.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: constants.GetPullThroughCachePullSecretName(cd)}, secret)
+			if !test.expectedSecret {
+				assert.True(t, errors.IsNotFound(err), "expected no pull-through cache pull secret")
+				return
+			}
+			require.NoError(t, err, "expected pull-through cache pull secret")
+			assert.Equal(t, cachePullSecret, secret.StringData[corev1.DockerConfigJsonKey], "unexpected pull-through cache pull secret")
+			assert.Equal(t, constants.SecretTypePullThroughCachePullSecret, secret.Labels[constants.SecretTypeLabel], "unexpected secret type")
+		})
+	}
+}
+
 func TestCopyInstallLogSecret(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 
@@ -2419,6 +3219,79 @@ func TestCopyInstallLogSecret(t *testing.T) {
 	}
 }
 
+func TestEnsureClusterNamespaceLabel(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	enabledTime := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name                 string
+		existingLabels       map[string]string
+		createdBeforeEnabled bool
+		expectLabel          bool
+	}{
+		{
+			name:        "unlabelled namespace",
+			expectLabel: true,
+		},
+		{
+			name:           "namespace with other labels",
+			existingLabels: map[string]string{"foo": "bar"},
+			expectLabel:    true,
+		},
+		{
+			name:           "already labelled namespace",
+			existingLabels: map[string]string{constants.ClusterNamespaceLabel: testName},
+			expectLabel:    true,
+		},
+		{
+			name:                 "namespace created before namespace per cluster was enabled",
+			existingLabels:       map[string]string{"foo": "bar"},
+			createdBeforeEnabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			created := enabledTime.Add(time.Minute)
+			if test.createdBeforeEnabled {
+				created = enabledTime.Add(-time.Minute)
+			}
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              testName,
+					Labels:            test.existingLabels,
+					CreationTimestamp: metav1.NewTime(created),
+				},
+			}
+			cd := testClusterDeployment()
+			cd.Namespace = testName
+			fakeClient := fake.NewFakeClient(ns)
+			rcd := &ReconcileClusterDeployment{
+				Client:                         fakeClient,
+				scheme:                         scheme.Scheme,
+				logger:                         log.WithField("controller", "clusterDeployment"),
+				namespacePerCluster:            true,
+				namespacePerClusterEnabledTime: enabledTime,
+			}
+
+			err := rcd.ensureClusterNamespaceLabel(cd, rcd.logger)
+			require.NoError(t, err, "unexpected error labelling namespace")
+
+			ns = &corev1.Namespace{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: testName}, ns))
+			if test.expectLabel {
+				assert.Equal(t, testName, ns.Labels[constants.ClusterNamespaceLabel], "unexpected cluster namespace label")
+			} else {
+				assert.NotContains(t, ns.Labels, constants.ClusterNamespaceLabel, "unexpected cluster namespace label")
+			}
+			for k, v := range test.existingLabels {
+				assert.Equal(t, v, ns.Labels[k], "unexpected change to existing label %s", k)
+			}
+		})
+	}
+}
+
 func getProvisions(c client.Client) []*hivev1.ClusterProvision {
 	provisionList := &hivev1.ClusterProvisionList{}
 	if err := c.List(context.TODO(), provisionList); err != nil {
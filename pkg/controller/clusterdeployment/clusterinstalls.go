@@ -0,0 +1,196 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// clusterInstallRequeueTime is how often a ClusterDeployment installed by a ClusterInstall is checked while the
+	// install is underway. The kind of the ClusterInstall is only known at runtime, so it is not watched.
+	clusterInstallRequeueTime = time.Minute
+
+	// Condition types the ClusterInstall implementations report in status.conditions.
+	clusterInstallCompletedCondition = "Completed"
+	clusterInstallFailedCondition    = "Failed"
+	clusterInstallStoppedCondition   = "Stopped"
+)
+
+// clusterInstallCondition is a condition reported by a ClusterInstall.
+type clusterInstallCondition struct {
+	Type    string                 `json:"type"`
+	Status  corev1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// reconcileClusterInstall follows the install of a cluster by the ClusterInstall referenced by the ClusterDeployment,
+// such as the AgentClusterInstall of assisted-service. The ClusterInstall does the provisioning, Hive only copies
+// the metadata of the cluster and the progress of the install into the ClusterDeployment.
+func (r *ReconcileClusterDeployment) reconcileClusterInstall(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+	ref := cd.Spec.ClusterInstallRef
+	cdLog = cdLog.WithField("clusterInstall", fmt.Sprintf("%s.%s/%s", ref.Kind, ref.Group, ref.Name))
+
+	clusterInstall := &unstructured.Unstructured{}
+	clusterInstall.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.Version, Kind: ref.Kind})
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: ref.Name}, clusterInstall); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Info("waiting for cluster install to be created")
+			return reconcile.Result{RequeueAfter: clusterInstallRequeueTime}, nil
+		}
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster install")
+		return reconcile.Result{}, err
+	}
+
+	if cd.Spec.ClusterMetadata == nil {
+		metadata, err := getClusterInstallMetadata(clusterInstall)
+		if err != nil {
+			cdLog.WithError(err).Error("could not read cluster metadata of cluster install")
+			return reconcile.Result{}, err
+		}
+		if metadata != nil {
+			cdLog.WithField("clusterID", metadata.ClusterID).Info("copying cluster metadata from cluster install")
+			cd.Spec.ClusterMetadata = metadata
+			if err := r.Update(context.TODO(), cd); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set cluster metadata")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+	}
+
+	conditions, err := getClusterInstallConditions(clusterInstall)
+	if err != nil {
+		cdLog.WithError(err).Error("could not read conditions of cluster install")
+		return reconcile.Result{}, err
+	}
+
+	statusChange := false
+	if cond := conditions[clusterInstallFailedCondition]; cond != nil {
+		conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionFailedCondition,
+			cond.Status,
+			cond.Reason,
+			cond.Message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			statusChange = true
+			cd.Status.Conditions = conds
+		}
+	}
+	if cond := conditions[clusterInstallStoppedCondition]; cond != nil {
+		conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionStoppedCondition,
+			cond.Status,
+			cond.Reason,
+			cond.Message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			statusChange = true
+			cd.Status.Conditions = conds
+		}
+	}
+	completed := conditions[clusterInstallCompletedCondition]
+	installed := completed != nil && completed.Status == corev1.ConditionTrue
+	if installed && cd.Status.InstalledTimestamp == nil {
+		statusChange = true
+		now := metav1.Now()
+		cd.Status.InstalledTimestamp = &now
+	}
+	if statusChange {
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !installed {
+		cdLog.Debug("cluster install has not completed")
+		return reconcile.Result{RequeueAfter: clusterInstallRequeueTime}, nil
+	}
+	if cd.Spec.ClusterMetadata == nil {
+		// The metadata is set by the time the install completes, but it may not have been observed yet.
+		cdLog.Info("cluster install completed without cluster metadata")
+		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
+	cdLog.Info("cluster install completed successfully")
+	cd.Spec.Installed = true
+	if r.protectedDelete {
+		if _, annotationPresent := cd.Annotations[constants.ProtectedDeleteAnnotation]; !annotationPresent {
+			initializeAnnotations(cd)
+			cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+		}
+	}
+	if err := r.Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set the Installed flag")
+		return reconcile.Result{}, err
+	}
+
+	jobDuration := time.Since(clusterInstall.GetCreationTimestamp().Time)
+	cdLog.WithField("duration", jobDuration.Seconds()).Debug("cluster install completed")
+	metricInstallJobDuration.Observe(float64(jobDuration.Seconds()))
+	metricClustersInstalled.WithLabelValues(hivemetrics.GetClusterDeploymentType(cd)).Inc()
+
+	return reconcile.Result{}, nil
+}
+
+// getClusterInstallMetadata returns the metadata of the cluster from spec.clusterMetadata of the ClusterInstall, or
+// nil if the ClusterInstall has not set it yet.
+func getClusterInstallMetadata(clusterInstall *unstructured.Unstructured) (*hivev1.ClusterMetadata, error) {
+	obj, found, err := unstructured.NestedMap(clusterInstall.Object, "spec", "clusterMetadata")
+	if err != nil || !found {
+		return nil, err
+	}
+	metadata := &hivev1.ClusterMetadata{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, metadata); err != nil {
+		return nil, errors.Wrap(err, "could not convert cluster metadata")
+	}
+	if metadata.ClusterID == "" || metadata.InfraID == "" || metadata.AdminKubeconfigSecretRef.Name == "" {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// getClusterInstallConditions returns the conditions in status.conditions of the ClusterInstall by type.
+func getClusterInstallConditions(clusterInstall *unstructured.Unstructured) (map[string]*clusterInstallCondition, error) {
+	objs, _, err := unstructured.NestedSlice(clusterInstall.Object, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+	conditions := map[string]*clusterInstallCondition{}
+	for _, obj := range objs {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected condition %v", obj)
+		}
+		cond := &clusterInstallCondition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, cond); err != nil {
+			return nil, errors.Wrap(err, "could not convert condition")
+		}
+		conditions[cond.Type] = cond
+	}
+	return conditions, nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -95,10 +96,16 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 			return nil, err
 		}
 	}
+	externalDestroyers, err := controllerutils.GetExternalDestroyers()
+	if err != nil {
+		log.WithError(err).Error("could not get external destroyers")
+		return nil, err
+	}
 	return &ReconcileClusterDeprovision{
 		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:               mgr.GetScheme(),
 		deprovisionsDisabled: deprovisionsDisabled,
+		externalDestroyers:   externalDestroyers,
 	}, nil
 }
 
@@ -106,7 +113,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterdeprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -142,6 +149,8 @@ type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
 	deprovisionsDisabled bool
+	// externalDestroyers are run by uninstall jobs in place of the destroyers built into Hive
+	externalDestroyers []hivev1.ExternalDestroyer
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeprovision object and makes changes based on the state read
@@ -267,11 +276,15 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 
 	// Generate an uninstall job
 	rLog.Debug("generating uninstall job")
-	uninstallJob, err := install.GenerateUninstallerJobForDeprovision(instance)
+	uninstallJob, err := install.GenerateUninstallerJobForDeprovision(instance, controllerutils.DeprovisionServiceAccountName, r.externalDestroyers)
 	if err != nil {
 		rLog.Errorf("error generating uninstaller job: %v", err)
 		return reconcile.Result{}, err
 	}
+	if err := controllerutils.ApplyJobScheduling(&uninstallJob.Spec.Template.Spec); err != nil {
+		rLog.WithError(err).Error("error applying job scheduling to uninstaller job")
+		return reconcile.Result{}, err
+	}
 
 	rLog.Debug("setting uninstall job controller reference")
 	rLog.WithField("derivedObject", uninstallJob.Name).Debug("Setting labels on derived object")
@@ -297,6 +310,10 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 	existingJob := &batchv1.Job{}
 	err = r.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
 	if err != nil && errors.IsNotFound(err) {
+		if err := controllerutils.SetupClusterDeprovisionServiceAccount(r, instance.Namespace, rLog); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting up service account for uninstall job")
+			return reconcile.Result{}, err
+		}
 		rLog.Debug("uninstall job does not exist, creating it")
 		err = r.Create(context.TODO(), uninstallJob)
 		if err != nil {
@@ -317,6 +334,15 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		jobDuration := existingJob.Status.CompletionTime.Time.Sub(existingJob.Status.StartTime.Time)
 		rLog.WithField("duration", jobDuration.Seconds()).Debug("uninstall job completed")
 		instance.Status.Completed = true
+		instance.Status.RemainingResources = nil
+		instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+			instance.Status.Conditions,
+			hivev1.DeprovisionFailedClusterDeprovisionCondition,
+			corev1.ConditionFalse,
+			deprovisionSucceededReason,
+			"Uninstall job succeeded",
+			controllerutils.UpdateConditionNever,
+		)
 		err = r.Status().Update(context.TODO(), instance)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
@@ -349,10 +375,64 @@ func (r *ReconcileClusterDeprovision) Reconcile(request reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsFailed(existingJob) {
+		return r.reconcileFailedJob(instance, existingJob, rLog)
+	}
+
 	rLog.Infof("uninstall job not yet successful")
 	return reconcile.Result{}, nil
 }
 
+// reconcileFailedJob records the classified failure of the uninstall job in the DeprovisionFailed condition, and
+// deletes the job to retry the uninstall once the backoff for the number of retries so far has passed.
+func (r *ReconcileClusterDeprovision) reconcileFailedJob(instance *hivev1.ClusterDeprovision, job *batchv1.Job, rLog log.FieldLogger) (reconcile.Result, error) {
+	if job.DeletionTimestamp != nil {
+		rLog.Debug("failed uninstall job is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	jobLog, err := r.getFailedJobLog(job, rLog)
+	if err != nil {
+		rLog.WithError(err).Error("could not get the log of the failed uninstall job")
+		return reconcile.Result{}, err
+	}
+	failure := classifyFailure(jobLog)
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.DeprovisionFailedClusterDeprovisionCondition,
+		corev1.ConditionTrue,
+		failure.reason,
+		failure.message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		rLog.WithField("reason", failure.reason).WithField("message", failure.message).Warn("uninstall job failed")
+		instance.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating deprovision failed condition")
+			return reconcile.Result{}, err
+		}
+	}
+
+	retryTime := nextRetryTime(job, instance.Status.Retries)
+	if wait := time.Until(retryTime); wait > 0 {
+		rLog.WithField("retryTime", retryTime).Info("waiting to retry failed uninstall job")
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	rLog.WithField("retries", instance.Status.Retries).Info("deleting failed uninstall job to retry the uninstall")
+	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting failed uninstall job")
+		return reconcile.Result{}, err
+	}
+	instance.Status.Retries++
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating uninstall retries")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
 	return []*controllerutils.OwnershipUniqueKey{
 		{
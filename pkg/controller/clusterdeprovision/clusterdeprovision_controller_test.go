@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
@@ -29,6 +30,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
 const (
@@ -158,6 +160,89 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateCompleted(t, c)
 			},
 		},
+		{
+			name:        "wait to retry failed job",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testFailedUninstallJob(time.Now()),
+				testUninstallPod(`time="2020-01-01T00:00:00Z" level=debug msg="DependencyViolation: resource sg-0123456789abcdef0 has a dependent object" arn="arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123456789abcdef0"`),
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				req := getClusterDeprovision(t, c)
+				cond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+				if assert.NotNil(t, cond, "expected deprovision failed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, dependencyViolationReason, cond.Reason, "unexpected condition reason")
+					assert.Contains(t, cond.Message, "ec2:security-group", "expected resource type in condition message")
+				}
+				assert.Zero(t, req.Status.Retries, "unexpected retries")
+			},
+		},
+		{
+			name: "retry failed job after backoff",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Retries = 1
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testFailedUninstallJob(time.Now().Add(-3 * time.Minute)),
+				testUninstallPod("Throttling: Rate exceeded"),
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				req := getClusterDeprovision(t, c)
+				cond := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+				if assert.NotNil(t, cond, "expected deprovision failed condition") {
+					assert.Equal(t, throttledReason, cond.Reason, "unexpected condition reason")
+				}
+				assert.Equal(t, int32(2), req.Status.Retries, "unexpected retries")
+			},
+		},
+		{
+			name: "clear deprovision failed condition when job is successful",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+					Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+					Status: corev1.ConditionTrue,
+					Reason: throttledReason,
+				}}
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				func() runtime.Object {
+					job := testUninstallJob()
+					job.Status.Conditions = []batchv1.JobCondition{
+						{
+							Type:   batchv1.JobComplete,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					now := metav1.Now()
+					job.Status.CompletionTime = &now
+					job.Status.StartTime = &now
+					return job
+				}(),
+			},
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DeprovisionFailedClusterDeprovisionCondition,
+						Reason: deprovisionSucceededReason,
+						Status: corev1.ConditionFalse,
+					},
+				})
+			},
+		},
 		{
 			name:        "credentials test fails",
 			deprovision: testClusterDeprovision(),
@@ -322,7 +407,10 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 }
 
 func testUninstallJob() *batchv1.Job {
-	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(testClusterDeprovision())
+	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(testClusterDeprovision(), controllerutils.DeprovisionServiceAccountName, nil)
+	// Label the job as the controller does, so that the hash of the job spec matches.
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.ClusterDeprovisionNameLabel, testName)
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.JobTypeLabel, constants.JobTypeDeprovision)
 	hash, err := controllerutils.CalculateJobSpecHash(uninstallJob)
 	if err != nil {
 		panic("should never get error calculating job spec hash")
@@ -332,6 +420,46 @@ func testUninstallJob() *batchv1.Job {
 	return uninstallJob
 }
 
+func testFailedUninstallJob(failureTime time.Time) *batchv1.Job {
+	job := testUninstallJob()
+	job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": job.Name}}
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:               batchv1.JobFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(failureTime),
+	}}
+	return job
+}
+
+func testUninstallPod(terminationMessage string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName + "-uninstall-abcde",
+			Labels:    map[string]string{"job-name": testName + "-uninstall"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "deprovision",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  terminationMessage,
+					},
+				},
+			}},
+		},
+	}
+}
+
+func getClusterDeprovision(t *testing.T, c client.Client) *hivev1.ClusterDeprovision {
+	req := &hivev1.ClusterDeprovision{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req)
+	require.NoError(t, err, "unexpected error getting ClusterDeprovision")
+	return req
+}
+
 func validateNoJobExists(t *testing.T, c client.Client) {
 	job := &batchv1.Job{}
 	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job)
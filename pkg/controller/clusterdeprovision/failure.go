@@ -0,0 +1,174 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// retryBackoffBase and retryBackoffCeiling bound the time waited before retrying a failed uninstall job. The
+	// wait doubles with every retry.
+	retryBackoffBase    = time.Minute
+	retryBackoffCeiling = time.Hour
+
+	throttledReason            = "Throttled"
+	dependencyViolationReason  = "DependencyViolation"
+	unknownFailureReason       = "UnknownError"
+	deprovisionSucceededReason = "DeprovisionSucceeded"
+
+	// maxFailureMessageLength limits how much of the uninstall log is copied into the condition message.
+	maxFailureMessageLength = 256
+)
+
+var (
+	authenticationFailureRegexp = regexp.MustCompile(`AuthFailure|InvalidClientTokenId|SignatureDoesNotMatch|UnrecognizedClientException|ExpiredToken|UnauthorizedOperation|AccessDenied|AuthorizationFailed|InvalidAuthenticationToken|invalid_grant|Unauthorized|Forbidden`)
+	throttlingRegexp            = regexp.MustCompile(`Throttling|RequestLimitExceeded|Rate exceeded|TooManyRequests|rateLimitExceeded|userRateLimitExceeded|SlowDown`)
+	dependencyViolationRegexp   = regexp.MustCompile(`DependencyViolation|has a dependent object|ResourceInUse|resourceInUseByAnotherResource|InUseSubnetCannotBeDeleted`)
+
+	// arnRegexp matches the service and resource type of an AWS ARN, such as ec2 and security-group in
+	// arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123456789abcdef0.
+	arnRegexp = regexp.MustCompile(`arn:aws[\w-]*:([\w-]+):[\w-]*:\d*:(?:([\w-]+)[/:])?`)
+)
+
+// deprovisionFailure is the classification of the failure of an uninstall job.
+type deprovisionFailure struct {
+	reason  string
+	message string
+}
+
+// classifyFailure classifies the failure of an uninstall job from the tail of its log. The lines that report a
+// failure are checked for authentication failures, then throttling, then dependency violations.
+func classifyFailure(jobLog string) deprovisionFailure {
+	var authLines, throttledLines, dependencyLines []string
+	lastLine := ""
+	for _, line := range strings.Split(jobLog, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lastLine = line
+		switch {
+		case authenticationFailureRegexp.MatchString(line):
+			authLines = append(authLines, line)
+		case throttlingRegexp.MatchString(line):
+			throttledLines = append(throttledLines, line)
+		case dependencyViolationRegexp.MatchString(line):
+			dependencyLines = append(dependencyLines, line)
+		}
+	}
+	switch {
+	case len(authLines) > 0:
+		return deprovisionFailure{
+			reason:  authenticationFailedReason,
+			message: failureMessage("Uninstall failed to authenticate with the cloud API", authLines),
+		}
+	case len(throttledLines) > 0:
+		return deprovisionFailure{
+			reason:  throttledReason,
+			message: failureMessage("Uninstall was throttled by the cloud API", throttledLines),
+		}
+	case len(dependencyLines) > 0:
+		return deprovisionFailure{
+			reason:  dependencyViolationReason,
+			message: failureMessage("Uninstall could not remove resources that other resources depend on", dependencyLines),
+		}
+	case lastLine != "":
+		return deprovisionFailure{
+			reason:  unknownFailureReason,
+			message: "Uninstall failed: " + truncate(lastLine, maxFailureMessageLength),
+		}
+	default:
+		return deprovisionFailure{
+			reason:  unknownFailureReason,
+			message: "Uninstall failed, see the uninstall job logs for details",
+		}
+	}
+}
+
+// failureMessage adds the cloud resource types named in the lines that report a failure to the summary of the
+// failure.
+func failureMessage(summary string, lines []string) string {
+	resourceTypes := sets.NewString()
+	for _, line := range lines {
+		for _, m := range arnRegexp.FindAllStringSubmatch(line, -1) {
+			if m[2] == "" {
+				resourceTypes.Insert(m[1])
+			} else {
+				resourceTypes.Insert(m[1] + ":" + m[2])
+			}
+		}
+	}
+	if resourceTypes.Len() == 0 {
+		return summary
+	}
+	return fmt.Sprintf("%s. Resource types: %s", summary, strings.Join(resourceTypes.List(), ", "))
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length] + "..."
+}
+
+// getFailedJobLog returns the tail of the log of the failed containers of an uninstall job, as kept in their
+// termination messages.
+func (r *ReconcileClusterDeprovision) getFailedJobLog(job *batchv1.Job, rLog log.FieldLogger) (string, error) {
+	podSelector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(context.TODO(), pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+		return "", err
+	}
+	// Check the most recent pods first.
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	for _, pod := range pods.Items {
+		statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+		var messages []string
+		for _, status := range statuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+				messages = append(messages, terminated.Message)
+			}
+		}
+		if len(messages) > 0 {
+			return strings.Join(messages, "\n"), nil
+		}
+	}
+	rLog.Debug("no failed uninstall container found")
+	return "", nil
+}
+
+// nextRetryTime returns the time at which a failed uninstall job is retried. The wait after the failure doubles with
+// every retry, up to the ceiling.
+func nextRetryTime(job *batchv1.Job, retries int32) time.Time {
+	failureTime := job.CreationTimestamp.Time
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			failureTime = condition.LastTransitionTime.Time
+		}
+	}
+	wait := retryBackoffBase
+	for i := int32(0); i < retries && wait < retryBackoffCeiling; i++ {
+		wait *= 2
+	}
+	if wait > retryBackoffCeiling {
+		wait = retryBackoffCeiling
+	}
+	return failureTime.Add(wait)
+}
@@ -0,0 +1,63 @@
+package clusterdeprovision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	cases := []struct {
+		name            string
+		log             string
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "authentication failure",
+			log:             `level=fatal msg="AuthFailure: AWS was not able to validate the provided access credentials"`,
+			expectedReason:  authenticationFailedReason,
+			expectedMessage: "Uninstall failed to authenticate with the cloud API",
+		},
+		{
+			name: "throttled",
+			log: `level=debug msg="Deleted" arn="arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0"
+level=info msg="Throttling: Rate exceeded" arn="arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/a1b2c3/d4e5f6"`,
+			expectedReason:  throttledReason,
+			expectedMessage: "Uninstall was throttled by the cloud API. Resource types: elasticloadbalancing:loadbalancer",
+		},
+		{
+			name: "dependency violation",
+			log: `level=debug msg="DependencyViolation: resource sg-0123456789abcdef0 has a dependent object" arn="arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123456789abcdef0"
+level=debug msg="DependencyViolation: The vpc 'vpc-0123456789abcdef0' has dependencies and cannot be deleted." arn="arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789abcdef0"
+level=debug msg="DependencyViolation" arn="arn:aws:s3:::test-bucket"`,
+			expectedReason:  dependencyViolationReason,
+			expectedMessage: "Uninstall could not remove resources that other resources depend on. Resource types: ec2:security-group, ec2:vpc, s3",
+		},
+		{
+			name: "authentication failure takes precedence",
+			log: `level=debug msg="Throttling: Rate exceeded"
+level=fatal msg="ExpiredToken: The security token included in the request is expired"`,
+			expectedReason:  authenticationFailedReason,
+			expectedMessage: "Uninstall failed to authenticate with the cloud API",
+		},
+		{
+			name:            "unknown",
+			log:             "level=debug msg=\"Deleted\"\nlevel=fatal msg=\"something went wrong\"\n",
+			expectedReason:  unknownFailureReason,
+			expectedMessage: `Uninstall failed: level=fatal msg="something went wrong"`,
+		},
+		{
+			name:            "no log",
+			expectedReason:  unknownFailureReason,
+			expectedMessage: "Uninstall failed, see the uninstall job logs for details",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failure := classifyFailure(tc.log)
+			assert.Equal(t, tc.expectedReason, failure.reason, "unexpected reason")
+			assert.Equal(t, tc.expectedMessage, failure.message, "unexpected message")
+		})
+	}
+}
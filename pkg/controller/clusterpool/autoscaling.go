@@ -0,0 +1,108 @@
+package clusterpool
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// defaultReplenishWindow is the replenish window of an autoscaled pool that does not set one.
+	defaultReplenishWindow = 1 * time.Hour
+
+	// claimHistoryRetention is how long the claims of an autoscaled pool are counted in its claim history. A week
+	// covers the claims made at the same time on the previous week, such as on Monday mornings.
+	claimHistoryRetention = 7 * 24 * time.Hour
+)
+
+// recordClaim counts a claim of an autoscaled pool in the claim history of the pool, by the hour in which the claim
+// was created.
+func recordClaim(pool *hivev1.ClusterPool, claim *hivev1.ClusterClaim, now time.Time) {
+	if pool.Spec.Autoscaling == nil {
+		return
+	}
+	hour := claim.CreationTimestamp.Truncate(time.Hour)
+	if hour.Before(now.Truncate(time.Hour).Add(-claimHistoryRetention)) {
+		return
+	}
+	for i, entry := range pool.Status.ClaimHistory {
+		if entry.Hour.Equal(&metav1.Time{Time: hour}) {
+			pool.Status.ClaimHistory[i].Claims++
+			return
+		}
+	}
+	pool.Status.ClaimHistory = append(pool.Status.ClaimHistory, hivev1.ClaimHistoryEntry{
+		Hour:   metav1.NewTime(hour),
+		Claims: 1,
+	})
+	sort.Slice(pool.Status.ClaimHistory, func(i, j int) bool {
+		return pool.Status.ClaimHistory[i].Hour.Before(&pool.Status.ClaimHistory[j].Hour)
+	})
+}
+
+// setTargetSize sets the target size of the pool in its status, and returns it. The target size of a pool that is not
+// autoscaled is its size under the size schedule in effect, if any. The target size of an autoscaled pool
+// is the number of claims it expects within its replenish window, which is the larger of the number of claims in the
+// last window, and the number of claims in the coming window one week earlier, within the minimum and maximum sizes
+// of the pool. The claim history of the pool is pruned of the hours that are no longer needed.
+func setTargetSize(pool *hivev1.ClusterPool, now time.Time) int {
+	autoscaling := pool.Spec.Autoscaling
+	if autoscaling == nil {
+		pool.Status.ClaimHistory = nil
+		pool.Status.TargetSize = scheduledSize(pool)
+		return int(pool.Status.TargetSize)
+	}
+
+	currentHour := now.Truncate(time.Hour)
+	var history []hivev1.ClaimHistoryEntry
+	for _, entry := range pool.Status.ClaimHistory {
+		if !entry.Hour.Time.Before(currentHour.Add(-claimHistoryRetention)) {
+			history = append(history, entry)
+		}
+	}
+	pool.Status.ClaimHistory = history
+
+	window := defaultReplenishWindow
+	if autoscaling.ReplenishWindow != nil {
+		window = autoscaling.ReplenishWindow.Duration
+	}
+	windowHours := int((window + time.Hour - 1) / time.Hour)
+	if windowHours < 1 {
+		windowHours = 1
+	}
+	// The last window includes the current hour, and the coming window one week earlier starts with it.
+	recent, weekAgo := int32(0), int32(0)
+	for _, entry := range history {
+		if age := int(currentHour.Sub(entry.Hour.Time) / time.Hour); age < windowHours {
+			recent += entry.Claims
+		}
+		if sinceWeekAgo := int(entry.Hour.Time.Sub(currentHour.Add(-claimHistoryRetention)) / time.Hour); sinceWeekAgo >= 0 && sinceWeekAgo < windowHours {
+			weekAgo += entry.Claims
+		}
+	}
+
+	size := recent
+	if weekAgo > size {
+		size = weekAgo
+	}
+	if size < autoscaling.MinSize {
+		size = autoscaling.MinSize
+	}
+	if size > autoscaling.MaxSize {
+		size = autoscaling.MaxSize
+	}
+	pool.Status.TargetSize = size
+	return int(size)
+}
+
+// targetSize returns the number of unclaimed clusters the pool keeps. For an autoscaled pool, this is the target size
+// last set in its status.
+func targetSize(pool *hivev1.ClusterPool) int {
+	if pool.Spec.Autoscaling != nil {
+		return int(pool.Status.TargetSize)
+	}
+	return int(scheduledSize(pool))
+}
@@ -0,0 +1,124 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestSetTargetSize(t *testing.T) {
+	now := time.Date(2020, 6, 15, 9, 30, 0, 0, time.UTC)
+	hoursAgo := func(hours int, claims int32) hivev1.ClaimHistoryEntry {
+		return hivev1.ClaimHistoryEntry{
+			Hour:   metav1.NewTime(now.Truncate(time.Hour).Add(-time.Duration(hours) * time.Hour)),
+			Claims: claims,
+		}
+	}
+	week := 7 * 24
+	cases := []struct {
+		name               string
+		pool               *hivev1.ClusterPool
+		expectedSize       int
+		expectedHistoryLen int
+	}{
+		{
+			name:         "not autoscaled",
+			pool:         testcp.Build(testcp.WithSize(3)),
+			expectedSize: 3,
+		},
+		{
+			name:         "not autoscaled drops history",
+			pool:         testcp.Build(testcp.WithSize(3), testcp.WithClaimHistory(hoursAgo(0, 5))),
+			expectedSize: 3,
+		},
+		{
+			name:         "no claims",
+			pool:         testcp.Build(testcp.WithSize(3), testcp.WithAutoscaling(1, 10)),
+			expectedSize: 1,
+		},
+		{
+			name: "claims in the last window",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10),
+				testcp.WithClaimHistory(hoursAgo(1, 2), hoursAgo(0, 3)),
+			),
+			// The default window of 1h only covers the current hour.
+			expectedSize:       3,
+			expectedHistoryLen: 2,
+		},
+		{
+			name: "claims in a longer window",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10),
+				testcp.WithReplenishWindow(90*time.Minute),
+				testcp.WithClaimHistory(hoursAgo(2, 4), hoursAgo(1, 2), hoursAgo(0, 3)),
+			),
+			expectedSize:       5,
+			expectedHistoryLen: 3,
+		},
+		{
+			name: "claims in the coming window one week earlier",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 10),
+				testcp.WithReplenishWindow(2*time.Hour),
+				testcp.WithClaimHistory(hoursAgo(week, 4), hoursAgo(week-1, 3), hoursAgo(week-2, 9), hoursAgo(0, 1)),
+			),
+			expectedSize:       7,
+			expectedHistoryLen: 4,
+		},
+		{
+			name: "maximum size",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(1, 4),
+				testcp.WithClaimHistory(hoursAgo(0, 9)),
+			),
+			expectedSize:       4,
+			expectedHistoryLen: 1,
+		},
+		{
+			name: "old history pruned",
+			pool: testcp.Build(
+				testcp.WithAutoscaling(0, 10),
+				testcp.WithClaimHistory(hoursAgo(week+1, 6), hoursAgo(week, 2)),
+			),
+			expectedSize:       2,
+			expectedHistoryLen: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			size := setTargetSize(tc.pool, now)
+			assert.Equal(t, tc.expectedSize, size, "unexpected target size")
+			assert.Equal(t, int32(tc.expectedSize), tc.pool.Status.TargetSize, "unexpected target size in status")
+			assert.Len(t, tc.pool.Status.ClaimHistory, tc.expectedHistoryLen, "unexpected length of claim history")
+		})
+	}
+}
+
+func TestRecordClaim(t *testing.T) {
+	now := time.Date(2020, 6, 15, 9, 30, 0, 0, time.UTC)
+	claimCreatedAt := func(created time.Time) *hivev1.ClusterClaim {
+		return &hivev1.ClusterClaim{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	}
+	pool := testcp.Build(testcp.WithAutoscaling(0, 10))
+	recordClaim(pool, claimCreatedAt(now.Add(-10*time.Minute)), now)
+	recordClaim(pool, claimCreatedAt(now.Add(-20*time.Minute)), now)
+	recordClaim(pool, claimCreatedAt(now.Add(-2*time.Hour)), now)
+	recordClaim(pool, claimCreatedAt(now.Add(-8*24*time.Hour)), now)
+	if assert.Len(t, pool.Status.ClaimHistory, 2, "unexpected length of claim history") {
+		assert.Equal(t, time.Date(2020, 6, 15, 7, 0, 0, 0, time.UTC), pool.Status.ClaimHistory[0].Hour.UTC(), "unexpected hour")
+		assert.Equal(t, int32(1), pool.Status.ClaimHistory[0].Claims, "unexpected claims")
+		assert.Equal(t, time.Date(2020, 6, 15, 9, 0, 0, 0, time.UTC), pool.Status.ClaimHistory[1].Hour.UTC(), "unexpected hour")
+		assert.Equal(t, int32(2), pool.Status.ClaimHistory[1].Claims, "unexpected claims")
+	}
+
+	notAutoscaled := testcp.Build()
+	recordClaim(notAutoscaled, claimCreatedAt(now), now)
+	assert.Empty(t, notAutoscaled.Status.ClaimHistory, "expected no claim history for pool that is not autoscaled")
+}
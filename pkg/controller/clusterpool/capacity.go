@@ -0,0 +1,242 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// capacityRecheckInterval is how often a pool waiting for capacity in its capacity pool checks whether capacity has
+// been freed by the other pools of the capacity pool.
+const capacityRecheckInterval = 1 * time.Minute
+
+// provisionAllowance returns how many of the wanted new clusters the pool may provision now, and the name of the
+// capacity pool that limits it, if any. A pool that is not part of a capacity pool may provision all of them.
+// Otherwise, the allowance is the smallest one left by the capacity pools of the pool.
+func (r *ReconcileClusterPool) provisionAllowance(pool *hivev1.ClusterPool, wanted int, logger log.FieldLogger) (int, string, error) {
+	if ref := pool.Spec.CapacityPoolRef; ref != nil && r.capacityPool(ref.Name) == nil {
+		logger.WithField("capacityPool", ref.Name).Warn("capacity pool of ClusterPool is not defined in HiveConfig, provisioning is not limited by it")
+	}
+	capacityPools := r.capacityPoolsOf(pool)
+	if len(capacityPools) == 0 {
+		return wanted, "", nil
+	}
+
+	poolList := &hivev1.ClusterPoolList{}
+	if err := r.List(context.Background(), poolList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterPools")
+		return 0, "", errors.Wrap(err, "could not list ClusterPools")
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return 0, "", errors.Wrap(err, "could not list ClusterDeployments")
+	}
+
+	allowed := wanted
+	limitedBy := ""
+	for _, capacityPool := range capacityPools {
+		available := capacityPoolAvailability(capacityPool, pool, poolList.Items, cdList.Items, logger)
+		if available < allowed {
+			allowed = available
+			limitedBy = capacityPool.Name
+		}
+	}
+	logger.WithFields(log.Fields{
+		"wanted":    wanted,
+		"allowed":   allowed,
+		"limitedBy": limitedBy,
+	}).Debug("computed provision allowance of ClusterPool")
+	return allowed, limitedBy, nil
+}
+
+// capacityPoolAvailability returns how many new clusters the capacity pool has room for the pool to provision now.
+// The clusters still provisioning in the pools of the capacity pool count against its MaxConcurrentProvisions, and
+// all of their clusters against its MaxClusters. The remaining capacity goes first to the pools with a higher
+// priority. Pools with the same priority are served in the order of their namespaces and names.
+func capacityPoolAvailability(
+	capacityPool *hivev1.ClusterPoolCapacityPool,
+	pool *hivev1.ClusterPool,
+	pools []hivev1.ClusterPool,
+	cds []hivev1.ClusterDeployment,
+	logger log.FieldLogger,
+) int {
+	var members []*hivev1.ClusterPool
+	for i, p := range pools {
+		if p.DeletionTimestamp == nil && isCapacityPoolMember(capacityPool, &pools[i]) {
+			members = append(members, &pools[i])
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Spec.Priority != members[j].Spec.Priority {
+			return members[i].Spec.Priority > members[j].Spec.Priority
+		}
+		if members[i].Namespace != members[j].Namespace {
+			return members[i].Namespace < members[j].Namespace
+		}
+		return members[i].Name < members[j].Name
+	})
+
+	provisioning := 0
+	total := 0
+	reserve := map[types.NamespacedName]int{}
+	for _, cd := range cds {
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || cd.DeletionTimestamp != nil {
+			continue
+		}
+		key := types.NamespacedName{Namespace: poolRef.Namespace, Name: poolRef.PoolName}
+		if !containsPool(members, key) {
+			continue
+		}
+		total++
+		if !cd.Spec.Installed && poolRef.Quarantine == nil {
+			provisioning++
+		}
+		if poolRef.ClaimName == "" {
+			reserve[key]++
+		}
+	}
+
+	available := int(capacityPool.MaxConcurrentProvisions) - provisioning
+	if maxClusters := capacityPool.MaxClusters; maxClusters != nil && int(*maxClusters)-total < available {
+		available = int(*maxClusters) - total
+	}
+	for _, member := range members {
+		if available <= 0 {
+			break
+		}
+		if member.Namespace == pool.Namespace && member.Name == pool.Name {
+			break
+		}
+		// The pools ahead of this pool are expected to provision the clusters they are short of.
+		if needed := targetSize(member) - reserve[types.NamespacedName{Namespace: member.Namespace, Name: member.Name}]; needed > 0 {
+			available -= needed
+		}
+	}
+	if available < 0 {
+		available = 0
+	}
+	logger.WithFields(log.Fields{
+		"capacityPool":            capacityPool.Name,
+		"maxConcurrentProvisions": capacityPool.MaxConcurrentProvisions,
+		"provisioning":            provisioning,
+		"clusters":                total,
+		"available":               available,
+	}).Debug("computed availability of capacity pool")
+	return available
+}
+
+// capacityPool returns the capacity pool with the name, or nil if there is none.
+func (r *ReconcileClusterPool) capacityPool(name string) *hivev1.ClusterPoolCapacityPool {
+	for i, capacityPool := range r.capacityPools {
+		if capacityPool.Name == name {
+			return &r.capacityPools[i]
+		}
+	}
+	return nil
+}
+
+// capacityPoolsOf returns the capacity pools defined in HiveConfig that the pool is part of.
+func (r *ReconcileClusterPool) capacityPoolsOf(pool *hivev1.ClusterPool) []*hivev1.ClusterPoolCapacityPool {
+	var capacityPools []*hivev1.ClusterPoolCapacityPool
+	for i := range r.capacityPools {
+		if isCapacityPoolMember(&r.capacityPools[i], pool) {
+			capacityPools = append(capacityPools, &r.capacityPools[i])
+		}
+	}
+	return capacityPools
+}
+
+// isCapacityPoolMember returns true if the pool references the capacity pool, or uses one of its credentials
+// secrets for its platform or the platform of one of its flavors.
+func isCapacityPoolMember(capacityPool *hivev1.ClusterPoolCapacityPool, pool *hivev1.ClusterPool) bool {
+	if ref := pool.Spec.CapacityPoolRef; ref != nil && ref.Name == capacityPool.Name {
+		return true
+	}
+	if len(capacityPool.CredentialsSecretRefs) == 0 {
+		return false
+	}
+	platforms := []hivev1.Platform{pool.Spec.Platform}
+	for _, flavor := range pool.Spec.Flavors {
+		if flavor.Platform != nil {
+			platforms = append(platforms, *flavor.Platform)
+		}
+	}
+	for _, platform := range platforms {
+		name := credentialsSecretName(platform)
+		if name == "" {
+			continue
+		}
+		for _, ref := range capacityPool.CredentialsSecretRefs {
+			if ref.Namespace == pool.Namespace && ref.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// credentialsSecretName returns the name of the cloud credentials secret of the platform, or "" for platforms on
+// which pools cannot create clusters.
+func credentialsSecretName(platform hivev1.Platform) string {
+	switch {
+	case platform.AWS != nil:
+		return platform.AWS.CredentialsSecretRef.Name
+	case platform.GCP != nil:
+		return platform.GCP.CredentialsSecretRef.Name
+	case platform.Azure != nil:
+		return platform.Azure.CredentialsSecretRef.Name
+	}
+	return ""
+}
+
+func containsPool(pools []*hivev1.ClusterPool, key types.NamespacedName) bool {
+	for _, p := range pools {
+		if p.Namespace == key.Namespace && p.Name == key.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// setCapacityConstrainedCondition sets the CapacityConstrained condition of the pool from the number of clusters it
+// cannot provision yet for lack of capacity in the named capacity pool, and updates the status of the pool if it
+// changed.
+func (r *ReconcileClusterPool) setCapacityConstrainedCondition(pool *hivev1.ClusterPool, deferred int, capacityPool string, logger log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := "CapacityAvailable"
+	message := "The capacity pool has capacity for the clusters of the pool"
+	if deferred > 0 {
+		status = corev1.ConditionTrue
+		reason = "ProvisionsDeferred"
+		message = fmt.Sprintf("Waiting for capacity in capacity pool %s to provision %d clusters", capacityPool, deferred)
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolCapacityConstrainedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update CapacityConstrained condition")
+		return errors.Wrap(err, "could not update CapacityConstrained condition")
+	}
+	return nil
+}
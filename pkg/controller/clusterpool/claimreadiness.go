@@ -0,0 +1,100 @@
+package clusterpool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// enqueuePoolForClusterSync maps a ClusterSync to the pool of its ClusterDeployment, so that the pool can assign the
+// cluster to a claim once the syncsets of its claim readiness are applied.
+func (r *ReconcileClusterPool) enqueuePoolForClusterSync(o handler.MapObject) []reconcile.Request {
+	clusterSync, ok := o.Object.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return nil
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: clusterSync.Namespace, Name: clusterSync.Name}, cd); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.logger.WithError(err).Error("could not get ClusterDeployment for ClusterSync")
+		}
+		return nil
+	}
+	poolRef := cd.Spec.ClusterPoolRef
+	if poolRef == nil || poolRef.ClaimName != "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: poolRef.Namespace, Name: poolRef.PoolName}}}
+}
+
+// claimReadyClusters splits the ready clusters of the pool into those that may be assigned to claims, and those still
+// waiting for the syncsets of the claim readiness of the pool to be applied. The order of the clusters is kept.
+func (r *ReconcileClusterPool) claimReadyClusters(
+	pool *hivev1.ClusterPool,
+	cds []*hivev1.ClusterDeployment,
+	logger log.FieldLogger,
+) ([]*hivev1.ClusterDeployment, []*hivev1.ClusterDeployment, error) {
+	readiness := pool.Spec.ClaimReadiness
+	if readiness == nil || len(readiness.SyncSets)+len(readiness.SelectorSyncSets) == 0 {
+		return cds, nil, nil
+	}
+	var ready, waiting []*hivev1.ClusterDeployment
+	for _, cd := range cds {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+		case apierrors.IsNotFound(err):
+			clusterSync = nil
+		case err != nil:
+			logger.WithError(err).WithField("cluster", cd.Name).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+			return nil, nil, errors.Wrap(err, "could not get ClusterSync")
+		}
+		if missing := unappliedSyncSets(readiness, clusterSync); len(missing) > 0 {
+			logger.WithField("cluster", cd.Name).WithField("syncSets", missing).Debug("cluster is waiting for syncsets before it can be claimed")
+			waiting = append(waiting, cd)
+			continue
+		}
+		ready = append(ready, cd)
+	}
+	return ready, waiting, nil
+}
+
+// unappliedSyncSets returns the syncsets of the claim readiness that the ClusterSync does not report as successfully
+// applied, as Kind/name. All of them are returned when there is no ClusterSync.
+func unappliedSyncSets(readiness *hivev1.ClusterPoolClaimReadiness, clusterSync *hiveintv1alpha1.ClusterSync) []string {
+	var syncSets, selectorSyncSets []hiveintv1alpha1.SyncStatus
+	if clusterSync != nil {
+		syncSets = clusterSync.Status.SyncSets
+		selectorSyncSets = clusterSync.Status.SelectorSyncSets
+	}
+	var missing []string
+	for _, name := range readiness.SyncSets {
+		if !syncSetApplied(syncSets, name) {
+			missing = append(missing, "SyncSet/"+name)
+		}
+	}
+	for _, name := range readiness.SelectorSyncSets {
+		if !syncSetApplied(selectorSyncSets, name) {
+			missing = append(missing, "SelectorSyncSet/"+name)
+		}
+	}
+	return missing
+}
+
+func syncSetApplied(statuses []hiveintv1alpha1.SyncStatus, name string) bool {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status.Result == hiveintv1alpha1.SuccessSyncSetResult
+		}
+	}
+	return false
+}
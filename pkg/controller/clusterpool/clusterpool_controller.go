@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -26,6 +27,7 @@ import (
 
 	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/clusterresource"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
@@ -38,6 +40,15 @@ const (
 	imageSetDependent          = "cluster image set"
 	pullSecretDependent        = "pull secret"
 	credentialsSecretDependent = "credentials secret"
+	// defaultInstallAttemptsLimit is the install attempts limit for clusters of pools that do not set one.
+	defaultInstallAttemptsLimit = 3
+	// hibernationFailureQuarantineDelay is how long the machines of an unclaimed cluster may fail to stop before the
+	// cluster is quarantined. Errors stopping machines are retried, and are often transient.
+	hibernationFailureQuarantineDelay = 30 * time.Minute
+	// brokenClusterQuarantineDelay is how long a running unclaimed cluster may be unreachable or report a degraded
+	// ClusterVersion before the cluster is quarantined. Clusters are often briefly unreachable or degraded after they
+	// resume from hibernation.
+	brokenClusterQuarantineDelay = 30 * time.Minute
 )
 
 var (
@@ -54,7 +65,14 @@ func Add(mgr manager.Manager) error {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
-	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+	capacityPools, err := controllerutils.GetClusterPoolCapacityPoolsConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not get cluster pool capacity pools")
+		return err
+	}
+	r := NewReconciler(mgr, clientRateLimiter)
+	r.capacityPools = capacityPools
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
 }
 
 // NewReconciler returns a new ReconcileClusterPool
@@ -71,7 +89,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterpool-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -111,6 +129,20 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to ClusterDeploymentCustomizations in the inventory of a pool
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeploymentCustomization{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(r.enqueuePoolsForCustomization),
+	}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs of the clusters of a pool, for its claim readiness
+	if err := c.Watch(&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(r.enqueuePoolForClusterSync),
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -122,11 +154,13 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+	// capacityPools are the capacity pools shared by ClusterPools, if configured.
+	capacityPools []hivev1.ClusterPoolCapacityPool
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
 // attempts to reach the desired state if not.
-func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (result reconcile.Result, returnErr error) {
 	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterPool", request.NamespacedName)
 	logger.Infof("reconciling cluster pool")
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
@@ -146,7 +180,7 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	// If the pool is deleted, clear finalizer once all ClusterDeployments have been deleted.
+	// If the pool is deleted, clear finalizer once all of its unclaimed ClusterDeployments have been deleted.
 	if clp.DeletionTimestamp != nil {
 		return reconcile.Result{}, r.reconcileDeletedPool(clp, logger)
 	}
@@ -167,18 +201,86 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 	}
 
 	// Find all ClusterDeployments from this pool:
-	poolCDs, err := r.getAllUnclaimedClusterDeployments(clp, logger)
+	allPoolCDs, err := r.getAllClusterDeployments(clp, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	var poolCDs []*hivev1.ClusterDeployment
 	var installingCDs []*hivev1.ClusterDeployment
 	var readyCDs []*hivev1.ClusterDeployment
 	numberOfDeletingCDs := 0
-	for _, cd := range poolCDs {
+	numberOfQuarantinedCDs := 0
+	numberOfUnclaimedQuarantinedCDs := 0
+	now := time.Now()
+	origStatus := clp.Status.DeepCopy()
+	pruneUnavailableRegions(clp, now)
+	for _, cd := range allPoolCDs {
+		quarantined := cd.Spec.ClusterPoolRef.Quarantine != nil
+		if quarantined && cd.DeletionTimestamp == nil {
+			numberOfQuarantinedCDs++
+		}
+		// Claimed clusters are no longer part of the pool, and are only counted here when quarantined.
+		if cd.Spec.ClusterPoolRef.ClaimName != "" {
+			continue
+		}
+		poolCDs = append(poolCDs, cd)
+		deleting := cd.DeletionTimestamp != nil
+		// Clusters that failed to provision for lack of quota or capacity in their region are replaced in the next
+		// region rather than retried or quarantined.
+		if !quarantined && !deleting {
+			message, err := r.regionFailure(cd, logger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if message != "" && poolRegion(clp, cd.Spec.ClusterPoolRef.Region) != nil {
+				markRegionUnavailable(clp, cd.Spec.ClusterPoolRef.Region, message, now)
+				if next := nextRegion(clp); next.Name != cd.Spec.ClusterPoolRef.Region {
+					if err := r.relocateCluster(cd, next, logger); err != nil {
+						return reconcile.Result{}, err
+					}
+					deleting = true
+				}
+			}
+		}
+		if !quarantined && !deleting {
+			reason, message, recheckAfter := quarantineReason(cd, now)
+			if reason != "" {
+				if err := r.quarantineCluster(clp, cd, reason, message, logger); err != nil {
+					return reconcile.Result{}, err
+				}
+				quarantined = true
+				numberOfQuarantinedCDs++
+			} else if recheckAfter > 0 {
+				defer func() {
+					result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(recheckAfter, result, returnErr)
+				}()
+			}
+		}
+		if quarantined && !deleting && cd.Spec.Installed && clp.Spec.ReplaceBrokenClusters {
+			if err := r.replaceBrokenCluster(clp, cd, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			deleting = true
+			numberOfQuarantinedCDs--
+		}
+		if !quarantined && !deleting && cd.Spec.Installed {
+			if expiresIn, ok := clusterExpiresIn(clp, cd, now); ok && expiresIn <= 0 {
+				if err := r.recycleCluster(clp, cd, logger); err != nil {
+					return reconcile.Result{}, err
+				}
+				deleting = true
+			} else if ok {
+				defer func() {
+					result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(expiresIn, result, returnErr)
+				}()
+			}
+		}
 		switch {
-		case cd.DeletionTimestamp != nil:
+		case deleting:
 			numberOfDeletingCDs++
+		case quarantined:
+			numberOfUnclaimedQuarantinedCDs++
 		case !cd.Spec.Installed:
 			installingCDs = append(installingCDs, cd)
 		default:
@@ -186,16 +288,29 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 		}
 	}
 
+	// Unavailable regions are pruned once they are due to be retried.
+	if retryIn := regionRetryIn(clp, now); retryIn > 0 {
+		defer func() {
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(retryIn, result, returnErr)
+		}()
+	}
+
 	logger.WithFields(log.Fields{
-		"installing": len(installingCDs),
-		"deleting":   numberOfDeletingCDs,
-		"total":      len(poolCDs),
-		"ready":      len(readyCDs),
+		"installing":  len(installingCDs),
+		"deleting":    numberOfDeletingCDs,
+		"quarantined": numberOfQuarantinedCDs,
+		"total":       len(poolCDs),
+		"ready":       len(readyCDs),
 	}).Debug("found clusters for ClusterPool")
 
-	origStatus := clp.Status.DeepCopy()
+	if err := r.updateInventoryStatus(clp, allPoolCDs, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 	clp.Status.Size = int32(len(installingCDs) + len(readyCDs))
 	clp.Status.Ready = int32(len(readyCDs))
+	clp.Status.Quarantined = int32(numberOfQuarantinedCDs)
+	clp.Status.Outdated = int32(countOutdated(clp, installingCDs) + countOutdated(clp, readyCDs))
+	setAllClustersCurrentCondition(clp)
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
@@ -209,41 +324,179 @@ func (r *ReconcileClusterPool) Reconcile(request reconcile.Request) (reconcile.R
 	}
 	logger.WithField("count", len(pendingClaims)).Debug("found pending claims for ClusterPool")
 
-	// reserveSize is the number of clusters that the pool currently has in reserve
-	reserveSize := len(installingCDs) + len(readyCDs) - len(pendingClaims)
+	// reserveSize is the number of clusters that the pool currently has in reserve. Unclaimed quarantined clusters
+	// are counted so that they are not replaced, which would hide a systemic problem behind clusters being created
+	// over and over again.
+	reserveSize := len(installingCDs) + len(readyCDs) + numberOfUnclaimedQuarantinedCDs - len(pendingClaims)
+
+	// Assign running clusters to claims first, so that claims do not wait for clusters to resume.
+	sort.SliceStable(readyCDs, func(i, j int) bool {
+		return isRunning(readyCDs[i]) && !isRunning(readyCDs[j])
+	})
+	origStatus = clp.Status.DeepCopy()
+	if clp.Spec.Draining {
+		// The claims of a draining pool wait for the pool to stop draining.
+		_, err = r.assignClustersToClaims(clp, pendingClaims, nil, logger)
+	} else {
+		// Clusters still waiting for the syncsets of the claim readiness of the pool are kept for later claims.
+		var claimable, unconfigured []*hivev1.ClusterDeployment
+		claimable, unconfigured, err = r.claimReadyClusters(clp, readyCDs, logger)
+		if err == nil {
+			readyCDs, err = r.assignClustersToClaims(clp, pendingClaims, claimable, logger)
+			readyCDs = append(readyCDs, unconfigured...)
+		}
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := setSizeSchedule(clp, now); err != nil {
+		// The schedules are validated when the pool is admitted, so this only happens to pools admitted before then.
+		logger.WithError(err).Error("invalid size schedule, keeping the size of the pool")
+	}
+	if next := clp.Status.NextSizeChange; next != nil {
+		defer func() {
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(next.Sub(now), result, returnErr)
+		}()
+	}
+	// The target size of an autoscaled pool counts the claims that were just assigned clusters.
+	size := setTargetSize(clp, now)
+	if !reflect.DeepEqual(origStatus, &clp.Status) {
+		if err := r.Status().Update(context.Background(), clp); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update target size of ClusterPool")
+			return reconcile.Result{}, errors.Wrap(err, "could not update target size of ClusterPool")
+		}
+	}
 
-	readyCDs, err = r.assignClustersToClaims(pendingClaims, readyCDs, logger)
+	// surge is the number of clusters above the target size that the pool creates to replace outdated clusters.
+	surge := 0
+	if !clp.Spec.Draining && !clp.Spec.Paused {
+		var outdated []*hivev1.ClusterDeployment
+		outdated, surge = outdatedClustersToDelete(clp, size, installingCDs, readyCDs)
+		for _, cd := range outdated {
+			if err := r.deleteOutdatedCluster(cd, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		installingCDs = withoutClusters(installingCDs, outdated)
+		readyCDs = withoutClusters(readyCDs, outdated)
+		reserveSize -= len(outdated)
+	}
+
+	runningCount, nextScheduledChange, err := scheduledRunningCount(clp, len(installingCDs)+len(readyCDs), now)
 	if err != nil {
+		// The schedule is validated when the pool is admitted, so this only happens to pools admitted before then.
+		logger.WithError(err).Error("invalid hibernation schedule, keeping the running count of the pool running")
+		runningCount = int(clp.Spec.RunningCount)
+	}
+	if !nextScheduledChange.IsZero() {
+		defer func() {
+			result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(nextScheduledChange.Sub(now), result, returnErr)
+		}()
+	}
+	if err := r.setPowerStates(installingCDs, readyCDs, runningCount, logger); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	switch drift := reserveSize - int(clp.Spec.Size); {
+	// deferred is the number of clusters that the pool needs but cannot provision yet for lack of capacity in the
+	// capacity pool limitedBy.
+	deferred := 0
+	limitedBy := ""
+	// stalledReason and stalledMessage explain why the pool cannot provision the clusters it needs, if it cannot.
+	var stalledReason, stalledMessage string
+	if err := r.setModeConditions(clp, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	switch drift := reserveSize - (size + surge); {
+	// If draining, delete all of the unclaimed clusters that are not quarantined.
+	case clp.Spec.Draining:
+		if unclaimed := len(installingCDs) + len(readyCDs); unclaimed > 0 {
+			if err := r.deleteExcessClusters(installingCDs, readyCDs, unclaimed, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 	// If too many, delete some.
 	case drift > 0:
+		// Quarantined clusters are never deleted to reduce the size of the pool.
+		if deletable := len(installingCDs) + len(readyCDs); drift > deletable {
+			drift = deletable
+		}
+		if drift == 0 {
+			break
+		}
 		if err := r.deleteExcessClusters(installingCDs, readyCDs, drift, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+	case drift < 0 && clp.Spec.Paused:
+		logger.WithField("missing", -drift).Debug("not adding clusters since pool is paused")
+		stalledReason = "Paused"
+		stalledMessage = fmt.Sprintf("Pool is paused and short of %d clusters", -drift)
 	// If too few, create new InstallConfig and ClusterDeployment.
 	case drift < 0:
-		if err := r.addClusters(clp, -drift, logger); err != nil {
+		var toAdd int
+		toAdd, limitedBy, err = r.provisionAllowance(clp, -drift, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if deferred = -drift - toAdd; deferred > 0 {
+			// Provisions finishing in the other pools of the capacity pool do not trigger a reconcile of this pool.
+			defer func() {
+				result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(capacityRecheckInterval, result, returnErr)
+			}()
+		}
+		if toAdd == 0 {
+			stalledReason = "CapacityConstrained"
+			stalledMessage = fmt.Sprintf("Waiting for capacity in capacity pool %s to provision %d clusters", limitedBy, deferred)
+			break
+		}
+		added, err := r.addClusters(clp, toAdd, countFlavors(allPoolCDs), logger)
+		if err != nil {
 			log.WithError(err).Error("error adding clusters")
+			if cond := controllerutils.FindClusterPoolCondition(clp.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+				// The condition is logged if it cannot be updated; the error adding clusters is returned either way.
+				r.setProvisioningStalledCondition(clp, "MissingDependencies", cond.Message, logger)
+			}
 			return reconcile.Result{}, err
 		}
+		if added < toAdd {
+			stalledReason = "InventoryExhausted"
+			stalledMessage = fmt.Sprintf("No inventory entries left to provision %d clusters", toAdd-added)
+		}
+	}
+
+	if err := r.setCapacityConstrainedCondition(clp, deferred, limitedBy, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if stalledReason == "" {
+		if failed := countProvisionFailed(poolCDs); failed > 0 {
+			stalledReason = "ProvisionFailed"
+			stalledMessage = fmt.Sprintf("%d unclaimed clusters are quarantined because they failed to provision", failed)
+		}
+	}
+	if err := r.setProvisioningStalledCondition(clp, stalledReason, stalledMessage, logger); err != nil {
+		return reconcile.Result{}, err
 	}
 
 	return reconcile.Result{}, nil
 }
 
+// addClusters creates new clusters for the pool, and returns how many it created. Fewer than newClusterCount are
+// created when the inventory of the pool runs out of entries.
 func (r *ReconcileClusterPool) addClusters(
 	clp *hivev1.ClusterPool,
 	newClusterCount int,
+	flavorCounts map[string]int,
 	logger log.FieldLogger,
-) error {
+) (int, error) {
 	logger.WithField("count", newClusterCount).Info("Adding new clusters")
 
 	var errs []error
 
-	if err := r.verifyClusterImageSet(clp, logger); err != nil {
+	// New clusters are created in the region that is next when they are added.
+	region := nextRegion(clp)
+	imageSet := regionImageSet(clp, region)
+	if err := r.verifyClusterImageSet(imageSet.Name, logger); err != nil {
 		errs = append(errs, fmt.Errorf("%s: %w", imageSetDependent, err))
 	}
 
@@ -253,49 +506,88 @@ func (r *ReconcileClusterPool) addClusters(
 		errs = append(errs, fmt.Errorf("%s: %w", pullSecretDependent, err))
 	}
 
-	cloudBuilder, err := r.createCloudBuilder(clp, logger)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("%s: %w", credentialsSecretDependent, err))
+	// The cloud builders of the flavors of the pool, by name. The pool platform is used when the pool has no flavors.
+	cloudBuilders := map[string]clusterresource.CloudBuilder{}
+	if len(clp.Spec.Flavors) == 0 {
+		cloudBuilder, err := r.createCloudBuilder(clp, regionPlatform(clp.Spec.Platform, region), logger)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", credentialsSecretDependent, err))
+		}
+		cloudBuilders[""] = cloudBuilder
+	}
+	for i := range clp.Spec.Flavors {
+		flavor := &clp.Spec.Flavors[i]
+		cloudBuilder, err := r.createCloudBuilder(clp, regionPlatform(flavorPlatform(clp, flavor), region), logger.WithField("flavor", flavor.Name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: flavor %s: %w", credentialsSecretDependent, flavor.Name, err))
+		}
+		cloudBuilders[flavor.Name] = cloudBuilder
 	}
 
 	dependenciesError := utilerrors.NewAggregate(errs)
 
 	if err := r.setMissingDependenciesCondition(clp, dependenciesError, logger); err != nil {
-		return err
+		return 0, err
 	}
 
 	if dependenciesError != nil {
-		return dependenciesError
+		return 0, dependenciesError
 	}
 
-	for i := 0; i < newClusterCount; i++ {
-		if err := r.createCluster(clp, cloudBuilder, pullSecret, logger); err != nil {
-			return err
+	i := 0
+	for i < newClusterCount {
+		flavor := nextFlavor(clp, flavorCounts)
+		var customization *hivev1.ClusterDeploymentCustomization
+		if len(clp.Spec.Inventory) > 0 {
+			customization, err = r.nextInventoryEntry(clp, logger)
+			if err != nil {
+				return i, err
+			}
+			if customization == nil {
+				logger.Info("no inventory entries left for new clusters")
+				return i, nil
+			}
+		}
+		flavorName := ""
+		if flavor != nil {
+			flavorName = flavor.Name
+		}
+		created, err := r.createCluster(clp, flavor, region, cloudBuilders[flavorName], pullSecret, customization, logger)
+		if err != nil {
+			return i, err
+		}
+		if created {
+			flavorCounts[flavorName]++
+			i++
 		}
 	}
 
-	return nil
+	return i, nil
 }
 
+// createCluster creates a new cluster for the pool with the flavor and in the region, if any, customized by the
+// ClusterDeploymentCustomization of an inventory entry when one is given. No cluster is created when the customization
+// cannot be applied; the entry is marked broken instead, and false is returned.
 func (r *ReconcileClusterPool) createCluster(
 	clp *hivev1.ClusterPool,
+	flavor *hivev1.ClusterPoolFlavor,
+	region *hivev1.ClusterPoolRegion,
 	cloudBuilder clusterresource.CloudBuilder,
 	pullSecret string,
+	customization *hivev1.ClusterDeploymentCustomization,
 	logger log.FieldLogger,
-) error {
-	ns, err := r.createRandomNamespace(clp)
+) (bool, error) {
+	specHash, err := clusterSpecHash(clp, flavor, region)
 	if err != nil {
-		logger.WithError(err).Error("error obtaining random namespace")
-		return err
+		return false, errors.Wrap(err, "could not hash the spec of the pool")
 	}
-	logger.WithField("cluster", ns.Name).Info("Creating new cluster")
-
 	// We will use this unique random namespace name for our cluster name.
+	name := apihelpers.GetResourceName(clp.Name, utilrand.String(5))
 	builder := &clusterresource.Builder{
-		Name:             ns.Name,
-		Namespace:        ns.Name,
+		Name:             name,
+		Namespace:        name,
 		BaseDomain:       clp.Spec.BaseDomain,
-		ImageSet:         clp.Spec.ImageSetRef.Name,
+		ImageSet:         regionImageSet(clp, region).Name,
 		WorkerNodesCount: int64(3),
 		MachineNetwork:   "10.0.0.0/16",
 		PullSecret:       pullSecret,
@@ -305,8 +597,27 @@ func (r *ReconcileClusterPool) createCluster(
 
 	objs, err := builder.Build()
 	if err != nil {
-		return errors.Wrap(err, "error building resources")
+		return false, errors.Wrap(err, "error building resources")
+	}
+	if flavor != nil && len(flavor.InstallConfigPatches) > 0 {
+		if err := applyInstallConfigPatches(objs, flavor.InstallConfigPatches); err != nil {
+			logger.WithField("flavor", flavor.Name).WithError(err).Error("could not apply install-config patches of flavor")
+			return false, errors.Wrapf(err, "could not apply install-config patches of flavor %s", flavor.Name)
+		}
+	}
+	if customization != nil {
+		if err := applyCustomization(objs, customization); err != nil {
+			return false, r.markInventoryEntryBroken(clp, customization, err, logger)
+		}
 	}
+
+	ns, err := r.createNamespace(clp, name)
+	if err != nil {
+		logger.WithError(err).Error("error creating namespace")
+		return false, err
+	}
+	logger.WithField("cluster", ns.Name).Info("Creating new cluster")
+
 	poolKey := types.NamespacedName{Namespace: clp.Namespace, Name: clp.Name}.String()
 	r.expectations.ExpectCreations(poolKey, 1)
 	// Add the ClusterPoolRef to the ClusterDeployment, and move it to the end of the slice.
@@ -316,8 +627,26 @@ func (r *ReconcileClusterPool) createCluster(
 			continue
 		}
 		poolRef := poolReference(clp)
+		if customization != nil {
+			poolRef.CustomizationRef = &corev1.LocalObjectReference{Name: customization.Name}
+		}
+		if flavor != nil {
+			poolRef.Flavor = flavor.Name
+		}
+		if region != nil {
+			poolRef.Region = region.Name
+		}
 		cd.Spec.ClusterPoolRef = &poolRef
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.ClusterPoolSpecHashAnnotation] = specHash
 		cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+		cd.Spec.InstallAttemptsLimit = clp.Spec.InstallAttemptsLimit
+		if cd.Spec.InstallAttemptsLimit == nil {
+			limit := int32(defaultInstallAttemptsLimit)
+			cd.Spec.InstallAttemptsLimit = &limit
+		}
 		lastIndex := len(objs) - 1
 		objs[i], objs[lastIndex] = objs[lastIndex], objs[i]
 	}
@@ -325,15 +654,17 @@ func (r *ReconcileClusterPool) createCluster(
 	for _, obj := range objs {
 		if err := r.Client.Create(context.Background(), obj); err != nil {
 			r.expectations.CreationObserved(poolKey)
-			return err
+			return false, err
 		}
 	}
+	if customization != nil {
+		setInventoryEntryState(clp, customization.Name, hivev1.InventoryEntryConsumed, ns.Name, "", 0)
+	}
 
-	return nil
+	return true, nil
 }
 
-func (r *ReconcileClusterPool) createRandomNamespace(clp *hivev1.ClusterPool) (*corev1.Namespace, error) {
-	namespaceName := apihelpers.GetResourceName(clp.Name, utilrand.String(5))
+func (r *ReconcileClusterPool) createNamespace(clp *hivev1.ClusterPool, namespaceName string) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: namespaceName,
@@ -388,15 +719,209 @@ func (r *ReconcileClusterPool) deleteExcessClusters(
 	return nil
 }
 
+// deleteOutdatedCluster deletes an unclaimed cluster that was created from an earlier version of the spec of the pool.
+func (r *ReconcileClusterPool) deleteOutdatedCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger = logger.WithField("cluster", cd.Name)
+	logger.Info("deleting outdated cluster")
+	if err := r.Delete(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete outdated cluster")
+		return errors.Wrap(err, "could not delete outdated cluster")
+	}
+	return nil
+}
+
+// withoutClusters returns the clusters that are not in the excluded clusters.
+func withoutClusters(cds, excluded []*hivev1.ClusterDeployment) []*hivev1.ClusterDeployment {
+	if len(excluded) == 0 {
+		return cds
+	}
+	var remaining []*hivev1.ClusterDeployment
+	for _, cd := range cds {
+		keep := true
+		for _, e := range excluded {
+			if cd == e {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, cd)
+		}
+	}
+	return remaining
+}
+
+// setPowerStates keeps the running count of the unclaimed clusters of the pool running, and the rest hibernating.
+// Installed clusters are kept running before installing ones, and clusters that are already running before those
+// that are not, so that the fewest clusters change power state.
+func (r *ReconcileClusterPool) setPowerStates(
+	installingClusters []*hivev1.ClusterDeployment,
+	readyClusters []*hivev1.ClusterDeployment,
+	runningCount int,
+	logger log.FieldLogger,
+) error {
+	cds := make([]*hivev1.ClusterDeployment, 0, len(readyClusters)+len(installingClusters))
+	cds = append(cds, readyClusters...)
+	cds = append(cds, installingClusters...)
+	sort.SliceStable(cds, func(i, j int) bool {
+		if cds[i].Spec.Installed != cds[j].Spec.Installed {
+			return cds[i].Spec.Installed
+		}
+		return cds[i].Spec.PowerState == hivev1.RunningClusterPowerState && cds[j].Spec.PowerState != hivev1.RunningClusterPowerState
+	})
+	for i, cd := range cds {
+		powerState := hivev1.HibernatingClusterPowerState
+		if i < runningCount {
+			powerState = hivev1.RunningClusterPowerState
+		}
+		if cd.Spec.PowerState == powerState {
+			continue
+		}
+		logger := logger.WithField("cluster", cd.Name).WithField("powerState", powerState)
+		logger.Info("changing power state of unclaimed cluster")
+		cd.Spec.PowerState = powerState
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not change power state of cluster")
+			return errors.Wrap(err, "could not change power state of cluster")
+		}
+	}
+	return nil
+}
+
+// isRunning returns true if the cluster is running, rather than hibernating or resuming.
+func isRunning(cd *hivev1.ClusterDeployment) bool {
+	if cd.Spec.PowerState != hivev1.RunningClusterPowerState {
+		return false
+	}
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	return cond != nil && cond.Reason == hivev1.RunningHibernationReason
+}
+
+// quarantineReason returns the reason and message with which an unclaimed cluster should be quarantined, if any.
+// When the cluster may need to be quarantined later, the time after which it should be checked again is returned.
+func quarantineReason(cd *hivev1.ClusterDeployment, now time.Time) (hivev1.ClusterPoolQuarantineReason, string, time.Duration) {
+	if !cd.Spec.Installed {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			return hivev1.ClusterPoolQuarantineProvisionFailed, cond.Message, 0
+		}
+		return "", "", 0
+	}
+	hibernatingCond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCond == nil {
+		return "", "", 0
+	}
+	switch hibernatingCond.Reason {
+	case hivev1.FailedToStartHibernationReason:
+		return hivev1.ClusterPoolQuarantineResumeFailed, hibernatingCond.Message, 0
+	case hivev1.FailedToStopHibernationReason:
+		if failingFor := now.Sub(hibernatingCond.LastProbeTime.Time); failingFor < hibernationFailureQuarantineDelay {
+			return "", "", hibernationFailureQuarantineDelay - failingFor
+		}
+		return hivev1.ClusterPoolQuarantineHibernationFailed, hibernatingCond.Message, 0
+	}
+	if !isRunning(cd) {
+		return "", "", 0
+	}
+	// Hibernating clusters are expected to be unreachable, so a running cluster is only broken once it has been
+	// unreachable or degraded for a while since it started running.
+	var recheckAfter time.Duration
+	for _, broken := range []struct {
+		conditionType hivev1.ClusterDeploymentConditionType
+		reason        hivev1.ClusterPoolQuarantineReason
+	}{
+		{conditionType: hivev1.UnreachableCondition, reason: hivev1.ClusterPoolQuarantineUnreachable},
+		{conditionType: hivev1.ClusterVersionDegradedCondition, reason: hivev1.ClusterPoolQuarantineClusterVersionDegraded},
+	} {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, broken.conditionType)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		since := cond.LastTransitionTime.Time
+		if hibernatingCond.LastTransitionTime.After(since) {
+			since = hibernatingCond.LastTransitionTime.Time
+		}
+		if brokenFor := now.Sub(since); brokenFor < brokenClusterQuarantineDelay {
+			if wait := brokenClusterQuarantineDelay - brokenFor; recheckAfter == 0 || wait < recheckAfter {
+				recheckAfter = wait
+			}
+			continue
+		}
+		return broken.reason, cond.Message, 0
+	}
+	return "", "", recheckAfter
+}
+
+// quarantineCluster quarantines a cluster of the pool with the given reason.
+func (r *ReconcileClusterPool) quarantineCluster(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, reason hivev1.ClusterPoolQuarantineReason, message string, logger log.FieldLogger) error {
+	logger = logger.WithField("cluster", cd.Name).WithField("reason", reason)
+	logger.WithField("message", message).Warn("quarantining cluster")
+	cd.Spec.ClusterPoolRef.Quarantine = &hivev1.ClusterPoolQuarantine{
+		Reason:  reason,
+		Message: message,
+		Time:    metav1.Now(),
+	}
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not quarantine cluster")
+		return errors.Wrap(err, "could not quarantine cluster")
+	}
+	hivemetrics.MetricClusterPoolClustersQuarantinedTotal.WithLabelValues(pool.Namespace, pool.Name, string(reason)).Inc()
+	return nil
+}
+
+// replaceBrokenCluster deletes an installed unclaimed cluster of the pool that was quarantined because it broke. The
+// pool replaces it like any other cluster it is short of.
+func (r *ReconcileClusterPool) replaceBrokenCluster(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	reason := cd.Spec.ClusterPoolRef.Quarantine.Reason
+	logger = logger.WithField("cluster", cd.Name).WithField("reason", reason)
+	logger.Info("deleting broken cluster to replace it")
+	if err := r.Delete(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete broken cluster")
+		return errors.Wrap(err, "could not delete broken cluster")
+	}
+	hivemetrics.MetricClusterPoolClustersReplacedTotal.WithLabelValues(pool.Namespace, pool.Name, string(reason)).Inc()
+	return nil
+}
+
+// clusterExpiresIn returns how long until an unclaimed cluster of the pool exceeds the maximum cluster age of the pool,
+// and whether the pool has a maximum cluster age.
+func clusterExpiresIn(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, now time.Time) (time.Duration, bool) {
+	if pool.Spec.MaxClusterAge == nil {
+		return 0, false
+	}
+	return cd.CreationTimestamp.Add(pool.Spec.MaxClusterAge.Duration).Sub(now), true
+}
+
+// recycleCluster deletes an unclaimed cluster of the pool that exceeded the maximum cluster age of the pool. The pool
+// replaces it like any other cluster it is short of.
+func (r *ReconcileClusterPool) recycleCluster(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger = logger.WithField("cluster", cd.Name)
+	logger.WithField("maxClusterAge", pool.Spec.MaxClusterAge.Duration).Info("deleting cluster that exceeded the maximum cluster age")
+	if err := r.Delete(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster that exceeded the maximum cluster age")
+		return errors.Wrap(err, "could not delete cluster that exceeded the maximum cluster age")
+	}
+	hivemetrics.MetricClusterPoolClustersRecycledTotal.WithLabelValues(pool.Namespace, pool.Name).Inc()
+	return nil
+}
+
+// reconcileDeletedPool drains a deleted pool. The pool deletes its unclaimed clusters, and keeps its finalizer until they
+// are gone, so that the pool is only removed once its clusters have been deprovisioned.
 func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
 	if !controllerutils.HasFinalizer(pool, finalizer) {
 		return nil
 	}
-	poolCDs, err := r.getAllUnclaimedClusterDeployments(pool, logger)
+	poolCDs, err := r.getAllClusterDeployments(pool, logger)
 	if err != nil {
 		return err
 	}
+	remaining := 0
 	for _, cd := range poolCDs {
+		// Claimed clusters belong to their claims, unless they were quarantined while being handed off.
+		if cd.Spec.ClusterPoolRef.ClaimName != "" && cd.Spec.ClusterPoolRef.Quarantine == nil {
+			continue
+		}
+		remaining++
 		if cd.DeletionTimestamp != nil {
 			continue
 		}
@@ -405,6 +930,26 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 			return errors.Wrap(err, "could not delete ClusterDeployment")
 		}
 	}
+	if remaining > 0 {
+		logger.WithField("remaining", remaining).Info("waiting for the clusters of the deleted pool to be deleted")
+		conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.ClusterPoolDrainingCondition,
+			corev1.ConditionTrue,
+			"PoolDeleted",
+			fmt.Sprintf("Pool is deleted and waiting for %d clusters to be deleted", remaining),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			return nil
+		}
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+		return nil
+	}
 	controllerutils.DeleteFinalizer(pool, finalizer)
 	if err := r.Update(context.Background(), pool); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterPool")
@@ -413,7 +958,9 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 	return nil
 }
 
-func (r *ReconcileClusterPool) getAllUnclaimedClusterDeployments(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+// getAllClusterDeployments returns all of the ClusterDeployments created for the pool, including those that have
+// been claimed.
+func (r *ReconcileClusterPool) getAllClusterDeployments(pool *hivev1.ClusterPool, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
 	cdList := &hivev1.ClusterDeploymentList{}
 	if err := r.Client.List(context.Background(), cdList); err != nil {
 		logger.WithError(err).Error("error listing ClusterDeployments")
@@ -421,7 +968,7 @@ func (r *ReconcileClusterPool) getAllUnclaimedClusterDeployments(pool *hivev1.Cl
 	}
 	var poolCDs []*hivev1.ClusterDeployment
 	for i, cd := range cdList.Items {
-		if refInCD := cd.Spec.ClusterPoolRef; refInCD != nil && *refInCD == poolReference(pool) {
+		if refInCD := cd.Spec.ClusterPoolRef; refInCD != nil && refInCD.Namespace == pool.Namespace && refInCD.PoolName == pool.Name {
 			poolCDs = append(poolCDs, &cdList.Items[i])
 		}
 	}
@@ -477,8 +1024,102 @@ func (r *ReconcileClusterPool) setMissingDependenciesCondition(pool *hivev1.Clus
 	return nil
 }
 
-func (r *ReconcileClusterPool) verifyClusterImageSet(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
-	err := r.Get(context.Background(), client.ObjectKey{Name: pool.Spec.ImageSetRef.Name}, &hivev1.ClusterImageSet{})
+// setProvisioningStalledCondition sets the ProvisioningStalled condition of the pool to true with the reason and
+// message, or to false when the reason is empty, and updates the status of the pool if it changed.
+func (r *ReconcileClusterPool) setProvisioningStalledCondition(pool *hivev1.ClusterPool, reason, message string, logger log.FieldLogger) error {
+	status := corev1.ConditionTrue
+	if reason == "" {
+		status = corev1.ConditionFalse
+		reason = "NotStalled"
+		message = "Pool is provisioning the clusters it needs"
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolProvisioningStalledCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ProvisioningStalled condition")
+		return errors.Wrap(err, "could not update ProvisioningStalled condition")
+	}
+	return nil
+}
+
+// countProvisionFailed counts the unclaimed clusters that are quarantined because they failed to provision.
+func countProvisionFailed(cds []*hivev1.ClusterDeployment) int {
+	count := 0
+	for _, cd := range cds {
+		if q := cd.Spec.ClusterPoolRef.Quarantine; q != nil && q.Reason == hivev1.ClusterPoolQuarantineProvisionFailed && cd.DeletionTimestamp == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// setModeConditions sets the Paused and Draining conditions of the pool from its spec.
+func (r *ReconcileClusterPool) setModeConditions(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
+	conds := pool.Status.Conditions
+	changed := false
+	for _, mode := range []struct {
+		conditionType hivev1.ClusterPoolConditionType
+		enabled       bool
+		reason        string
+		message       string
+		offReason     string
+		offMessage    string
+	}{
+		{
+			conditionType: hivev1.ClusterPoolPausedCondition,
+			enabled:       pool.Spec.Paused,
+			reason:        "Paused",
+			message:       "Pool is paused and does not create new clusters",
+			offReason:     "NotPaused",
+			offMessage:    "Pool is not paused",
+		},
+		{
+			conditionType: hivev1.ClusterPoolDrainingCondition,
+			enabled:       pool.Spec.Draining,
+			reason:        "Draining",
+			message:       "Pool is draining and does not assign clusters to claims",
+			offReason:     "NotDraining",
+			offMessage:    "Pool is not draining",
+		},
+	} {
+		status, reason, message := corev1.ConditionFalse, mode.offReason, mode.offMessage
+		if mode.enabled {
+			status, reason, message = corev1.ConditionTrue, mode.reason, mode.message
+		}
+		var modeChanged bool
+		conds, modeChanged = controllerutils.SetClusterPoolConditionWithChangeCheck(
+			conds,
+			mode.conditionType,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		changed = changed || modeChanged
+	}
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+		return fmt.Errorf("could not update ClusterPool conditions: %w", err)
+	}
+	return nil
+}
+
+func (r *ReconcileClusterPool) verifyClusterImageSet(name string, logger log.FieldLogger) error {
+	err := r.Get(context.Background(), client.ObjectKey{Name: name}, &hivev1.ClusterImageSet{})
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster image set")
 	}
@@ -507,8 +1148,8 @@ func (r *ReconcileClusterPool) getPullSecret(pool *hivev1.ClusterPool, logger lo
 	return string(pullSecret), nil
 }
 
-func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
-	switch platform := pool.Spec.Platform; {
+func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, platform hivev1.Platform, logger log.FieldLogger) (clusterresource.CloudBuilder, error) {
+	switch {
 	case platform.AWS != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.AWS.CredentialsSecretRef.Name, logger)
 		if err != nil {
@@ -516,6 +1157,7 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 		}
 		cloudBuilder := clusterresource.NewAWSCloudBuilderFromSecret(credsSecret)
 		cloudBuilder.Region = platform.AWS.Region
+		cloudBuilder.Subnets = platform.AWS.Subnets
 		return cloudBuilder, nil
 	case platform.GCP != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.GCP.CredentialsSecretRef.Name, logger)
@@ -528,6 +1170,10 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 			return nil, err
 		}
 		cloudBuilder.Region = platform.GCP.Region
+		cloudBuilder.NetworkProjectID = platform.GCP.NetworkProjectID
+		cloudBuilder.Network = platform.GCP.Network
+		cloudBuilder.ControlPlaneSubnet = platform.GCP.ControlPlaneSubnet
+		cloudBuilder.ComputeSubnet = platform.GCP.ComputeSubnet
 		return cloudBuilder, nil
 	case platform.Azure != nil:
 		credsSecret, err := r.getCredentialsSecret(pool, platform.Azure.CredentialsSecretRef.Name, logger)
@@ -537,6 +1183,10 @@ func (r *ReconcileClusterPool) createCloudBuilder(pool *hivev1.ClusterPool, logg
 		cloudBuilder := clusterresource.NewAzureCloudBuilderFromSecret(credsSecret)
 		cloudBuilder.BaseDomainResourceGroupName = platform.Azure.BaseDomainResourceGroupName
 		cloudBuilder.Region = platform.Azure.Region
+		cloudBuilder.NetworkResourceGroupName = platform.Azure.NetworkResourceGroupName
+		cloudBuilder.VirtualNetwork = platform.Azure.VirtualNetwork
+		cloudBuilder.ControlPlaneSubnet = platform.Azure.ControlPlaneSubnet
+		cloudBuilder.ComputeSubnet = platform.Azure.ComputeSubnet
 		return cloudBuilder, nil
 	// TODO: OpenStack, VMware, and Ovirt.
 	default:
@@ -565,16 +1215,22 @@ func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPo
 		}
 		pendingClaims = append(pendingClaims, &claimsList.Items[i])
 	}
+	// Claims are served in the order in which they were created. Claims created within the same second are served in
+	// the order of their names, so that their queue positions do not change from one reconcile to the next.
 	sort.Slice(
 		pendingClaims,
 		func(i, j int) bool {
-			return pendingClaims[i].CreationTimestamp.Before(&pendingClaims[j].CreationTimestamp)
+			if !pendingClaims[i].CreationTimestamp.Equal(&pendingClaims[j].CreationTimestamp) {
+				return pendingClaims[i].CreationTimestamp.Before(&pendingClaims[j].CreationTimestamp)
+			}
+			return pendingClaims[i].Name < pendingClaims[j].Name
 		},
 	)
 	return pendingClaims, nil
 }
 
-func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+func (r *ReconcileClusterPool) assignClustersToClaims(pool *hivev1.ClusterPool, claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+	var queuePosition int32
 	for _, claim := range claims {
 		logger := logger.WithField("claim", claim.Name)
 		var conds []hivev1.ClusterClaimCondition
@@ -595,17 +1251,31 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 				"Cluster assigned to ClusterClaim, awaiting claim",
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			claim.Status.Lifetime = controllerutils.ClusterClaimLifetime(pool, claim)
+			claim.Status.QueuePosition = 0
+			recordClaim(pool, claim, time.Now())
+			hivemetrics.MetricClusterPoolClaimFulfillmentSeconds.WithLabelValues(pool.Namespace, pool.Name).
+				Observe(time.Since(claim.CreationTimestamp.Time).Seconds())
 			statusChanged = true
 		} else {
 			logger.Debug("no clusters ready to assign to claim")
+			reason, message := "NoClusters", "No clusters in pool are ready to be claimed"
+			if pool.Spec.Draining {
+				reason, message = "PoolDraining", "Pool is draining and does not assign clusters to claims"
+			}
 			conds, statusChanged = controllerutils.SetClusterClaimConditionWithChangeCheck(
 				claim.Status.Conditions,
 				hivev1.ClusterClaimPendingCondition,
 				corev1.ConditionTrue,
-				"NoClusters",
-				"No clusters in pool are ready to be claimed",
+				reason,
+				message,
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			queuePosition++
+			if claim.Status.QueuePosition != queuePosition {
+				claim.Status.QueuePosition = queuePosition
+				statusChanged = true
+			}
 		}
 		if statusChanged {
 			claim.Status.Conditions = conds
@@ -2,6 +2,7 @@ package clusterpool
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -21,10 +22,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 	testsecret "github.com/openshift/hive/pkg/test/secret"
 )
@@ -39,6 +45,7 @@ const (
 func TestReconcileClusterPool(t *testing.T) {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
 	corev1.AddToScheme(scheme)
 
 	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
@@ -55,11 +62,40 @@ func TestReconcileClusterPool(t *testing.T) {
 			testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
 		)
 	}
+	// The spec hash of the clusters created for the pools of the tests that do not have flavors.
+	specHash, err := clusterSpecHash(poolBuilder.Build(), nil, nil)
+	require.NoError(t, err)
 	unclaimedCDBuilder := func(name string) testcd.Builder {
 		return cdBuilder(name).Options(
 			testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+			testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, specHash)),
 		)
 	}
+	outdated := testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, "outdated"))
+	otherPoolBuilder := testcp.FullBuilder("other-namespace", "other-pool", scheme).
+		GenericOptions(
+			testgeneric.WithFinalizer(finalizer),
+		).
+		Options(
+			testcp.ForAWS(credsSecretName, "us-east-1"),
+			testcp.WithBaseDomain("test-domain"),
+			testcp.WithImageSet(imageSetName),
+			testcp.WithCapacityPool("shared"),
+		)
+	otherPoolCDBuilder := func(name string) testcd.Builder {
+		return cdBuilder(name).Options(
+			testcd.WithUnclaimedClusterPoolReference("other-namespace", "other-pool"),
+		)
+	}
+	sharedCapacityPool := []hivev1.ClusterPoolCapacityPool{{Name: "shared", MaxConcurrentProvisions: 3}}
+	running := []testcd.Option{
+		testcd.WithPowerState(hivev1.RunningClusterPowerState),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.ClusterHibernatingCondition,
+			Status: corev1.ConditionFalse,
+			Reason: hivev1.RunningHibernationReason,
+		}),
+	}
 
 	tests := []struct {
 		name                               string
@@ -70,13 +106,37 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectedTotalClusters              int
 		expectedObservedSize               int32
 		expectedObservedReady              int32
+		expectedObservedQuarantined        int32
+		expectedObservedOutdated           int32
+		expectedQuarantined                map[string]hivev1.ClusterPoolQuarantineReason
+		expectedInstallAttemptsLimit       *int32
+		expectRequeueAfter                 bool
 		expectedDeletedClusters            []string
 		expectFinalizerRemoved             bool
+		expectDeletionPending              bool
 		expectedMissingDependenciesStatus  *bool
 		expectedMissingDependenciesMessage string
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedCustomizations             []string
+		expectedInventory                  map[string]hivev1.InventoryEntryState
+		expectedInventoryBroken            bool
+		expectedMachineNetwork             string // Tested on all new clusters.
+		expectedFlavors                    map[string]int
+		expectedFlavorRegions              map[string]string
+		expectedRegions                    map[string]int
+		expectedRegionImageSets            map[string]string
+		expectedUnavailableRegions         []string
+		expectedRunning                    []string
+		expectedAssignedCluster            string
+		expectedClaimLifetimes             map[string]*metav1.Duration
+		expectedQueuePositions             map[string]int32
+		capacityPools                      []hivev1.ClusterPoolCapacityPool
+		expectCapacityConstrained          bool
+		expectPaused                       bool
+		expectDraining                     bool
+		expectedStalledReason              string
 	}{
 		{
 			name: "create all clusters",
@@ -143,6 +203,148 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   0,
 			expectedDeletedClusters: []string{"c2"},
 		},
+		{
+			name: "recycle clusters older than maximum age",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-48 * time.Hour)),
+				).Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-1 * time.Hour)),
+				).Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    1,
+			expectedObservedReady:   1,
+			expectedDeletedClusters: []string{"c1"},
+			expectRequeueAfter:      true,
+		},
+		{
+			name: "do not recycle installing clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-48 * time.Hour)),
+				).Build(),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "assign cluster within maximum age to claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithMaxClusterAge(24*time.Hour)),
+				unclaimedCDBuilder("c1").GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-48 * time.Hour)),
+				).Build(append(running, testcd.Installed())...),
+				unclaimedCDBuilder("c2").GenericOptions(
+					testgeneric.WithCreationTimestamp(time.Now().Add(-1 * time.Hour)),
+				).Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedDeletedClusters:  []string{"c1"},
+			expectedAssignedClaims:   1,
+			expectedAssignedCluster:  "c2",
+			expectedUnassignedClaims: 0,
+		},
+		{
+			name: "claim readiness holds back clusters until syncsets are applied",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithClaimReadiness([]string{"config"}, nil)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				testcs.FullBuilder("c2", "c2", scheme).Build(
+					testcs.WithSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "config", Result: hiveintv1alpha1.SuccessSyncSetResult}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   1,
+			expectedAssignedCluster:  "c2",
+			expectedUnassignedClaims: 0,
+		},
+		{
+			name: "claim waits for failed selector syncset",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithClaimReadiness([]string{"config"}, []string{"global"})),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				testcs.FullBuilder("c1", "c1", scheme).Build(
+					testcs.WithSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "config", Result: hiveintv1alpha1.SuccessSyncSetResult}),
+					testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "global", Result: hiveintv1alpha1.FailureSyncSetResult}),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "autoscale pool to claim history",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 5),
+					testcp.WithClaimHistory(hivev1.ClaimHistoryEntry{Hour: metav1.NewTime(time.Now().Truncate(time.Hour)), Claims: 3}),
+				),
+			},
+			expectedTotalClusters: 3,
+		},
+		{
+			name: "autoscale pool within maximum size",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscaling(1, 2),
+					testcp.WithClaimHistory(hivev1.ClaimHistoryEntry{Hour: metav1.NewTime(time.Now().Truncate(time.Hour)), Claims: 3}),
+				),
+			},
+			expectedTotalClusters: 2,
+		},
+		{
+			name: "size schedule in effect",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithSizeSchedules(hivev1.ClusterPoolSizeSchedule{Name: "always", Start: "* * * * *", Size: 3}),
+				),
+			},
+			expectedTotalClusters: 3,
+			expectRequeueAfter:    true,
+		},
+		{
+			name: "size schedule not yet started",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithSizeSchedules(hivev1.ClusterPoolSizeSchedule{Name: "never", Start: "0 0 30 2 *", Size: 3}),
+				),
+			},
+			expectedTotalClusters: 1,
+		},
+		{
+			name: "autoscale pool for assigned claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(0, 5)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(time.Now())).
+					Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+		},
 		{
 			name: "delete installed clusters when there are not enough installing to delete",
 			existing: []runtime.Object{
@@ -167,7 +369,33 @@ func TestReconcileClusterPool(t *testing.T) {
 				unclaimedCDBuilder("c2").Build(),
 				unclaimedCDBuilder("c3").Build(),
 			},
-			expectedTotalClusters:  0,
+			expectedTotalClusters: 0,
+			expectDeletionPending: true,
+			expectDraining:        true,
+		},
+		{
+			name: "finalizer kept while clusters of deleted clusterpool are deleting",
+			existing: []runtime.Object{
+				poolBuilder.GenericOptions(testgeneric.Deleted()).Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").GenericOptions(
+					testgeneric.WithFinalizer("test-finalizer"),
+					testgeneric.Deleted(),
+				).Build(),
+			},
+			expectedTotalClusters: 1,
+			expectDeletionPending: true,
+			expectDraining:        true,
+		},
+		{
+			name: "finalizer removed once clusters of deleted clusterpool are gone",
+			existing: []runtime.Object{
+				poolBuilder.GenericOptions(testgeneric.Deleted()).Build(testcp.WithSize(1)),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.Installed(),
+				),
+			},
+			expectedTotalClusters:  1,
 			expectFinalizerRemoved: true,
 		},
 		{
@@ -248,6 +476,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `cluster image set: clusterimagesets.hive.openshift.io "test-image-set" not found`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "missing creds secret",
@@ -258,6 +487,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `credentials secret: secrets "aws-creds" not found`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "missing ClusterImageSet",
@@ -268,6 +498,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `cluster image set: clusterimagesets.hive.openshift.io "test-image-set" not found`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "multiple missing dependents",
@@ -279,6 +510,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `[cluster image set: clusterimagesets.hive.openshift.io "test-image-set" not found, credentials secret: secrets "aws-creds" not found]`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "missing dependents resolved",
@@ -322,6 +554,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `pull secret: secrets "test-pull-secret" not found`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "pull secret missing docker config",
@@ -335,6 +568,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectError:                        true,
 			expectedMissingDependenciesStatus:  pointer.BoolPtr(true),
 			expectedMissingDependenciesMessage: `pull secret: pull secret does not contain .dockerconfigjson data`,
+			expectedStalledReason:              "MissingDependencies",
 		},
 		{
 			name: "assign to claim",
@@ -352,72 +586,1132 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedUnassignedClaims: 0,
 		},
 		{
-			name: "no ready clusters to assign to claim",
+			name: "assign to claims with claim lifetimes",
 			existing: []runtime.Object{
-				poolBuilder.Build(testcp.WithSize(3)),
-				unclaimedCDBuilder("c1").Build(),
-				unclaimedCDBuilder("c2").Build(),
-				unclaimedCDBuilder("c3").Build(),
-				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				poolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithDefaultClaimLifetime(1*time.Hour),
+					testcp.WithMaximumClaimLifetime(3*time.Hour),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "default-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "short-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithLifetime(30*time.Minute),
+				),
+				testclaim.FullBuilder(testNamespace, "long-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithLifetime(5*time.Hour),
+				),
 			},
-			expectedTotalClusters:    4,
+			expectedTotalClusters:    6,
 			expectedObservedSize:     3,
-			expectedObservedReady:    0,
-			expectedAssignedClaims:   0,
-			expectedUnassignedClaims: 1,
+			expectedObservedReady:    3,
+			expectedAssignedClaims:   3,
+			expectedUnassignedClaims: 0,
+			expectedClaimLifetimes: map[string]*metav1.Duration{
+				"default-claim": {Duration: 1 * time.Hour},
+				"short-claim":   {Duration: 30 * time.Minute},
+				"long-claim":    {Duration: 3 * time.Hour},
+			},
 		},
 		{
-			name: "assign to multiple claims",
+			name: "assign to claim without claim lifetime",
 			existing: []runtime.Object{
-				poolBuilder.Build(testcp.WithSize(3)),
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithMaximumClaimLifetime(3*time.Hour)),
 				unclaimedCDBuilder("c1").Build(testcd.Installed()),
-				unclaimedCDBuilder("c2").Build(testcd.Installed()),
-				unclaimedCDBuilder("c3").Build(),
-				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
-				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
-				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+			expectedClaimLifetimes: map[string]*metav1.Duration{
+				"test-claim": {Duration: 3 * time.Hour},
 			},
-			expectedTotalClusters:    6,
-			expectedObservedSize:     3,
-			expectedObservedReady:    2,
-			expectedAssignedClaims:   2,
-			expectedUnassignedClaims: 1,
 		},
 		{
-			name: "do not assign to claims for other pools",
+			name: "assign to claim without pool claim lifetimes",
 			existing: []runtime.Object{
-				poolBuilder.Build(testcp.WithSize(3)),
+				poolBuilder.Build(testcp.WithSize(1)),
 				unclaimedCDBuilder("c1").Build(testcd.Installed()),
-				unclaimedCDBuilder("c2").Build(testcd.Installed()),
-				unclaimedCDBuilder("c3").Build(),
-				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool("other-pool")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+			expectedClaimLifetimes: map[string]*metav1.Duration{
+				"test-claim": nil,
 			},
-			expectedTotalClusters:    3,
-			expectedObservedSize:     3,
-			expectedObservedReady:    2,
-			expectedAssignedClaims:   0,
-			expectedUnassignedClaims: 1,
 		},
 		{
-			name: "do not assign to claims in other namespaces",
+			name: "capacity pool limits provisions",
 			existing: []runtime.Object{
-				poolBuilder.Build(testcp.WithSize(3)),
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithCapacityPool("shared")),
+				otherPoolBuilder.Build(testcp.WithSize(1)),
+				otherPoolCDBuilder("o1").Build(),
+			},
+			capacityPools:             sharedCapacityPool,
+			expectedTotalClusters:     3,
+			expectedObservedSize:      0,
+			expectedObservedReady:     0,
+			expectRequeueAfter:        true,
+			expectCapacityConstrained: true,
+		},
+		{
+			name: "higher priority pool provisions first",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithCapacityPool("shared")),
+				otherPoolBuilder.Build(testcp.WithSize(3), testcp.WithPriority(10)),
+				otherPoolCDBuilder("o1").Build(),
+			},
+			capacityPools:             sharedCapacityPool,
+			expectedTotalClusters:     1,
+			expectedObservedSize:      0,
+			expectedObservedReady:     0,
+			expectRequeueAfter:        true,
+			expectCapacityConstrained: true,
+			expectedStalledReason:     "CapacityConstrained",
+		},
+		{
+			name: "lower priority pool does not hold back provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithCapacityPool("shared"), testcp.WithPriority(10)),
+				otherPoolBuilder.Build(testcp.WithSize(3)),
+			},
+			capacityPools:         sharedCapacityPool,
+			expectedTotalClusters: 2,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "installed clusters do not count against capacity pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithCapacityPool("shared")),
+				otherPoolBuilder.Build(testcp.WithSize(3)),
+				otherPoolCDBuilder("o1").Build(testcd.Installed()),
+				otherPoolCDBuilder("o2").Build(testcd.Installed()),
+				otherPoolCDBuilder("o3").Build(testcd.Installed()),
+			},
+			capacityPools:         sharedCapacityPool,
+			expectedTotalClusters: 6,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "undefined capacity pool does not limit provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithCapacityPool("unknown")),
+			},
+			capacityPools:         sharedCapacityPool,
+			expectedTotalClusters: 4,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "capacity pool limits total clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithCapacityPool("quota")),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.Installed(),
+				),
+			},
+			capacityPools:             []hivev1.ClusterPoolCapacityPool{{Name: "quota", MaxConcurrentProvisions: 10, MaxClusters: pointer.Int32Ptr(3)}},
+			expectedTotalClusters:     3,
+			expectedObservedSize:      0,
+			expectedObservedReady:     0,
+			expectRequeueAfter:        true,
+			expectCapacityConstrained: true,
+		},
+		{
+			name: "capacity pool of credentials secret limits provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4)),
+			},
+			capacityPools: []hivev1.ClusterPoolCapacityPool{{
+				Name:                    "account",
+				MaxConcurrentProvisions: 2,
+				CredentialsSecretRefs:   []corev1.SecretReference{{Namespace: testNamespace, Name: credsSecretName}},
+			}},
+			expectedTotalClusters:     2,
+			expectedObservedSize:      0,
+			expectedObservedReady:     0,
+			expectRequeueAfter:        true,
+			expectCapacityConstrained: true,
+		},
+		{
+			name: "capacity pool of credentials secret in other namespace does not limit provisions",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4)),
+			},
+			capacityPools: []hivev1.ClusterPoolCapacityPool{{
+				Name:                    "account",
+				MaxConcurrentProvisions: 2,
+				CredentialsSecretRefs:   []corev1.SecretReference{{Namespace: "other-namespace", Name: credsSecretName}},
+			}},
+			expectedTotalClusters: 4,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "capacity constrained condition cleared",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.WithCapacityPool("shared"),
+					testcp.WithCondition(hivev1.ClusterPoolCondition{
+						Type:   hivev1.ClusterPoolCapacityConstrainedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "ProvisionsDeferred",
+					}),
+				),
+			},
+			capacityPools:         sharedCapacityPool,
+			expectedTotalClusters: 2,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+		},
+		{
+			name: "keep running count of clusters running",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{"c1", "c3"},
+		},
+		{
+			name: "keep running clusters running",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(1)),
 				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(append(running, testcd.Installed())...),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 3,
+			expectedRunning:       []string{"c2"},
+		},
+		{
+			name: "hibernate clusters beyond running count",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(append(running, testcd.Installed())...),
 				unclaimedCDBuilder("c2").Build(testcd.Installed()),
-				unclaimedCDBuilder("c3").Build(),
-				testclaim.FullBuilder("other-namespace", "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
 			},
-			expectedTotalClusters:    3,
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{},
+		},
+		{
+			name: "assign running cluster to claim and resume another",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithRunningCount(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(append(running, testcd.Installed())...),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    4,
 			expectedObservedSize:     3,
-			expectedObservedReady:    2,
-			expectedAssignedClaims:   0,
-			expectedUnassignedClaims: 1,
+			expectedObservedReady:    3,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 0,
+			expectedAssignedCluster:  "c2",
+			expectedRunning:          []string{"c1", "c2"},
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if !test.noClusterImageSet {
+		{
+			name: "resume clusters on schedule",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithHibernationSchedule("0 0 30 2 *", "* * * * *")),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(append(running, testcd.Installed())...),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{"c1", "c2", "c3"},
+			expectRequeueAfter:    true,
+		},
+		{
+			name: "hibernate clusters on schedule",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(2), testcp.WithHibernationSchedule("* * * * *", "0 0 30 2 *")),
+				unclaimedCDBuilder("c1").Build(append(running, testcd.Installed())...),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{},
+			expectRequeueAfter:    true,
+		},
+		{
+			name: "keep running count of clusters running before schedule activates",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(1), testcp.WithHibernationSchedule("0 0 30 2 *", "0 0 31 4 *")),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(append(running, testcd.Installed())...),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{"c2"},
+		},
+		{
+			name: "keep running count of clusters running with invalid schedule",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRunningCount(1), testcp.WithHibernationSchedule("every evening", "* * * * *")),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(append(running, testcd.Installed())...),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedRunning:       []string{"c2"},
+		},
+		{
+			name: "queue claims in order of creation",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "claim-c", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "claim-b", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC))).
+					Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "claim-a", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC))).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithQueuePosition(2)),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 2,
+			expectedQueuePositions: map[string]int32{
+				"claim-c": 0,
+				"claim-a": 1,
+				"claim-b": 2,
+			},
+		},
+		{
+			name: "no ready clusters to assign to claim",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(),
+				unclaimedCDBuilder("c2").Build(),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    0,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "assign to multiple claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    6,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "do not assign to claims for other pools",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool("other-pool")),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "do not assign to claims in other namespaces",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder("other-namespace", "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
+		{
+			name: "new clusters limited to default install attempts",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+			},
+			expectedTotalClusters:        1,
+			expectedInstallAttemptsLimit: pointer.Int32Ptr(3),
+		},
+		{
+			name: "new clusters limited to install attempts of pool",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), func(pool *hivev1.ClusterPool) {
+					pool.Spec.InstallAttemptsLimit = pointer.Int32Ptr(5)
+				}),
+			},
+			expectedTotalClusters:        1,
+			expectedInstallAttemptsLimit: pointer.Int32Ptr(5),
+		},
+		{
+			name: "quarantine cluster with stopped provision",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:    hivev1.ProvisionStoppedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "InstallAttemptsLimitReached",
+					Message: "Install attempts limit reached",
+				})),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        2,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedQuarantined: map[string]hivev1.ClusterPoolQuarantineReason{
+				"c1": hivev1.ClusterPoolQuarantineProvisionFailed,
+			},
+			expectedStalledReason: "ProvisionFailed",
+		},
+		{
+			name: "quarantine cluster that failed to resume",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+					Reason: hivev1.FailedToStartHibernationReason,
+				})),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedQuarantined: map[string]hivev1.ClusterPoolQuarantineReason{
+				"c1": hivev1.ClusterPoolQuarantineResumeFailed,
+			},
+		},
+		{
+			name: "quarantine cluster that failed to stop for too long",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:          hivev1.ClusterHibernatingCondition,
+					Status:        corev1.ConditionFalse,
+					Reason:        hivev1.FailedToStopHibernationReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				})),
+			},
+			expectedTotalClusters:       1,
+			expectedObservedSize:        0,
+			expectedObservedReady:       0,
+			expectedObservedQuarantined: 1,
+			expectedQuarantined: map[string]hivev1.ClusterPoolQuarantineReason{
+				"c1": hivev1.ClusterPoolQuarantineHibernationFailed,
+			},
+		},
+		{
+			name: "do not quarantine cluster that recently failed to stop",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:          hivev1.ClusterHibernatingCondition,
+					Status:        corev1.ConditionFalse,
+					Reason:        hivev1.FailedToStopHibernationReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+				})),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectRequeueAfter:    true,
+		},
+		{
+			name: "quarantine running cluster that is unreachable for too long",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Options(running...).Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.UnreachableCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				})),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedRunning:             []string{"c1"},
+			expectedQuarantined: map[string]hivev1.ClusterPoolQuarantineReason{
+				"c1": hivev1.ClusterPoolQuarantineUnreachable,
+			},
+		},
+		{
+			name: "quarantine running cluster with degraded cluster version",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Options(running...).Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.ClusterVersionDegradedCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				})),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedRunning:             []string{"c1"},
+			expectedQuarantined: map[string]hivev1.ClusterPoolQuarantineReason{
+				"c1": hivev1.ClusterPoolQuarantineClusterVersionDegraded,
+			},
+		},
+		{
+			name: "do not quarantine cluster that recently became unreachable",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Options(running...).Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.UnreachableCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+				})),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectRequeueAfter:    true,
+		},
+		{
+			name: "do not quarantine hibernating cluster that is unreachable",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.UnreachableCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				})),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+		},
+		{
+			name: "replace broken clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithReplaceBrokenClusters()),
+				unclaimedCDBuilder("c1").Options(running...).Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:               hivev1.UnreachableCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				})),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.Quarantined(hivev1.ClusterPoolQuarantineResumeFailed)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    1,
+			expectedObservedReady:   1,
+			expectedDeletedClusters: []string{"c1", "c2"},
+		},
+		{
+			name: "clusters that failed to provision are not replaced",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithReplaceBrokenClusters()),
+				unclaimedCDBuilder("c1").Build(testcd.Quarantined(hivev1.ClusterPoolQuarantineProvisionFailed)),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedStalledReason:       "ProvisionFailed",
+		},
+		{
+			name: "quarantined clusters are not replaced",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Quarantined(hivev1.ClusterPoolQuarantineProvisionFailed)),
+				unclaimedCDBuilder("c2").Build(testcd.Quarantined(hivev1.ClusterPoolQuarantineProvisionFailed)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 2,
+			expectedStalledReason:       "ProvisionFailed",
+		},
+		{
+			name: "quarantined clusters are not deleted when scaling down",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Quarantined(hivev1.ClusterPoolQuarantineProvisionFailed)),
+				unclaimedCDBuilder("c2").Build(testcd.Quarantined(hivev1.ClusterPoolQuarantineProvisionFailed)),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 2,
+			expectedDeletedClusters:     []string{"c3"},
+			expectedStalledReason:       "ProvisionFailed",
+		},
+		{
+			name: "quarantined clusters are not assigned to claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.Quarantined(hivev1.ClusterPoolQuarantineResumeFailed)),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        0,
+			expectedObservedReady:       0,
+			expectedObservedQuarantined: 1,
+			expectedAssignedClaims:      0,
+			expectedUnassignedClaims:    1,
+		},
+		{
+			name: "claimed quarantined clusters are counted but not replaced",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.Installed(),
+					testcd.Quarantined(hivev1.ClusterPoolQuarantineClaimHandoffFailed),
+				),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+		},
+		{
+			name: "quarantined clusters deleted when clusterpool deleted",
+			existing: []runtime.Object{
+				poolBuilder.GenericOptions(testgeneric.Deleted()).Build(testcp.WithSize(1)),
+				cdBuilder("c1").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.Installed(),
+					testcd.Quarantined(hivev1.ClusterPoolQuarantineClaimHandoffFailed),
+				),
+				cdBuilder("c2").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "other-claim"),
+					testcd.Installed(),
+				),
+			},
+			expectedTotalClusters:   1,
+			expectedDeletedClusters: []string{"c1"},
+			expectDeletionPending:   true,
+			expectDraining:          true,
+		},
+		{
+			name: "inventory limits new clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithInventory("cdc1", "cdc2")),
+				testCustomization("cdc1"),
+				testCustomization("cdc2"),
+			},
+			expectedTotalClusters:  2,
+			expectedCustomizations: []string{"cdc1", "cdc2"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryConsumed,
+				"cdc2": hivev1.InventoryEntryConsumed,
+			},
+			expectedStalledReason: "InventoryExhausted",
+		},
+		{
+			name: "inventory entry consumed by existing cluster",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2")),
+				testCustomization("cdc1"),
+				testCustomization("cdc2"),
+				unclaimedCDBuilder("c1").Build(testcd.WithCustomization("cdc1")),
+			},
+			expectedTotalClusters:  2,
+			expectedObservedSize:   1,
+			expectedCustomizations: []string{"cdc1", "cdc2"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryConsumed,
+				"cdc2": hivev1.InventoryEntryAvailable,
+			},
+		},
+		{
+			name: "missing customization is broken",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2")),
+				testCustomization("cdc2"),
+			},
+			expectedTotalClusters:  1,
+			expectedCustomizations: []string{"cdc2"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryBroken,
+				"cdc2": hivev1.InventoryEntryConsumed,
+			},
+			expectedInventoryBroken: true,
+			expectedStalledReason:   "InventoryExhausted",
+		},
+		{
+			name: "customization that cannot be applied is broken",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithInventory("cdc1", "cdc2")),
+				testCustomization("cdc1", hivev1.PatchEntity{Op: "replace", Path: "/missing/field", Value: "x"}),
+				testCustomization("cdc2"),
+			},
+			expectedTotalClusters:  1,
+			expectedCustomizations: []string{"cdc2"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryBroken,
+				"cdc2": hivev1.InventoryEntryConsumed,
+			},
+			expectedInventoryBroken: true,
+			expectedStalledReason:   "InventoryExhausted",
+		},
+		{
+			name: "customization stays broken until updated",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithInventory("cdc1"),
+					testcp.WithInventoryStatus(hivev1.InventoryEntryStatus{
+						Name:               "cdc1",
+						State:              hivev1.InventoryEntryBroken,
+						Message:            "could not apply install-config patches",
+						ObservedGeneration: 1,
+					}),
+				),
+				testCustomization("cdc1"),
+			},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryBroken,
+			},
+			expectedInventoryBroken: true,
+			expectedStalledReason:   "InventoryExhausted",
+		},
+		{
+			name: "updated customization is retried",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithInventory("cdc1"),
+					testcp.WithInventoryStatus(hivev1.InventoryEntryStatus{
+						Name:               "cdc1",
+						State:              hivev1.InventoryEntryBroken,
+						Message:            "could not apply install-config patches",
+						ObservedGeneration: 1,
+					}),
+				),
+				func() runtime.Object {
+					cdc := testCustomization("cdc1")
+					cdc.Generation = 2
+					return cdc
+				}(),
+			},
+			expectedTotalClusters:  1,
+			expectedCustomizations: []string{"cdc1"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryAvailable,
+			},
+		},
+		{
+			name: "customization patches install config",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithInventory("cdc1")),
+				testCustomization("cdc1", hivev1.PatchEntity{Op: "replace", Path: "/networking/machineNetwork/0/cidr", Value: "10.1.0.0/16"}),
+			},
+			expectedTotalClusters:  1,
+			expectedCustomizations: []string{"cdc1"},
+			expectedInventory: map[string]hivev1.InventoryEntryState{
+				"cdc1": hivev1.InventoryEntryAvailable,
+			},
+			expectedMachineNetwork: "10.1.0.0/16",
+		},
+		{
+			name: "paused pool does not create clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithPaused()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectPaused:          true,
+			expectedStalledReason: "Paused",
+		},
+		{
+			name: "paused pool assigns clusters to claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithPaused()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:  1,
+			expectedObservedSize:   1,
+			expectedObservedReady:  1,
+			expectedAssignedClaims: 1,
+			expectPaused:           true,
+			expectedStalledReason:  "Paused",
+		},
+		{
+			name: "paused pool still deletes excess clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithPaused()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectPaused:          true,
+		},
+		{
+			name: "draining pool deletes unclaimed clusters and does not assign claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithDraining()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(),
+				cdBuilder("c3").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "other-claim"),
+					testcd.Installed(),
+				),
+				unclaimedCDBuilder("c4").Build(testcd.Installed(), testcd.Quarantined(hivev1.ClusterPoolQuarantineResumeFailed)),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        2,
+			expectedObservedReady:       1,
+			expectedObservedQuarantined: 1,
+			expectedDeletedClusters:     []string{"c1", "c2"},
+			expectedUnassignedClaims:    1,
+			expectDraining:              true,
+		},
+		{
+			name: "outdated clusters kept without update strategy",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), outdated),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), outdated),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedObservedOutdated: 2,
+		},
+		{
+			name: "update strategy surges before deleting outdated clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithUpdateStrategy(0, 1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), outdated),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), outdated),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedObservedOutdated: 2,
+		},
+		{
+			name: "update strategy deletes outdated cluster once replacement is ready",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithUpdateStrategy(0, 1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), outdated),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), outdated),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     3,
+			expectedObservedReady:    3,
+			expectedObservedOutdated: 2,
+			expectedDeletedClusters:  []string{"c1"},
+		},
+		{
+			name: "update strategy deletes outdated clusters within max unavailable",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithUpdateStrategy(1, 0)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), outdated),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), outdated),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedObservedOutdated: 2,
+			expectedDeletedClusters:  []string{"c1"},
+		},
+		{
+			name: "update strategy deletes outdated installing clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithUpdateStrategy(0, 1)),
+				unclaimedCDBuilder("c1").Build(outdated),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedObservedOutdated: 1,
+			expectedDeletedClusters:  []string{"c1"},
+		},
+		{
+			name: "update strategy replaces clusters of removed flavor",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithUpdateStrategy(1, 0),
+					testcp.WithFlavors(hivev1.ClusterPoolFlavor{Name: "east", Weight: 1}),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithFlavor("west")),
+			},
+			expectedTotalClusters:    1,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedObservedOutdated: 1,
+			expectedDeletedClusters:  []string{"c1"},
+			expectedFlavors:          map[string]int{"east": 1},
+		},
+		{
+			name: "paused pool does not replace outdated clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithUpdateStrategy(1, 1), testcp.WithPaused()),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), outdated),
+			},
+			expectedTotalClusters:    1,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedObservedOutdated: 1,
+			expectPaused:             true,
+		},
+		{
+			name: "clusters spread across flavors by weight",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithFlavors(
+					hivev1.ClusterPoolFlavor{Name: "east", Weight: 3},
+					hivev1.ClusterPoolFlavor{Name: "west", Weight: 1, Platform: &hivev1.Platform{
+						AWS: &hivev1aws.Platform{
+							CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecretName},
+							Region:               "us-west-2",
+						},
+					}},
+				)),
+			},
+			expectedTotalClusters: 4,
+			expectedFlavors:       map[string]int{"east": 3, "west": 1},
+			expectedFlavorRegions: map[string]string{"east": "us-east-1", "west": "us-west-2"},
+		},
+		{
+			name: "new clusters balance existing flavors",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithFlavors(
+					hivev1.ClusterPoolFlavor{Name: "east", Weight: 1},
+					hivev1.ClusterPoolFlavor{Name: "west", Weight: 1},
+				)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithFlavor("east")),
+				cdBuilder("c2").Build(
+					testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					testcd.WithFlavor("east"),
+				),
+			},
+			expectedTotalClusters: 4,
+			expectedObservedSize:  1,
+			expectedObservedReady: 1,
+			expectedFlavors:       map[string]int{"east": 2, "west": 2},
+		},
+		{
+			name: "flavor patches install config",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithFlavors(hivev1.ClusterPoolFlavor{
+					Name:   "big-network",
+					Weight: 1,
+					InstallConfigPatches: []hivev1.PatchEntity{
+						{Op: "replace", Path: "/networking/machineNetwork/0/cidr", Value: "10.2.0.0/16"},
+					},
+				})),
+			},
+			expectedTotalClusters:  1,
+			expectedFlavors:        map[string]int{"big-network": 1},
+			expectedMachineNetwork: "10.2.0.0/16",
+		},
+		{
+			name: "clusters created in first region",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRegions(
+					hivev1.ClusterPoolRegion{Name: "us-east-2"},
+					hivev1.ClusterPoolRegion{Name: "us-west-2"},
+				)),
+			},
+			expectedTotalClusters: 2,
+			expectedRegions:       map[string]int{"us-east-2": 2},
+		},
+		{
+			name: "quota failure moves clusters to next region",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRegions(
+					hivev1.ClusterPoolRegion{Name: "us-east-2"},
+					hivev1.ClusterPoolRegion{Name: "us-west-2", ImageSetRef: &hivev1.ClusterImageSetReference{Name: "west-image-set"}},
+				)),
+				&hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: "west-image-set"}},
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithRegion("us-east-2")),
+				unclaimedCDBuilder("c2").Build(
+					testcd.WithRegion("us-east-2"),
+					testcd.WithProvisionRef("c2-provision"),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "AWSVCPULimitExceeded",
+					}),
+				),
+				&hivev1.ClusterProvision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "c2", Name: "c2-provision"},
+					Status: hivev1.ClusterProvisionStatus{
+						Conditions: []hivev1.ClusterProvisionCondition{{
+							Type:    hivev1.ClusterProvisionFailedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "AWSVCPULimitExceeded",
+							Message: "AWS vCPU limit exceeded",
+						}},
+						ErrorClass: string(errorclass.QuotaError),
+					},
+				},
+			},
+			expectedTotalClusters:      2,
+			expectedObservedSize:       1,
+			expectedObservedReady:      1,
+			expectedDeletedClusters:    []string{"c2"},
+			expectedRegions:            map[string]int{"us-east-2": 1, "us-west-2": 1},
+			expectedRegionImageSets:    map[string]string{"us-east-2": imageSetName, "us-west-2": "west-image-set"},
+			expectedUnavailableRegions: []string{"us-east-2"},
+			expectRequeueAfter:         true,
+		},
+		{
+			name: "capacity failure moves clusters to next region",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithRegions(
+					hivev1.ClusterPoolRegion{Name: "us-east-2"},
+					hivev1.ClusterPoolRegion{Name: "us-west-2", ImageSetRef: &hivev1.ClusterImageSetReference{Name: "west-image-set"}},
+				)),
+				&hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: "west-image-set"}},
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.WithRegion("us-east-2")),
+				unclaimedCDBuilder("c2").Build(
+					testcd.WithRegion("us-east-2"),
+					testcd.WithProvisionRef("c2-provision"),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "AWSInsufficientInstanceCapacity",
+					}),
+				),
+				&hivev1.ClusterProvision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "c2", Name: "c2-provision"},
+					Status: hivev1.ClusterProvisionStatus{
+						Conditions: []hivev1.ClusterProvisionCondition{{
+							Type:    hivev1.ClusterProvisionFailedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "AWSInsufficientInstanceCapacity",
+							Message: "AWS does not have enough capacity for the instance type in the region",
+						}},
+						ErrorClass: string(errorclass.CapacityError),
+					},
+				},
+			},
+			expectedTotalClusters:      2,
+			expectedObservedSize:       1,
+			expectedObservedReady:      1,
+			expectedDeletedClusters:    []string{"c2"},
+			expectedRegions:            map[string]int{"us-east-2": 1, "us-west-2": 1},
+			expectedRegionImageSets:    map[string]string{"us-east-2": imageSetName, "us-west-2": "west-image-set"},
+			expectedUnavailableRegions: []string{"us-east-2"},
+			expectRequeueAfter:         true,
+		},
+		{
+			name: "failure not caused by quota is retried in the same region",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1), testcp.WithRegions(
+					hivev1.ClusterPoolRegion{Name: "us-east-2"},
+					hivev1.ClusterPoolRegion{Name: "us-west-2"},
+				)),
+				unclaimedCDBuilder("c1").Build(
+					testcd.WithRegion("us-east-2"),
+					testcd.WithProvisionRef("c1-provision"),
+					testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ProvisionFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "UnknownError",
+					}),
+				),
+				&hivev1.ClusterProvision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "c1-provision"},
+					Status: hivev1.ClusterProvisionStatus{
+						Conditions: []hivev1.ClusterProvisionCondition{{
+							Type:    hivev1.ClusterProvisionFailedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "UnknownError",
+							Message: "Cluster installation failed",
+						}},
+						ErrorClass: string(errorclass.Unknown),
+					},
+				},
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  1,
+		},
+		{
+			name: "unavailable region retried after retry interval",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithRegions(
+						hivev1.ClusterPoolRegion{Name: "us-east-2"},
+						hivev1.ClusterPoolRegion{Name: "us-west-2"},
+					),
+					testcp.WithUnavailableRegions(hivev1.ClusterPoolUnavailableRegion{
+						Name:  "us-east-2",
+						Since: metav1.NewTime(time.Now().Add(-2 * regionRetryInterval)),
+					}),
+				),
+			},
+			expectedTotalClusters: 1,
+			expectedRegions:       map[string]int{"us-east-2": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.noClusterImageSet {
 				test.existing = append(
 					test.existing,
 					&hivev1.ClusterImageSet{
@@ -437,9 +1731,10 @@ func TestReconcileClusterPool(t *testing.T) {
 			logger.SetLevel(log.DebugLevel)
 			controllerExpectations := controllerutils.NewExpectations(logger)
 			rcp := &ReconcileClusterPool{
-				Client:       fakeClient,
-				logger:       logger,
-				expectations: controllerExpectations,
+				Client:        fakeClient,
+				logger:        logger,
+				expectations:  controllerExpectations,
+				capacityPools: test.capacityPools,
 			}
 
 			reconcileRequest := reconcile.Request{
@@ -449,12 +1744,15 @@ func TestReconcileClusterPool(t *testing.T) {
 				},
 			}
 
-			_, err := rcp.Reconcile(reconcileRequest)
+			result, err := rcp.Reconcile(reconcileRequest)
 			if test.expectError {
 				assert.Error(t, err, "expected error from reconcile")
 			} else {
 				assert.NoError(t, err, "expected no error from reconcile")
 			}
+			if test.expectRequeueAfter {
+				assert.NotZero(t, result.RequeueAfter, "expected requeue after")
+			}
 
 			cds := &hivev1.ClusterDeploymentList{}
 			err = fakeClient.List(context.Background(), cds)
@@ -469,12 +1767,22 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 
 			for _, cd := range cds.Items {
-				assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster to be hibernating")
+				if test.expectedRunning == nil {
+					assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "expected cluster to be hibernating")
+				}
 				if test.expectedLabels != nil {
 					for k, v := range test.expectedLabels {
 						assert.Equal(t, v, cd.Labels[k])
 					}
 				}
+				if test.expectedInstallAttemptsLimit != nil {
+					assert.Equal(t, test.expectedInstallAttemptsLimit, cd.Spec.InstallAttemptsLimit, "unexpected install attempts limit")
+				}
+				if reason, ok := test.expectedQuarantined[cd.Name]; ok {
+					if assert.NotNil(t, cd.Spec.ClusterPoolRef.Quarantine, "expected cluster to be quarantined") {
+						assert.Equal(t, reason, cd.Spec.ClusterPoolRef.Quarantine.Reason, "unexpected quarantine reason")
+					}
+				}
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -483,10 +1791,99 @@ func TestReconcileClusterPool(t *testing.T) {
 
 			if test.expectFinalizerRemoved {
 				assert.NotContains(t, pool.Finalizers, finalizer, "expected no finalizer on clusterpool")
+			} else if test.expectDeletionPending {
+				assert.Contains(t, pool.Finalizers, finalizer, "expect finalizer on clusterpool until its clusters are deleted")
 			} else {
 				assert.Contains(t, pool.Finalizers, finalizer, "expect finalizer on clusterpool")
 				assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 				assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
+				assert.Equal(t, test.expectedObservedQuarantined, pool.Status.Quarantined, "unexpected observed quarantined count")
+				assert.Equal(t, test.expectedObservedOutdated, pool.Status.Outdated, "unexpected observed outdated count")
+				allCurrent := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolAllClustersCurrentCondition)
+				assert.Equal(t, test.expectedObservedOutdated == 0, allCurrent != nil && allCurrent.Status == corev1.ConditionTrue, "unexpected AllClustersCurrent condition")
+			}
+
+			var customizations []string
+			for _, cd := range cds.Items {
+				if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil && poolRef.CustomizationRef != nil {
+					customizations = append(customizations, poolRef.CustomizationRef.Name)
+				}
+			}
+			sort.Strings(customizations)
+			assert.Equal(t, test.expectedCustomizations, customizations, "unexpected customizations of clusters")
+
+			if test.expectedFlavors != nil {
+				flavors := map[string]int{}
+				for _, cd := range cds.Items {
+					if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil && poolRef.Flavor != "" {
+						flavors[poolRef.Flavor]++
+						if region, ok := test.expectedFlavorRegions[poolRef.Flavor]; ok && assert.NotNil(t, cd.Spec.Platform.AWS, "expected AWS cluster") {
+							assert.Equal(t, region, cd.Spec.Platform.AWS.Region, "unexpected region of flavor %s", poolRef.Flavor)
+						}
+					}
+				}
+				assert.Equal(t, test.expectedFlavors, flavors, "unexpected flavors of clusters")
+			}
+
+			if test.expectedRegions != nil {
+				regions := map[string]int{}
+				for _, cd := range cds.Items {
+					if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil && poolRef.Region != "" {
+						regions[poolRef.Region]++
+						// The existing clusters of the tests do not have a platform.
+						if cd.Spec.Platform.AWS == nil {
+							continue
+						}
+						assert.Equal(t, poolRef.Region, cd.Spec.Platform.AWS.Region, "unexpected platform region")
+						if imageSet, ok := test.expectedRegionImageSets[poolRef.Region]; ok && assert.NotNil(t, cd.Spec.Provisioning, "expected provisioning") {
+							assert.Equal(t, imageSet, cd.Spec.Provisioning.ImageSetRef.Name, "unexpected image set of region %s", poolRef.Region)
+						}
+					}
+				}
+				assert.Equal(t, test.expectedRegions, regions, "unexpected regions of clusters")
+			}
+			var unavailableRegions []string
+			for _, region := range pool.Status.UnavailableRegions {
+				unavailableRegions = append(unavailableRegions, region.Name)
+			}
+			assert.Equal(t, test.expectedUnavailableRegions, unavailableRegions, "unexpected unavailable regions")
+
+			if test.expectedInventory != nil {
+				inventory := map[string]hivev1.InventoryEntryState{}
+				for _, status := range pool.Status.Inventory {
+					inventory[status.Name] = status.State
+				}
+				assert.Equal(t, test.expectedInventory, inventory, "unexpected inventory status")
+			}
+			inventoryBroken := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryBrokenCondition)
+			assert.Equal(t, test.expectedInventoryBroken, inventoryBroken != nil && inventoryBroken.Status == corev1.ConditionTrue, "unexpected InventoryBroken condition")
+			paused := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolPausedCondition)
+			assert.Equal(t, test.expectPaused, paused != nil && paused.Status == corev1.ConditionTrue, "unexpected Paused condition")
+			draining := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolDrainingCondition)
+			assert.Equal(t, test.expectDraining, draining != nil && draining.Status == corev1.ConditionTrue, "unexpected Draining condition")
+			stalled := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolProvisioningStalledCondition)
+			if test.expectedStalledReason != "" {
+				if assert.NotNil(t, stalled, "expected ProvisioningStalled condition") {
+					assert.Equal(t, corev1.ConditionTrue, stalled.Status, "expected ProvisioningStalled condition to be true")
+					assert.Equal(t, test.expectedStalledReason, stalled.Reason, "unexpected ProvisioningStalled reason")
+				}
+			} else if stalled != nil {
+				assert.Equal(t, corev1.ConditionFalse, stalled.Status, "expected ProvisioningStalled condition to be false")
+			}
+
+			if test.expectedMachineNetwork != "" {
+				secrets := &corev1.SecretList{}
+				require.NoError(t, fakeClient.List(context.Background(), secrets))
+				found := false
+				for _, secret := range secrets.Items {
+					installConfig, ok := secret.StringData["install-config.yaml"]
+					if !ok {
+						continue
+					}
+					found = true
+					assert.Contains(t, installConfig, "cidr: "+test.expectedMachineNetwork, "expected patched machine network")
+				}
+				assert.True(t, found, "expected install-config secret")
 			}
 
 			missingDependentsCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition)
@@ -501,6 +1898,15 @@ func TestReconcileClusterPool(t *testing.T) {
 				assert.Equal(t, test.expectedMissingDependenciesMessage, missingDependentsCondition.Message, "unexpected MissingDependencies conditon message")
 			}
 
+			capacityConstrainedCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolCapacityConstrainedCondition)
+			if test.expectCapacityConstrained {
+				if assert.NotNil(t, capacityConstrainedCondition, "expected CapacityConstrained condition") {
+					assert.Equal(t, corev1.ConditionTrue, capacityConstrainedCondition.Status, "expected CapacityConstrained condition to be true")
+				}
+			} else if capacityConstrainedCondition != nil {
+				assert.Equal(t, corev1.ConditionFalse, capacityConstrainedCondition.Status, "expected CapacityConstrained condition to be false")
+			}
+
 			claims := &hivev1.ClusterClaimList{}
 			err = fakeClient.List(context.Background(), claims)
 			require.NoError(t, err)
@@ -516,6 +1922,43 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 			assert.Equal(t, test.expectedAssignedClaims, actualAssignedClaims, "unexpected number of assigned claims")
 			assert.Equal(t, test.expectedUnassignedClaims, actualUnassignedClaims, "unexpected number of unassigned claims")
+			for _, claim := range claims.Items {
+				if expected, ok := test.expectedClaimLifetimes[claim.Name]; ok {
+					assert.Equal(t, expected, claim.Status.Lifetime, "unexpected lifetime of claim %s", claim.Name)
+				}
+				if expected, ok := test.expectedQueuePositions[claim.Name]; ok {
+					assert.Equal(t, expected, claim.Status.QueuePosition, "unexpected queue position of claim %s", claim.Name)
+				}
+			}
+			if test.expectedAssignedCluster != "" {
+				if assert.Len(t, claims.Items, 1, "expected one claim") {
+					assert.Equal(t, test.expectedAssignedCluster, claims.Items[0].Spec.Namespace, "unexpected cluster assigned to claim")
+				}
+			}
+
+			if test.expectedRunning != nil {
+				runningCDs := []string{}
+				for _, cd := range cds.Items {
+					if cd.Spec.PowerState == hivev1.RunningClusterPowerState {
+						runningCDs = append(runningCDs, cd.Name)
+					}
+				}
+				sort.Strings(runningCDs)
+				assert.Equal(t, test.expectedRunning, runningCDs, "unexpected running clusters")
+			}
 		})
 	}
 }
+
+func testCustomization(name string, patches ...hivev1.PatchEntity) *hivev1.ClusterDeploymentCustomization {
+	return &hivev1.ClusterDeploymentCustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  testNamespace,
+			Name:       name,
+			Generation: 1,
+		},
+		Spec: hivev1.ClusterDeploymentCustomizationSpec{
+			InstallConfigPatches: patches,
+		},
+	}
+}
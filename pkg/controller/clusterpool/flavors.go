@@ -0,0 +1,40 @@
+package clusterpool
+
+import (
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// countFlavors counts the clusters of the pool by the flavor with which they were created. Claimed clusters are
+// counted, since they still use the cloud resources of their flavor, but deleted clusters are not.
+func countFlavors(cds []*hivev1.ClusterDeployment) map[string]int {
+	counts := map[string]int{}
+	for _, cd := range cds {
+		if cd.DeletionTimestamp != nil || cd.Spec.ClusterPoolRef.Flavor == "" {
+			continue
+		}
+		counts[cd.Spec.ClusterPoolRef.Flavor]++
+	}
+	return counts
+}
+
+// nextFlavor returns the flavor of the pool with which to create the next cluster: the flavor with the fewest clusters
+// for its weight once the next cluster is added, or the first such flavor in the pool when there is a tie. It returns
+// nil when the pool has no flavors.
+func nextFlavor(pool *hivev1.ClusterPool, counts map[string]int) *hivev1.ClusterPoolFlavor {
+	var next *hivev1.ClusterPoolFlavor
+	for i, flavor := range pool.Spec.Flavors {
+		// Compare (count+1)/weight without dividing.
+		if next == nil || int64(counts[flavor.Name]+1)*int64(next.Weight) < int64(counts[next.Name]+1)*int64(flavor.Weight) {
+			next = &pool.Spec.Flavors[i]
+		}
+	}
+	return next
+}
+
+// flavorPlatform returns the platform of the clusters of the flavor.
+func flavorPlatform(pool *hivev1.ClusterPool, flavor *hivev1.ClusterPoolFlavor) hivev1.Platform {
+	if flavor != nil && flavor.Platform != nil {
+		return *flavor.Platform
+	}
+	return pool.Spec.Platform
+}
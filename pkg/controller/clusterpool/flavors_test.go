@@ -0,0 +1,67 @@
+package clusterpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestNextFlavor(t *testing.T) {
+	cases := []struct {
+		name     string
+		pool     *hivev1.ClusterPool
+		counts   map[string]int
+		expected []string
+	}{
+		{
+			name:     "no flavors",
+			pool:     testcp.Build(),
+			counts:   map[string]int{},
+			expected: []string{"", ""},
+		},
+		{
+			name: "equal weights alternate",
+			pool: testcp.Build(testcp.WithFlavors(
+				hivev1.ClusterPoolFlavor{Name: "a", Weight: 1},
+				hivev1.ClusterPoolFlavor{Name: "b", Weight: 1},
+			)),
+			counts:   map[string]int{},
+			expected: []string{"a", "b", "a", "b"},
+		},
+		{
+			name: "weighted",
+			pool: testcp.Build(testcp.WithFlavors(
+				hivev1.ClusterPoolFlavor{Name: "a", Weight: 3},
+				hivev1.ClusterPoolFlavor{Name: "b", Weight: 1},
+			)),
+			counts:   map[string]int{},
+			expected: []string{"a", "a", "a", "b", "a", "a", "a", "b"},
+		},
+		{
+			name: "existing clusters",
+			pool: testcp.Build(testcp.WithFlavors(
+				hivev1.ClusterPoolFlavor{Name: "a", Weight: 1},
+				hivev1.ClusterPoolFlavor{Name: "b", Weight: 1},
+			)),
+			counts:   map[string]int{"a": 3, "removed": 5},
+			expected: []string{"b", "b", "b", "a"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for range tc.expected {
+				name := ""
+				if flavor := nextFlavor(tc.pool, tc.counts); flavor != nil {
+					name = flavor.Name
+					tc.counts[name]++
+				}
+				actual = append(actual, name)
+			}
+			assert.Equal(t, tc.expected, actual, "unexpected flavors")
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package clusterpool
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/cron"
+)
+
+// scheduledRunningCount returns the number of the unclaimed clusters of the pool to keep running at the time, and the
+// time at which the hibernation schedule of the pool next hibernates or resumes them, if it has one. The schedule
+// keeps all of the clusters running after it last resumed them, and none after it last hibernated them. The running
+// count of the pool applies when the pool has no schedule, or the schedule has not yet hibernated or resumed clusters.
+func scheduledRunningCount(pool *hivev1.ClusterPool, clusters int, now time.Time) (int, time.Time, error) {
+	runningCount := int(pool.Spec.RunningCount)
+	if pool.Spec.HibernationConfig == nil || pool.Spec.HibernationConfig.Schedule == nil {
+		return runningCount, time.Time{}, nil
+	}
+	schedule := pool.Spec.HibernationConfig.Schedule
+	hibernate, err := cron.Parse(schedule.Hibernate)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "could not parse hibernate schedule")
+	}
+	resume, err := cron.Parse(schedule.Resume)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "could not parse resume schedule")
+	}
+	loc := time.UTC
+	if schedule.TimeZone != "" {
+		if loc, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return 0, time.Time{}, errors.Wrap(err, "could not load time zone of schedule")
+		}
+	}
+	now = now.In(loc)
+
+	next := hibernate.Next(now)
+	if nextResume := resume.Next(now); next.IsZero() || !nextResume.IsZero() && nextResume.Before(next) {
+		next = nextResume
+	}
+	lastHibernate, lastResume := hibernate.Prev(now), resume.Prev(now)
+	switch {
+	case lastHibernate.IsZero() && lastResume.IsZero():
+	case lastResume.After(lastHibernate):
+		runningCount = clusters
+	default:
+		runningCount = 0
+	}
+	return runningCount, next, nil
+}
@@ -0,0 +1,103 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestScheduledRunningCount(t *testing.T) {
+	// 2020-06-10 is a Wednesday.
+	workHours := testcp.WithHibernationSchedule("0 19 * * mon-fri", "0 7 * * mon-fri")
+	cases := []struct {
+		name                 string
+		pool                 *hivev1.ClusterPool
+		now                  time.Time
+		expectedRunningCount int
+		expectedNext         time.Time
+		expectError          bool
+	}{
+		{
+			name:                 "no schedule",
+			pool:                 testcp.Build(testcp.WithRunningCount(2)),
+			now:                  time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedRunningCount: 2,
+		},
+		{
+			name:                 "during working hours",
+			pool:                 testcp.Build(testcp.WithRunningCount(2), workHours),
+			now:                  time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedRunningCount: 5,
+			expectedNext:         time.Date(2020, 6, 10, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                 "overnight",
+			pool:                 testcp.Build(testcp.WithRunningCount(2), workHours),
+			now:                  time.Date(2020, 6, 10, 22, 0, 0, 0, time.UTC),
+			expectedRunningCount: 0,
+			expectedNext:         time.Date(2020, 6, 11, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                 "weekend",
+			pool:                 testcp.Build(testcp.WithRunningCount(2), workHours),
+			now:                  time.Date(2020, 6, 13, 12, 0, 0, 0, time.UTC),
+			expectedRunningCount: 0,
+			expectedNext:         time.Date(2020, 6, 15, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                 "at resume time",
+			pool:                 testcp.Build(testcp.WithRunningCount(2), workHours),
+			now:                  time.Date(2020, 6, 10, 7, 0, 0, 0, time.UTC),
+			expectedRunningCount: 5,
+			expectedNext:         time.Date(2020, 6, 10, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			pool: testcp.Build(testcp.WithRunningCount(2), workHours, func(pool *hivev1.ClusterPool) {
+				pool.Spec.HibernationConfig.Schedule.TimeZone = "America/New_York"
+			}),
+			// 20:00 in New York.
+			now:                  time.Date(2020, 6, 11, 0, 0, 0, 0, time.UTC),
+			expectedRunningCount: 0,
+			expectedNext:         time.Date(2020, 6, 11, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                 "schedule never activated",
+			pool:                 testcp.Build(testcp.WithRunningCount(2), testcp.WithHibernationSchedule("0 0 30 2 *", "0 0 31 4 *")),
+			now:                  time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedRunningCount: 2,
+		},
+		{
+			name:        "invalid schedule",
+			pool:        testcp.Build(testcp.WithHibernationSchedule("0 19 * *", "0 7 * * *")),
+			now:         time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectError: true,
+		},
+		{
+			name: "invalid time zone",
+			pool: testcp.Build(workHours, func(pool *hivev1.ClusterPool) {
+				pool.Spec.HibernationConfig.Schedule.TimeZone = "Nowhere/Special"
+			}),
+			now:         time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			runningCount, next, err := scheduledRunningCount(tc.pool, 5, tc.now)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			if !assert.NoError(t, err, "unexpected error") {
+				return
+			}
+			assert.Equal(t, tc.expectedRunningCount, runningCount, "unexpected running count")
+			assert.True(t, tc.expectedNext.Equal(next), "unexpected next change: expected %v, got %v", tc.expectedNext, next)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const installConfigSecretKey = "install-config.yaml"
+
+// enqueuePoolsForCustomization enqueues the pools in the namespace of a ClusterDeploymentCustomization that have it in
+// their inventory.
+func (r *ReconcileClusterPool) enqueuePoolsForCustomization(o handler.MapObject) []reconcile.Request {
+	cdc, ok := o.Object.(*hivev1.ClusterDeploymentCustomization)
+	if !ok {
+		return nil
+	}
+	pools := &hivev1.ClusterPoolList{}
+	if err := r.List(context.TODO(), pools, client.InNamespace(cdc.Namespace)); err != nil {
+		r.logger.WithError(err).Error("could not list ClusterPools for ClusterDeploymentCustomization")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, pool := range pools.Items {
+		for _, entry := range pool.Spec.Inventory {
+			if entry.Name == cdc.Name {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: pool.Namespace, Name: pool.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// updateInventoryStatus sets the state of each entry of the inventory of the pool in its status. An entry is
+// consumed while a ClusterDeployment of the pool uses it, and broken when its ClusterDeploymentCustomization does not
+// exist or could not be applied. The status is updated by the caller.
+func (r *ReconcileClusterPool) updateInventoryStatus(pool *hivev1.ClusterPool, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	consumers := map[string]string{}
+	for _, cd := range cds {
+		if ref := cd.Spec.ClusterPoolRef.CustomizationRef; ref != nil {
+			consumers[ref.Name] = cd.Namespace
+		}
+	}
+	previous := map[string]hivev1.InventoryEntryStatus{}
+	for _, status := range pool.Status.Inventory {
+		previous[status.Name] = status
+	}
+
+	var statuses []hivev1.InventoryEntryStatus
+	var broken []string
+	for _, entry := range pool.Spec.Inventory {
+		status := hivev1.InventoryEntryStatus{Name: entry.Name, State: hivev1.InventoryEntryAvailable}
+		if namespace, ok := consumers[entry.Name]; ok {
+			status.State = hivev1.InventoryEntryConsumed
+			status.ClusterNamespace = namespace
+			statuses = append(statuses, status)
+			continue
+		}
+		cdc := &hivev1.ClusterDeploymentCustomization{}
+		switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: pool.Namespace, Name: entry.Name}, cdc); {
+		case apierrors.IsNotFound(err):
+			status.State = hivev1.InventoryEntryBroken
+			status.Message = "ClusterDeploymentCustomization not found"
+		case err != nil:
+			logger.WithError(err).WithField("customization", entry.Name).Error("could not get ClusterDeploymentCustomization")
+			return errors.Wrap(err, "could not get ClusterDeploymentCustomization")
+		default:
+			// An entry that could not be applied stays broken until its ClusterDeploymentCustomization is updated.
+			if prev, ok := previous[entry.Name]; ok && prev.State == hivev1.InventoryEntryBroken &&
+				prev.ObservedGeneration != 0 && prev.ObservedGeneration == cdc.Generation {
+				status = prev
+			}
+		}
+		if status.State == hivev1.InventoryEntryBroken {
+			broken = append(broken, entry.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	pool.Status.Inventory = statuses
+	setInventoryBrokenCondition(pool, broken)
+	return nil
+}
+
+// setInventoryBrokenCondition sets the InventoryBroken condition of the pool from the names of its broken entries.
+func setInventoryBrokenCondition(pool *hivev1.ClusterPool, broken []string) {
+	if len(pool.Spec.Inventory) == 0 && controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolInventoryBrokenCondition) == nil {
+		return
+	}
+	status := corev1.ConditionFalse
+	reason := "InventoryValid"
+	message := "No inventory entries are broken"
+	if len(broken) > 0 {
+		status = corev1.ConditionTrue
+		reason = "EntriesBroken"
+		message = fmt.Sprintf("Broken inventory entries: %s", strings.Join(broken, ", "))
+	}
+	pool.Status.Conditions, _ = controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolInventoryBrokenCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+// nextInventoryEntry returns the ClusterDeploymentCustomization of the first available entry of the inventory of the
+// pool, or nil if there is none.
+func (r *ReconcileClusterPool) nextInventoryEntry(pool *hivev1.ClusterPool, logger log.FieldLogger) (*hivev1.ClusterDeploymentCustomization, error) {
+	for _, status := range pool.Status.Inventory {
+		if status.State != hivev1.InventoryEntryAvailable {
+			continue
+		}
+		cdc := &hivev1.ClusterDeploymentCustomization{}
+		if err := r.Get(context.TODO(), client.ObjectKey{Namespace: pool.Namespace, Name: status.Name}, cdc); err != nil {
+			logger.WithError(err).WithField("customization", status.Name).Log(controllerutils.LogLevel(err), "could not get ClusterDeploymentCustomization")
+			return nil, errors.Wrap(err, "could not get ClusterDeploymentCustomization")
+		}
+		return cdc, nil
+	}
+	return nil, nil
+}
+
+// setInventoryEntryState sets the state of an entry of the inventory of the pool.
+func setInventoryEntryState(pool *hivev1.ClusterPool, name string, state hivev1.InventoryEntryState, clusterNamespace, message string, generation int64) {
+	for i := range pool.Status.Inventory {
+		if pool.Status.Inventory[i].Name == name {
+			pool.Status.Inventory[i] = hivev1.InventoryEntryStatus{
+				Name:               name,
+				State:              state,
+				ClusterNamespace:   clusterNamespace,
+				Message:            message,
+				ObservedGeneration: generation,
+			}
+			return
+		}
+	}
+}
+
+// markInventoryEntryBroken records in the status of the pool that the ClusterDeploymentCustomization of an entry
+// could not be applied.
+func (r *ReconcileClusterPool) markInventoryEntryBroken(pool *hivev1.ClusterPool, cdc *hivev1.ClusterDeploymentCustomization, applyErr error, logger log.FieldLogger) error {
+	logger.WithError(applyErr).WithField("customization", cdc.Name).Warn("could not apply ClusterDeploymentCustomization")
+	setInventoryEntryState(pool, cdc.Name, hivev1.InventoryEntryBroken, "", applyErr.Error(), cdc.Generation)
+	var broken []string
+	for _, status := range pool.Status.Inventory {
+		if status.State == hivev1.InventoryEntryBroken {
+			broken = append(broken, status.Name)
+		}
+	}
+	setInventoryBrokenCondition(pool, broken)
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool inventory status")
+		return errors.Wrap(err, "could not update ClusterPool inventory status")
+	}
+	return nil
+}
+
+// applyCustomization applies the install-config patches of the ClusterDeploymentCustomization to the install-config
+// Secret among the resources built for a cluster.
+func applyCustomization(objs []runtime.Object, cdc *hivev1.ClusterDeploymentCustomization) error {
+	if len(cdc.Spec.InstallConfigPatches) == 0 {
+		return nil
+	}
+	return applyInstallConfigPatches(objs, cdc.Spec.InstallConfigPatches)
+}
+
+// applyInstallConfigPatches applies the patches to the install-config Secret among the resources built for a cluster.
+func applyInstallConfigPatches(objs []runtime.Object, patches []hivev1.PatchEntity) error {
+	for _, obj := range objs {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			continue
+		}
+		installConfig, ok := secret.StringData[installConfigSecretKey]
+		if !ok {
+			continue
+		}
+		patched, err := patchInstallConfig(installConfig, patches)
+		if err != nil {
+			return err
+		}
+		secret.StringData[installConfigSecretKey] = patched
+		return nil
+	}
+	return errors.New("install-config not found")
+}
+
+// patchInstallConfig applies the JSON patches to the install-config YAML.
+func patchInstallConfig(installConfig string, patches []hivev1.PatchEntity) (string, error) {
+	ops := make([]map[string]interface{}, len(patches))
+	for i, p := range patches {
+		op := map[string]interface{}{"op": p.Op, "path": p.Path}
+		switch p.Op {
+		case "add", "replace", "test":
+			op["value"] = p.Value
+		case "move", "copy":
+			op["from"] = p.From
+		}
+		ops[i] = op
+	}
+	rawPatch, err := json.Marshal(ops)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal install-config patches")
+	}
+	patch, err := jsonpatch.DecodePatch(rawPatch)
+	if err != nil {
+		return "", errors.Wrap(err, "could not decode install-config patches")
+	}
+	doc, err := yaml.YAMLToJSON([]byte(installConfig))
+	if err != nil {
+		return "", errors.Wrap(err, "could not convert install-config to JSON")
+	}
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		return "", errors.Wrap(err, "could not apply install-config patches")
+	}
+	out, err := yaml.JSONToYAML(patched)
+	if err != nil {
+		return "", errors.Wrap(err, "could not convert install-config to YAML")
+	}
+	return string(out), nil
+}
@@ -0,0 +1,168 @@
+package clusterpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
+)
+
+// regionRetryInterval is how long a region of a pool stays unavailable after a cluster failed to provision in it
+// because of a quota or capacity error.
+const regionRetryInterval = time.Hour
+
+// regionFailure returns the message of the failed provision of a cluster of the pool if it failed because of a quota
+// or capacity error in the region of the cluster, as classified by the errorClass of the install-log-regexes entry
+// that matched its install log. It returns an empty message for any other cluster.
+func (r *ReconcileClusterPool) regionFailure(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (string, error) {
+	if cd.Spec.Installed || cd.Spec.ClusterPoolRef.Region == "" || cd.Status.ProvisionRef == nil {
+		return "", nil
+	}
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionFailedCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return "", nil
+	}
+	provision := &hivev1.ClusterProvision{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Status.ProvisionRef.Name}, provision); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		logger.WithError(err).WithField("cluster", cd.Name).Log(controllerutils.LogLevel(err), "could not get ClusterProvision")
+		return "", errors.Wrap(err, "could not get ClusterProvision")
+	}
+	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+	if failedCond == nil || failedCond.Status != corev1.ConditionTrue {
+		return "", nil
+	}
+	switch errorclass.Class(provision.Status.ErrorClass) {
+	case errorclass.QuotaError, errorclass.CapacityError:
+	default:
+		return "", nil
+	}
+	return failedCond.Message, nil
+}
+
+// markRegionUnavailable records that the region of the pool is unavailable. A region that is already unavailable
+// keeps the time it became so, so that it is retried after regionRetryInterval regardless of the clusters still
+// failing in it.
+func markRegionUnavailable(pool *hivev1.ClusterPool, name, message string, now time.Time) {
+	for _, region := range pool.Status.UnavailableRegions {
+		if region.Name == name {
+			return
+		}
+	}
+	pool.Status.UnavailableRegions = append(pool.Status.UnavailableRegions, hivev1.ClusterPoolUnavailableRegion{
+		Name:    name,
+		Since:   metav1.NewTime(now),
+		Message: message,
+	})
+}
+
+// pruneUnavailableRegions forgets the unavailable regions of the pool that are due to be retried, or that were removed
+// from the pool.
+func pruneUnavailableRegions(pool *hivev1.ClusterPool, now time.Time) {
+	var remaining []hivev1.ClusterPoolUnavailableRegion
+	for _, region := range pool.Status.UnavailableRegions {
+		if !region.Since.Add(regionRetryInterval).After(now) || poolRegion(pool, region.Name) == nil {
+			continue
+		}
+		remaining = append(remaining, region)
+	}
+	pool.Status.UnavailableRegions = remaining
+}
+
+// regionRetryIn returns how long until the next of the unavailable regions of the pool is due to be retried, if any.
+func regionRetryIn(pool *hivev1.ClusterPool, now time.Time) time.Duration {
+	var retryIn time.Duration
+	for _, region := range pool.Status.UnavailableRegions {
+		if wait := region.Since.Add(regionRetryInterval).Sub(now); retryIn == 0 || wait < retryIn {
+			retryIn = wait
+		}
+	}
+	return retryIn
+}
+
+// nextRegion returns the region of the pool in which to create the next cluster: the first region that is not
+// unavailable, or the region that has been unavailable the longest when none are available. It returns nil when the
+// pool has no regions.
+func nextRegion(pool *hivev1.ClusterPool) *hivev1.ClusterPoolRegion {
+	var next *hivev1.ClusterPoolRegion
+	var nextSince time.Time
+	for i, region := range pool.Spec.Regions {
+		since, unavailable := regionUnavailableSince(pool, region.Name)
+		if !unavailable {
+			return &pool.Spec.Regions[i]
+		}
+		if next == nil || since.Before(nextSince) {
+			next = &pool.Spec.Regions[i]
+			nextSince = since
+		}
+	}
+	return next
+}
+
+func regionUnavailableSince(pool *hivev1.ClusterPool, name string) (time.Time, bool) {
+	for _, region := range pool.Status.UnavailableRegions {
+		if region.Name == name {
+			return region.Since.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// poolRegion returns the region of the pool with the name, or nil if the pool has no such region.
+func poolRegion(pool *hivev1.ClusterPool, name string) *hivev1.ClusterPoolRegion {
+	for i := range pool.Spec.Regions {
+		if pool.Spec.Regions[i].Name == name {
+			return &pool.Spec.Regions[i]
+		}
+	}
+	return nil
+}
+
+// regionPlatform returns the platform with its region replaced by the region, if any.
+func regionPlatform(platform hivev1.Platform, region *hivev1.ClusterPoolRegion) hivev1.Platform {
+	if region == nil {
+		return platform
+	}
+	platform = *platform.DeepCopy()
+	switch {
+	case platform.AWS != nil:
+		platform.AWS.Region = region.Name
+	case platform.GCP != nil:
+		platform.GCP.Region = region.Name
+	case platform.Azure != nil:
+		platform.Azure.Region = region.Name
+	}
+	return platform
+}
+
+// regionImageSet returns the ClusterImageSet of the clusters of the pool created in the region.
+func regionImageSet(pool *hivev1.ClusterPool, region *hivev1.ClusterPoolRegion) hivev1.ClusterImageSetReference {
+	if region != nil && region.ImageSetRef != nil {
+		return *region.ImageSetRef
+	}
+	return pool.Spec.ImageSetRef
+}
+
+// relocateCluster deletes an unclaimed cluster of the pool that failed to provision in its region, so that the pool
+// replaces it with a cluster in the next region.
+func (r *ReconcileClusterPool) relocateCluster(cd *hivev1.ClusterDeployment, next *hivev1.ClusterPoolRegion, logger log.FieldLogger) error {
+	logger = logger.WithField("cluster", cd.Name).WithField("region", cd.Spec.ClusterPoolRef.Region)
+	logger.WithField("nextRegion", next.Name).Info("deleting cluster that failed to provision in its region to replace it in the next region")
+	if err := r.Delete(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster that failed to provision in its region")
+		return errors.Wrap(err, "could not delete cluster that failed to provision in its region")
+	}
+	return nil
+}
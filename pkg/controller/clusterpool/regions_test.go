@@ -0,0 +1,91 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestNextRegion(t *testing.T) {
+	now := time.Now()
+	regions := testcp.WithRegions(
+		hivev1.ClusterPoolRegion{Name: "a"},
+		hivev1.ClusterPoolRegion{Name: "b"},
+		hivev1.ClusterPoolRegion{Name: "c"},
+	)
+	unavailable := func(name string, age time.Duration) hivev1.ClusterPoolUnavailableRegion {
+		return hivev1.ClusterPoolUnavailableRegion{Name: name, Since: metav1.NewTime(now.Add(-age))}
+	}
+	cases := []struct {
+		name                       string
+		pool                       *hivev1.ClusterPool
+		expected                   string
+		expectedUnavailableRegions []string
+	}{
+		{
+			name: "no regions",
+			pool: testcp.Build(),
+		},
+		{
+			name:     "first region",
+			pool:     testcp.Build(regions),
+			expected: "a",
+		},
+		{
+			name: "unavailable regions skipped",
+			pool: testcp.Build(regions, testcp.WithUnavailableRegions(
+				unavailable("a", time.Minute),
+				unavailable("b", time.Minute),
+			)),
+			expected:                   "c",
+			expectedUnavailableRegions: []string{"a", "b"},
+		},
+		{
+			name: "longest unavailable region when all are unavailable",
+			pool: testcp.Build(regions, testcp.WithUnavailableRegions(
+				unavailable("a", time.Minute),
+				unavailable("b", 3*time.Minute),
+				unavailable("c", 2*time.Minute),
+			)),
+			expected:                   "b",
+			expectedUnavailableRegions: []string{"a", "b", "c"},
+		},
+		{
+			name: "region retried after retry interval",
+			pool: testcp.Build(regions, testcp.WithUnavailableRegions(
+				unavailable("a", regionRetryInterval+time.Minute),
+				unavailable("b", time.Minute),
+			)),
+			expected:                   "a",
+			expectedUnavailableRegions: []string{"b"},
+		},
+		{
+			name: "removed region forgotten",
+			pool: testcp.Build(regions, testcp.WithUnavailableRegions(
+				unavailable("removed", time.Minute),
+			)),
+			expected: "a",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pruneUnavailableRegions(tc.pool, now)
+			var unavailableRegions []string
+			for _, region := range tc.pool.Status.UnavailableRegions {
+				unavailableRegions = append(unavailableRegions, region.Name)
+			}
+			assert.Equal(t, tc.expectedUnavailableRegions, unavailableRegions, "unexpected unavailable regions")
+			actual := ""
+			if region := nextRegion(tc.pool); region != nil {
+				actual = region.Name
+			}
+			assert.Equal(t, tc.expected, actual, "unexpected region")
+		})
+	}
+}
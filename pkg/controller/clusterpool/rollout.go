@@ -0,0 +1,135 @@
+package clusterpool
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// clusterSpecHash returns a hash of the parts of the spec of the pool from which the clusters of the flavor and region
+// are created. Changing any of them makes the existing clusters of the flavor and region outdated.
+func clusterSpecHash(pool *hivev1.ClusterPool, flavor *hivev1.ClusterPoolFlavor, region *hivev1.ClusterPoolRegion) (string, error) {
+	spec := struct {
+		BaseDomain           string                          `json:"baseDomain"`
+		ImageSetRef          hivev1.ClusterImageSetReference `json:"imageSetRef"`
+		Platform             hivev1.Platform                 `json:"platform"`
+		InstallConfigPatches []hivev1.PatchEntity            `json:"installConfigPatches,omitempty"`
+	}{
+		BaseDomain:  pool.Spec.BaseDomain,
+		ImageSetRef: regionImageSet(pool, region),
+		Platform:    flavorPlatform(pool, flavor),
+	}
+	if flavor != nil {
+		spec.InstallConfigPatches = flavor.InstallConfigPatches
+	}
+	return controllerutils.GetChecksumOfObject(spec)
+}
+
+// isOutdated returns true if the cluster was not created from the current spec of the pool: the spec changed since
+// the cluster was created, or the flavor or region of the cluster was removed from the pool. Clusters created before
+// the pool recorded the hash of its spec are outdated as well.
+func isOutdated(pool *hivev1.ClusterPool, cd *hivev1.ClusterDeployment) bool {
+	var flavor *hivev1.ClusterPoolFlavor
+	if name := cd.Spec.ClusterPoolRef.Flavor; name != "" || len(pool.Spec.Flavors) > 0 {
+		for i := range pool.Spec.Flavors {
+			if pool.Spec.Flavors[i].Name == name {
+				flavor = &pool.Spec.Flavors[i]
+			}
+		}
+		if flavor == nil {
+			return true
+		}
+	}
+	var region *hivev1.ClusterPoolRegion
+	if name := cd.Spec.ClusterPoolRef.Region; name != "" || len(pool.Spec.Regions) > 0 {
+		if region = poolRegion(pool, name); region == nil {
+			return true
+		}
+	}
+	hash, err := clusterSpecHash(pool, flavor, region)
+	if err != nil {
+		return false
+	}
+	return cd.Annotations[constants.ClusterPoolSpecHashAnnotation] != hash
+}
+
+// countOutdated counts the outdated clusters of the pool.
+func countOutdated(pool *hivev1.ClusterPool, cds []*hivev1.ClusterDeployment) int {
+	count := 0
+	for _, cd := range cds {
+		if isOutdated(pool, cd) {
+			count++
+		}
+	}
+	return count
+}
+
+// setAllClustersCurrentCondition sets the AllClustersCurrent condition of the pool from its count of outdated
+// clusters. The status of the pool is updated by the caller.
+func setAllClustersCurrentCondition(pool *hivev1.ClusterPool) {
+	status := corev1.ConditionTrue
+	reason := "AllCurrent"
+	message := "All unclaimed clusters were created from the current spec of the pool"
+	if pool.Status.Outdated > 0 {
+		status = corev1.ConditionFalse
+		reason = "OutdatedClusters"
+		message = fmt.Sprintf("%d unclaimed clusters were created from an earlier version of the spec of the pool", pool.Status.Outdated)
+	}
+	pool.Status.Conditions, _ = controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolAllClustersCurrentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+// outdatedClustersToDelete returns the outdated unclaimed clusters of the pool that its update strategy allows to
+// delete now, and the number of clusters above the size of the pool that it allows to create to replace the rest.
+// Outdated installing clusters are always deleted, since they are not available to claims yet. Outdated ready
+// clusters are deleted while the pool keeps at least its size less MaxUnavailable ready clusters, hibernating ones
+// first so that running clusters stay available to claims.
+func outdatedClustersToDelete(
+	pool *hivev1.ClusterPool,
+	size int,
+	installingCDs []*hivev1.ClusterDeployment,
+	readyCDs []*hivev1.ClusterDeployment,
+) ([]*hivev1.ClusterDeployment, int) {
+	strategy := pool.Spec.UpdateStrategy
+	if strategy == nil {
+		return nil, 0
+	}
+	var toDelete, outdatedReady []*hivev1.ClusterDeployment
+	for _, cd := range installingCDs {
+		if isOutdated(pool, cd) {
+			toDelete = append(toDelete, cd)
+		}
+	}
+	for _, cd := range readyCDs {
+		if isOutdated(pool, cd) {
+			outdatedReady = append(outdatedReady, cd)
+		}
+	}
+	sort.SliceStable(outdatedReady, func(i, j int) bool {
+		return !isRunning(outdatedReady[i]) && isRunning(outdatedReady[j])
+	})
+	deletable := len(readyCDs) - (size - int(strategy.MaxUnavailable))
+	if deletable < 0 {
+		deletable = 0
+	}
+	if deletable > len(outdatedReady) {
+		deletable = len(outdatedReady)
+	}
+	toDelete = append(toDelete, outdatedReady[:deletable]...)
+	surge := len(outdatedReady) - deletable
+	if surge > int(strategy.MaxSurge) {
+		surge = int(strategy.MaxSurge)
+	}
+	return toDelete, surge
+}
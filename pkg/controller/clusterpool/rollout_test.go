@@ -0,0 +1,85 @@
+package clusterpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestIsOutdated(t *testing.T) {
+	pool := testcp.Build(
+		testcp.WithImageSet("image-set"),
+		testcp.WithFlavors(hivev1.ClusterPoolFlavor{Name: "a", Weight: 1}),
+	)
+	hash, err := clusterSpecHash(pool, &pool.Spec.Flavors[0], nil)
+	require.NoError(t, err)
+	cases := []struct {
+		name     string
+		pool     *hivev1.ClusterPool
+		cd       *hivev1.ClusterDeployment
+		expected bool
+	}{
+		{
+			name: "current",
+			pool: pool,
+			cd: testcd.Build(
+				testcd.WithUnclaimedClusterPoolReference("namespace", "pool"),
+				testcd.WithFlavor("a"),
+				testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, hash)),
+			),
+		},
+		{
+			name: "image set changed",
+			pool: testcp.Build(
+				testcp.WithImageSet("other-image-set"),
+				testcp.WithFlavors(hivev1.ClusterPoolFlavor{Name: "a", Weight: 1}),
+			),
+			cd: testcd.Build(
+				testcd.WithUnclaimedClusterPoolReference("namespace", "pool"),
+				testcd.WithFlavor("a"),
+				testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, hash)),
+			),
+			expected: true,
+		},
+		{
+			name: "flavor removed",
+			pool: pool,
+			cd: testcd.Build(
+				testcd.WithUnclaimedClusterPoolReference("namespace", "pool"),
+				testcd.WithFlavor("b"),
+				testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, hash)),
+			),
+			expected: true,
+		},
+		{
+			name: "flavors added",
+			pool: pool,
+			cd: testcd.Build(
+				testcd.WithUnclaimedClusterPoolReference("namespace", "pool"),
+				testcd.Generic(testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, hash)),
+			),
+			expected: true,
+		},
+		{
+			name: "no spec hash",
+			pool: pool,
+			cd: testcd.Build(
+				testcd.WithUnclaimedClusterPoolReference("namespace", "pool"),
+				testcd.WithFlavor("a"),
+			),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isOutdated(tc.pool, tc.cd), "unexpected outdated state")
+		})
+	}
+}
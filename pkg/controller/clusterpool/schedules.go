@@ -0,0 +1,60 @@
+package clusterpool
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/cron"
+)
+
+// setSizeSchedule records in the status of the pool the size schedule in effect at the time, which is the schedule
+// that started last, and the time at which the next schedule starts. No schedule is in effect when the pool has no
+// schedules, none of them has started yet, or one of them cannot be parsed.
+func setSizeSchedule(pool *hivev1.ClusterPool, now time.Time) error {
+	pool.Status.ActiveSizeSchedule = ""
+	pool.Status.NextSizeChange = nil
+	var active string
+	var lastStart, nextStart time.Time
+	for _, schedule := range pool.Spec.Schedules {
+		start, err := cron.Parse(schedule.Start)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse start of schedule %s", schedule.Name)
+		}
+		loc := time.UTC
+		if schedule.TimeZone != "" {
+			if loc, err = time.LoadLocation(schedule.TimeZone); err != nil {
+				return errors.Wrapf(err, "could not load time zone of schedule %s", schedule.Name)
+			}
+		}
+		// Of the schedules that started at the same time, the first one in the pool is in effect.
+		if prev := start.Prev(now.In(loc)); !prev.IsZero() && (lastStart.IsZero() || prev.After(lastStart)) {
+			active = schedule.Name
+			lastStart = prev
+		}
+		if next := start.Next(now.In(loc)); !next.IsZero() && (nextStart.IsZero() || next.Before(nextStart)) {
+			nextStart = next
+		}
+	}
+	pool.Status.ActiveSizeSchedule = active
+	if !nextStart.IsZero() {
+		// Times read from the status of the pool are local, so the status only changes when the time does.
+		next := metav1.NewTime(nextStart.Local())
+		pool.Status.NextSizeChange = &next
+	}
+	return nil
+}
+
+// scheduledSize returns the size of the pool under the schedule in effect recorded in its status, or the size of the
+// pool when no schedule is in effect.
+func scheduledSize(pool *hivev1.ClusterPool) int32 {
+	for _, schedule := range pool.Spec.Schedules {
+		if schedule.Name == pool.Status.ActiveSizeSchedule && schedule.Name != "" {
+			return schedule.Size
+		}
+	}
+	return pool.Spec.Size
+}
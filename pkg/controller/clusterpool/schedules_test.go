@@ -0,0 +1,113 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestSetSizeSchedule(t *testing.T) {
+	// 2020-06-10 is a Wednesday.
+	businessHours := testcp.WithSizeSchedules(
+		hivev1.ClusterPoolSizeSchedule{Name: "day", Start: "0 8 * * mon-fri", Size: 20},
+		hivev1.ClusterPoolSizeSchedule{Name: "night", Start: "0 18 * * mon-fri", Size: 2},
+	)
+	cases := []struct {
+		name           string
+		pool           *hivev1.ClusterPool
+		now            time.Time
+		expectedActive string
+		expectedSize   int32
+		expectedNext   time.Time
+		expectError    bool
+	}{
+		{
+			name:         "no schedules",
+			pool:         testcp.Build(testcp.WithSize(5)),
+			now:          time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedSize: 5,
+		},
+		{
+			name:           "business hours",
+			pool:           testcp.Build(testcp.WithSize(5), businessHours),
+			now:            time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedActive: "day",
+			expectedSize:   20,
+			expectedNext:   time.Date(2020, 6, 10, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "overnight",
+			pool:           testcp.Build(testcp.WithSize(5), businessHours),
+			now:            time.Date(2020, 6, 11, 2, 0, 0, 0, time.UTC),
+			expectedActive: "night",
+			expectedSize:   2,
+			expectedNext:   time.Date(2020, 6, 11, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "weekend",
+			pool:           testcp.Build(testcp.WithSize(5), businessHours),
+			now:            time.Date(2020, 6, 13, 12, 0, 0, 0, time.UTC),
+			expectedActive: "night",
+			expectedSize:   2,
+			expectedNext:   time.Date(2020, 6, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "at start time",
+			pool:           testcp.Build(testcp.WithSize(5), businessHours),
+			now:            time.Date(2020, 6, 10, 8, 0, 0, 0, time.UTC),
+			expectedActive: "day",
+			expectedSize:   20,
+			expectedNext:   time.Date(2020, 6, 10, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			pool: testcp.Build(testcp.WithSize(5), testcp.WithSizeSchedules(
+				hivev1.ClusterPoolSizeSchedule{Name: "day", Start: "0 8 * * *", Size: 20, TimeZone: "America/New_York"},
+				hivev1.ClusterPoolSizeSchedule{Name: "night", Start: "0 18 * * *", Size: 2, TimeZone: "America/New_York"},
+			)),
+			// 07:00 in New York.
+			now:            time.Date(2020, 6, 10, 11, 0, 0, 0, time.UTC),
+			expectedActive: "night",
+			expectedSize:   2,
+			expectedNext:   time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "schedule never started",
+			pool: testcp.Build(testcp.WithSize(5), testcp.WithSizeSchedules(
+				hivev1.ClusterPoolSizeSchedule{Name: "leap", Start: "0 0 30 2 *", Size: 20},
+			)),
+			now:          time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedSize: 5,
+		},
+		{
+			name: "invalid schedule",
+			pool: testcp.Build(testcp.WithSize(5), testcp.WithSizeSchedules(
+				hivev1.ClusterPoolSizeSchedule{Name: "day", Start: "0 8 * *", Size: 20},
+			)),
+			now:          time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expectedSize: 5,
+			expectError:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := setSizeSchedule(tc.pool, tc.now)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+			assert.Equal(t, tc.expectedActive, tc.pool.Status.ActiveSizeSchedule, "unexpected active schedule")
+			assert.Equal(t, tc.expectedSize, scheduledSize(tc.pool), "unexpected size")
+			if tc.expectedNext.IsZero() {
+				assert.Nil(t, tc.pool.Status.NextSizeChange, "expected no next size change")
+			} else if assert.NotNil(t, tc.pool.Status.NextSizeChange, "expected next size change") {
+				assert.True(t, tc.expectedNext.Equal(tc.pool.Status.NextSizeChange.Time), "unexpected next size change: expected %v, got %v", tc.expectedNext, tc.pool.Status.NextSizeChange.Time)
+			}
+		})
+	}
+}
@@ -59,7 +59,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		fmt.Sprintf("%s-controller", ControllerName),
 		mgr,
 		controller.Options{
-			Reconciler:              r,
+			Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 			MaxConcurrentReconciles: concurrentReconciles,
 			RateLimiter:             rateLimiter,
 		},
@@ -95,7 +95,8 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 var _ reconcile.Reconciler = &ReconcileClusterPoolNamespace{}
 
 // ReconcileClusterPoolNamespace reconciles a Namespace object for the purpose of reaping namespaces created for
-// ClusterPool clusters after the clusters have been deleted.
+// ClusterPool clusters, and namespaces owned by clusters when namespace-per-cluster is enabled, after the clusters
+// have been deleted.
 type ReconcileClusterPoolNamespace struct {
 	client.Client
 	logger log.FieldLogger
@@ -122,8 +123,10 @@ func (r *ReconcileClusterPoolNamespace) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{}, nil
 	}
 
-	// If the namespace was not created for a ClusterPool cluster, ignore it
-	if _, ok := namespace.Labels[constants.ClusterPoolNameLabel]; !ok {
+	// If the namespace was not created for a ClusterPool cluster or owned by a cluster, ignore it
+	_, isPoolNamespace := namespace.Labels[constants.ClusterPoolNameLabel]
+	_, isClusterNamespace := namespace.Labels[constants.ClusterNamespaceLabel]
+	if !isPoolNamespace && !isClusterNamespace {
 		return reconcile.Result{}, nil
 	}
 
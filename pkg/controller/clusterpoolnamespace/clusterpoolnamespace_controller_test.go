@@ -40,6 +40,9 @@ func TestReconcileClusterPoolNamespace_Reconcile_Movement(t *testing.T) {
 	namespaceBuilder := namespaceWithoutLabelBuilder.GenericOptions(
 		testgeneric.WithLabel(constants.ClusterPoolNameLabel, "test-cluster-pool"),
 	)
+	clusterNamespaceBuilder := namespaceWithoutLabelBuilder.GenericOptions(
+		testgeneric.WithLabel(constants.ClusterNamespaceLabel, namespaceName),
+	)
 
 	validateNoRequeueAfter := func(t *testing.T, requeueAfter time.Duration, startTime, endTime time.Time) {
 		assert.Zero(t, requeueAfter, "unexpected non-zero requeue after")
@@ -100,6 +103,23 @@ func TestReconcileClusterPoolNamespace_Reconcile_Movement(t *testing.T) {
 			expectDeleted:        false,
 			validateRequeueAfter: validateNoRequeueAfter,
 		},
+		{
+			name:                 "cluster namespace without clusterdeployments",
+			namespaceBuilder:     clusterNamespaceBuilder,
+			expectDeleted:        true,
+			validateRequeueAfter: validateNoRequeueAfter,
+		},
+		{
+			name:             "cluster namespace with deleted clusterdeployment",
+			namespaceBuilder: clusterNamespaceBuilder,
+			resources: []runtime.Object{
+				testcd.FullBuilder(namespaceName, namespaceName, scheme).
+					GenericOptions(testgeneric.Deleted()).
+					Build(),
+			},
+			expectDeleted:        false,
+			validateRequeueAfter: validateWaitForCDGoneRequeueAfter,
+		},
 		{
 			name:                 "namespace without clusterpool label",
 			namespaceBuilder:     namespaceWithoutLabelBuilder,
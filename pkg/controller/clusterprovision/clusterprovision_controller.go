@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 	"github.com/openshift/hive/pkg/install"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -45,6 +46,13 @@ const (
 	resultFailure = "failure"
 
 	podStatusCheckDelay = 60 * time.Second
+
+	// provisionTimedOutReason is the failure reason for a provision that did not complete within its timeout.
+	provisionTimedOutReason = "ProvisionTimedOut"
+
+	// provisionTimeoutCleanupPeriod is how long the install job is given after the provision timeout to clean up
+	// the partial install before the provision is aborted.
+	provisionTimeoutCleanupPeriod = time.Hour
 )
 
 var (
@@ -61,7 +69,7 @@ var (
 		Name: "hive_install_errors",
 		Help: "Counter incremented every time we observe certain errors strings in install logs.",
 	},
-		[]string{"cluster_type", "reason"},
+		[]string{"cluster_type", "reason", "error_class"},
 	)
 )
 
@@ -102,7 +110,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int,
 
 	// Create a new controller
 	c, err := controller.New("clusterprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -191,7 +199,7 @@ func (r *ReconcileClusterProvision) Reconcile(request reconcile.Request) (reconc
 			return r.reconcileRunningJob(instance, pLog)
 		}
 		return r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, "NoJobReference", "Missing reference to install job", pLog)
-	case hivev1.ClusterProvisionStageComplete:
+	case hivev1.ClusterProvisionStageComplete, hivev1.ClusterProvisionStageDryRunComplete:
 		pLog.Debugf("ClusterProvision is %s", instance.Spec.Stage)
 		if instance.Status.JobRef != nil && time.Since(instance.CreationTimestamp.Time) > (24*time.Hour) {
 			return r.deleteInstallJob(instance, pLog)
@@ -283,6 +291,9 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 	switch {
 	case controllerutils.IsSuccessful(job):
 		if instance.Spec.Stage == hivev1.ClusterProvisionStageInitializing {
+			if instance.Spec.DryRun && instance.Spec.DryRunConfigMapRef != nil {
+				return r.reconcileSuccessfulDryRunJob(instance, pLog)
+			}
 			pLog.Error("install job completed without completing initialization")
 			return r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, "InitializationNotComplete", "Install job completed without completing initialization", pLog)
 		}
@@ -293,6 +304,12 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 
 	pLog.Debug("install job still running")
 
+	abortTime, hasTimeout := provisionAbortTime(instance)
+	if hasTimeout && time.Now().After(abortTime) {
+		pLog.WithField("timeout", instance.Spec.Timeout.Duration).Error("install job did not stop after the provision timeout")
+		return r.abortProvision(instance, provisionTimedOutReason, provisionTimedOutMessage(instance), pLog)
+	}
+
 	if time.Since(job.CreationTimestamp.Time) > podStatusCheckDelay {
 		installPod, err := r.getInstallPod(job, pLog)
 		if err != nil {
@@ -341,6 +358,9 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 	if timeUntilNextPodStatusCheck := podStatusCheckDelay - time.Since(job.CreationTimestamp.Time); timeUntilNextPodStatusCheck > 0 {
 		return reconcile.Result{RequeueAfter: timeUntilNextPodStatusCheck}, nil
 	}
+	if hasTimeout {
+		return reconcile.Result{RequeueAfter: time.Until(abortTime)}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -382,18 +402,56 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 	return result, err
 }
 
+func (r *ReconcileClusterProvision) reconcileSuccessfulDryRunJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
+	pLog.Info("dry-run install job succeeded")
+	return r.transitionStage(instance, hivev1.ClusterProvisionStageDryRunComplete, "DryRunComplete",
+		fmt.Sprintf("Install job has rendered the install-config and manifests into ConfigMap %s", instance.Spec.DryRunConfigMapRef.Name), pLog)
+}
+
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	var reason, message string
+	class := errorclass.Unknown
+	if deadline, ok := provisionDeadline(instance); ok && time.Now().After(deadline) {
+		pLog.WithField("timeout", instance.Spec.Timeout.Duration).Info("install job was stopped by the provision timeout")
+		reason, message = provisionTimedOutReason, provisionTimedOutMessage(instance)
+	} else {
+		reason, message, class = r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	}
+	pLog.WithField("errorClass", class).Info("classified install failure")
+	// The class is saved along with the Failed condition, which transitionStage writes to the status.
+	instance.Status.ErrorClass = string(class)
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
-		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason).Inc()
+		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason, string(class)).Inc()
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
 	}
 	return result, err
 }
 
+// provisionDeadline returns the time at which the install job stops the installer, if the provision has a timeout.
+func provisionDeadline(provision *hivev1.ClusterProvision) (time.Time, bool) {
+	if provision.Spec.Timeout == nil {
+		return time.Time{}, false
+	}
+	return provision.CreationTimestamp.Add(provision.Spec.Timeout.Duration), true
+}
+
+// provisionAbortTime returns the time at which the provision is aborted if the install job is still running, if the
+// provision has a timeout.
+func provisionAbortTime(provision *hivev1.ClusterProvision) (time.Time, bool) {
+	deadline, ok := provisionDeadline(provision)
+	if !ok {
+		return time.Time{}, false
+	}
+	return deadline.Add(provisionTimeoutCleanupPeriod), true
+}
+
+func provisionTimedOutMessage(provision *hivev1.ClusterProvision) string {
+	return fmt.Sprintf("Provision did not complete within the provision timeout of %s", provision.Spec.Timeout.Duration)
+}
+
 func (r *ReconcileClusterProvision) startProvisioning(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("provision initialization complete")
 	return r.transitionStage(instance, hivev1.ClusterProvisionStageProvisioning, "InitializationComplete", "Install job has completed its initialization. Provisioning started.", pLog)
@@ -441,6 +499,8 @@ func (r *ReconcileClusterProvision) transitionStage(
 		conditionType = hivev1.ClusterProvisionCompletedCondition
 	case hivev1.ClusterProvisionStageFailed:
 		conditionType = hivev1.ClusterProvisionFailedCondition
+	case hivev1.ClusterProvisionStageDryRunComplete:
+		conditionType = hivev1.ClusterProvisionDryRunCompletedCondition
 	default:
 		return reconcile.Result{}, errors.New("unknown stage")
 	}
@@ -28,6 +28,7 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 	"github.com/openshift/hive/pkg/install"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 	testjob "github.com/openshift/hive/pkg/test/job"
@@ -127,6 +128,33 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: "InitializationNotComplete",
 		},
+		{
+			name: "completed dry-run job",
+			existing: []runtime.Object{
+				testProvision(withJob(), dryRun("test-dry-run")),
+				testJob(completed()),
+				testPod("foo", success()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageDryRunComplete,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				cond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionDryRunCompletedCondition)
+				if assert.NotNil(t, cond, "expected dry-run completed condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "DryRunComplete", cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
+		{
+			name: "completed dry-run job without rendered output",
+			existing: []runtime.Object{
+				testProvision(withJob(), dryRun("")),
+				testJob(completed()),
+				testPod("foo", success()),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: "InitializationNotComplete",
+		},
 		{
 			name: "failed job",
 			existing: []runtime.Object{
@@ -136,6 +164,48 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: unknownReason,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				assert.Equal(t, string(errorclass.Unknown), provision.Status.ErrorClass, "unexpected error class")
+				failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+				if assert.NotNil(t, failedCond, "expected to find a Failed condition") {
+					assert.Equal(t, logMissingMessage, failedCond.Message, "unexpected fail message")
+				}
+			},
+		},
+		{
+			name: "failed job after provision timeout",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withTimeout(time.Hour), withCreationTime(time.Now().Add(-2*time.Hour))),
+				testJob(failedJob()),
+				testPod("foo"),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: provisionTimedOutReason,
+		},
+		{
+			name: "running job before provision timeout",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withTimeout(time.Hour), withCreationTime(time.Now())),
+				testJob(),
+				testPod("foo", running()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageProvisioning,
+			validateRequeueAfter: func(requeueAfter time.Duration, c client.Client, t *testing.T) {
+				assert.Greater(t, requeueAfter.Nanoseconds(), time.Hour.Nanoseconds(), "unexpected requeue after duration")
+				assert.LessOrEqual(t, requeueAfter.Nanoseconds(), (time.Hour + provisionTimeoutCleanupPeriod).Nanoseconds(), "unexpected requeue after duration")
+			},
+		},
+		{
+			name: "running job after provision timeout cleanup period",
+			existing: []runtime.Object{
+				testProvision(withJob(), provisioning(), withTimeout(time.Hour), withCreationTime(time.Now().Add(-3*time.Hour))),
+				testJob(),
+				testPod("foo", running()),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageProvisioning,
+			expectedFailReason: provisionTimedOutReason,
+			expectNoJob:        true,
 		},
 		{
 			name: "keep job for 24 hours after success",
@@ -362,6 +432,15 @@ func failed() provisionOption {
 	}
 }
 
+func dryRun(configMapName string) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.DryRun = true
+		if configMapName != "" {
+			p.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: configMapName}
+		}
+	}
+}
+
 func withJob() provisionOption {
 	return func(p *hivev1.ClusterProvision) {
 		p.Status.JobRef = &corev1.LocalObjectReference{
@@ -376,6 +455,12 @@ func withCreationTime(creationTime time.Time) provisionOption {
 	}
 }
 
+func withTimeout(timeout time.Duration) provisionOption {
+	return func(p *hivev1.ClusterProvision) {
+		p.Spec.Timeout = &metav1.Duration{Duration: timeout}
+	}
+}
+
 func withFailedCondition(reason string) provisionOption {
 	return func(p *hivev1.ClusterProvision) {
 		p.Status.Conditions = append(
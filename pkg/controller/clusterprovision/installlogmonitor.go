@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/errorclass"
 )
 
 const (
@@ -23,10 +24,11 @@ const (
 	unknownMessage     = "Cluster install failed but no known errors found in logs"
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// parseInstallLog parses install log to monitor for known issues. It returns the reason, message and class of the
+// install failure.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string, errorclass.Class) {
 	if log == nil {
-		return unknownReason, logMissingMessage
+		return unknownReason, logMissingMessage, errorclass.Unknown
 	}
 
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
@@ -36,19 +38,19 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, errorclass.Unknown
 	}
 
 	regexesRaw, ok := regexCM.Data[regexDataEntryName]
 	if !ok {
 		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, errorclass.Unknown
 	}
 
 	regexes := []installLogRegex{}
 	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
 		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, errorclass.Unknown
 	}
 
 	pLog.Info("processing new install log")
@@ -71,10 +73,14 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 				ssLog.WithError(err).Error("unable to compile regex")
 			case match:
 				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
+				class := ilr.ErrorClass
+				if class == "" {
+					class = errorclass.Unknown
+				}
+				return ilr.InstallFailingReason, ilr.InstallFailingMessage, class
 			}
 		}
 	}
 
-	return unknownReason, unknownMessage
+	return unknownReason, unknownMessage, errorclass.Unknown
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/openshift/hive/pkg/apis"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/errorclass"
 )
 
 func init() {
@@ -35,18 +36,21 @@ func TestParseInstallLog(t *testing.T) {
 		log            *string
 		existing       []runtime.Object
 		expectedReason string
+		expectedClass  errorclass.Class
 	}{
 		{
 			name:           "DNS already exists",
 			log:            pointer.StringPtr(dnsAlreadyExistsLog),
 			existing:       []runtime.Object{buildRegexConfigMap()},
 			expectedReason: "DNSAlreadyExists",
+			expectedClass:  errorclass.UserError,
 		},
 		{
 			name:           "PendingVerification",
 			log:            pointer.StringPtr(pendingVerificationLog),
 			existing:       []runtime.Object{buildRegexConfigMap()},
 			expectedReason: "PendingVerification",
+			expectedClass:  errorclass.UserError,
 		},
 		{
 			name:           "no log",
@@ -138,8 +142,13 @@ func TestParseInstallLog(t *testing.T) {
 				Client: fakeClient,
 				scheme: scheme.Scheme,
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			reason, message, class := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
+			expectedClass := test.expectedClass
+			if expectedClass == "" {
+				expectedClass = errorclass.Unknown
+			}
+			assert.Equal(t, expectedClass, class, "unexpected error class")
 			assert.NotEmpty(t, message, "expected message to be not empty")
 		})
 	}
@@ -158,11 +167,13 @@ func buildRegexConfigMap() *corev1.ConfigMap {
   - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
   installFailingReason: DNSAlreadyExists
   installFailingMessage: DNS record already exists
+  errorClass: UserError
 - name: PendingVerification
   searchRegexStrings:
   - "PendingVerification: Your request for accessing resources in this region is being validated"
   installFailingReason: PendingVerification
   installFailingMessage: Account pending verification for region
+  errorClass: UserError
 `,
 		},
 	}
@@ -1,5 +1,9 @@
 package clusterprovision
 
+import (
+	"github.com/openshift/hive/pkg/errorclass"
+)
+
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
 // the install-log-regexes ConfigMap.
@@ -15,4 +19,8 @@ type installLogRegex struct {
 
 	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
 	InstallFailingMessage string `json:"installFailingMessage"`
+
+	// ErrorClass is the class of the failure reported in the status of the ClusterProvision and in metrics.
+	// Failures with no class are reported as Unknown.
+	ErrorClass errorclass.Class `json:"errorClass,omitempty"`
 }
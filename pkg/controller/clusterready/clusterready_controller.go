@@ -0,0 +1,215 @@
+// Package clusterready provides a controller which rolls the conditions of a ClusterDeployment up into a single
+// Ready condition, so that consumers of the ClusterDeployment do not need to understand each of the individual
+// condition types.
+package clusterready
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterReadyControllerName
+)
+
+// notReadyRule maps a condition which, when true, makes the cluster not ready to the reason used for the Ready
+// condition.
+type notReadyRule struct {
+	conditionType hivev1.ClusterDeploymentConditionType
+	reason        string
+	// unsetReason, if not empty, is used as the reason for the Ready condition when the condition has not been set
+	// by its controller yet, in which case the cluster is not ready either.
+	unsetReason string
+}
+
+// provisioningRules are the rules for clusters that are not yet installed, in order of precedence.
+var provisioningRules = []notReadyRule{
+	{conditionType: hivev1.ProvisionStoppedCondition, reason: string(hivev1.ProvisionStoppedCondition)},
+	{conditionType: hivev1.ClusterImageSetNotFoundCondition, reason: string(hivev1.ClusterImageSetNotFoundCondition)},
+	{conditionType: hivev1.InvalidSecretReferencesCondition, reason: string(hivev1.InvalidSecretReferencesCondition)},
+	{conditionType: hivev1.InstallerImageResolutionFailedCondition, reason: string(hivev1.InstallerImageResolutionFailedCondition)},
+	{conditionType: hivev1.DNSNotReadyCondition, reason: string(hivev1.DNSNotReadyCondition)},
+	{conditionType: hivev1.InstallLaunchErrorCondition, reason: string(hivev1.InstallLaunchErrorCondition)},
+	{conditionType: hivev1.ProvisionFailedCondition, reason: string(hivev1.ProvisionFailedCondition)},
+	{conditionType: hivev1.DryRunCompleteCondition, reason: string(hivev1.DryRunCompleteCondition)},
+}
+
+// installedRules are the rules for installed clusters, in order of precedence.
+var installedRules = []notReadyRule{
+	{conditionType: hivev1.ClusterHibernatingCondition, reason: string(hivev1.ClusterHibernatingCondition)},
+	{conditionType: hivev1.UnreachableCondition, reason: string(hivev1.UnreachableCondition), unsetReason: hivev1.ReachabilityUnknownReadyReason},
+	{conditionType: hivev1.InvalidSecretReferencesCondition, reason: string(hivev1.InvalidSecretReferencesCondition)},
+	{conditionType: hivev1.ControlPlaneCertificateNotFoundCondition, reason: hivev1.CertificateNotFoundReadyReason},
+	{conditionType: hivev1.IngressCertificateNotFoundCondition, reason: hivev1.CertificateNotFoundReadyReason},
+	{conditionType: hivev1.SyncSetFailedCondition, reason: string(hivev1.SyncSetFailedCondition)},
+	{conditionType: hivev1.PostInstallJobsNotCompleteCondition, reason: string(hivev1.PostInstallJobsNotCompleteCondition)},
+}
+
+// Add creates a new ClusterReady controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterReady {
+	return &ReconcileClusterReady{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterReady, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterready-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterReady{}
+
+// ReconcileClusterReady maintains the Ready condition of a ClusterDeployment.
+type ReconcileClusterReady struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile computes the Ready condition from the other conditions of the ClusterDeployment.
+func (r *ReconcileClusterReady) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Error("error looking up cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	status, reason, message := readyCondition(cd)
+
+	var changed bool
+	if controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReadyCondition) == nil {
+		// The Ready condition is always present, even when it is false.
+		now := metav1.Now()
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ReadyCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+		changed = true
+	} else {
+		cd.Status.Conditions, changed = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ReadyCondition,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if !changed {
+		cdLog.Debug("ready condition unchanged")
+		return reconcile.Result{}, nil
+	}
+
+	cdLog.WithField("ready", status).WithField("reason", reason).Info("updating ready condition")
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating ready condition")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// readyCondition returns the status, reason and message of the Ready condition for the ClusterDeployment. The
+// precedence of the reasons is documented on hivev1.ReadyCondition.
+func readyCondition(cd *hivev1.ClusterDeployment) (corev1.ConditionStatus, string, string) {
+	if cd.DeletionTimestamp != nil {
+		return corev1.ConditionFalse, hivev1.DeletingReadyReason, "ClusterDeployment is being deleted"
+	}
+	if controllerutils.IsDetached(cd) {
+		return corev1.ConditionFalse, string(hivev1.DetachedCondition), "Cluster has been detached from Hive"
+	}
+	if _, relocateStatus, err := controllerutils.IsRelocating(cd); err == nil {
+		switch relocateStatus {
+		case hivev1.RelocateOutgoing, hivev1.RelocateIncoming:
+			return corev1.ConditionFalse, hivev1.RelocatingReadyReason, "ClusterDeployment is being relocated"
+		}
+	}
+	if cond := trueCondition(cd, hivev1.RelocationFailedCondition); cond != nil {
+		return corev1.ConditionFalse, string(hivev1.RelocationFailedCondition), cond.Message
+	}
+
+	rules := installedRules
+	if !cd.Spec.Installed {
+		rules = provisioningRules
+	}
+	for _, rule := range rules {
+		if cond := trueCondition(cd, rule.conditionType); cond != nil {
+			return corev1.ConditionFalse, rule.reason, cond.Message
+		}
+		if rule.unsetReason != "" &&
+			!controllerutils.IsClusterDeploymentConditionSet(controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, rule.conditionType)) {
+			return corev1.ConditionFalse, rule.unsetReason, fmt.Sprintf("%s condition has not been set yet", rule.conditionType)
+		}
+	}
+
+	if !cd.Spec.Installed {
+		return corev1.ConditionFalse, hivev1.ProvisioningReadyReason, "Cluster is being provisioned"
+	}
+	return corev1.ConditionTrue, hivev1.ClusterReadyReason, "Cluster is installed and ready"
+}
+
+func trueCondition(cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType) *hivev1.ClusterDeploymentCondition {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+	return cond
+}
@@ -0,0 +1,288 @@
+package clusterready
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testName      = "test-cluster-deployment"
+	testNamespace = "test-namespace"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func condition(conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus) testcd.Option {
+	return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  "TestReason",
+		Message: "test message",
+	})
+}
+
+// reachable marks the cluster as probed and reachable by the unreachable controller.
+var reachable = condition(hivev1.UnreachableCondition, corev1.ConditionFalse)
+
+func TestReadyCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	cdBuilder := testcd.FullBuilder(testNamespace, testName, scheme)
+
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "provisioning",
+			cd:             cdBuilder.Build(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ProvisioningReadyReason,
+		},
+		{
+			name: "provisioning ignores false conditions",
+			cd: cdBuilder.Build(
+				condition(hivev1.ProvisionFailedCondition, corev1.ConditionFalse),
+				condition(hivev1.DNSNotReadyCondition, corev1.ConditionFalse),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ProvisioningReadyReason,
+		},
+		{
+			name:           "provision failed",
+			cd:             cdBuilder.Build(condition(hivev1.ProvisionFailedCondition, corev1.ConditionTrue)),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ProvisionFailed",
+		},
+		{
+			name: "provision stopped takes precedence over provision failed",
+			cd: cdBuilder.Build(
+				condition(hivev1.ProvisionFailedCondition, corev1.ConditionTrue),
+				condition(hivev1.ProvisionStoppedCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ProvisionStopped",
+		},
+		{
+			name:           "dry run complete",
+			cd:             cdBuilder.Build(condition(hivev1.DryRunCompleteCondition, corev1.ConditionTrue)),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "DryRunComplete",
+		},
+		{
+			name: "dns not ready takes precedence over install launch error",
+			cd: cdBuilder.Build(
+				condition(hivev1.InstallLaunchErrorCondition, corev1.ConditionTrue),
+				condition(hivev1.DNSNotReadyCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "DNSNotReady",
+		},
+		{
+			name:           "installed",
+			cd:             cdBuilder.Build(testcd.Installed(), reachable),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hivev1.ClusterReadyReason,
+		},
+		{
+			name:           "installed, reachability not probed",
+			cd:             cdBuilder.Build(testcd.Installed()),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ReachabilityUnknownReadyReason,
+		},
+		{
+			name: "installed, reachability initialized",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.UnreachableCondition, corev1.ConditionUnknown),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ReachabilityUnknownReadyReason,
+		},
+		{
+			name: "reachability unknown takes precedence over syncset failed",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.SyncSetFailedCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ReachabilityUnknownReadyReason,
+		},
+		{
+			name: "installed ignores provisioning conditions",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				reachable,
+				condition(hivev1.ProvisionFailedCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hivev1.ClusterReadyReason,
+		},
+		{
+			name: "hibernating takes precedence over unreachable",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.UnreachableCondition, corev1.ConditionTrue),
+				condition(hivev1.ClusterHibernatingCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "Hibernating",
+		},
+		{
+			name: "unreachable takes precedence over syncset failed",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.SyncSetFailedCondition, corev1.ConditionTrue),
+				condition(hivev1.UnreachableCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "Unreachable",
+		},
+		{
+			name: "post-install jobs not complete",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				reachable,
+				condition(hivev1.PostInstallJobsNotCompleteCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "PostInstallJobsNotComplete",
+		},
+		{
+			name: "post-install jobs complete",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				reachable,
+				condition(hivev1.PostInstallJobsNotCompleteCondition, corev1.ConditionFalse),
+			),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "ClusterReady",
+		},
+		{
+			name: "ingress certificate not found",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				reachable,
+				condition(hivev1.IngressCertificateNotFoundCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.CertificateNotFoundReadyReason,
+		},
+		{
+			name: "relocation failed",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.RelocationFailedCondition, corev1.ConditionTrue),
+				condition(hivev1.UnreachableCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "RelocationFailed",
+		},
+		{
+			name: "relocating",
+			cd: cdBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.RelocateAnnotation, "test-relocate/outgoing"),
+			).Build(testcd.Installed()),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.RelocatingReadyReason,
+		},
+		{
+			name: "deleting",
+			cd: cdBuilder.GenericOptions(testgeneric.Deleted()).Build(
+				testcd.Installed(),
+				condition(hivev1.RelocationFailedCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.DeletingReadyReason,
+		},
+		{
+			name: "detached",
+			cd: cdBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.RelocateAnnotation, "test-relocate/outgoing"),
+			).Build(testcd.Installed(), testcd.Detached(false)),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: string(hivev1.DetachedCondition),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason, _ := readyCondition(tc.cd)
+			assert.Equal(t, tc.expectedStatus, status, "unexpected status")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	cdBuilder := testcd.FullBuilder(testNamespace, testName, scheme)
+
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "adds false ready condition",
+			cd:             cdBuilder.Build(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.ProvisioningReadyReason,
+		},
+		{
+			name:           "adds true ready condition",
+			cd:             cdBuilder.Build(testcd.Installed(), reachable),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hivev1.ClusterReadyReason,
+		},
+		{
+			name: "updates ready condition",
+			cd: cdBuilder.Build(
+				testcd.Installed(),
+				condition(hivev1.ReadyCondition, corev1.ConditionTrue),
+				condition(hivev1.UnreachableCondition, corev1.ConditionTrue),
+			),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "Unreachable",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, tc.cd)
+			r := &ReconcileClusterReady{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+			}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd), "error getting cluster deployment")
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ReadyCondition)
+			require.NotNil(t, cond, "missing ready condition")
+			assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected ready status")
+			assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected ready reason")
+		})
+	}
+}
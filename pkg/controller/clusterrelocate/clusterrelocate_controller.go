@@ -81,7 +81,7 @@ func Add(mgr manager.Manager) error {
 	}
 
 	c, err := controller.New("clusterrelocate-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
@@ -168,6 +168,8 @@ func (r *ReconcileClusterRelocate) Reconcile(request reconcile.Request) (reconci
 		return reconcile.Result{}, err
 	}
 
+	logger = controllerutils.AddDebugModeLogging(logger, cd)
+
 	currentRelocateName, relocateStatus, err := controllerutils.IsRelocating(cd)
 	if err != nil {
 		logger.WithError(err).Error("could not determine relocate status")
@@ -66,7 +66,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	c, err := controller.New("clusterstate-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -121,6 +121,8 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 		return reconcile.Result{}, err
 	}
 
+	logger = controllerutils.AddDebugModeLogging(logger, cd)
+
 	// Ensure owner references are correctly set
 	err = controllerutils.ReconcileOwnerReferences(cd, generateOwnershipUniqueKeys(cd), r, r.scheme, logger)
 	if err != nil {
@@ -136,6 +138,10 @@ func (r *ReconcileClusterState) Reconcile(request reconcile.Request) (reconcile.
 		logger.Debug("ClusterDeployment is not yet ready")
 		return reconcile.Result{}, nil
 	}
+	if controllerutils.IsDetached(cd) {
+		logger.Debug("cluster is detached")
+		return reconcile.Result{}, nil
+	}
 
 	if cd.Spec.ClusterMetadata == nil {
 		logger.Error("installed cluster with no cluster metadata")
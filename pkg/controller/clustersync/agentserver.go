@@ -0,0 +1,351 @@
+package clustersync
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/syncsetagent"
+)
+
+// agentServer serves the syncsets of the clusters whose syncset agent pulls them, and records the results that the
+// agents report in the ClusterSyncs of the clusters. Each agent authenticates with the token in the token secret of
+// its cluster.
+type agentServer struct {
+	reconciler *ReconcileClusterSync
+	logger     log.FieldLogger
+}
+
+// start serves the agents over TLS, with the serving certificate of the hive-syncset-server Service, until stop is
+// closed.
+func (s *agentServer) start(stop <-chan struct{}) error {
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", syncsetagent.ServerPort),
+		Handler: s,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.WithField("port", syncsetagent.ServerPort).Info("serving syncsets to syncset agents")
+		errCh <- server.ListenAndServeTLS(
+			filepath.Join(syncsetagent.ServingCertDir, corev1.TLSCertKey),
+			filepath.Join(syncsetagent.ServingCertDir, corev1.TLSPrivateKeyKey),
+		)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-stop:
+		return server.Shutdown(context.Background())
+	}
+}
+
+func (s *agentServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// The path is /v1/namespaces/<namespace>/clusterdeployments/<name>/syncsets.
+	parts := strings.Split(req.URL.Path, "/")
+	if len(parts) != 7 || req.URL.Path != syncsetagent.SyncSetsPath(parts[3], parts[5]) {
+		http.NotFound(w, req)
+		return
+	}
+	cdName := types.NamespacedName{Namespace: parts[3], Name: parts[5]}
+	logger := s.logger.WithField("clusterDeployment", cdName)
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := s.reconciler.Get(context.Background(), cdName, cd); {
+	case apierrors.IsNotFound(err):
+		http.NotFound(w, req)
+		return
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterDeployment")
+		http.Error(w, "could not get ClusterDeployment", http.StatusInternalServerError)
+		return
+	}
+	if !controllerutils.PullsSyncSets(cd) {
+		http.NotFound(w, req)
+		return
+	}
+	if ok, err := s.authenticate(req, cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get syncset agent token")
+		http.Error(w, "could not authenticate", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		logger.Warn("rejecting syncset agent request with an invalid token")
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		syncSets, err := s.syncSets(cd, logger)
+		if err != nil {
+			logger.WithError(err).Error("could not get syncsets for syncset agent")
+			http.Error(w, "could not get syncsets", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(syncSets); err != nil {
+			logger.WithError(err).Warn("could not write syncsets for syncset agent")
+		}
+	case http.MethodPost:
+		results := &syncsetagent.Results{}
+		if err := json.NewDecoder(req.Body).Decode(results); err != nil {
+			http.Error(w, "invalid results", http.StatusBadRequest)
+			return
+		}
+		if err := s.recordResults(cd, results, logger); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record results of syncset agent")
+			http.Error(w, "could not record results", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authenticate returns true when the request has the bearer token in the token secret of the cluster.
+func (s *agentServer) authenticate(req *http.Request, cd *hivev1.ClusterDeployment) (bool, error) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return false, nil
+	}
+	secret := &corev1.Secret{}
+	switch err := s.reconciler.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: syncsetagent.TokenSecretName(cd.Name)}, secret); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	expected := secret.Data[syncsetagent.TokenSecretKey]
+	return len(expected) > 0 && subtle.ConstantTimeCompare([]byte(token), expected) == 1, nil
+}
+
+// splitPulledSyncSets splits the syncsets into the one that installs the syncset agent, which is pushed to the
+// cluster, and the ones that the agent pulls.
+func splitPulledSyncSets(syncSets []CommonSyncSet) (pushed, pulled []CommonSyncSet) {
+	for _, syncSet := range syncSets {
+		if syncSet.AsMetaObject().GetLabels()[constants.SyncSetTypeLabel] == constants.SyncSetTypeSyncSetAgent {
+			pushed = append(pushed, syncSet)
+		} else {
+			pulled = append(pulled, syncSet)
+		}
+	}
+	return
+}
+
+// syncSets returns the syncsets that the agent of the cluster pulls, rendered for the cluster, in the order in which
+// the clustersync controller would apply them.
+func (s *agentServer) syncSets(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*syncsetagent.SyncSets, error) {
+	result := &syncsetagent.SyncSets{Items: []syncsetagent.SyncSet{}}
+	if controllerutils.IsSyncSetPaused(cd) {
+		logger.Debug("syncing to cluster is paused by annotation")
+		return result, nil
+	}
+	syncSets, err := s.reconciler.getSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return nil, err
+	}
+	selectorSyncSets, err := s.reconciler.getSelectorSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return nil, err
+	}
+	_, syncSets = splitPulledSyncSets(syncSets)
+	_, selectorSyncSets = splitPulledSyncSets(selectorSyncSets)
+
+	for _, wave := range applyWaves(syncSets, selectorSyncSets) {
+		for _, kind := range []struct {
+			name     string
+			syncSets []CommonSyncSet
+		}{
+			{name: "SyncSet", syncSets: syncSetsInWave(syncSets, wave)},
+			{name: "SelectorSyncSet", syncSets: syncSetsInWave(selectorSyncSets, wave)},
+		} {
+			sort.Slice(kind.syncSets, func(i, j int) bool {
+				return kind.syncSets[i].AsMetaObject().GetName() < kind.syncSets[j].AsMetaObject().GetName()
+			})
+			for _, syncSet := range kind.syncSets {
+				logger := logger.WithField(kind.name, syncSet.AsMetaObject().GetName())
+				result.Items = append(result.Items, s.renderSyncSet(cd, kind.name, syncSet, logger))
+			}
+		}
+	}
+	return result, nil
+}
+
+// renderSyncSet renders the syncset for the cluster. A syncset that cannot be rendered is returned with the error.
+func (s *agentServer) renderSyncSet(cd *hivev1.ClusterDeployment, kind string, syncSet CommonSyncSet, logger log.FieldLogger) syncsetagent.SyncSet {
+	spec := syncSet.GetSpec()
+	rendered := syncsetagent.SyncSet{
+		Kind:              kind,
+		Name:              syncSet.AsMetaObject().GetName(),
+		Generation:        syncSet.AsMetaObject().GetGeneration(),
+		ApplyWave:         spec.ApplyWave,
+		ApplyBehavior:     spec.ApplyBehavior,
+		ResourcesToDelete: spec.ResourcesToDelete,
+	}
+	var data *templateData
+	if spec.Templated {
+		data = newTemplateData(cd)
+	}
+	referencedResources, _, err, _ := s.reconciler.referencedResources(syncSet, logger)
+	if err != nil {
+		rendered.Error = err.Error()
+		return rendered
+	}
+	resources, _, err := decodeResources(syncSetResources(syncSet, referencedResources), data, logger)
+	if err != nil {
+		rendered.Error = err.Error()
+		return rendered
+	}
+	var objects []runtime.Object
+	for _, resource := range resources {
+		objects = append(objects, resource)
+	}
+	for i, secretMapping := range spec.Secrets {
+		secret, err, _ := s.reconciler.sourceSecret(syncSet, i, secretMapping, logger)
+		if err != nil {
+			rendered.Error = err.Error()
+			return rendered
+		}
+		secret.APIVersion = secretAPIVersion
+		secret.Kind = secretKind
+		objects = append(objects, secret)
+	}
+	for i, obj := range objects {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			rendered.Error = errors.Wrapf(err, "failed to encode resource %d", i).Error()
+			return rendered
+		}
+		rendered.Resources = append(rendered.Resources, runtime.RawExtension{Raw: raw})
+	}
+	for i, patch := range spec.Patches {
+		if data != nil {
+			patch.Patch, err = renderTemplate(patch.Patch, data)
+			if err != nil {
+				rendered.Error = errors.Wrapf(err, "failed to render patch %d", i).Error()
+				return rendered
+			}
+		}
+		rendered.Patches = append(rendered.Patches, patch)
+	}
+	return rendered
+}
+
+// recordResults records the results reported by the agent of the cluster in the ClusterSync of the cluster. Results
+// of syncsets that no longer apply to the cluster are ignored.
+func (s *agentServer) recordResults(cd *hivev1.ClusterDeployment, results *syncsetagent.Results, logger log.FieldLogger) error {
+	syncSets, err := s.reconciler.getSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return err
+	}
+	selectorSyncSets, err := s.reconciler.getSelectorSyncSetsForClusterDeployment(cd, logger)
+	if err != nil {
+		return err
+	}
+	_, syncSets = splitPulledSyncSets(syncSets)
+	_, selectorSyncSets = splitPulledSyncSets(selectorSyncSets)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		switch err := s.reconciler.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+		case apierrors.IsNotFound(err):
+			// The clustersync controller does not create the ClusterSync of a cluster that it cannot reach.
+			logger.Info("creating ClusterSync as it does not exist")
+			clusterSync = newClusterSync(cd)
+			if err := s.reconciler.Create(context.Background(), clusterSync); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		}
+		origStatus := clusterSync.Status.DeepCopy()
+
+		for _, result := range results.Items {
+			var known []CommonSyncSet
+			var syncStatuses *[]hiveintv1alpha1.SyncStatus
+			switch result.Kind {
+			case "SyncSet":
+				known, syncStatuses = syncSets, &clusterSync.Status.SyncSets
+			case "SelectorSyncSet":
+				known, syncStatuses = selectorSyncSets, &clusterSync.Status.SelectorSyncSets
+			default:
+				continue
+			}
+			if !containsSyncSet(known, result.Name) {
+				logger.WithField(result.Kind, result.Name).Debug("ignoring result of syncset that does not apply to the cluster")
+				continue
+			}
+			setAgentSyncStatus(syncStatuses, result)
+		}
+
+		setFailedCondition(clusterSync)
+		if clusterSync.Status.FirstSuccessTime == nil {
+			syncStatuses := append(append([]hiveintv1alpha1.SyncStatus{}, clusterSync.Status.SyncSets...), clusterSync.Status.SelectorSyncSets...)
+			if len(syncStatuses) > 0 {
+				s.reconciler.setFirstSuccessTime(syncStatuses, cd, clusterSync, logger)
+			}
+		}
+		if reflect.DeepEqual(origStatus, &clusterSync.Status) {
+			return nil
+		}
+		logger.Info("updating ClusterSync with results of syncset agent")
+		return s.reconciler.Status().Update(context.Background(), clusterSync)
+	})
+}
+
+// setAgentSyncStatus sets the sync status of the syncset from the result that the agent reported. The agent does not
+// track the resources of the syncset, so the status has no resources to delete.
+func setAgentSyncStatus(syncStatuses *[]hiveintv1alpha1.SyncStatus, result syncsetagent.Result) {
+	index := -1
+	var oldSyncStatus hiveintv1alpha1.SyncStatus
+	for i, status := range *syncStatuses {
+		if status.Name == result.Name {
+			index, oldSyncStatus = i, status
+			break
+		}
+	}
+	newSyncStatus := hiveintv1alpha1.SyncStatus{
+		Name:               result.Name,
+		ObservedGeneration: result.Generation,
+		Result:             hiveintv1alpha1.SuccessSyncSetResult,
+		LastTransitionTime: oldSyncStatus.LastTransitionTime,
+		FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+	}
+	if result.FailureMessage != "" {
+		newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		newSyncStatus.FailureMessage = result.FailureMessage
+	}
+	if !syncStatusesEqualIgnoringApplies(oldSyncStatus, newSyncStatus) {
+		newSyncStatus.LastTransitionTime = metav1.Now()
+	}
+	if newSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult && newSyncStatus.FirstSuccessTime == nil {
+		now := metav1.Now()
+		newSyncStatus.FirstSuccessTime = &now
+	}
+	if index < 0 {
+		*syncStatuses = append(*syncStatuses, newSyncStatus)
+		return
+	}
+	(*syncStatuses)[index] = newSyncStatus
+}
@@ -0,0 +1,294 @@
+package clustersync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/syncsetagent"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+const testAgentToken = "test-token"
+
+func testAgentCD(scheme *runtime.Scheme) *hivev1.ClusterDeployment {
+	return cdBuilder(scheme).GenericOptions(
+		testgeneric.WithAnnotation(constants.SyncSetAgentAnnotation, "true"),
+		testgeneric.WithLabel("test-label-key", "test-label-value"),
+	).Build()
+}
+
+func testAgentTokenSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: syncsetagent.TokenSecretName(testCDName)},
+		Data:       map[string][]byte{syncsetagent.TokenSecretKey: []byte(testAgentToken)},
+	}
+}
+
+func newTestAgentServer(scheme *runtime.Scheme, existing ...runtime.Object) *agentServer {
+	return &agentServer{
+		reconciler: &ReconcileClusterSync{
+			Client: fake.NewFakeClientWithScheme(scheme, existing...),
+			logger: log.StandardLogger(),
+		},
+		logger: log.StandardLogger(),
+	}
+}
+
+func agentRequest(method, token string, body interface{}) *http.Request {
+	var reqBody bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&reqBody).Encode(body)
+	}
+	req := httptest.NewRequest(method, syncsetagent.SyncSetsPath(testNamespace, testCDName), &reqBody)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestAgentServerAuthentication(t *testing.T) {
+	scheme := newScheme()
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		token          string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "valid token",
+			cd:             testAgentCD(scheme),
+			token:          testAgentToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid token",
+			cd:             testAgentCD(scheme),
+			token:          "other-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "no token",
+			cd:             testAgentCD(scheme),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "cluster without syncset agent",
+			cd:             cdBuilder(scheme).Build(),
+			token:          testAgentToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "no cluster",
+			token:          testAgentToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown path",
+			cd:             testAgentCD(scheme),
+			token:          testAgentToken,
+			path:           "/v1/namespaces/" + testNamespace + "/clusterdeployments/" + testCDName,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := []runtime.Object{testAgentTokenSecret()}
+			if tc.cd != nil {
+				existing = append(existing, tc.cd)
+			}
+			s := newTestAgentServer(scheme, existing...)
+			req := agentRequest(http.MethodGet, tc.token, nil)
+			if tc.path != "" {
+				req.URL.Path = tc.path
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatus, w.Code, "unexpected response status")
+		})
+	}
+}
+
+func TestAgentServerSyncSets(t *testing.T) {
+	scheme := newScheme()
+	cd := testAgentCD(scheme)
+	agentSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-agent", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "agent")),
+		testsyncset.Generic(testgeneric.WithLabel(constants.SyncSetTypeLabel, constants.SyncSetTypeSyncSetAgent)),
+	)
+	templatedSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-templated", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithTemplated(),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "{{ .ClusterName }}")),
+		testsyncset.WithSecrets(testSecretMapping("test-secret", "dest-namespace", "dest-secret")),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "dest-namespace",
+			Name:       "patched",
+			Patch:      `{"data":{"cluster":"{{ .ClusterName }}"}}`,
+			PatchType:  "merge",
+		}),
+	)
+	laterSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-a", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithApplyWave(1),
+		testsyncset.WithSecrets(testSecretMapping("missing-secret", "dest-namespace", "dest-secret")),
+	)
+	selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithApplyBehavior(hivev1.ServerSideApplySyncSetApplyBehavior),
+	)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-secret"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+	s := newTestAgentServer(scheme, cd, testAgentTokenSecret(), agentSyncSet, templatedSyncSet, laterSyncSet, selectorSyncSet, secret)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, agentRequest(http.MethodGet, testAgentToken, nil))
+	require.Equal(t, http.StatusOK, w.Code, "unexpected response status")
+	syncSets := &syncsetagent.SyncSets{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(syncSets), "could not decode syncsets")
+
+	// The syncset that installs the agent is not pulled, and the syncsets are in the order of their waves.
+	require.Len(t, syncSets.Items, 3, "unexpected number of syncsets")
+	templated := syncSets.Items[0]
+	assert.Equal(t, "SyncSet", templated.Kind)
+	assert.Equal(t, "test-syncset-templated", templated.Name)
+	assert.Equal(t, int64(2), templated.Generation)
+	assert.Empty(t, templated.Error, "unexpected error rendering syncset")
+	if assert.Len(t, templated.Resources, 2, "expected resource and secret") {
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, json.Unmarshal(templated.Resources[0].Raw, cm))
+		assert.Equal(t, testCDName, cm.Name, "resource not rendered")
+		renderedSecret := &corev1.Secret{}
+		require.NoError(t, json.Unmarshal(templated.Resources[1].Raw, renderedSecret))
+		assert.Equal(t, "Secret", renderedSecret.Kind)
+		assert.Equal(t, "dest-namespace", renderedSecret.Namespace)
+		assert.Equal(t, "dest-secret", renderedSecret.Name)
+		assert.Equal(t, []byte("value"), renderedSecret.Data["key"])
+	}
+	if assert.Len(t, templated.Patches, 1) {
+		assert.Equal(t, `{"data":{"cluster":"`+testCDName+`"}}`, templated.Patches[0].Patch, "patch not rendered")
+	}
+
+	assert.Equal(t, "SelectorSyncSet", syncSets.Items[1].Kind)
+	assert.Equal(t, "test-selectorsyncset", syncSets.Items[1].Name)
+	assert.Equal(t, hivev1.ServerSideApplySyncSetApplyBehavior, syncSets.Items[1].ApplyBehavior)
+
+	assert.Equal(t, "test-syncset-a", syncSets.Items[2].Name)
+	assert.Equal(t, int32(1), syncSets.Items[2].ApplyWave)
+	assert.Contains(t, syncSets.Items[2].Error, "failed to read secret 0", "expected error for missing secret")
+}
+
+func TestAgentServerResults(t *testing.T) {
+	scheme := newScheme()
+	pulledSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+	)
+	failingSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-failing", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+	)
+	selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+	)
+	results := &syncsetagent.Results{Items: []syncsetagent.Result{
+		{Kind: "SyncSet", Name: "test-syncset", Generation: 2},
+		{Kind: "SyncSet", Name: "test-syncset-failing", Generation: 1, FailureMessage: "failed to apply resource 0: test error"},
+		{Kind: "SelectorSyncSet", Name: "test-selectorsyncset", Generation: 1},
+		{Kind: "SyncSet", Name: "test-syncset-removed", Generation: 1},
+	}}
+	cases := []struct {
+		name        string
+		clusterSync *hiveintv1alpha1.ClusterSync
+	}{
+		{
+			name: "existing ClusterSync",
+			clusterSync: clusterSyncBuilder(scheme).Build(
+				testcs.WithSyncSetStatus(buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())),
+			),
+		},
+		{
+			name: "no ClusterSync",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := []runtime.Object{testAgentCD(scheme), testAgentTokenSecret(), pulledSyncSet, failingSyncSet, selectorSyncSet}
+			if tc.clusterSync != nil {
+				existing = append(existing, tc.clusterSync)
+			}
+			s := newTestAgentServer(scheme, existing...)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, agentRequest(http.MethodPost, testAgentToken, results))
+			require.Equal(t, http.StatusNoContent, w.Code, "unexpected response status: %s", w.Body.String())
+
+			clusterSync := &hiveintv1alpha1.ClusterSync{}
+			require.NoError(t, s.reconciler.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testCDName}, clusterSync))
+			if assert.Len(t, clusterSync.Status.SyncSets, 2, "unexpected syncset statuses") {
+				status := clusterSync.Status.SyncSets[0]
+				assert.Equal(t, "test-syncset", status.Name)
+				assert.Equal(t, int64(2), status.ObservedGeneration)
+				assert.Equal(t, hiveintv1alpha1.SuccessSyncSetResult, status.Result)
+				assert.NotNil(t, status.FirstSuccessTime)
+				if tc.clusterSync != nil {
+					assert.Equal(t, tc.clusterSync.Status.SyncSets[0].FirstSuccessTime.Unix(), status.FirstSuccessTime.Unix(), "first success time changed")
+				}
+				failing := clusterSync.Status.SyncSets[1]
+				assert.Equal(t, "test-syncset-failing", failing.Name)
+				assert.Equal(t, hiveintv1alpha1.FailureSyncSetResult, failing.Result)
+				assert.Equal(t, "failed to apply resource 0: test error", failing.FailureMessage)
+				assert.Nil(t, failing.FirstSuccessTime)
+			}
+			if assert.Len(t, clusterSync.Status.SelectorSyncSets, 1, "unexpected selectorsyncset statuses") {
+				assert.Equal(t, "test-selectorsyncset", clusterSync.Status.SelectorSyncSets[0].Name)
+				assert.Equal(t, hiveintv1alpha1.SuccessSyncSetResult, clusterSync.Status.SelectorSyncSets[0].Result)
+			}
+			if assert.Len(t, clusterSync.Status.Conditions, 1) {
+				assert.Equal(t, "SyncSet test-syncset-failing is failing", clusterSync.Status.Conditions[0].Message)
+			}
+		})
+	}
+}
+
+func TestAgentServerSyncSetsPaused(t *testing.T) {
+	scheme := newScheme()
+	cd := testAgentCD(scheme)
+	cd.Annotations[constants.SyncsetPauseAnnotation] = "true"
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+	)
+	s := newTestAgentServer(scheme, cd, testAgentTokenSecret(), syncSet)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, agentRequest(http.MethodGet, testAgentToken, nil))
+	require.Equal(t, http.StatusOK, w.Code, "unexpected response status")
+	syncSets := &syncsetagent.SyncSets{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(syncSets), "could not decode syncsets")
+	assert.Empty(t, syncSets.Items, "expected no syncsets for a paused cluster")
+}
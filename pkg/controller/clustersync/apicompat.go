@@ -0,0 +1,304 @@
+package clustersync
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// apiRemoval describes an API version of a kind that is no longer served by Kubernetes.
+type apiRemoval struct {
+	// removedIn is the minor version of Kubernetes 1.x that stopped serving the API version.
+	removedIn int
+	// replacement is the API version that replaces the removed API version, if any.
+	replacement string
+	// convert converts a resource to the replacement API version in place. When nil, the resource only needs its API
+	// version to be replaced.
+	convert func(u *unstructured.Unstructured) error
+	// unconvertible, when set, is why resources cannot safely be converted to the replacement API version.
+	unconvertible string
+}
+
+// apiRemovals are the removed API versions of the kinds that are commonly synced to clusters.
+var apiRemovals = map[schema.GroupVersionKind]apiRemoval{
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRole"}:        {removedIn: 22, replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRoleBinding"}: {removedIn: 22, replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "Role"}:               {removedIn: 22, replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "RoleBinding"}:        {removedIn: 22, replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}:                           {removedIn: 22, replacement: "networking.k8s.io/v1", convert: convertIngress},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"}:                    {removedIn: 22, replacement: "networking.k8s.io/v1", convert: convertIngress},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "IngressClass"}:               {removedIn: 22, replacement: "networking.k8s.io/v1"},
+	{Group: "apiregistration.k8s.io", Version: "v1beta1", Kind: "APIService"}:            {removedIn: 22, replacement: "apiregistration.k8s.io/v1"},
+	{Group: "coordination.k8s.io", Version: "v1beta1", Kind: "Lease"}:                    {removedIn: 22, replacement: "coordination.k8s.io/v1"},
+	{Group: "scheduling.k8s.io", Version: "v1beta1", Kind: "PriorityClass"}:              {removedIn: 22, replacement: "scheduling.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIDriver"}:                     {removedIn: 22, replacement: "storage.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "StorageClass"}:                  {removedIn: 22, replacement: "storage.k8s.io/v1"},
+	{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"}: {
+		removedIn:     22,
+		replacement:   "apiextensions.k8s.io/v1",
+		unconvertible: "apiextensions.k8s.io/v1 requires a structural schema for every version",
+	},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingWebhookConfiguration"}: {
+		removedIn:     22,
+		replacement:   "admissionregistration.k8s.io/v1",
+		unconvertible: "admissionregistration.k8s.io/v1 requires sideEffects and admissionReviewVersions, and changes the default failurePolicy and matchPolicy",
+	},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingWebhookConfiguration"}: {
+		removedIn:     22,
+		replacement:   "admissionregistration.k8s.io/v1",
+		unconvertible: "admissionregistration.k8s.io/v1 requires sideEffects and admissionReviewVersions, and changes the default failurePolicy and matchPolicy",
+	},
+	{Group: "certificates.k8s.io", Version: "v1beta1", Kind: "CertificateSigningRequest"}: {
+		removedIn:     22,
+		replacement:   "certificates.k8s.io/v1",
+		unconvertible: "certificates.k8s.io/v1 requires a signerName",
+	},
+	{Group: "batch", Version: "v1beta1", Kind: "CronJob"}:                       {removedIn: 25, replacement: "batch/v1"},
+	{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"}:          {removedIn: 25, replacement: "policy/v1", convert: convertPodDisruptionBudget},
+	{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"}:            {removedIn: 25, unconvertible: "PodSecurityPolicy was removed without a replacement"},
+	{Group: "autoscaling", Version: "v2beta2", Kind: "HorizontalPodAutoscaler"}: {removedIn: 26, replacement: "autoscaling/v2"},
+}
+
+// openShiftKubernetesMinorOffset is the difference between the minor version of OpenShift 4.x and the minor version
+// of the Kubernetes 1.x that it is based on.
+const openShiftKubernetesMinorOffset = 13
+
+// kubernetesMinorVersion returns the minor version of Kubernetes 1.x of the cluster, derived from its OpenShift
+// version.
+func kubernetesMinorVersion(cd *hivev1.ClusterDeployment) (int, bool) {
+	version, err := semver.ParseTolerant(cd.Labels[constants.VersionMajorMinorPatchLabel])
+	if err != nil || version.Major != 4 {
+		return 0, false
+	}
+	return int(version.Minor) + openShiftKubernetesMinorOffset, true
+}
+
+// apiCompatHelper is a resource helper which converts resources of API versions that the target cluster no longer
+// serves to the API versions that replace them, where a safe conversion exists. Resources that cannot safely be
+// converted fail with an error naming the resource and why it cannot be converted.
+type apiCompatHelper struct {
+	resource.Helper
+	kubernetesMinor int
+}
+
+// newAPICompatHelper wraps a resource helper for a cluster running the given minor version of Kubernetes 1.x.
+func newAPICompatHelper(helper resource.Helper, kubernetesMinor int) resource.Helper {
+	return &apiCompatHelper{Helper: helper, kubernetesMinor: kubernetesMinor}
+}
+
+func (h *apiCompatHelper) Apply(obj []byte) (resource.ApplyResult, error) {
+	obj, err := h.convert(obj)
+	if err != nil {
+		return "", err
+	}
+	return h.Helper.Apply(obj)
+}
+
+func (h *apiCompatHelper) CreateOrUpdate(obj []byte) (resource.ApplyResult, error) {
+	obj, err := h.convert(obj)
+	if err != nil {
+		return "", err
+	}
+	return h.Helper.CreateOrUpdate(obj)
+}
+
+func (h *apiCompatHelper) Create(obj []byte) (resource.ApplyResult, error) {
+	obj, err := h.convert(obj)
+	if err != nil {
+		return "", err
+	}
+	return h.Helper.Create(obj)
+}
+
+func (h *apiCompatHelper) ServerSideApply(obj []byte) (resource.ApplyResult, error) {
+	obj, err := h.convert(obj)
+	if err != nil {
+		return "", err
+	}
+	return h.Helper.ServerSideApply(obj)
+}
+
+// Patch patches the resource through the replacement API version when the content of the resource is the same in
+// both API versions. Patches of other removed API versions fail, since the patch itself would need converting.
+func (h *apiCompatHelper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+	removal, removed := h.removal(apiVersion, kind)
+	if !removed {
+		return h.Helper.Patch(name, kind, apiVersion, patch, patchType)
+	}
+	if removal.unconvertible != "" || removal.convert != nil {
+		return removedAPIError(apiVersion, kind, name.Namespace, name.Name, removal, "patches cannot be converted")
+	}
+	return h.Helper.Patch(name, kind, removal.replacement, patch, patchType)
+}
+
+// Get gets the resource through the replacement API version, which serves the same resources.
+func (h *apiCompatHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if removal, removed := h.removal(apiVersion, kind); removed && removal.replacement != "" {
+		apiVersion = removal.replacement
+	}
+	return h.Helper.Get(apiVersion, kind, namespace, name)
+}
+
+// Delete deletes the resource through the replacement API version, which serves the same resources.
+func (h *apiCompatHelper) Delete(apiVersion, kind, namespace, name string) error {
+	if removal, removed := h.removal(apiVersion, kind); removed && removal.replacement != "" {
+		apiVersion = removal.replacement
+	}
+	return h.Helper.Delete(apiVersion, kind, namespace, name)
+}
+
+// removal returns the removal of the API version of the kind, if the target cluster no longer serves it.
+func (h *apiCompatHelper) removal(apiVersion, kind string) (apiRemoval, bool) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return apiRemoval{}, false
+	}
+	removal, ok := apiRemovals[gv.WithKind(kind)]
+	if !ok || h.kubernetesMinor < removal.removedIn {
+		return apiRemoval{}, false
+	}
+	return removal, true
+}
+
+// convert converts the serialized resource to the replacement of its API version, if the target cluster no longer
+// serves its API version.
+func (h *apiCompatHelper) convert(obj []byte) ([]byte, error) {
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(obj, u); err != nil {
+		// Leave the error to the underlying helper.
+		return obj, nil
+	}
+	removal, removed := h.removal(u.GetAPIVersion(), u.GetKind())
+	if !removed {
+		return obj, nil
+	}
+	if removal.unconvertible != "" {
+		return nil, removedAPIError(u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName(), removal, removal.unconvertible)
+	}
+	if removal.convert != nil {
+		if err := removal.convert(u); err != nil {
+			return nil, removedAPIError(u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName(), removal, err.Error())
+		}
+	}
+	u.SetAPIVersion(removal.replacement)
+	return json.Marshal(u)
+}
+
+func removedAPIError(apiVersion, kind, namespace, name string, removal apiRemoval, reason string) error {
+	resourceName := name
+	if namespace != "" {
+		resourceName = namespace + "/" + name
+	}
+	if removal.replacement == "" {
+		return fmt.Errorf("%s %s %s is not served by Kubernetes 1.%d and later: %s",
+			apiVersion, kind, resourceName, removal.removedIn, reason)
+	}
+	return fmt.Errorf("%s %s %s is not served by Kubernetes 1.%d and later and cannot be converted to %s: %s",
+		apiVersion, kind, resourceName, removal.removedIn, removal.replacement, reason)
+}
+
+// convertIngress converts an extensions/v1beta1 or networking.k8s.io/v1beta1 Ingress to networking.k8s.io/v1.
+func convertIngress(u *unstructured.Unstructured) error {
+	if backend, ok, _ := unstructured.NestedMap(u.Object, "spec", "backend"); ok {
+		converted, err := convertIngressBackend(backend)
+		if err != nil {
+			return errors.Wrap(err, "spec.backend")
+		}
+		unstructured.RemoveNestedField(u.Object, "spec", "backend")
+		if err := unstructured.SetNestedMap(u.Object, converted, "spec", "defaultBackend"); err != nil {
+			return err
+		}
+	}
+	rules, _, err := unstructured.NestedSlice(u.Object, "spec", "rules")
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		rule, ok := rules[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paths, _, err := unstructured.NestedSlice(rule, "http", "paths")
+		if err != nil {
+			return err
+		}
+		for j := range paths {
+			path, ok := paths[j].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if backend, ok, _ := unstructured.NestedMap(path, "backend"); ok {
+				converted, err := convertIngressBackend(backend)
+				if err != nil {
+					return errors.Wrapf(err, "spec.rules[%d].http.paths[%d].backend", i, j)
+				}
+				path["backend"] = converted
+			}
+			// The path type is required in networking.k8s.io/v1. ImplementationSpecific is the behavior of paths
+			// without a type in the beta API versions.
+			if _, ok := path["pathType"]; !ok {
+				path["pathType"] = "ImplementationSpecific"
+			}
+		}
+		if len(paths) > 0 {
+			if err := unstructured.SetNestedSlice(rule, paths, "http", "paths"); err != nil {
+				return err
+			}
+		}
+	}
+	if len(rules) > 0 {
+		return unstructured.SetNestedSlice(u.Object, rules, "spec", "rules")
+	}
+	return nil
+}
+
+// convertIngressBackend converts the serviceName and servicePort of an Ingress backend to the service of a
+// networking.k8s.io/v1 backend.
+func convertIngressBackend(backend map[string]interface{}) (map[string]interface{}, error) {
+	serviceName, hasServiceName := backend["serviceName"]
+	servicePort, hasServicePort := backend["servicePort"]
+	if !hasServiceName && !hasServicePort {
+		return backend, nil
+	}
+	port := map[string]interface{}{}
+	switch p := servicePort.(type) {
+	case int64:
+		port["number"] = p
+	case float64:
+		port["number"] = int64(p)
+	case string:
+		port["name"] = p
+	default:
+		return nil, fmt.Errorf("unsupported servicePort %v", servicePort)
+	}
+	converted := map[string]interface{}{}
+	for k, v := range backend {
+		if k != "serviceName" && k != "servicePort" {
+			converted[k] = v
+		}
+	}
+	converted["service"] = map[string]interface{}{
+		"name": serviceName,
+		"port": port,
+	}
+	return converted, nil
+}
+
+// convertPodDisruptionBudget converts a policy/v1beta1 PodDisruptionBudget to policy/v1. The content is the same,
+// except that an empty selector selects no pods in policy/v1beta1 but every pod of the namespace in policy/v1.
+func convertPodDisruptionBudget(u *unstructured.Unstructured) error {
+	selector, ok, _ := unstructured.NestedMap(u.Object, "spec", "selector")
+	if !ok || len(selector) == 0 {
+		return errors.New("an empty selector selects no pods in policy/v1beta1 but every pod in policy/v1")
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package clustersync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+	resourcemock "github.com/openshift/hive/pkg/resource/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestAPICompatHelperApply(t *testing.T) {
+	cases := []struct {
+		name            string
+		kubernetesMinor int
+		obj             string
+		expectedObj     string
+		expectedErr     string
+	}{
+		{
+			name:            "served API version",
+			kubernetesMinor: 22,
+			obj:             `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"}}`,
+			expectedObj:     `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"}}`,
+		},
+		{
+			name:            "removed API version still served",
+			kubernetesMinor: 21,
+			obj:             `{"apiVersion":"rbac.authorization.k8s.io/v1beta1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"}}`,
+			expectedObj:     `{"apiVersion":"rbac.authorization.k8s.io/v1beta1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"}}`,
+		},
+		{
+			name:            "rbac",
+			kubernetesMinor: 22,
+			obj:             `{"apiVersion":"rbac.authorization.k8s.io/v1beta1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"},"rules":[{"apiGroups":[""],"resources":["pods"],"verbs":["get"]}]}`,
+			expectedObj:     `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"Role","metadata":{"name":"test","namespace":"test-ns"},"rules":[{"apiGroups":[""],"resources":["pods"],"verbs":["get"]}]}`,
+		},
+		{
+			name:            "ingress",
+			kubernetesMinor: 22,
+			obj: `{"apiVersion":"extensions/v1beta1","kind":"Ingress","metadata":{"name":"test","namespace":"test-ns"},"spec":{
+				"backend":{"serviceName":"default","servicePort":8080},
+				"rules":[{"host":"example.com","http":{"paths":[{"path":"/","backend":{"serviceName":"web","servicePort":"http"}},{"path":"/api","pathType":"Prefix","backend":{"serviceName":"api","servicePort":80}}]}}]}}`,
+			expectedObj: `{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"test","namespace":"test-ns"},"spec":{
+				"defaultBackend":{"service":{"name":"default","port":{"number":8080}}},
+				"rules":[{"host":"example.com","http":{"paths":[{"path":"/","pathType":"ImplementationSpecific","backend":{"service":{"name":"web","port":{"name":"http"}}}},{"path":"/api","pathType":"Prefix","backend":{"service":{"name":"api","port":{"number":80}}}}]}}]}}`,
+		},
+		{
+			name:            "pod disruption budget",
+			kubernetesMinor: 25,
+			obj:             `{"apiVersion":"policy/v1beta1","kind":"PodDisruptionBudget","metadata":{"name":"test","namespace":"test-ns"},"spec":{"minAvailable":1,"selector":{"matchLabels":{"app":"test"}}}}`,
+			expectedObj:     `{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"test","namespace":"test-ns"},"spec":{"minAvailable":1,"selector":{"matchLabels":{"app":"test"}}}}`,
+		},
+		{
+			name:            "pod disruption budget with empty selector",
+			kubernetesMinor: 25,
+			obj:             `{"apiVersion":"policy/v1beta1","kind":"PodDisruptionBudget","metadata":{"name":"test","namespace":"test-ns"},"spec":{"minAvailable":1}}`,
+			expectedErr:     "policy/v1beta1 PodDisruptionBudget test-ns/test is not served by Kubernetes 1.25 and later and cannot be converted to policy/v1: an empty selector selects no pods in policy/v1beta1 but every pod in policy/v1",
+		},
+		{
+			name:            "custom resource definition",
+			kubernetesMinor: 22,
+			obj:             `{"apiVersion":"apiextensions.k8s.io/v1beta1","kind":"CustomResourceDefinition","metadata":{"name":"tests.example.com"}}`,
+			expectedErr:     "apiextensions.k8s.io/v1beta1 CustomResourceDefinition tests.example.com is not served by Kubernetes 1.22 and later and cannot be converted to apiextensions.k8s.io/v1: apiextensions.k8s.io/v1 requires a structural schema for every version",
+		},
+		{
+			name:            "pod security policy",
+			kubernetesMinor: 25,
+			obj:             `{"apiVersion":"policy/v1beta1","kind":"PodSecurityPolicy","metadata":{"name":"test"}}`,
+			expectedErr:     "policy/v1beta1 PodSecurityPolicy test is not served by Kubernetes 1.25 and later: PodSecurityPolicy was removed without a replacement",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockHelper := resourcemock.NewMockHelper(mockCtrl)
+			var applied []byte
+			if tc.expectedErr == "" {
+				mockHelper.EXPECT().Apply(gomock.Any()).DoAndReturn(func(obj []byte) (resource.ApplyResult, error) {
+					applied = obj
+					return resource.CreatedApplyResult, nil
+				})
+			}
+			_, err := newAPICompatHelper(mockHelper, tc.kubernetesMinor).Apply([]byte(tc.obj))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr, "unexpected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			var expected, actual interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.expectedObj), &expected), "could not decode expected object")
+			require.NoError(t, json.Unmarshal(applied, &actual), "could not decode applied object")
+			assert.Equal(t, expected, actual, "unexpected applied object")
+		})
+	}
+}
+
+func TestAPICompatHelperDeleteAndPatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockHelper := resourcemock.NewMockHelper(mockCtrl)
+	helper := newAPICompatHelper(mockHelper, 22)
+	name := types.NamespacedName{Namespace: "test-ns", Name: "test"}
+
+	mockHelper.EXPECT().Delete("rbac.authorization.k8s.io/v1", "Role", "test-ns", "test").Return(nil)
+	assert.NoError(t, helper.Delete("rbac.authorization.k8s.io/v1beta1", "Role", "test-ns", "test"), "unexpected delete error")
+
+	mockHelper.EXPECT().Delete("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "tests.example.com").Return(nil)
+	assert.NoError(t, helper.Delete("apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "", "tests.example.com"), "unexpected delete error")
+
+	mockHelper.EXPECT().Patch(name, "Role", "rbac.authorization.k8s.io/v1", []byte(`{}`), "merge").Return(nil)
+	assert.NoError(t, helper.Patch(name, "Role", "rbac.authorization.k8s.io/v1beta1", []byte(`{}`), "merge"), "unexpected patch error")
+
+	err := helper.Patch(name, "Ingress", "networking.k8s.io/v1beta1", []byte(`{}`), "merge")
+	assert.EqualError(t, err, "networking.k8s.io/v1beta1 Ingress test-ns/test is not served by Kubernetes 1.22 and later and cannot be converted to networking.k8s.io/v1: patches cannot be converted", "unexpected patch error")
+}
+
+func TestKubernetesMinorVersion(t *testing.T) {
+	cases := []struct {
+		version       string
+		expectedMinor int
+		expectedOK    bool
+	}{
+		{version: "4.9.0", expectedMinor: 22, expectedOK: true},
+		{version: "4.12.3", expectedMinor: 25, expectedOK: true},
+		{version: ""},
+		{version: "3.11.0"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			cd := testcd.BasicBuilder().Build(testcd.WithLabel(constants.VersionMajorMinorPatchLabel, tc.version))
+			minor, ok := kubernetesMinorVersion(cd)
+			assert.Equal(t, tc.expectedOK, ok, "unexpected ok")
+			assert.Equal(t, tc.expectedMinor, minor, "unexpected minor version")
+		})
+	}
+}
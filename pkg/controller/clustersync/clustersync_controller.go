@@ -2,7 +2,9 @@ package clustersync
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"reflect"
@@ -10,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -18,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -35,6 +39,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/clusterselector"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -43,17 +48,20 @@ import (
 )
 
 const (
-	ControllerName         = hivev1.ClustersyncControllerName
-	defaultReapplyInterval = 2 * time.Hour
-	reapplyIntervalEnvKey  = "SYNCSET_REAPPLY_INTERVAL"
-	reapplyIntervalJitter  = 0.1
-	secretAPIVersion       = "v1"
-	secretKind             = "Secret"
-	labelApply             = "apply"
-	labelCreateOrUpdate    = "createOrUpdate"
-	labelCreateOnly        = "createOnly"
-	metricResultSuccess    = "success"
-	metricResultError      = "error"
+	ControllerName            = hivev1.ClustersyncControllerName
+	defaultReapplyInterval    = 2 * time.Hour
+	reapplyIntervalEnvKey     = "SYNCSET_REAPPLY_INTERVAL"
+	reapplyIntervalJitter     = 0.1
+	defaultDriftCheckInterval = 10 * time.Minute
+	driftCheckIntervalEnvKey  = "SYNCSET_DRIFT_CHECK_INTERVAL"
+	secretAPIVersion          = "v1"
+	secretKind                = "Secret"
+	labelApply                = "apply"
+	labelCreateOrUpdate       = "createOrUpdate"
+	labelCreateOnly           = "createOnly"
+	labelServerSideApply      = "serverSideApply"
+	metricResultSuccess       = "success"
+	metricResultError         = "error"
 )
 
 var (
@@ -120,6 +128,12 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+	if r.syncSetAgentEnabled {
+		server := &agentServer{reconciler: r, logger: logger.WithField("component", "syncset-server")}
+		if err := mgr.Add(manager.RunnableFunc(server.start)); err != nil {
+			return err
+		}
+	}
 	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
 }
 
@@ -136,11 +150,37 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		}
 	}
 	log.WithField("reapplyInterval", reapplyInterval).Info("Reapply interval set")
+	driftCheckInterval := defaultDriftCheckInterval
+	if envDriftCheckInterval := os.Getenv(driftCheckIntervalEnvKey); len(envDriftCheckInterval) > 0 {
+		var err error
+		driftCheckInterval, err = time.ParseDuration(envDriftCheckInterval)
+		if err != nil {
+			log.WithError(err).WithField("driftCheckInterval", envDriftCheckInterval).Errorf("unable to parse %s", driftCheckIntervalEnvKey)
+			return nil, err
+		}
+	}
+	log.WithField("driftCheckInterval", driftCheckInterval).Info("Drift check interval set")
+	shard, err := shardFromEnv()
+	if err != nil {
+		log.WithError(err).Error("unable to determine shard")
+		return nil, err
+	}
+	if shard.replicas > 1 {
+		log.WithField("ordinal", shard.ordinal).WithField("replicas", shard.replicas).WithField("seed", shard.seed).Info("syncing a shard of the ClusterDeployments")
+	}
+	syncSetAgent, err := controllerutils.GetSyncSetAgentConfig()
+	if err != nil {
+		log.WithError(err).Error("unable to get syncset agent config")
+		return nil, err
+	}
 	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
 	return &ReconcileClusterSync{
 		Client:                c,
 		logger:                logger,
 		reapplyInterval:       reapplyInterval,
+		driftCheckInterval:    driftCheckInterval,
+		shard:                 shard,
+		syncSetAgentEnabled:   syncSetAgent != nil,
 		resourceHelperBuilder: resourceHelperBuilderFunc,
 		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 			return remoteclient.NewBuilder(c, cd, ControllerName)
@@ -148,15 +188,26 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 	}, nil
 }
 
-func resourceHelperBuilderFunc(restConfig *rest.Config, logger log.FieldLogger) (resource.Helper, error) {
-	return resource.NewHelperFromRESTConfig(restConfig, logger), nil
+// resourceHelperBuilderFunc builds a resource helper for the cluster. Clusters of the same version share their cached
+// discovery data, so that the discovery data is not fetched from each of them after the controller restarts. When the
+// version of the cluster is known, resources of API versions that it no longer serves are converted where possible.
+func resourceHelperBuilderFunc(cd *hivev1.ClusterDeployment, restConfig *rest.Config, logger log.FieldLogger) (resource.Helper, error) {
+	version := cd.Labels[constants.VersionMajorMinorPatchLabel]
+	if version == "" {
+		return resource.NewHelperFromRESTConfig(restConfig, logger), nil
+	}
+	helper := resource.NewHelperWithSharedDiscoveryFromRESTConfig(restConfig, version, logger)
+	if kubernetesMinor, ok := kubernetesMinorVersion(cd); ok {
+		helper = newAPICompatHelper(helper, kubernetesMinor)
+	}
+	return helper, nil
 }
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterSync-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -164,16 +215,28 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 		return err
 	}
 
+	// Only the ClusterDeployments of the shard of this replica are enqueued.
+	sharding := newSharding(r.shard, r.Client, r.logger)
+	if err := metrics.Registry.Register(sharding); err != nil {
+		return err
+	}
+
 	// Watch for changes to ClusterDeployment
-	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, shardHandler{
+		EventHandler: &handler.EnqueueRequestForObject{},
+		sharding:     sharding,
+	}); err != nil {
 		return err
 	}
 
 	// Watch for changes to SyncSets
 	if err := c.Watch(
 		&source.Kind{Type: &hivev1.SyncSet{}},
-		&handler.EnqueueRequestsFromMapFunc{
-			ToRequests: handler.ToRequestsFunc(requestsForSyncSet),
+		shardHandler{
+			EventHandler: &handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(requestsForSyncSet),
+			},
+			sharding: sharding,
 		},
 	); err != nil {
 		return err
@@ -182,8 +245,11 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 	// Watch for changes to SelectorSyncSets
 	if err := c.Watch(
 		&source.Kind{Type: &hivev1.SelectorSyncSet{}},
-		&handler.EnqueueRequestsFromMapFunc{
-			ToRequests: requestsForSelectorSyncSet(r.Client, r.logger),
+		shardHandler{
+			EventHandler: &handler.EnqueueRequestsFromMapFunc{
+				ToRequests: requestsForSelectorSyncSet(r.Client, r.logger),
+			},
+			sharding: sharding,
 		},
 	); err != nil {
 		return err
@@ -222,10 +288,12 @@ func requestsForSelectorSyncSet(c client.Client, logger log.FieldLogger) handler
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments matching SelectorSyncSet")
 			return nil
 		}
-		requests := make([]reconcile.Request, len(cds.Items))
+		var requests []reconcile.Request
 		for i, cd := range cds.Items {
-			requests[i].Namespace = cd.Namespace
-			requests[i].Name = cd.Name
+			if !clusterselector.Matches(sss.Spec.ClusterDeploymentFieldSelector, &cds.Items[i]) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
 		}
 		return requests
 	}
@@ -239,7 +307,17 @@ type ReconcileClusterSync struct {
 	logger          log.FieldLogger
 	reapplyInterval time.Duration
 
-	resourceHelperBuilder func(*rest.Config, log.FieldLogger) (resource.Helper, error)
+	// driftCheckInterval is how often the resources of the syncsets that are reapplied on drift are checked.
+	driftCheckInterval time.Duration
+
+	// shard is the share of the ClusterDeployments synced by this replica of hive-clustersync.
+	shard shard
+
+	// syncSetAgentEnabled is true when the syncset agents of the clusters with the SyncSetAgentAnnotation pull the
+	// syncsets of their clusters.
+	syncSetAgentEnabled bool
+
+	resourceHelperBuilder func(*hivev1.ClusterDeployment, *rest.Config, log.FieldLogger) (resource.Helper, error)
 
 	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
 	// for the remote cluster's API server
@@ -268,6 +346,23 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if !r.shard.owns(cd) {
+		logger.Debug("ClusterDeployment is synced by another shard")
+		return reconcile.Result{}, nil
+	}
+
+	logger = controllerutils.AddDebugModeLogging(logger, cd)
+
+	// Syncing paused with the syncset pause annotation is reported in the ClusterSync, so that it is not mistaken
+	// for the syncsets having been applied.
+	if controllerutils.IsSyncSetPaused(cd) && cd.DeletionTimestamp == nil {
+		logger.WithField("annotation", constants.SyncsetPauseAnnotation).Warn("syncing to cluster is paused by annotation")
+		if err := r.reportPaused(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
 	// Is syncing paused?
 	if !controllerutils.ShouldSyncCluster(cd, logger) {
 		return reconcile.Result{}, nil
@@ -288,7 +383,7 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		logger.WithError(err).Error("unable to get REST config")
 		return reconcile.Result{}, err
 	}
-	resourceHelper, err := r.resourceHelperBuilder(restConfig, logger)
+	resourceHelper, err := r.resourceHelperBuilder(cd, restConfig, logger)
 	if err != nil {
 		log.WithError(err).Error("cannot create helper")
 		return reconcile.Result{}, err
@@ -299,11 +394,7 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	switch err := r.Get(context.Background(), request.NamespacedName, clusterSync); {
 	case apierrors.IsNotFound(err):
 		logger.Info("creating ClusterSync as it does not exist")
-		clusterSync.Namespace = cd.Namespace
-		clusterSync.Name = cd.Name
-		ownerRef := metav1.NewControllerRef(cd, cd.GroupVersionKind())
-		ownerRef.Controller = nil
-		clusterSync.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+		clusterSync = newClusterSync(cd)
 		if err := r.Create(context.Background(), clusterSync); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create ClusterSync")
 			return reconcile.Result{}, err
@@ -338,39 +429,100 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	// The syncset agent of the cluster pulls all of its syncsets except the one that installs the agent. The agent
+	// records the sync statuses of the syncsets that it pulls.
+	var pulledSyncSetsForCluster, pulledSelectorSyncSetsForCluster []CommonSyncSet
+	if r.syncSetAgentEnabled && controllerutils.PullsSyncSets(cd) {
+		syncSets, pulledSyncSetsForCluster = splitPulledSyncSets(syncSets)
+		selectorSyncSets, pulledSelectorSyncSetsForCluster = splitPulledSyncSets(selectorSyncSets)
+		logger.WithField("pulledSyncSets", len(pulledSyncSetsForCluster)+len(pulledSelectorSyncSetsForCluster)).Debug("syncsets are pulled by the syncset agent")
+	}
+
 	needToDoFullReapply := needToCreateClusterSync || r.timeUntilFullReapply(lease) <= 0
 	if needToDoFullReapply {
 		logger.Info("need to reapply all syncsets")
 	}
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
-	// Apply SyncSets
-	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
-		cd,
-		"SyncSet",
-		syncSets,
-		clusterSync.Status.SyncSets,
-		needToDoFullReapply,
-		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
-		resourceHelper,
-		logger,
-	)
-	clusterSync.Status.SyncSets = syncStatusesForSyncSets
+	reapplyKind, reapplyName, reapplyRequested := getReapplyRequest(cd)
+	if reapplyRequested {
+		logger.WithField("kind", reapplyKind).WithField("name", reapplyName).Info("reapply of syncset requested")
+	}
+	reapplyNameFor := func(syncSetType string) string {
+		if reapplyKind != syncSetType {
+			return ""
+		}
+		return reapplyName
+	}
+
+	// Apply the SyncSets and SelectorSyncSets wave by wave. Once a syncset in a wave fails to apply, the higher waves
+	// are held until the next attempt, keeping their existing sync statuses.
+	var syncStatusesForSyncSets, syncStatusesForSelectorSyncSets []hiveintv1alpha1.SyncStatus
+	var syncSetsNeedRequeue, selectorSyncSetsNeedRequeue bool
+	var failedWave *int32
+	for _, wave := range applyWaves(syncSets, selectorSyncSets) {
+		syncSetsForWave := syncSetsInWave(syncSets, wave)
+		selectorSyncSetsForWave := syncSetsInWave(selectorSyncSets, wave)
+		if failedWave != nil {
+			logger.WithField("wave", wave).WithField("failedWave", *failedWave).Info("holding apply of syncsets until the syncsets in a lower wave have been applied")
+			syncStatusesForSyncSets = append(syncStatusesForSyncSets, heldSyncStatuses(syncSetsForWave, clusterSync.Status.SyncSets)...)
+			syncStatusesForSelectorSyncSets = append(syncStatusesForSelectorSyncSets, heldSyncStatuses(selectorSyncSetsForWave, clusterSync.Status.SelectorSyncSets)...)
+			syncSetsNeedRequeue = true
+			continue
+		}
 
-	// Apply SelectorSyncSets
-	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
-		cd,
-		"SelectorSyncSet",
-		selectorSyncSets,
-		clusterSync.Status.SelectorSyncSets,
-		needToDoFullReapply,
-		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
-		resourceHelper,
-		logger,
-	)
+		// Apply SyncSets
+		waveSyncStatuses, needRequeue := r.applySyncSets(
+			cd,
+			"SyncSet",
+			syncSetsForWave,
+			clusterSync.Status.SyncSets,
+			needToDoFullReapply,
+			reapplyNameFor("SyncSet"),
+			false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
+			resourceHelper,
+			logger,
+		)
+		syncStatusesForSyncSets = append(syncStatusesForSyncSets, waveSyncStatuses...)
+		syncSetsNeedRequeue = syncSetsNeedRequeue || needRequeue
+
+		// Apply SelectorSyncSets
+		waveSelectorSyncStatuses, needRequeue := r.applySyncSets(
+			cd,
+			"SelectorSyncSet",
+			selectorSyncSetsForWave,
+			clusterSync.Status.SelectorSyncSets,
+			needToDoFullReapply,
+			reapplyNameFor("SelectorSyncSet"),
+			clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
+			resourceHelper,
+			logger,
+		)
+		syncStatusesForSelectorSyncSets = append(syncStatusesForSelectorSyncSets, waveSelectorSyncStatuses...)
+		selectorSyncSetsNeedRequeue = selectorSyncSetsNeedRequeue || needRequeue
+
+		if len(getFailingSyncSets(waveSyncStatuses))+len(getFailingSyncSets(waveSelectorSyncStatuses)) > 0 {
+			w := wave
+			failedWave = &w
+		}
+	}
+
+	syncStatusesForSyncSets = append(syncStatusesForSyncSets, heldSyncStatuses(pulledSyncSetsForCluster, clusterSync.Status.SyncSets)...)
+	syncStatusesForSelectorSyncSets = append(syncStatusesForSelectorSyncSets, heldSyncStatuses(pulledSelectorSyncSetsForCluster, clusterSync.Status.SelectorSyncSets)...)
+
+	// Delete the resources of the syncsets that no longer apply to the cluster.
+	removedSyncStatuses, needRequeue := deleteFromRemovedSyncSets(append(append([]CommonSyncSet{}, syncSets...), pulledSyncSetsForCluster...), clusterSync.Status.SyncSets, resourceHelper, logger)
+	syncStatusesForSyncSets = append(syncStatusesForSyncSets, removedSyncStatuses...)
+	syncSetsNeedRequeue = syncSetsNeedRequeue || needRequeue
+	removedSyncStatuses, needRequeue = deleteFromRemovedSyncSets(append(append([]CommonSyncSet{}, selectorSyncSets...), pulledSelectorSyncSetsForCluster...), clusterSync.Status.SelectorSyncSets, resourceHelper, logger)
+	syncStatusesForSelectorSyncSets = append(syncStatusesForSelectorSyncSets, removedSyncStatuses...)
+	selectorSyncSetsNeedRequeue = selectorSyncSetsNeedRequeue || needRequeue
+
+	clusterSync.Status.SyncSets = syncStatusesForSyncSets
 	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
 
 	setFailedCondition(clusterSync)
+	setPausedCondition(clusterSync, false)
 
 	// Set clusterSync.Status.FirstSyncSetsSuccessTime
 	syncStatuses := append(syncStatusesForSyncSets, syncStatusesForSelectorSyncSets...)
@@ -378,6 +530,10 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		r.setFirstSuccessTime(syncStatuses, cd, clusterSync, logger)
 	}
 
+	if reapplyRequested {
+		clusterSync.Status.LastReapplyRequest = reapplyRequestStatus(reapplyKind, reapplyName, syncStatusesForSyncSets, syncStatusesForSelectorSyncSets)
+	}
+
 	// Update the ClusterSync
 	if !reflect.DeepEqual(origStatus, &clusterSync.Status) {
 		logger.Info("updating ClusterSync")
@@ -387,6 +543,16 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 		}
 	}
 
+	// The result of the reapply is in the ClusterSync, so the request is done.
+	if reapplyRequested {
+		logger.Info("removing reapply syncset annotation")
+		delete(cd.Annotations, constants.ReapplySyncSetAnnotation)
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove reapply syncset annotation")
+			return reconcile.Result{}, err
+		}
+	}
+
 	if needToDoFullReapply {
 		logger.Info("setting last full apply time")
 		lease.Spec.RenewTime = metav1.NowMicro()
@@ -411,18 +577,41 @@ func (r *ReconcileClusterSync) Reconcile(request reconcile.Request) (reconcile.R
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	// Syncsets with their own reapply interval, or that are reapplied on drift, may need to be looked at sooner.
+	for _, after := range []time.Duration{
+		r.timeUntilSyncSetCheck(syncSets, syncStatusesForSyncSets),
+		r.timeUntilSyncSetCheck(selectorSyncSets, syncStatusesForSelectorSyncSets),
+	} {
+		if after < result.RequeueAfter {
+			result.RequeueAfter = after
+		}
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
 	return result, nil
 }
 
+// newClusterSync returns the ClusterSync of the ClusterDeployment, which is removed with the ClusterDeployment.
+func newClusterSync(cd *hivev1.ClusterDeployment) *hiveintv1alpha1.ClusterSync {
+	ownerRef := metav1.NewControllerRef(cd, cd.GroupVersionKind())
+	ownerRef.Controller = nil
+	return &hiveintv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       cd.Namespace,
+			Name:            cd.Name,
+			OwnerReferences: []metav1.OwnerReference{*ownerRef},
+		},
+	}
+}
+
 func (r *ReconcileClusterSync) applySyncSets(
 	cd *hivev1.ClusterDeployment,
 	syncSetType string,
 	syncSets []CommonSyncSet,
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
+	reapplyName string,
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
@@ -436,39 +625,71 @@ func (r *ReconcileClusterSync) applySyncSets(
 	for _, syncSet := range syncSets {
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
-		// Remove the matching old sync status from the slice of sync statuses so that the slice only contains sync
-		// statuses that have not been matched to a syncset.
-		if indexOfOldStatus >= 0 {
-			last := len(syncStatuses) - 1
-			syncStatuses[indexOfOldStatus] = syncStatuses[last]
-			syncStatuses = syncStatuses[:last]
-		}
 
-		// Determine if the syncset needs to be applied
+		// Determine if the syncset needs to be applied. A syncset with its own reapply interval is not part of the full
+		// re-apply of the cluster.
+		reapplyInterval := syncSet.GetSpec().ReapplyInterval
 		switch {
-		case needToDoFullReapply:
+		case syncSet.AsMetaObject().GetName() == reapplyName:
+			logger.Info("applying syncset because a reapply was requested")
+		case needToDoFullReapply && reapplyInterval == nil:
 			logger.Debug("applying syncset because it is time to do a full re-apply")
+		case reapplyInterval != nil && timeUntilReapply(oldSyncStatus.LastApplyTime, reapplyInterval.Duration) <= 0:
+			logger.Debug("applying syncset because it is time to re-apply it")
 		case indexOfOldStatus < 0:
 			logger.Debug("applying syncset because the syncset is new")
 		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult:
 			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case syncSet.GetSpec().ReapplyOnDrift && r.resourcesDrifted(cd, syncSet, resourceHelper, logger):
+			logger.Info("applying syncset because its resources have drifted")
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
 			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
 			continue
 		}
 
+		// Read the resources referenced by the syncset. A syncset whose referenced resources cannot be read is not
+		// applied, so that the resources it applied before are not deleted from the cluster in "Sync" mode.
+		referencedResources, referencedDeletePolicies, err, syncSetNeedsRequeue := r.referencedResources(syncSet, logger)
+		if err != nil {
+			if syncSetNeedsRequeue {
+				requeue = true
+			}
+			newSyncStatus := oldSyncStatus
+			newSyncStatus.Name = syncSet.AsMetaObject().GetName()
+			newSyncStatus.ObservedGeneration = syncSet.AsMetaObject().GetGeneration()
+			newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+			newSyncStatus.FailureMessage = err.Error()
+			if !syncStatusesEqualIgnoringApplies(oldSyncStatus, newSyncStatus) {
+				newSyncStatus.LastTransitionTime = metav1.Now()
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			continue
+		}
+
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, resourcesToOrphan, resourcesDeleted, resourceStatuses, syncSetNeedsRequeue, err := r.applySyncSet(cd, syncSet, referencedResources, referencedDeletePolicies, resourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
-			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			Resources: mergeResourceStatuses(
+				append(resourcesInSyncSet, referencesToPatches(syncSet)...),
+				resourceStatuses,
+				oldSyncStatus.Resources,
+			),
+			ResourcesDeleted: resourcesDeleted,
+			Result:           hiveintv1alpha1.SuccessSyncSetResult,
+		}
+		if reapplyInterval != nil {
+			now := metav1.Now()
+			newSyncStatus.LastApplyTime = &now
 		}
 		if syncSet.GetSpec().ResourceApplyMode == hivev1.SyncResourceApplyMode {
-			newSyncStatus.ResourcesToDelete = resourcesApplied
+			// Resources with the orphan deletion policy are not tracked, so they are left in the cluster when they are
+			// removed from the syncset.
+			newSyncStatus.ResourcesToDelete = withoutResources(resourcesApplied, resourcesToOrphan)
 		}
 		if syncSet.GetSpec().ResourceApplyMode == hivev1.UpsertResourceApplyMode && len(oldSyncStatus.ResourcesToDelete) > 0 {
 			logger.Infof("resource apply mode is %v but there are resources to delete in clustersync status", hivev1.UpsertResourceApplyMode)
@@ -500,14 +721,15 @@ func (r *ReconcileClusterSync) applySyncSets(
 				}
 				newSyncStatus.FailureMessage += err.Error()
 			}
-			newSyncStatus.ResourcesToDelete = mergeResources(newSyncStatus.ResourcesToDelete, remainingResources)
+			newSyncStatus.ResourcesToDelete = mergeResources(newSyncStatus.ResourcesToDelete, withoutResources(remainingResources, resourcesToOrphan))
 
 			newSyncStatus.LastTransitionTime = oldSyncStatus.LastTransitionTime
 			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
 		}
 
-		// Update the last transition time if there were any changes to the sync status.
-		if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+		// Update the last transition time if there were any changes to the sync status. The statuses of the resources
+		// have their own apply times, and any change in them that matters also changes the result of the syncset.
+		if !syncStatusesEqualIgnoringApplies(oldSyncStatus, newSyncStatus) {
 			newSyncStatus.LastTransitionTime = metav1.Now()
 		}
 
@@ -519,7 +741,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 			if cd.Status.InstalledTimestamp != nil && startTime.Before(cd.Status.InstalledTimestamp.Time) {
 				startTime = cd.Status.InstalledTimestamp.Time
 			}
-			if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); cond != nil && startTime.Before(cond.LastTransitionTime.Time) {
+			if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); controllerutils.IsClusterDeploymentConditionSet(cond) && startTime.Before(cond.LastTransitionTime.Time) {
 				startTime = cond.LastTransitionTime.Time
 			}
 			applyTime := now.Sub(startTime).Seconds()
@@ -551,9 +773,21 @@ func (r *ReconcileClusterSync) applySyncSets(
 		newSyncStatuses = append(newSyncStatuses, newSyncStatus)
 	}
 
-	// The remaining sync statuses in syncStatuses do not match any syncsets. Any resources to delete in the sync status
-	// need to be deleted.
+	return
+}
+
+// deleteFromRemovedSyncSets deletes the resources to delete in the sync statuses that do not match any of the syncsets.
+// The returned sync statuses are for the removed syncsets with resources that could not be deleted.
+func deleteFromRemovedSyncSets(
+	syncSets []CommonSyncSet,
+	syncStatuses []hiveintv1alpha1.SyncStatus,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
 	for _, oldSyncStatus := range syncStatuses {
+		if containsSyncSet(syncSets, oldSyncStatus.Name) {
+			continue
+		}
 		remainingResources, err := deleteFromTargetCluster(oldSyncStatus.ResourcesToDelete, nil, resourceHelper, logger)
 		if err != nil {
 			requeue = true
@@ -575,6 +809,106 @@ func (r *ReconcileClusterSync) applySyncSets(
 	return
 }
 
+// getReapplyRequest returns the kind and name of the syncset in the ReapplySyncSetAnnotation of the ClusterDeployment.
+// The last return value is false if the annotation is not set. An annotation that cannot be parsed is returned with
+// an empty name, which matches no syncset.
+func getReapplyRequest(cd *hivev1.ClusterDeployment) (kind, name string, requested bool) {
+	value, ok := cd.Annotations[constants.ReapplySyncSetAnnotation]
+	if !ok {
+		return "", "", false
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return value, "", true
+	}
+	return parts[0], parts[1], true
+}
+
+func reapplyRequestStatus(kind, name string, syncSetStatuses, selectorSyncSetStatuses []hiveintv1alpha1.SyncStatus) *hiveintv1alpha1.ReapplyRequestStatus {
+	status := &hiveintv1alpha1.ReapplyRequestStatus{
+		Kind:           kind,
+		Name:           name,
+		Result:         hiveintv1alpha1.FailureSyncSetResult,
+		CompletionTime: metav1.Now(),
+	}
+	var syncStatuses []hiveintv1alpha1.SyncStatus
+	switch kind {
+	case "SyncSet":
+		syncStatuses = syncSetStatuses
+	case "SelectorSyncSet":
+		syncStatuses = selectorSyncSetStatuses
+	default:
+		status.FailureMessage = fmt.Sprintf("%s annotation must be SyncSet/<name> or SelectorSyncSet/<name>", constants.ReapplySyncSetAnnotation)
+		return status
+	}
+	for _, syncStatus := range syncStatuses {
+		if syncStatus.Name == name {
+			status.Result = syncStatus.Result
+			status.FailureMessage = syncStatus.FailureMessage
+			return status
+		}
+	}
+	status.FailureMessage = fmt.Sprintf("%s %s does not apply to the cluster", kind, name)
+	return status
+}
+
+// syncStatusesEqualIgnoringApplies compares the sync statuses without the statuses of the resources and the last apply
+// time, which change when the syncset is reapplied even though the status of the syncset does not.
+func syncStatusesEqualIgnoringApplies(a, b hiveintv1alpha1.SyncStatus) bool {
+	a.Resources, b.Resources = nil, nil
+	a.LastApplyTime, b.LastApplyTime = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func containsSyncSet(syncSets []CommonSyncSet, name string) bool {
+	for _, syncSet := range syncSets {
+		if syncSet.AsMetaObject().GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyWaves returns the distinct apply waves of the syncsets in ascending order.
+func applyWaves(syncSets ...[]CommonSyncSet) []int32 {
+	var waves []int32
+	seen := map[int32]bool{}
+	for _, s := range syncSets {
+		for _, syncSet := range s {
+			wave := syncSet.GetSpec().ApplyWave
+			if !seen[wave] {
+				seen[wave] = true
+				waves = append(waves, wave)
+			}
+		}
+	}
+	sort.Slice(waves, func(i, j int) bool { return waves[i] < waves[j] })
+	return waves
+}
+
+// syncSetsInWave returns the syncsets in the given apply wave.
+func syncSetsInWave(syncSets []CommonSyncSet, wave int32) []CommonSyncSet {
+	var inWave []CommonSyncSet
+	for _, syncSet := range syncSets {
+		if syncSet.GetSpec().ApplyWave == wave {
+			inWave = append(inWave, syncSet)
+		}
+	}
+	return inWave
+}
+
+// heldSyncStatuses returns the existing sync statuses of syncsets whose apply is held. Syncsets that have not been
+// applied yet have no sync status.
+func heldSyncStatuses(syncSets []CommonSyncSet, syncStatuses []hiveintv1alpha1.SyncStatus) []hiveintv1alpha1.SyncStatus {
+	var held []hiveintv1alpha1.SyncStatus
+	for _, syncSet := range syncSets {
+		if status, i := getOldSyncStatus(syncSet, syncStatuses); i >= 0 {
+			held = append(held, status)
+		}
+	}
+	return held
+}
+
 func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.SyncStatus) (hiveintv1alpha1.SyncStatus, int) {
 	for i, status := range syncSetStatuses {
 		if status.Name == syncSet.AsMetaObject().GetName() {
@@ -585,18 +919,46 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 }
 
 func (r *ReconcileClusterSync) applySyncSet(
+	cd *hivev1.ClusterDeployment,
 	syncSet CommonSyncSet,
+	referencedResources []runtime.RawExtension,
+	referencedDeletePolicies []hivev1.SyncSetResourceDeletionPolicy,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
 	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	resourcesToOrphan []hiveintv1alpha1.SyncResourceReference,
+	resourcesDeleted []hiveintv1alpha1.SyncResourceReference,
+	resourceStatuses []hiveintv1alpha1.SyncResourceStatus,
 	requeue bool,
 	returnErr error,
 ) {
-	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
+	var data *templateData
+	if syncSet.GetSpec().Templated {
+		data = newTemplateData(cd)
+	}
+	resources, referencesToResources, decodeErr := decodeResources(syncSetResources(syncSet, referencedResources), data, logger)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
+	// The annotation on a resource takes precedence over the delete policy of its resource reference, which takes
+	// precedence over the deletion policy of the syncset.
+	deletionPolicy := syncSet.GetSpec().ResourceDeletionPolicy
+	referencedPolicies := referencedResourceDeletePolicies(referencedResources, referencedDeletePolicies, data, logger)
+	for i, resource := range resources {
+		policy := withDeletePolicy(deletionPolicy, referencedPolicies[referencesToResources[i]])
+		if annotation := resource.GetAnnotations()[constants.SyncSetDeletionPolicyAnnotation]; annotation != "" {
+			policy = hivev1.SyncSetResourceDeletionPolicy(annotation)
+		}
+		if policy == hivev1.OrphanResourceDeletionPolicy {
+			resourcesToOrphan = append(resourcesToOrphan, referencesToResources[i])
+		}
+	}
+	for i, secretMapping := range syncSet.GetSpec().Secrets {
+		if withDeletePolicy(deletionPolicy, secretMapping.DeletePolicy) == hivev1.OrphanResourceDeletionPolicy {
+			resourcesToOrphan = append(resourcesToOrphan, referencesToSecrets[i])
+		}
+	}
 	if decodeErr != nil {
 		returnErr = decodeErr
 		return
@@ -611,11 +973,16 @@ func (r *ReconcileClusterSync) applySyncSet(
 	case hivev1.CreateOnlySyncSetApplyBehavior:
 		applyFn = resourceHelper.Create
 		applyFnMetricsLabel = labelCreateOnly
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		applyFn = resourceHelper.ServerSideApply
+		applyFnMetricsLabel = labelServerSideApply
 	}
 
 	// Apply Resources
 	for i, resource := range resources {
-		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
+		var hash string
+		hash, returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
+		resourceStatuses = append(resourceStatuses, resourceStatus(referencesToResources[i], hash, returnErr))
 		if returnErr != nil {
 			resourcesApplied = referencesToResources[:i]
 			return
@@ -625,7 +992,9 @@ func (r *ReconcileClusterSync) applySyncSet(
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
-		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		var hash string
+		hash, returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		resourceStatuses = append(resourceStatuses, resourceStatus(referencesToSecrets[i], hash, returnErr))
 		if returnErr != nil {
 			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
 			return
@@ -634,28 +1003,53 @@ func (r *ReconcileClusterSync) applySyncSet(
 	resourcesApplied = append(resourcesApplied, referencesToSecrets...)
 
 	// Apply Patches
+	referencesToPatches := referencesToPatches(syncSet)
 	for i, patch := range syncSet.GetSpec().Patches {
-		returnErr, requeue = r.applyPatch(i, patch, resourceHelper, logger)
+		var hash string
+		hash, returnErr, requeue = r.applyPatch(i, patch, data, resourceHelper, logger)
+		resourceStatuses = append(resourceStatuses, resourceStatus(referencesToPatches[i], hash, returnErr))
 		if returnErr != nil {
 			return
 		}
 	}
 
+	// Delete Resources
+	resourcesToDelete := referencesToResourcesToDelete(syncSet)
+	remainingResources, err := deleteFromTargetCluster(resourcesToDelete, nil, resourceHelper, logger)
+	for _, r := range resourcesToDelete {
+		if !containsResource(remainingResources, r) {
+			resourcesDeleted = append(resourcesDeleted, r)
+		}
+	}
+	if err != nil {
+		returnErr, requeue = err, true
+		return
+	}
+
 	logger.Info("syncset applied")
 	return
 }
 
-func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
+// decodeResources decodes the resources of a syncset. When data is not nil, the string values of the resources are
+// rendered as templates with it.
+func decodeResources(rawResources []runtime.RawExtension, data *templateData, logger log.FieldLogger) (
 	resources []*unstructured.Unstructured, references []hiveintv1alpha1.SyncResourceReference, returnErr error,
 ) {
 	var decodeErrors []error
-	for i, resource := range syncSet.GetSpec().Resources {
+	for i, resource := range rawResources {
 		u := &unstructured.Unstructured{}
 		if err := yaml.Unmarshal(resource.Raw, u); err != nil {
 			logger.WithField("resourceIndex", i).WithError(err).Warn("error decoding unstructured object")
 			decodeErrors = append(decodeErrors, errors.Wrapf(err, "failed to decode resource %d", i))
 			continue
 		}
+		if data != nil {
+			if err := renderObject(u.Object, data); err != nil {
+				logger.WithField("resourceIndex", i).WithError(err).Warn("error rendering templated resource")
+				decodeErrors = append(decodeErrors, errors.Wrapf(err, "failed to render resource %d", i))
+				continue
+			}
+		}
 		resources = append(resources, u)
 		references = append(references, hiveintv1alpha1.SyncResourceReference{
 			APIVersion: u.GetAPIVersion(),
@@ -681,6 +1075,92 @@ func referencesToSecrets(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceRe
 	return references
 }
 
+func referencesToResourcesToDelete(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceReference {
+	var references []hiveintv1alpha1.SyncResourceReference
+	for _, ref := range syncSet.GetSpec().ResourcesToDelete {
+		references = append(references, hiveintv1alpha1.SyncResourceReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		})
+	}
+	return references
+}
+
+func referencesToPatches(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceReference {
+	var references []hiveintv1alpha1.SyncResourceReference
+	for _, patch := range syncSet.GetSpec().Patches {
+		references = append(references, hiveintv1alpha1.SyncResourceReference{
+			APIVersion: patch.APIVersion,
+			Kind:       patch.Kind,
+			Namespace:  patch.Namespace,
+			Name:       patch.Name,
+		})
+	}
+	return references
+}
+
+// resourceStatus returns the status of an attempt to apply a resource, secret or patch of a syncset with the hash.
+func resourceStatus(reference hiveintv1alpha1.SyncResourceReference, hash string, err error) hiveintv1alpha1.SyncResourceStatus {
+	status := hiveintv1alpha1.SyncResourceStatus{
+		SyncResourceReference: reference,
+		Hash:                  hash,
+		LastApplyTime:         metav1.Now(),
+		Result:                hiveintv1alpha1.SuccessSyncSetResult,
+	}
+	if err != nil {
+		status.Result = hiveintv1alpha1.FailureSyncSetResult
+		status.FailureMessage = err.Error()
+	}
+	return status
+}
+
+// mergeResourceStatuses returns the statuses of the resources, secrets and patches of a syncset in the order of their
+// references. The resources that were applied take their new status, keeping the hash of the last successful apply
+// when the apply failed, and keeping the old apply time when neither the hash nor the result changed. The resources
+// that were not applied, because an earlier one failed, keep their old status, if any.
+func mergeResourceStatuses(
+	references []hiveintv1alpha1.SyncResourceReference,
+	appliedStatuses []hiveintv1alpha1.SyncResourceStatus,
+	oldStatuses []hiveintv1alpha1.SyncResourceStatus,
+) []hiveintv1alpha1.SyncResourceStatus {
+	// A resource and a patch of the same object have the same reference, so each status is taken only once.
+	take := func(statuses []hiveintv1alpha1.SyncResourceStatus, reference hiveintv1alpha1.SyncResourceReference) (hiveintv1alpha1.SyncResourceStatus, []hiveintv1alpha1.SyncResourceStatus, bool) {
+		for i, status := range statuses {
+			if status.SyncResourceReference == reference {
+				remaining := append(append([]hiveintv1alpha1.SyncResourceStatus{}, statuses[:i]...), statuses[i+1:]...)
+				return status, remaining, true
+			}
+		}
+		return hiveintv1alpha1.SyncResourceStatus{}, statuses, false
+	}
+	var statuses []hiveintv1alpha1.SyncResourceStatus
+	for _, reference := range references {
+		var oldStatus, newStatus hiveintv1alpha1.SyncResourceStatus
+		var hasOld, applied bool
+		oldStatus, oldStatuses, hasOld = take(oldStatuses, reference)
+		newStatus, appliedStatuses, applied = take(appliedStatuses, reference)
+		switch {
+		case !applied && !hasOld:
+			continue
+		case !applied:
+			statuses = append(statuses, oldStatus)
+			continue
+		}
+		if hasOld {
+			if newStatus.Result == hiveintv1alpha1.FailureSyncSetResult {
+				newStatus.Hash = oldStatus.Hash
+			}
+			if newStatus.Hash == oldStatus.Hash && newStatus.Result == oldStatus.Result && newStatus.FailureMessage == oldStatus.FailureMessage {
+				newStatus.LastApplyTime = oldStatus.LastApplyTime
+			}
+		}
+		statuses = append(statuses, newStatus)
+	}
+	return statuses
+}
+
 func (r *ReconcileClusterSync) applyResource(
 	resourceIndex int,
 	resource *unstructured.Unstructured,
@@ -688,17 +1168,18 @@ func (r *ReconcileClusterSync) applyResource(
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	applyFnMetricsLabel string,
 	logger log.FieldLogger,
-) (returnErr error, requeue bool) {
+) (hash string, returnErr error, requeue bool) {
 	logger = logger.WithField("resourceIndex", resourceIndex).
 		WithField("resourceNamespace", reference.Namespace).
 		WithField("resourceName", reference.Name).
 		WithField("resourceAPIVersion", reference.APIVersion).
 		WithField("resourceKind", reference.Kind)
 	logger.Debug("applying resource")
-	if err := applyToTargetCluster(resource, applyFnMetricsLabel, applyFn, logger); err != nil {
-		return errors.Wrapf(err, "failed to apply resource %d", resourceIndex), true
+	hash, err := applyToTargetCluster(resource, applyFnMetricsLabel, applyFn, logger)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to apply resource %d", resourceIndex), true
 	}
-	return nil, false
+	return hash, nil, false
 }
 
 func (r *ReconcileClusterSync) applySecret(
@@ -709,31 +1190,47 @@ func (r *ReconcileClusterSync) applySecret(
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	applyFnMetricsLabel string,
 	logger log.FieldLogger,
-) (returnErr error, requeue bool) {
+) (hash string, returnErr error, requeue bool) {
 	logger = logger.WithField("secretIndex", secretIndex).
 		WithField("secretNamespace", reference.Namespace).
 		WithField("secretName", reference.Name)
+	secret, err, requeue := r.sourceSecret(syncSet, secretIndex, secretMapping, logger)
+	if err != nil {
+		return "", err, requeue
+	}
+	logger.Debug("applying secret")
+	hash, err = applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
+	}
+	return hash, nil, false
+}
+
+// sourceSecret reads the source secret of the secret mapping, and returns it with the name and namespace of the target
+// secret.
+func (r *ReconcileClusterSync) sourceSecret(
+	syncSet CommonSyncSet,
+	secretIndex int,
+	secretMapping hivev1.SecretMapping,
+	logger log.FieldLogger,
+) (secret *corev1.Secret, returnErr error, requeue bool) {
 	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
 	srcNamespace := secretMapping.SourceRef.Namespace
 	if srcNamespace == "" {
 		// The namespace of the source secret is required for SelectorSyncSets.
 		if syncSetNamespace == "" {
 			logger.Warn("namespace must be specified for source secret")
-			return fmt.Errorf("source namespace missing for secret %d", secretIndex), false
+			return nil, fmt.Errorf("source namespace missing for secret %d", secretIndex), false
 		}
 		// Use the namespace of the SyncSet if the namespace of the source secret is omitted.
 		srcNamespace = syncSetNamespace
-	} else {
-		// If the namespace of the source secret is specified, then it must match the namespace of the SyncSet.
-		if syncSetNamespace != "" && syncSetNamespace != srcNamespace {
-			logger.Warn("source secret must be in same namespace as SyncSet")
-			return fmt.Errorf("source in wrong namespace for secret %d", secretIndex), false
-		}
 	}
-	secret := &corev1.Secret{}
+	// A source secret in another namespace than the SyncSet was checked by the SyncSet webhook, which only admits it
+	// when the user creating or updating the SyncSet can get the secret.
+	secret = &corev1.Secret{}
 	if err := r.Get(context.Background(), types.NamespacedName{Namespace: srcNamespace, Name: secretMapping.SourceRef.Name}, secret); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read secret")
-		return errors.Wrapf(err, "failed to read secret %d", secretIndex), true
+		return nil, errors.Wrapf(err, "failed to read secret %d", secretIndex), true
 	}
 	// Clear out the fields of the metadata which are specific to the cluster to which the secret belongs.
 	secret.ObjectMeta = metav1.ObjectMeta{
@@ -742,24 +1239,29 @@ func (r *ReconcileClusterSync) applySecret(
 		Annotations: secret.Annotations,
 		Labels:      secret.Labels,
 	}
-	logger.Debug("applying secret")
-	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
-		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
-	}
-	return nil, false
+	return secret, nil, false
 }
 
 func (r *ReconcileClusterSync) applyPatch(
 	patchIndex int,
 	patch hivev1.SyncObjectPatch,
+	data *templateData,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
-) (returnErr error, requeue bool) {
+) (hash string, returnErr error, requeue bool) {
 	logger = logger.WithField("patchIndex", patchIndex).
 		WithField("patchNamespace", patch.Namespace).
 		WithField("patchName", patch.Name).
 		WithField("patchAPIVersion", patch.APIVersion).
 		WithField("patchKind", patch.Kind)
+	if data != nil {
+		rendered, err := renderTemplate(patch.Patch, data)
+		if err != nil {
+			logger.WithError(err).Warn("error rendering templated patch")
+			return "", errors.Wrapf(err, "failed to render patch %d", patchIndex), false
+		}
+		patch.Patch = rendered
+	}
 	logger.Debug("applying patch")
 	if err := resourceHelper.Patch(
 		types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
@@ -768,9 +1270,16 @@ func (r *ReconcileClusterSync) applyPatch(
 		[]byte(patch.Patch),
 		patch.PatchType,
 	); err != nil {
-		return errors.Wrapf(err, "failed to apply patch %d", patchIndex), true
+		if patch.PatchType == "json" && strings.Contains(err.Error(), jsonpatch.ErrTestFailed.Error()) {
+			// A failed test operation means that the object does not look as the patch expects, and no operation of
+			// the patch was applied. Retrying right away would fail the same way, so the patch is retried with the
+			// next apply of the syncset.
+			logger.WithError(err).Info("object does not match a test operation of the patch")
+			return "", errors.Wrapf(err, "failed to apply patch %d: object does not match a test operation", patchIndex), false
+		}
+		return "", errors.Wrapf(err, "failed to apply patch %d", patchIndex), true
 	}
-	return nil, false
+	return fmt.Sprintf("%x", md5.Sum([]byte(patch.PatchType+"\n"+patch.Patch))), nil, false
 }
 
 func applyToTargetCluster(
@@ -778,7 +1287,7 @@ func applyToTargetCluster(
 	applyFnMetricLabel string,
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	logger log.FieldLogger,
-) error {
+) (hash string, returnErr error) {
 	startTime := time.Now()
 	labels := obj.GetLabels()
 	if labels == nil {
@@ -791,7 +1300,7 @@ func applyToTargetCluster(
 	bytes, err := json.Marshal(obj)
 	if err != nil {
 		logger.WithError(err).Error("error marshalling unstructured object to json bytes")
-		return err
+		return "", err
 	}
 
 	applyResult, err := applyFn(bytes)
@@ -807,7 +1316,7 @@ func applyToTargetCluster(
 		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Inc()
 		metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Observe(applyTime)
 	}
-	return err
+	return fmt.Sprintf("%x", md5.Sum(bytes)), err
 }
 
 func deleteFromTargetCluster(
@@ -883,7 +1392,36 @@ func doesSelectorSyncSetApplyToClusterDeployment(selectorSyncSet *hivev1.Selecto
 		logger.WithError(err).Error("unable to convert selector")
 		return false
 	}
-	return labelSelector.Matches(labels.Set(cd.Labels))
+	return labelSelector.Matches(labels.Set(cd.Labels)) && clusterselector.Matches(selectorSyncSet.Spec.ClusterDeploymentFieldSelector, cd)
+}
+
+// reportPaused sets the Paused condition of the ClusterSync of a cluster that syncing to is paused, creating the
+// ClusterSync when it does not exist yet.
+func (r *ReconcileClusterSync) reportPaused(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+		logger.Info("creating ClusterSync as it does not exist")
+		clusterSync = newClusterSync(cd)
+		if err := r.Create(context.Background(), clusterSync); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create ClusterSync")
+			return err
+		}
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return err
+	}
+	origStatus := clusterSync.Status.DeepCopy()
+	setPausedCondition(clusterSync, true)
+	if reflect.DeepEqual(origStatus, &clusterSync.Status) {
+		return nil
+	}
+	logger.Info("updating ClusterSync")
+	if err := r.Status().Update(context.Background(), clusterSync); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterSync")
+		return err
+	}
+	return nil
 }
 
 func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
@@ -908,22 +1446,60 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 		}
 		message = fmt.Sprintf("%s %s failing", strings.Join(failureNames, " and "), verb)
 	}
-	if len(clusterSync.Status.Conditions) > 0 {
-		cond := clusterSync.Status.Conditions[0]
-		if status == cond.Status &&
-			reason == cond.Reason &&
-			message == cond.Message {
+	setClusterSyncCondition(clusterSync, hiveintv1alpha1.ClusterSyncFailed, status, reason, message)
+}
+
+// setPausedCondition sets the Paused condition of the ClusterSync. The condition is only added once syncing to the
+// cluster has been paused.
+func setPausedCondition(clusterSync *hiveintv1alpha1.ClusterSync, paused bool) {
+	if paused {
+		setClusterSyncCondition(
+			clusterSync,
+			hiveintv1alpha1.ClusterSyncPaused,
+			corev1.ConditionTrue,
+			"SyncSetPauseAnnotation",
+			fmt.Sprintf("Applying SyncSets and SelectorSyncSets to the cluster is paused by the %s annotation", constants.SyncsetPauseAnnotation),
+		)
+		return
+	}
+	for _, cond := range clusterSync.Status.Conditions {
+		if cond.Type == hiveintv1alpha1.ClusterSyncPaused {
+			setClusterSyncCondition(
+				clusterSync,
+				hiveintv1alpha1.ClusterSyncPaused,
+				corev1.ConditionFalse,
+				"Unpaused",
+				"Applying SyncSets and SelectorSyncSets to the cluster is not paused",
+			)
 			return
 		}
 	}
-	clusterSync.Status.Conditions = []hiveintv1alpha1.ClusterSyncCondition{{
-		Type:               hiveintv1alpha1.ClusterSyncFailed,
+}
+
+// setClusterSyncCondition sets the condition of the given type in the ClusterSync, keeping the other conditions.
+// The condition is left as it is when its status, reason and message are unchanged.
+func setClusterSyncCondition(clusterSync *hiveintv1alpha1.ClusterSync, condType hiveintv1alpha1.ClusterSyncConditionType, status corev1.ConditionStatus, reason, message string) {
+	newCond := hiveintv1alpha1.ClusterSyncCondition{
+		Type:               condType,
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
 		LastProbeTime:      metav1.Now(),
 		LastTransitionTime: metav1.Now(),
-	}}
+	}
+	for i, cond := range clusterSync.Status.Conditions {
+		if cond.Type != condType {
+			continue
+		}
+		if status == cond.Status &&
+			reason == cond.Reason &&
+			message == cond.Message {
+			return
+		}
+		clusterSync.Status.Conditions[i] = newCond
+		return
+	}
+	clusterSync.Status.Conditions = append(clusterSync.Status.Conditions, newCond)
 }
 
 func getFailingSyncSets(syncStatuses []hiveintv1alpha1.SyncStatus) []string {
@@ -975,6 +1551,29 @@ func mergeResources(a, b []hiveintv1alpha1.SyncResourceReference) []hiveintv1alp
 	return a
 }
 
+// withDeletePolicy returns the deletion policy of a resource with the delete policy of its resource reference or
+// secret mapping, which overrides the deletion policy of the syncset when it is set.
+func withDeletePolicy(policy, deletePolicy hivev1.SyncSetResourceDeletionPolicy) hivev1.SyncSetResourceDeletionPolicy {
+	if deletePolicy != "" {
+		return deletePolicy
+	}
+	return policy
+}
+
+// withoutResources returns the resources that are not in the excluded resources.
+func withoutResources(resources, excluded []hiveintv1alpha1.SyncResourceReference) []hiveintv1alpha1.SyncResourceReference {
+	if len(excluded) == 0 {
+		return resources
+	}
+	var remaining []hiveintv1alpha1.SyncResourceReference
+	for _, r := range resources {
+		if !containsResource(excluded, r) {
+			remaining = append(remaining, r)
+		}
+	}
+	return remaining
+}
+
 func containsResource(resources []hiveintv1alpha1.SyncResourceReference, resource hiveintv1alpha1.SyncResourceReference) bool {
 	for _, r := range resources {
 		if r == resource {
@@ -997,6 +1596,36 @@ func orderResources(a, b hiveintv1alpha1.SyncResourceReference) bool {
 	return a.Name < b.Name
 }
 
+// timeUntilSyncSetCheck returns the time until one of the syncsets is due to be reapplied on its own schedule, or
+// checked for drift.
+func (r *ReconcileClusterSync) timeUntilSyncSetCheck(syncSets []CommonSyncSet, syncStatuses []hiveintv1alpha1.SyncStatus) time.Duration {
+	timeUntilNext := time.Duration(math.MaxInt64)
+	for _, syncSet := range syncSets {
+		if syncSet.GetSpec().ReapplyOnDrift && r.driftCheckInterval < timeUntilNext {
+			timeUntilNext = r.driftCheckInterval
+		}
+		if reapplyInterval := syncSet.GetSpec().ReapplyInterval; reapplyInterval != nil {
+			syncStatus, _ := getOldSyncStatus(syncSet, syncStatuses)
+			if t := timeUntilReapply(syncStatus.LastApplyTime, reapplyInterval.Duration); t < timeUntilNext {
+				timeUntilNext = t
+			}
+		}
+	}
+	return timeUntilNext
+}
+
+// timeUntilReapply returns the time until a syncset last applied at the given time is due to be reapplied.
+func timeUntilReapply(lastApplyTime *metav1.Time, reapplyInterval time.Duration) time.Duration {
+	if lastApplyTime == nil {
+		return 0
+	}
+	timeUntilNext := reapplyInterval - time.Since(lastApplyTime.Time)
+	if timeUntilNext < 0 {
+		return 0
+	}
+	return timeUntilNext
+}
+
 func (r *ReconcileClusterSync) timeUntilFullReapply(lease *hiveintv1alpha1.ClusterSyncLease) time.Duration {
 	timeUntilNext := r.reapplyInterval - time.Since(lease.Spec.RenewTime.Time) +
 		time.Duration(reapplyIntervalJitter*rand.Float64()*r.reapplyInterval.Seconds())*time.Second
@@ -72,6 +72,10 @@ type reconcileTest struct {
 	expectUnchangedLeaseRenewTime bool
 	expectRequeue                 bool
 	expectNoWorkDone              bool
+
+	// A non-zero expectedMaxRequeueAfter is the longest expected requeue after, for syncsets that need to be looked at
+	// before the next full re-apply.
+	expectedMaxRequeueAfter time.Duration
 }
 
 func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime.Scheme, existing ...runtime.Object) *reconcileTest {
@@ -84,10 +88,11 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
 
 	r := &ReconcileClusterSync{
-		Client:          c,
-		logger:          logger,
-		reapplyInterval: defaultReapplyInterval,
-		resourceHelperBuilder: func(rc *rest.Config, _ log.FieldLogger) (resource.Helper, error) {
+		Client:             c,
+		logger:             logger,
+		reapplyInterval:    defaultReapplyInterval,
+		driftCheckInterval: defaultDriftCheckInterval,
+		resourceHelperBuilder: func(_ *hivev1.ClusterDeployment, rc *rest.Config, _ log.FieldLogger) (resource.Helper, error) {
 			return mockResourceHelper, nil
 		},
 		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
@@ -145,6 +150,9 @@ func (rt *reconcileTest) run(t *testing.T) {
 	assert.True(t, result.Requeue, "expected requeue to be true")
 	if rt.expectRequeue {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+	} else if rt.expectedMaxRequeueAfter != 0 {
+		assert.Greater(t, int64(result.RequeueAfter), int64(0), "requeue after too small")
+		assert.LessOrEqual(t, result.RequeueAfter.Seconds(), rt.expectedMaxRequeueAfter.Seconds(), "requeue after too large")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
 		if rt.expectUnchangedLeaseRenewTime {
@@ -218,11 +226,33 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 		return
 	}
 	for i, expectedStatus := range expectedStatuses {
+		// The statuses of the resources of the syncset are only checked by the tests that expect them.
+		if expectedStatus.Resources == nil {
+			expectedStatuses[i].Resources = actualStatuses[i].Resources
+		} else if assert.Equalf(t, len(expectedStatus.Resources), len(actualStatuses[i].Resources), "unexpected number of resource statuses in %s status %d", syncSetType, i) {
+			for j, expectedResourceStatus := range expectedStatus.Resources {
+				actual := actualStatuses[i].Resources[j]
+				if expectedResourceStatus.LastApplyTime.IsZero() {
+					hiveassert.BetweenTimes(t, actual.LastApplyTime.Time, startTime, endTime, "expected resource status %d of %s status %d to have LastApplyTime of now", j, syncSetType, i)
+					expectedStatuses[i].Resources[j].LastApplyTime = actual.LastApplyTime
+				}
+				if expectedResourceStatus.Hash == "" && expectedResourceStatus.Result == hiveintv1alpha1.SuccessSyncSetResult {
+					assert.NotEmptyf(t, actual.Hash, "expected resource status %d of %s status %d to have a hash", j, syncSetType, i)
+					expectedStatuses[i].Resources[j].Hash = actual.Hash
+				}
+			}
+		}
 		if expectedStatus.LastTransitionTime.IsZero() {
 			actual := actualStatuses[i].LastTransitionTime
 			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have LastTransitionTime of now", syncSetType, i)
 			expectedStatuses[i].LastTransitionTime = actual
 		}
+		if expectedStatus.LastApplyTime != nil && expectedStatus.LastApplyTime.IsZero() {
+			if actual := actualStatuses[i].LastApplyTime; actual != nil {
+				hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have LastApplyTime of now", syncSetType, i)
+				*expectedStatuses[i].LastApplyTime = *actual
+			}
+		}
 		if expectedStatus.FirstSuccessTime != nil && expectedStatus.FirstSuccessTime.IsZero() {
 			if actualStatuses[i].FirstSuccessTime != nil {
 				actual := actualStatuses[i].FirstSuccessTime
@@ -278,10 +308,6 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 				}),
 			),
 		},
-		{
-			name: "syncset pause",
-			cd:   cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.SyncsetPauseAnnotation, "true")).Build(),
-		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -298,6 +324,99 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_SyncSetPause(t *testing.T) {
+	scheme := newScheme()
+	pausedCD := cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.SyncsetPauseAnnotation, "true")).Build()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+	)
+	failedCondition := hiveintv1alpha1.ClusterSyncCondition{
+		Type:               hiveintv1alpha1.ClusterSyncFailed,
+		Status:             corev1.ConditionFalse,
+		Reason:             "Success",
+		Message:            "All SyncSets and SelectorSyncSets have been applied to the cluster",
+		LastTransitionTime: timeInThePast,
+		LastProbeTime:      timeInThePast,
+	}
+	cases := []struct {
+		name                string
+		existingClusterSync *hiveintv1alpha1.ClusterSync
+	}{
+		{
+			name: "no ClusterSync",
+		},
+		{
+			name: "existing ClusterSync",
+			existingClusterSync: clusterSyncBuilder(scheme).Build(
+				testcs.WithSyncSetStatus(buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())),
+				testcs.WithCondition(failedCondition),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			existing := []runtime.Object{pausedCD, syncSet}
+			if tc.existingClusterSync != nil {
+				existing = append(existing, tc.existingClusterSync)
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			result, err := rt.r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, reconcile.Result{}, result, "unexpected reconcile result")
+
+			clusterSync := &hiveintv1alpha1.ClusterSync{}
+			require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync), "expected ClusterSync")
+			var pausedCond *hiveintv1alpha1.ClusterSyncCondition
+			for i, cond := range clusterSync.Status.Conditions {
+				if cond.Type == hiveintv1alpha1.ClusterSyncPaused {
+					pausedCond = &clusterSync.Status.Conditions[i]
+				}
+			}
+			if assert.NotNil(t, pausedCond, "expected a Paused condition") {
+				assert.Equal(t, corev1.ConditionTrue, pausedCond.Status, "expected Paused condition to be true")
+			}
+			if tc.existingClusterSync != nil {
+				assert.Equal(t, tc.existingClusterSync.Status.SyncSets, clusterSync.Status.SyncSets, "expected sync statuses to be unchanged")
+				assert.Contains(t, clusterSync.Status.Conditions, failedCondition, "expected Failed condition to be unchanged")
+			}
+			err = rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testLeaseName}, &hiveintv1alpha1.ClusterSyncLease{})
+			assert.True(t, apierrors.IsNotFound(err), "expected no lease")
+		})
+	}
+}
+
+func TestReconcileClusterSync_SyncSetUnpaused(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	existingClusterSync := clusterSyncBuilder(scheme).Build(
+		testcs.WithCondition(hiveintv1alpha1.ClusterSyncCondition{
+			Type:               hiveintv1alpha1.ClusterSyncPaused,
+			Status:             corev1.ConditionTrue,
+			Reason:             "SyncSetPauseAnnotation",
+			LastTransitionTime: timeInThePast,
+			LastProbeTime:      timeInThePast,
+		}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), existingClusterSync)
+	rt.run(t)
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	require.NoError(t, rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync))
+	found := false
+	for _, cond := range clusterSync.Status.Conditions {
+		if cond.Type == hiveintv1alpha1.ClusterSyncPaused {
+			found = true
+			assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected Paused condition to be false")
+			assert.Equal(t, "Unpaused", cond.Reason, "unexpected Paused condition reason")
+		}
+	}
+	assert.True(t, found, "expected a Paused condition")
+}
+
 func TestReconcileClusterSync_ApplyResource(t *testing.T) {
 	cases := []struct {
 		applyMode                hivev1.SyncSetResourceApplyMode
@@ -428,6 +547,122 @@ func TestReconcileClusterSync_ApplyPatch(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ApplyTemplated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "{{ .ClusterName }}-config")
+	resourceToApply.Data = map[string]string{
+		"env":      `{{ index .Labels "env" }}`,
+		"platform": "{{ .Platform }}",
+		"domain":   "{{ .ClusterName }}.{{ .BaseDomain }}",
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithTemplated(),
+		testsyncset.WithResources(resourceToApply),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "dest-namespace",
+			Name:       "dest-name",
+			PatchType:  "merge",
+			Patch:      `{"data":{"infraID":"{{ .InfraID }}"}}`,
+		}),
+	)
+	cd := cdBuilder(scheme).Build(
+		testcd.WithLabel("env", "prod"),
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+	)
+	cd.Spec.BaseDomain = "example.com"
+	cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: "test-infra-id"}
+	rt := newReconcileTest(t, mockCtrl, scheme, cd, clusterSyncBuilder(scheme).Build(), syncSet)
+	renderedResource := testConfigMap("dest-namespace", testCDName+"-config")
+	renderedResource.Data = map[string]string{
+		"env":      "prod",
+		"platform": "aws",
+		"domain":   testCDName + ".example.com",
+	}
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(renderedResource)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Patch(
+		types.NamespacedName{Namespace: "dest-namespace", Name: "dest-name"},
+		"ConfigMap",
+		"v1",
+		[]byte(`{"data":{"infraID":"test-infra-id"}}`),
+		"merge",
+	).Return(nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ErrorRenderingTemplated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	resourceToApply.Data = map[string]string{"missing": "{{ .Missing }}"}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithTemplated(),
+		testsyncset.WithResources(resourceToApply),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult(`failed to render resource 0: failed to render data: failed to render missing: template: :1:3: executing "" at <.Missing>: can't evaluate field Missing in type *clustersync.templateData`),
+		withNoFirstSuccessTime(),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_UntemplatedNotRendered(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	resourceToApply.Data = map[string]string{"text": "{{ .ClusterName }}"}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(resourceToApply),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_DeleteResourcesToDelete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResourcesToDelete(
+			hivev1.SyncObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "dest-namespace", Name: "failing-resource"},
+			hivev1.SyncObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "dest-namespace", Name: "successful-resource"},
+		),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet)
+	rt.mockResourceHelper.EXPECT().
+		Delete("v1", "ConfigMap", "dest-namespace", "failing-resource").
+		Return(errors.New("error deleting resource"))
+	rt.mockResourceHelper.EXPECT().
+		Delete("v1", "ConfigMap", "dest-namespace", "successful-resource").
+		Return(nil)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult("Failed to delete v1, Kind=ConfigMap dest-namespace/failing-resource: error deleting resource"),
+		withResourcesDeleted(testConfigMapRef("dest-namespace", "successful-resource")),
+		withNoFirstSuccessTime(),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_ApplyAllTypes(t *testing.T) {
 	cases := []struct {
 		applyMode                hivev1.SyncSetResourceApplyMode
@@ -565,6 +800,269 @@ func TestReconcileClusterSync_Reapply(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ReapplyInterval(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := []struct {
+		name                    string
+		leaseRenewTime          time.Time
+		lastApplyTime           *metav1.Time
+		expectApply             bool
+		expectedMaxRequeueAfter time.Duration
+	}{
+		{
+			name:                    "never applied",
+			leaseRenewTime:          now.Add(-time.Hour),
+			expectApply:             true,
+			expectedMaxRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name:                    "reapply interval elapsed",
+			leaseRenewTime:          now.Add(-time.Hour),
+			lastApplyTime:           &metav1.Time{Time: now.Add(-time.Hour)},
+			expectApply:             true,
+			expectedMaxRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name:                    "reapply interval not elapsed",
+			leaseRenewTime:          now.Add(-time.Hour),
+			lastApplyTime:           &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			expectedMaxRequeueAfter: 20 * time.Minute,
+		},
+		{
+			name:                    "full reapply",
+			leaseRenewTime:          now.Add(-3 * time.Hour),
+			lastApplyTime:           &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			expectedMaxRequeueAfter: 20 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithReapplyInterval(30*time.Minute),
+				testsyncset.WithResources(resourceToApply),
+			)
+			existingStatusOpts := []syncStatusOption{withTransitionInThePast(), withFirstSuccessTimeInThePast()}
+			if tc.lastApplyTime != nil {
+				existingStatusOpts = append(existingStatusOpts, withLastApplyTime(*tc.lastApplyTime))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset", existingStatusOpts...)),
+				),
+				syncSet,
+				buildSyncLease(tc.leaseRenewTime),
+			)
+			expectedStatus := buildSyncStatus("test-syncset", existingStatusOpts...)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+				expectedStatus = buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast(), withLastApplyTime(metav1.Time{}))
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedStatus}
+			rt.expectUnchangedLeaseRenewTime = time.Since(tc.leaseRenewTime) < defaultReapplyInterval
+			rt.expectedMaxRequeueAfter = tc.expectedMaxRequeueAfter
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ReapplyOnDrift(t *testing.T) {
+	desired := testConfigMap("dest-namespace", "dest-name")
+	desired.Data = map[string]string{"key": "value"}
+	liveConfigMap := func(data map[string]string) *unstructured.Unstructured {
+		cm := testConfigMap("dest-namespace", "dest-name")
+		cm.ResourceVersion = "1"
+		cm.Annotations = map[string]string{"other": "annotation"}
+		cm.Data = data
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		require.NoError(t, err, "unexpected error converting ConfigMap")
+		return &unstructured.Unstructured{Object: obj}
+	}
+	cases := []struct {
+		name        string
+		live        *unstructured.Unstructured
+		getErr      error
+		expectApply bool
+	}{
+		{
+			name: "no drift",
+			live: liveConfigMap(map[string]string{"key": "value", "added": "in cluster"}),
+		},
+		{
+			name:        "field changed",
+			live:        liveConfigMap(map[string]string{"key": "changed"}),
+			expectApply: true,
+		},
+		{
+			name:        "field removed",
+			live:        liveConfigMap(nil),
+			expectApply: true,
+		},
+		{
+			name:        "resource deleted",
+			getErr:      apierrors.NewNotFound(corev1.Resource("configmaps"), "dest-name"),
+			expectApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithReapplyOnDrift(),
+				testsyncset.WithResources(desired),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())),
+				),
+				syncSet,
+				buildSyncLease(time.Now().Add(-time.Hour)),
+			)
+			rt.mockResourceHelper.EXPECT().Get("v1", "ConfigMap", "dest-namespace", "dest-name").Return(tc.live, tc.getErr)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(desired)).Return(resource.ConfiguredApplyResult, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.expectedMaxRequeueAfter = defaultDriftCheckInterval
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ReapplyRequested(t *testing.T) {
+	cases := []struct {
+		name                  string
+		request               string
+		applyErr              error
+		expectApply           bool
+		expectedRequestStatus hiveintv1alpha1.ReapplyRequestStatus
+	}{
+		{
+			name:        "syncset",
+			request:     "SyncSet/test-syncset",
+			expectApply: true,
+			expectedRequestStatus: hiveintv1alpha1.ReapplyRequestStatus{
+				Kind:   "SyncSet",
+				Name:   "test-syncset",
+				Result: hiveintv1alpha1.SuccessSyncSetResult,
+			},
+		},
+		{
+			name:        "syncset fails to apply",
+			request:     "SyncSet/test-syncset",
+			applyErr:    errors.New("test apply error"),
+			expectApply: true,
+			expectedRequestStatus: hiveintv1alpha1.ReapplyRequestStatus{
+				Kind:           "SyncSet",
+				Name:           "test-syncset",
+				Result:         hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage: "failed to apply resource 0: test apply error",
+			},
+		},
+		{
+			name:    "selectorsyncset with the same name",
+			request: "SelectorSyncSet/test-syncset",
+			expectedRequestStatus: hiveintv1alpha1.ReapplyRequestStatus{
+				Kind:           "SelectorSyncSet",
+				Name:           "test-syncset",
+				Result:         hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage: "SelectorSyncSet test-syncset does not apply to the cluster",
+			},
+		},
+		{
+			name:    "unknown syncset",
+			request: "SyncSet/other-syncset",
+			expectedRequestStatus: hiveintv1alpha1.ReapplyRequestStatus{
+				Kind:           "SyncSet",
+				Name:           "other-syncset",
+				Result:         hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage: "SyncSet other-syncset does not apply to the cluster",
+			},
+		},
+		{
+			name:    "invalid request",
+			request: "test-syncset",
+			expectedRequestStatus: hiveintv1alpha1.ReapplyRequestStatus{
+				Kind:           "test-syncset",
+				Result:         hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage: "hive.openshift.io/reapply-syncset annotation must be SyncSet/<name> or SelectorSyncSet/<name>",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(resourceToApply),
+			)
+			otherSyncSet := testsyncset.FullBuilder(testNamespace, "untouched-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(testConfigMap("dest-namespace", "other-name")),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.ReapplySyncSetAnnotation, tc.request)).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())),
+					testcs.WithSyncSetStatus(buildSyncStatus("untouched-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())),
+				),
+				syncSet,
+				otherSyncSet,
+				buildSyncLease(time.Now().Add(-time.Hour)),
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			expectedStatus := buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, tc.applyErr)
+			}
+			if tc.applyErr != nil {
+				expectedStatus = buildSyncStatus("test-syncset", withFailureResult(tc.expectedRequestStatus.FailureMessage), withFirstSuccessTimeInThePast())
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectRequeue = true
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+				expectedStatus,
+				buildSyncStatus("untouched-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			}
+			rt.expectUnchangedLeaseRenewTime = true
+			startTime := time.Now().Truncate(time.Second)
+			rt.run(t)
+			endTime := time.Now().Add(time.Second).Truncate(time.Second)
+
+			clusterSync := &hiveintv1alpha1.ClusterSync{}
+			require.NoError(t, rt.c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync))
+			if assert.NotNil(t, clusterSync.Status.LastReapplyRequest, "expected reapply request status") {
+				actual := *clusterSync.Status.LastReapplyRequest
+				hiveassert.BetweenTimes(t, actual.CompletionTime.Time, startTime, endTime, "unexpected completion time")
+				actual.CompletionTime = metav1.Time{}
+				assert.Equal(t, tc.expectedRequestStatus, actual, "unexpected reapply request status")
+			}
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, rt.c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testCDName}, cd))
+			assert.NotContains(t, cd.Annotations, constants.ReapplySyncSetAnnotation, "expected reapply annotation to be removed")
+		})
+	}
+}
+
 func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -691,13 +1189,196 @@ func TestReconcileClusterSync_ResourceRemovedFromSyncSet(t *testing.T) {
 				withObservedGeneration(2),
 				withFirstSuccessTimeInThePast(),
 			)
-			if tc.includeResourcesToDelete {
-				expectedSyncStatusBuilder = expectedSyncStatusBuilder.Options(withResourcesToDelete(
-					testConfigMapRef("dest-namespace", "retained-resource"),
+			if tc.includeResourcesToDelete {
+				expectedSyncStatusBuilder = expectedSyncStatusBuilder.Options(withResourcesToDelete(
+					testConfigMapRef("dest-namespace", "retained-resource"),
+				))
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatusBuilder.Build()}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ResourceDeletionPolicy(t *testing.T) {
+	withPolicyAnnotation := func(cm *corev1.ConfigMap, policy hivev1.SyncSetResourceDeletionPolicy) *corev1.ConfigMap {
+		cm.Annotations = map[string]string{constants.SyncSetDeletionPolicyAnnotation: string(policy)}
+		return cm
+	}
+	cases := []struct {
+		name                      string
+		policy                    hivev1.SyncSetResourceDeletionPolicy
+		resources                 []*corev1.ConfigMap
+		existingResourcesToDelete []hiveintv1alpha1.SyncResourceReference
+		expectDeleted             []string
+		expectedResourcesToDelete []hiveintv1alpha1.SyncResourceReference
+	}{
+		{
+			name:      "orphan policy",
+			policy:    hivev1.OrphanResourceDeletionPolicy,
+			resources: []*corev1.ConfigMap{testConfigMap("dest-namespace", "resource-a")},
+		},
+		{
+			name:   "orphan policy stops tracking retained resources",
+			policy: hivev1.OrphanResourceDeletionPolicy,
+			resources: []*corev1.ConfigMap{
+				testConfigMap("dest-namespace", "resource-a"),
+			},
+			existingResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "resource-a"),
+			},
+		},
+		{
+			name:   "resources tracked before orphan policy are deleted when removed",
+			policy: hivev1.OrphanResourceDeletionPolicy,
+			resources: []*corev1.ConfigMap{
+				testConfigMap("dest-namespace", "resource-a"),
+			},
+			existingResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "removed-resource"),
+			},
+			expectDeleted: []string{"removed-resource"},
+		},
+		{
+			name: "resource annotated with orphan policy",
+			resources: []*corev1.ConfigMap{
+				withPolicyAnnotation(testConfigMap("dest-namespace", "resource-a"), hivev1.OrphanResourceDeletionPolicy),
+				testConfigMap("dest-namespace", "resource-b"),
+			},
+			existingResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "resource-a"),
+			},
+			expectedResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "resource-b"),
+			},
+		},
+		{
+			name:   "resource annotated with delete policy",
+			policy: hivev1.OrphanResourceDeletionPolicy,
+			resources: []*corev1.ConfigMap{
+				withPolicyAnnotation(testConfigMap("dest-namespace", "resource-a"), hivev1.DeleteResourceDeletionPolicy),
+				testConfigMap("dest-namespace", "resource-b"),
+			},
+			expectedResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "resource-a"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			resources := make([]hivev1.MetaRuntimeObject, len(tc.resources))
+			for i, r := range tc.resources {
+				resources[i] = r
+			}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+				testsyncset.WithResourceDeletionPolicy(tc.policy),
+				testsyncset.WithResources(resources...),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+				withResourcesToDelete(tc.existingResourcesToDelete...),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)))
+			rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), syncSet, clusterSync)
+			for _, r := range resources {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(r)).Return(resource.CreatedApplyResult, nil)
+			}
+			for _, name := range tc.expectDeleted {
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", name).Return(nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withResourcesToDelete(tc.expectedResourcesToDelete...),
+				withFirstSuccessTimeInThePast(),
+			)}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_DeletePolicy(t *testing.T) {
+	cases := []struct {
+		name                      string
+		policy                    hivev1.SyncSetResourceDeletionPolicy
+		expectedResourcesToDelete []hiveintv1alpha1.SyncResourceReference
+	}{
+		{
+			name: "delete policy",
+			expectedResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "cm-2"),
+				testConfigMapRef("dest-namespace", "cm-3"),
+				testConfigMapRef("dest-namespace", "inline"),
+				testSecretRef("dest-namespace", "secret-2"),
+				testSecretRef("dest-namespace", "secret-3"),
+			},
+		},
+		{
+			name:   "orphan policy",
+			policy: hivev1.OrphanResourceDeletionPolicy,
+			expectedResourcesToDelete: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "cm-2"),
+				testConfigMapRef("dest-namespace", "cm-3"),
+				testSecretRef("dest-namespace", "secret-2"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			retainedSecret := testSecretMapping("src-secret-1", "dest-namespace", "secret-1")
+			retainedSecret.DeletePolicy = hivev1.OrphanResourceDeletionPolicy
+			deletedSecret := testSecretMapping("src-secret-2", "dest-namespace", "secret-2")
+			deletedSecret.DeletePolicy = hivev1.DeleteResourceDeletionPolicy
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+				testsyncset.WithResourceDeletionPolicy(tc.policy),
+				testsyncset.WithResources(testConfigMap("dest-namespace", "inline")),
+				testsyncset.WithResourceRefs(
+					hivev1.SyncSetResourceReference{Kind: "ConfigMap", Name: "retained", DeletePolicy: hivev1.OrphanResourceDeletionPolicy},
+					hivev1.SyncSetResourceReference{Kind: "ConfigMap", Name: "deleted", DeletePolicy: hivev1.DeleteResourceDeletionPolicy},
+				),
+				testsyncset.WithSecrets(
+					retainedSecret,
+					deletedSecret,
+					testSecretMapping("src-secret-3", "dest-namespace", "secret-3"),
+				),
+			)
+			retained := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "retained"},
+				Data: map[string]string{
+					"a.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-1\n",
+					"b.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-2\n  annotations:\n    hive.openshift.io/syncset-deletion-policy: Delete\n",
+				},
+			}
+			deleted := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "deleted"},
+				Data: map[string]string{
+					"a.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-3\n",
+				},
+			}
+			existing := []runtime.Object{syncSet, retained, deleted}
+			for _, name := range []string{"src-secret-1", "src-secret-2", "src-secret-3"} {
+				existing = append(existing, testsecret.FullBuilder(testNamespace, name, scheme).Build(
+					testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 				))
 			}
-			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatusBuilder.Build()}
-			rt.expectUnchangedLeaseRenewTime = true
+			existing = append(existing, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build())
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).Return(resource.CreatedApplyResult, nil).Times(7)
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesToDelete(tc.expectedResourcesToDelete...),
+			)}
 			rt.run(t)
 		})
 	}
@@ -809,6 +1490,182 @@ func TestReconcileClusterSync_ErrorApplyingPatch(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_FailedTestOperationInPatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	patch := `[{"op": "test", "path": "/data/key", "value": "expected"}, {"op": "replace", "path": "/data/key", "value": "new"}]`
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "dest-namespace",
+			Name:       "dest-name",
+			PatchType:  "json",
+			Patch:      patch,
+		}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet)
+	rt.mockResourceHelper.EXPECT().Patch(
+		types.NamespacedName{Namespace: "dest-namespace", Name: "dest-name"},
+		"ConfigMap",
+		"v1",
+		[]byte(patch),
+		"json",
+	).Return(errors.New("testing value /data/key failed: test failed"))
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult("failed to apply patch 0: object does not match a test operation: testing value /data/key failed: test failed"),
+		withNoFirstSuccessTime(),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ResourceStatuses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourcesToApply := []hivev1.MetaRuntimeObject{
+		testConfigMap("dest-namespace", "dest-name-0"),
+		testConfigMap("dest-namespace", "dest-name-1"),
+		testConfigMap("dest-namespace", "dest-name-2"),
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(resourcesToApply...),
+	)
+	existingSyncStatus := buildSyncStatus("test-syncset",
+		withFailureResult("failed to apply resource 1: old apply error"),
+		withTransitionInThePast(),
+		withNoFirstSuccessTime(),
+		withResourceStatuses(
+			hiveintv1alpha1.SyncResourceStatus{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-1"),
+				Hash:                  "old-hash",
+				LastApplyTime:         timeInThePast,
+				Result:                hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage:        "failed to apply resource 1: old apply error",
+			},
+			hiveintv1alpha1.SyncResourceStatus{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+				Hash:                  "old-hash",
+				LastApplyTime:         timeInThePast,
+				Result:                hiveintv1alpha1.SuccessSyncSetResult,
+			},
+		),
+	)
+	clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus))
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSync, syncSet)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[0])).
+		Return(resource.ApplyResult(""), nil)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[1])).
+		Return(resource.ApplyResult(""), errors.New("test apply error"))
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult("failed to apply resource 1: test apply error"),
+		withNoFirstSuccessTime(),
+		withResourceStatuses(
+			hiveintv1alpha1.SyncResourceStatus{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-0"),
+				Result:                hiveintv1alpha1.SuccessSyncSetResult,
+			},
+			hiveintv1alpha1.SyncResourceStatus{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-1"),
+				Hash:                  "old-hash",
+				Result:                hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage:        "failed to apply resource 1: test apply error",
+			},
+			hiveintv1alpha1.SyncResourceStatus{
+				SyncResourceReference: testConfigMapRef("dest-namespace", "dest-name-2"),
+				Hash:                  "old-hash",
+				LastApplyTime:         timeInThePast,
+				Result:                hiveintv1alpha1.SuccessSyncSetResult,
+			},
+		),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestMergeResourceStatuses(t *testing.T) {
+	ref := testConfigMapRef("dest-namespace", "dest-name")
+	now := metav1.NewTime(time.Date(2020, 6, 10, 12, 0, 0, 0, time.Local))
+	status := func(hash string, applyTime metav1.Time, failureMessage string) hiveintv1alpha1.SyncResourceStatus {
+		s := hiveintv1alpha1.SyncResourceStatus{
+			SyncResourceReference: ref,
+			Hash:                  hash,
+			LastApplyTime:         applyTime,
+			Result:                hiveintv1alpha1.SuccessSyncSetResult,
+		}
+		if failureMessage != "" {
+			s.Result = hiveintv1alpha1.FailureSyncSetResult
+			s.FailureMessage = failureMessage
+		}
+		return s
+	}
+	cases := []struct {
+		name     string
+		refs     []hiveintv1alpha1.SyncResourceReference
+		applied  []hiveintv1alpha1.SyncResourceStatus
+		old      []hiveintv1alpha1.SyncResourceStatus
+		expected []hiveintv1alpha1.SyncResourceStatus
+	}{
+		{
+			name:     "new resource",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref},
+			applied:  []hiveintv1alpha1.SyncResourceStatus{status("hash", now, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("hash", now, "")},
+		},
+		{
+			name:     "unchanged resource keeps apply time",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref},
+			applied:  []hiveintv1alpha1.SyncResourceStatus{status("hash", now, "")},
+			old:      []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+		},
+		{
+			name:     "changed resource",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref},
+			applied:  []hiveintv1alpha1.SyncResourceStatus{status("new-hash", now, "")},
+			old:      []hiveintv1alpha1.SyncResourceStatus{status("old-hash", timeInThePast, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("new-hash", now, "")},
+		},
+		{
+			name:     "failed resource keeps hash",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref},
+			applied:  []hiveintv1alpha1.SyncResourceStatus{status("", now, "error")},
+			old:      []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("hash", now, "error")},
+		},
+		{
+			name:     "resource not applied keeps status",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref},
+			old:      []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+		},
+		{
+			name: "removed resource dropped",
+			old:  []hiveintv1alpha1.SyncResourceStatus{status("hash", timeInThePast, "")},
+		},
+		{
+			name:     "resource and patch of the same object",
+			refs:     []hiveintv1alpha1.SyncResourceReference{ref, ref},
+			applied:  []hiveintv1alpha1.SyncResourceStatus{status("resource-hash", now, "")},
+			old:      []hiveintv1alpha1.SyncResourceStatus{status("resource-hash", timeInThePast, ""), status("patch-hash", timeInThePast, "")},
+			expected: []hiveintv1alpha1.SyncResourceStatus{status("resource-hash", timeInThePast, ""), status("patch-hash", timeInThePast, "")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mergeResourceStatuses(tc.refs, tc.applied, tc.old)
+			assert.Equal(t, tc.expected, actual, "unexpected resource statuses")
+		})
+	}
+}
+
 func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
 	cases := []struct {
 		name                string
@@ -1200,6 +2057,146 @@ func TestReconcileClusterSync_FailingSyncSetDoesNotBlockOtherSyncSets(t *testing
 	}
 }
 
+func TestReconcileClusterSync_ApplyWaves(t *testing.T) {
+	cases := []struct {
+		name            string
+		lowerWaveFails  bool
+		expectHeldApply bool
+	}{
+		{
+			name: "waves applied in order",
+		},
+		{
+			name:            "failing wave holds higher waves",
+			lowerWaveFails:  true,
+			expectHeldApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			cd := cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value"))
+			resourceInWaveZero := testConfigMap("dest-namespace", "wave-zero")
+			resourceForSelectorSyncSet := testConfigMap("dest-namespace", "selector")
+			resourceInWaveOne := testConfigMap("dest-namespace", "wave-one")
+			// The syncset in the higher wave sorts first by name.
+			syncSetInWaveOne := testsyncset.FullBuilder(testNamespace, "test-syncset-a", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithApplyWave(1),
+				testsyncset.WithResources(resourceInWaveOne),
+			)
+			syncSetInWaveZero := testsyncset.FullBuilder(testNamespace, "test-syncset-c", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(resourceInWaveZero),
+			)
+			selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset-b", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(1),
+				testselectorsyncset.WithResources(resourceForSelectorSyncSet),
+			)
+			existingStatus := buildSyncStatus("test-syncset-a", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingStatus)),
+				syncSetInWaveOne,
+				syncSetInWaveZero,
+				selectorSyncSet,
+			)
+			var selectorSyncSetErr error
+			selectorSyncSetStatus := buildSyncStatus("test-selectorsyncset-b")
+			if tc.lowerWaveFails {
+				selectorSyncSetErr = errors.New("test apply error")
+				selectorSyncSetStatus = buildSyncStatus("test-selectorsyncset-b",
+					withFailureResult("failed to apply resource 0: test apply error"),
+					withNoFirstSuccessTime(),
+				)
+				rt.expectedFailedMessage = "SelectorSyncSet test-selectorsyncset-b is failing"
+			}
+			calls := []*gomock.Call{
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceInWaveZero)).Return(resource.CreatedApplyResult, nil),
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceForSelectorSyncSet)).Return(resource.CreatedApplyResult, selectorSyncSetErr),
+			}
+			if tc.expectHeldApply {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset-c"), existingStatus}
+				rt.expectRequeue = true
+			} else {
+				calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceInWaveOne)).Return(resource.CreatedApplyResult, nil))
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+					buildSyncStatus("test-syncset-c"),
+					buildSyncStatus("test-syncset-a", withObservedGeneration(2), withFirstSuccessTimeInThePast()),
+				}
+			}
+			gomock.InOrder(calls...)
+			rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{selectorSyncSetStatus}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_SyncSetAgent(t *testing.T) {
+	cases := []struct {
+		name                string
+		syncSetAgentEnabled bool
+		expectPulled        bool
+	}{
+		{
+			name:                "syncsets pulled by agent",
+			syncSetAgentEnabled: true,
+			expectPulled:        true,
+		},
+		{
+			name: "syncset agent not enabled",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			cd := cdBuilder(scheme).GenericOptions(
+				testgeneric.WithAnnotation(constants.SyncSetAgentAnnotation, "true"),
+			).Build()
+			agentResource := testConfigMap("dest-namespace", "agent")
+			pulledResource := testConfigMap("dest-namespace", "pulled")
+			agentSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-agent", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(agentResource),
+				testsyncset.Generic(testgeneric.WithLabel(constants.SyncSetTypeLabel, constants.SyncSetTypeSyncSetAgent)),
+			)
+			pulledSyncSet := testsyncset.FullBuilder(testNamespace, "test-syncset-pulled", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithResources(pulledResource),
+			)
+			// The status recorded by the agent is for an older generation of the syncset.
+			pulledStatus := buildSyncStatus("test-syncset-pulled", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(pulledStatus)),
+				agentSyncSet,
+				pulledSyncSet,
+			)
+			rt.r.syncSetAgentEnabled = tc.syncSetAgentEnabled
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(agentResource)).Return(resource.CreatedApplyResult, nil)
+			if tc.expectPulled {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset-agent"), pulledStatus}
+			} else {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(pulledResource)).Return(resource.CreatedApplyResult, nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+					buildSyncStatus("test-syncset-agent"),
+					buildSyncStatus("test-syncset-pulled", withObservedGeneration(2), withFirstSuccessTimeInThePast()),
+				}
+			}
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_FailureMessage(t *testing.T) {
 	cases := []struct {
 		name                    string
@@ -1448,6 +2445,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 		{
 			applyBehavior: hivev1.CreateOrUpdateSyncSetApplyBehavior,
 		},
+		{
+			applyBehavior: hivev1.ServerSideApplySyncSetApplyBehavior,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(string(tc.applyBehavior), func(t *testing.T) {
@@ -1493,6 +2493,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+			case hivev1.ServerSideApplySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
 			}
 			rt.mockResourceHelper.EXPECT().Patch(
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
@@ -1552,6 +2555,43 @@ func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_SelectorSyncSetFieldSelector(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "resource-from-matching-selectorsyncset")
+	matchingSelectorSyncSet := testselectorsyncset.FullBuilder("matching-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithClusterDeploymentFieldSelector(&hivev1.ClusterDeploymentFieldSelector{
+			Platforms:    []string{"aws"},
+			VersionRange: ">=4.9.0 <4.10.0",
+			PowerStates:  []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+		}),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResources(resourceToApply),
+	)
+	nonMatchingSelectorSyncSet := testselectorsyncset.FullBuilder("non-matching-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithClusterDeploymentFieldSelector(&hivev1.ClusterDeploymentFieldSelector{
+			Platforms:    []string{"aws"},
+			VersionRange: ">=4.10.0",
+		}),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResources(
+			testConfigMap("dest-namespace", "resource-from-non-matching-selectorsyncset"),
+		),
+	)
+	cd := cdBuilder(scheme).Build(
+		testcd.WithLabel("test-label-key", "test-label-value"),
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+		testcd.WithClusterVersion("4.9.3"),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cd, clusterSyncBuilder(scheme).Build(), matchingSelectorSyncSet, nonMatchingSelectorSyncSet)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("matching-selectorsyncset")}
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_ApplySecretForSelectorSyncSet(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1637,7 +2677,7 @@ func TestReconcileClusterSync_ValidSecretNamespaceForSyncSet(t *testing.T) {
 	rt.run(t)
 }
 
-func TestReconcileClusterSync_InvalidSecretNamespaceForSyncSet(t *testing.T) {
+func TestReconcileClusterSync_SecretInOtherNamespaceForSyncSet(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	scheme := newScheme()
@@ -1655,11 +2695,15 @@ func TestReconcileClusterSync_InvalidSecretNamespaceForSyncSet(t *testing.T) {
 		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
 	)
 	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet, srcSecret)
-	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
-	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult("source in wrong namespace for secret 0"),
-		withNoFirstSuccessTime(),
-	)}
+	secretToApply := testsecret.BasicBuilder().GenericOptions(
+		testgeneric.WithNamespace("dest-namespace"),
+		testgeneric.WithName("dest-name"),
+		testgeneric.WithTypeMeta(scheme),
+	).Build(
+		testsecret.WithDataKeyValue("test-key", []byte("test-data")),
+	)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
 	rt.run(t)
 }
 
@@ -1684,6 +2728,112 @@ func TestReconcileClusterSync_MissingSourceSecret(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_ApplyResourceRefs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "inline")),
+		testsyncset.WithResourceRefs(
+			hivev1.SyncSetResourceReference{Kind: "ConfigMap", Name: "manifests"},
+			hivev1.SyncSetResourceReference{Kind: "Secret", Namespace: "other-namespace", Name: "secret-manifests"},
+		),
+	)
+	manifests := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+		Data: map[string]string{
+			"b.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-2\n---\n# empty\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-3\n",
+			"a.json": `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"namespace": "dest-namespace", "name": "cm-1"}}`,
+		},
+	}
+	secretManifests := testsecret.FullBuilder("other-namespace", "secret-manifests", scheme).Build(
+		testsecret.WithDataKeyValue("manifest", []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: dest-namespace\n  name: cm-4\n")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSyncBuilder(scheme).Build(), syncSet, manifests, secretManifests)
+	calls := []*gomock.Call{
+		rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(testConfigMap("dest-namespace", "inline"))).Return(resource.CreatedApplyResult, nil),
+	}
+	for _, name := range []string{"cm-1", "cm-2", "cm-3", "cm-4"} {
+		referenced := &unstructured.Unstructured{}
+		referenced.SetAPIVersion("v1")
+		referenced.SetKind("ConfigMap")
+		referenced.SetNamespace("dest-namespace")
+		referenced.SetName(name)
+		calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(referenced)).Return(resource.CreatedApplyResult, nil))
+	}
+	gomock.InOrder(calls...)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withResourcesToDelete(
+			testConfigMapRef("dest-namespace", "cm-1"),
+			testConfigMapRef("dest-namespace", "cm-2"),
+			testConfigMapRef("dest-namespace", "cm-3"),
+			testConfigMapRef("dest-namespace", "cm-4"),
+			testConfigMapRef("dest-namespace", "inline"),
+		),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_MissingResourceRef(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResourceRefs(hivev1.SyncSetResourceReference{Kind: "ConfigMap", Name: "manifests"}),
+	)
+	existingSyncStatus := buildSyncStatus("test-syncset",
+		withTransitionInThePast(),
+		withFirstSuccessTimeInThePast(),
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "cm-1")),
+	)
+	clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus))
+	rt := newReconcileTest(t, mockCtrl, scheme, cdBuilder(scheme).Build(), clusterSync, syncSet)
+	// The resources applied before are kept in the cluster.
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withObservedGeneration(2),
+		withFailureResult(`failed to read resource reference 0: configmaps "manifests" not found`),
+		withFirstSuccessTimeInThePast(),
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "cm-1")),
+	)}
+	rt.expectRequeue = true
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_MissingResourceRefNamespaceForSelectorSyncSet(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	cd := cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value"))
+	selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResourceRefs(hivev1.SyncSetResourceReference{Kind: "ConfigMap", Name: "manifests"}),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme, cd, clusterSyncBuilder(scheme).Build(), selectorSyncSet)
+	rt.expectedFailedMessage = "SelectorSyncSet test-selectorsyncset is failing"
+	rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-selectorsyncset",
+		withFailureResult("namespace missing for resource reference 0"),
+		withNoFirstSuccessTime(),
+	)}
+	rt.run(t)
+}
+
+func TestSplitDocuments(t *testing.T) {
+	documents, err := splitDocuments([]byte("---\na: 1\n---\n\n---\n# comment only\n---\n{\"b\": 2}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []runtime.RawExtension{
+		{Raw: []byte(`{"a":1}`)},
+		{Raw: []byte(`{"b":2}`)},
+	}, documents)
+}
+
 func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -2122,12 +3272,30 @@ func withResourcesToDelete(resourcesToDelete ...hiveintv1alpha1.SyncResourceRefe
 	}
 }
 
+func withResourcesDeleted(resourcesDeleted ...hiveintv1alpha1.SyncResourceReference) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.ResourcesDeleted = resourcesDeleted
+	}
+}
+
+func withResourceStatuses(resourceStatuses ...hiveintv1alpha1.SyncResourceStatus) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Resources = resourceStatuses
+	}
+}
+
 func withTransitionInThePast() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.LastTransitionTime = timeInThePast
 	}
 }
 
+func withLastApplyTime(lastApplyTime metav1.Time) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.LastApplyTime = &lastApplyTime
+	}
+}
+
 func withNoFirstSuccessTime() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.FirstSuccessTime = nil
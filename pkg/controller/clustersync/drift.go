@@ -0,0 +1,107 @@
+package clustersync
+
+import (
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// resourcesDrifted returns whether any of the resources of the syncset was deleted from the cluster, or no longer has
+// the values of the fields set by the syncset. Fields added to the resources in the cluster, such as defaulted fields
+// and the status, are not drift.
+func (r *ReconcileClusterSync) resourcesDrifted(cd *hivev1.ClusterDeployment, syncSet CommonSyncSet, resourceHelper resource.Helper, logger log.FieldLogger) bool {
+	var data *templateData
+	if syncSet.GetSpec().Templated {
+		data = newTemplateData(cd)
+	}
+	referencedResources, _, err, _ := r.referencedResources(syncSet, logger)
+	if err != nil {
+		// Applying the syncset reports the error.
+		return true
+	}
+	resources, _, err := decodeResources(syncSetResources(syncSet, referencedResources), data, logger)
+	if err != nil {
+		// Applying the syncset reports the error.
+		return true
+	}
+	for _, desired := range resources {
+		logger := logger.WithField("resourceKind", desired.GetKind()).
+			WithField("resourceNamespace", desired.GetNamespace()).
+			WithField("resourceName", desired.GetName())
+		live, err := resourceHelper.Get(desired.GetAPIVersion(), desired.GetKind(), desired.GetNamespace(), desired.GetName())
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Info("resource has been deleted from the cluster")
+			return true
+		case err != nil:
+			logger.WithError(err).Warn("could not get resource to check for drift")
+			return true
+		case !containsFields(live.Object, desired.Object):
+			logger.Info("resource has been changed in the cluster")
+			return true
+		}
+	}
+	return false
+}
+
+// containsFields returns whether the live value has every field of the desired value. Maps in the live value may have
+// more fields than the desired value, while lists must have the same number of items.
+func containsFields(live, desired interface{}) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		if len(d) == 0 && live == nil {
+			return true
+		}
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range d {
+			if !containsFields(l[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		if len(d) == 0 && live == nil {
+			return true
+		}
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !containsFields(l[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if l, ok := toFloat(live); ok {
+		if d, ok := toFloat(desired); ok {
+			return l == d
+		}
+	}
+	return reflect.DeepEqual(live, desired)
+}
+
+// toFloat returns the value of a number, which may be decoded as an integer on one side and a float on the other.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,78 @@
+package clustersync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainsFields(t *testing.T) {
+	cases := []struct {
+		name     string
+		live     string
+		desired  string
+		expected bool
+	}{
+		{
+			name:     "same",
+			live:     `{"a":"b","n":1}`,
+			desired:  `{"a":"b","n":1}`,
+			expected: true,
+		},
+		{
+			name:     "added fields",
+			live:     `{"a":"b","spec":{"x":1,"y":2},"status":{"ready":true}}`,
+			desired:  `{"a":"b","spec":{"x":1}}`,
+			expected: true,
+		},
+		{
+			name:    "changed field",
+			live:    `{"spec":{"x":2}}`,
+			desired: `{"spec":{"x":1}}`,
+		},
+		{
+			name:    "missing field",
+			live:    `{"spec":{}}`,
+			desired: `{"spec":{"x":1}}`,
+		},
+		{
+			name:     "null desired field",
+			live:     `{"metadata":{"name":"test"}}`,
+			desired:  `{"metadata":{"name":"test","creationTimestamp":null}}`,
+			expected: true,
+		},
+		{
+			name:     "empty desired map",
+			live:     `{"metadata":{"name":"test"}}`,
+			desired:  `{"metadata":{"name":"test","labels":{}}}`,
+			expected: true,
+		},
+		{
+			name:     "list items with added fields",
+			live:     `{"items":[{"name":"a","default":true},{"name":"b"}]}`,
+			desired:  `{"items":[{"name":"a"},{"name":"b"}]}`,
+			expected: true,
+		},
+		{
+			name:    "list with added item",
+			live:    `{"items":[{"name":"a"},{"name":"b"}]}`,
+			desired: `{"items":[{"name":"a"}]}`,
+		},
+		{
+			name:     "integer and float",
+			live:     `{"n":2}`,
+			desired:  `{"n":2.0}`,
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var live, desired map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.live), &live), "unexpected error unmarshalling live object")
+			require.NoError(t, json.Unmarshal([]byte(tc.desired), &desired), "unexpected error unmarshalling desired object")
+			assert.Equal(t, tc.expected, containsFields(live, desired), "unexpected result")
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package clustersync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	configMapKind = "ConfigMap"
+)
+
+// referencedResources reads the resources held in the ConfigMaps and Secrets referenced by the syncset. The documents
+// of each object are returned in the order of their keys, along with the delete policy of the reference of each
+// document.
+func (r *ReconcileClusterSync) referencedResources(syncSet CommonSyncSet, logger log.FieldLogger) (
+	resources []runtime.RawExtension, deletePolicies []hivev1.SyncSetResourceDeletionPolicy, returnErr error, requeue bool,
+) {
+	for i, ref := range syncSet.GetSpec().ResourceRefs {
+		logger := logger.WithField("resourceRefIndex", i).
+			WithField("resourceRefKind", ref.Kind).
+			WithField("resourceRefName", ref.Name)
+		namespace := ref.Namespace
+		if namespace == "" {
+			// The namespace of the referenced object is required for SelectorSyncSets.
+			namespace = syncSet.AsMetaObject().GetNamespace()
+			if namespace == "" {
+				logger.Warn("namespace must be specified for resource reference")
+				return nil, nil, fmt.Errorf("namespace missing for resource reference %d", i), false
+			}
+		}
+		// An object in another namespace than the SyncSet was checked by the SyncSet webhook, which only admits it when
+		// the user creating or updating the SyncSet can get the object.
+		key := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		data := map[string][]byte{}
+		switch ref.Kind {
+		case configMapKind:
+			configMap := &corev1.ConfigMap{}
+			if err := r.Get(context.Background(), key, configMap); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read configmap")
+				return nil, nil, errors.Wrapf(err, "failed to read resource reference %d", i), true
+			}
+			for k, v := range configMap.Data {
+				data[k] = []byte(v)
+			}
+		case secretKind:
+			secret := &corev1.Secret{}
+			if err := r.Get(context.Background(), key, secret); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read secret")
+				return nil, nil, errors.Wrapf(err, "failed to read resource reference %d", i), true
+			}
+			data = secret.Data
+		default:
+			return nil, nil, fmt.Errorf("unsupported kind %q of resource reference %d", ref.Kind, i), false
+		}
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			documents, err := splitDocuments(data[k])
+			if err != nil {
+				logger.WithField("key", k).WithError(err).Warn("error reading documents of resource reference")
+				return nil, nil, errors.Wrapf(err, "failed to read key %s of resource reference %d", k, i), false
+			}
+			resources = append(resources, documents...)
+			for range documents {
+				deletePolicies = append(deletePolicies, ref.DeletePolicy)
+			}
+		}
+	}
+	return resources, deletePolicies, nil, false
+}
+
+// splitDocuments splits the YAML or JSON documents in the data. Empty documents are skipped.
+func splitDocuments(data []byte) ([]runtime.RawExtension, error) {
+	var documents []runtime.RawExtension
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		j, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(j)) == 0 || string(j) == "null" {
+			continue
+		}
+		documents = append(documents, runtime.RawExtension{Raw: j})
+	}
+}
+
+// syncSetResources returns the resources of the syncset followed by the referenced resources.
+func syncSetResources(syncSet CommonSyncSet, referencedResources []runtime.RawExtension) []runtime.RawExtension {
+	resources := append([]runtime.RawExtension{}, syncSet.GetSpec().Resources...)
+	return append(resources, referencedResources...)
+}
+
+// referencedResourceDeletePolicies returns the delete policies set on the resource references of the syncset, by the
+// references to the objects held in the referenced ConfigMaps and Secrets. Documents of references without a delete
+// policy and documents that cannot be decoded are left out.
+func referencedResourceDeletePolicies(
+	referencedResources []runtime.RawExtension,
+	deletePolicies []hivev1.SyncSetResourceDeletionPolicy,
+	data *templateData,
+	logger log.FieldLogger,
+) map[hiveintv1alpha1.SyncResourceReference]hivev1.SyncSetResourceDeletionPolicy {
+	policies := map[hiveintv1alpha1.SyncResourceReference]hivev1.SyncSetResourceDeletionPolicy{}
+	for i, resource := range referencedResources {
+		if deletePolicies[i] == "" {
+			continue
+		}
+		_, references, err := decodeResources([]runtime.RawExtension{resource}, data, logger)
+		if err != nil {
+			// Applying the syncset reports the error.
+			continue
+		}
+		policies[references[0]] = deletePolicies[i]
+	}
+	return policies
+}
@@ -0,0 +1,236 @@
+package clustersync
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// shard is the share of ClusterDeployments synced by one replica of the hive-clustersync StatefulSet. The zero shard
+// syncs all ClusterDeployments.
+type shard struct {
+	ordinal  int
+	replicas int
+
+	// seed is hashed with the namespace and name of the ClusterDeployments, so that changing it reshuffles the
+	// ClusterDeployments across the shards.
+	seed string
+}
+
+// shardFromEnv reads the shard of this replica from the name of its pod, whose suffix is the ordinal of the pod in
+// the StatefulSet, and from the number of replicas. When the environment variables are not set, as when clustersync
+// runs within hive-controllers, the replica syncs all ClusterDeployments.
+func shardFromEnv() (shard, error) {
+	podName := os.Getenv(constants.ClusterSyncPodNameEnvVar)
+	replicasValue := os.Getenv(constants.ClusterSyncReplicasEnvVar)
+	if podName == "" || replicasValue == "" {
+		return shard{}, nil
+	}
+	replicas, err := strconv.Atoi(replicasValue)
+	if err != nil || replicas < 1 {
+		return shard{}, fmt.Errorf("invalid %s: %q", constants.ClusterSyncReplicasEnvVar, replicasValue)
+	}
+	ordinal, err := strconv.Atoi(podName[strings.LastIndex(podName, "-")+1:])
+	if err != nil || ordinal < 0 {
+		return shard{}, fmt.Errorf("cannot determine ordinal of pod %s", podName)
+	}
+	if ordinal >= replicas {
+		return shard{}, fmt.Errorf("ordinal %d of pod %s is not less than the %d replicas", ordinal, podName, replicas)
+	}
+	return shard{ordinal: ordinal, replicas: replicas, seed: os.Getenv(constants.ClusterSyncShardSeedEnvVar)}, nil
+}
+
+// owns reports whether the ClusterDeployment is synced by this shard. A ClusterDeployment pinned to a replica is
+// synced by the shard of that replica, and any other by the shard picked by hashing its namespace and name.
+func (s shard) owns(cd *hivev1.ClusterDeployment) bool {
+	if s.replicas <= 1 {
+		return true
+	}
+	if ordinal, ok := s.pinnedOrdinal(cd); ok {
+		return ordinal == s.ordinal
+	}
+	h := fnv.New32a()
+	if s.seed != "" {
+		h.Write([]byte(s.seed + "/"))
+	}
+	h.Write([]byte(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String()))
+	return int(h.Sum32()%uint32(s.replicas)) == s.ordinal
+}
+
+// pinnedOrdinal returns the ordinal of the replica that the ClusterDeployment is pinned to. The pin is ignored when
+// the ordinal is not that of a replica.
+func (s shard) pinnedOrdinal(cd *hivev1.ClusterDeployment) (int, bool) {
+	value, ok := cd.Annotations[constants.ClusterSyncShardAnnotation]
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(value)
+	if err != nil || ordinal < 0 || ordinal >= s.replicas {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+// shardQueue is the work queue of the clustersync controller, which drops the requests of the ClusterDeployments
+// that are synced by other shards, so that the queue only holds the work of this shard.
+type shardQueue struct {
+	workqueue.RateLimitingInterface
+	owns func(types.NamespacedName) bool
+}
+
+func (q shardQueue) Add(item interface{}) {
+	if q.ownsItem(item) {
+		q.RateLimitingInterface.Add(item)
+	}
+}
+
+func (q shardQueue) AddAfter(item interface{}, duration time.Duration) {
+	if q.ownsItem(item) {
+		q.RateLimitingInterface.AddAfter(item, duration)
+	}
+}
+
+func (q shardQueue) AddRateLimited(item interface{}) {
+	if q.ownsItem(item) {
+		q.RateLimitingInterface.AddRateLimited(item)
+	}
+}
+
+func (q shardQueue) ownsItem(item interface{}) bool {
+	request, ok := item.(reconcile.Request)
+	return !ok || q.owns(request.NamespacedName)
+}
+
+// shardHandler wraps the handler of a watch of the clustersync controller, so that it only enqueues the requests of
+// the ClusterDeployments synced by this shard. It keeps the work queue of the controller for the shard metrics.
+type shardHandler struct {
+	handler.EventHandler
+	sharding *sharding
+}
+
+func (h shardHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Create(e, h.sharding.queue(q))
+}
+
+func (h shardHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Update(e, h.sharding.queue(q))
+}
+
+func (h shardHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Delete(e, h.sharding.queue(q))
+}
+
+func (h shardHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Generic(e, h.sharding.queue(q))
+}
+
+// sharding filters the work of the clustersync controller to the ClusterDeployments of its shard, and collects the
+// metrics of the shard.
+type sharding struct {
+	shard  shard
+	client client.Client
+	logger log.FieldLogger
+
+	lock sync.Mutex
+	// workQueue is the work queue of the controller, once the first event has been handled.
+	workQueue workqueue.RateLimitingInterface
+
+	metricClusters   *prometheus.Desc
+	metricQueueDepth *prometheus.Desc
+}
+
+func newSharding(s shard, c client.Client, logger log.FieldLogger) *sharding {
+	return &sharding{
+		shard:  s,
+		client: c,
+		logger: logger,
+		metricClusters: prometheus.NewDesc(
+			"hive_clustersync_shard_clusters",
+			"Number of ClusterDeployments synced by a hive-clustersync replica, and how many of them are pinned to it.",
+			[]string{"shard", "pinned"},
+			nil,
+		),
+		metricQueueDepth: prometheus.NewDesc(
+			"hive_clustersync_shard_queue_depth",
+			"Number of ClusterDeployments waiting to be synced by a hive-clustersync replica.",
+			[]string{"shard"},
+			nil,
+		),
+	}
+}
+
+func (s *sharding) queue(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	s.lock.Lock()
+	s.workQueue = q
+	s.lock.Unlock()
+	if s.shard.replicas <= 1 {
+		return q
+	}
+	return shardQueue{RateLimitingInterface: q, owns: s.ownsRequest}
+}
+
+// ownsRequest reports whether the ClusterDeployment of a request is synced by this shard. The request is kept when
+// the ClusterDeployment cannot be read, and dropped by the reconciler when it is not owned.
+func (s *sharding) ownsRequest(name types.NamespacedName) bool {
+	cd := &hivev1.ClusterDeployment{}
+	if err := s.client.Get(context.Background(), name, cd); err != nil {
+		return true
+	}
+	return s.shard.owns(cd)
+}
+
+// Describe implements prometheus.Collector.
+func (s *sharding) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.metricClusters
+	ch <- s.metricQueueDepth
+}
+
+// Collect implements prometheus.Collector.
+func (s *sharding) Collect(ch chan<- prometheus.Metric) {
+	ordinal := strconv.Itoa(s.shard.ordinal)
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := s.client.List(context.Background(), cds); err != nil {
+		s.logger.WithError(err).Error("error listing cluster deployments")
+	} else {
+		var pinned, hashed int
+		for i := range cds.Items {
+			cd := &cds.Items[i]
+			if !s.shard.owns(cd) {
+				continue
+			}
+			if _, ok := s.shard.pinnedOrdinal(cd); ok {
+				pinned++
+			} else {
+				hashed++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(s.metricClusters, prometheus.GaugeValue, float64(pinned), ordinal, "true")
+		ch <- prometheus.MustNewConstMetric(s.metricClusters, prometheus.GaugeValue, float64(hashed), ordinal, "false")
+	}
+
+	s.lock.Lock()
+	q := s.workQueue
+	s.lock.Unlock()
+	if q != nil {
+		ch <- prometheus.MustNewConstMetric(s.metricQueueDepth, prometheus.GaugeValue, float64(q.Len()), ordinal)
+	}
+}
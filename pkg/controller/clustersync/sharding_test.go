@@ -0,0 +1,206 @@
+package clustersync
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestShardFromEnv(t *testing.T) {
+	cases := []struct {
+		name          string
+		podName       string
+		replicas      string
+		expectedShard shard
+		expectErr     bool
+	}{
+		{
+			name: "not sharded",
+		},
+		{
+			name:          "first replica",
+			podName:       "hive-clustersync-0",
+			replicas:      "3",
+			expectedShard: shard{ordinal: 0, replicas: 3},
+		},
+		{
+			name:          "last replica",
+			podName:       "hive-clustersync-2",
+			replicas:      "3",
+			expectedShard: shard{ordinal: 2, replicas: 3},
+		},
+		{
+			name:      "ordinal out of range",
+			podName:   "hive-clustersync-3",
+			replicas:  "3",
+			expectErr: true,
+		},
+		{
+			name:      "no ordinal",
+			podName:   "hive-clustersync",
+			replicas:  "3",
+			expectErr: true,
+		},
+		{
+			name:      "invalid replicas",
+			podName:   "hive-clustersync-0",
+			replicas:  "zero",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(constants.ClusterSyncPodNameEnvVar, tc.podName)
+			os.Setenv(constants.ClusterSyncReplicasEnvVar, tc.replicas)
+			defer os.Unsetenv(constants.ClusterSyncPodNameEnvVar)
+			defer os.Unsetenv(constants.ClusterSyncReplicasEnvVar)
+			s, err := shardFromEnv()
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedShard, s, "unexpected shard")
+		})
+	}
+}
+
+func TestShardOwns(t *testing.T) {
+	const replicas = 3
+	owners := make([]int, 100)
+	for i := range owners {
+		cd := testShardCD(fmt.Sprintf("ns-%d", i), "")
+		assert.True(t, shard{}.owns(cd), "expected zero shard to own every cluster")
+		owners[i] = -1
+		for ordinal := 0; ordinal < replicas; ordinal++ {
+			if (shard{ordinal: ordinal, replicas: replicas}).owns(cd) {
+				assert.Equal(t, -1, owners[i], "expected a single shard to own %s", cd.Namespace)
+				owners[i] = ordinal
+			}
+		}
+		assert.NotEqual(t, -1, owners[i], "expected a shard to own %s", cd.Namespace)
+	}
+}
+
+func TestShardOwnsSeed(t *testing.T) {
+	const replicas = 3
+	moved := 0
+	for i := 0; i < 100; i++ {
+		cd := testShardCD(fmt.Sprintf("ns-%d", i), "")
+		for ordinal := 0; ordinal < replicas; ordinal++ {
+			unseeded := shard{ordinal: ordinal, replicas: replicas}
+			seeded := shard{ordinal: ordinal, replicas: replicas, seed: "2026-10-16"}
+			if unseeded.owns(cd) && !seeded.owns(cd) {
+				moved++
+			}
+		}
+	}
+	assert.NotZero(t, moved, "expected the seed to move some clusters to other shards")
+}
+
+func TestShardOwnsPinned(t *testing.T) {
+	cases := []struct {
+		name          string
+		annotation    string
+		expectedOwner int
+	}{
+		{
+			name:          "pinned",
+			annotation:    "2",
+			expectedOwner: 2,
+		},
+		{
+			name:          "pinned to first replica",
+			annotation:    "0",
+			expectedOwner: 0,
+		},
+		{
+			name:          "ordinal out of range",
+			annotation:    "3",
+			expectedOwner: -1,
+		},
+		{
+			name:          "invalid ordinal",
+			annotation:    "two",
+			expectedOwner: -1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			const replicas = 3
+			for i := 0; i < 10; i++ {
+				cd := testShardCD(fmt.Sprintf("ns-%d", i), tc.annotation)
+				hashed := testShardCD(fmt.Sprintf("ns-%d", i), "")
+				for ordinal := 0; ordinal < replicas; ordinal++ {
+					s := shard{ordinal: ordinal, replicas: replicas}
+					expected := ordinal == tc.expectedOwner
+					if tc.expectedOwner == -1 {
+						expected = s.owns(hashed)
+					}
+					assert.Equal(t, expected, s.owns(cd), "unexpected owner of %s", cd.Namespace)
+				}
+			}
+		})
+	}
+}
+
+func TestShardingQueue(t *testing.T) {
+	scheme := newScheme()
+	pinned := testShardCD(testNamespace, "1")
+	other := testShardCD(testNamespace, "0")
+	other.Name = "other-cd"
+	s := newSharding(shard{ordinal: 1, replicas: 2}, fake.NewFakeClientWithScheme(scheme, pinned, other), log.StandardLogger())
+
+	q := s.queue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+	defer q.ShutDown()
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: pinned.Name}})
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: other.Name}})
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "missing-cd"}})
+	assert.Equal(t, 2, q.Len(), "expected the cluster of another shard to be dropped")
+
+	metrics := map[string]float64{}
+	ch := make(chan prometheus.Metric, 10)
+	s.Collect(ch)
+	close(ch)
+	for m := range ch {
+		pb := &dto.Metric{}
+		require.NoError(t, m.Write(pb))
+		name := m.Desc().String()
+		for _, l := range pb.Label {
+			if l.GetName() == "pinned" {
+				name += "pinned=" + l.GetValue()
+			}
+		}
+		metrics[name] = pb.GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		s.metricClusters.String() + "pinned=true":  1,
+		s.metricClusters.String() + "pinned=false": 0,
+		s.metricQueueDepth.String():                2,
+	}, metrics, "unexpected shard metrics")
+}
+
+func testShardCD(namespace, pinnedOrdinal string) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Namespace = namespace
+	cd.Name = "cluster"
+	if pinnedOrdinal != "" {
+		cd.Annotations = map[string]string{constants.ClusterSyncShardAnnotation: pinnedOrdinal}
+	}
+	return cd
+}
@@ -0,0 +1,89 @@
+package clustersync
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// templateData is the data available to the templates of a templated SyncSet or SelectorSyncSet.
+type templateData struct {
+	// ClusterName is the name of the ClusterDeployment.
+	ClusterName string
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string
+	// Labels are the labels of the ClusterDeployment.
+	Labels map[string]string
+	// Platform is the platform of the cluster, such as aws or gcp.
+	Platform string
+	// BaseDomain is the base domain of the cluster.
+	BaseDomain string
+	// InfraID is the infrastructure ID of the cluster. It is empty until the cluster is provisioned.
+	InfraID string
+}
+
+func newTemplateData(cd *hivev1.ClusterDeployment) *templateData {
+	data := &templateData{
+		ClusterName: cd.Name,
+		Namespace:   cd.Namespace,
+		Labels:      cd.Labels,
+		Platform:    cd.Labels[hivev1.HiveClusterPlatformLabel],
+		BaseDomain:  cd.Spec.BaseDomain,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		data.InfraID = cd.Spec.ClusterMetadata.InfraID
+	}
+	return data
+}
+
+// renderTemplate renders the text as a template with the data. Text that has no actions is returned as is.
+func renderTemplate(text string, data *templateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	t, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// renderObject renders every string value in the content of an unstructured object as a template with the data. Map
+// keys are not rendered.
+func renderObject(content map[string]interface{}, data *templateData) error {
+	for key, value := range content {
+		rendered, err := renderValue(value, data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to render %s", key)
+		}
+		content[key] = rendered
+	}
+	return nil
+}
+
+func renderValue(value interface{}, data *templateData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return renderTemplate(v, data)
+	case map[string]interface{}:
+		return v, renderObject(v, data)
+	case []interface{}:
+		for i := range v {
+			rendered, err := renderValue(v[i], data)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
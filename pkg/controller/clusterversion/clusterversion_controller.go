@@ -6,6 +6,7 @@ import (
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,6 +32,10 @@ import (
 const (
 	clusterVersionObjectName = "version"
 	ControllerName           = hivev1.ClusterVersionControllerName
+
+	// clusterVersionFailing is the condition of the ClusterVersion that reports that the cluster version operator
+	// cannot reach the desired version of the cluster.
+	clusterVersionFailing openshiftapiv1.ClusterStatusConditionType = "Failing"
 )
 
 // Add creates a new ClusterDeployment Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -61,7 +66,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterversion-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -110,6 +115,17 @@ func (r *ReconcileClusterVersion) Reconcile(request reconcile.Request) (reconcil
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if controllerutils.IsReconcilePaused(cd) {
+		cdLog.WithField("annotation", constants.ReconcilePauseAnnotation).Warn("reconciling cluster is paused by annotation")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsDetached(cd) {
+		cdLog.Debug("cluster is detached")
+		return reconcile.Result{}, nil
+	}
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
 		return reconcile.Result{}, nil
@@ -147,6 +163,10 @@ func (r *ReconcileClusterVersion) Reconcile(request reconcile.Request) (reconcil
 		return reconcile.Result{}, err
 	}
 
+	if err := r.updateClusterVersionDegradedCondition(cd, clusterVersion, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	cdLog.Debug("reconcile complete")
 	return reconcile.Result{}, nil
 }
@@ -186,3 +206,40 @@ func (r *ReconcileClusterVersion) updateClusterVersionLabels(cd *hivev1.ClusterD
 	}
 	return nil
 }
+
+// updateClusterVersionDegradedCondition sets the ClusterVersionDegraded condition of the ClusterDeployment from the
+// Available and Failing conditions of the ClusterVersion of the remote cluster.
+func (r *ReconcileClusterVersion) updateClusterVersionDegradedCondition(cd *hivev1.ClusterDeployment, clusterVersion *openshiftapiv1.ClusterVersion, cdLog log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := "ClusterVersionHealthy"
+	message := "ClusterVersion is available and not failing"
+	for _, cond := range clusterVersion.Status.Conditions {
+		switch {
+		case cond.Type == openshiftapiv1.OperatorAvailable && cond.Status == openshiftapiv1.ConditionFalse:
+			status = corev1.ConditionTrue
+			reason = "ClusterVersionNotAvailable"
+			message = cond.Message
+		case cond.Type == clusterVersionFailing && cond.Status == openshiftapiv1.ConditionTrue && status != corev1.ConditionTrue:
+			status = corev1.ConditionTrue
+			reason = "ClusterVersionFailing"
+			message = cond.Message
+		}
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ClusterVersionDegradedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating ClusterVersionDegraded condition")
+		return err
+	}
+	return nil
+}
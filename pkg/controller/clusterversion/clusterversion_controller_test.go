@@ -25,6 +25,7 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -48,11 +49,12 @@ func TestClusterVersionReconcile(t *testing.T) {
 	configv1.Install(scheme.Scheme)
 
 	tests := []struct {
-		name         string
-		existing     []runtime.Object
-		noRemoteCall bool
-		expectError  bool
-		validate     func(*testing.T, *hivev1.ClusterDeployment)
+		name             string
+		existing         []runtime.Object
+		remoteConditions []configv1.ClusterOperatorStatusCondition
+		noRemoteCall     bool
+		expectError      bool
+		validate         func(*testing.T, *hivev1.ClusterDeployment)
 	}{
 		{
 			// no cluster deployment, no error expected
@@ -78,6 +80,58 @@ func TestClusterVersionReconcile(t *testing.T) {
 				assert.Equal(t, "2.3.4", cd.Labels[constants.VersionMajorMinorPatchLabel], "unexpected version major-minor-patch label")
 			},
 		},
+		{
+			name: "healthy cluster version",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remoteConditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: "Failing", Status: configv1.ConditionFalse},
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterVersionDegradedCondition)
+				assert.True(t, cond == nil || cond.Status != corev1.ConditionTrue, "expected cluster version not to be degraded")
+			},
+		},
+		{
+			name: "failing cluster version",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remoteConditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: "Failing", Status: configv1.ConditionTrue, Message: "Cluster operator etcd is degraded"},
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterVersionDegradedCondition)
+				if assert.NotNil(t, cond, "expected ClusterVersionDegraded condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "ClusterVersionFailing", cond.Reason, "unexpected condition reason")
+					assert.Equal(t, "Cluster operator etcd is degraded", cond.Message, "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "unavailable cluster version",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remoteConditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: "Failing", Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionFalse},
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterVersionDegradedCondition)
+				if assert.NotNil(t, cond, "expected ClusterVersionDegraded condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "ClusterVersionNotAvailable", cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -87,7 +141,7 @@ func TestClusterVersionReconcile(t *testing.T) {
 			defer mockCtrl.Finish()
 			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
 			if !test.noRemoteCall {
-				mockRemoteClientBuilder.EXPECT().Build().Return(testRemoteClusterAPIClient(), nil)
+				mockRemoteClientBuilder.EXPECT().Build().Return(testRemoteClusterAPIClient(test.remoteConditions...), nil)
 			}
 			rcd := &ReconcileClusterVersion{
 				Client:                        fakeClient,
@@ -185,13 +239,14 @@ func testSecret(name, key, value string) *corev1.Secret {
 	return s
 }
 
-func testRemoteClusterAPIClient() client.Client {
+func testRemoteClusterAPIClient(conditions ...configv1.ClusterOperatorStatusCondition) client.Client {
 	remoteClusterVersion := &configv1.ClusterVersion{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: remoteClusterVersionObjectName,
 		},
 	}
 	remoteClusterVersion.Status = *testRemoteClusterVersionStatus()
+	remoteClusterVersion.Status.Conditions = conditions
 
 	return fake.NewFakeClient(remoteClusterVersion)
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"reflect"
 	"sort"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/etcdbackup"
 	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/resource"
 )
@@ -50,10 +52,13 @@ const (
 	certsFoundMessage    = "Control plane certificates are present"
 
 	kubeAPIServerPatchTemplate = `[ {"op": "replace", "path": "/spec/forceRedeploymentReason", "value": %q } ]`
+
+	etcdBackupReason = "ControlPlaneCertificateRotation"
 )
 
 var (
-	secretCheckInterval = 2 * time.Minute
+	secretCheckInterval     = 2 * time.Minute
+	etcdBackupCheckInterval = 30 * time.Second
 )
 
 type applier interface {
@@ -81,6 +86,9 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 		scheme:  mgr.GetScheme(),
 		applier: helper,
 	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
 
 	return r
 }
@@ -89,7 +97,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("controlplanecerts-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -113,6 +121,10 @@ type ReconcileControlPlaneCerts struct {
 	client.Client
 	scheme  *runtime.Scheme
 	applier applier
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -133,6 +145,8 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, err
 	}
 
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
 	// Ensure owner references are correctly set
 	err = controllerutils.ReconcileOwnerReferences(cd, generateOwnershipUniqueKeys(cd), r, r.scheme, cdLog)
 	if err != nil {
@@ -145,7 +159,7 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, nil
 	}
 
-	if !cd.Spec.Installed {
+	if !cd.Spec.Installed || controllerutils.IsDetached(cd) {
 		return reconcile.Result{}, nil
 	}
 
@@ -192,6 +206,20 @@ func (r *ReconcileControlPlaneCerts) Reconcile(request reconcile.Request) (recon
 		return reconcile.Result{}, err
 	}
 
+	// Changing the certificates redeploys the kube API server, so take an etcd backup first if the cluster asks
+	// for one.
+	if existingSyncSet == nil || !reflect.DeepEqual(existingSyncSet.Spec.Patches, desiredSyncSet.Spec.Patches) {
+		backedUp, err := etcdbackup.Ensure(r.Client, r.remoteClusterAPIClientBuilder(cd), r.applier, r.scheme, cd, etcdBackupReason, secretsHash(secrets), cdLog)
+		if err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to take etcd backup before rotating control plane certificates")
+			return reconcile.Result{}, err
+		}
+		if !backedUp {
+			cdLog.Info("waiting for etcd backup before rotating control plane certificates")
+			return reconcile.Result{RequeueAfter: etcdBackupCheckInterval}, nil
+		}
+	}
+
 	if _, err = r.applier.ApplyRuntimeObject(desiredSyncSet, r.scheme); err != nil {
 		cdLog.WithError(err).Error("failed to apply control plane certificates syncset")
 		return reconcile.Result{}, err
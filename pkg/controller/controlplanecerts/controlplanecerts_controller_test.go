@@ -28,6 +28,9 @@ import (
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/etcdbackup"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	"github.com/openshift/hive/pkg/resource"
 	testsecret "github.com/openshift/hive/pkg/test/secret"
 )
@@ -157,6 +160,45 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				validateAppliedSyncSet(t, applied)
 			},
 		},
+		{
+			name: "default control plane certs, wait for etcd backup",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withEtcdBackup().obj(),
+				fakeCertSecret("default-secret"),
+			},
+			validate: func(t *testing.T, c client.Client, applied []runtime.Object) {
+				if assert.Len(t, applied, 1, "only the etcd backup syncset should be applied") {
+					assert.Equal(t, etcdbackup.SyncSetName(fakeName), applied[0].(*hivev1.SyncSet).Name)
+				}
+				cd := getFakeClusterDeployment(t, c)
+				if assert.NotNil(t, cd.Status.LastEtcdBackup, "expected etcd backup in status") {
+					assert.Equal(t, etcdBackupReason, cd.Status.LastEtcdBackup.Reason)
+				}
+			},
+		},
+		{
+			name: "unchanged control plane certs, no etcd backup",
+			existing: []runtime.Object{
+				fakeClusterDeployment().defaultCert("default-cert", "default-secret").withEtcdBackup().obj(),
+				fakeCertSecret("default-secret"),
+				func() *hivev1.SyncSet {
+					ss := fakeSyncSet()
+					ss.Spec.Patches = []hivev1.SyncObjectPatch{{
+						APIVersion: "operator.openshift.io/v1",
+						Kind:       "KubeAPIServer",
+						Name:       "cluster",
+						Patch:      fmt.Sprintf(kubeAPIServerPatchTemplate, secretsHash([]*corev1.Secret{fakeCertSecret("default-secret")})),
+						PatchType:  "json",
+					}}
+					return ss
+				}(),
+			},
+			validate: func(t *testing.T, c client.Client, applied []runtime.Object) {
+				validateAppliedSyncSet(t, applied, additionalCert(fakeAPIURLDomain, "default-secret"))
+				cd := getFakeClusterDeployment(t, c)
+				assert.Nil(t, cd.Status.LastEtcdBackup, "expected no etcd backup")
+			},
+		},
 		{
 			name: "existing not found condition, change to false",
 			existing: []runtime.Object{
@@ -187,11 +229,15 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 			mockController := gomock.NewController(t)
 			defer mockController.Finish()
 
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockController)
+			mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClient(), nil).AnyTimes()
+
 			applier := &fakeApplier{}
 			r := &ReconcileControlPlaneCerts{
-				Client:  fakeClient,
-				scheme:  scheme.Scheme,
-				applier: applier,
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				applier:                       applier,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
 			}
 
 			_, err := r.Reconcile(reconcile.Request{
@@ -361,6 +407,13 @@ func (f *fakeClusterDeploymentWrapper) namedCert(name, domain, secretName string
 	return f
 }
 
+func (f *fakeClusterDeploymentWrapper) withEtcdBackup() *fakeClusterDeploymentWrapper {
+	cliImage := "example.com/cli"
+	f.cd.Spec.EtcdBackup = &hivev1.EtcdBackupConfig{Enabled: true}
+	f.cd.Status.CLIImage = &cliImage
+	return f
+}
+
 func (f *fakeClusterDeploymentWrapper) withNotFoundCondition() *fakeClusterDeploymentWrapper {
 	f.cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
 		f.cd.Status.Conditions,
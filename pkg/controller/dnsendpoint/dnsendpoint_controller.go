@@ -3,6 +3,7 @@ package dnsendpoint
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -30,6 +31,10 @@ import (
 
 const (
 	ControllerName = hivev1.DNSEndpointControllerName
+
+	// nameServerDeletionCheckInterval is how long to wait before checking again whether the NS record of a deleted
+	// DNSZone has been removed from the parent domain.
+	nameServerDeletionCheckInterval = 10 * time.Second
 )
 
 // Add creates a new DNSZone Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -57,7 +62,7 @@ func Add(mgr manager.Manager) error {
 		ControllerName.String(),
 		mgr,
 		controller.Options{
-			Reconciler:              reconciler,
+			Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, reconciler),
 			MaxConcurrentReconciles: concurrentReconciles,
 			RateLimiter:             queueRateLimiter,
 		},
@@ -207,11 +212,13 @@ func (r *ReconcileDNSEndpoint) Reconcile(request reconcile.Request) (reconcile.R
 
 	var nsTool nameServerTool
 
+	// Each managed domain entry has its own cloud credentials. Use the entry with the most specific root domain
+	// containing the zone so that a subdomain can be managed with different credentials than its parent.
 	for i, nst := range r.nameServerTools {
-		rootDomain, currentNameServers = nst.scraper.GetEndpoint(fullDomain)
-		if rootDomain != "" {
+		root, nameServers := nst.scraper.GetEndpoint(fullDomain)
+		if len(root) > len(rootDomain) {
+			rootDomain, currentNameServers = root, nameServers
 			nsTool = r.nameServerTools[i]
-			break
 		}
 	}
 
@@ -264,6 +271,20 @@ func (r *ReconcileDNSEndpoint) Reconcile(request reconcile.Request) (reconcile.R
 			return reconcile.Result{}, err
 		}
 		nsTool.scraper.RemoveEndpoint(fullDomain)
+
+		// A stale NS record left in the parent domain breaks the delegation of a re-created zone with the same
+		// name, so do not release the DNSZone until the record is confirmed to be gone.
+		if isDeleted {
+			removed, err := nameServersRemoved(nsTool.queryClient, rootDomain, fullDomain)
+			if err != nil {
+				dnsLog.WithError(err).Error("error verifying that NS record has been deleted")
+				return reconcile.Result{}, err
+			}
+			if !removed {
+				dnsLog.Info("NS record still present in parent domain, will check again")
+				return reconcile.Result{RequeueAfter: nameServerDeletionCheckInterval}, nil
+			}
+		}
 	}
 
 	parentLinkCreated := false
@@ -286,6 +307,15 @@ func (r *ReconcileDNSEndpoint) Reconcile(request reconcile.Request) (reconcile.R
 	return reconcile.Result{}, nil
 }
 
+// nameServersRemoved returns true if the root domain no longer holds name servers for the domain.
+func nameServersRemoved(query nameserver.Query, rootDomain, domain string) (bool, error) {
+	nameServers, err := query.Get(rootDomain)
+	if err != nil {
+		return false, err
+	}
+	return len(nameServers[domain]) == 0, nil
+}
+
 func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomain hivev1.ManageDNSConfig) nameserver.Query {
 	if managedDomain.AWS != nil {
 		secretName := managedDomain.AWS.CredentialsSecretRef.Name
@@ -65,8 +65,10 @@ func TestDNSEndpointReconcile(t *testing.T) {
 		nameServers              rootDomainsMap
 		configureQuery           func(*mock.MockQuery)
 		expectErr                bool
+		expectedResult           reconcile.Result
 		expectedNameServers      rootDomainsMap
 		expectedCreatedCondition bool
+		expectFinalizer          bool
 		expectedConditions       []conditionExpectations
 	}{
 		{
@@ -164,6 +166,9 @@ func TestDNSEndpointReconcile(t *testing.T) {
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
 				mockQuery.EXPECT().Delete(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().Get(rootDomain).Return(map[string]sets.String{
+					"other.test-domain": sets.NewString("other-value"),
+				}, nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
@@ -177,11 +182,46 @@ func TestDNSEndpointReconcile(t *testing.T) {
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
 				mockQuery.EXPECT().Delete(rootDomain, dnsName, nil).Return(nil)
+				mockQuery.EXPECT().Get(rootDomain).Return(nil, nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
 			},
 		},
+		{
+			name:    "deleted name server still in parent domain",
+			dnsZone: testDeletedDNSZone(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().Delete(rootDomain, dnsName, nil).Return(nil)
+				mockQuery.EXPECT().Get(rootDomain).Return(map[string]sets.String{
+					dnsName: sets.NewString("test-value-1"),
+				}, nil)
+			},
+			expectedResult: reconcile.Result{RequeueAfter: nameServerDeletionCheckInterval},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			expectFinalizer: true,
+		},
+		{
+			name:    "error verifying deleted name server",
+			dnsZone: testDeletedDNSZone(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().Delete(rootDomain, dnsName, nil).Return(nil)
+				mockQuery.EXPECT().Get(rootDomain).Return(nil, errors.New("get error"))
+			},
+			expectErr: true,
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			expectFinalizer: true,
+		},
 		{
 			name:    "create error",
 			dnsZone: testDNSZone(),
@@ -217,6 +257,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
 			},
+			expectFinalizer: true,
 		},
 		{
 			name:    "name servers not yet scraped",
@@ -346,16 +387,67 @@ func TestDNSEndpointReconcile(t *testing.T) {
 			} else {
 				assert.NoError(t, err, "expected no error from reconcile")
 			}
-			assert.Equal(t, reconcile.Result{}, result, "unexpected reconcile result")
+			assert.Equal(t, tc.expectedResult, result, "unexpected reconcile result")
 			assert.Equal(t, tc.expectedNameServers, scraper.nameServers, "unexpected name servers in scraper")
 			dnsZone := &hivev1.DNSZone{}
 			if err := fakeClient.Get(context.Background(), objectKey, dnsZone); assert.NoError(t, err, "unexpected error getting DNSZone") {
 				validateConditions(t, dnsZone, tc.expectedConditions)
+				if tc.dnsZone.DeletionTimestamp != nil {
+					assert.Equal(t, tc.expectFinalizer, controllerutils.HasFinalizer(dnsZone, hivev1.FinalizerDNSEndpoint), "unexpected DNSEndpoint finalizer")
+				}
 			}
 		})
 	}
 }
 
+func TestDNSEndpointReconcileMostSpecificManagedDomain(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	const (
+		subRootDomain = "sub.domain.com"
+		subDNSName    = "test.sub.domain.com"
+	)
+	objectKey := client.ObjectKey{Namespace: testNamespace, Name: testName}
+	dnsZone := testDNSZone()
+	dnsZone.Spec.Zone = subDNSName
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger := log.WithField("controller", ControllerName)
+	fakeClient := fake.NewFakeClient(dnsZone)
+
+	// The parent domain is managed with one set of credentials and should not be used.
+	parentQuery := mock.NewMockQuery(mockCtrl)
+	parentScraper := newNameServerScraper(logger, parentQuery, []string{rootDomain}, nil)
+	parentScraper.nameServers = rootDomainsMap{rootDomain: nameServersMap{}}
+
+	// The subdomain is managed with a different set of credentials.
+	subQuery := mock.NewMockQuery(mockCtrl)
+	subQuery.EXPECT().Create(subRootDomain, subDNSName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+	subScraper := newNameServerScraper(logger, subQuery, []string{subRootDomain}, nil)
+	subScraper.nameServers = rootDomainsMap{subRootDomain: nameServersMap{}}
+
+	cut := &ReconcileDNSEndpoint{
+		Client: fakeClient,
+		scheme: scheme.Scheme,
+		logger: logger,
+		nameServerTools: []nameServerTool{
+			{
+				scraper:     parentScraper,
+				queryClient: parentQuery,
+			},
+			{
+				scraper:     subScraper,
+				queryClient: subQuery,
+			},
+		},
+	}
+	_, err := cut.Reconcile(reconcile.Request{NamespacedName: objectKey})
+	assert.NoError(t, err, "expected no error from reconcile")
+	assert.Equal(t, rootDomainsMap{rootDomain: nameServersMap{}}, parentScraper.nameServers, "unexpected name servers in parent scraper")
+	assert.Contains(t, subScraper.nameServers[subRootDomain], subDNSName, "expected endpoint in subdomain scraper")
+}
+
 func validateConditions(t *testing.T, dnsZone *hivev1.DNSZone, conditions []conditionExpectations) {
 	for _, expectedCondition := range conditions {
 		cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, expectedCondition.conditionType)
@@ -160,11 +160,19 @@ func (s *nameServerScraper) scrape(rootDomain string) error {
 	return nil
 }
 
+// rootDomainNameServers finds the most specific root domain containing the specified domain.
 func (s *nameServerScraper) rootDomainNameServers(domain string) (string, nameServersMap) {
+	var rootDomain string
+	var nameServers nameServersMap
 	for root, nsMap := range s.nameServers {
-		if strings.HasSuffix(domain, root) {
-			return root, nsMap
+		if isSubdomain(domain, root) && len(root) > len(rootDomain) {
+			rootDomain, nameServers = root, nsMap
 		}
 	}
-	return "", nil
+	return rootDomain, nameServers
+}
+
+// isSubdomain returns true if domain is the same as or a subdomain of root.
+func isSubdomain(domain, root string) bool {
+	return domain == root || strings.HasSuffix(domain, "."+root)
 }
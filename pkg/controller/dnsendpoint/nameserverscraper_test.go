@@ -93,6 +93,29 @@ func TestGetEndpoint(t *testing.T) {
 			expectRootDomain: true,
 			expectedValues:   sets.NewString("test-value"),
 		},
+		{
+			name: "suffix that is not a parent domain",
+			nameServers: rootDomainsMap{
+				"main.com": nameServersMap{},
+			},
+		},
+		{
+			name: "nested root domains",
+			nameServers: rootDomainsMap{
+				"com": nameServersMap{
+					domain: endpointState{
+						nsValues: sets.NewString("other-value"),
+					},
+				},
+				rootDomain: nameServersMap{
+					domain: endpointState{
+						nsValues: sets.NewString("test-value"),
+					},
+				},
+			},
+			expectRootDomain: true,
+			expectedValues:   sets.NewString("test-value"),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
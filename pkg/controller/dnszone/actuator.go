@@ -23,6 +23,13 @@ type Actuator interface {
 	// Refresh will update the DNSZone object's platform-specific status fields.
 	Refresh() error
 
+	// GetOwner returns the owner recorded in the ownership record of the zone in the dns provider, or an empty string
+	// when the zone has no ownership record.
+	GetOwner() (string, error)
+
+	// SetOwner records the owner in the ownership record of the zone in the dns provider.
+	SetOwner(owner string) error
+
 	// SetConditionsForError sets conditions on the dnszone given a specific error
 	SetConditionsForError(err error) bool
 }
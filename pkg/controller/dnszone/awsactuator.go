@@ -46,7 +46,7 @@ type AWSActuator struct {
 	dnsZone *hivev1.DNSZone
 }
 
-type awsClientBuilderType func(secret *corev1.Secret, region string) (awsclient.Client, error)
+type awsClientBuilderType func(secret *corev1.Secret, options awsclient.Options) (awsclient.Client, error)
 
 // NewAWSActuator creates a new AWSActuator object. A new AWSActuator is expected to be created for each controller sync.
 func NewAWSActuator(
@@ -59,7 +59,10 @@ func NewAWSActuator(
 	if region == "" {
 		region = constants.AWSRoute53Region
 	}
-	awsClient, err := awsClientBuilder(secret, region)
+	awsClient, err := awsClientBuilder(secret, awsclient.Options{
+		Region:           region,
+		ServiceEndpoints: dnsZone.Spec.AWS.ServiceEndpoints,
+	})
 	if err != nil {
 		logger.WithError(err).Error("Error creating AWSClient")
 		return nil, err
@@ -529,6 +532,64 @@ func (a *AWSActuator) GetNameServers() ([]string, error) {
 	return result, nil
 }
 
+// GetOwner returns the owner recorded in the ownership TXT record of the route53 hosted zone.
+func (a *AWSActuator) GetOwner() (string, error) {
+	if a.hostedZone == nil {
+		return "", errors.New("hostedZone is unpopulated")
+	}
+
+	name := ownershipRecordName(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("id", a.hostedZone.Id).WithField("record", name)
+	logger.Debug("Listing hosted zone ownership record")
+	resp, err := a.awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(*a.hostedZone.Id),
+		StartRecordType: aws.String("TXT"),
+		StartRecordName: aws.String(name),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for zone")
+		return "", err
+	}
+	if len(resp.ResourceRecordSets) == 0 {
+		return "", nil
+	}
+	recordSet := resp.ResourceRecordSets[0]
+	if aws.StringValue(recordSet.Type) != "TXT" || aws.StringValue(recordSet.Name) != controllerutils.Dotted(name) || len(recordSet.ResourceRecords) == 0 {
+		return "", nil
+	}
+	return ownerFromRecordValue(aws.StringValue(recordSet.ResourceRecords[0].Value)), nil
+}
+
+// SetOwner records the owner in the ownership TXT record of the route53 hosted zone.
+func (a *AWSActuator) SetOwner(owner string) error {
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+
+	name := ownershipRecordName(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("id", a.hostedZone.Id).WithField("record", name)
+	logger.WithField("owner", owner).Info("Recording hosted zone owner")
+	_, err := a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(*a.hostedZone.Id),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String(name),
+					Type:            aws.String("TXT"),
+					TTL:             aws.Int64(ownershipRecordTTL),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(ownershipRecordValue(owner))}},
+				},
+			}},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error recording hosted zone owner")
+	}
+	return err
+}
+
 // Exists determines if the route53 hosted zone corresponding to the DNSZone exists
 func (a *AWSActuator) Exists() (bool, error) {
 	return a.hostedZone != nil, nil
@@ -2,6 +2,7 @@ package dnszone
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -187,3 +188,25 @@ func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
 		f(getResourcesOutput, true)
 	})
 }
+
+func mockAWSGetOwner(expect *mock.MockClientMockRecorder, owner string) {
+	output := &route53.ListResourceRecordSetsOutput{}
+	if owner != "" {
+		output.ResourceRecordSets = []*route53.ResourceRecordSet{
+			{
+				Type: aws.String("TXT"),
+				Name: aws.String("_hive-owner.blah.example.com."),
+				ResourceRecords: []*route53.ResourceRecord{
+					{
+						Value: aws.String(strconv.Quote(owner)),
+					},
+				},
+			},
+		}
+	}
+	expect.ListResourceRecordSets(gomock.Any()).Return(output, nil).Times(1)
+}
+
+func mockAWSSetOwner(expect *mock.MockClientMockRecorder) {
+	expect.ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+}
@@ -138,6 +138,62 @@ func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZo
 	return nil
 }
 
+// GetOwner implements the GetOwner call of the actuator interface
+func (a *AzureActuator) GetOwner() (string, error) {
+	if a.managedZone == nil {
+		return "", errors.New("managedZone is unpopulated")
+	}
+
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("record", ownershipRecordLabel)
+	logger.Debug("Listing managed zone ownership record")
+	recordSetsPage, err := a.azureClient.ListRecordSetsByZone(context.Background(), resourceGroupName, a.dnsZone.Spec.Zone, "")
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for managed zone")
+		return "", err
+	}
+	for recordSetsPage.NotDone() {
+		for _, recordSet := range recordSetsPage.Values() {
+			if recordSet.Name == nil || *recordSet.Name != ownershipRecordLabel || recordSet.RecordSetProperties == nil {
+				continue
+			}
+			if txtRecords := recordSet.TxtRecords; txtRecords != nil {
+				for _, txtRecord := range *txtRecords {
+					if txtRecord.Value != nil && len(*txtRecord.Value) > 0 {
+						return ownerFromRecordValue((*txtRecord.Value)[0]), nil
+					}
+				}
+			}
+		}
+		if err := recordSetsPage.NextWithContext(context.Background()); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// SetOwner implements the SetOwner call of the actuator interface
+func (a *AzureActuator) SetOwner(owner string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("record", ownershipRecordLabel)
+	logger.WithField("owner", owner).Info("Recording managed zone owner")
+	ttl := int64(ownershipRecordTTL)
+	_, err := a.azureClient.CreateOrUpdateRecordSet(context.Background(), resourceGroupName, a.dnsZone.Spec.Zone, ownershipRecordLabel, dns.TXT, dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:        &ttl,
+			TxtRecords: &[]dns.TxtRecord{{Value: &[]string{owner}}},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error recording managed zone owner")
+	}
+	return err
+}
+
 // Exists implements the Exists call of the actuator interface
 func (a *AzureActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
@@ -93,3 +93,26 @@ func mockDeleteAzureZone(mockCtrl *gomock.Controller, expect *mock.MockClientMoc
 	expect.ListRecordSetsByZone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(recordSetPage, nil)
 	expect.DeleteZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
+
+func mockAzureGetOwner(mockCtrl *gomock.Controller, expect *mock.MockClientMockRecorder, owner string) {
+	recordSetPage := mock.NewMockRecordSetPage(mockCtrl)
+	if owner != "" {
+		recordSetPage.EXPECT().NotDone().Return(true).Times(1)
+		recordSetPage.EXPECT().Values().Return([]dns.RecordSet{
+			{
+				Name: to.StringPtr("_hive-owner"),
+				Type: to.StringPtr("Microsoft.Network/dnszones/TXT"),
+				RecordSetProperties: &dns.RecordSetProperties{
+					TxtRecords: &[]dns.TxtRecord{{Value: to.StringSlicePtr([]string{owner})}},
+				},
+			},
+		}).Times(1)
+	} else {
+		recordSetPage.EXPECT().NotDone().Return(false).Times(1)
+	}
+	expect.ListRecordSetsByZone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(recordSetPage, nil).Times(1)
+}
+
+func mockAzureSetOwner(expect *mock.MockClientMockRecorder) {
+	expect.CreateOrUpdateRecordSet(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), dns.TXT, gomock.Any()).Return(dns.RecordSet{}, nil).Times(1)
+}
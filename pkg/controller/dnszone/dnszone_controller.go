@@ -39,6 +39,7 @@ const (
 	ControllerName                  = hivev1.DNSZoneControllerName
 	zoneResyncDuration              = 2 * time.Hour
 	domainAvailabilityCheckInterval = 30 * time.Second
+	hostedZoneDeletionCheckInterval = 10 * time.Second
 	dnsClientTimeout                = 30 * time.Second
 	resolverConfigFile              = "/etc/resolv.conf"
 	zoneCheckDNSServersEnvVar       = "ZONE_CHECK_DNS_SERVERS"
@@ -46,6 +47,10 @@ const (
 	accessGrantedReason             = "AccessGranted"
 	authenticationFailedReason      = "AuthenticationFailed"
 	authenticationSucceededReason   = "AuthenticationSucceeded"
+	zoneClaimedReason               = "ZoneClaimedByOtherDNSZone"
+	zoneOwnedByOtherReason          = "ZoneOwnedByOtherDNSZone"
+	zoneNotInConflictReason         = "ZoneNotInConflict"
+	zoneConflictCheckInterval       = 1 * time.Minute
 )
 
 var (
@@ -87,7 +92,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New(ControllerName.String(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -165,6 +170,14 @@ func (r *ReconcileDNSZone) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{}, nil
 	}
 
+	if desiredState.DeletionTimestamp == nil {
+		if result, err := r.reconcileZoneConflict(desiredState, dnsLog); err != nil {
+			return reconcile.Result{}, err
+		} else if result != nil {
+			return *result, nil
+		}
+	}
+
 	actuator, err := r.getActuator(desiredState, dnsLog)
 	if err != nil {
 		// Handle an edge case here where if the DNSZone has been deleted, it has its finalizer, the actuator couldn't be
@@ -239,11 +252,25 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 
 	if dnsZone.DeletionTimestamp != nil {
 		if zoneFound {
-			r.logger.Debug("DNSZone resource is deleted, deleting hosted zone")
-			err := actuator.Delete()
+			owner, err := actuator.GetOwner()
 			if err != nil {
+				r.logger.WithError(err).Error("Failed to get owner of hosted zone")
 				return reconcile.Result{}, err
 			}
+			// Hosted zones without an ownership record were created before ownership was recorded and are deleted
+			// as before.
+			if owner != "" && owner != zoneOwner(dnsZone) {
+				r.logger.WithField("owner", owner).Warn("DNSZone resource is deleted, not deleting hosted zone owned by another DNSZone")
+			} else {
+				r.logger.Debug("DNSZone resource is deleted, deleting hosted zone")
+				if err := actuator.Delete(); err != nil {
+					return reconcile.Result{}, err
+				}
+				// Keep the finalizer until a refresh confirms that the hosted zone is gone so that a new DNSZone
+				// with the same name does not race with the deletion of the old hosted zone.
+				r.logger.Info("hosted zone deleted, will verify removal before removing finalizer")
+				return reconcile.Result{RequeueAfter: hostedZoneDeletionCheckInterval}, nil
+			}
 		}
 		if controllerutils.HasFinalizer(dnsZone, hivev1.FinalizerDNSZone) {
 			// Remove the finalizer from the DNSZone. It will be persisted when we persist status
@@ -273,9 +300,35 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 			r.logger.WithError(err).Error("Failed to create hosted zone")
 			return reconcile.Result{}, err
 		}
+		if err := actuator.SetOwner(zoneOwner(dnsZone)); err != nil {
+			r.logger.WithError(err).Error("Failed to record owner of hosted zone")
+			return reconcile.Result{}, err
+		}
 	} else {
+		owner, err := actuator.GetOwner()
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to get owner of hosted zone")
+			return reconcile.Result{}, err
+		}
+		switch owner {
+		case zoneOwner(dnsZone):
+		case "":
+			r.logger.Info("Existing hosted zone has no owner, recording DNSZone as its owner")
+			if err := actuator.SetOwner(zoneOwner(dnsZone)); err != nil {
+				r.logger.WithError(err).Error("Failed to record owner of hosted zone")
+				return reconcile.Result{}, err
+			}
+		default:
+			r.logger.WithField("owner", owner).Warn("Existing hosted zone is owned by another DNSZone, not syncing")
+			return reconcile.Result{RequeueAfter: zoneConflictCheckInterval}, r.setZoneConflictCondition(
+				dnsZone,
+				zoneOwnedByOtherReason,
+				fmt.Sprintf("Hosted zone for %s is owned by DNSZone %s", dnsZone.Spec.Zone, owner),
+			)
+		}
+
 		r.logger.Info("Existing hosted zone found. Syncing with DNSZone resource")
-		err := actuator.UpdateMetadata()
+		err = actuator.UpdateMetadata()
 		if err != nil {
 			r.logger.WithError(err).Error("failed to sync tags for hosted zone")
 			return reconcile.Result{}, err
@@ -349,7 +402,7 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			return nil, err
 		}
 
-		return NewAWSActuator(dnsLog, secret, dnsZone, awsclient.NewClientFromSecret)
+		return NewAWSActuator(dnsLog, secret, dnsZone, awsclient.NewClientFromSecretWithOptions)
 	}
 
 	if dnsZone.Spec.GCP != nil {
@@ -407,6 +460,13 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableMessage = "DNS SOA record for zone is not reachable"
 	}
 	dnsZone.Status.LastSyncGeneration = dnsZone.ObjectMeta.Generation
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.ZoneConflictCondition,
+		corev1.ConditionFalse,
+		zoneNotInConflictReason,
+		"Zone is owned by the DNSZone",
+		controllerutils.UpdateConditionNever)
 	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
 		dnsZone.Status.Conditions,
 		hivev1.ZoneAvailableDNSZoneCondition,
@@ -476,3 +536,45 @@ func lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
 	}
 	return false, nil
 }
+
+// reconcileZoneConflict checks whether the zone of the DNSZone is claimed by another DNSZone. A DNSZone whose zone is
+// claimed is not synced with the dns provider, so that it cannot adopt or delete the hosted zone of the other DNSZone.
+// The returned result is non-nil when the DNSZone must not be synced.
+func (r *ReconcileDNSZone) reconcileZoneConflict(dnsZone *hivev1.DNSZone, logger log.FieldLogger) (*reconcile.Result, error) {
+	dnsZones := &hivev1.DNSZoneList{}
+	if err := r.List(context.TODO(), dnsZones); err != nil {
+		logger.WithError(err).Error("could not list DNSZones")
+		return nil, err
+	}
+	claimant := controllerutils.ConflictingDNSZone(dnsZone, dnsZones.Items)
+	if claimant == nil {
+		return nil, nil
+	}
+	logger.WithField("claimant", claimant.Namespace+"/"+claimant.Name).Warn("zone is claimed by another DNSZone, not syncing")
+	err := r.setZoneConflictCondition(
+		dnsZone,
+		zoneClaimedReason,
+		fmt.Sprintf("Zone %s is claimed by DNSZone %s/%s", dnsZone.Spec.Zone, claimant.Namespace, claimant.Name),
+	)
+	return &reconcile.Result{RequeueAfter: zoneConflictCheckInterval}, err
+}
+
+// setZoneConflictCondition sets the ZoneConflict condition of the DNSZone to true and persists it.
+func (r *ReconcileDNSZone) setZoneConflictCondition(dnsZone *hivev1.DNSZone, reason, message string) error {
+	var changed bool
+	dnsZone.Status.Conditions, changed = controllerutils.SetDNSZoneConditionWithChangeCheck(
+		dnsZone.Status.Conditions,
+		hivev1.ZoneConflictCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), dnsZone); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update DNSZone status")
+		return err
+	}
+	return nil
+}
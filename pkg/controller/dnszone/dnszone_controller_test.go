@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -55,6 +57,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			setupAWSMock: func(expect *awsmock.MockClientMockRecorder) {
 				mockAWSZoneDoesntExist(expect, validDNSZoneWithoutID())
 				mockCreateAWSZone(expect)
+				mockAWSSetOwner(expect)
 				mockNoExistingAWSTags(expect)
 				mockSyncAWSTags(expect)
 				mockAWSGetNSRecord(expect)
@@ -72,6 +75,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
 				mockAWSZoneExists(expect, validDNSZoneWithoutID())
 				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "ns/dnszoneobject")
 				mockAWSGetNSRecord(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
@@ -88,6 +92,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				mockAWSZoneDoesntExist(expect, validDNSZoneWithoutID())
 				mockCreateAWSZoneDuplicateFailure(expect)
 				mockListAWSZonesByNameFound(expect, validDNSZoneWithoutID())
+				mockAWSSetOwner(expect)
 				mockNoExistingAWSTags(expect)
 				mockSyncAWSTags(expect)
 				mockAWSGetNSRecord(expect)
@@ -105,6 +110,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
 				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
 				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "ns/dnszoneobject")
 				mockSyncAWSTags(expect)
 				mockAWSGetNSRecord(expect)
 			},
@@ -112,18 +118,76 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				assert.Equal(t, zone.Status.LastSyncGeneration, int64(6))
 			},
 		},
+		{
+			name:    "Existing zone without owner, record owner",
+			dnsZone: validDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "")
+				mockAWSSetOwner(expect)
+				mockSyncAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name:    "Existing zone owned by other DNSZone",
+			dnsZone: validDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "other/dnszone")
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneConflictCondition)
+				if assert.NotNil(t, condition, "zone conflict condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status)
+					assert.Equal(t, zoneOwnedByOtherReason, condition.Reason)
+				}
+				assert.Empty(t, zone.Status.NameServers, "nameservers must not be set in status")
+			},
+		},
 		{
 			name:    "Delete hosted zone",
 			dnsZone: validDNSZoneBeingDeleted(),
 			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
 				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
 				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "ns/dnszoneobject")
 				mockDeleteAWSZone(expect)
 			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "finalizer must be kept until the zone is confirmed deleted")
+			},
+		},
+		{
+			name:    "Delete hosted zone owned by other DNSZone",
+			dnsZone: validDNSZoneBeingDeleted(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "other/dnszone")
+			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Delete hosted zone without owner",
+			dnsZone: validDNSZoneBeingDeleted(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "")
+				mockDeleteAWSZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "finalizer must be kept until the zone is confirmed deleted")
+			},
+		},
 		{
 			name:    "Delete non-existent hosted zone",
 			dnsZone: validDNSZoneBeingDeleted(),
@@ -145,10 +209,11 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				mockGetResourcePages(expect)
 				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
 				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "ns/dnszoneobject")
 				mockDeleteAWSZone(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "finalizer must be kept until the zone is confirmed deleted")
 			},
 		},
 		{
@@ -158,6 +223,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
 				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
 				mockExistingAWSTags(expect)
+				mockAWSGetOwner(expect, "ns/dnszoneobject")
 				mockAWSGetNSRecord(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
@@ -250,6 +316,7 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
 				mockGCPZoneDoesntExist(expect)
 				mockCreateGCPZone(expect)
+				mockGCPSetOwner(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.NotNil(t, zone.Status.GCP)
@@ -263,6 +330,7 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			dnsZone: validDNSZoneWithoutID(),
 			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
 				mockGCPZoneExists(expect)
+				mockGCPGetOwner(expect, "ns/dnszoneobject")
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.NotNil(t, zone.Status.GCP)
@@ -271,6 +339,20 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
 			},
 		},
+		{
+			name:    "Existing zone owned by other DNSZone",
+			dnsZone: validDNSZoneWithoutID(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				mockGCPGetOwner(expect, "other/dnszone")
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneConflictCondition)
+				if assert.NotNil(t, condition, "zone conflict condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status)
+				}
+			},
+		},
 		{
 			name: "Delete managed zone",
 			dnsZone: testdnszone.BasicBuilder().
@@ -285,12 +367,24 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 				Build(),
 			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
 				mockGCPZoneExists(expect)
+				mockGCPGetOwner(expect, "testNamespace/testDNSZone")
 				mockDeleteGCPZone(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Delete managed zone owned by other DNSZone",
+			dnsZone: validDNSZoneBeingDeleted(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				mockGCPGetOwner(expect, "other/dnszone")
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
 		{
 			name:    "Delete non-existent managed zone",
 			dnsZone: validDNSZoneBeingDeleted(),
@@ -307,6 +401,7 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			soaLookupResult: true,
 			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
 				mockGCPZoneExists(expect)
+				mockGCPGetOwner(expect, "ns/dnszoneobject")
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
@@ -399,6 +494,7 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			setupAzureMock: func(_ *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
 				mockAzureZoneDoesntExist(expect)
 				mockCreateAzureZone(expect)
+				mockAzureSetOwner(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
@@ -407,8 +503,21 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 		{
 			name:    "Adopt existing zone",
 			dnsZone: validAzureDNSZone(),
-			setupAzureMock: func(_ *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
 				mockAzureZoneExists(expect)
+				mockAzureGetOwner(mockCtrl, expect, "ns/dnszoneobject")
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name:    "Existing zone without owner, record owner",
+			dnsZone: validAzureDNSZone(),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzureZoneExists(expect)
+				mockAzureGetOwner(mockCtrl, expect, "")
+				mockAzureSetOwner(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
@@ -419,8 +528,20 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			dnsZone: validAzureDNSZoneBeingDeleted(),
 			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
 				mockAzureZoneExists(expect)
+				mockAzureGetOwner(mockCtrl, expect, "ns/dnszoneobject")
 				mockDeleteAzureZone(mockCtrl, expect)
 			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "finalizer must be kept until the zone is confirmed deleted")
+			},
+		},
+		{
+			name:    "Delete managed zone owned by other DNSZone",
+			dnsZone: validAzureDNSZoneBeingDeleted(),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzureZoneExists(expect)
+				mockAzureGetOwner(mockCtrl, expect, "other/dnszone")
+			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
@@ -439,8 +560,9 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validAzureDNSZoneWithLinkToParent(),
 			soaLookupResult: true,
-			setupAzureMock: func(_ *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
 				mockAzureZoneExists(expect)
+				mockAzureGetOwner(mockCtrl, expect, "ns/dnszoneobject")
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
@@ -503,6 +625,87 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 	}
 }
 
+// TestReconcileZoneConflict tests that a DNSZone whose zone is claimed by another DNSZone is not synced.
+func TestReconcileZoneConflict(t *testing.T) {
+
+	log.SetLevel(log.DebugLevel)
+
+	olderDNSZone := func() *hivev1.DNSZone {
+		dz := validDNSZone()
+		dz.Namespace = "other"
+		dz.UID = types.UID("older")
+		dz.CreationTimestamp = metav1.NewTime(kubeTimeNow.Add(-time.Hour))
+		return dz
+	}
+
+	cases := []struct {
+		name           string
+		dnsZone        *hivev1.DNSZone
+		existing       []*hivev1.DNSZone
+		expectConflict bool
+	}{
+		{
+			name:    "no other DNSZones",
+			dnsZone: validDNSZone(),
+		},
+		{
+			name:           "zone claimed by older DNSZone",
+			dnsZone:        validDNSZone(),
+			existing:       []*hivev1.DNSZone{olderDNSZone()},
+			expectConflict: true,
+		},
+		{
+			name:    "zone claimed by deleted DNSZone",
+			dnsZone: validDNSZone(),
+			existing: []*hivev1.DNSZone{func() *hivev1.DNSZone {
+				dz := olderDNSZone()
+				dz.DeletionTimestamp = kubeTimeNow
+				return dz
+			}()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+			tc.dnsZone.CreationTimestamp = *kubeTimeNow
+			require.NoError(t, setFakeDNSZoneInKube(mocks, tc.dnsZone), "failed to create DNSZone into fake client")
+			for _, dz := range tc.existing {
+				require.NoError(t, setFakeDNSZoneInKube(mocks, dz), "failed to create DNSZone into fake client")
+			}
+			r := ReconcileDNSZone{
+				Client: mocks.fakeKubeClient,
+				logger: log.WithField("controller", ControllerName),
+				scheme: scheme.Scheme,
+			}
+
+			// Act
+			result, err := r.reconcileZoneConflict(tc.dnsZone, r.logger)
+
+			// Assert
+			require.NoError(t, err)
+			zone := &hivev1.DNSZone{}
+			err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: tc.dnsZone.Namespace, Name: tc.dnsZone.Name}, zone)
+			require.NoError(t, err)
+			condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneConflictCondition)
+			if tc.expectConflict {
+				if assert.NotNil(t, result, "expected DNSZone not to be synced") {
+					assert.Equal(t, zoneConflictCheckInterval, result.RequeueAfter)
+				}
+				if assert.NotNil(t, condition, "zone conflict condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status)
+					assert.Equal(t, zoneClaimedReason, condition.Reason)
+				}
+			} else {
+				assert.Nil(t, result, "expected DNSZone to be synced")
+				assert.Nil(t, condition, "zone conflict condition should not be set on dnszone")
+			}
+		})
+	}
+}
+
 func TestSetConditionsForErrorForAWS(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
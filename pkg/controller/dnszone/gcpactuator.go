@@ -158,6 +158,52 @@ func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, lo
 	return nil
 }
 
+// GetOwner implements the GetOwner call of the actuator interface
+func (a *GCPActuator) GetOwner() (string, error) {
+	if a.managedZone == nil {
+		return "", errors.New("managedZone is unpopulated")
+	}
+
+	name := controllerutils.Dotted(ownershipRecordName(a.dnsZone.Spec.Zone))
+	logger := a.logger.WithField("zoneName", a.managedZone.Name).WithField("record", name)
+	logger.Debug("Listing managed zone ownership record")
+	listOutput, err := a.gcpClient.ListResourceRecordSets(a.managedZone.Name, gcpclient.ListResourceRecordSetsOptions{
+		Name: name,
+		Type: "TXT",
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for managed zone")
+		return "", err
+	}
+	for _, recordSet := range listOutput.Rrsets {
+		if recordSet.Name == name && recordSet.Type == "TXT" && len(recordSet.Rrdatas) > 0 {
+			return ownerFromRecordValue(recordSet.Rrdatas[0]), nil
+		}
+	}
+	return "", nil
+}
+
+// SetOwner implements the SetOwner call of the actuator interface
+func (a *GCPActuator) SetOwner(owner string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	name := controllerutils.Dotted(ownershipRecordName(a.dnsZone.Spec.Zone))
+	logger := a.logger.WithField("zoneName", a.managedZone.Name).WithField("record", name)
+	logger.WithField("owner", owner).Info("Recording managed zone owner")
+	err := a.gcpClient.AddResourceRecordSet(a.managedZone.Name, &dns.ResourceRecordSet{
+		Name:    name,
+		Type:    "TXT",
+		Ttl:     ownershipRecordTTL,
+		Rrdatas: []string{ownershipRecordValue(owner)},
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error recording managed zone owner")
+	}
+	return err
+}
+
 // Exists implements the Exists call of the actuator interface
 func (a *GCPActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
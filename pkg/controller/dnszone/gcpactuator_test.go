@@ -1,6 +1,7 @@
 package dnszone
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -79,3 +80,21 @@ func mockDeleteGCPZone(expect *mock.MockClientMockRecorder) {
 	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
 	expect.DeleteManagedZone(gomock.Any()).Return(nil).Times(1)
 }
+
+func mockGCPGetOwner(expect *mock.MockClientMockRecorder, owner string) {
+	output := &dns.ResourceRecordSetsListResponse{}
+	if owner != "" {
+		output.Rrsets = []*dns.ResourceRecordSet{
+			{
+				Type:    "TXT",
+				Name:    "_hive-owner.blah.example.com.",
+				Rrdatas: []string{strconv.Quote(owner)},
+			},
+		}
+	}
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(output, nil).Times(1)
+}
+
+func mockGCPSetOwner(expect *mock.MockClientMockRecorder) {
+	expect.AddResourceRecordSet(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+}
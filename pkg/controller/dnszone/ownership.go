@@ -0,0 +1,37 @@
+package dnszone
+
+import (
+	"strconv"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// ownershipRecordLabel is the label of the TXT record that records the DNSZone which created a hosted zone. The
+	// controller only deletes hosted zones whose ownership record names the DNSZone being deleted.
+	ownershipRecordLabel = "_hive-owner"
+
+	// ownershipRecordTTL is the TTL in seconds of the ownership record.
+	ownershipRecordTTL = 300
+)
+
+// zoneOwner returns the owner recorded in the ownership record of the hosted zone of the DNSZone.
+func zoneOwner(dnsZone *hivev1.DNSZone) string {
+	return dnsZone.Namespace + "/" + dnsZone.Name
+}
+
+// ownershipRecordName returns the name of the ownership record of the zone.
+func ownershipRecordName(zone string) string {
+	return ownershipRecordLabel + "." + zone
+}
+
+// ownershipRecordValue returns the value of the ownership TXT record for the owner.
+func ownershipRecordValue(owner string) string {
+	return strconv.Quote(owner)
+}
+
+// ownerFromRecordValue returns the owner in the value of an ownership TXT record.
+func ownerFromRecordValue(value string) string {
+	return strings.Trim(value, `"`)
+}
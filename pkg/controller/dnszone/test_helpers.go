@@ -206,7 +206,7 @@ func setupDefaultMocks(t *testing.T) *mocks {
 }
 
 func fakeAWSClientBuilder(mockAWSClient *mockaws.MockClient) awsClientBuilderType {
-	return func(secret *corev1.Secret, region string) (awsclient.Client, error) {
+	return func(secret *corev1.Secret, options awsclient.Options) (awsclient.Client, error) {
 		return mockAWSClient, nil
 	}
 }
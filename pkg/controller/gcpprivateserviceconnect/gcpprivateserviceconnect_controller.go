@@ -0,0 +1,639 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	ControllerName = hivev1.GCPPrivateServiceConnectControllerName
+
+	// waitForResourceInterval is how long to wait before checking again on a GCP resource that is not ready.
+	waitForResourceInterval = 30 * time.Second
+
+	// resourceSuffix is appended to the infra ID of the cluster to name the GCP resources created for the cluster.
+	resourceSuffix = "-psc"
+
+	privateServiceConnectReadyReason  = "PrivateServiceConnectReady"
+	notConfiguredReason               = "NotConfigured"
+	noEndpointSubnetReason            = "NoEndpointSubnetInRegion"
+	waitingForInstallReason           = "WaitingForInstall"
+	waitingForLoadBalancerReason      = "WaitingForLoadBalancer"
+	waitingForServiceAttachmentReason = "WaitingForServiceAttachment"
+	waitingForEndpointReason          = "WaitingForEndpoint"
+	serviceAttachmentFailedReason     = "ServiceAttachmentFailed"
+	endpointFailedReason              = "EndpointFailed"
+	endpointRejectedReason            = "EndpointRejected"
+)
+
+// gcpClientBuilderType builds a GCP client from the credentials in the given secret, and returns the project of
+// those credentials.
+type gcpClientBuilderType func(c client.Client, secretName, namespace string) (gcpclient.Client, string, error)
+
+// Add creates a new GCPPrivateServiceConnect Controller and adds it to the Manager with default RBAC. The Manager
+// will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileGCPPrivateServiceConnect
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileGCPPrivateServiceConnect {
+	return &ReconcileGCPPrivateServiceConnect{
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:           log.WithField("controller", ControllerName),
+		gcpClientBuilder: newGCPClient,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileGCPPrivateServiceConnect, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controller.New("gcpprivateserviceconnect-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileGCPPrivateServiceConnect{}
+
+// ReconcileGCPPrivateServiceConnect reconciles the GCP Private Service Connect resources for a ClusterDeployment
+type ReconcileGCPPrivateServiceConnect struct {
+	client.Client
+	logger log.FieldLogger
+
+	// gcpClientBuilder is a function pointer to the function that builds the GCP clients for the project of the
+	// cluster and for the project in which the endpoints are created.
+	gcpClientBuilder gcpClientBuilderType
+}
+
+// Reconcile publishes the internal API load balancer of a cluster with GCP Private Service Connect enabled through
+// a service attachment, and creates an endpoint for that service attachment through which Hive reaches the
+// cluster's API.
+func (r *ReconcileGCPPrivateServiceConnect) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if !cd.DeletionTimestamp.IsZero() {
+		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect) {
+			return reconcile.Result{}, nil
+		}
+		return r.cleanupPrivateServiceConnect(cd, cdLog)
+	}
+
+	if !isPrivateServiceConnectEnabled(cd) {
+		cdLog.Debug("GCP Private Service Connect is not enabled for the cluster")
+		return reconcile.Result{}, nil
+	}
+
+	if !controllerutils.HasFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect) {
+		cdLog.Info("adding GCP Private Service Connect finalizer")
+		controllerutils.AddFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error adding GCP Private Service Connect finalizer")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	config, err := controllerutils.GetGCPPrivateServiceConnectConfig()
+	if err != nil {
+		cdLog.WithError(err).Error("could not get GCP Private Service Connect config")
+		return reconcile.Result{}, err
+	}
+	if config == nil {
+		cdLog.Warn("GCP Private Service Connect is not configured in HiveConfig")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, notConfiguredReason,
+			"GCP Private Service Connect is not configured in HiveConfig", cdLog)
+	}
+
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		cdLog.Debug("waiting for the infra ID of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForInstallReason,
+			"Waiting for the cluster install to start", cdLog)
+	}
+
+	return r.reconcilePrivateServiceConnect(cd, config, cdLog)
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) reconcilePrivateServiceConnect(cd *hivev1.ClusterDeployment, config *hivev1.GCPPrivateServiceConnectConfig, logger log.FieldLogger) (reconcile.Result, error) {
+	region := cd.Spec.Platform.GCP.Region
+	network, subnet := findEndpointSubnet(config, region)
+	if subnet == nil {
+		logger.WithField("region", region).Warn("no subnet in the endpoint VPC inventory is in the region of the cluster")
+		return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionTrue, noEndpointSubnetReason,
+			fmt.Sprintf("No subnet in the endpoint VPC inventory is in region %s", region), logger)
+	}
+	logger = logger.WithField("endpointSubnet", subnet.Subnet)
+
+	spokeClient, _, err := r.gcpClientBuilder(r.Client, cd.Spec.Platform.GCP.CredentialsSecretRef.Name, cd.Namespace)
+	if err != nil {
+		logger.WithError(err).Error("error creating GCP client for the cluster project")
+		return reconcile.Result{}, err
+	}
+	hubClient, hubProject, err := r.gcpClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace())
+	if err != nil {
+		logger.WithError(err).Error("error creating GCP client for the endpoint project")
+		return reconcile.Result{}, err
+	}
+
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	lb, err := spokeClient.GetForwardingRule(infraID+"-api-internal", region)
+	if err != nil {
+		if isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			logger.Info("waiting for the internal API load balancer to be created")
+			if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForLoadBalancerReason,
+				"Waiting for the internal API load balancer of the cluster to be created", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+		}
+		logger.WithError(err).Error("error looking up the internal API load balancer")
+		return reconcile.Result{}, err
+	}
+
+	status := privateServiceConnectStatus(cd)
+
+	serviceAttachment, err := r.ensureServiceAttachment(cd, status, lb, hubProject, spokeClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the service attachment")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, serviceAttachmentFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if serviceAttachment == nil {
+		logger.Info("waiting for the service attachment to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForServiceAttachmentReason,
+			"Waiting for the service attachment of the cluster to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	endpoint, address, err := r.ensureEndpoint(cd, status, serviceAttachment, hubProject, network, subnet, hubClient, logger)
+	if err != nil {
+		logger.WithError(err).Error("error reconciling the endpoint")
+		r.setNotReadyCondition(cd, corev1.ConditionTrue, endpointFailedReason, err.Error(), logger)
+		return reconcile.Result{}, err
+	}
+	if endpoint == nil {
+		logger.Info("waiting for the endpoint to be created")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForEndpointReason,
+			"Waiting for the endpoint to be created", logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	switch connectionStatus := endpointConnectionStatus(serviceAttachment, endpoint); connectionStatus {
+	case "ACCEPTED":
+	case "REJECTED", "CLOSED":
+		logger.WithField("connectionStatus", connectionStatus).Warn("the endpoint is not accepted by the service attachment")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, endpointRejectedReason,
+			fmt.Sprintf("The connection of endpoint %s to the service attachment is %s", endpoint.Name, connectionStatus), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	default:
+		logger.WithField("connectionStatus", connectionStatus).Info("waiting for the endpoint to be accepted by the service attachment")
+		if err := r.setNotReadyCondition(cd, corev1.ConditionTrue, waitingForEndpointReason,
+			fmt.Sprintf("Waiting for endpoint %s to be accepted by the service attachment", endpoint.Name), logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: waitForResourceInterval}, nil
+	}
+
+	if status.EndpointIP != address.Address {
+		logger.WithField("endpointIP", address.Address).Info("the cluster API is reachable through the endpoint")
+		status.EndpointIP = address.Address
+		if err := r.updateStatus(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, r.setNotReadyCondition(cd, corev1.ConditionFalse, privateServiceConnectReadyReason,
+		fmt.Sprintf("The cluster API is reachable through endpoint %s at %s", endpoint.Name, address.Address), logger)
+}
+
+// ensureServiceAttachment returns the service attachment for the internal API load balancer. The subnet of the
+// service attachment, the firewall rule allowing connections from that subnet and the service attachment are created
+// as needed, in which case nil is returned until they have been created. The project in which the endpoints are
+// created is allowed to connect to the service attachment.
+func (r *ReconcileGCPPrivateServiceConnect) ensureServiceAttachment(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1gcp.PrivateServiceConnectAccessStatus,
+	lb *compute.ForwardingRule,
+	hubProject string,
+	spokeClient gcpclient.Client,
+	logger log.FieldLogger,
+) (*gcpclient.ServiceAttachment, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(cd)
+	cidr := cd.Spec.Platform.GCP.PrivateServiceConnect.ServiceAttachmentSubnetCIDR
+	if cidr == "" {
+		return nil, errors.New("the CIDR of the service attachment subnet is not set")
+	}
+
+	subnet, err := spokeClient.GetSubnetwork(name, region)
+	if err != nil {
+		if !isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get service attachment subnet")
+		}
+		logger.WithField("cidr", cidr).Info("creating service attachment subnet")
+		if err := spokeClient.CreateSubnetwork(region, &compute.Subnetwork{
+			Name:        name,
+			Description: description(cd),
+			Network:     lb.Network,
+			IpCidrRange: cidr,
+			Purpose:     "PRIVATE_SERVICE_CONNECT",
+		}); err != nil && !isGoogleAPIErrorCode(err, http.StatusConflict) {
+			return nil, errors.Wrap(err, "could not create service attachment subnet")
+		}
+		status.ServiceAttachmentSubnet = name
+		return nil, r.updateStatus(cd, logger)
+	}
+
+	if _, err := spokeClient.GetFirewall(name); err != nil {
+		if !isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get service attachment firewall rule")
+		}
+		logger.Info("creating service attachment firewall rule")
+		if err := spokeClient.CreateFirewall(&compute.Firewall{
+			Name:         name,
+			Description:  description(cd),
+			Network:      lb.Network,
+			Direction:    "INGRESS",
+			SourceRanges: []string{subnet.IpCidrRange},
+			TargetTags:   []string{cd.Spec.ClusterMetadata.InfraID + "-master"},
+			Allowed: []*compute.FirewallAllowed{{
+				IPProtocol: "tcp",
+				Ports:      []string{"6443"},
+			}},
+		}); err != nil && !isGoogleAPIErrorCode(err, http.StatusConflict) {
+			return nil, errors.Wrap(err, "could not create service attachment firewall rule")
+		}
+		status.ServiceAttachmentFirewall = name
+		if err := r.updateStatus(cd, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	serviceAttachment, err := spokeClient.GetServiceAttachment(name, region)
+	if err != nil {
+		if !isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			return nil, errors.Wrap(err, "could not get service attachment")
+		}
+		logger.WithField("consumerProject", hubProject).Info("creating service attachment")
+		if err := spokeClient.CreateServiceAttachment(region, &gcpclient.ServiceAttachment{
+			Name:                 name,
+			TargetService:        lb.SelfLink,
+			ConnectionPreference: "ACCEPT_MANUAL",
+			NatSubnets:           []string{subnet.SelfLink},
+			ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+				ProjectIDOrNum:  hubProject,
+				ConnectionLimit: 1,
+			}},
+		}); err != nil && !isGoogleAPIErrorCode(err, http.StatusConflict) {
+			return nil, errors.Wrap(err, "could not create service attachment")
+		}
+		status.ServiceAttachment = name
+		return nil, r.updateStatus(cd, logger)
+	}
+	return serviceAttachment, nil
+}
+
+// ensureEndpoint returns the endpoint for the service attachment and its address. The address and the endpoint are
+// created as needed, in which case nil is returned until they have been created.
+func (r *ReconcileGCPPrivateServiceConnect) ensureEndpoint(
+	cd *hivev1.ClusterDeployment,
+	status *hivev1gcp.PrivateServiceConnectAccessStatus,
+	serviceAttachment *gcpclient.ServiceAttachment,
+	hubProject, network string,
+	subnet *hivev1.GCPPrivateServiceConnectSubnet,
+	hubClient gcpclient.Client,
+	logger log.FieldLogger,
+) (*compute.ForwardingRule, *compute.Address, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(cd)
+
+	address, err := hubClient.GetAddress(name, region)
+	if err != nil {
+		if !isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			return nil, nil, errors.Wrap(err, "could not get endpoint address")
+		}
+		logger.Info("reserving endpoint address")
+		if err := hubClient.CreateAddress(region, &compute.Address{
+			Name:        name,
+			Description: description(cd),
+			AddressType: "INTERNAL",
+			Subnetwork:  fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", hubProject, subnet.Region, subnet.Subnet),
+		}); err != nil && !isGoogleAPIErrorCode(err, http.StatusConflict) {
+			return nil, nil, errors.Wrap(err, "could not reserve endpoint address")
+		}
+		status.EndpointAddress = name
+		return nil, nil, r.updateStatus(cd, logger)
+	}
+	if address.Address == "" {
+		return nil, nil, nil
+	}
+
+	endpoint, err := hubClient.GetForwardingRule(name, region)
+	if err != nil {
+		if !isGoogleAPIErrorCode(err, http.StatusNotFound) {
+			return nil, nil, errors.Wrap(err, "could not get endpoint")
+		}
+		logger.WithField("address", address.Address).Info("creating endpoint")
+		if err := hubClient.CreateForwardingRule(region, &compute.ForwardingRule{
+			Name:        name,
+			Description: description(cd),
+			Network:     fmt.Sprintf("projects/%s/global/networks/%s", hubProject, network),
+			IPAddress:   address.SelfLink,
+			Target:      serviceAttachment.SelfLink,
+		}); err != nil && !isGoogleAPIErrorCode(err, http.StatusConflict) {
+			return nil, nil, errors.Wrap(err, "could not create endpoint")
+		}
+		status.Endpoint = name
+		return nil, nil, r.updateStatus(cd, logger)
+	}
+	return endpoint, address, nil
+}
+
+// cleanupPrivateServiceConnect deletes the GCP resources created for the cluster and removes the GCP Private Service
+// Connect finalizer. GCP deletes resources asynchronously, so each resource is deleted in turn, requeueing until the
+// previous one is gone.
+func (r *ReconcileGCPPrivateServiceConnect) cleanupPrivateServiceConnect(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	var status *hivev1gcp.PrivateServiceConnectAccessStatus
+	if cd.Status.Platform != nil && cd.Status.Platform.GCP != nil {
+		status = cd.Status.Platform.GCP.PrivateServiceConnect
+	}
+	region := cd.Spec.Platform.GCP.Region
+
+	if status != nil && (status.Endpoint != "" || status.EndpointAddress != "") {
+		config, err := controllerutils.GetGCPPrivateServiceConnectConfig()
+		if err != nil {
+			logger.WithError(err).Error("could not get GCP Private Service Connect config")
+			return reconcile.Result{}, err
+		}
+		if config == nil {
+			logger.Warn("GCP Private Service Connect is no longer configured in HiveConfig, leaving the endpoint in place")
+		} else {
+			hubClient, _, err := r.gcpClientBuilder(r.Client, config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace())
+			if err != nil {
+				logger.WithError(err).Error("error creating GCP client for the endpoint project")
+				return reconcile.Result{}, err
+			}
+			status.EndpointIP = ""
+			steps := []deleteStep{
+				{kind: "endpoint", name: &status.Endpoint, delete: hubClient.DeleteForwardingRule},
+				{kind: "endpoint address", name: &status.EndpointAddress, delete: hubClient.DeleteAddress},
+			}
+			if result, done, err := r.runDeleteSteps(cd, steps, region, logger); !done || err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if status != nil && (status.ServiceAttachment != "" || status.ServiceAttachmentFirewall != "" || status.ServiceAttachmentSubnet != "") {
+		spokeClient, _, err := r.gcpClientBuilder(r.Client, cd.Spec.Platform.GCP.CredentialsSecretRef.Name, cd.Namespace)
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Warn("GCP credentials for the cluster not found, leaving the service attachment in place")
+		case err != nil:
+			logger.WithError(err).Error("error creating GCP client for the cluster project")
+			return reconcile.Result{}, err
+		default:
+			steps := []deleteStep{
+				{kind: "service attachment", name: &status.ServiceAttachment, delete: spokeClient.DeleteServiceAttachment},
+				{kind: "service attachment firewall rule", name: &status.ServiceAttachmentFirewall, delete: func(name, _ string) error {
+					return spokeClient.DeleteFirewall(name)
+				}},
+				{kind: "service attachment subnet", name: &status.ServiceAttachmentSubnet, delete: spokeClient.DeleteSubnetwork},
+			}
+			if result, done, err := r.runDeleteSteps(cd, steps, region, logger); !done || err != nil {
+				return result, err
+			}
+		}
+	}
+
+	logger.Info("removing GCP Private Service Connect finalizer")
+	controllerutils.DeleteFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect)
+	if err := r.Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error removing GCP Private Service Connect finalizer")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// deleteStep deletes a GCP resource recorded in the status of the cluster deployment.
+type deleteStep struct {
+	kind   string
+	name   *string
+	delete func(name, region string) error
+}
+
+// runDeleteSteps deletes the resources of the steps in order. A resource is removed from the status once it is gone.
+// It returns false if a resource is still being deleted.
+func (r *ReconcileGCPPrivateServiceConnect) runDeleteSteps(cd *hivev1.ClusterDeployment, steps []deleteStep, region string, logger log.FieldLogger) (reconcile.Result, bool, error) {
+	for _, step := range steps {
+		if *step.name == "" {
+			continue
+		}
+		logger := logger.WithField("resource", *step.name)
+		err := step.delete(*step.name, region)
+		switch {
+		case isGoogleAPIErrorCode(err, http.StatusNotFound):
+			logger.Infof("%s deleted", step.kind)
+			*step.name = ""
+			if err := r.updateStatus(cd, logger); err != nil {
+				return reconcile.Result{}, false, err
+			}
+		case err == nil:
+			logger.Infof("deleting %s", step.kind)
+			return reconcile.Result{RequeueAfter: waitForResourceInterval}, false, nil
+		case isResourceInUse(err):
+			logger.Infof("waiting for the resources using the %s to be deleted", step.kind)
+			return reconcile.Result{RequeueAfter: waitForResourceInterval}, false, nil
+		default:
+			logger.WithError(err).Errorf("error deleting %s", step.kind)
+			return reconcile.Result{}, false, errors.Wrapf(err, "could not delete %s", step.kind)
+		}
+	}
+	return reconcile.Result{}, true, nil
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) setNotReadyCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.GCPPrivateServiceConnectNotReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	return r.updateStatus(cd, logger)
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) updateStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment status")
+		return err
+	}
+	return nil
+}
+
+func newGCPClient(c client.Client, secretName, namespace string) (gcpclient.Client, string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, "", err
+	}
+	projectID, err := gcpclient.ProjectIDFromSecret(secret)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not get the project of the GCP credentials")
+	}
+	gcpClient, err := gcpclient.NewClientFromSecret(secret)
+	if err != nil {
+		return nil, "", err
+	}
+	return gcpClient, projectID, nil
+}
+
+func isPrivateServiceConnectEnabled(cd *hivev1.ClusterDeployment) bool {
+	gcp := cd.Spec.Platform.GCP
+	return gcp != nil && gcp.PrivateServiceConnect != nil && gcp.PrivateServiceConnect.Enabled
+}
+
+// privateServiceConnectStatus returns the GCP Private Service Connect status of the cluster deployment, adding it if
+// needed.
+func privateServiceConnectStatus(cd *hivev1.ClusterDeployment) *hivev1gcp.PrivateServiceConnectAccessStatus {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP == nil {
+		cd.Status.Platform.GCP = &hivev1gcp.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP.PrivateServiceConnect == nil {
+		cd.Status.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccessStatus{}
+	}
+	return cd.Status.Platform.GCP.PrivateServiceConnect
+}
+
+// findEndpointSubnet returns the first subnet of the endpoint VPC inventory in the region, along with its network.
+func findEndpointSubnet(config *hivev1.GCPPrivateServiceConnectConfig, region string) (string, *hivev1.GCPPrivateServiceConnectSubnet) {
+	for _, inventory := range config.EndpointVPCInventory {
+		for i, subnet := range inventory.Subnets {
+			if subnet.Region == region {
+				return inventory.Network, &inventory.Subnets[i]
+			}
+		}
+	}
+	return "", nil
+}
+
+// endpointConnectionStatus returns the status of the connection of the endpoint to the service attachment, or an
+// empty string if the endpoint is not connected yet.
+func endpointConnectionStatus(serviceAttachment *gcpclient.ServiceAttachment, endpoint *compute.ForwardingRule) string {
+	for _, connected := range serviceAttachment.ConnectedEndpoints {
+		if resourcePath(connected.Endpoint) == resourcePath(endpoint.SelfLink) {
+			return connected.Status
+		}
+	}
+	return ""
+}
+
+// resourcePath returns the path of a GCP resource URL starting at the project, so that URLs of the same resource
+// from different API versions or hosts compare equal.
+func resourcePath(url string) string {
+	if i := strings.Index(url, "projects/"); i >= 0 {
+		return url[i:]
+	}
+	return url
+}
+
+func resourceName(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.ClusterMetadata.InfraID + resourceSuffix
+}
+
+func description(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("Private Service Connect access for cluster %s/%s", cd.Namespace, cd.Name)
+}
+
+func isGoogleAPIErrorCode(err error, code int) bool {
+	if apiErr, ok := err.(*googleapi.Error); ok {
+		return apiErr.Code == code
+	}
+	return false
+}
+
+// isResourceInUse returns true if the error is returned because the resource being deleted is still used by another
+// resource, or is still being created or deleted.
+func isResourceInUse(err error) bool {
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok || apiErr.Code != http.StatusBadRequest {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "resourceInUseByAnotherResource" || item.Reason == "resourceNotReady" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,532 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+const (
+	testNamespace       = "test-namespace"
+	testName            = "test-cluster"
+	testInfraID         = "mycluster-abcde"
+	testRegion          = "us-central1"
+	testCredentialsName = "test-creds"
+	testHubCredentials  = "hub-creds"
+	testSpokeProject    = "spoke-project"
+	testHubProject      = "hub-project"
+	testHubNetwork      = "hub-network"
+	testHubSubnet       = "hub-subnet"
+	testSubnetCIDR      = "192.168.0.0/29"
+	testEndpointIP      = "10.0.0.5"
+	testResourceName    = testInfraID + resourceSuffix
+	testLBSelfLink      = "https://www.googleapis.com/compute/v1/projects/spoke-project/regions/us-central1/forwardingRules/mycluster-abcde-api-internal"
+	testSpokeNetwork    = "https://www.googleapis.com/compute/v1/projects/spoke-project/global/networks/mycluster-abcde-network"
+	testSubnetSelfLink  = "https://www.googleapis.com/compute/v1/projects/spoke-project/regions/us-central1/subnetworks/mycluster-abcde-psc"
+	testSASelfLink      = "https://compute.googleapis.com/compute/v1/projects/spoke-project/regions/us-central1/serviceAttachments/mycluster-abcde-psc"
+	testAddressSelfLink = "https://www.googleapis.com/compute/v1/projects/hub-project/regions/us-central1/addresses/mycluster-abcde-psc"
+	testEndpointLink    = "https://www.googleapis.com/compute/v1/projects/hub-project/regions/us-central1/forwardingRules/mycluster-abcde-psc"
+)
+
+func TestReconcileGCPPrivateServiceConnect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		config             *hivev1.GCPPrivateServiceConnectConfig
+		setupSpokeMock     func(*mockgcp.MockClient)
+		setupHubMock       func(*mockgcp.MockClient)
+		expectFinalizer    bool
+		expectNoCondition  bool
+		expectedReason     string
+		expectedStatus     corev1.ConditionStatus
+		expectRequeueAfter bool
+		expectedPSCStatus  *hivev1gcp.PrivateServiceConnectAccessStatus
+	}{
+		{
+			name:              "private service connect not enabled",
+			cd:                testClusterDeployment(withoutPrivateServiceConnect),
+			config:            testConfig(),
+			expectNoCondition: true,
+		},
+		{
+			name:              "add finalizer",
+			cd:                testClusterDeployment(),
+			config:            testConfig(),
+			expectFinalizer:   true,
+			expectNoCondition: true,
+		},
+		{
+			name:            "not configured",
+			cd:              testClusterDeployment(withFinalizer),
+			expectFinalizer: true,
+			expectedReason:  notConfiguredReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:            "waiting for infra ID",
+			cd:              testClusterDeployment(withFinalizer, withoutInfraID),
+			config:          testConfig(),
+			expectFinalizer: true,
+			expectedReason:  waitingForInstallReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name: "no endpoint subnet in region",
+			cd:   testClusterDeployment(withFinalizer),
+			config: func() *hivev1.GCPPrivateServiceConnectConfig {
+				c := testConfig()
+				c.EndpointVPCInventory[0].Subnets[0].Region = "us-east1"
+				return c
+			}(),
+			expectFinalizer: true,
+			expectedReason:  noEndpointSubnetReason,
+			expectedStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:   "waiting for load balancer",
+			cd:     testClusterDeployment(withFinalizer),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().GetForwardingRule(testInfraID+"-api-internal", testRegion).Return(nil, notFound())
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForLoadBalancerReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "create service attachment subnet",
+			cd:     testClusterDeployment(withFinalizer),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockLoadBalancer(m)
+				m.EXPECT().GetSubnetwork(testResourceName, testRegion).Return(nil, notFound())
+				m.EXPECT().CreateSubnetwork(testRegion, gomock.Any()).DoAndReturn(func(_ string, subnet *compute.Subnetwork) error {
+					assert.Equal(t, testSubnetCIDR, subnet.IpCidrRange, "unexpected subnet CIDR")
+					assert.Equal(t, testSpokeNetwork, subnet.Network, "unexpected subnet network")
+					assert.Equal(t, "PRIVATE_SERVICE_CONNECT", subnet.Purpose, "unexpected subnet purpose")
+					return nil
+				})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForServiceAttachmentReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPSCStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+				ServiceAttachmentSubnet: testResourceName,
+			},
+		},
+		{
+			name:   "create firewall rule and service attachment",
+			cd:     testClusterDeployment(withFinalizer, withStatus(&hivev1gcp.PrivateServiceConnectAccessStatus{ServiceAttachmentSubnet: testResourceName})),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockLoadBalancer(m)
+				mockSubnet(m)
+				m.EXPECT().GetFirewall(testResourceName).Return(nil, notFound())
+				m.EXPECT().CreateFirewall(gomock.Any()).DoAndReturn(func(firewall *compute.Firewall) error {
+					assert.Equal(t, []string{testSubnetCIDR}, firewall.SourceRanges, "unexpected firewall source ranges")
+					assert.Equal(t, []string{testInfraID + "-master"}, firewall.TargetTags, "unexpected firewall target tags")
+					return nil
+				})
+				m.EXPECT().GetServiceAttachment(testResourceName, testRegion).Return(nil, notFound())
+				m.EXPECT().CreateServiceAttachment(testRegion, gomock.Any()).DoAndReturn(func(_ string, sa *gcpclient.ServiceAttachment) error {
+					assert.Equal(t, testLBSelfLink, sa.TargetService, "unexpected target service")
+					assert.Equal(t, []string{testSubnetSelfLink}, sa.NatSubnets, "unexpected NAT subnets")
+					if assert.Len(t, sa.ConsumerAcceptLists, 1, "unexpected consumer accept list") {
+						assert.Equal(t, testHubProject, sa.ConsumerAcceptLists[0].ProjectIDOrNum, "unexpected consumer project")
+					}
+					return nil
+				})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForServiceAttachmentReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPSCStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+				ServiceAttachmentSubnet:   testResourceName,
+				ServiceAttachmentFirewall: testResourceName,
+				ServiceAttachment:         testResourceName,
+			},
+		},
+		{
+			name:   "create endpoint address",
+			cd:     testClusterDeployment(withFinalizer, withStatus(spokeStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockServiceAttachment(m, "")
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().GetAddress(testResourceName, testRegion).Return(nil, notFound())
+				m.EXPECT().CreateAddress(testRegion, gomock.Any()).DoAndReturn(func(_ string, address *compute.Address) error {
+					assert.Equal(t, "INTERNAL", address.AddressType, "unexpected address type")
+					assert.Equal(t, "projects/hub-project/regions/us-central1/subnetworks/hub-subnet", address.Subnetwork, "unexpected address subnet")
+					return nil
+				})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPSCStatus: func() *hivev1gcp.PrivateServiceConnectAccessStatus {
+				s := spokeStatus()
+				s.EndpointAddress = testResourceName
+				return s
+			}(),
+		},
+		{
+			name:   "create endpoint",
+			cd:     testClusterDeployment(withFinalizer, withStatus(spokeStatus())),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockServiceAttachment(m, "")
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				mockAddress(m)
+				m.EXPECT().GetForwardingRule(testResourceName, testRegion).Return(nil, notFound())
+				m.EXPECT().CreateForwardingRule(testRegion, gomock.Any()).DoAndReturn(func(_ string, rule *compute.ForwardingRule) error {
+					assert.Equal(t, testSASelfLink, rule.Target, "unexpected endpoint target")
+					assert.Equal(t, testAddressSelfLink, rule.IPAddress, "unexpected endpoint address")
+					assert.Equal(t, "projects/hub-project/global/networks/hub-network", rule.Network, "unexpected endpoint network")
+					return nil
+				})
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPSCStatus: func() *hivev1gcp.PrivateServiceConnectAccessStatus {
+				s := spokeStatus()
+				s.Endpoint = testResourceName
+				return s
+			}(),
+		},
+		{
+			name:   "waiting for endpoint to be accepted",
+			cd:     testClusterDeployment(withFinalizer, withStatus(fullStatus(""))),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockServiceAttachment(m, "PENDING")
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				mockAddress(m)
+				mockEndpoint(m)
+			},
+			expectFinalizer:    true,
+			expectedReason:     waitingForEndpointReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectedPSCStatus:  fullStatus(""),
+		},
+		{
+			name:   "endpoint rejected",
+			cd:     testClusterDeployment(withFinalizer, withStatus(fullStatus(""))),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockServiceAttachment(m, "REJECTED")
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				mockAddress(m)
+				mockEndpoint(m)
+			},
+			expectFinalizer:    true,
+			expectedReason:     endpointRejectedReason,
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "endpoint accepted",
+			cd:     testClusterDeployment(withFinalizer, withNotReadyCondition(waitingForEndpointReason), withStatus(fullStatus(""))),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				mockServiceAttachment(m, "ACCEPTED")
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				mockAddress(m)
+				mockEndpoint(m)
+			},
+			expectFinalizer:   true,
+			expectedReason:    privateServiceConnectReadyReason,
+			expectedStatus:    corev1.ConditionFalse,
+			expectedPSCStatus: fullStatus(testEndpointIP),
+		},
+		{
+			name:   "cleanup deletes endpoint",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus(testEndpointIP))),
+			config: testConfig(),
+			setupHubMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().DeleteForwardingRule(testResourceName, testRegion).Return(nil)
+			},
+			expectFinalizer:    true,
+			expectNoCondition:  true,
+			expectRequeueAfter: true,
+		},
+		{
+			name:   "cleanup waits for address to be released",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus(testEndpointIP))),
+			config: testConfig(),
+			setupHubMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().DeleteForwardingRule(testResourceName, testRegion).Return(notFound())
+				m.EXPECT().DeleteAddress(testResourceName, testRegion).Return(&googleapi.Error{
+					Code:   http.StatusBadRequest,
+					Errors: []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}},
+				})
+			},
+			expectFinalizer:    true,
+			expectNoCondition:  true,
+			expectRequeueAfter: true,
+			expectedPSCStatus: func() *hivev1gcp.PrivateServiceConnectAccessStatus {
+				s := fullStatus("")
+				s.Endpoint = ""
+				return s
+			}(),
+		},
+		{
+			name:   "cleanup done",
+			cd:     testClusterDeployment(withFinalizer, withDeletion, withStatus(fullStatus(testEndpointIP))),
+			config: testConfig(),
+			setupSpokeMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().DeleteServiceAttachment(testResourceName, testRegion).Return(notFound())
+				m.EXPECT().DeleteFirewall(testResourceName).Return(notFound())
+				m.EXPECT().DeleteSubnetwork(testResourceName, testRegion).Return(notFound())
+			},
+			setupHubMock: func(m *mockgcp.MockClient) {
+				m.EXPECT().DeleteForwardingRule(testResourceName, testRegion).Return(notFound())
+				m.EXPECT().DeleteAddress(testResourceName, testRegion).Return(notFound())
+			},
+			expectNoCondition: true,
+			expectedPSCStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.config != nil {
+				b, err := json.Marshal(test.config)
+				require.NoError(t, err, "unexpected error marshaling config")
+				require.NoError(t, os.Setenv(constants.GCPPrivateServiceConnectEnvVar, string(b)))
+			} else {
+				require.NoError(t, os.Unsetenv(constants.GCPPrivateServiceConnectEnvVar))
+			}
+			defer os.Unsetenv(constants.GCPPrivateServiceConnectEnvVar)
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.cd)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			spokeClient := mockgcp.NewMockClient(mockCtrl)
+			if test.setupSpokeMock != nil {
+				test.setupSpokeMock(spokeClient)
+			}
+			hubClient := mockgcp.NewMockClient(mockCtrl)
+			if test.setupHubMock != nil {
+				test.setupHubMock(hubClient)
+			}
+
+			r := &ReconcileGCPPrivateServiceConnect{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+				gcpClientBuilder: func(_ client.Client, secretName, _ string) (gcpclient.Client, string, error) {
+					if secretName == testHubCredentials {
+						return hubClient, testHubProject, nil
+					}
+					return spokeClient, testSpokeProject, nil
+				},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, test.expectRequeueAfter, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+
+			assert.Equal(t, test.expectFinalizer, controllerutils.HasFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect), "unexpected finalizer")
+
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.GCPPrivateServiceConnectNotReadyCondition)
+			if test.expectNoCondition {
+				assert.Nil(t, cond, "unexpected GCPPrivateServiceConnectNotReady condition")
+			} else if assert.NotNil(t, cond, "expected GCPPrivateServiceConnectNotReady condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+
+			if test.expectedPSCStatus != nil {
+				if assert.NotNil(t, cd.Status.Platform, "expected platform status") &&
+					assert.NotNil(t, cd.Status.Platform.GCP, "expected GCP platform status") {
+					assert.Equal(t, test.expectedPSCStatus, cd.Status.Platform.GCP.PrivateServiceConnect, "unexpected private service connect status")
+				}
+			}
+		})
+	}
+}
+
+type cdOption func(*hivev1.ClusterDeployment)
+
+func testClusterDeployment(opts ...cdOption) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "mycluster",
+			BaseDomain:  "example.com",
+			Platform: hivev1.Platform{
+				GCP: &hivev1gcp.Platform{
+					Region:               testRegion,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: testCredentialsName},
+					PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{
+						Enabled:                     true,
+						ServiceAttachmentSubnetCIDR: testSubnetCIDR,
+					},
+				},
+			},
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID: testInfraID,
+			},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func withoutPrivateServiceConnect(cd *hivev1.ClusterDeployment) {
+	cd.Spec.Platform.GCP.PrivateServiceConnect = nil
+}
+
+func withFinalizer(cd *hivev1.ClusterDeployment) {
+	controllerutils.AddFinalizer(cd, hivev1.FinalizerGCPPrivateServiceConnect)
+}
+
+func withoutInfraID(cd *hivev1.ClusterDeployment) {
+	cd.Spec.ClusterMetadata = nil
+}
+
+func withDeletion(cd *hivev1.ClusterDeployment) {
+	now := metav1.Now()
+	cd.DeletionTimestamp = &now
+}
+
+func withNotReadyCondition(reason string) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.GCPPrivateServiceConnectNotReadyCondition,
+			Status: corev1.ConditionTrue,
+			Reason: reason,
+		})
+	}
+}
+
+func withStatus(status *hivev1gcp.PrivateServiceConnectAccessStatus) cdOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		*privateServiceConnectStatus(cd) = *status
+	}
+}
+
+func spokeStatus() *hivev1gcp.PrivateServiceConnectAccessStatus {
+	return &hivev1gcp.PrivateServiceConnectAccessStatus{
+		ServiceAttachmentSubnet:   testResourceName,
+		ServiceAttachmentFirewall: testResourceName,
+		ServiceAttachment:         testResourceName,
+	}
+}
+
+func fullStatus(endpointIP string) *hivev1gcp.PrivateServiceConnectAccessStatus {
+	s := spokeStatus()
+	s.EndpointAddress = testResourceName
+	s.Endpoint = testResourceName
+	s.EndpointIP = endpointIP
+	return s
+}
+
+func testConfig() *hivev1.GCPPrivateServiceConnectConfig {
+	return &hivev1.GCPPrivateServiceConnectConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: testHubCredentials},
+		EndpointVPCInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+			Network: testHubNetwork,
+			Subnets: []hivev1.GCPPrivateServiceConnectSubnet{{Subnet: testHubSubnet, Region: testRegion}},
+		}},
+	}
+}
+
+func notFound() error {
+	return &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func mockLoadBalancer(m *mockgcp.MockClient) {
+	m.EXPECT().GetForwardingRule(testInfraID+"-api-internal", testRegion).Return(&compute.ForwardingRule{
+		Name:     testInfraID + "-api-internal",
+		Network:  testSpokeNetwork,
+		SelfLink: testLBSelfLink,
+	}, nil)
+}
+
+func mockSubnet(m *mockgcp.MockClient) {
+	m.EXPECT().GetSubnetwork(testResourceName, testRegion).Return(&compute.Subnetwork{
+		Name:        testResourceName,
+		IpCidrRange: testSubnetCIDR,
+		SelfLink:    testSubnetSelfLink,
+	}, nil)
+}
+
+func mockServiceAttachment(m *mockgcp.MockClient, endpointStatus string) {
+	mockLoadBalancer(m)
+	mockSubnet(m)
+	m.EXPECT().GetFirewall(testResourceName).Return(&compute.Firewall{Name: testResourceName}, nil)
+	sa := &gcpclient.ServiceAttachment{Name: testResourceName, SelfLink: testSASelfLink}
+	if endpointStatus != "" {
+		sa.ConnectedEndpoints = []*gcpclient.ServiceAttachmentConnectedEndpoint{{
+			// The service attachment reports the endpoint with a different host than the compute API.
+			Endpoint: "https://compute.googleapis.com/compute/v1/projects/hub-project/regions/us-central1/forwardingRules/mycluster-abcde-psc",
+			Status:   endpointStatus,
+		}}
+	}
+	m.EXPECT().GetServiceAttachment(testResourceName, testRegion).Return(sa, nil)
+}
+
+func mockAddress(m *mockgcp.MockClient) {
+	m.EXPECT().GetAddress(testResourceName, testRegion).Return(&compute.Address{
+		Name:     testResourceName,
+		Address:  testEndpointIP,
+		SelfLink: testAddressSelfLink,
+	}, nil)
+}
+
+func mockEndpoint(m *mockgcp.MockClient) {
+	m.EXPECT().GetForwardingRule(testResourceName, testRegion).Return(&compute.ForwardingRule{
+		Name:     testResourceName,
+		SelfLink: testEndpointLink,
+	}, nil)
+}
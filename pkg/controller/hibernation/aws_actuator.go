@@ -41,12 +41,21 @@ func (a *awsActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 
 // StopMachines will stop machines belonging to the given ClusterDeployment
 func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+	return a.stopMachines(cd, c, nil, logger)
+}
+
+// StopMachinesByName will stop the machines with the given names belonging to the given ClusterDeployment
+func (a *awsActuator) StopMachinesByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) error {
+	return a.stopMachines(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *awsActuator) stopMachines(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, runningOrPendingStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, runningOrPendingStates, names, logger)
 	if err != nil {
 		return err
 	}
@@ -66,12 +75,21 @@ func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client
 
 // StartMachines will select machines belonging to the given ClusterDeployment
 func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+	return a.startMachines(cd, c, nil, logger)
+}
+
+// StartMachinesByName will start the machines with the given names belonging to the given ClusterDeployment
+func (a *awsActuator) StartMachinesByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) error {
+	return a.startMachines(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *awsActuator) startMachines(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, stoppedOrStoppingStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, stoppedOrStoppingStates, names, logger)
 	if err != nil {
 		return err
 	}
@@ -98,7 +116,7 @@ func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Cli
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notRunningStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notRunningStates, nil, logger)
 	if err != nil {
 		return false, err
 	}
@@ -108,13 +126,23 @@ func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Cli
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
 func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, c, nil, logger)
+}
+
+// MachinesStoppedByName will return true if the machines with the given names associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *awsActuator) MachinesStoppedByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *awsActuator) machinesStopped(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "aws")
 	logger.Infof("checking whether machines are stopped")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notStoppedStates, logger)
+	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notStoppedStates, names, logger)
 	if err != nil {
 		return false, err
 	}
@@ -122,14 +150,20 @@ func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Cli
 }
 
 func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, cd.Spec.Platform.AWS.Region)
+	awsClient, err := awsclient.NewClientWithOptions(c, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Namespace, awsclient.Options{
+		Region:           cd.Spec.Platform.AWS.Region,
+		ServiceEndpoints: cd.Spec.Platform.AWS.ServiceEndpoints,
+	})
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
 	return awsClient, err
 }
 
-func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, states sets.String, logger log.FieldLogger) ([]*string, error) {
+// getClusterInstanceIDs returns the IDs of the instances of the cluster in one of the given states. If names is not
+// nil, only the instances of the machines with the given names are returned. The name of the machine of an instance
+// is in its Name tag.
+func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, states, names sets.String, logger log.FieldLogger) ([]*string, error) {
 	infraID := cd.Spec.ClusterMetadata.InfraID
 	logger = logger.WithField("infraID", infraID)
 	logger.Debug("listing cluster instances")
@@ -148,7 +182,7 @@ func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, sta
 	result := []*string{}
 	for _, r := range out.Reservations {
 		for _, i := range r.Instances {
-			if states.Has(aws.StringValue(i.State.Name)) {
+			if states.Has(aws.StringValue(i.State.Name)) && machineSelected(names, awsInstanceName(i)) {
 				result = append(result, i.InstanceId)
 			}
 		}
@@ -156,3 +190,12 @@ func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, sta
 	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing instances")
 	return result, nil
 }
+
+func awsInstanceName(instance *ec2.Instance) string {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == "Name" {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
@@ -189,6 +189,28 @@ func TestMachinesStoppedAndRunning(t *testing.T) {
 	}
 }
 
+func TestMachinesByName(t *testing.T) {
+	names := []string{"machine-running-1", "machine-stopped-0"}
+
+	ctrl := gomock.NewController(t)
+	awsClient := mockawsclient.NewMockClient(ctrl)
+	setupClientInstances(awsClient, map[string]int{"running": 3, "stopped": 1})
+	awsClient.EXPECT().StartInstances(gomock.Any()).Do(
+		func(input *ec2.StartInstancesInput) {
+			assert.Equal(t, []string{"stopped-0"}, aws.StringValueSlice(input.InstanceIds))
+		}).Return(nil, nil)
+	actuator := testAWSActuator(awsClient)
+	require.Nil(t, actuator.StartMachinesByName(testClusterDeployment(), nil, names, log.New()))
+
+	ctrl = gomock.NewController(t)
+	awsClient = mockawsclient.NewMockClient(ctrl)
+	setupClientInstances(awsClient, map[string]int{"running": 3, "stopped": 1})
+	actuator = testAWSActuator(awsClient)
+	stopped, err := actuator.MachinesStoppedByName(testClusterDeployment(), nil, names, log.New())
+	require.Nil(t, err)
+	assert.False(t, stopped)
+}
+
 func matchInstanceIDs(t *testing.T, actual []*string, states map[string]int) {
 	expected := sets.NewString()
 	for state, count := range states {
@@ -228,6 +250,10 @@ func setupClientInstances(awsClient *mockawsclient.MockClient, states map[string
 				State: &ec2.InstanceState{
 					Name: aws.String(state),
 				},
+				Tags: []*ec2.Tag{{
+					Key:   aws.String("Name"),
+					Value: aws.String(fmt.Sprintf("machine-%s-%d", state, i)),
+				}},
 			})
 		}
 	}
@@ -53,12 +53,21 @@ func (a *azureActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 
 // StopMachines will stop machines belonging to the given ClusterDeployment
 func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+	return a.stopMachines(cd, c, nil, logger)
+}
+
+// StopMachinesByName will stop the machines with the given names belonging to the given ClusterDeployment
+func (a *azureActuator) StopMachinesByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) error {
+	return a.stopMachines(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *azureActuator) stopMachines(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureRunningOrPendingStates, logger)
+	machines, err := listAzureMachines(cd, azureClient, azureRunningOrPendingStates, names, logger)
 	if err != nil {
 		return err
 	}
@@ -80,12 +89,21 @@ func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Clie
 
 // StartMachines will select machines belonging to the given ClusterDeployment
 func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+	return a.startMachines(cd, c, nil, logger)
+}
+
+// StartMachinesByName will start the machines with the given names belonging to the given ClusterDeployment
+func (a *azureActuator) StartMachinesByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) error {
+	return a.startMachines(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *azureActuator) startMachines(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureStoppedOrStoppingStates, logger)
+	machines, err := listAzureMachines(cd, azureClient, azureStoppedOrStoppingStates, names, logger)
 	if err != nil {
 		return err
 	}
@@ -113,7 +131,7 @@ func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.C
 	if err != nil {
 		return false, err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureNotRunningStates, logger)
+	machines, err := listAzureMachines(cd, azureClient, azureNotRunningStates, nil, logger)
 	if err != nil {
 		return false, err
 	}
@@ -123,26 +141,42 @@ func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.C
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
 func (a *azureActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, c, nil, logger)
+}
+
+// MachinesStoppedByName will return true if the machines with the given names associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *azureActuator) MachinesStoppedByName(cd *hivev1.ClusterDeployment, c client.Client, names []string, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, c, sets.NewString(names...), logger)
+}
+
+func (a *azureActuator) machinesStopped(cd *hivev1.ClusterDeployment, c client.Client, names sets.String, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureNotStoppedStates, logger)
+	machines, err := listAzureMachines(cd, azureClient, azureNotStoppedStates, names, logger)
 	if err != nil {
 		return false, err
 	}
 	return len(machines) == 0, nil
 }
 
-func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states sets.String, logger log.FieldLogger) ([]compute.VirtualMachine, error) {
+// listAzureMachines returns the virtual machines of the cluster in one of the given power states. If names is not nil,
+// only the virtual machines with the given names are returned.
+func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states, names sets.String, logger log.FieldLogger) ([]compute.VirtualMachine, error) {
 	page, err := azureClient.ListAllVirtualMachines(context.TODO(), "true")
 	if err != nil {
 		return nil, err
 	}
 	var result []compute.VirtualMachine
 	for page.NotDone() {
-		result = append(result, filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger)...)
+		for _, vm := range filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger) {
+			if machineSelected(names, to.String(vm.Name)) {
+				result = append(result, vm)
+			}
+		}
 		if err = page.Next(); err != nil {
 			return nil, err
 		}
@@ -48,12 +48,21 @@ func (a *gcpActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 
 // StopMachines will start machines belonging to the given ClusterDeployment
 func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	return a.stopMachines(cd, hiveClient, nil, logger)
+}
+
+// StopMachinesByName will stop the machines with the given names belonging to the given ClusterDeployment
+func (a *gcpActuator) StopMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error {
+	return a.stopMachines(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *gcpActuator) stopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpRunningOrPendingStatuses, logger)
+	instances, err := gcpListComputeInstances(gcpClient, cd, gcpRunningOrPendingStatuses, names, logger)
 	if err != nil {
 		return err
 	}
@@ -70,12 +79,21 @@ func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient clie
 
 // StartMachines will select machines belonging to the given ClusterDeployment
 func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	return a.startMachines(cd, hiveClient, nil, logger)
+}
+
+// StartMachinesByName will start the machines with the given names belonging to the given ClusterDeployment
+func (a *gcpActuator) StartMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error {
+	return a.startMachines(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *gcpActuator) startMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpStoppedOrStoppingStatuses, logger)
+	instances, err := gcpListComputeInstances(gcpClient, cd, gcpStoppedOrStoppingStatuses, names, logger)
 	if err != nil {
 		return err
 	}
@@ -98,7 +116,7 @@ func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient c
 	if err != nil {
 		return false, err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotRunningStatuses, logger)
+	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotRunningStatuses, nil, logger)
 	if err != nil {
 		return false, err
 	}
@@ -108,12 +126,22 @@ func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient c
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
 func (a *gcpActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, hiveClient, nil, logger)
+}
+
+// MachinesStoppedByName will return true if the machines with the given names associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *gcpActuator) MachinesStoppedByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *gcpActuator) machinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return false, err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotStoppedStatuses, logger)
+	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotStoppedStatuses, names, logger)
 	if err != nil {
 		return false, err
 	}
@@ -137,7 +165,9 @@ func instanceFilter(cd *hivev1.ClusterDeployment) string {
 	return fmt.Sprintf("name eq \"%s-.*\"", cd.Spec.ClusterMetadata.InfraID)
 }
 
-func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
+// gcpListComputeInstances returns the instances of the cluster in one of the given statuses. If names is not nil, only
+// the instances with the given names are returned. Instances are named after their machines.
+func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses, names sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
 	var instances []*compute.Instance
 	logger.Debug("listing client instances")
 	err := gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
@@ -146,7 +176,7 @@ func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeplo
 	}, func(list *compute.InstanceAggregatedList) error {
 		for _, scopedList := range list.Items {
 			for _, instance := range scopedList.Instances {
-				if statuses.Has(instance.Status) {
+				if statuses.Has(instance.Status) && machineSelected(names, instance.Name) {
 					instances = append(instances, instance)
 				}
 			}
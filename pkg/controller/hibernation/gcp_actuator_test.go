@@ -193,6 +193,27 @@ func TestGCPMachinesStoppedAndRunning(t *testing.T) {
 	}
 }
 
+func TestGCPMachinesByName(t *testing.T) {
+	names := []string{"RUNNING-1", "STOPPED-0"}
+
+	ctrl := gomock.NewController(t)
+	gcpClient := mockgcpclient.NewMockClient(ctrl)
+	setupGCPClientInstances(gcpClient, map[string]int{"RUNNING": 3, "STOPPED": 1})
+	gcpClient.EXPECT().StopInstance(gomock.Any()).Times(1).Do(func(instance *compute.Instance) {
+		assert.Equal(t, "RUNNING-1", instance.Name)
+	}).Return(nil)
+	actuator := testGCPActuator(gcpClient)
+	require.Nil(t, actuator.StopMachinesByName(testClusterDeployment(), nil, names, log.New()))
+
+	ctrl = gomock.NewController(t)
+	gcpClient = mockgcpclient.NewMockClient(ctrl)
+	setupGCPClientInstances(gcpClient, map[string]int{"RUNNING": 3, "STOPPED": 1})
+	actuator = testGCPActuator(gcpClient)
+	stopped, err := actuator.MachinesStoppedByName(testClusterDeployment(), nil, []string{"STOPPED-0"}, log.New())
+	require.Nil(t, err)
+	assert.True(t, stopped)
+}
+
 func testGCPActuator(gcpClient gcpclient.Client) *gcpActuator {
 	return &gcpActuator{
 		getGCPClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (gcpclient.Client, error) {
@@ -5,6 +5,7 @@ package hibernation
 import (
 	log "github.com/sirupsen/logrus"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -25,4 +26,17 @@ type HibernationActuator interface {
 	// MachinesStopped will return true if the machines associated with the given
 	// ClusterDeployment are in a stopped state.
 	MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
+	// StopMachinesByName will stop the machines with the given names belonging to the given ClusterDeployment
+	StopMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error
+	// StartMachinesByName will start the machines with the given names belonging to the given ClusterDeployment
+	StartMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error
+	// MachinesStoppedByName will return true if the machines with the given names belonging to the given
+	// ClusterDeployment are in a stopped state.
+	MachinesStoppedByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) (bool, error)
+}
+
+// machineSelected returns true if a machine with the given name is one of the given names. A nil set of names
+// selects every machine.
+func machineSelected(names sets.String, name string) bool {
+	return names == nil || names.Has(name)
 }
@@ -3,6 +3,7 @@ package hibernation
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -48,6 +50,26 @@ const (
 	// avoid a false positive when the node status is checked too
 	// soon after the cluster is ready
 	nodeCheckWaitTime = 4 * time.Minute
+
+	// preflightCheckInterval is the time interval for re-running
+	// a failed hibernation preflight check
+	preflightCheckInterval = 5 * time.Minute
+
+	// nodeRecoveryWaitTime is the time to wait for the nodes of a
+	// resuming cluster to become ready before restarting its machines
+	nodeRecoveryWaitTime = 20 * time.Minute
+
+	// maxClockSkew is how far ahead of the hub the clock of a node
+	// may be before the node is reported as having a skewed clock
+	maxClockSkew = 5 * time.Minute
+
+	// restartedMessage is the message of the hibernating condition
+	// once the machines of a resuming cluster have been restarted.
+	restartedMessage = "Starting cluster machines after restarting them to recover nodes"
+
+	// machineAnnotation is the annotation on a node naming the
+	// machine of the node, as namespace/name
+	machineAnnotation = "machine.openshift.io/machine"
 )
 
 var (
@@ -104,7 +126,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 // AddToManager adds a new Controller to the controller manager
 func AddToManager(mgr manager.Manager, r *hibernationReconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	c, err := controller.New("hibernation-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -144,6 +166,17 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 		return reconcile.Result{}, err
 	}
 
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if controllerutils.IsReconcilePaused(cd) {
+		cdLog.WithField("annotation", constants.ReconcilePauseAnnotation).Warn("reconciling cluster is paused by annotation")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsDetached(cd) {
+		cdLog.Debug("cluster is detached")
+		return reconcile.Result{}, nil
+	}
+
 	// If cluster is already deleted, skip any processing
 	if !cd.DeletionTimestamp.IsZero() {
 		return reconcile.Result{}, nil
@@ -165,7 +198,7 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 	}
 
 	// Clear any lingering unsupported hibernation condition
-	if hibernatingCondition != nil && hibernatingCondition.Reason == hivev1.UnsupportedHibernationReason {
+	if controllerutils.IsClusterDeploymentConditionSet(hibernatingCondition) && hibernatingCondition.Reason == hivev1.UnsupportedHibernationReason {
 		if supported, msg := r.canHibernate(cd); supported {
 			return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, msg, corev1.ConditionFalse, cdLog)
 		}
@@ -182,7 +215,7 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 		var isRunning bool
 
 		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
-		if cond == nil {
+		if !controllerutils.IsClusterDeploymentConditionSet(cond) {
 			hibLog.Debug("cluster has no hibernating condition (never hibernated), using installed time")
 			isRunning = true
 		} else if cond.Status == corev1.ConditionFalse {
@@ -223,7 +256,10 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 	}
 
 	if !shouldHibernate {
-		if hibernatingCondition == nil || hibernatingCondition.Status == corev1.ConditionFalse {
+		if controllerutils.IsClusterDeploymentConditionSet(hibernatingCondition) && hibernatingCondition.Reason == hivev1.PreflightCheckFailedHibernationReason {
+			return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "Hibernation was cancelled", corev1.ConditionFalse, cdLog)
+		}
+		if !controllerutils.IsClusterDeploymentConditionSet(hibernatingCondition) || hibernatingCondition.Status == corev1.ConditionFalse {
 			return reconcile.Result{}, nil
 		}
 		switch hibernatingCondition.Reason {
@@ -231,11 +267,29 @@ func (r *hibernationReconciler) Reconcile(request reconcile.Request) (result rec
 			return r.startMachines(cd, cdLog)
 		case hivev1.ResumingHibernationReason:
 			return r.checkClusterResumed(cd, cdLog)
+		case hivev1.RestartingHibernationReason:
+			return r.checkClusterRestarted(cd, cdLog)
 		}
 		return reconcile.Result{}, nil
 	}
 
-	if hibernatingCondition == nil || hibernatingCondition.Status == corev1.ConditionFalse || hibernatingCondition.Reason == hivev1.ResumingHibernationReason {
+	if !controllerutils.IsClusterDeploymentConditionSet(hibernatingCondition) || hibernatingCondition.Status == corev1.ConditionFalse ||
+		hibernatingCondition.Reason == hivev1.ResumingHibernationReason || hibernatingCondition.Reason == hivev1.RestartingHibernationReason {
+		if shouldRunPreflightCheck(cd) {
+			problems, err := r.preflightCheck(cd, cdLog)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if len(problems) > 0 {
+				msg := fmt.Sprintf("Hibernation preflight check failed: %s. Set the %s annotation to \"true\" to hibernate anyway.",
+					strings.Join(problems, "; "), constants.ForceHibernationAnnotation)
+				result, err := r.setHibernatingCondition(cd, hivev1.PreflightCheckFailedHibernationReason, msg, corev1.ConditionFalse, cdLog)
+				if err == nil {
+					result.RequeueAfter = preflightCheckInterval
+				}
+				return result, err
+			}
+		}
 		return r.stopMachines(cd, cdLog)
 	}
 	if hibernatingCondition.Reason == hivev1.StoppingHibernationReason {
@@ -251,6 +305,13 @@ func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logg
 		logger.Warning("No compatible actuator found to start cluster machines")
 		return reconcile.Result{}, nil
 	}
+	if _, restarted := cd.Annotations[constants.HibernationRestartedMachinesAnnotation]; restarted {
+		delete(cd.Annotations, constants.HibernationRestartedMachinesAnnotation)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to clear the machines restarted by the previous resume")
+			return reconcile.Result{}, errors.Wrap(err, "failed to clear the machines restarted by the previous resume")
+		}
+	}
 	logger.Info("Resuming cluster")
 	if err := actuator.StartMachines(cd, r.Client, logger); err != nil {
 		msg := fmt.Sprintf("Failed to start machines: %v", err)
@@ -317,12 +378,179 @@ func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment
 	}
 	if !ready {
 		logger.Info("Nodes are not ready, checking for CSRs to approve")
-		return r.checkCSRs(cd, remoteClient, logger)
+		result, err := r.checkCSRs(cd, remoteClient, logger)
+		if err != nil {
+			return result, err
+		}
+		machines, reason, err := r.needsRestart(cd, remoteClient, logger)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether machines need to be restarted")
+			return reconcile.Result{}, err
+		}
+		if len(machines) > 0 {
+			return r.restartMachines(cd, machines, reason, logger)
+		}
+		return result, nil
+	}
+	notAvailable, err := unavailableOperators(remoteClient)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether cluster operators are available")
+		return reconcile.Result{}, err
+	}
+	if len(notAvailable) > 0 {
+		logger.WithField("operators", notAvailable).Info("Cluster operators are not available yet, waiting")
+		msg := fmt.Sprintf("Waiting for cluster operators to become available: %s", strings.Join(notAvailable, ", "))
+		result, err := r.setHibernatingCondition(cd, hivev1.ResumingHibernationReason, msg, corev1.ConditionTrue, logger)
+		if err == nil {
+			result.RequeueAfter = stateCheckInterval
+		}
+		return result, err
 	}
 	logger.Info("Cluster has started and is in Running state")
 	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All machines are started and nodes are ready", corev1.ConditionFalse, logger)
 }
 
+// needsRestart returns the machines of a resuming cluster that should be restarted to recover its nodes, along with
+// the reason. These are the machines of the nodes that have not become ready in time after the cluster started
+// resuming, which happens when their certificates expired while the cluster was hibernating and approving their CSRs
+// did not bring them back. Nodes whose clocks are ahead of the hub are reported as well, as a skewed clock makes valid
+// certificates look expired. Machines are restarted at most once per resume.
+func (r *hibernationReconciler) needsRestart(cd *hivev1.ClusterDeployment, remoteClient client.Client, logger log.FieldLogger) ([]string, string, error) {
+	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCondition == nil {
+		return nil, "", errors.New("cannot find hibernating condition")
+	}
+	if restarted, ok := cd.Annotations[constants.HibernationRestartedMachinesAnnotation]; ok {
+		logger.WithField("machines", restarted).Debug("Machines have already been restarted, waiting for nodes")
+		return nil, "", nil
+	}
+	if time.Since(hibernatingCondition.LastProbeTime.Time) < nodeRecoveryWaitTime {
+		return nil, "", nil
+	}
+	nodeList := &corev1.NodeList{}
+	if err := remoteClient.List(context.TODO(), nodeList); err != nil {
+		return nil, "", errors.Wrap(err, "failed to fetch cluster nodes")
+	}
+	machines := notReadyMachines(nodeList.Items)
+	if len(machines) == 0 {
+		return nil, "", nil
+	}
+	return machines, strings.Join(nodeProblems(nodeList.Items, time.Now()), "; "), nil
+}
+
+// notReadyMachines returns the names of the machines of the nodes that are not ready. The machine of a node is named
+// in the machine annotation of the node, or has the name of the node if the annotation is missing.
+func notReadyMachines(nodes []corev1.Node) []string {
+	var machines []string
+	for i := range nodes {
+		node := &nodes[i]
+		if isNodeReady(node) {
+			continue
+		}
+		machine := node.Name
+		if annotation := node.Annotations[machineAnnotation]; annotation != "" {
+			machine = annotation[strings.LastIndex(annotation, "/")+1:]
+		}
+		machines = append(machines, machine)
+	}
+	return machines
+}
+
+// nodeProblems returns the nodes that are not ready and the nodes whose clock is ahead of now by more than
+// maxClockSkew. The clock of a node is taken from the last heartbeat it reported.
+func nodeProblems(nodes []corev1.Node, now time.Time) []string {
+	var notReady, skewed []string
+	for i := range nodes {
+		node := &nodes[i]
+		if !isNodeReady(node) {
+			notReady = append(notReady, node.Name)
+		}
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.LastHeartbeatTime.Time.Sub(now) > maxClockSkew {
+				skewed = append(skewed, node.Name)
+			}
+		}
+	}
+	var problems []string
+	if len(notReady) > 0 {
+		problems = append(problems, fmt.Sprintf("nodes not ready after %v: %s", nodeRecoveryWaitTime, strings.Join(notReady, ", ")))
+	}
+	if len(skewed) > 0 {
+		problems = append(problems, fmt.Sprintf("nodes with clocks ahead by more than %v: %s", maxClockSkew, strings.Join(skewed, ", ")))
+	}
+	return problems
+}
+
+func (r *hibernationReconciler) restartMachines(cd *hivev1.ClusterDeployment, machines []string, reason string, logger log.FieldLogger) (reconcile.Result, error) {
+	actuator := r.getActuator(cd)
+	if actuator == nil {
+		logger.Warning("No compatible actuator found to restart cluster machines")
+		return reconcile.Result{}, nil
+	}
+	logger = logger.WithField("machines", machines)
+	logger.WithField("reason", reason).Info("Restarting cluster machines to recover nodes")
+	if err := actuator.StopMachinesByName(cd, r.Client, machines, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to stop machines for restart")
+		return reconcile.Result{}, errors.Wrap(err, "failed to stop machines for restart")
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.HibernationRestartedMachinesAnnotation] = strings.Join(machines, ",")
+	if err := r.Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to record the restarted machines")
+		return reconcile.Result{}, errors.Wrap(err, "failed to record the restarted machines")
+	}
+	msg := fmt.Sprintf("Restarting cluster machines %s: %s", strings.Join(machines, ", "), reason)
+	return r.setHibernatingCondition(cd, hivev1.RestartingHibernationReason, msg, corev1.ConditionTrue, logger)
+}
+
+func (r *hibernationReconciler) checkClusterRestarted(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	actuator := r.getActuator(cd)
+	if actuator == nil {
+		logger.Warning("No compatible actuator found to check machine status")
+		return reconcile.Result{}, nil
+	}
+	machines := strings.Split(cd.Annotations[constants.HibernationRestartedMachinesAnnotation], ",")
+	logger = logger.WithField("machines", machines)
+	stopped, err := actuator.MachinesStoppedByName(cd, r.Client, machines, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether machines are stopped.")
+		return reconcile.Result{}, err
+	}
+	if !stopped {
+		return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
+	}
+	logger.Info("Machines have stopped, starting them again")
+	if err := actuator.StartMachinesByName(cd, r.Client, machines, logger); err != nil {
+		msg := fmt.Sprintf("Failed to start machines: %v", err)
+		return r.setHibernatingCondition(cd, hivev1.FailedToStartHibernationReason, msg, corev1.ConditionTrue, logger)
+	}
+	return r.setHibernatingCondition(cd, hivev1.ResumingHibernationReason, restartedMessage, corev1.ConditionTrue, logger)
+}
+
+// unavailableOperators returns the names of the cluster operators that are not available. Operators that are
+// available but degraded are not waited for, as a degraded operator may stay that way regardless of the resume.
+func unavailableOperators(remoteClient client.Client) ([]string, error) {
+	operatorList := &configv1.ClusterOperatorList{}
+	if err := remoteClient.List(context.TODO(), operatorList); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch cluster operators")
+	}
+	var names []string
+	for _, co := range operatorList.Items {
+		available := false
+		for _, c := range co.Status.Conditions {
+			if c.Type == configv1.OperatorAvailable {
+				available = c.Status == configv1.ConditionTrue
+			}
+		}
+		if !available {
+			names = append(names, co.Name)
+		}
+	}
+	return names, nil
+}
+
 func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeployment, reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) (reconcile.Result, error) {
 	changed := false
 	if status == corev1.ConditionFalse && controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition) == nil {
@@ -22,9 +22,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/hibernation/mock"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
@@ -46,6 +48,7 @@ func TestReconcile(t *testing.T) {
 	batchv1.AddToScheme(scheme)
 	hivev1.AddToScheme(scheme)
 	machineapi.AddToScheme(scheme)
+	configv1.Install(scheme)
 
 	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
 		testcd.Installed(),
@@ -77,6 +80,14 @@ func TestReconcile(t *testing.T) {
 				require.Nil(t, getHibernatingCondition(cd))
 			},
 		},
+		{
+			name: "reconcile paused",
+			cd: cdBuilder.GenericOptions(testgeneric.WithAnnotation(constants.ReconcilePauseAnnotation, "true")).
+				Options(o.shouldHibernate).Build(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				require.Nil(t, getHibernatingCondition(cd))
+			},
+		},
 		{
 			name: "start hibernating, older version",
 			cd:   cdBuilder.Options(o.shouldHibernate, testcd.WithClusterVersion("4.3.11")).Build(),
@@ -100,6 +111,81 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name: "start hibernating, initialized condition",
+			cd: cdBuilder.Options(o.shouldHibernate,
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionUnknown, hivev1.InitializedConditionReason, 0))).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "start hibernating, preflight check fails",
+			cd: cdBuilder.GenericOptions(testgeneric.WithAnnotation(constants.HibernationPreflightCheckAnnotation, "true")).
+				Options(o.shouldHibernate).Build(),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, localVolume(), zonalVolume())
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.PreflightCheckFailedHibernationReason, cond.Reason)
+				assert.Contains(t, cond.Message, "persistent volume local-pv is a local volume")
+				assert.NotContains(t, cond.Message, "zonal-pv")
+			},
+		},
+		{
+			name: "start hibernating, preflight check passes",
+			cd: cdBuilder.GenericOptions(testgeneric.WithAnnotation(constants.HibernationPreflightCheckAnnotation, "true")).
+				Options(o.shouldHibernate).Build(),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, zonalVolume())
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "start hibernating, preflight check forced",
+			cd: cdBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.HibernationPreflightCheckAnnotation, "true"),
+				testgeneric.WithAnnotation(constants.ForceHibernationAnnotation, "true"),
+			).Options(o.shouldHibernate, o.preflightCheckFailed).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "hibernation cancelled after failed preflight check",
+			cd:   cdBuilder.Options(o.shouldRun, o.preflightCheckFailed).Build(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
 		{
 			name: "fail to stop machines",
 			cd:   cdBuilder.Options(o.shouldHibernate).Build(),
@@ -152,6 +238,19 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name: "start resuming, clear machines restarted by previous resume",
+			cd:   cdBuilder.Options(o.hibernating, o.restartedMachines).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				assert.NotContains(t, cd.Annotations, constants.HibernationRestartedMachinesAnnotation)
+			},
+		},
 		{
 			name: "fail to start machines",
 			cd:   cdBuilder.Options(o.hibernating).Build(),
@@ -240,6 +339,150 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
 			},
 		},
+		{
+			name: "starting, machines running, nodes ready, operators not available",
+			cd:   cdBuilder.Options(o.resuming).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				objs := append(readyNodes(),
+					clusterOperator("kube-apiserver", configv1.ConditionTrue, configv1.ConditionFalse),
+					clusterOperator("ingress", configv1.ConditionFalse, configv1.ConditionFalse),
+					clusterOperator("dns", configv1.ConditionTrue, configv1.ConditionTrue),
+				)
+				c := fake.NewFakeClientWithScheme(scheme, objs...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				assert.Contains(t, cond.Message, "ingress")
+				assert.NotContains(t, cond.Message, "dns")
+				assert.NotContains(t, cond.Message, "kube-apiserver")
+			},
+		},
+		{
+			name: "starting, machines running, nodes ready, operators available and degraded",
+			cd:   cdBuilder.Options(o.resuming).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				objs := append(readyNodes(), clusterOperator("dns", configv1.ConditionTrue, configv1.ConditionTrue))
+				c := fake.NewFakeClientWithScheme(scheme, objs...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "starting, machines running, nodes ready, operators available",
+			cd:   cdBuilder.Options(o.resuming).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				objs := append(readyNodes(), clusterOperator("kube-apiserver", configv1.ConditionTrue, configv1.ConditionFalse))
+				c := fake.NewFakeClientWithScheme(scheme, objs...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "starting, unready node past recovery wait time",
+			cd:   cdBuilder.Options(o.resumingFor(nodeRecoveryWaitTime + time.Minute)).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().StopMachinesByName(gomock.Any(), gomock.Any(), []string{"unready-machine"}, gomock.Any()).Times(1).Return(nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				fakeClient := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
+				builder.EXPECT().Build().Times(1).Return(fakeClient, nil)
+				builder.EXPECT().BuildKubeClient().Times(1).Return(fakekubeclient.NewSimpleClientset(), nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.RestartingHibernationReason, cond.Reason)
+				assert.Contains(t, cond.Message, "unready")
+				assert.Equal(t, "unready-machine", cd.Annotations[constants.HibernationRestartedMachinesAnnotation])
+			},
+		},
+		{
+			name: "starting, unready node after restart",
+			cd: cdBuilder.Options(o.resumingFor(nodeRecoveryWaitTime+time.Minute), o.restartedMachines, func(cd *hivev1.ClusterDeployment) {
+				getHibernatingCondition(cd).Message = restartedMessage
+			}).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				fakeClient := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
+				builder.EXPECT().Build().Times(1).Return(fakeClient, nil)
+				builder.EXPECT().BuildKubeClient().Times(1).Return(fakekubeclient.NewSimpleClientset(), nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				assert.Equal(t, restartedMessage, cond.Message)
+			},
+		},
+		{
+			name: "restarting, machines have not stopped",
+			cd:   cdBuilder.Options(o.restarting).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStoppedByName(gomock.Any(), gomock.Any(), []string{"unready-machine"}, gomock.Any()).Times(1).Return(false, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.RestartingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "restarting, machines have stopped",
+			cd:   cdBuilder.Options(o.restarting).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStoppedByName(gomock.Any(), gomock.Any(), []string{"unready-machine"}, gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().StartMachinesByName(gomock.Any(), gomock.Any(), []string{"unready-machine"}, gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+				assert.Equal(t, restartedMessage, cond.Message)
+			},
+		},
+		{
+			name: "restarting, hibernation requested",
+			cd:   cdBuilder.Options(o.shouldHibernate, o.restarting).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
+			},
+		},
 		{
 			name: "previously unsupported hibernation, now supported",
 			cd:   cdBuilder.Options(o.unsupported, testcd.WithHibernateAfter(8*time.Hour)).Build(),
@@ -463,12 +706,32 @@ func (*clusterDeploymentOptions) hibernating(cd *hivev1.ClusterDeployment) {
 		Status: corev1.ConditionTrue,
 	})
 }
-func (*clusterDeploymentOptions) resuming(cd *hivev1.ClusterDeployment) {
+func (o *clusterDeploymentOptions) resuming(cd *hivev1.ClusterDeployment) {
+	o.resumingFor(nodeCheckWaitTime + time.Minute)(cd)
+}
+func (*clusterDeploymentOptions) resumingFor(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.ClusterHibernatingCondition,
+			Reason:        hivev1.ResumingHibernationReason,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-d)),
+		})
+	}
+}
+func (o *clusterDeploymentOptions) restarting(cd *hivev1.ClusterDeployment) {
 	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
 		Type:   hivev1.ClusterHibernatingCondition,
-		Reason: hivev1.ResumingHibernationReason,
+		Reason: hivev1.RestartingHibernationReason,
 		Status: corev1.ConditionTrue,
 	})
+	o.restartedMachines(cd)
+}
+func (*clusterDeploymentOptions) restartedMachines(cd *hivev1.ClusterDeployment) {
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.HibernationRestartedMachinesAnnotation] = "unready-machine"
 }
 func (*clusterDeploymentOptions) unsupported(cd *hivev1.ClusterDeployment) {
 	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
@@ -477,6 +740,13 @@ func (*clusterDeploymentOptions) unsupported(cd *hivev1.ClusterDeployment) {
 		Reason: hivev1.UnsupportedHibernationReason,
 	})
 }
+func (*clusterDeploymentOptions) preflightCheckFailed(cd *hivev1.ClusterDeployment) {
+	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+		Type:   hivev1.ClusterHibernatingCondition,
+		Status: corev1.ConditionFalse,
+		Reason: hivev1.PreflightCheckFailedHibernationReason,
+	})
+}
 
 func getHibernatingCondition(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeploymentCondition {
 	for i := range cd.Status.Conditions {
@@ -506,6 +776,7 @@ func readyNodes() []runtime.Object {
 func unreadyNode() []runtime.Object {
 	node := &corev1.Node{}
 	node.Name = "unready"
+	node.Annotations = map[string]string{machineAnnotation: "openshift-machine-api/unready-machine"}
 	node.Status.Conditions = []corev1.NodeCondition{
 		{
 			Type:   corev1.NodeReady,
@@ -515,6 +786,41 @@ func unreadyNode() []runtime.Object {
 	return append(readyNodes(), node)
 }
 
+func clusterOperator(name string, available, degraded configv1.ConditionStatus) *configv1.ClusterOperator {
+	co := &configv1.ClusterOperator{}
+	co.Name = name
+	co.Status.Conditions = []configv1.ClusterOperatorStatusCondition{
+		{Type: configv1.OperatorAvailable, Status: available},
+		{Type: configv1.OperatorDegraded, Status: degraded},
+	}
+	return co
+}
+
+func localVolume() *corev1.PersistentVolume {
+	pv := &corev1.PersistentVolume{}
+	pv.Name = "local-pv"
+	pv.Spec.Local = &corev1.LocalVolumeSource{Path: "/mnt/local-storage"}
+	return pv
+}
+
+func zonalVolume() *corev1.PersistentVolume {
+	pv := &corev1.PersistentVolume{}
+	pv.Name = "zonal-pv"
+	pv.Spec.AWSElasticBlockStore = &corev1.AWSElasticBlockStoreVolumeSource{VolumeID: "vol-12345"}
+	pv.Spec.NodeAffinity = &corev1.VolumeNodeAffinity{
+		Required: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "topology.kubernetes.io/zone",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"us-east-1a"},
+				}},
+			}},
+		},
+	}
+	return pv
+}
+
 func csrs() []runtime.Object {
 	result := make([]runtime.Object, 5)
 	for i := 0; i < len(result); i++ {
@@ -524,3 +830,63 @@ func csrs() []runtime.Object {
 	}
 	return result
 }
+
+func TestNodeProblems(t *testing.T) {
+	now := time.Now()
+	node := func(name string, ready corev1.ConditionStatus, heartbeat time.Time) corev1.Node {
+		n := corev1.Node{}
+		n.Name = name
+		n.Status.Conditions = []corev1.NodeCondition{{
+			Type:              corev1.NodeReady,
+			Status:            ready,
+			LastHeartbeatTime: metav1.NewTime(heartbeat),
+		}}
+		return n
+	}
+	tests := []struct {
+		name     string
+		nodes    []corev1.Node
+		expected []string
+	}{
+		{
+			name:  "all nodes ready",
+			nodes: []corev1.Node{node("a", corev1.ConditionTrue, now), node("b", corev1.ConditionTrue, now.Add(-time.Hour))},
+		},
+		{
+			name:     "unready node",
+			nodes:    []corev1.Node{node("a", corev1.ConditionTrue, now), node("b", corev1.ConditionUnknown, now.Add(-time.Hour))},
+			expected: []string{"nodes not ready after 20m0s: b"},
+		},
+		{
+			name:  "node clock ahead",
+			nodes: []corev1.Node{node("a", corev1.ConditionFalse, now.Add(time.Hour)), node("b", corev1.ConditionTrue, now.Add(time.Minute))},
+			expected: []string{
+				"nodes not ready after 20m0s: a",
+				"nodes with clocks ahead by more than 5m0s: a",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, nodeProblems(test.nodes, now))
+		})
+	}
+}
+
+func TestNotReadyMachines(t *testing.T) {
+	node := func(name, machine string, ready corev1.ConditionStatus) corev1.Node {
+		n := corev1.Node{}
+		n.Name = name
+		if machine != "" {
+			n.Annotations = map[string]string{machineAnnotation: machine}
+		}
+		n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}
+		return n
+	}
+	nodes := []corev1.Node{
+		node("master-0", "openshift-machine-api/infra-master-0", corev1.ConditionTrue),
+		node("ip-10-0-1-2", "openshift-machine-api/infra-worker-a", corev1.ConditionUnknown),
+		node("worker-b", "", corev1.ConditionFalse),
+	}
+	assert.Equal(t, []string{"infra-worker-a", "worker-b"}, notReadyMachines(nodes))
+}
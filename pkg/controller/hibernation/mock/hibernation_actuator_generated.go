@@ -106,3 +106,46 @@ func (mr *MockHibernationActuatorMockRecorder) MachinesStopped(cd, hiveClient, l
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesStopped", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesStopped), cd, hiveClient, logger)
 }
+
+// StopMachinesByName mocks base method
+func (m *MockHibernationActuator) StopMachinesByName(cd *v1.ClusterDeployment, hiveClient client.Client, names []string, logger logrus.FieldLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopMachinesByName", cd, hiveClient, names, logger)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopMachinesByName indicates an expected call of StopMachinesByName
+func (mr *MockHibernationActuatorMockRecorder) StopMachinesByName(cd, hiveClient, names, logger interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMachinesByName", reflect.TypeOf((*MockHibernationActuator)(nil).StopMachinesByName), cd, hiveClient, names, logger)
+}
+
+// StartMachinesByName mocks base method
+func (m *MockHibernationActuator) StartMachinesByName(cd *v1.ClusterDeployment, hiveClient client.Client, names []string, logger logrus.FieldLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartMachinesByName", cd, hiveClient, names, logger)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartMachinesByName indicates an expected call of StartMachinesByName
+func (mr *MockHibernationActuatorMockRecorder) StartMachinesByName(cd, hiveClient, names, logger interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMachinesByName", reflect.TypeOf((*MockHibernationActuator)(nil).StartMachinesByName), cd, hiveClient, names, logger)
+}
+
+// MachinesStoppedByName mocks base method
+func (m *MockHibernationActuator) MachinesStoppedByName(cd *v1.ClusterDeployment, hiveClient client.Client, names []string, logger logrus.FieldLogger) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachinesStoppedByName", cd, hiveClient, names, logger)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MachinesStoppedByName indicates an expected call of MachinesStoppedByName
+func (mr *MockHibernationActuatorMockRecorder) MachinesStoppedByName(cd, hiveClient, names, logger interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesStoppedByName", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesStoppedByName), cd, hiveClient, names, logger)
+}
@@ -0,0 +1,83 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// hostnameLabel is the node label used by volumes that are pinned to a single node.
+const hostnameLabel = "kubernetes.io/hostname"
+
+// shouldRunPreflightCheck returns true if the cluster must pass the hibernation preflight check before it is
+// hibernated.
+func shouldRunPreflightCheck(cd *hivev1.ClusterDeployment) bool {
+	if check, err := strconv.ParseBool(cd.Annotations[constants.HibernationPreflightCheckAnnotation]); err != nil || !check {
+		return false
+	}
+	if force, err := strconv.ParseBool(cd.Annotations[constants.ForceHibernationAnnotation]); err == nil && force {
+		return false
+	}
+	return true
+}
+
+// preflightCheck looks for constructs in the cluster that are known to break when the cluster machines are stopped
+// and started. It returns a description of each problem found. Volumes pinned to a zone, such as cloud provider
+// block storage, are supported since the machines are started again in the same zone.
+func (r *hibernationReconciler) preflightCheck(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]string, error) {
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to connect to target cluster")
+		return nil, err
+	}
+	pvList := &corev1.PersistentVolumeList{}
+	if err := remoteClient.List(context.TODO(), pvList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch cluster persistent volumes")
+		return nil, errors.Wrap(err, "failed to fetch cluster persistent volumes")
+	}
+	var problems []string
+	for i := range pvList.Items {
+		if problem := unsupportedPersistentVolume(&pvList.Items[i]); problem != "" {
+			logger.WithField("persistentVolume", pvList.Items[i].Name).Info(problem)
+			problems = append(problems, fmt.Sprintf("persistent volume %s %s", pvList.Items[i].Name, problem))
+		}
+	}
+	return problems, nil
+}
+
+// unsupportedPersistentVolume returns why the persistent volume may not survive the cluster being stopped and
+// started, or an empty string if it is expected to survive.
+func unsupportedPersistentVolume(pv *corev1.PersistentVolume) string {
+	switch {
+	case pv.Spec.Local != nil:
+		return "is a local volume"
+	case pv.Spec.HostPath != nil:
+		return "is a host path volume"
+	case isPinnedToNode(pv):
+		return "is pinned to a single node"
+	}
+	return ""
+}
+
+func isPinnedToNode(pv *corev1.PersistentVolume) bool {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == hostnameLabel {
+				return true
+			}
+		}
+	}
+	return false
+}
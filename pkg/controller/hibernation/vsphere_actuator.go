@@ -0,0 +1,187 @@
+package hibernation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+var (
+	vSphereRunningStates  = sets.NewString(string(types.VirtualMachinePowerStatePoweredOn))
+	vSphereStoppedStates  = sets.NewString(string(types.VirtualMachinePowerStatePoweredOff), string(types.VirtualMachinePowerStateSuspended))
+	vSphereAllPowerStates = vSphereRunningStates.Union(vSphereStoppedStates)
+)
+
+func init() {
+	RegisterActuator(&vSphereActuator{getVSphereClientFn: getVSphereClient})
+}
+
+type vSphereActuator struct {
+	getVSphereClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *vSphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+// StopMachines will start machines belonging to the given ClusterDeployment
+func (a *vSphereActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	return a.stopMachines(cd, hiveClient, nil, logger)
+}
+
+// StopMachinesByName will stop the machines with the given names belonging to the given ClusterDeployment
+func (a *vSphereActuator) StopMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error {
+	return a.stopMachines(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *vSphereActuator) stopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vSphere")
+	vSphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vSphereLogout(vSphereClient, logger)
+	vms, err := vSphereListVirtualMachines(vSphereClient, cd, vSphereRunningStates, names, logger)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Powering off virtual machine")
+		if err := vSphereClient.PowerOffVirtualMachine(context.TODO(), vm.Reference()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StartMachines will select machines belonging to the given ClusterDeployment
+func (a *vSphereActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	return a.startMachines(cd, hiveClient, nil, logger)
+}
+
+// StartMachinesByName will start the machines with the given names belonging to the given ClusterDeployment
+func (a *vSphereActuator) StartMachinesByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) error {
+	return a.startMachines(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *vSphereActuator) startMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vSphere")
+	vSphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vSphereLogout(vSphereClient, logger)
+	vms, err := vSphereListVirtualMachines(vSphereClient, cd, vSphereStoppedStates, names, logger)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Powering on virtual machine")
+		if err := vSphereClient.PowerOnVirtualMachine(context.TODO(), vm.Reference()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state.
+func (a *vSphereActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "vSphere")
+	vSphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	defer vSphereLogout(vSphereClient, logger)
+	vms, err := vSphereListVirtualMachines(vSphereClient, cd, vSphereAllPowerStates.Difference(vSphereRunningStates), nil, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(vms) == 0, nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *vSphereActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, hiveClient, nil, logger)
+}
+
+// MachinesStoppedByName will return true if the machines with the given names associated with the given
+// ClusterDeployment are in a stopped state.
+func (a *vSphereActuator) MachinesStoppedByName(cd *hivev1.ClusterDeployment, hiveClient client.Client, names []string, logger log.FieldLogger) (bool, error) {
+	return a.machinesStopped(cd, hiveClient, sets.NewString(names...), logger)
+}
+
+func (a *vSphereActuator) machinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, names sets.String, logger log.FieldLogger) (bool, error) {
+	logger = logger.WithField("cloud", "vSphere")
+	vSphereClient, err := a.getVSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, err
+	}
+	defer vSphereLogout(vSphereClient, logger)
+	vms, err := vSphereListVirtualMachines(vSphereClient, cd, vSphereAllPowerStates.Difference(vSphereStoppedStates), names, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(vms) == 0, nil
+}
+
+func getVSphereClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (vsphereclient.Client, error) {
+	if cd.Spec.Platform.VSphere == nil {
+		return nil, errors.New("vSphere platform is not set in ClusterDeployment")
+	}
+	credentialsSecret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credentialsSecret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	certificatesSecret := &corev1.Secret{}
+	err = c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CertificatesSecretRef.Name, Namespace: cd.Namespace}, certificatesSecret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch vSphere certificates secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere certificates secret")
+	}
+	return vsphereclient.NewClientFromSecrets(cd.Spec.Platform.VSphere.VCenter, credentialsSecret, certificatesSecret)
+}
+
+func vSphereLogout(vSphereClient vsphereclient.Client, logger log.FieldLogger) {
+	if err := vSphereClient.Logout(context.TODO()); err != nil {
+		logger.WithError(err).Warn("Failed to log out of vCenter")
+	}
+}
+
+// vSphereListVirtualMachines returns the virtual machines of the cluster in one of the given power states. The installer
+// attaches a tag named after the infra ID to the virtual machines of the cluster. If names is not nil, only the
+// virtual machines with the given names are returned.
+func vSphereListVirtualMachines(vSphereClient vsphereclient.Client, cd *hivev1.ClusterDeployment, states, names sets.String, logger log.FieldLogger) ([]mo.VirtualMachine, error) {
+	logger.Debug("listing virtual machines")
+	vms, err := vSphereClient.ListVirtualMachines(context.TODO(), cd.Spec.ClusterMetadata.InfraID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list virtual machines")
+		return nil, err
+	}
+	var result []mo.VirtualMachine
+	for _, vm := range vms {
+		if states.Has(string(vm.Runtime.PowerState)) && machineSelected(names, vm.Name) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states.List()).Debug("found virtual machines")
+	return result, nil
+}
@@ -0,0 +1,179 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/pkg/apis/hive/v1/vsphere"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/vsphereclient"
+	mockvsphereclient "github.com/openshift/hive/pkg/vsphereclient/mock"
+)
+
+func TestVSphereCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+	}).Build()
+	actuator := vSphereActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestVSphereStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		vms         map[types.VirtualMachinePowerState]int
+		setupClient func(*testing.T, *mockvsphereclient.MockClient)
+	}{
+		{
+			name:     "stop no running vms",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 3, "suspended": 1},
+		},
+		{
+			name:     "stop running vms",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 2, "poweredOn": 3},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockClient) {
+				c.EXPECT().PowerOffVirtualMachine(gomock.Any(), gomock.Any()).Times(3).Do(
+					func(_ context.Context, ref types.ManagedObjectReference) {
+						assert.Contains(t, ref.Value, "poweredOn")
+					},
+				)
+			},
+		},
+		{
+			name:     "start no stopped vms",
+			testFunc: "StartMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOn": 3},
+		},
+		{
+			name:     "start stopped and suspended vms",
+			testFunc: "StartMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 2, "suspended": 1, "poweredOn": 4},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockClient) {
+				c.EXPECT().PowerOnVirtualMachine(gomock.Any(), gomock.Any()).Times(3).Do(
+					func(_ context.Context, ref types.ManagedObjectReference) {
+						assert.NotContains(t, ref.Value, "poweredOn")
+					},
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vSphereClient := mockvsphereclient.NewMockClient(ctrl)
+			setupVSphereClientVMs(vSphereClient, test.vms)
+			if test.setupClient != nil {
+				test.setupClient(t, vSphereClient)
+			}
+			actuator := testVSphereActuator(vSphereClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			assert.Nil(t, err)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestVSphereMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFunc string
+		expected bool
+		vms      map[types.VirtualMachinePowerState]int
+	}{
+		{
+			name:     "Stopped - All vms powered off or suspended",
+			testFunc: "MachinesStopped",
+			expected: true,
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 3, "suspended": 1},
+		},
+		{
+			name:     "Stopped - vms powered on",
+			testFunc: "MachinesStopped",
+			expected: false,
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 3, "poweredOn": 1},
+		},
+		{
+			name:     "Running - All vms powered on",
+			testFunc: "MachinesRunning",
+			expected: true,
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOn": 3},
+		},
+		{
+			name:     "Running - Some vms powered off",
+			testFunc: "MachinesRunning",
+			expected: false,
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOn": 3, "poweredOff": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vSphereClient := mockvsphereclient.NewMockClient(ctrl)
+			setupVSphereClientVMs(vSphereClient, test.vms)
+			actuator := testVSphereActuator(vSphereClient)
+			var err error
+			var result bool
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func testVSphereActuator(vSphereClient vsphereclient.Client) *vSphereActuator {
+	return &vSphereActuator{
+		getVSphereClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.Client, error) {
+			return vSphereClient, nil
+		},
+	}
+}
+
+func setupVSphereClientVMs(vSphereClient *mockvsphereclient.MockClient, states map[types.VirtualMachinePowerState]int) {
+	vms := []mo.VirtualMachine{}
+	for state, count := range states {
+		for i := 0; i < count; i++ {
+			name := fmt.Sprintf("%s-%d", state, i)
+			vm := mo.VirtualMachine{}
+			vm.Name = name
+			vm.Self = types.ManagedObjectReference{Type: "VirtualMachine", Value: name}
+			vm.Runtime.PowerState = state
+			vms = append(vms, vm)
+		}
+	}
+	vSphereClient.EXPECT().ListVirtualMachines(gomock.Any(), "abcd1234").Times(1).Return(vms, nil)
+	vSphereClient.EXPECT().Logout(gomock.Any()).Times(1).Return(nil)
+}
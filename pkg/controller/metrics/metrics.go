@@ -10,14 +10,17 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/errorclass"
 	"github.com/openshift/hive/pkg/imageset"
 )
 
@@ -76,6 +79,34 @@ var (
 		Name: "hive_syncsets_unapplied_total",
 		Help: "Total number of SyncSetsInstances referencing non-selector SyncSets that have not successfully applied all resources/patches/secrets.",
 	})
+	metricSyncSetClustersFailing = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_syncset_clusters_failing",
+		Help: "Number of clusters each non-selector SyncSet is failing to apply to. SyncSets that apply successfully to all of their clusters are not reported.",
+	}, []string{"namespace", "name"})
+	metricClusterPoolClustersQuarantined = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_quarantined",
+		Help: "Total number of quarantined clusters of each ClusterPool by quarantine reason.",
+	}, []string{"clusterpool_namespace", "clusterpool_name", "reason"})
+	metricClusterPoolSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_size",
+		Help: "Number of unclaimed clusters each ClusterPool keeps, from its size or its autoscaled target size.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricClusterPoolClustersProvisioning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_provisioning",
+		Help: "Total number of unclaimed clusters of each ClusterPool that are still provisioning.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricClusterPoolClustersReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_ready",
+		Help: "Total number of unclaimed clusters of each ClusterPool that are installed and ready to be claimed.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricClusterPoolClustersRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_running",
+		Help: "Total number of ready clusters of each ClusterPool that are kept running on standby.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricClusterPoolClustersClaimed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusters_claimed",
+		Help: "Total number of clusters of each ClusterPool that are assigned to claims.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 
 	// MetricClusterDeploymentDeprovisioningUnderwaySeconds is a prometheus metric for the number of seconds
 	// between when a still deprovisioning cluster was created and now.
@@ -87,6 +118,43 @@ var (
 		},
 		[]string{"cluster_deployment", "namespace", "cluster_type"},
 	)
+	// MetricClusterPoolClaimFulfillmentSeconds is a prometheus metric for the number of seconds between when a
+	// ClusterClaim was created and when its ClusterPool assigned it a cluster.
+	MetricClusterPoolClaimFulfillmentSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_clusterpool_claim_fulfillment_seconds",
+			Help:    "Distribution of the length of time ClusterClaims of each ClusterPool wait to be assigned a cluster.",
+			Buckets: []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200, 14400},
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
+	// MetricClusterPoolClustersQuarantinedTotal is a prometheus metric counting the clusters quarantined by
+	// each ClusterPool.
+	MetricClusterPoolClustersQuarantinedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_clusterpool_clusters_quarantined_total",
+			Help: "Counter incremented every time a cluster of a ClusterPool is quarantined.",
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name", "reason"},
+	)
+	// MetricClusterPoolClustersReplacedTotal is a prometheus metric counting the broken clusters deleted by each
+	// ClusterPool to replace them.
+	MetricClusterPoolClustersReplacedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_clusterpool_clusters_replaced_total",
+			Help: "Counter incremented every time a broken unclaimed cluster of a ClusterPool is deleted to replace it.",
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name", "reason"},
+	)
+	// MetricClusterPoolClustersRecycledTotal is a prometheus metric counting the clusters deleted by each ClusterPool
+	// for exceeding its maximum cluster age.
+	MetricClusterPoolClustersRecycledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_clusterpool_clusters_recycled_total",
+			Help: "Counter incremented every time an unclaimed cluster of a ClusterPool is deleted for exceeding the maximum cluster age.",
+		},
+		[]string{"clusterpool_namespace", "clusterpool_name"},
+	)
 	// metricControllerReconcileTime tracks the length of time our reconcile loops take. controller-runtime
 	// technically tracks this for us, but due to bugs currently also includes time in the queue, which leads to
 	// extremely strange results. For now, track our own metric.
@@ -98,6 +166,15 @@ var (
 		},
 		[]string{"controller", "outcome"},
 	)
+	// metricControllerReconcileErrors counts the errors returned by the reconcile loops of controllers by their
+	// class, so that alerts can tell errors of the user from those of cloud providers and of Hive.
+	metricControllerReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_controller_reconcile_errors_total",
+			Help: "Counter incremented every time a controllers reconcile loop returns an error, by error class.",
+		},
+		[]string{"controller", "error_class"},
+	)
 )
 
 // ReconcileOutcome is used in controller "reconcile complete" log entries, and the metricControllerReconcileTime
@@ -126,9 +203,21 @@ func init() {
 	metrics.Registry.MustRegister(metricSelectorSyncSetClustersUnappliedTotal)
 	metrics.Registry.MustRegister(metricSyncSetsTotal)
 	metrics.Registry.MustRegister(metricSyncSetsUnappliedTotal)
+	metrics.Registry.MustRegister(metricSyncSetClustersFailing)
+	metrics.Registry.MustRegister(metricClusterPoolClustersQuarantined)
+	metrics.Registry.MustRegister(metricClusterPoolSize)
+	metrics.Registry.MustRegister(metricClusterPoolClustersProvisioning)
+	metrics.Registry.MustRegister(metricClusterPoolClustersReady)
+	metrics.Registry.MustRegister(metricClusterPoolClustersRunning)
+	metrics.Registry.MustRegister(metricClusterPoolClustersClaimed)
 	metrics.Registry.MustRegister(metricControllerReconcileTime)
+	metrics.Registry.MustRegister(metricControllerReconcileErrors)
 
 	metrics.Registry.MustRegister(MetricClusterDeploymentDeprovisioningUnderwaySeconds)
+	metrics.Registry.MustRegister(MetricClusterPoolClaimFulfillmentSeconds)
+	metrics.Registry.MustRegister(MetricClusterPoolClustersQuarantinedTotal)
+	metrics.Registry.MustRegister(MetricClusterPoolClustersRecycledTotal)
+	metrics.Registry.MustRegister(MetricClusterPoolClustersReplacedTotal)
 }
 
 // Add creates a new metrics Calculator and adds it to the Manager.
@@ -203,6 +292,12 @@ func (mc *Calculator) Start(stopCh <-chan struct{}) error {
 				metricClusterDeploymentsWithConditionTotal,
 				mcLog)
 
+			metricClusterPoolClustersQuarantined.Reset()
+			for k, v := range countQuarantinedPoolClusters(clusterDeployments.Items) {
+				metricClusterPoolClustersQuarantined.WithLabelValues(k.namespace, k.name, string(k.reason)).Set(float64(v))
+			}
+			mc.calculateClusterPoolMetrics(clusterDeployments.Items, mcLog)
+
 			// Also add metrics only for clusters created in last 48h
 			accumulator, err = newClusterAccumulator("48h", []string{"0h", "1h", "2h", "8h", "24h"})
 			if err != nil {
@@ -332,6 +427,126 @@ func (mc *Calculator) calculateSelectorSyncSetMetrics(mcLog log.FieldLogger) {
 	}
 	metricSyncSetsTotal.Set(float64(ssInstancesTotal))
 	metricSyncSetsUnappliedTotal.Set(float64(ssInstancesUnappliedTotal))
+
+	metricSyncSetClustersFailing.Reset()
+	for k, v := range countFailingSyncSets(clusterSyncList.Items) {
+		metricSyncSetClustersFailing.WithLabelValues(k.Namespace, k.Name).Set(float64(v))
+	}
+}
+
+// countFailingSyncSets returns the number of clusters each non-selector SyncSet is failing to apply to. A SyncSet lives
+// in the namespace of its ClusterDeployments, and so in the namespace of the ClusterSyncs that report it.
+func countFailingSyncSets(clusterSyncs []hiveintv1alpha1.ClusterSync) map[types.NamespacedName]int {
+	counts := map[types.NamespacedName]int{}
+	for _, cs := range clusterSyncs {
+		for _, ss := range cs.Status.SyncSets {
+			if ss.Result != hiveintv1alpha1.FailureSyncSetResult {
+				continue
+			}
+			counts[types.NamespacedName{Namespace: cs.Namespace, Name: ss.Name}]++
+		}
+	}
+	return counts
+}
+
+func (mc *Calculator) calculateClusterPoolMetrics(cds []hivev1.ClusterDeployment, mcLog log.FieldLogger) {
+	mcLog.Debug("calculating metrics across all ClusterPools")
+	poolList := &hivev1.ClusterPoolList{}
+	if err := mc.Client.List(context.Background(), poolList); err != nil {
+		mcLog.WithError(err).Error("error listing all ClusterPools")
+		return
+	}
+
+	metricClusterPoolSize.Reset()
+	metricClusterPoolClustersProvisioning.Reset()
+	metricClusterPoolClustersReady.Reset()
+	metricClusterPoolClustersRunning.Reset()
+	metricClusterPoolClustersClaimed.Reset()
+	counts := countPoolClusters(cds)
+	for _, pool := range poolList.Items {
+		size := pool.Spec.Size
+		if pool.Spec.Autoscaling != nil || len(pool.Spec.Schedules) > 0 {
+			size = pool.Status.TargetSize
+		}
+		c := counts[types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}]
+		metricClusterPoolSize.WithLabelValues(pool.Namespace, pool.Name).Set(float64(size))
+		metricClusterPoolClustersProvisioning.WithLabelValues(pool.Namespace, pool.Name).Set(float64(c.provisioning))
+		metricClusterPoolClustersReady.WithLabelValues(pool.Namespace, pool.Name).Set(float64(c.ready))
+		metricClusterPoolClustersRunning.WithLabelValues(pool.Namespace, pool.Name).Set(float64(c.running))
+		metricClusterPoolClustersClaimed.WithLabelValues(pool.Namespace, pool.Name).Set(float64(c.claimed))
+	}
+}
+
+// poolClusterCounts counts the clusters of a ClusterPool by state. Quarantined clusters are counted by
+// countQuarantinedPoolClusters instead.
+type poolClusterCounts struct {
+	provisioning int
+	ready        int
+	running      int
+	claimed      int
+}
+
+func countPoolClusters(cds []hivev1.ClusterDeployment) map[types.NamespacedName]poolClusterCounts {
+	counts := map[types.NamespacedName]poolClusterCounts{}
+	for _, cd := range cds {
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || cd.DeletionTimestamp != nil {
+			continue
+		}
+		key := types.NamespacedName{Namespace: poolRef.Namespace, Name: poolRef.PoolName}
+		c := counts[key]
+		switch {
+		case poolRef.ClaimName != "":
+			c.claimed++
+		case poolRef.Quarantine != nil:
+			continue
+		case !cd.Spec.Installed:
+			c.provisioning++
+		default:
+			c.ready++
+			if isRunning(&cd) {
+				c.running++
+			}
+		}
+		counts[key] = c
+	}
+	return counts
+}
+
+// isRunning returns true if the cluster is meant to be running and has finished resuming.
+func isRunning(cd *hivev1.ClusterDeployment) bool {
+	if cd.Spec.PowerState != hivev1.RunningClusterPowerState {
+		return false
+	}
+	for _, cond := range cd.Status.Conditions {
+		if cond.Type == hivev1.ClusterHibernatingCondition {
+			return cond.Reason == hivev1.RunningHibernationReason
+		}
+	}
+	return false
+}
+
+// quarantinedPoolClustersKey identifies a ClusterPool and quarantine reason for which quarantined clusters are counted.
+type quarantinedPoolClustersKey struct {
+	namespace string
+	name      string
+	reason    hivev1.ClusterPoolQuarantineReason
+}
+
+func countQuarantinedPoolClusters(cds []hivev1.ClusterDeployment) map[quarantinedPoolClustersKey]int {
+	counts := map[quarantinedPoolClustersKey]int{}
+	for _, cd := range cds {
+		poolRef := cd.Spec.ClusterPoolRef
+		if poolRef == nil || poolRef.Quarantine == nil || cd.DeletionTimestamp != nil {
+			continue
+		}
+		counts[quarantinedPoolClustersKey{
+			namespace: poolRef.Namespace,
+			name:      poolRef.PoolName,
+			reason:    poolRef.Quarantine.Reason,
+		}]++
+	}
+	return counts
 }
 
 func processJobs(jobs []batchv1.Job) (runningTotal, succeededTotal, failedTotal map[string]int) {
@@ -606,4 +821,26 @@ func (ro *ReconcileObserver) SetOutcome(outcome ReconcileOutcome) {
 	ro.outcome = outcome
 }
 
+// NewErrorClassifyingReconciler wraps the reconciler of a controller so that the errors returned by its reconcile loop
+// are counted by their class.
+func NewErrorClassifyingReconciler(controllerName hivev1.ControllerName, r reconcile.Reconciler) reconcile.Reconciler {
+	return &errorClassifyingReconciler{
+		Reconciler:     r,
+		controllerName: controllerName,
+	}
+}
+
+type errorClassifyingReconciler struct {
+	reconcile.Reconciler
+	controllerName hivev1.ControllerName
+}
+
+func (r *errorClassifyingReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(request)
+	if err != nil {
+		metricControllerReconcileErrors.WithLabelValues(string(r.controllerName), string(errorclass.Of(err))).Inc()
+	}
+	return result, err
+}
+
 var elapsedDurationBuckets = []time.Duration{2 * time.Minute, time.Minute, 30 * time.Second, 10 * time.Second, 5 * time.Second, time.Second, 0}
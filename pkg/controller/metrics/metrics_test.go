@@ -7,11 +7,13 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/pkg/apis/hiveinternal/v1alpha1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestClusterAccumulator(t *testing.T) {
@@ -159,6 +161,122 @@ func TestInstallJobs(t *testing.T) {
 	assert.Equal(t, 1, failed[hivev1.DefaultClusterType])
 }
 
+func TestCountQuarantinedPoolClusters(t *testing.T) {
+	now := metav1.Now()
+	quarantined := func(name, pool string, reason hivev1.ClusterPoolQuarantineReason) hivev1.ClusterDeployment {
+		cd := testClusterDeployment(name, "managed", now, false)
+		cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+			Namespace: "pools",
+			PoolName:  pool,
+			Quarantine: &hivev1.ClusterPoolQuarantine{
+				Reason: reason,
+				Time:   now,
+			},
+		}
+		return cd
+	}
+	deleted := quarantined("c4", "pool-a", hivev1.ClusterPoolQuarantineProvisionFailed)
+	deleted.DeletionTimestamp = &now
+	cds := []hivev1.ClusterDeployment{
+		quarantined("c1", "pool-a", hivev1.ClusterPoolQuarantineProvisionFailed),
+		quarantined("c2", "pool-a", hivev1.ClusterPoolQuarantineProvisionFailed),
+		quarantined("c3", "pool-b", hivev1.ClusterPoolQuarantineClaimHandoffFailed),
+		deleted,
+		// Cluster not in a pool:
+		testClusterDeployment("c5", "managed", now, true),
+	}
+	counts := countQuarantinedPoolClusters(cds)
+	assert.Equal(t, map[quarantinedPoolClustersKey]int{
+		{namespace: "pools", name: "pool-a", reason: hivev1.ClusterPoolQuarantineProvisionFailed}:    2,
+		{namespace: "pools", name: "pool-b", reason: hivev1.ClusterPoolQuarantineClaimHandoffFailed}: 1,
+	}, counts)
+}
+
+func TestCountPoolClusters(t *testing.T) {
+	now := metav1.Now()
+	poolCluster := func(name, pool, claim string, installed bool) hivev1.ClusterDeployment {
+		cd := testClusterDeployment(name, "managed", now, installed)
+		cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+			Namespace: "pools",
+			PoolName:  pool,
+			ClaimName: claim,
+		}
+		return cd
+	}
+	running := poolCluster("c3", "pool-a", "", true)
+	running.Spec.PowerState = hivev1.RunningClusterPowerState
+	running.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+		Type:   hivev1.ClusterHibernatingCondition,
+		Status: corev1.ConditionFalse,
+		Reason: hivev1.RunningHibernationReason,
+	}}
+	resuming := poolCluster("c4", "pool-a", "", true)
+	resuming.Spec.PowerState = hivev1.RunningClusterPowerState
+	quarantined := poolCluster("c6", "pool-a", "", true)
+	quarantined.Spec.ClusterPoolRef.Quarantine = &hivev1.ClusterPoolQuarantine{
+		Reason: hivev1.ClusterPoolQuarantineResumeFailed,
+		Time:   now,
+	}
+	deleted := poolCluster("c7", "pool-a", "", true)
+	deleted.DeletionTimestamp = &now
+	cds := []hivev1.ClusterDeployment{
+		poolCluster("c1", "pool-a", "", false),
+		poolCluster("c2", "pool-a", "", true),
+		running,
+		resuming,
+		poolCluster("c5", "pool-a", "claim", true),
+		quarantined,
+		deleted,
+		poolCluster("c8", "pool-b", "claim", true),
+		// Cluster not in a pool:
+		testClusterDeployment("c9", "managed", now, true),
+	}
+	counts := countPoolClusters(cds)
+	assert.Equal(t, map[types.NamespacedName]poolClusterCounts{
+		{Namespace: "pools", Name: "pool-a"}: {provisioning: 1, ready: 3, running: 1, claimed: 1},
+		{Namespace: "pools", Name: "pool-b"}: {claimed: 1},
+	}, counts)
+}
+
+func TestCountFailingSyncSets(t *testing.T) {
+	clusterSync := func(namespace, name string, syncSets ...hiveintv1alpha1.SyncStatus) hiveintv1alpha1.ClusterSync {
+		return hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status: hiveintv1alpha1.ClusterSyncStatus{
+				SyncSets: syncSets,
+				SelectorSyncSets: []hiveintv1alpha1.SyncStatus{
+					{Name: "sss", Result: hiveintv1alpha1.FailureSyncSetResult},
+				},
+			},
+		}
+	}
+	syncStatus := func(name string, result hiveintv1alpha1.SyncSetResult) hiveintv1alpha1.SyncStatus {
+		return hiveintv1alpha1.SyncStatus{Name: name, Result: result}
+	}
+	clusterSyncs := []hiveintv1alpha1.ClusterSync{
+		clusterSync("ns1", "c1",
+			syncStatus("ss1", hiveintv1alpha1.FailureSyncSetResult),
+			syncStatus("ss2", hiveintv1alpha1.SuccessSyncSetResult),
+		),
+		clusterSync("ns1", "c2",
+			syncStatus("ss1", hiveintv1alpha1.FailureSyncSetResult),
+			syncStatus("ss2", hiveintv1alpha1.FailureSyncSetResult),
+		),
+		clusterSync("ns2", "c3",
+			syncStatus("ss1", hiveintv1alpha1.FailureSyncSetResult),
+		),
+		clusterSync("ns3", "c4",
+			syncStatus("ss1", hiveintv1alpha1.SuccessSyncSetResult),
+		),
+	}
+	counts := countFailingSyncSets(clusterSyncs)
+	assert.Equal(t, map[types.NamespacedName]int{
+		{Namespace: "ns1", Name: "ss1"}: 2,
+		{Namespace: "ns1", Name: "ss2"}: 1,
+		{Namespace: "ns2", Name: "ss1"}: 1,
+	}, counts)
+}
+
 func testClusterDeployment(name, clusterType string, created metav1.Time, installed bool) hivev1.ClusterDeployment {
 	return hivev1.ClusterDeployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -54,7 +54,7 @@ func (cc provisioningUnderwayCollector) Collect(ch chan<- prometheus.Metric) {
 		condition, reason := "Unknown", "Unknown"
 		for _, delayCondition := range provisioningDelayCondition {
 			if cdCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions,
-				delayCondition); cdCondition != nil {
+				delayCondition); controllerutils.IsClusterDeploymentConditionSet(cdCondition) {
 				condition = string(delayCondition)
 				if cdCondition.Reason != "" {
 					reason = cdCondition.Reason
@@ -0,0 +1,408 @@
+// Package postinstalljob provides a controller which runs the post-install jobs of a ClusterDeployment on the
+// cluster once it is installed.
+package postinstalljob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.PostInstallJobControllerName
+
+	// Namespace is the namespace on the cluster in which the post-install jobs run.
+	Namespace = "openshift-hive-post-install"
+
+	serviceAccountName = "post-install"
+	clusterRoleBinding = "hive-post-install"
+
+	// defaultTimeout is how long a post-install job may run when the job does not set a timeout.
+	defaultTimeout = time.Hour
+
+	// jobCheckInterval is how often the state of a running post-install job is checked.
+	jobCheckInterval = 30 * time.Second
+
+	jobsRunningReason   = "JobRunning"
+	jobsFailedReason    = "JobFailed"
+	jobsSucceededReason = "AllJobsSucceeded"
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new PostInstallJob controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	logger := log.WithField("controller", ControllerName)
+	r := &ReconcilePostInstallJob{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		applier: resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("postinstalljob-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePostInstallJob{}
+
+// ReconcilePostInstallJob runs the post-install jobs of a ClusterDeployment on the cluster.
+type ReconcilePostInstallJob struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile starts the next post-install job of an installed cluster once the previous one has succeeded, and
+// records the state of the jobs in the status of the ClusterDeployment.
+//
+// The jobs are applied to the cluster through a SyncSet, and their state is read from the cluster.
+func (r *ReconcilePostInstallJob) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed || controllerutils.IsDetached(cd) {
+		return reconcile.Result{}, nil
+	}
+	if len(cd.Spec.PostInstallJobs) == 0 && len(cd.Status.PostInstallJobs) == 0 &&
+		!controllerutils.IsClusterDeploymentConditionSet(controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PostInstallJobsNotCompleteCondition)) {
+		cdLog.Debug("cluster has no post-install jobs")
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := cd.Status.DeepCopy()
+
+	// Carry over the state of the jobs that are still in the spec, in the order of the spec, up to the first job
+	// that has not succeeded yet.
+	previous := map[string]hivev1.PostInstallJobStatus{}
+	for _, s := range cd.Status.PostInstallJobs {
+		previous[s.Name] = s
+	}
+	var statuses []hivev1.PostInstallJobStatus
+	var current *hivev1.PostInstallJob
+	for i, job := range cd.Spec.PostInstallJobs {
+		s, ok := previous[job.Name]
+		if ok && s.State == hivev1.PostInstallJobSucceeded {
+			statuses = append(statuses, s)
+			continue
+		}
+		if !ok {
+			cdLog.WithField("job", job.Name).Info("starting post-install job")
+			s = hivev1.PostInstallJobStatus{
+				Name:      job.Name,
+				State:     hivev1.PostInstallJobRunning,
+				Message:   "Waiting for the job to be created on the cluster",
+				StartTime: metav1.Now(),
+			}
+		}
+		statuses = append(statuses, s)
+		current = &cd.Spec.PostInstallJobs[i]
+		break
+	}
+	cd.Status.PostInstallJobs = statuses
+
+	result := reconcile.Result{}
+	if current == nil {
+		if err := r.deleteSyncSet(cd); err != nil {
+			cdLog.WithError(err).Error("could not delete post-install jobs syncset")
+			return reconcile.Result{}, err
+		}
+	} else {
+		jobLog := cdLog.WithField("job", current.Name)
+		syncSet, err := r.generateSyncSet(cd, current)
+		if err != nil {
+			jobLog.WithError(err).Error("could not generate post-install jobs syncset")
+			return reconcile.Result{}, err
+		}
+		if _, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+			jobLog.WithError(err).Error("could not apply post-install jobs syncset")
+			return reconcile.Result{}, err
+		}
+
+		remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
+			cd,
+			r.remoteClusterAPIClientBuilder(cd),
+			r.Client,
+			jobLog,
+		)
+		if unreachable {
+			// The state of the job is recorded once the cluster is reachable again.
+			return reconcile.Result{Requeue: requeue}, nil
+		}
+		s := &cd.Status.PostInstallJobs[len(cd.Status.PostInstallJobs)-1]
+		if err := updateJobStatus(remoteClient, s, jobLog); err != nil {
+			jobLog.WithError(err).Error("could not get state of post-install job")
+			return reconcile.Result{}, err
+		}
+		switch s.State {
+		case hivev1.PostInstallJobSucceeded:
+			// Start the next job.
+			result.Requeue = true
+		case hivev1.PostInstallJobRunning:
+			result.RequeueAfter = jobCheckInterval
+		}
+	}
+
+	status, reason, message := jobsCondition(cd.Status.PostInstallJobs, cd.Spec.PostInstallJobs)
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PostInstallJobsNotCompleteCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.updateStatus(cd, origStatus, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+func (r *ReconcilePostInstallJob) updateStatus(cd *hivev1.ClusterDeployment, origStatus *hivev1.ClusterDeploymentStatus, logger log.FieldLogger) error {
+	if equality.Semantic.DeepEqual(origStatus, &cd.Status) {
+		logger.Debug("post-install jobs status unchanged")
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update post-install jobs status")
+		return err
+	}
+	return nil
+}
+
+// updateJobStatus updates the status of a started post-install job from the state of its Job on the cluster. A
+// failed job goes back to running when its Job is deleted from the cluster, as the SyncSet then creates it again.
+func updateJobStatus(remoteClient client.Client, s *hivev1.PostInstallJobStatus, logger log.FieldLogger) error {
+	job := &batchv1.Job{}
+	switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: Namespace, Name: s.Name}, job); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("post-install job has not been created on the cluster yet")
+		s.State = hivev1.PostInstallJobRunning
+		s.Message = "Waiting for the job to be created on the cluster"
+		return nil
+	case err != nil:
+		return err
+	}
+
+	switch {
+	case controllerutils.IsFailed(job):
+		logger.Warn("post-install job failed")
+		s.State = hivev1.PostInstallJobFailed
+		s.Message = fmt.Sprintf("Job %s/%s on the cluster failed: %s. Delete the job to retry.", Namespace, s.Name, jobFailureMessage(job))
+	case controllerutils.IsSuccessful(job):
+		logger.Info("post-install job succeeded")
+		now := metav1.Now()
+		s.State = hivev1.PostInstallJobSucceeded
+		s.Message = ""
+		s.CompletionTime = &now
+	default:
+		logger.Debug("post-install job is still running")
+		s.State = hivev1.PostInstallJobRunning
+		s.Message = "The job is running on the cluster"
+	}
+	return nil
+}
+
+func jobFailureMessage(job *batchv1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Message != "" {
+			return cond.Message
+		}
+	}
+	return "unknown reason"
+}
+
+// jobsCondition returns the status, reason and message of the PostInstallJobsNotComplete condition.
+func jobsCondition(statuses []hivev1.PostInstallJobStatus, jobs []hivev1.PostInstallJob) (corev1.ConditionStatus, string, string) {
+	for i, s := range statuses {
+		switch s.State {
+		case hivev1.PostInstallJobFailed:
+			return corev1.ConditionTrue, jobsFailedReason, fmt.Sprintf("Post-install job %s failed: %s", s.Name, s.Message)
+		case hivev1.PostInstallJobRunning:
+			return corev1.ConditionTrue, jobsRunningReason, fmt.Sprintf("Post-install job %s (%d of %d) is running", s.Name, i+1, len(jobs))
+		}
+	}
+	if len(statuses) < len(jobs) {
+		next := len(statuses)
+		return corev1.ConditionTrue, jobsRunningReason, fmt.Sprintf("Post-install job %s (%d of %d) is starting", jobs[next].Name, next+1, len(jobs))
+	}
+	return corev1.ConditionFalse, jobsSucceededReason, "All post-install jobs have succeeded"
+}
+
+// SyncSetName returns the name of the SyncSet that runs the post-install jobs of a cluster.
+func SyncSetName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "post-install")
+}
+
+func (r *ReconcilePostInstallJob) deleteSyncSet(cd *hivev1.ClusterDeployment) error {
+	syncSet := &hivev1.SyncSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: SyncSetName(cd.Name)}, syncSet); {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	if err := r.Delete(context.TODO(), syncSet); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcilePostInstallJob) generateSyncSet(cd *hivev1.ClusterDeployment, job *hivev1.PostInstallJob) (*hivev1.SyncSet, error) {
+	timeout := defaultTimeout
+	if job.Timeout != nil {
+		timeout = job.Timeout.Duration
+	}
+	resources := []runtime.RawExtension{
+		{
+			Object: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: Namespace},
+			},
+		},
+		{
+			Object: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: serviceAccountName},
+			},
+		},
+		{
+			Object: &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleBinding},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     "cluster-admin",
+				},
+				Subjects: []rbacv1.Subject{{
+					Kind:      rbacv1.ServiceAccountKind,
+					Namespace: Namespace,
+					Name:      serviceAccountName,
+				}},
+			},
+		},
+		{
+			Object: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: job.Name},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:          pointer.Int32Ptr(3),
+					ActiveDeadlineSeconds: pointer.Int64Ptr(int64(timeout.Seconds())),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							ServiceAccountName: serviceAccountName,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							Containers: []corev1.Container{{
+								Name:    "job",
+								Image:   job.Image,
+								Command: job.Command,
+								Args:    job.Args,
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	resources, err := controllerutils.AddTypeMeta(resources, r.scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot add typemeta to post-install jobs syncset resources")
+	}
+
+	// Upsert leaves the jobs that have already succeeded on the cluster when the job in the syncset is replaced.
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SyncSetName(cd.Name),
+			Namespace: cd.Namespace,
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Resources:         resources,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypePostInstallJobs)
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "error setting owner reference on post-install jobs syncset")
+	}
+	return syncSet, nil
+}
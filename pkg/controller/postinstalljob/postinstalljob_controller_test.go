@@ -0,0 +1,361 @@
+package postinstalljob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	"github.com/openshift/hive/pkg/resource"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	testName      = "test-cluster"
+	testNamespace = "test-namespace"
+)
+
+var (
+	testJobs = []hivev1.PostInstallJob{
+		{Name: "register", Image: "example.com/agent:latest", Args: []string{"--register"}},
+		{Name: "scan", Image: "example.com/scanner:latest", Timeout: &metav1.Duration{Duration: 10 * time.Minute}},
+	}
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcilePostInstallJob(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		remote              []runtime.Object
+		expectBuild         bool
+		expectSyncSetJob    string
+		expectSyncSetDelete bool
+		expectResult        reconcile.Result
+		expectStates        []hivev1.PostInstallJobState
+		expectCondition     corev1.ConditionStatus
+		expectReason        string
+	}{
+		{
+			name: "no post-install jobs",
+			cd:   testClusterDeployment(),
+		},
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(withJobs(testJobs...))
+				cd.Spec.Installed = false
+				return cd
+			}(),
+		},
+		{
+			name:             "start first job",
+			cd:               testClusterDeployment(withJobs(testJobs...)),
+			expectBuild:      true,
+			expectSyncSetJob: "register",
+			expectResult:     reconcile.Result{RequeueAfter: jobCheckInterval},
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobRunning},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsRunningReason,
+		},
+		{
+			name: "first job running",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobRunning),
+			),
+			remote:           []runtime.Object{testJob("register", "")},
+			expectBuild:      true,
+			expectSyncSetJob: "register",
+			expectResult:     reconcile.Result{RequeueAfter: jobCheckInterval},
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobRunning},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsRunningReason,
+		},
+		{
+			name: "first job succeeded",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobRunning),
+			),
+			remote:           []runtime.Object{testJob("register", batchv1.JobComplete)},
+			expectBuild:      true,
+			expectSyncSetJob: "register",
+			expectResult:     reconcile.Result{Requeue: true},
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobSucceeded},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsRunningReason,
+		},
+		{
+			name: "start second job",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobSucceeded),
+			),
+			expectBuild:      true,
+			expectSyncSetJob: "scan",
+			expectResult:     reconcile.Result{RequeueAfter: jobCheckInterval},
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobSucceeded, hivev1.PostInstallJobRunning},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsRunningReason,
+		},
+		{
+			name: "second job failed",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobSucceeded),
+				withJobStatus("scan", hivev1.PostInstallJobRunning),
+			),
+			remote:           []runtime.Object{testJob("scan", batchv1.JobFailed)},
+			expectBuild:      true,
+			expectSyncSetJob: "scan",
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobSucceeded, hivev1.PostInstallJobFailed},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsFailedReason,
+		},
+		{
+			name: "failed job deleted to retry",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobFailed),
+			),
+			expectBuild:      true,
+			expectSyncSetJob: "register",
+			expectResult:     reconcile.Result{RequeueAfter: jobCheckInterval},
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobRunning},
+			expectCondition:  corev1.ConditionTrue,
+			expectReason:     jobsRunningReason,
+		},
+		{
+			name: "all jobs succeeded",
+			cd: testClusterDeployment(
+				withJobs(testJobs...),
+				withJobStatus("register", hivev1.PostInstallJobSucceeded),
+				withJobStatus("scan", hivev1.PostInstallJobSucceeded),
+				withNotCompleteCondition(),
+			),
+			existing:            []runtime.Object{testSyncSet()},
+			expectSyncSetDelete: true,
+			expectStates:        []hivev1.PostInstallJobState{hivev1.PostInstallJobSucceeded, hivev1.PostInstallJobSucceeded},
+			expectCondition:     corev1.ConditionFalse,
+			expectReason:        jobsSucceededReason,
+		},
+		{
+			name: "removed job is dropped from status",
+			cd: testClusterDeployment(
+				withJobs(testJobs[1]),
+				withJobStatus("register", hivev1.PostInstallJobFailed),
+				withJobStatus("scan", hivev1.PostInstallJobSucceeded),
+				withNotCompleteCondition(),
+			),
+			expectSyncSetDelete: true,
+			expectStates:        []hivev1.PostInstallJobState{hivev1.PostInstallJobSucceeded},
+			expectCondition:     corev1.ConditionFalse,
+			expectReason:        jobsSucceededReason,
+		},
+		{
+			name: "unreachable",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(
+					withJobs(testJobs...),
+					withJobStatus("register", hivev1.PostInstallJobRunning),
+				)
+				cd.Status.Conditions[0].Status = corev1.ConditionTrue
+				return cd
+			}(),
+			expectSyncSetJob: "register",
+			expectStates:     []hivev1.PostInstallJobState{hivev1.PostInstallJobRunning},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, test.cd)...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectBuild {
+				mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme, test.remote...), nil)
+			}
+			a := &fakeApplier{}
+			r := &ReconcilePostInstallJob{
+				Client:                        c,
+				scheme:                        scheme.Scheme,
+				applier:                       a,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			key := types.NamespacedName{Namespace: testNamespace, Name: testName}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectResult, result, "unexpected reconcile result")
+
+			if test.expectSyncSetJob != "" {
+				if assert.Len(t, a.appliedObjects, 1, "expected a syncset to be applied") {
+					ss := a.appliedObjects[0].(*hivev1.SyncSet)
+					assert.Equal(t, SyncSetName(testName), ss.Name)
+					assert.Equal(t, hivev1.UpsertResourceApplyMode, ss.Spec.ResourceApplyMode)
+					job := ss.Spec.Resources[len(ss.Spec.Resources)-1].Object.(*batchv1.Job)
+					assert.Equal(t, test.expectSyncSetJob, job.Name, "unexpected job in syncset")
+					assert.Equal(t, Namespace, job.Namespace, "unexpected namespace of job")
+					for _, j := range testJobs {
+						if j.Name == job.Name {
+							assert.Equal(t, j.Image, job.Spec.Template.Spec.Containers[0].Image, "unexpected image")
+							assert.Equal(t, j.Args, job.Spec.Template.Spec.Containers[0].Args, "unexpected args")
+						}
+					}
+				}
+			} else {
+				assert.Empty(t, a.appliedObjects, "expected no syncset to be applied")
+			}
+			if test.expectSyncSetDelete {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: SyncSetName(testName)}, &hivev1.SyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected syncset to be deleted")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), key, cd))
+			states := []hivev1.PostInstallJobState{}
+			for _, s := range cd.Status.PostInstallJobs {
+				states = append(states, s.State)
+				if s.State == hivev1.PostInstallJobSucceeded {
+					assert.NotNil(t, s.CompletionTime, "expected completion time for succeeded job %s", s.Name)
+				}
+			}
+			if test.expectStates == nil {
+				assert.Empty(t, states, "expected no post-install jobs in status")
+			} else {
+				assert.Equal(t, test.expectStates, states, "unexpected post-install job states")
+			}
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PostInstallJobsNotCompleteCondition)
+			if test.expectCondition == "" {
+				assert.Nil(t, cond, "expected no post-install jobs condition")
+			} else if assert.NotNil(t, cond, "expected post-install jobs condition") {
+				assert.Equal(t, test.expectCondition, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
+
+func TestGenerateSyncSetTimeout(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	r := &ReconcilePostInstallJob{scheme: scheme.Scheme}
+	cd := testClusterDeployment(withJobs(testJobs...))
+	for i, expected := range []int64{int64(defaultTimeout.Seconds()), 600} {
+		ss, err := r.generateSyncSet(cd, &cd.Spec.PostInstallJobs[i])
+		require.NoError(t, err)
+		job := ss.Spec.Resources[len(ss.Spec.Resources)-1].Object.(*batchv1.Job)
+		if assert.NotNil(t, job.Spec.ActiveDeadlineSeconds, "expected a deadline on the job") {
+			assert.Equal(t, expected, *job.Spec.ActiveDeadlineSeconds, "unexpected deadline for job %s", job.Name)
+		}
+	}
+}
+
+type clusterDeploymentOption func(*hivev1.ClusterDeployment)
+
+func withJobs(jobs ...hivev1.PostInstallJob) clusterDeploymentOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.PostInstallJobs = jobs
+	}
+}
+
+func withNotCompleteCondition() clusterDeploymentOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.PostInstallJobsNotCompleteCondition,
+			Status: corev1.ConditionTrue,
+			Reason: jobsRunningReason,
+		})
+	}
+}
+
+func withJobStatus(name string, state hivev1.PostInstallJobState) clusterDeploymentOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		s := hivev1.PostInstallJobStatus{
+			Name:      name,
+			State:     state,
+			StartTime: metav1.Now(),
+		}
+		if state == hivev1.PostInstallJobSucceeded {
+			now := metav1.Now()
+			s.CompletionTime = &now
+		}
+		cd.Status.PostInstallJobs = append(cd.Status.PostInstallJobs, s)
+	}
+}
+
+func testClusterDeployment(opts ...clusterDeploymentOption) *hivev1.ClusterDeployment {
+	cd := testcd.FullBuilder(testNamespace, testName, scheme.Scheme).Build(
+		testcd.Installed(),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	cd.UID = types.UID("test-uid")
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func testJob(name string, condition batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: Namespace,
+			Name:      name,
+		},
+	}
+	if condition != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:    condition,
+			Status:  corev1.ConditionTrue,
+			Message: "test message",
+		}}
+	}
+	return job
+}
+
+func testSyncSet() *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      SyncSetName(testName),
+		},
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
@@ -25,6 +25,7 @@ import (
 	installertypesaws "github.com/openshift/installer/pkg/types/aws"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -57,12 +58,16 @@ func NewAWSActuator(
 	client client.Client,
 	awsCreds *corev1.Secret,
 	region string,
+	serviceEndpoints []hivev1aws.ServiceEndpoint,
 	pool *hivev1.MachinePool,
 	masterMachine *machineapi.Machine,
 	scheme *runtime.Scheme,
 	logger log.FieldLogger,
 ) (*AWSActuator, error) {
-	awsClient, err := awsclient.NewClientFromSecret(awsCreds, region)
+	awsClient, err := awsclient.NewClientFromSecretWithOptions(awsCreds, awsclient.Options{
+		Region:           region,
+		ServiceEndpoints: serviceEndpoints,
+	})
 	if err != nil {
 		logger.WithError(err).Warn("failed to create AWS client")
 		return nil, err
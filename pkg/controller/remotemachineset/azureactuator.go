@@ -59,7 +59,11 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			Azure: &installertypesazure.Platform{
-				Region: cd.Spec.Platform.Azure.Region,
+				Region:                   cd.Spec.Platform.Azure.Region,
+				NetworkResourceGroupName: cd.Spec.Platform.Azure.NetworkResourceGroupName,
+				VirtualNetwork:           cd.Spec.Platform.Azure.VirtualNetwork,
+				ControlPlaneSubnet:       cd.Spec.Platform.Azure.ControlPlaneSubnet,
+				ComputeSubnet:            cd.Spec.Platform.Azure.ComputeSubnet,
 			},
 		},
 	}
@@ -26,6 +26,7 @@ func TestAzureActuator(t *testing.T) {
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		existingVirtualNetwork     bool
 		expectedErr                bool
 	}{
 		{
@@ -99,6 +100,25 @@ func TestAzureActuator(t *testing.T) {
 				generateAzureMachineSetName("zone5"): 0,
 			},
 		},
+		{
+			name: "generate machinesets in existing virtual network",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testAzureClusterDeployment()
+				cd.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				cd.Spec.Platform.Azure.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			pool: testAzurePool(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			existingVirtualNetwork: true,
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -133,6 +153,14 @@ func TestAzureActuator(t *testing.T) {
 			} else {
 				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
 			}
+			if test.existingVirtualNetwork {
+				for _, ms := range generatedMachineSets {
+					azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+					assert.Equal(t, "network-rg", azureProvider.NetworkResourceGroup, "unexpected network resource group")
+					assert.Equal(t, "existing-vnet", azureProvider.Vnet, "unexpected virtual network")
+					assert.Equal(t, "compute-subnet", azureProvider.Subnet, "unexpected subnet")
+				}
+			}
 		})
 	}
 }
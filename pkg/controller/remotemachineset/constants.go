@@ -3,4 +3,11 @@ package remotemachineset
 const (
 	// workerRole is used to locate installer created cloud resources such as subnets.
 	workerRole = "worker"
+
+	// machineAPINamespace is the namespace of the MachineSets in the remote cluster.
+	machineAPINamespace = "openshift-machine-api"
+
+	// openStackCloudsSecret is the secret in the remote cluster with the clouds.yaml used by the OpenStack machine
+	// controller. The installer creates it with a single cloud named installosp.CloudName.
+	openStackCloudsSecret = "openstack-cloud-credentials"
 )
@@ -160,8 +160,11 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
 			GCP: &installertypesgcp.Platform{
-				Region:    cd.Spec.Platform.GCP.Region,
-				ProjectID: a.projectID,
+				Region:             cd.Spec.Platform.GCP.Region,
+				ProjectID:          a.projectID,
+				Network:            cd.Spec.Platform.GCP.Network,
+				ControlPlaneSubnet: cd.Spec.Platform.GCP.ControlPlaneSubnet,
+				ComputeSubnet:      cd.Spec.Platform.GCP.ComputeSubnet,
 			},
 		},
 	}
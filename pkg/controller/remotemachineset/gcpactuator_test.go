@@ -42,6 +42,7 @@ func TestGCPActuator(t *testing.T) {
 		existing                        []runtime.Object
 		mockGCPClient                   func(*mockgcp.MockClient)
 		setupPendingCreationExpectation bool
+		existingNetwork                 bool
 
 		expectedMachineSetReplicas map[string]int64
 		expectedErr                bool
@@ -118,6 +119,18 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("r", "zone3"): 1,
 			},
 		},
+		{
+			name: "generate machinesets in existing network",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+				return pool
+			}(),
+			existingNetwork: true,
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
 		{
 			name:                            "no lease pending create expectation",
 			pool:                            testGCPPool(testPoolName),
@@ -135,6 +148,11 @@ func TestGCPActuator(t *testing.T) {
 
 			gClient := mockgcp.NewMockClient(mockCtrl)
 			clusterDeployment := testGCPClusterDeployment(testName, testInfraID)
+			if test.existingNetwork {
+				clusterDeployment.Spec.Platform.GCP.Network = "existing-network"
+				clusterDeployment.Spec.Platform.GCP.ControlPlaneSubnet = "control-plane-subnet"
+				clusterDeployment.Spec.Platform.GCP.ComputeSubnet = "compute-subnet"
+			}
 
 			logger := log.WithField("actuator", "gcpactuator")
 			controllerExpectations := controllerutils.NewExpectations(logger)
@@ -169,6 +187,15 @@ func TestGCPActuator(t *testing.T) {
 				assert.Error(t, err, "expected error for test case")
 			} else {
 				validateGCPMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				for _, ms := range generatedMachineSets {
+					gcpProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpprovider.GCPMachineProviderSpec)
+					if test.existingNetwork {
+						assert.Equal(t, "existing-network", gcpProvider.NetworkInterfaces[0].Network, "unexpected network")
+						assert.Equal(t, "compute-subnet", gcpProvider.NetworkInterfaces[0].Subnetwork, "unexpected subnetwork")
+					} else {
+						assert.Equal(t, testInfraID+"-network", gcpProvider.NetworkInterfaces[0].Network, "unexpected network")
+					}
+				}
 			}
 		})
 	}
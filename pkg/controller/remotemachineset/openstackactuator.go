@@ -6,6 +6,8 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	openstackprovider "sigs.k8s.io/cluster-api-provider-openstack/pkg/apis"
@@ -13,10 +15,9 @@ import (
 
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 	installosp "github.com/openshift/installer/pkg/asset/machines/openstack"
-	installertypes "github.com/openshift/installer/pkg/types"
-	installertypesosp "github.com/openshift/installer/pkg/types/openstack"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1osp "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 )
 
 // OpenStackActuator encapsulates the pieces necessary to be able to generate
@@ -59,42 +60,139 @@ func (a *OpenStackActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, po
 		return nil, false, errors.New("MachinePool is not for OpenStack")
 	}
 
-	computePool := baseMachinePool(pool)
-	computePool.Platform.OpenStack = &installertypesosp.MachinePool{
-		FlavorName: pool.Spec.Platform.OpenStack.Flavor,
+	return generateOpenStackMachineSets(
+		cd.Spec.ClusterMetadata.InfraID,
+		cd.Spec.Platform.OpenStack,
+		pool,
+		a.osImage,
+	), true, nil
+}
+
+// generateOpenStackMachineSets returns the MachineSets of the machine pool, one for each availability zone. The
+// MachineSets match the ones generated by the installer. They are not generated with the installer because it queries
+// the OpenStack cloud for trunk support, which Hive takes from the ClusterDeployment instead.
+func generateOpenStackMachineSets(infraID string, platform *hivev1osp.Platform, pool *hivev1.MachinePool, osImage string) []*machineapi.MachineSet {
+	zones := pool.Spec.Platform.OpenStack.Zones
+	if len(zones) == 0 {
+		// An empty zone is the Nova default availability zone.
+		zones = []string{""}
+	}
+
+	total := int32(0)
+	if pool.Spec.Replicas != nil {
+		total = int32(*pool.Spec.Replicas)
 	}
+	numOfAZs := int32(len(zones))
 
-	if pool.Spec.Platform.OpenStack.RootVolume != nil {
-		computePool.Platform.OpenStack.RootVolume = &installertypesosp.RootVolume{
-			Size: pool.Spec.Platform.OpenStack.RootVolume.Size,
-			Type: pool.Spec.Platform.OpenStack.RootVolume.Type,
+	machineSets := make([]*machineapi.MachineSet, 0, len(zones))
+	for idx, az := range zones {
+		replicas := int32(total / numOfAZs)
+		if int32(idx) < total%numOfAZs {
+			replicas++
 		}
+		name := fmt.Sprintf("%s-%s-%d", infraID, pool.Spec.Name, idx)
+		machineSets = append(machineSets, &machineapi.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: machineapi.SchemeGroupVersion.String(),
+				Kind:       "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machineAPINamespace,
+				Name:      name,
+				Labels: map[string]string{
+					"machine.openshift.io/cluster-api-cluster":      infraID,
+					"machine.openshift.io/cluster-api-machine-role": workerRole,
+					"machine.openshift.io/cluster-api-machine-type": workerRole,
+				},
+			},
+			Spec: machineapi.MachineSetSpec{
+				Replicas: &replicas,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset": name,
+						"machine.openshift.io/cluster-api-cluster":    infraID,
+					},
+				},
+				Template: machineapi.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"machine.openshift.io/cluster-api-machineset":   name,
+							"machine.openshift.io/cluster-api-cluster":      infraID,
+							"machine.openshift.io/cluster-api-machine-role": workerRole,
+							"machine.openshift.io/cluster-api-machine-type": workerRole,
+						},
+					},
+					Spec: machineapi.MachineSpec{
+						ProviderSpec: machineapi.ProviderSpec{
+							Value: &runtime.RawExtension{
+								Object: generateOpenStackProviderSpec(infraID, platform, pool.Spec.Platform.OpenStack, osImage, az),
+							},
+						},
+					},
+				},
+			},
+		})
 	}
+	return machineSets
+}
 
-	// Fake an install config as we do with other actuators. We only populate what we know is needed today.
-	// WARNING: changes to use more of installconfig in the MachineSets function can break here. Hopefully
-	// will be caught by unit tests.
-	ic := &installertypes.InstallConfig{
-		Platform: installertypes.Platform{
-			OpenStack: &installertypesosp.Platform{
-				Cloud: cd.Spec.Platform.OpenStack.Cloud,
+func generateOpenStackProviderSpec(infraID string, platform *hivev1osp.Platform, mpool *hivev1osp.MachinePool, osImage, az string) *openstackproviderv1alpha1.OpenstackProviderSpec {
+	networks := []openstackproviderv1alpha1.NetworkParam{{
+		Subnets: []openstackproviderv1alpha1.SubnetParam{{
+			Filter: openstackproviderv1alpha1.SubnetFilter{
+				Name: fmt.Sprintf("%s-nodes", infraID),
+				Tags: fmt.Sprintf("openshiftClusterID=%s", infraID),
 			},
-		},
+		}},
+	}}
+	for _, networkID := range mpool.AdditionalNetworkIDs {
+		networks = append(networks, openstackproviderv1alpha1.NetworkParam{
+			UUID:                  networkID,
+			NoAllowedAddressPairs: true,
+		})
 	}
 
-	installerMachineSets, err := installosp.MachineSets(
-		cd.Spec.ClusterMetadata.InfraID,
-		ic,
-		computePool,
-		a.osImage,
-		workerRole,
-		workerUserDataName,
-	)
-	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	securityGroups := []openstackproviderv1alpha1.SecurityGroupParam{{
+		Name: fmt.Sprintf("%s-%s", infraID, workerRole),
+	}}
+	for _, securityGroupID := range mpool.AdditionalSecurityGroupIDs {
+		securityGroups = append(securityGroups, openstackproviderv1alpha1.SecurityGroupParam{
+			UUID: securityGroupID,
+		})
 	}
 
-	return installerMachineSets, true, nil
+	spec := &openstackproviderv1alpha1.OpenstackProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: openstackproviderv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "OpenstackProviderSpec",
+		},
+		Flavor:           mpool.Flavor,
+		CloudName:        installosp.CloudName,
+		CloudsSecret:     &corev1.SecretReference{Name: openStackCloudsSecret, Namespace: machineAPINamespace},
+		UserDataSecret:   &corev1.SecretReference{Name: workerUserDataName},
+		Networks:         networks,
+		AvailabilityZone: az,
+		SecurityGroups:   securityGroups,
+		Trunk:            platform.TrunkSupport,
+		Tags: []string{
+			fmt.Sprintf("openshiftClusterID=%s", infraID),
+		},
+		ServerMetadata: map[string]string{
+			"Name":               fmt.Sprintf("%s-%s", infraID, workerRole),
+			"openshiftClusterID": infraID,
+		},
+	}
+	if mpool.RootVolume != nil {
+		spec.RootVolume = &openstackproviderv1alpha1.RootVolume{
+			Size:       mpool.RootVolume.Size,
+			SourceType: "image",
+			SourceUUID: osImage,
+			VolumeType: mpool.RootVolume.Type,
+		}
+	} else {
+		spec.Image = osImage
+	}
+	return spec
 }
 
 // Get the OS image from an existing master machine.
@@ -18,13 +18,16 @@ import (
 	hivev1osp "github.com/openshift/hive/pkg/apis/hive/v1/openstack"
 )
 
-// This test is broken! The installer now checks for trunk support by querying the OpenStack service.
-func BROKEN__TestOpenStackActuator(t *testing.T) {
+func TestOpenStackActuator(t *testing.T) {
 	tests := []struct {
 		name                       string
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		expectedZones              map[string]string
+		expectedTrunk              bool
+		expectedNetworks           int
+		expectedSecurityGroups     int
 		expectedErr                bool
 	}{
 		{
@@ -32,8 +35,55 @@ func BROKEN__TestOpenStackActuator(t *testing.T) {
 			clusterDeployment: testOSPClusterDeployment(),
 			pool:              testOSPPool(),
 			expectedMachineSetReplicas: map[string]int64{
-				fmt.Sprintf("%s-worker", testInfraID): 3,
+				fmt.Sprintf("%s-worker-0", testInfraID): 3,
 			},
+			expectedZones: map[string]string{
+				fmt.Sprintf("%s-worker-0", testInfraID): "",
+			},
+			expectedNetworks:       1,
+			expectedSecurityGroups: 1,
+		},
+		{
+			name:              "generate machinesets across zones",
+			clusterDeployment: testOSPClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testOSPPool()
+				p.Spec.Platform.OpenStack.Zones = []string{"zone1", "zone2"}
+				return p
+			}(),
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-0", testInfraID): 2,
+				fmt.Sprintf("%s-worker-1", testInfraID): 1,
+			},
+			expectedZones: map[string]string{
+				fmt.Sprintf("%s-worker-0", testInfraID): "zone1",
+				fmt.Sprintf("%s-worker-1", testInfraID): "zone2",
+			},
+			expectedNetworks:       1,
+			expectedSecurityGroups: 1,
+		},
+		{
+			name: "trunk support and additional networks",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testOSPClusterDeployment()
+				cd.Spec.Platform.OpenStack.TrunkSupport = true
+				return cd
+			}(),
+			pool: func() *hivev1.MachinePool {
+				p := testOSPPool()
+				p.Spec.Platform.OpenStack.AdditionalNetworkIDs = []string{"net1", "net2"}
+				p.Spec.Platform.OpenStack.AdditionalSecurityGroupIDs = []string{"sg1"}
+				return p
+			}(),
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-0", testInfraID): 3,
+			},
+			expectedZones: map[string]string{
+				fmt.Sprintf("%s-worker-0", testInfraID): "",
+			},
+			expectedTrunk:          true,
+			expectedNetworks:       3,
+			expectedSecurityGroups: 2,
 		},
 	}
 
@@ -44,7 +94,8 @@ func BROKEN__TestOpenStackActuator(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			actuator := &OpenStackActuator{
-				logger: log.WithField("actuator", "openstackactuator_test"),
+				logger:  log.WithField("actuator", "openstackactuator_test"),
+				osImage: "test-image",
 			}
 
 			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
@@ -54,6 +105,14 @@ func BROKEN__TestOpenStackActuator(t *testing.T) {
 			} else {
 				require.NoError(t, err, "unexpected error for test cast")
 				validateOSPMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				for _, ms := range generatedMachineSets {
+					ospProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*ospprovider.OpenstackProviderSpec)
+					assert.Equal(t, test.expectedZones[ms.Name], ospProvider.AvailabilityZone, "unexpected availability zone")
+					assert.Equal(t, test.expectedTrunk, ospProvider.Trunk, "unexpected trunk support")
+					assert.Len(t, ospProvider.Networks, test.expectedNetworks, "unexpected number of networks")
+					assert.Len(t, ospProvider.SecurityGroups, test.expectedSecurityGroups, "unexpected number of security groups")
+					assert.Equal(t, "test-image", ospProvider.Image, "unexpected image")
+				}
 			}
 		})
 	}
@@ -70,12 +70,18 @@ func Add(mgr manager.Manager) error {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
+	replicaLimits, err := controllerutils.GetMachinePoolReplicaLimitsConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not get machine pool replica limits")
+		return err
+	}
 
 	r := &ReconcileRemoteMachineSet{
-		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
-		scheme:       mgr.GetScheme(),
-		logger:       logger,
-		expectations: controllerutils.NewExpectations(logger),
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
+		scheme:        mgr.GetScheme(),
+		logger:        logger,
+		expectations:  controllerutils.NewExpectations(logger),
+		replicaLimits: replicaLimits,
 	}
 	r.actuatorBuilder = func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
 		return r.createActuator(cd, pool, masterMachine, remoteMachineSets, logger)
@@ -86,7 +92,7 @@ func Add(mgr manager.Manager) error {
 
 	// Create a new controller
 	c, err := controller.New("remotemachineset-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
@@ -170,6 +176,9 @@ type ReconcileRemoteMachineSet struct {
 	// A TTLCache of machinepoolnamelease creates each machinepool expects to see. Note that not all actuators make use
 	// of expectations.
 	expectations controllerutils.ExpectationsInterface
+
+	// replicaLimits limits the total replicas of the MachinePools of each tenant, if configured.
+	replicaLimits *hivev1.MachinePoolReplicaLimitsConfig
 }
 
 // Reconcile reads that state of the cluster for a MachinePool object and makes changes to the
@@ -219,6 +228,14 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
+	logger = controllerutils.AddDebugModeLogging(logger, cd)
+
+	if controllerutils.IsDetached(cd) {
+		// The machine sets of a detached cluster are left in place on the cluster.
+		logger.Debug("cluster is detached")
+		return r.removeFinalizer(pool, logger)
+	}
+
 	if !controllerutils.ShouldSyncCluster(cd, logger) {
 		return reconcile.Result{}, nil
 	}
@@ -284,6 +301,10 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return *result, nil
 	}
 
+	if err := r.enforceReplicaLimit(pool, generatedMachineSets, remoteMachineSets, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, logger)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -843,7 +864,7 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 		); err != nil {
 			return nil, err
 		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
+		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, cd.Spec.Platform.AWS.ServiceEndpoints, pool, masterMachine, r.scheme, logger)
 	case cd.Spec.Platform.GCP != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
@@ -874,6 +895,10 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 			return nil, err
 		}
 		return NewAzureActuator(creds, logger)
+	case cd.Spec.Platform.IBMCloud != nil:
+		return nil, errors.New("MachinePools are not yet supported on IBM Cloud")
+	case cd.Spec.Platform.Nutanix != nil:
+		return nil, errors.New("MachinePools are not yet supported on Nutanix")
 	case cd.Spec.Platform.OpenStack != nil:
 		return NewOpenStackActuator(masterMachine, r.scheme, logger)
 	case cd.Spec.Platform.VSphere != nil:
@@ -40,15 +40,14 @@ import (
 )
 
 const (
-	testName            = "foo"
-	testNamespace       = "default"
-	testClusterID       = "foo-12345-uuid"
-	testInfraID         = "foo-12345"
-	machineAPINamespace = "openshift-machine-api"
-	testAMI             = "ami-totallyfake"
-	testRegion          = "test-region"
-	testPoolName        = "worker"
-	testInstanceType    = "test-instance-type"
+	testName         = "foo"
+	testNamespace    = "default"
+	testClusterID    = "foo-12345-uuid"
+	testInfraID      = "foo-12345"
+	testAMI          = "ami-totallyfake"
+	testRegion       = "test-region"
+	testPoolName     = "worker"
+	testInstanceType = "test-instance-type"
 )
 
 func init() {
@@ -108,11 +107,14 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		name                             string
 		clusterDeployment                *hivev1.ClusterDeployment
 		machinePool                      *hivev1.MachinePool
+		existing                         []runtime.Object
 		remoteExisting                   []runtime.Object
+		replicaLimits                    *hivev1.MachinePoolReplicaLimitsConfig
 		generatedMachineSets             []*machineapi.MachineSet
 		actuatorDoNotProceed             bool
 		expectErr                        bool
 		expectNoFinalizer                bool
+		expectReplicaLimitExceeded       bool
 		expectedRemoteMachineSets        []*machineapi.MachineSet
 		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
 		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
@@ -616,6 +618,84 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("1"),
 			},
 		},
+		{
+			name:              "Update machine set replicas within replica limit",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			replicaLimits: &hivev1.MachinePoolReplicaLimitsConfig{DefaultMaxReplicas: pointer.Int64Ptr(3)},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
+			},
+		},
+		{
+			name:              "Hold machine set replicas over replica limit",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			replicaLimits: &hivev1.MachinePoolReplicaLimitsConfig{DefaultMaxReplicas: pointer.Int64Ptr(2)},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			expectReplicaLimitExceeded: true,
+		},
+		{
+			name:              "Create missing machine set without replicas over replica limit of tenant",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			existing: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Labels: map[string]string{"tenant": "test-tenant"}}},
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Namespace = "other-namespace"
+					return pool
+				}(),
+			},
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+			},
+			replicaLimits: &hivev1.MachinePoolReplicaLimitsConfig{
+				TenantLabel: "tenant",
+				Tenants:     []hivev1.TenantReplicaLimit{{Name: "test-tenant", MaxReplicas: 5}},
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 0, 0),
+			},
+			expectReplicaLimitExceeded: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -624,7 +704,10 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		autoscalingv1.SchemeBuilder.AddToScheme(scheme.Scheme)
 		autoscalingv1beta1.SchemeBuilder.AddToScheme(scheme.Scheme)
 		t.Run(test.name, func(t *testing.T) {
-			localExisting := []runtime.Object{}
+			localExisting := []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Labels: map[string]string{"tenant": "test-tenant"}}},
+			}
+			localExisting = append(localExisting, test.existing...)
 			if test.clusterDeployment != nil {
 				localExisting = append(localExisting, test.clusterDeployment)
 			}
@@ -658,7 +741,8 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				actuatorBuilder: func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, cdLog log.FieldLogger) (Actuator, error) {
 					return mockActuator, nil
 				},
-				expectations: controllerExpectations,
+				expectations:  controllerExpectations,
+				replicaLimits: test.replicaLimits,
 			}
 			_, err := rcd.Reconcile(reconcile.Request{
 				NamespacedName: types.NamespacedName{
@@ -685,6 +769,8 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				} else {
 					assert.Contains(t, pool.Finalizers, finalizer, "missing finalizer")
 				}
+				cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.ReplicaLimitExceededMachinePoolCondition)
+				assert.Equal(t, test.expectReplicaLimitExceeded, cond != nil && cond.Status == corev1.ConditionTrue, "unexpected ReplicaLimitExceeded condition")
 			}
 
 			rMSL, err := getRMSL(remoteFakeClient)
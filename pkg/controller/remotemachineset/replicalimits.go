@@ -0,0 +1,120 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/replicalimits"
+)
+
+// enforceReplicaLimit reports in the ReplicaLimitExceeded condition of the MachinePool whether the MachinePools of its
+// tenant exceed the replica limit of the tenant. While they do, the generated MachineSets of a MachinePool that does
+// not autoscale are held at the replicas of the remote MachineSets, so that the MachinePool can scale down but not up.
+func (r *ReconcileRemoteMachineSet) enforceReplicaLimit(
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	remoteMachineSets *machineapi.MachineSetList,
+	logger log.FieldLogger,
+) error {
+	if r.replicaLimits == nil || pool.DeletionTimestamp != nil {
+		return nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Name: pool.Namespace}, namespace); err != nil {
+		logger.WithError(err).Error("could not get namespace of machine pool")
+		return err
+	}
+	tenant, selector := replicalimits.Tenant(r.replicaLimits, namespace)
+	status := corev1.ConditionFalse
+	reason := "WithinReplicaLimit"
+	message := fmt.Sprintf("The MachinePools of tenant %q are within the replica limit", tenant)
+	if maxReplicas := replicalimits.MaxReplicas(r.replicaLimits, tenant); maxReplicas != nil {
+		total, err := r.tenantReplicas(pool.Namespace, selector)
+		if err != nil {
+			logger.WithError(err).Error("could not count replicas of tenant")
+			return err
+		}
+		if total > *maxReplicas {
+			logger.WithFields(log.Fields{"tenant": tenant, "replicas": total, "maxReplicas": *maxReplicas}).
+				Warn("machine pools of tenant exceed the replica limit")
+			status = corev1.ConditionTrue
+			reason = "ReplicaLimitExceeded"
+			message = fmt.Sprintf("The MachinePools of tenant %q have %d replicas, over the limit of %d. The MachinePool will not be scaled up.", tenant, total, *maxReplicas)
+		}
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ReplicaLimitExceededMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Error("failed to update MachinePool conditions")
+			return err
+		}
+	}
+	if status == corev1.ConditionTrue && pool.Spec.Autoscaling == nil {
+		holdReplicas(generatedMachineSets, remoteMachineSets, logger)
+	}
+	return nil
+}
+
+// tenantReplicas returns the total replicas of the MachinePools of a tenant. A tenant without a label selector is
+// the single namespace given.
+func (r *ReconcileRemoteMachineSet) tenantReplicas(namespace string, selector labels.Selector) (int64, error) {
+	namespaces := []string{namespace}
+	if selector != nil {
+		namespaceList := &corev1.NamespaceList{}
+		if err := r.List(context.TODO(), namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return 0, errors.Wrap(err, "could not list namespaces of tenant")
+		}
+		namespaces = namespaces[:0]
+		for _, ns := range namespaceList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	var total int64
+	for _, ns := range namespaces {
+		pools := &hivev1.MachinePoolList{}
+		if err := r.List(context.TODO(), pools, client.InNamespace(ns)); err != nil {
+			return 0, errors.Wrap(err, "could not list machine pools of tenant")
+		}
+		total += replicalimits.TotalReplicas(pools.Items, types.NamespacedName{})
+	}
+	return total, nil
+}
+
+// holdReplicas lowers the replicas of the generated MachineSets to those of the matching remote MachineSets. New
+// MachineSets are generated without replicas.
+func holdReplicas(generatedMachineSets []*machineapi.MachineSet, remoteMachineSets *machineapi.MachineSetList, logger log.FieldLogger) {
+	for _, ms := range generatedMachineSets {
+		var held int32
+		for _, rMS := range remoteMachineSets.Items {
+			if rMS.Name == ms.Name && rMS.Spec.Replicas != nil {
+				held = *rMS.Spec.Replicas
+				break
+			}
+		}
+		if ms.Spec.Replicas != nil && *ms.Spec.Replicas > held {
+			logger.WithFields(log.Fields{"machineset": ms.Name, "desired": *ms.Spec.Replicas, "held": held}).
+				Info("holding replicas at replica limit")
+			ms.Spec.Replicas = &held
+		}
+	}
+}
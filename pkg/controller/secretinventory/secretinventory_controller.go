@@ -0,0 +1,397 @@
+// Package secretinventory provides a controller which maintains an inventory of the secrets referenced by a
+// ClusterDeployment in its status. Each referenced secret is checked to ensure it exists and contains the data
+// expected for its use, so that a missing or invalid secret is surfaced on the ClusterDeployment rather than as
+// a failure in a downstream job.
+package secretinventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.SecretInventoryControllerName
+
+	secretFoundReason       = "SecretFound"
+	secretNotFoundReason    = "SecretNotFound"
+	secretValidReason       = "SecretValid"
+	secretInvalidReason     = "SecretInvalid"
+	secretNotCheckedReason  = "SecretNotChecked"
+	secretReferencesValid   = "SecretReferencesValid"
+	secretReferencesInvalid = "SecretReferencesInvalid"
+)
+
+// Add creates a new SecretInventory controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileSecretInventory {
+	return &ReconcileSecretInventory{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileSecretInventory, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("secretinventory-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to Secrets referenced by a ClusterDeployment
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: requestsForSecret(r.Client, r.logger),
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForSecret returns a mapping function which enqueues the ClusterDeployments in the namespace of a
+// secret that reference the secret.
+func requestsForSecret(c client.Client, logger log.FieldLogger) handler.ToRequestsFunc {
+	return func(o handler.MapObject) []reconcile.Request {
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cdList, client.InNamespace(o.Meta.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterDeployments for secret")
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range cdList.Items {
+			cd := &cdList.Items[i]
+			for _, ref := range secretReferences(cd) {
+				if ref.name == o.Meta.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+						Namespace: cd.Namespace,
+						Name:      cd.Name,
+					}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileSecretInventory{}
+
+// ReconcileSecretInventory maintains the inventory of secrets referenced by a ClusterDeployment.
+type ReconcileSecretInventory struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile checks each of the secrets referenced by a ClusterDeployment and records the results in the
+// ClusterDeployment status.
+func (r *ReconcileSecretInventory) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			cdLog.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Error("error looking up cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if cd.DeletionTimestamp != nil {
+		cdLog.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	var statuses []hivev1.SecretReferenceStatus
+	var invalid []string
+	for _, ref := range secretReferences(cd) {
+		status := hivev1.SecretReferenceStatus{
+			Name: ref.name,
+			Type: ref.refType,
+		}
+		if existing := findSecretReferenceStatus(cd.Status.SecretReferences, ref.name, ref.refType); existing != nil {
+			status.Conditions = existing.Conditions
+		}
+
+		secret := &corev1.Secret{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: ref.name}, secret); {
+		case apierrors.IsNotFound(err):
+			status.Conditions = setSecretReferenceCondition(status.Conditions, hivev1.SecretReferenceFoundCondition,
+				corev1.ConditionFalse, secretNotFoundReason, "secret does not exist")
+			status.Conditions = setSecretReferenceCondition(status.Conditions, hivev1.SecretReferenceValidCondition,
+				corev1.ConditionUnknown, secretNotCheckedReason, "secret does not exist")
+			invalid = append(invalid, fmt.Sprintf("%s secret %s not found", ref.refType, ref.name))
+		case err != nil:
+			cdLog.WithError(err).WithField("secret", ref.name).Log(controllerutils.LogLevel(err), "error getting secret")
+			return reconcile.Result{}, err
+		default:
+			status.Conditions = setSecretReferenceCondition(status.Conditions, hivev1.SecretReferenceFoundCondition,
+				corev1.ConditionTrue, secretFoundReason, "secret exists")
+			if err := ref.validate(secret); err != nil {
+				status.Conditions = setSecretReferenceCondition(status.Conditions, hivev1.SecretReferenceValidCondition,
+					corev1.ConditionFalse, secretInvalidReason, err.Error())
+				invalid = append(invalid, fmt.Sprintf("%s secret %s is invalid: %v", ref.refType, ref.name, err))
+			} else {
+				status.Conditions = setSecretReferenceCondition(status.Conditions, hivev1.SecretReferenceValidCondition,
+					corev1.ConditionTrue, secretValidReason, "secret contains the expected data")
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	changed := !reflect.DeepEqual(statuses, cd.Status.SecretReferences)
+	cd.Status.SecretReferences = statuses
+
+	condStatus, reason, message := corev1.ConditionFalse, secretReferencesValid, "all referenced secrets are valid"
+	if len(invalid) > 0 {
+		condStatus, reason, message = corev1.ConditionTrue, secretReferencesInvalid, strings.Join(invalid, "; ")
+	}
+	var condChanged bool
+	cd.Status.Conditions, condChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.InvalidSecretReferencesCondition,
+		condStatus,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	if !changed && !condChanged {
+		cdLog.Debug("secret references unchanged")
+		return reconcile.Result{}, nil
+	}
+
+	if len(invalid) > 0 {
+		cdLog.WithField("invalid", invalid).Info("cluster deployment has invalid secret references")
+	}
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment secret references")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func findSecretReferenceStatus(statuses []hivev1.SecretReferenceStatus, name string, refType hivev1.SecretReferenceType) *hivev1.SecretReferenceStatus {
+	for i, s := range statuses {
+		if s.Name == name && s.Type == refType {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// setSecretReferenceCondition sets the condition of the given type, leaving the conditions untouched if the
+// status, reason and message are unchanged.
+func setSecretReferenceCondition(
+	conditions []hivev1.SecretReferenceCondition,
+	conditionType hivev1.SecretReferenceConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) []hivev1.SecretReferenceCondition {
+	now := metav1.Now()
+	newConditions := make([]hivev1.SecretReferenceCondition, 0, len(conditions)+1)
+	found := false
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			found = true
+			if c.Status != status {
+				c.LastTransitionTime = now
+			}
+			if c.Status != status || c.Reason != reason || c.Message != message {
+				c.Status = status
+				c.Reason = reason
+				c.Message = message
+				c.LastProbeTime = now
+			}
+		}
+		newConditions = append(newConditions, c)
+	}
+	if !found {
+		newConditions = append(newConditions, hivev1.SecretReferenceCondition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+	}
+	return newConditions
+}
+
+type secretReference struct {
+	name     string
+	refType  hivev1.SecretReferenceType
+	validate func(*corev1.Secret) error
+}
+
+// secretReferences returns the secrets referenced by the ClusterDeployment along with the validation to apply
+// to each.
+func secretReferences(cd *hivev1.ClusterDeployment) []secretReference {
+	var refs []secretReference
+	add := func(name string, refType hivev1.SecretReferenceType, validate func(*corev1.Secret) error) {
+		if name == "" {
+			return
+		}
+		refs = append(refs, secretReference{name: name, refType: refType, validate: validate})
+	}
+
+	if cd.Spec.PullSecretRef != nil {
+		add(cd.Spec.PullSecretRef.Name, hivev1.PullSecretReference, validatePullSecret)
+	}
+
+	platform := cd.Spec.Platform
+	switch {
+	case platform.AWS != nil:
+		add(platform.AWS.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.AWSAccessKeyIDSecretKey, constants.AWSSecretAccessKeySecretKey))
+	case platform.Azure != nil:
+		add(platform.Azure.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.AzureCredentialsName))
+	case platform.GCP != nil:
+		add(platform.GCP.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.GCPCredentialsName))
+	case platform.IBMCloud != nil:
+		add(platform.IBMCloud.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.IBMCloudAPIKeySecretKey))
+	case platform.Nutanix != nil:
+		add(platform.Nutanix.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.UsernameSecretKey, constants.PasswordSecretKey))
+		if ref := platform.Nutanix.CertificatesSecretRef; ref != nil {
+			add(ref.Name, hivev1.CertificatesSecretReference, requireData)
+		}
+	case platform.OpenStack != nil:
+		add(platform.OpenStack.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.OpenStackCredentialsName))
+	case platform.Ovirt != nil:
+		add(platform.Ovirt.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.OvirtCredentialsName))
+		add(platform.Ovirt.CertificatesSecretRef.Name, hivev1.CertificatesSecretReference, requireData)
+	case platform.VSphere != nil:
+		add(platform.VSphere.CredentialsSecretRef.Name, hivev1.CredentialsSecretReference,
+			requireKeys(constants.UsernameSecretKey, constants.PasswordSecretKey))
+		add(platform.VSphere.CertificatesSecretRef.Name, hivev1.CertificatesSecretReference, requireData)
+	case platform.BareMetal != nil:
+		add(platform.BareMetal.LibvirtSSHPrivateKeySecretRef.Name, hivev1.LibvirtSSHPrivateKeySecretReference,
+			requireKeys(constants.SSHPrivateKeySecretKey))
+	}
+
+	// Provisioning secrets are only needed until the cluster is installed.
+	if p := cd.Spec.Provisioning; p != nil && !cd.Spec.Installed {
+		add(p.InstallConfigSecretRef.Name, hivev1.InstallConfigSecretReference,
+			requireKeys(constants.InstallConfigSecretKey))
+		if p.SSHPrivateKeySecretRef != nil {
+			add(p.SSHPrivateKeySecretRef.Name, hivev1.SSHPrivateKeySecretReference,
+				requireKeys(constants.SSHPrivateKeySecretKey))
+		}
+	}
+
+	if m := cd.Spec.ClusterMetadata; m != nil {
+		add(m.AdminKubeconfigSecretRef.Name, hivev1.AdminKubeconfigSecretReference, validateKubeconfig)
+		add(m.AdminPasswordSecretRef.Name, hivev1.AdminPasswordSecretReference,
+			requireKeys(constants.UsernameSecretKey, constants.PasswordSecretKey))
+	}
+
+	for _, bundle := range cd.Spec.CertificateBundles {
+		add(bundle.CertificateSecretRef.Name, hivev1.CertificateBundleSecretReference,
+			requireKeys(constants.TLSCrtSecretKey, constants.TLSKeySecretKey))
+	}
+
+	return refs
+}
+
+// requireKeys returns a validation function which checks that the secret has non-empty data for each of the keys.
+func requireKeys(keys ...string) func(*corev1.Secret) error {
+	return func(secret *corev1.Secret) error {
+		var missing []string
+		for _, key := range keys {
+			if len(secret.Data[key]) == 0 {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("secret is missing data for keys: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
+
+func requireData(secret *corev1.Secret) error {
+	if len(secret.Data) == 0 {
+		return fmt.Errorf("secret has no data")
+	}
+	return nil
+}
+
+func validatePullSecret(secret *corev1.Secret) error {
+	if err := requireKeys(corev1.DockerConfigJsonKey)(secret); err != nil {
+		return err
+	}
+	var pullSecret map[string]interface{}
+	if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &pullSecret); err != nil {
+		return fmt.Errorf("could not parse pull secret: %v", err)
+	}
+	return nil
+}
+
+func validateKubeconfig(secret *corev1.Secret) error {
+	if err := requireKeys(constants.KubeconfigSecretKey)(secret); err != nil {
+		return err
+	}
+	if _, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey]); err != nil {
+		return fmt.Errorf("could not parse kubeconfig: %v", err)
+	}
+	return nil
+}
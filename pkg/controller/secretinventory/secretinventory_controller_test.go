@@ -0,0 +1,244 @@
+package secretinventory
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	testName      = "test-cluster-deployment"
+	testNamespace = "test-namespace"
+
+	pullSecretName  = "pull-secret"
+	credsSecretName = "aws-creds"
+	kubeconfigName  = "admin-kubeconfig"
+	passwordName    = "admin-password"
+
+	testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://api.test-cluster:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    token: abc
+`
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(testNamespace, testName, scheme).Options(
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.PullSecretRef = &corev1.LocalObjectReference{Name: pullSecretName}
+			cd.Spec.Platform.AWS = &hivev1aws.Platform{
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecretName},
+				Region:               "us-east-1",
+			}
+		},
+	)
+	installed := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Installed = true
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigName},
+			AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: passwordName},
+		}
+	}
+	secretBuilder := testsecret.FullBuilder(testNamespace, "", scheme)
+	pullSecret := secretBuilder.Build(
+		testsecret.WithName(pullSecretName),
+		testsecret.WithDataKeyValue(corev1.DockerConfigJsonKey, []byte(`{"auths":{}}`)),
+	)
+	credsSecret := secretBuilder.Build(
+		testsecret.WithName(credsSecretName),
+		testsecret.WithDataKeyValue(constants.AWSAccessKeyIDSecretKey, []byte("id")),
+		testsecret.WithDataKeyValue(constants.AWSSecretAccessKeySecretKey, []byte("key")),
+	)
+	kubeconfigSecret := secretBuilder.Build(
+		testsecret.WithName(kubeconfigName),
+		testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte(testKubeconfig)),
+	)
+	passwordSecret := secretBuilder.Build(
+		testsecret.WithName(passwordName),
+		testsecret.WithDataKeyValue(constants.UsernameSecretKey, []byte("kubeadmin")),
+		testsecret.WithDataKeyValue(constants.PasswordSecretKey, []byte("password")),
+	)
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		expectedFound       map[string]corev1.ConditionStatus
+		expectedValid       map[string]corev1.ConditionStatus
+		expectedInvalidCond corev1.ConditionStatus
+	}{
+		{
+			name:     "all secrets valid",
+			cd:       cdBuilder.Build(installed),
+			existing: []runtime.Object{pullSecret, credsSecret, kubeconfigSecret, passwordSecret},
+			expectedFound: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+				kubeconfigName:  corev1.ConditionTrue,
+				passwordName:    corev1.ConditionTrue,
+			},
+			expectedValid: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+				kubeconfigName:  corev1.ConditionTrue,
+				passwordName:    corev1.ConditionTrue,
+			},
+			expectedInvalidCond: corev1.ConditionFalse,
+		},
+		{
+			name:     "missing credentials",
+			cd:       cdBuilder.Build(),
+			existing: []runtime.Object{pullSecret},
+			expectedFound: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionFalse,
+			},
+			expectedValid: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionUnknown,
+			},
+			expectedInvalidCond: corev1.ConditionTrue,
+		},
+		{
+			name: "invalid pull secret",
+			cd:   cdBuilder.Build(),
+			existing: []runtime.Object{
+				secretBuilder.Build(
+					testsecret.WithName(pullSecretName),
+					testsecret.WithDataKeyValue(corev1.DockerConfigJsonKey, []byte("not json")),
+				),
+				credsSecret,
+			},
+			expectedFound: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+			},
+			expectedValid: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionFalse,
+				credsSecretName: corev1.ConditionTrue,
+			},
+			expectedInvalidCond: corev1.ConditionTrue,
+		},
+		{
+			name: "credentials missing key",
+			cd:   cdBuilder.Build(),
+			existing: []runtime.Object{
+				pullSecret,
+				secretBuilder.Build(
+					testsecret.WithName(credsSecretName),
+					testsecret.WithDataKeyValue(constants.AWSAccessKeyIDSecretKey, []byte("id")),
+				),
+			},
+			expectedFound: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+			},
+			expectedValid: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionFalse,
+			},
+			expectedInvalidCond: corev1.ConditionTrue,
+		},
+		{
+			name: "invalid kubeconfig",
+			cd:   cdBuilder.Build(installed),
+			existing: []runtime.Object{
+				pullSecret,
+				credsSecret,
+				secretBuilder.Build(
+					testsecret.WithName(kubeconfigName),
+					testsecret.WithDataKeyValue(constants.KubeconfigSecretKey, []byte("{not a kubeconfig")),
+				),
+				passwordSecret,
+			},
+			expectedFound: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+				kubeconfigName:  corev1.ConditionTrue,
+				passwordName:    corev1.ConditionTrue,
+			},
+			expectedValid: map[string]corev1.ConditionStatus{
+				pullSecretName:  corev1.ConditionTrue,
+				credsSecretName: corev1.ConditionTrue,
+				kubeconfigName:  corev1.ConditionFalse,
+				passwordName:    corev1.ConditionTrue,
+			},
+			expectedInvalidCond: corev1.ConditionTrue,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, append(test.existing, test.cd)...)
+			r := &ReconcileSecretInventory{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+			}
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd)
+			require.NoError(t, err, "unexpected error getting cluster deployment")
+
+			assert.Len(t, cd.Status.SecretReferences, len(test.expectedFound), "unexpected number of secret references")
+			for _, ref := range cd.Status.SecretReferences {
+				assert.Equal(t, test.expectedFound[ref.Name], conditionStatus(ref, hivev1.SecretReferenceFoundCondition), "unexpected found status for %s", ref.Name)
+				assert.Equal(t, test.expectedValid[ref.Name], conditionStatus(ref, hivev1.SecretReferenceValidCondition), "unexpected valid status for %s", ref.Name)
+			}
+
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InvalidSecretReferencesCondition)
+			if test.expectedInvalidCond == corev1.ConditionTrue {
+				if assert.NotNil(t, cond, "missing invalid secret references condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected invalid secret references condition status")
+				}
+			} else if cond != nil {
+				assert.Equal(t, test.expectedInvalidCond, cond.Status, "unexpected invalid secret references condition status")
+			}
+		})
+	}
+}
+
+func conditionStatus(ref hivev1.SecretReferenceStatus, condType hivev1.SecretReferenceConditionType) corev1.ConditionStatus {
+	for _, c := range ref.Conditions {
+		if c.Type == condType {
+			return c.Status
+		}
+	}
+	return ""
+}
@@ -0,0 +1,271 @@
+package sshkeyrotation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.SSHKeyRotationControllerName
+
+	// machineConfigIgnitionVersion is the oldest ignition spec version accepted by the machine config operator. Newer
+	// releases translate it to the version they use.
+	machineConfigIgnitionVersion = "2.2.0"
+)
+
+// machineConfigRoles are the roles of the machine config pools whose hosts get the SSH key. The installer creates a
+// 99-<role>-ssh MachineConfig for each of them, which the SyncSet replaces.
+var machineConfigRoles = []string{"master", "worker"}
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new SSHKeyRotation controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	logger := log.WithField("controller", ControllerName)
+	return &ReconcileSSHKeyRotation{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		applier: resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("sshkeyrotation-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSSHKeyRotation{}
+
+// ReconcileSSHKeyRotation pushes the SSH key of a ClusterDeployment to the hosts of the cluster when the key is
+// changed after the cluster is installed.
+type ReconcileSSHKeyRotation struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+}
+
+// Reconcile rotates the SSH key authorized on the hosts of the cluster when the SSH private key secret of the
+// ClusterDeployment changes.
+func (r *ReconcileSSHKeyRotation) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		return reconcile.Result{}, nil
+	}
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.SSHPrivateKeySecretRef == nil {
+		cdLog.Debug("cluster has no ssh key")
+		return reconcile.Result{}, nil
+	}
+
+	secretName := cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name
+	cdLog = cdLog.WithField("secret", secretName)
+	publicKey, err := r.getPublicKey(cd.Namespace, secretName)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get ssh public key")
+		return reconcile.Result{}, err
+	}
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+	cdLog = cdLog.WithField("fingerprint", fingerprint)
+
+	status := cd.Status.SSHKey
+	switch {
+	case status == nil:
+		// The first key seen is the key the cluster was installed with, which the installer has already put on
+		// the hosts.
+		cdLog.Info("recording ssh key the cluster was installed with")
+		cd.Status.SSHKey = &hivev1.SSHKeyStatus{
+			SecretName:  secretName,
+			Fingerprint: fingerprint,
+		}
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record ssh key in status")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	case status.Fingerprint == fingerprint && status.LastRotationTime == nil:
+		cdLog.Debug("cluster still has the ssh key it was installed with")
+		if status.SecretName != secretName {
+			status.SecretName = secretName
+			if err := r.Status().Update(context.TODO(), cd); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record ssh key secret in status")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	syncSet, err := r.generateSyncSet(cd, publicKey)
+	if err != nil {
+		cdLog.WithError(err).Error("could not generate ssh key syncset")
+		return reconcile.Result{}, err
+	}
+	if _, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("could not apply ssh key syncset")
+		return reconcile.Result{}, err
+	}
+
+	if status.Fingerprint != fingerprint || status.SecretName != secretName {
+		cdLog.Info("rotated ssh key of the cluster hosts")
+		now := metav1.Now()
+		cd.Status.SSHKey = &hivev1.SSHKeyStatus{
+			SecretName:       secretName,
+			Fingerprint:      fingerprint,
+			LastRotationTime: &now,
+		}
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record ssh key rotation in status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileSSHKeyRotation) getPublicKey(namespace, secretName string) (ssh.PublicKey, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get ssh private key secret")
+	}
+	privateKey, ok := secret.Data[constants.SSHPrivateKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("ssh private key secret has no %s key", constants.SSHPrivateKeySecretKey)
+	}
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse ssh private key")
+	}
+	return signer.PublicKey(), nil
+}
+
+func (r *ReconcileSSHKeyRotation) generateSyncSet(cd *hivev1.ClusterDeployment, publicKey ssh.PublicKey) (*hivev1.SyncSet, error) {
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	resources := []runtime.RawExtension{}
+	for _, role := range machineConfigRoles {
+		resources = append(resources, runtime.RawExtension{Object: machineConfig(role, authorizedKey)})
+	}
+
+	// Upsert keeps the key on the hosts if the syncset is deleted.
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateSSHKeySyncSetName(cd.Name),
+			Namespace: cd.Namespace,
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Resources:         resources,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeSSHKey)
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "error setting owner reference")
+	}
+	return syncSet, nil
+}
+
+// machineConfig returns the MachineConfig authorizing the SSH key for the core user on the hosts with the given role.
+// It has the name of the MachineConfig created by the installer so that the key the cluster was installed with is
+// replaced rather than kept alongside the new key.
+func machineConfig(role, authorizedKey string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfig",
+		"metadata": map[string]interface{}{
+			"name": fmt.Sprintf("99-%s-ssh", role),
+			"labels": map[string]interface{}{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]interface{}{
+					"version": machineConfigIgnitionVersion,
+				},
+				"passwd": map[string]interface{}{
+					"users": []interface{}{
+						map[string]interface{}{
+							"name":              "core",
+							"sshAuthorizedKeys": []interface{}{authorizedKey},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+// GenerateSSHKeySyncSetName generates the name of the SyncSet that holds the SSH key of the cluster hosts.
+func GenerateSSHKeySyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, "ssh-key")
+}
@@ -0,0 +1,240 @@
+package sshkeyrotation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	testName      = "test-cluster"
+	testNamespace = "test-namespace"
+	oldKeySecret  = "old-ssh-key"
+	newKeySecret  = "new-ssh-key"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileSSHKeyRotation(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	oldKey, oldPublicKey := testSSHKey(t)
+	newKey, newPublicKey := testSSHKey(t)
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		expectErr           bool
+		expectSyncSet       bool
+		expectStatus        *hivev1.SSHKeyStatus
+		expectRotated       bool
+		expectAuthorizedKey ssh.PublicKey
+	}{
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(oldKeySecret, nil)
+				cd.Spec.Installed = false
+				return cd
+			}(),
+		},
+		{
+			name: "no ssh key",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(oldKeySecret, nil)
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = nil
+				return cd
+			}(),
+		},
+		{
+			name:      "missing secret",
+			cd:        testClusterDeployment("missing", nil),
+			expectErr: true,
+		},
+		{
+			name: "install key recorded",
+			cd:   testClusterDeployment(oldKeySecret, nil),
+			expectStatus: &hivev1.SSHKeyStatus{
+				SecretName:  oldKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(oldPublicKey),
+			},
+		},
+		{
+			name: "install key unchanged",
+			cd: testClusterDeployment(oldKeySecret, &hivev1.SSHKeyStatus{
+				SecretName:  oldKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(oldPublicKey),
+			}),
+			expectStatus: &hivev1.SSHKeyStatus{
+				SecretName:  oldKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(oldPublicKey),
+			},
+		},
+		{
+			name: "key rotated",
+			cd: testClusterDeployment(newKeySecret, &hivev1.SSHKeyStatus{
+				SecretName:  oldKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(oldPublicKey),
+			}),
+			expectSyncSet: true,
+			expectStatus: &hivev1.SSHKeyStatus{
+				SecretName:  newKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(newPublicKey),
+			},
+			expectRotated:       true,
+			expectAuthorizedKey: newPublicKey,
+		},
+		{
+			name: "rotated key kept in syncset",
+			cd: func() *hivev1.ClusterDeployment {
+				now := metav1.Now()
+				return testClusterDeployment(newKeySecret, &hivev1.SSHKeyStatus{
+					SecretName:       newKeySecret,
+					Fingerprint:      ssh.FingerprintSHA256(newPublicKey),
+					LastRotationTime: &now,
+				})
+			}(),
+			expectSyncSet: true,
+			expectStatus: &hivev1.SSHKeyStatus{
+				SecretName:  newKeySecret,
+				Fingerprint: ssh.FingerprintSHA256(newPublicKey),
+			},
+			expectRotated:       true,
+			expectAuthorizedKey: newPublicKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme,
+				test.cd,
+				testSecret(oldKeySecret, oldKey),
+				testSecret(newKeySecret, newKey),
+			)
+			a := &fakeApplier{}
+			r := &ReconcileSSHKeyRotation{
+				Client:  c,
+				scheme:  scheme.Scheme,
+				applier: a,
+			}
+
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+				return
+			}
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectSyncSet {
+				if assert.Len(t, a.appliedObjects, 1, "expected a syncset to be applied") {
+					ss := a.appliedObjects[0].(*hivev1.SyncSet)
+					assert.Equal(t, GenerateSSHKeySyncSetName(testName), ss.Name, "unexpected syncset name")
+					assert.Equal(t, constants.SyncSetTypeSSHKey, ss.Labels[constants.SyncSetTypeLabel], "unexpected syncset type label")
+					assert.Equal(t, hivev1.UpsertResourceApplyMode, ss.Spec.ResourceApplyMode, "unexpected resource apply mode")
+					if assert.Len(t, ss.Spec.Resources, 2, "expected a machine config per role") {
+						for i, role := range []string{"master", "worker"} {
+							mc := ss.Spec.Resources[i].Object.(*unstructured.Unstructured)
+							assert.Equal(t, "99-"+role+"-ssh", mc.GetName(), "unexpected machine config name")
+							assert.Equal(t, role, mc.GetLabels()["machineconfiguration.openshift.io/role"], "unexpected machine config role")
+							users, _, _ := unstructured.NestedSlice(mc.Object, "spec", "config", "passwd", "users")
+							if assert.Len(t, users, 1, "expected a single user") {
+								keys := users[0].(map[string]interface{})["sshAuthorizedKeys"].([]interface{})
+								authorizedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keys[0].(string)))
+								require.NoError(t, err, "could not parse authorized key")
+								assert.Equal(t, ssh.FingerprintSHA256(test.expectAuthorizedKey), ssh.FingerprintSHA256(authorizedKey), "unexpected authorized key")
+							}
+						}
+					}
+				}
+			} else {
+				assert.Empty(t, a.appliedObjects, "expected no syncset to be applied")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			if test.expectStatus == nil {
+				assert.Nil(t, cd.Status.SSHKey, "expected no ssh key in status")
+				return
+			}
+			if assert.NotNil(t, cd.Status.SSHKey, "expected ssh key in status") {
+				assert.Equal(t, test.expectStatus.SecretName, cd.Status.SSHKey.SecretName, "unexpected secret name")
+				assert.Equal(t, test.expectStatus.Fingerprint, cd.Status.SSHKey.Fingerprint, "unexpected fingerprint")
+				assert.Equal(t, test.expectRotated, cd.Status.SSHKey.LastRotationTime != nil, "unexpected rotation time")
+			}
+		})
+	}
+}
+
+func testClusterDeployment(secretName string, status *hivev1.SSHKeyStatus) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+			UID:       types.UID("test-uid"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: true,
+			Provisioning: &hivev1.Provisioning{
+				SSHPrivateKeySecretRef: &corev1.LocalObjectReference{Name: secretName},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			SSHKey: status,
+		},
+	}
+}
+
+func testSecret(name string, privateKey []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			constants.SSHPrivateKeySecretKey: privateKey,
+		},
+	}
+}
+
+func testSSHKey(t *testing.T) ([]byte, ssh.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "could not generate key")
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err, "could not marshal private key")
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err, "could not convert public key")
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), sshPublicKey
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
@@ -0,0 +1,327 @@
+// Package syncsetagent provides a controller which installs the syncset agent on the clusters that pull their
+// syncsets from Hive.
+package syncsetagent
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/images"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/syncsetagent"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.SyncSetAgentControllerName
+
+	serviceAccountName = "syncset-agent"
+	clusterRoleBinding = "hive-syncset-agent"
+	deploymentName     = "syncset-agent"
+
+	// tokenSecretName is the name of the secret with the token of the agent on the cluster.
+	tokenSecretName = "syncset-agent-token"
+	tokenMountPath  = "/etc/syncset-agent"
+
+	// tokenLength is the number of random bytes in a token.
+	tokenLength = 32
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new SyncSetAgent controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (reconcile.Reconciler, error) {
+	logger := log.WithField("controller", ControllerName)
+	config, err := controllerutils.GetSyncSetAgentConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileSyncSetAgent{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		applier: resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger),
+		config:  config,
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("syncsetagent-controller", mgr, controller.Options{
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSyncSetAgent{}
+
+// ReconcileSyncSetAgent installs the syncset agent on the clusters that pull their syncsets from Hive.
+type ReconcileSyncSetAgent struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+
+	// config is the syncset agent configuration from HiveConfig. It is nil when the syncset agent is not enabled.
+	config *hivev1.SyncSetAgentConfig
+}
+
+// Reconcile installs the syncset agent on an installed cluster that has the syncset agent annotation, and removes
+// the agent when the annotation is removed.
+//
+// The agent is installed through a SyncSet, which the clustersync controller pushes to the cluster. The agent
+// authenticates to Hive with a token from a secret next to the ClusterDeployment.
+func (r *ReconcileSyncSetAgent) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed || controllerutils.IsDetached(cd) {
+		return reconcile.Result{}, nil
+	}
+
+	if r.config == nil || !controllerutils.PullsSyncSets(cd) {
+		cdLog.Debug("cluster does not pull its syncsets")
+		if err := r.deleteAgent(cd); err != nil {
+			cdLog.WithError(err).Error("could not delete syncset agent")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureTokenSecret(cd, cdLog); err != nil {
+		cdLog.WithError(err).Error("could not ensure syncset agent token")
+		return reconcile.Result{}, err
+	}
+	syncSet, err := r.generateSyncSet(cd)
+	if err != nil {
+		cdLog.WithError(err).Error("could not generate syncset agent syncset")
+		return reconcile.Result{}, err
+	}
+	if _, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("could not apply syncset agent syncset")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// ensureTokenSecret creates the secret with the token of the agent if it does not exist yet. The secret is owned by
+// the ClusterDeployment, so that it is deleted with the ClusterDeployment.
+func (r *ReconcileSyncSetAgent) ensureTokenSecret(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	name := syncsetagent.TokenSecretName(cd.Name)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, &corev1.Secret{}); {
+	case err == nil:
+		return nil
+	case !apierrors.IsNotFound(err):
+		return err
+	}
+	token := make([]byte, tokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return errors.Wrap(err, "could not generate token")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			syncsetagent.TokenSecretKey: []byte(hex.EncodeToString(token)),
+		},
+	}
+	secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+		return errors.Wrap(err, "error setting owner reference on syncset agent token secret")
+	}
+	logger.Info("creating syncset agent token")
+	return r.Create(context.TODO(), secret)
+}
+
+// SyncSetName returns the name of the SyncSet that installs the syncset agent on a cluster.
+func SyncSetName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "syncset-agent")
+}
+
+// deleteAgent deletes the SyncSet that installs the agent and the token of the agent. The SyncSet is in Sync mode, so
+// its deletion removes the agent from the cluster.
+func (r *ReconcileSyncSetAgent) deleteAgent(cd *hivev1.ClusterDeployment) error {
+	for _, obj := range []runtime.Object{
+		&hivev1.SyncSet{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: SyncSetName(cd.Name)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: syncsetagent.TokenSecretName(cd.Name)}},
+	} {
+		if err := r.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileSyncSetAgent) generateSyncSet(cd *hivev1.ClusterDeployment) (*hivev1.SyncSet, error) {
+	pollInterval := syncsetagent.DefaultPollInterval
+	if r.config.PollInterval != "" {
+		var err error
+		if pollInterval, err = time.ParseDuration(r.config.PollInterval); err != nil {
+			return nil, errors.Wrap(err, "could not parse syncset agent poll interval")
+		}
+	}
+	labels := map[string]string{"app": deploymentName}
+	resources := []runtime.RawExtension{
+		{
+			Object: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: syncsetagent.Namespace},
+			},
+		},
+		{
+			Object: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Namespace: syncsetagent.Namespace, Name: serviceAccountName},
+			},
+		},
+		{
+			Object: &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleBinding},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     "cluster-admin",
+				},
+				Subjects: []rbacv1.Subject{{
+					Kind:      rbacv1.ServiceAccountKind,
+					Namespace: syncsetagent.Namespace,
+					Name:      serviceAccountName,
+				}},
+			},
+		},
+		{
+			Object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: syncsetagent.Namespace, Name: deploymentName},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: pointer.Int32Ptr(1),
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: serviceAccountName,
+							Containers: []corev1.Container{{
+								Name:            "syncset-agent",
+								Image:           images.GetHiveImage(),
+								ImagePullPolicy: images.GetHiveImagePullPolicy(),
+								Command:         []string{"/usr/bin/hiveutil", "syncset-agent"},
+								Args: []string{
+									"--hub-url", r.config.HubURL,
+									"--cluster-namespace", cd.Namespace,
+									"--cluster-name", cd.Name,
+									"--token-file", tokenMountPath + "/" + syncsetagent.TokenSecretKey,
+									"--poll-interval", pollInterval.String(),
+								},
+								VolumeMounts: []corev1.VolumeMount{{
+									Name:      "token",
+									MountPath: tokenMountPath,
+									ReadOnly:  true,
+								}},
+							}},
+							Volumes: []corev1.Volume{{
+								Name: "token",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{SecretName: tokenSecretName},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	resources, err := controllerutils.AddTypeMeta(resources, r.scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot add typemeta to syncset agent syncset resources")
+	}
+
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SyncSetName(cd.Name),
+			Namespace: cd.Namespace,
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+				Resources:         resources,
+				Secrets: []hivev1.SecretMapping{{
+					SourceRef: hivev1.SecretReference{Name: syncsetagent.TokenSecretName(cd.Name)},
+					TargetRef: hivev1.SecretReference{Namespace: syncsetagent.Namespace, Name: tokenSecretName},
+				}},
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeSyncSetAgent)
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "error setting owner reference on syncset agent syncset")
+	}
+	return syncSet, nil
+}
@@ -0,0 +1,199 @@
+package syncsetagent
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/syncsetagent"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testName      = "test-cluster"
+	testNamespace = "test-namespace"
+	testHubURL    = "https://syncset-server.example.com"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileSyncSetAgent(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		config             *hivev1.SyncSetAgentConfig
+		existing           []runtime.Object
+		expectSyncSet      bool
+		expectPollInterval string
+		expectToken        string
+		expectAgentDeleted bool
+		expectErr          bool
+	}{
+		{
+			name:               "install agent",
+			cd:                 testClusterDeployment(true),
+			config:             &hivev1.SyncSetAgentConfig{HubURL: testHubURL},
+			expectSyncSet:      true,
+			expectPollInterval: "1m0s",
+		},
+		{
+			name:   "keep existing token",
+			cd:     testClusterDeployment(true),
+			config: &hivev1.SyncSetAgentConfig{HubURL: testHubURL, PollInterval: "5m"},
+			existing: []runtime.Object{
+				testTokenSecret("existing-token"),
+			},
+			expectSyncSet:      true,
+			expectPollInterval: "5m0s",
+			expectToken:        "existing-token",
+		},
+		{
+			name:      "invalid poll interval",
+			cd:        testClusterDeployment(true),
+			config:    &hivev1.SyncSetAgentConfig{HubURL: testHubURL, PollInterval: "often"},
+			expectErr: true,
+		},
+		{
+			name: "not installed",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(true)
+				cd.Spec.Installed = false
+				return cd
+			}(),
+			config: &hivev1.SyncSetAgentConfig{HubURL: testHubURL},
+		},
+		{
+			name:               "annotation removed",
+			cd:                 testClusterDeployment(false),
+			config:             &hivev1.SyncSetAgentConfig{HubURL: testHubURL},
+			existing:           []runtime.Object{testSyncSet(), testTokenSecret("existing-token")},
+			expectAgentDeleted: true,
+		},
+		{
+			name:               "syncset agent not enabled",
+			cd:                 testClusterDeployment(true),
+			existing:           []runtime.Object{testSyncSet(), testTokenSecret("existing-token")},
+			expectAgentDeleted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, test.cd)...)
+			a := &fakeApplier{}
+			r := &ReconcileSyncSetAgent{
+				Client:  c,
+				scheme:  scheme.Scheme,
+				applier: a,
+				config:  test.config,
+			}
+
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			if test.expectSyncSet {
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: syncsetagent.TokenSecretName(testName)}, secret), "expected token secret")
+				token := string(secret.Data[syncsetagent.TokenSecretKey])
+				if test.expectToken != "" {
+					assert.Equal(t, test.expectToken, token, "unexpected token")
+				} else {
+					assert.Len(t, token, 2*tokenLength, "unexpected length of generated token")
+				}
+
+				if assert.Len(t, a.appliedObjects, 1, "expected a syncset to be applied") {
+					ss := a.appliedObjects[0].(*hivev1.SyncSet)
+					assert.Equal(t, SyncSetName(testName), ss.Name)
+					assert.Equal(t, constants.SyncSetTypeSyncSetAgent, ss.Labels[constants.SyncSetTypeLabel], "unexpected syncset type")
+					assert.Equal(t, hivev1.SyncResourceApplyMode, ss.Spec.ResourceApplyMode)
+					if assert.Len(t, ss.Spec.Secrets, 1, "expected token secret mapping") {
+						assert.Equal(t, syncsetagent.TokenSecretName(testName), ss.Spec.Secrets[0].SourceRef.Name)
+						assert.Equal(t, syncsetagent.Namespace, ss.Spec.Secrets[0].TargetRef.Namespace)
+					}
+					deployment := ss.Spec.Resources[len(ss.Spec.Resources)-1].Object.(*appsv1.Deployment)
+					assert.Equal(t, syncsetagent.Namespace, deployment.Namespace, "unexpected namespace of deployment")
+					assert.Equal(t, []string{
+						"--hub-url", testHubURL,
+						"--cluster-namespace", testNamespace,
+						"--cluster-name", testName,
+						"--token-file", "/etc/syncset-agent/token",
+						"--poll-interval", test.expectPollInterval,
+					}, deployment.Spec.Template.Spec.Containers[0].Args, "unexpected agent args")
+				}
+			} else {
+				assert.Empty(t, a.appliedObjects, "expected no syncset to be applied")
+			}
+
+			if test.expectAgentDeleted {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: SyncSetName(testName)}, &hivev1.SyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected syncset to be deleted")
+				err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: syncsetagent.TokenSecretName(testName)}, &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), "expected token secret to be deleted")
+			}
+		})
+	}
+}
+
+func testClusterDeployment(pullsSyncSets bool) *hivev1.ClusterDeployment {
+	opts := []testcd.Option{testcd.Installed()}
+	if pullsSyncSets {
+		opts = append(opts, testcd.Generic(testgeneric.WithAnnotation(constants.SyncSetAgentAnnotation, "true")))
+	}
+	cd := testcd.FullBuilder(testNamespace, testName, scheme.Scheme).Build(opts...)
+	cd.UID = types.UID("test-uid")
+	return cd
+}
+
+func testSyncSet() *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      SyncSetName(testName),
+		},
+	}
+}
+
+func testTokenSecret(token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      syncsetagent.TokenSecretName(testName),
+		},
+		Data: map[string][]byte{syncsetagent.TokenSecretKey: []byte(token)},
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
@@ -80,7 +80,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("unreachable-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              hivemetrics.NewErrorClassifyingReconciler(ControllerName, r),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -130,6 +130,8 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
+	cdLog = controllerutils.AddDebugModeLogging(cdLog, cd)
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
 		cdLog.Debug("cluster has deletion timestamp")
@@ -141,6 +143,11 @@ func (r *ReconcileRemoteMachineSet) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, nil
 	}
 
+	if controllerutils.IsDetached(cd) {
+		cdLog.Debug("cluster is detached")
+		return reconcile.Result{}, nil
+	}
+
 	if cd.Spec.ClusterMetadata == nil {
 		cdLog.Error("installed cluster with no cluster metadata")
 		return reconcile.Result{}, nil
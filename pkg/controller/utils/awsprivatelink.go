@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetAWSPrivateLinkConfig returns the AWS PrivateLink config from the environment, if any.
+func GetAWSPrivateLinkConfig() (*hivev1.AWSPrivateLinkConfig, error) {
+	value, ok := os.LookupEnv(constants.AWSPrivateLinkEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	config := &hivev1.AWSPrivateLinkConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.AWSPrivateLinkEnvVar)
+	}
+	return config, nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetAzurePrivateLinkConfig returns the Azure Private Link config from the environment, if any.
+func GetAzurePrivateLinkConfig() (*hivev1.AzurePrivateLinkConfig, error) {
+	value, ok := os.LookupEnv(constants.AzurePrivateLinkEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	config := &hivev1.AzurePrivateLinkConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.AzurePrivateLinkEnvVar)
+	}
+	return config, nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetClusterPoolCapacityPoolsConfig returns the capacity pools shared by ClusterPools from the environment, if any.
+func GetClusterPoolCapacityPoolsConfig() ([]hivev1.ClusterPoolCapacityPool, error) {
+	value, ok := os.LookupEnv(constants.ClusterPoolCapacityPoolsEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var capacityPools []hivev1.ClusterPoolCapacityPool
+	if err := json.Unmarshal([]byte(value), &capacityPools); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.ClusterPoolCapacityPoolsEnvVar)
+	}
+	return capacityPools, nil
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// ClusterClaimLifetime returns the lifetime of the claim: the lifetime in the spec of the claim, or the default claim
+// lifetime of the pool, limited to the maximum claim lifetime of the pool. It returns nil if the claim has no lifetime.
+func ClusterClaimLifetime(pool *hivev1.ClusterPool, claim *hivev1.ClusterClaim) *metav1.Duration {
+	lifetime := claim.Spec.Lifetime
+	poolLifetime := pool.Spec.ClaimLifetime
+	if poolLifetime == nil {
+		return lifetime
+	}
+	if lifetime == nil {
+		lifetime = poolLifetime.Default
+	}
+	if maximum := poolLifetime.Maximum; maximum != nil && (lifetime == nil || lifetime.Duration > maximum.Duration) {
+		lifetime = maximum
+	}
+	if lifetime == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: lifetime.Duration}
+}
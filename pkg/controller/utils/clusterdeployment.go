@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,13 +14,44 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 )
 
+// maxDebugModeDuration is the longest time into the future for which debug mode can be turned on.
+const maxDebugModeDuration = 24 * time.Hour
+
 func IsDeleteProtected(cd *hivev1.ClusterDeployment) bool {
 	protectedDelete, err := strconv.ParseBool(cd.Annotations[constants.ProtectedDeleteAnnotation])
 	return protectedDelete && err == nil
 }
 
+// IsReconcilePaused returns true if reconciliation of the cluster deployment has been paused with the reconcile-pause
+// annotation.
+func IsReconcilePaused(cd *hivev1.ClusterDeployment) bool {
+	paused, err := strconv.ParseBool(cd.Annotations[constants.ReconcilePauseAnnotation])
+	return paused && err == nil
+}
+
+// IsSyncSetPaused returns true if applying syncsets to the cluster has been paused with the syncset pause
+// annotation.
+func IsSyncSetPaused(cd *hivev1.ClusterDeployment) bool {
+	paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation])
+	return paused && err == nil
+}
+
+// IsDetached returns true if the cluster has been detached from Hive with spec.detach. Hive does not act on detached
+// clusters.
+func IsDetached(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Detach != nil
+}
+
 func ShouldSyncCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
-	if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); err == nil && paused {
+	if IsDetached(cd) {
+		logger.Info("syncing to cluster is disabled as the cluster is detached")
+		return false
+	}
+	if IsReconcilePaused(cd) {
+		logger.WithField("annotation", constants.ReconcilePauseAnnotation).Warn("reconciling cluster is paused by annotation")
+		return false
+	}
+	if IsSyncSetPaused(cd) {
 		logger.WithField("annotation", constants.SyncsetPauseAnnotation).Warn("syncing to cluster is disabled by annotation")
 		return false
 	}
@@ -68,3 +100,45 @@ func ClearRelocateAnnotation(obj metav1.Object) (changed bool) {
 	obj.SetAnnotations(annotations)
 	return true
 }
+
+// DebugModeUntil returns the time until which debug mode is turned on for the ClusterDeployment by the
+// DebugModeUntilAnnotation. The second return value is false if the annotation is not set or cannot be parsed.
+func DebugModeUntil(cd *hivev1.ClusterDeployment) (time.Time, bool) {
+	value, ok := cd.Annotations[constants.DebugModeUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// IsDebugModeEnabled returns true if debug mode is turned on for the ClusterDeployment. Debug mode is only turned
+// on if the time in the DebugModeUntilAnnotation has not passed and is at most 24 hours in the future.
+func IsDebugModeEnabled(cd *hivev1.ClusterDeployment) bool {
+	until, ok := DebugModeUntil(cd)
+	if !ok {
+		return false
+	}
+	remaining := time.Until(until)
+	return remaining > 0 && remaining <= maxDebugModeDuration
+}
+
+// AddDebugModeLogging returns a logger that logs at debug level while debug mode is turned on for the
+// ClusterDeployment. Otherwise the logger is returned unchanged.
+func AddDebugModeLogging(logger *log.Entry, cd *hivev1.ClusterDeployment) *log.Entry {
+	if logger.Logger.IsLevelEnabled(log.DebugLevel) || !IsDebugModeEnabled(cd) {
+		return logger
+	}
+	debugLogger := &log.Logger{
+		Out:          logger.Logger.Out,
+		Hooks:        logger.Logger.Hooks,
+		Formatter:    logger.Logger.Formatter,
+		ReportCaller: logger.Logger.ReportCaller,
+		Level:        log.DebugLevel,
+		ExitFunc:     logger.Logger.ExitFunc,
+	}
+	return log.NewEntry(debugLogger).WithFields(logger.Data).WithField("debugMode", true)
+}
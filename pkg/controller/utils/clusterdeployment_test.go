@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -95,6 +97,25 @@ func TestShouldSyncCluster(t *testing.T) {
 			),
 			expected: false,
 		},
+		{
+			name: "reconcile pause annotation true",
+			cd: clusterdeployment.Build(
+				clusterdeployment.Generic(generic.WithAnnotation(constants.ReconcilePauseAnnotation, "true")),
+			),
+			expected: false,
+		},
+		{
+			name:     "detached",
+			cd:       clusterdeployment.Build(clusterdeployment.Detached(false)),
+			expected: false,
+		},
+		{
+			name: "reconcile pause annotation false",
+			cd: clusterdeployment.Build(
+				clusterdeployment.Generic(generic.WithAnnotation(constants.ReconcilePauseAnnotation, "false")),
+			),
+			expected: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -219,3 +240,66 @@ func TestClearRelocateAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDebugModeEnabled(t *testing.T) {
+	cases := []struct {
+		name     string
+		absent   bool
+		value    string
+		expected bool
+	}{
+		{
+			name:   "absent",
+			absent: true,
+		},
+		{
+			name:     "future",
+			value:    time.Now().Add(time.Hour).Format(time.RFC3339),
+			expected: true,
+		},
+		{
+			name:  "past",
+			value: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		},
+		{
+			name:  "too far in the future",
+			value: time.Now().Add(25 * time.Hour).Format(time.RFC3339),
+		},
+		{
+			name:  "not parsable",
+			value: "1h",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var options []clusterdeployment.Option
+			if !tc.absent {
+				options = append(
+					options,
+					clusterdeployment.Generic(generic.WithAnnotation(constants.DebugModeUntilAnnotation, tc.value)),
+				)
+			}
+			cd := clusterdeployment.Build(options...)
+			assert.Equal(t, tc.expected, IsDebugModeEnabled(cd), "unexpected result")
+		})
+	}
+}
+
+func TestAddDebugModeLogging(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = out
+	logger.Level = logrus.InfoLevel
+	entry := logger.WithField("clusterDeployment", "test-namespace/test-cd")
+
+	AddDebugModeLogging(entry, clusterdeployment.Build()).Debug("not in debug mode")
+	assert.Empty(t, out.String(), "expected debug message to not be logged")
+
+	cd := clusterdeployment.Build(
+		clusterdeployment.Generic(generic.WithAnnotation(constants.DebugModeUntilAnnotation, time.Now().Add(time.Hour).Format(time.RFC3339))),
+	)
+	AddDebugModeLogging(entry, cd).Debug("in debug mode")
+	assert.Contains(t, out.String(), "in debug mode", "expected debug message to be logged")
+	assert.Contains(t, out.String(), "clusterDeployment=test-namespace/test-cd", "expected fields of logger to be kept")
+	assert.Equal(t, logrus.InfoLevel, logger.Level, "expected level of original logger to be unchanged")
+}
@@ -109,6 +109,40 @@ func SetClusterDeploymentConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// InitializeClusterDeploymentConditions adds the condition types that are missing from the conditions with status
+// Unknown. Returns true if any condition was added.
+func InitializeClusterDeploymentConditions(
+	conditions []hivev1.ClusterDeploymentCondition,
+	conditionTypes []hivev1.ClusterDeploymentConditionType,
+) ([]hivev1.ClusterDeploymentCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	for _, conditionType := range conditionTypes {
+		if FindClusterDeploymentCondition(conditions, conditionType) != nil {
+			continue
+		}
+		conditions = append(
+			conditions,
+			hivev1.ClusterDeploymentCondition{
+				Type:               conditionType,
+				Status:             corev1.ConditionUnknown,
+				Reason:             hivev1.InitializedConditionReason,
+				Message:            "Condition Initialized",
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	}
+	return conditions, changed
+}
+
+// IsClusterDeploymentConditionSet returns true if the condition has been set by its controller, rather than being
+// missing or only initialized.
+func IsClusterDeploymentConditionSet(condition *hivev1.ClusterDeploymentCondition) bool {
+	return condition != nil && condition.Status != corev1.ConditionUnknown
+}
+
 // SetClusterClaimCondition sets a condition on a ClusterClaim resource's status
 func SetClusterClaimCondition(
 	conditions []hivev1.ClusterClaimCondition,
@@ -177,6 +211,74 @@ func SetClusterClaimConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetAdoptClusterRequestCondition sets a condition on an AdoptClusterRequest resource's status
+func SetAdoptClusterRequestCondition(
+	conditions []hivev1.AdoptClusterRequestCondition,
+	conditionType hivev1.AdoptClusterRequestConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) []hivev1.AdoptClusterRequestCondition {
+	newConditions, _ := SetAdoptClusterRequestConditionWithChangeCheck(
+		conditions,
+		conditionType,
+		status,
+		reason,
+		message,
+		updateConditionCheck,
+	)
+	return newConditions
+}
+
+// SetAdoptClusterRequestConditionWithChangeCheck sets a condition on an AdoptClusterRequest resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetAdoptClusterRequestConditionWithChangeCheck(
+	conditions []hivev1.AdoptClusterRequestCondition,
+	conditionType hivev1.AdoptClusterRequestConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.AdoptClusterRequestCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindAdoptClusterRequestCondition(conditions, conditionType)
+	if existingCondition == nil {
+		if status == corev1.ConditionTrue {
+			conditions = append(
+				conditions,
+				hivev1.AdoptClusterRequestCondition{
+					Type:               conditionType,
+					Status:             status,
+					Reason:             reason,
+					Message:            message,
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				},
+			)
+			changed = true
+		}
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 // SetClusterPoolCondition sets a condition on a ClusterPool resource's status
 func SetClusterPoolCondition(
 	conditions []hivev1.ClusterPoolCondition,
@@ -557,6 +659,17 @@ func FindClusterClaimCondition(conditions []hivev1.ClusterClaimCondition, condit
 	return nil
 }
 
+// FindAdoptClusterRequestCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindAdoptClusterRequestCondition(conditions []hivev1.AdoptClusterRequestCondition, conditionType hivev1.AdoptClusterRequestConditionType) *hivev1.AdoptClusterRequestCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // FindClusterPoolCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindClusterPoolCondition(conditions []hivev1.ClusterPoolCondition, conditionType hivev1.ClusterPoolConditionType) *hivev1.ClusterPoolCondition {
@@ -2,10 +2,12 @@ package utils
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -99,3 +101,60 @@ func removeFinalizerIfPresent(c client.Client, logger log.FieldLogger, obj hivev
 	}
 	return nil
 }
+
+// ConflictingDNSZone returns the DNSZone in dnsZones that claims the zone of dnsZone, or nil when the zone is not claimed
+// by another DNSZone. DNSZones may share a zone only when they have the same controller owner. Otherwise the zone is
+// claimed by the oldest DNSZone that is not being deleted, with ties broken by namespace and name. A dnsZone without a
+// creation timestamp, such as one that is being created, is newer than every existing DNSZone.
+func ConflictingDNSZone(dnsZone *hivev1.DNSZone, dnsZones []hivev1.DNSZone) *hivev1.DNSZone {
+	var claimant *hivev1.DNSZone
+	for i := range dnsZones {
+		other := &dnsZones[i]
+		switch {
+		case other.Namespace == dnsZone.Namespace && other.Name == dnsZone.Name:
+			continue
+		case other.DeletionTimestamp != nil:
+			continue
+		case normalizeZone(other.Spec.Zone) != normalizeZone(dnsZone.Spec.Zone):
+			continue
+		case dnsZoneOwner(other) == dnsZoneOwner(dnsZone):
+			continue
+		case !dnsZoneCreatedBefore(other, dnsZone):
+			continue
+		}
+		if claimant == nil || dnsZoneCreatedBefore(other, claimant) {
+			claimant = other
+		}
+	}
+	return claimant
+}
+
+// normalizeZone returns the canonical form of a zone for comparison.
+func normalizeZone(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// dnsZoneOwner returns the UID of the controller owner of the DNSZone, or the namespace and name of the DNSZone when it
+// has no controller owner.
+func dnsZoneOwner(dnsZone *hivev1.DNSZone) string {
+	if owner := metav1.GetControllerOf(dnsZone); owner != nil && owner.UID != "" {
+		return string(owner.UID)
+	}
+	return dnsZone.Namespace + "/" + dnsZone.Name
+}
+
+// dnsZoneCreatedBefore returns true if dnsZone a was created before dnsZone b.
+func dnsZoneCreatedBefore(a, b *hivev1.DNSZone) bool {
+	switch aTime, bTime := a.CreationTimestamp, b.CreationTimestamp; {
+	case bTime.IsZero() && !aTime.IsZero():
+		return true
+	case aTime.IsZero() && !bTime.IsZero():
+		return false
+	case !aTime.Equal(&bTime):
+		return aTime.Before(&bTime)
+	case a.Namespace != b.Namespace:
+		return a.Namespace < b.Namespace
+	default:
+		return a.Name < b.Name
+	}
+}
@@ -2,10 +2,13 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -112,3 +115,112 @@ func TestReconcileDNSZoneForRelocation(t *testing.T) {
 		})
 	}
 }
+
+func TestConflictingDNSZone(t *testing.T) {
+	now := time.Now()
+	withOwner := func(uid string) testdnszone.Option {
+		return func(dnsZone *hivev1.DNSZone) {
+			dnsZone.OwnerReferences = append(dnsZone.OwnerReferences, *metav1.NewControllerRef(
+				&hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: uid, UID: types.UID(uid)}},
+				hivev1.SchemeGroupVersion.WithKind("ClusterDeployment"),
+			))
+		}
+	}
+	dnsZone := func(namespace, name, zone string, created time.Time, opts ...testdnszone.Option) hivev1.DNSZone {
+		return *testdnszone.BasicBuilder().
+			GenericOptions(
+				testgeneric.WithNamespace(namespace),
+				testgeneric.WithName(name),
+				testgeneric.WithCreationTimestamp(created),
+			).
+			Options(testdnszone.WithZone(zone)).
+			Build(opts...)
+	}
+	cases := []struct {
+		name           string
+		dnsZone        hivev1.DNSZone
+		dnsZones       []hivev1.DNSZone
+		expectConflict string
+	}{
+		{
+			name:    "no other zones",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			},
+		},
+		{
+			name:    "different zone",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "other.example.com", now.Add(-time.Hour), withOwner("owner2")),
+			},
+		},
+		{
+			name:    "older zone with different owner",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com.", now.Add(-time.Hour), withOwner("owner2")),
+			},
+			expectConflict: "ns2/zone2",
+		},
+		{
+			name:    "newer zone with different owner",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com", now.Add(time.Hour), withOwner("owner2")),
+			},
+		},
+		{
+			name:    "older zone with same owner",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com", now.Add(-time.Hour), withOwner("owner1")),
+			},
+		},
+		{
+			name:    "older deleted zone",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com", now.Add(-time.Hour), withOwner("owner2"), testdnszone.Generic(testgeneric.Deleted())),
+			},
+		},
+		{
+			name:    "same creation time breaks tie by namespace",
+			dnsZone: dnsZone("ns2", "zone2", "blah.example.com", now),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns1", "zone1", "blah.example.com", now),
+			},
+			expectConflict: "ns1/zone1",
+		},
+		{
+			name:    "oldest of several claimants",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", now, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com", now.Add(-time.Hour), withOwner("owner2")),
+				dnsZone("ns3", "zone3", "blah.example.com", now.Add(-2*time.Hour), withOwner("owner3")),
+			},
+			expectConflict: "ns3/zone3",
+		},
+		{
+			name:    "zone being created",
+			dnsZone: dnsZone("ns1", "zone1", "blah.example.com", time.Time{}, withOwner("owner1")),
+			dnsZones: []hivev1.DNSZone{
+				dnsZone("ns2", "zone2", "blah.example.com", now, withOwner("owner2")),
+			},
+			expectConflict: "ns2/zone2",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conflict := ConflictingDNSZone(&tc.dnsZone, tc.dnsZones)
+			if tc.expectConflict == "" {
+				assert.Nil(t, conflict, "expected no conflicting DNSZone")
+				return
+			}
+			if assert.NotNil(t, conflict, "expected a conflicting DNSZone") {
+				assert.Equal(t, tc.expectConflict, conflict.Namespace+"/"+conflict.Name, "unexpected conflicting DNSZone")
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetExternalDestroyers returns the external destroyers from the environment, if any.
+func GetExternalDestroyers() ([]hivev1.ExternalDestroyer, error) {
+	value, ok := os.LookupEnv(constants.ExternalDestroyersEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var destroyers []hivev1.ExternalDestroyer
+	if err := json.Unmarshal([]byte(value), &destroyers); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.ExternalDestroyersEnvVar)
+	}
+	return destroyers, nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetGCPPrivateServiceConnectConfig returns the GCP Private Service Connect config from the environment, if any.
+func GetGCPPrivateServiceConnectConfig() (*hivev1.GCPPrivateServiceConnectConfig, error) {
+	value, ok := os.LookupEnv(constants.GCPPrivateServiceConnectEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	config := &hivev1.GCPPrivateServiceConnectConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.GCPPrivateServiceConnectEnvVar)
+	}
+	return config, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetPullThroughCache returns the pull-through cache config for install and imageset jobs from the environment,
+// if any.
+func GetPullThroughCache() (*hivev1.PullThroughCacheConfig, error) {
+	value, ok := os.LookupEnv(constants.PullThroughCacheEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	cache := &hivev1.PullThroughCacheConfig{}
+	if err := json.Unmarshal([]byte(value), cache); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.PullThroughCacheEnvVar)
+	}
+	return cache, nil
+}
+
+// MirrorImage rewrites the image reference to pull from the mirror with the most specific source matching the
+// image. The image is returned unchanged when no mirror matches.
+func MirrorImage(image string, cache *hivev1.PullThroughCacheConfig) string {
+	if cache == nil {
+		return image
+	}
+	var best *hivev1.RegistryMirror
+	for i, m := range cache.Mirrors {
+		if m.Source == "" || !strings.HasPrefix(image, m.Source) {
+			continue
+		}
+		// The source must match whole path components of the image repository.
+		if rest := image[len(m.Source):]; rest != "" && !strings.ContainsAny(rest[:1], "/:@") {
+			continue
+		}
+		if best == nil || len(m.Source) > len(best.Source) {
+			best = &cache.Mirrors[i]
+		}
+	}
+	if best == nil {
+		return image
+	}
+	return best.Mirror + image[len(best.Source):]
+}
+
+// ApplyPullThroughCache rewrites the images of the containers in the pod spec to pull through the pull-through
+// cache configured for install and imageset jobs. If the cache requires credentials, the named pull secret holding
+// them is added to the image pull secrets of the pod.
+func ApplyPullThroughCache(podSpec *corev1.PodSpec, cachePullSecretName string) error {
+	cache, err := GetPullThroughCache()
+	if err != nil || cache == nil {
+		return err
+	}
+	if PullThroughCacheHasCredentials(cache) {
+		podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: cachePullSecretName})
+	}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Image = MirrorImage(podSpec.InitContainers[i].Image, cache)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Image = MirrorImage(podSpec.Containers[i].Image, cache)
+	}
+	return nil
+}
+
+// PullThroughCacheHasCredentials returns true if the pull-through cache references a secret with its credentials.
+func PullThroughCacheHasCredentials(cache *hivev1.PullThroughCacheConfig) bool {
+	return cache != nil && cache.CredentialsSecretRef != nil && cache.CredentialsSecretRef.Name != ""
+}
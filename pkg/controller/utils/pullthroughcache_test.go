@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestMirrorImage(t *testing.T) {
+	cache := &hivev1.PullThroughCacheConfig{
+		Mirrors: []hivev1.RegistryMirror{
+			{Source: "quay.io", Mirror: "cache.example.com/quay"},
+			{Source: "quay.io/openshift-release-dev", Mirror: "cache.example.com/release"},
+		},
+	}
+	cases := []struct {
+		name     string
+		image    string
+		cache    *hivev1.PullThroughCacheConfig
+		expected string
+	}{
+		{
+			name:     "no cache",
+			image:    "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64",
+			expected: "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64",
+		},
+		{
+			name:     "most specific mirror",
+			image:    "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64",
+			cache:    cache,
+			expected: "cache.example.com/release/ocp-release:4.6.0-x86_64",
+		},
+		{
+			name:     "registry mirror",
+			image:    "quay.io/other/image@sha256:abcd",
+			cache:    cache,
+			expected: "cache.example.com/quay/other/image@sha256:abcd",
+		},
+		{
+			name:     "source is not a path component prefix",
+			image:    "quay.io/openshift-release-dev-other/image:latest",
+			cache:    cache,
+			expected: "cache.example.com/quay/openshift-release-dev-other/image:latest",
+		},
+		{
+			name:     "no matching mirror",
+			image:    "registry.example.com/image:latest",
+			cache:    cache,
+			expected: "registry.example.com/image:latest",
+		},
+		{
+			name:     "registry prefix of another registry",
+			image:    "quay.io.example.com/image:latest",
+			cache:    cache,
+			expected: "quay.io.example.com/image:latest",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MirrorImage(tc.image, tc.cache), "unexpected image")
+		})
+	}
+}
+
+func TestApplyPullThroughCache(t *testing.T) {
+	cases := []struct {
+		name                     string
+		cache                    string
+		expectedImage            string
+		expectedImagePullSecrets []corev1.LocalObjectReference
+	}{
+		{
+			name:          "no cache",
+			expectedImage: "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64",
+		},
+		{
+			name:          "cache without credentials",
+			cache:         `{"mirrors":[{"source":"quay.io","mirror":"cache.example.com/quay"}]}`,
+			expectedImage: "cache.example.com/quay/openshift-release-dev/ocp-release:4.6.0-x86_64",
+		},
+		{
+			name:                     "cache with credentials",
+			cache:                    `{"mirrors":[{"source":"quay.io","mirror":"cache.example.com/quay"}],"credentialsSecretRef":{"name":"cache-creds"}}`,
+			expectedImage:            "cache.example.com/quay/openshift-release-dev/ocp-release:4.6.0-x86_64",
+			expectedImagePullSecrets: []corev1.LocalObjectReference{{Name: "merged"}, {Name: "cache-pull-secret"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cache != "" {
+				os.Setenv(constants.PullThroughCacheEnvVar, tc.cache)
+			}
+			defer os.Unsetenv(constants.PullThroughCacheEnvVar)
+			podSpec := &corev1.PodSpec{
+				Containers:       []corev1.Container{{Image: "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "merged"}},
+			}
+			if tc.expectedImagePullSecrets == nil {
+				tc.expectedImagePullSecrets = []corev1.LocalObjectReference{{Name: "merged"}}
+			}
+			err := ApplyPullThroughCache(podSpec, "cache-pull-secret")
+			require.NoError(t, err, "unexpected error applying pull-through cache")
+			assert.Equal(t, tc.expectedImage, podSpec.Containers[0].Image, "unexpected image")
+			assert.Equal(t, tc.expectedImagePullSecrets, podSpec.ImagePullSecrets, "unexpected image pull secrets")
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetMachinePoolReplicaLimitsConfig returns the machine pool replica limits config from the environment, if any.
+func GetMachinePoolReplicaLimitsConfig() (*hivev1.MachinePoolReplicaLimitsConfig, error) {
+	value, ok := os.LookupEnv(constants.MachinePoolReplicaLimitsEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	config := &hivev1.MachinePoolReplicaLimitsConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.MachinePoolReplicaLimitsEnvVar)
+	}
+	return config, nil
+}
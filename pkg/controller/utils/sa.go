@@ -21,6 +21,10 @@ const (
 	ServiceAccountName = "cluster-installer"
 	roleName           = "cluster-installer"
 	roleBindingName    = "cluster-installer"
+
+	// DeprovisionServiceAccountName is the service account of the uninstall pods, which can report the progress of
+	// the uninstall in the status of their ClusterDeprovision.
+	DeprovisionServiceAccountName = "cluster-deprovisioner"
 )
 
 var (
@@ -46,28 +50,47 @@ var (
 			Verbs:     []string{"get", "list", "update", "watch"},
 		},
 	}
+
+	deprovisionRoleRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"hive.openshift.io"},
+			Resources: []string{"clusterdeprovisions", "clusterdeprovisions/status"},
+			Verbs:     []string{"get", "update"},
+		},
+	}
 )
 
 // SetupClusterInstallServiceAccount ensures a service account exists which can upload
 // the required artifacts after running the installer in a pod. (metadata, admin kubeconfig)
 func SetupClusterInstallServiceAccount(c client.Client, namespace string, logger log.FieldLogger) error {
+	return setupServiceAccount(c, namespace, ServiceAccountName, roleName, roleBindingName, roleRules, logger)
+}
+
+// SetupClusterDeprovisionServiceAccount ensures a service account exists which can update the status of the
+// ClusterDeprovisions in the namespace.
+func SetupClusterDeprovisionServiceAccount(c client.Client, namespace string, logger log.FieldLogger) error {
+	return setupServiceAccount(c, namespace, DeprovisionServiceAccountName, DeprovisionServiceAccountName, DeprovisionServiceAccountName, deprovisionRoleRules, logger)
+}
+
+// setupServiceAccount ensures a service account exists which is bound to a role with the given rules.
+func setupServiceAccount(c client.Client, namespace, serviceAccountName, roleName, roleBindingName string, roleRules []rbacv1.PolicyRule, logger log.FieldLogger) error {
 	// create new serviceaccount if it doesn't already exist
-	switch err := c.Get(context.Background(), client.ObjectKey{Name: ServiceAccountName, Namespace: namespace}, &corev1.ServiceAccount{}); {
+	switch err := c.Get(context.Background(), client.ObjectKey{Name: serviceAccountName, Namespace: namespace}, &corev1.ServiceAccount{}); {
 	case apierrors.IsNotFound(err):
 		sa := &corev1.ServiceAccount{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      ServiceAccountName,
+				Name:      serviceAccountName,
 				Namespace: namespace,
 			},
 		}
 		if err := c.Create(context.TODO(), sa); err != nil {
 			return errors.Wrap(err, "error creating serviceaccount")
 		}
-		logger.WithField("name", ServiceAccountName).Info("created service account")
+		logger.WithField("name", serviceAccountName).Info("created service account")
 	case err != nil:
 		return errors.Wrap(err, "error checking for existing serviceaccount")
 	default:
-		logger.WithField("name", ServiceAccountName).Debug("service account already exists")
+		logger.WithField("name", serviceAccountName).Debug("service account already exists")
 	}
 
 	currentRole := &rbacv1.Role{}
@@ -107,7 +130,7 @@ func SetupClusterInstallServiceAccount(c client.Client, namespace string, logger
 			Subjects: []rbacv1.Subject{
 				{
 					Kind:      "ServiceAccount",
-					Name:      ServiceAccountName,
+					Name:      serviceAccountName,
 					Namespace: namespace,
 				},
 			},
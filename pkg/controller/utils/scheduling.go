@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ApplyPodScheduling adds the node selector, tolerations and architecture node affinity from the scheduling
+// config to the pod spec.
+func ApplyPodScheduling(podSpec *corev1.PodSpec, scheduling *hivev1.PodSchedulingConfig) {
+	if scheduling == nil {
+		return
+	}
+	if len(scheduling.NodeSelector) > 0 {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = make(map[string]string, len(scheduling.NodeSelector))
+		}
+		for k, v := range scheduling.NodeSelector {
+			podSpec.NodeSelector[k] = v
+		}
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, scheduling.Tolerations...)
+	if len(scheduling.Architectures) == 0 {
+		return
+	}
+	archRequirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   scheduling.Architectures,
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	// Node selector terms are ORed, so the architecture requirement must be added to every term.
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, archRequirement)
+	}
+}
+
+// GetJobScheduling returns the scheduling config for jobs launched by the hive controllers from the
+// environment, if any.
+func GetJobScheduling() (*hivev1.PodSchedulingConfig, error) {
+	value, ok := os.LookupEnv(constants.JobSchedulingEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	scheduling := &hivev1.PodSchedulingConfig{}
+	if err := json.Unmarshal([]byte(value), scheduling); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.JobSchedulingEnvVar)
+	}
+	return scheduling, nil
+}
+
+// ApplyJobScheduling adds the scheduling config for jobs launched by the hive controllers to the pod spec.
+func ApplyJobScheduling(podSpec *corev1.PodSpec) error {
+	scheduling, err := GetJobScheduling()
+	if err != nil {
+		return err
+	}
+	ApplyPodScheduling(podSpec, scheduling)
+	return nil
+}
+
+// ApplyProvisionPriorityClass assigns the PriorityClass for install pods from the environment, if any, to the pod
+// spec.
+func ApplyProvisionPriorityClass(podSpec *corev1.PodSpec) {
+	if priorityClass := os.Getenv(constants.ProvisionPriorityClassEnvVar); priorityClass != "" {
+		podSpec.PriorityClassName = priorityClass
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestApplyPodScheduling(t *testing.T) {
+	toleration := corev1.Toleration{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	archRequirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"amd64"},
+	}
+	cases := []struct {
+		name       string
+		podSpec    corev1.PodSpec
+		scheduling *hivev1.PodSchedulingConfig
+		expected   corev1.PodSpec
+	}{
+		{
+			name: "no scheduling",
+		},
+		{
+			name: "node selector and tolerations",
+			podSpec: corev1.PodSpec{
+				NodeSelector: map[string]string{"existing": "value"},
+			},
+			scheduling: &hivev1.PodSchedulingConfig{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:  []corev1.Toleration{toleration},
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					"existing":                      "value",
+					"node-role.kubernetes.io/infra": "",
+				},
+				Tolerations: []corev1.Toleration{toleration},
+			},
+		},
+		{
+			name: "architectures",
+			scheduling: &hivev1.PodSchedulingConfig{
+				Architectures: []string{"amd64"},
+			},
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "architectures with existing node selector terms",
+			podSpec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "a", Operator: corev1.NodeSelectorOpExists}}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "b", Operator: corev1.NodeSelectorOpExists}}},
+							},
+						},
+					},
+				},
+			},
+			scheduling: &hivev1.PodSchedulingConfig{
+				Architectures: []string{"amd64"},
+			},
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "a", Operator: corev1.NodeSelectorOpExists}, archRequirement}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "b", Operator: corev1.NodeSelectorOpExists}, archRequirement}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			podSpec := tc.podSpec
+			ApplyPodScheduling(&podSpec, tc.scheduling)
+			assert.Equal(t, tc.expected, podSpec, "unexpected pod spec")
+		})
+	}
+}
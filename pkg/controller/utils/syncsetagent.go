@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// GetSyncSetAgentConfig returns the syncset agent config from the environment, if any.
+func GetSyncSetAgentConfig() (*hivev1.SyncSetAgentConfig, error) {
+	value, ok := os.LookupEnv(constants.SyncSetAgentEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	config := &hivev1.SyncSetAgentConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.SyncSetAgentEnvVar)
+	}
+	return config, nil
+}
+
+// PullsSyncSets returns true when the syncset agent on the cluster pulls the syncsets of the cluster.
+func PullsSyncSets(cd *hivev1.ClusterDeployment) bool {
+	return cd.Annotations[constants.SyncSetAgentAnnotation] == "true"
+}
@@ -0,0 +1,216 @@
+// Package cron parses standard five-field cron expressions and computes the times at which they activate.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchYears bounds how far from the given time Next and Prev search for an activation, so that expressions which
+// never activate, such as "0 0 30 2 *", do not search forever.
+const searchYears = 5
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek record whether the day-of-month and day-of-week fields start with "*". When both
+	// fields are restricted, a day matches if either of them matches, as in the traditional cron.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteBounds     = bounds{min: 0, max: 59}
+	hourBounds       = bounds{min: 0, max: 23}
+	dayOfMonthBounds = bounds{min: 1, max: 31}
+	monthBounds      = bounds{min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday.
+	dayOfWeekBounds = bounds{min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a cron expression of the five fields minute, hour, day of month, month and day of week. Each field is a
+// comma-separated list of "*", values and ranges of values, each optionally followed by "/" and a step. Months and
+// days of week can also be given by the first three letters of their names.
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d: %q", len(fields), spec)
+	}
+	s := &Schedule{
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}
+	for i, f := range []struct {
+		name   string
+		bits   *uint64
+		bounds bounds
+	}{
+		{name: "minute", bits: &s.minute, bounds: minuteBounds},
+		{name: "hour", bits: &s.hour, bounds: hourBounds},
+		{name: "day of month", bits: &s.dayOfMonth, bounds: dayOfMonthBounds},
+		{name: "month", bits: &s.month, bounds: monthBounds},
+		{name: "day of week", bits: &s.dayOfWeek, bounds: dayOfWeekBounds},
+	} {
+		bits, err := parseField(fields[i], f.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %v", f.name, fields[i], err)
+		}
+		*f.bits = bits
+	}
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek = s.dayOfWeek&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		exprBits, err := parseExpr(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= exprBits
+	}
+	return bits, nil
+}
+
+func parseExpr(expr string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("too many steps in %q", expr)
+	}
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	if len(lowAndHigh) > 2 {
+		return 0, fmt.Errorf("too many hyphens in %q", expr)
+	}
+	var start, end uint
+	if lowAndHigh[0] == "*" {
+		if len(lowAndHigh) != 1 {
+			return 0, fmt.Errorf("\"*\" cannot start a range in %q", expr)
+		}
+		start, end = b.min, b.max
+	} else {
+		var err error
+		if start, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = parseValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		}
+	}
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		s, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || s == 0 {
+			return 0, fmt.Errorf("invalid step in %q", expr)
+		}
+		step = uint(s)
+		// A single value with a step, such as "5/15", runs from the value to the end of the field.
+		if len(lowAndHigh) == 1 && lowAndHigh[0] != "*" {
+			end = b.max
+		}
+	}
+	if start < b.min || end > b.max {
+		return 0, fmt.Errorf("%q is outside %d-%d", expr, b.min, b.max)
+	}
+	if start > end {
+		return 0, fmt.Errorf("range %q ends before it starts", expr)
+	}
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return uint(v), nil
+}
+
+// Next returns the first activation of the schedule after t, in the location of t. It returns the zero time if the
+// schedule does not activate within five years of t.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchYears, 0, 0)
+	for t.Before(limit) {
+		var next time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// Daylight saving time transitions can normalize a wall clock time to an earlier instant.
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// Prev returns the last activation of the schedule at or before t, in the location of t. It returns the zero time if
+// the schedule did not activate within five years before t.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute)
+	limit := t.AddDate(-searchYears, 0, 0)
+	for t.After(limit) {
+		var prev time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			prev = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+		case !s.dayMatches(t):
+			prev = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			prev = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			prev = t.Add(-time.Minute)
+		default:
+			return t
+		}
+		if !prev.Before(t) {
+			prev = t.Add(-time.Minute)
+		}
+		t = prev
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
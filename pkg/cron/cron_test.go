@@ -0,0 +1,125 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name        string
+		spec        string
+		expectError bool
+	}{
+		{name: "every minute", spec: "* * * * *"},
+		{name: "lists, ranges and steps", spec: "0,30 8-18/2 1-15 */3 1-5"},
+		{name: "names", spec: "0 0 * Jan-Jun mon,WED,fri"},
+		{name: "sunday as 7", spec: "0 0 * * 7"},
+		{name: "value with step", spec: "5/15 * * * *"},
+		{name: "too few fields", spec: "* * * *", expectError: true},
+		{name: "too many fields", spec: "* * * * * *", expectError: true},
+		{name: "minute out of range", spec: "60 * * * *", expectError: true},
+		{name: "day of month out of range", spec: "0 0 0 * *", expectError: true},
+		{name: "backwards range", spec: "0 18-8 * * *", expectError: true},
+		{name: "zero step", spec: "*/0 * * * *", expectError: true},
+		{name: "unknown name", spec: "0 0 * * funday", expectError: true},
+		{name: "star range", spec: "*-5 * * * *", expectError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.spec)
+			if tc.expectError {
+				assert.Error(t, err, "expected error parsing %q", tc.spec)
+			} else {
+				assert.NoError(t, err, "unexpected error parsing %q", tc.spec)
+			}
+		})
+	}
+}
+
+func TestNextAndPrev(t *testing.T) {
+	// 2020-06-10 is a Wednesday.
+	now := time.Date(2020, 6, 10, 12, 30, 45, 0, time.UTC)
+	cases := []struct {
+		name         string
+		spec         string
+		expectedNext time.Time
+		expectedPrev time.Time
+	}{
+		{
+			name:         "every minute",
+			spec:         "* * * * *",
+			expectedNext: time.Date(2020, 6, 10, 12, 31, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 6, 10, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "evenings",
+			spec:         "0 19 * * *",
+			expectedNext: time.Date(2020, 6, 10, 19, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 6, 9, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "weekday mornings",
+			spec:         "0 7 * * mon-fri",
+			expectedNext: time.Date(2020, 6, 11, 7, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 6, 10, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "sundays",
+			spec:         "15 3 * * 7",
+			expectedNext: time.Date(2020, 6, 14, 3, 15, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 6, 7, 3, 15, 0, 0, time.UTC),
+		},
+		{
+			name:         "day of month or day of week",
+			spec:         "0 0 1 * fri",
+			expectedNext: time.Date(2020, 6, 12, 0, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "yearly",
+			spec:         "0 0 1 jan *",
+			expectedNext: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "leap day",
+			spec:         "0 0 29 2 *",
+			expectedNext: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			expectedPrev: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			spec: "0 0 30 2 *",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			require.NoError(t, err, "unexpected error parsing %q", tc.spec)
+			assert.Equal(t, tc.expectedNext, s.Next(now), "unexpected next activation")
+			assert.Equal(t, tc.expectedPrev, s.Prev(now), "unexpected previous activation")
+		})
+	}
+}
+
+func TestPrevAtActivation(t *testing.T) {
+	s, err := Parse("0 19 * * *")
+	require.NoError(t, err, "unexpected error parsing schedule")
+	activation := time.Date(2020, 6, 10, 19, 0, 0, 0, time.UTC)
+	assert.Equal(t, activation, s.Prev(activation), "expected activation to be its own previous activation")
+	assert.Equal(t, activation.AddDate(0, 0, 1), s.Next(activation), "expected next activation to be the next day")
+}
+
+func TestLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "unexpected error loading location")
+	s, err := Parse("0 19 * * *")
+	require.NoError(t, err, "unexpected error parsing schedule")
+	now := time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC)
+	next := s.Next(now.In(loc))
+	assert.Equal(t, time.Date(2020, 6, 10, 23, 0, 0, 0, time.UTC), next.UTC(), "unexpected next activation")
+}
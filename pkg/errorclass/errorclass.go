@@ -0,0 +1,198 @@
+// Package errorclass classifies the errors Hive reports in conditions and metrics, so that errors the user must fix
+// can be told apart from failures of cloud providers and from bugs in Hive itself.
+package errorclass
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"google.golang.org/api/googleapi"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Class is the class of an error.
+type Class string
+
+const (
+	// UserError is an error caused by the configuration provided by the user, such as invalid credentials or
+	// settings. It persists until the user fixes the configuration.
+	UserError Class = "UserError"
+	// TransientCloudError is a temporary failure of a cloud provider or API server, such as throttling or an outage,
+	// that is expected to resolve on retry.
+	TransientCloudError Class = "TransientCloudError"
+	// QuotaError is an error caused by exhausting a quota or limit of the cloud account.
+	QuotaError Class = "QuotaError"
+	// CapacityError is an error caused by a region or zone of the cloud provider running out of capacity for the
+	// requested resources. Unlike a quota error, it usually resolves without the user doing anything.
+	CapacityError Class = "CapacityError"
+	// HiveInternalError is an error of Hive itself, such as a failure to render the resources it creates.
+	HiveInternalError Class = "HiveInternalError"
+	// Unknown is the class of errors that could not be classified. They are not attributed to Hive, since most of
+	// them come from cloud providers and clusters.
+	Unknown Class = "Unknown"
+)
+
+var (
+	awsQuotaCodes = map[string]bool{
+		"AddressLimitExceeded":          true,
+		"HostedZoneLimitExceeded":       true,
+		"InstanceLimitExceeded":         true,
+		"LimitExceeded":                 true,
+		"LimitExceededException":        true,
+		"NatGatewayLimitExceeded":       true,
+		"ServiceQuotaExceededException": true,
+		"TooManyHostedZones":            true,
+		"VcpuLimitExceeded":             true,
+		"VpcLimitExceeded":              true,
+	}
+	awsCapacityCodes = map[string]bool{
+		"InsufficientHostCapacity":     true,
+		"InsufficientInstanceCapacity": true,
+	}
+	awsTransientCodes = map[string]bool{
+		"InternalError":            true,
+		"InternalFailure":          true,
+		"PriorRequestNotComplete":  true,
+		"RequestLimitExceeded":     true,
+		"RequestThrottled":         true,
+		"RequestTimeout":           true,
+		"ServiceUnavailable":       true,
+		"Throttling":               true,
+		"ThrottlingException":      true,
+		"TooManyRequestsException": true,
+		"Unavailable":              true,
+	}
+	awsUserCodes = map[string]bool{
+		"AccessDenied":                true,
+		"AccessDeniedException":       true,
+		"AuthFailure":                 true,
+		"InvalidClientTokenId":        true,
+		"OptInRequired":               true,
+		"PendingVerification":         true,
+		"SignatureDoesNotMatch":       true,
+		"UnauthorizedOperation":       true,
+		"UnrecognizedClientException": true,
+	}
+	gcpQuotaReasons = map[string]bool{
+		"quotaExceeded": true,
+	}
+	gcpTransientReasons = map[string]bool{
+		"backendError":          true,
+		"rateLimitExceeded":     true,
+		"userRateLimitExceeded": true,
+	}
+)
+
+type classifiedError struct {
+	class Class
+	err   error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+
+// Cause provides compatibility with github.com/pkg/errors.
+func (e *classifiedError) Cause() error { return e.err }
+
+// Unwrap provides compatibility for Go 1.13 error chains.
+func (e *classifiedError) Unwrap() error { return e.err }
+
+// New returns an error of the class with the message.
+func New(class Class, message string) error {
+	return &classifiedError{class: class, err: errors.New(message)}
+}
+
+// Wrap marks the error as an error of the class. Wrap returns nil if err is nil.
+func Wrap(class Class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// Of returns the class of the error. An error marked with Wrap, or wrapping such an error, has the class it was marked
+// with. Errors of the cloud provider and Kubernetes clients are classified by their codes, and any other error is
+// Unknown.
+func Of(err error) Class {
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		return classified.class
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		if class, ok := awsClass(awsErr); ok {
+			return class
+		}
+	}
+	var gcpErr *googleapi.Error
+	if errors.As(err, &gcpErr) {
+		if class, ok := gcpClass(gcpErr); ok {
+			return class
+		}
+	}
+	var azureErr autorest.DetailedError
+	if errors.As(err, &azureErr) {
+		if statusCode, ok := azureErr.StatusCode.(int); ok {
+			if class, ok := statusCodeClass(statusCode); ok {
+				return class
+			}
+		}
+	}
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		if apierrors.IsConflict(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+			apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) {
+			return TransientCloudError
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return TransientCloudError
+	}
+	return Unknown
+}
+
+func awsClass(err awserr.Error) (Class, bool) {
+	code := err.Code()
+	switch {
+	case awsQuotaCodes[code]:
+		return QuotaError, true
+	case awsCapacityCodes[code]:
+		return CapacityError, true
+	case awsTransientCodes[code]:
+		return TransientCloudError, true
+	case awsUserCodes[code], strings.HasPrefix(code, "InvalidParameter"):
+		return UserError, true
+	}
+	if requestErr, ok := err.(awserr.RequestFailure); ok {
+		return statusCodeClass(requestErr.StatusCode())
+	}
+	return "", false
+}
+
+func gcpClass(err *googleapi.Error) (Class, bool) {
+	for _, item := range err.Errors {
+		switch {
+		case gcpQuotaReasons[item.Reason]:
+			return QuotaError, true
+		case gcpTransientReasons[item.Reason]:
+			return TransientCloudError, true
+		}
+	}
+	return statusCodeClass(err.Code)
+}
+
+// statusCodeClass classifies the HTTP status code of a failed request to a cloud provider.
+func statusCodeClass(statusCode int) (Class, bool) {
+	switch {
+	case statusCode == http.StatusTooManyRequests, statusCode >= http.StatusInternalServerError:
+		return TransientCloudError, true
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return UserError, true
+	}
+	return "", false
+}
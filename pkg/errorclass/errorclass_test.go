@@ -0,0 +1,120 @@
+package errorclass
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOf(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected Class
+	}{
+		{
+			name:     "unclassified",
+			err:      errors.New("something broke"),
+			expected: Unknown,
+		},
+		{
+			name:     "marked",
+			err:      Wrap(UserError, errors.New("bad setting")),
+			expected: UserError,
+		},
+		{
+			name:     "wrapping marked",
+			err:      pkgerrors.Wrap(New(QuotaError, "out of addresses"), "could not allocate address"),
+			expected: QuotaError,
+		},
+		{
+			name:     "AWS quota",
+			err:      awserr.New("VpcLimitExceeded", "The maximum number of VPCs has been reached.", nil),
+			expected: QuotaError,
+		},
+		{
+			name:     "AWS capacity",
+			err:      awserr.New("InsufficientInstanceCapacity", "We currently do not have sufficient capacity.", nil),
+			expected: CapacityError,
+		},
+		{
+			name:     "AWS throttling",
+			err:      pkgerrors.Wrap(awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil), "could not describe instances"),
+			expected: TransientCloudError,
+		},
+		{
+			name:     "AWS credentials",
+			err:      awserr.New("AuthFailure", "AWS was not able to validate the provided access credentials", nil),
+			expected: UserError,
+		},
+		{
+			name:     "AWS server error",
+			err:      awserr.NewRequestFailure(awserr.New("Unknown", "unknown", nil), http.StatusBadGateway, "request"),
+			expected: TransientCloudError,
+		},
+		{
+			name:     "AWS unknown code",
+			err:      awserr.New("Unknown", "unknown", nil),
+			expected: Unknown,
+		},
+		{
+			name: "GCP quota",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}},
+			},
+			expected: QuotaError,
+		},
+		{
+			name: "GCP rate limit",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
+			},
+			expected: TransientCloudError,
+		},
+		{
+			name:     "GCP permission denied",
+			err:      &googleapi.Error{Code: http.StatusForbidden},
+			expected: UserError,
+		},
+		{
+			name:     "Azure throttling",
+			err:      autorest.DetailedError{StatusCode: http.StatusTooManyRequests},
+			expected: TransientCloudError,
+		},
+		{
+			name:     "Azure unauthorized",
+			err:      autorest.DetailedError{StatusCode: http.StatusUnauthorized},
+			expected: UserError,
+		},
+		{
+			name:     "Kubernetes conflict",
+			err:      apierrors.NewConflict(schema.GroupResource{Resource: "clusterdeployments"}, "test", errors.New("conflict")),
+			expected: TransientCloudError,
+		},
+		{
+			name:     "Kubernetes not found",
+			err:      apierrors.NewNotFound(schema.GroupResource{Resource: "clusterdeployments"}, "test"),
+			expected: Unknown,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Of(tc.err), "unexpected class")
+		})
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	assert.Nil(t, Wrap(UserError, nil), "expected nil error")
+}
@@ -0,0 +1,283 @@
+// Package etcdbackup takes etcd backups on clusters before Hive makes disruptive changes to them.
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	// Namespace is the namespace on the cluster in which the backup jobs run.
+	Namespace = "openshift-hive-etcd-backup"
+
+	defaultDirectory   = "/home/core/backups"
+	serviceAccountName = "etcd-backup"
+	clusterRoleBinding = "hive-etcd-backup"
+
+	// privilegedSCCClusterRole grants the use of the privileged security context constraint, which the backup jobs
+	// need to run the backup script on the control plane host.
+	privilegedSCCClusterRole = "system:openshift:scc:privileged"
+
+	backupFailedReason    = "BackupJobFailed"
+	backupSucceededReason = "BackupSucceeded"
+)
+
+var (
+	// jobTTL is how long finished backup jobs are kept on the cluster.
+	jobTTL = int32((24 * time.Hour).Seconds())
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Ensure ensures that an etcd backup has been taken on the cluster before the disruptive change described by reason.
+// The key identifies the change: a new backup is taken whenever the key changes. Ensure returns true once the backup
+// has completed and the change may go ahead. It always returns true when etcd backups are not enabled for the
+// cluster.
+//
+// The backup is taken by a Job applied to the cluster through a SyncSet. The Job runs the cluster-backup.sh script
+// on a control plane node, and the backup is recorded in the status of the ClusterDeployment.
+func Ensure(
+	c client.Client,
+	remoteBuilder remoteclient.Builder,
+	a applier,
+	scheme *runtime.Scheme,
+	cd *hivev1.ClusterDeployment,
+	reason, key string,
+	logger log.FieldLogger,
+) (bool, error) {
+	if cd.Spec.EtcdBackup == nil || !cd.Spec.EtcdBackup.Enabled {
+		return true, nil
+	}
+
+	name := apihelpers.GetResourceName("etcd-backup", key)
+	logger = logger.WithField("etcdBackup", name)
+
+	backup := cd.Status.LastEtcdBackup
+	if backup != nil && backup.Name == name && backup.CompletionTime != nil {
+		logger.Debug("etcd backup has already been taken")
+		return true, nil
+	}
+
+	if cd.Status.CLIImage == nil {
+		return false, errors.New("cannot take etcd backup until the CLI image of the cluster is known")
+	}
+
+	if backup == nil || backup.Name != name {
+		directory := cd.Spec.EtcdBackup.Directory
+		if directory == "" {
+			directory = defaultDirectory
+		}
+		logger.WithField("reason", reason).Info("taking etcd backup before disruptive change")
+		cd.Status.LastEtcdBackup = &hivev1.EtcdBackupStatus{
+			Name:   name,
+			Reason: reason,
+			Path:   path.Join(directory, name),
+		}
+		if err := c.Status().Update(context.TODO(), cd); err != nil {
+			return false, errors.Wrap(err, "could not record etcd backup in status")
+		}
+		backup = cd.Status.LastEtcdBackup
+	}
+
+	syncSet, err := generateSyncSet(cd, backup, scheme)
+	if err != nil {
+		return false, err
+	}
+	if _, err := a.ApplyRuntimeObject(syncSet, scheme); err != nil {
+		return false, errors.Wrap(err, "could not apply etcd backup syncset")
+	}
+
+	remoteClient, err := remoteBuilder.Build()
+	if err != nil {
+		return false, errors.Wrap(err, "could not build client for the cluster")
+	}
+	job := &batchv1.Job{}
+	switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: Namespace, Name: name}, job); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("etcd backup job has not been created on the cluster yet")
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "could not get etcd backup job")
+	}
+
+	switch {
+	case controllerutils.IsFailed(job):
+		logger.Warn("etcd backup job failed")
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.EtcdBackupFailedCondition,
+			corev1.ConditionTrue,
+			backupFailedReason,
+			fmt.Sprintf("Job %s/%s on the cluster failed. Delete the job to retry the backup.", Namespace, name),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			cd.Status.Conditions = conditions
+			if err := c.Status().Update(context.TODO(), cd); err != nil {
+				return false, errors.Wrap(err, "could not update etcd backup failed condition")
+			}
+		}
+		return false, nil
+	case !controllerutils.IsSuccessful(job):
+		logger.Debug("etcd backup job is still running")
+		return false, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := remoteClient.List(context.TODO(), pods, client.InNamespace(Namespace), client.MatchingLabels{"job-name": name}); err != nil {
+		return false, errors.Wrap(err, "could not list etcd backup pods")
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			backup.Node = pod.Spec.NodeName
+			break
+		}
+	}
+	now := metav1.Now()
+	backup.CompletionTime = &now
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.EtcdBackupFailedCondition,
+		corev1.ConditionFalse,
+		backupSucceededReason,
+		"The etcd backup succeeded",
+		controllerutils.UpdateConditionNever,
+	)
+	if err := c.Status().Update(context.TODO(), cd); err != nil {
+		return false, errors.Wrap(err, "could not record etcd backup completion in status")
+	}
+	logger.WithField("node", backup.Node).WithField("path", backup.Path).Info("etcd backup completed")
+	return true, nil
+}
+
+// SyncSetName returns the name of the SyncSet that runs the etcd backups of a cluster.
+func SyncSetName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "etcd-backup")
+}
+
+func generateSyncSet(cd *hivev1.ClusterDeployment, backup *hivev1.EtcdBackupStatus, scheme *runtime.Scheme) (*hivev1.SyncSet, error) {
+	hostPathType := corev1.HostPathDirectory
+	resources := []runtime.RawExtension{
+		{
+			Object: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: Namespace},
+			},
+		},
+		{
+			Object: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: serviceAccountName},
+			},
+		},
+		{
+			Object: &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleBinding},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     privilegedSCCClusterRole,
+				},
+				Subjects: []rbacv1.Subject{{
+					Kind:      rbacv1.ServiceAccountKind,
+					Namespace: Namespace,
+					Name:      serviceAccountName,
+				}},
+			},
+		},
+		{
+			Object: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: backup.Name},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:            pointer.Int32Ptr(3),
+					TTLSecondsAfterFinished: &jobTTL,
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							ServiceAccountName: serviceAccountName,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							HostNetwork:        true,
+							NodeSelector:       map[string]string{"node-role.kubernetes.io/master": ""},
+							Tolerations: []corev1.Toleration{{
+								Key:      "node-role.kubernetes.io/master",
+								Operator: corev1.TolerationOpExists,
+								Effect:   corev1.TaintEffectNoSchedule,
+							}},
+							Containers: []corev1.Container{{
+								Name:    "backup",
+								Image:   *cd.Status.CLIImage,
+								Command: []string{"chroot", "/host", "/usr/local/bin/cluster-backup.sh", backup.Path},
+								SecurityContext: &corev1.SecurityContext{
+									Privileged: pointer.BoolPtr(true),
+								},
+								VolumeMounts: []corev1.VolumeMount{{
+									Name:      "host",
+									MountPath: "/host",
+								}},
+							}},
+							Volumes: []corev1.Volume{{
+								Name: "host",
+								VolumeSource: corev1.VolumeSource{
+									HostPath: &corev1.HostPathVolumeSource{
+										Path: "/",
+										Type: &hostPathType,
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	resources, err := controllerutils.AddTypeMeta(resources, scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot add typemeta to etcd backup syncset resources")
+	}
+
+	// Upsert leaves the jobs of earlier backups on the cluster when the job in the syncset is replaced. They are
+	// cleaned up by their TTL.
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SyncSetName(cd.Name),
+			Namespace: cd.Namespace,
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Resources:         resources,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeEtcdBackup)
+	if err := controllerutil.SetControllerReference(cd, syncSet, scheme); err != nil {
+		return nil, errors.Wrap(err, "error setting owner reference on etcd backup syncset")
+	}
+	return syncSet, nil
+}
@@ -0,0 +1,247 @@
+package etcdbackup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	testName      = "test-cluster"
+	testNamespace = "test-namespace"
+	testKey       = "abcdef"
+	testReason    = "TestChange"
+	testBackup    = "etcd-backup-abcdef"
+	testCLIImage  = "example.com/cli"
+)
+
+func TestEnsure(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name              string
+		cd                *hivev1.ClusterDeployment
+		remote            []runtime.Object
+		expectBuild       bool
+		expectDone        bool
+		expectErr         bool
+		expectSyncSet     bool
+		expectCompleted   bool
+		expectNode        string
+		expectFailedState corev1.ConditionStatus
+	}{
+		{
+			name:       "backups not enabled",
+			cd:         testClusterDeployment(nil),
+			expectDone: true,
+		},
+		{
+			name: "backup already taken",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true})
+				now := metav1.Now()
+				cd.Status.LastEtcdBackup = &hivev1.EtcdBackupStatus{Name: testBackup, CompletionTime: &now}
+				return cd
+			}(),
+			expectDone:      true,
+			expectCompleted: true,
+		},
+		{
+			name: "no cli image",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true})
+				cd.Status.CLIImage = nil
+				return cd
+			}(),
+			expectErr: true,
+		},
+		{
+			name:          "job not created yet",
+			cd:            testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true}),
+			expectBuild:   true,
+			expectSyncSet: true,
+		},
+		{
+			name: "earlier backup replaced",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true})
+				now := metav1.Now()
+				cd.Status.LastEtcdBackup = &hivev1.EtcdBackupStatus{Name: "etcd-backup-old", CompletionTime: &now}
+				return cd
+			}(),
+			expectBuild:   true,
+			expectSyncSet: true,
+		},
+		{
+			name:          "job running",
+			cd:            testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true}),
+			remote:        []runtime.Object{testJob("")},
+			expectBuild:   true,
+			expectSyncSet: true,
+		},
+		{
+			name:              "job failed",
+			cd:                testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true}),
+			remote:            []runtime.Object{testJob(batchv1.JobFailed)},
+			expectBuild:       true,
+			expectSyncSet:     true,
+			expectFailedState: corev1.ConditionTrue,
+		},
+		{
+			name: "job succeeded",
+			cd:   testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true}),
+			remote: []runtime.Object{
+				testJob(batchv1.JobComplete),
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: Namespace,
+						Name:      testBackup + "-xyz",
+						Labels:    map[string]string{"job-name": testBackup},
+					},
+					Spec:   corev1.PodSpec{NodeName: "master-1"},
+					Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+				},
+			},
+			expectBuild:     true,
+			expectDone:      true,
+			expectSyncSet:   true,
+			expectCompleted: true,
+			expectNode:      "master-1",
+		},
+		{
+			name: "job succeeded after failure",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment(&hivev1.EtcdBackupConfig{Enabled: true})
+				cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+					cd.Status.Conditions,
+					hivev1.EtcdBackupFailedCondition,
+					corev1.ConditionTrue,
+					backupFailedReason,
+					"failed",
+					controllerutils.UpdateConditionNever,
+				)
+				return cd
+			}(),
+			remote:            []runtime.Object{testJob(batchv1.JobComplete)},
+			expectBuild:       true,
+			expectDone:        true,
+			expectSyncSet:     true,
+			expectCompleted:   true,
+			expectFailedState: corev1.ConditionFalse,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, test.cd)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if test.expectBuild {
+				mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme, test.remote...), nil)
+			}
+			a := &fakeApplier{}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			done, err := Ensure(c, mockRemoteClientBuilder, a, scheme.Scheme, cd, testReason, testKey, log.WithField("test", test.name))
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectDone, done, "unexpected done")
+
+			if test.expectSyncSet {
+				if assert.Len(t, a.appliedObjects, 1, "expected a syncset to be applied") {
+					ss := a.appliedObjects[0].(*hivev1.SyncSet)
+					assert.Equal(t, SyncSetName(testName), ss.Name)
+					assert.Equal(t, hivev1.UpsertResourceApplyMode, ss.Spec.ResourceApplyMode)
+					job := ss.Spec.Resources[len(ss.Spec.Resources)-1].Object.(*batchv1.Job)
+					assert.Equal(t, testBackup, job.Name)
+					assert.Equal(t, testCLIImage, job.Spec.Template.Spec.Containers[0].Image)
+					assert.Contains(t, job.Spec.Template.Spec.Containers[0].Command, "/home/core/backups/"+testBackup)
+				}
+			} else {
+				assert.Empty(t, a.appliedObjects, "expected no syncset to be applied")
+			}
+
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			if test.cd.Spec.EtcdBackup == nil {
+				assert.Nil(t, cd.Status.LastEtcdBackup, "expected no backup in status")
+				return
+			}
+			if assert.NotNil(t, cd.Status.LastEtcdBackup, "expected backup in status") {
+				assert.Equal(t, testBackup, cd.Status.LastEtcdBackup.Name)
+				assert.Equal(t, test.expectCompleted, cd.Status.LastEtcdBackup.CompletionTime != nil, "unexpected completion")
+				assert.Equal(t, test.expectNode, cd.Status.LastEtcdBackup.Node)
+			}
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.EtcdBackupFailedCondition)
+			if test.expectFailedState == "" {
+				assert.Nil(t, cond, "expected no backup failed condition")
+			} else if assert.NotNil(t, cond, "expected backup failed condition") {
+				assert.Equal(t, test.expectFailedState, cond.Status, "unexpected backup failed condition status")
+			}
+		})
+	}
+}
+
+func testClusterDeployment(config *hivev1.EtcdBackupConfig) *hivev1.ClusterDeployment {
+	cliImage := testCLIImage
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+			UID:       types.UID("test-uid"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed:  true,
+			EtcdBackup: config,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			CLIImage: &cliImage,
+		},
+	}
+}
+
+func testJob(condition batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: Namespace,
+			Name:      testBackup,
+		},
+	}
+	if condition != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:   condition,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+	return job
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
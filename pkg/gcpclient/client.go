@@ -9,6 +9,7 @@ import (
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
@@ -50,6 +51,36 @@ type Client interface {
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
+
+	GetSubnetwork(name, region string) (*compute.Subnetwork, error)
+
+	CreateSubnetwork(region string, subnetwork *compute.Subnetwork) error
+
+	DeleteSubnetwork(name, region string) error
+
+	GetFirewall(name string) (*compute.Firewall, error)
+
+	CreateFirewall(firewall *compute.Firewall) error
+
+	DeleteFirewall(name string) error
+
+	GetServiceAttachment(name, region string) (*ServiceAttachment, error)
+
+	CreateServiceAttachment(region string, serviceAttachment *ServiceAttachment) error
+
+	DeleteServiceAttachment(name, region string) error
+
+	GetAddress(name, region string) (*compute.Address, error)
+
+	CreateAddress(region string, address *compute.Address) error
+
+	DeleteAddress(name, region string) error
+
+	GetForwardingRule(name, region string) (*compute.ForwardingRule, error)
+
+	CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) error
+
+	DeleteForwardingRule(name, region string) error
 }
 
 // ListManagedZonesOptions are the options for listing managed zones.
@@ -79,6 +110,7 @@ type gcpClient struct {
 	computeClient              *compute.Service
 	serviceUsageClient         *serviceusage.Service
 	dnsClient                  *dns.Service
+	httpClient                 *http.Client
 }
 
 const (
@@ -265,6 +297,86 @@ func (c *gcpClient) StartInstance(instance *compute.Instance) error {
 	return nil
 }
 
+func (c *gcpClient) GetSubnetwork(name, region string) (*compute.Subnetwork, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Subnetworks.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateSubnetwork(region string, subnetwork *compute.Subnetwork) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Subnetworks.Insert(c.projectName, region, subnetwork).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteSubnetwork(name, region string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Subnetworks.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetFirewall(name string) (*compute.Firewall, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Firewalls.Get(c.projectName, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateFirewall(firewall *compute.Firewall) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Firewalls.Insert(c.projectName, firewall).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteFirewall(name string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Firewalls.Delete(c.projectName, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetAddress(name, region string) (*compute.Address, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.Addresses.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateAddress(region string, address *compute.Address) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Addresses.Insert(c.projectName, region, address).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteAddress(name, region string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.Addresses.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetForwardingRule(name, region string) (*compute.ForwardingRule, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	return c.computeClient.ForwardingRules.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.ForwardingRules.Insert(c.projectName, region, forwardingRule).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteForwardingRule(name, region string) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.computeClient.ForwardingRules.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
 // NewClient creates our client wrapper object for interacting with GCP. The supplied byte slice contains the GCP creds.
 func NewClient(authJSON []byte) (Client, error) {
 	return newClient(authJSONPassthroughSource(authJSON))
@@ -355,6 +467,7 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		computeClient:              computeClient,
 		serviceUsageClient:         serviceUsageClient,
 		dnsClient:                  dnsClient,
+		httpClient:                 oauth2.NewClient(ctx, creds.TokenSource),
 	}, nil
 }
 
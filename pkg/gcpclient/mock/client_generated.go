@@ -236,3 +236,218 @@ func (mr *MockClientMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockClient)(nil).StartInstance), arg0)
 }
+
+// GetSubnetwork mocks base method
+func (m *MockClient) GetSubnetwork(name, region string) (*compute.Subnetwork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetwork", name, region)
+	ret0, _ := ret[0].(*compute.Subnetwork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetwork indicates an expected call of GetSubnetwork
+func (mr *MockClientMockRecorder) GetSubnetwork(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetwork", reflect.TypeOf((*MockClient)(nil).GetSubnetwork), name, region)
+}
+
+// CreateSubnetwork mocks base method
+func (m *MockClient) CreateSubnetwork(region string, subnetwork *compute.Subnetwork) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnetwork", region, subnetwork)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubnetwork indicates an expected call of CreateSubnetwork
+func (mr *MockClientMockRecorder) CreateSubnetwork(region, subnetwork interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnetwork", reflect.TypeOf((*MockClient)(nil).CreateSubnetwork), region, subnetwork)
+}
+
+// DeleteSubnetwork mocks base method
+func (m *MockClient) DeleteSubnetwork(name, region string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnetwork", name, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnetwork indicates an expected call of DeleteSubnetwork
+func (mr *MockClientMockRecorder) DeleteSubnetwork(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetwork", reflect.TypeOf((*MockClient)(nil).DeleteSubnetwork), name, region)
+}
+
+// GetFirewall mocks base method
+func (m *MockClient) GetFirewall(name string) (*compute.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirewall", name)
+	ret0, _ := ret[0].(*compute.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirewall indicates an expected call of GetFirewall
+func (mr *MockClientMockRecorder) GetFirewall(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirewall", reflect.TypeOf((*MockClient)(nil).GetFirewall), name)
+}
+
+// CreateFirewall mocks base method
+func (m *MockClient) CreateFirewall(firewall *compute.Firewall) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirewall", firewall)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFirewall indicates an expected call of CreateFirewall
+func (mr *MockClientMockRecorder) CreateFirewall(firewall interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirewall", reflect.TypeOf((*MockClient)(nil).CreateFirewall), firewall)
+}
+
+// DeleteFirewall mocks base method
+func (m *MockClient) DeleteFirewall(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewall", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirewall indicates an expected call of DeleteFirewall
+func (mr *MockClientMockRecorder) DeleteFirewall(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewall", reflect.TypeOf((*MockClient)(nil).DeleteFirewall), name)
+}
+
+// GetServiceAttachment mocks base method
+func (m *MockClient) GetServiceAttachment(name, region string) (*gcpclient.ServiceAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAttachment", name, region)
+	ret0, _ := ret[0].(*gcpclient.ServiceAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAttachment indicates an expected call of GetServiceAttachment
+func (mr *MockClientMockRecorder) GetServiceAttachment(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAttachment", reflect.TypeOf((*MockClient)(nil).GetServiceAttachment), name, region)
+}
+
+// CreateServiceAttachment mocks base method
+func (m *MockClient) CreateServiceAttachment(region string, serviceAttachment *gcpclient.ServiceAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAttachment", region, serviceAttachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateServiceAttachment indicates an expected call of CreateServiceAttachment
+func (mr *MockClientMockRecorder) CreateServiceAttachment(region, serviceAttachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAttachment", reflect.TypeOf((*MockClient)(nil).CreateServiceAttachment), region, serviceAttachment)
+}
+
+// DeleteServiceAttachment mocks base method
+func (m *MockClient) DeleteServiceAttachment(name, region string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAttachment", name, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceAttachment indicates an expected call of DeleteServiceAttachment
+func (mr *MockClientMockRecorder) DeleteServiceAttachment(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAttachment", reflect.TypeOf((*MockClient)(nil).DeleteServiceAttachment), name, region)
+}
+
+// GetAddress mocks base method
+func (m *MockClient) GetAddress(name, region string) (*compute.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", name, region)
+	ret0, _ := ret[0].(*compute.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress
+func (mr *MockClientMockRecorder) GetAddress(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockClient)(nil).GetAddress), name, region)
+}
+
+// CreateAddress mocks base method
+func (m *MockClient) CreateAddress(region string, address *compute.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", region, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAddress indicates an expected call of CreateAddress
+func (mr *MockClientMockRecorder) CreateAddress(region, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockClient)(nil).CreateAddress), region, address)
+}
+
+// DeleteAddress mocks base method
+func (m *MockClient) DeleteAddress(name, region string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", name, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress
+func (mr *MockClientMockRecorder) DeleteAddress(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockClient)(nil).DeleteAddress), name, region)
+}
+
+// GetForwardingRule mocks base method
+func (m *MockClient) GetForwardingRule(name, region string) (*compute.ForwardingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForwardingRule", name, region)
+	ret0, _ := ret[0].(*compute.ForwardingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForwardingRule indicates an expected call of GetForwardingRule
+func (mr *MockClientMockRecorder) GetForwardingRule(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForwardingRule", reflect.TypeOf((*MockClient)(nil).GetForwardingRule), name, region)
+}
+
+// CreateForwardingRule mocks base method
+func (m *MockClient) CreateForwardingRule(region string, forwardingRule *compute.ForwardingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForwardingRule", region, forwardingRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateForwardingRule indicates an expected call of CreateForwardingRule
+func (mr *MockClientMockRecorder) CreateForwardingRule(region, forwardingRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForwardingRule", reflect.TypeOf((*MockClient)(nil).CreateForwardingRule), region, forwardingRule)
+}
+
+// DeleteForwardingRule mocks base method
+func (m *MockClient) DeleteForwardingRule(name, region string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForwardingRule", name, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForwardingRule indicates an expected call of DeleteForwardingRule
+func (mr *MockClientMockRecorder) DeleteForwardingRule(name, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForwardingRule", reflect.TypeOf((*MockClient)(nil).DeleteForwardingRule), name, region)
+}
@@ -0,0 +1,106 @@
+package gcpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/api/googleapi"
+)
+
+// The vendored compute API predates Private Service Connect, so service attachments are managed through the REST
+// API directly.
+
+// ServiceAttachment publishes an internal load balancer through GCP Private Service Connect.
+type ServiceAttachment struct {
+	Name     string `json:"name,omitempty"`
+	Region   string `json:"region,omitempty"`
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// TargetService is the URL of the forwarding rule of the internal load balancer.
+	TargetService string `json:"targetService,omitempty"`
+
+	// ConnectionPreference is either ACCEPT_AUTOMATIC or ACCEPT_MANUAL.
+	ConnectionPreference string `json:"connectionPreference,omitempty"`
+
+	// NatSubnets are the URLs of the subnets used as the source addresses of the connections from the endpoints.
+	NatSubnets []string `json:"natSubnets,omitempty"`
+
+	EnableProxyProtocol bool `json:"enableProxyProtocol"`
+
+	// ConsumerAcceptLists are the projects that may connect when ConnectionPreference is ACCEPT_MANUAL.
+	ConsumerAcceptLists []*ServiceAttachmentConsumerProjectLimit `json:"consumerAcceptLists,omitempty"`
+
+	// ConnectedEndpoints are the endpoints connected to the service attachment.
+	ConnectedEndpoints []*ServiceAttachmentConnectedEndpoint `json:"connectedEndpoints,omitempty"`
+}
+
+// ServiceAttachmentConsumerProjectLimit allows a project to connect to a service attachment.
+type ServiceAttachmentConsumerProjectLimit struct {
+	ProjectIDOrNum  string `json:"projectIdOrNum,omitempty"`
+	ConnectionLimit int64  `json:"connectionLimit,omitempty"`
+}
+
+// ServiceAttachmentConnectedEndpoint is an endpoint connected to a service attachment.
+type ServiceAttachmentConnectedEndpoint struct {
+	// Endpoint is the URL of the forwarding rule of the endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Status is one of PENDING, ACCEPTED, REJECTED or CLOSED.
+	Status string `json:"status,omitempty"`
+}
+
+func (c *gcpClient) GetServiceAttachment(name, region string) (*ServiceAttachment, error) {
+	serviceAttachment := &ServiceAttachment{}
+	if err := c.doComputeRequest(http.MethodGet, serviceAttachmentsPath(c.projectName, region)+"/"+url.PathEscape(name), nil, serviceAttachment); err != nil {
+		return nil, err
+	}
+	return serviceAttachment, nil
+}
+
+func (c *gcpClient) CreateServiceAttachment(region string, serviceAttachment *ServiceAttachment) error {
+	return c.doComputeRequest(http.MethodPost, serviceAttachmentsPath(c.projectName, region), serviceAttachment, nil)
+}
+
+func (c *gcpClient) DeleteServiceAttachment(name, region string) error {
+	return c.doComputeRequest(http.MethodDelete, serviceAttachmentsPath(c.projectName, region)+"/"+url.PathEscape(name), nil, nil)
+}
+
+func serviceAttachmentsPath(project, region string) string {
+	return fmt.Sprintf("%s/regions/%s/serviceAttachments", url.PathEscape(project), url.PathEscape(region))
+}
+
+// doComputeRequest sends a request to the compute API. Errors are returned as *googleapi.Error, like the errors of
+// the generated compute client.
+func (c *gcpClient) doComputeRequest(method, path string, body, out interface{}) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, c.computeClient.BasePath+path, &reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	res, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -22,8 +22,9 @@ const (
 )
 
 // GenerateImageSetJob creates a job to determine the installer image for a ClusterImageSet
-// given a release image
-func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAccountName string) *batchv1.Job {
+// given a release image. The installer and cli image overrides, when not empty, are used instead of
+// the images of the release image.
+func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, installerImageOverride, cliImageOverride, serviceAccountName string) *batchv1.Job {
 	logger := log.WithFields(log.Fields{
 		"clusterdeployment": types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String(),
 	})
@@ -37,6 +38,24 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 		},
 	}
 
+	args := []string{
+		"update-installer-image",
+		"--work-dir",
+		"/common",
+		"--log-level",
+		"debug",
+		"--cluster-deployment-name",
+		cd.Name,
+		"--cluster-deployment-namespace",
+		cd.Namespace,
+	}
+	if installerImageOverride != "" {
+		args = append(args, "--installer-image-override", installerImageOverride)
+	}
+	if cliImageOverride != "" {
+		args = append(args, "--cli-image-override", cliImageOverride)
+	}
+
 	podSpec := corev1.PodSpec{
 		RestartPolicy: corev1.RestartPolicyOnFailure,
 		InitContainers: []corev1.Container{
@@ -55,18 +74,8 @@ func GenerateImageSetJob(cd *hivev1.ClusterDeployment, releaseImage, serviceAcco
 				Image:           images.GetHiveImage(),
 				ImagePullPolicy: images.GetHiveImagePullPolicy(),
 				Command:         []string{"/usr/bin/hiveutil"},
-				Args: []string{
-					"update-installer-image",
-					"--work-dir",
-					"/common",
-					"--log-level",
-					"debug",
-					"--cluster-deployment-name",
-					cd.Name,
-					"--cluster-deployment-namespace",
-					cd.Namespace,
-				},
-				VolumeMounts: volumeMounts,
+				Args:            args,
+				VolumeMounts:    volumeMounts,
 			},
 		},
 		Volumes: []corev1.Volume{
@@ -9,8 +9,22 @@ import (
 )
 
 func TestGenerateImageSetJob(t *testing.T) {
-	job := GenerateImageSetJob(testClusterDeployment(), testImageSet().Spec.ReleaseImage, "test-service-account")
+	job := GenerateImageSetJob(testClusterDeployment(), testImageSet().Spec.ReleaseImage, "", "", "test-service-account")
 	validateJob(t, job)
+	if hasArg(job, "--installer-image-override") || hasArg(job, "--cli-image-override") {
+		t.Errorf("unexpected image override args")
+	}
+}
+
+func TestGenerateImageSetJobWithOverrides(t *testing.T) {
+	job := GenerateImageSetJob(testClusterDeployment(), testImageSet().Spec.ReleaseImage, "test-installer-image", "test-cli-image", "test-service-account")
+	validateJob(t, job)
+	if !hasArg(job, "--installer-image-override", "test-installer-image") {
+		t.Errorf("missing installer image override arg")
+	}
+	if !hasArg(job, "--cli-image-override", "test-cli-image") {
+		t.Errorf("missing cli image override arg")
+	}
 }
 
 func testClusterDeployment() *hivev1.ClusterDeployment {
@@ -45,6 +59,26 @@ func validateJob(t *testing.T, job *batchv1.Job) {
 	}
 }
 
+// hasArg returns whether the hiveutil container is run with the arg, followed by the values if any are given.
+func hasArg(job *batchv1.Job, arg string, values ...string) bool {
+	args := job.Spec.Template.Spec.Containers[0].Args
+	for i := range args {
+		if args[i] != arg || i+len(values) >= len(args) {
+			continue
+		}
+		match := true
+		for j, value := range values {
+			if args[i+1+j] != value {
+				match = false
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
 func hasVolume(job *batchv1.Job, name string) bool {
 	for _, v := range job.Spec.Template.Spec.Volumes {
 		if v.Name == name {
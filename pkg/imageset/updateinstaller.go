@@ -42,6 +42,8 @@ type UpdateInstallerImageOptions struct {
 	ClusterDeploymentNamespace string
 	LogLevel                   string
 	WorkDir                    string
+	InstallerImageOverride     string
+	CLIImageOverride           string
 	log                        log.FieldLogger
 	client                     client.Client
 }
@@ -75,6 +77,8 @@ func NewUpdateInstallerImageCommand() *cobra.Command {
 	flags.StringVar(&opt.WorkDir, "work-dir", "/common", "directory to use for all input and output")
 	flags.StringVar(&opt.ClusterDeploymentName, "cluster-deployment-name", "", "name of ClusterDeployment to update")
 	flags.StringVar(&opt.ClusterDeploymentNamespace, "cluster-deployment-namespace", "", "namespace of ClusterDeployment to update")
+	flags.StringVar(&opt.InstallerImageOverride, "installer-image-override", "", "installer image to use instead of the one in the release image")
+	flags.StringVar(&opt.CLIImageOverride, "cli-image-override", "", "cli image to use instead of the one in the release image")
 	return cmd
 }
 
@@ -173,15 +177,21 @@ func (o *UpdateInstallerImageOptions) Run() (returnErr error) {
 	if cd.Spec.Platform.BareMetal != nil {
 		installerTagName = "baremetal-installer"
 	}
-	installerImage, err := findImageSpec(is, installerTagName)
-	if err != nil {
-		return errors.Wrap(err, "could not get installer image")
+	installerImage := o.InstallerImageOverride
+	if installerImage == "" {
+		installerImage, err = findImageSpec(is, installerTagName)
+		if err != nil {
+			return errors.Wrap(err, "could not get installer image")
+		}
 	}
 	o.log.WithField("installerImage", installerImage).Info("installer image found")
 
-	cliImage, err := findImageSpec(is, "cli")
-	if err != nil {
-		return errors.Wrap(err, "could not get cli image")
+	cliImage := o.CLIImageOverride
+	if cliImage == "" {
+		cliImage, err = findImageSpec(is, "cli")
+		if err != nil {
+			return errors.Wrap(err, "could not get cli image")
+		}
 	}
 	o.log.WithField("cliImage", cliImage).Info("cli image found")
 
@@ -36,6 +36,8 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 	tests := []struct {
 		name                      string
 		existingClusterDeployment *hivev1.ClusterDeployment
+		installerImageOverride    string
+		cliImageOverride          string
 		expectError               bool
 		setupWorkDir              func(t *testing.T, dir string)
 		validateClusterDeployment func(t *testing.T, clusterDeployment *hivev1.ClusterDeployment)
@@ -88,6 +90,29 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 			),
 			validateClusterDeployment: validateSuccessfulExecution,
 		},
+		{
+			name:                      "installer image override",
+			existingClusterDeployment: testClusterDeployment(),
+			installerImageOverride:    testInstallerImage,
+			setupWorkDir: writeImageReferencesFile(
+				map[string]string{
+					"installer": "registry.io/release-installer-image:latest",
+					"cli":       testCLIImage,
+				},
+			),
+			validateClusterDeployment: validateSuccessfulExecution,
+		},
+		{
+			name:                      "cli image override",
+			existingClusterDeployment: testClusterDeployment(),
+			cliImageOverride:          testCLIImage,
+			setupWorkDir: writeImageReferencesFile(
+				map[string]string{
+					"installer": testInstallerImage,
+				},
+			),
+			validateClusterDeployment: validateSuccessfulExecution,
+		},
 	}
 
 	for _, test := range tests {
@@ -101,6 +126,8 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 				ClusterDeploymentName:      testClusterDeployment().Name,
 				ClusterDeploymentNamespace: "test-namespace",
 				WorkDir:                    workDir,
+				InstallerImageOverride:     test.installerImageOverride,
+				CLIImageOverride:           test.cliImageOverride,
 				log:                        log.WithField("test", test.name),
 				client:                     client,
 			}
@@ -146,6 +173,10 @@ func validateSuccessfulExecution(t *testing.T, clusterDeployment *hivev1.Cluster
 		*clusterDeployment.Status.InstallerImage != testInstallerImage {
 		t.Errorf("did not get expected installer image in status")
 	}
+	if clusterDeployment.Status.CLIImage == nil ||
+		*clusterDeployment.Status.CLIImage != testCLIImage {
+		t.Errorf("did not get expected cli image in status")
+	}
 	if len(clusterDeployment.Status.Conditions) != 0 {
 		t.Errorf("conditions is not empty")
 	}
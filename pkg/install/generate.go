@@ -1,6 +1,7 @@
 package install
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -25,14 +26,22 @@ const (
 	gcpAuthFile        = gcpAuthDir + "/" + constants.GCPCredentialsName
 	openStackCloudsDir = "/etc/openstack"
 	vsphereCloudsDir   = "/vsphere"
+	nutanixCADir       = "/nutanix-ca"
 	ovirtCloudsDir     = "/.ovirt"
 	ovirtCADir         = "/.ovirt-ca"
 
+	// externalDestroyerCredsDir is the directory where the credentials secret is mounted for external destroyers.
+	externalDestroyerCredsDir = "/credentials"
+
 	// SSHPrivateKeyDir is the directory where the generated Job will mount the ssh secret to
 	SSHPrivateKeyDir = "/sshkeys"
 
 	// LibvirtSSHPrivateKeyDir is the directory where the generated Job will mount the libvirt ssh secret to
 	LibvirtSSHPrivateKeyDir = "/libvirtsshkeys"
+
+	// AdditionalManifestsDir is the directory under which the generated Job will mount the sources of
+	// user-provided manifests, at <directory>/<index of the source>.
+	AdditionalManifestsDir = "/additional-manifests"
 )
 
 var (
@@ -172,6 +181,24 @@ func InstallerPodSpec(
 			Name:  "GOOGLE_CREDENTIALS",
 			Value: gcpAuthFile,
 		})
+	case cd.Spec.Platform.IBMCloud != nil:
+		env = append(env, ibmCloudCredsEnvVars(cd.Spec.Platform.IBMCloud.CredentialsSecretRef.Name)...)
+	case cd.Spec.Platform.Nutanix != nil:
+		if ref := cd.Spec.Platform.Nutanix.CertificatesSecretRef; ref != nil {
+			volumes = append(volumes, corev1.Volume{
+				Name: "nutanix-certificates",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: ref.Name,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      "nutanix-certificates",
+				MountPath: nutanixCADir,
+			})
+		}
+		env = append(env, nutanixCredsEnvVars(cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name)...)
 	case cd.Spec.Platform.OpenStack != nil:
 		volumes = append(volumes, corev1.Volume{
 			Name: "openstack",
@@ -264,6 +291,56 @@ func InstallerPodSpec(
 		)
 	}
 
+	if proxy := cd.Spec.Proxy; proxy != nil && proxy.TrustedCA != nil {
+		volumes = append(
+			volumes,
+			corev1.Volume{
+				Name: "proxy-trusted-ca",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: *proxy.TrustedCA,
+					},
+				},
+			},
+		)
+		volumeMounts = append(
+			volumeMounts,
+			corev1.VolumeMount{
+				Name:      "proxy-trusted-ca",
+				MountPath: "/proxy-trusted-ca",
+			},
+		)
+	}
+
+	for i, source := range cd.Spec.Provisioning.Manifests {
+		volumeName := fmt.Sprintf("additional-manifests-%d", i)
+		volume := corev1.Volume{Name: volumeName}
+		switch {
+		case source.ConfigMapRef != nil:
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: *source.ConfigMapRef,
+			}
+		case source.SecretRef != nil:
+			volume.Secret = &corev1.SecretVolumeSource{
+				SecretName: source.SecretRef.Name,
+			}
+		default:
+			return nil, fmt.Errorf("manifests source %d must reference a ConfigMap or a Secret", i)
+		}
+		directory := source.Directory
+		if directory == "" {
+			directory = hivev1.InstallManifestsDirectoryManifests
+		}
+		volumes = append(volumes, volume)
+		volumeMounts = append(
+			volumeMounts,
+			corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: fmt.Sprintf("%s/%s/%d", AdditionalManifestsDir, directory, i),
+			},
+		)
+	}
+
 	if !skipGatherLogs {
 		// Add a volume where we will store full logs from both the installer, and the
 		// cluster itself (assuming we made it far enough).
@@ -339,6 +416,10 @@ func InstallerPodSpec(
 		// Add vSphere certificates to CA trust.
 		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", vsphereCloudsDir, hiveArg)
 	}
+	if cd.Spec.Platform.Nutanix != nil && cd.Spec.Platform.Nutanix.CertificatesSecretRef != nil {
+		// Add Prism Central certificates to CA trust.
+		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", nutanixCADir, hiveArg)
+	}
 	if cd.Spec.Platform.Ovirt != nil {
 		// Add oVirt certificates to CA trust.
 		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", ovirtCADir, hiveArg)
@@ -447,19 +528,36 @@ func GetUninstallJobName(name string) string {
 	return apihelpers.GetResourceName(name, "uninstall")
 }
 
-// GenerateUninstallerJobForDeprovision generates an uninstaller job for a given deprovision request
-func GenerateUninstallerJobForDeprovision(
-	req *hivev1.ClusterDeprovision) (*batchv1.Job, error) {
+// destroyerFunc completes a deprovision job with the containers and volumes that destroy the cluster.
+type destroyerFunc func(req *hivev1.ClusterDeprovision, job *batchv1.Job)
 
-	restartPolicy := corev1.RestartPolicyOnFailure
+// destroyers are the destroyers built into Hive, keyed by platform.
+var destroyers = map[string]destroyerFunc{
+	"aws":       completeAWSDeprovisionJob,
+	"azure":     completeAzureDeprovisionJob,
+	"gcp":       completeGCPDeprovisionJob,
+	"openstack": completeOpenStackDeprovisionJob,
+	"vsphere":   completeVSphereDeprovisionJob,
+	"ovirt":     completeOvirtDeprovisionJob,
+}
+
+// GenerateUninstallerJobForDeprovision generates an uninstaller job for a given deprovision request. The job runs
+// the external destroyer configured for the platform of the request, if any, or else the destroyer built into Hive
+// with the given service account.
+func GenerateUninstallerJobForDeprovision(
+	req *hivev1.ClusterDeprovision,
+	serviceAccountName string,
+	externalDestroyers []hivev1.ExternalDestroyer) (*batchv1.Job, error) {
 
+	// Failed uninstall jobs are not retried by the job controller. The deprovision controller classifies the failure
+	// and retries with its own backoff, so that a failing uninstall does not hot-loop against the cloud API.
 	podSpec := corev1.PodSpec{
 		DNSPolicy:     corev1.DNSClusterFirst,
-		RestartPolicy: restartPolicy,
+		RestartPolicy: corev1.RestartPolicyNever,
 	}
 
 	completions := int32(1)
-	backoffLimit := int32(123456) // effectively limitless
+	backoffLimit := int32(0)
 	labels := map[string]string{
 		constants.UninstallJobLabel:          "true",
 		constants.ClusterDeploymentNameLabel: req.Name,
@@ -481,24 +579,151 @@ func GenerateUninstallerJobForDeprovision(
 		},
 	}
 
+	if err := completeDeprovisionJob(req, serviceAccountName, externalDestroyers, job); err != nil {
+		return nil, err
+	}
+
+	// The tail of the logs of a failed destroyer is kept in its termination message, from which the deprovision
+	// controller classifies the failure.
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	}
+
+	return job, nil
+}
+
+// completeDeprovisionJob completes a deprovision job with the external destroyer configured for the platform of the
+// request, if any, or else the destroyer built into Hive. Only the destroyers built into Hive run with the service
+// account, through which they report their progress.
+func completeDeprovisionJob(req *hivev1.ClusterDeprovision, serviceAccountName string, externalDestroyers []hivev1.ExternalDestroyer, job *batchv1.Job) error {
+	platform := deprovisionPlatform(req)
+	for i, destroyer := range externalDestroyers {
+		if destroyer.Platform == platform {
+			return completeExternalDeprovisionJob(req, &externalDestroyers[i], job)
+		}
+	}
+
+	destroy, ok := destroyers[platform]
+	if !ok {
+		return errors.New("deprovision requests currently not supported for platform")
+	}
+	destroy(req, job)
+	job.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+	return nil
+}
+
+// deprovisionPlatform returns the name of the platform of a deprovision request, as used to look up its destroyer.
+func deprovisionPlatform(req *hivev1.ClusterDeprovision) string {
 	switch {
 	case req.Spec.Platform.AWS != nil:
-		completeAWSDeprovisionJob(req, job)
+		return "aws"
 	case req.Spec.Platform.Azure != nil:
-		completeAzureDeprovisionJob(req, job)
+		return "azure"
 	case req.Spec.Platform.GCP != nil:
-		completeGCPDeprovisionJob(req, job)
+		return "gcp"
+	case req.Spec.Platform.IBMCloud != nil:
+		return "ibmcloud"
+	case req.Spec.Platform.Nutanix != nil:
+		return "nutanix"
 	case req.Spec.Platform.OpenStack != nil:
-		completeOpenStackDeprovisionJob(req, job)
+		return "openstack"
 	case req.Spec.Platform.VSphere != nil:
-		completeVSphereDeprovisionJob(req, job)
+		return "vsphere"
 	case req.Spec.Platform.Ovirt != nil:
-		completeOvirtDeprovisionJob(req, job)
-	default:
-		return nil, errors.New("deprovision requests currently not supported for platform")
+		return "ovirt"
+	case req.Spec.Platform.External != nil:
+		return req.Spec.Platform.External.Platform
 	}
+	return ""
+}
 
-	return job, nil
+// deprovisionCredentialsSecret returns the name of the credentials secret of a deprovision request, if any.
+func deprovisionCredentialsSecret(req *hivev1.ClusterDeprovision) string {
+	var ref *corev1.LocalObjectReference
+	switch {
+	case req.Spec.Platform.AWS != nil:
+		ref = req.Spec.Platform.AWS.CredentialsSecretRef
+	case req.Spec.Platform.Azure != nil:
+		ref = req.Spec.Platform.Azure.CredentialsSecretRef
+	case req.Spec.Platform.GCP != nil:
+		ref = req.Spec.Platform.GCP.CredentialsSecretRef
+	case req.Spec.Platform.IBMCloud != nil:
+		ref = &req.Spec.Platform.IBMCloud.CredentialsSecretRef
+	case req.Spec.Platform.Nutanix != nil:
+		ref = &req.Spec.Platform.Nutanix.CredentialsSecretRef
+	case req.Spec.Platform.OpenStack != nil:
+		ref = req.Spec.Platform.OpenStack.CredentialsSecretRef
+	case req.Spec.Platform.VSphere != nil:
+		ref = &req.Spec.Platform.VSphere.CredentialsSecretRef
+	case req.Spec.Platform.Ovirt != nil:
+		ref = &req.Spec.Platform.Ovirt.CredentialsSecretRef
+	case req.Spec.Platform.External != nil:
+		ref = req.Spec.Platform.External.CredentialsSecretRef
+	}
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
+
+func completeExternalDeprovisionJob(req *hivev1.ClusterDeprovision, destroyer *hivev1.ExternalDestroyer, job *batchv1.Job) error {
+	spec, err := json.Marshal(req.Spec)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the spec of the deprovision request")
+	}
+	pullPolicy := destroyer.ImagePullPolicy
+	if pullPolicy == "" {
+		pullPolicy = corev1.PullIfNotPresent
+	}
+	container := corev1.Container{
+		Name:            "deprovision",
+		Image:           destroyer.Image,
+		ImagePullPolicy: pullPolicy,
+		Command:         destroyer.Command,
+		Args:            destroyer.Args,
+		Env: []corev1.EnvVar{
+			{
+				Name:  "CLUSTER_DEPROVISION",
+				Value: string(spec),
+			},
+			{
+				Name:  "PLATFORM",
+				Value: destroyer.Platform,
+			},
+			{
+				Name:  "INFRA_ID",
+				Value: req.Spec.InfraID,
+			},
+			{
+				Name:  "CLUSTER_ID",
+				Value: req.Spec.ClusterID,
+			},
+		},
+	}
+	if credentialsSecret := deprovisionCredentialsSecret(req); credentialsSecret != "" {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "CREDENTIALS_DIR",
+			Value: externalDestroyerCredsDir,
+		})
+		container.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "credentials",
+				MountPath: externalDestroyerCredsDir,
+			},
+		}
+		job.Spec.Template.Spec.Volumes = []corev1.Volume{
+			{
+				Name: "credentials",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: credentialsSecret,
+					},
+				},
+			},
+		}
+	}
+	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+	return nil
 }
 
 func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
@@ -518,10 +743,14 @@ func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job)
 				"debug",
 				"--region",
 				req.Spec.Platform.AWS.Region,
-				fmt.Sprintf("kubernetes.io/cluster/%s=owned", req.Spec.InfraID),
 			},
 		},
 	}
+	for _, e := range req.Spec.Platform.AWS.ServiceEndpoints {
+		containers[0].Args = append(containers[0].Args, "--service-endpoint", fmt.Sprintf("%s=%s", e.Name, e.URL))
+	}
+	containers[0].Args = append(containers[0].Args, "--cluster-deprovision", req.Name)
+	containers[0].Args = append(containers[0].Args, fmt.Sprintf("kubernetes.io/cluster/%s=owned", req.Spec.InfraID))
 	if len(req.Spec.ClusterID) > 0 {
 		// Also cleanup anything with the tag for the legacy cluster ID (credentials still using this for example)
 		containers[0].Args = append(containers[0].Args, fmt.Sprintf("openshiftClusterID=%s", req.Spec.ClusterID))
@@ -822,6 +1051,43 @@ func vSphereCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	return env
 }
 
+func ibmCloudCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: constants.IBMCloudAPIKeyEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.IBMCloudAPIKeySecretKey,
+				},
+			},
+		},
+	}
+}
+
+func nutanixCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: constants.NutanixUsernameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.UsernameSecretKey,
+				},
+			},
+		},
+		{
+			Name: constants.NutanixPasswordEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.PasswordSecretKey,
+				},
+			},
+		},
+	}
+}
+
 func oVirtCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	env := []corev1.EnvVar{}
 	env = append(
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1ibmcloud "github.com/openshift/hive/pkg/apis/hive/v1/ibmcloud"
+	hivev1nutanix "github.com/openshift/hive/pkg/apis/hive/v1/nutanix"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -22,9 +24,152 @@ func init() {
 
 func TestGenerateDeprovision(t *testing.T) {
 	dr := testClusterDeprovision()
-	job, err := GenerateUninstallerJobForDeprovision(dr)
+	job, err := GenerateUninstallerJobForDeprovision(dr, "test-service-account", nil)
 	assert.Nil(t, err)
-	assert.NotNil(t, job)
+	if assert.NotNil(t, job) {
+		assert.Equal(t, "test-service-account", job.Spec.Template.Spec.ServiceAccountName)
+		assert.Contains(t, job.Spec.Template.Spec.Containers[0].Args, "--cluster-deprovision")
+	}
+}
+
+func TestGenerateDeprovisionExternalDestroyer(t *testing.T) {
+	destroyers := []hivev1.ExternalDestroyer{
+		{
+			Platform: "aws",
+			Image:    "example.com/aws-destroyer",
+			Command:  []string{"/destroy"},
+			Args:     []string{"--verbose"},
+		},
+		{
+			Platform:        "baremetal",
+			Image:           "example.com/baremetal-destroyer",
+			ImagePullPolicy: corev1.PullAlways,
+		},
+		{
+			Platform: "ibmcloud",
+			Image:    "example.com/ibmcloud-destroyer",
+		},
+		{
+			Platform: "nutanix",
+			Image:    "example.com/nutanix-destroyer",
+		},
+	}
+	tests := []struct {
+		name          string
+		deprovision   func() *hivev1.ClusterDeprovision
+		expectErr     bool
+		expectedImage string
+		expectedCreds string
+	}{
+		{
+			name:          "built-in destroyer",
+			deprovision:   testClusterDeprovision,
+			expectedImage: "example.com/aws-destroyer",
+			expectedCreds: "aws-creds",
+		},
+		{
+			name: "external platform",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				dr := testClusterDeprovision()
+				dr.Spec.Platform.AWS = nil
+				dr.Spec.Platform.External = &hivev1.ExternalClusterDeprovision{
+					Platform: "baremetal",
+					CredentialsSecretRef: &corev1.LocalObjectReference{
+						Name: "baremetal-creds",
+					},
+				}
+				return dr
+			},
+			expectedImage: "example.com/baremetal-destroyer",
+			expectedCreds: "baremetal-creds",
+		},
+		{
+			name: "external platform without credentials",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				dr := testClusterDeprovision()
+				dr.Spec.Platform.AWS = nil
+				dr.Spec.Platform.External = &hivev1.ExternalClusterDeprovision{Platform: "baremetal"}
+				return dr
+			},
+			expectedImage: "example.com/baremetal-destroyer",
+		},
+		{
+			name: "IBM Cloud",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				dr := testClusterDeprovision()
+				dr.Spec.Platform.AWS = nil
+				dr.Spec.Platform.IBMCloud = &hivev1.IBMCloudClusterDeprovision{
+					Region: "us-south",
+					CredentialsSecretRef: corev1.LocalObjectReference{
+						Name: "ibmcloud-creds",
+					},
+				}
+				return dr
+			},
+			expectedImage: "example.com/ibmcloud-destroyer",
+			expectedCreds: "ibmcloud-creds",
+		},
+		{
+			name: "Nutanix",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				dr := testClusterDeprovision()
+				dr.Spec.Platform.AWS = nil
+				dr.Spec.Platform.Nutanix = &hivev1.NutanixClusterDeprovision{
+					PrismCentral: hivev1nutanix.PrismEndpoint{Address: "prism-central.example.com"},
+					CredentialsSecretRef: corev1.LocalObjectReference{
+						Name: "nutanix-creds",
+					},
+				}
+				return dr
+			},
+			expectedImage: "example.com/nutanix-destroyer",
+			expectedCreds: "nutanix-creds",
+		},
+		{
+			name: "no destroyer for platform",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				dr := testClusterDeprovision()
+				dr.Spec.Platform.AWS = nil
+				dr.Spec.Platform.External = &hivev1.ExternalClusterDeprovision{Platform: "libvirt"}
+				return dr
+			},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job, err := GenerateUninstallerJobForDeprovision(test.deprovision(), "test-service-account", destroyers)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			containers := job.Spec.Template.Spec.Containers
+			if !assert.Len(t, containers, 1) {
+				return
+			}
+			assert.Equal(t, test.expectedImage, containers[0].Image)
+			assert.Empty(t, job.Spec.Template.Spec.ServiceAccountName, "external destroyers should not run with the service account")
+			env := map[string]string{}
+			for _, e := range containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			assert.Equal(t, "test-infra-id", env["INFRA_ID"])
+			assert.Equal(t, "test-cluster-id", env["CLUSTER_ID"])
+			assert.NotEmpty(t, env["CLUSTER_DEPROVISION"])
+			if test.expectedCreds == "" {
+				assert.Empty(t, job.Spec.Template.Spec.Volumes)
+				assert.NotContains(t, env, "CREDENTIALS_DIR")
+				return
+			}
+			if assert.Len(t, job.Spec.Template.Spec.Volumes, 1) {
+				assert.Equal(t, test.expectedCreds, job.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+			}
+			assert.Equal(t, externalDestroyerCredsDir, env["CREDENTIALS_DIR"])
+		})
+	}
 }
 
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
@@ -91,6 +236,129 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Manifests Sources",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						Manifests: []hivev1.InstallManifestsSource{
+							{ConfigMapRef: &corev1.LocalObjectReference{Name: "manifests"}},
+							{SecretRef: &corev1.LocalObjectReference{Name: "machineconfigs"}, Directory: hivev1.InstallManifestsDirectoryOpenShift},
+						},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName:  "testprovision",
+			skipGatherLogs: true,
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				if !assert.NoError(t, actualError) {
+					return
+				}
+				assert.Contains(t, actualPodSpec.Volumes, corev1.Volume{
+					Name: "additional-manifests-0",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "manifests"},
+						},
+					},
+				})
+				assert.Contains(t, actualPodSpec.Volumes, corev1.Volume{
+					Name: "additional-manifests-1",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "machineconfigs"},
+					},
+				})
+				hiveContainer := actualPodSpec.Containers[2]
+				assert.Contains(t, hiveContainer.VolumeMounts, corev1.VolumeMount{
+					Name:      "additional-manifests-0",
+					MountPath: "/additional-manifests/manifests/0",
+				})
+				assert.Contains(t, hiveContainer.VolumeMounts, corev1.VolumeMount{
+					Name:      "additional-manifests-1",
+					MountPath: "/additional-manifests/openshift/1",
+				})
+			},
+		},
+		{
+			name: "Test Provision Pod IBM Cloud Credentials",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Platform: hivev1.Platform{
+						IBMCloud: &hivev1ibmcloud.Platform{
+							CredentialsSecretRef: corev1.LocalObjectReference{Name: "ibmcloud-creds"},
+							Region:               "us-south",
+						},
+					},
+					Provisioning: &hivev1.Provisioning{},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName:  "testprovision",
+			skipGatherLogs: true,
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				if !assert.NoError(t, actualError) {
+					return
+				}
+				assert.Contains(t, actualPodSpec.Containers[2].Env, corev1.EnvVar{
+					Name: "IC_API_KEY",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "ibmcloud-creds"},
+							Key:                  "ibmcloud_api_key",
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "Test Provision Pod Nutanix Credentials And Certificates",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Platform: hivev1.Platform{
+						Nutanix: &hivev1nutanix.Platform{
+							PrismCentral:          hivev1nutanix.PrismEndpoint{Address: "prism-central.example.com"},
+							PrismElementUUID:      "0005b0f1-8f43-a0f2-02b7-3cecef193712",
+							CredentialsSecretRef:  corev1.LocalObjectReference{Name: "nutanix-creds"},
+							CertificatesSecretRef: &corev1.LocalObjectReference{Name: "nutanix-certs"},
+							SubnetUUIDs:           []string{"c7938dc6-7659-453e-a688-e26020c68e43"},
+						},
+					},
+					Provisioning: &hivev1.Provisioning{},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName:  "testprovision",
+			skipGatherLogs: true,
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				if !assert.NoError(t, actualError) {
+					return
+				}
+				assert.Contains(t, actualPodSpec.Containers[2].Env, corev1.EnvVar{
+					Name: "NUTANIX_USERNAME",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "nutanix-creds"},
+							Key:                  "username",
+						},
+					},
+				})
+				assert.Contains(t, actualPodSpec.Containers[2].VolumeMounts, corev1.VolumeMount{
+					Name:      "nutanix-certificates",
+					MountPath: "/nutanix-ca",
+				})
+				assert.Contains(t, actualPodSpec.Containers[2].Args[0], "cp -vr /nutanix-ca/. /etc/pki/ca-trust/source/anchors/")
+			},
+		},
 	}
 
 	for _, test := range tests {
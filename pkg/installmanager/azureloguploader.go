@@ -0,0 +1,126 @@
+package installmanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	azureStorageAPIVersion = "2019-12-12"
+	azureUploadTimeout     = 10 * time.Minute
+)
+
+// azureLogUploader uploads logs to Azure Blob Storage. The vendored Azure SDK does not include a blob storage
+// client, so blobs are uploaded with the Put Blob REST operation authorized with the storage account key.
+type azureLogUploader struct {
+	client         *http.Client
+	endpoint       string
+	storageAccount string
+	container      string
+	accountKey     []byte
+}
+
+func newAzureLogUploader(secret *corev1.Secret, storageAccount, container string) (*azureLogUploader, error) {
+	if storageAccount == "" || container == "" {
+		return nil, errors.New("no Azure storage account and container configured for uploading logs")
+	}
+	encodedKey, ok := secret.Data[constants.AzureStorageAccountKeySecretKey]
+	if !ok {
+		return nil, errors.Errorf("Azure credentials secret %s does not contain key %s", secret.Name, constants.AzureStorageAccountKeySecretKey)
+	}
+	accountKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedKey)))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode Azure storage account key")
+	}
+	return &azureLogUploader{
+		client:         &http.Client{Timeout: azureUploadTimeout},
+		endpoint:       fmt.Sprintf("https://%s.blob.core.windows.net", storageAccount),
+		storageAccount: storageAccount,
+		container:      container,
+		accountKey:     accountKey,
+	}, nil
+}
+
+// Upload implements logUploader.
+func (u *azureLogUploader) Upload(key string, body io.ReadSeeker) (string, error) {
+	length, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	blobURL, err := url.Parse(u.endpoint)
+	if err != nil {
+		return "", err
+	}
+	blobURL.Path = fmt.Sprintf("/%s/%s", u.container, key)
+
+	req, err := http.NewRequest(http.MethodPut, blobURL.String(), ioutil.NopCloser(body))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = length
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageAPIVersion)
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", u.storageAccount, u.signature(req)))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", errors.Errorf("unexpected response uploading blob: %s: %s", resp.Status, respBody)
+	}
+	return blobURL.String(), nil
+}
+
+// signature computes the Shared Key signature of a request which has no query parameters and sets no standard
+// headers other than Content-Length.
+// See https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (u *azureLogUploader) signature(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+	stringToSign := strings.Join([]string{
+		req.Method,
+		"", // Content-Encoding
+		"", // Content-Language
+		contentLength,
+		"", // Content-MD5
+		"", // Content-Type
+		"", // Date
+		"", // If-Modified-Since
+		"", // If-Match
+		"", // If-None-Match
+		"", // If-Unmodified-Since
+		"", // Range
+		"x-ms-blob-type:" + req.Header.Get("x-ms-blob-type"),
+		"x-ms-date:" + req.Header.Get("x-ms-date"),
+		"x-ms-version:" + req.Header.Get("x-ms-version"),
+		fmt.Sprintf("/%s%s", u.storageAccount, req.URL.EscapedPath()),
+	}, "\n")
+	mac := hmac.New(sha256.New, u.accountKey)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -12,6 +12,7 @@ import (
 	azureutils "github.com/openshift/hive/contrib/pkg/utils/azure"
 	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/azureclient"
 	dns "github.com/openshift/hive/pkg/controller/dnszone"
@@ -34,7 +35,7 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 
 	switch {
 	case cd.Spec.Platform.AWS != nil:
-		return cleanupAWSDNSZone(dnsZone, cd.Spec.Platform.AWS.Region, logger)
+		return cleanupAWSDNSZone(dnsZone, cd.Spec.Platform.AWS.Region, cd.Spec.Platform.AWS.ServiceEndpoints, logger)
 	case cd.Spec.Platform.Azure != nil:
 		return cleanupAzureDNSZone(dnsZone, logger)
 	case cd.Spec.Platform.GCP != nil:
@@ -47,7 +48,7 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 
 // cleanupAWSDNSZone will return a DNS zone to the minimum set of DNS records
 // May no longer be necessary once https://jira.coreos.com/browse/CORS-1195 is fixed.
-func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldLogger) error {
+func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, serviceEndpoints []hivev1aws.ServiceEndpoint, logger log.FieldLogger) error {
 	if dnsZone.Status.AWS == nil {
 		return fmt.Errorf("found non-AWS DNSZone for AWS ClusterDeployment")
 	}
@@ -59,7 +60,10 @@ func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldL
 	zoneLogger := logger.WithField("dnsZoneID", *dnsZone.Status.AWS.ZoneID)
 	zoneLogger.Info("cleaning up DNSZone")
 
-	awsClient, err := awsclient.NewClient(nil, "", "", region)
+	awsClient, err := awsclient.NewClientWithOptions(nil, "", "", awsclient.Options{
+		Region:           region,
+		ServiceEndpoints: serviceEndpoints,
+	})
 	if err != nil {
 		logger.WithError(err).Error("failed to create AWS client")
 		return err
@@ -0,0 +1,63 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// gcsLogUploader uploads logs to Google Cloud Storage.
+type gcsLogUploader struct {
+	service *storage.Service
+	bucket  string
+}
+
+func newGCSLogUploader(secret *corev1.Secret, bucket string) (*gcsLogUploader, error) {
+	if bucket == "" {
+		return nil, errors.New("no Google Cloud Storage bucket configured for uploading logs")
+	}
+	authJSON, ok := secret.Data[constants.GCPCredentialsName]
+	if !ok {
+		return nil, errors.Errorf("GCP credentials secret %s does not contain key %s", secret.Name, constants.GCPCredentialsName)
+	}
+	ctx := context.Background()
+	creds, err := google.CredentialsFromJSON(ctx, authJSON, storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read GCP credentials")
+	}
+	service, err := storage.NewService(ctx,
+		option.WithCredentials(creds),
+		option.WithUserAgent("openshift.io hive/v1"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Google Cloud Storage client")
+	}
+	return &gcsLogUploader{
+		service: service,
+		bucket:  bucket,
+	}, nil
+}
+
+// Upload implements logUploader.
+func (u *gcsLogUploader) Upload(key string, body io.ReadSeeker) (string, error) {
+	object, err := u.service.Objects.Insert(u.bucket, &storage.Object{Name: key}).Media(body).Do()
+	if err != nil {
+		return "", err
+	}
+	objectURL := url.URL{
+		Scheme: "https",
+		Host:   "storage.googleapis.com",
+		Path:   fmt.Sprintf("/%s/%s", object.Bucket, object.Name),
+	}
+	return objectURL.String(), nil
+}
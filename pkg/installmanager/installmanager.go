@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -47,6 +48,7 @@ import (
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/destroy/vsphere"
 	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesaws "github.com/openshift/installer/pkg/types/aws"
 	installertypesazure "github.com/openshift/installer/pkg/types/azure"
 	installertypesgcp "github.com/openshift/installer/pkg/types/gcp"
 	installertypesopenstack "github.com/openshift/installer/pkg/types/openstack"
@@ -55,7 +57,10 @@ import (
 
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/resource"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
@@ -71,6 +76,7 @@ const (
 	kubeadminUsername                   = "kubeadmin"
 	adminKubeConfigSecretStringTemplate = "%s-admin-kubeconfig"
 	adminPasswordSecretStringTemplate   = "%s-admin-password"
+	dryRunConfigMapStringTemplate       = "%s-dry-run"
 	installerFullLogFile                = ".openshift_install.log"
 	installerConsoleLogFilePath         = "/tmp/openshift-install-console.log"
 	provisioningTransitionTimeout       = 5 * time.Minute
@@ -78,6 +84,8 @@ const (
 	defaultInstallConfigMountPath       = "/installconfig/install-config.yaml"
 	defaultPullSecretMountPath          = "/pullsecret/" + corev1.DockerConfigJsonKey
 	defaultManifestsMountPath           = "/manifests"
+	defaultAdditionalManifestsMountPath = "/additional-manifests"
+	defaultProxyTrustedCAMountPath      = "/proxy-trusted-ca/" + constants.TrustedCABundleConfigMapKey
 	defaultHomeDir                      = "/home/hive" // Used if no HOME env var set.
 )
 
@@ -100,6 +108,8 @@ type InstallManager struct {
 	InstallConfigMountPath           string
 	PullSecretMountPath              string
 	ManifestsMountPath               string
+	AdditionalManifestsMountPath     string
+	ProxyTrustedCAMountPath          string
 	DynamicClient                    client.Client
 	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
 	updateClusterProvision           func(*hivev1.ClusterProvision, *InstallManager, provisionMutation) error
@@ -109,8 +119,18 @@ type InstallManager struct {
 	readInstallerLog                 func(*hivev1.ClusterProvision, *InstallManager, bool) (string, error)
 	waitForProvisioningStage         func(*hivev1.ClusterProvision, *InstallManager) error
 	isGatherLogsEnabled              func() bool
+	newLogUploader                   func(*InstallManager) (logUploader, error)
 	waitForInstallCompleteExecutions int
 	binaryDir                        string
+	// installerLogLevel is the log level passed to the installer. The installer default is used when empty.
+	installerLogLevel string
+	// installDeadline is the time at which the installer is stopped so that the partial install can be cleaned
+	// up. The installer is not stopped when zero.
+	installDeadline time.Time
+	// logBundles are the paths of the log bundles gathered from a failed install.
+	logBundles []string
+	// provisionHooks are the external services called before and after provisioning the cluster.
+	provisionHooks []hivev1.ProvisionHook
 }
 
 // NewInstallManagerCommand is the entrypoint to create the 'install-manager' subcommand
@@ -135,6 +155,8 @@ func NewInstallManagerCommand() *cobra.Command {
 			im.InstallConfigMountPath = defaultInstallConfigMountPath
 			im.PullSecretMountPath = defaultPullSecretMountPath
 			im.ManifestsMountPath = defaultManifestsMountPath
+			im.AdditionalManifestsMountPath = defaultAdditionalManifestsMountPath
+			im.ProxyTrustedCAMountPath = defaultProxyTrustedCAMountPath
 			im.binaryDir = getHomeDir()
 
 			if err := im.Validate(); err != nil {
@@ -169,6 +191,7 @@ func (m *InstallManager) Complete(args []string) error {
 	m.uploadAdminPassword = uploadAdminPassword
 	m.readInstallerLog = readInstallerLog
 	m.isGatherLogsEnabled = isGatherLogsEnabled
+	m.newLogUploader = newLogUploader
 	m.cleanupFailedProvision = cleanupFailedProvision
 	m.waitForProvisioningStage = waitForProvisioningStage
 
@@ -200,6 +223,12 @@ func (m *InstallManager) Complete(args []string) error {
 		m.log.WithField("workdir", m.WorkDir).Fatalf("workdir does not exist")
 	}
 
+	m.provisionHooks, err = getProvisionHooks()
+	if err != nil {
+		m.log.WithError(err).Error("cannot get provision hooks")
+		return err
+	}
+
 	return nil
 }
 
@@ -234,6 +263,22 @@ func (m *InstallManager) Run() error {
 		m.log.Warn("cluster is already installed, exiting")
 		os.Exit(0)
 	}
+	if ref := cd.Spec.ClusterInstallRef; ref != nil {
+		// The cluster is installed by the ClusterInstall, such as the AgentClusterInstall of assisted-service for
+		// agent based bare metal installs, and the controllers never launch an install pod for it.
+		m.log.WithField("clusterInstall", ref.Name).Warnf("cluster is installed by a %s, exiting", ref.Kind)
+		os.Exit(0)
+	}
+
+	if controllerutils.IsDebugModeEnabled(cd) {
+		m.log.WithField("annotation", constants.DebugModeUntilAnnotation).Info("debug mode is on, running the installer with debug logging")
+		m.installerLogLevel = "debug"
+	}
+
+	if provision.Spec.Timeout != nil {
+		m.installDeadline = provision.CreationTimestamp.Add(provision.Spec.Timeout.Duration)
+		m.log.WithField("deadline", m.installDeadline).Info("installer will be stopped at the provision timeout")
+	}
 
 	// sshKeyPaths will contain paths to all ssh keys in use
 	var sshKeyPaths []string
@@ -292,6 +337,63 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error reading install-config.yaml")
 		return err
 	}
+	if cd.Spec.Proxy != nil {
+		icData, err = pasteInProxy(icData, cd.Spec.Proxy, m.ProxyTrustedCAMountPath)
+		if err != nil {
+			m.log.WithError(err).Error("error adding proxy to install-config.yaml")
+			return err
+		}
+	}
+	if aws := cd.Spec.Platform.AWS; aws != nil && len(aws.Subnets) > 0 {
+		icData, err = pasteInAWSSubnets(icData, aws.Subnets)
+		if err != nil {
+			m.log.WithError(err).Error("error adding AWS subnets to install-config.yaml")
+			return err
+		}
+	}
+	if azure := cd.Spec.Platform.Azure; azure != nil && azure.VirtualNetwork != "" {
+		icData, err = pasteInAzureNetwork(icData, azure)
+		if err != nil {
+			m.log.WithError(err).Error("error adding Azure virtual network to install-config.yaml")
+			return err
+		}
+	}
+	if gcp := cd.Spec.Platform.GCP; gcp != nil && gcp.Network != "" {
+		icData, err = pasteInGCPNetwork(icData, gcp)
+		if err != nil {
+			m.log.WithError(err).Error("error adding GCP network to install-config.yaml")
+			return err
+		}
+	}
+	if platform, image := bootImage(&cd.Spec.Platform); image != "" {
+		icData, err = pasteInBootImage(icData, platform, image)
+		if err != nil {
+			m.log.WithError(err).Error("error adding boot image to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.Networking != nil {
+		icData, err = pasteInNetworking(icData, cd.Spec.Provisioning.Networking)
+		if err != nil {
+			m.log.WithError(err).Error("error adding networking to install-config.yaml")
+			return err
+		}
+	}
+	// The pull secret is added after the pre-provision hooks have been called so that it is not sent to them.
+	icData, hookErr := m.runPreProvisionHooks(cd, provision, icData)
+	if hookErr != nil {
+		m.log.WithError(hookErr).Error("error running pre-provision hooks")
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				provision.Spec.InstallLog = pointer.StringPtr(hookErr.Error())
+			},
+		); err != nil {
+			m.log.WithError(err).Error("error updating cluster provision with pre-provision hook failure")
+		}
+		return hookErr
+	}
 	icData, err = pasteInPullSecret(icData, m.PullSecretMountPath)
 	if err != nil {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
@@ -311,6 +413,10 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
+	if provision.Spec.DryRun {
+		return m.dryRun(provision, scrubInstallLog)
+	}
+
 	// If the cluster provision has an infraID set, this implies we failed an install
 	// and are re-trying. Cleanup any resources that may have been provisioned.
 	m.log.Info("cleaning up from past install attempts")
@@ -339,6 +445,7 @@ func (m *InstallManager) Run() error {
 			m.log.WithError(err).Error("error updating cluster provision with asset generation log")
 			return err
 		}
+		m.uploadLogs(provision, scrubInstallLog)
 		return err
 	}
 
@@ -443,6 +550,8 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error reading installer log")
 	}
 
+	m.uploadLogs(provision, scrubInstallLog)
+
 	if installErr != nil {
 		m.log.WithError(installErr).Error("failed due to install error")
 		return installErr
@@ -450,6 +559,8 @@ func (m *InstallManager) Run() error {
 
 	m.log.Info("install completed successfully")
 
+	m.runPostProvisionHooks(cd, provision, metadata)
+
 	return nil
 }
 
@@ -531,13 +642,26 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 	switch {
 	case cd.Spec.Platform.AWS != nil:
 		// run the uninstaller to clean up any cloud resources previously created
-		filters := []aws.Filter{
-			{kubernetesKeyPrefix + infraID: "owned"},
+		serviceEndpoints := make([]installertypesaws.ServiceEndpoint, len(cd.Spec.Platform.AWS.ServiceEndpoints))
+		for i, e := range cd.Spec.Platform.AWS.ServiceEndpoints {
+			serviceEndpoints[i] = installertypesaws.ServiceEndpoint{Name: e.Name, URL: e.URL}
+		}
+		metadata := &installertypes.ClusterMetadata{
+			InfraID: infraID,
+			ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+				AWS: &installertypesaws.Metadata{
+					Region:           cd.Spec.Platform.AWS.Region,
+					ServiceEndpoints: serviceEndpoints,
+					Identifier: []map[string]string{
+						{kubernetesKeyPrefix + infraID: "owned"},
+					},
+				},
+			},
 		}
-		uninstaller = &aws.ClusterUninstaller{
-			Filters: filters,
-			Region:  cd.Spec.Platform.AWS.Region,
-			Logger:  logger,
+		var err error
+		uninstaller, err = aws.New(logger, metadata)
+		if err != nil {
+			return err
 		}
 	case cd.Spec.Platform.Azure != nil:
 		metadata := &installertypes.ClusterMetadata{
@@ -627,6 +751,12 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 		if err != nil {
 			return err
 		}
+	case cd.Spec.Platform.IBMCloud != nil:
+		logger.Warn("re-try cleanup is not supported for IBM Cloud")
+		return errors.New("re-try cleanup is not supported for IBM Cloud, remove the resources of the failed install before retrying")
+	case cd.Spec.Platform.Nutanix != nil:
+		logger.Warn("re-try cleanup is not supported for Nutanix")
+		return errors.New("re-try cleanup is not supported for Nutanix, remove the resources of the failed install before retrying")
 	default:
 		logger.Warn("unknown platform for re-try cleanup")
 		return errors.New("unknown platform for re-try cleanup")
@@ -642,7 +772,22 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 // generateAssets runs openshift-install commands to generate on-disk assets we need to
 // upload or modify prior to provisioning resources in the cloud.
 func (m *InstallManager) generateAssets(provision *hivev1.ClusterProvision) error {
+	if err := m.generateManifests(); err != nil {
+		return err
+	}
 
+	m.log.Info("running openshift-install create ignition-configs")
+	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
+		m.log.WithError(err).Error("error generating installer assets")
+		return err
+	}
+	m.log.Info("assets generated successfully")
+	return nil
+}
+
+// generateManifests runs openshift-install create manifests and adds the user-provided manifests to the
+// generated ones.
+func (m *InstallManager) generateManifests() error {
 	m.log.Info("running openshift-install create manifests")
 	err := m.runOpenShiftInstallCommand("create", "manifests")
 	if err != nil {
@@ -662,29 +807,157 @@ func (m *InstallManager) generateAssets(provision *hivev1.ClusterProvision) erro
 		m.log.Infof("copied %s to %s", src, dest)
 	}
 
-	m.log.Info("running openshift-install create ignition-configs")
-	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
-		m.log.WithError(err).Error("error generating installer assets")
+	if err := m.copyAdditionalManifests(); err != nil {
+		m.log.WithError(err).Error("error copying additional manifests")
 		return err
 	}
-	m.log.Info("assets generated successfully")
 	return nil
 }
 
+// dryRun renders the manifests for the cluster and saves them, along with the install-config, in a ConfigMap
+// for review. No cloud resources are provisioned.
+func (m *InstallManager) dryRun(provision *hivev1.ClusterProvision, scrubInstallLog bool) error {
+	m.log.Info("rendering manifests for dry run")
+	var configMap *corev1.ConfigMap
+	dryRunErr := m.generateManifests()
+	if dryRunErr == nil {
+		configMap, dryRunErr = m.uploadDryRunOutput(provision)
+	}
+
+	installLog, err := m.readInstallerLog(provision, m, scrubInstallLog)
+	if err != nil {
+		m.log.WithError(err).Error("error reading installer log")
+	}
+	if err := m.updateClusterProvision(
+		provision,
+		m,
+		func(provision *hivev1.ClusterProvision) {
+			if installLog != "" {
+				provision.Spec.InstallLog = pointer.StringPtr(installLog)
+			}
+			if configMap != nil {
+				provision.Spec.DryRunConfigMapRef = &corev1.LocalObjectReference{Name: configMap.Name}
+			}
+		},
+	); err != nil {
+		m.log.WithError(err).Error("error updating cluster provision with dry-run output")
+		if dryRunErr == nil {
+			dryRunErr = errors.Wrap(err, "error updating cluster provision with dry-run output")
+		}
+	}
+
+	m.uploadLogs(provision, scrubInstallLog)
+
+	if dryRunErr != nil {
+		m.log.WithError(dryRunErr).Error("dry run failed")
+		return dryRunErr
+	}
+	m.log.Info("dry run completed successfully")
+	return nil
+}
+
+// uploadDryRunOutput saves the install-config and the rendered manifests in a ConfigMap owned by the provision.
+// The install-config is saved as it was provided, without the pull secret, and manifests for Secrets are left
+// out so that no credentials are exposed.
+func (m *InstallManager) uploadDryRunOutput(provision *hivev1.ClusterProvision) (*corev1.ConfigMap, error) {
+	m.log.Info("uploading dry-run output")
+	icData, err := ioutil.ReadFile(m.InstallConfigMountPath)
+	if err != nil {
+		m.log.WithError(err).Error("error reading install-config.yaml")
+		return nil, err
+	}
+	data := map[string]string{
+		"install-config.yaml": string(icData),
+	}
+	for _, dir := range []string{"manifests", "openshift"} {
+		files, err := ioutil.ReadDir(filepath.Join(m.WorkDir, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not read %s directory", dir)
+		}
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			content, err := ioutil.ReadFile(filepath.Join(m.WorkDir, dir, f.Name()))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read manifest %s", f.Name())
+			}
+			manifest := struct {
+				Kind string `json:"kind"`
+			}{}
+			if err := yaml.Unmarshal(content, &manifest); err == nil && manifest.Kind == "Secret" {
+				m.log.WithField("manifest", f.Name()).WithField("directory", dir).Debug("leaving Secret out of dry-run output")
+				continue
+			}
+			data[fmt.Sprintf("%s_%s", dir, f.Name())] = string(content)
+		}
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(dryRunConfigMapStringTemplate, m.ClusterProvisionName),
+			Namespace: m.Namespace,
+		},
+		Data: data,
+	}
+
+	m.log.WithField("derivedObject", configMap.Name).Debug("Setting labels on derived object")
+	configMap.Labels = k8slabels.AddLabel(configMap.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		m.log.WithError(err).Errorf("error getting GVK for provision")
+		return nil, err
+	}
+
+	configMap.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+
+	// Replace the output of any earlier attempt of this provision.
+	if err := m.deleteAnyExistingObject(types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}, &corev1.ConfigMap{}); err != nil {
+		m.log.WithError(err).Error("failed to fetch/delete any pre-existing dry-run configmap")
+		return nil, err
+	}
+	if err := createWithRetries(configMap, m); err != nil {
+		return nil, err
+	}
+
+	return configMap, nil
+}
+
 // provisionCluster invokes the openshift-install create cluster command to provision resources
 // in the cloud.
 func (m *InstallManager) provisionCluster() error {
 
 	m.log.Info("running openshift-install create cluster")
 
-	if err := m.runOpenShiftInstallCommand("create", "cluster"); err != nil {
+	ctx := context.Background()
+	if !m.installDeadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, m.installDeadline)
+		defer cancel()
+	}
+
+	if err := m.runOpenShiftInstallCommandWithContext(ctx, "create", "cluster"); err != nil {
 		if (m.waitForInstallCompleteExecutions > 0) && m.isBootstrapComplete() {
-			for i := 0; i < m.waitForInstallCompleteExecutions; i++ {
+			for i := 0; i < m.waitForInstallCompleteExecutions && ctx.Err() == nil; i++ {
 				m.log.WithField("waitIteration", i).WithError(err).
 					Warn("provisioning cluster failed after completing bootstrapping, waiting longer for install to complete")
-				err = m.runOpenShiftInstallCommand("wait-for", "install-complete")
+				err = m.runOpenShiftInstallCommandWithContext(ctx, "wait-for", "install-complete")
 			}
 		}
+		if ctx.Err() == context.DeadlineExceeded {
+			m.log.WithField("deadline", m.installDeadline).Error("installer stopped at the provision timeout")
+			return errors.Wrap(err, "provision timed out")
+		}
 		if err != nil {
 			m.log.WithError(err).Error("error provisioning cluster")
 			return err
@@ -694,8 +967,16 @@ func (m *InstallManager) provisionCluster() error {
 }
 
 func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
+	return m.runOpenShiftInstallCommandWithContext(context.Background(), args...)
+}
+
+// runOpenShiftInstallCommandWithContext runs the installer, killing it if the context is done before it exits.
+func (m *InstallManager) runOpenShiftInstallCommandWithContext(ctx context.Context, args ...string) error {
+	if m.installerLogLevel != "" {
+		args = append(args, "--log-level", m.installerLogLevel)
+	}
 	m.log.WithField("args", args).Info("running openshift-install binary")
-	cmd := exec.Command(filepath.Join(m.binaryDir, "openshift-install"), args...)
+	cmd := exec.CommandContext(ctx, filepath.Join(m.binaryDir, "openshift-install"), args...)
 	cmd.Dir = m.WorkDir
 
 	// save the commands' stdout/stderr to a file
@@ -1031,6 +1312,7 @@ func (m *InstallManager) gatherBootstrapNodeLogs(cd *hivev1.ClusterDeployment, n
 			return err
 		}
 		m.log.Infof("moved %s to %s", lb, m.LogsDir)
+		m.logBundles = append(m.logBundles, filepath.Join(m.LogsDir, filepath.Base(lb)))
 	}
 	m.log.Info("bootstrap node log gathering complete")
 
@@ -1330,6 +1612,62 @@ func cleanupLogOutput(fullLog string) string {
 	return cleanedString
 }
 
+// copyAdditionalManifests copies the user-provided manifests from each of the manifests sources of the
+// ClusterDeployment into the corresponding installer directory. The sources are mounted at
+// <mount path>/<installer directory>/<index of the source>, and are copied in the order of the sources so that
+// later sources replace files from earlier ones.
+func (m *InstallManager) copyAdditionalManifests() error {
+	if m.AdditionalManifestsMountPath == "" {
+		return nil
+	}
+	for _, dir := range []hivev1.InstallManifestsDirectory{
+		hivev1.InstallManifestsDirectoryManifests,
+		hivev1.InstallManifestsDirectoryOpenShift,
+	} {
+		srcRoot := filepath.Join(m.AdditionalManifestsMountPath, string(dir))
+		entries, err := ioutil.ReadDir(srcRoot)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", srcRoot)
+		}
+		var indices []int
+		for _, e := range entries {
+			if i, err := strconv.Atoi(e.Name()); err == nil {
+				indices = append(indices, i)
+			}
+		}
+		sort.Ints(indices)
+		dest := filepath.Join(m.WorkDir, string(dir))
+		if err := os.MkdirAll(dest, 0755); err != nil {
+			return errors.Wrapf(err, "could not create %s", dest)
+		}
+		for _, i := range indices {
+			src := filepath.Join(srcRoot, strconv.Itoa(i))
+			files, err := ioutil.ReadDir(src)
+			if err != nil {
+				return errors.Wrapf(err, "could not read %s", src)
+			}
+			for _, f := range files {
+				// Skip the hidden entries that kubelet uses to atomically update ConfigMap and Secret volumes.
+				if strings.HasPrefix(f.Name(), ".") {
+					continue
+				}
+				content, err := ioutil.ReadFile(filepath.Join(src, f.Name()))
+				if err != nil {
+					return errors.Wrapf(err, "could not read manifest %s", f.Name())
+				}
+				if err := ioutil.WriteFile(filepath.Join(dest, f.Name()), content, 0644); err != nil {
+					return errors.Wrapf(err, "could not write manifest %s", f.Name())
+				}
+				m.log.WithField("manifest", f.Name()).WithField("directory", dir).Info("copied user-provided manifest")
+			}
+		}
+	}
+	return nil
+}
+
 // isDirNonEmpty returns true if the directory exists and contains at least one file.
 func isDirNonEmpty(dir string) bool {
 	f, err := os.Open(dir)
@@ -1355,6 +1693,168 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// pasteInProxy sets the proxy of the install-config from the proxy of the ClusterDeployment. The CA bundle of the
+// proxy, if any, is appended to the additional trust bundle of the install-config.
+func pasteInProxy(icData []byte, proxy *hivev1.Proxy, trustedCAFile string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icProxy := map[string]interface{}{}
+	if proxy.HTTPProxy != "" {
+		icProxy["httpProxy"] = proxy.HTTPProxy
+	}
+	if proxy.HTTPSProxy != "" {
+		icProxy["httpsProxy"] = proxy.HTTPSProxy
+	}
+	if proxy.NoProxy != "" {
+		icProxy["noProxy"] = proxy.NoProxy
+	}
+	if len(icProxy) > 0 {
+		icRaw["proxy"] = icProxy
+	}
+	if proxy.TrustedCA != nil {
+		caData, err := ioutil.ReadFile(trustedCAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read the trusted CA bundle of the proxy")
+		}
+		bundle, _ := icRaw["additionalTrustBundle"].(string)
+		if bundle != "" && !strings.HasSuffix(bundle, "\n") {
+			bundle += "\n"
+		}
+		icRaw["additionalTrustBundle"] = bundle + string(caData)
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInAWSSubnets sets the existing subnets of the AWS platform of the install-config.
+func pasteInAWSSubnets(icData []byte, subnets []string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icAWS := installConfigPlatform(icRaw, "aws")
+	icAWS["subnets"] = subnets
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInAzureNetwork sets the existing virtual network and subnets of the Azure platform of the install-config from
+// the Azure platform of the ClusterDeployment.
+func pasteInAzureNetwork(icData []byte, azure *hivev1azure.Platform) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icAzure := installConfigPlatform(icRaw, "azure")
+	icAzure["networkResourceGroupName"] = azure.NetworkResourceGroupName
+	icAzure["virtualNetwork"] = azure.VirtualNetwork
+	icAzure["controlPlaneSubnet"] = azure.ControlPlaneSubnet
+	icAzure["computeSubnet"] = azure.ComputeSubnet
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInGCPNetwork sets the existing network and subnets, and the host project of a Shared VPC network, of the GCP
+// platform of the install-config from the GCP platform of the ClusterDeployment.
+func pasteInGCPNetwork(icData []byte, gcp *hivev1gcp.Platform) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icGCP := installConfigPlatform(icRaw, "gcp")
+	icGCP["network"] = gcp.Network
+	icGCP["controlPlaneSubnet"] = gcp.ControlPlaneSubnet
+	icGCP["computeSubnet"] = gcp.ComputeSubnet
+	if gcp.NetworkProjectID != "" {
+		icGCP["networkProjectID"] = gcp.NetworkProjectID
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// bootImage returns the name of the platform of the ClusterDeployment and the boot image that overrides the RHCOS
+// image of the release image on that platform, or an empty image if there is no override.
+func bootImage(platform *hivev1.Platform) (string, string) {
+	switch {
+	case platform.AWS != nil:
+		return "aws", platform.AWS.AMIID
+	case platform.Azure != nil:
+		return "azure", platform.Azure.ClusterOSImage
+	case platform.GCP != nil:
+		return "gcp", platform.GCP.ClusterOSImage
+	}
+	return "", ""
+}
+
+// pasteInBootImage sets the boot image of the named platform of the install-config, which the installer uses for the
+// bootstrap and control plane machines instead of the RHCOS image of the release image.
+func pasteInBootImage(icData []byte, platform, image string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icPlatform := installConfigPlatform(icRaw, platform)
+	switch platform {
+	case "aws":
+		icPlatform["amiID"] = image
+	default:
+		icPlatform["clusterOSImage"] = image
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInNetworking sets the networks of the install-config that are set in the networking of the ClusterDeployment.
+// Networks may be dual-stack, with an IPv4 and an IPv6 CIDR each. The deprecated single-CIDR fields are removed so that
+// they do not conflict with the networks that replace them.
+func pasteInNetworking(icData []byte, networking *hivev1.Networking) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icNetworking, _ := icRaw["networking"].(map[string]interface{})
+	if icNetworking == nil {
+		icNetworking = map[string]interface{}{}
+		icRaw["networking"] = icNetworking
+	}
+	if networking.NetworkType != "" {
+		delete(icNetworking, "type")
+		icNetworking["networkType"] = networking.NetworkType
+	}
+	if len(networking.MachineNetwork) > 0 {
+		machineNetwork := make([]interface{}, len(networking.MachineNetwork))
+		for i, cidr := range networking.MachineNetwork {
+			machineNetwork[i] = map[string]interface{}{"cidr": cidr}
+		}
+		delete(icNetworking, "machineCIDR")
+		icNetworking["machineNetwork"] = machineNetwork
+	}
+	if len(networking.ClusterNetwork) > 0 {
+		clusterNetwork := make([]interface{}, len(networking.ClusterNetwork))
+		for i, entry := range networking.ClusterNetwork {
+			clusterNetwork[i] = map[string]interface{}{"cidr": entry.CIDR, "hostPrefix": entry.HostPrefix}
+		}
+		icNetworking["clusterNetwork"] = clusterNetwork
+	}
+	if len(networking.ServiceNetwork) > 0 {
+		delete(icNetworking, "serviceCIDR")
+		icNetworking["serviceNetwork"] = networking.ServiceNetwork
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// installConfigPlatform returns the named platform section of the raw install-config, adding it if missing.
+func installConfigPlatform(icRaw map[string]interface{}, name string) map[string]interface{} {
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	if platform == nil {
+		platform = map[string]interface{}{}
+		icRaw["platform"] = platform
+	}
+	section, _ := platform[name].(map[string]interface{})
+	if section == nil {
+		section = map[string]interface{}{}
+		platform[name] = section
+	}
+	return section
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
@@ -8,6 +8,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,12 +23,17 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 
+	"github.com/openshift/installer/pkg/ipnet"
 	installertypes "github.com/openshift/installer/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/hive/pkg/apis"
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/constants"
 )
 
@@ -65,6 +71,15 @@ exit 0
 
 	alwaysErrorBinary = `#!/bin/sh
 exit 1`
+
+	fakeManifestsInstallerBinary = `#!/bin/sh
+WORKDIR=%s
+mkdir -p $WORKDIR/manifests $WORKDIR/openshift
+printf 'kind: ConfigMap\nmetadata:\n  name: cluster-config-v1\n' > $WORKDIR/manifests/cluster-config.yaml
+printf 'kind: Secret\nmetadata:\n  name: pull-secret\n' > $WORKDIR/manifests/openshift-config-secret-pull-secret.yaml
+printf 'kind: MachineSet\nmetadata:\n  name: worker\n' > $WORKDIR/openshift/99_openshift-cluster-api_worker-machineset-0.yaml
+echo "some fake installer log output" >  /tmp/openshift-install-console.log
+`
 )
 
 var (
@@ -327,6 +342,129 @@ func TestInstallManager(t *testing.T) {
 	}
 }
 
+func TestInstallManagerDryRun(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tempDir, err := ioutil.TempDir("", "installmanagerdryruntest")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	defer os.Remove(installerConsoleLogFilePath)
+
+	binaryTempDir, err := ioutil.TempDir(tempDir, "bin")
+	require.NoError(t, err)
+
+	provision := testClusterProvision()
+	provision.Spec.Stage = hivev1.ClusterProvisionStageInitializing
+	provision.Spec.DryRun = true
+	fakeClient := fake.NewFakeClient(testClusterDeployment(), provision)
+
+	mountedInstallConfigFile := filepath.Join(tempDir, "mounted-install-config.yaml")
+	require.NoError(t, ioutil.WriteFile(mountedInstallConfigFile, []byte("INSTALL_CONFIG: FAKE"), 0600))
+	mountedPullSecretFile := filepath.Join(tempDir, "mounted-pull-secret.json")
+	require.NoError(t, ioutil.WriteFile(mountedPullSecretFile, []byte("{}"), 0600))
+
+	im := InstallManager{
+		LogLevel:               "debug",
+		WorkDir:                tempDir,
+		ClusterProvisionName:   testProvisionName,
+		Namespace:              testNamespace,
+		DynamicClient:          fakeClient,
+		InstallConfigMountPath: mountedInstallConfigFile,
+		PullSecretMountPath:    mountedPullSecretFile,
+		binaryDir:              binaryTempDir,
+	}
+	im.Complete([]string{})
+	im.waitForProvisioningStage = func(*hivev1.ClusterProvision, *InstallManager) error {
+		t.Error("dry run should not wait for the provisioning stage")
+		return nil
+	}
+	im.cleanupFailedProvision = func(client.Client, *hivev1.ClusterDeployment, string, log.FieldLogger) error {
+		t.Error("dry run should not clean up a failed provision")
+		return nil
+	}
+
+	require.NoError(t, writeFakeBinary(filepath.Join(tempDir, installerBinary), fmt.Sprintf(fakeManifestsInstallerBinary, tempDir)))
+	require.NoError(t, writeFakeBinary(filepath.Join(tempDir, ocBinary), fmt.Sprintf(fakeManifestsInstallerBinary, tempDir)))
+
+	require.NoError(t, im.Run(), "unexpected error running dry run")
+
+	configMap := &corev1.ConfigMap{}
+	err = fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "test-provision-dry-run"}, configMap)
+	if assert.NoError(t, err, "expected dry-run configmap") {
+		assert.Equal(t, map[string]string{
+			"install-config.yaml":                                         "INSTALL_CONFIG: FAKE",
+			"manifests_cluster-config.yaml":                               "kind: ConfigMap\nmetadata:\n  name: cluster-config-v1\n",
+			"openshift_99_openshift-cluster-api_worker-machineset-0.yaml": "kind: MachineSet\nmetadata:\n  name: worker\n",
+		}, configMap.Data, "unexpected dry-run output")
+		assert.Equal(t, testProvisionName, configMap.Labels[constants.ClusterProvisionNameLabel], "incorrect cluster provision name label")
+	}
+
+	_, err = os.Stat(filepath.Join(tempDir, "metadata.json"))
+	assert.True(t, os.IsNotExist(err), "dry run should not generate ignition configs")
+
+	updatedProvision := &hivev1.ClusterProvision{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, updatedProvision))
+	if assert.NotNil(t, updatedProvision.Spec.DryRunConfigMapRef, "expected dry-run configmap reference to be set") {
+		assert.Equal(t, "test-provision-dry-run", updatedProvision.Spec.DryRunConfigMapRef.Name, "unexpected name for dry-run configmap reference")
+	}
+	if assert.NotNil(t, updatedProvision.Spec.InstallLog, "expected install log to be set") {
+		assert.Equal(t, "some fake installer log output\n", *updatedProvision.Spec.InstallLog, "did not find expected contents in saved installer log")
+	}
+	assert.Nil(t, updatedProvision.Spec.InfraID, "expected infra ID to be empty")
+}
+
+func TestRunOpenShiftInstallCommandLogLevel(t *testing.T) {
+	for _, logLevel := range []string{"", "debug"} {
+		t.Run(fmt.Sprintf("log level %q", logLevel), func(t *testing.T) {
+			tempDir, err := ioutil.TempDir("", "installmanagerloglevel")
+			require.NoError(t, err)
+			defer os.RemoveAll(tempDir)
+			defer os.Remove(installerConsoleLogFilePath)
+
+			argsFile := filepath.Join(tempDir, "args")
+			require.NoError(t, writeFakeBinary(filepath.Join(tempDir, installerBinary), fmt.Sprintf("#!/bin/sh\necho \"$@\" > %s\n", argsFile)))
+
+			im := &InstallManager{
+				WorkDir:           tempDir,
+				binaryDir:         tempDir,
+				installerLogLevel: logLevel,
+				log:               log.WithField("test", "TestRunOpenShiftInstallCommandLogLevel"),
+			}
+			require.NoError(t, im.runOpenShiftInstallCommand("create", "manifests"), "unexpected error running installer")
+
+			args, err := ioutil.ReadFile(argsFile)
+			require.NoError(t, err, "could not read installer args")
+			expected := "create manifests"
+			if logLevel != "" {
+				expected += " --log-level " + logLevel
+			}
+			assert.Equal(t, expected+"\n", string(args), "unexpected installer args")
+		})
+	}
+}
+
+func TestProvisionClusterTimeout(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "installmanagertimeout")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	defer os.Remove(installerConsoleLogFilePath)
+
+	require.NoError(t, writeFakeBinary(filepath.Join(tempDir, installerBinary), "#!/bin/sh\nexec sleep 30\n"))
+
+	im := &InstallManager{
+		WorkDir:         tempDir,
+		binaryDir:       tempDir,
+		installDeadline: time.Now().Add(time.Second),
+		log:             log.WithField("test", "TestProvisionClusterTimeout"),
+	}
+	start := time.Now()
+	err = im.provisionCluster()
+	if assert.Error(t, err, "expected provision to time out") {
+		assert.Contains(t, err.Error(), "provision timed out", "unexpected error")
+	}
+	assert.True(t, time.Since(start) < 20*time.Second, "installer was not stopped at the deadline")
+}
+
 func writeFakeBinary(fileName string, contents string) error {
 	data := []byte(contents)
 	err := ioutil.WriteFile(fileName, data, 0755)
@@ -699,6 +837,338 @@ func TestIsBootstrapComplete(t *testing.T) {
 	}
 }
 
+func TestCopyAdditionalManifests(t *testing.T) {
+	mountDir, err := ioutil.TempDir("", "TestCopyAdditionalManifestsMount")
+	require.NoError(t, err, "could not create temp dir")
+	defer os.RemoveAll(mountDir)
+	workDir, err := ioutil.TempDir("", "TestCopyAdditionalManifestsWork")
+	require.NoError(t, err, "could not create temp dir")
+	defer os.RemoveAll(workDir)
+
+	writeFile := func(name, content string) {
+		p := path.Join(mountDir, name)
+		require.NoError(t, os.MkdirAll(path.Dir(p), 0755), "could not create directory")
+		require.NoError(t, ioutil.WriteFile(p, []byte(content), 0644), "could not write file")
+	}
+	writeFile("manifests/0/cni.yaml", "first")
+	writeFile("manifests/0/..data/cni.yaml", "hidden")
+	writeFile("manifests/2/cni.yaml", "third")
+	writeFile("manifests/10/other.yaml", "eleventh")
+	writeFile("openshift/1/99-machineconfig.yaml", "machineconfig")
+
+	im := &InstallManager{
+		WorkDir:                      workDir,
+		AdditionalManifestsMountPath: mountDir,
+		log:                          log.WithField("test", "TestCopyAdditionalManifests"),
+	}
+	require.NoError(t, im.copyAdditionalManifests(), "unexpected error copying manifests")
+
+	for name, expected := range map[string]string{
+		"manifests/cni.yaml":              "third",
+		"manifests/other.yaml":            "eleventh",
+		"openshift/99-machineconfig.yaml": "machineconfig",
+	} {
+		content, err := ioutil.ReadFile(path.Join(workDir, name))
+		if assert.NoError(t, err, "could not read copied manifest %s", name) {
+			assert.Equal(t, expected, string(content), "unexpected content for %s", name)
+		}
+	}
+	_, err = os.Stat(path.Join(workDir, "manifests", "..data"))
+	assert.True(t, os.IsNotExist(err), "hidden entries should not be copied")
+}
+
+func Test_pasteInProxy(t *testing.T) {
+	const proxyCA = "-----BEGIN CERTIFICATE-----\nproxy\n-----END CERTIFICATE-----\n"
+	caFile := filepath.Join(t.TempDir(), "ca-bundle.crt")
+	require.NoError(t, ioutil.WriteFile(caFile, []byte(proxyCA), 0644))
+
+	cases := []struct {
+		name           string
+		existingBundle string
+		proxy          *hivev1.Proxy
+		expectedProxy  *installertypes.Proxy
+		expectedBundle string
+	}{
+		{
+			name:          "proxy",
+			proxy:         &hivev1.Proxy{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3129", NoProxy: ".example.com"},
+			expectedProxy: &installertypes.Proxy{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3129", NoProxy: ".example.com"},
+		},
+		{
+			name:           "trusted ca",
+			proxy:          &hivev1.Proxy{HTTPSProxy: "https://proxy:3129", TrustedCA: &corev1.LocalObjectReference{Name: "proxy-ca"}},
+			expectedProxy:  &installertypes.Proxy{HTTPSProxy: "https://proxy:3129"},
+			expectedBundle: proxyCA,
+		},
+		{
+			name:           "trusted ca with existing bundle",
+			existingBundle: "existing",
+			proxy:          &hivev1.Proxy{HTTPSProxy: "https://proxy:3129", TrustedCA: &corev1.LocalObjectReference{Name: "proxy-ca"}},
+			expectedProxy:  &installertypes.Proxy{HTTPSProxy: "https://proxy:3129"},
+			expectedBundle: "existing\n" + proxyCA,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			if tc.existingBundle != "" {
+				icData = append(icData, []byte("additionalTrustBundle: "+tc.existingBundle+"\n")...)
+			}
+			actual, err := pasteInProxy(icData, tc.proxy, caFile)
+			require.NoError(t, err, "unexpected error pasting in proxy")
+			ic := &installertypes.InstallConfig{}
+			require.NoError(t, yaml.Unmarshal(actual, ic), "unexpected error unmarshaling InstallConfig")
+			assert.Equal(t, tc.expectedProxy, ic.Proxy, "unexpected proxy")
+			assert.Equal(t, tc.expectedBundle, ic.AdditionalTrustBundle, "unexpected additional trust bundle")
+			assert.Equal(t, "hive-cluster", ic.ObjectMeta.Name, "expected the rest of the InstallConfig to be kept")
+		})
+	}
+}
+
+func Test_pasteInGCPNetwork(t *testing.T) {
+	const icData = `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  gcp:
+    projectID: service-project
+    region: us-central1
+`
+	cases := []struct {
+		name     string
+		gcp      *hivev1gcp.Platform
+		expected map[string]interface{}
+	}{
+		{
+			name: "existing network",
+			gcp: &hivev1gcp.Platform{
+				Network:            "existing-network",
+				ControlPlaneSubnet: "control-plane-subnet",
+				ComputeSubnet:      "compute-subnet",
+			},
+			expected: map[string]interface{}{
+				"projectID":          "service-project",
+				"region":             "us-central1",
+				"network":            "existing-network",
+				"controlPlaneSubnet": "control-plane-subnet",
+				"computeSubnet":      "compute-subnet",
+			},
+		},
+		{
+			name: "shared vpc",
+			gcp: &hivev1gcp.Platform{
+				NetworkProjectID:   "host-project",
+				Network:            "shared-network",
+				ControlPlaneSubnet: "control-plane-subnet",
+				ComputeSubnet:      "compute-subnet",
+			},
+			expected: map[string]interface{}{
+				"projectID":          "service-project",
+				"region":             "us-central1",
+				"networkProjectID":   "host-project",
+				"network":            "shared-network",
+				"controlPlaneSubnet": "control-plane-subnet",
+				"computeSubnet":      "compute-subnet",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := pasteInGCPNetwork([]byte(icData), tc.gcp)
+			require.NoError(t, err, "unexpected error pasting in GCP network")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshaling InstallConfig")
+			platform := icRaw["platform"].(map[string]interface{})
+			assert.Equal(t, tc.expected, platform["gcp"], "unexpected GCP platform")
+			assert.Equal(t, "hive-cluster", icRaw["metadata"].(map[string]interface{})["name"], "expected the rest of the InstallConfig to be kept")
+		})
+	}
+}
+
+func Test_pasteInAWSSubnets(t *testing.T) {
+	const icData = `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  aws:
+    region: us-east-1
+    subnets:
+    - subnet-replaced
+`
+	actual, err := pasteInAWSSubnets([]byte(icData), []string{"subnet-0123456789abcdef0", "subnet-0123456789abcdef1"})
+	require.NoError(t, err, "unexpected error pasting in AWS subnets")
+	icRaw := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshaling InstallConfig")
+	platform := icRaw["platform"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"region":  "us-east-1",
+		"subnets": []interface{}{"subnet-0123456789abcdef0", "subnet-0123456789abcdef1"},
+	}, platform["aws"], "unexpected AWS platform")
+	assert.Equal(t, "hive-cluster", icRaw["metadata"].(map[string]interface{})["name"], "expected the rest of the InstallConfig to be kept")
+}
+
+func Test_pasteInAzureNetwork(t *testing.T) {
+	const icData = `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  azure:
+    region: eastus
+    baseDomainResourceGroupName: os4-common
+`
+	actual, err := pasteInAzureNetwork([]byte(icData), &hivev1azure.Platform{
+		NetworkResourceGroupName: "network-rg",
+		VirtualNetwork:           "existing-vnet",
+		ControlPlaneSubnet:       "control-plane-subnet",
+		ComputeSubnet:            "compute-subnet",
+	})
+	require.NoError(t, err, "unexpected error pasting in Azure virtual network")
+	icRaw := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshaling InstallConfig")
+	platform := icRaw["platform"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"region":                      "eastus",
+		"baseDomainResourceGroupName": "os4-common",
+		"networkResourceGroupName":    "network-rg",
+		"virtualNetwork":              "existing-vnet",
+		"controlPlaneSubnet":          "control-plane-subnet",
+		"computeSubnet":               "compute-subnet",
+	}, platform["azure"], "unexpected Azure platform")
+	assert.Equal(t, "hive-cluster", icRaw["metadata"].(map[string]interface{})["name"], "expected the rest of the InstallConfig to be kept")
+}
+
+func Test_pasteInBootImage(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform hivev1.Platform
+		icData   string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "aws",
+			platform: hivev1.Platform{AWS: &hivev1aws.Platform{AMIID: "ami-0123456789abcdef0"}},
+			icData: `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  aws:
+    region: us-east-1
+`,
+			expected: map[string]interface{}{
+				"region": "us-east-1",
+				"amiID":  "ami-0123456789abcdef0",
+			},
+		},
+		{
+			name: "azure",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{
+				ClusterOSImage: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/gallery/images/rhcos/versions/1.0.0",
+			}},
+			icData: `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  azure:
+    region: eastus
+`,
+			expected: map[string]interface{}{
+				"region":         "eastus",
+				"clusterOSImage": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/gallery/images/rhcos/versions/1.0.0",
+			},
+		},
+		{
+			name:     "gcp",
+			platform: hivev1.Platform{GCP: &hivev1gcp.Platform{ClusterOSImage: "rhcos-mirror"}},
+			icData: `apiVersion: v1
+metadata:
+  name: hive-cluster
+platform:
+  gcp:
+    projectID: project
+    region: us-central1
+`,
+			expected: map[string]interface{}{
+				"projectID":      "project",
+				"region":         "us-central1",
+				"clusterOSImage": "rhcos-mirror",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			platform, image := bootImage(&tc.platform)
+			require.NotEmpty(t, image, "expected a boot image")
+			actual, err := pasteInBootImage([]byte(tc.icData), platform, image)
+			require.NoError(t, err, "unexpected error pasting in boot image")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshaling InstallConfig")
+			icPlatform := icRaw["platform"].(map[string]interface{})
+			assert.Equal(t, tc.expected, icPlatform[tc.name], "unexpected platform")
+			assert.Equal(t, "hive-cluster", icRaw["metadata"].(map[string]interface{})["name"], "expected the rest of the InstallConfig to be kept")
+		})
+	}
+}
+
+func Test_pasteInNetworking(t *testing.T) {
+	cases := []struct {
+		name       string
+		networking *hivev1.Networking
+		expected   installertypes.Networking
+	}{
+		{
+			name:       "service network only",
+			networking: &hivev1.Networking{ServiceNetwork: []string{"172.31.0.0/16"}},
+			expected: installertypes.Networking{
+				NetworkType:           "OpenShiftSDN",
+				DeprecatedMachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16"),
+				ClusterNetwork: []installertypes.ClusterNetworkEntry{
+					{CIDR: *ipnet.MustParseCIDR("10.128.0.0/14"), HostPrefix: 23},
+				},
+				ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.31.0.0/16")},
+			},
+		},
+		{
+			name: "dual-stack",
+			networking: &hivev1.Networking{
+				NetworkType:    "OVNKubernetes",
+				MachineNetwork: []string{"10.0.0.0/16", "fd00::/48"},
+				ClusterNetwork: []hivev1.ClusterNetworkEntry{
+					{CIDR: "10.128.0.0/14", HostPrefix: 23},
+					{CIDR: "fd01::/48", HostPrefix: 64},
+				},
+				ServiceNetwork: []string{"172.30.0.0/16", "fd02::/112"},
+			},
+			expected: installertypes.Networking{
+				NetworkType: "OVNKubernetes",
+				MachineNetwork: []installertypes.MachineNetworkEntry{
+					{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")},
+					{CIDR: *ipnet.MustParseCIDR("fd00::/48")},
+				},
+				ClusterNetwork: []installertypes.ClusterNetworkEntry{
+					{CIDR: *ipnet.MustParseCIDR("10.128.0.0/14"), HostPrefix: 23},
+					{CIDR: *ipnet.MustParseCIDR("fd01::/48"), HostPrefix: 64},
+				},
+				ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16"), *ipnet.MustParseCIDR("fd02::/112")},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			actual, err := pasteInNetworking(icData, tc.networking)
+			require.NoError(t, err, "unexpected error pasting in networking")
+			ic := &installertypes.InstallConfig{}
+			require.NoError(t, yaml.Unmarshal(actual, ic), "unexpected error unmarshaling InstallConfig")
+			if assert.NotNil(t, ic.Networking, "expected networking") {
+				assert.Equal(t, tc.expected, *ic.Networking, "unexpected networking")
+			}
+			assert.Equal(t, "hive-cluster", ic.ObjectMeta.Name, "expected the rest of the InstallConfig to be kept")
+		})
+	}
+}
+
 func Test_pasteInPullSecret(t *testing.T) {
 	for _, inputFile := range []string{
 		"install-config.yaml",
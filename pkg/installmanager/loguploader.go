@@ -0,0 +1,137 @@
+package installmanager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// logUploader uploads provision logs to object storage.
+type logUploader interface {
+	// Upload stores the body in the object with the given key and returns the URL of the object.
+	Upload(key string, body io.ReadSeeker) (string, error)
+}
+
+// newLogUploader returns the log uploader configured through the environment of the install pod, or nil when
+// uploading of logs is not configured.
+func newLogUploader(m *InstallManager) (logUploader, error) {
+	provider := os.Getenv(constants.InstallLogsUploadProviderEnvVar)
+	if provider == "" {
+		return nil, nil
+	}
+
+	secretName := os.Getenv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if secretName == "" {
+		return nil, errors.New("no credentials secret configured for uploading logs")
+	}
+	secret := &corev1.Secret{}
+	if err := m.DynamicClient.Get(context.Background(), types.NamespacedName{Namespace: m.Namespace, Name: secretName}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get credentials secret for uploading logs")
+	}
+
+	switch provider {
+	case constants.InstallLogsUploadProviderAWS:
+		return newS3LogUploader(
+			secret,
+			os.Getenv(constants.InstallLogsAWSRegionEnvVar),
+			os.Getenv(constants.InstallLogsAWSServiceEndpointEnvVar),
+			os.Getenv(constants.InstallLogsAWSS3BucketEnvVar),
+		)
+	case constants.InstallLogsUploadProviderGCP:
+		return newGCSLogUploader(secret, os.Getenv(constants.InstallLogsGCPBucketEnvVar))
+	case constants.InstallLogsUploadProviderAzure:
+		return newAzureLogUploader(
+			secret,
+			os.Getenv(constants.InstallLogsAzureStorageAccountEnvVar),
+			os.Getenv(constants.InstallLogsAzureContainerEnvVar),
+		)
+	default:
+		return nil, errors.Errorf("unsupported provider for uploading logs: %s", provider)
+	}
+}
+
+// uploadLogs uploads the full installer log, and the log bundles gathered from a failed install, to object storage
+// when it is configured, and records the URLs of the uploaded objects in the status of the ClusterProvision.
+// Failures are logged but are not fatal, as the logs are only used for debugging.
+func (m *InstallManager) uploadLogs(provision *hivev1.ClusterProvision, scrubInstallLog bool) {
+	uploader, err := m.newLogUploader(m)
+	if err != nil {
+		m.log.WithError(err).Error("error configuring log upload")
+		return
+	}
+	if uploader == nil {
+		m.log.Debug("log upload is not configured")
+		return
+	}
+
+	keyPrefix := path.Join(provision.Namespace, provision.Name)
+	logURLs := map[string]string{}
+
+	installLog, err := ioutil.ReadFile(filepath.Join(m.WorkDir, installerFullLogFile))
+	if err != nil {
+		m.log.WithError(err).Warn("error reading installer log for upload")
+	} else {
+		if scrubInstallLog {
+			installLog = []byte(cleanupLogOutput(string(installLog)))
+		}
+		url, err := uploader.Upload(path.Join(keyPrefix, installerFullLogFile), bytes.NewReader(installLog))
+		if err != nil {
+			m.log.WithError(err).Warn("error uploading installer log")
+		} else {
+			logURLs[installerFullLogFile] = url
+		}
+	}
+
+	for _, bundle := range m.logBundles {
+		name := filepath.Base(bundle)
+		url, err := uploadFile(uploader, path.Join(keyPrefix, name), bundle)
+		if err != nil {
+			m.log.WithError(err).WithField("file", bundle).Warn("error uploading log bundle")
+			continue
+		}
+		logURLs[name] = url
+	}
+
+	if len(logURLs) == 0 {
+		return
+	}
+	for name, url := range logURLs {
+		m.log.WithField("log", name).WithField("url", url).Info("uploaded log")
+	}
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(provision); err != nil {
+			return err
+		}
+		if provision.Status.LogURLs == nil {
+			provision.Status.LogURLs = map[string]string{}
+		}
+		for name, url := range logURLs {
+			provision.Status.LogURLs[name] = url
+		}
+		return m.DynamicClient.Status().Update(context.Background(), provision)
+	}); err != nil {
+		m.log.WithError(err).Warn("error recording uploaded log URLs on cluster provision")
+	}
+}
+
+func uploadFile(uploader logUploader, key, filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return uploader.Upload(key, f)
+}
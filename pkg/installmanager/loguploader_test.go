@@ -0,0 +1,248 @@
+package installmanager
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/apis"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+type fakeLogUploader struct {
+	uploads map[string]string
+	err     error
+}
+
+func (u *fakeLogUploader) Upload(key string, body io.ReadSeeker) (string, error) {
+	if u.err != nil {
+		return "", u.err
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	u.uploads[key] = string(content)
+	return "https://logs.example.com/" + key, nil
+}
+
+func TestUploadLogs(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	tests := []struct {
+		name            string
+		notConfigured   bool
+		uploadErr       error
+		scrubInstallLog bool
+		logBundles      []string
+		expectedUploads map[string]string
+		expectedLogURLs map[string]string
+		existingLogURLs map[string]string
+	}{
+		{
+			name:          "not configured",
+			notConfigured: true,
+		},
+		{
+			name: "installer log",
+			expectedUploads: map[string]string{
+				"test-namespace/test-provision/.openshift_install.log": "level=info\npassword: secret\n",
+			},
+			expectedLogURLs: map[string]string{
+				".openshift_install.log": "https://logs.example.com/test-namespace/test-provision/.openshift_install.log",
+			},
+		},
+		{
+			name:            "scrubbed installer log",
+			scrubInstallLog: true,
+			expectedUploads: map[string]string{
+				"test-namespace/test-provision/.openshift_install.log": "level=info\nREDACTED LINE OF OUTPUT\n",
+			},
+			expectedLogURLs: map[string]string{
+				".openshift_install.log": "https://logs.example.com/test-namespace/test-provision/.openshift_install.log",
+			},
+		},
+		{
+			name:            "log bundles",
+			scrubInstallLog: true,
+			logBundles:      []string{"log-bundle-1.tar.gz"},
+			existingLogURLs: map[string]string{
+				"other.log": "https://logs.example.com/other.log",
+			},
+			expectedUploads: map[string]string{
+				"test-namespace/test-provision/.openshift_install.log": "level=info\nREDACTED LINE OF OUTPUT\n",
+				"test-namespace/test-provision/log-bundle-1.tar.gz":    "bundle log-bundle-1.tar.gz",
+			},
+			expectedLogURLs: map[string]string{
+				"other.log":              "https://logs.example.com/other.log",
+				".openshift_install.log": "https://logs.example.com/test-namespace/test-provision/.openshift_install.log",
+				"log-bundle-1.tar.gz":    "https://logs.example.com/test-namespace/test-provision/log-bundle-1.tar.gz",
+			},
+		},
+		{
+			name:      "upload error",
+			uploadErr: fmt.Errorf("upload failed"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir, err := ioutil.TempDir("", "uploadlogstest")
+			require.NoError(t, err)
+			defer os.RemoveAll(tempDir)
+
+			require.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, installerFullLogFile), []byte("level=info\npassword: secret\n"), 0644))
+			var logBundles []string
+			for _, name := range test.logBundles {
+				bundle := filepath.Join(tempDir, name)
+				require.NoError(t, ioutil.WriteFile(bundle, []byte("bundle "+name), 0644))
+				logBundles = append(logBundles, bundle)
+			}
+
+			provision := testClusterProvision()
+			provision.Status.LogURLs = test.existingLogURLs
+			fakeClient := fake.NewFakeClient(provision)
+
+			uploader := &fakeLogUploader{uploads: map[string]string{}, err: test.uploadErr}
+			im := InstallManager{
+				log:                  log.WithField("test", test.name),
+				WorkDir:              tempDir,
+				ClusterProvisionName: testProvisionName,
+				Namespace:            testNamespace,
+				DynamicClient:        fakeClient,
+				logBundles:           logBundles,
+				newLogUploader: func(*InstallManager) (logUploader, error) {
+					if test.notConfigured {
+						return nil, nil
+					}
+					return uploader, nil
+				},
+			}
+
+			im.uploadLogs(testClusterProvision(), test.scrubInstallLog)
+
+			if test.expectedUploads == nil {
+				test.expectedUploads = map[string]string{}
+			}
+			assert.Equal(t, test.expectedUploads, uploader.uploads, "unexpected uploads")
+
+			provision = &hivev1.ClusterProvision{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName}, provision))
+			if test.expectedLogURLs == nil {
+				test.expectedLogURLs = test.existingLogURLs
+			}
+			assert.Equal(t, test.expectedLogURLs, provision.Status.LogURLs, "unexpected log URLs")
+		})
+	}
+}
+
+func TestAzureLogUploader(t *testing.T) {
+	accountKey := []byte("test-account-key")
+	var received *http.Request
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "azure-logs"},
+		Data: map[string][]byte{
+			constants.AzureStorageAccountKeySecretKey: []byte(base64.StdEncoding.EncodeToString(accountKey)),
+		},
+	}
+	uploader, err := newAzureLogUploader(secret, "testaccount", "logs")
+	require.NoError(t, err, "unexpected error creating uploader")
+	uploader.endpoint = server.URL
+
+	url, err := uploader.Upload("test-namespace/test-provision/.openshift_install.log", strings.NewReader("install log"))
+	require.NoError(t, err, "unexpected error uploading")
+
+	assert.Equal(t, server.URL+"/logs/test-namespace/test-provision/.openshift_install.log", url, "unexpected URL")
+	require.NotNil(t, received, "no request received")
+	assert.Equal(t, http.MethodPut, received.Method, "unexpected method")
+	assert.Equal(t, "install log", receivedBody, "unexpected body")
+	assert.Equal(t, "BlockBlob", received.Header.Get("x-ms-blob-type"), "unexpected blob type")
+	assert.Equal(t, azureStorageAPIVersion, received.Header.Get("x-ms-version"), "unexpected API version")
+
+	stringToSign := "PUT\n\n\n11\n\n\n\n\n\n\n\n\n" +
+		"x-ms-blob-type:BlockBlob\n" +
+		"x-ms-date:" + received.Header.Get("x-ms-date") + "\n" +
+		"x-ms-version:" + azureStorageAPIVersion + "\n" +
+		"/testaccount/logs/test-namespace/test-provision/.openshift_install.log"
+	mac := hmac.New(sha256.New, accountKey)
+	mac.Write([]byte(stringToSign))
+	assert.Equal(t, "SharedKey testaccount:"+base64.StdEncoding.EncodeToString(mac.Sum(nil)), received.Header.Get("Authorization"), "unexpected authorization")
+}
+
+func TestS3Credentials(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              map[string][]byte
+		expectedKeyID     string
+		expectedSecretKey string
+		expectError       bool
+	}{
+		{
+			name: "credentials file",
+			data: map[string][]byte{
+				"cloud": []byte("[default]\naws_access_key_id = minio\naws_secret_access_key = minio123\n"),
+			},
+			expectedKeyID:     "minio",
+			expectedSecretKey: "minio123",
+		},
+		{
+			name: "access keys",
+			data: map[string][]byte{
+				constants.AWSAccessKeyIDSecretKey:     []byte("key-id"),
+				constants.AWSSecretAccessKeySecretKey: []byte("secret-key"),
+			},
+			expectedKeyID:     "key-id",
+			expectedSecretKey: "secret-key",
+		},
+		{
+			name:        "missing credentials",
+			data:        map[string][]byte{},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			creds, err := s3Credentials(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-logs"},
+				Data:       test.data,
+			})
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			value, err := creds.Get()
+			require.NoError(t, err, "unexpected error getting credentials")
+			assert.Equal(t, test.expectedKeyID, value.AccessKeyID, "unexpected access key ID")
+			assert.Equal(t, test.expectedSecretKey, value.SecretAccessKey, "unexpected secret access key")
+		})
+	}
+}
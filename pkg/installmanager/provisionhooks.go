@@ -0,0 +1,246 @@
+package installmanager
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultProvisionHookTimeout = 30 * time.Second
+	// maxProvisionHookResponseSize limits how much of a response from a provision hook is read.
+	maxProvisionHookResponseSize = 1024 * 1024
+	// provisionHookDeniedMessage starts the message recorded in the install log of a provision denied by a
+	// provision hook. The install-log-regexes ConfigMap matches it to set the reason the provision failed.
+	provisionHookDeniedMessage = "provision denied by provision hook"
+)
+
+// ProvisionHookReview is sent to provision hooks with a request, and returned by them with a response.
+type ProvisionHookReview struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Request describes the provision for which the hook is called.
+	// +optional
+	Request *ProvisionHookRequest `json:"request,omitempty"`
+
+	// Response is the decision of the hook.
+	// +optional
+	Response *ProvisionHookResponse `json:"response,omitempty"`
+}
+
+// ProvisionHookRequest describes the provision for which a provision hook is called.
+type ProvisionHookRequest struct {
+	// UID identifies the call. The response must have the same UID.
+	UID types.UID `json:"uid"`
+
+	// Stage is the point in provisioning at which the hook is called.
+	Stage hivev1.ProvisionHookStage `json:"stage"`
+
+	// ClusterDeployment is the ClusterDeployment being provisioned.
+	ClusterDeployment *hivev1.ClusterDeployment `json:"clusterDeployment"`
+
+	// ClusterProvisionName is the name of the ClusterProvision of the provision attempt.
+	ClusterProvisionName string `json:"clusterProvisionName"`
+
+	// DryRun is true if the provision stops once the install-config and manifests have been rendered. Hooks should
+	// not make changes outside of the install-config for a dry run.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// InstallConfig is the generated install-config, without its pull secret. Only set for PreProvision hooks.
+	// +optional
+	InstallConfig string `json:"installConfig,omitempty"`
+
+	// InfraID is the infrastructure ID of the installed cluster. Only set for PostProvision hooks.
+	// +optional
+	InfraID string `json:"infraID,omitempty"`
+
+	// ClusterID is the cluster ID of the installed cluster. Only set for PostProvision hooks.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+}
+
+// ProvisionHookResponse is the decision of a provision hook.
+type ProvisionHookResponse struct {
+	// UID is the UID of the request.
+	UID types.UID `json:"uid"`
+
+	// Allowed is true if the provision may continue.
+	Allowed bool `json:"allowed"`
+
+	// Reason explains why the provision was denied.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// InstallConfig replaces the install-config of the provision when set. Only used for PreProvision hooks.
+	// +optional
+	InstallConfig string `json:"installConfig,omitempty"`
+}
+
+// provisionHookDeniedError is returned when a provision hook denies a provision.
+type provisionHookDeniedError struct {
+	hook   string
+	reason string
+}
+
+func (e *provisionHookDeniedError) Error() string {
+	if e.reason == "" {
+		return fmt.Sprintf("%s %q", provisionHookDeniedMessage, e.hook)
+	}
+	return fmt.Sprintf("%s %q: %s", provisionHookDeniedMessage, e.hook, e.reason)
+}
+
+// getProvisionHooks returns the provision hooks from the environment, if any.
+func getProvisionHooks() ([]hivev1.ProvisionHook, error) {
+	value, ok := os.LookupEnv(constants.ProvisionHooksEnvVar)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var hooks []hivev1.ProvisionHook
+	if err := json.Unmarshal([]byte(value), &hooks); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", constants.ProvisionHooksEnvVar)
+	}
+	return hooks, nil
+}
+
+// runPreProvisionHooks calls the PreProvision hooks in order with the install-config, which must not yet contain
+// the pull secret. It returns the install-config as replaced by the hooks. A provisionHookDeniedError is returned
+// if a hook denies the provision.
+func (m *InstallManager) runPreProvisionHooks(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, icData []byte) ([]byte, error) {
+	for i := range m.provisionHooks {
+		hook := &m.provisionHooks[i]
+		if hook.Stage != hivev1.ProvisionHookStagePreProvision {
+			continue
+		}
+		hookLog := m.log.WithField("provisionHook", hook.Name)
+		hookLog.Info("calling pre-provision hook")
+		response, err := callProvisionHook(hook, &ProvisionHookRequest{
+			UID:                  uuid.NewUUID(),
+			Stage:                hook.Stage,
+			ClusterDeployment:    cd,
+			ClusterProvisionName: provision.Name,
+			DryRun:               provision.Spec.DryRun,
+			InstallConfig:        string(icData),
+		})
+		if err == nil && response.Allowed && response.InstallConfig != "" {
+			if yamlErr := yaml.Unmarshal([]byte(response.InstallConfig), &map[string]interface{}{}); yamlErr != nil {
+				err = errors.Wrap(yamlErr, "could not parse returned install-config")
+			}
+		}
+		if err != nil {
+			if hook.FailurePolicy == hivev1.ProvisionHookFailurePolicyIgnore {
+				hookLog.WithError(err).Warn("error calling pre-provision hook, ignoring")
+				continue
+			}
+			return nil, errors.Wrapf(err, "error calling provision hook %q", hook.Name)
+		}
+		if !response.Allowed {
+			hookLog.WithField("reason", response.Reason).Info("provision denied by pre-provision hook")
+			return nil, &provisionHookDeniedError{hook: hook.Name, reason: response.Reason}
+		}
+		if response.InstallConfig != "" {
+			hookLog.Info("install-config replaced by pre-provision hook")
+			icData = []byte(response.InstallConfig)
+		}
+	}
+	return icData, nil
+}
+
+// runPostProvisionHooks calls the PostProvision hooks in order. Errors and denials are only logged, as the cluster
+// has already been installed.
+func (m *InstallManager) runPostProvisionHooks(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, metadata *installertypes.ClusterMetadata) {
+	for i := range m.provisionHooks {
+		hook := &m.provisionHooks[i]
+		if hook.Stage != hivev1.ProvisionHookStagePostProvision {
+			continue
+		}
+		hookLog := m.log.WithField("provisionHook", hook.Name)
+		hookLog.Info("calling post-provision hook")
+		response, err := callProvisionHook(hook, &ProvisionHookRequest{
+			UID:                  uuid.NewUUID(),
+			Stage:                hook.Stage,
+			ClusterDeployment:    cd,
+			ClusterProvisionName: provision.Name,
+			InfraID:              metadata.InfraID,
+			ClusterID:            metadata.ClusterID,
+		})
+		switch {
+		case err != nil:
+			hookLog.WithError(err).Warn("error calling post-provision hook, ignoring")
+		case !response.Allowed:
+			hookLog.WithField("reason", response.Reason).Warn("post-provision hook did not allow the provision, ignoring")
+		}
+	}
+}
+
+// callProvisionHook sends the request to the provision hook and returns its response.
+func callProvisionHook(hook *hivev1.ProvisionHook, request *ProvisionHookRequest) (*ProvisionHookResponse, error) {
+	if hook.URL == "" {
+		return nil, errors.New("no URL configured")
+	}
+	timeout := defaultProvisionHookTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(hook.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(hook.CABundle) {
+			return nil, errors.New("no certificates found in the CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	client := &http.Client{Transport: transport, Timeout: timeout}
+
+	body, err := json.Marshal(&ProvisionHookReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+			Kind:       "ProvisionHookReview",
+		},
+		Request: request,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode provision hook review")
+	}
+	resp, err := client.Post(hook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxProvisionHookResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response: %s", resp.Status)
+	}
+	review := &ProvisionHookReview{}
+	if err := json.Unmarshal(respBody, review); err != nil {
+		return nil, errors.Wrap(err, "could not decode provision hook review")
+	}
+	if review.Response == nil {
+		return nil, errors.New("provision hook review has no response")
+	}
+	if review.Response.UID != request.UID {
+		return nil, errors.Errorf("provision hook review response UID %q does not match request UID %q", review.Response.UID, request.UID)
+	}
+	return review.Response, nil
+}
@@ -0,0 +1,190 @@
+package installmanager
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const testInstallConfig = "apiVersion: v1\nbaseDomain: example.com\n"
+
+func TestRunPreProvisionHooks(t *testing.T) {
+	allow := func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+		return &ProvisionHookResponse{UID: req.UID, Allowed: true}, http.StatusOK
+	}
+	cases := []struct {
+		name                  string
+		stage                 hivev1.ProvisionHookStage
+		failurePolicy         hivev1.ProvisionHookFailurePolicyType
+		respond               func(req *ProvisionHookRequest) (*ProvisionHookResponse, int)
+		expectCalled          bool
+		expectedInstallConfig string
+		expectDenied          bool
+		expectErr             bool
+	}{
+		{
+			name:                  "allowed",
+			respond:               allow,
+			expectCalled:          true,
+			expectedInstallConfig: testInstallConfig,
+		},
+		{
+			name: "install-config replaced",
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return &ProvisionHookResponse{
+					UID:           req.UID,
+					Allowed:       true,
+					InstallConfig: req.InstallConfig + "metadata:\n  name: replaced\n",
+				}, http.StatusOK
+			},
+			expectCalled:          true,
+			expectedInstallConfig: testInstallConfig + "metadata:\n  name: replaced\n",
+		},
+		{
+			name: "denied",
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return &ProvisionHookResponse{UID: req.UID, Reason: "no addresses available"}, http.StatusOK
+			},
+			expectCalled: true,
+			expectDenied: true,
+		},
+		{
+			name: "invalid install-config returned",
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return &ProvisionHookResponse{UID: req.UID, Allowed: true, InstallConfig: "{"}, http.StatusOK
+			},
+			expectCalled: true,
+			expectErr:    true,
+		},
+		{
+			name: "mismatched UID",
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return &ProvisionHookResponse{UID: "other", Allowed: true}, http.StatusOK
+			},
+			expectCalled: true,
+			expectErr:    true,
+		},
+		{
+			name: "error",
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return nil, http.StatusInternalServerError
+			},
+			expectCalled: true,
+			expectErr:    true,
+		},
+		{
+			name:          "error ignored",
+			failurePolicy: hivev1.ProvisionHookFailurePolicyIgnore,
+			respond: func(req *ProvisionHookRequest) (*ProvisionHookResponse, int) {
+				return nil, http.StatusInternalServerError
+			},
+			expectCalled:          true,
+			expectedInstallConfig: testInstallConfig,
+		},
+		{
+			name:                  "post-provision hook not called",
+			stage:                 hivev1.ProvisionHookStagePostProvision,
+			respond:               allow,
+			expectedInstallConfig: testInstallConfig,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				review := &ProvisionHookReview{}
+				if !assert.NoError(t, json.NewDecoder(r.Body).Decode(review), "could not decode request") {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if assert.NotNil(t, review.Request, "expected request") {
+					assert.Equal(t, hivev1.ProvisionHookStagePreProvision, review.Request.Stage, "unexpected stage")
+					assert.Equal(t, testDeploymentName, review.Request.ClusterDeployment.Name, "unexpected cluster deployment")
+					assert.Equal(t, testProvisionName, review.Request.ClusterProvisionName, "unexpected cluster provision")
+				}
+				response, status := tc.respond(review.Request)
+				w.WriteHeader(status)
+				if response != nil {
+					json.NewEncoder(w).Encode(&ProvisionHookReview{Response: response})
+				}
+			}))
+			defer server.Close()
+
+			stage := tc.stage
+			if stage == "" {
+				stage = hivev1.ProvisionHookStagePreProvision
+			}
+			im := &InstallManager{
+				log: log.WithField("test", tc.name),
+				provisionHooks: []hivev1.ProvisionHook{{
+					Name:          "test-hook",
+					Stage:         stage,
+					URL:           server.URL,
+					CABundle:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+					FailurePolicy: tc.failurePolicy,
+				}},
+			}
+
+			icData, err := im.runPreProvisionHooks(testClusterDeployment(), testClusterProvision(), []byte(testInstallConfig))
+			assert.Equal(t, tc.expectCalled, called, "unexpected call to hook")
+			switch {
+			case tc.expectDenied:
+				require.Error(t, err, "expected provision to be denied")
+				assert.IsType(t, &provisionHookDeniedError{}, err, "expected denied error")
+				assert.Equal(t, `provision denied by provision hook "test-hook": no addresses available`, err.Error(), "unexpected error message")
+			case tc.expectErr:
+				assert.Error(t, err, "expected error")
+			default:
+				require.NoError(t, err, "unexpected error")
+				assert.Equal(t, tc.expectedInstallConfig, string(icData), "unexpected install-config")
+			}
+		})
+	}
+}
+
+func TestRunPostProvisionHooks(t *testing.T) {
+	var requests []*ProvisionHookRequest
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := &ProvisionHookReview{}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(review), "could not decode request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, review.Request)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	im := &InstallManager{
+		log: log.WithField("test", "post-provision"),
+		provisionHooks: []hivev1.ProvisionHook{
+			{Name: "pre", Stage: hivev1.ProvisionHookStagePreProvision, URL: server.URL, CABundle: caBundle},
+			{Name: "post-1", Stage: hivev1.ProvisionHookStagePostProvision, URL: server.URL, CABundle: caBundle},
+			{Name: "post-2", Stage: hivev1.ProvisionHookStagePostProvision, URL: server.URL, CABundle: caBundle},
+		},
+	}
+	im.runPostProvisionHooks(testClusterDeployment(), testClusterProvision(), &installertypes.ClusterMetadata{
+		InfraID:   "test-cluster-fe9531",
+		ClusterID: "fe953108-f64c-4166-bb8e-20da7665ba00",
+	})
+
+	require.Len(t, requests, 2, "expected both post-provision hooks to be called despite errors")
+	for _, req := range requests {
+		assert.Equal(t, hivev1.ProvisionHookStagePostProvision, req.Stage, "unexpected stage")
+		assert.Equal(t, "test-cluster-fe9531", req.InfraID, "unexpected infra ID")
+		assert.Equal(t, "fe953108-f64c-4166-bb8e-20da7665ba00", req.ClusterID, "unexpected cluster ID")
+		assert.Empty(t, req.InstallConfig, "install-config should not be sent to post-provision hooks")
+	}
+}
@@ -0,0 +1,101 @@
+package installmanager
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// s3CredentialsSecretKey is the key in the credentials secret which holds an AWS credentials file.
+	s3CredentialsSecretKey = "cloud"
+	defaultS3Region        = "us-east-1"
+)
+
+// s3LogUploader uploads logs to AWS S3 or an S3 compatible provider.
+type s3LogUploader struct {
+	uploader *s3manager.Uploader
+	bucket   string
+}
+
+func newS3LogUploader(secret *corev1.Secret, region, serviceEndpoint, bucket string) (*s3LogUploader, error) {
+	if bucket == "" {
+		return nil, errors.New("no S3 bucket configured for uploading logs")
+	}
+	creds, err := s3Credentials(secret)
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		region = defaultS3Region
+	}
+	awsConfig := &aws.Config{
+		Region:      aws.String(region),
+		Credentials: creds,
+	}
+	if serviceEndpoint != "" {
+		// S3 compatible providers generally do not support virtual-hosted style bucket addressing.
+		awsConfig.Endpoint = aws.String(serviceEndpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS session")
+	}
+	return &s3LogUploader{
+		uploader: s3manager.NewUploader(s),
+		bucket:   bucket,
+	}, nil
+}
+
+// s3Credentials reads the AWS credentials from the secret. The secret holds either an AWS credentials file in the
+// "cloud" key, or the access key ID and secret access key in their own keys.
+func s3Credentials(secret *corev1.Secret) (*credentials.Credentials, error) {
+	credsFile, ok := secret.Data[s3CredentialsSecretKey]
+	if !ok {
+		accessKeyID, secretAccessKey := secret.Data[constants.AWSAccessKeyIDSecretKey], secret.Data[constants.AWSSecretAccessKeySecretKey]
+		if len(accessKeyID) == 0 || len(secretAccessKey) == 0 {
+			return nil, errors.Errorf("AWS credentials secret %s does not contain key %s", secret.Name, s3CredentialsSecretKey)
+		}
+		return credentials.NewStaticCredentials(string(accessKeyID), string(secretAccessKey), ""), nil
+	}
+
+	// The SDK can only read a credentials file from disk, so read the credentials out of a temporary file.
+	f, err := ioutil.TempFile("", "aws-credentials")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := f.Write(credsFile); err != nil {
+		return nil, err
+	}
+	value, err := credentials.NewSharedCredentials(f.Name(), "default").Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read AWS credentials file")
+	}
+	return credentials.NewStaticCredentialsFromCreds(value), nil
+}
+
+// Upload implements logUploader.
+func (u *s3LogUploader) Upload(key string, body io.ReadSeeker) (string, error) {
+	output, err := u.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	if err != nil {
+		return "", err
+	}
+	return output.Location, nil
+}
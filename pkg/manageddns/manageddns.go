@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -29,3 +30,18 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// MatchManagedDomain returns the managed domain entry with the most specific domain that is the given domain or one of
+// its parents, or nil if the domain is not managed.
+func MatchManagedDomain(managedDomains []hivev1.ManageDNSConfig, domain string) *hivev1.ManageDNSConfig {
+	var match *hivev1.ManageDNSConfig
+	var matchDomain string
+	for i, md := range managedDomains {
+		for _, d := range md.Domains {
+			if (domain == d || strings.HasSuffix(domain, "."+d)) && len(d) > len(matchDomain) {
+				match, matchDomain = &managedDomains[i], d
+			}
+		}
+	}
+	return match
+}
@@ -0,0 +1,111 @@
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// The archive is a gzipped tarball holding a YAML manifest for each resource. Namespaced resources are stored at
+// namespaces/<namespace>/<kind>/<name>.yaml and cluster-scoped resources at cluster/<kind>/<name>.yaml.
+
+func archivePath(obj runtime.Object) (string, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get object meta")
+	}
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	if objMeta.GetNamespace() == "" {
+		return path.Join("cluster", kind, objMeta.GetName()+".yaml"), nil
+	}
+	return path.Join("namespaces", objMeta.GetNamespace(), kind, objMeta.GetName()+".yaml"), nil
+}
+
+func decode(data []byte, scheme *runtime.Scheme) (runtime.Object, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(data, typeMeta); err != nil {
+		return nil, err
+	}
+	obj, err := scheme.New(typeMeta.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func writeArchive(w io.Writer, objs []runtime.Object) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	now := time.Now()
+	for _, obj := range objs {
+		name, err := archivePath(obj)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.Wrapf(err, "could not encode %s", name)
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return errors.Wrapf(err, "could not write header for %s", name)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return errors.Wrapf(err, "could not write %s", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "could not close archive")
+	}
+	return errors.Wrap(gzw.Close(), "could not close archive")
+}
+
+func readArchive(r io.Reader, scheme *runtime.Scheme) ([]runtime.Object, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read archive")
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	var objs []runtime.Object
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read archive")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s", header.Name)
+		}
+		obj, err := decode(data, scheme)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode %s", header.Name)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
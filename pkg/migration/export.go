@@ -0,0 +1,249 @@
+package migration
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+var (
+	// namespacedTypes are the namespaced Hive resources that are exported. Resources that only record the progress
+	// of an operation, such as ClusterProvisions and ClusterDeprovisions, are re-created by the controllers.
+	namespacedTypes = func() []runtime.Object {
+		return []runtime.Object{
+			&hivev1.ClusterDeploymentList{},
+			&hivev1.ClusterPoolList{},
+			&hivev1.ClusterClaimList{},
+			&hivev1.DNSZoneList{},
+			&hivev1.MachinePoolList{},
+			&hivev1.SyncSetList{},
+			&hivev1.SyncIdentityProviderList{},
+		}
+	}
+
+	// clusterTypes are the cluster-scoped Hive resources that are exported when exporting the whole hub.
+	clusterTypes = func() []runtime.Object {
+		return []runtime.Object{
+			&hivev1.HiveConfigList{},
+			&hivev1.ClusterImageSetList{},
+			&hivev1.SelectorSyncSetList{},
+			&hivev1.SelectorSyncIdentityProviderList{},
+		}
+	}
+)
+
+// ExportOptions are the options for exporting Hive resources.
+type ExportOptions struct {
+	// Namespaces are the namespaces to export. When empty, the Hive resources in all namespaces are exported along
+	// with the cluster-scoped Hive resources.
+	Namespaces []string
+}
+
+// Export writes an archive of the Hive resources selected by the options to w, along with the namespaces, secrets,
+// config maps and ClusterImageSets they depend on. Instance-specific metadata, finalizers and status are removed.
+// Owner references are kept so that they can be re-established by Import.
+func Export(c client.Client, scheme *runtime.Scheme, opts ExportOptions, w io.Writer, logger log.FieldLogger) error {
+	e := &exporter{
+		Client: c,
+		scheme: scheme,
+		logger: logger,
+		seen:   map[string]bool{},
+	}
+	if err := e.collect(opts); err != nil {
+		return err
+	}
+	return writeArchive(w, e.objs)
+}
+
+type exporter struct {
+	client.Client
+	scheme *runtime.Scheme
+	logger log.FieldLogger
+	objs   []runtime.Object
+	seen   map[string]bool
+}
+
+func (e *exporter) collect(opts ExportOptions) error {
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		// An empty namespace lists across all namespaces.
+		namespaces = []string{""}
+		for _, list := range clusterTypes() {
+			if err := e.addList(list); err != nil {
+				return err
+			}
+		}
+	}
+	for _, namespace := range namespaces {
+		for _, list := range namespacedTypes() {
+			if err := e.addList(list, client.InNamespace(namespace)); err != nil {
+				return err
+			}
+		}
+	}
+
+	refs := newReferences()
+	imageSets := map[string]bool{}
+	for _, obj := range e.objs {
+		refs.add(obj)
+		for _, name := range imageSetReferences(obj) {
+			imageSets[name] = true
+		}
+		if cd, ok := obj.(*hivev1.ClusterDeployment); ok && !cd.Spec.Installed {
+			e.logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).
+				Warn("cluster is not installed; importing it will start a new install")
+		}
+	}
+	for _, name := range sortedKeys(imageSets) {
+		if err := e.addObject(&hivev1.ClusterImageSet{}, types.NamespacedName{Name: name}); err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedNames(refs.secrets) {
+		if err := e.addObject(&corev1.Secret{}, key); err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedNames(refs.configMaps) {
+		if err := e.addObject(&corev1.ConfigMap{}, key); err != nil {
+			return err
+		}
+	}
+
+	namespaceNames := map[string]bool{}
+	for _, obj := range e.objs {
+		objMeta, _ := meta.Accessor(obj)
+		if ns := objMeta.GetNamespace(); ns != "" {
+			namespaceNames[ns] = true
+		}
+	}
+	for _, name := range sortedKeys(namespaceNames) {
+		if err := e.addObject(&corev1.Namespace{}, types.NamespacedName{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	for i, obj := range e.objs {
+		cleaned, err := e.prepareForExport(obj)
+		if err != nil {
+			return err
+		}
+		e.objs[i] = cleaned
+	}
+	return nil
+}
+
+func (e *exporter) addList(list runtime.Object, opts ...client.ListOption) error {
+	if err := e.List(context.TODO(), list, opts...); err != nil {
+		return errors.Wrapf(err, "could not list %T", list)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return errors.Wrapf(err, "could not extract items from %T", list)
+	}
+	for _, item := range items {
+		e.add(item)
+	}
+	return nil
+}
+
+// addObject adds the object with the given key. Objects that do not exist are skipped with a warning.
+func (e *exporter) addObject(obj runtime.Object, key types.NamespacedName) error {
+	logger := e.logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", key.String())
+	if err := e.Get(context.TODO(), key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("referenced resource not found, skipping")
+			return nil
+		}
+		return errors.Wrapf(err, "could not get %T %s", obj, key)
+	}
+	if secret, ok := obj.(*corev1.Secret); ok && secret.Type == corev1.SecretTypeServiceAccountToken {
+		logger.Info("skipping service account token secret")
+		return nil
+	}
+	e.add(obj)
+	return nil
+}
+
+func (e *exporter) add(obj runtime.Object) {
+	objMeta, _ := meta.Accessor(obj)
+	key := reflect.TypeOf(obj).String() + "/" + objMeta.GetNamespace() + "/" + objMeta.GetName()
+	if e.seen[key] {
+		return
+	}
+	e.seen[key] = true
+	e.logger.WithField("type", reflect.TypeOf(obj)).
+		WithField("resource", types.NamespacedName{Namespace: objMeta.GetNamespace(), Name: objMeta.GetName()}.String()).
+		Info("exporting resource")
+	e.objs = append(e.objs, obj)
+}
+
+// prepareForExport returns a copy of the object without the fields that are specific to the cluster it was read
+// from.
+func (e *exporter) prepareForExport(obj runtime.Object) (runtime.Object, error) {
+	obj = obj.DeepCopyObject()
+	gvk, err := apiutil.GVKForObject(obj, e.scheme)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not determine kind of %T", obj)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get object meta")
+	}
+	objMeta.SetSelfLink("")
+	objMeta.SetUID("")
+	objMeta.SetResourceVersion("")
+	objMeta.SetGeneration(0)
+	objMeta.SetCreationTimestamp(metav1.Time{})
+	objMeta.SetDeletionTimestamp(nil)
+	objMeta.SetDeletionGracePeriodSeconds(nil)
+	objMeta.SetClusterName("")
+	objMeta.SetManagedFields(nil)
+	objMeta.SetFinalizers(nil)
+
+	switch t := obj.(type) {
+	case *corev1.Namespace:
+		t.Spec = corev1.NamespaceSpec{}
+		t.Status = corev1.NamespaceStatus{}
+	default:
+		if status := reflect.ValueOf(obj).Elem().FieldByName("Status"); status.IsValid() && status.CanSet() {
+			status.Set(reflect.Zero(status.Type()))
+		}
+	}
+	return obj, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNames(m map[types.NamespacedName]bool) []types.NamespacedName {
+	keys := make([]types.NamespacedName, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
@@ -0,0 +1,205 @@
+package migration
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// importOrder is the order in which resources are created. Dependencies are created before the resources that use
+// them. ClusterDeployments are created before ClusterPools so that the clusterpool controller counts the imported
+// clusters rather than creating new ones, and DNSZones are created before ClusterDeployments so that the
+// clusterdeployment controller does not create new ones.
+var importOrder = []string{
+	"Namespace",
+	"HiveConfig",
+	"ClusterImageSet",
+	"Secret",
+	"ConfigMap",
+	"SelectorSyncSet",
+	"SelectorSyncIdentityProvider",
+	"DNSZone",
+	"MachinePool",
+	"SyncSet",
+	"SyncIdentityProvider",
+	"ClusterDeployment",
+	"ClusterPool",
+	"ClusterClaim",
+}
+
+// ImportOptions are the options for importing Hive resources.
+type ImportOptions struct {
+	// NamespaceMapping maps namespaces in the archive to the namespaces to import their resources into. Namespaces
+	// that are not in the mapping keep their name.
+	NamespaceMapping map[string]string
+}
+
+// Import creates the resources in an archive written by Export. Resources that already exist are left unchanged.
+// References to other namespaces are updated to follow the namespace mapping, and owner references are updated to
+// point to the owners created by the import.
+func Import(c client.Client, scheme *runtime.Scheme, opts ImportOptions, r io.Reader, logger log.FieldLogger) error {
+	objs, err := readArchive(r, scheme)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if err := remapNamespaces(obj, opts.NamespaceMapping); err != nil {
+			return err
+		}
+	}
+	sortForImport(objs)
+
+	// Owner references are removed when creating the resources since the UIDs of the owners are not known until
+	// the owners are created. Creating a resource with an owner reference to a UID that does not exist would cause
+	// the garbage collector to delete it.
+	ownerRefs := map[int][]metav1.OwnerReference{}
+	for i, obj := range objs {
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return errors.Wrap(err, "could not get object meta")
+		}
+		logger := logger.WithField("type", reflect.TypeOf(obj)).
+			WithField("resource", types.NamespacedName{Namespace: objMeta.GetNamespace(), Name: objMeta.GetName()}.String())
+		refs := objMeta.GetOwnerReferences()
+		objMeta.SetOwnerReferences(nil)
+		switch err := c.Create(context.TODO(), obj); {
+		case apierrors.IsAlreadyExists(err):
+			logger.Warn("resource already exists, leaving it unchanged")
+		case err != nil:
+			return errors.Wrapf(err, "could not create %T %s/%s", obj, objMeta.GetNamespace(), objMeta.GetName())
+		default:
+			logger.Info("resource created")
+			if len(refs) > 0 {
+				ownerRefs[i] = refs
+			}
+		}
+	}
+
+	for i, obj := range objs {
+		refs, ok := ownerRefs[i]
+		if !ok {
+			continue
+		}
+		if err := setOwnerReferences(c, scheme, obj, refs, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setOwnerReferences sets the owner references of the object to point to the owners with the same kind and name in
+// the destination cluster. References to owners that do not exist are dropped.
+func setOwnerReferences(c client.Client, scheme *runtime.Scheme, obj runtime.Object, refs []metav1.OwnerReference, logger log.FieldLogger) error {
+	objMeta, _ := meta.Accessor(obj)
+	logger = logger.WithField("type", reflect.TypeOf(obj)).
+		WithField("resource", types.NamespacedName{Namespace: objMeta.GetNamespace(), Name: objMeta.GetName()}.String())
+
+	var newRefs []metav1.OwnerReference
+	for _, ref := range refs {
+		logger := logger.WithField("owner", ref.Kind+"/"+ref.Name)
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			logger.WithError(err).Warn("could not parse API version of owner, dropping owner reference")
+			continue
+		}
+		owner, err := scheme.New(gv.WithKind(ref.Kind))
+		if err != nil {
+			logger.WithError(err).Warn("unknown owner kind, dropping owner reference")
+			continue
+		}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: objMeta.GetNamespace(), Name: ref.Name}, owner); err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Warn("owner not found, dropping owner reference")
+				continue
+			}
+			return errors.Wrapf(err, "could not get owner %s %s", ref.Kind, ref.Name)
+		}
+		ownerMeta, _ := meta.Accessor(owner)
+		ref.UID = ownerMeta.GetUID()
+		newRefs = append(newRefs, ref)
+	}
+	if len(newRefs) == 0 {
+		return nil
+	}
+
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: objMeta.GetNamespace(), Name: objMeta.GetName()}, obj); err != nil {
+		return errors.Wrapf(err, "could not get %T %s/%s", obj, objMeta.GetNamespace(), objMeta.GetName())
+	}
+	objMeta, _ = meta.Accessor(obj)
+	objMeta.SetOwnerReferences(newRefs)
+	if err := c.Update(context.TODO(), obj); err != nil {
+		return errors.Wrapf(err, "could not set owner references of %T %s/%s", obj, objMeta.GetNamespace(), objMeta.GetName())
+	}
+	logger.Info("owner references set")
+	return nil
+}
+
+// remapNamespaces moves the object to its namespace in the mapping, along with the references it holds to other
+// namespaces.
+func remapNamespaces(obj runtime.Object, mapping map[string]string) error {
+	remap := func(namespace string) string {
+		if to, ok := mapping[namespace]; ok {
+			return to
+		}
+		return namespace
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return errors.Wrap(err, "could not get object meta")
+	}
+	if objMeta.GetNamespace() != "" {
+		objMeta.SetNamespace(remap(objMeta.GetNamespace()))
+	}
+
+	switch t := obj.(type) {
+	case *corev1.Namespace:
+		t.Name = remap(t.Name)
+	case *hivev1.ClusterDeployment:
+		if t.Spec.ClusterPoolRef != nil {
+			t.Spec.ClusterPoolRef.Namespace = remap(t.Spec.ClusterPoolRef.Namespace)
+		}
+	case *hivev1.ClusterClaim:
+		if t.Spec.Namespace != "" {
+			t.Spec.Namespace = remap(t.Spec.Namespace)
+		}
+	case *hivev1.SyncSet:
+		for i := range t.Spec.Secrets {
+			if ns := t.Spec.Secrets[i].SourceRef.Namespace; ns != "" {
+				t.Spec.Secrets[i].SourceRef.Namespace = remap(ns)
+			}
+		}
+	case *hivev1.SelectorSyncSet:
+		for i := range t.Spec.Secrets {
+			t.Spec.Secrets[i].SourceRef.Namespace = remap(t.Spec.Secrets[i].SourceRef.Namespace)
+		}
+	}
+	return nil
+}
+
+func sortForImport(objs []runtime.Object) {
+	rank := func(obj runtime.Object) int {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		for i, k := range importOrder {
+			if k == kind {
+				return i
+			}
+		}
+		return len(importOrder)
+	}
+	sort.SliceStable(objs, func(i, j int) bool { return rank(objs[i]) < rank(objs[j]) })
+}
@@ -0,0 +1,239 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/pkg/apis/hive/v1/aws"
+)
+
+const (
+	testNamespace      = "cluster-ns"
+	testOtherNamespace = "other-ns"
+	testCDName         = "mycluster"
+	testImageSetName   = "openshift-v4.6.0"
+)
+
+func TestExportImport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		exportNamespaces  []string
+		namespaceMapping  map[string]string
+		existing          []runtime.Object
+		expectedNamespace string
+		expectOther       bool
+		expectHiveConfig  bool
+	}{
+		{
+			name:              "export namespace",
+			exportNamespaces:  []string{testNamespace},
+			expectedNamespace: testNamespace,
+		},
+		{
+			name:              "export namespace with mapping",
+			exportNamespaces:  []string{testNamespace},
+			namespaceMapping:  map[string]string{testNamespace: "new-ns"},
+			expectedNamespace: "new-ns",
+		},
+		{
+			name:              "export hub",
+			expectedNamespace: testNamespace,
+			expectOther:       true,
+			expectHiveConfig:  true,
+		},
+		{
+			name:             "existing resources are left unchanged",
+			exportNamespaces: []string{testNamespace},
+			existing: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pull-secret"},
+					Data:       map[string][]byte{"existing": []byte("true")},
+				},
+			},
+			expectedNamespace: testNamespace,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := log.WithField("test", test.name)
+			srcClient := fake.NewFakeClientWithScheme(scheme, testSourceObjects()...)
+			buf := &bytes.Buffer{}
+			require.NoError(t, Export(srcClient, scheme, ExportOptions{Namespaces: test.exportNamespaces}, buf, logger),
+				"unexpected error exporting")
+
+			destClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			require.NoError(t, Import(destClient, scheme, ImportOptions{NamespaceMapping: test.namespaceMapping}, buf, logger),
+				"unexpected error importing")
+
+			ns := test.expectedNamespace
+			get := func(obj runtime.Object, namespace, name string) error {
+				return destClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj)
+			}
+
+			require.NoError(t, get(&corev1.Namespace{}, "", ns), "expected namespace")
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, get(cd, ns, testCDName), "expected ClusterDeployment")
+			assert.Empty(t, cd.Finalizers, "unexpected finalizers")
+			assert.Empty(t, cd.Status.Conditions, "unexpected status")
+			require.NoError(t, get(&hivev1.ClusterImageSet{}, "", testImageSetName), "expected ClusterImageSet")
+
+			pullSecret := &corev1.Secret{}
+			require.NoError(t, get(pullSecret, ns, "pull-secret"), "expected pull secret")
+			if len(test.existing) > 0 {
+				assert.Equal(t, "true", string(pullSecret.Data["existing"]), "expected existing secret to be unchanged")
+			}
+			for _, name := range []string{"aws-creds", "admin-kubeconfig", "manifests-secret"} {
+				assert.NoError(t, get(&corev1.Secret{}, ns, name), "expected secret %s", name)
+			}
+			assert.NoError(t, get(&corev1.ConfigMap{}, ns, "manifests"), "expected config map")
+			assert.True(t, apierrors.IsNotFound(get(&corev1.Secret{}, ns, "unrelated")), "unexpected unrelated secret")
+			assert.True(t, apierrors.IsNotFound(get(&corev1.Secret{}, ns, "token")), "unexpected service account token secret")
+
+			dnsZone := &hivev1.DNSZone{}
+			require.NoError(t, get(dnsZone, ns, testCDName+"-zone"), "expected DNSZone")
+			if assert.Len(t, dnsZone.OwnerReferences, 1, "expected owner reference") {
+				assert.Equal(t, testCDName, dnsZone.OwnerReferences[0].Name, "unexpected owner")
+				assert.Equal(t, cd.UID, dnsZone.OwnerReferences[0].UID, "expected owner reference to the imported ClusterDeployment")
+			}
+
+			syncSet := &hivev1.SyncSet{}
+			require.NoError(t, get(syncSet, ns, "syncset"), "expected SyncSet")
+			assert.Equal(t, ns, syncSet.Spec.Secrets[0].SourceRef.Namespace, "unexpected secret mapping namespace")
+
+			otherErr := get(&hivev1.ClusterDeployment{}, testOtherNamespace, "other")
+			if test.expectOther {
+				assert.NoError(t, otherErr, "expected ClusterDeployment in other namespace")
+			} else {
+				assert.True(t, apierrors.IsNotFound(otherErr), "unexpected ClusterDeployment in other namespace")
+			}
+			hiveConfigErr := get(&hivev1.HiveConfig{}, "", "hive")
+			globalPullSecretErr := get(&corev1.Secret{}, "hive", "global-pull-secret")
+			if test.expectHiveConfig {
+				assert.NoError(t, hiveConfigErr, "expected HiveConfig")
+				assert.NoError(t, globalPullSecretErr, "expected global pull secret")
+			} else {
+				assert.True(t, apierrors.IsNotFound(hiveConfigErr), "unexpected HiveConfig")
+				assert.True(t, apierrors.IsNotFound(globalPullSecretErr), "unexpected global pull secret")
+			}
+		})
+	}
+}
+
+func testSourceObjects() []runtime.Object {
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string][]byte{"key": []byte(name)},
+		}
+	}
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  testNamespace,
+			Name:       testCDName,
+			UID:        types.UID("source-cd-uid"),
+			Finalizers: []string{hivev1.FinalizerDeprovision},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testCDName,
+			BaseDomain:  "example.com",
+			Installed:   true,
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					Region:               "us-east-1",
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"},
+				},
+			},
+			PullSecretRef: &corev1.LocalObjectReference{Name: "pull-secret"},
+			Provisioning: &hivev1.Provisioning{
+				InstallConfigSecretRef: corev1.LocalObjectReference{Name: "missing-install-config"},
+				ImageSetRef:            &hivev1.ClusterImageSetReference{Name: testImageSetName},
+				ManifestsConfigMapRef:  &corev1.LocalObjectReference{Name: "manifests"},
+				Manifests: []hivev1.InstallManifestsSource{
+					{SecretRef: &corev1.LocalObjectReference{Name: "manifests-secret"}},
+				},
+			},
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID:                  "mycluster-abcde",
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "admin-kubeconfig"},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{Type: hivev1.UnreachableCondition, Status: corev1.ConditionFalse}},
+		},
+	}
+	token := secret(testNamespace, "token")
+	token.Type = corev1.SecretTypeServiceAccountToken
+	return []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testOtherNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "hive"}},
+		cd,
+		&hivev1.DNSZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testCDName + "-zone",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: hivev1.SchemeGroupVersion.String(),
+					Kind:       "ClusterDeployment",
+					Name:       testCDName,
+					UID:        cd.UID,
+				}},
+			},
+			Spec: hivev1.DNSZoneSpec{Zone: "mycluster.example.com"},
+		},
+		&hivev1.SyncSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "syncset"},
+			Spec: hivev1.SyncSetSpec{
+				SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+					Secrets: []hivev1.SecretMapping{{
+						SourceRef: hivev1.SecretReference{Namespace: testNamespace, Name: "token"},
+						TargetRef: hivev1.SecretReference{Namespace: "kube-system", Name: "token"},
+					}},
+				},
+				ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: testCDName}},
+			},
+		},
+		&hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: testImageSetName},
+			Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: "quay.io/openshift-release-dev/ocp-release:4.6.0-x86_64"},
+		},
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testOtherNamespace, Name: "other"},
+			Spec:       hivev1.ClusterDeploymentSpec{ClusterName: "other", BaseDomain: "example.com", Installed: true},
+		},
+		&hivev1.HiveConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "hive"},
+			Spec: hivev1.HiveConfigSpec{
+				GlobalPullSecretRef: &corev1.LocalObjectReference{Name: "global-pull-secret"},
+			},
+		},
+		secret("hive", "global-pull-secret"),
+		secret(testNamespace, "pull-secret"),
+		secret(testNamespace, "aws-creds"),
+		secret(testNamespace, "admin-kubeconfig"),
+		secret(testNamespace, "manifests-secret"),
+		secret(testNamespace, "unrelated"),
+		token,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+			Data:       map[string]string{"manifest.yaml": "{}"},
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+var localObjectReferenceType = reflect.TypeOf(corev1.LocalObjectReference{})
+
+// references holds the secrets and config maps that Hive resources depend on.
+type references struct {
+	secrets    map[types.NamespacedName]bool
+	configMaps map[types.NamespacedName]bool
+}
+
+func newReferences() *references {
+	return &references{
+		secrets:    map[types.NamespacedName]bool{},
+		configMaps: map[types.NamespacedName]bool{},
+	}
+}
+
+// add adds the secrets and config maps referenced by the object.
+func (r *references) add(obj runtime.Object) {
+	switch t := obj.(type) {
+	case *hivev1.HiveConfig:
+		namespace := t.Spec.TargetNamespace
+		if namespace == "" {
+			namespace = constants.DefaultHiveNamespace
+		}
+		r.addLocal(namespace, "", reflect.ValueOf(t.Spec))
+	case *hivev1.SyncSet:
+		for _, mapping := range t.Spec.Secrets {
+			namespace := mapping.SourceRef.Namespace
+			if namespace == "" {
+				namespace = t.Namespace
+			}
+			r.secrets[types.NamespacedName{Namespace: namespace, Name: mapping.SourceRef.Name}] = true
+		}
+	case *hivev1.SelectorSyncSet:
+		for _, mapping := range t.Spec.Secrets {
+			r.secrets[types.NamespacedName{Namespace: mapping.SourceRef.Namespace, Name: mapping.SourceRef.Name}] = true
+		}
+	case *hivev1.ClusterDeployment:
+		r.addLocal(t.Namespace, "", reflect.ValueOf(t.Spec))
+	case *hivev1.ClusterPool:
+		r.addLocal(t.Namespace, "", reflect.ValueOf(t.Spec))
+	case *hivev1.DNSZone:
+		r.addLocal(t.Namespace, "", reflect.ValueOf(t.Spec))
+	}
+}
+
+// addLocal walks the value looking for local object references. A reference is to a secret if the name of the field
+// holding it ends in SecretRef, and to a config map if the name ends in ConfigMapRef.
+func (r *references) addLocal(namespace, fieldName string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			r.addLocal(namespace, fieldName, v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.addLocal(namespace, fieldName, v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == localObjectReferenceType {
+			name := v.Interface().(corev1.LocalObjectReference).Name
+			if name == "" {
+				return
+			}
+			key := types.NamespacedName{Namespace: namespace, Name: name}
+			switch {
+			case strings.HasSuffix(fieldName, "SecretRef"):
+				r.secrets[key] = true
+			case strings.HasSuffix(fieldName, "ConfigMapRef"):
+				r.configMaps[key] = true
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				// unexported
+				continue
+			}
+			r.addLocal(namespace, field.Name, v.Field(i))
+		}
+	}
+}
+
+// imageSetReferences returns the names of the ClusterImageSets referenced by the object.
+func imageSetReferences(obj runtime.Object) []string {
+	switch t := obj.(type) {
+	case *hivev1.ClusterDeployment:
+		if t.Spec.Provisioning != nil && t.Spec.Provisioning.ImageSetRef != nil {
+			return []string{t.Spec.Provisioning.ImageSetRef.Name}
+		}
+	case *hivev1.ClusterPool:
+		return []string{t.Spec.ImageSetRef.Name}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package operator
+
+import (
+	"github.com/openshift/hive/pkg/operator/operatorcondition"
+)
+
+func init() {
+	// AddToOperatorFuncs is a list of functions to create controllers and add them to an operator manager.
+	AddToOperatorFuncs = append(AddToOperatorFuncs, operatorcondition.Add)
+}
@@ -1,13 +1,17 @@
 // Code generated for package assets by go-bindata DO NOT EDIT. (@generated)
 // sources:
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusterclaim-webhook.yaml
+// config/hiveadmission/clusterdeployment-mutating-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
+// config/hiveadmission/clusterpool-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
 // config/hiveadmission/deployment.yaml
 // config/hiveadmission/dnszones-webhook.yaml
 // config/hiveadmission/hiveadmission_rbac_role.yaml
 // config/hiveadmission/hiveadmission_rbac_role_binding.yaml
+// config/hiveadmission/machinepool-mutating-webhook.yaml
 // config/hiveadmission/machinepool-webhook.yaml
 // config/hiveadmission/selectorsyncset-webhook.yaml
 // config/hiveadmission/service-account.yaml
@@ -120,6 +124,87 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterclaimWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterclaimvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+`)
+
+func configHiveadmissionClusterclaimWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterdeploymentMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterdeploymentmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterdeploymentmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterdeploymentmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeployments
+  failurePolicy: Fail
+`)
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterdeploymentMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterdeployment-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -203,6 +288,48 @@ func configHiveadmissionClusterimagesetWebhookYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterpoolWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterpoolvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterpoolvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterpoolvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    - DELETE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterpools
+  failurePolicy: Fail
+`)
+
+func configHiveadmissionClusterpoolWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterpoolWebhookYaml, nil
+}
+
+func configHiveadmissionClusterpoolWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterpoolWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterpool-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterprovisionWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -289,6 +416,11 @@ spec:
         volumeMounts:
         - mountPath: /var/serving-cert
           name: serving-cert
+        env:
+        - name: HIVE_NS
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
         readinessProbe:
           httpGet:
             path: /healthz
@@ -372,6 +504,14 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - dnszones
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - ""
   resources:
@@ -397,6 +537,15 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterpools
+  - machinepools
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - authorization.k8s.io
   resources:
@@ -450,6 +599,46 @@ func configHiveadmissionHiveadmission_rbac_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionMachinepoolMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: machinepoolmutators.admission.hive.openshift.io
+webhooks:
+- name: machinepoolmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/machinepoolmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - machinepools
+  failurePolicy: Fail
+`)
+
+func configHiveadmissionMachinepoolMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionMachinepoolMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionMachinepoolMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionMachinepoolMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/machinepool-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionMachinepoolWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -779,6 +968,14 @@ rules:
   - backups
   verbs:
   - create
+- apiGroups:
+  - extensions.hive.openshift.io
+  resources:
+  - agentclusterinstalls
+  verbs:
+  - get
+  - list
+  - watch
 `)
 
 func configControllersHive_controllers_roleYamlBytes() ([]byte, error) {
@@ -913,7 +1110,9 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - adoptclusterrequests
   - clusterdeployments
+  - clusterdeploymentcustomizations
   - clusterprovisions
   - dnszones
   - machinepools
@@ -959,6 +1158,7 @@ rules:
   resources:
   - clusterdeployments
   - clusterimagesets
+  - clusterpools
   - clusterprovisions
   - dnszones
   - machinepools
@@ -1089,7 +1289,9 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - adoptclusterrequests
   - clusterdeployments
+  - clusterdeploymentcustomizations
   - clusterprovisions
   - dnszones
   - machinepools
@@ -1225,7 +1427,9 @@ rules:
 - apiGroups:
   - hive.openshift.io
   resources:
+  - adoptclusterrequests
   - clusterdeployments
+  - clusterdeploymentcustomizations
   - clusterprovisions
   - dnszones
   - machinepools
@@ -1323,6 +1527,10 @@ metadata:
   namespace: hive
 data:
   regexes: |
+    # errorClass classifies the failure as a UserError, TransientCloudError, QuotaError,
+    # CapacityError or HiveInternalError. Failures with no errorClass are Unknown. UserError and QuotaError
+    # failures are Configuration failures for the install retry policy, all others are
+    # Infrastructure failures.
     # AWS Specific
     # https://bugzilla.redhat.com/show_bug.cgi?id=1844320
     - name: AWSUnableToFindMatchingRouteTable
@@ -1330,52 +1538,74 @@ data:
       - "Error: Unable to find matching route for Route Table"
       installFailingReason: AWSUnableToFindMatchingRouteTable
       installFailingMessage: Unable to find matching route for route table
+      errorClass: UserError
     - name: AWSNATGatewayLimitExceeded
       searchRegexStrings:
       - "NatGatewayLimitExceeded"
       installFailingReason: AWSNATGatewayLimitExceeded
       installFailingMessage: AWS NAT gateway limit exceeded
+      errorClass: QuotaError
+    - name: AWSVCPULimitExceeded
+      searchRegexStrings:
+      - "VcpuLimitExceeded"
+      installFailingReason: AWSVCPULimitExceeded
+      installFailingMessage: AWS vCPU limit exceeded
+      errorClass: QuotaError
+    - name: AWSInsufficientInstanceCapacity
+      searchRegexStrings:
+      - "InsufficientInstanceCapacity"
+      installFailingReason: AWSInsufficientInstanceCapacity
+      installFailingMessage: AWS does not have enough capacity for the instance type in the region
+      errorClass: CapacityError
     - name: DNSAlreadyExists
       searchRegexStrings:
       - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
       installFailingReason: DNSAlreadyExists
       installFailingMessage: DNS record already exists
+      errorClass: UserError
     - name: PendingVerification
       searchRegexStrings:
       - "PendingVerification: Your request for accessing resources in this region is being validated"
       installFailingReason: PendingVerification
       installFailingMessage: Account pending verification for region
+      errorClass: UserError
     - name: NoMatchingRoute53Zone
       searchRegexStrings:
       - "data.aws_route53_zone.public: no matching Route53Zone found"
       installFailingReason: NoMatchingRoute53Zone
       installFailingMessage: No matching Route53Zone found
+      errorClass: UserError
     - name: KubeAPIWaitTimeout
       searchRegexStrings:
       - "waiting for Kubernetes API: context deadline exceeded"
       installFailingReason: KubeAPIWaitTimeout
       installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+      errorClass: TransientCloudError
     - name: MonitoringOperatorStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Cluster operator monitoring is still updating"
       installFailingReason: MonitoringOperatorStillUpdating
       installFailingMessage: Timeout waiting for the monitoring operator to become ready
+      errorClass: TransientCloudError
     - name: SimulatorThrottling
       searchRegexStrings:
       - "validate AWS credentials: checking install permissions: error simulating policy: Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded while simulating policy
+      errorClass: TransientCloudError
     - name: GeneralThrottling
       searchRegexStrings:
       - "Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded
+      errorClass: TransientCloudError
     # Bare Metal
     - name: LibvirtSSHKeyPermissionDenied
       searchRegexStrings:
       - "platform.baremetal.libvirtURI: Internal error: could not connect to libvirt: virError.Code=38, Domain=7, Message=.Cannot recv data: Permission denied"
       installFailingReason: LibvirtSSHKeyPermissionDenied
       installFailingMessage: "Permission denied connecting to libvirt host, check SSH key configuration and pass phrase"
+      errorClass: UserError
     # Processing stops at the first match, so this more generic
     # message about the connection failure must always come after the
     # more specific message for LibvirtSSHKeyPermissionDenied.
@@ -1384,6 +1614,14 @@ data:
       - "could not connect to libvirt"
       installFailingReason: LibvirtConnectionFailed
       installFailingMessage: "Could not connect to libvirt host"
+      errorClass: UserError
+    # Provision hooks
+    - name: ProvisionHookDenied
+      searchRegexStrings:
+      - "provision denied by provision hook"
+      installFailingReason: ProvisionHookDenied
+      installFailingMessage: Provisioning was denied by a pre-provision hook
+      errorClass: UserError
 `)
 
 func configConfigmapsInstallLogRegexesConfigmapYamlBytes() ([]byte, error) {
@@ -1453,32 +1691,36 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
-	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
-	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
-	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
-	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
-	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
-	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
-	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
-	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
-	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
-	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
-	"config/hiveadmission/service.yaml":                         configHiveadmissionServiceYaml,
-	"config/hiveadmission/syncset-webhook.yaml":                 configHiveadmissionSyncsetWebhookYaml,
-	"config/controllers/deployment.yaml":                        configControllersDeploymentYaml,
-	"config/controllers/hive_controllers_role.yaml":             configControllersHive_controllers_roleYaml,
-	"config/controllers/hive_controllers_role_binding.yaml":     configControllersHive_controllers_role_bindingYaml,
-	"config/controllers/hive_controllers_serviceaccount.yaml":   configControllersHive_controllers_serviceaccountYaml,
-	"config/controllers/service.yaml":                           configControllersServiceYaml,
-	"config/rbac/hive_admin_role.yaml":                          configRbacHive_admin_roleYaml,
-	"config/rbac/hive_admin_role_binding.yaml":                  configRbacHive_admin_role_bindingYaml,
-	"config/rbac/hive_frontend_role.yaml":                       configRbacHive_frontend_roleYaml,
-	"config/rbac/hive_frontend_role_binding.yaml":               configRbacHive_frontend_role_bindingYaml,
-	"config/rbac/hive_frontend_serviceaccount.yaml":             configRbacHive_frontend_serviceaccountYaml,
-	"config/rbac/hive_reader_role.yaml":                         configRbacHive_reader_roleYaml,
-	"config/rbac/hive_reader_role_binding.yaml":                 configRbacHive_reader_role_bindingYaml,
-	"config/configmaps/install-log-regexes-configmap.yaml":      configConfigmapsInstallLogRegexesConfigmapYaml,
+	"config/hiveadmission/apiservice.yaml":                         configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusterclaim-webhook.yaml":               configHiveadmissionClusterclaimWebhookYaml,
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml": configHiveadmissionClusterdeploymentMutatingWebhookYaml,
+	"config/hiveadmission/clusterdeployment-webhook.yaml":          configHiveadmissionClusterdeploymentWebhookYaml,
+	"config/hiveadmission/clusterimageset-webhook.yaml":            configHiveadmissionClusterimagesetWebhookYaml,
+	"config/hiveadmission/clusterpool-webhook.yaml":                configHiveadmissionClusterpoolWebhookYaml,
+	"config/hiveadmission/clusterprovision-webhook.yaml":           configHiveadmissionClusterprovisionWebhookYaml,
+	"config/hiveadmission/deployment.yaml":                         configHiveadmissionDeploymentYaml,
+	"config/hiveadmission/dnszones-webhook.yaml":                   configHiveadmissionDnszonesWebhookYaml,
+	"config/hiveadmission/hiveadmission_rbac_role.yaml":            configHiveadmissionHiveadmission_rbac_roleYaml,
+	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml":    configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/machinepool-mutating-webhook.yaml":       configHiveadmissionMachinepoolMutatingWebhookYaml,
+	"config/hiveadmission/machinepool-webhook.yaml":                configHiveadmissionMachinepoolWebhookYaml,
+	"config/hiveadmission/selectorsyncset-webhook.yaml":            configHiveadmissionSelectorsyncsetWebhookYaml,
+	"config/hiveadmission/service-account.yaml":                    configHiveadmissionServiceAccountYaml,
+	"config/hiveadmission/service.yaml":                            configHiveadmissionServiceYaml,
+	"config/hiveadmission/syncset-webhook.yaml":                    configHiveadmissionSyncsetWebhookYaml,
+	"config/controllers/deployment.yaml":                           configControllersDeploymentYaml,
+	"config/controllers/hive_controllers_role.yaml":                configControllersHive_controllers_roleYaml,
+	"config/controllers/hive_controllers_role_binding.yaml":        configControllersHive_controllers_role_bindingYaml,
+	"config/controllers/hive_controllers_serviceaccount.yaml":      configControllersHive_controllers_serviceaccountYaml,
+	"config/controllers/service.yaml":                              configControllersServiceYaml,
+	"config/rbac/hive_admin_role.yaml":                             configRbacHive_admin_roleYaml,
+	"config/rbac/hive_admin_role_binding.yaml":                     configRbacHive_admin_role_bindingYaml,
+	"config/rbac/hive_frontend_role.yaml":                          configRbacHive_frontend_roleYaml,
+	"config/rbac/hive_frontend_role_binding.yaml":                  configRbacHive_frontend_role_bindingYaml,
+	"config/rbac/hive_frontend_serviceaccount.yaml":                configRbacHive_frontend_serviceaccountYaml,
+	"config/rbac/hive_reader_role.yaml":                            configRbacHive_reader_roleYaml,
+	"config/rbac/hive_reader_role_binding.yaml":                    configRbacHive_reader_role_bindingYaml,
+	"config/configmaps/install-log-regexes-configmap.yaml":         configConfigmapsInstallLogRegexesConfigmapYaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -1534,19 +1776,23 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"service.yaml":                         {configControllersServiceYaml, map[string]*bintree{}},
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
-			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
-			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
-			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
-			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
-			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
-			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
-			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
-			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
-			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
-			"service.yaml":                         {configHiveadmissionServiceYaml, map[string]*bintree{}},
-			"syncset-webhook.yaml":                 {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
+			"apiservice.yaml":                         {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusterclaim-webhook.yaml":               {configHiveadmissionClusterclaimWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-mutating-webhook.yaml": {configHiveadmissionClusterdeploymentMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-webhook.yaml":          {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
+			"clusterimageset-webhook.yaml":            {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
+			"clusterpool-webhook.yaml":                {configHiveadmissionClusterpoolWebhookYaml, map[string]*bintree{}},
+			"clusterprovision-webhook.yaml":           {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
+			"deployment.yaml":                         {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
+			"dnszones-webhook.yaml":                   {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role.yaml":            {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role_binding.yaml":    {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"machinepool-mutating-webhook.yaml":       {configHiveadmissionMachinepoolMutatingWebhookYaml, map[string]*bintree{}},
+			"machinepool-webhook.yaml":                {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
+			"selectorsyncset-webhook.yaml":            {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
+			"service-account.yaml":                    {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
+			"service.yaml":                            {configHiveadmissionServiceYaml, map[string]*bintree{}},
+			"syncset-webhook.yaml":                    {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
 		}},
 		"rbac": {nil, map[string]*bintree{
 			"hive_admin_role.yaml":              {configRbacHive_admin_roleYaml, map[string]*bintree{}},
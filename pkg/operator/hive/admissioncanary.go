@@ -0,0 +1,238 @@
+package hive
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// A new hiveadmission revision is first rolled out as a single canary pod. The hiveadmission service only switches
+// to the new revision once the canary is available, and the hiveadmission deployment is only updated after the
+// switch. A regression in a new hiveadmission build therefore leaves the webhooks served by the previous revision
+// rather than failing every request to the API server.
+
+const (
+	hiveAdmissionDeploymentName       = "hiveadmission"
+	hiveAdmissionCanaryDeploymentName = "hiveadmission-canary"
+
+	// admissionRevisionLabel is set on hiveadmission pods to a hash of their pod template. The hiveadmission service
+	// selects the pods of a single revision.
+	admissionRevisionLabel = "hive.openshift.io/admission-revision"
+
+	// admissionCanaryLabel is set on the pods of the canary deployment.
+	admissionCanaryLabel = "hive.openshift.io/admission-canary"
+
+	// admissionPodLabel is set on all hiveadmission pods, including the canary pods.
+	admissionPodLabel = "hiveadmission"
+
+	// admissionCanaryMinReadySeconds is how long the canary pod must be ready before the service switches to its
+	// revision.
+	admissionCanaryMinReadySeconds = 60
+)
+
+// admissionRollout is the plan for rolling out a hiveadmission revision.
+type admissionRollout struct {
+	// serviceSelector is the selector to set on the hiveadmission service.
+	serviceSelector map[string]string
+	// updateDeployment is true if the hiveadmission deployment should be updated to the new revision.
+	updateDeployment bool
+	// applyCanary is true if the canary deployment should be applied with the new revision.
+	applyCanary bool
+	// deleteCanary is true if the canary deployment is no longer needed.
+	deleteCanary bool
+}
+
+// planAdmissionRollout decides how to roll out the given hiveadmission revision based on the current hiveadmission
+// deployment, service and canary deployment, each of which is nil if it does not exist.
+func planAdmissionRollout(revision string, deployment *appsv1.Deployment, service *corev1.Service, canary *appsv1.Deployment) admissionRollout {
+	newSelector := map[string]string{
+		admissionPodLabel:      "true",
+		admissionRevisionLabel: revision,
+	}
+
+	// Nothing is serving yet, so there is no traffic to protect.
+	if deployment == nil || service == nil {
+		return admissionRollout{
+			serviceSelector:  newSelector,
+			updateDeployment: true,
+			deleteCanary:     canary != nil,
+		}
+	}
+
+	if service.Spec.Selector[admissionRevisionLabel] == revision {
+		return admissionRollout{
+			serviceSelector:  newSelector,
+			updateDeployment: true,
+			deleteCanary:     canary != nil && deploymentRolledOut(deployment, revision),
+		}
+	}
+
+	if canary != nil && deploymentRolledOut(canary, revision) {
+		return admissionRollout{
+			serviceSelector:  newSelector,
+			updateDeployment: true,
+			applyCanary:      true,
+		}
+	}
+
+	// Keep serving the current revision until the canary is available.
+	return admissionRollout{
+		serviceSelector: service.Spec.Selector,
+		applyCanary:     true,
+	}
+}
+
+// deploymentRolledOut returns true if all of the replicas of the deployment are available and at the given revision.
+func deploymentRolledOut(deployment *appsv1.Deployment, revision string) bool {
+	if deployment.Spec.Template.Labels[admissionRevisionLabel] != revision {
+		return false
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.UpdatedReplicas == replicas && status.Replicas == replicas && status.AvailableReplicas >= replicas
+}
+
+// admissionRevision returns a hash of the pod template of the hiveadmission deployment.
+func admissionRevision(template *corev1.PodTemplateSpec) (string, error) {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	hash := md5.Sum(b)
+	return hex.EncodeToString(hash[:])[:16], nil
+}
+
+// canaryDeployment returns the canary deployment for the hiveadmission deployment. The canary pods are not selected
+// by the hiveadmission deployment, nor by the hiveadmission service until it switches to their revision.
+func canaryDeployment(deployment *appsv1.Deployment) *appsv1.Deployment {
+	canary := deployment.DeepCopy()
+	canary.Name = hiveAdmissionCanaryDeploymentName
+	canary.Labels = map[string]string{admissionCanaryLabel: "true"}
+	canary.Spec.Replicas = pointer.Int32Ptr(1)
+	canary.Spec.MinReadySeconds = admissionCanaryMinReadySeconds
+	canary.Spec.Selector.MatchLabels = map[string]string{admissionCanaryLabel: "true"}
+	canary.Spec.Template.Name = hiveAdmissionCanaryDeploymentName
+	canary.Spec.Template.Labels = map[string]string{
+		admissionPodLabel:      "true",
+		admissionCanaryLabel:   "true",
+		admissionRevisionLabel: deployment.Spec.Template.Labels[admissionRevisionLabel],
+	}
+	return canary
+}
+
+// rolloutHiveAdmission applies the hiveadmission deployment and service, rolling out a new revision through a canary
+// deployment.
+func (r *ReconcileHiveConfig) rolloutHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, deployment *appsv1.Deployment, service *corev1.Service) error {
+	revision, err := admissionRevision(&deployment.Spec.Template)
+	if err != nil {
+		hLog.WithError(err).Error("error computing hiveadmission revision")
+		return err
+	}
+	hLog = hLog.WithField("revision", revision)
+	deployment.Spec.Template.Labels[admissionPodLabel] = "true"
+	deployment.Spec.Template.Labels[admissionRevisionLabel] = revision
+
+	currentDeployment := &appsv1.Deployment{}
+	if !r.getIfExists(hLog, types.NamespacedName{Namespace: deployment.Namespace, Name: hiveAdmissionDeploymentName}, currentDeployment, &err) {
+		currentDeployment = nil
+	}
+	currentService := &corev1.Service{}
+	if !r.getIfExists(hLog, types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, currentService, &err) {
+		currentService = nil
+	}
+	currentCanary := &appsv1.Deployment{}
+	if !r.getIfExists(hLog, types.NamespacedName{Namespace: deployment.Namespace, Name: hiveAdmissionCanaryDeploymentName}, currentCanary, &err) {
+		currentCanary = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	plan := planAdmissionRollout(revision, currentDeployment, currentService, currentCanary)
+
+	if plan.applyCanary {
+		if currentCanary != nil && currentCanary.Spec.Template.Labels[admissionRevisionLabel] == revision {
+			for _, cond := range currentCanary.Status.Conditions {
+				if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+					hLog.WithField("message", cond.Message).Warn("hiveadmission canary is not becoming available")
+					recorder.Warningf("HiveAdmissionCanaryFailed", "hiveadmission revision %s is not becoming available: %s", revision, cond.Message)
+				}
+			}
+		}
+		result, err := util.ApplyRuntimeObjectWithGC(h, canaryDeployment(deployment), instance)
+		if err != nil {
+			hLog.WithError(err).Error("error applying hiveadmission canary deployment")
+			return err
+		}
+		hLog.WithField("result", result).Info("hiveadmission canary deployment applied")
+	}
+
+	if currentService != nil && currentService.Spec.Selector[admissionRevisionLabel] != plan.serviceSelector[admissionRevisionLabel] {
+		hLog.Info("switching hiveadmission service to new revision")
+		recorder.Eventf("HiveAdmissionRevisionActivated", "hiveadmission service switched to revision %s", revision)
+	}
+	service.Spec.Selector = plan.serviceSelector
+	result, err := util.ApplyRuntimeObjectWithGC(h, service, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hiveadmission service")
+		return err
+	}
+	hLog.WithField("result", result).Info("hiveadmission service applied")
+
+	if plan.updateDeployment {
+		result, err := util.ApplyRuntimeObjectWithGC(h, deployment, instance)
+		if err != nil {
+			hLog.WithError(err).Error("error applying deployment")
+			return err
+		}
+		hLog.WithField("result", result).Info("hiveadmission deployment applied")
+	} else {
+		hLog.Info("waiting for hiveadmission canary to become available before updating hiveadmission")
+	}
+
+	if plan.deleteCanary {
+		hLog.Info("deleting hiveadmission canary deployment")
+		if err := r.Delete(context.TODO(), currentCanary); err != nil && !errors.IsNotFound(err) {
+			hLog.WithError(err).Error("error deleting hiveadmission canary deployment")
+			return err
+		}
+	}
+	return nil
+}
+
+// getIfExists gets the object, returning false if it does not exist. Other errors are stored in errOut.
+func (r *ReconcileHiveConfig) getIfExists(hLog log.FieldLogger, key types.NamespacedName, obj runtime.Object, errOut *error) bool {
+	err := r.Get(context.TODO(), key, obj)
+	switch {
+	case err == nil:
+		return true
+	case errors.IsNotFound(err):
+		return false
+	default:
+		hLog.WithError(err).WithField("name", key.Name).Error("error getting hiveadmission resource")
+		*errOut = err
+		return false
+	}
+}
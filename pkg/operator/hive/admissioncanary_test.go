@@ -0,0 +1,179 @@
+package hive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestPlanAdmissionRollout(t *testing.T) {
+	const (
+		oldRevision = "old-revision"
+		newRevision = "new-revision"
+	)
+	revisionSelector := func(revision string) map[string]string {
+		return map[string]string{admissionPodLabel: "true", admissionRevisionLabel: revision}
+	}
+	legacySelector := map[string]string{"app": "hiveadmission"}
+
+	deployment := func(revision string, replicas, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32Ptr(replicas),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{admissionRevisionLabel: revision}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           replicas,
+				UpdatedReplicas:    replicas,
+				AvailableReplicas:  available,
+			},
+		}
+	}
+	service := func(selector map[string]string) *corev1.Service {
+		return &corev1.Service{Spec: corev1.ServiceSpec{Selector: selector}}
+	}
+
+	cases := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		service    *corev1.Service
+		canary     *appsv1.Deployment
+		expected   admissionRollout
+	}{
+		{
+			name: "fresh install",
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+			},
+		},
+		{
+			name:       "fresh install with leftover canary",
+			deployment: deployment(newRevision, 2, 0),
+			canary:     deployment(oldRevision, 1, 1),
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+				deleteCanary:     true,
+			},
+		},
+		{
+			name:       "revision already active",
+			deployment: deployment(newRevision, 2, 2),
+			service:    service(revisionSelector(newRevision)),
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+			},
+		},
+		{
+			name:       "new revision starts canary",
+			deployment: deployment(oldRevision, 2, 2),
+			service:    service(revisionSelector(oldRevision)),
+			expected: admissionRollout{
+				serviceSelector: revisionSelector(oldRevision),
+				applyCanary:     true,
+			},
+		},
+		{
+			name:       "new revision keeps legacy selector",
+			deployment: deployment("", 2, 2),
+			service:    service(legacySelector),
+			expected: admissionRollout{
+				serviceSelector: legacySelector,
+				applyCanary:     true,
+			},
+		},
+		{
+			name:       "canary not available",
+			deployment: deployment(oldRevision, 2, 2),
+			service:    service(revisionSelector(oldRevision)),
+			canary:     deployment(newRevision, 1, 0),
+			expected: admissionRollout{
+				serviceSelector: revisionSelector(oldRevision),
+				applyCanary:     true,
+			},
+		},
+		{
+			name:       "canary of previous revision",
+			deployment: deployment(oldRevision, 2, 2),
+			service:    service(revisionSelector(oldRevision)),
+			canary:     deployment("other-revision", 1, 1),
+			expected: admissionRollout{
+				serviceSelector: revisionSelector(oldRevision),
+				applyCanary:     true,
+			},
+		},
+		{
+			name:       "canary available switches service",
+			deployment: deployment(oldRevision, 2, 2),
+			service:    service(revisionSelector(oldRevision)),
+			canary:     deployment(newRevision, 1, 1),
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+				applyCanary:      true,
+			},
+		},
+		{
+			name:       "canary kept while deployment rolls out",
+			deployment: deployment(newRevision, 2, 1),
+			service:    service(revisionSelector(newRevision)),
+			canary:     deployment(newRevision, 1, 1),
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+			},
+		},
+		{
+			name:       "canary deleted after deployment rolls out",
+			deployment: deployment(newRevision, 2, 2),
+			service:    service(revisionSelector(newRevision)),
+			canary:     deployment(newRevision, 1, 1),
+			expected: admissionRollout{
+				serviceSelector:  revisionSelector(newRevision),
+				updateDeployment: true,
+				deleteCanary:     true,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := planAdmissionRollout(newRevision, tc.deployment, tc.service, tc.canary)
+			assert.Equal(t, tc.expected, actual, "unexpected rollout plan")
+		})
+	}
+}
+
+func TestCanaryDeployment(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hive", Name: hiveAdmissionDeploymentName},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "hiveadmission", admissionPodLabel: "true"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+					"app":                  "hiveadmission",
+					admissionPodLabel:      "true",
+					admissionRevisionLabel: "new-revision",
+				}},
+			},
+		},
+	}
+	canary := canaryDeployment(deployment)
+	assert.Equal(t, hiveAdmissionCanaryDeploymentName, canary.Name, "unexpected name")
+	assert.Equal(t, int32(1), *canary.Spec.Replicas, "unexpected replicas")
+	assert.NotContains(t, canary.Spec.Template.Labels, "app", "canary pods must not match the legacy service selector")
+	assert.Equal(t, "new-revision", canary.Spec.Template.Labels[admissionRevisionLabel], "unexpected revision")
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas, "deployment must not be modified")
+	assert.Contains(t, deployment.Spec.Selector.MatchLabels, "app", "deployment must not be modified")
+}
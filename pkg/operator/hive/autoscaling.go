@@ -0,0 +1,174 @@
+package hive
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// autoscalingInterval is how often the size of the fleet is measured when autoscaling.
+	autoscalingInterval = 5 * time.Minute
+
+	// autoscalingStep is the number of objects by which resource requests grow at a time. Growing in steps rather
+	// than with every object keeps the pods from being restarted whenever a cluster is created or deleted.
+	autoscalingStep = 250
+
+	// fleetListPageSize is the number of objects fetched per request when measuring the fleet.
+	fleetListPageSize = 500
+
+	defaultClusterSyncMinReplicas        int32 = 1
+	defaultClusterSyncMaxReplicas        int32 = 10
+	defaultClusterSyncClustersPerReplica int32 = 500
+)
+
+var (
+	// controllersStepRequests is added to the resource requests of hive-controllers for every step of
+	// ClusterDeployments on the hub.
+	controllersStepRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+
+	// clusterSyncStepRequests is added to the resource requests of a hive-clustersync replica for every step of
+	// ClusterDeployments and SyncSets that it syncs.
+	clusterSyncStepRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+)
+
+// fleetSize is the number of objects on the hub that the load of the Hive controllers grows with.
+type fleetSize struct {
+	clusterDeployments int
+	syncSets           int
+}
+
+// measureFleet counts the ClusterDeployments and SyncSets on the hub. The objects are listed from the API server in
+// pages rather than from the cache, so that the operator does not hold the whole fleet in memory.
+func (r *ReconcileHiveConfig) measureFleet() (fleetSize, error) {
+	var size fleetSize
+	var err error
+	if size.clusterDeployments, err = r.countObjects(func() listWithContinue { return &hivev1.ClusterDeploymentList{} }); err != nil {
+		return size, errors.Wrap(err, "could not count ClusterDeployments")
+	}
+	if size.syncSets, err = r.countObjects(func() listWithContinue { return &hivev1.SyncSetList{} }); err != nil {
+		return size, errors.Wrap(err, "could not count SyncSets")
+	}
+	return size, nil
+}
+
+// listWithContinue is a list whose items can be counted and that can be fetched in pages.
+type listWithContinue interface {
+	runtime.Object
+	GetContinue() string
+}
+
+// countObjects counts the objects of the kind of list returned by newList.
+func (r *ReconcileHiveConfig) countObjects(newList func() listWithContinue) (int, error) {
+	count := 0
+	continueToken := ""
+	for {
+		list := newList()
+		opts := []client.ListOption{client.Limit(fleetListPageSize)}
+		if continueToken != "" {
+			opts = append(opts, client.Continue(continueToken))
+		}
+		if err := r.apiReader.List(context.TODO(), list, opts...); err != nil {
+			return 0, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return 0, err
+		}
+		count += len(items)
+		if continueToken = list.GetContinue(); continueToken == "" {
+			return count, nil
+		}
+	}
+}
+
+// clusterSyncReplicas returns the number of hive-clustersync replicas needed to sync the ClusterDeployments.
+func clusterSyncReplicas(config *hivev1.ClusterSyncAutoscaling, clusterDeployments int) int32 {
+	minReplicas := int32Value(config.MinReplicas, defaultClusterSyncMinReplicas)
+	maxReplicas := int32Value(config.MaxReplicas, defaultClusterSyncMaxReplicas)
+	clustersPerReplica := int(int32Value(config.ClustersPerReplica, defaultClusterSyncClustersPerReplica))
+	replicas := int32((clusterDeployments + clustersPerReplica - 1) / clustersPerReplica)
+	if replicas > maxReplicas {
+		replicas = maxReplicas
+	}
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	return replicas
+}
+
+// scaledRequests returns the base resource requests grown by the step requests for every step of the objects, kept
+// within the bounds.
+func scaledRequests(base, step corev1.ResourceList, objects int, bounds *hivev1.ResourceRequestBounds) corev1.ResourceList {
+	steps := int64(objects / autoscalingStep)
+	requests := corev1.ResourceList{}
+	for name, quantity := range base {
+		if increment, ok := step[name]; ok {
+			quantity = *resource.NewMilliQuantity(quantity.MilliValue()+increment.MilliValue()*steps, quantity.Format)
+		}
+		requests[name] = quantity
+	}
+	for name, minimum := range bounds.Min {
+		if quantity, ok := requests[name]; !ok || quantity.Cmp(minimum) < 0 {
+			requests[name] = minimum.DeepCopy()
+		}
+	}
+	for name, maximum := range bounds.Max {
+		if quantity, ok := requests[name]; ok && quantity.Cmp(maximum) > 0 {
+			requests[name] = maximum.DeepCopy()
+		}
+	}
+	return requests
+}
+
+// autoscale sizes the hive-controllers container for the fleet, and returns the number of hive-clustersync replicas
+// and the resource requests of each. When autoscaling is not configured, a single replica is returned along with the
+// resource requests of hive-controllers.
+func (r *ReconcileHiveConfig) autoscale(hLog log.FieldLogger, instance *hivev1.HiveConfig, hiveContainer *corev1.Container) (int32, corev1.ResourceList, error) {
+	baseRequests := hiveContainer.Resources.Requests
+	config := instance.Spec.Autoscaling
+	if config == nil {
+		return 1, baseRequests, nil
+	}
+	size, err := r.measureFleet()
+	if err != nil {
+		return 0, nil, err
+	}
+	hLog = hLog.WithField("clusterDeployments", size.clusterDeployments).WithField("syncSets", size.syncSets)
+	if config.Controllers != nil {
+		hiveContainer.Resources.Requests = scaledRequests(baseRequests, controllersStepRequests, size.clusterDeployments, config.Controllers)
+		hLog.WithField("requests", hiveContainer.Resources.Requests).Info("scaled hive-controllers")
+	}
+	if config.ClusterSync == nil {
+		return 1, baseRequests, nil
+	}
+	replicas := clusterSyncReplicas(config.ClusterSync, size.clusterDeployments)
+	objectsPerReplica := (size.clusterDeployments + size.syncSets + int(replicas) - 1) / int(replicas)
+	requests := scaledRequests(baseRequests, clusterSyncStepRequests, objectsPerReplica, &config.ClusterSync.ResourceRequestBounds)
+	hLog.WithField("replicas", replicas).WithField("requests", requests).Info("scaled hive-clustersync")
+	return replicas, requests, nil
+}
+
+func int32Value(value *int32, defaultValue int32) int32 {
+	if value != nil {
+		return *value
+	}
+	return defaultValue
+}
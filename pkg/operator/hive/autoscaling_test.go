@@ -0,0 +1,140 @@
+package hive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestClusterSyncReplicas(t *testing.T) {
+	cases := []struct {
+		name               string
+		config             *hivev1.ClusterSyncAutoscaling
+		clusterDeployments int
+		expected           int32
+	}{
+		{
+			name:     "no clusters",
+			config:   &hivev1.ClusterSyncAutoscaling{},
+			expected: 1,
+		},
+		{
+			name:               "defaults",
+			config:             &hivev1.ClusterSyncAutoscaling{},
+			clusterDeployments: 2001,
+			expected:           5,
+		},
+		{
+			name:               "default maximum",
+			config:             &hivev1.ClusterSyncAutoscaling{},
+			clusterDeployments: 100000,
+			expected:           10,
+		},
+		{
+			name: "configured bounds",
+			config: &hivev1.ClusterSyncAutoscaling{
+				MinReplicas:        pointer.Int32Ptr(2),
+				MaxReplicas:        pointer.Int32Ptr(4),
+				ClustersPerReplica: pointer.Int32Ptr(100),
+			},
+			clusterDeployments: 250,
+			expected:           3,
+		},
+		{
+			name: "configured minimum",
+			config: &hivev1.ClusterSyncAutoscaling{
+				MinReplicas: pointer.Int32Ptr(2),
+			},
+			clusterDeployments: 10,
+			expected:           2,
+		},
+		{
+			name: "configured maximum",
+			config: &hivev1.ClusterSyncAutoscaling{
+				MaxReplicas:        pointer.Int32Ptr(4),
+				ClustersPerReplica: pointer.Int32Ptr(100),
+			},
+			clusterDeployments: 1000,
+			expected:           4,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clusterSyncReplicas(tc.config, tc.clusterDeployments), "unexpected replicas")
+		})
+	}
+}
+
+func TestScaledRequests(t *testing.T) {
+	base := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("50m"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	}
+	step := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	cases := []struct {
+		name           string
+		objects        int
+		bounds         hivev1.ResourceRequestBounds
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{
+			name:           "no objects",
+			expectedCPU:    "50m",
+			expectedMemory: "512Mi",
+		},
+		{
+			name:           "less than a step",
+			objects:        249,
+			expectedCPU:    "50m",
+			expectedMemory: "512Mi",
+		},
+		{
+			name:           "steps",
+			objects:        2000,
+			expectedCPU:    "850m",
+			expectedMemory: "2560Mi",
+		},
+		{
+			name: "minimum",
+			bounds: hivev1.ResourceRequestBounds{
+				Min: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+			objects:        500,
+			expectedCPU:    "500m",
+			expectedMemory: "1Gi",
+		},
+		{
+			name: "maximum",
+			bounds: hivev1.ResourceRequestBounds{
+				Max: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			objects:        5000,
+			expectedCPU:    "1",
+			expectedMemory: "2Gi",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := scaledRequests(base, step, tc.objects, &tc.bounds)
+			cpu := requests[corev1.ResourceCPU]
+			memory := requests[corev1.ResourceMemory]
+			assert.Equal(t, tc.expectedCPU, cpu.String(), "unexpected cpu request")
+			assert.Equal(t, tc.expectedMemory, memory.String(), "unexpected memory request")
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package hive
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/syncsetagent"
+)
+
+const (
+	// clusterSyncName is the name of the StatefulSet, and of its headless Service, that runs the clustersync
+	// controller apart from the other Hive controllers.
+	clusterSyncName = "hive-clustersync"
+
+	clusterSyncControlPlaneLabel = "clustersync"
+)
+
+// generateClusterSyncStatefulSet returns the hive-clustersync StatefulSet, whose pods run the clustersync controller
+// alone. The pods are copies of the hive-controllers pods, each syncing the shard of the ClusterDeployments selected
+// by its ordinal. The pods use the given image and pull policy when set. When the syncset agent is enabled, the pods mount the serving cert of the syncset server, whose
+// hash is set on the pods so that they are restarted when the cert is rotated.
+func generateClusterSyncStatefulSet(instance *hivev1.HiveConfig, hiveDeployment *appsv1.Deployment, replicas int32, requests corev1.ResourceList, image string, pullPolicy corev1.PullPolicy, servingCertHash string) *appsv1.StatefulSet {
+	labels := clusterSyncLabels()
+	template := hiveDeployment.Spec.Template.DeepCopy()
+	template.Labels = labels
+
+	container := &template.Spec.Containers[0]
+	if image != "" {
+		container.Image = image
+	}
+	if pullPolicy != "" {
+		container.ImagePullPolicy = pullPolicy
+	}
+	container.Resources.Requests = requests
+	container.Args = []string{"--controllers", string(hivev1.ClustersyncControllerName)}
+	if dc := instance.Spec.DisabledControllers; len(dc) != 0 {
+		container.Args = append(container.Args, "--disabled-controllers", strings.Join(dc, ","))
+	}
+	if level := instance.Spec.LogLevel; level != "" {
+		container.Args = append(container.Args, "--log-level", level)
+	}
+	container.Env = append(container.Env,
+		// Each replica syncs its own shard, so the replicas do not elect a leader.
+		corev1.EnvVar{
+			Name:  "HIVE_SKIP_LEADER_ELECTION",
+			Value: "true",
+		},
+		corev1.EnvVar{
+			Name: constants.ClusterSyncPodNameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		},
+		// Changing the number of replicas changes the shards, so the pods are restarted with the new number.
+		corev1.EnvVar{
+			Name:  constants.ClusterSyncReplicasEnvVar,
+			Value: strconv.Itoa(int(replicas)),
+		},
+	)
+	if sharding := instance.Spec.ClusterSyncSharding; sharding != nil && sharding.RebalanceTrigger != "" {
+		// Changing the trigger reshuffles the shards, so the pods are restarted with the new value.
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  constants.ClusterSyncShardSeedEnvVar,
+			Value: sharding.RebalanceTrigger,
+		})
+	}
+
+	if instance.Spec.SyncSetAgent != nil {
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: "syncset-server-serving-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: syncsetagent.ServingCertSecretName},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "syncset-server-serving-cert",
+			MountPath: syncsetagent.ServingCertDir,
+			ReadOnly:  true,
+		})
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[servingCertSecretHashAnnotation] = servingCertHash
+	}
+
+	if hiveDeployment.Spec.Replicas != nil && *hiveDeployment.Spec.Replicas == 0 {
+		// Maintenance mode
+		replicas = 0
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterSyncName,
+			Namespace: hiveDeployment.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:            &replicas,
+			Selector:            &metav1.LabelSelector{MatchLabels: labels},
+			ServiceName:         clusterSyncName,
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Template:            *template,
+		},
+	}
+}
+
+// generateClusterSyncService returns the headless Service of the hive-clustersync StatefulSet, which exposes the
+// metrics of its pods.
+func generateClusterSyncService(namespace string) *corev1.Service {
+	labels := clusterSyncLabels()
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterSyncName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  labels,
+			Ports: []corev1.ServicePort{{
+				Name:     "metrics",
+				Port:     2112,
+				Protocol: corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// generateSyncSetServerService returns the Service through which the syncset agents pull the syncsets of their
+// clusters from the hive-clustersync pods. Any of the pods serves the syncsets of any cluster.
+func generateSyncSetServerService(namespace string) *corev1.Service {
+	labels := clusterSyncLabels()
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      syncsetagent.ServiceName,
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				// The service CA issues the serving cert with which the syncset server serves TLS.
+				"service.alpha.openshift.io/serving-cert-secret-name": syncsetagent.ServingCertSecretName,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "syncset-server",
+				Port:       syncsetagent.ServerPort,
+				TargetPort: intstr.FromInt(syncsetagent.ServerPort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// syncSetServerCertHash returns the hash of the serving cert of the syncset server when the syncset agent is enabled
+// in HiveConfig.
+func (r *ReconcileHiveConfig) syncSetServerCertHash(hLog log.FieldLogger, instance *hivev1.HiveConfig, hiveNSName string) string {
+	if instance.Spec.SyncSetAgent == nil {
+		return ""
+	}
+	servingCertSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: hiveNSName, Name: syncsetagent.ServingCertSecretName}, servingCertSecret); err != nil {
+		hLog.WithError(err).WithField("secretName", syncsetagent.ServingCertSecretName).Log(
+			controllerutils.LogLevel(err), "error getting syncset server serving cert secret")
+	}
+	return computeSecretDataHash(servingCertSecret.Data)
+}
+
+// deploySyncSetServerService applies the Service of the syncset server when the syncset agent is enabled in
+// HiveConfig, and deletes it when it is not.
+func (r *ReconcileHiveConfig) deploySyncSetServerService(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, hiveNSName string) error {
+	service := generateSyncSetServerService(hiveNSName)
+	if instance.Spec.SyncSetAgent == nil {
+		existing := &corev1.Service{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: hiveNSName, Name: service.Name}, existing); {
+		case apierrors.IsNotFound(err):
+			return nil
+		case err != nil:
+			hLog.WithError(err).Error("error getting syncset server service")
+			return err
+		}
+		if !isOwnedBy(existing, instance) {
+			return nil
+		}
+		hLog.Info("deleting syncset server service no longer enabled in hiveconfig")
+		if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, service, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying syncset server service")
+		return err
+	}
+	hLog.Infof("syncset server service applied (%s)", result)
+	return nil
+}
+
+func clusterSyncLabels() map[string]string {
+	return map[string]string{
+		"control-plane":           clusterSyncControlPlaneLabel,
+		"controller-tools.k8s.io": "1.0",
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -33,6 +35,7 @@ import (
 	"github.com/openshift/hive/pkg/operator/assets"
 	"github.com/openshift/hive/pkg/operator/util"
 	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/syncsetagent"
 )
 
 const (
@@ -59,32 +62,38 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	hiveDeployment := resourceread.ReadDeploymentV1OrDie(asset)
 	hiveContainer := &hiveDeployment.Spec.Template.Spec.Containers[0]
 
-	hLog.Infof("hive image: %s", r.hiveImage)
-	if r.hiveImage != "" {
-		hiveContainer.Image = r.hiveImage
+	controllersImage, controllersPullPolicy := r.componentImage(instance, controllersImageOverride, controllersScheduling)
+	jobsImage, jobsPullPolicy := r.componentImage(instance, jobsImageOverride, jobsScheduling)
+
+	hLog.Infof("hive image: %s", controllersImage)
+	if controllersImage != "" {
+		hiveContainer.Image = controllersImage
+	}
+	if jobsImage != "" {
 		hiveImageEnvVar := corev1.EnvVar{
 			Name:  images.HiveImageEnvVar,
-			Value: r.hiveImage,
+			Value: jobsImage,
 		}
 
 		hiveContainer.Env = append(hiveContainer.Env, hiveImageEnvVar)
 	}
 
-	if r.hiveImagePullPolicy != "" {
-		hiveContainer.ImagePullPolicy = r.hiveImagePullPolicy
-
+	if controllersPullPolicy != "" {
+		hiveContainer.ImagePullPolicy = controllersPullPolicy
+	}
+	if jobsPullPolicy != "" {
 		hiveContainer.Env = append(
 			hiveContainer.Env,
 			corev1.EnvVar{
 				Name:  images.HiveImagePullPolicyEnvVar,
-				Value: string(r.hiveImagePullPolicy),
+				Value: string(jobsPullPolicy),
 			},
 		)
 	}
 
-	if dc := instance.Spec.DisabledControllers; len(dc) != 0 {
-		hiveContainer.Args = append(hiveContainer.Args, "--disabled-controllers", strings.Join(dc, ","))
-	}
+	// The clustersync controller runs in the hive-clustersync StatefulSet, where it can be scaled out.
+	disabledControllers := append([]string{string(hivev1.ClustersyncControllerName)}, instance.Spec.DisabledControllers...)
+	hiveContainer.Args = append(hiveContainer.Args, "--disabled-controllers", strings.Join(disabledControllers, ","))
 
 	if level := instance.Spec.LogLevel; level != "" {
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
@@ -99,6 +108,13 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if syncSetDriftCheckInterval := instance.Spec.SyncSetDriftCheckInterval; syncSetDriftCheckInterval != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  "SYNCSET_DRIFT_CHECK_INTERVAL",
+			Value: syncSetDriftCheckInterval,
+		})
+	}
+
 	addManagedDomainsVolume(&hiveDeployment.Spec.Template.Spec, mdConfigMap.Name)
 
 	hiveNSName := getHiveNamespace(instance)
@@ -137,6 +153,44 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 			},
 		}
 		hiveContainer.Env = append(hiveContainer.Env, awsLogsEnvVars...)
+	} else if instance.Spec.FailedProvisionConfig.GCP != nil {
+		gcpSpec := instance.Spec.FailedProvisionConfig.GCP
+		gcpLogsEnvVars := []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderGCP,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: gcpSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsGCPBucketEnvVar,
+				Value: gcpSpec.Bucket,
+			},
+		}
+		hiveContainer.Env = append(hiveContainer.Env, gcpLogsEnvVars...)
+	} else if instance.Spec.FailedProvisionConfig.Azure != nil {
+		azureSpec := instance.Spec.FailedProvisionConfig.Azure
+		azureLogsEnvVars := []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAzure,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: azureSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAzureStorageAccountEnvVar,
+				Value: azureSpec.StorageAccount,
+			},
+			{
+				Name:  constants.InstallLogsAzureContainerEnvVar,
+				Value: azureSpec.Container,
+			},
+		}
+		hiveContainer.Env = append(hiveContainer.Env, azureLogsEnvVars...)
 	}
 
 	if zoneCheckDNSServers := os.Getenv(dnsServersEnvVar); len(zoneCheckDNSServers) > 0 {
@@ -191,14 +245,166 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		})
 	}
 
+	if instance.Spec.NamespacePerCluster == hivev1.NamespacePerClusterEnabled {
+		// Record when the mode was enabled, so that namespaces that existed before then are never reaped. The
+		// status is saved with the rest of the status of HiveConfig.
+		if instance.Status.NamespacePerClusterEnabledTime == nil {
+			now := metav1.Now()
+			instance.Status.NamespacePerClusterEnabledTime = &now
+		}
+		hLog.WithField("enabledTime", instance.Status.NamespacePerClusterEnabledTime).Info("Namespace per cluster enabled")
+		hiveContainer.Env = append(hiveContainer.Env,
+			corev1.EnvVar{
+				Name:  hiveconstants.NamespacePerClusterEnvVar,
+				Value: "true",
+			},
+			corev1.EnvVar{
+				Name:  hiveconstants.NamespacePerClusterEnabledTimeEnvVar,
+				Value: instance.Status.NamespacePerClusterEnabledTime.UTC().Format(time.RFC3339),
+			},
+		)
+	} else {
+		instance.Status.NamespacePerClusterEnabledTime = nil
+	}
+
+	if scheduling := instance.Spec.Scheduling; scheduling != nil {
+		utils.ApplyPodScheduling(&hiveDeployment.Spec.Template.Spec, scheduling.Controllers)
+		if scheduling.Jobs != nil {
+			jobScheduling, err := json.Marshal(scheduling.Jobs)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal job scheduling")
+			}
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.JobSchedulingEnvVar,
+				Value: string(jobScheduling),
+			})
+		}
+	}
+
+	if instance.Spec.PullThroughCache != nil {
+		pullThroughCache, err := json.Marshal(instance.Spec.PullThroughCache)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal pull-through cache")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.PullThroughCacheEnvVar,
+			Value: string(pullThroughCache),
+		})
+	}
+
+	if instance.Spec.AWSPrivateLink != nil {
+		awsPrivateLink, err := json.Marshal(instance.Spec.AWSPrivateLink)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal AWS PrivateLink config")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.AWSPrivateLinkEnvVar,
+			Value: string(awsPrivateLink),
+		})
+	}
+
+	if instance.Spec.GCPPrivateServiceConnect != nil {
+		gcpPrivateServiceConnect, err := json.Marshal(instance.Spec.GCPPrivateServiceConnect)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal GCP Private Service Connect config")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.GCPPrivateServiceConnectEnvVar,
+			Value: string(gcpPrivateServiceConnect),
+		})
+	}
+
+	if instance.Spec.SyncSetAgent != nil {
+		if err := syncsetagent.ValidateHubURL(instance.Spec.SyncSetAgent.HubURL); err != nil {
+			return errors.Wrap(err, "invalid syncset agent config")
+		}
+		syncSetAgent, err := json.Marshal(instance.Spec.SyncSetAgent)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal syncset agent config")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.SyncSetAgentEnvVar,
+			Value: string(syncSetAgent),
+		})
+	}
+
+	if len(instance.Spec.ExternalDestroyers) > 0 {
+		externalDestroyers, err := json.Marshal(instance.Spec.ExternalDestroyers)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal external destroyers")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ExternalDestroyersEnvVar,
+			Value: string(externalDestroyers),
+		})
+	}
+
+	if len(instance.Spec.ProvisionHooks) > 0 {
+		provisionHooks, err := json.Marshal(instance.Spec.ProvisionHooks)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal provision hooks")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ProvisionHooksEnvVar,
+			Value: string(provisionHooks),
+		})
+	}
+
+	if instance.Spec.AzurePrivateLink != nil {
+		azurePrivateLink, err := json.Marshal(instance.Spec.AzurePrivateLink)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal Azure Private Link config")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.AzurePrivateLinkEnvVar,
+			Value: string(azurePrivateLink),
+		})
+	}
+
+	if instance.Spec.MachinePoolReplicaLimits != nil {
+		replicaLimits, err := json.Marshal(instance.Spec.MachinePoolReplicaLimits)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal machine pool replica limits")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.MachinePoolReplicaLimitsEnvVar,
+			Value: string(replicaLimits),
+		})
+	}
+
+	if len(instance.Spec.ClusterPoolCapacityPools) > 0 {
+		capacityPools, err := json.Marshal(instance.Spec.ClusterPoolCapacityPools)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cluster pool capacity pools")
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ClusterPoolCapacityPoolsEnvVar,
+			Value: string(capacityPools),
+		})
+	}
+
+	if instance.Spec.PriorityClasses != nil {
+		hiveDeployment.Spec.Template.Spec.PriorityClassName = criticalPriorityClassName
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ProvisionPriorityClassEnvVar,
+			Value: provisionPriorityClassName,
+		})
+	}
+
 	if err := r.includeAdditionalCAs(hLog, h, instance, hiveDeployment); err != nil {
 		return err
 	}
 
 	r.includeGlobalPullSecret(hLog, h, instance, hiveDeployment)
 
+	clusterSyncReplicas, clusterSyncRequests, err := r.autoscale(hLog, instance, hiveContainer)
+	if err != nil {
+		hLog.WithError(err).Error("error autoscaling hive controllers")
+		return err
+	}
+
 	if instance.Spec.MaintenanceMode != nil && *instance.Spec.MaintenanceMode {
-		hLog.Warn("maintenanceMode enabled in HiveConfig, setting hive-controllers replicas to 0")
+		hLog.Warn("maintenanceMode enabled in HiveConfig, setting hive-controllers and hive-clustersync replicas to 0")
 		replicas := int32(0)
 		hiveDeployment.Spec.Replicas = &replicas
 	}
@@ -306,6 +512,28 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	}
 	hLog.Infof("hive-controllers deployment applied (%s)", result)
 
+	result, err = util.ApplyRuntimeObjectWithGC(h, generateClusterSyncService(hiveNSName), instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-clustersync service")
+		return err
+	}
+	hLog.Infof("hive-clustersync service applied (%s)", result)
+
+	if err := r.deploySyncSetServerService(hLog, h, instance, hiveNSName); err != nil {
+		return err
+	}
+
+	clusterSyncImage, clusterSyncPullPolicy := r.clusterSyncImage(instance, controllersImage, controllersPullPolicy)
+	hLog.Infof("hive-clustersync image: %s", clusterSyncImage)
+	clusterSyncStatefulSet := generateClusterSyncStatefulSet(instance, hiveDeployment, clusterSyncReplicas, clusterSyncRequests,
+		clusterSyncImage, clusterSyncPullPolicy, r.syncSetServerCertHash(hLog, instance, hiveNSName))
+	result, err = util.ApplyRuntimeObjectWithGC(h, clusterSyncStatefulSet, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-clustersync statefulset")
+		return err
+	}
+	hLog.Infof("hive-clustersync statefulset applied (%s)", result)
+
 	hLog.Info("all hive components successfully reconciled")
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
 	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/syncsetagent"
 
 	"github.com/openshift/library-go/pkg/operator/events"
 
@@ -26,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
@@ -69,7 +71,7 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileHiveConfig{Client: mgr.GetClient(), scheme: mgr.GetScheme(), restConfig: mgr.GetConfig(), mgr: mgr}
+	return &ReconcileHiveConfig{Client: mgr.GetClient(), apiReader: mgr.GetAPIReader(), scheme: mgr.GetScheme(), restConfig: mgr.GetConfig(), mgr: mgr}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -211,6 +213,7 @@ var _ reconcile.Reconciler = &ReconcileHiveConfig{}
 // ReconcileHiveConfig reconciles a Hive object
 type ReconcileHiveConfig struct {
 	client.Client
+	apiReader                         client.Reader
 	scheme                            *runtime.Scheme
 	kubeClient                        kubernetes.Interface
 	apiextClient                      *apiextclientv1beta1.ApiextensionsV1beta1Client
@@ -221,6 +224,7 @@ type ReconcileHiveConfig struct {
 	hiveImage                         string
 	hiveOperatorNamespace             string
 	hiveImagePullPolicy               corev1.PullPolicy
+	hubArchitectures                  sets.String
 	syncAggregatorCA                  bool
 	managedConfigCMLister             corev1listers.ConfigMapLister
 	ctrlr                             controller.Controller
@@ -329,6 +333,20 @@ func (r *ReconcileHiveConfig) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	if err := r.deployPriorityClasses(hLog, h, instance); err != nil {
+		hLog.WithError(err).Error("error deploying priority classes")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	// The architectures of the nodes of the hub select the architecture specific images of the components.
+	r.hubArchitectures, err = r.nodeArchitectures()
+	if err != nil {
+		hLog.WithError(err).Error("error determining the architectures of the hub nodes")
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	err = r.deployHive(hLog, h, instance, recorder, managedDomainsConfigMap)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying Hive")
@@ -358,6 +376,11 @@ func (r *ReconcileHiveConfig) Reconcile(request reconcile.Request) (reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	// Measure the fleet again later to keep the Hive controllers sized for it.
+	if instance.Spec.Autoscaling != nil {
+		return reconcile.Result{RequeueAfter: autoscalingInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -376,8 +399,8 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 			return err
 		}
 
-		// Watch Secrets in hive namespace, so we can detect changes to the hiveadmission serving cert secret and
-		// force a deployment rollout.
+		// Watch Secrets in hive namespace, so we can detect changes to the hiveadmission and syncset server serving
+		// cert secrets and force a rollout.
 		err := r.ctrlr.Watch(&source.Informer{Informer: secretsInformer}, handler.Funcs{
 			CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
 				hLog.Debug("eventHandler CreateFunc")
@@ -389,12 +412,12 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 			},
 		}, predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
-				hLog.WithField("predicateResponse", e.Meta.GetName() == hiveAdmissionServingCertSecretName).Debug("secret CreateEvent")
-				return e.Meta.GetName() == hiveAdmissionServingCertSecretName
+				hLog.WithField("predicateResponse", isServingCertSecret(e.Meta.GetName())).Debug("secret CreateEvent")
+				return isServingCertSecret(e.Meta.GetName())
 			},
 			UpdateFunc: func(e event.UpdateEvent) bool {
-				hLog.WithField("predicateResponse", e.MetaNew.GetName() == hiveAdmissionServingCertSecretName).Debug("secret UpdateEvent")
-				return e.MetaNew.GetName() == hiveAdmissionServingCertSecretName
+				hLog.WithField("predicateResponse", isServingCertSecret(e.MetaNew.GetName())).Debug("secret UpdateEvent")
+				return isServingCertSecret(e.MetaNew.GetName())
 			},
 		})
 		if err != nil {
@@ -408,6 +431,11 @@ func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName s
 	return nil
 }
 
+// isServingCertSecret returns true if the secret is the serving cert secret of a Hive component.
+func isServingCertSecret(name string) bool {
+	return name == hiveAdmissionServingCertSecretName || name == syncsetagent.ServingCertSecretName
+}
+
 func (r *ReconcileHiveConfig) cleanupLegacyObjects(hLog log.FieldLogger) error {
 	gvrNSNames := []gvrNSName{
 		{group: "rbac.authorization.k8s.io", version: "v1", resource: "clusterroles", name: "manager-role"},
@@ -4,11 +4,13 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/operator/assets"
 	"github.com/openshift/hive/pkg/operator/util"
@@ -37,22 +39,11 @@ const (
 	servingCertSecretHashAnnotation  = "hive.openshift.io/serving-cert-secret-hash"
 )
 
-var webhookAssets = []string{
-	"config/hiveadmission/clusterdeployment-webhook.yaml",
-	"config/hiveadmission/clusterimageset-webhook.yaml",
-	"config/hiveadmission/clusterprovision-webhook.yaml",
-	"config/hiveadmission/dnszones-webhook.yaml",
-	"config/hiveadmission/machinepool-webhook.yaml",
-	"config/hiveadmission/syncset-webhook.yaml",
-	"config/hiveadmission/selectorsyncset-webhook.yaml",
-}
-
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap) error {
 	hiveNSName := getHiveNamespace(instance)
 
 	// Load namespaced assets, decode them, set to our target namespace, and apply:
 	namespacedAssets := []string{
-		"config/hiveadmission/service.yaml",
 		"config/hiveadmission/service-account.yaml",
 	}
 	for _, assetPath := range namespacedAssets {
@@ -85,15 +76,20 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("asset", crbAsset).Info("applied ClusterRoleRoleBinding asset with namespace override")
 	}
 
+	if err := r.deploySyncSetDryRunRBAC(hLog, h, instance, hiveNSName); err != nil {
+		return err
+	}
+
 	asset := assets.MustAsset("config/hiveadmission/deployment.yaml")
 	hLog.Debug("reading deployment")
 	hiveAdmDeployment := resourceread.ReadDeploymentV1OrDie(asset)
 	hiveAdmDeployment.Namespace = hiveNSName
-	if r.hiveImage != "" {
-		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Image = r.hiveImage
+	admissionImage, admissionPullPolicy := r.componentImage(instance, admissionImageOverride, admissionScheduling)
+	if admissionImage != "" {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Image = admissionImage
 	}
-	if r.hiveImagePullPolicy != "" {
-		hiveAdmDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = r.hiveImagePullPolicy
+	if admissionPullPolicy != "" {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = admissionPullPolicy
 	}
 	if hiveAdmDeployment.Annotations == nil {
 		hiveAdmDeployment.Annotations = map[string]string{}
@@ -106,11 +102,79 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 
 	addManagedDomainsVolume(&hiveAdmDeployment.Spec.Template.Spec, mdConfigMap.Name)
 
-	validatingWebhooks := make([]*admregv1.ValidatingWebhookConfiguration, len(webhookAssets))
-	for i, yaml := range webhookAssets {
-		asset = assets.MustAsset(yaml)
-		wh := util.ReadValidatingWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme)
-		validatingWebhooks[i] = wh
+	if instance.Spec.Scheduling != nil {
+		controllerutils.ApplyPodScheduling(&hiveAdmDeployment.Spec.Template.Spec, instance.Spec.Scheduling.Admission)
+	}
+
+	if instance.Spec.PriorityClasses != nil {
+		hiveAdmDeployment.Spec.Template.Spec.PriorityClassName = criticalPriorityClassName
+	}
+
+	if instance.Spec.DeleteProtection == hivev1.DeleteProtectionEnabled {
+		hLog.Info("Delete Protection enabled")
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.ProtectedDeleteEnvVar,
+			Value: "true",
+		})
+	}
+
+	if instance.Spec.NamespacePerCluster == hivev1.NamespacePerClusterEnabled {
+		hLog.Info("Namespace per cluster enabled")
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.NamespacePerClusterEnvVar,
+			Value: "true",
+		})
+	}
+
+	if instance.Spec.SyncSetDryRun == hivev1.SyncSetDryRunEnabled {
+		hLog.Info("SyncSet dry-run enabled")
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.SyncSetDryRunEnvVar,
+			Value: "true",
+		})
+	}
+
+	if instance.Spec.AdmissionPolicy != nil {
+		hLog.WithField("url", instance.Spec.AdmissionPolicy.URL).Info("Admission policy enabled")
+		admissionPolicy, err := json.Marshal(instance.Spec.AdmissionPolicy)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling admission policy")
+			return err
+		}
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.AdmissionPolicyEnvVar,
+			Value: string(admissionPolicy),
+		})
+	}
+
+	if instance.Spec.ClusterDefaults != nil {
+		clusterDefaults, err := json.Marshal(instance.Spec.ClusterDefaults)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling cluster defaults")
+			return err
+		}
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.ClusterDefaultsEnvVar,
+			Value: string(clusterDefaults),
+		})
+	}
+
+	if instance.Spec.MachinePoolReplicaLimits != nil {
+		replicaLimits, err := json.Marshal(instance.Spec.MachinePoolReplicaLimits)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling machine pool replica limits")
+			return err
+		}
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.MachinePoolReplicaLimitsEnvVar,
+			Value: string(replicaLimits),
+		})
+	}
+
+	validatingWebhooks, mutatingWebhooks, deployedWebhooks, err := readWebhookAssets(webhookAssets)
+	if err != nil {
+		hLog.WithError(err).Error("error reading webhook assets")
+		return err
 	}
 
 	hLog.Debug("reading apiservice")
@@ -135,7 +199,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	if !isOpenShift || is311 {
 		hLog.Debug("non-OpenShift 4.x cluster detected, modifying hiveadmission webhooks for CA certs")
-		err = r.injectCerts(apiService, validatingWebhooks, nil, hiveNSName, hLog)
+		err = r.injectCerts(apiService, validatingWebhooks, mutatingWebhooks, hiveNSName, hLog)
 		if err != nil {
 			hLog.WithError(err).Error("error injecting certs")
 			return err
@@ -155,14 +219,15 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	hiveAdmDeployment.Spec.Template.Annotations[servingCertSecretHashAnnotation] = certSecretHash
 
-	result, err := util.ApplyRuntimeObjectWithGC(h, hiveAdmDeployment, instance)
-	if err != nil {
-		hLog.WithError(err).Error("error applying deployment")
+	hLog.Debug("reading service")
+	hiveAdmService := resourceread.ReadServiceV1OrDie(assets.MustAsset("config/hiveadmission/service.yaml"))
+	hiveAdmService.Namespace = hiveNSName
+
+	if err := r.rolloutHiveAdmission(hLog, h, instance, recorder, hiveAdmDeployment, hiveAdmService); err != nil {
 		return err
 	}
-	hLog.WithField("result", result).Info("hiveadmission deployment applied")
 
-	result, err = util.ApplyRuntimeObjectWithGC(h, apiService, instance)
+	result, err := util.ApplyRuntimeObjectWithGC(h, apiService, instance)
 	if err != nil {
 		hLog.WithError(err).Error("error applying apiservice")
 		return err
@@ -178,6 +243,21 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
 	}
 
+	for _, webhook := range mutatingWebhooks {
+		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
+		if err != nil {
+			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying mutating webhook")
+			return err
+		}
+		hLog.WithField("webhook", webhook.Name).Infof("mutating webhook: %s", result)
+	}
+
+	if err := r.deleteStaleWebhooks(instance, deployedWebhooks, recorder, hLog); err != nil {
+		hLog.WithError(err).Error("error deleting stale webhooks")
+		return err
+	}
+	instance.Status.DeployedWebhooks = deployedWebhooks
+
 	hLog.Info("hiveadmission components reconciled successfully")
 	return nil
 }
@@ -0,0 +1,126 @@
+package hive
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// resolveImage returns the image and pull policy to use for a component given its optional override. Fields
+// not specified in the override fall back to the default image and pull policy, which are those of the
+// hive-operator. An architecture specific image matching arch takes precedence over the override's Image.
+func resolveImage(override *hivev1.ImageOverride, arch, defaultImage string, defaultPullPolicy corev1.PullPolicy) (string, corev1.PullPolicy) {
+	image, pullPolicy := defaultImage, defaultPullPolicy
+	if override == nil {
+		return image, pullPolicy
+	}
+	if override.Image != "" {
+		image = override.Image
+	}
+	for _, ai := range override.ArchitectureImages {
+		if ai.Architecture == arch && ai.Image != "" {
+			image = ai.Image
+			break
+		}
+	}
+	if override.ImagePullPolicy != "" {
+		pullPolicy = override.ImagePullPolicy
+	}
+	return image, pullPolicy
+}
+
+// componentImage returns the image and pull policy to use for the component whose override and scheduling
+// configuration are selected by the getters from the HiveConfig.
+func (r *ReconcileHiveConfig) componentImage(
+	instance *hivev1.HiveConfig,
+	imageGetter func(*hivev1.ComponentImagesConfig) *hivev1.ImageOverride,
+	schedulingGetter func(*hivev1.SchedulingConfig) *hivev1.PodSchedulingConfig,
+) (string, corev1.PullPolicy) {
+	var override *hivev1.ImageOverride
+	if ci := instance.Spec.ComponentImages; ci != nil {
+		override = imageGetter(ci)
+	}
+	var scheduling *hivev1.PodSchedulingConfig
+	if sc := instance.Spec.Scheduling; sc != nil {
+		scheduling = schedulingGetter(sc)
+	}
+	return resolveImage(override, componentArchitecture(scheduling, r.hubArchitectures), r.hiveImage, r.hiveImagePullPolicy)
+}
+
+// clusterSyncImage returns the image and pull policy to use for the hive-clustersync pods. The pods are scheduled like
+// the hive-controllers pods, and fields not specified in the clustersync override fall back to the image and pull
+// policy of the hive-controllers pods.
+func (r *ReconcileHiveConfig) clusterSyncImage(instance *hivev1.HiveConfig, controllersImage string, controllersPullPolicy corev1.PullPolicy) (string, corev1.PullPolicy) {
+	var override *hivev1.ImageOverride
+	if ci := instance.Spec.ComponentImages; ci != nil {
+		override = ci.ClusterSync
+	}
+	var scheduling *hivev1.PodSchedulingConfig
+	if sc := instance.Spec.Scheduling; sc != nil {
+		scheduling = controllersScheduling(sc)
+	}
+	return resolveImage(override, componentArchitecture(scheduling, r.hubArchitectures), controllersImage, controllersPullPolicy)
+}
+
+// componentArchitecture returns the CPU architecture of the nodes the pods of a component run on, for selecting its
+// architecture specific image. These are the nodes of the hub that the scheduling configuration of the component
+// allows. It returns an empty architecture when the pods may run on nodes of several architectures, or when the
+// architectures of the nodes are not known, in which case no architecture specific image is selected.
+func componentArchitecture(scheduling *hivev1.PodSchedulingConfig, hubArchitectures sets.String) string {
+	archs := hubArchitectures
+	if scheduling != nil {
+		if len(scheduling.Architectures) > 0 {
+			archs = archs.Intersection(sets.NewString(scheduling.Architectures...))
+		}
+		if arch, ok := scheduling.NodeSelector[corev1.LabelArchStable]; ok {
+			archs = archs.Intersection(sets.NewString(arch))
+		}
+	}
+	if archs.Len() != 1 {
+		return ""
+	}
+	return archs.List()[0]
+}
+
+// nodeArchitectures returns the CPU architectures of the nodes of the hub, from their kubernetes.io/arch labels.
+func (r *ReconcileHiveConfig) nodeArchitectures() (sets.String, error) {
+	nodes, err := r.kubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	archs := sets.NewString()
+	for _, node := range nodes.Items {
+		if arch := node.Labels[corev1.LabelArchStable]; arch != "" {
+			archs.Insert(arch)
+		}
+	}
+	return archs, nil
+}
+
+func controllersImageOverride(ci *hivev1.ComponentImagesConfig) *hivev1.ImageOverride {
+	return ci.Controllers
+}
+
+func admissionImageOverride(ci *hivev1.ComponentImagesConfig) *hivev1.ImageOverride {
+	return ci.Admission
+}
+
+func jobsImageOverride(ci *hivev1.ComponentImagesConfig) *hivev1.ImageOverride {
+	return ci.Jobs
+}
+
+func controllersScheduling(sc *hivev1.SchedulingConfig) *hivev1.PodSchedulingConfig {
+	return sc.Controllers
+}
+
+func admissionScheduling(sc *hivev1.SchedulingConfig) *hivev1.PodSchedulingConfig {
+	return sc.Admission
+}
+
+func jobsScheduling(sc *hivev1.SchedulingConfig) *hivev1.PodSchedulingConfig {
+	return sc.Jobs
+}
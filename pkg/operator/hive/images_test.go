@@ -0,0 +1,231 @@
+package hive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestResolveImage(t *testing.T) {
+	const (
+		defaultImage      = "quay.io/openshift-hive/hive:default"
+		defaultPullPolicy = corev1.PullAlways
+	)
+	tests := []struct {
+		name               string
+		override           *hivev1.ImageOverride
+		arch               string
+		expectedImage      string
+		expectedPullPolicy corev1.PullPolicy
+	}{
+		{
+			name:               "no override",
+			arch:               "amd64",
+			expectedImage:      defaultImage,
+			expectedPullPolicy: defaultPullPolicy,
+		},
+		{
+			name: "image override",
+			override: &hivev1.ImageOverride{
+				Image: "quay.io/test/hive:test",
+			},
+			arch:               "amd64",
+			expectedImage:      "quay.io/test/hive:test",
+			expectedPullPolicy: defaultPullPolicy,
+		},
+		{
+			name: "pull policy override",
+			override: &hivev1.ImageOverride{
+				ImagePullPolicy: corev1.PullIfNotPresent,
+			},
+			arch:               "amd64",
+			expectedImage:      defaultImage,
+			expectedPullPolicy: corev1.PullIfNotPresent,
+		},
+		{
+			name: "matching architecture",
+			override: &hivev1.ImageOverride{
+				Image: "quay.io/test/hive:test",
+				ArchitectureImages: []hivev1.ArchitectureImage{
+					{Architecture: "amd64", Image: "quay.io/test/hive:amd64"},
+					{Architecture: "arm64", Image: "quay.io/test/hive:arm64"},
+				},
+			},
+			arch:               "arm64",
+			expectedImage:      "quay.io/test/hive:arm64",
+			expectedPullPolicy: defaultPullPolicy,
+		},
+		{
+			name: "no matching architecture",
+			override: &hivev1.ImageOverride{
+				Image: "quay.io/test/hive:test",
+				ArchitectureImages: []hivev1.ArchitectureImage{
+					{Architecture: "amd64", Image: "quay.io/test/hive:amd64"},
+				},
+			},
+			arch:               "ppc64le",
+			expectedImage:      "quay.io/test/hive:test",
+			expectedPullPolicy: defaultPullPolicy,
+		},
+		{
+			name: "no matching architecture without image",
+			override: &hivev1.ImageOverride{
+				ArchitectureImages: []hivev1.ArchitectureImage{
+					{Architecture: "amd64", Image: "quay.io/test/hive:amd64"},
+				},
+			},
+			arch:               "s390x",
+			expectedImage:      defaultImage,
+			expectedPullPolicy: defaultPullPolicy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			image, pullPolicy := resolveImage(test.override, test.arch, defaultImage, defaultPullPolicy)
+			assert.Equal(t, test.expectedImage, image, "unexpected image")
+			assert.Equal(t, test.expectedPullPolicy, pullPolicy, "unexpected pull policy")
+		})
+	}
+}
+
+func TestComponentArchitecture(t *testing.T) {
+	tests := []struct {
+		name             string
+		scheduling       *hivev1.PodSchedulingConfig
+		hubArchitectures sets.String
+		expectedArch     string
+	}{
+		{
+			name:             "single architecture hub",
+			hubArchitectures: sets.NewString("arm64"),
+			expectedArch:     "arm64",
+		},
+		{
+			name:             "multi-architecture hub",
+			hubArchitectures: sets.NewString("amd64", "arm64"),
+		},
+		{
+			name:             "unknown hub architectures",
+			hubArchitectures: sets.NewString(),
+		},
+		{
+			name: "multi-architecture hub restricted by architectures",
+			scheduling: &hivev1.PodSchedulingConfig{
+				Architectures: []string{"arm64"},
+			},
+			hubArchitectures: sets.NewString("amd64", "arm64"),
+			expectedArch:     "arm64",
+		},
+		{
+			name: "multi-architecture hub restricted by node selector",
+			scheduling: &hivev1.PodSchedulingConfig{
+				NodeSelector: map[string]string{corev1.LabelArchStable: "amd64"},
+			},
+			hubArchitectures: sets.NewString("amd64", "arm64"),
+			expectedArch:     "amd64",
+		},
+		{
+			name: "architectures not on hub",
+			scheduling: &hivev1.PodSchedulingConfig{
+				Architectures: []string{"amd64", "s390x"},
+			},
+			hubArchitectures: sets.NewString("amd64", "arm64"),
+			expectedArch:     "amd64",
+		},
+		{
+			name: "several allowed architectures",
+			scheduling: &hivev1.PodSchedulingConfig{
+				Architectures: []string{"amd64", "arm64"},
+			},
+			hubArchitectures: sets.NewString("amd64", "arm64"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedArch, componentArchitecture(test.scheduling, test.hubArchitectures), "unexpected architecture")
+		})
+	}
+}
+
+func TestClusterSyncImage(t *testing.T) {
+	const (
+		controllersImage      = "quay.io/openshift-hive/hive:controllers"
+		controllersPullPolicy = corev1.PullAlways
+	)
+	tests := []struct {
+		name               string
+		componentImages    *hivev1.ComponentImagesConfig
+		scheduling         *hivev1.SchedulingConfig
+		hubArchitectures   sets.String
+		expectedImage      string
+		expectedPullPolicy corev1.PullPolicy
+	}{
+		{
+			name:               "no component images",
+			expectedImage:      controllersImage,
+			expectedPullPolicy: controllersPullPolicy,
+		},
+		{
+			name: "no clustersync override",
+			componentImages: &hivev1.ComponentImagesConfig{
+				Admission: &hivev1.ImageOverride{Image: "quay.io/test/hive:admission"},
+			},
+			expectedImage:      controllersImage,
+			expectedPullPolicy: controllersPullPolicy,
+		},
+		{
+			name: "clustersync override",
+			componentImages: &hivev1.ComponentImagesConfig{
+				ClusterSync: &hivev1.ImageOverride{
+					Image:           "quay.io/test/hive:clustersync",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				},
+			},
+			expectedImage:      "quay.io/test/hive:clustersync",
+			expectedPullPolicy: corev1.PullIfNotPresent,
+		},
+		{
+			name: "clustersync pull policy override",
+			componentImages: &hivev1.ComponentImagesConfig{
+				ClusterSync: &hivev1.ImageOverride{ImagePullPolicy: corev1.PullIfNotPresent},
+			},
+			expectedImage:      controllersImage,
+			expectedPullPolicy: corev1.PullIfNotPresent,
+		},
+		{
+			name: "clustersync architecture from controllers scheduling",
+			componentImages: &hivev1.ComponentImagesConfig{
+				ClusterSync: &hivev1.ImageOverride{
+					ArchitectureImages: []hivev1.ArchitectureImage{
+						{Architecture: "arm64", Image: "quay.io/test/hive:arm64"},
+					},
+				},
+			},
+			scheduling: &hivev1.SchedulingConfig{
+				Controllers: &hivev1.PodSchedulingConfig{Architectures: []string{"arm64"}},
+			},
+			hubArchitectures:   sets.NewString("amd64", "arm64"),
+			expectedImage:      "quay.io/test/hive:arm64",
+			expectedPullPolicy: controllersPullPolicy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &ReconcileHiveConfig{hubArchitectures: test.hubArchitectures}
+			instance := &hivev1.HiveConfig{
+				Spec: hivev1.HiveConfigSpec{
+					ComponentImages: test.componentImages,
+					Scheduling:      test.scheduling,
+				},
+			}
+			image, pullPolicy := r.clusterSyncImage(instance, controllersImage, controllersPullPolicy)
+			assert.Equal(t, test.expectedImage, image, "unexpected image")
+			assert.Equal(t, test.expectedPullPolicy, pullPolicy, "unexpected pull policy")
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package hive
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	// criticalPriorityClassName is the PriorityClass of the hive-controllers and hiveadmission pods.
+	criticalPriorityClassName = "hive-critical"
+
+	// provisionPriorityClassName is the PriorityClass of the install pods.
+	provisionPriorityClassName = "hive-provision"
+
+	defaultCriticalPriority  int32 = 1000000
+	defaultProvisionPriority int32 = 100000
+)
+
+// generatePriorityClasses returns the PriorityClasses for the Hive workloads configured in HiveConfig.
+func generatePriorityClasses(config *hivev1.PriorityClassesConfig) []*schedulingv1.PriorityClass {
+	valueOrDefault := func(value *int32, defaultValue int32) int32 {
+		if value != nil {
+			return *value
+		}
+		return defaultValue
+	}
+	return []*schedulingv1.PriorityClass{
+		{
+			ObjectMeta:  metav1.ObjectMeta{Name: criticalPriorityClassName},
+			Value:       valueOrDefault(config.CriticalValue, defaultCriticalPriority),
+			Description: "Priority of the Hive control loops and admission webhooks.",
+		},
+		{
+			ObjectMeta:  metav1.ObjectMeta{Name: provisionPriorityClassName},
+			Value:       valueOrDefault(config.ProvisionValue, defaultProvisionPriority),
+			Description: "Priority of the install pods of the clusters Hive is provisioning.",
+		},
+	}
+}
+
+// deployPriorityClasses creates the PriorityClasses for the Hive workloads when they are configured in HiveConfig,
+// and deletes the ones created by the operator when they are not.
+func (r *ReconcileHiveConfig) deployPriorityClasses(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) error {
+	if instance.Spec.PriorityClasses == nil {
+		for _, name := range []string{criticalPriorityClassName, provisionPriorityClassName} {
+			existing, err := r.getPriorityClass(name)
+			if err != nil {
+				return err
+			}
+			if existing == nil || !isOwnedBy(existing, instance) {
+				continue
+			}
+			hLog.WithField("priorityClass", name).Info("deleting priority class no longer configured in hiveconfig")
+			if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, priorityClass := range generatePriorityClasses(instance.Spec.PriorityClasses) {
+		pcLog := hLog.WithField("priorityClass", priorityClass.Name)
+		existing, err := r.getPriorityClass(priorityClass.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Value != priorityClass.Value {
+			// The value of a PriorityClass cannot be changed, so it is recreated. Pods that are already running keep
+			// the priority they were created with.
+			pcLog.WithField("oldValue", existing.Value).WithField("value", priorityClass.Value).
+				Info("recreating priority class with new value")
+			if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		result, err := util.ApplyRuntimeObjectWithGC(h, priorityClass, instance)
+		if err != nil {
+			pcLog.WithError(err).Error("error applying priority class")
+			return err
+		}
+		pcLog.WithField("result", result).Info("priority class applied")
+	}
+	return nil
+}
+
+func (r *ReconcileHiveConfig) getPriorityClass(name string) (*schedulingv1.PriorityClass, error) {
+	priorityClass := &schedulingv1.PriorityClass{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Name: name}, priorityClass); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return priorityClass, nil
+}
+
+func isOwnedBy(obj metav1.Object, instance *hivev1.HiveConfig) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == instance.UID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package hive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestGeneratePriorityClasses(t *testing.T) {
+	cases := []struct {
+		name              string
+		config            *hivev1.PriorityClassesConfig
+		expectedCritical  int32
+		expectedProvision int32
+	}{
+		{
+			name:              "defaults",
+			config:            &hivev1.PriorityClassesConfig{},
+			expectedCritical:  defaultCriticalPriority,
+			expectedProvision: defaultProvisionPriority,
+		},
+		{
+			name: "configured values",
+			config: &hivev1.PriorityClassesConfig{
+				CriticalValue:  pointer.Int32Ptr(2000),
+				ProvisionValue: pointer.Int32Ptr(1000),
+			},
+			expectedCritical:  2000,
+			expectedProvision: 1000,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			priorityClasses := generatePriorityClasses(tc.config)
+			if assert.Len(t, priorityClasses, 2, "unexpected number of priority classes") {
+				assert.Equal(t, criticalPriorityClassName, priorityClasses[0].Name, "unexpected name")
+				assert.Equal(t, tc.expectedCritical, priorityClasses[0].Value, "unexpected critical value")
+				assert.Equal(t, provisionPriorityClassName, priorityClasses[1].Name, "unexpected name")
+				assert.Equal(t, tc.expectedProvision, priorityClasses[1].Value, "unexpected provision value")
+			}
+		})
+	}
+}
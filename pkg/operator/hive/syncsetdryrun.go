@@ -0,0 +1,93 @@
+package hive
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// syncSetDryRunRBACName is the name of the ClusterRole and ClusterRoleBinding that let hiveadmission dry-run the
+// resources of syncsets in the clusters.
+const syncSetDryRunRBACName = "system:openshift:hive:hiveadmission-syncset-dry-run"
+
+// generateSyncSetDryRunRBAC returns the ClusterRole and ClusterRoleBinding that allow hiveadmission to read the
+// ClusterDeployments and the admin kubeconfig secrets of the clusters that it dry-runs syncsets in.
+func generateSyncSetDryRunRBAC(hiveNSName string) (*rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding) {
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: syncSetDryRunRBACName},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{hivev1.SchemeGroupVersion.Group},
+				Resources: []string{"clusterdeployments"},
+				Verbs:     []string{"get", "list"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: syncSetDryRunRBACName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     syncSetDryRunRBACName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: hiveNSName,
+			Name:      "hiveadmission",
+		}},
+	}
+	return role, binding
+}
+
+// deploySyncSetDryRunRBAC grants hiveadmission the access it needs to dry-run syncsets when the dry-run is enabled in
+// HiveConfig, and removes the access when it is not.
+func (r *ReconcileHiveConfig) deploySyncSetDryRunRBAC(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, hiveNSName string) error {
+	role, binding := generateSyncSetDryRunRBAC(hiveNSName)
+	if instance.Spec.SyncSetDryRun != hivev1.SyncSetDryRunEnabled {
+		for _, existing := range []interface {
+			metav1.Object
+			runtime.Object
+		}{&rbacv1.ClusterRoleBinding{}, &rbacv1.ClusterRole{}} {
+			switch err := r.Get(context.TODO(), types.NamespacedName{Name: syncSetDryRunRBACName}, existing); {
+			case apierrors.IsNotFound(err):
+				continue
+			case err != nil:
+				hLog.WithError(err).Error("error getting syncset dry-run RBAC")
+				return err
+			}
+			if !isOwnedBy(existing, instance) {
+				continue
+			}
+			hLog.WithField("name", syncSetDryRunRBACName).Info("deleting syncset dry-run RBAC no longer enabled in hiveconfig")
+			if err := r.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, obj := range []runtime.Object{role, binding} {
+		result, err := util.ApplyRuntimeObjectWithGC(h, obj, instance)
+		if err != nil {
+			hLog.WithError(err).Error("error applying syncset dry-run RBAC")
+			return err
+		}
+		hLog.WithField("result", result).Info("syncset dry-run RBAC applied")
+	}
+	return nil
+}
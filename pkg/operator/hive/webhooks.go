@@ -0,0 +1,151 @@
+package hive
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	admregv1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/operator/assets"
+	"github.com/openshift/hive/pkg/operator/util"
+)
+
+const (
+	validatingWebhookConfigurationKind = "ValidatingWebhookConfiguration"
+	mutatingWebhookConfigurationKind   = "MutatingWebhookConfiguration"
+)
+
+// webhookAssets are the admission webhook configurations deployed by the operator. The webhook configurations that
+// are deployed are recorded in the status of HiveConfig, so that those whose assets are later removed or renamed are
+// deleted rather than left behind to reject writes to resources that hiveadmission no longer serves.
+var webhookAssets = []string{
+	"config/hiveadmission/clusterclaim-webhook.yaml",
+	"config/hiveadmission/clusterdeployment-webhook.yaml",
+	"config/hiveadmission/clusterimageset-webhook.yaml",
+	"config/hiveadmission/clusterpool-webhook.yaml",
+	"config/hiveadmission/clusterprovision-webhook.yaml",
+	"config/hiveadmission/dnszones-webhook.yaml",
+	"config/hiveadmission/machinepool-webhook.yaml",
+	"config/hiveadmission/syncset-webhook.yaml",
+	"config/hiveadmission/selectorsyncset-webhook.yaml",
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml",
+	"config/hiveadmission/machinepool-mutating-webhook.yaml",
+}
+
+// readWebhookAssets reads the webhook configurations of the assets, and returns them along with the record of each in
+// the status of HiveConfig.
+func readWebhookAssets(assetPaths []string) ([]*admregv1.ValidatingWebhookConfiguration, []*admregv1.MutatingWebhookConfiguration, []hivev1.DeployedWebhook, error) {
+	var validatingWebhooks []*admregv1.ValidatingWebhookConfiguration
+	var mutatingWebhooks []*admregv1.MutatingWebhookConfiguration
+	deployed := make([]hivev1.DeployedWebhook, len(assetPaths))
+	for i, assetPath := range assetPaths {
+		asset := assets.MustAsset(assetPath)
+		hasher := md5.New()
+		hasher.Write(asset)
+		deployed[i] = hivev1.DeployedWebhook{
+			Asset:    assetPath,
+			Checksum: hex.EncodeToString(hasher.Sum(nil)),
+		}
+		switch wh := util.ReadWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme).(type) {
+		case *admregv1.ValidatingWebhookConfiguration:
+			validatingWebhooks = append(validatingWebhooks, wh)
+			deployed[i].Kind = validatingWebhookConfigurationKind
+			deployed[i].Name = wh.Name
+		case *admregv1.MutatingWebhookConfiguration:
+			mutatingWebhooks = append(mutatingWebhooks, wh)
+			deployed[i].Kind = mutatingWebhookConfigurationKind
+			deployed[i].Name = wh.Name
+		default:
+			return nil, nil, nil, fmt.Errorf("asset %s is not a webhook configuration", assetPath)
+		}
+	}
+	return validatingWebhooks, mutatingWebhooks, deployed, nil
+}
+
+// deleteStaleWebhooks deletes the webhook configurations deployed by the operator that are not among the deployed
+// webhooks. These are the webhook configurations recorded in the status of HiveConfig, and those owned by HiveConfig
+// that were deployed before the status recorded them.
+func (r *ReconcileHiveConfig) deleteStaleWebhooks(instance *hivev1.HiveConfig, deployed []hivev1.DeployedWebhook, recorder events.Recorder, hLog log.FieldLogger) error {
+	current := map[string]sets.String{
+		validatingWebhookConfigurationKind: sets.NewString(),
+		mutatingWebhookConfigurationKind:   sets.NewString(),
+	}
+	for _, wh := range deployed {
+		current[wh.Kind].Insert(wh.Name)
+	}
+	stale := map[string]sets.String{
+		validatingWebhookConfigurationKind: sets.NewString(),
+		mutatingWebhookConfigurationKind:   sets.NewString(),
+	}
+	for _, wh := range instance.Status.DeployedWebhooks {
+		if names, ok := stale[wh.Kind]; ok && !current[wh.Kind].Has(wh.Name) {
+			names.Insert(wh.Name)
+		}
+	}
+
+	validatingWebhooks, err := r.kubeClient.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		hLog.WithError(err).Log(controllerutils.LogLevel(err), "error listing validating webhook configurations")
+		return err
+	}
+	for _, wh := range validatingWebhooks.Items {
+		if isOwnedByHiveConfig(wh.OwnerReferences, instance) && !current[validatingWebhookConfigurationKind].Has(wh.Name) {
+			stale[validatingWebhookConfigurationKind].Insert(wh.Name)
+		}
+	}
+	mutatingWebhooks, err := r.kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		hLog.WithError(err).Log(controllerutils.LogLevel(err), "error listing mutating webhook configurations")
+		return err
+	}
+	for _, wh := range mutatingWebhooks.Items {
+		if isOwnedByHiveConfig(wh.OwnerReferences, instance) && !current[mutatingWebhookConfigurationKind].Has(wh.Name) {
+			stale[mutatingWebhookConfigurationKind].Insert(wh.Name)
+		}
+	}
+
+	for _, kind := range []string{validatingWebhookConfigurationKind, mutatingWebhookConfigurationKind} {
+		for _, name := range stale[kind].List() {
+			whLog := hLog.WithField("kind", kind).WithField("webhook", name)
+			var err error
+			if kind == validatingWebhookConfigurationKind {
+				err = r.kubeClient.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().Delete(context.Background(), name, metav1.DeleteOptions{})
+			} else {
+				err = r.kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Delete(context.Background(), name, metav1.DeleteOptions{})
+			}
+			switch {
+			case apierrors.IsNotFound(err):
+				whLog.Debug("stale webhook configuration already deleted")
+			case err != nil:
+				whLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting stale webhook configuration")
+				recorder.Warningf("StaleWebhookDeleteFailed", "Unable to delete %s %s, which is no longer deployed: %v", kind, name, err)
+				return err
+			default:
+				whLog.Warn("deleted stale webhook configuration")
+				recorder.Warningf("StaleWebhookDeleted", "Deleted %s %s, which is no longer deployed", kind, name)
+			}
+		}
+	}
+	return nil
+}
+
+func isOwnedByHiveConfig(ownerRefs []metav1.OwnerReference, instance *hivev1.HiveConfig) bool {
+	for _, ref := range ownerRefs {
+		if ref.UID == instance.UID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package hive
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	admregv1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const testHiveConfigUID = types.UID("test-hiveconfig-uid")
+
+func TestReadWebhookAssets(t *testing.T) {
+	validatingWebhooks, mutatingWebhooks, deployed, err := readWebhookAssets(webhookAssets)
+	require.NoError(t, err, "unexpected error reading webhook assets")
+	assert.Len(t, validatingWebhooks, 9, "unexpected number of validating webhooks")
+	assert.Len(t, mutatingWebhooks, 2, "unexpected number of mutating webhooks")
+	if assert.Len(t, deployed, len(webhookAssets), "unexpected number of deployed webhooks") {
+		for i, wh := range deployed {
+			assert.Equal(t, webhookAssets[i], wh.Asset, "unexpected asset")
+			assert.NotEmpty(t, wh.Name, "expected name")
+			assert.NotEmpty(t, wh.Checksum, "expected checksum")
+		}
+		assert.Equal(t, validatingWebhookConfigurationKind, deployed[0].Kind, "unexpected kind")
+		assert.Equal(t, mutatingWebhookConfigurationKind, deployed[len(deployed)-1].Kind, "unexpected kind")
+	}
+}
+
+func TestDeleteStaleWebhooks(t *testing.T) {
+	deployed := []hivev1.DeployedWebhook{
+		{Kind: validatingWebhookConfigurationKind, Name: "current-validating"},
+		{Kind: mutatingWebhookConfigurationKind, Name: "current-mutating"},
+	}
+	cases := []struct {
+		name             string
+		existing         []runtime.Object
+		recorded         []hivev1.DeployedWebhook
+		expectedDeleted  []string
+		expectedRetained []string
+	}{
+		{
+			name: "no stale webhooks",
+			existing: []runtime.Object{
+				validatingWebhook("current-validating", true),
+				mutatingWebhook("current-mutating", true),
+			},
+			recorded:         deployed,
+			expectedRetained: []string{"current-validating", "current-mutating"},
+		},
+		{
+			name: "recorded webhooks removed",
+			existing: []runtime.Object{
+				validatingWebhook("current-validating", true),
+				validatingWebhook("renamed-validating", false),
+				mutatingWebhook("current-mutating", true),
+				mutatingWebhook("removed-mutating", false),
+			},
+			recorded: append([]hivev1.DeployedWebhook{
+				{Kind: validatingWebhookConfigurationKind, Name: "renamed-validating"},
+				{Kind: mutatingWebhookConfigurationKind, Name: "removed-mutating"},
+			}, deployed...),
+			expectedDeleted:  []string{"renamed-validating", "removed-mutating"},
+			expectedRetained: []string{"current-validating", "current-mutating"},
+		},
+		{
+			name: "owned webhooks not recorded",
+			existing: []runtime.Object{
+				validatingWebhook("current-validating", true),
+				validatingWebhook("old-validating", true),
+				mutatingWebhook("current-mutating", true),
+			},
+			expectedDeleted:  []string{"old-validating"},
+			expectedRetained: []string{"current-validating", "current-mutating"},
+		},
+		{
+			name: "webhooks of others retained",
+			existing: []runtime.Object{
+				validatingWebhook("other-validating", false),
+				mutatingWebhook("other-mutating", false),
+			},
+			expectedRetained: []string{"other-validating", "other-mutating"},
+		},
+		{
+			name: "recorded webhook already deleted",
+			recorded: []hivev1.DeployedWebhook{
+				{Kind: validatingWebhookConfigurationKind, Name: "renamed-validating"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(tc.existing...)
+			r := &ReconcileHiveConfig{kubeClient: kubeClient}
+			instance := &hivev1.HiveConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: hiveConfigName, UID: testHiveConfigUID},
+				Status:     hivev1.HiveConfigStatus{DeployedWebhooks: tc.recorded},
+			}
+			recorder := events.NewInMemoryRecorder("test")
+
+			err := r.deleteStaleWebhooks(instance, deployed, recorder, log.WithField("test", tc.name))
+			require.NoError(t, err, "unexpected error deleting stale webhooks")
+
+			remaining := map[string]bool{}
+			validating, err := kubeClient.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err, "unexpected error listing validating webhooks")
+			for _, wh := range validating.Items {
+				remaining[wh.Name] = true
+			}
+			mutating, err := kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err, "unexpected error listing mutating webhooks")
+			for _, wh := range mutating.Items {
+				remaining[wh.Name] = true
+			}
+			for _, name := range tc.expectedDeleted {
+				assert.False(t, remaining[name], "expected webhook %s to be deleted", name)
+			}
+			for _, name := range tc.expectedRetained {
+				assert.True(t, remaining[name], "expected webhook %s to be retained", name)
+			}
+			assert.Len(t, recorder.Events(), len(tc.expectedDeleted), "unexpected number of events")
+		})
+	}
+}
+
+func webhookOwnerReferences(owned bool) []metav1.OwnerReference {
+	if !owned {
+		return nil
+	}
+	return []metav1.OwnerReference{{APIVersion: "hive.openshift.io/v1", Kind: "HiveConfig", Name: hiveConfigName, UID: testHiveConfigUID}}
+}
+
+func validatingWebhook(name string, owned bool) *admregv1.ValidatingWebhookConfiguration {
+	return &admregv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name, OwnerReferences: webhookOwnerReferences(owned)},
+	}
+}
+
+func mutatingWebhook(name string, owned bool) *admregv1.MutatingWebhookConfiguration {
+	return &admregv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name, OwnerReferences: webhookOwnerReferences(owned)},
+	}
+}
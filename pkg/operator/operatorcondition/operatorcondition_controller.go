@@ -0,0 +1,277 @@
+package operatorcondition
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/operator/hive"
+)
+
+const (
+	// operatorConditionNameEnvVar is set by OLM on the hive-operator deployment to the name of the
+	// OperatorCondition for the operator. It is not set when Hive is not installed by OLM.
+	operatorConditionNameEnvVar = "OPERATOR_CONDITION_NAME"
+
+	upgradeableConditionType = "Upgradeable"
+)
+
+var operatorConditionGVR = schema.GroupVersionResource{
+	Group:    "operators.coreos.com",
+	Version:  "v1",
+	Resource: "operatorconditions",
+}
+
+// Add creates a new OperatorCondition controller and adds it to the Manager. The controller maintains the
+// Upgradeable condition of the OperatorCondition of the hive-operator, so it is only added when running under OLM.
+func Add(mgr manager.Manager) error {
+	conditionName := os.Getenv(operatorConditionNameEnvVar)
+	if conditionName == "" {
+		log.Debug("not running under OLM, skipping the operatorcondition controller")
+		return nil
+	}
+	dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	r := &ReconcileOperatorCondition{
+		Client:        mgr.GetClient(),
+		dynamicClient: dynamicClient,
+		namespace:     os.Getenv(hive.HiveOperatorNamespaceEnvVar),
+		conditionName: conditionName,
+	}
+	return add(mgr, r)
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileOperatorCondition) error {
+	c, err := controller.New("operatorcondition-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// All events map to the one OperatorCondition of the hive-operator.
+	enqueueOperatorCondition := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(handler.MapObject) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: r.namespace, Name: r.conditionName}}}
+		}),
+	}
+
+	// Watch for provisions starting and finishing:
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterProvision{}}, enqueueOperatorCondition, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return provisionInFlight(e.Object) },
+		DeleteFunc: func(e event.DeleteEvent) bool { return provisionInFlight(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return provisionInFlight(e.ObjectOld) != provisionInFlight(e.ObjectNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for relocations starting and finishing:
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, enqueueOperatorCondition, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return relocateInFlight(e.Object) },
+		DeleteFunc: func(e event.DeleteEvent) bool { return relocateInFlight(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return relocateInFlight(e.ObjectOld) != relocateInFlight(e.ObjectNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set the condition when the operator starts, since nothing may be in flight to trigger a reconcile.
+	start := make(chan event.GenericEvent, 1)
+	start <- event.GenericEvent{
+		Meta: &metav1.ObjectMeta{Namespace: r.namespace, Name: r.conditionName},
+	}
+	return c.Watch(&source.Channel{Source: start}, &handler.EnqueueRequestForObject{})
+}
+
+var _ reconcile.Reconciler = &ReconcileOperatorCondition{}
+
+// ReconcileOperatorCondition sets the Upgradeable condition of the OperatorCondition of the hive-operator. The
+// operator is not upgradeable while provisions or relocations are in progress, since upgrading it can orphan the pods
+// running them.
+type ReconcileOperatorCondition struct {
+	client.Client
+	dynamicClient dynamic.Interface
+
+	// namespace is the namespace of the hive-operator and its OperatorCondition.
+	namespace string
+	// conditionName is the name of the OperatorCondition of the hive-operator.
+	conditionName string
+}
+
+// Reconcile sets the Upgradeable condition of the OperatorCondition from the operations in progress.
+func (r *ReconcileOperatorCondition) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.WithField("operatorCondition", request.NamespacedName.String())
+	logger.Debug("reconciling operator condition")
+
+	inFlight, total, err := r.operationsInFlight()
+	if err != nil {
+		logger.WithError(err).Error("error determining in-flight operations")
+		return reconcile.Result{}, err
+	}
+
+	status, reason, message := metav1.ConditionTrue, "AsExpected", "No critical operations in progress"
+	if total > 0 {
+		status, reason = metav1.ConditionFalse, "OperationsInProgress"
+		message = fmt.Sprintf("%d critical operations in progress: %s", total, strings.Join(inFlight, ", "))
+		if total > len(inFlight) {
+			message += ", ..."
+		}
+	}
+
+	opCondClient := r.dynamicClient.Resource(operatorConditionGVR).Namespace(request.Namespace)
+	opCond, err := opCondClient.Get(context.TODO(), request.Name, metav1.GetOptions{})
+	if err != nil {
+		logger.WithError(err).Error("error getting OperatorCondition")
+		return reconcile.Result{}, err
+	}
+	changed, err := setOperatorCondition(opCond, upgradeableConditionType, status, reason, message)
+	if err != nil {
+		logger.WithError(err).Error("error setting Upgradeable condition")
+		return reconcile.Result{}, err
+	}
+	if !changed {
+		return reconcile.Result{}, nil
+	}
+	logger.WithField("upgradeable", status).WithField("message", message).Info("updating Upgradeable condition")
+	if _, err := opCondClient.Update(context.TODO(), opCond, metav1.UpdateOptions{}); err != nil {
+		logger.WithError(err).Error("error updating OperatorCondition")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// maxListed is the most operations in progress named in the message of the Upgradeable condition.
+const maxListed = 10
+
+// operationsInFlight returns a description of up to maxListed of the provisions and relocations that are in progress,
+// and how many are in progress in total. The lists are served from the cache of the watches of the controller.
+func (r *ReconcileOperatorCondition) operationsInFlight() ([]string, int, error) {
+	var inFlight []string
+	total := 0
+	add := func(description string) {
+		if total < maxListed {
+			inFlight = append(inFlight, description)
+		}
+		total++
+	}
+
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := r.List(context.TODO(), provisions); err != nil {
+		return nil, 0, err
+	}
+	for i := range provisions.Items {
+		if provisionInFlight(&provisions.Items[i]) {
+			add(fmt.Sprintf("provision %s/%s", provisions.Items[i].Namespace, provisions.Items[i].Name))
+		}
+	}
+
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cds); err != nil {
+		return nil, 0, err
+	}
+	for i := range cds.Items {
+		if relocateInFlight(&cds.Items[i]) {
+			add(fmt.Sprintf("relocation of %s/%s", cds.Items[i].Namespace, cds.Items[i].Name))
+		}
+	}
+
+	return inFlight, total, nil
+}
+
+// provisionInFlight returns true if the object is a ClusterProvision that is initializing or provisioning.
+func provisionInFlight(obj runtime.Object) bool {
+	provision, ok := obj.(*hivev1.ClusterProvision)
+	if !ok {
+		return false
+	}
+	switch provision.Spec.Stage {
+	case hivev1.ClusterProvisionStageInitializing, hivev1.ClusterProvisionStageProvisioning:
+		return true
+	}
+	return false
+}
+
+// relocateInFlight returns true if the object is a ClusterDeployment that is being relocated to or from this cluster.
+func relocateInFlight(obj runtime.Object) bool {
+	cd, ok := obj.(*hivev1.ClusterDeployment)
+	if !ok {
+		return false
+	}
+	_, status, err := controllerutils.IsRelocating(cd)
+	if err != nil {
+		return false
+	}
+	switch status {
+	case hivev1.RelocateOutgoing, hivev1.RelocateIncoming:
+		return true
+	}
+	return false
+}
+
+// setOperatorCondition sets the condition of the given type in the spec of the OperatorCondition. Returns true if
+// the OperatorCondition was changed.
+func setOperatorCondition(opCond *unstructured.Unstructured, conditionType string, status metav1.ConditionStatus, reason, message string) (bool, error) {
+	conditions, _, err := unstructured.NestedSlice(opCond.Object, "spec", "conditions")
+	if err != nil {
+		return false, err
+	}
+	newCondition := map[string]interface{}{
+		"type":               conditionType,
+		"status":             string(status),
+		"reason":             reason,
+		"message":            message,
+		"lastTransitionTime": metav1.Now().UTC().Format(time.RFC3339),
+	}
+	found := false
+	for i, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		found = true
+		if cond["status"] == string(status) && cond["reason"] == reason && cond["message"] == message {
+			return false, nil
+		}
+		if cond["status"] == string(status) {
+			newCondition["lastTransitionTime"] = cond["lastTransitionTime"]
+		}
+		conditions[i] = newCondition
+	}
+	if !found {
+		conditions = append(conditions, newCondition)
+	}
+	if err := unstructured.SetNestedSlice(opCond.Object, conditions, "spec", "conditions"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,182 @@
+package operatorcondition
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestOperationsInFlight(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	provision := func(name string, stage hivev1.ClusterProvisionStage) runtime.Object {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+			Spec:       hivev1.ClusterProvisionSpec{Stage: stage},
+		}
+	}
+	cd := func(name, relocate string) runtime.Object {
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+		}
+		if relocate != "" {
+			cd.Annotations = map[string]string{constants.RelocateAnnotation: relocate}
+		}
+		return cd
+	}
+
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		expected      []string
+		expectedTotal int
+	}{
+		{
+			name: "nothing in flight",
+			existing: []runtime.Object{
+				provision("complete", hivev1.ClusterProvisionStageComplete),
+				provision("failed", hivev1.ClusterProvisionStageFailed),
+				cd("not-relocating", ""),
+				cd("relocated", "test-relocate/complete"),
+			},
+		},
+		{
+			name: "provisions in flight",
+			existing: []runtime.Object{
+				provision("initializing", hivev1.ClusterProvisionStageInitializing),
+				provision("provisioning", hivev1.ClusterProvisionStageProvisioning),
+			},
+			expected: []string{
+				"provision test-namespace/initializing",
+				"provision test-namespace/provisioning",
+			},
+			expectedTotal: 2,
+		},
+		{
+			name: "relocations in flight",
+			existing: []runtime.Object{
+				cd("outgoing", "test-relocate/outgoing"),
+				cd("incoming", "test-relocate/incoming"),
+			},
+			expected: []string{
+				"relocation of test-namespace/incoming",
+				"relocation of test-namespace/outgoing",
+			},
+			expectedTotal: 2,
+		},
+		{
+			name: "more in flight than listed",
+			existing: func() []runtime.Object {
+				var objs []runtime.Object
+				for i := 0; i < maxListed+2; i++ {
+					objs = append(objs, provision(fmt.Sprintf("provisioning-%02d", i), hivev1.ClusterProvisionStageProvisioning))
+				}
+				return objs
+			}(),
+			expected: func() []string {
+				var expected []string
+				for i := 0; i < maxListed; i++ {
+					expected = append(expected, fmt.Sprintf("provision test-namespace/provisioning-%02d", i))
+				}
+				return expected
+			}(),
+			expectedTotal: maxListed + 2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ReconcileOperatorCondition{Client: fake.NewFakeClientWithScheme(scheme, tc.existing...)}
+			inFlight, total, err := r.operationsInFlight()
+			require.NoError(t, err, "unexpected error")
+			assert.ElementsMatch(t, tc.expected, inFlight, "unexpected operations in flight")
+			assert.Equal(t, tc.expectedTotal, total, "unexpected total operations in flight")
+		})
+	}
+}
+
+func TestSetOperatorCondition(t *testing.T) {
+	const oldTime = "2020-01-01T00:00:00Z"
+	opCond := func(conditions ...interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "operators.coreos.com/v1",
+			"kind":       "OperatorCondition",
+		}}
+		if conditions != nil {
+			obj.Object["spec"] = map[string]interface{}{"conditions": conditions}
+		}
+		return obj
+	}
+	upgradeable := func(status, reason, message string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":               upgradeableConditionType,
+			"status":             status,
+			"reason":             reason,
+			"message":            message,
+			"lastTransitionTime": oldTime,
+		}
+	}
+
+	cases := []struct {
+		name                 string
+		opCond               *unstructured.Unstructured
+		status               metav1.ConditionStatus
+		expectChanged        bool
+		expectTransitionKept bool
+	}{
+		{
+			name:          "no conditions",
+			opCond:        opCond(),
+			status:        metav1.ConditionFalse,
+			expectChanged: true,
+		},
+		{
+			name:          "status changed",
+			opCond:        opCond(upgradeable("True", "AsExpected", "ok")),
+			status:        metav1.ConditionFalse,
+			expectChanged: true,
+		},
+		{
+			name:                 "message changed",
+			opCond:               opCond(upgradeable("False", "test-reason", "old message")),
+			status:               metav1.ConditionFalse,
+			expectChanged:        true,
+			expectTransitionKept: true,
+		},
+		{
+			name:                 "unchanged",
+			opCond:               opCond(upgradeable("False", "test-reason", "test-message")),
+			status:               metav1.ConditionFalse,
+			expectTransitionKept: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := setOperatorCondition(tc.opCond, upgradeableConditionType, tc.status, "test-reason", "test-message")
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectChanged, changed, "unexpected changed")
+			conditions, _, err := unstructured.NestedSlice(tc.opCond.Object, "spec", "conditions")
+			require.NoError(t, err, "unexpected error getting conditions")
+			require.Len(t, conditions, 1, "expected a single condition")
+			cond := conditions[0].(map[string]interface{})
+			assert.Equal(t, string(tc.status), cond["status"], "unexpected status")
+			assert.Equal(t, "test-reason", cond["reason"], "unexpected reason")
+			assert.Equal(t, "test-message", cond["message"], "unexpected message")
+			if tc.expectTransitionKept {
+				assert.Equal(t, oldTime, cond["lastTransitionTime"], "expected last transition time to be kept")
+			} else {
+				assert.NotEqual(t, oldTime, cond["lastTransitionTime"], "expected last transition time to be updated")
+			}
+		})
+	}
+}
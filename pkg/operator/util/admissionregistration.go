@@ -29,3 +29,15 @@ func ReadMutatingWebhookConfigurationV1Beta1OrDie(objBytes []byte, scheme *runti
 	}
 	return requiredObj.(*admregv1.MutatingWebhookConfiguration)
 }
+
+// ReadWebhookConfigurationV1Beta1OrDie reads a ValidatingWebhookConfiguration or MutatingWebhookConfiguration,
+// as this is not yet added to library-go.
+func ReadWebhookConfigurationV1Beta1OrDie(objBytes []byte, scheme *runtime.Scheme) runtime.Object {
+	apiExtensionsCodecs := serializer.NewCodecFactory(scheme)
+
+	requiredObj, err := runtime.Decode(apiExtensionsCodecs.UniversalDecoder(admregv1.SchemeGroupVersion), objBytes)
+	if err != nil {
+		panic(err)
+	}
+	return requiredObj
+}
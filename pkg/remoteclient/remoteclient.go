@@ -4,6 +4,10 @@ package remoteclient
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -191,7 +195,7 @@ func InitialURL(c client.Client, cd *hivev1.ClusterDeployment) (string, error) {
 // the ClusterDeployment to determine if the remote cluster is reachable.
 func Unreachable(cd *hivev1.ClusterDeployment) (unreachable bool, lastCheck time.Time) {
 	cond := utils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
-	if cond == nil {
+	if !utils.IsClusterDeploymentConditionSet(cond) {
 		unreachable = true
 		return
 	}
@@ -319,16 +323,152 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 
 	utils.AddControllerMetricsTransportWrapper(cfg, b.controllerName, true)
 
+	usingOverride := false
 	if override := b.cd.Spec.ControlPlaneConfig.APIURLOverride; override != "" {
 		if b.urlToUse == primaryURL ||
 			(b.urlToUse == activeURL && IsPrimaryURLActive(b.cd)) {
 			cfg.Host = override
+			usingOverride = true
+		}
+	}
+
+	// The API of a cluster reached through GCP Private Service Connect or Azure Private Link does not resolve to an
+	// address reachable from Hive, so connections are made to the endpoint instead. The host name is still used to
+	// verify the serving certificate of the cluster.
+	if ip := privateEndpointIP(b.cd); ip != "" && !usingOverride {
+		cfg.Dial = dialThrough(ip)
+	} else if proxy := b.cd.Spec.Proxy; proxy != nil {
+		// Connections to a private endpoint are made directly, so the proxy of the cluster is only used otherwise.
+		if err := b.configureProxy(cfg, proxy); err != nil {
+			return nil, err
 		}
 	}
 
 	return cfg, nil
 }
 
+// configureProxy makes the config connect to the cluster through the proxy of the cluster. The CA bundle of the
+// proxy, if any, is trusted alongside the CA of the cluster.
+func (b *builder) configureProxy(cfg *rest.Config, proxy *hivev1.Proxy) error {
+	proxyFunc, err := proxyFuncFor(proxy)
+	if err != nil {
+		return err
+	}
+	cfg.Proxy = proxyFunc
+
+	if proxy.TrustedCA == nil {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := b.c.Get(context.Background(), client.ObjectKey{Namespace: b.cd.Namespace, Name: proxy.TrustedCA.Name}, cm); err != nil {
+		return errors.Wrap(err, "could not get trusted CA bundle of the proxy")
+	}
+	caData, ok := cm.Data[constants.TrustedCABundleConfigMapKey]
+	if !ok {
+		return errors.Errorf("trusted CA ConfigMap of the proxy does not contain %q data", constants.TrustedCABundleConfigMapKey)
+	}
+	// An empty CA leaves the system roots trusted, which would stop being the case if only the proxy CA were added.
+	if len(cfg.CAData) > 0 {
+		cfg.CAData = append(append(cfg.CAData, '\n'), caData...)
+	}
+	return nil
+}
+
+// proxyFuncFor returns a function that selects the proxy for a request from the proxy of a cluster. Requests to
+// hosts matching the no-proxy list are made directly.
+func proxyFuncFor(proxy *hivev1.Proxy) (func(*http.Request) (*url.URL, error), error) {
+	parse := func(proxyURL string) (*url.URL, error) {
+		if proxyURL == "" {
+			return nil, nil
+		}
+		u, err := url.Parse(proxyURL)
+		return u, errors.Wrapf(err, "could not parse proxy URL %q", proxyURL)
+	}
+	httpProxy, err := parse(proxy.HTTPProxy)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parse(proxy.HTTPSProxy)
+	if err != nil {
+		return nil, err
+	}
+	noProxy := strings.Split(proxy.NoProxy, ",")
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+// bypassProxy returns true if the host matches an entry of the no-proxy list. Entries are domains, which also match
+// their subdomains, IP addresses, CIDRs, or "*" to match every host. IPv6 addresses may be enclosed in brackets.
+func bypassProxy(host string, noProxy []string) bool {
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	for _, entry := range noProxy {
+		entry = strings.TrimSpace(entry)
+		if strings.HasPrefix(entry, "[") {
+			entry = strings.Trim(entry, "[]")
+		}
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case ip != nil:
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+		default:
+			entry = strings.TrimPrefix(entry, ".")
+			if host == entry || strings.HasSuffix(host, "."+entry) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// privateEndpointIP returns the IP address of the GCP Private Service Connect endpoint or of the Azure Private Link
+// private endpoint of the cluster, or an empty string if the cluster is reached through neither.
+func privateEndpointIP(cd *hivev1.ClusterDeployment) string {
+	status := cd.Status.Platform
+	if gcp := cd.Spec.Platform.GCP; gcp != nil && gcp.PrivateServiceConnect != nil && gcp.PrivateServiceConnect.Enabled {
+		if status == nil || status.GCP == nil || status.GCP.PrivateServiceConnect == nil {
+			return ""
+		}
+		return status.GCP.PrivateServiceConnect.EndpointIP
+	}
+	if azure := cd.Spec.Platform.Azure; azure != nil && azure.PrivateLink != nil && azure.PrivateLink.Enabled {
+		if status == nil || status.Azure == nil || status.Azure.PrivateLink == nil {
+			return ""
+		}
+		return status.Azure.PrivateLink.EndpointIP
+	}
+	return ""
+}
+
+// dialThrough returns a dial function that connects to the given IP address, keeping the port of the address
+// being dialed.
+func dialThrough(ip string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		_, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+	}
+}
+
 func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*rest.Config, error) {
 	kubeconfigSecret := &corev1.Secret{}
 	if err := c.Get(
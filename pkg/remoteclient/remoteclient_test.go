@@ -3,7 +3,10 @@ package remoteclient
 import (
 	"context"
 	"io/ioutil"
+	"net"
+	"net/http"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +19,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/pkg/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/pkg/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/constants"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
@@ -135,6 +140,237 @@ func Test_builder_RESTConfig(t *testing.T) {
 	}
 }
 
+func Test_builder_RESTConfig_PrivateEndpoint(t *testing.T) {
+	cases := []struct {
+		name         string
+		azure        bool
+		enabled      bool
+		endpointIP   string
+		overrideURL  string
+		expectedDial bool
+	}{
+		{
+			name:         "endpoint ready",
+			enabled:      true,
+			endpointIP:   "10.0.0.5",
+			expectedDial: true,
+		},
+		{
+			name:    "endpoint not ready",
+			enabled: true,
+		},
+		{
+			name:       "not enabled",
+			endpointIP: "10.0.0.5",
+		},
+		{
+			name:        "override active",
+			enabled:     true,
+			endpointIP:  "10.0.0.5",
+			overrideURL: "url-override",
+		},
+		{
+			name:         "azure endpoint ready",
+			azure:        true,
+			enabled:      true,
+			endpointIP:   "10.0.0.5",
+			expectedDial: true,
+		},
+		{
+			name:    "azure endpoint not ready",
+			azure:   true,
+			enabled: true,
+		},
+		{
+			name:       "azure not enabled",
+			azure:      true,
+			endpointIP: "10.0.0.5",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			if tc.azure {
+				cd.Spec.Platform.Azure = &hivev1azure.Platform{
+					PrivateLink: &hivev1azure.PrivateLinkAccess{Enabled: tc.enabled},
+				}
+				cd.Status.Platform = &hivev1.PlatformStatus{
+					Azure: &hivev1azure.PlatformStatus{
+						PrivateLink: &hivev1azure.PrivateLinkAccessStatus{EndpointIP: tc.endpointIP},
+					},
+				}
+			} else {
+				cd.Spec.Platform.GCP = &hivev1gcp.Platform{
+					PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: tc.enabled},
+				}
+				cd.Status.Platform = &hivev1.PlatformStatus{
+					GCP: &hivev1gcp.PlatformStatus{
+						PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccessStatus{EndpointIP: tc.endpointIP},
+					},
+				}
+			}
+			if tc.overrideURL != "" {
+				setAPIURLOverride(cd, tc.overrideURL)
+				setOverrideActive(cd)
+			}
+			c := fakeClient(cd, testKubeconfigSecret(t))
+			cfg, err := NewBuilder(c, cd, testControllerName).RESTConfig()
+			assert.NoError(t, err, "unexpected error getting REST config")
+			assert.Equal(t, tc.expectedDial, cfg.Dial != nil, "unexpected dial function")
+		})
+	}
+}
+
+func Test_builder_RESTConfig_Proxy(t *testing.T) {
+	const proxyCA = "-----BEGIN CERTIFICATE-----\nproxy\n-----END CERTIFICATE-----\n"
+	cases := []struct {
+		name                 string
+		proxy                *hivev1.Proxy
+		privateEndpoint      bool
+		existing             []runtime.Object
+		expectErr            bool
+		expectedProxy        string
+		expectedCAWithProxy  bool
+		expectedProxyFuncSet bool
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:                 "https proxy",
+			proxy:                &hivev1.Proxy{HTTPProxy: "http://http-proxy:3128", HTTPSProxy: "http://https-proxy:3128"},
+			expectedProxyFuncSet: true,
+			expectedProxy:        "http://https-proxy:3128",
+		},
+		{
+			name:                 "api in no proxy",
+			proxy:                &hivev1.Proxy{HTTPSProxy: "http://https-proxy:3128", NoProxy: "10.0.0.0/8,.example.com"},
+			expectedProxyFuncSet: true,
+		},
+		{
+			name:  "trusted ca",
+			proxy: &hivev1.Proxy{HTTPSProxy: "https://https-proxy:3128", TrustedCA: &corev1.LocalObjectReference{Name: "proxy-ca"}},
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "proxy-ca"},
+				Data:       map[string]string{constants.TrustedCABundleConfigMapKey: proxyCA},
+			}},
+			expectedProxyFuncSet: true,
+			expectedProxy:        "https://https-proxy:3128",
+			expectedCAWithProxy:  true,
+		},
+		{
+			name:      "missing trusted ca",
+			proxy:     &hivev1.Proxy{HTTPSProxy: "https://https-proxy:3128", TrustedCA: &corev1.LocalObjectReference{Name: "proxy-ca"}},
+			expectErr: true,
+		},
+		{
+			name:            "private endpoint",
+			proxy:           &hivev1.Proxy{HTTPSProxy: "http://https-proxy:3128"},
+			privateEndpoint: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.Proxy = tc.proxy
+			if tc.privateEndpoint {
+				cd.Spec.Platform.GCP = &hivev1gcp.Platform{
+					PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: true},
+				}
+				cd.Status.Platform = &hivev1.PlatformStatus{
+					GCP: &hivev1gcp.PlatformStatus{
+						PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccessStatus{EndpointIP: "10.0.0.5"},
+					},
+				}
+			}
+			c := fakeClient(append(tc.existing, cd, testKubeconfigSecret(t))...)
+			cfg, err := NewBuilder(c, cd, testControllerName).RESTConfig()
+			if tc.expectErr {
+				assert.Error(t, err, "expected error getting REST config")
+				return
+			}
+			if !assert.NoError(t, err, "unexpected error getting REST config") {
+				return
+			}
+			if !tc.expectedProxyFuncSet {
+				assert.Nil(t, cfg.Proxy, "unexpected proxy function")
+				return
+			}
+			if !assert.NotNil(t, cfg.Proxy, "expected proxy function") {
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, apiURL, nil)
+			proxyURL, err := cfg.Proxy(req)
+			assert.NoError(t, err, "unexpected error selecting proxy")
+			if tc.expectedProxy == "" {
+				assert.Nil(t, proxyURL, "expected no proxy for the API")
+			} else if assert.NotNil(t, proxyURL, "expected proxy for the API") {
+				assert.Equal(t, tc.expectedProxy, proxyURL.String(), "unexpected proxy for the API")
+			}
+			assert.Equal(t, tc.expectedCAWithProxy, strings.Contains(string(cfg.CAData), proxyCA), "unexpected proxy CA in CA data")
+		})
+	}
+}
+
+func Test_bypassProxy(t *testing.T) {
+	noProxy := []string{"example.com", " .internal.example.org", "10.0.0.0/16", "192.168.1.1", "fd00::/48", "[fd01::1]", ""}
+	cases := []struct {
+		host     string
+		expected bool
+	}{
+		{host: "example.com", expected: true},
+		{host: "api.example.com", expected: true},
+		{host: "notexample.com"},
+		{host: "api.internal.example.org", expected: true},
+		{host: "example.org"},
+		{host: "10.0.3.4", expected: true},
+		{host: "10.1.3.4"},
+		{host: "192.168.1.1", expected: true},
+		{host: "192.168.1.2"},
+		{host: "fd00::5", expected: true},
+		{host: "fd02::5"},
+		{host: "fd01::1", expected: true},
+		{host: "[fd01::1]", expected: true},
+		{host: "fd01::2"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, bypassProxy(tc.host, noProxy))
+		})
+	}
+	assert.True(t, bypassProxy("anything.example.net", []string{"*"}), "expected wildcard to match")
+}
+
+func Test_dialThrough(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err, "unexpected error listening") {
+		return
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	conn, err := dialThrough("127.0.0.1")(context.Background(), "tcp", net.JoinHostPort("api.hive-cluster.example.com", port))
+	if assert.NoError(t, err, "unexpected error dialing") {
+		assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String(), "unexpected remote address")
+		conn.Close()
+	}
+}
+
+func Test_dialThroughIPv6(t *testing.T) {
+	listener, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 loopback unavailable: %v", err)
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	conn, err := dialThrough("::1")(context.Background(), "tcp", net.JoinHostPort("api.hive-cluster.example.com", port))
+	if assert.NoError(t, err, "unexpected error dialing") {
+		assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String(), "unexpected remote address")
+		conn.Close()
+	}
+}
+
 func Test_Unreachable(t *testing.T) {
 	probeTime := time.Unix(123456789, 0)
 	cases := []struct {
@@ -148,6 +384,11 @@ func Test_Unreachable(t *testing.T) {
 			cd:                  testcd.Build(),
 			expectedUnreachable: true,
 		},
+		{
+			name:                "unreachable initialized",
+			cd:                  testcd.Build(withUnreachableCondition(corev1.ConditionUnknown, probeTime)),
+			expectedUnreachable: true,
+		},
 		{
 			name:                "unreachable true",
 			cd:                  testcd.Build(withUnreachableCondition(corev1.ConditionTrue, probeTime)),
@@ -0,0 +1,63 @@
+// Package replicalimits counts the replicas of the MachinePools of a tenant against the limits configured in the
+// machinePoolReplicaLimits section of HiveConfig.
+package replicalimits
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+// Tenant returns the name of the tenant of the namespace, along with the selector of the namespaces of the tenant,
+// or nil if the namespace is a tenant of its own.
+func Tenant(config *hivev1.MachinePoolReplicaLimitsConfig, namespace *corev1.Namespace) (string, labels.Selector) {
+	if config.TenantLabel == "" {
+		return namespace.Name, nil
+	}
+	tenant, ok := namespace.Labels[config.TenantLabel]
+	if !ok {
+		return namespace.Name, nil
+	}
+	return tenant, labels.SelectorFromSet(labels.Set{config.TenantLabel: tenant})
+}
+
+// MaxReplicas returns the limit of the tenant, or nil if the replicas of the tenant are not limited.
+func MaxReplicas(config *hivev1.MachinePoolReplicaLimitsConfig, tenant string) *int64 {
+	for i := range config.Tenants {
+		if config.Tenants[i].Name == tenant {
+			return &config.Tenants[i].MaxReplicas
+		}
+	}
+	return config.DefaultMaxReplicas
+}
+
+// PoolReplicas returns the replicas of the MachinePool counted against the limit of its tenant: its replicas, or its
+// maximum replicas when it autoscales.
+func PoolReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Spec.Autoscaling.MaxReplicas)
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	}
+	return 0
+}
+
+// TotalReplicas returns the replicas of the MachinePools counted against the limit of their tenant, leaving out the
+// excluded MachinePool and the MachinePools being deleted.
+func TotalReplicas(pools []hivev1.MachinePool, exclude types.NamespacedName) int64 {
+	var total int64
+	for i := range pools {
+		pool := &pools[i]
+		if pool.DeletionTimestamp != nil {
+			continue
+		}
+		if pool.Namespace == exclude.Namespace && pool.Name == exclude.Name {
+			continue
+		}
+		total += PoolReplicas(pool)
+	}
+	return total
+}
@@ -0,0 +1,91 @@
+package replicalimits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+func TestTenant(t *testing.T) {
+	cases := []struct {
+		name             string
+		tenantLabel      string
+		labels           map[string]string
+		expectedTenant   string
+		expectedSelector string
+	}{
+		{
+			name:           "no tenant label",
+			labels:         map[string]string{"team": "blue"},
+			expectedTenant: "test-namespace",
+		},
+		{
+			name:           "namespace without tenant label",
+			tenantLabel:    "team",
+			expectedTenant: "test-namespace",
+		},
+		{
+			name:             "namespace with tenant label",
+			tenantLabel:      "team",
+			labels:           map[string]string{"team": "blue"},
+			expectedTenant:   "blue",
+			expectedSelector: "team=blue",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &hivev1.MachinePoolReplicaLimitsConfig{TenantLabel: tc.tenantLabel}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: tc.labels}}
+			tenant, selector := Tenant(config, namespace)
+			assert.Equal(t, tc.expectedTenant, tenant, "unexpected tenant")
+			if tc.expectedSelector == "" {
+				assert.Nil(t, selector, "expected no selector")
+			} else if assert.NotNil(t, selector, "expected selector") {
+				assert.Equal(t, tc.expectedSelector, selector.String(), "unexpected selector")
+			}
+		})
+	}
+}
+
+func TestMaxReplicas(t *testing.T) {
+	config := &hivev1.MachinePoolReplicaLimitsConfig{
+		DefaultMaxReplicas: pointer.Int64Ptr(20),
+		Tenants:            []hivev1.TenantReplicaLimit{{Name: "blue", MaxReplicas: 100}},
+	}
+	assert.Equal(t, int64(100), *MaxReplicas(config, "blue"), "unexpected limit of listed tenant")
+	assert.Equal(t, int64(20), *MaxReplicas(config, "green"), "unexpected limit of unlisted tenant")
+	assert.Nil(t, MaxReplicas(&hivev1.MachinePoolReplicaLimitsConfig{}, "green"), "expected no limit")
+}
+
+func TestTotalReplicas(t *testing.T) {
+	now := metav1.Now()
+	pools := []hivev1.MachinePool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "replicas"},
+			Spec:       hivev1.MachinePoolSpec{Replicas: pointer.Int64Ptr(3)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "autoscaling"},
+			Spec:       hivev1.MachinePoolSpec{Autoscaling: &hivev1.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 10}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "no-replicas"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "deleted", DeletionTimestamp: &now},
+			Spec:       hivev1.MachinePoolSpec{Replicas: pointer.Int64Ptr(50)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "excluded"},
+			Spec:       hivev1.MachinePoolSpec{Replicas: pointer.Int64Ptr(7)},
+		},
+	}
+	assert.Equal(t, int64(13), TotalReplicas(pools, types.NamespacedName{Namespace: "ns2", Name: "excluded"}), "unexpected total replicas")
+}
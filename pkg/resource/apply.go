@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	kresource "k8s.io/cli-runtime/pkg/resource"
@@ -39,6 +40,9 @@ const (
 
 const fieldTooLong metav1.CauseType = "FieldValueTooLong"
 
+// serverSideApplyFieldManager is the field manager that owns the fields applied with server-side apply.
+const serverSideApplyFieldManager = "hive"
+
 // Apply applies the given resource bytes to the target cluster specified by kubeconfig
 func (r *helper) Apply(obj []byte) (ApplyResult, error) {
 	factory, err := r.getFactory("")
@@ -125,6 +129,22 @@ func (r *helper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme)
 	return r.Create(data)
 }
 
+// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with the hive
+// field manager. Fields owned by other managers are not overwritten; changing them fails with a conflict.
+func (r *helper) ServerSideApply(obj []byte) (ApplyResult, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for apply")
+		return "", err
+	}
+	result, err := r.serverSideApply(factory, obj)
+	if err != nil {
+		r.logger.WithError(err).Warn("running the server-side apply failed")
+		return "", err
+	}
+	return result, nil
+}
+
 func (r *helper) createOnly(f cmdutil.Factory, obj []byte) (ApplyResult, error) {
 	info, err := r.getResourceInternalInfo(f, obj)
 	if err != nil {
@@ -194,6 +214,49 @@ func (r *helper) createOrUpdate(f cmdutil.Factory, obj []byte, errOut io.Writer)
 	return result, nil
 }
 
+func (r *helper) serverSideApply(f cmdutil.Factory, obj []byte) (ApplyResult, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", err
+	}
+	c, err := f.DynamicClient()
+	if err != nil {
+		return "", err
+	}
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+	if err != nil {
+		return "", err
+	}
+	// The resource version of the existing object, if any, tells whether the apply created or changed the object.
+	var existingResourceVersion string
+	if err := info.Get(); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+	} else {
+		existingResourceVersion = info.ResourceVersion
+	}
+	gvr := info.ResourceMapping().Resource
+	applied, err := c.Resource(gvr).Namespace(info.Namespace).Patch(
+		context.TODO(),
+		info.Name,
+		types.ApplyPatchType,
+		data,
+		metav1.PatchOptions{FieldManager: serverSideApplyFieldManager},
+	)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case existingResourceVersion == "":
+		return CreatedApplyResult, nil
+	case applied.GetResourceVersion() == existingResourceVersion:
+		return UnchangedApplyResult, nil
+	default:
+		return ConfiguredApplyResult, nil
+	}
+}
+
 func (r *helper) setupApplyCommand(f cmdutil.Factory, obj []byte, ioStreams genericclioptions.IOStreams) (*kcmdapply.ApplyOptions, *changeTracker, error) {
 	r.logger.Debug("setting up apply command")
 	o := kcmdapply.NewApplyOptions(ioStreams)
@@ -1,21 +1,139 @@
 package resource
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/rest"
 )
 
-func getDiscoveryClient(config *rest.Config, cacheDir string) (discovery.CachedDiscoveryInterface, error) {
+const (
+	discoveryCacheTTL = 10 * time.Minute
+	// sharedDiscoveryCacheTTL is how long discovery data shared by the clusters of a version is used before it is
+	// fetched again. The data is fetched again sooner when a resource cannot be found in it, so a long TTL does not
+	// prevent resources added by CRDs from being found.
+	sharedDiscoveryCacheTTL = 24 * time.Hour
+	openAPISchemaFile       = "openapi-v2.pb"
+)
+
+// getDiscoveryClient returns a discovery client which caches on disk. The cache is kept per API server host, unless
+// a shared cache key is given. The cache is then shared by every cluster with the same key, such as the clusters of
+// a version, and also holds the OpenAPI schema.
+func getDiscoveryClient(config *rest.Config, cacheDir, sharedCacheKey string) (discovery.CachedDiscoveryInterface, error) {
 	config.Burst = 100
 	httpCacheDir := filepath.Join(cacheDir, ".kube", "http-cache")
-	discoveryCacheDir := computeDiscoverCacheDir(filepath.Join(cacheDir, ".kube", "cache", "discovery"), config.Host)
-	return disk.NewCachedDiscoveryClientForConfig(config, discoveryCacheDir, httpCacheDir, time.Duration(10*time.Minute))
+	if sharedCacheKey == "" {
+		discoveryCacheDir := computeDiscoverCacheDir(filepath.Join(cacheDir, ".kube", "cache", "discovery"), config.Host)
+		return disk.NewCachedDiscoveryClientForConfig(config, discoveryCacheDir, httpCacheDir, discoveryCacheTTL)
+	}
+	discoveryCacheDir := computeDiscoverCacheDir(filepath.Join(cacheDir, ".kube", "cache", "shared-discovery"), sharedCacheKey)
+	client, err := disk.NewCachedDiscoveryClientForConfig(config, discoveryCacheDir, httpCacheDir, sharedDiscoveryCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedDiscoveryClient{
+		CachedDiscoveryInterface: client,
+		openAPISchemaFile:        filepath.Join(discoveryCacheDir, openAPISchemaFile),
+		ttl:                      sharedDiscoveryCacheTTL,
+	}, nil
+}
+
+// sharedDiscoveryClient adds caching of the OpenAPI schema to a disk cached discovery client, which otherwise
+// fetches the schema from the server every time it is needed.
+type sharedDiscoveryClient struct {
+	discovery.CachedDiscoveryInterface
+	openAPISchemaFile string
+	ttl               time.Duration
+
+	mutex       sync.Mutex
+	invalidated bool
+}
+
+// OpenAPISchema returns the cached OpenAPI schema, fetching it from the server if it is not cached, has expired, or
+// the cache has been invalidated.
+func (d *sharedDiscoveryClient) OpenAPISchema() (*openapi_v2.Document, error) {
+	d.mutex.Lock()
+	invalidated := d.invalidated
+	d.mutex.Unlock()
+	if !invalidated {
+		if doc, err := d.getCachedSchema(); err == nil {
+			return doc, nil
+		}
+	}
+	doc, err := d.CachedDiscoveryInterface.OpenAPISchema()
+	if err != nil {
+		return nil, err
+	}
+	// A schema that cannot be cached is fetched again next time.
+	if err := d.writeCachedSchema(doc); err == nil {
+		d.mutex.Lock()
+		d.invalidated = false
+		d.mutex.Unlock()
+	}
+	return doc, nil
+}
+
+// Invalidate makes the next calls fetch the discovery data and OpenAPI schema from the server.
+func (d *sharedDiscoveryClient) Invalidate() {
+	d.mutex.Lock()
+	d.invalidated = true
+	d.mutex.Unlock()
+	d.CachedDiscoveryInterface.Invalidate()
+}
+
+func (d *sharedDiscoveryClient) getCachedSchema() (*openapi_v2.Document, error) {
+	info, err := os.Stat(d.openAPISchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(info.ModTime().Add(d.ttl)) {
+		return nil, os.ErrNotExist
+	}
+	data, err := ioutil.ReadFile(d.openAPISchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	doc := &openapi_v2.Document{}
+	if err := proto.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// writeCachedSchema writes the schema to a temporary file which is then renamed, so that clients sharing the cache
+// never read a partially written schema.
+func (d *sharedDiscoveryClient) writeCachedSchema(doc *openapi_v2.Document) error {
+	data, err := proto.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(d.openAPISchemaFile)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, openAPISchemaFile+".")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), d.openAPISchemaFile)
 }
 
 // overlyCautiousIllegalFileCharacters matches characters that *might* not be supported.  Windows is really restrictive, so this is really restrictive
@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Get returns the object with the given API version, kind, namespace and name from the target cluster. An object
+// that does not exist is returned as a NotFound error.
+func (r *helper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	f, err := r.getFactory(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get factory")
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapper")
+	}
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapping")
+	}
+	if namespace == "" && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		// Like apply, use the default namespace for namespaced objects without a namespace.
+		if namespace, _, err = f.ToRawKubeConfigLoader().Namespace(); err != nil {
+			return nil, errors.Wrap(err, "could not get default namespace")
+		}
+	}
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create dynamic client")
+	}
+	return dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
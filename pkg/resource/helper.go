@@ -6,6 +6,7 @@ import (
 	"os"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -30,10 +31,15 @@ type Helper interface {
 	CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
 	Create(obj []byte) (ApplyResult, error)
 	CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
+	// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with the hive
+	// field manager. Fields owned by other managers are not overwritten; changing them fails with a conflict.
+	ServerSideApply(obj []byte) (ApplyResult, error)
 	// Info determines the name/namespace and type of the passed in resource bytes
 	Info(obj []byte) (*Info, error)
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
+	// Get returns the object with the given API version, kind, namespace and name from the target cluster
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
 	Delete(apiVersion, kind, namespace, name string) error
 }
 
@@ -46,7 +52,10 @@ type helper struct {
 	remote         bool
 	kubeconfig     []byte
 	restConfig     *rest.Config
-	getFactory     func(namespace string) (cmdutil.Factory, error)
+	// discoveryCacheKey, when set, shares the cached discovery data of the target cluster with the other
+	// clusters using the same key.
+	discoveryCacheKey string
+	getFactory        func(namespace string) (cmdutil.Factory, error)
 }
 
 // NewHelperFromRESTConfig returns a new object that allows apply and patch operations
@@ -60,6 +69,20 @@ func NewHelperFromRESTConfig(restConfig *rest.Config, logger log.FieldLogger) He
 	return r
 }
 
+// NewHelperWithSharedDiscoveryFromRESTConfig returns a new object that allows apply and patch operations. The discovery
+// data and OpenAPI schema of the target cluster are cached under the given key and shared with every other cluster
+// using the same key, such as the other clusters of the same version.
+func NewHelperWithSharedDiscoveryFromRESTConfig(restConfig *rest.Config, discoveryCacheKey string, logger log.FieldLogger) Helper {
+	r := &helper{
+		logger:            logger,
+		cacheDir:          getCacheDir(logger),
+		restConfig:        restConfig,
+		discoveryCacheKey: discoveryCacheKey,
+	}
+	r.getFactory = r.getRESTConfigFactory
+	return r
+}
+
 // NewHelperWithMetricsFromRESTConfig returns a new object that allows apply and patch operations, with metrics tracking enabled.
 func NewHelperWithMetricsFromRESTConfig(restConfig *rest.Config, controllerName hivev1.ControllerName, logger log.FieldLogger) Helper {
 	r := &helper{
@@ -60,7 +60,7 @@ func (r *kubeconfigClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryI
 	if err != nil {
 		return nil, err
 	}
-	return getDiscoveryClient(config, r.cacheDir)
+	return getDiscoveryClient(config, r.cacheDir, "")
 }
 
 // ToRESTMapper returns a restmapper
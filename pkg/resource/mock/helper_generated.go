@@ -7,6 +7,7 @@ package mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/openshift/hive/pkg/resource"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 	reflect "reflect"
@@ -125,6 +126,21 @@ func (mr *MockHelperMockRecorder) CreateRuntimeObject(obj, scheme interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuntimeObject", reflect.TypeOf((*MockHelper)(nil).CreateRuntimeObject), obj, scheme)
 }
 
+// ServerSideApply mocks base method
+func (m *MockHelper) ServerSideApply(obj []byte) (resource.ApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerSideApply", obj)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerSideApply indicates an expected call of ServerSideApply
+func (mr *MockHelperMockRecorder) ServerSideApply(obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerSideApply", reflect.TypeOf((*MockHelper)(nil).ServerSideApply), obj)
+}
+
 // Info mocks base method
 func (m *MockHelper) Info(obj []byte) (*resource.Info, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +170,21 @@ func (mr *MockHelperMockRecorder) Patch(name, kind, apiVersion, patch, patchType
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockHelper)(nil).Patch), name, kind, apiVersion, patch, patchType)
 }
 
+// Get mocks base method
+func (m *MockHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", apiVersion, kind, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockHelperMockRecorder) Get(apiVersion, kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHelper)(nil).Get), apiVersion, kind, namespace, name)
+}
+
 // Delete mocks base method
 func (m *MockHelper) Delete(apiVersion, kind, namespace, name string) error {
 	m.ctrl.T.Helper()
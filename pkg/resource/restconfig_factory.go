@@ -18,14 +18,20 @@ func (r *helper) getRESTConfigFactory(namespace string) (cmdutil.Factory, error)
 		r.restConfig = cfg
 	}
 	r.logger.WithField("cache-dir", r.cacheDir).Debug("creating cmdutil.Factory from REST client config and cache directory")
-	f := cmdutil.NewFactory(&restConfigClientGetter{restConfig: r.restConfig, cacheDir: r.cacheDir, namespace: namespace})
+	f := cmdutil.NewFactory(&restConfigClientGetter{
+		restConfig:        r.restConfig,
+		cacheDir:          r.cacheDir,
+		discoveryCacheKey: r.discoveryCacheKey,
+		namespace:         namespace,
+	})
 	return f, nil
 }
 
 type restConfigClientGetter struct {
-	restConfig *rest.Config
-	cacheDir   string
-	namespace  string
+	restConfig        *rest.Config
+	cacheDir          string
+	discoveryCacheKey string
+	namespace         string
 }
 
 // ToRESTConfig returns restconfig
@@ -36,7 +42,7 @@ func (r *restConfigClientGetter) ToRESTConfig() (*rest.Config, error) {
 // ToDiscoveryClient returns discovery client
 func (r *restConfigClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
 	config := rest.CopyConfig(r.restConfig)
-	return getDiscoveryClient(config, r.cacheDir)
+	return getDiscoveryClient(config, r.cacheDir, r.discoveryCacheKey)
 }
 
 // ToRESTMapper returns a restmapper
@@ -0,0 +1,252 @@
+package syncsetagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	// DefaultPollInterval is how often the agent pulls the syncsets of its cluster when HiveConfig does not set the
+	// poll interval.
+	DefaultPollInterval = time.Minute
+
+	// requestTimeout bounds each request of the agent to Hive.
+	requestTimeout = 30 * time.Second
+)
+
+// Agent pulls the syncsets of its cluster from Hive, applies them to the cluster, and reports the results to Hive.
+type Agent struct {
+	HubURL           string
+	ClusterNamespace string
+	ClusterName      string
+	TokenFile        string
+	PollInterval     time.Duration
+	LogLevel         string
+
+	httpClient *http.Client
+	helper     resource.Helper
+	logger     log.FieldLogger
+}
+
+// NewSyncSetAgentCommand returns the command that runs the syncset agent on a cluster.
+func NewSyncSetAgentCommand() *cobra.Command {
+	a := &Agent{}
+	cmd := &cobra.Command{
+		Use:   "syncset-agent",
+		Short: "Pulls the syncsets of the cluster from Hive and applies them.",
+		Long:  "The syncset agent runs on a cluster that Hive cannot reach. It pulls the SyncSets and SelectorSyncSets of the cluster from Hive, applies them to the cluster, and reports the results to Hive.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := a.Complete(); err != nil {
+				log.WithError(err).Fatal("cannot complete command")
+			}
+			if err := a.Validate(); err != nil {
+				log.WithError(err).Fatal("invalid command options")
+			}
+			a.Run(wait.NeverStop)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&a.LogLevel, "log-level", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&a.HubURL, "hub-url", "", "URL at which the agent reaches Hive")
+	flags.StringVar(&a.ClusterNamespace, "cluster-namespace", "", "namespace of the ClusterDeployment of the cluster")
+	flags.StringVar(&a.ClusterName, "cluster-name", "", "name of the ClusterDeployment of the cluster")
+	flags.StringVar(&a.TokenFile, "token-file", "", "file with the token that the agent authenticates to Hive with")
+	flags.DurationVar(&a.PollInterval, "poll-interval", DefaultPollInterval, "how often the syncsets are pulled and applied")
+	return cmd
+}
+
+// Complete sets up the logger and the clients of the agent.
+func (a *Agent) Complete() error {
+	level, err := log.ParseLevel(a.LogLevel)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse log level")
+	}
+	log.SetLevel(level)
+	a.logger = log.WithField("clusterDeployment", a.ClusterNamespace+"/"+a.ClusterName)
+
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return errors.Wrap(err, "cannot get in-cluster config")
+	}
+	a.helper = resource.NewHelperFromRESTConfig(cfg, a.logger)
+	a.httpClient = &http.Client{Timeout: requestTimeout}
+	return nil
+}
+
+// Validate checks that the options of the agent are set, and that Hive is reached over https.
+func (a *Agent) Validate() error {
+	switch {
+	case a.HubURL == "":
+		return errors.New("--hub-url is required")
+	case a.ClusterNamespace == "" || a.ClusterName == "":
+		return errors.New("--cluster-namespace and --cluster-name are required")
+	case a.TokenFile == "":
+		return errors.New("--token-file is required")
+	case a.PollInterval <= 0:
+		return errors.New("--poll-interval must be positive")
+	}
+	return errors.Wrap(ValidateHubURL(a.HubURL), "invalid --hub-url")
+}
+
+// Run pulls and applies the syncsets every poll interval until stop is closed.
+func (a *Agent) Run(stop <-chan struct{}) {
+	a.logger.WithField("hubURL", a.HubURL).WithField("pollInterval", a.PollInterval).Info("starting syncset agent")
+	wait.Until(func() {
+		if err := a.sync(); err != nil {
+			a.logger.WithError(err).Error("could not sync the syncsets of the cluster")
+		}
+	}, a.PollInterval, stop)
+}
+
+func (a *Agent) sync() error {
+	syncSets := &SyncSets{}
+	if err := a.do(http.MethodGet, nil, syncSets); err != nil {
+		return errors.Wrap(err, "could not pull syncsets")
+	}
+	a.logger.WithField("syncSets", len(syncSets.Items)).Debug("pulled syncsets")
+	results := a.apply(syncSets)
+	if err := a.do(http.MethodPost, results, nil); err != nil {
+		return errors.Wrap(err, "could not report results")
+	}
+	return nil
+}
+
+// apply applies the syncsets in order. Once a syncset in an apply wave fails to apply, the syncsets in the higher
+// waves are not applied until the next poll.
+func (a *Agent) apply(syncSets *SyncSets) *Results {
+	results := &Results{Items: []Result{}}
+	var failedWave *int32
+	for _, syncSet := range syncSets.Items {
+		logger := a.logger.WithField(syncSet.Kind, syncSet.Name)
+		if failedWave != nil && syncSet.ApplyWave > *failedWave {
+			logger.WithField("wave", syncSet.ApplyWave).WithField("failedWave", *failedWave).Info("holding apply of syncset until the syncsets in a lower wave have been applied")
+			continue
+		}
+		result := Result{Kind: syncSet.Kind, Name: syncSet.Name, Generation: syncSet.Generation}
+		if err := a.applySyncSet(syncSet, logger); err != nil {
+			logger.WithError(err).Warn("syncset failed to apply")
+			result.FailureMessage = err.Error()
+			wave := syncSet.ApplyWave
+			failedWave = &wave
+		}
+		results.Items = append(results.Items, result)
+	}
+	return results
+}
+
+// applySyncSet applies the resources and the patches of the syncset, and deletes its resources to delete, stopping at
+// the first failure like the clustersync controller does.
+func (a *Agent) applySyncSet(syncSet SyncSet, logger log.FieldLogger) error {
+	if syncSet.Error != "" {
+		return errors.New(syncSet.Error)
+	}
+
+	applyFn := a.helper.Apply
+	switch syncSet.ApplyBehavior {
+	case hivev1.CreateOrUpdateSyncSetApplyBehavior:
+		applyFn = a.helper.CreateOrUpdate
+	case hivev1.CreateOnlySyncSetApplyBehavior:
+		applyFn = a.helper.Create
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		applyFn = a.helper.ServerSideApply
+	}
+
+	for i, raw := range syncSet.Resources {
+		u := &unstructured.Unstructured{}
+		if err := json.Unmarshal(raw.Raw, u); err != nil {
+			return errors.Wrapf(err, "failed to decode resource %d", i)
+		}
+		labels := u.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		// Label the resource as managed by hive, as the clustersync controller does.
+		labels[constants.HiveManagedLabel] = "true"
+		u.SetLabels(labels)
+		data, err := json.Marshal(u)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode resource %d", i)
+		}
+		result, err := applyFn(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply resource %d", i)
+		}
+		logger.WithField("resourceIndex", i).WithField("applyResult", result).Debug("resource applied")
+	}
+
+	for i, patch := range syncSet.Patches {
+		if err := a.helper.Patch(
+			types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
+			patch.Kind,
+			patch.APIVersion,
+			[]byte(patch.Patch),
+			patch.PatchType,
+		); err != nil {
+			return errors.Wrapf(err, "failed to apply patch %d", i)
+		}
+	}
+
+	var failures []string
+	for _, r := range syncSet.ResourcesToDelete {
+		if err := a.helper.Delete(r.APIVersion, r.Kind, r.Namespace, r.Name); err != nil {
+			failures = append(failures, fmt.Sprintf("Failed to delete %s, Kind=%s %s/%s: %v", r.APIVersion, r.Kind, r.Namespace, r.Name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	logger.Info("syncset applied")
+	return nil
+}
+
+// do sends a request with the JSON encoded body to Hive, and decodes the JSON response into out when out is not nil.
+// The token is read for every request, so that a rotated token is picked up.
+func (a *Agent) do(method string, body interface{}, out interface{}) error {
+	token, err := ioutil.ReadFile(a.TokenFile)
+	if err != nil {
+		return errors.Wrap(err, "cannot read token")
+	}
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(a.HubURL, "/")+SyncSetsPath(a.ClusterNamespace, a.ClusterName), &reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
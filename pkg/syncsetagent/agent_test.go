@@ -0,0 +1,222 @@
+package syncsetagent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+	"github.com/openshift/hive/pkg/resource"
+	mockresource "github.com/openshift/hive/pkg/resource/mock"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cd"
+	testToken     = "test-token"
+)
+
+func testSyncSet(kind, name string, wave int32, resources ...string) SyncSet {
+	syncSet := SyncSet{Kind: kind, Name: name, Generation: 1, ApplyWave: wave}
+	for _, r := range resources {
+		syncSet.Resources = append(syncSet.Resources, runtime.RawExtension{Raw: []byte(r)})
+	}
+	return syncSet
+}
+
+func testConfigMap(name string) string {
+	return fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":%q}}`, name)
+}
+
+func managedConfigMap(name string) []byte {
+	return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"labels":{"hive.openshift.io/managed":"true"},"name":%q,"namespace":"default"}}`, name))
+}
+
+func TestAgentSync(t *testing.T) {
+	cases := []struct {
+		name            string
+		syncSets        []SyncSet
+		setupHelper     func(*mockresource.MockHelper)
+		expectedResults []Result
+	}{
+		{
+			name: "resources, patches and resources to delete",
+			syncSets: []SyncSet{func() SyncSet {
+				s := testSyncSet("SyncSet", "ss1", 0, testConfigMap("cm1"))
+				s.Patches = []hivev1.SyncObjectPatch{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm2", Patch: `{"data":{"a":"b"}}`, PatchType: "merge"}}
+				s.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm3"}}
+				return s
+			}()},
+			setupHelper: func(h *mockresource.MockHelper) {
+				h.EXPECT().Apply(managedConfigMap("cm1")).Return(resource.CreatedApplyResult, nil)
+				h.EXPECT().Patch(types.NamespacedName{Namespace: "default", Name: "cm2"}, "ConfigMap", "v1", []byte(`{"data":{"a":"b"}}`), "merge").Return(nil)
+				h.EXPECT().Delete("v1", "ConfigMap", "default", "cm3").Return(nil)
+			},
+			expectedResults: []Result{{Kind: "SyncSet", Name: "ss1", Generation: 1}},
+		},
+		{
+			name: "server-side apply",
+			syncSets: []SyncSet{func() SyncSet {
+				s := testSyncSet("SelectorSyncSet", "sss1", 0, testConfigMap("cm1"))
+				s.ApplyBehavior = hivev1.ServerSideApplySyncSetApplyBehavior
+				return s
+			}()},
+			setupHelper: func(h *mockresource.MockHelper) {
+				h.EXPECT().ServerSideApply(managedConfigMap("cm1")).Return(resource.ConfiguredApplyResult, nil)
+			},
+			expectedResults: []Result{{Kind: "SelectorSyncSet", Name: "sss1", Generation: 1}},
+		},
+		{
+			name: "failed resource",
+			syncSets: []SyncSet{
+				testSyncSet("SyncSet", "ss1", 0, testConfigMap("cm1"), testConfigMap("cm2")),
+				testSyncSet("SyncSet", "ss2", 0, testConfigMap("cm3")),
+			},
+			setupHelper: func(h *mockresource.MockHelper) {
+				h.EXPECT().Apply(managedConfigMap("cm1")).Return(resource.ApplyResult(""), fmt.Errorf("test error"))
+				h.EXPECT().Apply(managedConfigMap("cm3")).Return(resource.CreatedApplyResult, nil)
+			},
+			expectedResults: []Result{
+				{Kind: "SyncSet", Name: "ss1", Generation: 1, FailureMessage: "failed to apply resource 0: test error"},
+				{Kind: "SyncSet", Name: "ss2", Generation: 1},
+			},
+		},
+		{
+			name: "higher waves held after failure",
+			syncSets: []SyncSet{
+				testSyncSet("SyncSet", "ss1", 0, testConfigMap("cm1")),
+				testSyncSet("SelectorSyncSet", "sss1", 0, testConfigMap("cm2")),
+				testSyncSet("SyncSet", "ss2", 1, testConfigMap("cm3")),
+			},
+			setupHelper: func(h *mockresource.MockHelper) {
+				h.EXPECT().Apply(managedConfigMap("cm1")).Return(resource.ApplyResult(""), fmt.Errorf("test error"))
+				h.EXPECT().Apply(managedConfigMap("cm2")).Return(resource.CreatedApplyResult, nil)
+			},
+			expectedResults: []Result{
+				{Kind: "SyncSet", Name: "ss1", Generation: 1, FailureMessage: "failed to apply resource 0: test error"},
+				{Kind: "SelectorSyncSet", Name: "sss1", Generation: 1},
+			},
+		},
+		{
+			name: "syncset not rendered",
+			syncSets: []SyncSet{func() SyncSet {
+				s := testSyncSet("SyncSet", "ss1", 0, testConfigMap("cm1"))
+				s.Error = "failed to read secret 0"
+				return s
+			}()},
+			expectedResults: []Result{{Kind: "SyncSet", Name: "ss1", Generation: 1, FailureMessage: "failed to read secret 0"}},
+		},
+		{
+			name:            "no syncsets",
+			expectedResults: []Result{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			helper := mockresource.NewMockHelper(mockCtrl)
+			if tc.setupHelper != nil {
+				tc.setupHelper(helper)
+			}
+
+			var results *Results
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != SyncSetsPath(testNamespace, testName) || r.Header.Get("Authorization") != "Bearer "+testToken {
+					http.Error(w, "unexpected request", http.StatusBadRequest)
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					json.NewEncoder(w).Encode(&SyncSets{Items: tc.syncSets})
+				case http.MethodPost:
+					results = &Results{}
+					json.NewDecoder(r.Body).Decode(results)
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "syncset-agent")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			tokenFile := filepath.Join(dir, "token")
+			require.NoError(t, ioutil.WriteFile(tokenFile, []byte(testToken+"\n"), 0600))
+
+			a := &Agent{
+				HubURL:           server.URL + "/",
+				ClusterNamespace: testNamespace,
+				ClusterName:      testName,
+				TokenFile:        tokenFile,
+				httpClient:       server.Client(),
+				helper:           helper,
+				logger:           log.WithField("test", t.Name()),
+			}
+			require.NoError(t, a.sync(), "unexpected error syncing")
+			if assert.NotNil(t, results, "results not reported") {
+				assert.Equal(t, tc.expectedResults, results.Items, "unexpected results")
+			}
+		})
+	}
+}
+
+func TestAgentSyncUnauthorized(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "syncset-agent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte(testToken), 0600))
+
+	a := &Agent{
+		HubURL:           server.URL,
+		ClusterNamespace: testNamespace,
+		ClusterName:      testName,
+		TokenFile:        tokenFile,
+		httpClient:       server.Client(),
+		logger:           log.WithField("test", t.Name()),
+	}
+	err = a.sync()
+	if assert.Error(t, err, "expected error syncing") {
+		assert.Contains(t, err.Error(), "401 Unauthorized: invalid token", "unexpected error")
+	}
+}
+
+func TestValidateHubURL(t *testing.T) {
+	cases := []struct {
+		hubURL      string
+		expectError bool
+	}{
+		{hubURL: "https://hive-syncset-server.apps.hub.example.com"},
+		{hubURL: "https://hive-syncset-server.apps.hub.example.com/"},
+		{hubURL: "http://hive-syncset-server.apps.hub.example.com", expectError: true},
+		{hubURL: "hive-syncset-server.apps.hub.example.com", expectError: true},
+		{hubURL: "https://", expectError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.hubURL, func(t *testing.T) {
+			err := ValidateHubURL(tc.hubURL)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
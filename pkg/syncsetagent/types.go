@@ -0,0 +1,109 @@
+// Package syncsetagent provides the syncset agent, which runs on a cluster and applies the SyncSets and
+// SelectorSyncSets of the cluster that it pulls from Hive, and the types that the agent and Hive exchange.
+package syncsetagent
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apihelpers "github.com/openshift/hive/pkg/apis/helpers"
+	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
+)
+
+const (
+	// ServerPort is the port on which the hive-clustersync pods serve the syncsets to the agents.
+	ServerPort = 8090
+
+	// ServiceName is the name of the Service in the Hive namespace through which the agents reach the
+	// hive-clustersync pods.
+	ServiceName = "hive-syncset-server"
+
+	// ServingCertSecretName is the name of the secret in the Hive namespace with the serving certificate that the
+	// service CA issues for the Service.
+	ServingCertSecretName = "hive-syncset-server-serving-cert"
+
+	// ServingCertDir is the directory in which the serving certificate is mounted in the hive-clustersync pods.
+	ServingCertDir = "/var/syncset-server-serving-cert"
+
+	// Namespace is the namespace on the cluster in which the agent runs.
+	Namespace = "openshift-hive-syncset-agent"
+
+	// TokenSecretKey is the key of the token of an agent in its token secret.
+	TokenSecretKey = "token"
+)
+
+// ValidateHubURL returns an error if the URL at which the agents reach Hive is not an https URL. The agents send their
+// tokens to Hive, so they must not reach it over plain HTTP.
+func ValidateHubURL(hubURL string) error {
+	u, err := url.Parse(hubURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("hub URL %q is not an https URL", hubURL)
+	}
+	return nil
+}
+
+// SyncSetsPath returns the path at which Hive serves the syncsets of a cluster. The agent of the cluster posts the
+// results of applying them to the same path.
+func SyncSetsPath(cdNamespace, cdName string) string {
+	return fmt.Sprintf("/v1/namespaces/%s/clusterdeployments/%s/syncsets", cdNamespace, cdName)
+}
+
+// TokenSecretName returns the name of the secret, in the namespace of the ClusterDeployment, with the token that the
+// agent of the cluster authenticates with.
+func TokenSecretName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "syncset-agent-token")
+}
+
+// SyncSets are the syncsets of a cluster in the order in which they are applied.
+type SyncSets struct {
+	Items []SyncSet `json:"items"`
+}
+
+// SyncSet is a SyncSet or SelectorSyncSet rendered for a cluster.
+type SyncSet struct {
+	// Kind is SyncSet or SelectorSyncSet.
+	Kind string `json:"kind"`
+
+	Name string `json:"name"`
+
+	Generation int64 `json:"generation"`
+
+	ApplyWave int32 `json:"applyWave,omitempty"`
+
+	ApplyBehavior hivev1.SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// Resources are the resources of the syncset, followed by the secrets of its secret mappings.
+	Resources []runtime.RawExtension `json:"resources,omitempty"`
+
+	Patches []hivev1.SyncObjectPatch `json:"patches,omitempty"`
+
+	ResourcesToDelete []hivev1.SyncObjectReference `json:"resourcesToDelete,omitempty"`
+
+	// Error is why the syncset could not be rendered for the cluster. The agent reports it as the failure of the
+	// syncset without applying the syncset.
+	Error string `json:"error,omitempty"`
+}
+
+// Results are the results of applying the syncsets of a cluster. The syncsets that were not applied, because a
+// syncset in a lower apply wave failed, have no result.
+type Results struct {
+	Items []Result `json:"items"`
+}
+
+// Result is the result of applying a syncset.
+type Result struct {
+	Kind string `json:"kind"`
+
+	Name string `json:"name"`
+
+	// Generation is the generation of the syncset that was applied.
+	Generation int64 `json:"generation"`
+
+	// FailureMessage is why the syncset failed to apply. It is empty when the syncset was applied.
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
@@ -111,3 +111,15 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+func WithStatusLifetime(lifetime time.Duration) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Status.Lifetime = &metav1.Duration{Duration: lifetime}
+	}
+}
+
+func WithQueuePosition(position int32) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Status.QueuePosition = position
+	}
+}
@@ -3,6 +3,7 @@ package clusterdeployment
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -117,12 +118,58 @@ func WithClusterPoolReference(namespace, poolName, claimName string) Option {
 	}
 }
 
+// Quarantined quarantines the cluster in its ClusterPool with the given reason. Must be used after setting the
+// ClusterPoolReference.
+func Quarantined(reason hivev1.ClusterPoolQuarantineReason) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.Quarantine = &hivev1.ClusterPoolQuarantine{
+			Reason: reason,
+			Time:   metav1.Now(),
+		}
+	}
+}
+
+// WithCustomization sets the ClusterDeploymentCustomization applied to the cluster from the inventory of its
+// ClusterPool. Must be used after setting the ClusterPoolReference.
+func WithCustomization(name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: name}
+	}
+}
+
+// WithFlavor sets the flavor of the ClusterPool with which the cluster was created. Must be used after setting the
+// ClusterPoolReference.
+func WithFlavor(name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.Flavor = name
+	}
+}
+
+func WithRegion(name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ClusterPoolRef.Region = name
+	}
+}
+
+func WithProvisionRef(name string) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Status.ProvisionRef = &corev1.LocalObjectReference{Name: name}
+	}
+}
+
 func Installed() Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.Installed = true
 	}
 }
 
+// Detached sets the cluster deployment as detached from Hive.
+func Detached(preserveSecrets bool) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Detach = &hivev1.ClusterDetach{PreserveSecrets: preserveSecrets}
+	}
+}
+
 func InstalledTimestamp(instTime time.Time) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.Installed = true
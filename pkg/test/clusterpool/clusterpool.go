@@ -1,7 +1,10 @@
 package clusterpool
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -77,7 +80,7 @@ func ForAWS(credsSecretName, region string) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.Platform.AWS = &hivev1aws.Platform{
 			CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecretName},
-			Region:               "region",
+			Region:               region,
 		}
 	}
 }
@@ -94,6 +97,12 @@ func WithSize(size int) Option {
 	}
 }
 
+func WithRunningCount(size int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.RunningCount = int32(size)
+	}
+}
+
 func WithClusterDeploymentLabels(labels map[string]string) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.Labels = labels
@@ -112,6 +121,142 @@ func WithImageSet(clusterImageSetName string) Option {
 	}
 }
 
+func WithDefaultClaimLifetime(lifetime time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.ClaimLifetime == nil {
+			clusterPool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{}
+		}
+		clusterPool.Spec.ClaimLifetime.Default = &metav1.Duration{Duration: lifetime}
+	}
+}
+
+func WithMaximumClaimLifetime(lifetime time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.ClaimLifetime == nil {
+			clusterPool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{}
+		}
+		clusterPool.Spec.ClaimLifetime.Maximum = &metav1.Duration{Duration: lifetime}
+	}
+}
+
+func WithCapacityPool(name string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.CapacityPoolRef = &hivev1.CapacityPoolReference{Name: name}
+	}
+}
+
+func WithPriority(priority int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Priority = priority
+	}
+}
+
+func WithHibernationSchedule(hibernate, resume string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.HibernationConfig = &hivev1.ClusterPoolHibernationConfig{
+			Schedule: &hivev1.HibernationSchedule{Hibernate: hibernate, Resume: resume},
+		}
+	}
+}
+
+func WithSizeSchedules(schedules ...hivev1.ClusterPoolSizeSchedule) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Schedules = schedules
+	}
+}
+
+func WithMaxClusterAge(age time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.MaxClusterAge = &metav1.Duration{Duration: age}
+	}
+}
+
+func WithReplaceBrokenClusters() Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ReplaceBrokenClusters = true
+	}
+}
+
+func WithPaused() Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Paused = true
+	}
+}
+
+func WithDraining() Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Draining = true
+	}
+}
+
+func WithClaimReadiness(syncSets, selectorSyncSets []string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimReadiness = &hivev1.ClusterPoolClaimReadiness{SyncSets: syncSets, SelectorSyncSets: selectorSyncSets}
+	}
+}
+
+func WithUpdateStrategy(maxUnavailable, maxSurge int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.UpdateStrategy = &hivev1.ClusterPoolUpdateStrategy{MaxUnavailable: maxUnavailable, MaxSurge: maxSurge}
+	}
+}
+
+func WithAutoscaling(minSize, maxSize int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: minSize, MaxSize: maxSize}
+	}
+}
+
+func WithReplenishWindow(window time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling.ReplenishWindow = &metav1.Duration{Duration: window}
+	}
+}
+
+func WithClaimHistory(entries ...hivev1.ClaimHistoryEntry) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.ClaimHistory = entries
+	}
+}
+
+func WithFlavors(flavors ...hivev1.ClusterPoolFlavor) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Flavors = flavors
+	}
+}
+
+func WithRegions(regions ...hivev1.ClusterPoolRegion) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Regions = regions
+	}
+}
+
+func WithUnavailableRegions(regions ...hivev1.ClusterPoolUnavailableRegion) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.UnavailableRegions = regions
+	}
+}
+
+// WithInventory sets the inventory of the ClusterPool to ClusterDeploymentCustomizations of the given names.
+func WithInventory(names ...string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Inventory = nil
+		for _, name := range names {
+			clusterPool.Spec.Inventory = append(clusterPool.Spec.Inventory, hivev1.InventoryEntry{
+				Kind: hivev1.ClusterDeploymentCustomizationInventoryEntry,
+				Name: name,
+			})
+		}
+	}
+}
+
+// WithInventoryStatus sets the status of an entry of the inventory of the ClusterPool.
+func WithInventoryStatus(status hivev1.InventoryEntryStatus) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.Inventory = append(clusterPool.Status.Inventory, status)
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
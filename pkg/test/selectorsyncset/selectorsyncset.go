@@ -93,6 +93,12 @@ func WithLabelSelector(labelKey, labelValue string) Option {
 	}
 }
 
+func WithClusterDeploymentFieldSelector(selector *hivev1.ClusterDeploymentFieldSelector) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ClusterDeploymentFieldSelector = selector
+	}
+}
+
 func WithApplyMode(applyMode hivev1.SyncSetResourceApplyMode) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.ResourceApplyMode = applyMode
@@ -114,6 +120,12 @@ func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	}
 }
 
+func WithResourceRefs(refs ...hivev1.SyncSetResourceReference) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ResourceRefs = refs
+	}
+}
+
 func WithSecrets(secrets ...hivev1.SecretMapping) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.Secrets = secrets
@@ -125,3 +137,15 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		selectorSyncSet.Spec.Patches = patches
 	}
 }
+
+func WithResourcesToDelete(resourcesToDelete ...hivev1.SyncObjectReference) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ResourcesToDelete = resourcesToDelete
+	}
+}
+
+func WithApplyWave(wave int32) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ApplyWave = wave
+	}
+}
@@ -1,7 +1,10 @@
 package syncset
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	hivev1 "github.com/openshift/hive/pkg/apis/hive/v1"
@@ -101,6 +104,12 @@ func WithApplyMode(applyMode hivev1.SyncSetResourceApplyMode) Option {
 	}
 }
 
+func WithResourceDeletionPolicy(policy hivev1.SyncSetResourceDeletionPolicy) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourceDeletionPolicy = policy
+	}
+}
+
 func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.ApplyBehavior = applyBehavior
@@ -116,6 +125,12 @@ func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	}
 }
 
+func WithResourceRefs(refs ...hivev1.SyncSetResourceReference) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourceRefs = refs
+	}
+}
+
 func WithSecrets(secrets ...hivev1.SecretMapping) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Secrets = secrets
@@ -127,3 +142,33 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		syncSet.Spec.Patches = patches
 	}
 }
+
+func WithTemplated() Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.Templated = true
+	}
+}
+
+func WithReapplyInterval(reapplyInterval time.Duration) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ReapplyInterval = &metav1.Duration{Duration: reapplyInterval}
+	}
+}
+
+func WithReapplyOnDrift() Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ReapplyOnDrift = true
+	}
+}
+
+func WithApplyWave(wave int32) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ApplyWave = wave
+	}
+}
+
+func WithResourcesToDelete(resourcesToDelete ...hivev1.SyncObjectReference) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourcesToDelete = resourcesToDelete
+	}
+}
@@ -0,0 +1,152 @@
+package vsphereclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+const (
+	loginTimeout = 60 * time.Second
+
+	virtualMachineType = "VirtualMachine"
+)
+
+// Client is a wrapper object for actual vSphere libraries to allow for easier mocking/testing.
+type Client interface {
+	// Virtual Machines
+	ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error)
+	PowerOnVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error
+	PowerOffVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error
+
+	// Logout ends the sessions of the client in the vCenter.
+	Logout(ctx context.Context) error
+}
+
+type vsphereClient struct {
+	client         *vim25.Client
+	restClient     *rest.Client
+	sessionManager *session.Manager
+}
+
+// ListVirtualMachines returns the virtual machines the given tag is attached to, with their name and power state.
+func (c *vsphereClient) ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error) {
+	attached, err := tags.NewManager(c.restClient).GetAttachedObjectsOnTags(ctx, []string{tag})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list objects attached to tag")
+	}
+	var refs []types.ManagedObjectReference
+	for _, objects := range attached {
+		for _, obj := range objects.ObjectIDs {
+			if ref := obj.Reference(); ref.Type == virtualMachineType {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var virtualMachines []mo.VirtualMachine
+	if err := property.DefaultCollector(c.client).Retrieve(ctx, refs, []string{"name", "runtime.powerState"}, &virtualMachines); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve virtual machines")
+	}
+	return virtualMachines, nil
+}
+
+// PowerOnVirtualMachine starts powering on the virtual machine. It does not wait for the virtual machine to be
+// powered on.
+func (c *vsphereClient) PowerOnVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error {
+	_, err := object.NewVirtualMachine(c.client, ref).PowerOn(ctx)
+	return err
+}
+
+// PowerOffVirtualMachine starts powering off the virtual machine. It does not wait for the virtual machine to be
+// powered off.
+func (c *vsphereClient) PowerOffVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error {
+	_, err := object.NewVirtualMachine(c.client, ref).PowerOff(ctx)
+	return err
+}
+
+// Logout ends the sessions of the client in the vCenter.
+func (c *vsphereClient) Logout(ctx context.Context) error {
+	defer c.client.CloseIdleConnections()
+	if err := c.restClient.Logout(ctx); err != nil {
+		return err
+	}
+	return c.sessionManager.Logout(ctx)
+}
+
+// NewClientFromSecrets creates our client wrapper object for interacting with vSphere. The vSphere creds are read from
+// the credentials secret, and the CA certificates of the vCenter from the certificates secret.
+func NewClientFromSecrets(vCenter string, credentialsSecret, certificatesSecret *corev1.Secret) (Client, error) {
+	username, ok := credentialsSecret.Data[constants.UsernameSecretKey]
+	if !ok {
+		return nil, errors.Errorf("credentials secret does not contain %q", constants.UsernameSecretKey)
+	}
+	password, ok := credentialsSecret.Data[constants.PasswordSecretKey]
+	if !ok {
+		return nil, errors.Errorf("credentials secret does not contain %q", constants.PasswordSecretKey)
+	}
+	var caCerts [][]byte
+	for _, cert := range certificatesSecret.Data {
+		caCerts = append(caCerts, cert)
+	}
+	return NewClient(vCenter, string(username), string(password), caCerts)
+}
+
+// NewClient creates our client wrapper object for interacting with vSphere using the vSphere creds provided. The
+// vCenter must present a certificate signed by one of the PEM encoded CA certificates.
+func NewClient(vCenter, username, password string, caCerts [][]byte) (Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+
+	u, err := soap.ParseURL(vCenter)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse vCenter URL")
+	}
+	pool := x509.NewCertPool()
+	for _, cert := range caCerts {
+		if !pool.AppendCertsFromPEM(cert) {
+			return nil, errors.New("invalid vSphere CA certificate")
+		}
+	}
+	soapClient := soap.NewClient(u, false)
+	soapClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = pool
+
+	client, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create vSphere client")
+	}
+	user := url.UserPassword(username, password)
+	sessionManager := session.NewManager(client)
+	if err := sessionManager.Login(ctx, user); err != nil {
+		return nil, errors.Wrap(err, "could not log in to vCenter")
+	}
+	restClient := rest.NewClient(client)
+	if err := restClient.Login(ctx, user); err != nil {
+		return nil, errors.Wrap(err, "could not log in to vCenter API")
+	}
+	return &vsphereClient{
+		client:         client,
+		restClient:     restClient,
+		sessionManager: sessionManager,
+	}, nil
+}
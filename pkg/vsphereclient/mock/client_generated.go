@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	mo "github.com/vmware/govmomi/vim25/mo"
+	types "github.com/vmware/govmomi/vim25/types"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// ListVirtualMachines mocks base method
+func (m *MockClient) ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualMachines", ctx, tag)
+	ret0, _ := ret[0].([]mo.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualMachines indicates an expected call of ListVirtualMachines
+func (mr *MockClientMockRecorder) ListVirtualMachines(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualMachines", reflect.TypeOf((*MockClient)(nil).ListVirtualMachines), ctx, tag)
+}
+
+// PowerOnVirtualMachine mocks base method
+func (m *MockClient) PowerOnVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOnVirtualMachine", ctx, ref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOnVirtualMachine indicates an expected call of PowerOnVirtualMachine
+func (mr *MockClientMockRecorder) PowerOnVirtualMachine(ctx, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOnVirtualMachine", reflect.TypeOf((*MockClient)(nil).PowerOnVirtualMachine), ctx, ref)
+}
+
+// PowerOffVirtualMachine mocks base method
+func (m *MockClient) PowerOffVirtualMachine(ctx context.Context, ref types.ManagedObjectReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOffVirtualMachine", ctx, ref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOffVirtualMachine indicates an expected call of PowerOffVirtualMachine
+func (mr *MockClientMockRecorder) PowerOffVirtualMachine(ctx, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOffVirtualMachine", reflect.TypeOf((*MockClient)(nil).PowerOffVirtualMachine), ctx, ref)
+}
+
+// Logout mocks base method
+func (m *MockClient) Logout(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout
+func (mr *MockClientMockRecorder) Logout(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockClient)(nil).Logout), ctx)
+}